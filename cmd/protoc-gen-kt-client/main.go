@@ -0,0 +1,14 @@
+package main
+
+import (
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/internal/ktclientgen"
+	"github.com/SebastienMelki/sebuf/internal/pluginrun"
+)
+
+func main() {
+	pluginrun.Main(func(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+		return ktclientgen.Run(req, ktclientgen.Options{})
+	})
+}
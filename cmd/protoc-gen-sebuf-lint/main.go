@@ -0,0 +1,22 @@
+// Command protoc-gen-sebuf-lint runs sebuf-lint as a protoc plugin:
+//
+//	protoc --sebuf-lint_out=. --sebuf-lint_opt=format=json api.proto
+//
+// It generates no files. Findings are printed to stderr, and the run fails
+// when one has error severity.
+package main
+
+import (
+	"os"
+
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/internal/lint"
+	"github.com/SebastienMelki/sebuf/internal/pluginrun"
+)
+
+func main() {
+	pluginrun.Main(func(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+		return lint.RunPlugin(req, os.Stderr)
+	})
+}
@@ -1,18 +1,14 @@
 package main
 
 import (
-	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/types/pluginpb"
 
+	"github.com/SebastienMelki/sebuf/internal/pluginrun"
 	"github.com/SebastienMelki/sebuf/internal/tsclientgen"
 )
 
 func main() {
-	options := protogen.Options{}
-
-	options.Run(func(plugin *protogen.Plugin) error {
-		plugin.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
-		gen := tsclientgen.New(plugin)
-		return gen.Generate()
+	pluginrun.Main(func(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+		return tsclientgen.Run(req, tsclientgen.Options{})
 	})
 }
@@ -0,0 +1,97 @@
+// Command sebuf-lint checks the sebuf annotations of a descriptor set or buf
+// image without generating code:
+//
+//	protoc --include_imports --include_source_info -o api.binpb api.proto
+//	sebuf-lint api.binpb
+//
+//	buf build -o api.binpb && sebuf-lint -format json api.binpb
+//
+// Files named after the descriptor set restrict the run to them. Rule
+// severities are read from .sebuf-lint.yaml when present. The exit code is 1
+// when a finding has error severity and 2 on invalid input.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/SebastienMelki/sebuf/internal/lint"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("sebuf-lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", lint.FormatText, "output format: text or json")
+	configPath := flags.String("config", "", "config file (default "+lint.ConfigFileName+" when present)")
+	listRules := flags.Bool("rules", false, "list the rules and their default severities, and exit")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: sebuf-lint [flags] <descriptor set or buf image | -> [file.proto...]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *listRules {
+		w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		for _, rule := range lint.Rules() {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", rule.ID, rule.Severity, rule.Doc)
+		}
+		_ = w.Flush()
+		return 0
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	findings, err := lintFiles(flags.Arg(0), flags.Args()[1:], *configPath, stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "sebuf-lint: %v\n", err)
+		return 2
+	}
+	if err = lint.Write(stdout, *format, findings); err != nil {
+		fmt.Fprintf(stderr, "sebuf-lint: %v\n", err)
+		return 2
+	}
+	if lint.HasErrors(findings) {
+		return 1
+	}
+	return 0
+}
+
+// lintFiles lints paths of the descriptor set at input, read from stdin for "-".
+func lintFiles(input string, paths []string, configPath string, stdin io.Reader) ([]lint.Finding, error) {
+	var config *lint.Config
+	var err error
+	if configPath != "" {
+		config, err = lint.LoadConfig(configPath)
+	} else {
+		config, err = lint.LoadDefaultConfig()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	if input == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(input)
+	}
+	if err != nil {
+		return nil, err
+	}
+	files, err := lint.Load(data, paths)
+	if err != nil {
+		return nil, err
+	}
+	return lint.Lint(files, config), nil
+}
@@ -25,17 +25,12 @@ import (
 
 	"github.com/SebastienMelki/sebuf/examples/error-handler/api/proto/models"
 	"github.com/SebastienMelki/sebuf/examples/error-handler/api/proto/services"
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
 
-// ErrNotFound is a custom error for not found resources.
-type ErrNotFound struct {
-	ResourceType string
-	ResourceID   string
-}
-
-func (e *ErrNotFound) Error() string {
-	return fmt.Sprintf("%s not found: %s", e.ResourceType, e.ResourceID)
-}
+// ErrUserNotFound is returned for unknown user IDs. Its well-known code makes
+// the framework answer with HTTP 404 and {"message":"user not found","code":"NOT_FOUND"}.
+var ErrUserNotFound = sebufhttp.NewError(sebufhttp.ErrorCodeNotFound, "user not found")
 
 // UserServiceImpl implements the UserService.
 type UserServiceImpl struct {
@@ -96,7 +91,7 @@ func (s *UserServiceImpl) UpdateUser(ctx context.Context, req *models.UpdateUser
 
 	user, exists := s.users[req.Id]
 	if !exists {
-		return nil, &ErrNotFound{ResourceType: "user", ResourceID: req.Id}
+		return nil, sebufhttp.WithDetail(ErrUserNotFound, "id", req.Id)
 	}
 
 	user.Name = req.Name
@@ -110,7 +105,7 @@ func (s *UserServiceImpl) DeleteUser(ctx context.Context, req *models.DeleteUser
 	defer s.mu.Unlock()
 
 	if _, exists := s.users[req.Id]; !exists {
-		return nil, &ErrNotFound{ResourceType: "user", ResourceID: req.Id}
+		return nil, sebufhttp.WithDetail(ErrUserNotFound, "id", req.Id)
 	}
 
 	delete(s.users, req.Id)
@@ -135,11 +130,10 @@ func HeaderErrorHandler(w http.ResponseWriter, r *http.Request, err error) proto
 	w.Header().Set("X-Error-ID", uuid.NewString())
 	w.Header().Set("X-Error-Timestamp", time.Now().UTC().Format(time.RFC3339))
 
-	// Check error type and add specific headers
-	var notFound *ErrNotFound
-	if errors.As(err, &notFound) {
+	// Check error code and add specific headers
+	if errors.Is(err, ErrUserNotFound) {
 		w.Header().Set("X-Error-Type", "not_found")
-		w.Header().Set("X-Resource-Type", notFound.ResourceType)
+		w.Header().Set("X-Resource-Type", "user")
 	}
 
 	return nil // Use default response with custom headers
@@ -148,10 +142,9 @@ func HeaderErrorHandler(w http.ResponseWriter, r *http.Request, err error) proto
 // Example 3: StatusCodeErrorHandler - Sets custom HTTP status codes for specific errors.
 // Useful when you want different status codes than the defaults.
 func StatusCodeErrorHandler(w http.ResponseWriter, r *http.Request, err error) proto.Message {
-	// Check for not found errors - use 404
-	var notFound *ErrNotFound
-	if errors.As(err, &notFound) {
-		w.WriteHeader(http.StatusNotFound)
+	// Not found errors already get 404 from their code; answer 410 instead
+	if errors.Is(err, ErrUserNotFound) {
+		w.WriteHeader(http.StatusGone)
 		return nil // Framework will marshal default error with our status code
 	}
 
@@ -169,12 +162,12 @@ func CustomBodyErrorHandler(w http.ResponseWriter, r *http.Request, err error) p
 	}
 
 	// Check for not found errors - return NotFoundError proto message
-	var notFound *ErrNotFound
-	if errors.As(err, &notFound) {
+	var notFound *sebufhttp.Error
+	if errors.As(err, &notFound) && notFound.GetCode() == sebufhttp.ErrorCodeNotFound {
 		w.WriteHeader(http.StatusNotFound)
 		return &models.NotFoundError{
-			ResourceType: notFound.ResourceType,
-			ResourceId:   notFound.ResourceID,
+			ResourceType: "user",
+			ResourceId:   notFound.GetDetails()["id"],
 			Message:      err.Error(),
 		}
 	}
@@ -205,8 +198,7 @@ func FullControlErrorHandler(w http.ResponseWriter, r *http.Request, err error)
 	statusCode := http.StatusInternalServerError
 	errorType := "internal_error"
 
-	var notFound *ErrNotFound
-	if errors.As(err, &notFound) {
+	if errors.Is(err, ErrUserNotFound) {
 		statusCode = http.StatusNotFound
 		errorType = "not_found"
 	}
@@ -250,16 +242,11 @@ func CombinedErrorHandler(w http.ResponseWriter, r *http.Request, err error) pro
 		return nil // Return nil to let framework use the proto error directly
 	}
 
-	// Handle Go struct not found errors (legacy pattern)
-	var notFound *ErrNotFound
-	if errors.As(err, &notFound) {
+	// Handle coded not found errors: the framework already answers them with
+	// 404 and their code and details, so only the header is added
+	if errors.Is(err, ErrUserNotFound) {
 		w.Header().Set("X-Error-Type", "not_found")
-		w.WriteHeader(http.StatusNotFound)
-		return &models.NotFoundError{
-			ResourceType: notFound.ResourceType,
-			ResourceId:   notFound.ResourceID,
-			Message:      err.Error(),
-		}
+		return nil
 	}
 
 	// For other service errors, return CustomError proto message from errors.proto
@@ -11,6 +11,8 @@ import (
 	"net/http"
 	"time"
 
+	"google.golang.org/protobuf/proto"
+
 	"github.com/SebastienMelki/sebuf/examples/restful-crud/api/proto/models"
 	"github.com/SebastienMelki/sebuf/examples/restful-crud/api/proto/services"
 )
@@ -86,7 +88,7 @@ func main() {
 	fmt.Println("\nPatching product price...")
 	patched, err := client.PatchProduct(ctx, &models.PatchProductRequest{
 		ProductId: product.Id,
-		Price:     29.99,
+		Price:     proto.Float64(29.99),
 	})
 	if err != nil {
 		log.Fatalf("Failed to patch product: %v", err)
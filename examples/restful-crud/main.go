@@ -8,6 +8,8 @@ import (
 	"os"
 	"time"
 
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
 	"github.com/SebastienMelki/sebuf/examples/restful-crud/api/proto/models"
 	"github.com/SebastienMelki/sebuf/examples/restful-crud/api/proto/services"
 )
@@ -180,28 +182,35 @@ func (s *ProductService) UpdateProduct(ctx context.Context, req *models.UpdatePr
 	return product, nil
 }
 
-// PatchProduct performs a partial update of an existing product.
+// PatchProduct applies a JSON Merge Patch to an existing product: fields the
+// body leaves out are kept, and null clears the description and category.
 func (s *ProductService) PatchProduct(ctx context.Context, req *models.PatchProductRequest) (*models.Product, error) {
 	product, exists := s.products[req.ProductId]
 	if !exists {
 		return nil, fmt.Errorf("product not found: %s", req.ProductId)
 	}
 
-	// Partial update - only update non-zero fields
-	if req.Name != "" {
-		product.Name = req.Name
+	patch := services.MergePatchPatchProductRequestFromContext(ctx)
+	for _, field := range []string{"name", "price", "stock_quantity"} {
+		if patch.IsNull(field) {
+			return nil, fmt.Errorf("%s cannot be cleared", field)
+		}
+	}
+	if name, ok := patch.GetName(); ok {
+		product.Name = name
 	}
-	if req.Description != "" {
-		product.Description = req.Description
+	if patch.Has("description") {
+		// Zero when the patch clears it with null
+		product.Description, _ = patch.GetDescription()
 	}
-	if req.Price != 0 {
-		product.Price = req.Price
+	if price, ok := patch.GetPrice(); ok {
+		product.Price = price
 	}
-	if req.StockQuantity != 0 {
-		product.StockQuantity = req.StockQuantity
+	if stock, ok := patch.GetStockQuantity(); ok {
+		product.StockQuantity = stock
 	}
-	if req.CategoryId != "" {
-		product.CategoryId = req.CategoryId
+	if patch.Has("category_id") {
+		product.CategoryId, _ = patch.GetCategoryId()
 	}
 	product.UpdatedAt = time.Now().Unix()
 
@@ -256,6 +265,23 @@ func equalFoldSubstr(s1, s2 string) bool {
 	return true
 }
 
+// logRequests logs each request with its content type and the headers the
+// service declares, read through the generated ProductServiceHeaders names
+// rather than string literals.
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType := r.Header.Get("Content-Type")
+		if contentType == "" {
+			contentType = sebufhttp.JSONContentType
+		}
+		hasKey := r.Header.Get(services.ProductServiceHeaders.XAPIKey) != ""
+		confirmed := r.Header.Get(services.ProductServiceHeaders.XConfirmDelete) == "true"
+		log.Printf("%s %s (%s, api key: %t, delete confirmed: %t)",
+			r.Method, r.URL.Path, contentType, hasKey, confirmed)
+		next.ServeHTTP(w, r)
+	})
+}
+
 func main() {
 	// Use our custom service implementation
 	service := NewProductService()
@@ -309,5 +335,5 @@ func main() {
 	if port == "" {
 		port = "8080"
 	}
-	log.Fatal(http.ListenAndServe(":"+port, mux))
+	log.Fatal(http.ListenAndServe(":"+port, logRequests(mux)))
 }
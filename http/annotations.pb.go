@@ -82,6 +82,225 @@ func (HttpMethod) EnumDescriptor() ([]byte, []int) {
 	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{0}
 }
 
+// PatchFormat selects the semantics of PATCH request bodies
+type PatchFormat int32
+
+const (
+	// The body is an ordinary JSON request message
+	PatchFormat_PATCH_FORMAT_UNSPECIFIED PatchFormat = 0
+	// The body is a JSON Merge Patch (RFC 7386): absent keys leave their field
+	// untouched and null clears it. Null is only allowed on fields with
+	// presence: optional, message and oneof fields.
+	PatchFormat_PATCH_FORMAT_MERGE_PATCH PatchFormat = 1
+)
+
+// Enum value maps for PatchFormat.
+var (
+	PatchFormat_name = map[int32]string{
+		0: "PATCH_FORMAT_UNSPECIFIED",
+		1: "PATCH_FORMAT_MERGE_PATCH",
+	}
+	PatchFormat_value = map[string]int32{
+		"PATCH_FORMAT_UNSPECIFIED": 0,
+		"PATCH_FORMAT_MERGE_PATCH": 1,
+	}
+)
+
+func (x PatchFormat) Enum() *PatchFormat {
+	p := new(PatchFormat)
+	*p = x
+	return p
+}
+
+func (x PatchFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PatchFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_sebuf_http_annotations_proto_enumTypes[1].Descriptor()
+}
+
+func (PatchFormat) Type() protoreflect.EnumType {
+	return &file_sebuf_http_annotations_proto_enumTypes[1]
+}
+
+func (x PatchFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PatchFormat.Descriptor instead.
+func (PatchFormat) EnumDescriptor() ([]byte, []int) {
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{1}
+}
+
+// ErrorFormat selects how a generated server writes error responses.
+type ErrorFormat int32
+
+const (
+	// The sebuf.http.Error and sebuf.http.ValidationError messages, encoded
+	// like responses
+	ErrorFormat_ERROR_FORMAT_UNSPECIFIED ErrorFormat = 0
+	// Problem Details (RFC 9457) as application/problem+json, with the
+	// violations of a ValidationError in an errors array of JSON Pointers
+	ErrorFormat_ERROR_FORMAT_PROBLEM_JSON ErrorFormat = 1
+)
+
+// Enum value maps for ErrorFormat.
+var (
+	ErrorFormat_name = map[int32]string{
+		0: "ERROR_FORMAT_UNSPECIFIED",
+		1: "ERROR_FORMAT_PROBLEM_JSON",
+	}
+	ErrorFormat_value = map[string]int32{
+		"ERROR_FORMAT_UNSPECIFIED":  0,
+		"ERROR_FORMAT_PROBLEM_JSON": 1,
+	}
+)
+
+func (x ErrorFormat) Enum() *ErrorFormat {
+	p := new(ErrorFormat)
+	*p = x
+	return p
+}
+
+func (x ErrorFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_sebuf_http_annotations_proto_enumTypes[2].Descriptor()
+}
+
+func (ErrorFormat) Type() protoreflect.EnumType {
+	return &file_sebuf_http_annotations_proto_enumTypes[2]
+}
+
+func (x ErrorFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorFormat.Descriptor instead.
+func (ErrorFormat) EnumDescriptor() ([]byte, []int) {
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{2}
+}
+
+// QueryEncoding controls how a query parameter carries its field
+type QueryEncoding int32
+
+const (
+	// Scalars and enums as text; repeated ones as one parameter per value
+	QueryEncoding_QUERY_ENCODING_UNSPECIFIED QueryEncoding = 0
+	// The field's JSON, as in a request body, in unpadded base64url (RFC 4648
+	// section 5), as a single parameter. Only valid on message and repeated
+	// message fields, for structured filters on GET methods that must stay
+	// cacheable.
+	QueryEncoding_QUERY_ENCODING_JSON_BASE64URL QueryEncoding = 1
+)
+
+// Enum value maps for QueryEncoding.
+var (
+	QueryEncoding_name = map[int32]string{
+		0: "QUERY_ENCODING_UNSPECIFIED",
+		1: "QUERY_ENCODING_JSON_BASE64URL",
+	}
+	QueryEncoding_value = map[string]int32{
+		"QUERY_ENCODING_UNSPECIFIED":    0,
+		"QUERY_ENCODING_JSON_BASE64URL": 1,
+	}
+)
+
+func (x QueryEncoding) Enum() *QueryEncoding {
+	p := new(QueryEncoding)
+	*p = x
+	return p
+}
+
+func (x QueryEncoding) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (QueryEncoding) Descriptor() protoreflect.EnumDescriptor {
+	return file_sebuf_http_annotations_proto_enumTypes[3].Descriptor()
+}
+
+func (QueryEncoding) Type() protoreflect.EnumType {
+	return &file_sebuf_http_annotations_proto_enumTypes[3]
+}
+
+func (x QueryEncoding) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use QueryEncoding.Descriptor instead.
+func (QueryEncoding) EnumDescriptor() ([]byte, []int) {
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{3}
+}
+
+// FieldSource declares where a request field is read from.
+// Unannotated fields keep the implicit behavior: fields named in the path are
+// bound from it, query-annotated fields from the query string, and the rest
+// from the body (which may also carry the path and query fields).
+type FieldSource int32
+
+const (
+	// Implicit source (see above)
+	FieldSource_FIELD_SOURCE_UNSPECIFIED FieldSource = 0
+	// Request body only
+	FieldSource_FIELD_SOURCE_BODY FieldSource = 1
+	// Query string only; named by the query annotation, or the field name
+	FieldSource_FIELD_SOURCE_QUERY FieldSource = 2
+	// Path variable only; the field must be named in the method path
+	FieldSource_FIELD_SOURCE_PATH FieldSource = 3
+	// Request header only; named by the field with underscores as hyphens
+	// (x_tenant_id reads X-Tenant-Id). Only valid on string fields.
+	FieldSource_FIELD_SOURCE_HEADER FieldSource = 4
+)
+
+// Enum value maps for FieldSource.
+var (
+	FieldSource_name = map[int32]string{
+		0: "FIELD_SOURCE_UNSPECIFIED",
+		1: "FIELD_SOURCE_BODY",
+		2: "FIELD_SOURCE_QUERY",
+		3: "FIELD_SOURCE_PATH",
+		4: "FIELD_SOURCE_HEADER",
+	}
+	FieldSource_value = map[string]int32{
+		"FIELD_SOURCE_UNSPECIFIED": 0,
+		"FIELD_SOURCE_BODY":        1,
+		"FIELD_SOURCE_QUERY":       2,
+		"FIELD_SOURCE_PATH":        3,
+		"FIELD_SOURCE_HEADER":      4,
+	}
+)
+
+func (x FieldSource) Enum() *FieldSource {
+	p := new(FieldSource)
+	*p = x
+	return p
+}
+
+func (x FieldSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FieldSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_sebuf_http_annotations_proto_enumTypes[4].Descriptor()
+}
+
+func (FieldSource) Type() protoreflect.EnumType {
+	return &file_sebuf_http_annotations_proto_enumTypes[4]
+}
+
+func (x FieldSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FieldSource.Descriptor instead.
+func (FieldSource) EnumDescriptor() ([]byte, []int) {
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{4}
+}
+
 // Int64Encoding controls how int64/uint64 fields serialize to JSON
 type Int64Encoding int32
 
@@ -119,11 +338,11 @@ func (x Int64Encoding) String() string {
 }
 
 func (Int64Encoding) Descriptor() protoreflect.EnumDescriptor {
-	return file_sebuf_http_annotations_proto_enumTypes[1].Descriptor()
+	return file_sebuf_http_annotations_proto_enumTypes[5].Descriptor()
 }
 
 func (Int64Encoding) Type() protoreflect.EnumType {
-	return &file_sebuf_http_annotations_proto_enumTypes[1]
+	return &file_sebuf_http_annotations_proto_enumTypes[5]
 }
 
 func (x Int64Encoding) Number() protoreflect.EnumNumber {
@@ -132,7 +351,7 @@ func (x Int64Encoding) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Int64Encoding.Descriptor instead.
 func (Int64Encoding) EnumDescriptor() ([]byte, []int) {
-	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{1}
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{5}
 }
 
 // EnumEncoding controls how enum fields serialize to JSON
@@ -172,11 +391,11 @@ func (x EnumEncoding) String() string {
 }
 
 func (EnumEncoding) Descriptor() protoreflect.EnumDescriptor {
-	return file_sebuf_http_annotations_proto_enumTypes[2].Descriptor()
+	return file_sebuf_http_annotations_proto_enumTypes[6].Descriptor()
 }
 
 func (EnumEncoding) Type() protoreflect.EnumType {
-	return &file_sebuf_http_annotations_proto_enumTypes[2]
+	return &file_sebuf_http_annotations_proto_enumTypes[6]
 }
 
 func (x EnumEncoding) Number() protoreflect.EnumNumber {
@@ -185,7 +404,7 @@ func (x EnumEncoding) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use EnumEncoding.Descriptor instead.
 func (EnumEncoding) EnumDescriptor() ([]byte, []int) {
-	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{2}
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{6}
 }
 
 // EmptyBehavior controls how empty message fields serialize to JSON.
@@ -230,11 +449,11 @@ func (x EmptyBehavior) String() string {
 }
 
 func (EmptyBehavior) Descriptor() protoreflect.EnumDescriptor {
-	return file_sebuf_http_annotations_proto_enumTypes[3].Descriptor()
+	return file_sebuf_http_annotations_proto_enumTypes[7].Descriptor()
 }
 
 func (EmptyBehavior) Type() protoreflect.EnumType {
-	return &file_sebuf_http_annotations_proto_enumTypes[3]
+	return &file_sebuf_http_annotations_proto_enumTypes[7]
 }
 
 func (x EmptyBehavior) Number() protoreflect.EnumNumber {
@@ -243,7 +462,7 @@ func (x EmptyBehavior) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use EmptyBehavior.Descriptor instead.
 func (EmptyBehavior) EnumDescriptor() ([]byte, []int) {
-	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{3}
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{7}
 }
 
 // TimestampFormat controls how google.protobuf.Timestamp fields serialize to JSON.
@@ -291,11 +510,11 @@ func (x TimestampFormat) String() string {
 }
 
 func (TimestampFormat) Descriptor() protoreflect.EnumDescriptor {
-	return file_sebuf_http_annotations_proto_enumTypes[4].Descriptor()
+	return file_sebuf_http_annotations_proto_enumTypes[8].Descriptor()
 }
 
 func (TimestampFormat) Type() protoreflect.EnumType {
-	return &file_sebuf_http_annotations_proto_enumTypes[4]
+	return &file_sebuf_http_annotations_proto_enumTypes[8]
 }
 
 func (x TimestampFormat) Number() protoreflect.EnumNumber {
@@ -304,7 +523,7 @@ func (x TimestampFormat) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use TimestampFormat.Descriptor instead.
 func (TimestampFormat) EnumDescriptor() ([]byte, []int) {
-	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{4}
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{8}
 }
 
 // BytesEncoding controls how bytes fields serialize to JSON.
@@ -356,11 +575,11 @@ func (x BytesEncoding) String() string {
 }
 
 func (BytesEncoding) Descriptor() protoreflect.EnumDescriptor {
-	return file_sebuf_http_annotations_proto_enumTypes[5].Descriptor()
+	return file_sebuf_http_annotations_proto_enumTypes[9].Descriptor()
 }
 
 func (BytesEncoding) Type() protoreflect.EnumType {
-	return &file_sebuf_http_annotations_proto_enumTypes[5]
+	return &file_sebuf_http_annotations_proto_enumTypes[9]
 }
 
 func (x BytesEncoding) Number() protoreflect.EnumNumber {
@@ -369,7 +588,112 @@ func (x BytesEncoding) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use BytesEncoding.Descriptor instead.
 func (BytesEncoding) EnumDescriptor() ([]byte, []int) {
-	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{5}
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{9}
+}
+
+// JsonNaming selects the JSON keys of the fields of a message. A field's
+// explicit json_name always takes precedence.
+type JsonNaming int32
+
+const (
+	// The file's file_json_naming, or CAMEL_CASE when the file sets none
+	JsonNaming_JSON_NAMING_UNSPECIFIED JsonNaming = 0
+	// lowerCamelCase keys, the protojson default: "userId"
+	JsonNaming_JSON_NAMING_CAMEL_CASE JsonNaming = 1
+	// The proto field names: "user_id"
+	JsonNaming_JSON_NAMING_SNAKE_CASE JsonNaming = 2
+)
+
+// Enum value maps for JsonNaming.
+var (
+	JsonNaming_name = map[int32]string{
+		0: "JSON_NAMING_UNSPECIFIED",
+		1: "JSON_NAMING_CAMEL_CASE",
+		2: "JSON_NAMING_SNAKE_CASE",
+	}
+	JsonNaming_value = map[string]int32{
+		"JSON_NAMING_UNSPECIFIED": 0,
+		"JSON_NAMING_CAMEL_CASE":  1,
+		"JSON_NAMING_SNAKE_CASE":  2,
+	}
+)
+
+func (x JsonNaming) Enum() *JsonNaming {
+	p := new(JsonNaming)
+	*p = x
+	return p
+}
+
+func (x JsonNaming) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JsonNaming) Descriptor() protoreflect.EnumDescriptor {
+	return file_sebuf_http_annotations_proto_enumTypes[10].Descriptor()
+}
+
+func (JsonNaming) Type() protoreflect.EnumType {
+	return &file_sebuf_http_annotations_proto_enumTypes[10]
+}
+
+func (x JsonNaming) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JsonNaming.Descriptor instead.
+func (JsonNaming) EnumDescriptor() ([]byte, []int) {
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{10}
+}
+
+// WebhookSignatureAlgorithm selects the hash of a webhook's HMAC signature.
+type WebhookSignatureAlgorithm int32
+
+const (
+	// Unspecified defaults to HMAC-SHA256.
+	WebhookSignatureAlgorithm_WEBHOOK_SIGNATURE_ALGORITHM_UNSPECIFIED WebhookSignatureAlgorithm = 0
+	WebhookSignatureAlgorithm_WEBHOOK_SIGNATURE_ALGORITHM_SHA256      WebhookSignatureAlgorithm = 1
+	WebhookSignatureAlgorithm_WEBHOOK_SIGNATURE_ALGORITHM_SHA512      WebhookSignatureAlgorithm = 2
+)
+
+// Enum value maps for WebhookSignatureAlgorithm.
+var (
+	WebhookSignatureAlgorithm_name = map[int32]string{
+		0: "WEBHOOK_SIGNATURE_ALGORITHM_UNSPECIFIED",
+		1: "WEBHOOK_SIGNATURE_ALGORITHM_SHA256",
+		2: "WEBHOOK_SIGNATURE_ALGORITHM_SHA512",
+	}
+	WebhookSignatureAlgorithm_value = map[string]int32{
+		"WEBHOOK_SIGNATURE_ALGORITHM_UNSPECIFIED": 0,
+		"WEBHOOK_SIGNATURE_ALGORITHM_SHA256":      1,
+		"WEBHOOK_SIGNATURE_ALGORITHM_SHA512":      2,
+	}
+)
+
+func (x WebhookSignatureAlgorithm) Enum() *WebhookSignatureAlgorithm {
+	p := new(WebhookSignatureAlgorithm)
+	*p = x
+	return p
+}
+
+func (x WebhookSignatureAlgorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookSignatureAlgorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_sebuf_http_annotations_proto_enumTypes[11].Descriptor()
+}
+
+func (WebhookSignatureAlgorithm) Type() protoreflect.EnumType {
+	return &file_sebuf_http_annotations_proto_enumTypes[11]
+}
+
+func (x WebhookSignatureAlgorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookSignatureAlgorithm.Descriptor instead.
+func (WebhookSignatureAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{11}
 }
 
 // HttpConfig defines HTTP-specific configuration for an RPC method
@@ -382,9 +706,63 @@ type HttpConfig struct {
 	// When true, this method uses Server-Sent Events (SSE) for streaming responses.
 	// The server sends events with Content-Type: text/event-stream.
 	// Each event is the response message serialized as JSON in the SSE data field.
-	Stream        bool `protobuf:"varint,3,opt,name=stream,proto3" json:"stream,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Stream bool `protobuf:"varint,3,opt,name=stream,proto3" json:"stream,omitempty"`
+	// When true, the generated server requires an Idempotency-Key request header
+	// and deduplicates requests by it: a repeated key with an identical body
+	// replays the stored response, and a repeated key with a different body (or
+	// one still being processed) is rejected with 409 Conflict. Not supported on
+	// streaming methods.
+	Idempotency bool `protobuf:"varint,4,opt,name=idempotency,proto3" json:"idempotency,omitempty"`
+	// Caching policy for successful responses. Only allowed on GET methods.
+	Cache *CacheConfig `protobuf:"bytes,5,opt,name=cache,proto3" json:"cache,omitempty"`
+	// How long the handler may run, in milliseconds. The generated server
+	// cancels the handler's context when it expires and responds with
+	// 504 Gateway Timeout. Overrides the server's WithDefaultTimeout; zero keeps
+	// it. Not supported on streaming methods.
+	TimeoutMs int32 `protobuf:"varint,6,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	// When true, the generated server also accepts request bodies encoded as
+	// application/x-www-form-urlencoded. Form keys bind to fields by JSON name
+	// using the query parameter rules; nested message fields are addressed with
+	// dotted keys (address.city=Beirut) and repeated fields with repeated keys.
+	// Only meaningful on methods with a request body (POST, PUT, PATCH).
+	AcceptForm bool `protobuf:"varint,7,opt,name=accept_form,json=acceptForm,proto3" json:"accept_form,omitempty"`
+	// When true, the generated server also accepts multipart/form-data request
+	// bodies, bounded by the server's WithMaxBodySize (32 MiB when unset). File
+	// parts bind to bytes fields named by the part's form name, other parts bind
+	// like accept_form values. Only meaningful on methods with a request body
+	// (POST, PUT, PATCH).
+	AcceptMultipart bool `protobuf:"varint,8,opt,name=accept_multipart,json=acceptMultipart,proto3" json:"accept_multipart,omitempty"`
+	// When true, the generated server rejects JSON request bodies with keys that
+	// name no field of the request message, answering 400 with a violation per
+	// unknown key. Otherwise unknown keys are ignored. Also enabled for every
+	// method by the service's strict_json or the server's WithStrictJSON.
+	StrictJson bool `protobuf:"varint,9,opt,name=strict_json,json=strictJson,proto3" json:"strict_json,omitempty"`
+	// When true, the generated Go server writes the response items as the handler
+	// yields them instead of holding the whole response in memory. The response
+	// message must have a single field, a repeated message field, usually
+	// annotated with unwrap. The JSON written is the document the buffered
+	// response would have been, and the generated Go and TypeScript clients decode
+	// the items as they arrive. Not supported together with stream, idempotency,
+	// cache or timeout_ms.
+	StreamResponse bool `protobuf:"varint,10,opt,name=stream_response,json=streamResponse,proto3" json:"stream_response,omitempty"`
+	// How the request body of a PATCH method is read. With
+	// PATCH_FORMAT_MERGE_PATCH, the generated Go server also accepts
+	// application/merge-patch+json bodies and tells handlers which fields the
+	// body sets, clears with null or leaves out. Only valid on PATCH methods.
+	PatchFormat PatchFormat `protobuf:"varint,11,opt,name=patch_format,json=patchFormat,proto3,enum=sebuf.http.PatchFormat" json:"patch_format,omitempty"`
+	// Typed bodies of the error statuses the method documents, keyed by HTTP
+	// status from 400 to 599 (e.g. 404: "NotFoundError"). Each value names a
+	// message, relative to the package of the file or fully qualified. The
+	// generated Go and TypeScript clients decode a response with one of these
+	// statuses into its message and fail with an error type of their own for
+	// it; other error statuses keep failing as before.
+	ErrorResponses map[int32]string `protobuf:"bytes,12,rep,name=error_responses,json=errorResponses,proto3" json:"error_responses,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Static headers the generated server sets on every response of the
+	// method, errors included. They replace the service's response_headers of
+	// the same name; other service headers still apply.
+	ResponseHeaders []*ResponseHeader `protobuf:"bytes,13,rep,name=response_headers,json=responseHeaders,proto3" json:"response_headers,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *HttpConfig) Reset() {
@@ -435,33 +813,520 @@ func (x *HttpConfig) GetStream() bool {
 	if x != nil {
 		return x.Stream
 	}
-	return false
+	return false
+}
+
+func (x *HttpConfig) GetIdempotency() bool {
+	if x != nil {
+		return x.Idempotency
+	}
+	return false
+}
+
+func (x *HttpConfig) GetCache() *CacheConfig {
+	if x != nil {
+		return x.Cache
+	}
+	return nil
+}
+
+func (x *HttpConfig) GetTimeoutMs() int32 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *HttpConfig) GetAcceptForm() bool {
+	if x != nil {
+		return x.AcceptForm
+	}
+	return false
+}
+
+func (x *HttpConfig) GetAcceptMultipart() bool {
+	if x != nil {
+		return x.AcceptMultipart
+	}
+	return false
+}
+
+func (x *HttpConfig) GetStrictJson() bool {
+	if x != nil {
+		return x.StrictJson
+	}
+	return false
+}
+
+func (x *HttpConfig) GetStreamResponse() bool {
+	if x != nil {
+		return x.StreamResponse
+	}
+	return false
+}
+
+func (x *HttpConfig) GetPatchFormat() PatchFormat {
+	if x != nil {
+		return x.PatchFormat
+	}
+	return PatchFormat_PATCH_FORMAT_UNSPECIFIED
+}
+
+func (x *HttpConfig) GetErrorResponses() map[int32]string {
+	if x != nil {
+		return x.ErrorResponses
+	}
+	return nil
+}
+
+func (x *HttpConfig) GetResponseHeaders() []*ResponseHeader {
+	if x != nil {
+		return x.ResponseHeaders
+	}
+	return nil
+}
+
+// ResponseHeader is a static header a generated server sets on responses,
+// such as Cache-Control or X-Frame-Options. The server's
+// WithResponseHeaderOverrides option changes or removes it at deployment.
+type ResponseHeader struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the header (e.g. X-Frame-Options)
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Value the header is set to (e.g. DENY)
+	Value         string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseHeader) Reset() {
+	*x = ResponseHeader{}
+	mi := &file_sebuf_http_annotations_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseHeader) ProtoMessage() {}
+
+func (x *ResponseHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_sebuf_http_annotations_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseHeader.ProtoReflect.Descriptor instead.
+func (*ResponseHeader) Descriptor() ([]byte, []int) {
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ResponseHeader) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResponseHeader) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// CacheConfig controls the Cache-Control header the generated server sets on
+// successful responses, and how long the server's optional in-process
+// response cache (WithResponseCache) keeps them.
+type CacheConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// How long the response may be reused, emitted as max-age.
+	MaxAgeSeconds int32 `protobuf:"varint,1,opt,name=max_age_seconds,json=maxAgeSeconds,proto3" json:"max_age_seconds,omitempty"`
+	// When true the response is marked public (cacheable by shared caches such
+	// as CDNs); otherwise it is marked private.
+	Public        bool `protobuf:"varint,2,opt,name=public,proto3" json:"public,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheConfig) Reset() {
+	*x = CacheConfig{}
+	mi := &file_sebuf_http_annotations_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheConfig) ProtoMessage() {}
+
+func (x *CacheConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_sebuf_http_annotations_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheConfig.ProtoReflect.Descriptor instead.
+func (*CacheConfig) Descriptor() ([]byte, []int) {
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CacheConfig) GetMaxAgeSeconds() int32 {
+	if x != nil {
+		return x.MaxAgeSeconds
+	}
+	return 0
+}
+
+func (x *CacheConfig) GetPublic() bool {
+	if x != nil {
+		return x.Public
+	}
+	return false
+}
+
+// ServiceConfig defines HTTP-specific configuration for an entire service
+type ServiceConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Base path prefix for all methods in this service
+	BasePath string `protobuf:"bytes,1,opt,name=base_path,json=basePath,proto3" json:"base_path,omitempty"`
+	// API versions the service is served under, oldest first. Every method is
+	// registered under each version's base path; method paths are relative to
+	// it. Clients call the newest non-deprecated version unless told otherwise.
+	// Mutually exclusive with base_path.
+	Versions []*ApiVersion `protobuf:"bytes,2,rep,name=versions,proto3" json:"versions,omitempty"`
+	// When true, every method of the service rejects JSON request bodies with
+	// unknown keys, as if it set strict_json.
+	StrictJson bool `protobuf:"varint,3,opt,name=strict_json,json=strictJson,proto3" json:"strict_json,omitempty"`
+	// Declarations of the path parameters of base_path (or of the versions'
+	// base paths), such as tenant_id in /t/{tenant_id}/api/v1. A base path
+	// parameter is bound to the request field of the same name, which every
+	// method must have unless the parameter is declared with context_only.
+	BasePathParams []*BasePathParam `protobuf:"bytes,4,rep,name=base_path_params,json=basePathParams,proto3" json:"base_path_params,omitempty"`
+	// Format of the error responses of the generated server. WithProblemJSON
+	// selects PROBLEM_JSON at runtime for services that leave it unspecified.
+	ErrorFormat ErrorFormat `protobuf:"varint,5,opt,name=error_format,json=errorFormat,proto3,enum=sebuf.http.ErrorFormat" json:"error_format,omitempty"`
+	// Static headers the generated server sets on every response of every
+	// method of the service, errors included, before any body is written.
+	ResponseHeaders []*ResponseHeader `protobuf:"bytes,6,rep,name=response_headers,json=responseHeaders,proto3" json:"response_headers,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ServiceConfig) Reset() {
+	*x = ServiceConfig{}
+	mi := &file_sebuf_http_annotations_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceConfig) ProtoMessage() {}
+
+func (x *ServiceConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_sebuf_http_annotations_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceConfig.ProtoReflect.Descriptor instead.
+func (*ServiceConfig) Descriptor() ([]byte, []int) {
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ServiceConfig) GetBasePath() string {
+	if x != nil {
+		return x.BasePath
+	}
+	return ""
+}
+
+func (x *ServiceConfig) GetVersions() []*ApiVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *ServiceConfig) GetStrictJson() bool {
+	if x != nil {
+		return x.StrictJson
+	}
+	return false
+}
+
+func (x *ServiceConfig) GetBasePathParams() []*BasePathParam {
+	if x != nil {
+		return x.BasePathParams
+	}
+	return nil
+}
+
+func (x *ServiceConfig) GetErrorFormat() ErrorFormat {
+	if x != nil {
+		return x.ErrorFormat
+	}
+	return ErrorFormat_ERROR_FORMAT_UNSPECIFIED
+}
+
+func (x *ServiceConfig) GetResponseHeaders() []*ResponseHeader {
+	if x != nil {
+		return x.ResponseHeaders
+	}
+	return nil
+}
+
+// BasePathParam declares a path parameter of a service base path.
+type BasePathParam struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the parameter, as written between braces in the base path
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// When true, the parameter is bound to no request field: handlers read it
+	// from the request context only.
+	ContextOnly   bool `protobuf:"varint,2,opt,name=context_only,json=contextOnly,proto3" json:"context_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BasePathParam) Reset() {
+	*x = BasePathParam{}
+	mi := &file_sebuf_http_annotations_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BasePathParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BasePathParam) ProtoMessage() {}
+
+func (x *BasePathParam) ProtoReflect() protoreflect.Message {
+	mi := &file_sebuf_http_annotations_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BasePathParam.ProtoReflect.Descriptor instead.
+func (*BasePathParam) Descriptor() ([]byte, []int) {
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BasePathParam) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BasePathParam) GetContextOnly() bool {
+	if x != nil {
+		return x.ContextOnly
+	}
+	return false
+}
+
+// ApiVersion is one base path a versioned service is served under.
+type ApiVersion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Base path prefix of this version's routes (e.g. /api/v2)
+	BasePath string `protobuf:"bytes,1,opt,name=base_path,json=basePath,proto3" json:"base_path,omitempty"`
+	// Name clients select the version by. Defaults to the last segment of
+	// base_path (v2 for /api/v2).
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// When true, responses of this version's routes carry a Deprecation: true
+	// header.
+	Deprecated bool `protobuf:"varint,3,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	// Date (YYYY-MM-DD) after which a deprecated version may stop being served,
+	// sent as the Sunset response header. Requires deprecated.
+	Sunset        string `protobuf:"bytes,4,opt,name=sunset,proto3" json:"sunset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApiVersion) Reset() {
+	*x = ApiVersion{}
+	mi := &file_sebuf_http_annotations_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApiVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiVersion) ProtoMessage() {}
+
+func (x *ApiVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_sebuf_http_annotations_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiVersion.ProtoReflect.Descriptor instead.
+func (*ApiVersion) Descriptor() ([]byte, []int) {
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ApiVersion) GetBasePath() string {
+	if x != nil {
+		return x.BasePath
+	}
+	return ""
+}
+
+func (x *ApiVersion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApiVersion) GetDeprecated() bool {
+	if x != nil {
+		return x.Deprecated
+	}
+	return false
+}
+
+func (x *ApiVersion) GetSunset() string {
+	if x != nil {
+		return x.Sunset
+	}
+	return ""
+}
+
+// Visibility restricts the generators that emit a service or method. Generators
+// are named after their plugin without the protoc-gen- prefix: go-http,
+// go-client, openapiv3, ts-client, ts-server, py-client and kt-client. A
+// service or method cannot set both lists.
+type Visibility struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Generators that skip the element (e.g. ["openapiv3", "ts-client"] for an
+	// internal method served only by the Go server).
+	Exclude []string `protobuf:"bytes,1,rep,name=exclude,proto3" json:"exclude,omitempty"`
+	// When set, the only generators that emit the element.
+	IncludeOnly   []string `protobuf:"bytes,2,rep,name=include_only,json=includeOnly,proto3" json:"include_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Visibility) Reset() {
+	*x = Visibility{}
+	mi := &file_sebuf_http_annotations_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Visibility) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Visibility) ProtoMessage() {}
+
+func (x *Visibility) ProtoReflect() protoreflect.Message {
+	mi := &file_sebuf_http_annotations_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Visibility.ProtoReflect.Descriptor instead.
+func (*Visibility) Descriptor() ([]byte, []int) {
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Visibility) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *Visibility) GetIncludeOnly() []string {
+	if x != nil {
+		return x.IncludeOnly
+	}
+	return nil
 }
 
-// ServiceConfig defines HTTP-specific configuration for an entire service
-type ServiceConfig struct {
+// MethodExample is a named example of a method's request and response, each
+// written as the JSON protojson reads into the message. OpenAPI documents it
+// under its name on the request body and the success response. Generators
+// fail when a payload does not unmarshal into its message.
+type MethodExample struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Base path prefix for all methods in this service
-	BasePath      string `protobuf:"bytes,1,opt,name=base_path,json=basePath,proto3" json:"base_path,omitempty"`
+	// Key of the example, distinct among the examples of the method. The mock
+	// server answers with the response of the example named "default".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Short description of the example.
+	Summary string `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	// JSON of the request message (e.g. '{"title": "Dune"}'). Only valid on
+	// methods with a request body: POST, PUT and PATCH.
+	Request string `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	// JSON of the response message.
+	Response      string `protobuf:"bytes,4,opt,name=response,proto3" json:"response,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ServiceConfig) Reset() {
-	*x = ServiceConfig{}
-	mi := &file_sebuf_http_annotations_proto_msgTypes[1]
+func (x *MethodExample) Reset() {
+	*x = MethodExample{}
+	mi := &file_sebuf_http_annotations_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ServiceConfig) String() string {
+func (x *MethodExample) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServiceConfig) ProtoMessage() {}
+func (*MethodExample) ProtoMessage() {}
 
-func (x *ServiceConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_sebuf_http_annotations_proto_msgTypes[1]
+func (x *MethodExample) ProtoReflect() protoreflect.Message {
+	mi := &file_sebuf_http_annotations_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -472,14 +1337,35 @@ func (x *ServiceConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServiceConfig.ProtoReflect.Descriptor instead.
-func (*ServiceConfig) Descriptor() ([]byte, []int) {
-	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use MethodExample.ProtoReflect.Descriptor instead.
+func (*MethodExample) Descriptor() ([]byte, []int) {
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ServiceConfig) GetBasePath() string {
+func (x *MethodExample) GetName() string {
 	if x != nil {
-		return x.BasePath
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MethodExample) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *MethodExample) GetRequest() string {
+	if x != nil {
+		return x.Request
+	}
+	return ""
+}
+
+func (x *MethodExample) GetResponse() string {
+	if x != nil {
+		return x.Response
 	}
 	return ""
 }
@@ -495,7 +1381,7 @@ type FieldExamples struct {
 
 func (x *FieldExamples) Reset() {
 	*x = FieldExamples{}
-	mi := &file_sebuf_http_annotations_proto_msgTypes[2]
+	mi := &file_sebuf_http_annotations_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -507,7 +1393,7 @@ func (x *FieldExamples) String() string {
 func (*FieldExamples) ProtoMessage() {}
 
 func (x *FieldExamples) ProtoReflect() protoreflect.Message {
-	mi := &file_sebuf_http_annotations_proto_msgTypes[2]
+	mi := &file_sebuf_http_annotations_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -520,7 +1406,7 @@ func (x *FieldExamples) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FieldExamples.ProtoReflect.Descriptor instead.
 func (*FieldExamples) Descriptor() ([]byte, []int) {
-	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{2}
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *FieldExamples) GetValues() []string {
@@ -536,14 +1422,24 @@ type QueryConfig struct {
 	// The query parameter name in the URL (e.g., "page_size" for ?page_size=10)
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	// Whether this query parameter is required
-	Required      bool `protobuf:"varint,2,opt,name=required,proto3" json:"required,omitempty"`
+	Required bool `protobuf:"varint,2,opt,name=required,proto3" json:"required,omitempty"`
+	// How the field is carried in the query string
+	Encoding QueryEncoding `protobuf:"varint,3,opt,name=encoding,proto3,enum=sebuf.http.QueryEncoding" json:"encoding,omitempty"`
+	// Largest decoded QUERY_ENCODING_JSON_BASE64URL value servers accept, in
+	// bytes; larger ones are rejected with a field violation. Defaults to 4096.
+	MaxBytes uint32 `protobuf:"varint,4,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	// Other names servers accept for the parameter, such as its names before a
+	// rename. Servers read the first alias present when the name is absent;
+	// clients always send the name. An alias cannot be the name or an alias of
+	// another query parameter of the message.
+	Aliases       []string `protobuf:"bytes,5,rep,name=aliases,proto3" json:"aliases,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *QueryConfig) Reset() {
 	*x = QueryConfig{}
-	mi := &file_sebuf_http_annotations_proto_msgTypes[3]
+	mi := &file_sebuf_http_annotations_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -555,7 +1451,7 @@ func (x *QueryConfig) String() string {
 func (*QueryConfig) ProtoMessage() {}
 
 func (x *QueryConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_sebuf_http_annotations_proto_msgTypes[3]
+	mi := &file_sebuf_http_annotations_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -568,7 +1464,7 @@ func (x *QueryConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryConfig.ProtoReflect.Descriptor instead.
 func (*QueryConfig) Descriptor() ([]byte, []int) {
-	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{3}
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *QueryConfig) GetName() string {
@@ -585,6 +1481,105 @@ func (x *QueryConfig) GetRequired() bool {
 	return false
 }
 
+func (x *QueryConfig) GetEncoding() QueryEncoding {
+	if x != nil {
+		return x.Encoding
+	}
+	return QueryEncoding_QUERY_ENCODING_UNSPECIFIED
+}
+
+func (x *QueryConfig) GetMaxBytes() uint32 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *QueryConfig) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+// EncodingDefaults sets the JSON encodings of the fields of a message, or of
+// every message in a file, that do not set the encoding themselves. Each
+// applies only to the fields it is valid on, so a file can default int64
+// fields to NUMBER without touching its other fields. Precedence: field, then
+// message encoding_defaults, then file_encoding_defaults, then the protojson
+// default.
+type EncodingDefaults struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Default int64_encoding of the int64, sint64, sfixed64, uint64 and fixed64 fields
+	Int64Encoding Int64Encoding `protobuf:"varint,1,opt,name=int64_encoding,json=int64Encoding,proto3,enum=sebuf.http.Int64Encoding" json:"int64_encoding,omitempty"`
+	// Default enum_encoding of the enum fields
+	EnumEncoding EnumEncoding `protobuf:"varint,2,opt,name=enum_encoding,json=enumEncoding,proto3,enum=sebuf.http.EnumEncoding" json:"enum_encoding,omitempty"`
+	// Default timestamp_format of the google.protobuf.Timestamp fields
+	TimestampFormat TimestampFormat `protobuf:"varint,3,opt,name=timestamp_format,json=timestampFormat,proto3,enum=sebuf.http.TimestampFormat" json:"timestamp_format,omitempty"`
+	// Default bytes_encoding of the bytes fields
+	BytesEncoding BytesEncoding `protobuf:"varint,4,opt,name=bytes_encoding,json=bytesEncoding,proto3,enum=sebuf.http.BytesEncoding" json:"bytes_encoding,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EncodingDefaults) Reset() {
+	*x = EncodingDefaults{}
+	mi := &file_sebuf_http_annotations_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EncodingDefaults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncodingDefaults) ProtoMessage() {}
+
+func (x *EncodingDefaults) ProtoReflect() protoreflect.Message {
+	mi := &file_sebuf_http_annotations_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncodingDefaults.ProtoReflect.Descriptor instead.
+func (*EncodingDefaults) Descriptor() ([]byte, []int) {
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EncodingDefaults) GetInt64Encoding() Int64Encoding {
+	if x != nil {
+		return x.Int64Encoding
+	}
+	return Int64Encoding_INT64_ENCODING_UNSPECIFIED
+}
+
+func (x *EncodingDefaults) GetEnumEncoding() EnumEncoding {
+	if x != nil {
+		return x.EnumEncoding
+	}
+	return EnumEncoding_ENUM_ENCODING_UNSPECIFIED
+}
+
+func (x *EncodingDefaults) GetTimestampFormat() TimestampFormat {
+	if x != nil {
+		return x.TimestampFormat
+	}
+	return TimestampFormat_TIMESTAMP_FORMAT_UNSPECIFIED
+}
+
+func (x *EncodingDefaults) GetBytesEncoding() BytesEncoding {
+	if x != nil {
+		return x.BytesEncoding
+	}
+	return BytesEncoding_BYTES_ENCODING_UNSPECIFIED
+}
+
 // OneofConfig controls oneof serialization as a discriminated union.
 // Applied to a oneof definition via (sebuf.http.oneof_config).
 type OneofConfig struct {
@@ -603,7 +1598,7 @@ type OneofConfig struct {
 
 func (x *OneofConfig) Reset() {
 	*x = OneofConfig{}
-	mi := &file_sebuf_http_annotations_proto_msgTypes[4]
+	mi := &file_sebuf_http_annotations_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -615,7 +1610,7 @@ func (x *OneofConfig) String() string {
 func (*OneofConfig) ProtoMessage() {}
 
 func (x *OneofConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_sebuf_http_annotations_proto_msgTypes[4]
+	mi := &file_sebuf_http_annotations_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -628,7 +1623,7 @@ func (x *OneofConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OneofConfig.ProtoReflect.Descriptor instead.
 func (*OneofConfig) Descriptor() ([]byte, []int) {
-	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{4}
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *OneofConfig) GetDiscriminator() string {
@@ -645,6 +1640,125 @@ func (x *OneofConfig) GetFlatten() bool {
 	return false
 }
 
+// ResponseStatuses makes a response message a result message: one of several
+// bodies, each answered with its own HTTP status. Applied to the message's
+// oneof via (sebuf.http.response_statuses).
+type ResponseStatuses struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// HTTP status of each variant, keyed by the variant's proto field name
+	// (e.g. order: 200, conflict: 409). Every variant must be listed, each with
+	// a distinct status from 200 to 599 that carries a body.
+	Statuses      map[string]int32 `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseStatuses) Reset() {
+	*x = ResponseStatuses{}
+	mi := &file_sebuf_http_annotations_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseStatuses) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseStatuses) ProtoMessage() {}
+
+func (x *ResponseStatuses) ProtoReflect() protoreflect.Message {
+	mi := &file_sebuf_http_annotations_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseStatuses.ProtoReflect.Descriptor instead.
+func (*ResponseStatuses) Descriptor() ([]byte, []int) {
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ResponseStatuses) GetStatuses() map[string]int32 {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+// WebhookConfig marks a message as the payload of an outbound webhook.
+// protoc-gen-go-client (webhooks=true) generates Send<Message>, which POSTs the
+// message to a receiver with an HMAC signature of the body, and
+// Verify<Message>Signature for the receiving side.
+type WebhookConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path appended to the endpoint URL the payload is sent to (e.g.
+	// /hooks/orders). Empty sends to the endpoint URL itself.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Header carrying the signature, "<algorithm>=<hex HMAC of the body>" (e.g.
+	// sha256=5d41...). Defaults to X-Webhook-Signature.
+	SignatureHeader string `protobuf:"bytes,2,opt,name=signature_header,json=signatureHeader,proto3" json:"signature_header,omitempty"`
+	// Hash of the HMAC signature. Defaults to SHA-256.
+	Algorithm     WebhookSignatureAlgorithm `protobuf:"varint,3,opt,name=algorithm,proto3,enum=sebuf.http.WebhookSignatureAlgorithm" json:"algorithm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookConfig) Reset() {
+	*x = WebhookConfig{}
+	mi := &file_sebuf_http_annotations_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookConfig) ProtoMessage() {}
+
+func (x *WebhookConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_sebuf_http_annotations_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookConfig.ProtoReflect.Descriptor instead.
+func (*WebhookConfig) Descriptor() ([]byte, []int) {
+	return file_sebuf_http_annotations_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WebhookConfig) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *WebhookConfig) GetSignatureHeader() string {
+	if x != nil {
+		return x.SignatureHeader
+	}
+	return ""
+}
+
+func (x *WebhookConfig) GetAlgorithm() WebhookSignatureAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return WebhookSignatureAlgorithm_WEBHOOK_SIGNATURE_ALGORITHM_UNSPECIFIED
+}
+
 var file_sebuf_http_annotations_proto_extTypes = []protoimpl.ExtensionInfo{
 	{
 		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
@@ -662,6 +1776,30 @@ var file_sebuf_http_annotations_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "bytes,50004,opt,name=service_config",
 		Filename:      "sebuf/http/annotations.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*Visibility)(nil),
+		Field:         50028,
+		Name:          "sebuf.http.visibility",
+		Tag:           "bytes,50028,opt,name=visibility",
+		Filename:      "sebuf/http/annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: ([]*MethodExample)(nil),
+		Field:         50036,
+		Name:          "sebuf.http.examples",
+		Tag:           "bytes,50036,rep,name=examples",
+		Filename:      "sebuf/http/annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.ServiceOptions)(nil),
+		ExtensionType: (*Visibility)(nil),
+		Field:         50029,
+		Name:          "sebuf.http.service_visibility",
+		Tag:           "bytes,50029,opt,name=service_visibility",
+		Filename:      "sebuf/http/annotations.proto",
+	},
 	{
 		ExtendedType:  (*descriptorpb.OneofOptions)(nil),
 		ExtensionType: (*OneofConfig)(nil),
@@ -670,6 +1808,14 @@ var file_sebuf_http_annotations_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "bytes,50017,opt,name=oneof_config",
 		Filename:      "sebuf/http/annotations.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.OneofOptions)(nil),
+		ExtensionType: (*ResponseStatuses)(nil),
+		Field:         50027,
+		Name:          "sebuf.http.response_statuses",
+		Tag:           "bytes,50027,opt,name=response_statuses",
+		Filename:      "sebuf/http/annotations.proto",
+	},
 	{
 		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
 		ExtensionType: (*FieldExamples)(nil),
@@ -766,6 +1912,102 @@ var file_sebuf_http_annotations_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "bytes,50020,opt,name=flatten_prefix",
 		Filename:      "sebuf/http/annotations.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*FieldSource)(nil),
+		Field:         50021,
+		Name:          "sebuf.http.source",
+		Tag:           "varint,50021,opt,name=source,enum=sebuf.http.FieldSource",
+		Filename:      "sebuf/http/annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50022,
+		Name:          "sebuf.http.sensitive",
+		Tag:           "varint,50022,opt,name=sensitive",
+		Filename:      "sebuf/http/annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         50024,
+		Name:          "sebuf.http.multipart_filename",
+		Tag:           "bytes,50024,opt,name=multipart_filename",
+		Filename:      "sebuf/http/annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50034,
+		Name:          "sebuf.http.raw_body",
+		Tag:           "varint,50034,opt,name=raw_body",
+		Filename:      "sebuf/http/annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50035,
+		Name:          "sebuf.http.raw_body_content_type",
+		Tag:           "varint,50035,opt,name=raw_body_content_type",
+		Filename:      "sebuf/http/annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*WebhookConfig)(nil),
+		Field:         50023,
+		Name:          "sebuf.http.webhook",
+		Tag:           "bytes,50023,opt,name=webhook",
+		Filename:      "sebuf/http/annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*JsonNaming)(nil),
+		Field:         50025,
+		Name:          "sebuf.http.json_naming",
+		Tag:           "varint,50025,opt,name=json_naming,enum=sebuf.http.JsonNaming",
+		Filename:      "sebuf/http/annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*EncodingDefaults)(nil),
+		Field:         50030,
+		Name:          "sebuf.http.encoding_defaults",
+		Tag:           "bytes,50030,opt,name=encoding_defaults",
+		Filename:      "sebuf/http/annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50032,
+		Name:          "sebuf.http.reject_alternate_names",
+		Tag:           "varint,50032,opt,name=reject_alternate_names",
+		Filename:      "sebuf/http/annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FileOptions)(nil),
+		ExtensionType: (*JsonNaming)(nil),
+		Field:         50026,
+		Name:          "sebuf.http.file_json_naming",
+		Tag:           "varint,50026,opt,name=file_json_naming,enum=sebuf.http.JsonNaming",
+		Filename:      "sebuf/http/annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FileOptions)(nil),
+		ExtensionType: (*EncodingDefaults)(nil),
+		Field:         50031,
+		Name:          "sebuf.http.file_encoding_defaults",
+		Tag:           "bytes,50031,opt,name=file_encoding_defaults",
+		Filename:      "sebuf/http/annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FileOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50033,
+		Name:          "sebuf.http.file_reject_alternate_names",
+		Tag:           "varint,50033,opt,name=file_reject_alternate_names",
+		Filename:      "sebuf/http/annotations.proto",
+	},
 	{
 		ExtendedType:  (*descriptorpb.EnumValueOptions)(nil),
 		ExtensionType: (*string)(nil),
@@ -780,12 +2022,18 @@ var file_sebuf_http_annotations_proto_extTypes = []protoimpl.ExtensionInfo{
 var (
 	// optional sebuf.http.HttpConfig config = 50003;
 	E_Config = &file_sebuf_http_annotations_proto_extTypes[0]
+	// optional sebuf.http.Visibility visibility = 50028;
+	E_Visibility = &file_sebuf_http_annotations_proto_extTypes[2]
+	// repeated sebuf.http.MethodExample examples = 50036;
+	E_Examples = &file_sebuf_http_annotations_proto_extTypes[3]
 )
 
 // Extension fields to descriptorpb.ServiceOptions.
 var (
 	// optional sebuf.http.ServiceConfig service_config = 50004;
 	E_ServiceConfig = &file_sebuf_http_annotations_proto_extTypes[1]
+	// optional sebuf.http.Visibility service_visibility = 50029;
+	E_ServiceVisibility = &file_sebuf_http_annotations_proto_extTypes[4]
 )
 
 // Extension fields to descriptorpb.OneofOptions.
@@ -794,7 +2042,14 @@ var (
 	// When set, adds a discriminator field to the JSON output identifying which variant is set.
 	//
 	// optional sebuf.http.OneofConfig oneof_config = 50017;
-	E_OneofConfig = &file_sebuf_http_annotations_proto_extTypes[2]
+	E_OneofConfig = &file_sebuf_http_annotations_proto_extTypes[5]
+	// Selects the response status and body by the variant set. The server
+	// answers with the set variant's status and the variant's message as the
+	// body, without the enclosing message. The oneof's variants must be message
+	// fields, and the message must have no other fields.
+	//
+	// optional sebuf.http.ResponseStatuses response_statuses = 50027;
+	E_ResponseStatuses = &file_sebuf_http_annotations_proto_extTypes[6]
 )
 
 // Extension fields to descriptorpb.FieldOptions.
@@ -802,11 +2057,11 @@ var (
 	// Example values for documentation/OpenAPI
 	//
 	// optional sebuf.http.FieldExamples field_examples = 50007;
-	E_FieldExamples = &file_sebuf_http_annotations_proto_extTypes[3]
+	E_FieldExamples = &file_sebuf_http_annotations_proto_extTypes[7]
 	// Query parameter configuration for a field
 	//
 	// optional sebuf.http.QueryConfig query = 50008;
-	E_Query = &file_sebuf_http_annotations_proto_extTypes[4]
+	E_Query = &file_sebuf_http_annotations_proto_extTypes[8]
 	// Mark a repeated field for unwrapping when parent message is a map value.
 	// When set to true on a repeated field, and the message containing this field
 	// is used as a map value, the JSON serialization will collapse the wrapper
@@ -814,62 +2069,143 @@ var (
 	// Constraints: Only valid on repeated fields, only one per message.
 	//
 	// optional bool unwrap = 50009;
-	E_Unwrap = &file_sebuf_http_annotations_proto_extTypes[5]
+	E_Unwrap = &file_sebuf_http_annotations_proto_extTypes[9]
 	// Controls int64/uint64 JSON encoding for this field.
 	// Valid on: int64, sint64, sfixed64, uint64, fixed64 fields.
 	// Default: STRING encoding (protojson default for JavaScript precision safety).
 	//
 	// optional sebuf.http.Int64Encoding int64_encoding = 50010;
-	E_Int64Encoding = &file_sebuf_http_annotations_proto_extTypes[6]
+	E_Int64Encoding = &file_sebuf_http_annotations_proto_extTypes[10]
 	// Controls enum JSON encoding for this field.
 	// Valid on: enum fields only.
 	// Default: STRING encoding (protojson default using proto enum names).
 	//
 	// optional sebuf.http.EnumEncoding enum_encoding = 50011;
-	E_EnumEncoding = &file_sebuf_http_annotations_proto_extTypes[7]
+	E_EnumEncoding = &file_sebuf_http_annotations_proto_extTypes[11]
 	// Mark a primitive field as nullable (explicit null vs absent).
 	// Only valid on proto3 optional fields (HasOptionalKeyword=true).
 	// When true: unset field serializes as null, set field serializes normally.
 	// When false (default): unset field is omitted from JSON.
 	//
 	// optional bool nullable = 50013;
-	E_Nullable = &file_sebuf_http_annotations_proto_extTypes[8]
+	E_Nullable = &file_sebuf_http_annotations_proto_extTypes[12]
 	// Controls how empty message fields serialize to JSON.
 	// Only valid on singular message fields (not repeated, not map).
 	// "Empty" = all fields at proto default (proto.Size() == 0).
 	//
 	// optional sebuf.http.EmptyBehavior empty_behavior = 50014;
-	E_EmptyBehavior = &file_sebuf_http_annotations_proto_extTypes[9]
+	E_EmptyBehavior = &file_sebuf_http_annotations_proto_extTypes[13]
 	// Controls timestamp JSON encoding for this field.
 	// Valid on: google.protobuf.Timestamp fields only.
 	// Default: RFC3339 (protojson default).
 	//
 	// optional sebuf.http.TimestampFormat timestamp_format = 50015;
-	E_TimestampFormat = &file_sebuf_http_annotations_proto_extTypes[10]
+	E_TimestampFormat = &file_sebuf_http_annotations_proto_extTypes[14]
 	// Controls bytes JSON encoding for this field.
 	// Valid on: bytes fields only.
 	// Default: BASE64 (protojson default).
 	//
 	// optional sebuf.http.BytesEncoding bytes_encoding = 50016;
-	E_BytesEncoding = &file_sebuf_http_annotations_proto_extTypes[11]
+	E_BytesEncoding = &file_sebuf_http_annotations_proto_extTypes[15]
 	// Custom discriminator value for this oneof variant field.
 	// When set, this value is used in the discriminator field instead of the proto field name.
 	// Only valid on fields that are part of a oneof with oneof_config annotation.
 	//
 	// optional string oneof_value = 50018;
-	E_OneofValue = &file_sebuf_http_annotations_proto_extTypes[12]
+	E_OneofValue = &file_sebuf_http_annotations_proto_extTypes[16]
 	// Flatten a nested message field, promoting its child fields to the parent level in JSON.
 	// Only valid on singular message fields (not repeated, not map, not oneof variant).
 	// When true: child message fields appear at the parent level (e.g., address.street becomes street).
 	//
 	// optional bool flatten = 50019;
-	E_Flatten = &file_sebuf_http_annotations_proto_extTypes[13]
+	E_Flatten = &file_sebuf_http_annotations_proto_extTypes[17]
 	// Prefix to prepend to flattened field names to avoid collisions.
 	// Only valid when flatten=true is also set.
 	// Example: flatten_prefix="billing_" with child field "street" produces "billing_street" in JSON.
 	//
 	// optional string flatten_prefix = 50020;
-	E_FlattenPrefix = &file_sebuf_http_annotations_proto_extTypes[14]
+	E_FlattenPrefix = &file_sebuf_http_annotations_proto_extTypes[18]
+	// Where this request field is read from. An explicit non-body source removes
+	// the field from the request body: clients send it only in its declared
+	// location, servers ignore it in the body, and OpenAPI documents it only as
+	// a parameter.
+	//
+	// optional sebuf.http.FieldSource source = 50021;
+	E_Source = &file_sebuf_http_annotations_proto_extTypes[19]
+	// Mark a field as sensitive (passwords, tokens, secrets).
+	// Only valid on string and bytes fields, including repeated fields and map values.
+	// Generated Redacted() helpers replace sensitive strings with "[REDACTED]" and
+	// empty sensitive bytes, mocks never return example values for them, and
+	// OpenAPI documents sensitive strings with format: password.
+	//
+	// optional bool sensitive = 50022;
+	E_Sensitive = &file_sebuf_http_annotations_proto_extTypes[20]
+	// Names a bytes field of the same message. When that field is bound from a
+	// multipart file part (accept_multipart), the part's filename is stored in
+	// this string field.
+	//
+	// optional string multipart_filename = 50024;
+	E_MultipartFilename = &file_sebuf_http_annotations_proto_extTypes[21]
+	// Mark the bytes field of a response message as the raw response body.
+	// Servers write the field's bytes as the body instead of encoding the
+	// message, clients return them as is, and OpenAPI documents the response as
+	// application/octet-stream. The message may only hold this field and a
+	// string field marked raw_body_content_type. Not valid on the responses of
+	// stream or stream_response methods.
+	//
+	// optional bool raw_body = 50034;
+	E_RawBody = &file_sebuf_http_annotations_proto_extTypes[22]
+	// Mark the string field holding the Content-Type of a raw_body response.
+	// Servers send application/octet-stream when it is empty or absent.
+	//
+	// optional bool raw_body_content_type = 50035;
+	E_RawBodyContentType = &file_sebuf_http_annotations_proto_extTypes[23]
+)
+
+// Extension fields to descriptorpb.MessageOptions.
+var (
+	// Marks the message as an outbound webhook payload.
+	//
+	// optional sebuf.http.WebhookConfig webhook = 50023;
+	E_Webhook = &file_sebuf_http_annotations_proto_extTypes[24]
+	// JSON keys of the message's fields. Overrides the file's file_json_naming.
+	// Applies to the message's own fields, not to those of nested messages.
+	//
+	// optional sebuf.http.JsonNaming json_naming = 50025;
+	E_JsonNaming = &file_sebuf_http_annotations_proto_extTypes[25]
+	// Encodings of the message's fields that do not set their own. Overrides the
+	// file's file_encoding_defaults, one encoding at a time. Applies to the
+	// message's own fields, not to those of nested messages.
+	//
+	// optional sebuf.http.EncodingDefaults encoding_defaults = 50030;
+	E_EncodingDefaults = &file_sebuf_http_annotations_proto_extTypes[26]
+	// Rejects JSON input naming a field by its alternate name: the proto name
+	// when the JSON key is the lowerCamelCase name or a json_name, and the
+	// lowerCamelCase name or json_name when the JSON key is the proto name.
+	// Generated unmarshalers accept both by default, as protojson does.
+	// Overrides the file's file_reject_alternate_names.
+	//
+	// optional bool reject_alternate_names = 50032;
+	E_RejectAlternateNames = &file_sebuf_http_annotations_proto_extTypes[27]
+)
+
+// Extension fields to descriptorpb.FileOptions.
+var (
+	// JSON keys of the fields of every message in the file that does not set
+	// json_naming itself.
+	//
+	// optional sebuf.http.JsonNaming file_json_naming = 50026;
+	E_FileJsonNaming = &file_sebuf_http_annotations_proto_extTypes[28]
+	// Encodings of the fields of every message in the file that neither the
+	// field nor its message's encoding_defaults set.
+	//
+	// optional sebuf.http.EncodingDefaults file_encoding_defaults = 50031;
+	E_FileEncodingDefaults = &file_sebuf_http_annotations_proto_extTypes[29]
+	// Rejects alternate field names in the JSON input of every message in the
+	// file that does not set reject_alternate_names itself.
+	//
+	// optional bool file_reject_alternate_names = 50033;
+	E_FileRejectAlternateNames = &file_sebuf_http_annotations_proto_extTypes[30]
 )
 
 // Extension fields to descriptorpb.EnumValueOptions.
@@ -879,7 +2215,7 @@ var (
 	// Combines with enum_encoding=STRING on fields using this enum.
 	//
 	// optional string enum_value = 50012;
-	E_EnumValue = &file_sebuf_http_annotations_proto_extTypes[15]
+	E_EnumValue = &file_sebuf_http_annotations_proto_extTypes[31]
 )
 
 var File_sebuf_http_annotations_proto protoreflect.FileDescriptor
@@ -887,22 +2223,88 @@ var File_sebuf_http_annotations_proto protoreflect.FileDescriptor
 const file_sebuf_http_annotations_proto_rawDesc = "" +
 	"\n" +
 	"\x1csebuf/http/annotations.proto\x12\n" +
-	"sebuf.http\x1a google/protobuf/descriptor.proto\"h\n" +
+	"sebuf.http\x1a google/protobuf/descriptor.proto\"\x89\x05\n" +
 	"\n" +
 	"HttpConfig\x12\x12\n" +
 	"\x04path\x18\x01 \x01(\tR\x04path\x12.\n" +
 	"\x06method\x18\x02 \x01(\x0e2\x16.sebuf.http.HttpMethodR\x06method\x12\x16\n" +
-	"\x06stream\x18\x03 \x01(\bR\x06stream\",\n" +
+	"\x06stream\x18\x03 \x01(\bR\x06stream\x12 \n" +
+	"\vidempotency\x18\x04 \x01(\bR\vidempotency\x12-\n" +
+	"\x05cache\x18\x05 \x01(\v2\x17.sebuf.http.CacheConfigR\x05cache\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x06 \x01(\x05R\ttimeoutMs\x12\x1f\n" +
+	"\vaccept_form\x18\a \x01(\bR\n" +
+	"acceptForm\x12)\n" +
+	"\x10accept_multipart\x18\b \x01(\bR\x0facceptMultipart\x12\x1f\n" +
+	"\vstrict_json\x18\t \x01(\bR\n" +
+	"strictJson\x12'\n" +
+	"\x0fstream_response\x18\n" +
+	" \x01(\bR\x0estreamResponse\x12:\n" +
+	"\fpatch_format\x18\v \x01(\x0e2\x17.sebuf.http.PatchFormatR\vpatchFormat\x12S\n" +
+	"\x0ferror_responses\x18\f \x03(\v2*.sebuf.http.HttpConfig.ErrorResponsesEntryR\x0eerrorResponses\x12E\n" +
+	"\x10response_headers\x18\r \x03(\v2\x1a.sebuf.http.ResponseHeaderR\x0fresponseHeaders\x1aA\n" +
+	"\x13ErrorResponsesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\":\n" +
+	"\x0eResponseHeader\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"M\n" +
+	"\vCacheConfig\x12&\n" +
+	"\x0fmax_age_seconds\x18\x01 \x01(\x05R\rmaxAgeSeconds\x12\x16\n" +
+	"\x06public\x18\x02 \x01(\bR\x06public\"\xc9\x02\n" +
 	"\rServiceConfig\x12\x1b\n" +
-	"\tbase_path\x18\x01 \x01(\tR\bbasePath\"'\n" +
+	"\tbase_path\x18\x01 \x01(\tR\bbasePath\x122\n" +
+	"\bversions\x18\x02 \x03(\v2\x16.sebuf.http.ApiVersionR\bversions\x12\x1f\n" +
+	"\vstrict_json\x18\x03 \x01(\bR\n" +
+	"strictJson\x12C\n" +
+	"\x10base_path_params\x18\x04 \x03(\v2\x19.sebuf.http.BasePathParamR\x0ebasePathParams\x12:\n" +
+	"\ferror_format\x18\x05 \x01(\x0e2\x17.sebuf.http.ErrorFormatR\verrorFormat\x12E\n" +
+	"\x10response_headers\x18\x06 \x03(\v2\x1a.sebuf.http.ResponseHeaderR\x0fresponseHeaders\"F\n" +
+	"\rBasePathParam\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fcontext_only\x18\x02 \x01(\bR\vcontextOnly\"u\n" +
+	"\n" +
+	"ApiVersion\x12\x1b\n" +
+	"\tbase_path\x18\x01 \x01(\tR\bbasePath\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"deprecated\x18\x03 \x01(\bR\n" +
+	"deprecated\x12\x16\n" +
+	"\x06sunset\x18\x04 \x01(\tR\x06sunset\"I\n" +
+	"\n" +
+	"Visibility\x12\x18\n" +
+	"\aexclude\x18\x01 \x03(\tR\aexclude\x12!\n" +
+	"\finclude_only\x18\x02 \x03(\tR\vincludeOnly\"s\n" +
+	"\rMethodExample\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\asummary\x18\x02 \x01(\tR\asummary\x12\x18\n" +
+	"\arequest\x18\x03 \x01(\tR\arequest\x12\x1a\n" +
+	"\bresponse\x18\x04 \x01(\tR\bresponse\"'\n" +
 	"\rFieldExamples\x12\x16\n" +
-	"\x06values\x18\x01 \x03(\tR\x06values\"=\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\xab\x01\n" +
 	"\vQueryConfig\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
-	"\brequired\x18\x02 \x01(\bR\brequired\"M\n" +
+	"\brequired\x18\x02 \x01(\bR\brequired\x125\n" +
+	"\bencoding\x18\x03 \x01(\x0e2\x19.sebuf.http.QueryEncodingR\bencoding\x12\x1b\n" +
+	"\tmax_bytes\x18\x04 \x01(\rR\bmaxBytes\x12\x18\n" +
+	"\aaliases\x18\x05 \x03(\tR\aaliases\"\x9d\x02\n" +
+	"\x10EncodingDefaults\x12@\n" +
+	"\x0eint64_encoding\x18\x01 \x01(\x0e2\x19.sebuf.http.Int64EncodingR\rint64Encoding\x12=\n" +
+	"\renum_encoding\x18\x02 \x01(\x0e2\x18.sebuf.http.EnumEncodingR\fenumEncoding\x12F\n" +
+	"\x10timestamp_format\x18\x03 \x01(\x0e2\x1b.sebuf.http.TimestampFormatR\x0ftimestampFormat\x12@\n" +
+	"\x0ebytes_encoding\x18\x04 \x01(\x0e2\x19.sebuf.http.BytesEncodingR\rbytesEncoding\"M\n" +
 	"\vOneofConfig\x12$\n" +
 	"\rdiscriminator\x18\x01 \x01(\tR\rdiscriminator\x12\x18\n" +
-	"\aflatten\x18\x02 \x01(\bR\aflatten*\x98\x01\n" +
+	"\aflatten\x18\x02 \x01(\bR\aflatten\"\x97\x01\n" +
+	"\x10ResponseStatuses\x12F\n" +
+	"\bstatuses\x18\x01 \x03(\v2*.sebuf.http.ResponseStatuses.StatusesEntryR\bstatuses\x1a;\n" +
+	"\rStatusesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\x93\x01\n" +
+	"\rWebhookConfig\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12)\n" +
+	"\x10signature_header\x18\x02 \x01(\tR\x0fsignatureHeader\x12C\n" +
+	"\talgorithm\x18\x03 \x01(\x0e2%.sebuf.http.WebhookSignatureAlgorithmR\talgorithm*\x98\x01\n" +
 	"\n" +
 	"HttpMethod\x12\x1b\n" +
 	"\x17HTTP_METHOD_UNSPECIFIED\x10\x00\x12\x13\n" +
@@ -910,7 +2312,22 @@ const file_sebuf_http_annotations_proto_rawDesc = "" +
 	"\x10HTTP_METHOD_POST\x10\x02\x12\x13\n" +
 	"\x0fHTTP_METHOD_PUT\x10\x03\x12\x16\n" +
 	"\x12HTTP_METHOD_DELETE\x10\x04\x12\x15\n" +
-	"\x11HTTP_METHOD_PATCH\x10\x05*e\n" +
+	"\x11HTTP_METHOD_PATCH\x10\x05*I\n" +
+	"\vPatchFormat\x12\x1c\n" +
+	"\x18PATCH_FORMAT_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18PATCH_FORMAT_MERGE_PATCH\x10\x01*J\n" +
+	"\vErrorFormat\x12\x1c\n" +
+	"\x18ERROR_FORMAT_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19ERROR_FORMAT_PROBLEM_JSON\x10\x01*R\n" +
+	"\rQueryEncoding\x12\x1e\n" +
+	"\x1aQUERY_ENCODING_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dQUERY_ENCODING_JSON_BASE64URL\x10\x01*\x8a\x01\n" +
+	"\vFieldSource\x12\x1c\n" +
+	"\x18FIELD_SOURCE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11FIELD_SOURCE_BODY\x10\x01\x12\x16\n" +
+	"\x12FIELD_SOURCE_QUERY\x10\x02\x12\x15\n" +
+	"\x11FIELD_SOURCE_PATH\x10\x03\x12\x17\n" +
+	"\x13FIELD_SOURCE_HEADER\x10\x04*e\n" +
 	"\rInt64Encoding\x12\x1e\n" +
 	"\x1aINT64_ENCODING_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15INT64_ENCODING_STRING\x10\x01\x12\x19\n" +
@@ -936,25 +2353,53 @@ const file_sebuf_http_annotations_proto_rawDesc = "" +
 	"\x19BYTES_ENCODING_BASE64_RAW\x10\x02\x12\x1c\n" +
 	"\x18BYTES_ENCODING_BASE64URL\x10\x03\x12 \n" +
 	"\x1cBYTES_ENCODING_BASE64URL_RAW\x10\x04\x12\x16\n" +
-	"\x12BYTES_ENCODING_HEX\x10\x05:P\n" +
+	"\x12BYTES_ENCODING_HEX\x10\x05*a\n" +
+	"\n" +
+	"JsonNaming\x12\x1b\n" +
+	"\x17JSON_NAMING_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16JSON_NAMING_CAMEL_CASE\x10\x01\x12\x1a\n" +
+	"\x16JSON_NAMING_SNAKE_CASE\x10\x02*\x98\x01\n" +
+	"\x19WebhookSignatureAlgorithm\x12+\n" +
+	"'WEBHOOK_SIGNATURE_ALGORITHM_UNSPECIFIED\x10\x00\x12&\n" +
+	"\"WEBHOOK_SIGNATURE_ALGORITHM_SHA256\x10\x01\x12&\n" +
+	"\"WEBHOOK_SIGNATURE_ALGORITHM_SHA512\x10\x02:P\n" +
 	"\x06config\x12\x1e.google.protobuf.MethodOptions\x18ӆ\x03 \x01(\v2\x16.sebuf.http.HttpConfigR\x06config:c\n" +
-	"\x0eservice_config\x12\x1f.google.protobuf.ServiceOptions\x18Ԇ\x03 \x01(\v2\x19.sebuf.http.ServiceConfigR\rserviceConfig:^\n" +
-	"\foneof_config\x12\x1d.google.protobuf.OneofOptions\x18\xe1\x86\x03 \x01(\v2\x17.sebuf.http.OneofConfigR\voneofConfig\x88\x01\x01:a\n" +
+	"\x0eservice_config\x12\x1f.google.protobuf.ServiceOptions\x18Ԇ\x03 \x01(\v2\x19.sebuf.http.ServiceConfigR\rserviceConfig:X\n" +
+	"\n" +
+	"visibility\x12\x1e.google.protobuf.MethodOptions\x18\xec\x86\x03 \x01(\v2\x16.sebuf.http.VisibilityR\n" +
+	"visibility:W\n" +
+	"\bexamples\x12\x1e.google.protobuf.MethodOptions\x18\xf4\x86\x03 \x03(\v2\x19.sebuf.http.MethodExampleR\bexamples:h\n" +
+	"\x12service_visibility\x12\x1f.google.protobuf.ServiceOptions\x18\xed\x86\x03 \x01(\v2\x16.sebuf.http.VisibilityR\x11serviceVisibility:[\n" +
+	"\foneof_config\x12\x1d.google.protobuf.OneofOptions\x18\xe1\x86\x03 \x01(\v2\x17.sebuf.http.OneofConfigR\voneofConfig:j\n" +
+	"\x11response_statuses\x12\x1d.google.protobuf.OneofOptions\x18\xeb\x86\x03 \x01(\v2\x1c.sebuf.http.ResponseStatusesR\x10responseStatuses:a\n" +
 	"\x0efield_examples\x12\x1d.google.protobuf.FieldOptions\x18׆\x03 \x01(\v2\x19.sebuf.http.FieldExamplesR\rfieldExamples:N\n" +
 	"\x05query\x12\x1d.google.protobuf.FieldOptions\x18؆\x03 \x01(\v2\x17.sebuf.http.QueryConfigR\x05query:7\n" +
-	"\x06unwrap\x12\x1d.google.protobuf.FieldOptions\x18ن\x03 \x01(\bR\x06unwrap:d\n" +
-	"\x0eint64_encoding\x12\x1d.google.protobuf.FieldOptions\x18چ\x03 \x01(\x0e2\x19.sebuf.http.Int64EncodingR\rint64Encoding\x88\x01\x01:a\n" +
-	"\renum_encoding\x12\x1d.google.protobuf.FieldOptions\x18ۆ\x03 \x01(\x0e2\x18.sebuf.http.EnumEncodingR\fenumEncoding\x88\x01\x01:>\n" +
-	"\bnullable\x12\x1d.google.protobuf.FieldOptions\x18݆\x03 \x01(\bR\bnullable\x88\x01\x01:d\n" +
-	"\x0eempty_behavior\x12\x1d.google.protobuf.FieldOptions\x18ކ\x03 \x01(\x0e2\x19.sebuf.http.EmptyBehaviorR\remptyBehavior\x88\x01\x01:j\n" +
-	"\x10timestamp_format\x12\x1d.google.protobuf.FieldOptions\x18߆\x03 \x01(\x0e2\x1b.sebuf.http.TimestampFormatR\x0ftimestampFormat\x88\x01\x01:d\n" +
-	"\x0ebytes_encoding\x12\x1d.google.protobuf.FieldOptions\x18\xe0\x86\x03 \x01(\x0e2\x19.sebuf.http.BytesEncodingR\rbytesEncoding\x88\x01\x01:C\n" +
+	"\x06unwrap\x12\x1d.google.protobuf.FieldOptions\x18ن\x03 \x01(\bR\x06unwrap:a\n" +
+	"\x0eint64_encoding\x12\x1d.google.protobuf.FieldOptions\x18چ\x03 \x01(\x0e2\x19.sebuf.http.Int64EncodingR\rint64Encoding:^\n" +
+	"\renum_encoding\x12\x1d.google.protobuf.FieldOptions\x18ۆ\x03 \x01(\x0e2\x18.sebuf.http.EnumEncodingR\fenumEncoding:;\n" +
+	"\bnullable\x12\x1d.google.protobuf.FieldOptions\x18݆\x03 \x01(\bR\bnullable:a\n" +
+	"\x0eempty_behavior\x12\x1d.google.protobuf.FieldOptions\x18ކ\x03 \x01(\x0e2\x19.sebuf.http.EmptyBehaviorR\remptyBehavior:g\n" +
+	"\x10timestamp_format\x12\x1d.google.protobuf.FieldOptions\x18߆\x03 \x01(\x0e2\x1b.sebuf.http.TimestampFormatR\x0ftimestampFormat:a\n" +
+	"\x0ebytes_encoding\x12\x1d.google.protobuf.FieldOptions\x18\xe0\x86\x03 \x01(\x0e2\x19.sebuf.http.BytesEncodingR\rbytesEncoding:@\n" +
 	"\voneof_value\x12\x1d.google.protobuf.FieldOptions\x18\xe2\x86\x03 \x01(\tR\n" +
-	"oneofValue\x88\x01\x01:<\n" +
-	"\aflatten\x12\x1d.google.protobuf.FieldOptions\x18\xe3\x86\x03 \x01(\bR\aflatten\x88\x01\x01:I\n" +
-	"\x0eflatten_prefix\x12\x1d.google.protobuf.FieldOptions\x18\xe4\x86\x03 \x01(\tR\rflattenPrefix\x88\x01\x01:E\n" +
+	"oneofValue:9\n" +
+	"\aflatten\x12\x1d.google.protobuf.FieldOptions\x18\xe3\x86\x03 \x01(\bR\aflatten:F\n" +
+	"\x0eflatten_prefix\x12\x1d.google.protobuf.FieldOptions\x18\xe4\x86\x03 \x01(\tR\rflattenPrefix:P\n" +
+	"\x06source\x12\x1d.google.protobuf.FieldOptions\x18\xe5\x86\x03 \x01(\x0e2\x17.sebuf.http.FieldSourceR\x06source:=\n" +
+	"\tsensitive\x12\x1d.google.protobuf.FieldOptions\x18\xe6\x86\x03 \x01(\bR\tsensitive:N\n" +
+	"\x12multipart_filename\x12\x1d.google.protobuf.FieldOptions\x18\xe8\x86\x03 \x01(\tR\x11multipartFilename::\n" +
+	"\braw_body\x12\x1d.google.protobuf.FieldOptions\x18\xf2\x86\x03 \x01(\bR\arawBody:R\n" +
+	"\x15raw_body_content_type\x12\x1d.google.protobuf.FieldOptions\x18\xf3\x86\x03 \x01(\bR\x12rawBodyContentType:V\n" +
+	"\awebhook\x12\x1f.google.protobuf.MessageOptions\x18\xe7\x86\x03 \x01(\v2\x19.sebuf.http.WebhookConfigR\awebhook:Z\n" +
+	"\vjson_naming\x12\x1f.google.protobuf.MessageOptions\x18\xe9\x86\x03 \x01(\x0e2\x16.sebuf.http.JsonNamingR\n" +
+	"jsonNaming:l\n" +
+	"\x11encoding_defaults\x12\x1f.google.protobuf.MessageOptions\x18\xee\x86\x03 \x01(\v2\x1c.sebuf.http.EncodingDefaultsR\x10encodingDefaults:W\n" +
+	"\x16reject_alternate_names\x12\x1f.google.protobuf.MessageOptions\x18\xf0\x86\x03 \x01(\bR\x14rejectAlternateNames:`\n" +
+	"\x10file_json_naming\x12\x1c.google.protobuf.FileOptions\x18\xea\x86\x03 \x01(\x0e2\x16.sebuf.http.JsonNamingR\x0efileJsonNaming:r\n" +
+	"\x16file_encoding_defaults\x12\x1c.google.protobuf.FileOptions\x18\xef\x86\x03 \x01(\v2\x1c.sebuf.http.EncodingDefaultsR\x14fileEncodingDefaults:]\n" +
+	"\x1bfile_reject_alternate_names\x12\x1c.google.protobuf.FileOptions\x18\xf1\x86\x03 \x01(\bR\x18fileRejectAlternateNames:B\n" +
 	"\n" +
-	"enum_value\x12!.google.protobuf.EnumValueOptions\x18܆\x03 \x01(\tR\tenumValue\x88\x01\x01B+Z)github.com/SebastienMelki/sebuf/http;httpb\x06proto3"
+	"enum_value\x12!.google.protobuf.EnumValueOptions\x18܆\x03 \x01(\tR\tenumValueB+Z)github.com/SebastienMelki/sebuf/http;httpb\x06proto3"
 
 var (
 	file_sebuf_http_annotations_proto_rawDescOnce sync.Once
@@ -968,59 +2413,119 @@ func file_sebuf_http_annotations_proto_rawDescGZIP() []byte {
 	return file_sebuf_http_annotations_proto_rawDescData
 }
 
-var file_sebuf_http_annotations_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
-var file_sebuf_http_annotations_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_sebuf_http_annotations_proto_enumTypes = make([]protoimpl.EnumInfo, 12)
+var file_sebuf_http_annotations_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_sebuf_http_annotations_proto_goTypes = []any{
 	(HttpMethod)(0),                       // 0: sebuf.http.HttpMethod
-	(Int64Encoding)(0),                    // 1: sebuf.http.Int64Encoding
-	(EnumEncoding)(0),                     // 2: sebuf.http.EnumEncoding
-	(EmptyBehavior)(0),                    // 3: sebuf.http.EmptyBehavior
-	(TimestampFormat)(0),                  // 4: sebuf.http.TimestampFormat
-	(BytesEncoding)(0),                    // 5: sebuf.http.BytesEncoding
-	(*HttpConfig)(nil),                    // 6: sebuf.http.HttpConfig
-	(*ServiceConfig)(nil),                 // 7: sebuf.http.ServiceConfig
-	(*FieldExamples)(nil),                 // 8: sebuf.http.FieldExamples
-	(*QueryConfig)(nil),                   // 9: sebuf.http.QueryConfig
-	(*OneofConfig)(nil),                   // 10: sebuf.http.OneofConfig
-	(*descriptorpb.MethodOptions)(nil),    // 11: google.protobuf.MethodOptions
-	(*descriptorpb.ServiceOptions)(nil),   // 12: google.protobuf.ServiceOptions
-	(*descriptorpb.OneofOptions)(nil),     // 13: google.protobuf.OneofOptions
-	(*descriptorpb.FieldOptions)(nil),     // 14: google.protobuf.FieldOptions
-	(*descriptorpb.EnumValueOptions)(nil), // 15: google.protobuf.EnumValueOptions
+	(PatchFormat)(0),                      // 1: sebuf.http.PatchFormat
+	(ErrorFormat)(0),                      // 2: sebuf.http.ErrorFormat
+	(QueryEncoding)(0),                    // 3: sebuf.http.QueryEncoding
+	(FieldSource)(0),                      // 4: sebuf.http.FieldSource
+	(Int64Encoding)(0),                    // 5: sebuf.http.Int64Encoding
+	(EnumEncoding)(0),                     // 6: sebuf.http.EnumEncoding
+	(EmptyBehavior)(0),                    // 7: sebuf.http.EmptyBehavior
+	(TimestampFormat)(0),                  // 8: sebuf.http.TimestampFormat
+	(BytesEncoding)(0),                    // 9: sebuf.http.BytesEncoding
+	(JsonNaming)(0),                       // 10: sebuf.http.JsonNaming
+	(WebhookSignatureAlgorithm)(0),        // 11: sebuf.http.WebhookSignatureAlgorithm
+	(*HttpConfig)(nil),                    // 12: sebuf.http.HttpConfig
+	(*ResponseHeader)(nil),                // 13: sebuf.http.ResponseHeader
+	(*CacheConfig)(nil),                   // 14: sebuf.http.CacheConfig
+	(*ServiceConfig)(nil),                 // 15: sebuf.http.ServiceConfig
+	(*BasePathParam)(nil),                 // 16: sebuf.http.BasePathParam
+	(*ApiVersion)(nil),                    // 17: sebuf.http.ApiVersion
+	(*Visibility)(nil),                    // 18: sebuf.http.Visibility
+	(*MethodExample)(nil),                 // 19: sebuf.http.MethodExample
+	(*FieldExamples)(nil),                 // 20: sebuf.http.FieldExamples
+	(*QueryConfig)(nil),                   // 21: sebuf.http.QueryConfig
+	(*EncodingDefaults)(nil),              // 22: sebuf.http.EncodingDefaults
+	(*OneofConfig)(nil),                   // 23: sebuf.http.OneofConfig
+	(*ResponseStatuses)(nil),              // 24: sebuf.http.ResponseStatuses
+	(*WebhookConfig)(nil),                 // 25: sebuf.http.WebhookConfig
+	nil,                                   // 26: sebuf.http.HttpConfig.ErrorResponsesEntry
+	nil,                                   // 27: sebuf.http.ResponseStatuses.StatusesEntry
+	(*descriptorpb.MethodOptions)(nil),    // 28: google.protobuf.MethodOptions
+	(*descriptorpb.ServiceOptions)(nil),   // 29: google.protobuf.ServiceOptions
+	(*descriptorpb.OneofOptions)(nil),     // 30: google.protobuf.OneofOptions
+	(*descriptorpb.FieldOptions)(nil),     // 31: google.protobuf.FieldOptions
+	(*descriptorpb.MessageOptions)(nil),   // 32: google.protobuf.MessageOptions
+	(*descriptorpb.FileOptions)(nil),      // 33: google.protobuf.FileOptions
+	(*descriptorpb.EnumValueOptions)(nil), // 34: google.protobuf.EnumValueOptions
 }
 var file_sebuf_http_annotations_proto_depIdxs = []int32{
 	0,  // 0: sebuf.http.HttpConfig.method:type_name -> sebuf.http.HttpMethod
-	11, // 1: sebuf.http.config:extendee -> google.protobuf.MethodOptions
-	12, // 2: sebuf.http.service_config:extendee -> google.protobuf.ServiceOptions
-	13, // 3: sebuf.http.oneof_config:extendee -> google.protobuf.OneofOptions
-	14, // 4: sebuf.http.field_examples:extendee -> google.protobuf.FieldOptions
-	14, // 5: sebuf.http.query:extendee -> google.protobuf.FieldOptions
-	14, // 6: sebuf.http.unwrap:extendee -> google.protobuf.FieldOptions
-	14, // 7: sebuf.http.int64_encoding:extendee -> google.protobuf.FieldOptions
-	14, // 8: sebuf.http.enum_encoding:extendee -> google.protobuf.FieldOptions
-	14, // 9: sebuf.http.nullable:extendee -> google.protobuf.FieldOptions
-	14, // 10: sebuf.http.empty_behavior:extendee -> google.protobuf.FieldOptions
-	14, // 11: sebuf.http.timestamp_format:extendee -> google.protobuf.FieldOptions
-	14, // 12: sebuf.http.bytes_encoding:extendee -> google.protobuf.FieldOptions
-	14, // 13: sebuf.http.oneof_value:extendee -> google.protobuf.FieldOptions
-	14, // 14: sebuf.http.flatten:extendee -> google.protobuf.FieldOptions
-	14, // 15: sebuf.http.flatten_prefix:extendee -> google.protobuf.FieldOptions
-	15, // 16: sebuf.http.enum_value:extendee -> google.protobuf.EnumValueOptions
-	6,  // 17: sebuf.http.config:type_name -> sebuf.http.HttpConfig
-	7,  // 18: sebuf.http.service_config:type_name -> sebuf.http.ServiceConfig
-	10, // 19: sebuf.http.oneof_config:type_name -> sebuf.http.OneofConfig
-	8,  // 20: sebuf.http.field_examples:type_name -> sebuf.http.FieldExamples
-	9,  // 21: sebuf.http.query:type_name -> sebuf.http.QueryConfig
-	1,  // 22: sebuf.http.int64_encoding:type_name -> sebuf.http.Int64Encoding
-	2,  // 23: sebuf.http.enum_encoding:type_name -> sebuf.http.EnumEncoding
-	3,  // 24: sebuf.http.empty_behavior:type_name -> sebuf.http.EmptyBehavior
-	4,  // 25: sebuf.http.timestamp_format:type_name -> sebuf.http.TimestampFormat
-	5,  // 26: sebuf.http.bytes_encoding:type_name -> sebuf.http.BytesEncoding
-	27, // [27:27] is the sub-list for method output_type
-	27, // [27:27] is the sub-list for method input_type
-	17, // [17:27] is the sub-list for extension type_name
-	1,  // [1:17] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
+	14, // 1: sebuf.http.HttpConfig.cache:type_name -> sebuf.http.CacheConfig
+	1,  // 2: sebuf.http.HttpConfig.patch_format:type_name -> sebuf.http.PatchFormat
+	26, // 3: sebuf.http.HttpConfig.error_responses:type_name -> sebuf.http.HttpConfig.ErrorResponsesEntry
+	13, // 4: sebuf.http.HttpConfig.response_headers:type_name -> sebuf.http.ResponseHeader
+	17, // 5: sebuf.http.ServiceConfig.versions:type_name -> sebuf.http.ApiVersion
+	16, // 6: sebuf.http.ServiceConfig.base_path_params:type_name -> sebuf.http.BasePathParam
+	2,  // 7: sebuf.http.ServiceConfig.error_format:type_name -> sebuf.http.ErrorFormat
+	13, // 8: sebuf.http.ServiceConfig.response_headers:type_name -> sebuf.http.ResponseHeader
+	3,  // 9: sebuf.http.QueryConfig.encoding:type_name -> sebuf.http.QueryEncoding
+	5,  // 10: sebuf.http.EncodingDefaults.int64_encoding:type_name -> sebuf.http.Int64Encoding
+	6,  // 11: sebuf.http.EncodingDefaults.enum_encoding:type_name -> sebuf.http.EnumEncoding
+	8,  // 12: sebuf.http.EncodingDefaults.timestamp_format:type_name -> sebuf.http.TimestampFormat
+	9,  // 13: sebuf.http.EncodingDefaults.bytes_encoding:type_name -> sebuf.http.BytesEncoding
+	27, // 14: sebuf.http.ResponseStatuses.statuses:type_name -> sebuf.http.ResponseStatuses.StatusesEntry
+	11, // 15: sebuf.http.WebhookConfig.algorithm:type_name -> sebuf.http.WebhookSignatureAlgorithm
+	28, // 16: sebuf.http.config:extendee -> google.protobuf.MethodOptions
+	29, // 17: sebuf.http.service_config:extendee -> google.protobuf.ServiceOptions
+	28, // 18: sebuf.http.visibility:extendee -> google.protobuf.MethodOptions
+	28, // 19: sebuf.http.examples:extendee -> google.protobuf.MethodOptions
+	29, // 20: sebuf.http.service_visibility:extendee -> google.protobuf.ServiceOptions
+	30, // 21: sebuf.http.oneof_config:extendee -> google.protobuf.OneofOptions
+	30, // 22: sebuf.http.response_statuses:extendee -> google.protobuf.OneofOptions
+	31, // 23: sebuf.http.field_examples:extendee -> google.protobuf.FieldOptions
+	31, // 24: sebuf.http.query:extendee -> google.protobuf.FieldOptions
+	31, // 25: sebuf.http.unwrap:extendee -> google.protobuf.FieldOptions
+	31, // 26: sebuf.http.int64_encoding:extendee -> google.protobuf.FieldOptions
+	31, // 27: sebuf.http.enum_encoding:extendee -> google.protobuf.FieldOptions
+	31, // 28: sebuf.http.nullable:extendee -> google.protobuf.FieldOptions
+	31, // 29: sebuf.http.empty_behavior:extendee -> google.protobuf.FieldOptions
+	31, // 30: sebuf.http.timestamp_format:extendee -> google.protobuf.FieldOptions
+	31, // 31: sebuf.http.bytes_encoding:extendee -> google.protobuf.FieldOptions
+	31, // 32: sebuf.http.oneof_value:extendee -> google.protobuf.FieldOptions
+	31, // 33: sebuf.http.flatten:extendee -> google.protobuf.FieldOptions
+	31, // 34: sebuf.http.flatten_prefix:extendee -> google.protobuf.FieldOptions
+	31, // 35: sebuf.http.source:extendee -> google.protobuf.FieldOptions
+	31, // 36: sebuf.http.sensitive:extendee -> google.protobuf.FieldOptions
+	31, // 37: sebuf.http.multipart_filename:extendee -> google.protobuf.FieldOptions
+	31, // 38: sebuf.http.raw_body:extendee -> google.protobuf.FieldOptions
+	31, // 39: sebuf.http.raw_body_content_type:extendee -> google.protobuf.FieldOptions
+	32, // 40: sebuf.http.webhook:extendee -> google.protobuf.MessageOptions
+	32, // 41: sebuf.http.json_naming:extendee -> google.protobuf.MessageOptions
+	32, // 42: sebuf.http.encoding_defaults:extendee -> google.protobuf.MessageOptions
+	32, // 43: sebuf.http.reject_alternate_names:extendee -> google.protobuf.MessageOptions
+	33, // 44: sebuf.http.file_json_naming:extendee -> google.protobuf.FileOptions
+	33, // 45: sebuf.http.file_encoding_defaults:extendee -> google.protobuf.FileOptions
+	33, // 46: sebuf.http.file_reject_alternate_names:extendee -> google.protobuf.FileOptions
+	34, // 47: sebuf.http.enum_value:extendee -> google.protobuf.EnumValueOptions
+	12, // 48: sebuf.http.config:type_name -> sebuf.http.HttpConfig
+	15, // 49: sebuf.http.service_config:type_name -> sebuf.http.ServiceConfig
+	18, // 50: sebuf.http.visibility:type_name -> sebuf.http.Visibility
+	19, // 51: sebuf.http.examples:type_name -> sebuf.http.MethodExample
+	18, // 52: sebuf.http.service_visibility:type_name -> sebuf.http.Visibility
+	23, // 53: sebuf.http.oneof_config:type_name -> sebuf.http.OneofConfig
+	24, // 54: sebuf.http.response_statuses:type_name -> sebuf.http.ResponseStatuses
+	20, // 55: sebuf.http.field_examples:type_name -> sebuf.http.FieldExamples
+	21, // 56: sebuf.http.query:type_name -> sebuf.http.QueryConfig
+	5,  // 57: sebuf.http.int64_encoding:type_name -> sebuf.http.Int64Encoding
+	6,  // 58: sebuf.http.enum_encoding:type_name -> sebuf.http.EnumEncoding
+	7,  // 59: sebuf.http.empty_behavior:type_name -> sebuf.http.EmptyBehavior
+	8,  // 60: sebuf.http.timestamp_format:type_name -> sebuf.http.TimestampFormat
+	9,  // 61: sebuf.http.bytes_encoding:type_name -> sebuf.http.BytesEncoding
+	4,  // 62: sebuf.http.source:type_name -> sebuf.http.FieldSource
+	25, // 63: sebuf.http.webhook:type_name -> sebuf.http.WebhookConfig
+	10, // 64: sebuf.http.json_naming:type_name -> sebuf.http.JsonNaming
+	22, // 65: sebuf.http.encoding_defaults:type_name -> sebuf.http.EncodingDefaults
+	10, // 66: sebuf.http.file_json_naming:type_name -> sebuf.http.JsonNaming
+	22, // 67: sebuf.http.file_encoding_defaults:type_name -> sebuf.http.EncodingDefaults
+	68, // [68:68] is the sub-list for method output_type
+	68, // [68:68] is the sub-list for method input_type
+	48, // [48:68] is the sub-list for extension type_name
+	16, // [16:48] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_sebuf_http_annotations_proto_init() }
@@ -1033,9 +2538,9 @@ func file_sebuf_http_annotations_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sebuf_http_annotations_proto_rawDesc), len(file_sebuf_http_annotations_proto_rawDesc)),
-			NumEnums:      6,
-			NumMessages:   5,
-			NumExtensions: 16,
+			NumEnums:      12,
+			NumMessages:   16,
+			NumExtensions: 32,
 			NumServices:   0,
 		},
 		GoTypes:           file_sebuf_http_annotations_proto_goTypes,
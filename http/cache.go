@@ -0,0 +1,240 @@
+package http
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	nethttp "net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachePolicy describes how ResponseCacheMiddleware caches a method's
+// successful responses. Generated servers build one from the method's cache
+// annotation.
+type CachePolicy struct {
+	// MaxAge is emitted as the Cache-Control max-age and bounds how long a
+	// ResponseCache keeps the response.
+	MaxAge time.Duration
+	// Public marks the response cacheable by shared caches; otherwise it is
+	// marked private.
+	Public bool
+	// VaryHeaders lists the request headers whose values select a different
+	// cached response. Accept and Content-Type, which choose the response
+	// encoding, always do.
+	VaryHeaders []string
+}
+
+// CacheControl returns the Cache-Control header value for p.
+func (p CachePolicy) CacheControl() string {
+	visibility := "private"
+	if p.Public {
+		visibility = "public"
+	}
+	return fmt.Sprintf("%s, max-age=%d", visibility, int64(p.MaxAge/time.Second))
+}
+
+// ResponseCache is an in-process LRU cache of marshaled responses, bounded by
+// the number of entries. It is safe for concurrent use.
+type ResponseCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List // Most recently used at the front
+}
+
+type responseCacheEntry struct {
+	key     string
+	status  int
+	header  nethttp.Header
+	body    []byte
+	stored  time.Time
+	expires time.Time
+}
+
+// NewResponseCache returns an empty ResponseCache holding at most size
+// responses. A size < 1 is treated as 1.
+func NewResponseCache(size int) *ResponseCache {
+	return &ResponseCache{
+		size:    max(size, 1),
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// Len returns the number of responses currently held, including expired ones
+// that have not been evicted yet.
+func (c *ResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *ResponseCache) get(key string, now time.Time) (*responseCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry, _ := elem.Value.(*responseCacheEntry)
+	if !now.Before(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry, true
+}
+
+func (c *ResponseCache) add(entry *responseCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[entry.key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[entry.key] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		if evicted, ok := oldest.Value.(*responseCacheEntry); ok {
+			delete(c.entries, evicted.key)
+		}
+	}
+}
+
+// ResponseCacheMiddleware sets the Cache-Control header described by policy on
+// successful (2xx) responses of next. Generated servers wrap the service call of
+// every method annotated with cache in it, inside request binding, so cache
+// hits still run header and request validation.
+//
+// When cache is non-nil, successful responses are also kept in it for
+// policy.MaxAge, keyed by scope (generated servers pass the RPC's full name),
+// the request URL, the Accept, Content-Type, and policy.VaryHeaders request
+// headers, and the caller: the principal in the request context and the
+// Authorization and Cookie headers. A request matching a kept response is answered from the cache, with
+// an Age header, without calling next.
+func ResponseCacheMiddleware(
+	next nethttp.Handler,
+	cache *ResponseCache,
+	scope string,
+	policy CachePolicy,
+) nethttp.Handler {
+	cacheControl := policy.CacheControl()
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if cache == nil || policy.MaxAge <= 0 {
+			next.ServeHTTP(&cachingResponseWriter{ResponseWriter: w, cacheControl: cacheControl}, r)
+			return
+		}
+
+		key := responseCacheKey(scope, r, policy.VaryHeaders)
+		now := time.Now()
+		if entry, ok := cache.get(key, now); ok {
+			header := w.Header()
+			for name, values := range entry.header {
+				header[name] = values
+			}
+			header.Set("Age", strconv.FormatInt(int64(now.Sub(entry.stored)/time.Second), 10))
+			w.WriteHeader(entry.status)
+			_, _ = w.Write(entry.body)
+			return
+		}
+
+		recorder := &cachingResponseWriter{ResponseWriter: w, cacheControl: cacheControl, capture: true}
+		next.ServeHTTP(recorder, r)
+		if !isSuccessStatus(recorder.status) {
+			return
+		}
+		cache.add(&responseCacheEntry{
+			key:     key,
+			status:  recorder.status,
+			header:  recorder.header,
+			body:    recorder.body.Bytes(),
+			stored:  now,
+			expires: now.Add(policy.MaxAge),
+		})
+	})
+}
+
+// responseCacheKey identifies the response to r among those kept for scope.
+// Responses are kept per caller: the key covers a hash of the principal an
+// authenticator established (see ContextWithPrincipal) and of the
+// Authorization and Cookie headers, so one caller is never answered with
+// another's response.
+func responseCacheKey(scope string, r *nethttp.Request, varyHeaders []string) string {
+	var b strings.Builder
+	b.WriteString(scope)
+	b.WriteByte('\n')
+	b.WriteString(r.Host)
+	b.WriteString(r.URL.RequestURI())
+	for _, name := range append([]string{"Accept", "Content-Type"}, varyHeaders...) {
+		b.WriteByte('\n')
+		b.WriteString(strings.Join(r.Header.Values(name), ","))
+	}
+	b.WriteByte('\n')
+	b.WriteString(responseCacheCaller(r))
+	return b.String()
+}
+
+// responseCacheCaller returns a hash of the identity and credentials of the
+// caller of r.
+func responseCacheCaller(r *nethttp.Request) string {
+	h := sha256.New()
+	if principal := r.Context().Value(principalCtxKey{}); principal != nil {
+		fmt.Fprintf(h, "%T:%v", principal, principal)
+	}
+	for _, name := range []string{"Authorization", "Cookie"} {
+		h.Write([]byte{0})
+		h.Write([]byte(strings.Join(r.Header.Values(name), "\n")))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func isSuccessStatus(status int) bool {
+	return status >= nethttp.StatusOK && status < nethttp.StatusMultipleChoices
+}
+
+// cachingResponseWriter adds Cache-Control to successful responses and, when
+// capture is set, keeps a copy of them for a ResponseCache.
+type cachingResponseWriter struct {
+	nethttp.ResponseWriter
+	cacheControl string
+	capture      bool
+	status       int
+	header       nethttp.Header
+	body         bytes.Buffer
+}
+
+func (cw *cachingResponseWriter) WriteHeader(code int) {
+	if cw.status == 0 {
+		cw.status = code
+		if isSuccessStatus(code) {
+			cw.ResponseWriter.Header().Set("Cache-Control", cw.cacheControl)
+		}
+		if cw.capture {
+			cw.header = cw.ResponseWriter.Header().Clone()
+		}
+	}
+	cw.ResponseWriter.WriteHeader(code)
+}
+
+func (cw *cachingResponseWriter) Write(b []byte) (int, error) {
+	if cw.status == 0 {
+		cw.WriteHeader(nethttp.StatusOK)
+	}
+	if cw.capture {
+		cw.body.Write(b)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying ResponseWriter.
+func (cw *cachingResponseWriter) Unwrap() nethttp.ResponseWriter {
+	return cw.ResponseWriter
+}
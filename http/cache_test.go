@@ -0,0 +1,188 @@
+package http_test
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// infoHandler answers with a JSON body and counts its invocations, failing
+// with 404 for the "missing" query.
+func infoHandler(calls *atomic.Int32) nethttp.Handler {
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		n := calls.Add(1)
+		if r.URL.Query().Get("q") == "missing" {
+			w.WriteHeader(nethttp.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"call":` + strconv.Itoa(int(n)) + `}`))
+	})
+}
+
+func get(h nethttp.Handler, target string, header ...string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(nethttp.MethodGet, target, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		r.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+var infoPolicy = http.CachePolicy{MaxAge: time.Minute, Public: true, VaryHeaders: []string{"X-API-Key"}}
+
+func TestCachePolicy_CacheControl(t *testing.T) {
+	if got := infoPolicy.CacheControl(); got != "public, max-age=60" {
+		t.Errorf("public policy = %q", got)
+	}
+	if got := (http.CachePolicy{MaxAge: 90 * time.Second}).CacheControl(); got != "private, max-age=90" {
+		t.Errorf("private policy = %q", got)
+	}
+}
+
+func TestResponseCacheMiddleware_HitSkipsHandler(t *testing.T) {
+	var calls atomic.Int32
+	h := http.ResponseCacheMiddleware(infoHandler(&calls), http.NewResponseCache(8), "svc.GetInfo", infoPolicy)
+
+	first := get(h, "/info?q=a")
+	second := get(h, "/info?q=a")
+
+	if calls.Load() != 1 {
+		t.Fatalf("handler ran %d times, want 1", calls.Load())
+	}
+	if second.Code != nethttp.StatusOK || second.Body.String() != first.Body.String() {
+		t.Errorf("hit = %d %q, want %d %q", second.Code, second.Body.String(), first.Code, first.Body.String())
+	}
+	for name, want := range map[string]string{
+		"Cache-Control": "public, max-age=60",
+		"Content-Type":  "application/json",
+		"Age":           "0",
+	} {
+		if got := second.Header().Get(name); got != want {
+			t.Errorf("hit %s = %q, want %q", name, got, want)
+		}
+	}
+	if first.Header().Get("Age") != "" {
+		t.Error("miss must not carry an Age header")
+	}
+}
+
+func TestResponseCacheMiddleware_KeyedByQueryAndHeaders(t *testing.T) {
+	var calls atomic.Int32
+	h := http.ResponseCacheMiddleware(infoHandler(&calls), http.NewResponseCache(8), "svc.GetInfo", infoPolicy)
+
+	get(h, "/info?q=a")
+	get(h, "/info?q=b")
+	get(h, "/info?q=a", "X-API-Key", "other")
+	get(h, "/info?q=a", "Accept", "application/x-protobuf")
+	if calls.Load() != 4 {
+		t.Fatalf("handler ran %d times, want 4 (every request is a miss)", calls.Load())
+	}
+
+	get(h, "/info?q=a", "X-API-Key", "other")
+	if calls.Load() != 4 {
+		t.Errorf("repeated request with the same vary header should hit, handler ran %d times", calls.Load())
+	}
+}
+
+func TestResponseCacheMiddleware_ErrorsAreNotCached(t *testing.T) {
+	var calls atomic.Int32
+	h := http.ResponseCacheMiddleware(infoHandler(&calls), http.NewResponseCache(8), "svc.GetInfo", infoPolicy)
+
+	w := get(h, "/info?q=missing")
+	get(h, "/info?q=missing")
+
+	if calls.Load() != 2 {
+		t.Errorf("handler ran %d times, want 2", calls.Load())
+	}
+	if w.Header().Get("Cache-Control") != "" {
+		t.Error("error responses must not get Cache-Control")
+	}
+}
+
+func TestResponseCacheMiddleware_WithoutCacheOnlySetsHeader(t *testing.T) {
+	var calls atomic.Int32
+	h := http.ResponseCacheMiddleware(infoHandler(&calls), nil, "svc.GetInfo", infoPolicy)
+
+	get(h, "/info")
+	w := get(h, "/info")
+
+	if calls.Load() != 2 {
+		t.Errorf("handler ran %d times, want 2", calls.Load())
+	}
+	if got := w.Header().Get("Cache-Control"); got != "public, max-age=60" {
+		t.Errorf("Cache-Control = %q", got)
+	}
+}
+
+func TestResponseCacheMiddleware_Expiry(t *testing.T) {
+	var calls atomic.Int32
+	policy := http.CachePolicy{MaxAge: 10 * time.Millisecond}
+	h := http.ResponseCacheMiddleware(infoHandler(&calls), http.NewResponseCache(8), "svc.GetInfo", policy)
+
+	get(h, "/info")
+	time.Sleep(20 * time.Millisecond)
+	get(h, "/info")
+
+	if calls.Load() != 2 {
+		t.Errorf("handler ran %d times, want 2 after expiry", calls.Load())
+	}
+}
+
+func TestResponseCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	var calls atomic.Int32
+	cache := http.NewResponseCache(2)
+	h := http.ResponseCacheMiddleware(infoHandler(&calls), cache, "svc.GetInfo", infoPolicy)
+
+	get(h, "/info?q=a")
+	get(h, "/info?q=b")
+	get(h, "/info?q=a") // a is now more recent than b
+	get(h, "/info?q=c") // evicts b
+	if cache.Len() != 2 {
+		t.Fatalf("Len = %d, want 2", cache.Len())
+	}
+
+	get(h, "/info?q=a")
+	if calls.Load() != 3 {
+		t.Errorf("a should still be cached, handler ran %d times", calls.Load())
+	}
+	get(h, "/info?q=b")
+	if calls.Load() != 4 {
+		t.Errorf("b should have been evicted, handler ran %d times", calls.Load())
+	}
+}
+
+func TestResponseCacheMiddleware_KeyedByCaller(t *testing.T) {
+	var calls atomic.Int32
+	policy := http.CachePolicy{MaxAge: time.Minute}
+	h := http.ResponseCacheMiddleware(infoHandler(&calls), http.NewResponseCache(8), "svc.GetInfo", policy)
+	asPrincipal := func(principal string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(nethttp.MethodGet, "/info", nil)
+		r = r.WithContext(http.ContextWithPrincipal(r.Context(), principal))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	alice := asPrincipal("alice")
+	bob := asPrincipal("bob")
+	if calls.Load() != 2 || bob.Body.String() == alice.Body.String() {
+		t.Fatalf("bob was answered with alice's response %q (handler ran %d times)", bob.Body.String(), calls.Load())
+	}
+	if again := asPrincipal("alice"); calls.Load() != 2 || again.Body.String() != alice.Body.String() {
+		t.Errorf("alice's repeated request should hit, handler ran %d times", calls.Load())
+	}
+
+	get(h, "/info", "Authorization", "Bearer alice")
+	get(h, "/info", "Authorization", "Bearer bob")
+	get(h, "/info", "Cookie", "session=alice")
+	if calls.Load() != 5 {
+		t.Errorf("handler ran %d times, want 5 (requests with other credentials are misses)", calls.Load())
+	}
+}
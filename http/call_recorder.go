@@ -0,0 +1,83 @@
+package http
+
+import (
+	nethttp "net/http"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// CallRecorder records the calls of a generated client, or answers them from
+// earlier recordings without sending them, for integration test fixtures. The
+// github.com/SebastienMelki/sebuf/http/cassette package implements it.
+type CallRecorder interface {
+	// Do sends req with send and records the call, or answers it without
+	// calling send.
+	Do(call ClientCall, req *nethttp.Request, send func() (*nethttp.Response, error)) (*nethttp.Response, error)
+}
+
+// RecordCall sends req through recorder, or with send when recorder is nil.
+func RecordCall(
+	recorder CallRecorder,
+	call ClientCall,
+	req *nethttp.Request,
+	send func() (*nethttp.Response, error),
+) (*nethttp.Response, error) {
+	if recorder == nil {
+		return send()
+	}
+	return recorder.Do(call, req, send)
+}
+
+// RedactHeaders returns a copy of header with the values of credential
+// headers, such as Authorization, and of the sensitive headers redacted.
+func RedactHeaders(header nethttp.Header, sensitive []string) nethttp.Header {
+	redacted := header.Clone()
+	for name, values := range redacted {
+		if !isCredentialHeader(name) && !slices.ContainsFunc(sensitive, func(h string) bool {
+			return strings.EqualFold(h, name)
+		}) {
+			continue
+		}
+		for i := range values {
+			values[i] = RedactedValue
+		}
+	}
+	return redacted
+}
+
+// RedactedRequestJSON returns the message call sends as compact JSON, with its
+// sensitive fields redacted, or nil when the call has no body.
+func RedactedRequestJSON(call ClientCall) ([]byte, error) {
+	if call.Body == nil {
+		return nil, nil
+	}
+	return marshalMessageJSON(Redact(call.Body))
+}
+
+// RedactResponseBody returns body, the body of a response to call, with the
+// sensitive fields of the response message redacted. Only successful JSON and
+// binary protobuf responses decoded into call.Response are redacted, and
+// re-encoded in the same format; other bodies, and those without a sensitive
+// field set, are returned as they are.
+func RedactResponseBody(call ClientCall, resp *nethttp.Response, body []byte) ([]byte, error) {
+	contentType := resp.Header.Get("Content-Type")
+	isJSON := strings.HasPrefix(contentType, JSONContentType)
+	if call.Response == nil || len(body) == 0 || resp.StatusCode >= nethttp.StatusBadRequest ||
+		(!isJSON && !strings.HasPrefix(contentType, ProtoContentType)) {
+		return body, nil
+	}
+	msg := proto.Clone(call.Response)
+	if err := unmarshalMessage(body, msg, isJSON); err != nil {
+		return nil, err
+	}
+	redacted := Redact(msg)
+	if proto.Equal(redacted, msg) {
+		return body, nil
+	}
+	if isJSON {
+		return marshalMessageJSON(redacted)
+	}
+	return proto.Marshal(redacted)
+}
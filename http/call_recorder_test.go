@@ -0,0 +1,84 @@
+package http_test
+
+import (
+	"bytes"
+	nethttp "net/http"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestRedactHeaders(t *testing.T) {
+	header := nethttp.Header{
+		"Authorization": {"Bearer top-secret"},
+		"X-Session":     {"a", "b"},
+		"X-Request-Id":  {"req-1"},
+	}
+	got := http.RedactHeaders(header, []string{"x-session"})
+	want := nethttp.Header{
+		"Authorization": {http.RedactedValue},
+		"X-Session":     {http.RedactedValue, http.RedactedValue},
+		"X-Request-Id":  {"req-1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RedactHeaders = %v, want %v", got, want)
+	}
+	if header.Get("Authorization") != "Bearer top-secret" {
+		t.Error("RedactHeaders modified the header it was given")
+	}
+}
+
+func TestRedactResponseBody(t *testing.T) {
+	_, session := sensitiveMessages(t)
+	call := http.ClientCall{Method: "AuthService.GetSession", Response: dynamicpb.NewMessage(session)}
+	jsonResp := &nethttp.Response{StatusCode: nethttp.StatusOK, Header: nethttp.Header{}}
+	jsonResp.Header.Set("Content-Type", http.JSONContentType)
+
+	got, err := http.RedactResponseBody(call, jsonResp, []byte(`{"token": "tok-123", "user": "ada"}`))
+	if err != nil || string(got) != `{"token":"[REDACTED]","user":"ada"}` {
+		t.Errorf("RedactResponseBody = %s, %v, want the token redacted", got, err)
+	}
+	plain := []byte(`{"user": "ada"}`)
+	if got, err = http.RedactResponseBody(call, jsonResp, plain); err != nil || !bytes.Equal(got, plain) {
+		t.Errorf("RedactResponseBody = %s, %v, want a body without sensitive fields as it is", got, err)
+	}
+
+	binary, err := proto.Marshal(newMessage(session, map[string]string{"token": "tok-123", "user": "ada"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	protoResp := &nethttp.Response{StatusCode: nethttp.StatusOK, Header: nethttp.Header{}}
+	protoResp.Header.Set("Content-Type", http.ProtoContentType)
+	if got, err = http.RedactResponseBody(call, protoResp, binary); err != nil {
+		t.Fatalf("RedactResponseBody: %v", err)
+	}
+	redacted := dynamicpb.NewMessage(session)
+	if err = proto.Unmarshal(got, redacted); err != nil {
+		t.Fatal(err)
+	}
+	if want := newMessage(session, map[string]string{"token": http.RedactedValue, "user": "ada"}); !proto.Equal(
+		redacted, want) {
+		t.Errorf("RedactResponseBody decoded to %v, want %v", redacted, want)
+	}
+
+	errorResp := &nethttp.Response{StatusCode: nethttp.StatusNotFound, Header: jsonResp.Header}
+	body := []byte(`{"message":"tok-123 not found"}`)
+	if got, err = http.RedactResponseBody(call, errorResp, body); err != nil || !bytes.Equal(got, body) {
+		t.Errorf("RedactResponseBody = %s, %v, want an error body as it is", got, err)
+	}
+}
+
+func TestRecordCallWithoutRecorder(t *testing.T) {
+	sent := false
+	_, err := http.RecordCall(nil, http.ClientCall{}, nil, func() (*nethttp.Response, error) {
+		sent = true
+		return &nethttp.Response{}, nil
+	})
+	if err != nil || !sent {
+		t.Errorf("RecordCall without a recorder = %v, sent %v, want the request sent", err, sent)
+	}
+}
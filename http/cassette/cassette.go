@@ -0,0 +1,316 @@
+// Package cassette records the calls of generated Go clients as YAML
+// cassettes, and replays them without the network, so integration tests run
+// against a real backend once and deterministically in CI afterwards:
+//
+//	mode := cassette.Replay
+//	if os.Getenv("RECORD") != "" {
+//		mode = cassette.Record
+//	}
+//	client := api.NewProductServiceClient(baseURL,
+//		api.WithProductServiceRecorder(cassette.New("testdata/cassettes", mode)))
+//
+// Each call is kept in its own file, named after the RPC and a hash of the
+// request: its verb, path and query, headers and body. Volatile headers, such
+// as Date and request IDs, are left out of the hash; IgnoreHeaders adds to
+// them. Sensitive fields and credential headers are redacted before anything
+// is written, so a request differing only in those matches the same cassette.
+// Recording writes a README.md describing the format next to the cassettes.
+package cassette
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	yaml "go.yaml.in/yaml/v4"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+)
+
+// Mode decides whether a Recorder sends calls or replays them.
+type Mode int
+
+const (
+	// Replay answers every call from its cassette without sending it, and
+	// fails calls that have none with a *MissError.
+	Replay Mode = iota
+	// Record sends every call and writes its cassette, replacing an earlier
+	// recording of the same request.
+	Record
+)
+
+// FormatVersion is the version of the cassette format written by Record.
+const FormatVersion = 1
+
+// DefaultVolatileHeaders are the request headers left out of the hash a
+// request is matched by, as they change from one run to the next.
+var DefaultVolatileHeaders = []string{
+	"Date",
+	"Idempotency-Key",
+	"Traceparent",
+	"Tracestate",
+	"User-Agent",
+	"X-Correlation-Id",
+	"X-Request-Id",
+}
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	// Version is FormatVersion when the cassette was written.
+	Version int `yaml:"version"`
+	// Method is the RPC, as "Service.Method".
+	Method   string   `yaml:"method"`
+	Request  Request  `yaml:"request"`
+	Response Response `yaml:"response"`
+}
+
+// Request is the request of a recorded call.
+type Request struct {
+	// Method and URL are the verb and the path and query string, without the
+	// scheme and host, so recordings replay against any base URL.
+	Method string              `yaml:"method"`
+	URL    string              `yaml:"url"`
+	Header map[string][]string `yaml:"headers,omitempty"`
+	// Body is the request message as JSON, whatever the content type sent.
+	Body string `yaml:"body,omitempty"`
+}
+
+// Response is the response of a recorded call.
+type Response struct {
+	Status int                 `yaml:"status"`
+	Header map[string][]string `yaml:"headers,omitempty"`
+	// Body is the response body when it is UTF-8 text, and BodyBase64 holds
+	// it base64-encoded otherwise, as for binary protobuf.
+	Body       string `yaml:"body,omitempty"`
+	BodyBase64 string `yaml:"body_base64,omitempty"`
+}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// IgnoreHeaders leaves the named request headers out of the hash, in addition
+// to DefaultVolatileHeaders.
+func IgnoreHeaders(names ...string) Option {
+	return func(r *Recorder) {
+		r.ignored = append(r.ignored, names...)
+	}
+}
+
+// Recorder records or replays the calls of generated clients configured with
+// it, in the cassettes of a directory. It is safe for concurrent use, and can
+// be shared by the clients of several services.
+type Recorder struct {
+	dir     string
+	mode    Mode
+	ignored []string
+
+	mu     sync.Mutex
+	readme sync.Once
+}
+
+var _ sebufhttp.CallRecorder = (*Recorder)(nil)
+
+// New returns a Recorder keeping its cassettes in dir, which Record creates
+// when missing.
+func New(dir string, mode Mode, opts ...Option) *Recorder {
+	r := &Recorder{dir: dir, mode: mode, ignored: slices.Clone(DefaultVolatileHeaders)}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Do records or replays a call. Responses that the caller reads as they
+// arrive, from SSE and stream_response methods, are not recorded: Record sends
+// those calls without writing a cassette, and Replay fails them.
+func (r *Recorder) Do(
+	call sebufhttp.ClientCall,
+	req *nethttp.Request,
+	send func() (*nethttp.Response, error),
+) (*nethttp.Response, error) {
+	if call.Stream {
+		if r.mode == Record {
+			return send()
+		}
+		return nil, fmt.Errorf("cassette: %s streams its response, which is not recorded", call.Method)
+	}
+	recorded, err := recordRequest(call, req)
+	if err != nil {
+		return nil, err
+	}
+	name := r.FileName(call.Method, recorded)
+	if r.mode == Replay {
+		return r.replay(call.Method, name, recorded, req)
+	}
+
+	resp, err := send()
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("cassette: failed to read response body: %w", err)
+	}
+	if err := r.write(name, call, recorded, resp, body); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FileName returns the name of the cassette of a call to method, the RPC as
+// "Service.Method", with the request req.
+func (r *Recorder) FileName(method string, req Request) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n", req.Method, req.URL)
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		if !r.isIgnored(name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Fprintf(h, "%s: %s\n", name, strings.Join(req.Header[name], ", "))
+	}
+	fmt.Fprintf(h, "\n%s", req.Body)
+	return method + "_" + hex.EncodeToString(h.Sum(nil))[:16] + ".yaml"
+}
+
+// isIgnored reports whether the request header name is left out of the hash.
+func (r *Recorder) isIgnored(name string) bool {
+	return slices.ContainsFunc(r.ignored, func(ignored string) bool {
+		return strings.EqualFold(ignored, name)
+	})
+}
+
+// recordRequest returns the request of a call as recorded, with credential
+// and sensitive headers and sensitive body fields redacted.
+func recordRequest(call sebufhttp.ClientCall, req *nethttp.Request) (Request, error) {
+	body, err := sebufhttp.RedactedRequestJSON(call)
+	if err != nil {
+		return Request{}, fmt.Errorf("cassette: failed to encode request body: %w", err)
+	}
+	return Request{
+		Method: req.Method,
+		URL:    req.URL.RequestURI(),
+		Header: headerMap(sebufhttp.RedactHeaders(req.Header, call.SensitiveHeaders)),
+		Body:   string(body),
+	}, nil
+}
+
+// headerMap returns header as the map a cassette holds, nil when empty.
+func headerMap(header nethttp.Header) map[string][]string {
+	if len(header) == 0 {
+		return nil
+	}
+	return map[string][]string(header)
+}
+
+// write writes the cassette of a call, and the README of the directory on the
+// first recording.
+func (r *Recorder) write(
+	name string,
+	call sebufhttp.ClientCall,
+	req Request,
+	resp *nethttp.Response,
+	body []byte,
+) error {
+	body, err := sebufhttp.RedactResponseBody(call, resp, body)
+	if err != nil {
+		return fmt.Errorf("cassette: failed to redact response body of %s: %w", call.Method, err)
+	}
+	cassette := Cassette{
+		Version: FormatVersion,
+		Method:  call.Method,
+		Request: req,
+		Response: Response{
+			Status: resp.StatusCode,
+			Header: headerMap(sebufhttp.RedactHeaders(resp.Header, []string{"Set-Cookie"})),
+		},
+	}
+	if utf8.Valid(body) {
+		cassette.Response.Body = string(body)
+	} else {
+		cassette.Response.BodyBase64 = base64.StdEncoding.EncodeToString(body)
+	}
+
+	data, err := yaml.Dump(cassette, yaml.WithIndent(2), yaml.WithLineWidth(-1), yaml.WithFlowSimpleCollections(true))
+	if err != nil {
+		return fmt.Errorf("cassette: failed to encode %s: %w", name, err)
+	}
+	data = append([]byte("# Recorded by github.com/SebastienMelki/sebuf/http/cassette; see README.md.\n"), data...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+	var readmeErr error
+	r.readme.Do(func() {
+		readmeErr = os.WriteFile(filepath.Join(r.dir, "README.md"), []byte(readme), 0o644)
+	})
+	if readmeErr != nil {
+		return fmt.Errorf("cassette: %w", readmeErr)
+	}
+	if err := os.WriteFile(filepath.Join(r.dir, name), data, 0o644); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+	return nil
+}
+
+// replay answers a call from the cassette name.
+func (r *Recorder) replay(method, name string, sent Request, req *nethttp.Request) (*nethttp.Response, error) {
+	cassette, err := Load(filepath.Join(r.dir, name))
+	if os.IsNotExist(err) {
+		return nil, r.miss(method, name, sent)
+	}
+	if err != nil {
+		return nil, err
+	}
+	body := []byte(cassette.Response.Body)
+	if cassette.Response.BodyBase64 != "" {
+		if body, err = base64.StdEncoding.DecodeString(cassette.Response.BodyBase64); err != nil {
+			return nil, fmt.Errorf("cassette: invalid body_base64 in %s: %w", name, err)
+		}
+	}
+	status := cassette.Response.Status
+	return &nethttp.Response{
+		Status:        fmt.Sprintf("%d %s", status, nethttp.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        nethttp.Header(cassette.Response.Header).Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// Load reads a cassette file.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cassette Cassette
+	if err := yaml.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("cassette: invalid cassette %s: %w", path, err)
+	}
+	if cassette.Version != FormatVersion {
+		return nil, fmt.Errorf("cassette: %s has version %d, want %d", path, cassette.Version, FormatVersion)
+	}
+	return &cassette, nil
+}
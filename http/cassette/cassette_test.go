@@ -0,0 +1,130 @@
+package cassette_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	nethttp "net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/http/cassette"
+)
+
+// respond returns a send function answering with status and body, counting
+// its calls in sent.
+func respond(sent *int, status int, contentType string, body []byte) func() (*nethttp.Response, error) {
+	return func() (*nethttp.Response, error) {
+		*sent++
+		return &nethttp.Response{
+			StatusCode: status,
+			Header:     nethttp.Header{"Content-Type": {contentType}},
+			Body:       io.NopCloser(bytes.NewReader(body)),
+		}, nil
+	}
+}
+
+func newRequest(t *testing.T, target string, header nethttp.Header) *nethttp.Request {
+	t.Helper()
+	req, err := nethttp.NewRequest(nethttp.MethodPost, target, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header = header
+	return req
+}
+
+func TestRecordReplay(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cassettes")
+	call := sebufhttp.ClientCall{
+		Method:   "NoteService.Echo",
+		Body:     wrapperspb.String("hello"),
+		Response: &wrapperspb.BytesValue{},
+	}
+	binary, err := proto.Marshal(wrapperspb.Bytes([]byte{0xff, 0x00}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sent int
+	recorder := cassette.New(dir, cassette.Record)
+	req := newRequest(t, "https://api.example.com/v1/echo?lang=en", nethttp.Header{
+		"Content-Type":  {sebufhttp.ProtoContentType},
+		"Authorization": {"Bearer secret"},
+		"X-Request-Id":  {"1"},
+	})
+	resp, err := recorder.Do(call, req, respond(&sent, nethttp.StatusOK, sebufhttp.ProtoContentType, binary))
+	if err != nil {
+		t.Fatalf("Do in Record mode: %v", err)
+	}
+	if body, _ := io.ReadAll(resp.Body); !bytes.Equal(body, binary) || sent != 1 {
+		t.Fatalf("recorded call returned %x after %d sends, want %x after 1", body, sent, binary)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "README.md")); err != nil {
+		t.Errorf("README.md: %v", err)
+	}
+
+	replayer := cassette.New(dir, cassette.Replay, cassette.IgnoreHeaders("X-Trace"))
+	req = newRequest(t, "http://localhost:8080/v1/echo?lang=en", nethttp.Header{
+		"Content-Type":  {sebufhttp.ProtoContentType},
+		"Authorization": {"Bearer other"},
+		"X-Request-Id":  {"2"},
+		"X-Trace":       {"abc"},
+	})
+	resp, err = replayer.Do(call, req, respond(&sent, nethttp.StatusInternalServerError, "", nil))
+	if err != nil {
+		t.Fatalf("Do in Replay mode: %v", err)
+	}
+	if body, _ := io.ReadAll(resp.Body); !bytes.Equal(body, binary) || resp.StatusCode != nethttp.StatusOK ||
+		resp.Status != "200 OK" || resp.Header.Get("Content-Type") != sebufhttp.ProtoContentType || sent != 1 {
+		t.Errorf("replayed %s %v %x after %d sends, want the recorded response", resp.Status, resp.Header, body, sent)
+	}
+}
+
+func TestReplayMiss(t *testing.T) {
+	dir := t.TempDir()
+	echo := sebufhttp.ClientCall{Method: "NoteService.Echo", Body: wrapperspb.String("hello")}
+	var sent int
+	recorder := cassette.New(dir, cassette.Record)
+	for _, call := range []sebufhttp.ClientCall{echo, {Method: "NoteService.Ping"}} {
+		req := newRequest(t, "http://localhost/v1/echo", nil)
+		send := respond(&sent, nethttp.StatusOK, sebufhttp.JSONContentType, nil)
+		if _, err := recorder.Do(call, req, send); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	echo.Body = wrapperspb.String("bye")
+	_, err := cassette.New(dir, cassette.Replay).Do(echo, newRequest(t, "http://localhost/v1/echo", nil), nil)
+	var miss *cassette.MissError
+	if !errors.As(err, &miss) {
+		t.Fatalf("Do = %v, want a *cassette.MissError", err)
+	}
+	if !strings.HasPrefix(miss.Nearest, "NoteService.Echo_") || len(miss.Differences) != 1 ||
+		miss.Differences[0] != `body is "\"hello\"", not "\"bye\""` {
+		t.Errorf("nearest %s differing by %q, want the Echo cassette differing by body", miss.Nearest, miss.Differences)
+	}
+}
+
+func TestStreamsAreNotRecorded(t *testing.T) {
+	dir := t.TempDir()
+	call := sebufhttp.ClientCall{Method: "NoteService.Watch", Stream: true}
+	var sent int
+	req := newRequest(t, "http://localhost/v1/watch", nil)
+	send := respond(&sent, nethttp.StatusOK, "text/event-stream", []byte("data: {}\n\n"))
+	if _, err := cassette.New(dir, cassette.Record).Do(call, req, send); err != nil || sent != 1 {
+		t.Fatalf("Do in Record mode = %v after %d sends", err, sent)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("a streamed call wrote %d files", len(entries))
+	}
+	if _, err := cassette.New(dir, cassette.Replay).Do(call, req, send); err == nil || sent != 1 {
+		t.Errorf("Do in Replay mode = %v after %d sends, want an error without sending", err, sent)
+	}
+}
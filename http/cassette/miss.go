@@ -0,0 +1,101 @@
+package cassette
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// maxShownValue is the number of bytes of a value, such as a body, a MissError
+// shows.
+const maxShownValue = 80
+
+// MissError is returned in Replay mode for a call without a cassette.
+type MissError struct {
+	// Method is the RPC, as "Service.Method", and Request the request sent.
+	Method  string
+	Request Request
+	// Dir and FileName are the directory and the name the cassette of the
+	// call would have.
+	Dir      string
+	FileName string
+	// Nearest is the name of the cassette whose request differs the least
+	// from the request sent, empty when Dir holds none, and Differences lists
+	// how they differ.
+	Nearest     string
+	Differences []string
+}
+
+func (e *MissError) Error() string {
+	msg := fmt.Sprintf("cassette: no recording of %s %s %s in %s (looked for %s)",
+		e.Method, e.Request.Method, e.Request.URL, e.Dir, e.FileName)
+	if e.Nearest == "" {
+		return msg + "; the directory holds no cassettes"
+	}
+	return fmt.Sprintf("%s; the nearest is %s, whose %s", msg, e.Nearest, strings.Join(e.Differences, "; "))
+}
+
+// miss returns the MissError of a call to method with the request sent, whose
+// cassette name is missing.
+func (r *Recorder) miss(method, name string, sent Request) error {
+	missErr := &MissError{Method: method, Request: sent, Dir: r.dir, FileName: name}
+	paths, _ := filepath.Glob(filepath.Join(r.dir, "*.yaml"))
+	sameMethod := false
+	for _, path := range paths {
+		cassette, err := Load(path)
+		if err != nil {
+			continue
+		}
+		differences := r.differences(method, sent, cassette)
+		same := cassette.Method == method
+		// A cassette of the same RPC wins over any other, then the fewest
+		// differences do.
+		if missErr.Nearest == "" || (same && !sameMethod) ||
+			(same == sameMethod && len(differences) < len(missErr.Differences)) {
+			missErr.Nearest = filepath.Base(path)
+			missErr.Differences = differences
+			sameMethod = same
+		}
+	}
+	return missErr
+}
+
+// differences describes how the request recorded in cassette differs from
+// the request sent to method, leaving ignored headers out.
+func (r *Recorder) differences(method string, sent Request, cassette *Cassette) []string {
+	var differences []string
+	differ := func(what, recorded, sent string) {
+		if recorded != sent {
+			differences = append(differences, fmt.Sprintf("%s is %q, not %q", what, shorten(recorded), shorten(sent)))
+		}
+	}
+	differ("method", cassette.Method, method)
+	differ("verb", cassette.Request.Method, sent.Method)
+	differ("url", cassette.Request.URL, sent.URL)
+
+	names := make([]string, 0, len(sent.Header)+len(cassette.Request.Header))
+	for name := range sent.Header {
+		names = append(names, name)
+	}
+	for name := range cassette.Request.Header {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range slices.Compact(names) {
+		if !r.isIgnored(name) {
+			recorded := strings.Join(cassette.Request.Header[name], ", ")
+			differ("header "+name, recorded, strings.Join(sent.Header[name], ", "))
+		}
+	}
+	differ("body", cassette.Request.Body, sent.Body)
+	return differences
+}
+
+// shorten cuts a value to maxShownValue bytes.
+func shorten(value string) string {
+	if len(value) <= maxShownValue {
+		return value
+	}
+	return value[:maxShownValue] + "..."
+}
@@ -0,0 +1,42 @@
+package cassette
+
+// readme is the README.md Record writes next to the cassettes.
+const readme = `# Cassettes
+
+This directory holds the calls of a generated sebuf Go client, recorded by
+github.com/SebastienMelki/sebuf/http/cassette. In Replay mode the client
+answers each call from its cassette without the network; re-record them in
+Record mode against a real server. Do not edit this file: recording rewrites it.
+
+## Files
+
+Each call is kept in ` + "`<Service>.<Method>_<hash>.yaml`" + `. The hash is the
+first 16 hex digits of the SHA-256 of the request: its verb, path and query,
+headers sorted by name, and body. Volatile headers, such as Date,
+Idempotency-Key, Traceparent, User-Agent, X-Correlation-Id and X-Request-Id,
+and any added with cassette.IgnoreHeaders, are left out of the hash. Recording
+the same request again replaces its cassette.
+
+## Format (version 1)
+
+` + "```yaml" + `
+version: 1                    # the format version
+method: ProductService.GetProduct
+request:
+  method: GET                 # the HTTP verb
+  url: /api/v1/products/42    # path and query, without scheme and host
+  headers:                    # header name -> values
+    Accept: [application/json]
+  body: '{"id":"42"}'         # the request message as JSON, if any
+response:
+  status: 200
+  headers:
+    Content-Type: [application/json]
+  body: '{"id":"42","name":"Lamp"}'  # the body, when it is UTF-8 text
+  # body_base64: ...          # the body base64-encoded, otherwise
+` + "```" + `
+
+Sensitive fields, annotated with sebuf.http.sensitive, and credential headers,
+such as Authorization, cookies and API keys, are replaced by "[REDACTED]"
+before anything is written.
+`
@@ -0,0 +1,224 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned, wrapped, for calls a CircuitBreaker rejects
+// without sending them. Test for it with errors.Is.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// Defaults of the zero BreakerConfig fields.
+const (
+	DefaultBreakerFailureThreshold = 5
+	DefaultBreakerCooldown         = 30 * time.Second
+	DefaultBreakerHalfOpenRequests = 1
+)
+
+// BreakerScope selects what a CircuitBreaker keeps separate circuits for.
+type BreakerScope int
+
+const (
+	// BreakerPerHost shares one circuit between the calls to a host.
+	BreakerPerHost BreakerScope = iota
+	// BreakerPerMethod keeps one circuit per RPC method.
+	BreakerPerMethod
+)
+
+// BreakerState is the state of a circuit.
+type BreakerState int
+
+const (
+	// BreakerClosed sends every call, counting consecutive failures.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects every call with ErrCircuitOpen until the cooldown ends.
+	BreakerOpen
+	// BreakerHalfOpen sends a limited number of probe calls; the circuit closes
+	// once they all succeed and opens again as soon as one fails.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("BreakerState(%d)", int(s))
+}
+
+// BreakerConfig configures a CircuitBreaker. Zero fields take their defaults.
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed calls that opens a
+	// closed circuit. Transport errors and 5xx responses are failures; 4xx
+	// responses are the caller's fault and count as successes.
+	FailureThreshold int
+	// Cooldown is how long an open circuit rejects calls before it lets probes
+	// through.
+	Cooldown time.Duration
+	// HalfOpenRequests is the number of probe calls a half-open circuit sends,
+	// and that must all succeed to close it.
+	HalfOpenRequests int
+	// Scope selects whether circuits are kept per host or per method.
+	Scope BreakerScope
+	// OnStateChange, if set, is called after every state transition of a
+	// circuit, e.g. to export metrics. key is the host or the method name.
+	OnStateChange func(key string, from, to BreakerState)
+}
+
+// CircuitBreaker stops generated clients from sending calls to a downstream
+// that keeps failing. A nil *CircuitBreaker sends every call.
+type CircuitBreaker struct {
+	cfg      BreakerConfig
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// circuit is the state of the calls sharing a key.
+type circuit struct {
+	state    BreakerState
+	failures int       // consecutive failures while closed
+	openedAt time.Time // when the circuit last opened
+	probes   int       // probe calls sent while half-open
+	passed   int       // probe calls that succeeded while half-open
+}
+
+// NewCircuitBreaker returns a CircuitBreaker configured by cfg.
+func NewCircuitBreaker(cfg BreakerConfig) *CircuitBreaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = DefaultBreakerFailureThreshold
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = DefaultBreakerCooldown
+	}
+	if cfg.HalfOpenRequests <= 0 {
+		cfg.HalfOpenRequests = DefaultBreakerHalfOpenRequests
+	}
+	return &CircuitBreaker{cfg: cfg, circuits: make(map[string]*circuit)}
+}
+
+// State returns the state of the circuit of key, a host or a method name
+// depending on the scope.
+func (b *CircuitBreaker) State(key string) BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c, ok := b.circuits[key]
+	if !ok {
+		return BreakerClosed
+	}
+	if c.state == BreakerOpen && time.Since(c.openedAt) >= b.cfg.Cooldown {
+		return BreakerHalfOpen
+	}
+	return c.state
+}
+
+// Do calls send, which sends req, unless the circuit of the call is open, and
+// records the outcome. method is the RPC method name, used as the key of
+// per-method circuits. Calls whose context ended are not counted: the caller
+// gave up, not the downstream.
+func (b *CircuitBreaker) Do(
+	method string,
+	req *nethttp.Request,
+	send func() (*nethttp.Response, error),
+) (*nethttp.Response, error) {
+	if b == nil {
+		return send()
+	}
+	key := req.URL.Host
+	if b.cfg.Scope == BreakerPerMethod {
+		key = method
+	}
+
+	probe, err := b.allow(key)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := send()
+	if err != nil && req.Context().Err() != nil {
+		b.release(key, probe)
+		return resp, err
+	}
+	b.record(key, probe, err == nil && resp.StatusCode < nethttp.StatusInternalServerError)
+	return resp, err
+}
+
+// allow admits a call to the circuit of key, reporting whether it is a
+// half-open probe, or returns ErrCircuitOpen.
+func (b *CircuitBreaker) allow(key string) (bool, error) {
+	b.mu.Lock()
+	c, ok := b.circuits[key]
+	if !ok {
+		c = &circuit{}
+		b.circuits[key] = c
+	}
+	from := c.state
+	if c.state == BreakerOpen && time.Since(c.openedAt) >= b.cfg.Cooldown {
+		c.state, c.probes, c.passed = BreakerHalfOpen, 0, 0
+	}
+	admitted := c.state == BreakerClosed || (c.state == BreakerHalfOpen && c.probes < b.cfg.HalfOpenRequests)
+	probe := c.state == BreakerHalfOpen && admitted
+	if probe {
+		c.probes++
+	}
+	to := c.state
+	b.mu.Unlock()
+
+	b.notify(key, from, to)
+	if !admitted {
+		return false, fmt.Errorf("%w: %s", ErrCircuitOpen, key)
+	}
+	return probe, nil
+}
+
+// record counts the outcome of a call admitted by allow.
+func (b *CircuitBreaker) record(key string, probe, succeeded bool) {
+	b.mu.Lock()
+	c := b.circuits[key]
+	from := c.state
+	switch {
+	case probe && c.state == BreakerHalfOpen && !succeeded:
+		c.state, c.openedAt = BreakerOpen, time.Now()
+	case probe && c.state == BreakerHalfOpen:
+		c.passed++
+		if c.passed >= b.cfg.HalfOpenRequests {
+			c.state, c.failures = BreakerClosed, 0
+		}
+	case c.state == BreakerClosed && !succeeded:
+		c.failures++
+		if c.failures >= b.cfg.FailureThreshold {
+			c.state, c.openedAt = BreakerOpen, time.Now()
+		}
+	case c.state == BreakerClosed:
+		c.failures = 0
+	}
+	to := c.state
+	b.mu.Unlock()
+
+	b.notify(key, from, to)
+}
+
+// release returns the probe slot of a call that was not counted.
+func (b *CircuitBreaker) release(key string, probe bool) {
+	if !probe {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if c := b.circuits[key]; c.state == BreakerHalfOpen && c.probes > 0 {
+		c.probes--
+	}
+}
+
+// notify reports a state transition to OnStateChange.
+func (b *CircuitBreaker) notify(key string, from, to BreakerState) {
+	if from != to && b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(key, from, to)
+	}
+}
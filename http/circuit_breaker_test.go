@@ -0,0 +1,229 @@
+package http_test
+
+import (
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// scriptedServer answers each request with the next status of its script,
+// repeating the last one once the script runs out.
+type scriptedServer struct {
+	mu     sync.Mutex
+	script []int
+	calls  atomic.Int32
+}
+
+func (s *scriptedServer) ServeHTTP(w nethttp.ResponseWriter, _ *nethttp.Request) {
+	s.calls.Add(1)
+	s.mu.Lock()
+	status := s.script[0]
+	if len(s.script) > 1 {
+		s.script = s.script[1:]
+	}
+	s.mu.Unlock()
+	w.WriteHeader(status)
+}
+
+func (s *scriptedServer) set(script ...int) {
+	s.mu.Lock()
+	s.script = script
+	s.mu.Unlock()
+}
+
+// transition is one state change reported to OnStateChange.
+type transition struct {
+	key      string
+	from, to http.BreakerState
+}
+
+func callBreaker(t *testing.T, b *http.CircuitBreaker, client *nethttp.Client, url string) (int, error) {
+	t.Helper()
+	req, err := nethttp.NewRequest(nethttp.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := b.Do("TestService.Get", req, func() (*nethttp.Response, error) {
+		return client.Do(req)
+	})
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func TestCircuitBreaker_Recovers(t *testing.T) {
+	script := &scriptedServer{script: []int{nethttp.StatusServiceUnavailable}}
+	srv := httptest.NewServer(script)
+	defer srv.Close()
+
+	var mu sync.Mutex
+	var transitions []transition
+	b := http.NewCircuitBreaker(http.BreakerConfig{
+		FailureThreshold: 3,
+		Cooldown:         50 * time.Millisecond,
+		HalfOpenRequests: 2,
+		Scope:            http.BreakerPerMethod,
+		OnStateChange: func(key string, from, to http.BreakerState) {
+			mu.Lock()
+			transitions = append(transitions, transition{key, from, to})
+			mu.Unlock()
+		},
+	})
+
+	for range 3 {
+		if status, err := callBreaker(t, b, srv.Client(), srv.URL); err != nil || status != nethttp.StatusServiceUnavailable {
+			t.Fatalf("call = %d, %v; want 503", status, err)
+		}
+	}
+	if got := b.State("TestService.Get"); got != http.BreakerOpen {
+		t.Fatalf("state after 3 failures = %v, want open", got)
+	}
+
+	// Open: calls are rejected without reaching the server.
+	if _, err := callBreaker(t, b, srv.Client(), srv.URL); !errors.Is(err, http.ErrCircuitOpen) {
+		t.Fatalf("call while open = %v, want ErrCircuitOpen", err)
+	}
+	if n := script.calls.Load(); n != 3 {
+		t.Fatalf("server saw %d requests, want 3", n)
+	}
+
+	// Half-open: a failed probe opens the circuit again.
+	time.Sleep(60 * time.Millisecond)
+	if got := b.State("TestService.Get"); got != http.BreakerHalfOpen {
+		t.Fatalf("state after cooldown = %v, want half-open", got)
+	}
+	if status, _ := callBreaker(t, b, srv.Client(), srv.URL); status != nethttp.StatusServiceUnavailable {
+		t.Fatalf("probe status = %d, want 503", status)
+	}
+	if got := b.State("TestService.Get"); got != http.BreakerOpen {
+		t.Fatalf("state after failed probe = %v, want open", got)
+	}
+
+	// Half-open again: both probes succeed and the circuit closes.
+	script.set(nethttp.StatusOK)
+	time.Sleep(60 * time.Millisecond)
+	for range 2 {
+		if status, err := callBreaker(t, b, srv.Client(), srv.URL); err != nil || status != nethttp.StatusOK {
+			t.Fatalf("probe = %d, %v; want 200", status, err)
+		}
+	}
+	if got := b.State("TestService.Get"); got != http.BreakerClosed {
+		t.Fatalf("state after successful probes = %v, want closed", got)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	key := "TestService.Get"
+	want := []transition{
+		{key, http.BreakerClosed, http.BreakerOpen},
+		{key, http.BreakerOpen, http.BreakerHalfOpen},
+		{key, http.BreakerHalfOpen, http.BreakerOpen},
+		{key, http.BreakerOpen, http.BreakerHalfOpen},
+		{key, http.BreakerHalfOpen, http.BreakerClosed},
+	}
+	if len(transitions) != len(want) {
+		t.Fatalf("transitions = %v, want %v", transitions, want)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("transition %d = %v, want %v", i, transitions[i], want[i])
+		}
+	}
+}
+
+func TestCircuitBreaker_HalfOpenLimitsProbes(t *testing.T) {
+	release := make(chan struct{})
+	var calls atomic.Int32
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(nethttp.StatusBadGateway)
+			return
+		}
+		<-release
+	}))
+	defer srv.Close()
+
+	b := http.NewCircuitBreaker(http.BreakerConfig{FailureThreshold: 1, Cooldown: 20 * time.Millisecond})
+	if status, _ := callBreaker(t, b, srv.Client(), srv.URL); status != nethttp.StatusBadGateway {
+		t.Fatalf("status = %d, want 502", status)
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	probeDone := make(chan error, 1)
+	go func() {
+		_, err := callBreaker(t, b, srv.Client(), srv.URL)
+		probeDone <- err
+	}()
+	for calls.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := callBreaker(t, b, srv.Client(), srv.URL); !errors.Is(err, http.ErrCircuitOpen) {
+		t.Errorf("call during the probe = %v, want ErrCircuitOpen", err)
+	}
+	close(release)
+	if err := <-probeDone; err != nil {
+		t.Fatalf("probe: %v", err)
+	}
+	if got := b.State(srv.Listener.Addr().String()); got != http.BreakerClosed {
+		t.Errorf("state after the probe = %v, want closed", got)
+	}
+}
+
+func TestCircuitBreaker_ClientErrorsDoNotTrip(t *testing.T) {
+	script := &scriptedServer{script: []int{nethttp.StatusNotFound}}
+	srv := httptest.NewServer(script)
+	defer srv.Close()
+
+	b := http.NewCircuitBreaker(http.BreakerConfig{FailureThreshold: 2})
+	for range 5 {
+		if status, err := callBreaker(t, b, srv.Client(), srv.URL); err != nil || status != nethttp.StatusNotFound {
+			t.Fatalf("call = %d, %v; want 404", status, err)
+		}
+	}
+	if got := b.State(srv.Listener.Addr().String()); got != http.BreakerClosed {
+		t.Errorf("state after 4xx responses = %v, want closed", got)
+	}
+}
+
+func TestCircuitBreaker_TransportErrorsTrip(t *testing.T) {
+	srv := httptest.NewServer(nethttp.NotFoundHandler())
+	url := srv.URL
+	host := srv.Listener.Addr().String()
+	srv.Close()
+
+	b := http.NewCircuitBreaker(http.BreakerConfig{FailureThreshold: 2})
+	for range 2 {
+		if _, err := callBreaker(t, b, nethttp.DefaultClient, url); err == nil || errors.Is(err, http.ErrCircuitOpen) {
+			t.Fatalf("call = %v, want a transport error", err)
+		}
+	}
+	if _, err := callBreaker(t, b, nethttp.DefaultClient, url); !errors.Is(err, http.ErrCircuitOpen) {
+		t.Errorf("call after 2 transport errors = %v, want ErrCircuitOpen", err)
+	}
+	if got := b.State(host); got != http.BreakerOpen {
+		t.Errorf("state = %v, want open", got)
+	}
+}
+
+func TestCircuitBreaker_Nil(t *testing.T) {
+	var b *http.CircuitBreaker
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	for range 10 {
+		status, err := callBreaker(t, b, srv.Client(), srv.URL)
+		if err != nil || status != nethttp.StatusInternalServerError {
+			t.Fatalf("call = %d, %v; want 500", status, err)
+		}
+	}
+}
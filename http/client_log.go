@@ -0,0 +1,283 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultDebugBodyLimit is the number of bytes of a request or response body a
+// ClientLogger with a zero BodyLimit dumps before truncating it.
+const DefaultDebugBodyLimit = 4096
+
+// ClientLogEvent describes a call a generated client made, passed to the hook
+// of its ClientLogger once the response arrived or the call failed.
+type ClientLogEvent struct {
+	// Method is the RPC, as "Service.Method".
+	Method string
+	// HTTPMethod and URL are those of the request sent.
+	HTTPMethod string
+	URL        string
+	// Status is the status code of the response, 0 when none arrived.
+	Status int
+	// Duration is the time from sending the request to receiving the response
+	// headers, or to the failure.
+	Duration time.Duration
+	// Err is the error sending the request or reading the response. Responses
+	// with an error status are reported by Status.
+	Err error
+	// Attempt is the attempt the response answered: 1 for the request, and 2
+	// and up for its copies when the client hedges.
+	Attempt int
+}
+
+// ClientCall describes the call a generated client passes to ClientLogger.Do.
+type ClientCall struct {
+	// Method is the RPC, as "Service.Method".
+	Method string
+	// Body is the message sent as the request body, nil for methods without one.
+	Body proto.Message
+	// Response is an empty response message a successful response body is
+	// decoded into for the dump, nil when the body is not a single message.
+	Response proto.Message
+	// Stream is set for methods whose response body the caller reads as it
+	// arrives. Their response bodies are not dumped.
+	Stream bool
+	// SensitiveHeaders are the headers sent from sensitive request fields.
+	SensitiveHeaders []string
+}
+
+// ClientLogger logs the calls of a generated client. Debug receives a dump of
+// each call: a curl command equivalent to the request, then the response
+// status and body, with sensitive fields and credential headers redacted and
+// bodies truncated at BodyLimit bytes. Hook receives a ClientLogEvent per call.
+// A nil ClientLogger logs nothing.
+type ClientLogger struct {
+	// Debug receives the dumps. Writes are serialized.
+	Debug io.Writer
+	// BodyLimit is the number of bytes of a body dumped before truncating it,
+	// DefaultDebugBodyLimit when zero and unlimited when negative.
+	BodyLimit int
+	// Hook receives an event per call.
+	Hook func(ClientLogEvent)
+
+	mu sync.Mutex
+}
+
+// Do sends req with send and logs the call. When the response body is dumped,
+// it is read in full and replaced by an in-memory copy.
+func (l *ClientLogger) Do(
+	call ClientCall,
+	req *nethttp.Request,
+	send func() (*nethttp.Response, error),
+) (*nethttp.Response, error) {
+	if l == nil || (l.Debug == nil && l.Hook == nil) {
+		return send()
+	}
+	start := time.Now()
+	resp, err := send()
+	event := ClientLogEvent{
+		Method:     call.Method,
+		HTTPMethod: req.Method,
+		URL:        req.URL.String(),
+		Duration:   time.Since(start),
+		Err:        err,
+		Attempt:    hedgeAttempt(resp),
+	}
+
+	var respBody []byte
+	if err == nil {
+		event.Status = resp.StatusCode
+		if l.Debug != nil && !call.Stream {
+			respBody, err = io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(respBody))
+			if err != nil {
+				err = fmt.Errorf("failed to read response body: %w", err)
+				event.Err = err
+				resp = nil
+			}
+		}
+	}
+
+	if l.Debug != nil {
+		l.dump(call, req, resp, respBody, event)
+	}
+	if l.Hook != nil {
+		l.Hook(event)
+	}
+	return resp, err
+}
+
+// dump writes the dump of a call to Debug.
+func (l *ClientLogger) dump(
+	call ClientCall,
+	req *nethttp.Request,
+	resp *nethttp.Response,
+	respBody []byte,
+	event ClientLogEvent,
+) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "--> %s\n", call.Method)
+	b.WriteString(l.curlCommand(call, req))
+	b.WriteByte('\n')
+	switch {
+	case event.Err != nil:
+		fmt.Fprintf(&b, "<-- error after %s: %v\n", event.Duration.Round(time.Millisecond), event.Err)
+	default:
+		fmt.Fprintf(&b, "<-- %s in %s", resp.Status, event.Duration.Round(time.Millisecond))
+		if event.Attempt > 1 {
+			fmt.Fprintf(&b, " (attempt %d)", event.Attempt)
+		}
+		b.WriteByte('\n')
+		if !call.Stream {
+			if body := l.responseDump(call, resp, respBody); body != "" {
+				b.WriteString(body)
+				b.WriteByte('\n')
+			}
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = io.WriteString(l.Debug, b.String())
+}
+
+// curlCommand returns a curl command sending req, with credential headers,
+// the headers of call.SensitiveHeaders and the sensitive fields of the body
+// redacted. A body that is not JSON is shown as JSON in a trailing comment.
+func (l *ClientLogger) curlCommand(call ClientCall, req *nethttp.Request) string {
+	var b strings.Builder
+	b.WriteString("curl -X " + req.Method + " " + shellQuote(req.URL.String()))
+	header := RedactHeaders(req.Header, call.SensitiveHeaders)
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		for _, value := range header[name] {
+			b.WriteString(" -H " + shellQuote(name+": "+value))
+		}
+	}
+	if call.Body == nil {
+		return b.String()
+	}
+	data, err := RedactedRequestJSON(call)
+	if err != nil {
+		return b.String() + " # request body could not be shown: " + err.Error()
+	}
+	data = l.truncate(data)
+	if strings.HasPrefix(req.Header.Get("Content-Type"), JSONContentType) {
+		return b.String() + " --data-raw " + shellQuote(string(data))
+	}
+	return fmt.Sprintf("%s --data-binary @- # %s body, shown as JSON: %s",
+		b.String(), req.Header.Get("Content-Type"), data)
+}
+
+// responseDump returns the dump of a response body: a successful response
+// in JSON or binary protobuf decoded into call.Response, redacted and
+// indented, an error response in JSON indented, or its size otherwise.
+func (l *ClientLogger) responseDump(call ClientCall, resp *nethttp.Response, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	contentType := resp.Header.Get("Content-Type")
+	isJSON := strings.HasPrefix(contentType, JSONContentType)
+	isProto := strings.HasPrefix(contentType, ProtoContentType)
+	var data []byte
+	switch {
+	case resp.StatusCode >= nethttp.StatusBadRequest && isJSON:
+		data = body
+	case call.Response != nil && resp.StatusCode < nethttp.StatusBadRequest && (isJSON || isProto):
+		msg := proto.Clone(call.Response)
+		if err := unmarshalMessage(body, msg, isJSON); err != nil {
+			return fmt.Sprintf("[%d bytes of %s, not shown: %v]", len(body), contentType, err)
+		}
+		var err error
+		if data, err = marshalMessageJSON(Redact(msg)); err != nil {
+			return fmt.Sprintf("[%d bytes of %s, not shown: %v]", len(body), contentType, err)
+		}
+	default:
+		return fmt.Sprintf("[%d bytes of %s]", len(body), contentType)
+	}
+	var indented bytes.Buffer
+	if json.Indent(&indented, data, "", "  ") == nil {
+		data = indented.Bytes()
+	}
+	return string(l.truncate(data))
+}
+
+// truncate cuts data at the body limit, noting the size it had.
+func (l *ClientLogger) truncate(data []byte) []byte {
+	limit := l.BodyLimit
+	if limit == 0 {
+		limit = DefaultDebugBodyLimit
+	}
+	if limit < 0 || len(data) <= limit {
+		return data
+	}
+	return fmt.Appendf(slices.Clip(data[:limit]), "... [truncated, %d bytes total]", len(data))
+}
+
+// unmarshalMessage decodes a JSON or binary protobuf body into msg, through
+// its own UnmarshalJSON when it has one.
+func unmarshalMessage(body []byte, msg proto.Message, isJSON bool) error {
+	if !isJSON {
+		return proto.Unmarshal(body, msg)
+	}
+	if u, ok := msg.(json.Unmarshaler); ok {
+		return u.UnmarshalJSON(body)
+	}
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(body, msg)
+}
+
+// marshalMessageJSON encodes msg as compact JSON, through its own MarshalJSON
+// when it has one.
+func marshalMessageJSON(msg proto.Message) ([]byte, error) {
+	var data []byte
+	var err error
+	if m, ok := msg.(json.Marshaler); ok {
+		data, err = m.MarshalJSON()
+	} else {
+		data, err = protojson.Marshal(msg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var compact bytes.Buffer
+	if json.Compact(&compact, data) != nil {
+		return data, nil
+	}
+	return compact.Bytes(), nil
+}
+
+// isCredentialHeader reports whether a header carries credentials, such as
+// Authorization, cookies, API keys, tokens or secrets.
+func isCredentialHeader(name string) bool {
+	name = strings.ToLower(name)
+	switch name {
+	case "authorization", "proxy-authorization", "cookie":
+		return true
+	}
+	for _, part := range []string{"api-key", "apikey", "token", "secret", "password"} {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,266 @@
+package http_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// sensitiveMessages returns a Credentials message with a sensitive password
+// and a Session message with a sensitive token and a nested Credentials.
+func sensitiveMessages(t *testing.T) (credentials, session protoreflect.MessageDescriptor) {
+	t.Helper()
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, sensitive bool,
+	) *descriptorpb.FieldDescriptorProto {
+		fd := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+			JsonName: proto.String(name),
+		}
+		if sensitive {
+			fd.Options = &descriptorpb.FieldOptions{}
+			proto.SetExtension(fd.Options, http.E_Sensitive, true)
+		}
+		return fd
+	}
+	const str = descriptorpb.FieldDescriptorProto_TYPE_STRING
+	nested := field("credentials", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, false)
+	nested.TypeName = proto.String(".logtest.Credentials")
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("logtest.proto"),
+		Package: proto.String("logtest"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Credentials"), Field: []*descriptorpb.FieldDescriptorProto{
+				field("username", 1, str, false),
+				field("password", 2, str, true),
+			}},
+			{Name: proto.String("Session"), Field: []*descriptorpb.FieldDescriptorProto{
+				field("token", 1, str, true),
+				field("user", 2, str, false),
+				nested,
+			}},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file.Messages().Get(0), file.Messages().Get(1)
+}
+
+// newMessage returns a message of desc with the given string fields set.
+func newMessage(desc protoreflect.MessageDescriptor, fields map[string]string) *dynamicpb.Message {
+	msg := dynamicpb.NewMessage(desc)
+	for name, value := range fields {
+		msg.Set(desc.Fields().ByName(protoreflect.Name(name)), protoreflect.ValueOfString(value))
+	}
+	return msg
+}
+
+// postJSON returns a JSON POST request to url carrying an Authorization and an
+// X-Session header.
+func postJSON(t *testing.T, url string) *nethttp.Request {
+	t.Helper()
+	req, err := nethttp.NewRequest(nethttp.MethodPost, url+"/login", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer top-secret")
+	req.Header.Set("X-Session", "session-secret")
+	req.Header.Set("X-Request-Id", "req-1")
+	return req
+}
+
+func TestClientLoggerDumpsFailingRequest(t *testing.T) {
+	credentials, session := sensitiveMessages(t)
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(nethttp.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"message":"`+strings.Repeat("x", 100)+`"}`)
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	var events []http.ClientLogEvent
+	logger := &http.ClientLogger{
+		Debug:     &out,
+		BodyLimit: 48,
+		Hook:      func(e http.ClientLogEvent) { events = append(events, e) },
+	}
+	req := postJSON(t, srv.URL)
+	resp, err := logger.Do(http.ClientCall{
+		Method:           "AuthService.Login",
+		Body:             newMessage(credentials, map[string]string{"username": "ada", "password": "hunter2"}),
+		Response:         dynamicpb.NewMessage(session),
+		SensitiveHeaders: []string{"x-session"},
+	}, req, func() (*nethttp.Response, error) { return srv.Client().Do(req) })
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if len(body) != 114 {
+		t.Errorf("response body has %d bytes after the dump, want 114", len(body))
+	}
+
+	dump := out.String()
+	for _, want := range []string{
+		"--> AuthService.Login\ncurl -X POST '" + srv.URL + "/login'",
+		"-H 'Authorization: [REDACTED]'",
+		"-H 'X-Session: [REDACTED]'",
+		"-H 'X-Request-Id: req-1'",
+		`--data-raw '{"username":"ada","password":"[REDACTED]"}'`,
+		"<-- 401 Unauthorized in ",
+		"{\n  \"message\": \"xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx... [truncated, 119 bytes total]\n",
+	} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("dump does not contain %q:\n%s", want, dump)
+		}
+	}
+	for _, secret := range []string{"top-secret", "session-secret", "hunter2"} {
+		if strings.Contains(dump, secret) {
+			t.Errorf("dump leaks %q:\n%s", secret, dump)
+		}
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	e := events[0]
+	if e.Method != "AuthService.Login" || e.HTTPMethod != nethttp.MethodPost || e.URL != srv.URL+"/login" ||
+		e.Status != nethttp.StatusUnauthorized || e.Err != nil || e.Attempt != 1 || e.Duration <= 0 {
+		t.Errorf("event = %+v", e)
+	}
+}
+
+func TestClientLoggerRedactsResponse(t *testing.T) {
+	_, session := sensitiveMessages(t)
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"token":"tok-123","user":"ada","credentials":{"password":"hunter2"}}`)
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	logger := &http.ClientLogger{Debug: &out}
+	req, _ := nethttp.NewRequest(nethttp.MethodGet, srv.URL+"/session", nil)
+	resp, err := logger.Do(http.ClientCall{Method: "AuthService.GetSession", Response: dynamicpb.NewMessage(session)},
+		req, func() (*nethttp.Response, error) { return srv.Client().Do(req) })
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	const want = "<-- 200 OK in "
+	dump := out.String()
+	if !strings.Contains(dump, want) || !strings.Contains(dump, `"token": "[REDACTED]"`) ||
+		!strings.Contains(dump, `"password": "[REDACTED]"`) || !strings.Contains(dump, `"user": "ada"`) {
+		t.Errorf("dump does not show the redacted response:\n%s", dump)
+	}
+	if strings.Contains(dump, "tok-123") || strings.Contains(dump, "hunter2") {
+		t.Errorf("dump leaks a sensitive field:\n%s", dump)
+	}
+}
+
+func TestClientLoggerTransportError(t *testing.T) {
+	srv := httptest.NewServer(nethttp.NotFoundHandler())
+	srv.Close()
+
+	var out bytes.Buffer
+	var event http.ClientLogEvent
+	logger := &http.ClientLogger{Debug: &out, Hook: func(e http.ClientLogEvent) { event = e }}
+	req := postJSON(t, srv.URL)
+	_, err := logger.Do(http.ClientCall{Method: "AuthService.Login"}, req, func() (*nethttp.Response, error) {
+		return nethttp.DefaultClient.Do(req)
+	})
+	if err == nil {
+		t.Fatal("Do succeeded against a closed server")
+	}
+	if !errors.Is(event.Err, err) || event.Status != 0 {
+		t.Errorf("event = %+v, want the error %v and no status", event, err)
+	}
+	if dump := out.String(); !strings.Contains(dump, "<-- error after ") || strings.Contains(dump, "top-secret") {
+		t.Errorf("dump = %s", dump)
+	}
+}
+
+func TestClientLoggerReportsHedgeAttempt(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if calls.Add(1) == 1 {
+			<-r.Context().Done()
+			return
+		}
+		_, _ = io.WriteString(w, "hedge")
+	}))
+	defer srv.Close()
+
+	var event http.ClientLogEvent
+	logger := &http.ClientLogger{Hook: func(e http.ClientLogEvent) { event = e }}
+	req, _ := nethttp.NewRequest(nethttp.MethodGet, srv.URL, nil)
+	resp, err := logger.Do(http.ClientCall{Method: "Svc.Get"}, req, func() (*nethttp.Response, error) {
+		return http.DoHedged(srv.Client(), req, 20*time.Millisecond, 2)
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	_ = resp.Body.Close()
+	if event.Attempt != 2 || event.Status != nethttp.StatusOK {
+		t.Errorf("event = %+v, want attempt 2", event)
+	}
+}
+
+func TestNilClientLogger(t *testing.T) {
+	var logger *http.ClientLogger
+	sent := false
+	if _, err := logger.Do(http.ClientCall{}, nil, func() (*nethttp.Response, error) {
+		sent = true
+		return nil, errors.New("boom")
+	}); err == nil || !sent {
+		t.Errorf("nil logger did not pass the call through: sent = %v, err = %v", sent, err)
+	}
+}
+
+func TestRedactThroughReflection(t *testing.T) {
+	credentials, session := sensitiveMessages(t)
+	msg := newMessage(session, map[string]string{"token": "tok-123", "user": "ada"})
+	msg.Set(session.Fields().ByName("credentials"),
+		protoreflect.ValueOfMessage(newMessage(credentials, map[string]string{"password": "hunter2"})))
+
+	redacted := http.Redact(msg).ProtoReflect()
+	nested := redacted.Get(session.Fields().ByName("credentials")).Message()
+	if got := redacted.Get(session.Fields().ByName("token")).String(); got != http.RedactedValue {
+		t.Errorf("token = %q", got)
+	}
+	if got := nested.Get(credentials.Fields().ByName("password")).String(); got != http.RedactedValue {
+		t.Errorf("credentials.password = %q", got)
+	}
+	if got := redacted.Get(session.Fields().ByName("user")).String(); got != "ada" {
+		t.Errorf("user = %q", got)
+	}
+	if got := msg.Get(session.Fields().ByName("token")).String(); got != "tok-123" {
+		t.Errorf("Redact modified the message: token = %q", got)
+	}
+
+	plain := newMessage(credentials, map[string]string{"username": "ada"})
+	if http.Redact(plain) != proto.Message(plain) {
+		t.Error("Redact copied a message without sensitive values")
+	}
+}
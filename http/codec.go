@@ -0,0 +1,60 @@
+package http
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Codec converts request and response bodies between JSON and another wire
+// format. Generated servers and clients built with the extra_codecs plugin
+// parameter encode and decode bodies as JSON, then convert them with the codec
+// registered for the Content-Type, so the fields of both formats have the same
+// names and encodings.
+type Codec interface {
+	// ContentType returns the media type the codec is registered for, such as
+	// application/msgpack.
+	ContentType() string
+	// FromJSON converts a JSON value to the codec's format.
+	FromJSON(data []byte) ([]byte, error)
+	// ToJSON converts a value in the codec's format to JSON.
+	ToJSON(data []byte) ([]byte, error)
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = make(map[string]Codec)
+)
+
+// RegisterCodec makes codec available for its content type. It is meant to be
+// called from the init function of a codec package, such as msgpackcodec, so
+// that importing the package enables the format. It panics if codec is nil or
+// a codec is already registered for its content type.
+func RegisterCodec(codec Codec) {
+	if codec == nil {
+		panic("sebuf/http: RegisterCodec codec is nil")
+	}
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	contentType := codec.ContentType()
+	if _, dup := codecs[contentType]; dup {
+		panic(fmt.Sprintf("sebuf/http: RegisterCodec called twice for %s", contentType))
+	}
+	codecs[contentType] = codec
+}
+
+// LookupCodec returns the codec registered for contentType.
+func LookupCodec(contentType string) (Codec, bool) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	codec, ok := codecs[contentType]
+	return codec, ok
+}
+
+// CodecNotRegisteredError returns the error of a body in contentType when no
+// codec is registered for it. Generated servers answer it with 415.
+func CodecNotRegisteredError(contentType string) *Error {
+	return &Error{
+		Code:    ErrorCodeUnsupportedMediaType,
+		Message: fmt.Sprintf("no codec is registered for %s", contentType),
+	}
+}
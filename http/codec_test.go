@@ -0,0 +1,46 @@
+package http_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// upperCodec is a codec for text/x-upper that upper-cases JSON.
+type upperCodec struct{}
+
+func (upperCodec) ContentType() string { return "text/x-upper" }
+
+func (upperCodec) FromJSON(data []byte) ([]byte, error) { return bytes.ToUpper(data), nil }
+
+func (upperCodec) ToJSON(data []byte) ([]byte, error) { return bytes.ToLower(data), nil }
+
+func TestRegisterCodec(t *testing.T) {
+	if _, ok := http.LookupCodec("text/x-upper"); ok {
+		t.Fatal("codec found before it was registered")
+	}
+	http.RegisterCodec(upperCodec{})
+	codec, ok := http.LookupCodec("text/x-upper")
+	if !ok {
+		t.Fatal("codec not found after it was registered")
+	}
+	if out, _ := codec.FromJSON([]byte(`{"a":1}`)); string(out) != `{"A":1}` {
+		t.Errorf("FromJSON = %s", out)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a second codec for text/x-upper did not panic")
+		}
+	}()
+	http.RegisterCodec(upperCodec{})
+}
+
+func TestCodecNotRegisteredError(t *testing.T) {
+	err := http.CodecNotRegisteredError("application/msgpack")
+	if err.GetCode() != http.ErrorCodeUnsupportedMediaType ||
+		err.GetMessage() != "no codec is registered for application/msgpack" {
+		t.Errorf("err = %v", err)
+	}
+}
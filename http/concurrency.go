@@ -0,0 +1,44 @@
+package http
+
+// RetryAfterSaturated is the Retry-After value, in seconds, generated servers
+// send with the 503 responses written while their concurrency limit is reached.
+const RetryAfterSaturated = "1"
+
+// ConcurrencyLimiter caps how many handlers of a generated server execute at
+// once. Generated servers acquire a slot after the request is bound and
+// validated, and release it when the handler returns. A nil
+// *ConcurrencyLimiter imposes no limit.
+type ConcurrencyLimiter struct {
+	slots chan struct{}
+}
+
+// NewConcurrencyLimiter returns a limiter admitting up to limit concurrent
+// handlers, or nil (no limit) when limit is not positive.
+func NewConcurrencyLimiter(limit int) *ConcurrencyLimiter {
+	if limit <= 0 {
+		return nil
+	}
+	return &ConcurrencyLimiter{slots: make(chan struct{}, limit)}
+}
+
+// TryAcquire claims a slot without blocking and reports whether one was free.
+// Every successful TryAcquire must be paired with a Release.
+func (l *ConcurrencyLimiter) TryAcquire() bool {
+	if l == nil {
+		return true
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Release frees a slot claimed by TryAcquire.
+func (l *ConcurrencyLimiter) Release() {
+	if l == nil {
+		return
+	}
+	<-l.slots
+}
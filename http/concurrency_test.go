@@ -0,0 +1,34 @@
+package http_test
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestConcurrencyLimiter(t *testing.T) {
+	l := http.NewConcurrencyLimiter(2)
+	if !l.TryAcquire() || !l.TryAcquire() {
+		t.Fatal("expected two free slots")
+	}
+	if l.TryAcquire() {
+		t.Fatal("expected the limiter to be saturated")
+	}
+	l.Release()
+	if !l.TryAcquire() {
+		t.Fatal("expected a released slot to be reusable")
+	}
+}
+
+func TestConcurrencyLimiter_Unlimited(t *testing.T) {
+	l := http.NewConcurrencyLimiter(0)
+	if l != nil {
+		t.Fatalf("NewConcurrencyLimiter(0) = %v, want nil", l)
+	}
+	for range 100 {
+		if !l.TryAcquire() {
+			t.Fatal("a nil limiter must always admit")
+		}
+	}
+	l.Release()
+}
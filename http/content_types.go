@@ -0,0 +1,27 @@
+package http
+
+// Content types generated servers and clients read and write. Generated
+// packages define their JSONContentType, ContentTypeJSON and related constants
+// as these values.
+const (
+	// JSONContentType is the content type for JSON.
+	JSONContentType = "application/json"
+	// BinaryContentType is the content type for binary protobuf.
+	BinaryContentType = "application/octet-stream"
+	// ProtoContentType is the content type for protobuf.
+	ProtoContentType = "application/x-protobuf"
+	// MergePatchContentType is the content type for JSON Merge Patch (RFC
+	// 7386) bodies, accepted by methods with patch_format MERGE_PATCH.
+	MergePatchContentType = "application/merge-patch+json"
+	// ProblemContentType is the content type for Problem Details (RFC 9457)
+	// error bodies, written by servers generated with WithProblemJSON or
+	// error_format PROBLEM_JSON.
+	ProblemContentType = "application/problem+json"
+	// FormContentType is the content type for URL-encoded forms.
+	FormContentType = "application/x-www-form-urlencoded"
+	// MultipartContentType is the content type for multipart forms.
+	MultipartContentType = "multipart/form-data"
+	// MsgpackContentType is the content type for msgpack, served with the
+	// msgpackcodec package.
+	MsgpackContentType = "application/msgpack"
+)
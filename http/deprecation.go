@@ -0,0 +1,109 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	nethttp "net/http"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// DeprecationReporter is called by generated servers with WithDeprecationReporting
+// for each deprecated field a request body sets. method is the full name of the
+// RPC, such as library.v1.LibraryService.CreateBook, and field the dotted proto
+// name path of the field, such as book.isbn. A query parameter alias the
+// request sends is reported as ?<alias>, such as ?q.
+type DeprecationReporter func(method, field string)
+
+// wellKnownPackage is the package of the well-known types, whose JSON forms
+// do not follow the fields of their messages.
+const wellKnownPackage = "google.protobuf"
+
+// DeprecatedJSONFields returns the dotted proto name paths of the deprecated
+// fields of desc, nested messages included, that the JSON body sets. A field
+// is set when its key, by JSON or proto name, is present, whatever its value:
+// sending a deprecated field as null or as its zero value still relies on it.
+// Elements of repeated fields and values of map fields share the path of
+// their field. A body that is not a JSON object sets no field.
+func DeprecatedJSONFields(body []byte, desc protoreflect.MessageDescriptor) []string {
+	seen := make(map[string]bool)
+	collectDeprecatedJSONFields(bytes.TrimSpace(body), desc, "", seen)
+	if len(seen) == 0 {
+		return nil
+	}
+	fields := make([]string, 0, len(seen))
+	for field := range seen {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// collectDeprecatedJSONFields records in seen the deprecated fields of desc
+// set by the JSON object raw, under prefix.
+func collectDeprecatedJSONFields(
+	raw json.RawMessage,
+	desc protoreflect.MessageDescriptor,
+	prefix string,
+	seen map[string]bool,
+) {
+	if len(raw) == 0 || raw[0] != '{' || desc.ParentFile().Package() == wellKnownPackage {
+		return
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &members); err != nil {
+		return
+	}
+	for key, value := range members {
+		fd := fieldByJSONKey(desc, key)
+		if fd == nil {
+			continue
+		}
+		path := prefix + string(fd.Name())
+		if options, ok := fd.Options().(*descriptorpb.FieldOptions); ok && options.GetDeprecated() {
+			seen[path] = true
+		}
+		value = bytes.TrimSpace(value)
+		switch {
+		case fd.IsMap():
+			if valueDesc := fd.MapValue().Message(); valueDesc != nil {
+				var entries map[string]json.RawMessage
+				if json.Unmarshal(value, &entries) == nil {
+					for _, entry := range entries {
+						collectDeprecatedJSONFields(bytes.TrimSpace(entry), valueDesc, path+".", seen)
+					}
+				}
+			}
+		case fd.Message() == nil:
+		case fd.IsList():
+			var elements []json.RawMessage
+			if json.Unmarshal(value, &elements) == nil {
+				for _, element := range elements {
+					collectDeprecatedJSONFields(bytes.TrimSpace(element), fd.Message(), path+".", seen)
+				}
+			}
+		default:
+			collectDeprecatedJSONFields(value, fd.Message(), path+".", seen)
+		}
+	}
+}
+
+// ReportDeprecatedFields calls report for each of the deprecated fields a
+// request to method set, and lists them in the Deprecation header of w, when w
+// is not nil.
+func ReportDeprecatedFields(w nethttp.ResponseWriter, report DeprecationReporter, method string, fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+	if report != nil {
+		for _, field := range fields {
+			report(method, field)
+		}
+	}
+	if w != nil {
+		w.Header().Add(DeprecationHeader, strings.Join(fields, ", "))
+	}
+}
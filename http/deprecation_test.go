@@ -0,0 +1,126 @@
+package http_test
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// deprecationOrder returns deprecation.test.Order { string id = 1; string
+// legacy_id = 2 [deprecated]; Address address = 3; repeated Address previous =
+// 4; } with Address { string street = 1; string zip_code = 2 [deprecated]; }.
+func deprecationOrder(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+	field := func(name, jsonName string, number int32, deprecated bool) *descriptorpb.FieldDescriptorProto {
+		fd := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(jsonName),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+		}
+		if deprecated {
+			fd.Options = &descriptorpb.FieldOptions{Deprecated: proto.Bool(true)}
+		}
+		return fd
+	}
+	address := func(
+		name string,
+		number int32,
+		label descriptorpb.FieldDescriptorProto_Label,
+	) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    label.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+			TypeName: proto.String(".deprecation.test.Address"),
+		}
+	}
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("deprecation_test.proto"),
+		Package: proto.String("deprecation.test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Address"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("street", "street", 1, false),
+				field("zip_code", "zipCode", 2, true),
+			},
+		}, {
+			Name: proto.String("Order"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("id", "id", 1, false),
+				field("legacy_id", "legacyId", 2, true),
+				address("address", 3, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+				address("previous", 4, descriptorpb.FieldDescriptorProto_LABEL_REPEATED),
+			},
+		}},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file.Messages().ByName("Order")
+}
+
+func TestDeprecatedJSONFields(t *testing.T) {
+	desc := deprecationOrder(t)
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{"none", `{"id": "o-1", "address": {"street": "Main St"}}`, nil},
+		{"top level by JSON name", `{"id": "o-1", "legacyId": "7"}`, []string{"legacy_id"}},
+		{"top level by proto name", `{"legacy_id": ""}`, []string{"legacy_id"}},
+		{"nested message", `{"address": {"street": "Main St", "zipCode": "75001"}}`, []string{"address.zip_code"}},
+		{"nested null", `{"address": {"zip_code": null}}`, []string{"address.zip_code"}},
+		{
+			"repeated messages",
+			`{"legacyId": "7", "previous": [{"street": "a"}, {"zipCode": "1"}, {"zipCode": "2"}]}`,
+			[]string{"legacy_id", "previous.zip_code"},
+		},
+		{"null message", `{"address": null}`, nil},
+		{"not an object", `["legacyId"]`, nil},
+		{"empty", ``, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := http.DeprecatedJSONFields([]byte(tt.body), desc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DeprecatedJSONFields(%s) = %v, want %v", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportDeprecatedFields(t *testing.T) {
+	var reported []string
+	report := func(method, field string) { reported = append(reported, method+" "+field) }
+	rec := httptest.NewRecorder()
+	fields := []string{"address.zip_code", "legacy_id"}
+	http.ReportDeprecatedFields(rec, report, "shop.v1.OrderService.CreateOrder", fields)
+
+	want := []string{"shop.v1.OrderService.CreateOrder address.zip_code", "shop.v1.OrderService.CreateOrder legacy_id"}
+	if !reflect.DeepEqual(reported, want) {
+		t.Errorf("reported %v, want %v", reported, want)
+	}
+	if got := rec.Header().Get(http.DeprecationHeader); got != "address.zip_code, legacy_id" {
+		t.Errorf("%s = %q, want the deprecated fields", http.DeprecationHeader, got)
+	}
+
+	rec = httptest.NewRecorder()
+	http.ReportDeprecatedFields(rec, report, "shop.v1.OrderService.CreateOrder", nil)
+	if _, ok := rec.Header()[nethttp.CanonicalHeaderKey(http.DeprecationHeader)]; ok {
+		t.Error("a request setting no deprecated field should not get a Deprecation header")
+	}
+}
@@ -0,0 +1,53 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	nethttp "net/http"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// DescriptorsPath is the path under which generated Register<Service>Reflection
+// functions serve the descriptors of a service, followed by its full protobuf
+// name (e.g. /__sebuf/descriptors/acme.notes.v1.NoteService).
+const DescriptorsPath = "/__sebuf/descriptors/"
+
+// DecodeDescriptorSet decompresses and unmarshals a gzip-compressed
+// FileDescriptorSet, as embedded by servers generated with embed_descriptors.
+// Each call returns a new set. It panics if compressed is not such a set,
+// which generated code never embeds.
+func DecodeDescriptorSet(compressed []byte) *descriptorpb.FileDescriptorSet {
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		panic(fmt.Sprintf("sebufhttp: invalid embedded descriptors: %v", err))
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		panic(fmt.Sprintf("sebufhttp: invalid embedded descriptors: %v", err))
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err = proto.Unmarshal(data, set); err != nil {
+		panic(fmt.Sprintf("sebufhttp: invalid embedded descriptors: %v", err))
+	}
+	return set
+}
+
+// DescriptorsHandler serves set, in protobuf when the request accepts it and
+// in JSON otherwise, as NegotiateResponseContentType decides. When allow is
+// not nil, the requests it rejects are answered with 404 Not Found, as if the
+// handler did not exist. Generated Register<Service>Reflection functions
+// serve it at DescriptorsPath followed by the service name, with the allow of
+// WithRouteDebugAuth.
+func DescriptorsHandler(set *descriptorpb.FileDescriptorSet, allow func(*nethttp.Request) bool) nethttp.Handler {
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if allow != nil && !allow(r) {
+			nethttp.NotFound(w, r)
+			return
+		}
+		Responder{}.WriteMessage(w, r, set, nethttp.StatusOK, "internal server error")
+	})
+}
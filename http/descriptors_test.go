@@ -0,0 +1,113 @@
+package http_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// compressedSet returns the gzip-compressed FileDescriptorSet of
+// google/protobuf/duration.proto, as generated servers embed it.
+func compressedSet(t *testing.T) (*descriptorpb.FileDescriptorSet, []byte) {
+	t.Helper()
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(durationpb.File_google_protobuf_duration_proto),
+	}}
+	data, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatalf("marshal set: %v", err)
+	}
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err = writer.Write(data); err != nil {
+		t.Fatalf("compress set: %v", err)
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatalf("compress set: %v", err)
+	}
+	return set, buf.Bytes()
+}
+
+func TestDecodeDescriptorSet(t *testing.T) {
+	set, compressed := compressedSet(t)
+	decoded := http.DecodeDescriptorSet(compressed)
+	if !proto.Equal(decoded, set) {
+		t.Fatalf("DecodeDescriptorSet = %v, want %v", decoded, set)
+	}
+	if http.DecodeDescriptorSet(compressed) == decoded {
+		t.Error("DecodeDescriptorSet returned the same set twice")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("DecodeDescriptorSet did not panic on data that is not gzip")
+		}
+	}()
+	http.DecodeDescriptorSet([]byte("not gzip"))
+}
+
+func TestDescriptorsHandler(t *testing.T) {
+	set, _ := compressedSet(t)
+	handler := http.DescriptorsHandler(set, func(r *nethttp.Request) bool {
+		return r.Header.Get("X-Debug") == "yes"
+	})
+	serve := func(accept, debug string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(nethttp.MethodGet, http.DescriptorsPath+"google.protobuf.Duration", nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if debug != "" {
+			req.Header.Set("X-Debug", debug)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := serve("", ""); rec.Code != nethttp.StatusNotFound {
+		t.Errorf("status without X-Debug = %d, want %d", rec.Code, nethttp.StatusNotFound)
+	}
+
+	rec := serve("", "yes")
+	if rec.Code != nethttp.StatusOK {
+		t.Fatalf("JSON status = %d, want %d", rec.Code, nethttp.StatusOK)
+	}
+	fromJSON := &descriptorpb.FileDescriptorSet{}
+	if err := protojson.Unmarshal(rec.Body.Bytes(), fromJSON); err != nil {
+		t.Fatalf("decode JSON body %q: %v", rec.Body.String(), err)
+	}
+	if !proto.Equal(fromJSON, set) {
+		t.Errorf("JSON body = %v, want %v", fromJSON, set)
+	}
+
+	rec = serve("application/x-protobuf", "yes")
+	if rec.Code != nethttp.StatusOK {
+		t.Fatalf("protobuf status = %d, want %d", rec.Code, nethttp.StatusOK)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/x-protobuf" {
+		t.Errorf("protobuf Content-Type = %q, want application/x-protobuf", got)
+	}
+	fromProto := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(rec.Body.Bytes(), fromProto); err != nil {
+		t.Fatalf("decode protobuf body: %v", err)
+	}
+	if !proto.Equal(fromProto, set) {
+		t.Errorf("protobuf body = %v, want %v", fromProto, set)
+	}
+
+	rec = httptest.NewRecorder()
+	http.DescriptorsHandler(set, nil).ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, http.DescriptorsPath, nil))
+	if rec.Code != nethttp.StatusOK {
+		t.Errorf("status without allow = %d, want %d", rec.Code, nethttp.StatusOK)
+	}
+}
@@ -0,0 +1,31 @@
+package http
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrorResponse is the error generated Go clients return when the server
+// answers a status the method documents in error_responses: the status, and
+// the body decoded into the message documented for it. Callers match it with
+// errors.As:
+//
+//	var notFound *sebufhttp.ErrorResponse[*api.NotFoundError]
+//	if errors.As(err, &notFound) {
+//		log.Printf("no book %s", notFound.Body.GetId())
+//	}
+//
+// A body that does not decode into the message is returned as the error of an
+// undocumented status would be.
+type ErrorResponse[T proto.Message] struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Body is the response body, decoded.
+	Body T
+}
+
+// Error implements the error interface.
+func (e *ErrorResponse[T]) Error() string {
+	return fmt.Sprintf("request failed with status %d: %s", e.StatusCode, e.Body.ProtoReflect().Descriptor().Name())
+}
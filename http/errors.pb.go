@@ -69,11 +69,17 @@ func (x *ValidationError) GetViolations() []*FieldViolation {
 }
 
 // Error is returned when a handler encounters an error.
-// It contains a simple error message that the developer can customize.
+// It contains an error message that the developer can customize, and an
+// optional machine-readable code and details clients can branch on.
 type Error struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Error message (e.g., "user not found", "database connection failed")
-	Message       string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Machine-readable error code (e.g., "NOT_FOUND", "UNIMPLEMENTED").
+	// Empty unless the error was raised with a code.
+	Code string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	// Additional machine-readable context (e.g., {"resource_id": "user-42"}).
+	Details       map[string]string `protobuf:"bytes,3,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -115,6 +121,20 @@ func (x *Error) GetMessage() string {
 	return ""
 }
 
+func (x *Error) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Error) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
 // FieldViolation describes a single validation error for a specific field.
 type FieldViolation struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -181,9 +201,14 @@ const file_proto_sebuf_http_errors_proto_rawDesc = "" +
 	"\x0fValidationError\x12:\n" +
 	"\n" +
 	"violations\x18\x01 \x03(\v2\x1a.sebuf.http.FieldViolationR\n" +
-	"violations\"!\n" +
+	"violations\"\xab\x01\n" +
 	"\x05Error\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"H\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x128\n" +
+	"\adetails\x18\x03 \x03(\v2\x1e.sebuf.http.Error.DetailsEntryR\adetails\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"H\n" +
 	"\x0eFieldViolation\x12\x14\n" +
 	"\x05field\x18\x01 \x01(\tR\x05field\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescriptionB+Z)github.com/SebastienMelki/sebuf/http;httpb\x06proto3"
@@ -200,19 +225,21 @@ func file_proto_sebuf_http_errors_proto_rawDescGZIP() []byte {
 	return file_proto_sebuf_http_errors_proto_rawDescData
 }
 
-var file_proto_sebuf_http_errors_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_sebuf_http_errors_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_proto_sebuf_http_errors_proto_goTypes = []any{
 	(*ValidationError)(nil), // 0: sebuf.http.ValidationError
 	(*Error)(nil),           // 1: sebuf.http.Error
 	(*FieldViolation)(nil),  // 2: sebuf.http.FieldViolation
+	nil,                     // 3: sebuf.http.Error.DetailsEntry
 }
 var file_proto_sebuf_http_errors_proto_depIdxs = []int32{
 	2, // 0: sebuf.http.ValidationError.violations:type_name -> sebuf.http.FieldViolation
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3, // 1: sebuf.http.Error.details:type_name -> sebuf.http.Error.DetailsEntry
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_proto_sebuf_http_errors_proto_init() }
@@ -226,7 +253,7 @@ func file_proto_sebuf_http_errors_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_sebuf_http_errors_proto_rawDesc), len(file_proto_sebuf_http_errors_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -3,8 +3,74 @@ package http
 import (
 	"fmt"
 	"strings"
+
+	"google.golang.org/protobuf/proto"
 )
 
+// ErrorCodeUnimplemented is the Error.Code returned by generated
+// Unimplemented<Service>Server methods. Generated servers map it to
+// HTTP 501 Not Implemented.
+const ErrorCodeUnimplemented = "UNIMPLEMENTED"
+
+// ErrorCodeIdempotencyConflict is the Error.Code written when an
+// Idempotency-Key is reused with a different request body. Generated servers
+// map it to HTTP 409 Conflict.
+const ErrorCodeIdempotencyConflict = "IDEMPOTENCY_CONFLICT"
+
+// ErrorCodeIdempotencyInProgress is the Error.Code written when a request
+// arrives while an earlier request with the same Idempotency-Key is still being
+// handled. Generated servers map it to HTTP 409 Conflict; the client may retry.
+const ErrorCodeIdempotencyInProgress = "IDEMPOTENCY_IN_PROGRESS"
+
+// ErrorCodeUnavailable is the Error.Code written when a server configured
+// with a concurrency limit is saturated, or when a request reaches a service
+// that was unregistered. Generated servers map it to HTTP 503 Service
+// Unavailable, with a Retry-After header when saturated.
+const ErrorCodeUnavailable = "UNAVAILABLE"
+
+// ErrorCodeDeadlineExceeded is the Error.Code written when a handler does not
+// finish within its timeout. Generated servers map it to HTTP 504 Gateway
+// Timeout.
+const ErrorCodeDeadlineExceeded = "DEADLINE_EXCEEDED"
+
+// ErrorCodePayloadTooLarge is the Error.Code written when a request body
+// exceeds the server's maximum body size. Generated servers map it to HTTP 413
+// Content Too Large.
+const ErrorCodePayloadTooLarge = "PAYLOAD_TOO_LARGE"
+
+// ErrorCodeUnsupportedMediaType is the Error.Code written when a request body
+// does not look like its declared Content-Type, such as a protobuf body sent as
+// application/json. Generated servers map it to HTTP 415 Unsupported Media
+// Type.
+const ErrorCodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+
+// ErrorCodeNotFound is the Error.Code of a handler error for a resource that
+// does not exist. Generated servers map it to HTTP 404 Not Found.
+const ErrorCodeNotFound = "NOT_FOUND"
+
+// ErrorCodeAlreadyExists is the Error.Code of a handler error for a resource
+// that already exists. Generated servers map it to HTTP 409 Conflict.
+const ErrorCodeAlreadyExists = "ALREADY_EXISTS"
+
+// ErrorCodePermissionDenied is the Error.Code of a handler error for a caller
+// that may not perform the request. Generated servers map it to HTTP 403
+// Forbidden.
+const ErrorCodePermissionDenied = "PERMISSION_DENIED"
+
+// ErrorCodeUnauthenticated is the Error.Code of a handler error for a request
+// without valid credentials. Generated servers map it to HTTP 401
+// Unauthorized.
+const ErrorCodeUnauthenticated = "UNAUTHENTICATED"
+
+// ErrorCodeInvalidArgument is the Error.Code of a handler error for a request
+// the handler rejects beyond its validation rules. Generated servers map it to
+// HTTP 400 Bad Request.
+const ErrorCodeInvalidArgument = "INVALID_ARGUMENT"
+
+// DefaultMaxMultipartBodySize bounds the multipart/form-data bodies of methods
+// with accept_multipart when the generated server sets no WithMaxBodySize.
+const DefaultMaxMultipartBodySize int64 = 32 << 20
+
 // Error implements the error interface for ValidationError.
 // This allows ValidationError to be used with errors.As() and errors.Is().
 func (e *ValidationError) Error() string {
@@ -43,3 +109,36 @@ func (e *Error) Error() string {
 
 	return e.GetMessage()
 }
+
+// NewError returns an Error with code and message, for handlers to return.
+// Generated servers answer it with the HTTP status of a well-known code
+// (ErrorCodeNotFound is 404, ErrorCodeAlreadyExists 409, and so on) and with
+// 500 for any other code, writing the code and details in the body.
+func NewError(code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// WithDetail returns a copy of err with the detail key set to value, leaving
+// err unchanged so that shared errors can be annotated per request.
+func WithDetail(err *Error, key, value string) *Error {
+	detailed, _ := proto.Clone(err).(*Error)
+	if detailed == nil {
+		detailed = &Error{}
+	}
+	if detailed.Details == nil {
+		detailed.Details = make(map[string]string)
+	}
+	detailed.Details[key] = value
+	return detailed
+}
+
+// Is reports whether target is an *Error with the same non-empty Code, so that
+// errors.Is(err, NewError(ErrorCodeNotFound, "")) matches any not-found error,
+// whatever its message and details.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok || e == nil || t == nil || t.GetCode() == "" {
+		return false
+	}
+	return e.GetCode() == t.GetCode()
+}
@@ -2,6 +2,7 @@ package http_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/SebastienMelki/sebuf/http"
@@ -171,3 +172,60 @@ func TestErrorInterface_Wrapping(t *testing.T) {
 		t.Errorf("wrappedSebufErr.Error() = %q, want %q", wrappedSebufErr.Error(), expectedWrappedSebuf)
 	}
 }
+
+func TestNewError_WithDetail(t *testing.T) {
+	base := http.NewError(http.ErrorCodeNotFound, "user not found")
+	detailed := http.WithDetail(http.WithDetail(base, "resource", "user"), "id", "user-42")
+
+	if detailed.GetCode() != http.ErrorCodeNotFound || detailed.GetMessage() != "user not found" {
+		t.Errorf("WithDetail() = %v, want the code and message of the original error", detailed)
+	}
+	if got := detailed.GetDetails(); len(got) != 2 || got["resource"] != "user" || got["id"] != "user-42" {
+		t.Errorf("Details = %v, want resource and id", got)
+	}
+	if len(base.GetDetails()) != 0 {
+		t.Errorf("WithDetail modified the original error: %v", base.GetDetails())
+	}
+}
+
+func TestError_Is(t *testing.T) {
+	notFound := http.NewError(http.ErrorCodeNotFound, "")
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{
+			name:   "same code, other message and details",
+			err:    http.WithDetail(http.NewError(http.ErrorCodeNotFound, "user not found"), "id", "1"),
+			target: notFound,
+			want:   true,
+		},
+		{
+			name:   "wrapped",
+			err:    fmt.Errorf("get user: %w", http.NewError(http.ErrorCodeNotFound, "user not found")),
+			target: notFound,
+			want:   true,
+		},
+		{
+			name:   "other code",
+			err:    http.NewError(http.ErrorCodeAlreadyExists, "user exists"),
+			target: notFound,
+			want:   false,
+		},
+		{
+			name:   "target without code",
+			err:    &http.Error{Message: "boom"},
+			target: &http.Error{Message: "boom"},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
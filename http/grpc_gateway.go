@@ -0,0 +1,407 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// GRPCCode is a gRPC status code, as carried in the code field of a
+// google.rpc.Status.
+type GRPCCode int32
+
+// The gRPC status codes, as defined by google.rpc.Code.
+const (
+	GRPCCodeOK                 GRPCCode = 0
+	GRPCCodeCanceled           GRPCCode = 1
+	GRPCCodeUnknown            GRPCCode = 2
+	GRPCCodeInvalidArgument    GRPCCode = 3
+	GRPCCodeDeadlineExceeded   GRPCCode = 4
+	GRPCCodeNotFound           GRPCCode = 5
+	GRPCCodeAlreadyExists      GRPCCode = 6
+	GRPCCodePermissionDenied   GRPCCode = 7
+	GRPCCodeResourceExhausted  GRPCCode = 8
+	GRPCCodeFailedPrecondition GRPCCode = 9
+	GRPCCodeAborted            GRPCCode = 10
+	GRPCCodeOutOfRange         GRPCCode = 11
+	GRPCCodeUnimplemented      GRPCCode = 12
+	GRPCCodeInternal           GRPCCode = 13
+	GRPCCodeUnavailable        GRPCCode = 14
+	GRPCCodeDataLoss           GRPCCode = 15
+	GRPCCodeUnauthenticated    GRPCCode = 16
+)
+
+// clientClosedRequest is the non-standard status grpc-gateway answers
+// canceled requests with.
+const clientClosedRequest = 499
+
+// HTTPStatus returns the HTTP status grpc-gateway answers an error with code
+// with. Unknown codes map to 500.
+func (c GRPCCode) HTTPStatus() int {
+	switch c {
+	case GRPCCodeOK:
+		return http.StatusOK
+	case GRPCCodeCanceled:
+		return clientClosedRequest
+	case GRPCCodeInvalidArgument, GRPCCodeFailedPrecondition, GRPCCodeOutOfRange:
+		return http.StatusBadRequest
+	case GRPCCodeDeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case GRPCCodeNotFound:
+		return http.StatusNotFound
+	case GRPCCodeAlreadyExists, GRPCCodeAborted:
+		return http.StatusConflict
+	case GRPCCodePermissionDenied:
+		return http.StatusForbidden
+	case GRPCCodeUnauthenticated:
+		return http.StatusUnauthorized
+	case GRPCCodeResourceExhausted:
+		return http.StatusTooManyRequests
+	case GRPCCodeUnimplemented:
+		return http.StatusNotImplemented
+	case GRPCCodeUnavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// GRPCCodeFromHTTPStatus returns the code of an error answered with an HTTP
+// status: the code grpc-gateway answers with that status, preferring
+// InvalidArgument for 400, Aborted for 409 and Unknown for 500, which is the
+// code of errors returned without one. 413 is ResourceExhausted, 412, which
+// answers schema mismatches, is FailedPrecondition and other statuses map to
+// Unknown.
+func GRPCCodeFromHTTPStatus(status int) GRPCCode {
+	switch status {
+	case http.StatusOK:
+		return GRPCCodeOK
+	case clientClosedRequest:
+		return GRPCCodeCanceled
+	case http.StatusBadRequest:
+		return GRPCCodeInvalidArgument
+	case http.StatusGatewayTimeout:
+		return GRPCCodeDeadlineExceeded
+	case http.StatusNotFound:
+		return GRPCCodeNotFound
+	case http.StatusConflict:
+		return GRPCCodeAborted
+	case http.StatusPreconditionFailed:
+		return GRPCCodeFailedPrecondition
+	case http.StatusForbidden:
+		return GRPCCodePermissionDenied
+	case http.StatusUnauthorized:
+		return GRPCCodeUnauthenticated
+	case http.StatusTooManyRequests, http.StatusRequestEntityTooLarge:
+		return GRPCCodeResourceExhausted
+	case http.StatusNotImplemented:
+		return GRPCCodeUnimplemented
+	case http.StatusServiceUnavailable:
+		return GRPCCodeUnavailable
+	}
+	return GRPCCodeUnknown
+}
+
+// GRPCError is an error with a gRPC status code. Servers generated with
+// compat=grpc_gateway answer it with the HTTP status of its code and a
+// google.rpc.Status body; other servers treat it as any other error.
+type GRPCError struct {
+	Code    GRPCCode
+	Message string
+}
+
+// Error implements the error interface.
+func (e *GRPCError) Error() string {
+	return e.Message
+}
+
+// GatewayStatus returns the google.rpc.Status body grpc-gateway writes for
+// err, and the HTTP status to write it with. statusCode is the status the
+// server answers err with otherwise.
+//
+// Errors carrying a gRPC code keep it and are answered with its HTTP status:
+// a *GRPCError, or an error of google.golang.org/grpc/status, whose status is
+// sent as is. For other errors the code is derived from statusCode. A
+// ValidationError becomes a google.rpc.BadRequest detail with one field
+// violation per violation, and an error that is itself a proto message is
+// sent as a detail.
+func GatewayStatus(err error, statusCode int) (proto.Message, int) {
+	types, typesErr := loadGatewayTypes()
+	if typesErr != nil {
+		return &Error{Message: err.Error()}, statusCode
+	}
+	var grpcErr *GRPCError
+	if errors.As(err, &grpcErr) {
+		return types.newStatus(grpcErr.Code, grpcErr.Message), grpcErr.Code.HTTPStatus()
+	}
+	if status := grpcStatusProto(err); status != nil {
+		code := status.ProtoReflect().Get(types.statusCode).Int()
+		return status, GRPCCode(code).HTTPStatus()
+	}
+
+	code := GRPCCodeFromHTTPStatus(statusCode)
+	var valErr *ValidationError
+	if errors.As(err, &valErr) {
+		status := types.newStatus(code, valErr.Error())
+		types.addDetail(status, types.newBadRequest(valErr.GetViolations()))
+		return status, statusCode
+	}
+	var handlerErr *Error
+	if errors.As(err, &handlerErr) {
+		return types.newStatus(code, handlerErr.GetMessage()), statusCode
+	}
+	status := types.newStatus(code, err.Error())
+	if detail, ok := err.(proto.Message); ok {
+		types.addDetail(status, detail)
+	}
+	return status, statusCode
+}
+
+// GatewayMarshalOptions returns the options a google.rpc.Status is marshaled
+// with: opts with every field emitted, as grpc-gateway's default marshaler
+// does, and a resolver that falls back to protoregistry.GlobalTypes for the
+// google.rpc detail types.
+func GatewayMarshalOptions(opts protojson.MarshalOptions) protojson.MarshalOptions {
+	opts.EmitUnpopulated = true
+	if opts.Resolver != nil {
+		opts.Resolver = gatewayResolver{opts.Resolver}
+	}
+	return opts
+}
+
+// gatewayResolver resolves Any types with a configured resolver, then with
+// protoregistry.GlobalTypes.
+type gatewayResolver struct {
+	resolver TypeResolver
+}
+
+func (r gatewayResolver) FindMessageByName(name protoreflect.FullName) (protoreflect.MessageType, error) {
+	if mt, err := r.resolver.FindMessageByName(name); err == nil {
+		return mt, nil
+	}
+	return protoregistry.GlobalTypes.FindMessageByName(name)
+}
+
+func (r gatewayResolver) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	if mt, err := r.resolver.FindMessageByURL(url); err == nil {
+		return mt, nil
+	}
+	return protoregistry.GlobalTypes.FindMessageByURL(url)
+}
+
+func (r gatewayResolver) FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	return r.resolver.FindExtensionByName(field)
+}
+
+func (r gatewayResolver) FindExtensionByNumber(
+	message protoreflect.FullName,
+	field protoreflect.FieldNumber,
+) (protoreflect.ExtensionType, error) {
+	return r.resolver.FindExtensionByNumber(message, field)
+}
+
+// grpcStatusProto returns the google.rpc.Status of an error created by
+// google.golang.org/grpc/status, or nil. Such errors have a GRPCStatus method
+// whose result has a Proto method returning the status message; both are
+// called by reflection so this package does not depend on gRPC.
+func grpcStatusProto(err error) proto.Message {
+	for ; err != nil; err = errors.Unwrap(err) {
+		method := reflect.ValueOf(err).MethodByName("GRPCStatus")
+		if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+			continue
+		}
+		status := method.Call(nil)[0]
+		if status.Kind() == reflect.Pointer && status.IsNil() {
+			return nil
+		}
+		protoMethod := status.MethodByName("Proto")
+		if !protoMethod.IsValid() || protoMethod.Type().NumIn() != 0 || protoMethod.Type().NumOut() != 1 {
+			return nil
+		}
+		msg, ok := protoMethod.Call(nil)[0].Interface().(proto.Message)
+		if !ok || msg.ProtoReflect().Descriptor().FullName() != "google.rpc.Status" {
+			return nil
+		}
+		return msg
+	}
+	return nil
+}
+
+// gatewayTypes holds the google.rpc message types of a status body.
+type gatewayTypes struct {
+	status         protoreflect.MessageType
+	statusCode     protoreflect.FieldDescriptor
+	statusMessage  protoreflect.FieldDescriptor
+	statusDetails  protoreflect.FieldDescriptor
+	badRequest     protoreflect.MessageType
+	violations     protoreflect.FieldDescriptor
+	violationField protoreflect.FieldDescriptor
+	violationDesc  protoreflect.FieldDescriptor
+}
+
+var (
+	gatewayTypesOnce   sync.Once
+	gatewayTypesCached *gatewayTypes
+	gatewayTypesErr    error
+)
+
+// loadGatewayTypes returns the google.rpc.Status and google.rpc.BadRequest
+// types. The generated types are used when the program links them (through
+// google.golang.org/genproto); otherwise the subset of their files needed here
+// is built and registered in the global registries, so protojson resolves the
+// details and clients can decode them.
+func loadGatewayTypes() (*gatewayTypes, error) {
+	gatewayTypesOnce.Do(func() {
+		var types gatewayTypes
+		types.status, gatewayTypesErr = findOrRegisterMessage("google.rpc.Status", gatewayStatusFile)
+		if gatewayTypesErr != nil {
+			return
+		}
+		types.badRequest, gatewayTypesErr = findOrRegisterMessage("google.rpc.BadRequest", gatewayErrorDetailsFile)
+		if gatewayTypesErr != nil {
+			return
+		}
+		statusFields := types.status.Descriptor().Fields()
+		types.statusCode = statusFields.ByName("code")
+		types.statusMessage = statusFields.ByName("message")
+		types.statusDetails = statusFields.ByName("details")
+		types.violations = types.badRequest.Descriptor().Fields().ByName("field_violations")
+		violationFields := types.violations.Message().Fields()
+		types.violationField = violationFields.ByName("field")
+		types.violationDesc = violationFields.ByName("description")
+		gatewayTypesCached = &types
+	})
+	return gatewayTypesCached, gatewayTypesErr
+}
+
+// findOrRegisterMessage returns the global type of a message, registering the
+// file built by newFile when the program does not link one.
+func findOrRegisterMessage(
+	name protoreflect.FullName,
+	newFile func() *descriptorpb.FileDescriptorProto,
+) (protoreflect.MessageType, error) {
+	if mt, err := protoregistry.GlobalTypes.FindMessageByName(name); err == nil {
+		return mt, nil
+	}
+	file, err := protodesc.NewFile(newFile(), protoregistry.GlobalFiles)
+	if err != nil {
+		return nil, err
+	}
+	if err = protoregistry.GlobalFiles.RegisterFile(file); err != nil {
+		return nil, err
+	}
+	messages := file.Messages()
+	for i := range messages.Len() {
+		if err = protoregistry.GlobalTypes.RegisterMessage(dynamicpb.NewMessageType(messages.Get(i))); err != nil {
+			return nil, err
+		}
+	}
+	return protoregistry.GlobalTypes.FindMessageByName(name)
+}
+
+func (t *gatewayTypes) newStatus(code GRPCCode, message string) proto.Message {
+	status := t.status.New()
+	status.Set(t.statusCode, protoreflect.ValueOfInt32(int32(code)))
+	status.Set(t.statusMessage, protoreflect.ValueOfString(message))
+	return status.Interface()
+}
+
+func (t *gatewayTypes) newBadRequest(violations []*FieldViolation) proto.Message {
+	badRequest := t.badRequest.New()
+	list := badRequest.Mutable(t.violations).List()
+	for _, violation := range violations {
+		item := list.NewElement().Message()
+		item.Set(t.violationField, protoreflect.ValueOfString(violation.GetField()))
+		item.Set(t.violationDesc, protoreflect.ValueOfString(violation.GetDescription()))
+		list.Append(protoreflect.ValueOfMessage(item))
+	}
+	return badRequest.Interface()
+}
+
+// addDetail appends detail to the details of status, packed in an Any. A
+// detail that cannot be packed is left out.
+func (t *gatewayTypes) addDetail(status, detail proto.Message) {
+	packed, err := anypb.New(detail)
+	if err != nil {
+		return
+	}
+	details := status.ProtoReflect().Mutable(t.statusDetails).List()
+	item := details.NewElement().Message()
+	proto.Merge(item.Interface(), packed)
+	details.Append(protoreflect.ValueOfMessage(item))
+}
+
+// gatewayStatusFile returns google/rpc/status.proto.
+func gatewayStatusFile() *descriptorpb.FileDescriptorProto {
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("google/rpc/status.proto"),
+		Package:    proto.String("google.rpc"),
+		Dependency: []string{"google/protobuf/any.proto"},
+		Syntax:     proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Status"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				gatewayField("code", 1, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""),
+				gatewayField("message", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+				gatewayRepeated(gatewayField("details", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+					".google.protobuf.Any")),
+			},
+		}},
+	}
+}
+
+// gatewayErrorDetailsFile returns the part of google/rpc/error_details.proto
+// defining BadRequest.
+func gatewayErrorDetailsFile() *descriptorpb.FileDescriptorProto {
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("google/rpc/error_details.proto"),
+		Package: proto.String("google.rpc"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("BadRequest"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				gatewayRepeated(gatewayField("field_violations", 1, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+					".google.rpc.BadRequest.FieldViolation")),
+			},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("FieldViolation"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					gatewayField("field", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+					gatewayField("description", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+				},
+			}},
+		}},
+	}
+}
+
+func gatewayField(
+	name string,
+	number int32,
+	kind descriptorpb.FieldDescriptorProto_Type,
+	typeName string,
+) *descriptorpb.FieldDescriptorProto {
+	field := &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String(name),
+		Number: proto.Int32(number),
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:   kind.Enum(),
+	}
+	if typeName != "" {
+		field.TypeName = proto.String(typeName)
+	}
+	return field
+}
+
+func gatewayRepeated(field *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return field
+}
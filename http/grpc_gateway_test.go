@@ -0,0 +1,156 @@
+package http_test
+
+import (
+	"encoding/json"
+	"errors"
+	nethttp "net/http"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestGRPCCode_HTTPStatus(t *testing.T) {
+	tests := []struct {
+		code http.GRPCCode
+		want int
+	}{
+		{http.GRPCCodeOK, nethttp.StatusOK},
+		{http.GRPCCodeCanceled, 499},
+		{http.GRPCCodeUnknown, nethttp.StatusInternalServerError},
+		{http.GRPCCodeInvalidArgument, nethttp.StatusBadRequest},
+		{http.GRPCCodeDeadlineExceeded, nethttp.StatusGatewayTimeout},
+		{http.GRPCCodeNotFound, nethttp.StatusNotFound},
+		{http.GRPCCodeAlreadyExists, nethttp.StatusConflict},
+		{http.GRPCCodePermissionDenied, nethttp.StatusForbidden},
+		{http.GRPCCodeResourceExhausted, nethttp.StatusTooManyRequests},
+		{http.GRPCCodeFailedPrecondition, nethttp.StatusBadRequest},
+		{http.GRPCCodeAborted, nethttp.StatusConflict},
+		{http.GRPCCodeOutOfRange, nethttp.StatusBadRequest},
+		{http.GRPCCodeUnimplemented, nethttp.StatusNotImplemented},
+		{http.GRPCCodeInternal, nethttp.StatusInternalServerError},
+		{http.GRPCCodeUnavailable, nethttp.StatusServiceUnavailable},
+		{http.GRPCCodeDataLoss, nethttp.StatusInternalServerError},
+		{http.GRPCCodeUnauthenticated, nethttp.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		if got := tt.code.HTTPStatus(); got != tt.want {
+			t.Errorf("GRPCCode(%d).HTTPStatus() = %d, want %d", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestGRPCCodeFromHTTPStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   http.GRPCCode
+	}{
+		{nethttp.StatusBadRequest, http.GRPCCodeInvalidArgument},
+		{nethttp.StatusUnauthorized, http.GRPCCodeUnauthenticated},
+		{nethttp.StatusForbidden, http.GRPCCodePermissionDenied},
+		{nethttp.StatusNotFound, http.GRPCCodeNotFound},
+		{nethttp.StatusConflict, http.GRPCCodeAborted},
+		{nethttp.StatusPreconditionFailed, http.GRPCCodeFailedPrecondition},
+		{nethttp.StatusRequestEntityTooLarge, http.GRPCCodeResourceExhausted},
+		{nethttp.StatusTooManyRequests, http.GRPCCodeResourceExhausted},
+		{nethttp.StatusInternalServerError, http.GRPCCodeUnknown},
+		{nethttp.StatusNotImplemented, http.GRPCCodeUnimplemented},
+		{nethttp.StatusServiceUnavailable, http.GRPCCodeUnavailable},
+		{nethttp.StatusGatewayTimeout, http.GRPCCodeDeadlineExceeded},
+		{nethttp.StatusTeapot, http.GRPCCodeUnknown},
+	}
+	for _, tt := range tests {
+		if got := http.GRPCCodeFromHTTPStatus(tt.status); got != tt.want {
+			t.Errorf("GRPCCodeFromHTTPStatus(%d) = %d, want %d", tt.status, got, tt.want)
+		}
+	}
+}
+
+// The bodies below were recorded from grpc-gateway v2 with its default
+// marshaler, answering the same errors from a gRPC server.
+func TestGatewayStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		statusCode int
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "gRPC code",
+			err:        &http.GRPCError{Code: http.GRPCCodeNotFound, Message: "book not found"},
+			statusCode: nethttp.StatusInternalServerError,
+			wantStatus: nethttp.StatusNotFound,
+			wantBody:   `{"code":5,"message":"book not found","details":[]}`,
+		},
+		{
+			name: "validation error",
+			err: &http.ValidationError{Violations: []*http.FieldViolation{
+				{Field: "filter.author", Description: "value is required"},
+			}},
+			statusCode: nethttp.StatusBadRequest,
+			wantStatus: nethttp.StatusBadRequest,
+			wantBody: `{"code":3,"message":"validation error: filter.author: value is required","details":[` +
+				`{"@type":"type.googleapis.com/google.rpc.BadRequest","fieldViolations":[` +
+				`{"field":"filter.author","description":"value is required"}]}]}`,
+		},
+		{
+			name:       "handler error",
+			err:        &http.Error{Message: "service unavailable"},
+			statusCode: nethttp.StatusServiceUnavailable,
+			wantStatus: nethttp.StatusServiceUnavailable,
+			wantBody:   `{"code":14,"message":"service unavailable","details":[]}`,
+		},
+		{
+			name:       "plain error",
+			err:        errors.New("boom"),
+			statusCode: nethttp.StatusInternalServerError,
+			wantStatus: nethttp.StatusInternalServerError,
+			wantBody:   `{"code":2,"message":"boom","details":[]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, statusCode := http.GatewayStatus(tt.err, tt.statusCode)
+			if statusCode != tt.wantStatus {
+				t.Errorf("status code = %d, want %d", statusCode, tt.wantStatus)
+			}
+			body, err := http.GatewayMarshalOptions(protojson.MarshalOptions{}).Marshal(status)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			assertSameJSON(t, body, tt.wantBody)
+
+			// The binary encoding round-trips through the same type.
+			data, err := proto.Marshal(status)
+			if err != nil {
+				t.Fatalf("proto.Marshal: %v", err)
+			}
+			decoded := status.ProtoReflect().New().Interface()
+			if err := proto.Unmarshal(data, decoded); err != nil {
+				t.Fatalf("proto.Unmarshal: %v", err)
+			}
+			if !proto.Equal(decoded, status) {
+				t.Errorf("binary round trip = %v, want %v", decoded, status)
+			}
+		})
+	}
+}
+
+// assertSameJSON compares JSON documents, ignoring the spacing protojson may add.
+func assertSameJSON(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var gotValue, wantValue any
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("unmarshal %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("unmarshal %s: %v", want, err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,151 @@
+package http
+
+import (
+	nethttp "net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// sniffLen is the number of bytes net/http sniffs the Content-Type of a
+// response from.
+const sniffLen = 512
+
+// HeadHandler answers HEAD requests with the status and headers next responds
+// to the same GET request with, and no body. next runs once, as for GET, with
+// its body counted and discarded rather than sent, so the response carries the
+// Content-Length of the body GET would send, which net/http only sets for
+// small bodies; a Content-Length next sets itself is kept. Flushes are ignored
+// until next returns. Generated servers register it on the HEAD form of every
+// GET route.
+func HeadHandler(next nethttp.Handler) nethttp.Handler {
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if r.Method != nethttp.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		rec := &headRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+
+		status := rec.status
+		if status == 0 {
+			status = nethttp.StatusOK
+		}
+		header := w.Header()
+		if _, set := header["Content-Type"]; !set && len(rec.sniff) > 0 {
+			header.Set("Content-Type", nethttp.DetectContentType(rec.sniff))
+		}
+		if header.Get("Content-Length") == "" && header.Get("Transfer-Encoding") == "" && bodyAllowed(status) {
+			header.Set("Content-Length", strconv.FormatInt(rec.n, 10))
+		}
+		w.WriteHeader(status)
+	})
+}
+
+// bodyAllowed reports whether a response of status may carry a body, and so a
+// Content-Length.
+func bodyAllowed(status int) bool {
+	return status >= nethttp.StatusOK && status != nethttp.StatusNoContent && status != nethttp.StatusNotModified
+}
+
+// headRecorder keeps the status and size of a response while discarding its
+// body, along with the first bytes net/http would sniff its Content-Type from.
+// It has no Unwrap: http.ResponseController must not flush the headers before
+// the size is known.
+type headRecorder struct {
+	nethttp.ResponseWriter
+	status int
+	n      int64
+	sniff  []byte
+}
+
+func (rec *headRecorder) WriteHeader(code int) {
+	if rec.status == 0 {
+		rec.status = code
+	}
+}
+
+func (rec *headRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = nethttp.StatusOK
+	}
+	if room := sniffLen - len(rec.sniff); room > 0 {
+		rec.sniff = append(rec.sniff, b[:min(room, len(b))]...)
+	}
+	rec.n += int64(len(b))
+	return len(b), nil
+}
+
+// Flush lets streaming handlers run to completion; nothing is sent until they
+// return.
+func (rec *headRecorder) Flush() {}
+
+// allowedMethods records the methods served on each path of one router, keyed
+// by allowKey.
+type allowedMethods struct {
+	mu     sync.RWMutex
+	byPath map[string][]string
+}
+
+// allowedMethodsByRouter holds the methods recorded by OptionsHandler for every
+// router it was called with.
+var allowedMethodsByRouter = struct {
+	sync.Mutex
+	byRouter map[any]*allowedMethods
+}{byRouter: make(map[any]*allowedMethods)}
+
+var allowWildcardPattern = regexp.MustCompile(`\{[^}]*\}`)
+
+// allowKey returns the key of path in allowedMethods: routers cannot tell
+// "/users/{id}" and "/users/{user_id}" apart, so wildcard names are erased.
+// A multi-segment wildcard keeps its "..." marker, since "/files/{name}" and
+// "/files/{path...}" match different requests.
+func allowKey(path string) string {
+	return allowWildcardPattern.ReplaceAllStringFunc(path, func(wildcard string) string {
+		if strings.HasSuffix(wildcard, "...}") {
+			return "{}..."
+		}
+		return "{}"
+	})
+}
+
+// OptionsHandler records that methods are served on path of router, keyed by
+// identity, and returns the handler answering OPTIONS requests for path with
+// 204 No Content and an Allow header listing, in alphabetical order, OPTIONS
+// and every method recorded for path on router, by every service registered
+// on it. Paths differing only in the names of their wildcards are the same
+// path. The second result is true on the first call for path on router, for
+// the caller to register the handler; the methods of later calls join the
+// Allow header of the handler already registered. Generated servers built
+// with auto_options=true call it for every route.
+func OptionsHandler(router any, path string, methods ...string) (nethttp.Handler, bool) {
+	allowedMethodsByRouter.Lock()
+	allowed, ok := allowedMethodsByRouter.byRouter[router]
+	if !ok {
+		allowed = &allowedMethods{byPath: make(map[string][]string)}
+		allowedMethodsByRouter.byRouter[router] = allowed
+	}
+	allowedMethodsByRouter.Unlock()
+
+	key := allowKey(path)
+	allowed.mu.Lock()
+	defer allowed.mu.Unlock()
+	recorded, exists := allowed.byPath[key]
+	for _, method := range slices.Concat(methods, []string{nethttp.MethodOptions}) {
+		if !slices.Contains(recorded, method) {
+			recorded = append(recorded, method)
+		}
+	}
+	slices.Sort(recorded)
+	allowed.byPath[key] = recorded
+
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		allowed.mu.RLock()
+		allow := strings.Join(allowed.byPath[key], ", ")
+		allowed.mu.RUnlock()
+		w.Header().Set("Allow", allow)
+		w.WriteHeader(nethttp.StatusNoContent)
+	}), !exists
+}
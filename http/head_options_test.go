@@ -0,0 +1,91 @@
+package http_test
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestHeadHandler(t *testing.T) {
+	body := strings.Repeat("x", 10000)
+	calls := 0
+	handler := http.HeadHandler(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		calls++
+		w.Header().Set("X-Item", "42")
+		w.WriteHeader(nethttp.StatusAccepted)
+		_, _ = w.Write([]byte(body[:5000]))
+		w.(nethttp.Flusher).Flush()
+		_, _ = w.Write([]byte(body[5000:]))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(nethttp.MethodHead, "/items/42", nil))
+	if calls != 1 {
+		t.Errorf("handler ran %d times, want 1", calls)
+	}
+	if rec.Code != nethttp.StatusAccepted {
+		t.Errorf("status = %d, want %d", rec.Code, nethttp.StatusAccepted)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("body = %q, want none", rec.Body.String())
+	}
+	if rec.Flushed {
+		t.Error("HEAD response was flushed before the handler returned")
+	}
+	for name, want := range map[string]string{
+		"Content-Length": "10000",
+		"Content-Type":   "text/plain; charset=utf-8",
+		"X-Item":         "42",
+	} {
+		if got := rec.Header().Get(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, "/items/42", nil))
+	if rec.Body.String() != body {
+		t.Errorf("GET body has %d bytes, want %d", rec.Body.Len(), len(body))
+	}
+
+	noContent := http.HeadHandler(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusNoContent)
+	}))
+	rec = httptest.NewRecorder()
+	noContent.ServeHTTP(rec, httptest.NewRequest(nethttp.MethodHead, "/items/42", nil))
+	if got := rec.Header().Values("Content-Length"); len(got) != 0 {
+		t.Errorf("Content-Length of 204 = %q, want none", got)
+	}
+}
+
+func TestOptionsHandler(t *testing.T) {
+	router := nethttp.NewServeMux()
+	items, first := http.OptionsHandler(router, "/items/{id}", nethttp.MethodGet, nethttp.MethodHead)
+	if !first {
+		t.Fatal("first call for /items/{id} did not report it first")
+	}
+	if _, first = http.OptionsHandler(router, "/items/{item_id}", nethttp.MethodDelete); first {
+		t.Error("/items/{item_id} was not recognized as /items/{id}")
+	}
+	if _, first = http.OptionsHandler(router, "/items", nethttp.MethodPost); !first {
+		t.Error("first call for /items did not report it first")
+	}
+	if _, first = http.OptionsHandler(router, "/items/{path...}", nethttp.MethodGet); !first {
+		t.Error("/items/{path...} was recognized as /items/{id}")
+	}
+	if _, first = http.OptionsHandler(nethttp.NewServeMux(), "/items/{id}", nethttp.MethodPut); !first {
+		t.Error("another router shares the methods of router")
+	}
+
+	rec := httptest.NewRecorder()
+	items.ServeHTTP(rec, httptest.NewRequest(nethttp.MethodOptions, "/items/42", nil))
+	if rec.Code != nethttp.StatusNoContent {
+		t.Errorf("status = %d, want %d", rec.Code, nethttp.StatusNoContent)
+	}
+	if got, want := rec.Header().Get("Allow"), "DELETE, GET, HEAD, OPTIONS"; got != want {
+		t.Errorf("Allow = %q, want %q", got, want)
+	}
+}
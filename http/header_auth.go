@@ -0,0 +1,84 @@
+package http
+
+import (
+	"context"
+	"errors"
+	nethttp "net/http"
+	"strings"
+)
+
+// HeaderAuthenticator verifies the credentials a request header carries, such
+// as an API key or bearer token declared with an auth_type.
+type HeaderAuthenticator struct {
+	// Header is the name of the header, matched case-insensitively.
+	Header string
+	// Authenticate verifies value, the header as sent. It returns the context
+	// the request continues with, for example carrying a principal added with
+	// ContextWithPrincipal, or an error when the credentials are invalid.
+	Authenticate func(ctx context.Context, value string) (context.Context, error)
+}
+
+// AuthenticateHeaders runs the authenticators in order, and returns r with the
+// context they returned. Each authenticator runs on every request, whether or
+// not the declared service and method headers include its header. A request
+// without the header fails as with invalid credentials, unless the declared
+// headers mark it optional; a missing required header is reported by header
+// validation first. An authenticator error that is not an *Error is returned
+// as an Error with code ErrorCodeUnauthenticated, which generated servers
+// answer with 401 Unauthorized.
+func AuthenticateHeaders(
+	r *nethttp.Request,
+	authenticators []HeaderAuthenticator,
+	declared ...[]*Header,
+) (*nethttp.Request, error) {
+	for _, auth := range authenticators {
+		value := r.Header.Get(auth.Header)
+		if value == "" {
+			if headerOptional(auth.Header, declared) {
+				continue
+			}
+			return r, NewError(ErrorCodeUnauthenticated, "missing credentials in header "+auth.Header)
+		}
+		ctx, err := auth.Authenticate(r.Context(), value)
+		if err != nil {
+			var e *Error
+			if errors.As(err, &e) {
+				return r, e
+			}
+			return r, NewError(ErrorCodeUnauthenticated, "invalid credentials in header "+auth.Header)
+		}
+		if ctx != nil {
+			r = r.WithContext(ctx)
+		}
+	}
+	return r, nil
+}
+
+// headerOptional reports whether the header lists declare name as not
+// required. A later list, such as the method headers, overrides an earlier one.
+func headerOptional(name string, declared [][]*Header) bool {
+	optional := false
+	for _, headers := range declared {
+		for _, header := range headers {
+			if strings.EqualFold(header.GetName(), name) {
+				optional = !header.GetRequired()
+			}
+		}
+	}
+	return optional
+}
+
+type principalCtxKey struct{}
+
+// ContextWithPrincipal returns a copy of ctx carrying principal, the identity
+// a HeaderAuthenticator established for the request.
+func ContextWithPrincipal(ctx context.Context, principal any) context.Context {
+	return context.WithValue(ctx, principalCtxKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal ContextWithPrincipal added to
+// ctx, and whether ctx carries one of type T.
+func PrincipalFromContext[T any](ctx context.Context) (T, bool) {
+	principal, ok := ctx.Value(principalCtxKey{}).(T)
+	return principal, ok
+}
@@ -0,0 +1,127 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestAuthenticateHeaders(t *testing.T) {
+	var calls []string
+	authenticator := func(header string) http.HeaderAuthenticator {
+		return http.HeaderAuthenticator{
+			Header: header,
+			Authenticate: func(ctx context.Context, value string) (context.Context, error) {
+				calls = append(calls, header)
+				switch value {
+				case "forbidden":
+					return nil, http.NewError(http.ErrorCodePermissionDenied, "not allowed")
+				case "valid":
+					return http.ContextWithPrincipal(ctx, header), nil
+				default:
+					return nil, errors.New("unknown key")
+				}
+			},
+		}
+	}
+	authenticators := []http.HeaderAuthenticator{authenticator("X-Api-Key"), authenticator("Authorization")}
+	serviceHeaders := []*http.Header{{Name: "x-api-key", Required: true}}
+	methodHeaders := []*http.Header{{Name: "Authorization", Required: true}}
+
+	r := httptest.NewRequest(nethttp.MethodGet, "/", nil)
+	r.Header.Set("X-Api-Key", "valid")
+	r.Header.Set("Authorization", "valid")
+	got, err := http.AuthenticateHeaders(r, authenticators, serviceHeaders, methodHeaders)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"X-Api-Key", "Authorization"}; !slices.Equal(calls, want) {
+		t.Errorf("authenticators called %q, want %q in declaration order", calls, want)
+	}
+	if principal, ok := http.PrincipalFromContext[string](got.Context()); !ok || principal != "Authorization" {
+		t.Errorf("PrincipalFromContext = %q, %v, want the last authenticator's", principal, ok)
+	}
+
+	r.Header.Set("Authorization", "stolen")
+	_, err = http.AuthenticateHeaders(r, authenticators, serviceHeaders, methodHeaders)
+	var e *http.Error
+	if !errors.As(err, &e) || e.GetCode() != http.ErrorCodeUnauthenticated {
+		t.Errorf("AuthenticateHeaders error = %v, want an UNAUTHENTICATED Error", err)
+	}
+
+	r.Header.Set("Authorization", "forbidden")
+	_, err = http.AuthenticateHeaders(r, authenticators, serviceHeaders, methodHeaders)
+	if !errors.As(err, &e) || e.GetCode() != http.ErrorCodePermissionDenied {
+		t.Errorf("AuthenticateHeaders error = %v, want the authenticator's Error", err)
+	}
+}
+
+func TestAuthenticateUndeclaredHeader(t *testing.T) {
+	authenticators := []http.HeaderAuthenticator{{
+		Header: "Authorization",
+		Authenticate: func(ctx context.Context, value string) (context.Context, error) {
+			if value != "Bearer valid" {
+				return nil, errors.New("bad token")
+			}
+			return http.ContextWithPrincipal(ctx, "alice"), nil
+		},
+	}}
+	declared := []*http.Header{{Name: "X-Request-Id", Required: true}}
+
+	r := httptest.NewRequest(nethttp.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer valid")
+	got, err := http.AuthenticateHeaders(r, authenticators, declared)
+	if principal, _ := http.PrincipalFromContext[string](got.Context()); err != nil || principal != "alice" {
+		t.Errorf("AuthenticateHeaders = %q, %v, want principal alice", principal, err)
+	}
+
+	for _, value := range []string{"Bearer forged", ""} {
+		r.Header.Set("Authorization", value)
+		_, err = http.AuthenticateHeaders(r, authenticators, declared)
+		var e *http.Error
+		if !errors.As(err, &e) || e.GetCode() != http.ErrorCodeUnauthenticated {
+			t.Errorf("Authorization %q: error = %v, want an UNAUTHENTICATED Error", value, err)
+		}
+	}
+}
+
+func TestAuthenticateOptionalHeader(t *testing.T) {
+	called := false
+	authenticators := []http.HeaderAuthenticator{{
+		Header: "X-Api-Key",
+		Authenticate: func(ctx context.Context, _ string) (context.Context, error) {
+			called = true
+			return ctx, nil
+		},
+	}}
+	r := httptest.NewRequest(nethttp.MethodGet, "/", nil)
+
+	optional := []*http.Header{{Name: "X-Api-Key"}}
+	if _, err := http.AuthenticateHeaders(r, authenticators, optional); err != nil || called {
+		t.Errorf("AuthenticateHeaders without an optional header = %v, called %v; want it skipped", err, called)
+	}
+
+	// The method declaring the header required overrides the service
+	required := []*http.Header{{Name: "x-api-key", Required: true}}
+	if _, err := http.AuthenticateHeaders(r, authenticators, optional, required); err == nil {
+		t.Error("AuthenticateHeaders without a required header succeeded, want UNAUTHENTICATED")
+	}
+}
+
+func TestPrincipalFromContext(t *testing.T) {
+	if _, ok := http.PrincipalFromContext[string](context.Background()); ok {
+		t.Error("PrincipalFromContext found a principal in an empty context")
+	}
+	ctx := http.ContextWithPrincipal(context.Background(), 42)
+	if _, ok := http.PrincipalFromContext[string](ctx); ok {
+		t.Error("PrincipalFromContext returned a principal of another type")
+	}
+	if got, ok := http.PrincipalFromContext[int](ctx); !ok || got != 42 {
+		t.Errorf("PrincipalFromContext = %v, %v, want 42", got, ok)
+	}
+}
@@ -0,0 +1,24 @@
+package http
+
+// HeaderSpec describes a header declared with service_headers or
+// method_headers. Generated servers expose the headers of each service as a
+// <Service>HeaderSpecs value, so middleware can read the declarations instead
+// of repeating them.
+type HeaderSpec struct {
+	// Name is the header name as declared.
+	Name string
+	// Type is the declared type of a value: string, integer, number, boolean
+	// or array. It is string when the declaration leaves it out.
+	Type string
+	// Format is the declared format of a value, such as uuid or date-time.
+	Format string
+	// Required is set for headers a request must carry.
+	Required bool
+	// Multiple is set for headers carrying several values.
+	Multiple bool
+	// Deprecated is set for deprecated headers.
+	Deprecated bool
+	// DefaultValue is the value a request omitting the header is treated as
+	// carrying, empty when none is declared.
+	DefaultValue string
+}
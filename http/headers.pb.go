@@ -22,6 +22,63 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// AuthType is the authentication scheme of a header
+type AuthType int32
+
+const (
+	// Not an authentication header
+	AuthType_AUTH_TYPE_UNSPECIFIED AuthType = 0
+	// An API key carried as the header value
+	AuthType_AUTH_TYPE_API_KEY AuthType = 1
+	// HTTP Bearer authentication (RFC 6750), in the Authorization header
+	AuthType_AUTH_TYPE_BEARER AuthType = 2
+	// HTTP Basic authentication (RFC 7617), in the Authorization header
+	AuthType_AUTH_TYPE_BASIC AuthType = 3
+)
+
+// Enum value maps for AuthType.
+var (
+	AuthType_name = map[int32]string{
+		0: "AUTH_TYPE_UNSPECIFIED",
+		1: "AUTH_TYPE_API_KEY",
+		2: "AUTH_TYPE_BEARER",
+		3: "AUTH_TYPE_BASIC",
+	}
+	AuthType_value = map[string]int32{
+		"AUTH_TYPE_UNSPECIFIED": 0,
+		"AUTH_TYPE_API_KEY":     1,
+		"AUTH_TYPE_BEARER":      2,
+		"AUTH_TYPE_BASIC":       3,
+	}
+)
+
+func (x AuthType) Enum() *AuthType {
+	p := new(AuthType)
+	*p = x
+	return p
+}
+
+func (x AuthType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuthType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_sebuf_http_headers_proto_enumTypes[0].Descriptor()
+}
+
+func (AuthType) Type() protoreflect.EnumType {
+	return &file_proto_sebuf_http_headers_proto_enumTypes[0]
+}
+
+func (x AuthType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuthType.Descriptor instead.
+func (AuthType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_sebuf_http_headers_proto_rawDescGZIP(), []int{0}
+}
+
 // Header definition for OpenAPI specification
 type Header struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -38,7 +95,23 @@ type Header struct {
 	// Example value for the header
 	Example string `protobuf:"bytes,6,opt,name=example,proto3" json:"example,omitempty"`
 	// Whether the header is deprecated
-	Deprecated    bool `protobuf:"varint,7,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	Deprecated bool `protobuf:"varint,7,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	// Value used when the request omits the header. Generated clients send it
+	// unless the caller sets the header, and generated servers treat a missing
+	// header as carrying it, so a required header with a default never fails
+	// validation for being absent.
+	DefaultValue string `protobuf:"bytes,8,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	// Authentication scheme the header carries. The OpenAPI generator documents
+	// such headers as security schemes instead of parameters.
+	AuthType AuthType `protobuf:"varint,9,opt,name=auth_type,json=authType,proto3,enum=sebuf.http.AuthType" json:"auth_type,omitempty"`
+	// Groups alternative authentication headers: an operation must carry every
+	// authentication header without a group, and one header of each group.
+	AuthGroup string `protobuf:"bytes,10,opt,name=auth_group,json=authGroup,proto3" json:"auth_group,omitempty"`
+	// Whether the header may carry several values, sent as repeated header lines
+	// or as one comma-separated line. Validation applies to each value, type and
+	// format describe a single value, and generated code exposes the values as a
+	// list.
+	Multiple      bool `protobuf:"varint,11,opt,name=multiple,proto3" json:"multiple,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -122,6 +195,34 @@ func (x *Header) GetDeprecated() bool {
 	return false
 }
 
+func (x *Header) GetDefaultValue() string {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return ""
+}
+
+func (x *Header) GetAuthType() AuthType {
+	if x != nil {
+		return x.AuthType
+	}
+	return AuthType_AUTH_TYPE_UNSPECIFIED
+}
+
+func (x *Header) GetAuthGroup() string {
+	if x != nil {
+		return x.AuthGroup
+	}
+	return ""
+}
+
+func (x *Header) GetMultiple() bool {
+	if x != nil {
+		return x.Multiple
+	}
+	return false
+}
+
 // Service-level headers configuration
 type ServiceHeaders struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -250,7 +351,7 @@ var File_proto_sebuf_http_headers_proto protoreflect.FileDescriptor
 const file_proto_sebuf_http_headers_proto_rawDesc = "" +
 	"\n" +
 	"\x1eproto/sebuf/http/headers.proto\x12\n" +
-	"sebuf.http\x1a google/protobuf/descriptor.proto\"\xc0\x01\n" +
+	"sebuf.http\x1a google/protobuf/descriptor.proto\"\xd3\x02\n" +
 	"\x06Header\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
@@ -260,11 +361,22 @@ const file_proto_sebuf_http_headers_proto_rawDesc = "" +
 	"\aexample\x18\x06 \x01(\tR\aexample\x12\x1e\n" +
 	"\n" +
 	"deprecated\x18\a \x01(\bR\n" +
-	"deprecated\"O\n" +
+	"deprecated\x12#\n" +
+	"\rdefault_value\x18\b \x01(\tR\fdefaultValue\x121\n" +
+	"\tauth_type\x18\t \x01(\x0e2\x14.sebuf.http.AuthTypeR\bauthType\x12\x1d\n" +
+	"\n" +
+	"auth_group\x18\n" +
+	" \x01(\tR\tauthGroup\x12\x1a\n" +
+	"\bmultiple\x18\v \x01(\bR\bmultiple\"O\n" +
 	"\x0eServiceHeaders\x12=\n" +
 	"\x10required_headers\x18\x01 \x03(\v2\x12.sebuf.http.HeaderR\x0frequiredHeaders\"N\n" +
 	"\rMethodHeaders\x12=\n" +
-	"\x10required_headers\x18\x01 \x03(\v2\x12.sebuf.http.HeaderR\x0frequiredHeaders:f\n" +
+	"\x10required_headers\x18\x01 \x03(\v2\x12.sebuf.http.HeaderR\x0frequiredHeaders*g\n" +
+	"\bAuthType\x12\x19\n" +
+	"\x15AUTH_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11AUTH_TYPE_API_KEY\x10\x01\x12\x14\n" +
+	"\x10AUTH_TYPE_BEARER\x10\x02\x12\x13\n" +
+	"\x0fAUTH_TYPE_BASIC\x10\x03:f\n" +
 	"\x0fservice_headers\x12\x1f.google.protobuf.ServiceOptions\x18Ն\x03 \x01(\v2\x1a.sebuf.http.ServiceHeadersR\x0eserviceHeaders:b\n" +
 	"\x0emethod_headers\x12\x1e.google.protobuf.MethodOptions\x18ֆ\x03 \x01(\v2\x19.sebuf.http.MethodHeadersR\rmethodHeadersB+Z)github.com/SebastienMelki/sebuf/http;httpb\x06proto3"
 
@@ -280,26 +392,29 @@ func file_proto_sebuf_http_headers_proto_rawDescGZIP() []byte {
 	return file_proto_sebuf_http_headers_proto_rawDescData
 }
 
+var file_proto_sebuf_http_headers_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_proto_sebuf_http_headers_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_proto_sebuf_http_headers_proto_goTypes = []any{
-	(*Header)(nil),                      // 0: sebuf.http.Header
-	(*ServiceHeaders)(nil),              // 1: sebuf.http.ServiceHeaders
-	(*MethodHeaders)(nil),               // 2: sebuf.http.MethodHeaders
-	(*descriptorpb.ServiceOptions)(nil), // 3: google.protobuf.ServiceOptions
-	(*descriptorpb.MethodOptions)(nil),  // 4: google.protobuf.MethodOptions
+	(AuthType)(0),                       // 0: sebuf.http.AuthType
+	(*Header)(nil),                      // 1: sebuf.http.Header
+	(*ServiceHeaders)(nil),              // 2: sebuf.http.ServiceHeaders
+	(*MethodHeaders)(nil),               // 3: sebuf.http.MethodHeaders
+	(*descriptorpb.ServiceOptions)(nil), // 4: google.protobuf.ServiceOptions
+	(*descriptorpb.MethodOptions)(nil),  // 5: google.protobuf.MethodOptions
 }
 var file_proto_sebuf_http_headers_proto_depIdxs = []int32{
-	0, // 0: sebuf.http.ServiceHeaders.required_headers:type_name -> sebuf.http.Header
-	0, // 1: sebuf.http.MethodHeaders.required_headers:type_name -> sebuf.http.Header
-	3, // 2: sebuf.http.service_headers:extendee -> google.protobuf.ServiceOptions
-	4, // 3: sebuf.http.method_headers:extendee -> google.protobuf.MethodOptions
-	1, // 4: sebuf.http.service_headers:type_name -> sebuf.http.ServiceHeaders
-	2, // 5: sebuf.http.method_headers:type_name -> sebuf.http.MethodHeaders
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	4, // [4:6] is the sub-list for extension type_name
-	2, // [2:4] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0, // 0: sebuf.http.Header.auth_type:type_name -> sebuf.http.AuthType
+	1, // 1: sebuf.http.ServiceHeaders.required_headers:type_name -> sebuf.http.Header
+	1, // 2: sebuf.http.MethodHeaders.required_headers:type_name -> sebuf.http.Header
+	4, // 3: sebuf.http.service_headers:extendee -> google.protobuf.ServiceOptions
+	5, // 4: sebuf.http.method_headers:extendee -> google.protobuf.MethodOptions
+	2, // 5: sebuf.http.service_headers:type_name -> sebuf.http.ServiceHeaders
+	3, // 6: sebuf.http.method_headers:type_name -> sebuf.http.MethodHeaders
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	5, // [5:7] is the sub-list for extension type_name
+	3, // [3:5] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_proto_sebuf_http_headers_proto_init() }
@@ -312,13 +427,14 @@ func file_proto_sebuf_http_headers_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_sebuf_http_headers_proto_rawDesc), len(file_proto_sebuf_http_headers_proto_rawDesc)),
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   3,
 			NumExtensions: 2,
 			NumServices:   0,
 		},
 		GoTypes:           file_proto_sebuf_http_headers_proto_goTypes,
 		DependencyIndexes: file_proto_sebuf_http_headers_proto_depIdxs,
+		EnumInfos:         file_proto_sebuf_http_headers_proto_enumTypes,
 		MessageInfos:      file_proto_sebuf_http_headers_proto_msgTypes,
 		ExtensionInfos:    file_proto_sebuf_http_headers_proto_extTypes,
 	}.Build()
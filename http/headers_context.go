@@ -0,0 +1,70 @@
+package http
+
+import (
+	"context"
+	nethttp "net/http"
+	"strings"
+)
+
+type headersCtxKey struct{}
+
+// ContextWithHeaders returns a copy of ctx carrying headers. Generated servers
+// call it with the declared service and method headers once they pass
+// validation, so handlers can read them with HeadersFromContext.
+func ContextWithHeaders(ctx context.Context, headers nethttp.Header) context.Context {
+	return context.WithValue(ctx, headersCtxKey{}, headers)
+}
+
+// HeadersFromContext returns the validated headers of the request being
+// handled: the declared service and method headers that are required or have
+// a default_value, with omitted ones set to their default. Other request
+// headers are not included. A header declared with multiple: true holds each
+// of its values, read with Values; Get returns the first. It returns an empty
+// Header when ctx carries none, so Get can always be called on the result.
+func HeadersFromContext(ctx context.Context) nethttp.Header {
+	if headers, ok := ctx.Value(headersCtxKey{}).(nethttp.Header); ok {
+		return headers
+	}
+	return nethttp.Header{}
+}
+
+// OutgoingHeaderPropagation returns the headers named in allowlist among the
+// validated headers of the request being handled (see HeadersFromContext), to
+// be sent on the requests a handler makes to other services. Names are matched
+// case-insensitively and returned in canonical form. The values of a header
+// declared with multiple: true are joined with ", ". It returns nil when ctx
+// carries none of them, as outside a generated handler.
+func OutgoingHeaderPropagation(ctx context.Context, allowlist []string) map[string]string {
+	if len(allowlist) == 0 {
+		return nil
+	}
+	inbound := HeadersFromContext(ctx)
+	var outgoing map[string]string
+	for _, name := range allowlist {
+		values := inbound.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+		if outgoing == nil {
+			outgoing = make(map[string]string, len(allowlist))
+		}
+		outgoing[nethttp.CanonicalHeaderKey(name)] = strings.Join(values, ", ")
+	}
+	return outgoing
+}
+
+// SplitHeaderValues returns the values of a header declared with
+// multiple: true, given its lines. A client may send the values as repeated
+// header lines, as one comma-separated line, or both, so each line is split at
+// its commas. Values are trimmed, and empty ones are dropped.
+func SplitHeaderValues(lines []string) []string {
+	var values []string
+	for _, line := range lines {
+		for value := range strings.SplitSeq(line, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
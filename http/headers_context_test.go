@@ -0,0 +1,78 @@
+package http_test
+
+import (
+	"context"
+	"maps"
+	nethttp "net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestHeadersFromContext(t *testing.T) {
+	if got := http.HeadersFromContext(context.Background()); got == nil || len(got) != 0 {
+		t.Errorf("HeadersFromContext without headers = %v, want empty non-nil Header", got)
+	}
+
+	headers := nethttp.Header{}
+	headers.Set("X-Client-Version", "1.0.0")
+	ctx := http.ContextWithHeaders(context.Background(), headers)
+
+	if got := http.HeadersFromContext(ctx).Get("x-client-version"); got != "1.0.0" {
+		t.Errorf("HeadersFromContext().Get = %q, want 1.0.0", got)
+	}
+}
+
+func TestOutgoingHeaderPropagation(t *testing.T) {
+	if got := http.OutgoingHeaderPropagation(context.Background(), []string{"X-Tenant-ID"}); got != nil {
+		t.Errorf("OutgoingHeaderPropagation without headers = %v, want nil", got)
+	}
+
+	headers := nethttp.Header{}
+	headers.Set("X-Tenant-Id", "acme")
+	headers.Set("X-Api-Key", "secret")
+	headers["Traceparent"] = []string{"00-abc-def-01"}
+	headers["X-Region"] = []string{"eu", "us"}
+	ctx := http.ContextWithHeaders(context.Background(), headers)
+
+	got := http.OutgoingHeaderPropagation(ctx, []string{"x-tenant-id", "traceparent", "X-Region", "X-Request-ID"})
+	want := map[string]string{"X-Tenant-Id": "acme", "Traceparent": "00-abc-def-01", "X-Region": "eu, us"}
+	if !maps.Equal(got, want) {
+		t.Errorf("OutgoingHeaderPropagation = %v, want %v", got, want)
+	}
+	if got := http.OutgoingHeaderPropagation(ctx, nil); got != nil {
+		t.Errorf("OutgoingHeaderPropagation without an allowlist = %v, want nil", got)
+	}
+}
+
+func TestSplitHeaderValues(t *testing.T) {
+	header := nethttp.Header{}
+	header.Add("X-Tag", "red")
+	header.Add("x-tag", " green , blue,,")
+	got, want := http.SplitHeaderValues(header.Values("X-TAG")), []string{"red", "green", "blue"}
+	if !slices.Equal(got, want) {
+		t.Errorf("SplitHeaderValues = %q, want %q", got, want)
+	}
+	if got := http.SplitHeaderValues(header.Values("X-Missing")); got != nil {
+		t.Errorf("SplitHeaderValues of a missing header = %q, want nil", got)
+	}
+}
+
+func TestPathParamFromContext(t *testing.T) {
+	if got := http.PathParamFromContext(context.Background(), "tenant_id"); got != "" {
+		t.Errorf("PathParamFromContext without params = %q, want empty", got)
+	}
+
+	var got string
+	handler := http.PathParamsMiddleware(nethttp.HandlerFunc(func(_ nethttp.ResponseWriter, r *nethttp.Request) {
+		got = http.PathParamFromContext(r.Context(), "tenant_id")
+	}), "tenant_id")
+	mux := nethttp.NewServeMux()
+	mux.Handle("GET /t/{tenant_id}/items", handler)
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(nethttp.MethodGet, "/t/acme/items", nil))
+	if got != "acme" {
+		t.Errorf("PathParamFromContext = %q, want acme", got)
+	}
+}
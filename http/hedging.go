@@ -0,0 +1,179 @@
+package http
+
+import (
+	"context"
+	"io"
+	nethttp "net/http"
+	"time"
+)
+
+// DoHedged sends req with client and, while no response has arrived, sends up
+// to maxHedges identical copies of it, one every delay. The first response to
+// arrive is returned and the other requests are cancelled, closing their
+// connections; responses arriving later are discarded. An attempt failing with
+// an error does not end the call while other attempts are in flight or hedges
+// remain: the next hedge is sent right away, and the last error is returned
+// once every attempt failed.
+//
+// A 409 Conflict or 5xx response does not win either while other attempts are
+// in flight: a sebuf server answers a hedge whose Idempotency-Key is still being
+// processed with 409 IDEMPOTENCY_IN_PROGRESS, while the original attempt goes
+// on to succeed. Such a response is returned only once no attempt remains in
+// flight, the earliest attempt's when several answered so.
+//
+// Generated clients hedge only GET methods and methods annotated with
+// idempotency: true, since the server may execute every copy. A request with a
+// body is only hedged when GetBody is set, as http.NewRequest does for
+// in-memory bodies. A non-positive delay or maxHedges sends req once.
+func DoHedged(
+	client *nethttp.Client,
+	req *nethttp.Request,
+	delay time.Duration,
+	maxHedges int,
+) (*nethttp.Response, error) {
+	rewindable := req.Body == nil || req.Body == nethttp.NoBody || req.GetBody != nil
+	if delay <= 0 || maxHedges <= 0 || !rewindable {
+		return client.Do(req)
+	}
+
+	results := make(chan hedgeResult, maxHedges+1)
+	var cancels []context.CancelFunc
+
+	send := func() error {
+		attempt := len(cancels)
+		ctx, cancel := context.WithCancel(context.WithValue(req.Context(), hedgeAttemptKey{}, attempt+1))
+		attemptReq := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				cancel()
+				return err
+			}
+			attemptReq.Body = body
+		}
+		cancels = append(cancels, cancel)
+		go func() {
+			resp, err := client.Do(attemptReq) //nolint:bodyclose // closed by the caller or discardLateResponses
+			results <- hedgeResult{attempt: attempt, resp: resp, err: err}
+		}()
+		return nil
+	}
+
+	if err := send(); err != nil {
+		return nil, err
+	}
+	inFlight := 1
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var lastErr error
+	var held *hedgeResult // A 409 or 5xx response, returned if no attempt does better
+	for {
+		select {
+		case res := <-results:
+			inFlight--
+			switch {
+			case res.err == nil && !hedgeProvisional(res.resp.StatusCode):
+				for i, cancel := range cancels {
+					if i != res.attempt {
+						cancel()
+					}
+				}
+				if held != nil {
+					_ = held.resp.Body.Close()
+				}
+				go discardLateResponses(results, inFlight)
+				res.resp.Body = &cancelOnClose{ReadCloser: res.resp.Body, cancel: cancels[res.attempt]}
+				return res.resp, nil
+			case res.err == nil && (held == nil || res.attempt < held.attempt):
+				if held != nil {
+					_ = held.resp.Body.Close()
+					cancels[held.attempt]()
+				}
+				held = &res
+			case res.err == nil:
+				_ = res.resp.Body.Close()
+				cancels[res.attempt]()
+			default:
+				cancels[res.attempt]()
+				lastErr = res.err
+			}
+			if inFlight > 0 {
+				continue
+			}
+			if held != nil {
+				held.resp.Body = &cancelOnClose{ReadCloser: held.resp.Body, cancel: cancels[held.attempt]}
+				return held.resp, nil
+			}
+			if len(cancels) > maxHedges || req.Context().Err() != nil {
+				return nil, lastErr
+			}
+			if err := send(); err != nil {
+				return nil, err
+			}
+			inFlight++
+			timer.Reset(delay)
+		case <-timer.C:
+			if len(cancels) > maxHedges {
+				continue
+			}
+			if err := send(); err != nil {
+				continue // Keep waiting for the attempts in flight
+			}
+			inFlight++
+			timer.Reset(delay)
+		}
+	}
+}
+
+// hedgeProvisional reports whether a response with status only ends a hedged
+// call once no other attempt can answer better: a conflict, such as a hedge
+// racing its original on an Idempotency-Key, or a server error.
+func hedgeProvisional(status int) bool {
+	return status == nethttp.StatusConflict || status >= nethttp.StatusInternalServerError
+}
+
+// hedgeAttemptKey is the context key of the 1-based number of the attempt a
+// hedged request was sent as.
+type hedgeAttemptKey struct{}
+
+// hedgeAttempt returns the number of the attempt resp answered: 1 for the
+// original request, 2 and up for its hedges.
+func hedgeAttempt(resp *nethttp.Response) int {
+	if resp != nil && resp.Request != nil {
+		if attempt, ok := resp.Request.Context().Value(hedgeAttemptKey{}).(int); ok {
+			return attempt
+		}
+	}
+	return 1
+}
+
+// hedgeResult is the outcome of one attempt of a hedged call.
+type hedgeResult struct {
+	attempt int
+	resp    *nethttp.Response
+	err     error
+}
+
+// discardLateResponses closes the responses of the n attempts still in flight
+// once a hedged call returned.
+func discardLateResponses(results <-chan hedgeResult, n int) {
+	for range n {
+		if res := <-results; res.err == nil {
+			_ = res.resp.Body.Close()
+		}
+	}
+}
+
+// cancelOnClose releases the context of the winning attempt once its body is
+// closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
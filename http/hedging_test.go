@@ -0,0 +1,166 @@
+package http_test
+
+import (
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestDoHedged_HedgeWins(t *testing.T) {
+	var calls atomic.Int32
+	stalledClosed := make(chan struct{})
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if calls.Add(1) == 1 {
+			<-r.Context().Done()
+			close(stalledClosed)
+			return
+		}
+		_, _ = io.WriteString(w, "hedge")
+	}))
+	defer srv.Close()
+
+	req, err := nethttp.NewRequest(nethttp.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DoHedged(srv.Client(), req, 20*time.Millisecond, 2)
+	if err != nil {
+		t.Fatalf("DoHedged: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "hedge" {
+		t.Errorf("body = %q, want the hedge's response", body)
+	}
+
+	select {
+	case <-stalledClosed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the stalled request was not cancelled")
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("server saw %d requests, want 2", n)
+	}
+}
+
+// TestDoHedged_ConflictDoesNotWin asserts a hedge answered with 409, as a
+// sebuf server answers a hedge whose Idempotency-Key is still in flight, does
+// not cancel the original attempt, whose response is returned.
+func TestDoHedged_ConflictDoesNotWin(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-time.After(100 * time.Millisecond):
+			case <-r.Context().Done():
+				return
+			}
+			_, _ = io.WriteString(w, "original")
+			return
+		}
+		w.WriteHeader(nethttp.StatusConflict)
+	}))
+	defer srv.Close()
+
+	req, err := nethttp.NewRequest(nethttp.MethodPost, srv.URL, strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DoHedged(srv.Client(), req, 10*time.Millisecond, 1)
+	if err != nil {
+		t.Fatalf("DoHedged: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != nethttp.StatusOK || string(body) != "original" {
+		t.Errorf("response = %d %q, want the original attempt's 200", resp.StatusCode, body)
+	}
+}
+
+// TestDoHedged_ServerErrorsReturnTheOriginal asserts that when every attempt
+// fails with a 5xx, the original attempt's response is returned once all of
+// them answered.
+func TestDoHedged_ServerErrorsReturnTheOriginal(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		if calls.Add(1) == 1 {
+			time.Sleep(50 * time.Millisecond)
+			w.WriteHeader(nethttp.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(nethttp.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	req, err := nethttp.NewRequest(nethttp.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DoHedged(srv.Client(), req, 10*time.Millisecond, 1)
+	if err != nil {
+		t.Fatalf("DoHedged: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != nethttp.StatusServiceUnavailable {
+		t.Errorf("status = %d, want the original attempt's 503", resp.StatusCode)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("server saw %d requests, want 2", n)
+	}
+}
+
+func TestDoHedged_NoHedgeWhenFast(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		calls.Add(1)
+		w.WriteHeader(nethttp.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	req, err := nethttp.NewRequest(nethttp.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DoHedged(srv.Client(), req, time.Second, 2)
+	if err != nil {
+		t.Fatalf("DoHedged: %v", err)
+	}
+	_ = resp.Body.Close()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("server saw %d requests, want 1", n)
+	}
+}
+
+func TestDoHedged_Disabled(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(_ nethttp.ResponseWriter, _ *nethttp.Request) {
+		calls.Add(1)
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	req, err := nethttp.NewRequest(nethttp.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DoHedged(srv.Client(), req, time.Millisecond, 0)
+	if err != nil {
+		t.Fatalf("DoHedged: %v", err)
+	}
+	_ = resp.Body.Close()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("server saw %d requests, want 1", n)
+	}
+}
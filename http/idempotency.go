@@ -0,0 +1,390 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	nethttp "net/http"
+	"sync"
+	"time"
+)
+
+// IdempotencyKeyHeader is the request header methods annotated with
+// idempotency: true are deduplicated by.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is set to "true" on responses replayed from an
+// IdempotencyStore instead of produced by the handler.
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// DefaultIdempotencyTTL is how long responses are kept when the server is not
+// configured with a TTL.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// IdempotencyRecord is the state an IdempotencyStore keeps per key.
+type IdempotencyRecord struct {
+	// RequestHash is the hex SHA-256 of the method, request URI and body of the
+	// request that claimed the key.
+	RequestHash string `json:"requestHash"`
+	// Completed is false while the request that claimed the key is running.
+	Completed bool `json:"completed"`
+	// Status, Header, and Body are the response replayed once Completed.
+	Status int            `json:"status,omitempty"`
+	Header nethttp.Header `json:"header,omitempty"`
+	Body   []byte         `json:"body,omitempty"`
+}
+
+// IdempotencyStore persists IdempotencyRecords for IdempotencyMiddleware.
+// Implementations must be safe for concurrent use, and Reserve must be atomic
+// across every server instance sharing the store (SET NX PX in Redis) so that
+// concurrent duplicates run the handler at most once. Records are plain data
+// with JSON tags, so a shared store can serialize them as JSON.
+type IdempotencyStore interface {
+	// Get returns the record stored under key. ok is false if there is none
+	// or it has expired.
+	Get(ctx context.Context, key string) (record *IdempotencyRecord, ok bool, err error)
+	// Set stores record under key for ttl, replacing any existing record.
+	Set(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error
+	// Reserve stores record under key for ttl only if key holds no record, and
+	// reports whether it did.
+	Reserve(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) (bool, error)
+	// Delete removes the record stored under key, if any.
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryIdempotencyStore is an in-process IdempotencyStore. Expired records
+// are dropped lazily. It only deduplicates requests served by the same
+// process; deployments with several replicas need a shared store.
+type MemoryIdempotencyStore struct {
+	mu        sync.Mutex
+	entries   map[string]memoryIdempotencyEntry
+	lastSweep time.Time
+}
+
+type memoryIdempotencyEntry struct {
+	record  IdempotencyRecord
+	expires time.Time
+}
+
+// memorySweepInterval bounds how often Set and Reserve scan for expired records.
+const memorySweepInterval = time.Minute
+
+// NewMemoryIdempotencyStore returns an empty MemoryIdempotencyStore.
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{entries: map[string]memoryIdempotencyEntry{}}
+}
+
+// Get implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) Get(_ context.Context, key string) (*IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok || !time.Now().Before(entry.expires) {
+		return nil, false, nil
+	}
+	record := cloneIdempotencyRecord(&entry.record)
+	return &record, true, nil
+}
+
+// Set implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) Set(
+	_ context.Context,
+	key string,
+	record *IdempotencyRecord,
+	ttl time.Duration,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.sweep(now)
+	s.entries[key] = memoryIdempotencyEntry{record: cloneIdempotencyRecord(record), expires: now.Add(ttl)}
+	return nil
+}
+
+// Reserve implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) Reserve(
+	_ context.Context,
+	key string,
+	record *IdempotencyRecord,
+	ttl time.Duration,
+) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.sweep(now)
+	if entry, ok := s.entries[key]; ok && now.Before(entry.expires) {
+		return false, nil
+	}
+	s.entries[key] = memoryIdempotencyEntry{record: cloneIdempotencyRecord(record), expires: now.Add(ttl)}
+	return true, nil
+}
+
+// Delete implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+// sweep drops expired entries at most once per memorySweepInterval. The caller
+// must hold s.mu.
+func (s *MemoryIdempotencyStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < memorySweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, entry := range s.entries {
+		if !now.Before(entry.expires) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+func cloneIdempotencyRecord(record *IdempotencyRecord) IdempotencyRecord {
+	clone := *record
+	clone.Header = record.Header.Clone()
+	clone.Body = bytes.Clone(record.Body)
+	return clone
+}
+
+// IdempotencyMiddleware deduplicates requests to next by their Idempotency-Key
+// header. Generated servers wrap the service call of every method annotated
+// with idempotency: true in it, inside request binding, so that requests are
+// deduplicated only once their headers are validated and authenticated. scope
+// namespaces the keys in store (generated servers pass the RPC's full name),
+// and keys are further namespaced by the principal of the request (see
+// ContextWithPrincipal), told apart by its fmt.Sprint form, so callers never
+// share responses. writeError writes
+// the middleware's own errors through the server's error handling. A ttl <= 0
+// selects DefaultIdempotencyTTL, and bodies over maxBodySize bytes, when it is
+// positive, are answered with ErrorCodePayloadTooLarge.
+//
+// The first request with a key reserves it and runs next. Its response is kept
+// for ttl unless it is a 5xx (or next panics), in which case the key is
+// released so the request can be retried. A later request with the same key:
+//   - replays the kept response, with IdempotentReplayedHeader set, when its
+//     method, request URI and body are identical;
+//   - fails with ErrorCodeIdempotencyConflict when they differ;
+//   - fails with ErrorCodeIdempotencyInProgress while the first request is
+//     still running, so concurrent duplicates never both reach next.
+func IdempotencyMiddleware(
+	next nethttp.Handler,
+	store IdempotencyStore,
+	scope string,
+	ttl time.Duration,
+	maxBodySize int64,
+	writeError func(nethttp.ResponseWriter, *nethttp.Request, error),
+) nethttp.Handler {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			writeError(w, r, &ValidationError{Violations: []*FieldViolation{{
+				Field:       IdempotencyKeyHeader,
+				Description: fmt.Sprintf("required header '%s' is missing", IdempotencyKeyHeader),
+			}}})
+			return
+		}
+
+		hash, err := idempotencyRequestHash(w, r, maxBodySize)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		storeKey := idempotencyStoreKey(r.Context(), scope, key)
+
+		reserved, err := store.Reserve(r.Context(), storeKey, &IdempotencyRecord{RequestHash: hash}, ttl)
+		if err != nil {
+			writeError(w, r, fmt.Errorf("idempotency store: %w", err))
+			return
+		}
+		if !reserved {
+			replayIdempotentResponse(w, r, store, storeKey, hash, writeError)
+			return
+		}
+
+		// The response is stored after the client may have gone away, so the
+		// store calls below must not inherit the request's cancellation.
+		ctx := context.WithoutCancel(r.Context())
+		stored := false
+		defer func() {
+			if !stored {
+				_ = store.Delete(ctx, storeKey)
+			}
+		}()
+
+		recorder := &idempotencyRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r)
+		recorder.snapshotHeader()
+		if recorder.status >= nethttp.StatusInternalServerError {
+			return
+		}
+		stored = store.Set(ctx, storeKey, &IdempotencyRecord{
+			RequestHash: hash,
+			Completed:   true,
+			Status:      recorder.status,
+			Header:      recorder.header,
+			Body:        recorder.body.Bytes(),
+		}, ttl) == nil
+	})
+}
+
+// idempotencyRequestHash returns the hex SHA-256 of the method, request URI
+// and body of r, reading at most maxBodySize bytes of the body when it is
+// positive. The body is left for next to read again; a form body already
+// parsed by request binding is hashed from r.PostForm, and a multipart body
+// part by part (see hashMultipartBody).
+func idempotencyRequestHash(w nethttp.ResponseWriter, r *nethttp.Request, maxBodySize int64) (string, error) {
+	reader := r.Body
+	if maxBodySize > 0 {
+		reader = nethttp.MaxBytesReader(w, r.Body, maxBodySize)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		var tooLarge *nethttp.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return "", &Error{
+				Code:    ErrorCodePayloadTooLarge,
+				Message: fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit),
+			}
+		}
+		return "", &ValidationError{Violations: []*FieldViolation{{
+			Field:       "body",
+			Description: fmt.Sprintf("failed to read request body: %v", err),
+		}}}
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	h := sha256.New()
+	for _, part := range []string{r.Method, r.URL.RequestURI(), r.PostForm.Encode()} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	if mediaType, params, parseErr := mime.ParseMediaType(r.Header.Get("Content-Type")); parseErr == nil &&
+		mediaType == MultipartContentType && hashMultipartBody(h, body, params["boundary"]) == nil {
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashMultipartBody writes the form name, filename, Content-Type and content of
+// every part of a multipart body to h. The boundary is left out, since clients
+// pick a new one for every request, retries included. It returns an error,
+// having written nothing, when body is not a valid multipart body.
+func hashMultipartBody(h io.Writer, body []byte, boundary string) error {
+	var parts bytes.Buffer
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&parts, "%q %q %q %d\x00", part.FormName(), part.FileName(), part.Header.Get("Content-Type"), len(data))
+		parts.Write(data)
+	}
+	_, err := h.Write(parts.Bytes())
+	return err
+}
+
+// idempotencyStoreKey returns the key the response to a request with
+// Idempotency-Key key is stored under: key namespaced by scope and by a digest
+// of the principal of ctx, if any.
+func idempotencyStoreKey(ctx context.Context, scope, key string) string {
+	principal := ctx.Value(principalCtxKey{})
+	if principal == nil {
+		return scope + ":" + key
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprint(principal)))
+	return scope + ":" + hex.EncodeToString(sum[:]) + ":" + key
+}
+
+// replayIdempotentResponse answers a request whose key was already reserved.
+func replayIdempotentResponse(
+	w nethttp.ResponseWriter,
+	r *nethttp.Request,
+	store IdempotencyStore,
+	storeKey, hash string,
+	writeError func(nethttp.ResponseWriter, *nethttp.Request, error),
+) {
+	record, ok, err := store.Get(r.Context(), storeKey)
+	switch {
+	case err != nil:
+		writeError(w, r, fmt.Errorf("idempotency store: %w", err))
+	case ok && record.RequestHash != hash:
+		writeError(w, r, &Error{
+			Code:    ErrorCodeIdempotencyConflict,
+			Message: "Idempotency-Key was already used with a different request",
+		})
+	case !ok || !record.Completed:
+		// !ok: the first request failed and released the key
+		// between our Reserve and Get; the client can simply retry.
+		writeError(w, r, &Error{
+			Code:    ErrorCodeIdempotencyInProgress,
+			Message: "a request with this Idempotency-Key is still being processed",
+		})
+	default:
+		header := w.Header()
+		for name, values := range record.Header {
+			header[name] = values
+		}
+		header.Set(IdempotentReplayedHeader, "true")
+		w.WriteHeader(record.Status)
+		_, _ = w.Write(record.Body)
+	}
+}
+
+// idempotencyRecorder passes a response through while keeping a copy of its
+// status, headers, and body for the IdempotencyStore.
+type idempotencyRecorder struct {
+	nethttp.ResponseWriter
+	status int
+	header nethttp.Header
+	body   bytes.Buffer
+}
+
+func (rec *idempotencyRecorder) WriteHeader(code int) {
+	if rec.status == 0 {
+		rec.status = code
+		rec.header = rec.ResponseWriter.Header().Clone()
+	}
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+func (rec *idempotencyRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.WriteHeader(nethttp.StatusOK)
+	}
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying ResponseWriter.
+func (rec *idempotencyRecorder) Unwrap() nethttp.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// snapshotHeader fills in the status and headers of a handler that returned
+// without writing anything, which net/http answers with 200 OK.
+func (rec *idempotencyRecorder) snapshotHeader() {
+	if rec.status == 0 {
+		rec.status = nethttp.StatusOK
+		rec.header = rec.ResponseWriter.Header().Clone()
+	}
+}
@@ -0,0 +1,313 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// writeTestError mirrors the status mapping of generated servers.
+func writeTestError(w nethttp.ResponseWriter, _ *nethttp.Request, err error) {
+	status := nethttp.StatusInternalServerError
+	var valErr *http.ValidationError
+	var handlerErr *http.Error
+	switch {
+	case errors.As(err, &valErr):
+		status = nethttp.StatusBadRequest
+	case errors.As(err, &handlerErr) && (handlerErr.GetCode() == http.ErrorCodeIdempotencyConflict ||
+		handlerErr.GetCode() == http.ErrorCodeIdempotencyInProgress):
+		status = nethttp.StatusConflict
+	case errors.As(err, &handlerErr) && handlerErr.GetCode() == http.ErrorCodePayloadTooLarge:
+		status = nethttp.StatusRequestEntityTooLarge
+	}
+	nethttp.Error(w, err.Error(), status)
+}
+
+func idempotentRequest(key, body string) *nethttp.Request {
+	r := httptest.NewRequest(nethttp.MethodPost, "/orders", strings.NewReader(body))
+	if key != "" {
+		r.Header.Set(http.IdempotencyKeyHeader, key)
+	}
+	return r
+}
+
+// countingHandler echoes the request body with 201 and counts its invocations.
+func countingHandler(calls *atomic.Int32) nethttp.Handler {
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		calls.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Order", "1")
+		w.WriteHeader(nethttp.StatusCreated)
+		_, _ = w.Write(body)
+	})
+}
+
+func serve(h nethttp.Handler, r *nethttp.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestIdempotencyMiddleware_MissingKey(t *testing.T) {
+	var calls atomic.Int32
+	h := http.IdempotencyMiddleware(countingHandler(&calls), http.NewMemoryIdempotencyStore(), "svc.Create", 0, 0,
+		writeTestError)
+
+	w := serve(h, idempotentRequest("", `{"a":1}`))
+	if w.Code != nethttp.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "required header 'Idempotency-Key' is missing") {
+		t.Errorf("body = %q", w.Body.String())
+	}
+	if calls.Load() != 0 {
+		t.Error("handler must not run without an Idempotency-Key")
+	}
+}
+
+func TestIdempotencyMiddleware_ReplaysIdenticalRequest(t *testing.T) {
+	var calls atomic.Int32
+	h := http.IdempotencyMiddleware(countingHandler(&calls), http.NewMemoryIdempotencyStore(), "svc.Create", 0, 0,
+		writeTestError)
+
+	first := serve(h, idempotentRequest("k1", `{"a":1}`))
+	second := serve(h, idempotentRequest("k1", `{"a":1}`))
+
+	if calls.Load() != 1 {
+		t.Fatalf("handler ran %d times, want 1", calls.Load())
+	}
+	if second.Code != nethttp.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Errorf("replay = %d %q, want %d %q", second.Code, second.Body.String(), first.Code, first.Body.String())
+	}
+	if second.Header().Get("X-Order") != "1" {
+		t.Error("replay should restore the handler's headers")
+	}
+	if second.Header().Get(http.IdempotentReplayedHeader) != "true" {
+		t.Error("replay should set Idempotent-Replayed")
+	}
+	if first.Header().Get(http.IdempotentReplayedHeader) != "" {
+		t.Error("original response must not be marked replayed")
+	}
+}
+
+func TestIdempotencyMiddleware_ConflictOnDifferentBody(t *testing.T) {
+	var calls atomic.Int32
+	h := http.IdempotencyMiddleware(countingHandler(&calls), http.NewMemoryIdempotencyStore(), "svc.Create", 0, 0,
+		writeTestError)
+
+	serve(h, idempotentRequest("k1", `{"a":1}`))
+	w := serve(h, idempotentRequest("k1", `{"a":2}`))
+
+	if w.Code != nethttp.StatusConflict {
+		t.Fatalf("status = %d, want 409", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "different request") {
+		t.Errorf("body = %q", w.Body.String())
+	}
+	if calls.Load() != 1 {
+		t.Errorf("handler ran %d times, want 1", calls.Load())
+	}
+}
+
+// multipartIdempotentRequest returns a multipart upload of content as the file
+// of a "photo" part. Every call picks a new boundary, as clients do.
+func multipartIdempotentRequest(t *testing.T, key, content string) *nethttp.Request {
+	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	if err := form.WriteField("title", "holiday"); err != nil {
+		t.Fatal(err)
+	}
+	part, err := form.CreateFormFile("photo", "photo.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = part.Write([]byte(content))
+	if err = form.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r := idempotentRequest(key, body.String())
+	r.Header.Set("Content-Type", form.FormDataContentType())
+	return r
+}
+
+func TestIdempotencyMiddleware_MultipartFiles(t *testing.T) {
+	var calls atomic.Int32
+	h := http.IdempotencyMiddleware(countingHandler(&calls), http.NewMemoryIdempotencyStore(), "svc.Upload", 0, 0,
+		writeTestError)
+
+	serve(h, multipartIdempotentRequest(t, "k1", "first photo"))
+	if w := serve(h, multipartIdempotentRequest(t, "k1", "first photo")); w.Code != nethttp.StatusCreated ||
+		w.Header().Get(http.IdempotentReplayedHeader) != "true" {
+		t.Errorf("retry with a new boundary = %d %q, want a replay", w.Code, w.Body.String())
+	}
+	if w := serve(h, multipartIdempotentRequest(t, "k1", "other photo")); w.Code != nethttp.StatusConflict {
+		t.Errorf("status with a changed file = %d, want 409", w.Code)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("handler ran %d times, want 1", calls.Load())
+	}
+}
+
+func TestIdempotencyMiddleware_KeysAreScoped(t *testing.T) {
+	var calls atomic.Int32
+	store := http.NewMemoryIdempotencyStore()
+	create := http.IdempotencyMiddleware(countingHandler(&calls), store, "svc.Create", 0, 0, writeTestError)
+	update := http.IdempotencyMiddleware(countingHandler(&calls), store, "svc.Update", 0, 0, writeTestError)
+
+	serve(create, idempotentRequest("k1", `{"a":1}`))
+	w := serve(update, idempotentRequest("k1", `{"a":2}`))
+
+	if w.Code != nethttp.StatusCreated || calls.Load() != 2 {
+		t.Errorf("same key on another method: status %d, %d calls; want 201, 2 calls", w.Code, calls.Load())
+	}
+}
+
+func TestIdempotencyMiddleware_ConcurrentDuplicateGetsInProgress(t *testing.T) {
+	var calls atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	slow := nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		close(started)
+		<-release
+		countingHandler(&calls).ServeHTTP(w, r)
+	})
+	h := http.IdempotencyMiddleware(slow, http.NewMemoryIdempotencyStore(), "svc.Create", 0, 0, writeTestError)
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- serve(h, idempotentRequest("k1", `{"a":1}`)) }()
+	<-started
+
+	// The first request holds the key, so the duplicate is rejected without
+	// reaching the handler (which would block on release).
+	duplicate := serve(h, idempotentRequest("k1", `{"a":1}`))
+	if duplicate.Code != nethttp.StatusConflict {
+		t.Fatalf("duplicate status = %d, want 409", duplicate.Code)
+	}
+	if !strings.Contains(duplicate.Body.String(), "still being processed") {
+		t.Errorf("duplicate body = %q", duplicate.Body.String())
+	}
+
+	close(release)
+	if first := <-done; first.Code != nethttp.StatusCreated {
+		t.Fatalf("first status = %d, want 201", first.Code)
+	}
+	if replay := serve(h, idempotentRequest("k1", `{"a":1}`)); replay.Code != nethttp.StatusCreated {
+		t.Errorf("replay after completion = %d, want 201", replay.Code)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("handler ran %d times, want 1", calls.Load())
+	}
+}
+
+func TestIdempotencyMiddleware_ServerErrorReleasesKey(t *testing.T) {
+	var calls atomic.Int32
+	flaky := nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(nethttp.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(nethttp.StatusOK)
+	})
+	h := http.IdempotencyMiddleware(flaky, http.NewMemoryIdempotencyStore(), "svc.Create", 0, 0, writeTestError)
+
+	if w := serve(h, idempotentRequest("k1", `{}`)); w.Code != nethttp.StatusServiceUnavailable {
+		t.Fatalf("first status = %d, want 503", w.Code)
+	}
+	if w := serve(h, idempotentRequest("k1", `{}`)); w.Code != nethttp.StatusOK {
+		t.Fatalf("retry status = %d, want 200", w.Code)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("handler ran %d times, want 2", calls.Load())
+	}
+}
+
+func TestIdempotencyMiddleware_ConflictOnDifferentURI(t *testing.T) {
+	var calls atomic.Int32
+	h := http.IdempotencyMiddleware(countingHandler(&calls), http.NewMemoryIdempotencyStore(), "svc.Refund", 0, 0,
+		writeTestError)
+
+	refund := func(target string) *nethttp.Request {
+		r := httptest.NewRequest(nethttp.MethodPost, target, strings.NewReader(`{}`))
+		r.Header.Set(http.IdempotencyKeyHeader, "k1")
+		return r
+	}
+	serve(h, refund("/orders/1/refund"))
+	for _, target := range []string{"/orders/2/refund", "/orders/1/refund?full=true"} {
+		if w := serve(h, refund(target)); w.Code != nethttp.StatusConflict {
+			t.Errorf("%s: status = %d, want 409 rather than the response for /orders/1/refund", target, w.Code)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("handler ran %d times, want 1", calls.Load())
+	}
+}
+
+func TestIdempotencyMiddleware_KeysArePerPrincipal(t *testing.T) {
+	var calls atomic.Int32
+	h := http.IdempotencyMiddleware(countingHandler(&calls), http.NewMemoryIdempotencyStore(), "svc.Create", 0, 0,
+		writeTestError)
+
+	as := func(principal string) *nethttp.Request {
+		r := idempotentRequest("k1", `{"a":1}`)
+		return r.WithContext(http.ContextWithPrincipal(r.Context(), principal))
+	}
+	serve(h, as("alice"))
+	if w := serve(h, as("mallory")); w.Header().Get(http.IdempotentReplayedHeader) != "" {
+		t.Error("a response stored for alice was replayed to mallory")
+	}
+	if w := serve(h, as("alice")); w.Header().Get(http.IdempotentReplayedHeader) != "true" {
+		t.Error("alice's retry should be replayed")
+	}
+	if calls.Load() != 2 {
+		t.Errorf("handler ran %d times, want once per principal", calls.Load())
+	}
+}
+
+func TestIdempotencyMiddleware_BodyLimit(t *testing.T) {
+	var calls atomic.Int32
+	h := http.IdempotencyMiddleware(countingHandler(&calls), http.NewMemoryIdempotencyStore(), "svc.Create", 0, 8,
+		writeTestError)
+
+	if w := serve(h, idempotentRequest("k1", `{"a":"too long"}`)); w.Code != nethttp.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want 413", w.Code)
+	}
+	if w := serve(h, idempotentRequest("k2", `{"a":1}`)); w.Code != nethttp.StatusCreated || w.Body.String() != `{"a":1}` {
+		t.Errorf("small body = %d %q, want 201 echoing it", w.Code, w.Body.String())
+	}
+	if calls.Load() != 1 {
+		t.Errorf("handler ran %d times, want 1", calls.Load())
+	}
+}
+
+func TestMemoryIdempotencyStore_Expiry(t *testing.T) {
+	ctx := context.Background()
+	store := http.NewMemoryIdempotencyStore()
+
+	reserved, err := store.Reserve(ctx, "k", &http.IdempotencyRecord{RequestHash: "h"}, 10*time.Millisecond)
+	if err != nil || !reserved {
+		t.Fatalf("Reserve = %v, %v; want true, nil", reserved, err)
+	}
+	if again, _ := store.Reserve(ctx, "k", &http.IdempotencyRecord{}, time.Minute); again {
+		t.Fatal("Reserve must fail while the key is held")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok, _ := store.Get(ctx, "k"); ok {
+		t.Error("Get should not return an expired record")
+	}
+	if again, _ := store.Reserve(ctx, "k", &http.IdempotencyRecord{}, time.Minute); !again {
+		t.Error("Reserve should succeed once the record expired")
+	}
+}
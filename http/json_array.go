@@ -0,0 +1,139 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// jsonArrayFlushItems is the number of items a JSONArrayWriter writes
+	// between flushes.
+	jsonArrayFlushItems = 64
+	// jsonArrayFlushInterval is the longest a JSONArrayWriter holds written
+	// items back from the client.
+	jsonArrayFlushInterval = 100 * time.Millisecond
+)
+
+// JSONArrayWriter writes the response of a stream_response method one item at
+// a time: a JSON array, or an object whose only key holds the array. The
+// document written is the one the buffered response would have been, so any
+// JSON client can read it; only the server's memory use changes. Generated
+// servers create one per request.
+type JSONArrayWriter struct {
+	w          nethttp.ResponseWriter
+	ctx        context.Context
+	controller *nethttp.ResponseController
+	marshal    func(proto.Message) ([]byte, error)
+	open       []byte
+	close      []byte
+	started    bool
+	pending    int
+	flushedAt  time.Time
+}
+
+// NewJSONArrayWriter returns a JSONArrayWriter answering r on w. With an empty
+// key it writes a bare array, otherwise {"key":[...]}. marshal encodes an item.
+func NewJSONArrayWriter(
+	w nethttp.ResponseWriter,
+	r *nethttp.Request,
+	key string,
+	marshal func(proto.Message) ([]byte, error),
+) *JSONArrayWriter {
+	a := &JSONArrayWriter{
+		w:          w,
+		ctx:        r.Context(),
+		controller: nethttp.NewResponseController(w),
+		marshal:    marshal,
+		open:       []byte("["),
+		close:      []byte("]"),
+	}
+	if key != "" {
+		quoted, _ := json.Marshal(key) // a string always marshals
+		a.open = append(append(append([]byte("{"), quoted...), ':'), a.open...)
+		a.close = []byte("]}")
+	}
+	return a
+}
+
+// Write writes item as the next element of the array. The first call sends the
+// 200 OK status. It returns the context error once the client is gone, so the
+// handler can stop producing items.
+func (a *JSONArrayWriter) Write(item proto.Message) error {
+	if err := a.ctx.Err(); err != nil {
+		return err
+	}
+	data, err := a.marshal(item)
+	if err != nil {
+		return fmt.Errorf("marshal stream item: %w", err)
+	}
+	separator := []byte(",")
+	if !a.started {
+		a.start()
+		separator = a.open
+	}
+	if _, err = a.w.Write(separator); err != nil {
+		return err
+	}
+	if _, err = a.w.Write(data); err != nil {
+		return err
+	}
+	a.pending++
+	if a.pending >= jsonArrayFlushItems || time.Since(a.flushedAt) >= jsonArrayFlushInterval {
+		return a.flush()
+	}
+	return nil
+}
+
+// Started reports whether the response has been started. Until it is, an
+// error can still be answered with an error response; after it, the response
+// can only be cut short.
+func (a *JSONArrayWriter) Started() bool {
+	return a.started
+}
+
+// Close ends the array, writing an empty one when no item was written, and
+// flushes the response.
+func (a *JSONArrayWriter) Close() error {
+	if !a.started {
+		a.start()
+		if _, err := a.w.Write(a.open); err != nil {
+			return err
+		}
+	}
+	if _, err := a.w.Write(a.close); err != nil {
+		return err
+	}
+	return a.flush()
+}
+
+// Flush sends the items written so far to the client. Generated servers call it
+// before aborting a failed response, so the client gets every item the handler
+// yielded before the failure.
+func (a *JSONArrayWriter) Flush() error {
+	return a.flush()
+}
+
+// start writes the response header.
+func (a *JSONArrayWriter) start() {
+	a.started = true
+	a.w.Header().Set("Content-Type", JSONContentType)
+	a.w.WriteHeader(nethttp.StatusOK)
+	a.flushedAt = time.Now()
+}
+
+// flush sends the items written so far to the client. Writers that cannot
+// flush leave them to the server's own buffering.
+func (a *JSONArrayWriter) flush() error {
+	a.pending = 0
+	a.flushedAt = time.Now()
+	if err := a.controller.Flush(); err != nil && !errors.Is(err, nethttp.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// marshalCompact encodes a FieldViolation without protojson's randomized
+// whitespace, so the tests can compare whole documents.
+func marshalCompact(m proto.Message) ([]byte, error) {
+	return []byte(`{"field":"` + m.(*http.FieldViolation).GetField() + `"}`), nil
+}
+
+func TestJSONArrayWriter(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		items []string
+		want  string
+	}{
+		{name: "bare array", items: []string{"a", "b"}, want: `[{"field":"a"},{"field":"b"}]`},
+		{name: "keyed array", key: "violations", items: []string{"a"}, want: `{"violations":[{"field":"a"}]}`},
+		{name: "empty bare array", want: `[]`},
+		{name: "empty keyed array", key: "violations", want: `{"violations":[]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			stream := http.NewJSONArrayWriter(rec, httptest.NewRequest(nethttp.MethodGet, "/", nil), tt.key, marshalCompact)
+			for _, field := range tt.items {
+				if err := stream.Write(&http.FieldViolation{Field: field}); err != nil {
+					t.Fatalf("Write: %v", err)
+				}
+			}
+			if stream.Started() != (len(tt.items) > 0) {
+				t.Errorf("Started = %v after %d items", stream.Started(), len(tt.items))
+			}
+			if err := stream.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+			if got := rec.Body.String(); got != tt.want {
+				t.Errorf("body = %s, want %s", got, tt.want)
+			}
+			if got := rec.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %q", got)
+			}
+		})
+	}
+}
+
+func TestJSONArrayWriterStopsWhenClientIsGone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := httptest.NewRequest(nethttp.MethodGet, "/", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	stream := http.NewJSONArrayWriter(rec, r, "", marshalCompact)
+	if err := stream.Write(&http.FieldViolation{Field: "a"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	cancel()
+	if err := stream.Write(&http.FieldViolation{Field: "b"}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Write after disconnect = %v, want context.Canceled", err)
+	}
+}
+
+func TestJSONArrayWriterMarshalErrorBeforeStart(t *testing.T) {
+	rec := httptest.NewRecorder()
+	marshalErr := errors.New("boom")
+	stream := http.NewJSONArrayWriter(rec, httptest.NewRequest(nethttp.MethodGet, "/", nil), "",
+		func(proto.Message) ([]byte, error) { return nil, marshalErr })
+	if err := stream.Write(&http.FieldViolation{}); !errors.Is(err, marshalErr) {
+		t.Fatalf("Write = %v, want %v", err, marshalErr)
+	}
+	if stream.Started() {
+		t.Error("a failed first item must leave the response unstarted, so an error response can still be written")
+	}
+}
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// ResolveAlternateJSONNames renames the keys of the JSON object raw that names
+// maps, the alternate names of fields, to the JSON keys of those fields.
+// protojson accepts both the proto name and the JSON name of a field;
+// generated UnmarshalJSON methods call it before reading the fields they
+// decode themselves so that those accept both as well. Like protojson, it
+// fails when a field is named both ways.
+func ResolveAlternateJSONNames(raw map[string]json.RawMessage, names map[string]string) error {
+	for _, alternate := range alternateKeys(raw, names) {
+		key := names[alternate]
+		if _, exists := raw[key]; exists {
+			return fmt.Errorf("duplicate field %q", key)
+		}
+		raw[key] = raw[alternate]
+		delete(raw, alternate)
+	}
+	return nil
+}
+
+// RejectAlternateJSONNames fails on the keys of the JSON object raw that names
+// maps, the alternate names of fields. Generated UnmarshalJSON methods of
+// messages with reject_alternate_names call it instead of
+// ResolveAlternateJSONNames.
+func RejectAlternateJSONNames(raw map[string]json.RawMessage, names map[string]string) error {
+	if alternates := alternateKeys(raw, names); len(alternates) > 0 {
+		return fmt.Errorf("unknown field %q", alternates[0])
+	}
+	return nil
+}
+
+// alternateKeys returns the keys of raw that names maps, sorted so that errors
+// name the same key on every run.
+func alternateKeys(raw map[string]json.RawMessage, names map[string]string) []string {
+	var alternates []string
+	for key := range raw {
+		if _, ok := names[key]; ok {
+			alternates = append(alternates, key)
+		}
+	}
+	slices.Sort(alternates)
+	return alternates
+}
@@ -0,0 +1,69 @@
+package http_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestResolveAlternateJSONNames(t *testing.T) {
+	names := map[string]string{"user_id": "userId", "home_postal_code": "home_postalCode"}
+	tests := []struct {
+		name    string
+		raw     map[string]json.RawMessage
+		want    map[string]json.RawMessage
+		wantErr string
+	}{
+		{
+			name: "renames alternate names",
+			raw: map[string]json.RawMessage{
+				"user_id":          json.RawMessage(`"u1"`),
+				"home_postal_code": json.RawMessage(`"75001"`),
+			},
+			want: map[string]json.RawMessage{
+				"userId":          json.RawMessage(`"u1"`),
+				"home_postalCode": json.RawMessage(`"75001"`),
+			},
+		},
+		{
+			name: "keeps JSON names and unknown keys",
+			raw:  map[string]json.RawMessage{"userId": json.RawMessage(`"u1"`), "extra": json.RawMessage(`1`)},
+			want: map[string]json.RawMessage{"userId": json.RawMessage(`"u1"`), "extra": json.RawMessage(`1`)},
+		},
+		{
+			name:    "fails on a field named both ways",
+			raw:     map[string]json.RawMessage{"userId": json.RawMessage(`"u1"`), "user_id": json.RawMessage(`"u2"`)},
+			wantErr: `duplicate field "userId"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := http.ResolveAlternateJSONNames(tt.raw, names)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.raw, tt.want) {
+				t.Errorf("raw = %v, want %v", tt.raw, tt.want)
+			}
+		})
+	}
+}
+
+func TestRejectAlternateJSONNames(t *testing.T) {
+	names := map[string]string{"user_id": "userId", "display_name": "displayName"}
+	if err := http.RejectAlternateJSONNames(map[string]json.RawMessage{"userId": nil}, names); err != nil {
+		t.Errorf("JSON names: unexpected error: %v", err)
+	}
+	err := http.RejectAlternateJSONNames(map[string]json.RawMessage{"user_id": nil, "display_name": nil}, names)
+	if want := `unknown field "display_name"`; err == nil || err.Error() != want {
+		t.Errorf("err = %v, want %q", err, want)
+	}
+}
@@ -0,0 +1,189 @@
+package http
+
+import (
+	"encoding/json"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// jsonNamingCache records, by message full name, whether MarshalProtoJSON has
+// fields to rename in a message or the messages reachable from it.
+var jsonNamingCache sync.Map //nolint:gochecknoglobals // process-wide memo of descriptor lookups
+
+// MessageJSONNaming returns the naming policy of the fields of a message: its
+// json_naming annotation, else its file's file_json_naming, else CAMEL_CASE.
+func MessageJSONNaming(md protoreflect.MessageDescriptor) JsonNaming {
+	if naming, ok := proto.GetExtension(md.Options(), E_JsonNaming).(JsonNaming); ok &&
+		naming != JsonNaming_JSON_NAMING_UNSPECIFIED {
+		return naming
+	}
+	if naming, ok := proto.GetExtension(md.ParentFile().Options(), E_FileJsonNaming).(JsonNaming); ok &&
+		naming != JsonNaming_JSON_NAMING_UNSPECIFIED {
+		return naming
+	}
+	return JsonNaming_JSON_NAMING_CAMEL_CASE
+}
+
+// JSONFieldName returns the JSON key of a field: its explicit json_name if it
+// has one, its proto name when its message's naming policy is SNAKE_CASE, and
+// its lowerCamelCase name otherwise.
+//
+// protoc fills in json_name for every field, so a json_name equal to the
+// lowerCamelCase name cannot be told apart from the default: SNAKE_CASE
+// messages name such fields after their proto name.
+func JSONFieldName(fd protoreflect.FieldDescriptor) string {
+	if fd.IsExtension() || fd.JSONName() != camelCaseJSONName(fd.Name()) {
+		return fd.JSONName()
+	}
+	if MessageJSONNaming(fd.ContainingMessage()) == JsonNaming_JSON_NAMING_SNAKE_CASE {
+		return string(fd.Name())
+	}
+	return fd.JSONName()
+}
+
+// HasJSONNaming reports whether a message, or a message reachable through its
+// fields, has fields named differently from protojson by its naming policy.
+// Generated messages for which it is true marshal with MarshalProtoJSON.
+func HasJSONNaming(md protoreflect.MessageDescriptor) bool {
+	if cached, ok := jsonNamingCache.Load(md.FullName()); ok {
+		renames, _ := cached.(bool)
+		return renames
+	}
+	renames := reachesRenamedField(md, make(map[protoreflect.FullName]bool))
+	jsonNamingCache.Store(md.FullName(), renames)
+	return renames
+}
+
+// reachesRenamedField walks the messages reachable from md, skipping those in
+// visited, until one has a field JSONFieldName renames.
+func reachesRenamedField(md protoreflect.MessageDescriptor, visited map[protoreflect.FullName]bool) bool {
+	if visited[md.FullName()] {
+		return false
+	}
+	visited[md.FullName()] = true
+	fields := md.Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		if JSONFieldName(fd) != fd.JSONName() {
+			return true
+		}
+		if valueMessage := fieldValueMessage(fd); valueMessage != nil && reachesRenamedField(valueMessage, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalProtoJSON marshals m like opts.Marshal, then renames the keys of the
+// fields of m and of its nested messages to their JSONFieldName. Messages
+// without a SNAKE_CASE policy are left as protojson marshals them. With
+// opts.UseProtoNames, every field keeps its proto name.
+func MarshalProtoJSON(opts protojson.MarshalOptions, m proto.Message) ([]byte, error) {
+	data, err := opts.Marshal(m)
+	if err != nil || opts.UseProtoNames || !HasJSONNaming(m.ProtoReflect().Descriptor()) {
+		return data, err
+	}
+	return renameJSONFields(m.ProtoReflect().Descriptor(), data)
+}
+
+// renameJSONFields renames the keys of the JSON object data, holding a message
+// of type md as protojson marshals it.
+func renameJSONFields(md protoreflect.MessageDescriptor, data json.RawMessage) (json.RawMessage, error) {
+	if !HasJSONNaming(md) {
+		return data, nil
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil || object == nil {
+		// null, or a well-known type with a non-object JSON form
+		return data, nil //nolint:nilerr // Left as protojson marshaled it
+	}
+
+	renamed := make(map[string]json.RawMessage, len(object))
+	for key, value := range object {
+		renamed[key] = value
+	}
+	fields := md.Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		value, ok := object[fd.JSONName()]
+		if !ok {
+			continue
+		}
+		value, err := renameFieldValue(fd, value)
+		if err != nil {
+			return nil, err
+		}
+		delete(renamed, fd.JSONName())
+		renamed[JSONFieldName(fd)] = value
+	}
+	return json.Marshal(renamed)
+}
+
+// renameFieldValue renames the fields of the messages a field value holds.
+func renameFieldValue(fd protoreflect.FieldDescriptor, value json.RawMessage) (json.RawMessage, error) {
+	valueMessage := fieldValueMessage(fd)
+	if valueMessage == nil || !HasJSONNaming(valueMessage) {
+		return value, nil
+	}
+	switch {
+	case fd.IsList():
+		var items []json.RawMessage
+		if err := json.Unmarshal(value, &items); err != nil {
+			return nil, err
+		}
+		for i, item := range items {
+			renamedItem, err := renameJSONFields(valueMessage, item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = renamedItem
+		}
+		return json.Marshal(items)
+	case fd.IsMap():
+		var entries map[string]json.RawMessage
+		if err := json.Unmarshal(value, &entries); err != nil {
+			return nil, err
+		}
+		for key, entry := range entries {
+			renamedEntry, err := renameJSONFields(valueMessage, entry)
+			if err != nil {
+				return nil, err
+			}
+			entries[key] = renamedEntry
+		}
+		return json.Marshal(entries)
+	default:
+		return renameJSONFields(valueMessage, value)
+	}
+}
+
+// fieldValueMessage returns the message type of a field's values (of a map
+// field's values), or nil for scalar fields.
+func fieldValueMessage(fd protoreflect.FieldDescriptor) protoreflect.MessageDescriptor {
+	if fd.IsMap() {
+		fd = fd.MapValue()
+	}
+	return fd.Message()
+}
+
+// camelCaseJSONName returns the JSON name protoc derives from a field name
+// when none is declared: underscores are dropped and the letter following one
+// is upper-cased.
+func camelCaseJSONName(name protoreflect.Name) string {
+	b := make([]byte, 0, len(name))
+	afterUnderscore := false
+	for i := range len(name) {
+		c := name[i]
+		if c != '_' {
+			if afterUnderscore && 'a' <= c && c <= 'z' {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+		}
+		afterUnderscore = c == '_'
+	}
+	return string(b)
+}
@@ -0,0 +1,158 @@
+package http_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// namingFile declares a SNAKE_CASE file whose Address message opts back into
+// lowerCamelCase.
+const namingFile = `
+name: "naming.proto"
+package: "naming"
+syntax: "proto3"
+options { [sebuf.http.file_json_naming]: JSON_NAMING_SNAKE_CASE }
+message_type {
+  name: "Order"
+  field { name: "order_id" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "orderId" }
+  field { name: "gift_note" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "giftMessage" }
+  field { name: "address" number: 3 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".naming.Address" json_name: "address" }
+  field { name: "line_items" number: 4 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".naming.Item" json_name: "lineItems" }
+  field { name: "items_by_sku" number: 5 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".naming.Order.ItemsBySkuEntry" json_name: "itemsBySku" }
+  nested_type {
+    name: "ItemsBySkuEntry"
+    field { name: "key" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "key" }
+    field { name: "value" number: 2 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".naming.Item" json_name: "value" }
+    options { map_entry: true }
+  }
+}
+message_type {
+  name: "Item"
+  field { name: "unit_count" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 json_name: "unitCount" }
+}
+message_type {
+  name: "Address"
+  field { name: "street_line" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "streetLine" }
+  options { [sebuf.http.json_naming]: JSON_NAMING_CAMEL_CASE }
+}
+message_type {
+  name: "Plain"
+  field { name: "plain_value" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "plainValue" }
+  options { [sebuf.http.json_naming]: JSON_NAMING_CAMEL_CASE }
+}
+`
+
+func namingMessages(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+	var fdp descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(namingFile), &fdp); err != nil {
+		t.Fatalf("parse descriptor: %v", err)
+	}
+	fd, err := protodesc.NewFile(&fdp, nil)
+	if err != nil {
+		t.Fatalf("build descriptor: %v", err)
+	}
+	return fd
+}
+
+func TestJSONFieldName(t *testing.T) {
+	fd := namingMessages(t)
+	order := fd.Messages().ByName("Order")
+	address := fd.Messages().ByName("Address")
+
+	tests := []struct {
+		field protoreflect.FieldDescriptor
+		want  string
+	}{
+		{order.Fields().ByName("order_id"), "order_id"},
+		{order.Fields().ByName("gift_note"), "giftMessage"},
+		{order.Fields().ByName("line_items"), "line_items"},
+		{address.Fields().ByName("street_line"), "streetLine"},
+	}
+	for _, tt := range tests {
+		if got := http.JSONFieldName(tt.field); got != tt.want {
+			t.Errorf("JSONFieldName(%s) = %q, want %q", tt.field.FullName(), got, tt.want)
+		}
+	}
+
+	if got := http.MessageJSONNaming(address); got != http.JsonNaming_JSON_NAMING_CAMEL_CASE {
+		t.Errorf("MessageJSONNaming(Address) = %v", got)
+	}
+	if !http.HasJSONNaming(order) {
+		t.Error("HasJSONNaming(Order) = false, want true")
+	}
+	if http.HasJSONNaming(fd.Messages().ByName("Plain")) {
+		t.Error("HasJSONNaming(Plain) = true, want false")
+	}
+}
+
+func TestMarshalProtoJSON(t *testing.T) {
+	fd := namingMessages(t)
+	order := dynamicpb.NewMessage(fd.Messages().ByName("Order"))
+	fields := order.Descriptor().Fields()
+	order.Set(fields.ByName("order_id"), protoreflect.ValueOfString("o-1"))
+	order.Set(fields.ByName("gift_note"), protoreflect.ValueOfString("enjoy"))
+
+	address := order.Mutable(fields.ByName("address")).Message()
+	address.Set(address.Descriptor().Fields().ByName("street_line"), protoreflect.ValueOfString("1 Main St"))
+
+	item := dynamicpb.NewMessage(fd.Messages().ByName("Item"))
+	item.Set(item.Descriptor().Fields().ByName("unit_count"), protoreflect.ValueOfInt32(3))
+	order.Mutable(fields.ByName("line_items")).List().Append(protoreflect.ValueOfMessage(item))
+	order.Mutable(fields.ByName("items_by_sku")).Map().Set(
+		protoreflect.ValueOfString("sku-1").MapKey(), protoreflect.ValueOfMessage(item),
+	)
+
+	data, err := http.MarshalProtoJSON(protojson.MarshalOptions{}, order)
+	if err != nil {
+		t.Fatalf("MarshalProtoJSON: %v", err)
+	}
+	var got map[string]any
+	if err = json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal %s: %v", data, err)
+	}
+	want := map[string]any{
+		"order_id":     "o-1",
+		"giftMessage":  "enjoy",
+		"address":      map[string]any{"streetLine": "1 Main St"},
+		"line_items":   []any{map[string]any{"unit_count": float64(3)}},
+		"items_by_sku": map[string]any{"sku-1": map[string]any{"unit_count": float64(3)}},
+	}
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("MarshalProtoJSON = %s, want %s", gotJSON, wantJSON)
+	}
+
+	// protojson reads the renamed keys back
+	decoded := dynamicpb.NewMessage(order.Descriptor())
+	if err = protojson.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("protojson.Unmarshal: %v", err)
+	}
+	if !proto.Equal(decoded, order) {
+		t.Errorf("round trip = %v, want %v", decoded, order)
+	}
+
+	// UseProtoNames already names every field after its proto name
+	got = nil
+	data, err = http.MarshalProtoJSON(protojson.MarshalOptions{UseProtoNames: true}, order)
+	if err != nil {
+		t.Fatalf("MarshalProtoJSON(UseProtoNames): %v", err)
+	}
+	if err = json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal %s: %v", data, err)
+	}
+	if _, ok := got["gift_note"]; !ok {
+		t.Errorf("UseProtoNames output %s lacks gift_note", data)
+	}
+}
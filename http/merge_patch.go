@@ -0,0 +1,129 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type mergePatchCtxKey struct{}
+
+// MergePatch records which fields of a request message a JSON Merge Patch
+// (RFC 7386) body sets, clears with null or leaves out. Generated servers bind
+// the body of methods with patch_format MERGE_PATCH to the request message as
+// usual, and handlers read the patch through the generated MergePatch<Message>
+// views. A nil MergePatch sets no field.
+type MergePatch struct {
+	desc protoreflect.MessageDescriptor
+	// members holds whether each field the patch names, by proto name, is null
+	members map[protoreflect.Name]bool
+}
+
+// ParseMergePatch reads the top-level members of body, a JSON Merge Patch of a
+// desc message. Keys naming no field of desc are left to the binding of the
+// message, which ignores or rejects them. An empty body sets no field. A null
+// member on a field without presence, which could not be told apart from its
+// zero value, is reported as a violation of that field in a *ValidationError.
+func ParseMergePatch(body []byte, desc protoreflect.MessageDescriptor) (*MergePatch, error) {
+	patch := &MergePatch{desc: desc, members: make(map[protoreflect.Name]bool)}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return patch, nil
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, errors.New("a merge patch must be a JSON object")
+	}
+	var violations []*FieldViolation
+	for key, value := range raw {
+		fd := fieldByJSONKey(desc, key)
+		if fd == nil {
+			continue
+		}
+		isNull := string(bytes.TrimSpace(value)) == "null"
+		if isNull && !fd.HasPresence() {
+			violations = append(violations, &FieldViolation{
+				Field:       string(fd.Name()),
+				Description: "cannot be null: only optional, message and oneof fields can be cleared",
+			})
+			continue
+		}
+		patch.members[fd.Name()] = isNull
+	}
+	if len(violations) > 0 {
+		sort.Slice(violations, func(i, j int) bool { return violations[i].Field < violations[j].Field })
+		return nil, &ValidationError{Violations: violations}
+	}
+	return patch, nil
+}
+
+// MergePatchOfMessage returns the patch setting the populated fields of msg,
+// for request bodies that cannot carry a merge patch, such as protobuf ones.
+// It clears no field.
+func MergePatchOfMessage(msg protoreflect.Message) *MergePatch {
+	patch := &MergePatch{desc: msg.Descriptor(), members: make(map[protoreflect.Name]bool)}
+	msg.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		patch.members[fd.Name()] = false
+		return true
+	})
+	return patch
+}
+
+// fieldByJSONKey returns the field of desc a JSON key names: its JSON name or
+// its proto name, which cover the keys JSONFieldName returns.
+func fieldByJSONKey(desc protoreflect.MessageDescriptor, key string) protoreflect.FieldDescriptor {
+	if fd := desc.Fields().ByJSONName(key); fd != nil {
+		return fd
+	}
+	return desc.Fields().ByName(protoreflect.Name(key))
+}
+
+// Has reports whether the patch names field, by its proto or JSON name, with
+// a value or with null.
+func (p *MergePatch) Has(field string) bool {
+	_, ok := p.member(field)
+	return ok
+}
+
+// IsNull reports whether the patch clears field, named by its proto or JSON
+// name, with null.
+func (p *MergePatch) IsNull(field string) bool {
+	isNull, ok := p.member(field)
+	return ok && isNull
+}
+
+// IsSet reports whether the patch sets field, named by its proto or JSON
+// name, to a value other than null.
+func (p *MergePatch) IsSet(field string) bool {
+	isNull, ok := p.member(field)
+	return ok && !isNull
+}
+
+func (p *MergePatch) member(field string) (bool, bool) {
+	if p == nil {
+		return false, false
+	}
+	fd := fieldByJSONKey(p.desc, field)
+	if fd == nil {
+		return false, false
+	}
+	isNull, ok := p.members[fd.Name()]
+	return isNull, ok
+}
+
+// ContextWithMergePatch returns a copy of ctx carrying patch. Generated
+// servers call it with the patch of each request to a method with
+// patch_format MERGE_PATCH.
+func ContextWithMergePatch(ctx context.Context, patch *MergePatch) context.Context {
+	return context.WithValue(ctx, mergePatchCtxKey{}, patch)
+}
+
+// MergePatchFromContext returns the patch of the request being handled, or nil
+// when ctx carries none, as outside a method with patch_format MERGE_PATCH.
+func MergePatchFromContext(ctx context.Context) *MergePatch {
+	patch, _ := ctx.Value(mergePatchCtxKey{}).(*MergePatch)
+	return patch
+}
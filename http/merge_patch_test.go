@@ -0,0 +1,76 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestParseMergePatch(t *testing.T) {
+	desc := (&http.HttpConfig{}).ProtoReflect().Descriptor()
+	patch, err := http.ParseMergePatch([]byte(`{"path": "/items", "timeoutMs": 5, "cache": null, "other": 1}`), desc)
+	if err != nil {
+		t.Fatalf("ParseMergePatch: %v", err)
+	}
+	tests := []struct {
+		field              string
+		has, isNull, isSet bool
+	}{
+		{"path", true, false, true},
+		{"timeout_ms", true, false, true},
+		{"timeoutMs", true, false, true},
+		{"cache", true, true, false},
+		{"method", false, false, false},
+		{"other", false, false, false},
+	}
+	for _, tt := range tests {
+		if got := patch.Has(tt.field); got != tt.has {
+			t.Errorf("Has(%q) = %v, want %v", tt.field, got, tt.has)
+		}
+		if got := patch.IsNull(tt.field); got != tt.isNull {
+			t.Errorf("IsNull(%q) = %v, want %v", tt.field, got, tt.isNull)
+		}
+		if got := patch.IsSet(tt.field); got != tt.isSet {
+			t.Errorf("IsSet(%q) = %v, want %v", tt.field, got, tt.isSet)
+		}
+	}
+
+	empty, err := http.ParseMergePatch(nil, desc)
+	if err != nil || empty.Has("path") {
+		t.Errorf("an empty body should set no field, got %v, %v", empty, err)
+	}
+	if _, err = http.ParseMergePatch([]byte(`["path"]`), desc); err == nil {
+		t.Error("a body that is not an object should be rejected")
+	}
+}
+
+func TestParseMergePatchRejectsNullsWithoutPresence(t *testing.T) {
+	desc := (&http.HttpConfig{}).ProtoReflect().Descriptor()
+	_, err := http.ParseMergePatch([]byte(`{"path": null, "stream": null, "cache": null}`), desc)
+	var validationErr *http.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("ParseMergePatch error = %v, want a *ValidationError", err)
+	}
+	if len(validationErr.Violations) != 2 || validationErr.Violations[0].Field != "path" ||
+		validationErr.Violations[1].Field != "stream" {
+		t.Errorf("violations = %v, want path and stream", validationErr.Violations)
+	}
+}
+
+func TestMergePatchOfMessage(t *testing.T) {
+	patch := http.MergePatchOfMessage((&http.HttpConfig{Path: "/items"}).ProtoReflect())
+	if !patch.IsSet("path") || patch.Has("cache") {
+		t.Errorf("the patch should set the populated fields only")
+	}
+
+	var none *http.MergePatch
+	if none.Has("path") || none.IsNull("path") || none.IsSet("path") {
+		t.Error("a nil patch should set no field")
+	}
+	ctx := http.ContextWithMergePatch(context.Background(), patch)
+	if http.MergePatchFromContext(ctx) != patch || http.MergePatchFromContext(context.Background()) != nil {
+		t.Error("MergePatchFromContext should return the patch of the context")
+	}
+}
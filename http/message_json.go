@@ -0,0 +1,40 @@
+package http
+
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// MarshalMessageJSON encodes msg as the generated servers encode JSON bodies:
+// through its generated MarshalJSONSebuf with opts when it has custom JSON
+// serialization (unwrap, flatten, encodings, naming policies), through its own
+// MarshalJSON when it has one, and with opts otherwise.
+func MarshalMessageJSON(msg proto.Message, opts protojson.MarshalOptions) ([]byte, error) {
+	if m, ok := msg.(interface {
+		MarshalJSONSebuf(opts protojson.MarshalOptions) ([]byte, error)
+	}); ok {
+		return m.MarshalJSONSebuf(opts)
+	}
+	if m, ok := msg.(json.Marshaler); ok {
+		return m.MarshalJSON()
+	}
+	return opts.Marshal(msg)
+}
+
+// UnmarshalMessageJSON decodes data into msg as the generated servers bind JSON
+// bodies: through its generated UnmarshalJSONSebuf with opts when it has
+// custom JSON serialization, through its own UnmarshalJSON when it has one,
+// and with opts otherwise.
+func UnmarshalMessageJSON(data []byte, msg proto.Message, opts protojson.UnmarshalOptions) error {
+	if u, ok := msg.(interface {
+		UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error
+	}); ok {
+		return u.UnmarshalJSONSebuf(data, opts)
+	}
+	if u, ok := msg.(json.Unmarshaler); ok {
+		return u.UnmarshalJSON(data)
+	}
+	return opts.Unmarshal(data, msg)
+}
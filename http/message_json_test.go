@@ -0,0 +1,69 @@
+package http_test
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// upperString stands for a generated message with custom JSON serialization:
+// it encodes its value upper-cased, reporting the options it was given.
+type upperString struct {
+	*wrapperspb.StringValue
+
+	marshalOpts   *protojson.MarshalOptions
+	unmarshalOpts *protojson.UnmarshalOptions
+}
+
+func (s *upperString) MarshalJSONSebuf(opts protojson.MarshalOptions) ([]byte, error) {
+	*s.marshalOpts = opts
+	return []byte(`"UPPER"`), nil
+}
+
+// MarshalJSON is the encoding/json fallback generated messages also have.
+func (s *upperString) MarshalJSON() ([]byte, error) {
+	return []byte(`"fallback"`), nil
+}
+
+func (s *upperString) UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error {
+	*s.unmarshalOpts = opts
+	s.Value = string(data)
+	return nil
+}
+
+func TestMarshalMessageJSON_CustomSerialization(t *testing.T) {
+	msg := &upperString{
+		StringValue:   wrapperspb.String("a"),
+		marshalOpts:   &protojson.MarshalOptions{},
+		unmarshalOpts: &protojson.UnmarshalOptions{},
+	}
+	data, err := http.MarshalMessageJSON(msg, protojson.MarshalOptions{EmitUnpopulated: true})
+	if err != nil || string(data) != `"UPPER"` {
+		t.Fatalf("MarshalMessageJSON = %s, %v, want MarshalJSONSebuf over MarshalJSON", data, err)
+	}
+	if !msg.marshalOpts.EmitUnpopulated {
+		t.Error("MarshalJSONSebuf did not receive the options")
+	}
+
+	err = http.UnmarshalMessageJSON([]byte(`"b"`), msg, protojson.UnmarshalOptions{DiscardUnknown: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Value != `"b"` || !msg.unmarshalOpts.DiscardUnknown {
+		t.Errorf("UnmarshalJSONSebuf got %q with %+v", msg.Value, msg.unmarshalOpts)
+	}
+}
+
+func TestMarshalMessageJSON_Protojson(t *testing.T) {
+	data, err := http.MarshalMessageJSON(wrapperspb.Int64(5), protojson.MarshalOptions{})
+	if err != nil || string(data) != `"5"` {
+		t.Fatalf("MarshalMessageJSON = %s, %v, want the protojson encoding", data, err)
+	}
+	var got wrapperspb.Int64Value
+	if err := http.UnmarshalMessageJSON(data, &got, protojson.UnmarshalOptions{}); err != nil || got.Value != 5 {
+		t.Errorf("UnmarshalMessageJSON = %v, %v", got.Value, err)
+	}
+}
@@ -0,0 +1,182 @@
+package http
+
+import (
+	"context"
+	"io"
+	nethttp "net/http"
+	"strconv"
+	"time"
+)
+
+// Metric names recorded by generated servers configured with WithMetrics. They
+// are part of the public API and do not change between releases.
+const (
+	// MetricRequestsTotal counts requests, labeled by method and code.
+	MetricRequestsTotal = "sebuf_http_requests_total"
+	// MetricRequestDuration observes request durations in seconds, labeled by method.
+	MetricRequestDuration = "sebuf_http_request_duration_seconds"
+	// MetricRequestSize observes request body sizes in bytes, labeled by method.
+	MetricRequestSize = "sebuf_http_request_size_bytes"
+	// MetricResponseSize observes response body sizes in bytes, labeled by method.
+	MetricResponseSize = "sebuf_http_response_size_bytes"
+	// MetricValidationFailures counts header and message validation violations
+	// that were enforced or warned about, labeled by method and field.
+	MetricValidationFailures = "sebuf_http_validation_failures_total"
+)
+
+// Metric label names.
+const (
+	// MetricLabelMethod is the full protobuf name of the RPC, such as
+	// "example.v1.UserService.CreateUser".
+	MetricLabelMethod = "method"
+	// MetricLabelCode is the HTTP status code of the response, such as "200".
+	MetricLabelCode = "code"
+	// MetricLabelField is the request field or header that failed validation.
+	MetricLabelField = "field"
+)
+
+// MetricsRegistry creates the metric vectors generated servers record into.
+// It is small enough to adapt to Prometheus, OpenTelemetry or any other
+// metrics library; the prometheus sub-package provides a ready adapter. The
+// label values passed to a vector are in the order of its labelNames.
+type MetricsRegistry interface {
+	CounterVec(name, help string, labelNames []string) CounterVec
+	HistogramVec(name, help string, labelNames []string) HistogramVec
+}
+
+// CounterVec is a counter partitioned by label values.
+type CounterVec interface {
+	Inc(labelValues ...string)
+}
+
+// HistogramVec is a histogram partitioned by label values.
+type HistogramVec interface {
+	Observe(value float64, labelValues ...string)
+}
+
+// ServerMetrics holds the metric vectors of a generated server. A nil
+// *ServerMetrics records nothing.
+type ServerMetrics struct {
+	requests           CounterVec
+	duration           HistogramVec
+	requestSize        HistogramVec
+	responseSize       HistogramVec
+	validationFailures CounterVec
+}
+
+// NewServerMetrics creates the server metrics in registry. It returns nil when
+// registry is nil.
+func NewServerMetrics(registry MetricsRegistry) *ServerMetrics {
+	if registry == nil {
+		return nil
+	}
+	method := []string{MetricLabelMethod}
+	return &ServerMetrics{
+		requests: registry.CounterVec(MetricRequestsTotal,
+			"Total number of HTTP requests by method and status code.",
+			[]string{MetricLabelMethod, MetricLabelCode}),
+		duration: registry.HistogramVec(MetricRequestDuration,
+			"Duration of HTTP requests in seconds.", method),
+		requestSize: registry.HistogramVec(MetricRequestSize,
+			"Size of HTTP request bodies in bytes.", method),
+		responseSize: registry.HistogramVec(MetricResponseSize,
+			"Size of HTTP response bodies in bytes.", method),
+		validationFailures: registry.CounterVec(MetricValidationFailures,
+			"Total number of request validation violations by method and field.",
+			[]string{MetricLabelMethod, MetricLabelField}),
+	}
+}
+
+// MetricsMiddleware records the requests next serves for the RPC method, its
+// full protobuf name. It returns next unchanged when metrics is nil.
+func MetricsMiddleware(next nethttp.Handler, metrics *ServerMetrics, method string) nethttp.Handler {
+	if metrics == nil {
+		return next
+	}
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		start := time.Now()
+		r = r.WithContext(context.WithValue(r.Context(), metricsCtxKey{}, metricsScope{metrics, method}))
+		body := &countingReader{ReadCloser: r.Body}
+		if r.Body != nil {
+			r.Body = body
+		}
+		recorder := &metricsRecorder{ResponseWriter: w}
+
+		next.ServeHTTP(recorder, r)
+
+		if recorder.status == 0 {
+			recorder.status = nethttp.StatusOK
+		}
+		metrics.requests.Inc(method, strconv.Itoa(recorder.status))
+		metrics.duration.Observe(time.Since(start).Seconds(), method)
+		metrics.requestSize.Observe(float64(body.n), method)
+		metrics.responseSize.Observe(float64(recorder.n), method)
+	})
+}
+
+// recordValidationFailure counts the violations of verr against the method
+// MetricsMiddleware is recording in ctx, if any.
+func recordValidationFailure(ctx context.Context, verr *ValidationError) {
+	scope, ok := ctx.Value(metricsCtxKey{}).(metricsScope)
+	if !ok {
+		return
+	}
+	for _, violation := range verr.GetViolations() {
+		scope.metrics.validationFailures.Inc(scope.method, violation.GetField())
+	}
+}
+
+type metricsCtxKey struct{}
+
+// metricsScope is the request context value MetricsMiddleware sets.
+type metricsScope struct {
+	metrics *ServerMetrics
+	method  string
+}
+
+// countingReader counts the bytes read from a request body.
+type countingReader struct {
+	io.ReadCloser
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// metricsRecorder passes a response through while keeping its status and size.
+type metricsRecorder struct {
+	nethttp.ResponseWriter
+	status int
+	n      int64
+}
+
+func (rec *metricsRecorder) WriteHeader(code int) {
+	if rec.status == 0 {
+		rec.status = code
+	}
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+func (rec *metricsRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = nethttp.StatusOK
+	}
+	n, err := rec.ResponseWriter.Write(b)
+	rec.n += int64(n)
+	return n, err
+}
+
+// Flush lets streaming handlers flush through the recorder.
+func (rec *metricsRecorder) Flush() {
+	if flusher, ok := rec.ResponseWriter.(nethttp.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying ResponseWriter.
+func (rec *metricsRecorder) Unwrap() nethttp.ResponseWriter {
+	return rec.ResponseWriter
+}
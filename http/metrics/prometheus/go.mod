@@ -0,0 +1,22 @@
+module github.com/SebastienMelki/sebuf/http/metrics/prometheus
+
+go 1.26.0
+
+require (
+	github.com/SebastienMelki/sebuf v0.0.0-20250818125809-ff61bcf670dd
+	github.com/prometheus/client_golang v1.22.0
+)
+
+require (
+	buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go v1.36.11-20260709200747-435963d16310.1 // indirect
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/prometheus/client_model v0.6.1 // indirect
+	github.com/prometheus/common v0.62.0 // indirect
+	github.com/prometheus/procfs v0.15.1 // indirect
+	golang.org/x/sys v0.30.0 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
+)
+
+replace github.com/SebastienMelki/sebuf => ../../..
@@ -0,0 +1,88 @@
+// Package prometheus adapts a Prometheus registerer to the
+// sebufhttp.MetricsRegistry generated servers record into through WithMetrics.
+// It lives in its own module so servers that do not use Prometheus do not
+// depend on client_golang.
+//
+//	mux := http.NewServeMux()
+//	err := api.RegisterUserServiceServer(server,
+//		api.WithMux(mux),
+//		api.WithMetrics(sebufprometheus.NewRegistry(prometheus.DefaultRegisterer)),
+//	)
+package prometheus
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+)
+
+// SizeBuckets are the histogram buckets of the request and response size
+// metrics: 100 bytes to 100 MB.
+var SizeBuckets = prometheus.ExponentialBuckets(100, 10, 7)
+
+// Registry implements sebufhttp.MetricsRegistry on a Prometheus registerer.
+type Registry struct {
+	registerer prometheus.Registerer
+}
+
+var _ sebufhttp.MetricsRegistry = (*Registry)(nil)
+
+// NewRegistry returns a registry that registers the server metrics with
+// registerer. Registering a metric that is already registered reuses it, so
+// several services can share one registerer.
+func NewRegistry(registerer prometheus.Registerer) *Registry {
+	return &Registry{registerer: registerer}
+}
+
+// CounterVec registers a counter vector.
+func (r *Registry) CounterVec(name, help string, labelNames []string) sebufhttp.CounterVec {
+	vec := register(r.registerer, prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labelNames))
+	return counterVec{vec}
+}
+
+// HistogramVec registers a histogram vector. Durations use the Prometheus
+// default buckets and sizes use SizeBuckets.
+func (r *Registry) HistogramVec(name, help string, labelNames []string) sebufhttp.HistogramVec {
+	buckets := prometheus.DefBuckets
+	if strings.HasSuffix(name, "_bytes") {
+		buckets = SizeBuckets
+	}
+	vec := register(r.registerer, prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets}, labelNames,
+	))
+	return histogramVec{vec}
+}
+
+// register registers collector, returning the collector already registered
+// under its name when there is one.
+func register[C prometheus.Collector](registerer prometheus.Registerer, collector C) C {
+	if err := registerer.Register(collector); err != nil {
+		var already prometheus.AlreadyRegisteredError
+		if errors.As(err, &already) {
+			if existing, ok := already.ExistingCollector.(C); ok {
+				return existing
+			}
+		}
+		panic(err)
+	}
+	return collector
+}
+
+type counterVec struct {
+	vec *prometheus.CounterVec
+}
+
+func (c counterVec) Inc(labelValues ...string) {
+	c.vec.WithLabelValues(labelValues...).Inc()
+}
+
+type histogramVec struct {
+	vec *prometheus.HistogramVec
+}
+
+func (h histogramVec) Observe(value float64, labelValues ...string) {
+	h.vec.WithLabelValues(labelValues...).Observe(value)
+}
@@ -0,0 +1,94 @@
+package http_test
+
+import (
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// fakeRegistry records every Inc and Observe as "name{label values}".
+type fakeRegistry struct {
+	mu      sync.Mutex
+	samples []string
+}
+
+func (f *fakeRegistry) CounterVec(name, _ string, _ []string) http.CounterVec {
+	return fakeVec{f, name}
+}
+
+func (f *fakeRegistry) HistogramVec(name, _ string, _ []string) http.HistogramVec {
+	return fakeVec{f, name}
+}
+
+func (f *fakeRegistry) has(sample string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Contains(f.samples, sample)
+}
+
+type fakeVec struct {
+	registry *fakeRegistry
+	name     string
+}
+
+func (v fakeVec) Inc(labelValues ...string) {
+	v.record(labelValues)
+}
+
+func (v fakeVec) Observe(_ float64, labelValues ...string) {
+	v.record(labelValues)
+}
+
+func (v fakeVec) record(labelValues []string) {
+	v.registry.mu.Lock()
+	defer v.registry.mu.Unlock()
+	v.registry.samples = append(v.registry.samples, v.name+"{"+strings.Join(labelValues, ",")+"}")
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	registry := &fakeRegistry{}
+	metrics := http.NewServerMetrics(registry)
+	handler := http.MetricsMiddleware(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		_, _ = io.ReadAll(r.Body)
+		if r.URL.Path == "/bad" {
+			_, proceed := http.ApplyValidationMode(r, http.ValidationEnforce, &http.ValidationError{
+				Violations: []*http.FieldViolation{{Field: "name", Description: "required"}},
+			}, nil)
+			if !proceed {
+				w.WriteHeader(nethttp.StatusBadRequest)
+				return
+			}
+		}
+		_, _ = io.WriteString(w, "ok")
+	}), metrics, "test.v1.Svc.Do")
+
+	for _, path := range []string{"/ok", "/bad"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(nethttp.MethodPost, path, strings.NewReader(`{}`)))
+	}
+
+	for _, want := range []string{
+		"sebuf_http_requests_total{test.v1.Svc.Do,200}",
+		"sebuf_http_requests_total{test.v1.Svc.Do,400}",
+		"sebuf_http_request_duration_seconds{test.v1.Svc.Do}",
+		"sebuf_http_request_size_bytes{test.v1.Svc.Do}",
+		"sebuf_http_response_size_bytes{test.v1.Svc.Do}",
+		"sebuf_http_validation_failures_total{test.v1.Svc.Do,name}",
+	} {
+		if !registry.has(want) {
+			t.Errorf("missing sample %s in %v", want, registry.samples)
+		}
+	}
+}
+
+func TestNewServerMetrics_NilRegistry(t *testing.T) {
+	if m := http.NewServerMetrics(nil); m != nil {
+		t.Fatalf("NewServerMetrics(nil) = %v, want nil", m)
+	}
+}
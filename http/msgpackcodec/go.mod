@@ -0,0 +1,16 @@
+module github.com/SebastienMelki/sebuf/http/msgpackcodec
+
+go 1.26.0
+
+require (
+	github.com/SebastienMelki/sebuf v0.0.0-20250818125809-ff61bcf670dd
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
+require (
+	buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go v1.36.11-20260709200747-435963d16310.1 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
+)
+
+replace github.com/SebastienMelki/sebuf => ../..
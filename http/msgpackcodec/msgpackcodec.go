@@ -0,0 +1,97 @@
+// Package msgpackcodec registers the application/msgpack codec of generated
+// servers and clients built with the extra_codecs=msgpack plugin parameter.
+// Importing it for its side effect enables the format:
+//
+//	import _ "github.com/SebastienMelki/sebuf/http/msgpackcodec"
+//
+// Bodies are converted from and to the JSON the generated code produces, so a
+// msgpack body is a map keyed by the same JSON names with the same values.
+// It lives in its own module so servers and clients that do not use msgpack
+// do not depend on it.
+package msgpackcodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+)
+
+// ContentType is the media type the codec is registered for.
+const ContentType = "application/msgpack"
+
+func init() {
+	sebufhttp.RegisterCodec(Codec{})
+}
+
+// Codec converts between JSON and msgpack.
+type Codec struct{}
+
+var _ sebufhttp.Codec = Codec{}
+
+// ContentType returns application/msgpack.
+func (Codec) ContentType() string {
+	return ContentType
+}
+
+// FromJSON converts a JSON value to msgpack. Integers stay integers; map keys
+// are written sorted so the same JSON always gives the same bytes.
+func (Codec) FromJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("msgpackcodec: decoding JSON: %w", err)
+	}
+
+	var buf bytes.Buffer
+	encoder := msgpack.NewEncoder(&buf)
+	encoder.SetSortMapKeys(true)
+	if err := encoder.Encode(fromJSONNumbers(value)); err != nil {
+		return nil, fmt.Errorf("msgpackcodec: encoding msgpack: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ToJSON converts a msgpack value to JSON.
+func (Codec) ToJSON(data []byte) ([]byte, error) {
+	var value any
+	if err := msgpack.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("msgpackcodec: decoding msgpack: %w", err)
+	}
+	out, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("msgpackcodec: encoding JSON: %w", err)
+	}
+	return out, nil
+}
+
+// fromJSONNumbers replaces the json.Numbers in a decoded JSON value with the
+// int64, uint64 or float64 they hold.
+func fromJSONNumbers(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = fromJSONNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = fromJSONNumbers(item)
+		}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return u
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+	}
+	return value
+}
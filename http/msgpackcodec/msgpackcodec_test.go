@@ -0,0 +1,59 @@
+package msgpackcodec_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/http/msgpackcodec"
+)
+
+func TestRegistered(t *testing.T) {
+	codec, ok := sebufhttp.LookupCodec(msgpackcodec.ContentType)
+	if !ok {
+		t.Fatal("no codec registered for application/msgpack")
+	}
+	if _, isMsgpack := codec.(msgpackcodec.Codec); !isMsgpack {
+		t.Errorf("codec = %T, want msgpackcodec.Codec", codec)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	const data = `{"count":3,"id":"42","price":9.5,"tags":["a","b"],` +
+		`"owner":{"displayName":"Ada"},"big":18446744073709551615}`
+	var codec msgpackcodec.Codec
+	packed, err := codec.FromJSON([]byte(data))
+	if err != nil {
+		t.Fatalf("FromJSON: %v", err)
+	}
+
+	var decoded map[string]any
+	if err = msgpack.Unmarshal(packed, &decoded); err != nil {
+		t.Fatalf("msgpack.Unmarshal: %v", err)
+	}
+	if fmt.Sprint(decoded["count"]) != "3" || decoded["id"] != "42" || decoded["price"] != 9.5 {
+		t.Errorf("decoded = %v", decoded)
+	}
+
+	out, err := codec.ToJSON(packed)
+	if err != nil {
+		t.Fatalf("ToJSON: %v", err)
+	}
+	const want = `{"big":18446744073709551615,"count":3,"id":"42",` +
+		`"owner":{"displayName":"Ada"},"price":9.5,"tags":["a","b"]}`
+	if string(out) != want {
+		t.Errorf("ToJSON = %s, want %s", out, want)
+	}
+}
+
+func TestInvalidInput(t *testing.T) {
+	var codec msgpackcodec.Codec
+	if _, err := codec.FromJSON([]byte(`{"a":`)); err == nil {
+		t.Error("FromJSON accepted truncated JSON")
+	}
+	if _, err := codec.ToJSON([]byte{0xc1}); err == nil {
+		t.Error("ToJSON accepted an invalid msgpack byte")
+	}
+}
@@ -0,0 +1,432 @@
+// Package openapitest checks that a server behaves as its OpenAPI document
+// says. VerifySpecAgainstServer sends a request to every operation of a spec
+// generated by protoc-gen-openapiv3 and validates the status and body of each
+// response against the documented responses, so drift between the spec and
+// the server fails a test:
+//
+//	spec, _ := os.ReadFile("ProductService.openapi.yaml")
+//	mux := http.NewServeMux()
+//	_ = api.RegisterProductServiceServer(impl, api.WithMux(mux))
+//	openapitest.VerifySpecAgainstServer(t, spec, mux, openapitest.Fixtures{
+//		"*": {Headers: map[string]string{"X-API-Key": testKey}},
+//	})
+//
+// Requests are built from the examples the spec documents, which carry the
+// field_examples annotations, and otherwise from the schemas: each value is
+// derived from its type, format, enum and bounds. Fixtures override any part of
+// a request, such as credentials or values a pattern constrains.
+package openapitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	yaml "go.yaml.in/yaml/v4"
+)
+
+// AllOperations is the key of a Fixtures entry applying to every operation.
+// An operation's own entry takes precedence over it, field by field.
+const AllOperations = "*"
+
+// Fixture overrides parts of the request sent to an operation.
+type Fixture struct {
+	// PathParams replace the values of path parameters, by name.
+	PathParams map[string]string
+	// Query replaces the values of query parameters, by name.
+	Query url.Values
+	// Headers are set on the request after the documented header parameters.
+	Headers map[string]string
+	// Body replaces the request body, sent with ContentType, application/json
+	// when empty.
+	Body        []byte
+	ContentType string
+	// Status is the status the response must have. When zero, any documented
+	// status is accepted.
+	Status int
+	// Skip, when set, skips the operation with this reason.
+	Skip string
+}
+
+// FixtureProvider returns the fixture of an operation, by operationId.
+type FixtureProvider interface {
+	Fixture(operationID string) (Fixture, bool)
+}
+
+// Fixtures is a FixtureProvider keyed by operationId. The AllOperations entry
+// applies to every operation.
+type Fixtures map[string]Fixture
+
+// Fixture returns the fixture of operationID merged over the AllOperations one.
+func (f Fixtures) Fixture(operationID string) (Fixture, bool) {
+	all, hasAll := f[AllOperations]
+	own, hasOwn := f[operationID]
+	if !hasOwn {
+		return all, hasAll
+	}
+	if !hasAll {
+		return own, true
+	}
+	merged := own
+	merged.PathParams = mergeMaps(all.PathParams, own.PathParams)
+	merged.Headers = mergeMaps(all.Headers, own.Headers)
+	merged.Query = url.Values(mergeMaps(all.Query, own.Query))
+	if merged.Body == nil {
+		merged.Body, merged.ContentType = all.Body, all.ContentType
+	}
+	if merged.Status == 0 {
+		merged.Status = all.Status
+	}
+	if merged.Skip == "" {
+		merged.Skip = all.Skip
+	}
+	return merged, true
+}
+
+// mergeMaps returns the entries of base overridden by those of over.
+func mergeMaps[M ~map[string]V, V any](base, over M) M {
+	if len(base) == 0 {
+		return over
+	}
+	merged := make(M, len(base)+len(over))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range over {
+		merged[k] = v
+	}
+	return merged
+}
+
+// VerifySpecAgainstServer sends a request to every operation of spec, a JSON or
+// YAML OpenAPI 3 document, through handler, and reports each response whose
+// status is not documented, whose content type is not documented for it, or
+// whose body does not match the documented schema. Each operation runs as a
+// subtest named after its operationId; mismatches name the JSON location and
+// the schema path that failed. Properties an object schema does not declare
+// are reported too, as they are drift between the spec and the server. A nil
+// fixtures sends the requests built from the spec alone.
+func VerifySpecAgainstServer(t *testing.T, spec []byte, handler http.Handler, fixtures FixtureProvider) {
+	t.Helper()
+	operations, err := loadOperations(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, op := range operations {
+		t.Run(op.id, func(t *testing.T) {
+			var fixture Fixture
+			if fixtures != nil {
+				fixture, _ = fixtures.Fixture(op.op.OperationId)
+			}
+			if fixture.Skip != "" {
+				t.Skip(fixture.Skip)
+			}
+			for _, err := range op.verify(handler, fixture) {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// loadOperations parses spec and returns its operations in document order.
+func loadOperations(spec []byte) ([]operation, error) {
+	document, err := libopenapi.NewDocument(spec)
+	if err != nil {
+		return nil, fmt.Errorf("parse OpenAPI document: %w", err)
+	}
+	model, err := document.BuildV3Model()
+	if err != nil {
+		return nil, fmt.Errorf("build OpenAPI model: %w", err)
+	}
+	if model.Model.Paths == nil {
+		return nil, fmt.Errorf("OpenAPI document has no paths")
+	}
+
+	var operations []operation
+	for path, item := range model.Model.Paths.PathItems.FromOldest() {
+		for method, op := range item.GetOperations().FromOldest() {
+			id := op.OperationId
+			if id == "" {
+				id = strings.ToUpper(method) + " " + path
+			}
+			operations = append(operations, operation{
+				id:     id,
+				method: strings.ToUpper(method),
+				path:   path,
+				params: slices.Concat(item.Parameters, op.Parameters),
+				op:     op,
+			})
+		}
+	}
+	return operations, nil
+}
+
+// operation is an operation of the spec under verification.
+type operation struct {
+	id     string
+	method string
+	path   string
+	params []*v3.Parameter
+	op     *v3.Operation
+}
+
+// verify sends the request of the operation to handler and returns the
+// mismatches between the response and the spec.
+func (o operation) verify(handler http.Handler, fixture Fixture) []error {
+	req, err := o.request(fixture)
+	if err != nil {
+		return []error{fmt.Errorf("%s: %w", o.id, err)}
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	resp := rec.Result()
+	body := rec.Body.Bytes()
+	where := fmt.Sprintf("%s: %s %s returned %d", o.id, req.Method, req.URL.RequestURI(), resp.StatusCode)
+
+	if fixture.Status != 0 && resp.StatusCode != fixture.Status {
+		return []error{fmt.Errorf("%s, want %d: %s", where, fixture.Status, truncate(body))}
+	}
+	documented := o.response(resp.StatusCode)
+	if documented == nil {
+		return []error{fmt.Errorf("%s, which the spec does not document: %s", where, truncate(body))}
+	}
+	if len(body) == 0 || documented.Content == nil || documented.Content.Len() == 0 {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return []error{fmt.Errorf("%s with an invalid Content-Type %q", where, resp.Header.Get("Content-Type"))}
+	}
+	media, ok := documented.Content.Get(mediaType)
+	if !ok {
+		return []error{fmt.Errorf("%s with Content-Type %s, which the spec does not document for it", where, mediaType)}
+	}
+	if media.Schema == nil || !isJSON(mediaType) {
+		return nil
+	}
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return []error{fmt.Errorf("%s with a body that is not JSON: %w", where, err)}
+	}
+	var errs []error
+	for _, mismatch := range validate(value, media.Schema, "$", "#", true) {
+		errs = append(errs, fmt.Errorf("%s: %w", where, mismatch))
+	}
+	return errs
+}
+
+// response returns the documented response for status: the one for the
+// exact code, then for its class such as 4XX, then the default one.
+func (o operation) response(status int) *v3.Response {
+	responses := o.op.Responses
+	if responses == nil {
+		return nil
+	}
+	if responses.Codes != nil {
+		if r, ok := responses.Codes.Get(strconv.Itoa(status)); ok {
+			return r
+		}
+		for code, r := range responses.Codes.FromOldest() {
+			if strings.EqualFold(code, strconv.Itoa(status/100)+"XX") {
+				return r
+			}
+		}
+	}
+	return responses.Default
+}
+
+// request builds the request of the operation: its documented parameters and
+// JSON body, overridden by fixture.
+func (o operation) request(fixture Fixture) (*http.Request, error) {
+	path := o.path
+	query := url.Values{}
+	header := http.Header{}
+	for _, param := range o.params {
+		switch param.In {
+		case "path":
+			value, ok := fixture.PathParams[param.Name]
+			if !ok {
+				value = paramString(paramExample(param))
+			}
+			path = strings.ReplaceAll(path, "{"+param.Name+"}", url.PathEscape(value))
+		case "query":
+			if !isRequired(param) && !hasExample(param) {
+				continue
+			}
+			example := paramExample(param)
+			if values, ok := example.([]any); ok {
+				for _, v := range values {
+					query.Add(param.Name, paramString(v))
+				}
+			} else if example != nil {
+				query.Set(param.Name, paramString(example))
+			}
+		case "header":
+			if isRequired(param) || hasExample(param) {
+				header.Set(param.Name, paramString(paramExample(param)))
+			}
+		}
+	}
+	for name, values := range fixture.Query {
+		query[name] = values
+	}
+
+	var body []byte
+	contentType := fixture.ContentType
+	switch {
+	case fixture.Body != nil:
+		body = fixture.Body
+		if contentType == "" {
+			contentType = "application/json"
+		}
+	case o.op.RequestBody != nil && o.op.RequestBody.Content != nil && o.op.RequestBody.Content.Len() > 0:
+		var err error
+		if body, contentType, err = o.requestBody(); err != nil {
+			return nil, err
+		}
+	}
+
+	target := path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req := httptest.NewRequest(o.method, target, bytes.NewReader(body))
+	req.Header = header
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for name, value := range fixture.Headers {
+		req.Header.Set(name, value)
+	}
+	return req, nil
+}
+
+// requestBody returns the documented JSON request body: the media type's
+// example, or a value derived from its schema.
+func (o operation) requestBody() ([]byte, string, error) {
+	for contentType, media := range o.op.RequestBody.Content.FromOldest() {
+		if !isJSON(contentType) {
+			continue
+		}
+		var value any
+		switch {
+		case media.Example != nil:
+			value = decodeNode(media.Example)
+		case media.Examples != nil && media.Examples.Len() > 0:
+			value = decodeNode(media.Examples.Oldest().Value.Value)
+		default:
+			value = exampleValue(media.Schema, map[string]bool{})
+		}
+		body, err := json.Marshal(value)
+		return body, contentType, err
+	}
+	return nil, "", fmt.Errorf("no JSON request body is documented; set Fixture.Body")
+}
+
+// isJSON reports whether a media type carries JSON.
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// isRequired reports whether a parameter is required.
+func isRequired(param *v3.Parameter) bool {
+	return param.Required != nil && *param.Required
+}
+
+// hasExample reports whether a parameter or its schema documents an example.
+func hasExample(param *v3.Parameter) bool {
+	if param.Example != nil || (param.Examples != nil && param.Examples.Len() > 0) {
+		return true
+	}
+	if param.Schema == nil {
+		return false
+	}
+	schema := param.Schema.Schema()
+	return schema != nil && (schema.Example != nil || len(schema.Examples) > 0)
+}
+
+// paramExample returns the documented example of a parameter, or a value
+// derived from its schema.
+func paramExample(param *v3.Parameter) any {
+	switch {
+	case param.Example != nil:
+		return decodeNode(param.Example)
+	case param.Examples != nil && param.Examples.Len() > 0:
+		return decodeNode(param.Examples.Oldest().Value.Value)
+	case param.Schema != nil:
+		return exampleValue(param.Schema, map[string]bool{})
+	default:
+		return "example"
+	}
+}
+
+// paramString formats a parameter value for a path, query or header.
+func paramString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = paramString(item)
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// decodeNode decodes a YAML node of the spec into a JSON-compatible value.
+func decodeNode(node *yaml.Node) any {
+	if node == nil {
+		return nil
+	}
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return node.Value
+	}
+	return value
+}
+
+// truncate returns body for an error message, cut after 200 bytes.
+func truncate(body []byte) string {
+	const limit = 200
+	if len(body) > limit {
+		return string(body[:limit]) + "..."
+	}
+	return string(body)
+}
+
+// resolve returns the schema of proxy and the path identifying it: the
+// reference when proxy is one, parentPath otherwise.
+func resolve(proxy *base.SchemaProxy, parentPath string) (*base.Schema, string, error) {
+	schemaPath := parentPath
+	if proxy.IsReference() {
+		schemaPath = proxy.GetReference()
+	}
+	schema := proxy.Schema()
+	if schema == nil {
+		err := proxy.GetBuildError()
+		if err == nil {
+			err = fmt.Errorf("schema cannot be built")
+		}
+		return nil, schemaPath, err
+	}
+	return schema, schemaPath, nil
+}
@@ -0,0 +1,277 @@
+package openapitest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// itemSpec documents GetItem, which reads an item by id with a required
+// request id header, and CreateItem, which echoes its JSON body.
+const itemSpec = `openapi: 3.1.0
+info:
+  title: ItemService
+  version: 1.0.0
+paths:
+  /items/{id}:
+    get:
+      operationId: GetItem
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+            format: uuid
+        - name: X-Request-Id
+          in: header
+          required: true
+          schema:
+            type: string
+        - name: view
+          in: query
+          schema:
+            type: string
+            enum: [VIEW_UNSPECIFIED, VIEW_FULL]
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Item'
+        default:
+          description: Error
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+  /items:
+    post:
+      operationId: CreateItem
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Item'
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Item'
+components:
+  schemas:
+    Item:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+          format: uuid
+        name:
+          type: string
+          minLength: 3
+          example: Widget
+        count:
+          type: integer
+          minimum: 2
+          maximum: 10
+        status:
+          type: string
+          enum: [STATUS_UNSPECIFIED, STATUS_ACTIVE]
+        tags:
+          type: array
+          minItems: 2
+          items:
+            type: string
+        createdAt:
+          type: string
+          format: date-time
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+`
+
+// itemHandler serves itemSpec, answering GetItem with item.
+func itemHandler(item string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("X-Request-Id") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"message":"missing X-Request-Id"}`)
+			return
+		}
+		_, _ = io.WriteString(w, strings.ReplaceAll(item, "$id", r.PathValue("id")))
+	})
+	mux.HandleFunc("POST /items", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.Copy(w, r.Body)
+	})
+	return mux
+}
+
+// verifyOperation verifies the operation id of itemSpec against handler.
+func verifyOperation(t *testing.T, id string, handler http.Handler, fixture Fixture) []error {
+	t.Helper()
+	operations, err := loadOperations([]byte(itemSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, op := range operations {
+		if op.id == id {
+			return op.verify(handler, fixture)
+		}
+	}
+	t.Fatalf("no operation %s", id)
+	return nil
+}
+
+func TestVerifySpecAgainstServer(t *testing.T) {
+	handler := itemHandler(`{"id":"$id","name":"Widget","count":3,"status":"STATUS_ACTIVE",` +
+		`"createdAt":"2024-01-15T10:30:00Z"}`)
+	VerifySpecAgainstServer(t, []byte(itemSpec), handler, nil)
+}
+
+func TestVerifyReportsDrift(t *testing.T) {
+	tests := []struct {
+		name    string
+		item    string
+		fixture Fixture
+		want    string
+	}{
+		{
+			name: "undocumented property",
+			item: `{"id":"$id","color":"red"}`,
+			want: `$ has the undocumented property "color" (schema #/components/schemas/Item)`,
+		},
+		{
+			name: "enum value",
+			item: `{"id":"$id","status":"STATUS_DELETED"}`,
+			want: `$.status is "STATUS_DELETED", not one of ["STATUS_UNSPECIFIED","STATUS_ACTIVE"] ` +
+				`(schema #/components/schemas/Item/properties/status)`,
+		},
+		{
+			name: "type",
+			item: `{"id":"$id","count":"3"}`,
+			want: `$.count is a JSON string, want integer`,
+		},
+		{
+			name: "integer",
+			item: `{"id":"$id","count":3.5}`,
+			want: `$.count is a JSON number, want integer`,
+		},
+		{
+			name: "bound",
+			item: `{"id":"$id","count":11}`,
+			want: `$.count is 11, above the maximum 10`,
+		},
+		{
+			name: "required",
+			item: `{"name":"Widget"}`,
+			want: `$ lacks the required property "id"`,
+		},
+		{
+			name: "format",
+			item: `{"id":"$id","createdAt":"yesterday"}`,
+			want: `$.createdAt is "yesterday", which is not a valid date-time`,
+		},
+		{
+			name:    "expected status",
+			item:    `{"id":"$id"}`,
+			fixture: Fixture{Headers: map[string]string{"X-Request-Id": ""}, Status: http.StatusOK},
+			want:    `returned 400, want 200`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := verifyOperation(t, "GetItem", itemHandler(tt.item), tt.fixture)
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.want) {
+				t.Errorf("errors = %v, want one containing %q", errs, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyReportsUndocumentedResponses(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, "created")
+	})
+	errs := verifyOperation(t, "CreateItem", handler, Fixture{})
+	const want = "Content-Type text/plain, which the spec does not document"
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), want) {
+		t.Errorf("errors = %v, want an undocumented Content-Type", errs)
+	}
+
+	handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+	errs = verifyOperation(t, "CreateItem", handler, Fixture{})
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "returned 409, which the spec does not document") {
+		t.Errorf("errors = %v, want an undocumented status", errs)
+	}
+}
+
+func TestRequestFromSpec(t *testing.T) {
+	operations, err := loadOperations([]byte(itemSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	get, create := operations[0], operations[1]
+
+	req, err := get.request(Fixture{Query: map[string][]string{"view": {"VIEW_FULL"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := req.URL.RequestURI(), "/items/123e4567-e89b-12d3-a456-426614174000?view=VIEW_FULL"; got != want {
+		t.Errorf("URI = %s, want %s", got, want)
+	}
+	if req.Header.Get("X-Request-Id") != "example" {
+		t.Errorf("X-Request-Id = %q, want the derived example", req.Header.Get("X-Request-Id"))
+	}
+
+	req, err = create.request(Fixture{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body map[string]any
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"id":        "123e4567-e89b-12d3-a456-426614174000",
+		"name":      "Widget",
+		"count":     2.0,
+		"status":    "STATUS_ACTIVE",
+		"tags":      []any{"example", "example"},
+		"createdAt": "2024-01-15T10:30:00Z",
+	}
+	if got, want := jsonString(body), jsonString(want); got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+	if req.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("Content-Type = %q", req.Header.Get("Content-Type"))
+	}
+}
+
+func TestFixturesMerge(t *testing.T) {
+	fixtures := Fixtures{
+		AllOperations: {Headers: map[string]string{"Authorization": "Bearer t", "X-Tenant": "a"}, Status: 200},
+		"GetItem":     {Headers: map[string]string{"X-Tenant": "b"}},
+	}
+	got, ok := fixtures.Fixture("GetItem")
+	if !ok || got.Headers["Authorization"] != "Bearer t" || got.Headers["X-Tenant"] != "b" || got.Status != 200 {
+		t.Errorf("Fixture(GetItem) = %+v, %v", got, ok)
+	}
+	if got, ok := fixtures.Fixture("CreateItem"); !ok || got.Headers["X-Tenant"] != "a" {
+		t.Errorf("Fixture(CreateItem) = %+v, %v", got, ok)
+	}
+}
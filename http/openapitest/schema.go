@@ -0,0 +1,577 @@
+package openapitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	yaml "go.yaml.in/yaml/v4"
+)
+
+// maxExampleDepth bounds the nesting of derived example objects.
+const maxExampleDepth = 4
+
+// exampleValue returns the documented example of a schema, or a value derived
+// from it. visiting holds the references being derived, so recursive schemas
+// end in an omitted value.
+func exampleValue(proxy *base.SchemaProxy, visiting map[string]bool) any {
+	if proxy == nil || len(visiting) > maxExampleDepth {
+		return nil
+	}
+	if ref := proxy.GetReference(); ref != "" {
+		if visiting[ref] {
+			return nil
+		}
+		visiting[ref] = true
+		defer delete(visiting, ref)
+	}
+	schema := proxy.Schema()
+	if schema == nil {
+		return nil
+	}
+	switch {
+	case schema.Example != nil:
+		return decodeNode(schema.Example)
+	case len(schema.Examples) > 0:
+		return decodeNode(schema.Examples[0])
+	case schema.Const != nil:
+		return decodeNode(schema.Const)
+	case len(schema.Enum) > 0:
+		return enumExample(schema)
+	case len(schema.OneOf) > 0:
+		return exampleValue(schema.OneOf[0], visiting)
+	case len(schema.AnyOf) > 0:
+		return exampleValue(schema.AnyOf[0], visiting)
+	case len(schema.AllOf) > 0:
+		merged := map[string]any{}
+		for _, member := range schema.AllOf {
+			if object, ok := exampleValue(member, visiting).(map[string]any); ok {
+				for k, v := range object {
+					merged[k] = v
+				}
+			}
+		}
+		return merged
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		object := map[string]any{}
+		if schema.Properties != nil {
+			for name, property := range schema.Properties.FromOldest() {
+				if value := exampleValue(property, visiting); value != nil {
+					object[name] = value
+				}
+			}
+		}
+		return object
+	case "array":
+		if schema.Items == nil || !schema.Items.IsA() {
+			return []any{}
+		}
+		item := exampleValue(schema.Items.A, visiting)
+		if item == nil {
+			return []any{}
+		}
+		count := 1
+		if schema.MinItems != nil && *schema.MinItems > 1 {
+			count = int(*schema.MinItems)
+		}
+		items := make([]any, count)
+		for i := range items {
+			items[i] = item
+		}
+		return items
+	case "string":
+		return stringExample(schema)
+	case "integer":
+		return numberExample(schema, true)
+	case "number":
+		return numberExample(schema, false)
+	case "boolean":
+		return true
+	default:
+		return nil
+	}
+}
+
+// schemaType returns the type of a schema other than null, "" when none is
+// declared.
+func schemaType(schema *base.Schema) string {
+	for _, typ := range schema.Type {
+		if typ != "null" {
+			return typ
+		}
+	}
+	return ""
+}
+
+// enumExample returns the first enum value that does not look like an
+// unspecified proto enum value, or the first value.
+func enumExample(schema *base.Schema) any {
+	for _, node := range schema.Enum {
+		if !strings.Contains(strings.ToLower(node.Value), "unspecified") {
+			return decodeNode(node)
+		}
+	}
+	return decodeNode(schema.Enum[0])
+}
+
+// formatExamples are the derived values of strings by format.
+var formatExamples = map[string]string{
+	"uuid":      "123e4567-e89b-12d3-a456-426614174000",
+	"date":      "2024-01-15",
+	"date-time": "2024-01-15T10:30:00Z",
+	"time":      "10:30:00",
+	"email":     "user@example.com",
+	"uri":       "https://example.com",
+	"url":       "https://example.com",
+	"hostname":  "example.com",
+	"ipv4":      "192.0.2.1",
+	"ip":        "192.0.2.1",
+	"ipv6":      "2001:db8::1",
+	"byte":      "ZXhhbXBsZQ==",
+	"base64url": "ZXhhbXBsZQ",
+	"hex":       "6578616d706c65",
+	"int64":     "1",
+	"uint64":    "1",
+}
+
+// stringExample returns a string of the schema's format, within its length
+// bounds.
+func stringExample(schema *base.Schema) string {
+	value, ok := formatExamples[schema.Format]
+	if !ok {
+		value = "example"
+	}
+	if schema.MinLength != nil {
+		for utf8.RuneCountInString(value) < int(*schema.MinLength) {
+			value += "x"
+		}
+	}
+	if schema.MaxLength != nil && utf8.RuneCountInString(value) > int(*schema.MaxLength) {
+		value = string([]rune(value)[:*schema.MaxLength])
+	}
+	return value
+}
+
+// numberExample returns a number within the schema's bounds: 1 when that is
+// within them, the nearest bound otherwise.
+func numberExample(schema *base.Schema, integer bool) any {
+	value := 1.0
+	switch schema.Format {
+	case "unix-timestamp":
+		value = 1705314600
+	case "unix-timestamp-ms":
+		value = 1705314600000
+	}
+	if minimum, exclusive := lowerBound(schema); !math.IsInf(minimum, -1) && value <= minimum {
+		value = minimum
+		if exclusive {
+			value = math.Floor(minimum) + 1
+		}
+	}
+	if maximum, exclusive := upperBound(schema); !math.IsInf(maximum, 1) && value >= maximum {
+		value = maximum
+		if exclusive {
+			value = math.Ceil(maximum) - 1
+		}
+	}
+	if integer {
+		return int64(value)
+	}
+	return value
+}
+
+// lowerBound returns the minimum of a schema and whether it is exclusive,
+// -Inf when there is none.
+func lowerBound(schema *base.Schema) (float64, bool) {
+	if e := schema.ExclusiveMinimum; e != nil {
+		if e.IsB() {
+			return e.B, true
+		}
+		if e.A && schema.Minimum != nil {
+			return *schema.Minimum, true
+		}
+	}
+	if schema.Minimum != nil {
+		return *schema.Minimum, false
+	}
+	return math.Inf(-1), false
+}
+
+// upperBound returns the maximum of a schema and whether it is exclusive,
+// +Inf when there is none.
+func upperBound(schema *base.Schema) (float64, bool) {
+	if e := schema.ExclusiveMaximum; e != nil {
+		if e.IsB() {
+			return e.B, true
+		}
+		if e.A && schema.Maximum != nil {
+			return *schema.Maximum, true
+		}
+	}
+	if schema.Maximum != nil {
+		return *schema.Maximum, false
+	}
+	return math.Inf(1), false
+}
+
+// schemaError is a value that does not match its schema.
+type schemaError struct {
+	// location is the JSON path of the value, such as $.items[0].id.
+	location string
+	// schemaPath identifies the schema, such as
+	// #/components/schemas/Item/properties/id.
+	schemaPath string
+	message    string
+}
+
+func (e *schemaError) Error() string {
+	return fmt.Sprintf("%s %s (schema %s)", e.location, e.message, e.schemaPath)
+}
+
+// validate returns the mismatches between value, decoded with UseNumber, and
+// the schema of proxy. location and parentPath are the JSON path of value and
+// the schema path of proxy when it is not a reference. With strict set,
+// properties an object schema does not declare are mismatches.
+func validate(value any, proxy *base.SchemaProxy, location, parentPath string, strict bool) []error {
+	schema, schemaPath, err := resolve(proxy, parentPath)
+	if err != nil {
+		return []error{&schemaError{location, schemaPath, "cannot be checked: " + err.Error()}}
+	}
+	mismatch := func(format string, args ...any) []error {
+		return []error{&schemaError{location, schemaPath, fmt.Sprintf(format, args...)}}
+	}
+
+	if value == nil {
+		if slices.Contains(schema.Type, "null") || (schema.Nullable != nil && *schema.Nullable) {
+			return nil
+		}
+		if len(schema.Type) > 0 {
+			return mismatch("is null, want %s", strings.Join(schema.Type, " or "))
+		}
+	}
+	if len(schema.Enum) > 0 && !slices.ContainsFunc(schema.Enum, func(e *yaml.Node) bool {
+		return jsonEqual(value, decodeNode(e))
+	}) {
+		return mismatch("is %s, not one of %s", jsonString(value), enumString(schema))
+	}
+	if schema.Const != nil && !jsonEqual(value, decodeNode(schema.Const)) {
+		return mismatch("is %s, want %s", jsonString(value), jsonString(decodeNode(schema.Const)))
+	}
+
+	var errs []error
+	if len(schema.Type) > 0 {
+		typ := jsonType(value)
+		// An integer is a number too.
+		if !slices.ContainsFunc(schema.Type, func(t string) bool {
+			return t == typ || (t == "number" && typ == "integer")
+		}) {
+			return mismatch("is a JSON %s, want %s", typ, strings.Join(schema.Type, " or "))
+		}
+		switch v := value.(type) {
+		case string:
+			errs = append(errs, validateString(v, schema, mismatch)...)
+		case json.Number:
+			errs = append(errs, validateNumber(v, schema, mismatch)...)
+		case []any:
+			errs = append(errs, validateArray(v, schema, location, schemaPath, mismatch)...)
+		case map[string]any:
+			errs = append(errs, validateObject(v, schema, location, schemaPath, strict, mismatch)...)
+		}
+	} else if object, ok := value.(map[string]any); ok && (schema.Properties != nil || len(schema.Required) > 0) {
+		errs = append(errs, validateObject(object, schema, location, schemaPath, strict, mismatch)...)
+	}
+	return append(errs, validateComposition(value, schema, location, schemaPath, strict, mismatch)...)
+}
+
+// validateString checks the length, pattern and format of a string.
+func validateString(value string, schema *base.Schema, mismatch func(string, ...any) []error) []error {
+	length := int64(utf8.RuneCountInString(value))
+	switch {
+	case schema.MinLength != nil && length < *schema.MinLength:
+		return mismatch("has %d characters, fewer than the minimum %d", length, *schema.MinLength)
+	case schema.MaxLength != nil && length > *schema.MaxLength:
+		return mismatch("has %d characters, more than the maximum %d", length, *schema.MaxLength)
+	}
+	if schema.Pattern != "" {
+		if re, err := regexp.Compile(schema.Pattern); err == nil && !re.MatchString(value) {
+			return mismatch("is %q, which does not match the pattern %s", value, schema.Pattern)
+		}
+	}
+	if !validFormat(value, schema.Format) {
+		return mismatch("is %q, which is not a valid %s", value, schema.Format)
+	}
+	return nil
+}
+
+// uuidPattern matches the canonical textual form of a UUID.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// validFormat reports whether a string has the given format. Only the formats
+// whose encoding the generators choose are checked; other formats are
+// accepted as they are.
+func validFormat(value, format string) bool {
+	switch format {
+	case "uuid":
+		return uuidPattern.MatchString(value)
+	case "date":
+		_, err := time.Parse(time.DateOnly, value)
+		return err == nil
+	case "date-time":
+		_, err := time.Parse(time.RFC3339Nano, value)
+		return err == nil
+	case "int64", "uint64":
+		n, ok := new(big.Int).SetString(value, 10)
+		return ok && (format == "int64" || n.Sign() >= 0)
+	default:
+		return true
+	}
+}
+
+// validateNumber checks that a number is an integer when required and within
+// the bounds of its schema.
+func validateNumber(value json.Number, schema *base.Schema, mismatch func(string, ...any) []error) []error {
+	if slices.Contains(schema.Type, "integer") && !slices.Contains(schema.Type, "number") &&
+		strings.ContainsAny(value.String(), ".eE") {
+		if f, err := value.Float64(); err != nil || f != math.Trunc(f) {
+			return mismatch("is %s, want an integer", value)
+		}
+	}
+	f, err := value.Float64()
+	if err != nil {
+		return mismatch("is %s, which is not a number", value)
+	}
+	if minimum, exclusive := lowerBound(schema); f < minimum || (exclusive && f == minimum) {
+		return mismatch("is %s, below the minimum %v", value, minimum)
+	}
+	if maximum, exclusive := upperBound(schema); f > maximum || (exclusive && f == maximum) {
+		return mismatch("is %s, above the maximum %v", value, maximum)
+	}
+	return nil
+}
+
+// validateArray checks the length and items of an array.
+func validateArray(
+	value []any,
+	schema *base.Schema,
+	location, schemaPath string,
+	mismatch func(string, ...any) []error,
+) []error {
+	switch {
+	case schema.MinItems != nil && int64(len(value)) < *schema.MinItems:
+		return mismatch("has %d items, fewer than the minimum %d", len(value), *schema.MinItems)
+	case schema.MaxItems != nil && int64(len(value)) > *schema.MaxItems:
+		return mismatch("has %d items, more than the maximum %d", len(value), *schema.MaxItems)
+	}
+	if schema.Items == nil || !schema.Items.IsA() {
+		return nil
+	}
+	var errs []error
+	for i, item := range value {
+		errs = append(errs, validate(item, schema.Items.A, fmt.Sprintf("%s[%d]", location, i),
+			schemaPath+"/items", true)...)
+	}
+	return errs
+}
+
+// validateObject checks the required, declared and additional properties of
+// an object.
+func validateObject(
+	value map[string]any,
+	schema *base.Schema,
+	location, schemaPath string,
+	strict bool,
+	mismatch func(string, ...any) []error,
+) []error {
+	var errs []error
+	for _, name := range schema.Required {
+		if _, ok := value[name]; !ok {
+			errs = append(errs, mismatch("lacks the required property %q", name)...)
+		}
+	}
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		propertyLocation := location + "." + name
+		if schema.Properties != nil {
+			if property, ok := schema.Properties.Get(name); ok {
+				errs = append(errs, validate(value[name], property, propertyLocation,
+					schemaPath+"/properties/"+name, true)...)
+				continue
+			}
+		}
+		additional := schema.AdditionalProperties
+		switch {
+		case additional != nil && additional.IsA():
+			errs = append(errs, validate(value[name], additional.A, propertyLocation,
+				schemaPath+"/additionalProperties", true)...)
+		case additional != nil && !additional.B:
+			errs = append(errs, mismatch("has the property %q, which additionalProperties: false forbids", name)...)
+		case additional == nil && strict && !declaredByComposition(schema, name) &&
+			(schema.Properties != nil && schema.Properties.Len() > 0):
+			errs = append(errs, mismatch("has the undocumented property %q", name)...)
+		}
+	}
+	return errs
+}
+
+// declaredByComposition reports whether a member of the schema's allOf
+// declares the property name.
+func declaredByComposition(schema *base.Schema, name string) bool {
+	for _, member := range schema.AllOf {
+		if s := member.Schema(); s != nil && s.Properties != nil {
+			if _, ok := s.Properties.Get(name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateComposition checks value against the allOf, oneOf and anyOf members
+// of a schema.
+func validateComposition(
+	value any,
+	schema *base.Schema,
+	location, schemaPath string,
+	strict bool,
+	mismatch func(string, ...any) []error,
+) []error {
+	var errs []error
+	for i, member := range schema.AllOf {
+		// Members declare part of the properties each, so none of them is strict.
+		errs = append(errs, validate(value, member, location, fmt.Sprintf("%s/allOf/%d", schemaPath, i), false)...)
+	}
+	if strict && len(schema.AllOf) > 0 && schema.Properties == nil {
+		if object, ok := value.(map[string]any); ok {
+			for name := range object {
+				if !declaredByComposition(schema, name) {
+					errs = append(errs, mismatch("has the undocumented property %q", name)...)
+				}
+			}
+		}
+	}
+
+	if len(schema.OneOf) > 0 {
+		var matched []int
+		var memberErrs []error
+		for i, member := range schema.OneOf {
+			memberErr := validate(value, member, location, fmt.Sprintf("%s/oneOf/%d", schemaPath, i), strict)
+			if len(memberErr) == 0 {
+				matched = append(matched, i)
+			}
+			memberErrs = append(memberErrs, memberErr...)
+		}
+		switch len(matched) {
+		case 0:
+			errs = append(errs, mismatch("matches none of the oneOf schemas: %v", joinErrors(memberErrs))...)
+		case 1:
+		default:
+			errs = append(errs, mismatch("matches the oneOf schemas %v, want exactly one", matched)...)
+		}
+	}
+
+	if len(schema.AnyOf) > 0 {
+		var memberErrs []error
+		for i, member := range schema.AnyOf {
+			memberErr := validate(value, member, location, fmt.Sprintf("%s/anyOf/%d", schemaPath, i), strict)
+			if len(memberErr) == 0 {
+				return errs
+			}
+			memberErrs = append(memberErrs, memberErr...)
+		}
+		errs = append(errs, mismatch("matches none of the anyOf schemas: %v", joinErrors(memberErrs))...)
+	}
+	return errs
+}
+
+// joinErrors joins the messages of errs with semicolons.
+func joinErrors(errs []error) string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// jsonType returns the JSON Schema type of a value decoded with UseNumber.
+func jsonType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			if f, err := v.Float64(); err != nil || f != math.Trunc(f) {
+				return "number"
+			}
+		}
+		return "integer"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// jsonEqual reports whether two values have the same JSON encoding, comparing
+// numbers by value.
+func jsonEqual(a, b any) bool {
+	if n, ok := a.(json.Number); ok {
+		fa, errA := n.Float64()
+		fb, errB := toFloat(b)
+		return errA == nil && errB == nil && fa == fb
+	}
+	return jsonString(a) == jsonString(b)
+}
+
+// toFloat converts a number decoded from YAML to float64.
+func toFloat(value any) (float64, error) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("%v is not a number", value)
+	}
+}
+
+// jsonString returns the JSON encoding of a value.
+func jsonString(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// enumString returns the enum values of a schema as a JSON array.
+func enumString(schema *base.Schema) string {
+	values := make([]any, len(schema.Enum))
+	for i, node := range schema.Enum {
+		values[i] = decodeNode(node)
+	}
+	return jsonString(values)
+}
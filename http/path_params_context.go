@@ -0,0 +1,42 @@
+package http
+
+import (
+	"context"
+	nethttp "net/http"
+)
+
+type pathParamsCtxKey struct{}
+
+// PathParamsMiddleware stores the values of the named path wildcards of each
+// request in its context, where handlers read them with PathParamFromContext.
+// Generated servers wrap the handlers of services whose base path has
+// parameters, such as tenant_id in /t/{tenant_id}/api/v1, with it.
+func PathParamsMiddleware(next nethttp.Handler, names ...string) nethttp.Handler {
+	return PathParamsMiddlewareFunc(next, (*nethttp.Request).PathValue, names...)
+}
+
+// PathParamsMiddlewareFunc is PathParamsMiddleware reading the path wildcards
+// with pathValue, for routers that do not set them on the request, such as chi
+// and gorilla/mux.
+func PathParamsMiddlewareFunc(
+	next nethttp.Handler,
+	pathValue func(r *nethttp.Request, name string) string,
+	names ...string,
+) nethttp.Handler {
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		params := make(map[string]string, len(names))
+		for _, name := range names {
+			params[name] = pathValue(r, name)
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), pathParamsCtxKey{}, params)))
+	})
+}
+
+// PathParamFromContext returns the value of the base path parameter name of the
+// request being handled, or "" when ctx carries none. Parameters bound to a
+// request field can also be read from the request; those declared with
+// context_only can only be read here.
+func PathParamFromContext(ctx context.Context, name string) string {
+	params, _ := ctx.Value(pathParamsCtxKey{}).(map[string]string)
+	return params[name]
+}
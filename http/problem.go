@@ -0,0 +1,251 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"mime"
+	nethttp "net/http"
+	"strconv"
+	"strings"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ProblemTypeDefault is the type of the Problem Details generated servers
+// write: the problem is described by its status alone (RFC 9457 section 4.2.1).
+const ProblemTypeDefault = "about:blank"
+
+// ProblemDetails is an application/problem+json body (RFC 9457), as written by
+// generated servers with WithProblemJSON or error_format PROBLEM_JSON. The
+// code and details of an Error, and the violations of a ValidationError, are
+// carried in extension members so that clients can rebuild the typed error.
+type ProblemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Code is the Error.Code of the error, when it has one
+	Code string `json:"code,omitempty"`
+	// Details are the Error.Details of the error
+	Details map[string]string `json:"details,omitempty"`
+	// Errors are the violations of a ValidationError, one per field
+	Errors []ProblemFieldError `json:"errors,omitempty"`
+}
+
+// ProblemFieldError is a field violation of a ValidationError, locating the
+// field in the request body with a JSON Pointer (RFC 6901).
+type ProblemFieldError struct {
+	Pointer string `json:"pointer"`
+	Detail  string `json:"detail"`
+}
+
+// NewProblemDetails returns the Problem Details of err answered with status,
+// for the request to instance (its path). The violations of a ValidationError
+// are located by the JSONPointer of their field.
+func NewProblemDetails(err error, status int, instance string) *ProblemDetails {
+	return newProblemDetails(err, status, instance, nil)
+}
+
+// newProblemDetails is NewProblemDetails, locating the violations in pointers
+// by the JSON Pointer recorded for them.
+func newProblemDetails(
+	err error,
+	status int,
+	instance string,
+	pointers map[*FieldViolation]string,
+) *ProblemDetails {
+	problem := &ProblemDetails{
+		Type:     ProblemTypeDefault,
+		Title:    nethttp.StatusText(status),
+		Status:   status,
+		Detail:   err.Error(),
+		Instance: instance,
+	}
+	var valErr *ValidationError
+	if errors.As(err, &valErr) {
+		for _, violation := range valErr.GetViolations() {
+			pointer, ok := pointers[violation]
+			if !ok {
+				pointer = JSONPointer(violation.GetField())
+			}
+			problem.Errors = append(problem.Errors, ProblemFieldError{
+				Pointer: pointer,
+				Detail:  violation.GetDescription(),
+			})
+		}
+		return problem
+	}
+	var handlerErr *Error
+	if errors.As(err, &handlerErr) {
+		problem.Detail = handlerErr.GetMessage()
+		problem.Code = handlerErr.GetCode()
+		problem.Details = handlerErr.GetDetails()
+	}
+	return problem
+}
+
+// WriteProblem writes the Problem Details of err with status as the response
+// to r. When the status of w was already written, only the body is. Violations
+// recorded by WithViolationPointers are located by their pointer.
+func WriteProblem(w nethttp.ResponseWriter, r *nethttp.Request, err error, status int, wroteHeader bool) {
+	pointers, _ := r.Context().Value(violationPointersCtxKey{}).(map[*FieldViolation]string)
+	body, marshalErr := json.Marshal(newProblemDetails(err, status, r.URL.Path, pointers))
+	if marshalErr != nil {
+		if !wroteHeader {
+			nethttp.Error(w, "error processing request", status)
+		}
+		return
+	}
+	if !wroteHeader {
+		w.Header().Set("Content-Type", ProblemContentType)
+		w.WriteHeader(status)
+	}
+	_, _ = w.Write(body)
+}
+
+// IsProblemContentType reports whether contentType, as read from a
+// Content-Type header, is application/problem+json.
+func IsProblemContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == ProblemContentType
+}
+
+// ParseProblemDetails reads an application/problem+json body.
+func ParseProblemDetails(body []byte) (*ProblemDetails, error) {
+	var problem ProblemDetails
+	if err := json.Unmarshal(body, &problem); err != nil {
+		return nil, err
+	}
+	return &problem, nil
+}
+
+// Err returns the typed error the Problem Details describe: a ValidationError
+// when they hold field errors, and an Error with their code and details
+// otherwise.
+func (p *ProblemDetails) Err() error {
+	if len(p.Errors) > 0 {
+		valErr := &ValidationError{}
+		for _, fieldErr := range p.Errors {
+			valErr.Violations = append(valErr.Violations, &FieldViolation{
+				Field:       FieldFromJSONPointer(fieldErr.Pointer),
+				Description: fieldErr.Detail,
+			})
+		}
+		return valErr
+	}
+	message := p.Detail
+	if message == "" {
+		message = p.Title
+	}
+	return &Error{Message: message, Code: p.Code, Details: p.Details}
+}
+
+type violationPointersCtxKey struct{}
+
+// WithViolationPointers returns r recording the JSON Pointer of each violation
+// in pointers, for WriteProblem. Generated servers record those of the
+// violations of a request body, built by ViolationPointer, since the dotted
+// FieldViolation.Field names fields by their proto name and leaves out list
+// indexes and map keys.
+func WithViolationPointers(r *nethttp.Request, pointers map[*FieldViolation]string) *nethttp.Request {
+	if len(pointers) == 0 {
+		return r
+	}
+	if recorded, ok := r.Context().Value(violationPointersCtxKey{}).(map[*FieldViolation]string); ok {
+		merged := make(map[*FieldViolation]string, len(recorded)+len(pointers))
+		maps.Copy(merged, recorded)
+		maps.Copy(merged, pointers)
+		pointers = merged
+	}
+	return r.WithContext(context.WithValue(r.Context(), violationPointersCtxKey{}, pointers))
+}
+
+// ViolationPointer returns the JSON Pointer (RFC 6901) of the field at path, as
+// reported by protovalidate, in the JSON of a message of type md: fields are
+// named by their JSON key, as JSONFieldName names them, followed by the index
+// of a list element or the key of a map entry. items[2].street_name is
+// /items/2/streetName.
+func ViolationPointer(md protoreflect.MessageDescriptor, path *validate.FieldPath) string {
+	var b strings.Builder
+	for _, element := range path.GetElements() {
+		var fd protoreflect.FieldDescriptor
+		if md != nil {
+			fd = md.Fields().ByNumber(protoreflect.FieldNumber(element.GetFieldNumber()))
+		}
+		b.WriteByte('/')
+		if fd == nil {
+			b.WriteString(jsonPointerEscaper.Replace(element.GetFieldName()))
+			md = nil
+		} else {
+			b.WriteString(jsonPointerEscaper.Replace(JSONFieldName(fd)))
+			if fd.IsMap() {
+				fd = fd.MapValue()
+			}
+			md = fd.Message()
+		}
+		if token, ok := subscriptToken(element); ok {
+			b.WriteByte('/')
+			b.WriteString(jsonPointerEscaper.Replace(token))
+		}
+	}
+	return b.String()
+}
+
+// subscriptToken returns the reference token of the list index or map key of
+// element, if it has one.
+func subscriptToken(element *validate.FieldPathElement) (string, bool) {
+	switch subscript := element.GetSubscript().(type) {
+	case *validate.FieldPathElement_Index:
+		return strconv.FormatUint(subscript.Index, 10), true
+	case *validate.FieldPathElement_BoolKey:
+		return strconv.FormatBool(subscript.BoolKey), true
+	case *validate.FieldPathElement_IntKey:
+		return strconv.FormatInt(subscript.IntKey, 10), true
+	case *validate.FieldPathElement_UintKey:
+		return strconv.FormatUint(subscript.UintKey, 10), true
+	case *validate.FieldPathElement_StringKey:
+		return subscript.StringKey, true
+	default:
+		return "", false
+	}
+}
+
+// jsonPointerEscaper escapes the reference tokens of a JSON Pointer in a single
+// pass, so that the ~ of an escaped / is not escaped again.
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// jsonPointerUnescaper reverses jsonPointerEscaper in a single pass, so that
+// ~01 reads as ~1 rather than /.
+var jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// JSONPointer returns the JSON Pointer (RFC 6901) of a dotted field path, as
+// held by FieldViolation.Field: address.street is /address/street. A / or ~ in
+// a segment, as in a map key, is escaped as ~1 or ~0.
+func JSONPointer(field string) string {
+	if field == "" {
+		return ""
+	}
+	var b strings.Builder
+	for _, segment := range strings.Split(field, ".") {
+		b.WriteByte('/')
+		b.WriteString(jsonPointerEscaper.Replace(segment))
+	}
+	return b.String()
+}
+
+// FieldFromJSONPointer returns the dotted field path of a JSON Pointer, the
+// reverse of JSONPointer.
+func FieldFromJSONPointer(pointer string) string {
+	if pointer == "" {
+		return ""
+	}
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = jsonPointerUnescaper.Replace(segment)
+	}
+	return strings.Join(segments, ".")
+}
@@ -0,0 +1,198 @@
+package http_test
+
+import (
+	"encoding/json"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestJSONPointerEscaping(t *testing.T) {
+	tests := []struct {
+		field   string
+		pointer string
+	}{
+		{"", ""},
+		{"name", "/name"},
+		{"address.street", "/address/street"},
+		{"labels.a/b", "/labels/a~1b"},
+		{"labels.a~b", "/labels/a~0b"},
+		{"labels.~/", "/labels/~0~1"},
+		{"labels.~1", "/labels/~01"},
+	}
+	for _, tt := range tests {
+		if got := http.JSONPointer(tt.field); got != tt.pointer {
+			t.Errorf("JSONPointer(%q) = %q, want %q", tt.field, got, tt.pointer)
+		}
+		if got := http.FieldFromJSONPointer(tt.pointer); got != tt.field {
+			t.Errorf("FieldFromJSONPointer(%q) = %q, want %q", tt.pointer, got, tt.field)
+		}
+	}
+}
+
+func TestViolationPointer(t *testing.T) {
+	fd := namingMessages(t)
+	order := fd.Messages().ByName("Order")
+	element := func(name string, number int32) *validate.FieldPathElement {
+		return &validate.FieldPathElement{FieldName: proto.String(name), FieldNumber: proto.Int32(number)}
+	}
+	indexed := func(name string, number int32, index uint64) *validate.FieldPathElement {
+		e := element(name, number)
+		e.Subscript = &validate.FieldPathElement_Index{Index: index}
+		return e
+	}
+	keyed := func(name string, number int32, key string) *validate.FieldPathElement {
+		e := element(name, number)
+		e.Subscript = &validate.FieldPathElement_StringKey{StringKey: key}
+		return e
+	}
+
+	tests := []struct {
+		name     string
+		elements []*validate.FieldPathElement
+		want     string
+	}{
+		{"multi-word field", []*validate.FieldPathElement{element("address", 3), element("street_line", 1)},
+			"/address/streetLine"},
+		{"repeated element", []*validate.FieldPathElement{indexed("line_items", 4, 2), element("unit_count", 1)},
+			"/line_items/2/unit_count"},
+		{"map entry", []*validate.FieldPathElement{keyed("items_by_sku", 5, "a/b"), element("unit_count", 1)},
+			"/items_by_sku/a~1b/unit_count"},
+		{"unknown field", []*validate.FieldPathElement{element("gone_field", 9)}, "/gone_field"},
+	}
+	for _, tt := range tests {
+		path := &validate.FieldPath{Elements: tt.elements}
+		if got := http.ViolationPointer(order, path); got != tt.want {
+			t.Errorf("%s: ViolationPointer = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	plain := fd.Messages().ByName("Plain")
+	path := &validate.FieldPath{Elements: []*validate.FieldPathElement{element("plain_value", 1)}}
+	if got := http.ViolationPointer(plain, path); got != "/plainValue" {
+		t.Errorf("ViolationPointer in a lowerCamelCase message = %q, want /plainValue", got)
+	}
+}
+
+func TestWriteProblemViolationPointers(t *testing.T) {
+	violation := &http.FieldViolation{Field: "line_items.street_name", Description: "too short"}
+	valErr := &http.ValidationError{Violations: []*http.FieldViolation{
+		violation,
+		{Field: "address.street_line", Description: "required"},
+	}}
+	r := httptest.NewRequest(nethttp.MethodPost, "/v1/orders", nil)
+	r = http.WithViolationPointers(r, map[*http.FieldViolation]string{violation: "/lineItems/2/streetName"})
+
+	rec := httptest.NewRecorder()
+	http.WriteProblem(rec, r, valErr, nethttp.StatusBadRequest, false)
+	problem, err := http.ParseProblemDetails(rec.Body.Bytes())
+	if err != nil {
+		t.Fatalf("ParseProblemDetails: %v", err)
+	}
+	want := []http.ProblemFieldError{
+		{Pointer: "/lineItems/2/streetName", Detail: "too short"},
+		{Pointer: "/address/street_line", Detail: "required"},
+	}
+	if !reflect.DeepEqual(problem.Errors, want) {
+		t.Errorf("errors = %+v, want %+v", problem.Errors, want)
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		want   http.ProblemDetails
+	}{
+		{
+			name: "validation error",
+			err: &http.ValidationError{Violations: []*http.FieldViolation{
+				{Field: "labels.a/b", Description: "too long"},
+			}},
+			status: nethttp.StatusBadRequest,
+			want: http.ProblemDetails{
+				Type: http.ProblemTypeDefault, Title: "Bad Request", Status: nethttp.StatusBadRequest,
+				Detail: "validation error: labels.a/b: too long", Instance: "/v1/notes",
+				Errors: []http.ProblemFieldError{{Pointer: "/labels/a~1b", Detail: "too long"}},
+			},
+		},
+		{
+			name:   "handler error",
+			err:    http.WithDetail(http.NewError(http.ErrorCodeNotFound, "no such note"), "id", "7"),
+			status: nethttp.StatusNotFound,
+			want: http.ProblemDetails{
+				Type: http.ProblemTypeDefault, Title: "Not Found", Status: nethttp.StatusNotFound,
+				Detail: "no such note", Instance: "/v1/notes", Code: http.ErrorCodeNotFound,
+				Details: map[string]string{"id": "7"},
+			},
+		},
+		{
+			name:   "plain error",
+			err:    errors.New("boom"),
+			status: nethttp.StatusInternalServerError,
+			want: http.ProblemDetails{
+				Type: http.ProblemTypeDefault, Title: "Internal Server Error",
+				Status: nethttp.StatusInternalServerError, Detail: "boom", Instance: "/v1/notes",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			http.WriteProblem(rec, httptest.NewRequest(nethttp.MethodPost, "/v1/notes", nil), tt.err, tt.status, false)
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if got := rec.Header().Get("Content-Type"); !http.IsProblemContentType(got) {
+				t.Errorf("Content-Type = %q, want %s", got, http.ProblemContentType)
+			}
+			problem, err := http.ParseProblemDetails(rec.Body.Bytes())
+			if err != nil {
+				t.Fatalf("ParseProblemDetails: %v", err)
+			}
+			if !reflect.DeepEqual(*problem, tt.want) {
+				t.Errorf("problem = %+v, want %+v", *problem, tt.want)
+			}
+		})
+	}
+}
+
+func TestProblemDetailsErr(t *testing.T) {
+	var problem http.ProblemDetails
+	body := `{"type":"about:blank","title":"Bad Request","status":400,` +
+		`"errors":[{"pointer":"/labels/a~1b~0c","detail":"too long"}]}`
+	if err := json.Unmarshal([]byte(body), &problem); err != nil {
+		t.Fatal(err)
+	}
+	var valErr *http.ValidationError
+	if err := problem.Err(); !errors.As(err, &valErr) || len(valErr.GetViolations()) != 1 ||
+		valErr.GetViolations()[0].GetField() != "labels.a/b~c" {
+		t.Fatalf("Err() = %v, want a ValidationError of labels.a/b~c", err)
+	}
+
+	problem = http.ProblemDetails{Title: "Not Found", Status: nethttp.StatusNotFound, Code: http.ErrorCodeNotFound}
+	if err := problem.Err(); !errors.Is(err, http.NewError(http.ErrorCodeNotFound, "")) || err.Error() != "Not Found" {
+		t.Errorf("Err() = %v, want a NOT_FOUND Error titled Not Found", err)
+	}
+}
+
+func TestIsProblemContentType(t *testing.T) {
+	for contentType, want := range map[string]bool{
+		"application/problem+json":                true,
+		"application/problem+json; charset=utf-8": true,
+		"application/json":                        false,
+		"":                                        false,
+	} {
+		if got := http.IsProblemContentType(contentType); got != want {
+			t.Errorf("IsProblemContentType(%q) = %v, want %v", contentType, got, want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DefaultJSONQueryParamMaxBytes is the largest decoded value of a query
+// parameter declared with encoding QUERY_ENCODING_JSON_BASE64URL servers
+// accept when its query annotation sets no max_bytes.
+const DefaultJSONQueryParamMaxBytes = 4096
+
+// MarshalJSONQueryParam returns the value of a QUERY_ENCODING_JSON_BASE64URL
+// query parameter carrying msg: its JSON, as MarshalMessageJSON writes it
+// without insignificant whitespace, in unpadded base64url. Equal messages give
+// equal values, so URLs holding them stay cacheable.
+func MarshalJSONQueryParam(msg proto.Message) (string, error) {
+	data, err := marshalCompactJSON(msg)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// MarshalJSONQueryParamList is MarshalJSONQueryParam for a repeated message
+// field, whose JSON is the array of msgs.
+func MarshalJSONQueryParamList[M proto.Message](msgs []M) (string, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, msg := range msgs {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		data, err := marshalCompactJSON(msg)
+		if err != nil {
+			return "", err
+		}
+		buf.Write(data)
+	}
+	buf.WriteByte(']')
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// marshalCompactJSON encodes msg with MarshalMessageJSON, dropping the
+// whitespace protojson randomly inserts.
+func marshalCompactJSON(msg proto.Message) ([]byte, error) {
+	data, err := MarshalMessageJSON(msg, protojson.MarshalOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = json.Compact(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSONQueryParam sets field of msg, a message or repeated message
+// field, from value, a QUERY_ENCODING_JSON_BASE64URL query parameter. Padded
+// values are accepted too. Values decoding to more than maxBytes are rejected
+// before they are decoded. The JSON is read as UnmarshalMessageJSON reads
+// request bodies, ignoring unknown keys.
+func UnmarshalJSONQueryParam(
+	value string,
+	maxBytes int,
+	msg protoreflect.Message,
+	field protoreflect.FieldDescriptor,
+) error {
+	encoded := strings.TrimRight(value, "=")
+	if size := base64.RawURLEncoding.DecodedLen(len(encoded)); size > maxBytes {
+		return fmt.Errorf("decoded value of %d bytes exceeds the limit of %d bytes", size, maxBytes)
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return errors.New("value is not base64url")
+	}
+
+	opts := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if !field.IsList() {
+		fieldValue := msg.NewField(field)
+		if err = UnmarshalMessageJSON(data, fieldValue.Message().Interface(), opts); err != nil {
+			return fmt.Errorf("value is not a JSON %s: %w", field.Message().Name(), err)
+		}
+		msg.Set(field, fieldValue)
+		return nil
+	}
+
+	var items []json.RawMessage
+	if err = json.Unmarshal(data, &items); err != nil {
+		return fmt.Errorf("value is not a JSON array: %w", err)
+	}
+	list := msg.NewField(field).List()
+	for i, item := range items {
+		element := list.NewElement()
+		if err = UnmarshalMessageJSON(item, element.Message().Interface(), opts); err != nil {
+			return fmt.Errorf("item %d is not a JSON %s: %w", i, field.Message().Name(), err)
+		}
+		list.Append(element)
+	}
+	msg.Set(field, protoreflect.ValueOfList(list))
+	return nil
+}
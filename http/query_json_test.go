@@ -0,0 +1,84 @@
+package http_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestJSONQueryParamRoundTrip(t *testing.T) {
+	violations := []*http.FieldViolation{
+		{Field: "name", Description: "too short"},
+		{Field: "tags", Description: "required"},
+	}
+	value, err := http.MarshalJSONQueryParamList(violations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		t.Fatalf("value %q is not unpadded base64url: %v", value, err)
+	}
+	want := `[{"field":"name","description":"too short"},{"field":"tags","description":"required"}]`
+	if string(data) != want {
+		t.Errorf("decoded value = %s, want %s", data, want)
+	}
+
+	got := &http.ValidationError{}
+	field := got.ProtoReflect().Descriptor().Fields().ByName("violations")
+	if err = http.UnmarshalJSONQueryParam(value, 1024, got.ProtoReflect(), field); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, &http.ValidationError{Violations: violations}) {
+		t.Errorf("UnmarshalJSONQueryParam = %v, want %v", got, violations)
+	}
+}
+
+func TestJSONQueryParamSingularMessage(t *testing.T) {
+	options := &descriptorpb.FieldOptions{Deprecated: proto.Bool(true)}
+	value, err := http.MarshalJSONQueryParam(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := &descriptorpb.FieldDescriptorProto{}
+	field := got.ProtoReflect().Descriptor().Fields().ByName("options")
+	// Padded values are accepted as well
+	if err = http.UnmarshalJSONQueryParam(value+"==", 1024, got.ProtoReflect(), field); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got.GetOptions(), options) {
+		t.Errorf("UnmarshalJSONQueryParam = %v, want %v", got.GetOptions(), options)
+	}
+}
+
+func TestUnmarshalJSONQueryParamErrors(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	tests := []struct {
+		name     string
+		value    string
+		maxBytes int
+		want     string
+	}{
+		{"too large", encode(`[` + strings.Repeat(`{},`, 100) + `{}]`), 64, "exceeds the limit of 64 bytes"},
+		{"not base64url", "a+b/", 64, "not base64url"},
+		{"not an array", encode(`{"field":"name"}`), 64, "not a JSON array"},
+		{"bad item", encode(`[{"field":1}]`), 64, "item 0 is not a JSON FieldViolation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := (&http.ValidationError{}).ProtoReflect()
+			field := msg.Descriptor().Fields().ByName(protoreflect.Name("violations"))
+			err := http.UnmarshalJSONQueryParam(tt.value, tt.maxBytes, msg, field)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("UnmarshalJSONQueryParam = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// RedactedValue replaces the value of sensitive string fields in redacted
+// messages.
+const RedactedValue = "[REDACTED]"
+
+// Redactor is implemented by generated messages that contain fields annotated
+// with sebuf.http.sensitive, directly or through nested messages.
+type Redactor interface {
+	// Redacted returns a deep copy of the message with its sensitive fields
+	// redacted, leaving the message itself untouched.
+	Redacted() proto.Message
+}
+
+// Redact returns the redacted copy of msg when it implements Redactor. Other
+// messages, such as those of packages generated without protoc-gen-go-http,
+// are redacted through reflection by the same rules: msg itself is returned
+// when none of its set fields is sensitive, and a redacted copy otherwise.
+// Hooks that log or export request and response payloads serialize
+// Redact(msg) so sensitive fields never leave the process.
+func Redact(msg proto.Message) proto.Message {
+	if r, ok := msg.(Redactor); ok {
+		return r.Redacted()
+	}
+	if msg == nil || !msg.ProtoReflect().IsValid() || !reachesSensitive(msg.ProtoReflect()) {
+		return msg
+	}
+	clone := proto.Clone(msg)
+	redactSensitive(clone.ProtoReflect())
+	return clone
+}
+
+// isSensitive reports whether fd is annotated with sebuf.http.sensitive.
+func isSensitive(fd protoreflect.FieldDescriptor) bool {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	if !ok || opts == nil {
+		return false
+	}
+	sensitive, _ := proto.GetExtension(opts, E_Sensitive).(bool)
+	return sensitive
+}
+
+// reachesSensitive reports whether m has a set sensitive field, directly or in
+// a nested message.
+func reachesSensitive(m protoreflect.Message) bool {
+	found := false
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		found = isSensitive(fd) || anyNestedMessage(fd, v, reachesSensitive)
+		return !found
+	})
+	return found
+}
+
+// anyNestedMessage reports whether check holds for a message held by the
+// field fd with value v.
+func anyNestedMessage(
+	fd protoreflect.FieldDescriptor,
+	v protoreflect.Value,
+	check func(protoreflect.Message) bool,
+) bool {
+	switch {
+	case fd.IsMap():
+		if fd.MapValue().Message() == nil {
+			return false
+		}
+		found := false
+		v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+			found = check(mv.Message())
+			return !found
+		})
+		return found
+	case fd.Message() == nil:
+		return false
+	case fd.IsList():
+		for i := range v.List().Len() {
+			if check(v.List().Get(i).Message()) {
+				return true
+			}
+		}
+		return false
+	default:
+		return check(v.Message())
+	}
+}
+
+// redactSensitive redacts m in place: sensitive strings become RedactedValue,
+// sensitive bytes are emptied and nested messages are redacted in turn.
+func redactSensitive(m protoreflect.Message) {
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+	for _, fd := range fields {
+		if !isSensitive(fd) {
+			anyNestedMessage(fd, m.Get(fd), func(nested protoreflect.Message) bool {
+				redactSensitive(nested)
+				return false
+			})
+			continue
+		}
+		valueField := fd
+		if fd.IsMap() {
+			valueField = fd.MapValue()
+		}
+		redacted := protoreflect.ValueOfBytes([]byte{})
+		if valueField.Kind() == protoreflect.StringKind {
+			redacted = protoreflect.ValueOfString(RedactedValue)
+		}
+		switch {
+		case fd.IsList():
+			list := m.Mutable(fd).List()
+			for i := range list.Len() {
+				list.Set(i, redacted)
+			}
+		case fd.IsMap():
+			entries := m.Mutable(fd).Map()
+			entries.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+				entries.Set(k, redacted)
+				return true
+			})
+		default:
+			m.Set(fd, redacted)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package http
+
+import (
+	nethttp "net/http"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// NegotiateResponseContentType returns the content type generated servers
+// answer r in (RFC 9110): the protobuf or JSON type named by its Accept header,
+// or, when Accept is absent or */*, the protobuf type of its Content-Type, and
+// JSON otherwise. Handlers mounted next to generated ones use it to answer in
+// the same encoding.
+func NegotiateResponseContentType(r *nethttp.Request) string {
+	accept := mediaType(r.Header.Get("Accept"))
+	switch accept {
+	case BinaryContentType, ProtoContentType:
+		return accept
+	case "", "*/*":
+		ct := mediaType(r.Header.Get("Content-Type"))
+		if ct == BinaryContentType || ct == ProtoContentType {
+			return ct
+		}
+		return JSONContentType
+	default:
+		return JSONContentType
+	}
+}
+
+// mediaType returns the media type of a Content-Type or Accept value, without
+// its parameters.
+func mediaType(value string) string {
+	if i := strings.IndexAny(value, " ;"); i >= 0 {
+		return value[:i]
+	}
+	return value
+}
+
+// Responder writes messages as responses the way generated servers do. The
+// zero Responder encodes JSON with the default protojson options in the
+// content type of NegotiateResponseContentType.
+type Responder struct {
+	// MarshalOptions encode JSON bodies, and the JSON converted by codecs.
+	MarshalOptions protojson.MarshalOptions
+	// Negotiate returns the content type of the response to a request,
+	// NegotiateResponseContentType when nil. A content type other than JSON
+	// and protobuf is encoded with the codec registered for it.
+	Negotiate func(r *nethttp.Request) string
+}
+
+// WriteError writes err with status as the response to r, in the content type
+// of NegotiateResponseContentType. If err cannot be encoded, the lowercased
+// status text, such as "internal server error", is answered as plain text.
+func WriteError(w nethttp.ResponseWriter, r *nethttp.Request, err *Error, status int) {
+	Responder{}.WriteError(w, r, err, status)
+}
+
+// WriteValidationError writes v with status 400 as the response to r, in the
+// content type of NegotiateResponseContentType. If v cannot be encoded,
+// "validation failed" is answered as plain text.
+func WriteValidationError(w nethttp.ResponseWriter, r *nethttp.Request, v *ValidationError) {
+	Responder{}.WriteValidationError(w, r, v)
+}
+
+// WriteError writes err with status as the response to r, answering the
+// lowercased status text as plain text if err cannot be encoded.
+func (rs Responder) WriteError(w nethttp.ResponseWriter, r *nethttp.Request, err *Error, status int) {
+	rs.WriteMessage(w, r, err, status, strings.ToLower(nethttp.StatusText(status)))
+}
+
+// WriteValidationError writes v with status 400 as the response to r,
+// answering "validation failed" as plain text if v cannot be encoded.
+func (rs Responder) WriteValidationError(w nethttp.ResponseWriter, r *nethttp.Request, v *ValidationError) {
+	rs.WriteMessage(w, r, v, nethttp.StatusBadRequest, "validation failed")
+}
+
+// WriteMessage writes msg with status as the response to r, answering
+// fallback as plain text with the same status if msg cannot be encoded.
+func (rs Responder) WriteMessage(
+	w nethttp.ResponseWriter,
+	r *nethttp.Request,
+	msg proto.Message,
+	status int,
+	fallback string,
+) {
+	contentType := rs.contentType(r)
+	body, err := rs.marshal(msg, contentType)
+	if err != nil {
+		nethttp.Error(w, fallback, status)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// WriteBody writes msg as the body of the response to r, leaving its status
+// to what was written before. Nothing is written if msg cannot be encoded.
+func (rs Responder) WriteBody(w nethttp.ResponseWriter, r *nethttp.Request, msg proto.Message) {
+	contentType := rs.contentType(r)
+	body, err := rs.marshal(msg, contentType)
+	if err != nil {
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(body)
+}
+
+// contentType returns the content type of the response to r.
+func (rs Responder) contentType(r *nethttp.Request) string {
+	if rs.Negotiate != nil {
+		return rs.Negotiate(r)
+	}
+	return NegotiateResponseContentType(r)
+}
+
+// marshal encodes msg in contentType.
+func (rs Responder) marshal(msg proto.Message, contentType string) ([]byte, error) {
+	switch contentType {
+	case BinaryContentType, ProtoContentType:
+		return proto.Marshal(msg)
+	case JSONContentType:
+		return MarshalMessageJSON(msg, rs.MarshalOptions)
+	}
+	codec, ok := LookupCodec(contentType)
+	if !ok {
+		return nil, CodecNotRegisteredError(contentType)
+	}
+	data, err := MarshalMessageJSON(msg, rs.MarshalOptions)
+	if err != nil {
+		return nil, err
+	}
+	return codec.FromJSON(data)
+}
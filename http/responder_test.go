@@ -0,0 +1,115 @@
+package http_test
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestNegotiateResponseContentType(t *testing.T) {
+	tests := []struct {
+		accept      string
+		contentType string
+		want        string
+	}{
+		{"", "", http.JSONContentType},
+		{"application/json", http.ProtoContentType, http.JSONContentType},
+		{"application/x-protobuf; charset=utf-8", "", http.ProtoContentType},
+		{http.BinaryContentType, http.JSONContentType, http.BinaryContentType},
+		{"", "application/x-protobuf;proto=Book", http.ProtoContentType},
+		{"*/*", http.BinaryContentType, http.BinaryContentType},
+		{"text/html", http.ProtoContentType, http.JSONContentType},
+		{"", http.MsgpackContentType, http.JSONContentType},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(nethttp.MethodPost, "/", nil)
+		r.Header.Set("Accept", tt.accept)
+		r.Header.Set("Content-Type", tt.contentType)
+		if got := http.NegotiateResponseContentType(r); got != tt.want {
+			t.Errorf("Accept %q, Content-Type %q: NegotiateResponseContentType = %q, want %q",
+				tt.accept, tt.contentType, got, tt.want)
+		}
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	err := &http.Error{Code: http.ErrorCodeNotFound, Message: "book not found"}
+
+	w := httptest.NewRecorder()
+	http.WriteError(w, httptest.NewRequest(nethttp.MethodGet, "/books/1", nil), err, nethttp.StatusNotFound)
+	if w.Code != nethttp.StatusNotFound || w.Header().Get("Content-Type") != http.JSONContentType {
+		t.Errorf("JSON error answered %d as %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	var decoded http.Error
+	if unmarshalErr := protojson.Unmarshal(w.Body.Bytes(), &decoded); unmarshalErr != nil ||
+		!proto.Equal(&decoded, err) {
+		t.Errorf("JSON body %s decodes to %v, %v, want %v", w.Body, &decoded, unmarshalErr, err)
+	}
+
+	r := httptest.NewRequest(nethttp.MethodGet, "/books/1", nil)
+	r.Header.Set("Accept", http.ProtoContentType)
+	w = httptest.NewRecorder()
+	http.WriteError(w, r, err, nethttp.StatusNotFound)
+	if w.Code != nethttp.StatusNotFound || w.Header().Get("Content-Type") != http.ProtoContentType {
+		t.Errorf("protobuf error answered %d as %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	decoded.Reset()
+	if unmarshalErr := proto.Unmarshal(w.Body.Bytes(), &decoded); unmarshalErr != nil ||
+		!proto.Equal(&decoded, err) {
+		t.Errorf("protobuf body decodes to %v, %v, want %v", &decoded, unmarshalErr, err)
+	}
+}
+
+func TestWriteValidationError(t *testing.T) {
+	v := &http.ValidationError{Violations: []*http.FieldViolation{{Field: "title", Description: "required"}}}
+	for _, contentType := range []string{http.JSONContentType, http.BinaryContentType} {
+		r := httptest.NewRequest(nethttp.MethodPost, "/books", nil)
+		r.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		http.WriteValidationError(w, r, v)
+		if w.Code != nethttp.StatusBadRequest || w.Header().Get("Content-Type") != contentType {
+			t.Fatalf("%s request answered %d as %q", contentType, w.Code, w.Header().Get("Content-Type"))
+		}
+		var decoded http.ValidationError
+		var err error
+		if contentType == http.JSONContentType {
+			err = protojson.Unmarshal(w.Body.Bytes(), &decoded)
+		} else {
+			err = proto.Unmarshal(w.Body.Bytes(), &decoded)
+		}
+		if err != nil || !proto.Equal(&decoded, v) {
+			t.Errorf("%s body decodes to %v, %v, want %v", contentType, &decoded, err, v)
+		}
+	}
+}
+
+func TestResponder(t *testing.T) {
+	responder := http.Responder{MarshalOptions: protojson.MarshalOptions{EmitUnpopulated: true}}
+	w := httptest.NewRecorder()
+	responder.WriteError(w, httptest.NewRequest(nethttp.MethodGet, "/", nil), &http.Error{Message: "boom"},
+		nethttp.StatusInternalServerError)
+	if body := w.Body.String(); !strings.Contains(body, `"code":""`) {
+		t.Errorf("body %s was not encoded with the MarshalOptions", body)
+	}
+
+	w = httptest.NewRecorder()
+	w.WriteHeader(nethttp.StatusTeapot)
+	responder.WriteBody(w, httptest.NewRequest(nethttp.MethodGet, "/", nil), &http.Error{Message: "boom"})
+	if w.Code != nethttp.StatusTeapot || !strings.Contains(w.Body.String(), `"message":"boom"`) {
+		t.Errorf("WriteBody answered %d with %s, want the body after the status written before", w.Code, w.Body)
+	}
+
+	unencodable := http.Responder{Negotiate: func(*nethttp.Request) string { return "application/x-unknown" }}
+	w = httptest.NewRecorder()
+	unencodable.WriteError(w, httptest.NewRequest(nethttp.MethodGet, "/", nil), &http.Error{Message: "boom"},
+		nethttp.StatusInternalServerError)
+	if w.Code != nethttp.StatusInternalServerError || w.Body.String() != "internal server error\n" {
+		t.Errorf("unencodable error answered %d with %q, want the status text", w.Code, w.Body)
+	}
+}
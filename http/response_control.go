@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	nethttp "net/http"
+	"slices"
+	"sync"
+)
+
+type responseControlCtxKey struct{}
+
+// ResponseControl holds the response headers, trailers, status and warnings a
+// handler sets with SetResponseHeader, AddResponseHeader, SetResponseTrailer,
+// SetStatus and AddWarning. Generated servers attach one to the context of every unary
+// handler call and apply it to the successful response; error responses ignore
+// it. Once the response is written, further changes are dropped with a warning.
+type ResponseControl struct {
+	mu       sync.Mutex
+	header   nethttp.Header
+	trailer  nethttp.Header
+	status   int
+	warnings []Warning
+	written  bool
+}
+
+// ContextWithResponseControl returns a copy of ctx carrying a new
+// ResponseControl, and that ResponseControl.
+func ContextWithResponseControl(ctx context.Context) (context.Context, *ResponseControl) {
+	control := &ResponseControl{header: nethttp.Header{}, trailer: nethttp.Header{}}
+	return context.WithValue(ctx, responseControlCtxKey{}, control), control
+}
+
+// SetResponseHeader sets a header of the successful response to the request
+// being handled, replacing any value set before (e.g. Location after a create).
+func SetResponseHeader(ctx context.Context, key, value string) {
+	updateResponse(ctx, "SetResponseHeader", func(c *ResponseControl) { c.header.Set(key, value) })
+}
+
+// AddResponseHeader adds a value to a header of the successful response to the
+// request being handled (e.g. one pagination Link per relation).
+func AddResponseHeader(ctx context.Context, key, value string) {
+	updateResponse(ctx, "AddResponseHeader", func(c *ResponseControl) { c.header.Add(key, value) })
+}
+
+// SetResponseTrailer sets a trailer sent after the body of the successful
+// response to the request being handled.
+func SetResponseTrailer(ctx context.Context, key, value string) {
+	updateResponse(ctx, "SetResponseTrailer", func(c *ResponseControl) { c.trailer.Set(key, value) })
+}
+
+// SetStatus sets the status code of the successful response to the request
+// being handled (e.g. 201 Created), in place of 200 OK. The last call wins.
+func SetStatus(ctx context.Context, code int) {
+	if code < 100 || code > 999 {
+		slog.Warn("sebufhttp: SetStatus called with an invalid status code; ignored", slog.Int("code", code))
+		return
+	}
+	updateResponse(ctx, "SetStatus", func(c *ResponseControl) { c.status = code })
+}
+
+// updateResponse applies update to the ResponseControl of ctx, or logs a
+// warning when ctx carries none or its response was already written.
+func updateResponse(ctx context.Context, caller string, update func(*ResponseControl)) {
+	control, ok := ctx.Value(responseControlCtxKey{}).(*ResponseControl)
+	if !ok {
+		slog.Warn("sebufhttp: " + caller + " called outside a generated unary handler; ignored")
+		return
+	}
+	control.mu.Lock()
+	defer control.mu.Unlock()
+	if control.written {
+		slog.Warn("sebufhttp: " + caller + " called after the response was written; ignored")
+		return
+	}
+	update(control)
+}
+
+// WriteHeader copies the headers set by the handler onto w, replacing those
+// already set, adds a Warning header per warning, declares its trailers and
+// writes its status, if any. When no status was set, the header is left for
+// the first Write to send with 200 OK. Later changes are dropped.
+func (c *ResponseControl) WriteHeader(w nethttp.ResponseWriter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.written = true
+	header := w.Header()
+	for key, values := range c.header {
+		header[key] = values
+	}
+	for _, warning := range c.warnings {
+		header.Add(WarningHeader, FormatWarning(warning))
+	}
+	for key := range c.trailer {
+		header.Add("Trailer", key)
+	}
+	if c.status != 0 {
+		w.WriteHeader(c.status)
+	}
+}
+
+// Warnings returns the warnings added so far.
+func (c *ResponseControl) Warnings() []Warning {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.warnings)
+}
+
+// WriteTrailer sets the trailers declared by WriteHeader on w, once the body
+// has been written.
+func (c *ResponseControl) WriteTrailer(w nethttp.ResponseWriter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	header := w.Header()
+	for key, values := range c.trailer {
+		header[key] = values
+	}
+}
+
+// Close drops later changes without applying them, as for error responses.
+func (c *ResponseControl) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.written = true
+}
@@ -0,0 +1,108 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// captureWarnings routes the default logger to a buffer for the test.
+func captureWarnings(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func TestResponseControlAppliesHeadersAndStatus(t *testing.T) {
+	ctx, control := http.ContextWithResponseControl(context.Background())
+	http.SetResponseHeader(ctx, "Location", "/users/41")
+	http.SetResponseHeader(ctx, "Location", "/users/42")
+	http.AddResponseHeader(ctx, "Link", `</users?page=2>; rel="next"`)
+	http.AddResponseHeader(ctx, "Link", `</users?page=9>; rel="last"`)
+	http.SetResponseTrailer(ctx, "X-Checksum", "abc")
+	http.SetStatus(ctx, nethttp.StatusAccepted)
+	http.SetStatus(ctx, nethttp.StatusCreated)
+
+	rec := httptest.NewRecorder()
+	control.WriteHeader(rec)
+	_, _ = rec.WriteString("{}")
+	control.WriteTrailer(rec)
+
+	if rec.Code != nethttp.StatusCreated {
+		t.Errorf("status = %d, want the last SetStatus (201)", rec.Code)
+	}
+	if got := rec.Header().Get("Location"); got != "/users/42" {
+		t.Errorf("Location = %q, want the last SetResponseHeader", got)
+	}
+	if got := rec.Header().Values("Link"); len(got) != 2 {
+		t.Errorf("Link = %v, want both added values", got)
+	}
+	if got := rec.Result().Trailer.Get("X-Checksum"); got != "abc" {
+		t.Errorf("X-Checksum trailer = %q, want abc", got)
+	}
+}
+
+func TestResponseControlWithoutStatusLeavesDefault(t *testing.T) {
+	ctx, control := http.ContextWithResponseControl(context.Background())
+	http.SetResponseHeader(ctx, "X-Request-Cost", "3")
+
+	rec := httptest.NewRecorder()
+	control.WriteHeader(rec)
+	if rec.Code != nethttp.StatusOK || rec.Header().Get("X-Request-Cost") != "3" {
+		t.Errorf("response = %d %v, want 200 with X-Request-Cost", rec.Code, rec.Header())
+	}
+}
+
+func TestResponseControlIgnoresLateChanges(t *testing.T) {
+	warnings := captureWarnings(t)
+
+	ctx, control := http.ContextWithResponseControl(context.Background())
+	rec := httptest.NewRecorder()
+	control.WriteHeader(rec)
+	http.SetStatus(ctx, nethttp.StatusCreated)
+	http.SetResponseHeader(ctx, "Location", "/users/42")
+
+	if rec.Code != nethttp.StatusOK || rec.Header().Get("Location") != "" {
+		t.Errorf("late changes should not reach the response, got %d %v", rec.Code, rec.Header())
+	}
+	for _, caller := range []string{"SetStatus", "SetResponseHeader"} {
+		if !strings.Contains(warnings.String(), caller+" called after the response was written") {
+			t.Errorf("expected a warning for the late %s, got %q", caller, warnings.String())
+		}
+	}
+
+	ctx, control = http.ContextWithResponseControl(context.Background())
+	control.Close()
+	http.SetStatus(ctx, nethttp.StatusCreated)
+	rec = httptest.NewRecorder()
+	control.WriteHeader(rec)
+	if rec.Code != nethttp.StatusOK {
+		t.Errorf("changes after Close should be dropped, got status %d", rec.Code)
+	}
+}
+
+func TestResponseControlOutsideHandler(t *testing.T) {
+	warnings := captureWarnings(t)
+
+	http.SetStatus(context.Background(), nethttp.StatusCreated)
+	if !strings.Contains(warnings.String(), "SetStatus called outside a generated unary handler") {
+		t.Errorf("expected a warning, got %q", warnings.String())
+	}
+
+	ctx, control := http.ContextWithResponseControl(context.Background())
+	http.SetStatus(ctx, 42)
+	rec := httptest.NewRecorder()
+	control.WriteHeader(rec)
+	if rec.Code != nethttp.StatusOK || !strings.Contains(warnings.String(), "invalid status code") {
+		t.Errorf("an invalid status should be ignored with a warning, got %d %q", rec.Code, warnings.String())
+	}
+}
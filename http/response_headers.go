@@ -0,0 +1,39 @@
+package http
+
+import (
+	nethttp "net/http"
+	"net/textproto"
+)
+
+// ResponseHeadersMiddleware wraps the handler of a route so that every
+// response, errors included, carries headers, the static response_headers of
+// the route's method, changed by overrides: an override replaces the value of
+// the header of its name, adds a header headers lacks, or removes the header
+// when its value is empty. Names are matched whatever their casing. The
+// headers are set before next runs, so headers next sets, such as the
+// Cache-Control of a cache annotation, replace them. Generated servers wrap
+// every route, passing the overrides of WithResponseHeaderOverrides.
+func ResponseHeadersMiddleware(next nethttp.Handler, headers, overrides map[string]string) nethttp.Handler {
+	values := make(map[string]string, len(headers)+len(overrides))
+	for name, value := range headers {
+		values[textproto.CanonicalMIMEHeaderKey(name)] = value
+	}
+	for name, value := range overrides {
+		name = textproto.CanonicalMIMEHeaderKey(name)
+		if value == "" {
+			delete(values, name)
+			continue
+		}
+		values[name] = value
+	}
+	if len(values) == 0 {
+		return next
+	}
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		header := w.Header()
+		for name, value := range values {
+			header[name] = []string{value}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
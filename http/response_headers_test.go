@@ -0,0 +1,56 @@
+package http_test
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestResponseHeadersMiddleware(t *testing.T) {
+	next := nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.WriteHeader(nethttp.StatusNotFound)
+	})
+	headers := map[string]string{
+		"Cache-Control":   "no-store",
+		"x-frame-options": "DENY",
+		"Referrer-Policy": "no-referrer",
+	}
+	overrides := map[string]string{
+		"X-Frame-Options":           "SAMEORIGIN",
+		"referrer-policy":           "",
+		"Strict-Transport-Security": "max-age=31536000",
+	}
+
+	rec := httptest.NewRecorder()
+	http.ResponseHeadersMiddleware(next, headers, overrides).
+		ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, "/notes/1", nil))
+	if rec.Code != nethttp.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, nethttp.StatusNotFound)
+	}
+	want := map[string]string{
+		"X-Frame-Options":           "SAMEORIGIN",
+		"Strict-Transport-Security": "max-age=31536000",
+		"Cache-Control":             "max-age=60",
+	}
+	for name, value := range want {
+		if got := rec.Header().Get(name); got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+	if _, ok := rec.Header()["Referrer-Policy"]; ok {
+		t.Error("Referrer-Policy should be removed by its empty override")
+	}
+}
+
+func TestResponseHeadersMiddlewareWithoutHeaders(t *testing.T) {
+	next := nethttp.HandlerFunc(func(nethttp.ResponseWriter, *nethttp.Request) {})
+	handler := http.ResponseHeadersMiddleware(next, nil, map[string]string{"X-Frame-Options": ""})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, "/notes/1", nil))
+	if len(rec.Header()) != 0 {
+		t.Errorf("headers = %v, want none", rec.Header())
+	}
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// resultOneofs caches, per message descriptor, the oneof annotated with
+// response_statuses and the status of each of its variants, or nil for
+// messages that are not result messages.
+var resultOneofs sync.Map // protoreflect.MessageDescriptor -> *resultOneof
+
+type resultOneof struct {
+	oneof    protoreflect.OneofDescriptor
+	statuses map[protoreflect.FieldNumber]int
+}
+
+// ResponseVariant returns the HTTP status and body of a result message: a
+// message whose oneof is annotated with sebuf.http.response_statuses. The body
+// is the message of the variant set, sent in place of msg. ok is false for
+// messages that are not result messages. A result message with no variant set
+// is an error, which generated servers answer with 500.
+func ResponseVariant(msg proto.Message) (status int, body proto.Message, ok bool, err error) {
+	reflectMsg := msg.ProtoReflect()
+	result := lookupResultOneof(reflectMsg.Descriptor())
+	if result == nil {
+		return 0, nil, false, nil
+	}
+	field := reflectMsg.WhichOneof(result.oneof)
+	if field == nil {
+		return 0, nil, true, fmt.Errorf("%s: no variant of %s is set, so there is no response to send",
+			reflectMsg.Descriptor().FullName(), result.oneof.Name())
+	}
+	status, mapped := result.statuses[field.Number()]
+	if !mapped || field.Message() == nil {
+		return 0, nil, true, fmt.Errorf("%s: variant %s of %s has no response status",
+			reflectMsg.Descriptor().FullName(), field.Name(), result.oneof.Name())
+	}
+	return status, reflectMsg.Get(field).Message().Interface(), true, nil
+}
+
+// lookupResultOneof returns the result oneof of a message descriptor, reading
+// its annotation on first use.
+func lookupResultOneof(desc protoreflect.MessageDescriptor) *resultOneof {
+	if cached, found := resultOneofs.Load(desc); found {
+		return cached.(*resultOneof)
+	}
+	var result *resultOneof
+	oneofs := desc.Oneofs()
+	for i := range oneofs.Len() {
+		oneof := oneofs.Get(i)
+		options, isOneofOptions := oneof.Options().(*descriptorpb.OneofOptions)
+		if !isOneofOptions || !proto.HasExtension(options, E_ResponseStatuses) {
+			continue
+		}
+		annotation, _ := proto.GetExtension(options, E_ResponseStatuses).(*ResponseStatuses)
+		result = &resultOneof{oneof: oneof, statuses: make(map[protoreflect.FieldNumber]int)}
+		fields := oneof.Fields()
+		for j := range fields.Len() {
+			field := fields.Get(j)
+			if status, mapped := annotation.GetStatuses()[string(field.Name())]; mapped {
+				result.statuses[field.Number()] = int(status)
+			}
+		}
+		break
+	}
+	actual, _ := resultOneofs.LoadOrStore(desc, result)
+	return actual.(*resultOneof)
+}
@@ -0,0 +1,87 @@
+package http_test
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// resultFile returns the messages result.test.Order { string id = 1; } and
+// result.test.CreateOrderResult { oneof result { Order order = 1; Order
+// conflict = 2; } } with the oneof mapping order to 200 and conflict to 409.
+func resultFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+	variant := func(name string, number int32) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:       proto.String(name),
+			JsonName:   proto.String(name),
+			Number:     proto.Int32(number),
+			Label:      descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:       descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+			TypeName:   proto.String(".result.test.Order"),
+			OneofIndex: proto.Int32(0),
+		}
+	}
+	options := &descriptorpb.OneofOptions{}
+	proto.SetExtension(options, http.E_ResponseStatuses, &http.ResponseStatuses{
+		Statuses: map[string]int32{"order": 200, "conflict": 409},
+	})
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("result_test.proto"),
+		Package: proto.String("result.test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Order"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("id"),
+				JsonName: proto.String("id"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			}},
+		}, {
+			Name:      proto.String("CreateOrderResult"),
+			Field:     []*descriptorpb.FieldDescriptorProto{variant("order", 1), variant("conflict", 2)},
+			OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("result"), Options: options}},
+		}},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestResponseVariant(t *testing.T) {
+	file := resultFile(t)
+	orderDesc := file.Messages().ByName("Order")
+	resultDesc := file.Messages().ByName("CreateOrderResult")
+
+	order := dynamicpb.NewMessage(orderDesc)
+	order.Set(orderDesc.Fields().ByName("id"), protoreflect.ValueOfString("o-1"))
+	if _, _, ok, err := http.ResponseVariant(order); ok || err != nil {
+		t.Errorf("ResponseVariant(Order) = ok %v, err %v, want not a result message", ok, err)
+	}
+
+	result := dynamicpb.NewMessage(resultDesc)
+	result.Set(resultDesc.Fields().ByName("conflict"), protoreflect.ValueOfMessage(order))
+	status, body, ok, err := http.ResponseVariant(result)
+	if !ok || err != nil {
+		t.Fatalf("ResponseVariant(conflict) = ok %v, err %v", ok, err)
+	}
+	if status != 409 || !proto.Equal(body, order) {
+		t.Errorf("ResponseVariant(conflict) = %d, %v, want 409 and the conflict message", status, body)
+	}
+
+	_, _, ok, err = http.ResponseVariant(dynamicpb.NewMessage(resultDesc))
+	if !ok || err == nil || !strings.Contains(err.Error(), "no variant of result is set") {
+		t.Errorf("ResponseVariant(unset) = ok %v, err %v, want the unset variant error", ok, err)
+	}
+}
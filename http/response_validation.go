@@ -0,0 +1,85 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	nethttp "net/http"
+)
+
+// ResponseValidationMode selects whether a generated server validates the
+// response messages its handlers return against their buf.validate rules.
+type ResponseValidationMode int
+
+const (
+	// ResponseValidationOff does not validate responses. It is the default.
+	ResponseValidationOff ResponseValidationMode = iota
+	// ResponseValidationWarn validates responses, logs their violations and
+	// sends them anyway.
+	ResponseValidationWarn
+	// ResponseValidationEnforce validates responses, logs their violations and
+	// answers an invalid response with ResponseValidationFailed instead.
+	ResponseValidationEnforce
+)
+
+// String returns the mode's name.
+func (m ResponseValidationMode) String() string {
+	switch m {
+	case ResponseValidationOff:
+		return "off"
+	case ResponseValidationWarn:
+		return "warn"
+	case ResponseValidationEnforce:
+		return "enforce"
+	default:
+		return "unknown"
+	}
+}
+
+// ResponseValidationFailed returns the error a generated server answers an
+// invalid response with under ResponseValidationEnforce: a 500 whose message
+// names no field and carries none of the response's values.
+func ResponseValidationFailed() *Error {
+	return &Error{Message: "response validation failed"}
+}
+
+// ApplyResponseValidation decides what happens to the response to r whose
+// validation failed with err, a *ValidationError listing its violations or
+// the error that kept it from being validated. The violations, or err, are
+// logged to logger (slog.Default() when nil) unless mode is
+// ResponseValidationOff; it reports whether the response may be sent, which
+// ResponseValidationEnforce does not allow.
+func ApplyResponseValidation(
+	r *nethttp.Request,
+	mode ResponseValidationMode,
+	err error,
+	logger *slog.Logger,
+) bool {
+	if mode == ResponseValidationOff {
+		return true
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	level, msg := slog.LevelWarn, "response validation failed, sending the response"
+	if mode == ResponseValidationEnforce {
+		level, msg = slog.LevelError, "response validation failed, rejecting the response"
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		logger.Log(r.Context(), level, msg,
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.String("error", err.Error()),
+		)
+		return mode != ResponseValidationEnforce
+	}
+	for _, violation := range verr.GetViolations() {
+		logger.Log(r.Context(), level, msg,
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.String("field", violation.GetField()),
+			slog.String("description", violation.GetDescription()),
+		)
+	}
+	return mode != ResponseValidationEnforce
+}
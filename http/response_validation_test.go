@@ -0,0 +1,58 @@
+package http_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestApplyResponseValidation(t *testing.T) {
+	r := httptest.NewRequest(nethttp.MethodGet, "/notes/1", nil)
+	invalid := &http.ValidationError{Violations: []*http.FieldViolation{
+		{Field: "id", Description: "value is required"},
+	}}
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	if !http.ApplyResponseValidation(r, http.ResponseValidationOff, invalid, logger) || logs.Len() != 0 {
+		t.Errorf("off should send the response without logging, logged %q", logs.String())
+	}
+
+	if !http.ApplyResponseValidation(r, http.ResponseValidationWarn, invalid, logger) {
+		t.Error("warn should send the response")
+	}
+	if !strings.Contains(logs.String(), "level=WARN") || !strings.Contains(logs.String(), "field=id") {
+		t.Errorf("warn should log the violation, got %q", logs.String())
+	}
+
+	logs.Reset()
+	if http.ApplyResponseValidation(r, http.ResponseValidationEnforce, invalid, logger) {
+		t.Error("enforce should reject the response")
+	}
+	if !strings.Contains(logs.String(), "level=ERROR") ||
+		!strings.Contains(logs.String(), `description="value is required"`) {
+		t.Errorf("enforce should log the violation, got %q", logs.String())
+	}
+
+	logs.Reset()
+	cause := errors.New("compilation error: undefined field 'nme'")
+	if http.ApplyResponseValidation(r, http.ResponseValidationEnforce, cause, logger) {
+		t.Error("enforce should reject a response that cannot be validated")
+	}
+	if !strings.Contains(logs.String(), "nme") {
+		t.Errorf("enforce should log the error, got %q", logs.String())
+	}
+}
+
+func TestResponseValidationFailed(t *testing.T) {
+	err := http.ResponseValidationFailed()
+	if err.GetCode() != "" || err.GetMessage() != "response validation failed" {
+		t.Errorf("ResponseValidationFailed() = %v, want an internal error without details", err)
+	}
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Route is the HTTP verb and path template of an RPC, such as GET
+// /api/v1/users/{id}. Generated clients export the routes of each service in a
+// <Service>Routes variable, for callers that build requests or match URLs
+// without calling the client.
+type Route struct {
+	Method string
+	Path   string
+}
+
+// RouteInfo describes a route registered by a generated server: the HTTP verb
+// and path pattern, the full protobuf name of its service, the name of its RPC
+// and the headers it requires. Generated servers hold the routes of each
+// service in a static list, which WithRouteDebug logs and serves and
+// <Service>ServerRoutes returns.
+type RouteInfo struct {
+	Method          string   `json:"method"`
+	Path            string   `json:"path"`
+	Service         string   `json:"service"`
+	RPC             string   `json:"rpc"`
+	RequiredHeaders []string `json:"requiredHeaders,omitempty"`
+}
+
+// BuildPath returns the route path pattern, such as /api/v1/orgs/{org_id}, with
+// its wildcards replaced in order by values, URL-escaped. The value of a
+// {name...} wildcard keeps its slashes. Generated servers build the paths of
+// their routes with it, in the <Service>Path<Method>For functions.
+func BuildPath(pattern string, values ...string) string {
+	var b strings.Builder
+	rest := pattern
+	for _, value := range values {
+		start := strings.IndexByte(rest, '{')
+		end := strings.IndexByte(rest, '}')
+		if start < 0 || end < start {
+			break
+		}
+		b.WriteString(rest[:start])
+		if strings.HasSuffix(rest[start:end], "...") {
+			b.WriteString(EscapePathWildcard(value))
+		} else {
+			b.WriteString(url.PathEscape(value))
+		}
+		rest = rest[end+1:]
+	}
+	b.WriteString(rest)
+	return b.String()
+}
+
+// EscapePathWildcard returns value URL-escaped as the value of a {name...}
+// wildcard, which matches the rest of a path: every segment is escaped, spaces
+// and reserved characters included, but the slashes between segments are kept.
+// The server reads docs/a b.txt back from docs/a%20b.txt.
+func EscapePathWildcard(value string) string {
+	segments := strings.Split(value, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	nethttp "net/http"
+	"sync"
+)
+
+// RouteDebugPath is the path at which RegisterRouteDebug lists the routes of a mux.
+const RouteDebugPath = "/__sebuf/routes"
+
+// routeListing serves the routes registered with RegisterRouteDebug on one mux,
+// or with RouteDebugHandler on one router.
+type routeListing struct {
+	mu     sync.RWMutex
+	routes []RouteInfo
+	allow  []func(*nethttp.Request) bool
+}
+
+// routeListings holds the listing of every router RegisterRouteDebug or
+// RouteDebugHandler was called with.
+var routeListings = struct {
+	sync.Mutex
+	byRouter map[any]*routeListing
+}{byRouter: make(map[any]*routeListing)}
+
+// RegisterRouteDebug logs each of routes to logger (slog.Default() when nil) and
+// lists them as JSON at GET /__sebuf/routes of mux. Services registered on the
+// same mux share one listing, in registration order. When allow is not nil, the
+// listing answers the requests it rejects with 404 Not Found, as if it did not
+// exist; a listing shared by several registrations requires every allow it was
+// given to accept the request. Generated servers call it for each service
+// registered with WithRouteDebug.
+func RegisterRouteDebug(
+	mux *nethttp.ServeMux,
+	routes []RouteInfo,
+	logger *slog.Logger,
+	allow func(*nethttp.Request) bool,
+) {
+	if listing, created := addRouteListing(mux, routes, logger, allow); created {
+		mux.Handle("GET "+RouteDebugPath, listing)
+	}
+}
+
+// RouteDebugHandler is RegisterRouteDebug for routers other than ServeMux: it
+// returns the listing of router, keyed by identity, for the caller to serve at
+// GET /__sebuf/routes. Generated servers mounted on chi or gorilla/mux routers
+// call it for each service mounted with WithRouteDebug.
+func RouteDebugHandler(
+	router any,
+	routes []RouteInfo,
+	logger *slog.Logger,
+	allow func(*nethttp.Request) bool,
+) nethttp.Handler {
+	listing, _ := addRouteListing(router, routes, logger, allow)
+	return listing
+}
+
+// addRouteListing logs routes and appends them to the listing of router,
+// creating it on the first call for router.
+func addRouteListing(
+	router any,
+	routes []RouteInfo,
+	logger *slog.Logger,
+	allow func(*nethttp.Request) bool,
+) (*routeListing, bool) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	for _, route := range routes {
+		logger.Info("registered route",
+			slog.String("method", route.Method),
+			slog.String("path", route.Path),
+			slog.String("service", route.Service),
+			slog.String("rpc", route.RPC),
+			slog.Any("required_headers", route.RequiredHeaders),
+		)
+	}
+
+	routeListings.Lock()
+	listing, exists := routeListings.byRouter[router]
+	if !exists {
+		listing = &routeListing{}
+		routeListings.byRouter[router] = listing
+	}
+	routeListings.Unlock()
+
+	listing.mu.Lock()
+	defer listing.mu.Unlock()
+	listing.routes = append(listing.routes, routes...)
+	if allow != nil {
+		listing.allow = append(listing.allow, allow)
+	}
+	return listing, !exists
+}
+
+func (l *routeListing) ServeHTTP(w nethttp.ResponseWriter, r *nethttp.Request) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for _, allow := range l.allow {
+		if !allow(r) {
+			nethttp.NotFound(w, r)
+			return
+		}
+	}
+	routes := l.routes
+	if routes == nil {
+		routes = []RouteInfo{}
+	}
+	body, err := json.Marshal(struct {
+		Routes []RouteInfo `json:"routes"`
+	}{Routes: routes})
+	if err != nil {
+		nethttp.Error(w, err.Error(), nethttp.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", JSONContentType)
+	_, _ = w.Write(body)
+}
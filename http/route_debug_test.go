@@ -0,0 +1,90 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	nethttp "net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestRegisterRouteDebug(t *testing.T) {
+	users := []http.RouteInfo{
+		{Method: "GET", Path: "/api/users/{id}", Service: "api.UserService", RPC: "GetUser",
+			RequiredHeaders: []string{"X-Api-Key"}},
+	}
+	orders := []http.RouteInfo{
+		{Method: "POST", Path: "/api/orders", Service: "api.OrderService", RPC: "CreateOrder"},
+	}
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	mux := nethttp.NewServeMux()
+	http.RegisterRouteDebug(mux, users, logger, nil)
+	http.RegisterRouteDebug(mux, orders, logger, func(r *nethttp.Request) bool {
+		return r.Header.Get("X-Debug") == "yes"
+	})
+
+	for _, want := range []string{
+		"path=/api/users/{id}", "rpc=GetUser", "required_headers=[X-Api-Key]", "path=/api/orders",
+	} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("logs do not contain %q:\n%s", want, logs.String())
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, http.RouteDebugPath, nil))
+	if rec.Code != nethttp.StatusNotFound {
+		t.Errorf("status without X-Debug = %d, want %d", rec.Code, nethttp.StatusNotFound)
+	}
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(nethttp.MethodGet, http.RouteDebugPath, nil)
+	req.Header.Set("X-Debug", "yes")
+	mux.ServeHTTP(rec, req)
+	if rec.Code != nethttp.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, nethttp.StatusOK)
+	}
+	var listing struct {
+		Routes []http.RouteInfo `json:"routes"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &listing); err != nil {
+		t.Fatalf("decode listing: %v", err)
+	}
+	if want := slices.Concat(users, orders); !reflect.DeepEqual(listing.Routes, want) {
+		t.Errorf("routes = %+v, want %+v", listing.Routes, want)
+	}
+}
+
+func TestRouteDebugHandler(t *testing.T) {
+	users := []http.RouteInfo{{Method: "GET", Path: "/users/{id}", Service: "api.UserService", RPC: "GetUser"}}
+	orders := []http.RouteInfo{{Method: "POST", Path: "/orders", Service: "api.OrderService", RPC: "CreateOrder"}}
+	logger := slog.New(slog.DiscardHandler)
+	router, other := new(int), new(int)
+	first := http.RouteDebugHandler(router, users, logger, nil)
+	second := http.RouteDebugHandler(router, orders, logger, nil)
+	if first != second {
+		t.Errorf("RouteDebugHandler returned two listings for the same router")
+	}
+	if http.RouteDebugHandler(other, orders, logger, nil) == first {
+		t.Errorf("RouteDebugHandler shared the listing of another router")
+	}
+
+	rec := httptest.NewRecorder()
+	second.ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, http.RouteDebugPath, nil))
+	var listing struct {
+		Routes []http.RouteInfo `json:"routes"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &listing); err != nil {
+		t.Fatalf("decode listing: %v", err)
+	}
+	if want := slices.Concat(users, orders); !reflect.DeepEqual(listing.Routes, want) {
+		t.Errorf("routes = %+v, want %+v", listing.Routes, want)
+	}
+}
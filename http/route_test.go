@@ -0,0 +1,62 @@
+package http_test
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestBuildPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		values  []string
+		want    string
+	}{
+		{
+			name:    "replaces wildcards in order",
+			pattern: "/api/v1/orgs/{org_id}/teams/{team_id}",
+			values:  []string{"acme", "core"},
+			want:    "/api/v1/orgs/acme/teams/core",
+		},
+		{
+			name:    "escapes values",
+			pattern: "/api/v1/orgs/{org_id}/teams/{team_id}",
+			values:  []string{"a b", "x/y?z"},
+			want:    "/api/v1/orgs/a%20b/teams/x%2Fy%3Fz",
+		},
+		{
+			name:    "keeps the slashes of a remaining-path wildcard",
+			pattern: "/files/{path...}",
+			values:  []string{"docs/read me.md"},
+			want:    "/files/docs/read%20me.md",
+		},
+		{
+			name:    "path without wildcards",
+			pattern: "/api/v1/health",
+			want:    "/api/v1/health",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := http.BuildPath(tt.pattern, tt.values...); got != tt.want {
+				t.Errorf("BuildPath(%q, %q) = %q, want %q", tt.pattern, tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapePathWildcard(t *testing.T) {
+	for value, want := range map[string]string{
+		"":                     "",
+		"report.pdf":           "report.pdf",
+		"docs/2024/read me.md": "docs/2024/read%20me.md",
+		"a?b#c/%d":             "a%3Fb%23c/%25d",
+		"trailing/":            "trailing/",
+		"/leading":             "/leading",
+	} {
+		if got := http.EscapePathWildcard(value); got != want {
+			t.Errorf("EscapePathWildcard(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
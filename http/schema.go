@@ -0,0 +1,135 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	nethttp "net/http"
+)
+
+// SchemaHeader is the request header carrying the schema fingerprint of the
+// service a generated client was built from, sent by clients generated with
+// schema_fingerprint=true.
+const SchemaHeader = "X-Sebuf-Schema"
+
+// ErrorCodeSchemaMismatch is the Error.Code written when a client sends a
+// schema fingerprint other than the server's under SchemaMismatchReject. Its
+// details hold both fingerprints, under clientSchema and serverSchema.
+// Generated servers map it to HTTP 412 Precondition Failed.
+const ErrorCodeSchemaMismatch = "SCHEMA_MISMATCH"
+
+// SchemaMismatchMode selects how a generated server treats a request whose
+// schema fingerprint differs from its own.
+type SchemaMismatchMode int
+
+const (
+	// SchemaMismatchWarn logs the mismatch and handles the request. It is the
+	// default.
+	SchemaMismatchWarn SchemaMismatchMode = iota
+	// SchemaMismatchReject answers the request with 412 Precondition Failed
+	// and an Error with code SCHEMA_MISMATCH.
+	SchemaMismatchReject
+	// SchemaMismatchIgnore handles the request without logging.
+	SchemaMismatchIgnore
+)
+
+// String returns the mode's name.
+func (m SchemaMismatchMode) String() string {
+	switch m {
+	case SchemaMismatchWarn:
+		return "warn"
+	case SchemaMismatchReject:
+		return "reject"
+	case SchemaMismatchIgnore:
+		return "ignore"
+	default:
+		return "unknown"
+	}
+}
+
+// SchemaMatch compares the schema fingerprint a client sent with the one of
+// the server handling its request.
+type SchemaMatch struct {
+	// Client is the fingerprint of the SchemaHeader, empty when the client
+	// sent none, as clients generated without schema_fingerprint do.
+	Client string
+	// Server is the fingerprint of the service, empty when it was generated
+	// without schema_fingerprint.
+	Server string
+}
+
+// Known reports whether both fingerprints are known, so that they can be
+// compared.
+func (m SchemaMatch) Known() bool {
+	return m.Client != "" && m.Server != ""
+}
+
+// Mismatch reports whether the client was built from another schema than the
+// server. It is false when either fingerprint is unknown.
+func (m SchemaMatch) Mismatch() bool {
+	return m.Known() && m.Client != m.Server
+}
+
+type schemaMatchCtxKey struct{}
+
+// ContextWithSchemaMatch returns a copy of ctx carrying match.
+func ContextWithSchemaMatch(ctx context.Context, match SchemaMatch) context.Context {
+	return context.WithValue(ctx, schemaMatchCtxKey{}, match)
+}
+
+// SchemaMatchFromContext returns the schema match of the request being
+// handled, or the zero SchemaMatch, which is not Known, when ctx carries none,
+// as in services generated without schema_fingerprint.
+func SchemaMatchFromContext(ctx context.Context) SchemaMatch {
+	match, _ := ctx.Value(schemaMatchCtxKey{}).(SchemaMatch)
+	return match
+}
+
+// SchemaMiddleware compares the SchemaHeader of each request with fingerprint,
+// the server's, and adds the SchemaMatch to the request context. A mismatching
+// request is handled as mode selects: logged to logger (slog.Default() when
+// nil), rejected through writeError, or let through. Requests without the
+// header are always handled. Generated servers wrap every route of services
+// generated with schema_fingerprint=true.
+func SchemaMiddleware(
+	next nethttp.Handler,
+	fingerprint string,
+	mode SchemaMismatchMode,
+	writeError func(nethttp.ResponseWriter, *nethttp.Request, error),
+	logger *slog.Logger,
+) nethttp.Handler {
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		match := SchemaMatch{Client: r.Header.Get(SchemaHeader), Server: fingerprint}
+		if match.Mismatch() {
+			switch mode {
+			case SchemaMismatchReject:
+				writeError(w, r, schemaMismatchError(match))
+				return
+			case SchemaMismatchWarn:
+				log := logger
+				if log == nil {
+					log = slog.Default()
+				}
+				log.WarnContext(r.Context(), "client schema does not match the server's",
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.String("client_schema", match.Client),
+					slog.String("server_schema", match.Server),
+				)
+			case SchemaMismatchIgnore:
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(ContextWithSchemaMatch(r.Context(), match)))
+	})
+}
+
+// schemaMismatchError returns the Error rejecting a request under
+// SchemaMismatchReject.
+func schemaMismatchError(match SchemaMatch) *Error {
+	return &Error{
+		Code: ErrorCodeSchemaMismatch,
+		Message: fmt.Sprintf("client schema %s does not match server schema %s; regenerate the client",
+			match.Client, match.Server),
+		Details: map[string]string{"clientSchema": match.Client, "serverSchema": match.Server},
+	}
+}
@@ -0,0 +1,85 @@
+package http_test
+
+import (
+	"encoding/json"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// writeSchemaError writes err with the status generated servers map its code to.
+func writeSchemaError(w nethttp.ResponseWriter, _ *nethttp.Request, err error) {
+	status := nethttp.StatusInternalServerError
+	if errors.Is(err, http.NewError(http.ErrorCodeSchemaMismatch, "")) {
+		status = nethttp.StatusPreconditionFailed
+	}
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(err)
+}
+
+func TestSchemaMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		mode       http.SchemaMismatchMode
+		wantStatus int
+		wantMatch  http.SchemaMatch
+		wantLog    bool
+	}{
+		{"match", "abc", http.SchemaMismatchReject, nethttp.StatusOK,
+			http.SchemaMatch{Client: "abc", Server: "abc"}, false},
+		{"no header", "", http.SchemaMismatchReject, nethttp.StatusOK, http.SchemaMatch{Server: "abc"}, false},
+		{"mismatch warned", "old", http.SchemaMismatchWarn, nethttp.StatusOK,
+			http.SchemaMatch{Client: "old", Server: "abc"}, true},
+		{"mismatch ignored", "old", http.SchemaMismatchIgnore, nethttp.StatusOK,
+			http.SchemaMatch{Client: "old", Server: "abc"}, false},
+		{"mismatch rejected", "old", http.SchemaMismatchReject, nethttp.StatusPreconditionFailed,
+			http.SchemaMatch{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := captureWarnings(t)
+			var got http.SchemaMatch
+			handler := http.SchemaMiddleware(nethttp.HandlerFunc(func(_ nethttp.ResponseWriter, r *nethttp.Request) {
+				got = http.SchemaMatchFromContext(r.Context())
+			}), "abc", tt.mode, writeSchemaError, nil)
+
+			r := httptest.NewRequest(nethttp.MethodGet, "/items", nil)
+			if tt.header != "" {
+				r.Header.Set(http.SchemaHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, r)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if got != tt.wantMatch {
+				t.Errorf("SchemaMatchFromContext = %+v, want %+v", got, tt.wantMatch)
+			}
+			if logged := strings.Contains(warnings.String(), "client schema does not match"); logged != tt.wantLog {
+				t.Errorf("logged = %t, want %t: %s", logged, tt.wantLog, warnings)
+			}
+			if tt.wantStatus == nethttp.StatusPreconditionFailed &&
+				!strings.Contains(rec.Body.String(), `"serverSchema":"abc"`) {
+				t.Errorf("body = %s, want both fingerprints in the details", rec.Body)
+			}
+		})
+	}
+}
+
+func TestSchemaMatch(t *testing.T) {
+	if http.SchemaMatchFromContext(t.Context()).Known() {
+		t.Error("a context without a match should not be Known")
+	}
+	if (http.SchemaMatch{Server: "abc"}).Mismatch() {
+		t.Error("a client without a fingerprint should not mismatch")
+	}
+	if !(http.SchemaMatch{Client: "old", Server: "abc"}).Mismatch() {
+		t.Error("different fingerprints should mismatch")
+	}
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ServerSlot holds the implementation the handlers of one registration of a
+// generated service dispatch to. Handlers load it once per call, so a request
+// is served entirely by the implementation installed when it reached the
+// handler, even if another one is installed meanwhile.
+type ServerSlot[S any] struct {
+	server atomic.Pointer[S]
+}
+
+// Load returns the installed implementation, or false when the service was
+// unregistered and none has been installed since.
+func (s *ServerSlot[S]) Load() (S, bool) {
+	if server := s.server.Load(); server != nil {
+		return *server, true
+	}
+	var zero S
+	return zero, false
+}
+
+// ServerSlots tracks the slots of every registration of a generated service,
+// so that Update<Service>Server and Unregister<Service>Server reach every mux
+// the service is registered on. The zero value is ready to use.
+type ServerSlots[S any] struct {
+	mu    sync.Mutex
+	slots []*ServerSlot[S]
+}
+
+// Add returns a new slot holding server, for a new registration.
+func (s *ServerSlots[S]) Add(server S) *ServerSlot[S] {
+	slot := &ServerSlot[S]{}
+	slot.server.Store(&server)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.slots = append(s.slots, slot)
+	return slot
+}
+
+// Store installs server in every slot.
+func (s *ServerSlots[S]) Store(server S) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, slot := range s.slots {
+		slot.server.Store(&server)
+	}
+}
+
+// Clear removes the implementation from every slot until the next Store.
+func (s *ServerSlots[S]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, slot := range s.slots {
+		slot.server.Store(nil)
+	}
+}
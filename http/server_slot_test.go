@@ -0,0 +1,76 @@
+package http_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// greeter stands in for a generated service interface.
+type greeter interface {
+	Greet() string
+}
+
+type fixedGreeter string
+
+func (g fixedGreeter) Greet() string { return string(g) }
+
+func TestServerSlots(t *testing.T) {
+	var slots http.ServerSlots[greeter]
+	first := slots.Add(fixedGreeter("a"))
+	second := slots.Add(fixedGreeter("b"))
+
+	for slot, want := range map[*http.ServerSlot[greeter]]string{first: "a", second: "b"} {
+		if server, ok := slot.Load(); !ok || server.Greet() != want {
+			t.Errorf("new slot holds %v, %v; want %q", server, ok, want)
+		}
+	}
+
+	slots.Clear()
+	for _, slot := range []*http.ServerSlot[greeter]{first, second} {
+		if server, ok := slot.Load(); ok {
+			t.Errorf("cleared slot holds %v", server)
+		}
+	}
+
+	slots.Store(fixedGreeter("c"))
+	for _, slot := range []*http.ServerSlot[greeter]{first, second} {
+		if server, ok := slot.Load(); !ok || server.Greet() != "c" {
+			t.Errorf("stored slot holds %v, %v; want c", server, ok)
+		}
+	}
+}
+
+// TestServerSlots_Concurrent swaps implementations while readers load them;
+// run with -race.
+func TestServerSlots_Concurrent(t *testing.T) {
+	var slots http.ServerSlots[greeter]
+	slot := slots.Add(fixedGreeter("a"))
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			for range 1000 {
+				if server, ok := slot.Load(); ok {
+					if got := server.Greet(); got != "a" && got != "b" {
+						t.Errorf("loaded %q", got)
+					}
+				}
+			}
+		})
+	}
+	wg.Go(func() {
+		for i := range 1000 {
+			switch i % 3 {
+			case 0:
+				slots.Store(fixedGreeter("b"))
+			case 1:
+				slots.Clear()
+			default:
+				slots.Add(fixedGreeter("a"))
+			}
+		}
+	})
+	wg.Wait()
+}
@@ -0,0 +1,190 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand/v2"
+	nethttp "net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultShadowTimeout bounds a mirrored request of a ShadowMirror with a zero
+// Timeout, from sending it to reading its response body.
+const DefaultShadowTimeout = 2 * time.Second
+
+// DefaultShadowWorkers is the number of mirrored requests a ShadowMirror with a
+// zero Workers has in flight at most.
+const DefaultShadowWorkers = 4
+
+// ShadowMirror mirrors a sample of the calls of a generated client to a second
+// server, such as the new backend of a migration, and passes both responses to
+// Compare. Mirroring never affects the caller: the mirrored request is sent
+// once the primary response arrived, in the background, and its failures are
+// dropped. A nil ShadowMirror mirrors nothing.
+type ShadowMirror struct {
+	// BaseURL is the base URL of the shadow server. Requests keep their path
+	// relative to the client's base URL, their query, headers and body.
+	BaseURL string
+	// SampleRate is the fraction of calls mirrored, from 0 to 1.
+	SampleRate float64
+	// Compare receives the primary and the shadow response of each mirrored
+	// call whose shadow request succeeded, with bodies read in full. It runs on
+	// a background goroutine.
+	Compare func(primary, shadow *nethttp.Response)
+	// Mutations allows mirroring methods other than GET, HEAD and OPTIONS,
+	// which would execute them on the shadow server too.
+	Mutations bool
+	// Timeout bounds each mirrored request, DefaultShadowTimeout when zero. It
+	// is independent of the caller's context, which may end first.
+	Timeout time.Duration
+	// Workers bounds the mirrored requests in flight, DefaultShadowWorkers
+	// when zero. Calls sampled while all workers are busy are not mirrored.
+	Workers int
+
+	once    sync.Once
+	workers chan struct{}
+	wg      sync.WaitGroup
+}
+
+// Mirror mirrors req, sent with client to the server at baseURL, when it is
+// sampled, and returns resp for the caller. The body of a mirrored call's resp
+// is read in full and replaced by an in-memory copy. The mirrored request
+// re-sends req's body through GetBody, so requests without it are not
+// mirrored.
+func (m *ShadowMirror) Mirror(
+	client *nethttp.Client,
+	baseURL string,
+	req *nethttp.Request,
+	resp *nethttp.Response,
+) *nethttp.Response {
+	if m == nil || m.Compare == nil || m.BaseURL == "" || !m.mirrors(req) {
+		return resp
+	}
+	target, ok := m.shadowURL(baseURL, req.URL)
+	if !ok || !m.acquire() {
+		return resp
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		m.release()
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errReader{err}))
+		return resp
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	primary := *resp
+	primary.Header = resp.Header.Clone()
+	primary.Body = io.NopCloser(bytes.NewReader(body))
+
+	go func() {
+		defer m.release()
+		m.mirror(client, req, target, &primary)
+	}()
+	return resp
+}
+
+// Wait waits for the mirrored requests in flight and their comparisons.
+func (m *ShadowMirror) Wait() {
+	if m != nil {
+		m.wg.Wait()
+	}
+}
+
+// mirrors reports whether req is sampled and may be mirrored.
+func (m *ShadowMirror) mirrors(req *nethttp.Request) bool {
+	switch req.Method {
+	case nethttp.MethodGet, nethttp.MethodHead, nethttp.MethodOptions:
+	default:
+		if !m.Mutations {
+			return false
+		}
+	}
+	if req.Body != nil && req.Body != nethttp.NoBody && req.GetBody == nil {
+		return false
+	}
+	return m.SampleRate >= 1 || (m.SampleRate > 0 && rand.Float64() < m.SampleRate)
+}
+
+// shadowURL returns the URL of the mirrored request: u with baseURL replaced
+// by the shadow server's.
+func (m *ShadowMirror) shadowURL(baseURL string, u *url.URL) (string, bool) {
+	rest, ok := strings.CutPrefix(u.String(), strings.TrimSuffix(baseURL, "/"))
+	if !ok {
+		return "", false
+	}
+	return strings.TrimSuffix(m.BaseURL, "/") + rest, true
+}
+
+// acquire takes a worker, reporting false when all are busy.
+func (m *ShadowMirror) acquire() bool {
+	m.once.Do(func() {
+		workers := m.Workers
+		if workers <= 0 {
+			workers = DefaultShadowWorkers
+		}
+		m.workers = make(chan struct{}, workers)
+	})
+	select {
+	case m.workers <- struct{}{}:
+		m.wg.Add(1)
+		return true
+	default:
+		return false
+	}
+}
+
+// release returns a worker taken by acquire.
+func (m *ShadowMirror) release() {
+	<-m.workers
+	m.wg.Done()
+}
+
+// mirror sends the copy of req to target and compares its response with
+// primary. Failures end the mirroring silently.
+func (m *ShadowMirror) mirror(client *nethttp.Client, req *nethttp.Request, target string, primary *nethttp.Response) {
+	timeout := m.Timeout
+	if timeout <= 0 {
+		timeout = DefaultShadowTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(req.Context()), timeout)
+	defer cancel()
+
+	shadowReq, err := nethttp.NewRequestWithContext(ctx, req.Method, target, nil)
+	if err != nil {
+		return
+	}
+	shadowReq.Header = req.Header.Clone()
+	if req.GetBody != nil {
+		if shadowReq.Body, err = req.GetBody(); err != nil {
+			return
+		}
+		shadowReq.GetBody = req.GetBody
+		shadowReq.ContentLength = req.ContentLength
+	}
+
+	shadow, err := client.Do(shadowReq)
+	if err != nil {
+		return
+	}
+	body, err := io.ReadAll(shadow.Body)
+	_ = shadow.Body.Close()
+	if err != nil {
+		return
+	}
+	shadow.Body = io.NopCloser(bytes.NewReader(body))
+	m.Compare(primary, shadow)
+}
+
+// errReader returns err once the body read before the failure is consumed.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
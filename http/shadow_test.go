@@ -0,0 +1,205 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// shadowPair is a primary and a shadow server, each answering with its name,
+// the request path and the request body, and a mirror comparing them.
+type shadowPair struct {
+	primary, shadow *httptest.Server
+	mirror          *http.ShadowMirror
+
+	mu          sync.Mutex
+	comparisons [][2]string
+	shadowCalls int
+}
+
+func newShadowPair(t *testing.T, sampleRate float64) *shadowPair {
+	t.Helper()
+	p := &shadowPair{}
+	handler := func(name string) nethttp.HandlerFunc {
+		return func(w nethttp.ResponseWriter, r *nethttp.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if name == "shadow" {
+				p.mu.Lock()
+				p.shadowCalls++
+				p.mu.Unlock()
+			}
+			w.Header().Set("X-Server", name)
+			_, _ = io.WriteString(w, name+" "+r.URL.RequestURI()+" "+string(body))
+		}
+	}
+	p.primary = httptest.NewServer(handler("primary"))
+	p.shadow = httptest.NewServer(handler("shadow"))
+	t.Cleanup(p.primary.Close)
+	t.Cleanup(p.shadow.Close)
+	p.mirror = &http.ShadowMirror{
+		BaseURL:    p.shadow.URL + "/v2",
+		SampleRate: sampleRate,
+		Compare: func(primary, shadow *nethttp.Response) {
+			a, _ := io.ReadAll(primary.Body)
+			b, _ := io.ReadAll(shadow.Body)
+			p.mu.Lock()
+			p.comparisons = append(p.comparisons, [2]string{string(a), string(b)})
+			p.mu.Unlock()
+		},
+	}
+	return p
+}
+
+// call sends a request to the primary server and mirrors it, returning the
+// body the caller reads.
+func (p *shadowPair) call(t *testing.T, ctx context.Context, method, body string) string {
+	t.Helper()
+	var reqBody io.Reader
+	if body != "" {
+		reqBody = strings.NewReader(body)
+	}
+	req, err := nethttp.NewRequestWithContext(ctx, method, p.primary.URL+"/items/1?view=full", reqBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := p.primary.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp = p.mirror.Mirror(p.primary.Client(), p.primary.URL, req, resp)
+	defer resp.Body.Close()
+	got, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Header.Get("X-Server") != "primary" {
+		t.Errorf("X-Server = %q, want the primary response", resp.Header.Get("X-Server"))
+	}
+	return string(got)
+}
+
+func TestShadowMirror_ComparesSampledCalls(t *testing.T) {
+	p := newShadowPair(t, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	if got := p.call(t, ctx, nethttp.MethodGet, ""); got != "primary /items/1?view=full " {
+		t.Errorf("caller read %q, want the primary body untouched", got)
+	}
+	// The caller's context ending does not cancel the mirrored request.
+	cancel()
+	p.mirror.Wait()
+
+	want := [][2]string{{"primary /items/1?view=full ", "shadow /v2/items/1?view=full "}}
+	if len(p.comparisons) != 1 || p.comparisons[0] != want[0] {
+		t.Errorf("comparisons = %q, want %q", p.comparisons, want)
+	}
+}
+
+func TestShadowMirror_SkipsUnsampledCalls(t *testing.T) {
+	p := newShadowPair(t, 0)
+	for range 5 {
+		if got := p.call(t, context.Background(), nethttp.MethodGet, ""); got != "primary /items/1?view=full " {
+			t.Errorf("caller read %q", got)
+		}
+	}
+	p.mirror.Wait()
+	if p.shadowCalls != 0 || len(p.comparisons) != 0 {
+		t.Errorf("%d shadow calls and %d comparisons, want none", p.shadowCalls, len(p.comparisons))
+	}
+}
+
+func TestShadowMirror_Mutations(t *testing.T) {
+	p := newShadowPair(t, 1)
+	p.call(t, context.Background(), nethttp.MethodPost, `{"name":"a"}`)
+	p.mirror.Wait()
+	if p.shadowCalls != 0 {
+		t.Fatalf("POST mirrored %d times without Mutations", p.shadowCalls)
+	}
+
+	p.mirror.Mutations = true
+	got := p.call(t, context.Background(), nethttp.MethodPost, `{"name":"a"}`)
+	if got != `primary /items/1?view=full {"name":"a"}` {
+		t.Errorf("caller read %q", got)
+	}
+	p.mirror.Wait()
+	want := [2]string{`primary /items/1?view=full {"name":"a"}`, `shadow /v2/items/1?view=full {"name":"a"}`}
+	if len(p.comparisons) != 1 || p.comparisons[0] != want {
+		t.Errorf("comparisons = %q, want the replayed body", p.comparisons)
+	}
+}
+
+func TestShadowMirror_FailuresStayHidden(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(nethttp.HandlerFunc(func(_ nethttp.ResponseWriter, r *nethttp.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer slow.Close()
+	defer close(release)
+
+	p := newShadowPair(t, 1)
+	p.mirror.BaseURL = slow.URL
+	p.mirror.Timeout = 20 * time.Millisecond
+	start := time.Now()
+	if got := p.call(t, context.Background(), nethttp.MethodGet, ""); got != "primary /items/1?view=full " {
+		t.Errorf("caller read %q", got)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("the call waited %v for the shadow server", elapsed)
+	}
+	p.mirror.Wait()
+	if len(p.comparisons) != 0 {
+		t.Errorf("comparisons = %q, want none for a timed-out shadow", p.comparisons)
+	}
+
+	p.mirror.BaseURL = "http://127.0.0.1:1"
+	p.call(t, context.Background(), nethttp.MethodGet, "")
+	p.mirror.Wait()
+	if len(p.comparisons) != 0 {
+		t.Errorf("comparisons = %q, want none for an unreachable shadow", p.comparisons)
+	}
+}
+
+func TestShadowMirror_DropsCallsWhileWorkersAreBusy(t *testing.T) {
+	release := make(chan struct{})
+	var calls int
+	var mu sync.Mutex
+	busy := httptest.NewServer(nethttp.HandlerFunc(func(_ nethttp.ResponseWriter, _ *nethttp.Request) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		<-release
+	}))
+	defer busy.Close()
+
+	p := newShadowPair(t, 1)
+	p.mirror.BaseURL = busy.URL
+	p.mirror.Workers = 1
+	for range 3 {
+		p.call(t, context.Background(), nethttp.MethodGet, "")
+	}
+	close(release)
+	p.mirror.Wait()
+	if calls != 1 {
+		t.Errorf("shadow server got %d calls, want 1 with a single worker", calls)
+	}
+}
+
+func TestShadowMirror_Nil(t *testing.T) {
+	var mirror *http.ShadowMirror
+	resp := &nethttp.Response{Body: io.NopCloser(bytes.NewReader([]byte("x")))}
+	req := httptest.NewRequest(nethttp.MethodGet, "/", nil)
+	if got := mirror.Mirror(nethttp.DefaultClient, "", req, resp); got != resp {
+		t.Error("a nil ShadowMirror must return the response as is")
+	}
+	mirror.Wait()
+}
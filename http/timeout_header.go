@@ -0,0 +1,132 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	nethttp "net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeoutAppliedHeader is set by generated servers configured with
+// WithTimeoutHeader to the timeout, in milliseconds, they bounded a request's
+// context by.
+const TimeoutAppliedHeader = "X-Timeout-Applied-Ms"
+
+// GRPCTimeoutHeader is the header gRPC sends timeouts in, with a unit suffix.
+const GRPCTimeoutHeader = "Grpc-Timeout"
+
+// maxGRPCTimeoutDigits is the most digits a grpc-timeout value may have.
+const maxGRPCTimeoutDigits = 8
+
+// grpcTimeoutUnits maps the unit suffixes of grpc-timeout values to their
+// duration.
+var grpcTimeoutUnits = map[byte]time.Duration{
+	'H': time.Hour,
+	'M': time.Minute,
+	'S': time.Second,
+	'm': time.Millisecond,
+	'u': time.Microsecond,
+	'n': time.Nanosecond,
+}
+
+// TimeoutHeader is the request header a generated server bounds the context
+// of each request by (see WithTimeoutHeader), and the longest timeout it
+// applies. The zero value applies none; a Max <= 0 applies any.
+type TimeoutHeader struct {
+	Name string
+	Max  time.Duration
+}
+
+// Apply bounds the context of r by the timeout r carries in h.Name, clamped to
+// h.Max, and sets TimeoutAppliedHeader on w to that timeout in milliseconds.
+// It returns r unchanged when h has no name or r has no such header, and a
+// *ValidationError naming the header when its value is not a timeout (see
+// ParseTimeout). The returned cancel must be called once r is served.
+func (h TimeoutHeader) Apply(
+	w nethttp.ResponseWriter,
+	r *nethttp.Request,
+) (*nethttp.Request, context.CancelFunc, error) {
+	if h.Name == "" {
+		return r, func() {}, nil
+	}
+	value := r.Header.Get(h.Name)
+	if value == "" {
+		return r, func() {}, nil
+	}
+	timeout, err := ParseTimeout(value)
+	if err != nil {
+		return r, func() {}, &ValidationError{Violations: []*FieldViolation{{
+			Field:       h.Name,
+			Description: fmt.Sprintf("header '%s' is not a timeout: %v", h.Name, err),
+		}}}
+	}
+	if h.Max > 0 {
+		timeout = min(timeout, h.Max)
+	}
+	w.Header().Set(TimeoutAppliedHeader, strconv.FormatInt(timeout.Milliseconds(), 10))
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	return r.WithContext(ctx), cancel, nil
+}
+
+// ParseTimeout parses the value of a timeout header: a number of milliseconds,
+// as sent in X-Request-Timeout-Ms, or a grpc-timeout value, at most 8 digits
+// followed by a unit: H, M, S, m (milliseconds), u (microseconds) or n
+// (nanoseconds).
+func ParseTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, errors.New("empty timeout")
+	}
+	digits, unit := value, time.Millisecond
+	if last := value[len(value)-1]; last < '0' || last > '9' {
+		var ok bool
+		if unit, ok = grpcTimeoutUnits[last]; !ok {
+			return 0, fmt.Errorf("unknown unit %q in %q", last, value)
+		}
+		digits = value[:len(value)-1]
+		if len(digits) > maxGRPCTimeoutDigits {
+			return 0, fmt.Errorf("%q has more than %d digits", value, maxGRPCTimeoutDigits)
+		}
+	}
+	if digits == "" || strings.TrimLeft(digits, "0123456789") != "" {
+		return 0, fmt.Errorf("%q is not a number of milliseconds or a grpc-timeout value", value)
+	}
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil || n > math.MaxInt64/int64(unit) {
+		return 0, fmt.Errorf("%q is out of range", value)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// TimeoutHeaderValue returns the value of header name carrying the time left
+// before the deadline of ctx, rounded up to the millisecond: in grpc-timeout
+// form for GRPCTimeoutHeader, else as a number of milliseconds. It reports
+// false when name is empty, or ctx has no deadline or it has passed. Generated
+// clients configured with WithDeadlinePropagation send it.
+func TimeoutHeaderValue(ctx context.Context, name string) (string, bool) {
+	deadline, ok := ctx.Deadline()
+	if name == "" || !ok {
+		return "", false
+	}
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return "", false
+	}
+	ms := (remaining + time.Millisecond - 1) / time.Millisecond
+	if !strings.EqualFold(name, GRPCTimeoutHeader) {
+		return strconv.FormatInt(int64(ms), 10), true
+	}
+	for _, unit := range []struct {
+		suffix string
+		size   time.Duration
+	}{{"m", time.Millisecond}, {"S", time.Second}, {"M", time.Minute}, {"H", time.Hour}} {
+		n := (remaining + unit.size - 1) / unit.size
+		if n < 1e8 {
+			return strconv.FormatInt(int64(n), 10) + unit.suffix, true
+		}
+	}
+	return "99999999H", true
+}
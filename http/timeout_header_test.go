@@ -0,0 +1,89 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestParseTimeout(t *testing.T) {
+	for value, want := range map[string]time.Duration{
+		"1500":      1500 * time.Millisecond,
+		"0":         0,
+		"250m":      250 * time.Millisecond,
+		"2S":        2 * time.Second,
+		"3M":        3 * time.Minute,
+		"1H":        time.Hour,
+		"750u":      750 * time.Microsecond,
+		"99999999n": 99999999 * time.Nanosecond,
+	} {
+		got, err := http.ParseTimeout(value)
+		if err != nil || got != want {
+			t.Errorf("ParseTimeout(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"", "soon", "1.5", "-100", "10x", "S", "123456789m", "99999999999999999999"} {
+		if got, err := http.ParseTimeout(value); err == nil {
+			t.Errorf("ParseTimeout(%q) = %v, want an error", value, got)
+		}
+	}
+}
+
+func TestTimeoutHeaderApply(t *testing.T) {
+	header := http.TimeoutHeader{Name: "X-Request-Timeout-Ms", Max: time.Second}
+
+	r := httptest.NewRequest(nethttp.MethodGet, "/items", nil)
+	r.Header.Set("X-Request-Timeout-Ms", "5000")
+	w := httptest.NewRecorder()
+	bounded, cancel, err := header.Apply(w, r)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	defer cancel()
+	deadline, ok := bounded.Context().Deadline()
+	if !ok || time.Until(deadline) > time.Second {
+		t.Errorf("deadline in %v, want at most the 1s maximum", time.Until(deadline))
+	}
+	if got := w.Header().Get(http.TimeoutAppliedHeader); got != "1000" {
+		t.Errorf("%s = %q, want 1000", http.TimeoutAppliedHeader, got)
+	}
+
+	r = httptest.NewRequest(nethttp.MethodGet, "/items", nil)
+	w = httptest.NewRecorder()
+	unbounded, cancel, err := header.Apply(w, r)
+	cancel()
+	if err != nil || unbounded != r || w.Header().Get(http.TimeoutAppliedHeader) != "" {
+		t.Errorf("Apply without the header = %v, %v; want r unchanged", unbounded, err)
+	}
+
+	r.Header.Set("X-Request-Timeout-Ms", "soon")
+	_, cancel, err = header.Apply(httptest.NewRecorder(), r)
+	cancel()
+	var valErr *http.ValidationError
+	if !errors.As(err, &valErr) || valErr.GetViolations()[0].GetField() != "X-Request-Timeout-Ms" {
+		t.Errorf("Apply with a malformed value = %v, want a violation of the header", err)
+	}
+}
+
+func TestTimeoutHeaderValue(t *testing.T) {
+	if _, ok := http.TimeoutHeaderValue(context.Background(), "X-Request-Timeout-Ms"); ok {
+		t.Error("a context without deadline has a timeout header value")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	value, ok := http.TimeoutHeaderValue(ctx, "X-Request-Timeout-Ms")
+	if ms, err := http.ParseTimeout(value); !ok || err != nil || ms <= time.Second || ms > 2*time.Second {
+		t.Errorf("TimeoutHeaderValue = %q, want about 2000 milliseconds", value)
+	}
+	value, ok = http.TimeoutHeaderValue(ctx, "grpc-timeout")
+	if !ok || !strings.HasSuffix(value, "m") {
+		t.Errorf("TimeoutHeaderValue for grpc-timeout = %q, want milliseconds with their unit", value)
+	}
+}
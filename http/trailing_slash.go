@@ -0,0 +1,19 @@
+package http
+
+import (
+	nethttp "net/http"
+	"strings"
+)
+
+// TrailingSlashRedirectHandler answers requests for the trailing-slash form of
+// a route ("/users/") with a 308 Permanent Redirect to its canonical form
+// ("/users"), keeping the query string. Generated servers built with
+// trailing_slash=redirect register it on the trailing-slash form of every route.
+func TrailingSlashRedirectHandler() nethttp.Handler {
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		target := *r.URL
+		target.Path = strings.TrimSuffix(target.Path, "/")
+		target.RawPath = strings.TrimSuffix(target.RawPath, "/")
+		nethttp.Redirect(w, r, target.String(), nethttp.StatusPermanentRedirect)
+	})
+}
@@ -0,0 +1,33 @@
+package http_test
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestTrailingSlashRedirectHandler(t *testing.T) {
+	tests := []struct {
+		target   string
+		location string
+	}{
+		{target: "/api/v1/users/", location: "/api/v1/users"},
+		{target: "/api/v1/users/42/?fields=name&fields=email", location: "/api/v1/users/42?fields=name&fields=email"},
+		{target: "/api/v1/files/a%2Fb/", location: "/api/v1/files/a%2Fb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			http.TrailingSlashRedirectHandler().
+				ServeHTTP(rec, httptest.NewRequest(nethttp.MethodPost, tt.target, nil))
+			if rec.Code != nethttp.StatusPermanentRedirect {
+				t.Errorf("status = %d, want %d", rec.Code, nethttp.StatusPermanentRedirect)
+			}
+			if got := rec.Header().Get("Location"); got != tt.location {
+				t.Errorf("Location = %q, want %q", got, tt.location)
+			}
+		})
+	}
+}
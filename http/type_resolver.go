@@ -0,0 +1,46 @@
+package http
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// TypeResolver resolves the types protojson needs for google.protobuf.Any
+// fields, as the Resolver of protojson.MarshalOptions and UnmarshalOptions.
+// *protoregistry.Types implements it.
+type TypeResolver interface {
+	protoregistry.MessageTypeResolver
+	protoregistry.ExtensionTypeResolver
+}
+
+// NewTypeResolver returns a TypeResolver looking up messages in resolver.
+// Extensions are looked up in resolver when it implements
+// protoregistry.ExtensionTypeResolver, and in protoregistry.GlobalTypes
+// otherwise. A nil resolver returns nil, which protojson treats as
+// protoregistry.GlobalTypes.
+func NewTypeResolver(resolver protoregistry.MessageTypeResolver) TypeResolver {
+	if resolver == nil {
+		return nil
+	}
+	if full, ok := resolver.(TypeResolver); ok {
+		return full
+	}
+	return globalExtensionsResolver{resolver}
+}
+
+// globalExtensionsResolver completes a MessageTypeResolver with the global
+// extension registry.
+type globalExtensionsResolver struct {
+	protoregistry.MessageTypeResolver
+}
+
+func (globalExtensionsResolver) FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	return protoregistry.GlobalTypes.FindExtensionByName(field)
+}
+
+func (globalExtensionsResolver) FindExtensionByNumber(
+	message protoreflect.FullName,
+	field protoreflect.FieldNumber,
+) (protoreflect.ExtensionType, error) {
+	return protoregistry.GlobalTypes.FindExtensionByNumber(message, field)
+}
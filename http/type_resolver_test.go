@@ -0,0 +1,95 @@
+package http_test
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// messagesOnly hides the extension methods of a resolver.
+type messagesOnly struct {
+	protoregistry.MessageTypeResolver
+}
+
+// localNoteTypes returns a registry holding only the message resolver.test.Note
+// { string text = 1; }, which the global registry does not know.
+func localNoteTypes(t *testing.T) (*protoregistry.Types, protoreflect.MessageType) {
+	t.Helper()
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("resolver_test.proto"),
+		Package: proto.String("resolver.test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Note"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("text"),
+				JsonName: proto.String("text"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			}},
+		}},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noteType := dynamicpb.NewMessageType(file.Messages().ByName("Note"))
+	types := new(protoregistry.Types)
+	if err = types.RegisterMessage(noteType); err != nil {
+		t.Fatal(err)
+	}
+	return types, noteType
+}
+
+func TestNewTypeResolver(t *testing.T) {
+	if http.NewTypeResolver(nil) != nil {
+		t.Error("NewTypeResolver(nil) != nil")
+	}
+
+	types, noteType := localNoteTypes(t)
+	if got := http.NewTypeResolver(types); got != types {
+		t.Errorf("NewTypeResolver(types) = %v, want the registry itself", got)
+	}
+
+	note := noteType.New()
+	note.Set(note.Descriptor().Fields().ByName("text"), protoreflect.ValueOfString("hi"))
+	payload, err := anypb.New(note.Interface())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = protojson.Marshal(payload); err == nil {
+		t.Fatal("the global registry resolved resolver.test.Note")
+	}
+
+	resolver := http.NewTypeResolver(messagesOnly{types})
+	data, err := protojson.MarshalOptions{Resolver: resolver}.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(strings.ReplaceAll(string(data), " ", ""), `"text":"hi"`) {
+		t.Errorf("Marshal = %s, want the note's text", data)
+	}
+
+	var decoded anypb.Any
+	if err = (protojson.UnmarshalOptions{Resolver: resolver}).Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if decoded.GetTypeUrl() != payload.GetTypeUrl() {
+		t.Errorf("type URL = %q, want %q", decoded.GetTypeUrl(), payload.GetTypeUrl())
+	}
+
+	// Extensions still resolve against the global registry
+	if _, err = resolver.FindExtensionByName("sebuf.http.config"); err != nil {
+		t.Errorf("FindExtensionByName(sebuf.http.config): %v", err)
+	}
+}
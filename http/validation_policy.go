@@ -0,0 +1,159 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	nethttp "net/http"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ValidationMode selects how a generated server treats a request that fails
+// header or protovalidate validation.
+type ValidationMode int
+
+const (
+	// ValidationEnforce rejects invalid requests with a ValidationError. It is
+	// the default.
+	ValidationEnforce ValidationMode = iota
+	// ValidationWarn runs validation but lets invalid requests through. The
+	// violations are logged and made available to the handler through
+	// ViolationsFromContext.
+	ValidationWarn
+	// ValidationSkip does not run validation at all.
+	ValidationSkip
+)
+
+// String returns the mode's name.
+func (m ValidationMode) String() string {
+	switch m {
+	case ValidationEnforce:
+		return "enforce"
+	case ValidationWarn:
+		return "warn"
+	case ValidationSkip:
+		return "skip"
+	default:
+		return "unknown"
+	}
+}
+
+// ValidationPolicy chooses the ValidationMode for a request. Generated servers
+// consult it with a nil msg when header validation fails, and with the bound
+// request message before validating it. A nil policy always enforces
+// validation.
+type ValidationPolicy func(r *nethttp.Request, msg proto.Message) ValidationMode
+
+// Mode returns the mode p selects for r and msg, or ValidationEnforce when p
+// is nil.
+func (p ValidationPolicy) Mode(r *nethttp.Request, msg proto.Message) ValidationMode {
+	if p == nil {
+		return ValidationEnforce
+	}
+	return p(r, msg)
+}
+
+// ApplyValidationMode decides what happens to a request whose validation failed
+// with verr under mode. It reports false when the request must be rejected with
+// verr. Otherwise it returns the request to continue with: under
+// ValidationWarn, the violations are logged to logger (slog.Default() when nil)
+// and added to the request context for ViolationsFromContext. Enforced and
+// warned violations are counted in MetricValidationFailures when the request
+// is served through MetricsMiddleware.
+func ApplyValidationMode(
+	r *nethttp.Request,
+	mode ValidationMode,
+	verr *ValidationError,
+	logger *slog.Logger,
+) (*nethttp.Request, bool) {
+	switch mode {
+	case ValidationSkip:
+		return r, true
+	case ValidationWarn:
+		recordValidationFailure(r.Context(), verr)
+		if logger == nil {
+			logger = slog.Default()
+		}
+		for _, violation := range verr.GetViolations() {
+			logger.WarnContext(r.Context(), "request validation failed",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("field", violation.GetField()),
+				slog.String("description", violation.GetDescription()),
+			)
+		}
+		violations := slices.Concat(ViolationsFromContext(r.Context()), verr.GetViolations())
+		return r.WithContext(context.WithValue(r.Context(), violationsCtxKey{}, violations)), true
+	default:
+		recordValidationFailure(r.Context(), verr)
+		return r, false
+	}
+}
+
+type violationsCtxKey struct{}
+
+// ViolationsFromContext returns the header and message validation violations
+// that a ValidationWarn policy let through for the request being handled, or
+// nil when there were none.
+func ViolationsFromContext(ctx context.Context) []*FieldViolation {
+	violations, _ := ctx.Value(violationsCtxKey{}).([]*FieldViolation)
+	return violations
+}
+
+// ValidationFailureMode selects how a generated server treats a request whose
+// message it cannot validate at all, because building its protovalidate
+// validator failed, for example on a buf.validate CEL expression that does not
+// compile. Registration already fails on such messages, so this only matters
+// for types that were not warmed up or whose validation fails at run time.
+type ValidationFailureMode int
+
+const (
+	// ValidationFailClosed rejects the request with an internal error. It is
+	// the default.
+	ValidationFailClosed ValidationFailureMode = iota
+	// ValidationFailOpen lets the request through unvalidated.
+	ValidationFailOpen
+)
+
+// String returns the mode's name.
+func (m ValidationFailureMode) String() string {
+	switch m {
+	case ValidationFailClosed:
+		return "fail-closed"
+	case ValidationFailOpen:
+		return "fail-open"
+	default:
+		return "unknown"
+	}
+}
+
+// ApplyValidationFailure decides what happens to a request whose message could
+// not be validated because its validator failed with err. The failure is
+// logged to logger (slog.Default() when nil) either way; it reports whether
+// the request may be handled unvalidated, which only ValidationFailOpen
+// allows.
+func ApplyValidationFailure(
+	r *nethttp.Request,
+	mode ValidationFailureMode,
+	err error,
+	logger *slog.Logger,
+) bool {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if mode == ValidationFailOpen {
+		logger.WarnContext(r.Context(), "request validation unavailable, handling the request unvalidated",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.String("error", err.Error()),
+		)
+		return true
+	}
+	logger.ErrorContext(r.Context(), "request validation unavailable, rejecting the request",
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.String("error", err.Error()),
+	)
+	return false
+}
@@ -0,0 +1,100 @@
+package http_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func titleViolation() *http.ValidationError {
+	return &http.ValidationError{Violations: []*http.FieldViolation{
+		{Field: "title", Description: "value length must be at least 3 characters"},
+	}}
+}
+
+func TestValidationPolicy_NilEnforces(t *testing.T) {
+	var policy http.ValidationPolicy
+	r := httptest.NewRequest(nethttp.MethodPost, "/notes", nil)
+	if mode := policy.Mode(r, nil); mode != http.ValidationEnforce {
+		t.Errorf("nil policy mode = %v, want enforce", mode)
+	}
+
+	policy = func(*nethttp.Request, proto.Message) http.ValidationMode { return http.ValidationSkip }
+	if mode := policy.Mode(r, nil); mode != http.ValidationSkip {
+		t.Errorf("policy mode = %v, want skip", mode)
+	}
+}
+
+func TestApplyValidationMode(t *testing.T) {
+	r := httptest.NewRequest(nethttp.MethodPost, "/notes", nil)
+
+	if _, proceed := http.ApplyValidationMode(r, http.ValidationEnforce, titleViolation(), nil); proceed {
+		t.Error("enforce should reject")
+	}
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	got, proceed := http.ApplyValidationMode(r, http.ValidationSkip, titleViolation(), logger)
+	if !proceed || got != r || logs.Len() != 0 {
+		t.Errorf("skip should proceed with the same request without logging, logged %q", logs.String())
+	}
+
+	got, proceed = http.ApplyValidationMode(r, http.ValidationWarn, titleViolation(), logger)
+	if !proceed {
+		t.Fatal("warn should proceed")
+	}
+	if !strings.Contains(logs.String(), "field=title") {
+		t.Errorf("warn should log the violation, got %q", logs.String())
+	}
+	if violations := http.ViolationsFromContext(got.Context()); len(violations) != 1 ||
+		violations[0].GetField() != "title" {
+		t.Errorf("ViolationsFromContext = %v", violations)
+	}
+	if http.ViolationsFromContext(r.Context()) != nil {
+		t.Error("the original request must not carry violations")
+	}
+}
+
+func TestApplyValidationMode_WarnAccumulates(t *testing.T) {
+	r := httptest.NewRequest(nethttp.MethodPost, "/notes", nil)
+	logger := slog.New(slog.DiscardHandler)
+	headerErr := &http.ValidationError{Violations: []*http.FieldViolation{{Field: "X-Tenant-ID"}}}
+
+	r, _ = http.ApplyValidationMode(r, http.ValidationWarn, headerErr, logger)
+	r, _ = http.ApplyValidationMode(r, http.ValidationWarn, titleViolation(), logger)
+
+	violations := http.ViolationsFromContext(r.Context())
+	if len(violations) != 2 || violations[0].GetField() != "X-Tenant-ID" || violations[1].GetField() != "title" {
+		t.Errorf("ViolationsFromContext = %v, want header then message violations", violations)
+	}
+}
+
+func TestApplyValidationFailure(t *testing.T) {
+	r := httptest.NewRequest(nethttp.MethodPost, "/notes", nil)
+	cause := errors.New("compilation error: undefined field 'titel'")
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	if http.ApplyValidationFailure(r, http.ValidationFailClosed, cause, logger) {
+		t.Error("fail-closed should reject")
+	}
+	if !strings.Contains(logs.String(), "level=ERROR") || !strings.Contains(logs.String(), "titel") {
+		t.Errorf("fail-closed should log the error, got %q", logs.String())
+	}
+
+	logs.Reset()
+	if !http.ApplyValidationFailure(r, http.ValidationFailOpen, cause, logger) {
+		t.Error("fail-open should proceed")
+	}
+	if !strings.Contains(logs.String(), "level=WARN") || !strings.Contains(logs.String(), "titel") {
+		t.Errorf("fail-open should log the error, got %q", logs.String())
+	}
+}
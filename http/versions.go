@@ -0,0 +1,28 @@
+package http
+
+import (
+	nethttp "net/http"
+)
+
+// Response headers set on the routes of deprecated API versions.
+const (
+	// DeprecationHeader marks a response as served by a deprecated API version.
+	DeprecationHeader = "Deprecation"
+	// SunsetHeader carries the HTTP-date after which the version may stop being
+	// served (RFC 8594).
+	SunsetHeader = "Sunset"
+)
+
+// DeprecatedVersionMiddleware wraps the handler of a deprecated API version's
+// route so that every response, errors included, carries Deprecation: true and,
+// when sunset is not empty, a Sunset header with that HTTP-date. Generated
+// servers wrap the routes of versions annotated with deprecated: true.
+func DeprecatedVersionMiddleware(next nethttp.Handler, sunset string) nethttp.Handler {
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.Header().Set(DeprecationHeader, "true")
+		if sunset != "" {
+			w.Header().Set(SunsetHeader, sunset)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
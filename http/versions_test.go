@@ -0,0 +1,42 @@
+package http_test
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestDeprecatedVersionMiddleware(t *testing.T) {
+	next := nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusNotFound)
+	})
+
+	t.Run("with sunset", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		http.DeprecatedVersionMiddleware(next, "Thu, 01 Jan 2026 00:00:00 GMT").
+			ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, "/api/v1/users", nil))
+		if rec.Code != nethttp.StatusNotFound {
+			t.Errorf("status = %d, want %d", rec.Code, nethttp.StatusNotFound)
+		}
+		if got := rec.Header().Get(http.DeprecationHeader); got != "true" {
+			t.Errorf("Deprecation = %q, want true", got)
+		}
+		if got := rec.Header().Get(http.SunsetHeader); got != "Thu, 01 Jan 2026 00:00:00 GMT" {
+			t.Errorf("Sunset = %q", got)
+		}
+	})
+
+	t.Run("without sunset", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		http.DeprecatedVersionMiddleware(next, "").
+			ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, "/api/v1/users", nil))
+		if got := rec.Header().Get(http.DeprecationHeader); got != "true" {
+			t.Errorf("Deprecation = %q, want true", got)
+		}
+		if _, ok := rec.Header()[http.SunsetHeader]; ok {
+			t.Error("Sunset should not be set without a sunset date")
+		}
+	})
+}
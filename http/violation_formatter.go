@@ -0,0 +1,143 @@
+package http
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Violation describes one failed validation rule, as handed to a
+// ViolationFormatter to produce the FieldViolation description.
+type Violation struct {
+	// Field is the path of the violating field, or the name of the header.
+	Field string
+	// ConstraintID identifies the failed rule: the protovalidate rule ID (for
+	// example "string.min_len"), or for headers "header.required",
+	// "header.format.<format>" and "header.type.<type>".
+	ConstraintID string
+	// Params holds the rule's parameters by name, such as "min_len" for
+	// string.min_len, or "header", "format" and "type" for headers.
+	Params map[string]any
+	// Message is the default English description of the violation.
+	Message string
+}
+
+// ViolationFormatter produces the description of a violation, for example to
+// localize it. Generated servers install one with WithViolationFormatter or
+// WithViolationCatalog.
+type ViolationFormatter func(v Violation) string
+
+// Format returns the description f produces for v, or v.Message when f is nil
+// or produces an empty description.
+func (f ViolationFormatter) Format(v Violation) string {
+	if f == nil {
+		return v.Message
+	}
+	if description := f(v); description != "" {
+		return description
+	}
+	return v.Message
+}
+
+// NewViolationCatalog returns a ViolationFormatter that describes violations
+// with the text/template of their ConstraintID in catalog. Templates are
+// executed with the Violation, so they read rule parameters as
+// {{.Params.min_len}} and the field as {{.Field}}. Violations without a
+// template, or whose template fails to execute, as when it reads a parameter
+// the violation lacks, keep their Message. It returns an error if a template
+// does not parse.
+func NewViolationCatalog(catalog map[string]string) (ViolationFormatter, error) {
+	templates := make(map[string]*template.Template, len(catalog))
+	for id, text := range catalog {
+		tmpl, err := template.New(id).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("violation catalog: %w", err)
+		}
+		templates[id] = tmpl
+	}
+	return func(v Violation) string {
+		tmpl, ok := templates[v.ConstraintID]
+		if !ok {
+			return v.Message
+		}
+		var description strings.Builder
+		if err := tmpl.Execute(&description, v); err != nil {
+			return v.Message
+		}
+		return description.String()
+	}, nil
+}
+
+// MustViolationCatalog is like NewViolationCatalog but panics if a template
+// does not parse. It suits catalogs declared in the program.
+func MustViolationCatalog(catalog map[string]string) ViolationFormatter {
+	formatter, err := NewViolationCatalog(catalog)
+	if err != nil {
+		panic(err)
+	}
+	return formatter
+}
+
+// RuleParams returns the Params of a protovalidate violation whose rule is the
+// field rule of the rules message, set to value: a list value becomes a []any,
+// a message value its proto.Message. It returns nil when rule is nil, as for
+// CEL expressions.
+func RuleParams(rule protoreflect.FieldDescriptor, value protoreflect.Value) map[string]any {
+	if rule == nil || !value.IsValid() {
+		return nil
+	}
+	return map[string]any{string(rule.Name()): ruleParam(rule, value)}
+}
+
+func ruleParam(rule protoreflect.FieldDescriptor, value protoreflect.Value) any {
+	if rule.IsList() {
+		list := value.List()
+		items := make([]any, list.Len())
+		for i := range list.Len() {
+			items[i] = ruleValue(rule, list.Get(i))
+		}
+		return items
+	}
+	return ruleValue(rule, value)
+}
+
+func ruleValue(rule protoreflect.FieldDescriptor, value protoreflect.Value) any {
+	if rule.Message() != nil {
+		return value.Message().Interface()
+	}
+	return value.Interface()
+}
+
+// HeaderViolation returns the Violation of a declared header that is missing,
+// when invalid is nil, or whose value failed validation with invalid.
+func HeaderViolation(header *Header, invalid error) Violation {
+	name := header.GetName()
+	if invalid == nil {
+		return Violation{
+			Field:        name,
+			ConstraintID: "header.required",
+			Params:       map[string]any{"header": name},
+			Message:      fmt.Sprintf("required header '%s' is missing", name),
+		}
+	}
+
+	headerType := header.GetType()
+	if headerType == "" {
+		headerType = "string"
+	}
+	params := map[string]any{"header": name, "type": headerType}
+	constraintID := "header.type." + headerType
+	// Formats only apply to string headers
+	if format := header.GetFormat(); format != "" && headerType == "string" {
+		params["format"] = format
+		constraintID = "header.format." + format
+	}
+	return Violation{
+		Field:        name,
+		ConstraintID: constraintID,
+		Params:       params,
+		Message:      fmt.Sprintf("header '%s' validation failed: %v", name, invalid),
+	}
+}
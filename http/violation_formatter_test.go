@@ -0,0 +1,170 @@
+package http_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	validate "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+var frenchCatalog = map[string]string{
+	"string.min_len": "{{.Field}} doit contenir au moins {{.Params.min_len}} caractères",
+	"string.in": "{{.Field}} doit valoir l'un de " +
+		"{{range $i, $v := .Params.in}}{{if $i}}, {{end}}{{$v}}{{end}}",
+	"header.required":    "l'en-tête {{.Params.header}} est obligatoire",
+	"header.format.uuid": "l'en-tête {{.Params.header}} doit être un UUID",
+	"broken":             "{{.Params.missing}}",
+}
+
+func stringRule(t *testing.T, name protoreflect.Name) protoreflect.FieldDescriptor {
+	t.Helper()
+	rule := (&validate.StringRules{}).ProtoReflect().Descriptor().Fields().ByName(name)
+	if rule == nil {
+		t.Fatalf("no string rule %s", name)
+	}
+	return rule
+}
+
+func TestViolationCatalog(t *testing.T) {
+	formatter, err := http.NewViolationCatalog(frenchCatalog)
+	if err != nil {
+		t.Fatalf("NewViolationCatalog: %v", err)
+	}
+	in := (&validate.StringRules{In: []string{"a", "b"}}).ProtoReflect().Get(stringRule(t, "in"))
+
+	tests := []struct {
+		name      string
+		violation http.Violation
+		want      string
+	}{
+		{
+			name: "min_len interpolates its parameter",
+			violation: http.Violation{
+				Field:        "name",
+				ConstraintID: "string.min_len",
+				Params:       http.RuleParams(stringRule(t, "min_len"), protoreflect.ValueOfUint64(3)),
+				Message:      "value length must be at least 3 characters",
+			},
+			want: "name doit contenir au moins 3 caractères",
+		},
+		{
+			name: "list parameter",
+			violation: http.Violation{
+				Field:        "kind",
+				ConstraintID: "string.in",
+				Params:       http.RuleParams(stringRule(t, "in"), in),
+				Message:      "value must be in list [a, b]",
+			},
+			want: "kind doit valoir l'un de a, b",
+		},
+		{
+			name:      "missing header",
+			violation: http.HeaderViolation(&http.Header{Name: "X-Request-ID", Required: true}, nil),
+			want:      "l'en-tête X-Request-ID est obligatoire",
+		},
+		{
+			name: "header format",
+			violation: http.HeaderViolation(&http.Header{Name: "X-Request-ID", Type: "string", Format: "uuid"},
+				errors.New("invalid UUID format")),
+			want: "l'en-tête X-Request-ID doit être un UUID",
+		},
+		{
+			name: "no template keeps the message",
+			violation: http.Violation{
+				Field: "age", ConstraintID: "int32.gt", Message: "value must be greater than 0",
+			},
+			want: "value must be greater than 0",
+		},
+		{
+			name:      "failing template keeps the message",
+			violation: http.Violation{Field: "x", ConstraintID: "broken", Message: "original"},
+			want:      "original",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatter.Format(tt.violation); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViolationCatalog_ParseError(t *testing.T) {
+	if _, err := http.NewViolationCatalog(map[string]string{"string.min_len": "{{.Field"}); err == nil {
+		t.Error("NewViolationCatalog should reject a template that does not parse")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("MustViolationCatalog should panic on a template that does not parse")
+		}
+	}()
+	http.MustViolationCatalog(map[string]string{"string.min_len": "{{.Field"})
+}
+
+func TestViolationFormatter_Fallback(t *testing.T) {
+	v := http.Violation{Field: "name", ConstraintID: "string.min_len", Message: "too short"}
+	var nilFormatter http.ViolationFormatter
+	if got := nilFormatter.Format(v); got != "too short" {
+		t.Errorf("nil formatter Format() = %q, want the message", got)
+	}
+	empty := http.ViolationFormatter(func(http.Violation) string { return "" })
+	if got := empty.Format(v); got != "too short" {
+		t.Errorf("empty description Format() = %q, want the message", got)
+	}
+}
+
+func TestHeaderViolation(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     *http.Header
+		invalid    error
+		wantID     string
+		wantParams map[string]any
+		wantMsg    string
+	}{
+		{
+			name:       "missing",
+			header:     &http.Header{Name: "X-Api-Key", Required: true},
+			wantID:     "header.required",
+			wantParams: map[string]any{"header": "X-Api-Key"},
+			wantMsg:    "required header 'X-Api-Key' is missing",
+		},
+		{
+			name:       "format",
+			header:     &http.Header{Name: "X-Request-ID", Format: "uuid"},
+			invalid:    errors.New("invalid UUID format"),
+			wantID:     "header.format.uuid",
+			wantParams: map[string]any{"header": "X-Request-ID", "type": "string", "format": "uuid"},
+			wantMsg:    "header 'X-Request-ID' validation failed: invalid UUID format",
+		},
+		{
+			name:       "type",
+			header:     &http.Header{Name: "X-Count", Type: "integer"},
+			invalid:    errors.New("must be a valid integer"),
+			wantID:     "header.type.integer",
+			wantParams: map[string]any{"header": "X-Count", "type": "integer"},
+			wantMsg:    "header 'X-Count' validation failed: must be a valid integer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := http.HeaderViolation(tt.header, tt.invalid)
+			if got.Field != tt.header.GetName() || got.ConstraintID != tt.wantID ||
+				!reflect.DeepEqual(got.Params, tt.wantParams) || got.Message != tt.wantMsg {
+				t.Errorf("HeaderViolation() = %+v, want ID %s, params %v and message %q",
+					got, tt.wantID, tt.wantParams, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestRuleParams_NoRule(t *testing.T) {
+	if got := http.RuleParams(nil, protoreflect.Value{}); got != nil {
+		t.Errorf("RuleParams(nil) = %v, want nil", got)
+	}
+}
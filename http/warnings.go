@@ -0,0 +1,202 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	nethttp "net/http"
+	"strings"
+)
+
+const (
+	// WarningHeader is the response header carrying the warnings of a
+	// successful response, one value per warning, as written by FormatWarning.
+	WarningHeader = "Warning"
+	// WarningsJSONKey is the top-level key of the warnings servers with
+	// WithWarningsInBody add to JSON response objects.
+	WarningsJSONKey = "_warnings"
+)
+
+// warnCode is the RFC 7234 warn-code of the warnings servers send: 299,
+// "Miscellaneous Persistent Warning".
+const warnCode = "299"
+
+// Warning reports a degraded but successful response, such as a listing only
+// some of its backends answered.
+type Warning struct {
+	// Code identifies the kind of warning, e.g. "partial_results".
+	Code string `json:"code"`
+	// Message describes the warning to a person.
+	Message string `json:"message"`
+}
+
+// AddWarning adds a warning to the successful response to the request being
+// handled. Generated servers send it in a Warning header and, with
+// WithWarningsInBody, in the _warnings array of the JSON body.
+func AddWarning(ctx context.Context, code, message string) {
+	warning := Warning{Code: code, Message: message}
+	updateResponse(ctx, "AddWarning", func(c *ResponseControl) { c.warnings = append(c.warnings, warning) })
+}
+
+// FormatWarning returns the Warning header value of w, in the format of RFC
+// 7234 section 5.5 without an agent: 299 - "code: message". Quotes and
+// backslashes are escaped, and control characters replaced by spaces.
+func FormatWarning(w Warning) string {
+	text := w.Message
+	if w.Code != "" {
+		text = w.Code + ": " + w.Message
+	}
+	var b strings.Builder
+	b.WriteString(warnCode + ` - "`)
+	for _, r := range text {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < ' ' || r == 0x7f:
+			b.WriteByte(' ')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// ParseWarnings returns the warnings of Warning header values, each holding
+// one or more comma-separated warnings as written by FormatWarning. The text
+// of each is split into code and message at its first ": "; text without one
+// is a message without a code. Malformed values are skipped.
+func ParseWarnings(values []string) []Warning {
+	var warnings []Warning
+	for _, value := range values {
+		for value != "" {
+			text, rest, ok := nextWarningText(value)
+			if ok {
+				var w Warning
+				if code, message, found := strings.Cut(text, ": "); found {
+					w = Warning{Code: code, Message: message}
+				} else {
+					w = Warning{Message: text}
+				}
+				warnings = append(warnings, w)
+			}
+			value = rest
+		}
+	}
+	return warnings
+}
+
+// nextWarningText reads the first warning of value, warn-code SP warn-agent SP
+// quoted warn-text with an optional quoted warn-date, and returns its
+// unescaped text and what follows its separating comma. It reports false when
+// the warning is malformed, returning the rest after the next comma.
+func nextWarningText(value string) (string, string, bool) {
+	value = strings.TrimLeft(value, " \t,")
+	fields := strings.SplitN(value, " ", 3)
+	if len(fields) < 3 || len(fields[0]) != 3 || !strings.HasPrefix(fields[2], `"`) {
+		_, rest, _ := strings.Cut(value, ",")
+		return "", rest, false
+	}
+	quoted := fields[2]
+	var text strings.Builder
+	for i := 1; i < len(quoted); i++ {
+		switch c := quoted[i]; {
+		case c == '\\' && i+1 < len(quoted):
+			i++
+			text.WriteByte(quoted[i])
+		case c == '"':
+			// Skip the warn-date, a second quoted string, if any
+			rest := quoted[i+1:]
+			if _, after, found := strings.Cut(rest, ","); found {
+				return text.String(), after, true
+			}
+			return text.String(), "", true
+		default:
+			text.WriteByte(c)
+		}
+	}
+	return "", "", false
+}
+
+// AppendJSONWarnings returns body, a JSON object, with warnings added under
+// its top-level _warnings key. Bodies that are not JSON objects, such as the
+// arrays of root-unwrapped responses, are returned unchanged, as is body when
+// there are no warnings.
+func AppendJSONWarnings(body []byte, warnings []Warning) []byte {
+	trimmed := bytes.TrimSpace(body)
+	if len(warnings) == 0 || len(trimmed) < 2 || trimmed[0] != '{' || trimmed[len(trimmed)-1] != '}' {
+		return body
+	}
+	encoded, err := json.Marshal(warnings)
+	if err != nil {
+		return body
+	}
+	inner := bytes.TrimSpace(trimmed[1 : len(trimmed)-1])
+	out := make([]byte, 0, len(trimmed)+len(encoded)+len(WarningsJSONKey)+4)
+	out = append(out, '{')
+	if len(inner) > 0 {
+		out = append(out, inner...)
+		out = append(out, ',')
+	}
+	out = append(out, `"`+WarningsJSONKey+`":`...)
+	out = append(out, encoded...)
+	return append(out, '}')
+}
+
+// TakeJSONWarnings returns body, a JSON object, without its top-level
+// _warnings key, and the warnings it held. Bodies without one are returned
+// unchanged.
+func TakeJSONWarnings(body []byte) ([]byte, []Warning) {
+	if !bytes.Contains(body, []byte(`"`+WarningsJSONKey+`"`)) {
+		return body, nil
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(body, &object); err != nil {
+		return body, nil
+	}
+	raw, ok := object[WarningsJSONKey]
+	if !ok {
+		return body, nil
+	}
+	delete(object, WarningsJSONKey)
+	stripped, err := json.Marshal(object)
+	if err != nil {
+		return body, nil
+	}
+	var warnings []Warning
+	_ = json.Unmarshal(raw, &warnings)
+	return stripped, warnings
+}
+
+// ResponseMetadata describes the successful response to a generated client
+// call, beyond its message.
+type ResponseMetadata struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Header holds the response headers.
+	Header nethttp.Header
+	// Warnings holds the warnings of a partial response, from its Warning
+	// headers or, without any, from the _warnings of its JSON body.
+	Warnings []Warning
+}
+
+// ReadResponseMetadata returns the metadata of resp, whose body is body, and
+// body without the warnings a server with WithWarningsInBody added to it.
+// fromBody is false for bodies that cannot hold them, such as binary or
+// root-unwrapped ones, which are returned unchanged.
+func ReadResponseMetadata(resp *nethttp.Response, body []byte, fromBody bool) ([]byte, ResponseMetadata) {
+	metadata := ResponseMetadata{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Warnings:   ParseWarnings(resp.Header.Values(WarningHeader)),
+	}
+	if fromBody {
+		var bodyWarnings []Warning
+		body, bodyWarnings = TakeJSONWarnings(body)
+		if len(metadata.Warnings) == 0 {
+			metadata.Warnings = bodyWarnings
+		}
+	}
+	return body, metadata
+}
@@ -0,0 +1,123 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	nethttp "net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestAddWarningWritesWarningHeaders(t *testing.T) {
+	ctx, control := http.ContextWithResponseControl(context.Background())
+	http.AddWarning(ctx, "partial_results", "3 of 5 backends responded")
+	http.AddWarning(ctx, "stale", `cache is "old", see \docs`)
+
+	rec := httptest.NewRecorder()
+	control.WriteHeader(rec)
+	want := []string{
+		`299 - "partial_results: 3 of 5 backends responded"`,
+		`299 - "stale: cache is \"old\", see \\docs"`,
+	}
+	if got := rec.Header().Values(http.WarningHeader); !reflect.DeepEqual(got, want) {
+		t.Errorf("Warning = %q, want %q", got, want)
+	}
+	if got := control.Warnings(); len(got) != 2 || got[0].Code != "partial_results" {
+		t.Errorf("Warnings = %v, want both warnings", got)
+	}
+
+	warnings := captureWarnings(t)
+	http.AddWarning(ctx, "late", "after the response")
+	if len(control.Warnings()) != 2 || !strings.Contains(warnings.String(), "AddWarning called after") {
+		t.Errorf("a late warning should be dropped with a log, got %v %q", control.Warnings(), warnings.String())
+	}
+}
+
+func TestParseWarnings(t *testing.T) {
+	want := []http.Warning{
+		{Code: "partial_results", Message: "3 of 5 backends responded"},
+		{Code: "stale", Message: `cache is "old", see \docs`},
+		{Message: "no code here"},
+		{Code: "dated", Message: "with a date"},
+	}
+	values := []string{
+		// Joined the way fetch and proxies fold repeated headers
+		http.FormatWarning(want[0]) + ", " + http.FormatWarning(want[1]),
+		http.FormatWarning(want[2]),
+		`299 api.example.com "dated: with a date" "Wed, 21 Oct 2015 07:28:00 GMT"`,
+		"not a warning",
+	}
+	if got := http.ParseWarnings(values); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseWarnings = %+v, want %+v", got, want)
+	}
+}
+
+func TestFormatWarningReplacesControlCharacters(t *testing.T) {
+	got := http.FormatWarning(http.Warning{Code: "split", Message: "line one\r\nline two"})
+	if got != `299 - "split: line one  line two"` {
+		t.Errorf("FormatWarning = %q", got)
+	}
+}
+
+func TestJSONWarningsRoundTrip(t *testing.T) {
+	warnings := []http.Warning{{Code: "partial_results", Message: "3 of 5 backends responded"}}
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"object", `{"items":["a","b"]}`, `{"items":["a","b"],"_warnings":[` +
+			`{"code":"partial_results","message":"3 of 5 backends responded"}]}`},
+		{"empty object", "{}\n", `{"_warnings":[{"code":"partial_results","message":"3 of 5 backends responded"}]}`},
+		{"root array", `["a","b"]`, `["a","b"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := http.AppendJSONWarnings([]byte(tt.body), warnings)
+			if string(body) != tt.want {
+				t.Fatalf("AppendJSONWarnings = %s, want %s", body, tt.want)
+			}
+			stripped, got := http.TakeJSONWarnings(body)
+			if tt.name == "root array" {
+				if got != nil || string(stripped) != tt.body {
+					t.Errorf("TakeJSONWarnings = %s, %v, want the body unchanged", stripped, got)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, warnings) {
+				t.Errorf("TakeJSONWarnings warnings = %v, want %v", got, warnings)
+			}
+			var original, after any
+			_ = json.Unmarshal([]byte(tt.body), &original)
+			_ = json.Unmarshal(stripped, &after)
+			if !reflect.DeepEqual(original, after) {
+				t.Errorf("TakeJSONWarnings body = %s, want %s", stripped, tt.body)
+			}
+		})
+	}
+}
+
+func TestReadResponseMetadata(t *testing.T) {
+	body := []byte(`{"id":"1","_warnings":[{"code":"from_body","message":"body"}]}`)
+	resp := &nethttp.Response{StatusCode: nethttp.StatusOK, Header: nethttp.Header{}}
+
+	stripped, metadata := http.ReadResponseMetadata(resp, body, true)
+	if string(stripped) != `{"id":"1"}` || len(metadata.Warnings) != 1 || metadata.Warnings[0].Code != "from_body" {
+		t.Errorf("without a header: body %s, metadata %+v, want the body warnings", stripped, metadata)
+	}
+
+	resp.Header.Add(http.WarningHeader, http.FormatWarning(http.Warning{Code: "from_header", Message: "header"}))
+	stripped, metadata = http.ReadResponseMetadata(resp, body, true)
+	if string(stripped) != `{"id":"1"}` || len(metadata.Warnings) != 1 || metadata.Warnings[0].Code != "from_header" {
+		t.Errorf("with a header: body %s, metadata %+v, want the header warnings", stripped, metadata)
+	}
+
+	stripped, _ = http.ReadResponseMetadata(resp, body, false)
+	if string(stripped) != string(body) {
+		t.Errorf("fromBody false changed the body to %s", stripped)
+	}
+}
@@ -0,0 +1,232 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	nethttp "net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultWebhookSignatureHeader carries the signature of webhooks whose
+// WebhookConfig does not name a header.
+const DefaultWebhookSignatureHeader = "X-Webhook-Signature"
+
+const (
+	defaultWebhookMaxAttempts = 3
+	defaultWebhookBackoff     = 500 * time.Millisecond
+)
+
+// ErrInvalidWebhookSignature is returned when a received webhook's signature
+// is missing or does not match its body.
+var ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
+// WebhookDeliveryError reports a webhook the receiver answered with a non-2xx
+// status on the last attempt.
+type WebhookDeliveryError struct {
+	StatusCode int
+	Body       []byte
+	Attempts   int
+}
+
+func (e *WebhookDeliveryError) Error() string {
+	return fmt.Sprintf("webhook delivery failed with status %d after %d attempt(s)", e.StatusCode, e.Attempts)
+}
+
+// WebhookOption configures the delivery of a webhook.
+type WebhookOption func(*webhookOptions)
+
+type webhookOptions struct {
+	client      *nethttp.Client
+	secret      []byte
+	maxAttempts int
+	backoff     time.Duration
+	header      nethttp.Header
+}
+
+// WithWebhookSecret sets the key the body is signed with. Without it the
+// webhook is sent unsigned.
+func WithWebhookSecret(secret []byte) WebhookOption {
+	return func(o *webhookOptions) {
+		o.secret = secret
+	}
+}
+
+// WithWebhookHTTPClient sets the client webhooks are sent with. Defaults to
+// http.DefaultClient.
+func WithWebhookHTTPClient(client *nethttp.Client) WebhookOption {
+	return func(o *webhookOptions) {
+		o.client = client
+	}
+}
+
+// WithWebhookRetries sets how many times a webhook is sent at most and the
+// wait before the first retry, doubling after each one. Defaults to 3 attempts
+// and 500ms.
+func WithWebhookRetries(maxAttempts int, backoff time.Duration) WebhookOption {
+	return func(o *webhookOptions) {
+		o.maxAttempts = maxAttempts
+		o.backoff = backoff
+	}
+}
+
+// WithWebhookHeader adds a header to the webhook request.
+func WithWebhookHeader(key, value string) WebhookOption {
+	return func(o *webhookOptions) {
+		o.header.Add(key, value)
+	}
+}
+
+// MarshalWebhookPayload serializes a webhook payload the way generated clients
+// serialize requests: with the message's own MarshalJSON when sebuf generated
+// one for its encoding annotations, and protojson otherwise.
+func MarshalWebhookPayload(payload proto.Message) ([]byte, error) {
+	if marshaler, ok := payload.(json.Marshaler); ok {
+		return marshaler.MarshalJSON()
+	}
+	return protojson.Marshal(payload)
+}
+
+// SignWebhook returns the signature header value of body:
+// "<algorithm>=<hex HMAC of body>", e.g. "sha256=5d41...".
+func SignWebhook(body, secret []byte, algorithm WebhookSignatureAlgorithm) string {
+	name, newHash := webhookHash(algorithm)
+	mac := hmac.New(newHash, secret)
+	mac.Write(body)
+	return name + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SendWebhook POSTs body as JSON to url, signed in signatureHeader when a
+// secret is set. Transport errors and 5xx responses are retried with
+// exponential backoff; other non-2xx responses fail right away with a
+// *WebhookDeliveryError. Generated Send<Message> functions call it with the
+// settings of the message's webhook annotation.
+func SendWebhook(
+	ctx context.Context,
+	url string,
+	body []byte,
+	signatureHeader string,
+	algorithm WebhookSignatureAlgorithm,
+	opts ...WebhookOption,
+) error {
+	o := &webhookOptions{
+		client:      nethttp.DefaultClient,
+		maxAttempts: defaultWebhookMaxAttempts,
+		backoff:     defaultWebhookBackoff,
+		header:      make(nethttp.Header),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if signatureHeader == "" {
+		signatureHeader = DefaultWebhookSignatureHeader
+	}
+
+	backoff := o.backoff
+	for attempt := 1; ; attempt++ {
+		retry, err := sendWebhookAttempt(ctx, o, url, body, signatureHeader, algorithm, attempt)
+		if !retry || attempt >= o.maxAttempts {
+			return err
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// sendWebhookAttempt sends the webhook once, reporting whether a failure may
+// be retried.
+func sendWebhookAttempt(
+	ctx context.Context,
+	o *webhookOptions,
+	url string,
+	body []byte,
+	signatureHeader string,
+	algorithm WebhookSignatureAlgorithm,
+	attempt int,
+) (bool, error) {
+	req, err := nethttp.NewRequestWithContext(ctx, nethttp.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	for key, values := range o.header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", JSONContentType)
+	if o.secret != nil {
+		req.Header.Set(signatureHeader, SignWebhook(body, o.secret, algorithm))
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	return resp.StatusCode >= nethttp.StatusInternalServerError, &WebhookDeliveryError{
+		StatusCode: resp.StatusCode,
+		Body:       respBody,
+		Attempts:   attempt,
+	}
+}
+
+// VerifyWebhookSignature checks the signature in signatureHeader of a received
+// webhook against its body, returning ErrInvalidWebhookSignature when it is
+// missing or does not match. The body is read and then restored, so the
+// handler can still decode it.
+func VerifyWebhookSignature(
+	r *nethttp.Request,
+	signatureHeader string,
+	algorithm WebhookSignatureAlgorithm,
+	secret []byte,
+) error {
+	if signatureHeader == "" {
+		signatureHeader = DefaultWebhookSignatureHeader
+	}
+	signature := r.Header.Get(signatureHeader)
+	if signature == "" {
+		return ErrInvalidWebhookSignature
+	}
+	if r.Body == nil {
+		r.Body = nethttp.NoBody
+	}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to read webhook body: %w", err)
+	}
+	expected := SignWebhook(body, secret, algorithm)
+	if !hmac.Equal([]byte(strings.TrimSpace(signature)), []byte(expected)) {
+		return ErrInvalidWebhookSignature
+	}
+	return nil
+}
+
+// webhookHash returns the signature prefix and hash of algorithm.
+func webhookHash(algorithm WebhookSignatureAlgorithm) (string, func() hash.Hash) {
+	if algorithm == WebhookSignatureAlgorithm_WEBHOOK_SIGNATURE_ALGORITHM_SHA512 {
+		return "sha512", sha512.New
+	}
+	return "sha256", sha256.New
+}
@@ -0,0 +1,127 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+var webhookSecret = []byte("s3cret")
+
+func TestSendWebhook_SignedRoundTrip(t *testing.T) {
+	sha512 := http.WebhookSignatureAlgorithm_WEBHOOK_SIGNATURE_ALGORITHM_SHA512
+	payload := &http.FieldViolation{Field: "name", Description: "required"}
+	var calls atomic.Int32
+	received := make(chan *http.FieldViolation, 1)
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(nethttp.StatusServiceUnavailable)
+			return
+		}
+		if err := http.VerifyWebhookSignature(r, "X-Signature", sha512, webhookSecret); err != nil {
+			t.Errorf("VerifyWebhookSignature: %v", err)
+			w.WriteHeader(nethttp.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		got := &http.FieldViolation{}
+		if err := protojson.Unmarshal(body, got); err != nil {
+			t.Errorf("decoding the verified body: %v", err)
+		}
+		received <- got
+	}))
+	defer srv.Close()
+
+	body, err := http.MarshalWebhookPayload(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = http.SendWebhook(context.Background(), srv.URL, body, "X-Signature", sha512,
+		http.WithWebhookSecret(webhookSecret), http.WithWebhookRetries(3, time.Millisecond))
+	if err != nil {
+		t.Fatalf("SendWebhook: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("receiver called %d times, want 2 (one 503, one retry)", got)
+	}
+	if got := <-received; got.GetField() != "name" || got.GetDescription() != "required" {
+		t.Errorf("received %v, want %v", got, payload)
+	}
+}
+
+func TestSendWebhook_ClientErrorIsNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		calls.Add(1)
+		w.WriteHeader(nethttp.StatusGone)
+	}))
+	defer srv.Close()
+
+	err := http.SendWebhook(context.Background(), srv.URL, []byte("{}"), "",
+		http.WebhookSignatureAlgorithm_WEBHOOK_SIGNATURE_ALGORITHM_UNSPECIFIED,
+		http.WithWebhookRetries(3, time.Millisecond))
+	var deliveryErr *http.WebhookDeliveryError
+	if !errors.As(err, &deliveryErr) || deliveryErr.StatusCode != nethttp.StatusGone || deliveryErr.Attempts != 1 {
+		t.Fatalf("SendWebhook error = %v, want a 410 delivery error after one attempt", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("receiver called %d times, want 1", got)
+	}
+}
+
+func TestSendWebhook_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		calls.Add(1)
+		w.WriteHeader(nethttp.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	err := http.SendWebhook(context.Background(), srv.URL, []byte("{}"), "",
+		http.WebhookSignatureAlgorithm_WEBHOOK_SIGNATURE_ALGORITHM_UNSPECIFIED,
+		http.WithWebhookRetries(2, time.Millisecond))
+	var deliveryErr *http.WebhookDeliveryError
+	if !errors.As(err, &deliveryErr) || deliveryErr.Attempts != 2 {
+		t.Fatalf("SendWebhook error = %v, want a delivery error after two attempts", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("receiver called %d times, want 2", got)
+	}
+}
+
+func TestVerifyWebhookSignature_Rejects(t *testing.T) {
+	sha256 := http.WebhookSignatureAlgorithm_WEBHOOK_SIGNATURE_ALGORITHM_SHA256
+	sha512 := http.WebhookSignatureAlgorithm_WEBHOOK_SIGNATURE_ALGORITHM_SHA512
+	body := []byte(`{"field":"name"}`)
+	tests := []struct {
+		name      string
+		signature string
+	}{
+		{name: "missing"},
+		{name: "wrong secret", signature: http.SignWebhook(body, []byte("other"), sha256)},
+		{name: "tampered body", signature: http.SignWebhook([]byte(`{"field":"id"}`), webhookSecret, sha256)},
+		{name: "wrong algorithm", signature: http.SignWebhook(body, webhookSecret, sha512)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(nethttp.MethodPost, "/hooks", bytes.NewReader(body))
+			if tt.signature != "" {
+				r.Header.Set(http.DefaultWebhookSignatureHeader, tt.signature)
+			}
+			err := http.VerifyWebhookSignature(r, "", sha256, webhookSecret)
+			if !errors.Is(err, http.ErrInvalidWebhookSignature) {
+				t.Errorf("VerifyWebhookSignature = %v, want ErrInvalidWebhookSignature", err)
+			}
+		})
+	}
+}
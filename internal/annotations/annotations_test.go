@@ -2,6 +2,7 @@ package annotations
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/SebastienMelki/sebuf/http"
@@ -116,6 +117,10 @@ func TestExtractPathParams(t *testing.T) {
 
 		// With trailing content
 		{"path with trailing content", "/users/{id}/profile", []string{"id"}},
+
+		// Wildcards
+		{"wildcard", "/files/{path...}", []string{"path"}},
+		{"param and wildcard", "/buckets/{bucket}/objects/{key...}", []string{"bucket", "key"}},
 	}
 
 	for _, tt := range tests {
@@ -153,6 +158,7 @@ func TestBuildHTTPPath(t *testing.T) {
 		{"nested service path", "/api/v1/admin", "/users/list", "/api/v1/admin/users/list"},
 		{"path with params", "/api/v1", "/users/{user_id}", "/api/v1/users/{user_id}"},
 		{"both with params", "/orgs/{org_id}", "/teams/{team_id}", "/orgs/{org_id}/teams/{team_id}"},
+		{"method path with wildcard", "/api/v1", "/files/{path...}", "/api/v1/files/{path...}"},
 
 		// Edge cases
 		{"service only no leading slash", "api", "", "/api"},
@@ -170,6 +176,41 @@ func TestBuildHTTPPath(t *testing.T) {
 	}
 }
 
+func TestPathWildcard(t *testing.T) {
+	tests := []struct {
+		path     string
+		wildcard string
+		template string
+		wantErr  bool
+	}{
+		{"/files/{path...}", "path", "/files/{path}", false},
+		{"/buckets/{bucket}/objects/{key...}", "key", "/buckets/{bucket}/objects/{key}", false},
+		{"/{path...}", "path", "/{path}", false},
+		{"/users/{id}", "", "/users/{id}", false},
+		{"/files", "", "/files", false},
+		{"/files/{path...}/raw", "", "/files/{path...}/raw", true},
+		{"/files/v{path...}", "", "/files/v{path...}", true},
+		{"/files/{...}", "", "/files/{...}", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := PathWildcard(tt.path); got != tt.wildcard {
+				t.Errorf("PathWildcard(%q) = %q, expected %q", tt.path, got, tt.wildcard)
+			}
+			if tt.wildcard != "" && !IsPathWildcard(tt.path, tt.wildcard) {
+				t.Errorf("IsPathWildcard(%q, %q) = false, expected true", tt.path, tt.wildcard)
+			}
+			if got := TemplatePath(tt.path); got != tt.template {
+				t.Errorf("TemplatePath(%q) = %q, expected %q", tt.path, got, tt.template)
+			}
+			if err := ValidatePathWildcard(tt.path); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePathWildcard(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestEnsureLeadingSlash(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -293,6 +334,66 @@ func TestCombineHeaders_MethodOverridesService(t *testing.T) {
 	}
 }
 
+func TestCombineHeaders_MatchesCaseInsensitively(t *testing.T) {
+	result := CombineHeaders(
+		[]*http.Header{{Name: "x-api-key", Description: "service"}},
+		[]*http.Header{{Name: "X-API-Key", Description: "method"}},
+	)
+	if len(result) != 1 || result[0].GetDescription() != "method" {
+		t.Errorf("CombineHeaders = %v, want the method header alone", result)
+	}
+}
+
+func TestValidateHeaderList(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []*http.Header
+		wantErr string
+	}{
+		{name: "distinct headers", headers: []*http.Header{{Name: "X-API-Key"}, {Name: "X-Request-ID"}}},
+		{
+			name:    "same header twice",
+			headers: []*http.Header{{Name: "X-API-Key"}, {Name: "X-API-Key"}},
+			wantErr: `header "X-API-Key" is declared twice`,
+		},
+		{
+			name:    "names differing only by case",
+			headers: []*http.Header{{Name: "X-API-Key"}, {Name: "x-api-key"}},
+			wantErr: `headers "X-API-Key" and "x-api-key" differ only by case and name the same header "X-Api-Key"`,
+		},
+		{
+			name:    "name with a space",
+			headers: []*http.Header{{Name: "X API Key"}},
+			wantErr: `header name "X API Key" is not a valid HTTP field name`,
+		},
+		{
+			name:    "empty name",
+			headers: []*http.Header{{Description: "unnamed"}},
+			wantErr: `header name "" is not a valid HTTP field name`,
+		},
+		{name: "multiple header", headers: []*http.Header{{Name: "X-Tag", Type: "string", Multiple: true}}},
+		{
+			name:    "multiple array header",
+			headers: []*http.Header{{Name: "X-Tag", Type: "array", Multiple: true}},
+			wantErr: "must describe a single value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateHeaderList(tt.headers)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateHeaderList: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateHeaderList = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestLowerFirst(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1002,3 +1103,25 @@ func TestExtensionNumberSequence(t *testing.T) {
 		})
 	}
 }
+
+func TestHeadersWithDefaults(t *testing.T) {
+	headers := []*http.Header{
+		{Name: "X-API-Key", Required: true},
+		{Name: "X-Client-Version", DefaultValue: "1.2.0"},
+		{Name: "X-Request-ID"},
+		{Name: "X-Tenant", Required: true, DefaultValue: "public"},
+	}
+
+	result := HeadersWithDefaults(headers)
+
+	if len(result) != 2 {
+		t.Fatalf("HeadersWithDefaults() returned %d headers, expected 2", len(result))
+	}
+	if result[0].GetName() != "X-Client-Version" || result[1].GetName() != "X-Tenant" {
+		t.Errorf("HeadersWithDefaults() = [%s %s], expected [X-Client-Version X-Tenant]",
+			result[0].GetName(), result[1].GetName())
+	}
+	if HeadersWithDefaults(nil) != nil {
+		t.Error("HeadersWithDefaults(nil) should be nil")
+	}
+}
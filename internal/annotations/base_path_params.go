@@ -0,0 +1,116 @@
+package annotations
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// BasePathParam is a path parameter of a service base path, such as tenant_id
+// in /t/{tenant_id}/api/v1.
+type BasePathParam struct {
+	Name        string // Name between braces in the base path
+	ContextOnly bool   // When true, bound to no request field (context_only)
+}
+
+// GetBasePathParams returns the path parameters of the base path of a service,
+// in path order, or nil when it has none. For a versioned service they are
+// those of its default version, which ValidateBasePathParams checks every
+// version shares.
+func GetBasePathParams(service *protogen.Service) []BasePathParam {
+	names := ExtractPathParams(GetServiceBasePath(service))
+	if len(names) == 0 {
+		return nil
+	}
+	declarations := getServiceConfig(service).GetBasePathParams()
+	params := make([]BasePathParam, 0, len(names))
+	for _, name := range names {
+		param := BasePathParam{Name: name}
+		for _, declaration := range declarations {
+			if declaration.GetName() == name {
+				param.ContextOnly = declaration.GetContextOnly()
+			}
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// GetBoundBasePathParams returns the names of the base path parameters of a
+// service that are bound to the request field of the same name: those not
+// declared with context_only.
+func GetBoundBasePathParams(service *protogen.Service) []string {
+	var names []string
+	for _, param := range GetBasePathParams(service) {
+		if !param.ContextOnly {
+			names = append(names, param.Name)
+		}
+	}
+	return names
+}
+
+// ValidateBasePathParams checks the base path parameters of a service: none may
+// be a wildcard, every base_path_params declaration must name one of them,
+// every version must have the same ones, no method path may repeat one, and
+// the request of every method must have a singular string field for each one
+// not declared with context_only.
+func ValidateBasePathParams(service *protogen.Service) error {
+	basePaths := []string{GetServiceBasePath(service)}
+	for _, version := range GetServiceVersions(service) {
+		basePaths = append(basePaths, version.BasePath)
+	}
+	for _, basePath := range basePaths {
+		if strings.Contains(basePath, wildcardSuffix+"}") {
+			return fmt.Errorf("%s: base path %q has a wildcard, which is only allowed at the end of a method path",
+				service.Desc.Name(), basePath)
+		}
+	}
+
+	params := GetBasePathParams(service)
+	names := ExtractPathParams(GetServiceBasePath(service))
+	for _, declaration := range getServiceConfig(service).GetBasePathParams() {
+		if !slices.Contains(names, declaration.GetName()) {
+			return fmt.Errorf("%s: base_path_params declares %q, which is not a parameter of the base path",
+				service.Desc.Name(), declaration.GetName())
+		}
+	}
+	for _, version := range GetServiceVersions(service) {
+		if versionNames := ExtractPathParams(version.BasePath); !slices.Equal(versionNames, names) {
+			return fmt.Errorf("%s: version %q has base path parameters %v, but the default version has %v. "+
+				"Every version needs the same parameters", service.Desc.Name(), version.Name, versionNames, names)
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+
+	for _, method := range service.Methods {
+		if config := GetMethodHTTPConfig(method); config != nil {
+			for _, name := range config.PathParams {
+				if slices.Contains(names, name) {
+					return fmt.Errorf("%s: path parameter {%s} is already a parameter of the base path",
+						method.Desc.FullName(), name)
+				}
+			}
+		}
+		for _, param := range params {
+			if param.ContextOnly {
+				continue
+			}
+			field := FindFieldByProtoName(method.Input, param.Name)
+			if field == nil {
+				return fmt.Errorf("%s: base path parameter {%s} is not a field of %s. Add the field, or declare "+
+					"the parameter in base_path_params with context_only: true",
+					method.Desc.FullName(), param.Name, method.Input.Desc.Name())
+			}
+			if field.Desc.Kind() != protoreflect.StringKind || field.Desc.IsList() {
+				return fmt.Errorf("%s: base path parameter {%s} is bound to %s.%s, which must be a singular string",
+					method.Desc.FullName(), param.Name, method.Input.Desc.Name(), param.Name)
+			}
+		}
+	}
+	return nil
+}
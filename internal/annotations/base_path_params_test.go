@@ -0,0 +1,84 @@
+package annotations
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// basePathParamsFile builds the validateOneofFile messages with a Tenant
+// service under /t/{tenant_id}, whose Get method takes a request with a
+// tenant_id field and whose Ping method takes TextContent, which has none.
+func basePathParamsFile(params ...*http.BasePathParam) *descriptorpb.FileDescriptorProto {
+	fd := validateOneofFile()
+	fd.MessageType = append(fd.MessageType, &descriptorpb.DescriptorProto{
+		Name:  proto.String("TenantRequest"),
+		Field: []*descriptorpb.FieldDescriptorProto{scalarField("tenant_id", 1)},
+	})
+	method := func(name, input string) *descriptorpb.MethodDescriptorProto {
+		return &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(name),
+			InputType:  proto.String("." + validateTestPkg + "." + input),
+			OutputType: proto.String("." + validateTestPkg + ".ImageContent"),
+		}
+	}
+	service := &descriptorpb.ServiceDescriptorProto{
+		Name: proto.String("Tenant"),
+		Method: []*descriptorpb.MethodDescriptorProto{
+			method("Get", "TenantRequest"),
+			method("Ping", "TextContent"),
+		},
+		Options: &descriptorpb.ServiceOptions{},
+	}
+	proto.SetExtension(service.GetOptions(), http.E_ServiceConfig, &http.ServiceConfig{
+		BasePath:       "/t/{tenant_id}/api",
+		BasePathParams: params,
+	})
+	fd.Service = []*descriptorpb.ServiceDescriptorProto{service}
+	return fd
+}
+
+func TestValidateBasePathParams(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    []*http.BasePathParam
+		wantBound []string
+		wantErr   string
+	}{
+		{
+			name:    "bound parameter missing from a request",
+			wantErr: "Tenant.Ping: base path parameter {tenant_id} is not a field of TextContent",
+		},
+		{
+			name:   "context_only parameter",
+			params: []*http.BasePathParam{{Name: "tenant_id", ContextOnly: true}},
+		},
+		{
+			name:    "unknown declaration",
+			params:  []*http.BasePathParam{{Name: "org_id", ContextOnly: true}},
+			wantErr: `base_path_params declares "org_id"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := buildValidatePlugin(t, basePathParamsFile(tt.params...))
+			service := plugin.Files[0].Services[0]
+			err := ValidateBasePathParams(service)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateBasePathParams: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateBasePathParams error = %v, want it to contain %q", err, tt.wantErr)
+			}
+			if bound := GetBoundBasePathParams(service); tt.wantErr == "" && !slices.Equal(bound, tt.wantBound) {
+				t.Errorf("GetBoundBasePathParams = %v, want %v", bound, tt.wantBound)
+			}
+		})
+	}
+}
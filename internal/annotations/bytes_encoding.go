@@ -46,10 +46,11 @@ func GetBytesEncoding(field *protogen.Field) http.BytesEncoding {
 	return encoding
 }
 
-// HasBytesEncodingAnnotation returns true if the field has any non-default bytes_encoding.
+// HasBytesEncodingAnnotation returns true if the field has any non-default bytes_encoding,
+// by its own annotation or a scoped default (see ResolveBytesEncoding).
 // Returns false for UNSPECIFIED and BASE64 (both use protojson default behavior).
 func HasBytesEncodingAnnotation(field *protogen.Field) bool {
-	encoding := GetBytesEncoding(field)
+	encoding := ResolveBytesEncoding(field)
 	return encoding != http.BytesEncoding_BYTES_ENCODING_UNSPECIFIED &&
 		encoding != http.BytesEncoding_BYTES_ENCODING_BASE64
 }
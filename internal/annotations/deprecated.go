@@ -0,0 +1,63 @@
+package annotations
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// IsMethodDeprecated returns true if the RPC sets the standard `deprecated = true` option.
+func IsMethodDeprecated(method *protogen.Method) bool {
+	methodOptions, ok := method.Desc.Options().(*descriptorpb.MethodOptions)
+	return ok && methodOptions.GetDeprecated()
+}
+
+// IsServiceDeprecated returns true if the service sets the standard `deprecated = true` option.
+func IsServiceDeprecated(service *protogen.Service) bool {
+	serviceOptions, ok := service.Desc.Options().(*descriptorpb.ServiceOptions)
+	return ok && serviceOptions.GetDeprecated()
+}
+
+// IsFieldDeprecated returns true if the field sets the standard `[deprecated = true]` option.
+func IsFieldDeprecated(field *protogen.Field) bool {
+	fieldOptions, ok := field.Desc.Options().(*descriptorpb.FieldOptions)
+	return ok && fieldOptions.GetDeprecated()
+}
+
+// DeprecatedFieldPaths returns the dotted paths, by proto name, of the fields
+// of desc with the deprecated option, and of those of the messages it nests,
+// list elements and map values included (profile.fax_number). A recursive
+// message is not entered again within itself.
+func DeprecatedFieldPaths(desc protoreflect.MessageDescriptor) []string {
+	return appendDeprecatedFieldPaths(nil, desc, "", make(map[protoreflect.FullName]bool))
+}
+
+func appendDeprecatedFieldPaths(
+	paths []string,
+	desc protoreflect.MessageDescriptor,
+	prefix string,
+	visited map[protoreflect.FullName]bool,
+) []string {
+	if visited[desc.FullName()] {
+		return paths
+	}
+	visited[desc.FullName()] = true
+	defer delete(visited, desc.FullName())
+
+	fields := desc.Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		path := prefix + string(field.Name())
+		if fieldOptions, ok := field.Options().(*descriptorpb.FieldOptions); ok && fieldOptions.GetDeprecated() {
+			paths = append(paths, path)
+		}
+		nested := field.Message()
+		if field.IsMap() {
+			nested = field.MapValue().Message()
+		}
+		if nested != nil {
+			paths = appendDeprecatedFieldPaths(paths, nested, path+".", visited)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,60 @@
+package annotations
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// deprecate marks a field descriptor with the standard deprecated option.
+func deprecate(field *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	field.Options = &descriptorpb.FieldOptions{Deprecated: proto.Bool(true)}
+	return field
+}
+
+func TestDeprecatedFieldPaths(t *testing.T) {
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	repeated := descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	plugin := buildValidatePlugin(t, &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("deprecated.proto"),
+		Package: proto.String(validateTestPkg),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{
+			GoPackage: proto.String("github.com/SebastienMelki/sebuf/internal/annotations/validatev1"),
+		},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Address"), Field: []*descriptorpb.FieldDescriptorProto{
+				scalarField("street", 1),
+				deprecate(scalarField("zip_code", 2)),
+			}},
+			{Name: proto.String("Customer"), Field: []*descriptorpb.FieldDescriptorProto{
+				scalarField("name", 1),
+				deprecate(scalarField("fax_number", 2)),
+				msgField("billing", 3, "Address", optional),
+				msgField("shipping", 4, "Address", repeated),
+				msgField("referrer", 5, "Customer", optional),
+			}},
+			{Name: proto.String("Plain"), Field: []*descriptorpb.FieldDescriptorProto{scalarField("name", 1)}},
+		},
+	})
+
+	tests := []struct {
+		message string
+		want    []string
+	}{
+		{"Plain", nil},
+		{"Address", []string{"zip_code"}},
+		// Address is listed along both fields; the recursion into referrer stops
+		{"Customer", []string{"fax_number", "billing.zip_code", "shipping.zip_code"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			msg := findValidateMessage(t, plugin, tt.message)
+			if got := DeprecatedFieldPaths(msg.Desc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DeprecatedFieldPaths(%s) = %v, want %v", tt.message, got, tt.want)
+			}
+		})
+	}
+}
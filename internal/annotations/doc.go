@@ -10,12 +10,19 @@
 // Each annotation concept lives in its own file with standardized function signatures:
 //
 //   - http_config.go:    GetMethodHTTPConfig, GetServiceBasePath
-//   - headers.go:        GetServiceHeaders, GetMethodHeaders, CombineHeaders
-//   - query.go:          GetQueryParams
+//   - headers.go:        GetServiceHeaders, GetMethodHeaders, CombineHeaders, CheckServiceHeaders,
+//     ValidateServiceHeaders, ReportHeaderOverrides
+//   - query.go:          GetQueryParams, QueryUnbindableReason, ValidateBodylessRequestFields,
+//     ValidateQueryEncodings, ValidateQueryAliases, ValidateScalarQueryParams
 //   - unwrap.go:         HasUnwrapAnnotation, GetUnwrapField, FindUnwrapField, IsRootUnwrap
-//   - field_examples.go: GetFieldExamples
-//   - path.go:           ExtractPathParams, BuildHTTPPath, EnsureLeadingSlash
+//   - field_examples.go: GetFieldExamples, ResolveExampleValue, PopulatesExample
+//   - method_examples.go: GetMethodExamples, GetDefaultExample, ValidateMethodExamples
+//   - path.go:           ExtractPathParams, BuildHTTPPath, EnsureLeadingSlash, PathWildcard,
+//     IsPathWildcard, ValidatePathWildcard, TemplatePath
+//   - base_path_params.go: GetBasePathParams, GetBoundBasePathParams, ValidateBasePathParams
+//   - versions.go:       GetServiceVersions, DefaultAPIVersion, ValidateServiceVersions
 //   - method.go:         HTTPMethodToString, HTTPMethodToLower
+//   - deprecated.go:     IsMethodDeprecated, IsServiceDeprecated, IsFieldDeprecated, DeprecatedFieldPaths
 //   - helpers.go:        LowerFirst
 //
 // To add a new annotation type, create a new file following this pattern:
@@ -0,0 +1,40 @@
+package annotations
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Full names of the well-known types whose JSON form is not fixed by their
+// descriptor: protojson writes them as arbitrary JSON values.
+const (
+	AnyFullName       protoreflect.FullName = "google.protobuf.Any"
+	StructFullName    protoreflect.FullName = "google.protobuf.Struct"
+	ValueFullName     protoreflect.FullName = "google.protobuf.Value"
+	ListValueFullName protoreflect.FullName = "google.protobuf.ListValue"
+)
+
+// IsDynamicJSONMessage reports whether msg is google.protobuf.Any, Struct,
+// Value or ListValue. Generators describe these by their JSON shape: Any as an
+// object with an "@type" key next to the fields of the embedded message,
+// Struct as an object, ListValue as an array, and Value as any JSON value.
+func IsDynamicJSONMessage(msg *protogen.Message) bool {
+	if msg == nil {
+		return false
+	}
+	switch msg.Desc.FullName() {
+	case AnyFullName, StructFullName, ValueFullName, ListValueFullName:
+		return true
+	}
+	return false
+}
+
+// DynamicJSONType returns the full name of the dynamic JSON well-known type
+// field (or, for repeated fields, its elements) holds, or "" for any other
+// field. Map fields hold map entries, so callers check their value field.
+func DynamicJSONType(field *protogen.Field) protoreflect.FullName {
+	if field.Desc.IsMap() || !IsDynamicJSONMessage(field.Message) {
+		return ""
+	}
+	return field.Message.Desc.FullName()
+}
@@ -0,0 +1,119 @@
+package annotations
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// scopedEncodingDefaults returns the encoding_defaults of the message declaring
+// field and the file_encoding_defaults of its file, either nil when unset. The
+// fields of map entries get neither: their encodings, like those of the map
+// field itself, are not scoped.
+func scopedEncodingDefaults(field *protogen.Field) (message, file *http.EncodingDefaults) {
+	parent := field.Desc.ContainingMessage()
+	if parent == nil || parent.IsMapEntry() {
+		return nil, nil
+	}
+	if options, ok := parent.Options().(*descriptorpb.MessageOptions); ok && options != nil &&
+		proto.HasExtension(options, http.E_EncodingDefaults) {
+		message, _ = proto.GetExtension(options, http.E_EncodingDefaults).(*http.EncodingDefaults)
+	}
+	if options, ok := parent.ParentFile().Options().(*descriptorpb.FileOptions); ok && options != nil &&
+		proto.HasExtension(options, http.E_FileEncodingDefaults) {
+		file, _ = proto.GetExtension(options, http.E_FileEncodingDefaults).(*http.EncodingDefaults)
+	}
+	return message, file
+}
+
+// isInt64Kind reports whether int64_encoding is valid on a field of kind.
+func isInt64Kind(kind protoreflect.Kind) bool {
+	//exhaustive:ignore - only the 64-bit integer kinds take int64_encoding
+	switch kind {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return true
+	default:
+		return false
+	}
+}
+
+// ResolveInt64Encoding returns the int64 encoding of a field: its own
+// int64_encoding, else the default of its message's encoding_defaults, else
+// that of its file's file_encoding_defaults. Returns INT64_ENCODING_UNSPECIFIED
+// when none is set (callers should use protojson default: STRING). Scoped
+// defaults only apply to int64, sint64, sfixed64, uint64 and fixed64 fields.
+func ResolveInt64Encoding(field *protogen.Field) http.Int64Encoding {
+	if encoding := GetInt64Encoding(field); encoding != http.Int64Encoding_INT64_ENCODING_UNSPECIFIED {
+		return encoding
+	}
+	if !isInt64Kind(field.Desc.Kind()) {
+		return http.Int64Encoding_INT64_ENCODING_UNSPECIFIED
+	}
+	message, file := scopedEncodingDefaults(field)
+	if encoding := message.GetInt64Encoding(); encoding != http.Int64Encoding_INT64_ENCODING_UNSPECIFIED {
+		return encoding
+	}
+	return file.GetInt64Encoding()
+}
+
+// ResolveEnumEncoding returns the enum encoding of a field: its own
+// enum_encoding, else the default of its message's encoding_defaults, else
+// that of its file's file_encoding_defaults. Returns ENUM_ENCODING_UNSPECIFIED
+// when none is set (callers should use protojson default: STRING names).
+// Scoped defaults only apply to enum fields.
+func ResolveEnumEncoding(field *protogen.Field) http.EnumEncoding {
+	if encoding := GetEnumEncoding(field); encoding != http.EnumEncoding_ENUM_ENCODING_UNSPECIFIED {
+		return encoding
+	}
+	if field.Desc.Kind() != protoreflect.EnumKind {
+		return http.EnumEncoding_ENUM_ENCODING_UNSPECIFIED
+	}
+	message, file := scopedEncodingDefaults(field)
+	if encoding := message.GetEnumEncoding(); encoding != http.EnumEncoding_ENUM_ENCODING_UNSPECIFIED {
+		return encoding
+	}
+	return file.GetEnumEncoding()
+}
+
+// ResolveTimestampFormat returns the timestamp format of a field: its own
+// timestamp_format, else the default of its message's encoding_defaults, else
+// that of its file's file_encoding_defaults. Returns
+// TIMESTAMP_FORMAT_UNSPECIFIED when none is set (callers should use protojson
+// default: RFC3339). Scoped defaults only apply to google.protobuf.Timestamp
+// fields.
+func ResolveTimestampFormat(field *protogen.Field) http.TimestampFormat {
+	if format := GetTimestampFormat(field); format != http.TimestampFormat_TIMESTAMP_FORMAT_UNSPECIFIED {
+		return format
+	}
+	if !IsTimestampField(field) {
+		return http.TimestampFormat_TIMESTAMP_FORMAT_UNSPECIFIED
+	}
+	message, file := scopedEncodingDefaults(field)
+	if format := message.GetTimestampFormat(); format != http.TimestampFormat_TIMESTAMP_FORMAT_UNSPECIFIED {
+		return format
+	}
+	return file.GetTimestampFormat()
+}
+
+// ResolveBytesEncoding returns the bytes encoding of a field: its own
+// bytes_encoding, else the default of its message's encoding_defaults, else
+// that of its file's file_encoding_defaults. Returns BYTES_ENCODING_UNSPECIFIED
+// when none is set (callers should use protojson default: BASE64). Scoped
+// defaults only apply to bytes fields.
+func ResolveBytesEncoding(field *protogen.Field) http.BytesEncoding {
+	if encoding := GetBytesEncoding(field); encoding != http.BytesEncoding_BYTES_ENCODING_UNSPECIFIED {
+		return encoding
+	}
+	if field.Desc.Kind() != protoreflect.BytesKind {
+		return http.BytesEncoding_BYTES_ENCODING_UNSPECIFIED
+	}
+	message, file := scopedEncodingDefaults(field)
+	if encoding := message.GetBytesEncoding(); encoding != http.BytesEncoding_BYTES_ENCODING_UNSPECIFIED {
+		return encoding
+	}
+	return file.GetBytesEncoding()
+}
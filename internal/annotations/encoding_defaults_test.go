@@ -0,0 +1,74 @@
+package annotations
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// int64Field builds an int64 field, with its own int64_encoding unless encoding
+// is UNSPECIFIED.
+func int64Field(name string, number int32, encoding http.Int64Encoding) *descriptorpb.FieldDescriptorProto {
+	field := scalarField(name, number)
+	field.Type = descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()
+	if encoding != http.Int64Encoding_INT64_ENCODING_UNSPECIFIED {
+		field.Options = &descriptorpb.FieldOptions{}
+		proto.SetExtension(field.GetOptions(), http.E_Int64Encoding, encoding)
+	}
+	return field
+}
+
+func TestResolveInt64Encoding(t *testing.T) {
+	const (
+		unspecified = http.Int64Encoding_INT64_ENCODING_UNSPECIFIED
+		str         = http.Int64Encoding_INT64_ENCODING_STRING
+		number      = http.Int64Encoding_INT64_ENCODING_NUMBER
+	)
+	fd := validateOneofFile()
+	proto.SetExtension(fd.GetOptions(), http.E_FileEncodingDefaults, &http.EncodingDefaults{Int64Encoding: number})
+	scoped := &descriptorpb.DescriptorProto{
+		Name: proto.String("Scoped"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			int64Field("inherited", 1, unspecified),
+			int64Field("overridden", 2, number),
+			scalarField("name", 3),
+		},
+		Options: &descriptorpb.MessageOptions{},
+	}
+	proto.SetExtension(scoped.GetOptions(), http.E_EncodingDefaults, &http.EncodingDefaults{Int64Encoding: str})
+	unscoped := &descriptorpb.DescriptorProto{
+		Name: proto.String("Unscoped"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			int64Field("inherited", 1, unspecified),
+			int64Field("overridden", 2, str),
+		},
+	}
+	fd.MessageType = append(fd.MessageType, scoped, unscoped)
+
+	want := map[string]http.Int64Encoding{
+		"Scoped.inherited":    str,
+		"Scoped.overridden":   number,
+		"Scoped.name":         unspecified,
+		"Unscoped.inherited":  number,
+		"Unscoped.overridden": str,
+	}
+	for _, message := range buildValidatePlugin(t, fd).Files[0].Messages {
+		for _, field := range message.Fields {
+			key := string(message.Desc.Name()) + "." + string(field.Desc.Name())
+			expected, ok := want[key]
+			if !ok {
+				continue
+			}
+			delete(want, key)
+			if got := ResolveInt64Encoding(field); got != expected {
+				t.Errorf("ResolveInt64Encoding(%s) = %v, want %v", key, got, expected)
+			}
+		}
+	}
+	for key := range want {
+		t.Errorf("field %s not found", key)
+	}
+}
@@ -71,14 +71,15 @@ func HasAnyEnumValueMapping(enum *protogen.Enum) bool {
 	return false
 }
 
-// HasConflictingEnumAnnotations checks if a field has both enum_encoding=NUMBER and
-// enum_value annotations on its enum values, which is an error per CONTEXT.md.
+// HasConflictingEnumAnnotations checks if a field has both enum_encoding=NUMBER, by its own
+// annotation or a scoped default, and enum_value annotations on its enum values, which is an
+// error per CONTEXT.md.
 func HasConflictingEnumAnnotations(field *protogen.Field) bool {
 	if field.Enum == nil {
 		return false
 	}
 
-	encoding := GetEnumEncoding(field)
+	encoding := ResolveEnumEncoding(field)
 	if encoding != http.EnumEncoding_ENUM_ENCODING_NUMBER {
 		return false
 	}
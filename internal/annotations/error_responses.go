@@ -0,0 +1,106 @@
+package annotations
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrorResponse is an error status a method documents with error_responses and
+// the message its body decodes into.
+type ErrorResponse struct {
+	Status  int
+	Message *protogen.Message
+}
+
+// GetErrorResponses returns the error responses a method documents, sorted by
+// status, resolving each message among the messages of the method's file and
+// of the files it imports. It returns an error when a status is not an error
+// status or a message cannot be found.
+func GetErrorResponses(plugin *protogen.Plugin, method *protogen.Method) ([]ErrorResponse, error) {
+	config := GetMethodHTTPConfig(method)
+	if config == nil || len(config.ErrorResponses) == 0 {
+		return nil, nil
+	}
+	mapped := config.ErrorResponses
+	file := method.Desc.ParentFile()
+	visible := []protoreflect.FileDescriptor{file}
+	for i := range file.Imports().Len() {
+		visible = append(visible, file.Imports().Get(i).FileDescriptor)
+	}
+
+	responses := make([]ErrorResponse, 0, len(mapped))
+	for _, status := range slices.Sorted(maps.Keys(mapped)) {
+		name := fmt.Sprintf("%s: error_responses %d", method.Desc.FullName(), status)
+		if status < 400 || status > 599 {
+			return nil, fmt.Errorf("%s: status must be from 400 to 599", name)
+		}
+		message := findMessage(plugin, visible, mapped[status])
+		if message == nil {
+			return nil, fmt.Errorf("%s: message %q is not declared in %s or a file it imports",
+				name, mapped[status], file.Path())
+		}
+		if IsResultMessage(message) {
+			return nil, fmt.Errorf("%s: %s is a result message, which cannot be an error body",
+				name, message.Desc.FullName())
+		}
+		responses = append(responses, ErrorResponse{Status: int(status), Message: message})
+	}
+	return responses, nil
+}
+
+// findMessage returns the message named by ref among the messages of files:
+// ref is relative to the package of the first file, or fully qualified.
+func findMessage(plugin *protogen.Plugin, files []protoreflect.FileDescriptor, ref string) *protogen.Message {
+	ref = strings.TrimPrefix(ref, ".")
+	if ref == "" {
+		return nil
+	}
+	candidates := []protoreflect.FullName{files[0].Package().Append(protoreflect.Name(ref))}
+	if files[0].Package() == "" {
+		candidates[0] = protoreflect.FullName(ref)
+	}
+	if strings.Contains(ref, ".") {
+		candidates = append([]protoreflect.FullName{protoreflect.FullName(ref)}, candidates...)
+	}
+	for _, candidate := range candidates {
+		for _, fd := range files {
+			file, ok := plugin.FilesByPath[fd.Path()]
+			if !ok {
+				continue
+			}
+			if message := findMessageIn(file.Messages, candidate); message != nil {
+				return message
+			}
+		}
+	}
+	return nil
+}
+
+// findMessageIn returns the message named fullName among messages and their
+// nested messages.
+func findMessageIn(messages []*protogen.Message, fullName protoreflect.FullName) *protogen.Message {
+	for _, message := range messages {
+		if message.Desc.FullName() == fullName {
+			return message
+		}
+		if nested := findMessageIn(message.Messages, fullName); nested != nil {
+			return nested
+		}
+	}
+	return nil
+}
+
+// ValidateErrorResponses checks the error_responses of the methods of service.
+func ValidateErrorResponses(plugin *protogen.Plugin, service *protogen.Service) error {
+	for _, method := range service.Methods {
+		if _, err := GetErrorResponses(plugin, method); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package annotations
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// errorResponsesFile builds the resultMessageFile messages, with a Reason
+// message nested in TextContent, and a Library service whose Get method
+// documents errorResponses.
+func errorResponsesFile(errorResponses map[int32]string) *descriptorpb.FileDescriptorProto {
+	fd := resultMessageFile(false, map[string]int32{"text": 200, "image": 409})
+	fd.GetMessageType()[0].NestedType = []*descriptorpb.DescriptorProto{{
+		Name:  proto.String("Reason"),
+		Field: []*descriptorpb.FieldDescriptorProto{scalarField("detail", 1)},
+	}}
+	method := &descriptorpb.MethodDescriptorProto{
+		Name:       proto.String("Get"),
+		InputType:  proto.String("." + validateTestPkg + ".TextContent"),
+		OutputType: proto.String("." + validateTestPkg + ".ImageContent"),
+		Options:    &descriptorpb.MethodOptions{},
+	}
+	proto.SetExtension(method.GetOptions(), http.E_Config, &http.HttpConfig{
+		Path:           "/get",
+		ErrorResponses: errorResponses,
+	})
+	fd.Service = []*descriptorpb.ServiceDescriptorProto{{
+		Name:   proto.String("Library"),
+		Method: []*descriptorpb.MethodDescriptorProto{method},
+	}}
+	return fd
+}
+
+func TestGetErrorResponses(t *testing.T) {
+	plugin := buildValidatePlugin(t, errorResponsesFile(map[int32]string{
+		429: "." + validateTestPkg + ".ImageContent",
+		404: "TextContent",
+		422: "TextContent.Reason",
+	}))
+	responses, err := GetErrorResponses(plugin, plugin.Files[0].Services[0].Methods[0])
+	if err != nil {
+		t.Fatalf("GetErrorResponses: %v", err)
+	}
+	want := []struct {
+		status  int
+		message string
+	}{{404, "TextContent"}, {422, "Reason"}, {429, "ImageContent"}}
+	if len(responses) != len(want) {
+		t.Fatalf("responses = %+v, want %+v", responses, want)
+	}
+	for i, w := range want {
+		if responses[i].Status != w.status || string(responses[i].Message.Desc.Name()) != w.message {
+			t.Errorf("responses[%d] = %d: %s, want %d: %s",
+				i, responses[i].Status, responses[i].Message.Desc.FullName(), w.status, w.message)
+		}
+	}
+}
+
+func TestGetErrorResponsesErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		errorResponses map[int32]string
+		want           string
+	}{
+		{"success status", map[int32]string{200: "TextContent"}, "status must be from 400 to 599"},
+		{"unknown message", map[int32]string{404: "Missing"}, `message "Missing" is not declared`},
+		{"other package", map[int32]string{404: "other.TextContent"}, `message "other.TextContent" is not declared`},
+		{"result message", map[int32]string{409: "Event"}, "is a result message"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := buildValidatePlugin(t, errorResponsesFile(tt.errorResponses))
+			err := ValidateErrorResponses(plugin, plugin.Files[0].Services[0])
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ValidateErrorResponses error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,14 @@
 package annotations
 
 import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/descriptorpb"
 
 	"github.com/SebastienMelki/sebuf/http"
@@ -33,3 +39,202 @@ func GetFieldExamples(field *protogen.Field) []string {
 
 	return fieldExamples.GetValues()
 }
+
+// Fallback example values used when a field has no usable field_examples
+// annotation. They are fixed so mocks and fixtures are reproducible.
+const (
+	ExampleUUID    = "550e8400-e29b-41d4-a716-446655440000"
+	ExampleEmail   = "user@example.com"
+	ExampleName    = "Alice Johnson"
+	ExamplePhone   = "+1-555-0123"
+	ExampleAddress = "123 Main Street, Anytown, USA"
+	ExampleURL     = "https://example.com"
+	ExampleString  = "example string"
+	ExampleBytes   = "example bytes"
+	ExampleInt     = 42
+	ExampleFloat   = 3.14
+	ExampleMapKey  = "sample_key"
+)
+
+// ResolveExampleValue returns the mock value for a scalar or enum field. It is
+// the single example-selection rule shared by the Go mock server and the
+// TypeScript fixtures: the first field example, if it parses as the field's
+// kind, otherwise a fallback default. String fallbacks are chosen from the
+// field name (id, email, name, phone, address, url); enums fall back to their
+// first non-zero value. Sensitive fields never use their examples: strings
+// resolve to http.RedactedValue and bytes to an empty value. Message-typed
+// fields yield an invalid Value.
+func ResolveExampleValue(field *protogen.Field) protoreflect.Value {
+	if IsSensitiveField(field) {
+		if field.Desc.Kind() == protoreflect.BytesKind {
+			return protoreflect.ValueOfBytes([]byte{})
+		}
+		return protoreflect.ValueOfString(http.RedactedValue)
+	}
+	if examples := GetFieldExamples(field); len(examples) > 0 {
+		if v, ok := parseExampleValue(field, examples[0]); ok {
+			return v
+		}
+	}
+	return defaultExampleValue(field)
+}
+
+// ResolveExampleMapKey returns the key of the single entry mocks and fixtures
+// put into a map field, given the map entry's key field.
+func ResolveExampleMapKey(keyField *protogen.Field) protoreflect.MapKey {
+	//exhaustive:ignore - map keys are integral, bool or string
+	switch keyField.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(true).MapKey()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(1).MapKey()
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(1).MapKey()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(1).MapKey()
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(1).MapKey()
+	default:
+		return protoreflect.ValueOfString(ExampleMapKey).MapKey()
+	}
+}
+
+// ExampleMessage returns the message an example for field recurses into: the
+// field's message, or the value message of a map field. It returns nil for
+// scalar, enum and scalar-valued map fields.
+func ExampleMessage(field *protogen.Field) *protogen.Message {
+	if field.Desc.IsMap() {
+		return field.Message.Fields[1].Message
+	}
+	return field.Message
+}
+
+// PopulatesExample reports whether mocks and fixtures set field, given the
+// messages already being populated on the way down (path, outermost first).
+// Members of real oneofs are left unset, as are fields whose message is a
+// google.protobuf well-known type or already on the path, which stops
+// recursive messages from expanding forever.
+func PopulatesExample(field *protogen.Field, path []*protogen.Message) bool {
+	if field.Oneof != nil && !field.Oneof.Desc.IsSynthetic() {
+		return false
+	}
+	msg := ExampleMessage(field)
+	if msg == nil {
+		return true
+	}
+	if msg.Desc.ParentFile().Package() == "google.protobuf" {
+		return false
+	}
+	return !slices.Contains(path, msg)
+}
+
+func parseExampleValue(field *protogen.Field, example string) (protoreflect.Value, bool) {
+	switch field.Desc.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(example), true
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(example)), true
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(example)
+		return protoreflect.ValueOfBool(b), err == nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		i, err := strconv.ParseInt(example, 10, 32)
+		return protoreflect.ValueOfInt32(int32(i)), err == nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		i, err := strconv.ParseInt(example, 10, 64)
+		return protoreflect.ValueOfInt64(i), err == nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		u, err := strconv.ParseUint(example, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(u)), err == nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		u, err := strconv.ParseUint(example, 10, 64)
+		return protoreflect.ValueOfUint64(u), err == nil
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(example, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err == nil && !math.IsInf(f, 0) && !math.IsNaN(f)
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(example, 64)
+		return protoreflect.ValueOfFloat64(f), err == nil && !math.IsInf(f, 0) && !math.IsNaN(f)
+	case protoreflect.EnumKind:
+		for _, value := range field.Enum.Values {
+			if string(value.Desc.Name()) == example || GetEnumValueMapping(value) == example {
+				return protoreflect.ValueOfEnum(value.Desc.Number()), true
+			}
+		}
+		return protoreflect.Value{}, false
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return protoreflect.Value{}, false
+	default:
+		return protoreflect.Value{}, false
+	}
+}
+
+func defaultExampleValue(field *protogen.Field) protoreflect.Value {
+	switch field.Desc.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(defaultExampleString(field))
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(ExampleBytes))
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(true)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(ExampleInt)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(ExampleInt)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(ExampleInt)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(ExampleInt)
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(ExampleFloat)
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(ExampleFloat)
+	case protoreflect.EnumKind:
+		values := field.Enum.Values
+		for _, value := range values {
+			if value.Desc.Number() != 0 {
+				return protoreflect.ValueOfEnum(value.Desc.Number())
+			}
+		}
+		if len(values) == 0 {
+			return protoreflect.ValueOfEnum(0)
+		}
+		return protoreflect.ValueOfEnum(values[0].Desc.Number())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return protoreflect.Value{}
+	default:
+		return protoreflect.Value{}
+	}
+}
+
+// defaultExampleString picks a string fallback from common field names.
+func defaultExampleString(field *protogen.Field) string {
+	name := strings.ToLower(string(field.Desc.Name()))
+	switch {
+	case strings.Contains(name, "id"):
+		return ExampleUUID
+	case strings.Contains(name, "email"):
+		return ExampleEmail
+	case strings.Contains(name, "name"):
+		return ExampleName
+	case strings.Contains(name, "phone"):
+		return ExamplePhone
+	case strings.Contains(name, "address"):
+		return ExampleAddress
+	case strings.Contains(name, "url"):
+		return ExampleURL
+	default:
+		return ExampleString
+	}
+}
+
+// ExampleEnumValue returns the enum value v (from ResolveExampleValue) names in
+// field's enum, or nil if the enum declares no such number.
+func ExampleEnumValue(field *protogen.Field, v protoreflect.Value) *protogen.EnumValue {
+	for _, value := range field.Enum.Values {
+		if value.Desc.Number() == v.Enum() {
+			return value
+		}
+	}
+	return nil
+}
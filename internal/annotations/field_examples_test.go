@@ -0,0 +1,123 @@
+package annotations
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// withExamples attaches a field_examples annotation to a field descriptor.
+func withExamples(field *descriptorpb.FieldDescriptorProto, values ...string) *descriptorpb.FieldDescriptorProto {
+	field.Options = &descriptorpb.FieldOptions{}
+	proto.SetExtension(field.Options, http.E_FieldExamples, &http.FieldExamples{Values: values})
+	return field
+}
+
+func typedField(
+	name string,
+	number int32,
+	typ descriptorpb.FieldDescriptorProto_Type,
+	typeName string,
+) *descriptorpb.FieldDescriptorProto {
+	field := scalarField(name, number)
+	field.Type = typ.Enum()
+	if typeName != "" {
+		field.TypeName = proto.String("." + validateTestPkg + "." + typeName)
+	}
+	return field
+}
+
+// examplesFile builds a Node message covering examples, fallbacks, enums,
+// recursion and oneofs.
+func examplesFile() *descriptorpb.FileDescriptorProto {
+	color := &descriptorpb.EnumDescriptorProto{
+		Name: proto.String("Color"),
+		Value: []*descriptorpb.EnumValueDescriptorProto{
+			{Name: proto.String("COLOR_UNSPECIFIED"), Number: proto.Int32(0)},
+			{Name: proto.String("COLOR_RED"), Number: proto.Int32(1)},
+			{Name: proto.String("COLOR_BLUE"), Number: proto.Int32(2)},
+		},
+	}
+	choice := scalarField("choice", 8)
+	choice.OneofIndex = proto.Int32(0)
+	node := &descriptorpb.DescriptorProto{
+		Name: proto.String("Node"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			withExamples(scalarField("title", 1), "first", "second"),
+			scalarField("owner_id", 2),
+			withExamples(typedField("count", 3, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""), "many"),
+			withExamples(typedField("ratio", 4, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE, ""), "0.5"),
+			typedField("color", 5, descriptorpb.FieldDescriptorProto_TYPE_ENUM, "Color"),
+			withExamples(typedField("accent", 6, descriptorpb.FieldDescriptorProto_TYPE_ENUM, "Color"), "COLOR_BLUE"),
+			typedField("parent", 7, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, "Node"),
+			choice,
+		},
+		OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("pick")}},
+	}
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("examples.proto"),
+		Package: proto.String(validateTestPkg),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{
+			GoPackage: proto.String("github.com/SebastienMelki/sebuf/internal/annotations/validatev1"),
+		},
+		EnumType:    []*descriptorpb.EnumDescriptorProto{color},
+		MessageType: []*descriptorpb.DescriptorProto{node},
+	}
+}
+
+func findField(t *testing.T, msg *protogen.Message, name string) *protogen.Field {
+	t.Helper()
+	for _, field := range msg.Fields {
+		if string(field.Desc.Name()) == name {
+			return field
+		}
+	}
+	t.Fatalf("field %q not found on message %q", name, msg.Desc.Name())
+	return nil
+}
+
+func TestResolveExampleValue(t *testing.T) {
+	node := findValidateMessage(t, buildValidatePlugin(t, examplesFile()), "Node")
+
+	if got := ResolveExampleValue(findField(t, node, "title")).String(); got != "first" {
+		t.Errorf("title = %q, want the first example", got)
+	}
+	if got := ResolveExampleValue(findField(t, node, "owner_id")).String(); got != ExampleUUID {
+		t.Errorf("owner_id = %q, want the id fallback", got)
+	}
+	if got := ResolveExampleValue(findField(t, node, "count")).Int(); got != ExampleInt {
+		t.Errorf("count = %d, want the fallback for an unparseable example", got)
+	}
+	if got := ResolveExampleValue(findField(t, node, "ratio")).Float(); got != 0.5 {
+		t.Errorf("ratio = %v, want 0.5", got)
+	}
+	if got := ResolveExampleValue(findField(t, node, "color")).Enum(); got != 1 {
+		t.Errorf("color = %d, want the first non-zero value", got)
+	}
+	if got := ResolveExampleValue(findField(t, node, "accent")).Enum(); got != 2 {
+		t.Errorf("accent = %d, want the example COLOR_BLUE", got)
+	}
+}
+
+func TestPopulatesExample(t *testing.T) {
+	node := findValidateMessage(t, buildValidatePlugin(t, examplesFile()), "Node")
+	path := []*protogen.Message{node}
+
+	if !PopulatesExample(findField(t, node, "title"), path) {
+		t.Error("scalar fields are populated")
+	}
+	if PopulatesExample(findField(t, node, "parent"), path) {
+		t.Error("a message already on the path must not be populated")
+	}
+	if !PopulatesExample(findField(t, node, "parent"), nil) {
+		t.Error("a message not on the path is populated")
+	}
+	if PopulatesExample(findField(t, node, "choice"), path) {
+		t.Error("oneof members are left unset")
+	}
+}
@@ -0,0 +1,24 @@
+package annotations
+
+import (
+	"cmp"
+	"slices"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// GeneratedFiles returns the files plugin generates, sorted by path.
+// Generators walking several files into one output, or one collection, use
+// this order rather than the request's, which protoc derives from the command
+// line and the import graph, so that listing the same files differently does
+// not reorder the generated code.
+func GeneratedFiles(plugin *protogen.Plugin) []*protogen.File {
+	var files []*protogen.File
+	for _, file := range plugin.Files {
+		if file.Generate {
+			files = append(files, file)
+		}
+	}
+	slices.SortFunc(files, func(a, b *protogen.File) int { return cmp.Compare(a.Desc.Path(), b.Desc.Path()) })
+	return files
+}
@@ -129,7 +129,7 @@ func ValidateFlattenCollisions(message *protogen.Message) error {
 			continue
 		}
 
-		usedNames[field.Desc.JSONName()] = fmt.Sprintf("parent field %q", field.Desc.Name())
+		usedNames[JSONFieldName(field)] = fmt.Sprintf("parent field %q", field.Desc.Name())
 	}
 
 	// Then check each flattened field's children
@@ -141,7 +141,7 @@ func ValidateFlattenCollisions(message *protogen.Message) error {
 		prefix := GetFlattenPrefix(field)
 
 		for _, childField := range field.Message.Fields {
-			flattenedName := prefix + childField.Desc.JSONName()
+			flattenedName := prefix + JSONFieldName(childField)
 			if source, exists := usedNames[flattenedName]; exists {
 				return fmt.Errorf(
 					"field %s.%s: flattened child %q (JSON: %q) collides with %s",
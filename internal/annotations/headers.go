@@ -1,10 +1,16 @@
 package annotations
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
 	"sort"
+	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/descriptorpb"
 
 	"github.com/SebastienMelki/sebuf/http"
@@ -62,9 +68,194 @@ func GetMethodHeaders(method *protogen.Method) []*http.Header {
 	return methodHeaders.GetRequiredHeaders()
 }
 
+// CanonicalHeaderName returns the canonical form of a header name, the one Go's
+// net/http sends and reports: x-api-key and X-API-Key both become X-Api-Key.
+// Generators write declared headers under this name, so servers, clients and
+// OpenAPI documents agree on it whatever casing the proto uses. Generated
+// identifiers, such as client option names, keep following the declared name.
+func CanonicalHeaderName(name string) string {
+	return textproto.CanonicalMIMEHeaderKey(name)
+}
+
+// HeaderProblem is a problem with the header declarations of a service or of
+// one of its methods.
+type HeaderProblem struct {
+	// Element is the service or method declaring the header.
+	Element protoreflect.Descriptor
+	// Header is the declared name of the header.
+	Header  string
+	Message string
+	// Override marks a method header redeclaring a service header with another
+	// type or format. Generators warn about overrides, and fail on them only
+	// with the strict_headers parameter, as CombineHeaders lets the method
+	// header win; every other problem fails generation.
+	Override bool
+}
+
+// Error implements the error interface.
+func (p HeaderProblem) Error() string {
+	return fmt.Sprintf("%s: %s", p.Element.FullName(), p.Message)
+}
+
+// CheckServiceHeaders returns every problem with the header declarations of a
+// service and of each of its methods, service first and then methods in
+// order. A header name must be a valid HTTP field name, and a list must not
+// declare a header twice, even with different casing. A multiple header must
+// not have the type array, because its type describes each of its values. A
+// method header overriding a service header must keep its type and format;
+// its description, example and other settings may change.
+func CheckServiceHeaders(service *protogen.Service) []HeaderProblem {
+	var problems []HeaderProblem
+	serviceHeaders := GetServiceHeaders(service)
+	for _, problem := range checkHeaderList(serviceHeaders) {
+		problem.Element = service.Desc
+		problems = append(problems, problem)
+	}
+	for _, method := range service.Methods {
+		methodHeaders := GetMethodHeaders(method)
+		for _, problem := range checkHeaderList(methodHeaders) {
+			problem.Element = method.Desc
+			problems = append(problems, problem)
+		}
+		for _, problem := range checkHeaderOverrides(serviceHeaders, methodHeaders) {
+			problem.Element = method.Desc
+			problems = append(problems, problem)
+		}
+	}
+	return problems
+}
+
+// ValidateServiceHeaders checks the header declarations of a service and of
+// each of its methods, returning every problem CheckServiceHeaders finds
+// except overrides, joined into one error.
+func ValidateServiceHeaders(service *protogen.Service) error {
+	var errs []error
+	for _, problem := range CheckServiceHeaders(service) {
+		if !problem.Override {
+			errs = append(errs, problem)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ReportHeaderOverrides writes a warning to w for each method header of
+// service overriding a service header with another type or format. With
+// strict, it writes nothing and returns the overrides joined into one error
+// instead.
+func ReportHeaderOverrides(service *protogen.Service, strict bool, w io.Writer) error {
+	var errs []error
+	for _, problem := range CheckServiceHeaders(service) {
+		if !problem.Override {
+			continue
+		}
+		if strict {
+			errs = append(errs, problem)
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "Warning: %s\n", problem.Error())
+	}
+	return errors.Join(errs...)
+}
+
+func validateHeaderList(headers []*http.Header) error {
+	var errs []error
+	for _, problem := range checkHeaderList(headers) {
+		errs = append(errs, errors.New(problem.Message))
+	}
+	return errors.Join(errs...)
+}
+
+// checkHeaderList returns the problems of a list of header declarations,
+// without their Element.
+func checkHeaderList(headers []*http.Header) []HeaderProblem {
+	var problems []HeaderProblem
+	report := func(name, format string, args ...any) {
+		problems = append(problems, HeaderProblem{Header: name, Message: fmt.Sprintf(format, args...)})
+	}
+	declared := make(map[string]string, len(headers))
+	for _, header := range headers {
+		name := header.GetName()
+		if !isHeaderFieldName(name) {
+			report(name, "header name %q is not a valid HTTP field name", name)
+			continue
+		}
+		canonical := CanonicalHeaderName(name)
+		if previous, ok := declared[canonical]; ok {
+			if previous == name {
+				report(name, "header %q is declared twice", name)
+			} else {
+				report(name, "headers %q and %q differ only by case and name the same header %q",
+					previous, name, canonical)
+			}
+			continue
+		}
+		declared[canonical] = name
+		if header.GetMultiple() && strings.EqualFold(header.GetType(), "array") {
+			report(name, "header %q is multiple, so its type must describe a single value, not array", name)
+		}
+	}
+	return problems
+}
+
+// checkHeaderOverrides returns the method headers overriding a service header
+// with another type or format, as problems without their Element.
+func checkHeaderOverrides(serviceHeaders, methodHeaders []*http.Header) []HeaderProblem {
+	byName := make(map[string]*http.Header, len(serviceHeaders))
+	for _, header := range serviceHeaders {
+		byName[CanonicalHeaderName(header.GetName())] = header
+	}
+	var problems []HeaderProblem
+	for _, header := range methodHeaders {
+		inherited, ok := byName[CanonicalHeaderName(header.GetName())]
+		if !ok {
+			continue
+		}
+		var changes []string
+		if from, to := headerType(inherited), headerType(header); from != to {
+			changes = append(changes, fmt.Sprintf("type from %s to %s", from, to))
+		}
+		if from, to := inherited.GetFormat(), header.GetFormat(); !strings.EqualFold(from, to) {
+			changes = append(changes, fmt.Sprintf("format from %q to %q", from, to))
+		}
+		if len(changes) > 0 {
+			problems = append(problems, HeaderProblem{
+				Header: header.GetName(),
+				Message: fmt.Sprintf("header %q overrides the service header and changes its %s",
+					header.GetName(), strings.Join(changes, " and its ")),
+				Override: true,
+			})
+		}
+	}
+	return problems
+}
+
+// headerType returns the declared type of a header, lowercased, with string
+// standing for an unset type.
+func headerType(header *http.Header) string {
+	if header.GetType() == "" {
+		return "string"
+	}
+	return strings.ToLower(header.GetType())
+}
+
+// isHeaderFieldName reports whether name is a valid HTTP field name: a
+// non-empty token of RFC 9110, made of visible ASCII characters other than
+// delimiters.
+func isHeaderFieldName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r > '~' || r <= ' ' || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, r) {
+			return false
+		}
+	}
+	return true
+}
+
 // CombineHeaders merges service headers with method headers, with method headers
-// taking precedence. The result is sorted by header name for deterministic output.
-// Headers with empty names are skipped.
+// taking precedence. Headers are matched by their canonical name, and the result
+// is sorted by it for deterministic output. Headers with empty names are skipped.
 func CombineHeaders(serviceHeaders, methodHeaders []*http.Header) []*http.Header {
 	if len(serviceHeaders) == 0 {
 		return methodHeaders
@@ -79,14 +270,14 @@ func CombineHeaders(serviceHeaders, methodHeaders []*http.Header) []*http.Header
 	// Add service headers first
 	for _, header := range serviceHeaders {
 		if header.GetName() != "" {
-			headerMap[header.GetName()] = header
+			headerMap[CanonicalHeaderName(header.GetName())] = header
 		}
 	}
 
 	// Add method headers, overriding service headers with same name
 	for _, header := range methodHeaders {
 		if header.GetName() != "" {
-			headerMap[header.GetName()] = header
+			headerMap[CanonicalHeaderName(header.GetName())] = header
 		}
 	}
 
@@ -105,3 +296,16 @@ func CombineHeaders(serviceHeaders, methodHeaders []*http.Header) []*http.Header
 
 	return result
 }
+
+// HeadersWithDefaults returns the headers that declare a default_value, in
+// their original order. Generated clients send these defaults unless the caller
+// sets the header.
+func HeadersWithDefaults(headers []*http.Header) []*http.Header {
+	var result []*http.Header
+	for _, header := range headers {
+		if header.GetDefaultValue() != "" {
+			result = append(result, header)
+		}
+	}
+	return result
+}
@@ -0,0 +1,145 @@
+package annotations
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/internal/pluginrun/pluginruntest"
+)
+
+// notesService returns the NoteService of notes.proto declaring
+// serviceHeaders, and getNoteHeaders on its GetNote method.
+func notesService(t *testing.T, serviceHeaders, getNoteHeaders []*http.Header) *protogen.Service {
+	t.Helper()
+	req := pluginruntest.Request("")
+	file := req.GetProtoFile()[len(req.GetProtoFile())-1]
+	service := file.GetService()[0]
+	proto.SetExtension(service.GetOptions(), http.E_ServiceHeaders,
+		&http.ServiceHeaders{RequiredHeaders: serviceHeaders})
+	for _, method := range service.GetMethod() {
+		if method.GetName() == "GetNote" {
+			proto.SetExtension(method.GetOptions(), http.E_MethodHeaders,
+				&http.MethodHeaders{RequiredHeaders: getNoteHeaders})
+		}
+	}
+	plugin, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatalf("build notes.proto: %v", err)
+	}
+	return plugin.Files[len(plugin.Files)-1].Services[0]
+}
+
+func TestCheckHeaderOverrides(t *testing.T) {
+	tests := []struct {
+		name     string
+		service  *http.Header
+		method   *http.Header
+		wantDiff string
+	}{
+		{
+			name:     "type changed",
+			service:  &http.Header{Name: "X-Limit", Type: "integer"},
+			method:   &http.Header{Name: "X-Limit", Type: "string", Format: "uuid"},
+			wantDiff: `changes its type from integer to string and its format from "" to "uuid"`,
+		},
+		{
+			name:     "format changed",
+			service:  &http.Header{Name: "X-Request-Id", Type: "string", Format: "uuid"},
+			method:   &http.Header{Name: "x-request-id", Type: "string", Format: "email"},
+			wantDiff: `changes its format from "uuid" to "email"`,
+		},
+		{
+			name:    "description and example changed",
+			service: &http.Header{Name: "X-Limit", Type: "integer", Description: "Page size"},
+			method:  &http.Header{Name: "X-Limit", Type: "INTEGER", Description: "Batch size", Example: "50"},
+		},
+		{
+			name:    "unset type kept as string",
+			service: &http.Header{Name: "X-Tenant", Type: "string", Required: true},
+			method:  &http.Header{Name: "X-Tenant", Deprecated: true},
+		},
+		{
+			name:    "header not declared by the service",
+			service: &http.Header{Name: "X-Tenant", Type: "string"},
+			method:  &http.Header{Name: "X-Limit", Type: "integer"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := checkHeaderOverrides([]*http.Header{tt.service}, []*http.Header{tt.method})
+			if tt.wantDiff == "" {
+				if len(problems) != 0 {
+					t.Errorf("checkHeaderOverrides = %v, want none", problems)
+				}
+				return
+			}
+			if len(problems) != 1 || !problems[0].Override || !strings.Contains(problems[0].Message, tt.wantDiff) {
+				t.Errorf("checkHeaderOverrides = %+v, want one override that %s", problems, tt.wantDiff)
+			}
+		})
+	}
+}
+
+func TestCheckServiceHeaders(t *testing.T) {
+	service := notesService(t,
+		[]*http.Header{{Name: "X-Limit", Type: "integer"}, {Name: "X-Tenant"}, {Name: "x-tenant"}},
+		[]*http.Header{{Name: "X-Limit", Type: "string"}, {Name: "X:Trace"}},
+	)
+
+	var got []string
+	for _, problem := range CheckServiceHeaders(service) {
+		got = append(got, problem.Error())
+	}
+	want := []string{
+		`test.notes.v1.NoteService: headers "X-Tenant" and "x-tenant" differ only by case and name the same ` +
+			`header "X-Tenant"`,
+		`test.notes.v1.NoteService.GetNote: header name "X:Trace" is not a valid HTTP field name`,
+		`test.notes.v1.NoteService.GetNote: header "X-Limit" overrides the service header and changes its type ` +
+			`from integer to string`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("CheckServiceHeaders =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// The declaration problems are reported together, and the override apart
+	err := ValidateServiceHeaders(service)
+	if err == nil || err.Error() != want[0]+"\n"+want[1] {
+		t.Errorf("ValidateServiceHeaders = %v, want both declaration problems", err)
+	}
+
+	var warnings bytes.Buffer
+	if err := ReportHeaderOverrides(service, false, &warnings); err != nil {
+		t.Errorf("ReportHeaderOverrides = %v, want a warning only", err)
+	}
+	if warnings.String() != "Warning: "+want[2]+"\n" {
+		t.Errorf("warnings = %q, want the override", warnings.String())
+	}
+
+	warnings.Reset()
+	err = ReportHeaderOverrides(service, true, &warnings)
+	var problem HeaderProblem
+	if !errors.As(err, &problem) || problem.Header != "X-Limit" || warnings.Len() != 0 {
+		t.Errorf("strict ReportHeaderOverrides = %v, warnings %q, want the override as an error",
+			err, warnings.String())
+	}
+}
+
+func TestCheckServiceHeadersAcceptsValidOverrides(t *testing.T) {
+	service := notesService(t,
+		[]*http.Header{{Name: "X-Limit", Type: "integer", Description: "Page size"}},
+		[]*http.Header{{Name: "X-Limit", Type: "integer", Description: "Batch size", Example: "50", Required: true}},
+	)
+	if problems := CheckServiceHeaders(service); len(problems) != 0 {
+		t.Errorf("CheckServiceHeaders = %v, want none", problems)
+	}
+	var warnings bytes.Buffer
+	if err := ReportHeaderOverrides(service, true, &warnings); err != nil || warnings.Len() != 0 {
+		t.Errorf("ReportHeaderOverrides = %v, warnings %q, want silence", err, warnings.String())
+	}
+}
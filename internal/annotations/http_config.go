@@ -10,10 +10,21 @@ import (
 
 // HTTPConfig represents the HTTP configuration for a method.
 type HTTPConfig struct {
-	Path       string
-	Method     string   // "GET", "POST", "PUT", "DELETE", "PATCH"
-	PathParams []string // Path variable names extracted from path
-	Stream     bool     // When true, this method uses SSE streaming
+	Path            string
+	Method          string            // "GET", "POST", "PUT", "DELETE", "PATCH"
+	PathParams      []string          // Path variable names extracted from path
+	Stream          bool              // When true, this method uses SSE streaming
+	Idempotency     bool              // When true, requests are deduplicated by their Idempotency-Key header
+	Cache           *http.CacheConfig // Caching policy for successful responses, nil when unset
+	TimeoutMs       int32             // Handler timeout in milliseconds, 0 when unset
+	AcceptForm      bool              // When true, form-encoded request bodies are accepted too
+	AcceptMultipart bool              // When true, multipart/form-data request bodies are accepted too
+	StrictJSON      bool              // When true, JSON request bodies with unknown keys are rejected
+	StreamResponse  bool              // When true, the response items are written as they are produced
+	MergePatch      bool              // When true, the request body is a JSON Merge Patch (RFC 7386)
+	ErrorResponses  map[int32]string  // Message names of the documented error statuses, by status
+	// Static headers set on every response, replacing the service's of the same name
+	ResponseHeaders []*http.ResponseHeader
 }
 
 // ServiceConfig represents the HTTP configuration for a service.
@@ -47,35 +58,52 @@ func GetMethodHTTPConfig(method *protogen.Method) *HTTPConfig {
 	path := httpConfig.GetPath()
 
 	return &HTTPConfig{
-		Path:       path,
-		Method:     HTTPMethodToString(httpConfig.GetMethod()),
-		PathParams: ExtractPathParams(path),
-		Stream:     httpConfig.GetStream(),
+		Path:            path,
+		Method:          HTTPMethodToString(httpConfig.GetMethod()),
+		PathParams:      ExtractPathParams(path),
+		Stream:          httpConfig.GetStream(),
+		Idempotency:     httpConfig.GetIdempotency(),
+		Cache:           httpConfig.GetCache(),
+		TimeoutMs:       httpConfig.GetTimeoutMs(),
+		AcceptForm:      httpConfig.GetAcceptForm(),
+		AcceptMultipart: httpConfig.GetAcceptMultipart(),
+		StrictJSON:      httpConfig.GetStrictJson(),
+		StreamResponse:  httpConfig.GetStreamResponse(),
+		MergePatch:      httpConfig.GetPatchFormat() == http.PatchFormat_PATCH_FORMAT_MERGE_PATCH,
+		ErrorResponses:  httpConfig.GetErrorResponses(),
+		ResponseHeaders: httpConfig.GetResponseHeaders(),
 	}
 }
 
-// GetServiceBasePath extracts the base path from service options.
-// Returns an empty string if no service config annotation is present.
-func GetServiceBasePath(service *protogen.Service) string {
-	options := service.Desc.Options()
-	if options == nil {
-		return ""
+// IsStrictJSON reports whether a method rejects JSON request bodies with
+// unknown keys, set by strict_json on the method or on its service.
+func IsStrictJSON(method *protogen.Method) bool {
+	if config := GetMethodHTTPConfig(method); config != nil && config.StrictJSON {
+		return true
 	}
+	return getServiceConfig(method.Parent).GetStrictJson()
+}
 
-	serviceOptions, ok := options.(*descriptorpb.ServiceOptions)
-	if !ok {
-		return ""
-	}
+// IsMergePatch reports whether a method reads its request body as a JSON Merge
+// Patch, set by patch_format: PATCH_FORMAT_MERGE_PATCH.
+func IsMergePatch(method *protogen.Method) bool {
+	config := GetMethodHTTPConfig(method)
+	return config != nil && config.MergePatch
+}
 
-	ext := proto.GetExtension(serviceOptions, http.E_ServiceConfig)
-	if ext == nil {
-		return ""
-	}
+// IsProblemJSON reports whether a service writes its errors as Problem Details
+// (RFC 9457) by default, set by error_format: ERROR_FORMAT_PROBLEM_JSON.
+func IsProblemJSON(service *protogen.Service) bool {
+	return getServiceConfig(service).GetErrorFormat() == http.ErrorFormat_ERROR_FORMAT_PROBLEM_JSON
+}
 
-	serviceConfig, ok := ext.(*http.ServiceConfig)
-	if !ok || serviceConfig == nil {
-		return ""
+// GetServiceBasePath extracts the base path from service options. For a
+// versioned service it returns the base path of its default version (see
+// DefaultAPIVersion). Returns an empty string if no service config annotation
+// is present.
+func GetServiceBasePath(service *protogen.Service) string {
+	if version := DefaultAPIVersion(GetServiceVersions(service)); version != nil {
+		return version.BasePath
 	}
-
-	return serviceConfig.GetBasePath()
+	return getServiceConfig(service).GetBasePath()
 }
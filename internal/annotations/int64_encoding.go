@@ -35,8 +35,9 @@ func GetInt64Encoding(field *protogen.Field) http.Int64Encoding {
 	return encoding
 }
 
-// IsInt64NumberEncoding returns true if the field should encode int64/uint64 as JSON number.
+// IsInt64NumberEncoding returns true if the field should encode int64/uint64 as JSON number,
+// by its own annotation or a scoped default (see ResolveInt64Encoding).
 // Returns false for UNSPECIFIED or STRING (both use protojson default string encoding).
 func IsInt64NumberEncoding(field *protogen.Field) bool {
-	return GetInt64Encoding(field) == http.Int64Encoding_INT64_ENCODING_NUMBER
+	return ResolveInt64Encoding(field) == http.Int64Encoding_INT64_ENCODING_NUMBER
 }
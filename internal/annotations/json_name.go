@@ -0,0 +1,109 @@
+package annotations
+
+import (
+	"slices"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// JSONFieldName returns the JSON key of a field: its json_name when the proto
+// declares one, its proto name (e.g., "user_id") when the message's json_naming
+// policy is SNAKE_CASE, otherwise the lowerCamelCase form of the field name.
+// Every generator uses it for JSON object keys, TypeScript properties and
+// OpenAPI property names so that naming policies and custom json_name
+// overrides are honored consistently.
+func JSONFieldName(field *protogen.Field) string {
+	return http.JSONFieldName(field.Desc)
+}
+
+// HasJSONNaming reports whether a message, or a message reachable through its
+// fields, has fields the json_naming policy names differently from protojson.
+// Generated Go code marshals such messages with sebufhttp.MarshalProtoJSON
+// instead of protojson.
+func HasJSONNaming(message *protogen.Message) bool {
+	return http.HasJSONNaming(message.Desc)
+}
+
+// FindFieldByProtoName returns the field of message named name in the proto
+// source (e.g., "user_id"), or nil if there is none.
+func FindFieldByProtoName(message *protogen.Message, name string) *protogen.Field {
+	for _, field := range message.Fields {
+		if string(field.Desc.Name()) == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// AlternateJSONNames maps the alternate names of the JSON keys a generated
+// UnmarshalJSON of message reads to those keys. A field's alternates are the
+// names protojson accepts for it besides its JSONFieldName: its proto name and
+// its json_name. Flattened fields promote the alternates of their child fields,
+// under the flatten prefix, as do the message variants of flattened oneofs.
+// Alternates that are the key of another field, or of two fields, are left out.
+// It returns nil when no field has an alternate.
+func AlternateJSONNames(message *protogen.Message) map[string]string {
+	keys := make(map[string]bool)
+	candidates := make(map[string][]string)
+	addField := func(field *protogen.Field, prefix string) {
+		key := prefix + JSONFieldName(field)
+		keys[key] = true
+		for _, name := range []string{string(field.Desc.Name()), field.Desc.JSONName()} {
+			if alternate := prefix + name; alternate != key && !slices.Contains(candidates[alternate], key) {
+				candidates[alternate] = append(candidates[alternate], key)
+			}
+		}
+	}
+	for _, field := range message.Fields {
+		addField(field, "")
+		if IsFlattenField(field) && field.Message != nil {
+			for _, child := range field.Message.Fields {
+				addField(child, GetFlattenPrefix(field))
+			}
+		}
+	}
+	for _, oneof := range message.Oneofs {
+		info := GetOneofDiscriminatorInfo(oneof)
+		if info == nil {
+			continue
+		}
+		keys[info.Discriminator] = true
+		if !info.Flatten {
+			continue
+		}
+		for _, variant := range info.Variants {
+			if variant.IsMessage {
+				for _, child := range variant.Field.Message.Fields {
+					addField(child, "")
+				}
+			}
+		}
+	}
+
+	var names map[string]string
+	for alternate, targets := range candidates {
+		if keys[alternate] || len(targets) > 1 {
+			continue
+		}
+		if names == nil {
+			names = make(map[string]string)
+		}
+		names[alternate] = targets[0]
+	}
+	return names
+}
+
+// RejectsAlternateJSONNames reports whether the generated UnmarshalJSON of a
+// message rejects the alternate names of its fields: its reject_alternate_names
+// annotation, else its file's file_reject_alternate_names.
+func RejectsAlternateJSONNames(message *protogen.Message) bool {
+	if proto.HasExtension(message.Desc.Options(), http.E_RejectAlternateNames) {
+		reject, _ := proto.GetExtension(message.Desc.Options(), http.E_RejectAlternateNames).(bool)
+		return reject
+	}
+	reject, _ := proto.GetExtension(message.Desc.ParentFile().Options(), http.E_FileRejectAlternateNames).(bool)
+	return reject
+}
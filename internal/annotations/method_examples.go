@@ -0,0 +1,84 @@
+package annotations
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// DefaultExampleName is the name of the example whose response the mock
+// server answers with.
+const DefaultExampleName = "default"
+
+// GetMethodExamples returns the examples annotations of a method, in
+// declaration order, or nil when it has none.
+func GetMethodExamples(method *protogen.Method) []*http.MethodExample {
+	options, ok := method.Desc.Options().(*descriptorpb.MethodOptions)
+	if !ok || options == nil {
+		return nil
+	}
+	examples, _ := proto.GetExtension(options, http.E_Examples).([]*http.MethodExample)
+	return examples
+}
+
+// GetDefaultExample returns the example of a method named "default", or nil.
+func GetDefaultExample(method *protogen.Method) *http.MethodExample {
+	for _, example := range GetMethodExamples(method) {
+		if example.GetName() == DefaultExampleName {
+			return example
+		}
+	}
+	return nil
+}
+
+// ValidateMethodExamples checks the examples of each method of a service: an
+// example has a name distinct among the method's examples and a request or a
+// response, a request only on methods with a body, and each payload must be
+// JSON protojson unmarshals into the method's input or output message.
+func ValidateMethodExamples(service *protogen.Service) error {
+	for _, method := range service.Methods {
+		seen := make(map[string]bool)
+		for _, example := range GetMethodExamples(method) {
+			name := example.GetName()
+			switch {
+			case name == "":
+				return fmt.Errorf("%s: example has no name", method.Desc.FullName())
+			case seen[name]:
+				return fmt.Errorf("%s: example %q is declared twice", method.Desc.FullName(), name)
+			case example.GetRequest() == "" && example.GetResponse() == "":
+				return fmt.Errorf("%s: example %q has neither a request nor a response", method.Desc.FullName(), name)
+			}
+			seen[name] = true
+			if example.GetRequest() != "" {
+				if config := GetMethodHTTPConfig(method); config != nil &&
+					(config.Method == methodGET || config.Method == methodDELETE) {
+					return fmt.Errorf("%s: example %q has a request, but %s requests have no body",
+						method.Desc.FullName(), name, config.Method)
+				}
+				if err := unmarshalExample(example.GetRequest(), method.Input.Desc); err != nil {
+					return fmt.Errorf("%s: request of example %q is not a %s: %w",
+						method.Desc.FullName(), name, method.Input.Desc.FullName(), err)
+				}
+			}
+			if example.GetResponse() != "" {
+				if err := unmarshalExample(example.GetResponse(), method.Output.Desc); err != nil {
+					return fmt.Errorf("%s: response of example %q is not a %s: %w",
+						method.Desc.FullName(), name, method.Output.Desc.FullName(), err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// unmarshalExample unmarshals the JSON of an example into a message of md.
+func unmarshalExample(data string, md protoreflect.MessageDescriptor) error {
+	return protojson.Unmarshal([]byte(data), dynamicpb.NewMessage(md))
+}
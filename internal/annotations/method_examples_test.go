@@ -0,0 +1,153 @@
+package annotations
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// methodExamplesMethod builds a service Svc with a method Do from Req to Resp,
+// served on verb, with the given examples.
+func methodExamplesMethod(t *testing.T, verb http.HttpMethod, examples []*http.MethodExample) *protogen.Method {
+	t.Helper()
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("method_examples.proto"),
+		Package: proto.String(validateTestPkg),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{
+			GoPackage: proto.String("github.com/SebastienMelki/sebuf/internal/annotations/validatev1"),
+		},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Req"), Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("title"),
+				JsonName: proto.String("title"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			}}},
+			{Name: proto.String("Resp"), Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("count"),
+				JsonName: proto.String("count"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(),
+			}}},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Svc"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Do"),
+				InputType:  proto.String("." + validateTestPkg + ".Req"),
+				OutputType: proto.String("." + validateTestPkg + ".Resp"),
+				Options:    &descriptorpb.MethodOptions{},
+			}},
+		}},
+	}
+	options := fd.GetService()[0].GetMethod()[0].GetOptions()
+	proto.SetExtension(options, http.E_Config, &http.HttpConfig{Path: "/do", Method: verb})
+	proto.SetExtension(options, http.E_Examples, examples)
+	return buildValidatePlugin(t, fd).Files[0].Services[0].Methods[0]
+}
+
+func TestGetDefaultExample(t *testing.T) {
+	method := methodExamplesMethod(t, http.HttpMethod_HTTP_METHOD_POST, []*http.MethodExample{
+		{Name: "empty", Response: `{}`},
+		{Name: "default", Response: `{"count": 3}`},
+	})
+	if got := GetMethodExamples(method); len(got) != 2 || got[0].GetName() != "empty" {
+		t.Errorf("GetMethodExamples = %v, want the two examples in order", got)
+	}
+	if got := GetDefaultExample(method); got.GetResponse() != `{"count": 3}` {
+		t.Errorf("GetDefaultExample = %v, want the example named default", got)
+	}
+	if got := GetDefaultExample(methodExamplesMethod(t, http.HttpMethod_HTTP_METHOD_POST, nil)); got != nil {
+		t.Errorf("GetDefaultExample without examples = %v, want nil", got)
+	}
+}
+
+func TestValidateMethodExamples(t *testing.T) {
+	tests := []struct {
+		name     string
+		verb     http.HttpMethod
+		examples []*http.MethodExample
+		wantErr  string
+	}{
+		{
+			name: "valid",
+			verb: http.HttpMethod_HTTP_METHOD_POST,
+			examples: []*http.MethodExample{
+				{Name: "default", Request: `{"title": "Dune"}`, Response: `{"count": 1}`},
+				{Name: "response only", Response: `{"count": "2"}`},
+			},
+		},
+		{
+			name:     "response of a GET method",
+			verb:     http.HttpMethod_HTTP_METHOD_GET,
+			examples: []*http.MethodExample{{Name: "default", Response: `{"count": 1}`}},
+		},
+		{
+			name:     "no name",
+			verb:     http.HttpMethod_HTTP_METHOD_POST,
+			examples: []*http.MethodExample{{Response: `{}`}},
+			wantErr:  "Svc.Do: example has no name",
+		},
+		{
+			name: "declared twice",
+			verb: http.HttpMethod_HTTP_METHOD_POST,
+			examples: []*http.MethodExample{
+				{Name: "default", Response: `{}`},
+				{Name: "default", Request: `{}`},
+			},
+			wantErr: `Svc.Do: example "default" is declared twice`,
+		},
+		{
+			name:     "no payload",
+			verb:     http.HttpMethod_HTTP_METHOD_POST,
+			examples: []*http.MethodExample{{Name: "default", Summary: "Nothing"}},
+			wantErr:  `Svc.Do: example "default" has neither a request nor a response`,
+		},
+		{
+			name:     "request of a GET method",
+			verb:     http.HttpMethod_HTTP_METHOD_GET,
+			examples: []*http.MethodExample{{Name: "default", Request: `{"title": "Dune"}`}},
+			wantErr:  `Svc.Do: example "default" has a request, but GET requests have no body`,
+		},
+		{
+			name:     "invalid request",
+			verb:     http.HttpMethod_HTTP_METHOD_POST,
+			examples: []*http.MethodExample{{Name: "default", Request: `{"title": 42}`}},
+			wantErr:  `Svc.Do: request of example "default" is not a ` + validateTestPkg + `.Req: `,
+		},
+		{
+			name:     "invalid response",
+			verb:     http.HttpMethod_HTTP_METHOD_POST,
+			examples: []*http.MethodExample{{Name: "default", Response: `{"total": 1}`}},
+			wantErr:  `Svc.Do: response of example "default" is not a ` + validateTestPkg + `.Resp: `,
+		},
+		{
+			name:     "malformed JSON",
+			verb:     http.HttpMethod_HTTP_METHOD_POST,
+			examples: []*http.MethodExample{{Name: "default", Response: `{"count": 1`}},
+			wantErr:  `Svc.Do: response of example "default" is not a ` + validateTestPkg + `.Resp: `,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMethodExamples(methodExamplesMethod(t, tt.verb, tt.examples).Parent)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateMethodExamples: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateMethodExamples error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
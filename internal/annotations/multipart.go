@@ -0,0 +1,57 @@
+package annotations
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// GetMultipartFilenameTarget returns the name of the bytes field whose
+// multipart filename the field captures, or "" when the field has no
+// multipart_filename annotation.
+func GetMultipartFilenameTarget(field *protogen.Field) string {
+	fieldOptions, ok := field.Desc.Options().(*descriptorpb.FieldOptions)
+	if !ok || fieldOptions == nil {
+		return ""
+	}
+	target, _ := proto.GetExtension(fieldOptions, http.E_MultipartFilename).(string)
+	return target
+}
+
+// IsMultipartFileField reports whether a field can be bound from a multipart
+// file part: a bytes field, or repeated bytes taking one part per occurrence.
+func IsMultipartFileField(field *protogen.Field) bool {
+	return field.Desc.Kind() == protoreflect.BytesKind && !field.Desc.IsMap()
+}
+
+// ValidateMultipartFilenames checks the multipart_filename annotations of a
+// message: each must be on a string field and name a distinct bytes field of
+// the same message.
+func ValidateMultipartFilenames(message *protogen.Message) error {
+	targets := make(map[string]string)
+	for _, field := range message.Fields {
+		target := GetMultipartFilenameTarget(field)
+		if target == "" {
+			continue
+		}
+		where := fmt.Sprintf("%s.%s", message.Desc.FullName(), field.Desc.Name())
+		if field.Desc.Kind() != protoreflect.StringKind || field.Desc.IsMap() {
+			return fmt.Errorf("%s: multipart_filename is only valid on string fields", where)
+		}
+		fileField := message.Desc.Fields().ByName(protoreflect.Name(target))
+		if fileField == nil || fileField.Kind() != protoreflect.BytesKind || fileField.IsMap() {
+			return fmt.Errorf("%s: multipart_filename %q does not name a bytes field of %s",
+				where, target, message.Desc.Name())
+		}
+		if other, ok := targets[target]; ok {
+			return fmt.Errorf("%s: the filename of %s is already captured by %s", where, target, other)
+		}
+		targets[target] = string(field.Desc.Name())
+	}
+	return nil
+}
@@ -48,12 +48,13 @@ func ValidateNullableAnnotation(field *protogen.Field, messageName string) error
 		return nil // No annotation, nothing to validate
 	}
 
-	// Nullable only valid on proto3 optional fields
-	if !field.Desc.HasOptionalKeyword() {
+	// Nullable only valid on optional fields: proto3 or proto2 optional, or
+	// with editions explicit presence
+	if !field.Desc.HasOptionalKeyword() && !IsOptionalScalarField(field) {
 		return &NullableValidationError{
 			MessageName: messageName,
 			FieldName:   string(field.Desc.Name()),
-			Reason:      "nullable annotation is only valid on proto3 optional fields",
+			Reason:      "nullable annotation is only valid on optional fields",
 		}
 	}
 
@@ -167,11 +167,11 @@ func validateDiscriminatorNameCollision(
 			continue // Skip oneof's own fields
 		}
 
-		if field.Desc.JSONName() == discriminator {
+		if JSONFieldName(field) == discriminator {
 			return fmt.Errorf(
 				"oneof %s.%s: discriminator name %q collides with field %q (JSON: %q)",
 				message.Desc.Name(), oneof.Desc.Name(), discriminator,
-				field.Desc.Name(), field.Desc.JSONName(),
+				field.Desc.Name(), JSONFieldName(field),
 			)
 		}
 	}
@@ -190,12 +190,12 @@ func validateDiscriminatorVariantCollision(
 	discriminator string,
 ) error {
 	for _, field := range oneof.Fields {
-		if field.Desc.JSONName() == discriminator {
+		if JSONFieldName(field) == discriminator {
 			return fmt.Errorf(
 				"oneof %s.%s (flatten=false): discriminator name %q collides with variant %q (JSON: %q); "+
 					"on the non-flatten path the discriminator and the variant key share the parent object",
 				message.Desc.Name(), oneof.Desc.Name(), discriminator,
-				field.Desc.Name(), field.Desc.JSONName(),
+				field.Desc.Name(), JSONFieldName(field),
 			)
 		}
 	}
@@ -229,7 +229,7 @@ func validateOneofFlatten(
 		}
 
 		for _, childField := range variantField.Message.Fields {
-			childJSON := childField.Desc.JSONName()
+			childJSON := JSONFieldName(childField)
 			if source, exists := reserved[childJSON]; exists {
 				return fmt.Errorf(
 					"oneof %s.%s with flatten=true: variant %q child field %q (JSON: %q) collides with %s",
@@ -257,7 +257,7 @@ func buildReservedNames(
 			continue
 		}
 
-		reserved[field.Desc.JSONName()] = fmt.Sprintf("parent field %q", field.Desc.Name())
+		reserved[JSONFieldName(field)] = fmt.Sprintf("parent field %q", field.Desc.Name())
 	}
 
 	return reserved
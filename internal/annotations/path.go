@@ -1,15 +1,22 @@
 package annotations
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 )
 
-// pathParamRegex matches path variables like {user_id} or {id}.
+// pathParamRegex matches path variables like {user_id} or {id}, and wildcards
+// like {path...}.
 var pathParamRegex = regexp.MustCompile(`\{([^}]+)\}`)
 
-// ExtractPathParams parses path variables from a path string.
-// Example: "/users/{user_id}/posts/{post_id}" -> ["user_id", "post_id"].
+// wildcardSuffix ends the name of a path wildcard, {path...}, which matches the
+// rest of the path, slashes included (Go 1.22 ServeMux patterns).
+const wildcardSuffix = "..."
+
+// ExtractPathParams parses path variables from a path string. A wildcard is
+// returned by its name, without the "...".
+// Example: "/users/{user_id}/files/{path...}" -> ["user_id", "path"].
 func ExtractPathParams(path string) []string {
 	matches := pathParamRegex.FindAllStringSubmatch(path, -1)
 	if len(matches) == 0 {
@@ -19,14 +26,63 @@ func ExtractPathParams(path string) []string {
 	params := make([]string, 0, len(matches))
 	for _, match := range matches {
 		if len(match) > 1 {
-			params = append(params, match[1])
+			params = append(params, strings.TrimSuffix(match[1], wildcardSuffix))
 		}
 	}
 	return params
 }
 
+// PathWildcard returns the name of the wildcard ending path, path for
+// "/files/{path...}", or an empty string when path has none.
+func PathWildcard(path string) string {
+	start := strings.LastIndex(path, "/{")
+	if start < 0 || !strings.HasSuffix(path, wildcardSuffix+"}") {
+		return ""
+	}
+	name := strings.TrimSuffix(path[start+len("/{"):len(path)-len("}")], wildcardSuffix)
+	if name == "" || strings.ContainsAny(name, "{}") {
+		return ""
+	}
+	return name
+}
+
+// IsPathWildcard reports whether name is the wildcard ending path.
+func IsPathWildcard(path, name string) bool {
+	return name != "" && PathWildcard(path) == name
+}
+
+// ValidatePathWildcard checks that a wildcard of path, if any, is named and is
+// its final segment, as net/http requires: "/files/{path...}" is valid, while
+// "/files/{path...}/raw" and "/files/v{path...}" are not.
+func ValidatePathWildcard(path string) error {
+	for _, match := range pathParamRegex.FindAllStringSubmatchIndex(path, -1) {
+		name := path[match[2]:match[3]]
+		if !strings.HasSuffix(name, wildcardSuffix) {
+			continue
+		}
+		if name == wildcardSuffix {
+			return fmt.Errorf("wildcard {%s} in path %q has no name", name, path)
+		}
+		if match[1] != len(path) || !strings.HasSuffix(path[:match[0]], "/") {
+			return fmt.Errorf("wildcard {%s} in path %q must be the final segment of the path", name, path)
+		}
+	}
+	return nil
+}
+
+// TemplatePath returns path with its wildcard written as a plain variable,
+// "/files/{path}" for "/files/{path...}", for formats without wildcards such
+// as OpenAPI path templates.
+func TemplatePath(path string) string {
+	if name := PathWildcard(path); name != "" {
+		return strings.TrimSuffix(path, wildcardSuffix+"}") + "}"
+	}
+	return path
+}
+
 // BuildHTTPPath combines service base path with method path.
-// Handles slash normalization between the two path segments.
+// Handles slash normalization between the two path segments. A wildcard ending
+// the method path, {path...}, is kept as written.
 func BuildHTTPPath(servicePath, methodPath string) string {
 	// Handle empty paths
 	if servicePath == "" && methodPath == "" {
@@ -1,8 +1,12 @@
 package annotations
 
 import (
+	"fmt"
+	"slices"
+
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/descriptorpb"
 
 	"github.com/SebastienMelki/sebuf/http"
@@ -18,32 +22,24 @@ type QueryParam struct {
 	Required      bool            // Whether the parameter is required
 	FieldKind     string          // Proto field kind (e.g., "string", "int32", "bool")
 	Field         *protogen.Field // Raw protogen field reference
+	JSON          bool            // Carried as QUERY_ENCODING_JSON_BASE64URL
+	MaxJSONBytes  int             // Largest decoded JSON value servers accept, when JSON
+	Aliases       []string        // Other names servers accept, read in order when ParamName is absent
 }
 
 // GetQueryParams extracts query parameter configurations from message fields.
-// Returns all fields that have the sebuf.http.query annotation.
+// Returns all fields that have the sebuf.http.query annotation or are declared
+// with source = FIELD_SOURCE_QUERY (named after the field unless annotated).
 func GetQueryParams(message *protogen.Message) []QueryParam {
 	var params []QueryParam
 
 	for _, field := range message.Fields {
-		options := field.Desc.Options()
-		if options == nil {
-			continue
-		}
-
-		fieldOptions, ok := options.(*descriptorpb.FieldOptions)
-		if !ok {
-			continue
-		}
-
-		ext := proto.GetExtension(fieldOptions, http.E_Query)
-		if ext == nil {
-			continue
-		}
-
-		queryConfig, ok := ext.(*http.QueryConfig)
-		if !ok || queryConfig == nil {
-			continue
+		queryConfig := getQueryConfig(field)
+		if queryConfig == nil {
+			if GetFieldSource(field) != http.FieldSource_FIELD_SOURCE_QUERY {
+				continue
+			}
+			queryConfig = &http.QueryConfig{}
 		}
 
 		// Use the configured name, or default to the proto field name
@@ -52,16 +48,187 @@ func GetQueryParams(message *protogen.Message) []QueryParam {
 			paramName = string(field.Desc.Name())
 		}
 
-		params = append(params, QueryParam{
+		param := QueryParam{
 			FieldName:     string(field.Desc.Name()),
 			FieldGoName:   field.GoName,
-			FieldJSONName: field.Desc.JSONName(),
+			FieldJSONName: JSONFieldName(field),
 			ParamName:     paramName,
 			Required:      queryConfig.GetRequired(),
 			FieldKind:     field.Desc.Kind().String(),
 			Field:         field,
-		})
+			Aliases:       queryConfig.GetAliases(),
+		}
+		if queryConfig.GetEncoding() == http.QueryEncoding_QUERY_ENCODING_JSON_BASE64URL {
+			param.JSON = true
+			param.MaxJSONBytes = http.DefaultJSONQueryParamMaxBytes
+			if maxBytes := queryConfig.GetMaxBytes(); maxBytes > 0 {
+				param.MaxJSONBytes = int(maxBytes)
+			}
+		}
+		params = append(params, param)
 	}
 
 	return params
 }
+
+// getQueryConfig returns the sebuf.http.query annotation of a field, or nil.
+func getQueryConfig(field *protogen.Field) *http.QueryConfig {
+	options := field.Desc.Options()
+	if options == nil {
+		return nil
+	}
+
+	fieldOptions, ok := options.(*descriptorpb.FieldOptions)
+	if !ok {
+		return nil
+	}
+
+	ext := proto.GetExtension(fieldOptions, http.E_Query)
+	if ext == nil {
+		return nil
+	}
+
+	queryConfig, ok := ext.(*http.QueryConfig)
+	if !ok || queryConfig == nil {
+		return nil
+	}
+	return queryConfig
+}
+
+// GetURLQueryParams returns the query parameters a client sends in the URL.
+// Methods without a body send all of them; methods with a body send only the
+// fields declared with source QUERY and carry the other query-annotated fields
+// in the body, which servers also accept.
+func GetURLQueryParams(message *protogen.Message, hasBody bool) []QueryParam {
+	queryParams := GetQueryParams(message)
+	if !hasBody {
+		return queryParams
+	}
+	var declared []QueryParam
+	for _, qp := range queryParams {
+		if GetFieldSource(qp.Field) == http.FieldSource_FIELD_SOURCE_QUERY {
+			declared = append(declared, qp)
+		}
+	}
+	return declared
+}
+
+// QueryUnbindableReason returns why a field cannot be carried in a query
+// string, or "" when it can. Query parameters carry scalars, enums and repeated
+// scalars or enums; maps, messages and bytes have no query representation,
+// except for messages and repeated messages encoded as JSON_BASE64URL.
+func QueryUnbindableReason(field *protogen.Field) string {
+	switch {
+	case field.Desc.IsMap():
+		return "it is a map"
+	case field.Desc.Kind() == protoreflect.MessageKind || field.Desc.Kind() == protoreflect.GroupKind:
+		if getQueryConfig(field).GetEncoding() == http.QueryEncoding_QUERY_ENCODING_JSON_BASE64URL {
+			return ""
+		}
+		if field.Desc.IsList() {
+			return "it is a repeated message"
+		}
+		return "it is a message"
+	case field.Desc.Kind() == protoreflect.BytesKind:
+		return "it is a bytes field"
+	}
+	return ""
+}
+
+// ValidateBodylessRequestFields checks that the GET and DELETE methods of a
+// service can bind every request field without a body: each field must be a
+// path variable, read from a header, or have a type the query string can
+// carry. Otherwise servers would silently leave the field unset.
+func ValidateBodylessRequestFields(service *protogen.Service) error {
+	basePathParams := GetBoundBasePathParams(service)
+	for _, method := range service.Methods {
+		config := GetMethodHTTPConfig(method)
+		if config == nil || (config.Method != methodGET && config.Method != methodDELETE) {
+			continue
+		}
+		pathParams := append(slices.Clone(basePathParams), config.PathParams...)
+		for _, field := range method.Input.Fields {
+			if slices.Contains(pathParams, string(field.Desc.Name())) ||
+				GetFieldSource(field) == http.FieldSource_FIELD_SOURCE_HEADER {
+				continue
+			}
+			if reason := QueryUnbindableReason(field); reason != "" {
+				return fmt.Errorf("%s: field %s.%s cannot be bound from the query string of a %s request "+
+					"because %s. Change the method to POST, PUT or PATCH, or remove the field from the request",
+					method.Desc.FullName(), method.Input.Desc.Name(), field.Desc.Name(), config.Method, reason)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateQueryEncodings checks the encoding and max_bytes of the query
+// annotations of a service's requests: QUERY_ENCODING_JSON_BASE64URL is only
+// valid on message and repeated message fields, and max_bytes only with it.
+func ValidateQueryEncodings(service *protogen.Service) error {
+	for _, method := range service.Methods {
+		for _, field := range method.Input.Fields {
+			config := getQueryConfig(field)
+			isMessage := field.Message != nil && !field.Desc.IsMap()
+			switch {
+			case config.GetEncoding() == http.QueryEncoding_QUERY_ENCODING_JSON_BASE64URL && !isMessage:
+				return fmt.Errorf("%s: field %s.%s uses query encoding QUERY_ENCODING_JSON_BASE64URL, "+
+					"which is only valid on message and repeated message fields",
+					method.Desc.FullName(), method.Input.Desc.Name(), field.Desc.Name())
+			case config.GetMaxBytes() > 0 &&
+				config.GetEncoding() != http.QueryEncoding_QUERY_ENCODING_JSON_BASE64URL:
+				return fmt.Errorf("%s: field %s.%s sets max_bytes, "+
+					"which only applies to query encoding QUERY_ENCODING_JSON_BASE64URL",
+					method.Desc.FullName(), method.Input.Desc.Name(), field.Desc.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateQueryAliases checks the aliases of the query parameters of a
+// service's requests: each must be a non-empty name that no other query
+// parameter of the request uses as its name or alias, so servers can tell
+// which field a parameter binds.
+func ValidateQueryAliases(service *protogen.Service) error {
+	for _, method := range service.Methods {
+		params := GetQueryParams(method.Input)
+		owners := make(map[string]QueryParam, len(params))
+		for _, param := range params {
+			owners[param.ParamName] = param
+		}
+		for _, param := range params {
+			for _, alias := range param.Aliases {
+				if alias == "" {
+					return fmt.Errorf("%s: query parameter %s of field %s.%s has an empty alias",
+						method.Desc.FullName(), param.ParamName, method.Input.Desc.Name(), param.FieldName)
+				}
+				if owner, ok := owners[alias]; ok {
+					return fmt.Errorf("%s: alias %s of query parameter %s (field %s.%s) "+
+						"is already the query parameter or an alias of field %s.%s",
+						method.Desc.FullName(), alias, param.ParamName, method.Input.Desc.Name(), param.FieldName,
+						method.Input.Desc.Name(), owner.FieldName)
+				}
+				owners[alias] = param
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateScalarQueryParams checks that no request of a service has a query
+// parameter encoded as QUERY_ENCODING_JSON_BASE64URL, for the generators that
+// only send and bind scalar query parameters. generator names the plugin in
+// the error.
+func ValidateScalarQueryParams(service *protogen.Service, generator string) error {
+	for _, method := range service.Methods {
+		for _, param := range GetQueryParams(method.Input) {
+			if param.JSON {
+				return fmt.Errorf("%s: query parameter %s of field %s.%s uses encoding "+
+					"QUERY_ENCODING_JSON_BASE64URL, which %s does not support",
+					method.Desc.FullName(), param.ParamName, method.Input.Desc.Name(), param.FieldName, generator)
+			}
+		}
+	}
+	return nil
+}
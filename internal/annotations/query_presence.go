@@ -0,0 +1,73 @@
+package annotations
+
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// QueryPresence tells when a generated client sends a query parameter. The
+// client generators all follow GetQueryPresence, so that a request carries the
+// same query string whatever the language of its client, and servers read an
+// absent parameter as the zero value of its field.
+type QueryPresence int
+
+const (
+	// QueryPresenceNonZero sends the parameter when its field does not hold
+	// its zero value: a non-empty string, a number other than 0, an enum
+	// value other than the first, or true.
+	QueryPresenceNonZero QueryPresence = iota
+	// QueryPresenceWhenSet sends the parameter whenever its field is set,
+	// even to its zero value: the field has explicit presence, or is a
+	// message carried as JSON.
+	QueryPresenceWhenSet
+	// QueryPresenceNonEmpty sends the elements of a repeated field, and
+	// leaves the parameter out when the list is empty.
+	QueryPresenceNonEmpty
+)
+
+// GetQueryPresence returns when clients send the query parameter qp.
+func GetQueryPresence(qp QueryParam) QueryPresence {
+	switch {
+	case qp.Field == nil:
+		return QueryPresenceNonZero
+	case qp.Field.Desc.IsList():
+		return QueryPresenceNonEmpty
+	case qp.Field.Desc.Message() != nil, HasExplicitPresence(qp.Field):
+		return QueryPresenceWhenSet
+	}
+	return QueryPresenceNonZero
+}
+
+// QueryZeroValue returns the query string form of the zero value of qp, which
+// clients leave out under QueryPresenceNonZero: "" for strings, "0" for
+// numbers, "false" for booleans, and the wire name of the first value for
+// enums, or "0" under enum_encoding=NUMBER.
+func QueryZeroValue(qp QueryParam) string {
+	if qp.Field == nil {
+		return ""
+	}
+	//exhaustive:ignore -- strings, bytes and messages have no non-empty zero value
+	switch qp.Field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(false)
+	case protoreflect.EnumKind:
+		values := qp.Field.Enum.Values
+		if len(values) == 0 || ResolveEnumEncoding(qp.Field) == http.EnumEncoding_ENUM_ENCODING_NUMBER {
+			return "0"
+		}
+		if mapping := GetEnumValueMapping(values[0]); mapping != "" {
+			return mapping
+		}
+		return string(values[0].Desc.Name())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind,
+		protoreflect.FloatKind, protoreflect.DoubleKind:
+		return "0"
+	}
+	return ""
+}
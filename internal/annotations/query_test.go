@@ -0,0 +1,333 @@
+package annotations
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// bodylessFile builds the validateOneofFile messages with an Items service
+// whose Get method is served with method on /items/{id}. Its request has an id
+// path variable, an X-Tenant header field, and field.
+func bodylessFile(method http.HttpMethod, field *descriptorpb.FieldDescriptorProto) *descriptorpb.FileDescriptorProto {
+	fd := validateOneofFile()
+	labelsEntry := &descriptorpb.DescriptorProto{
+		Name: proto.String("LabelsEntry"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			scalarField("key", 1),
+			scalarField("value", 2),
+		},
+		Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+	}
+	fd.MessageType = append(fd.MessageType, &descriptorpb.DescriptorProto{
+		Name: proto.String("GetItemRequest"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			scalarField("id", 1),
+			withSource(scalarField("x_tenant", 2), http.FieldSource_FIELD_SOURCE_HEADER),
+			field,
+		},
+		NestedType: []*descriptorpb.DescriptorProto{labelsEntry},
+	})
+	get := &descriptorpb.MethodDescriptorProto{
+		Name:       proto.String("Get"),
+		InputType:  proto.String("." + validateTestPkg + ".GetItemRequest"),
+		OutputType: proto.String("." + validateTestPkg + ".TextContent"),
+		Options:    &descriptorpb.MethodOptions{},
+	}
+	proto.SetExtension(get.GetOptions(), http.E_Config, &http.HttpConfig{Path: "/items/{id}", Method: method})
+	fd.Service = []*descriptorpb.ServiceDescriptorProto{{
+		Name:   proto.String("Items"),
+		Method: []*descriptorpb.MethodDescriptorProto{get},
+	}}
+	return fd
+}
+
+// withQueryConfig annotates a field descriptor with config.
+func withQueryConfig(
+	field *descriptorpb.FieldDescriptorProto,
+	config *http.QueryConfig,
+) *descriptorpb.FieldDescriptorProto {
+	field.Options = &descriptorpb.FieldOptions{}
+	proto.SetExtension(field.Options, http.E_Query, config)
+	return field
+}
+
+// repeatedField marks a field descriptor repeated.
+func repeatedField(field *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return field
+}
+
+func TestValidateBodylessRequestFields(t *testing.T) {
+	const message = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+	tests := []struct {
+		name    string
+		method  http.HttpMethod
+		field   *descriptorpb.FieldDescriptorProto
+		wantErr string
+	}{
+		{
+			name:   "map",
+			method: http.HttpMethod_HTTP_METHOD_GET,
+			field:  repeatedField(typedField("labels", 3, message, "GetItemRequest.LabelsEntry")),
+			wantErr: "field GetItemRequest.labels cannot be bound from the query string of a GET request " +
+				"because it is a map",
+		},
+		{
+			name:   "message",
+			method: http.HttpMethod_HTTP_METHOD_GET,
+			field:  withQuery(typedField("filter", 3, message, "TextContent"), ""),
+			wantErr: "field GetItemRequest.filter cannot be bound from the query string of a GET request " +
+				"because it is a message",
+		},
+		{
+			name:    "bytes",
+			method:  http.HttpMethod_HTTP_METHOD_DELETE,
+			field:   typedField("token", 3, descriptorpb.FieldDescriptorProto_TYPE_BYTES, ""),
+			wantErr: "of a DELETE request because it is a bytes field",
+		},
+		{
+			name:   "repeated message",
+			method: http.HttpMethod_HTTP_METHOD_GET,
+			field:  repeatedField(typedField("images", 3, message, "ImageContent")),
+			wantErr: "field GetItemRequest.images cannot be bound from the query string of a GET request " +
+				"because it is a repeated message",
+		},
+		{
+			name:   "repeated scalar",
+			method: http.HttpMethod_HTTP_METHOD_GET,
+			field:  withQuery(repeatedField(scalarField("tags", 3)), ""),
+		},
+		{
+			name:   "JSON_BASE64URL repeated message",
+			method: http.HttpMethod_HTTP_METHOD_GET,
+			field: withQueryConfig(repeatedField(typedField("images", 3, message, "ImageContent")),
+				&http.QueryConfig{Encoding: http.QueryEncoding_QUERY_ENCODING_JSON_BASE64URL}),
+		},
+		{
+			name:   "message with a body",
+			method: http.HttpMethod_HTTP_METHOD_POST,
+			field:  typedField("filter", 3, message, "TextContent"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := buildValidatePlugin(t, bodylessFile(tt.method, tt.field))
+			err := ValidateBodylessRequestFields(plugin.Files[0].Services[0])
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateBodylessRequestFields: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateBodylessRequestFields error = %v, want it to contain %q", err, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), "Change the method to POST, PUT or PATCH") {
+				t.Errorf("error %q does not suggest changing the method", err)
+			}
+		})
+	}
+}
+
+func TestGetQueryParams_JSON(t *testing.T) {
+	const message = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+	jsonBase64URL := http.QueryEncoding_QUERY_ENCODING_JSON_BASE64URL
+	tests := []struct {
+		name         string
+		config       *http.QueryConfig
+		wantJSON     bool
+		wantMaxBytes int
+	}{
+		{"plain", &http.QueryConfig{}, false, 0},
+		{"default limit", &http.QueryConfig{Encoding: jsonBase64URL}, true, http.DefaultJSONQueryParamMaxBytes},
+		{"max_bytes", &http.QueryConfig{Encoding: jsonBase64URL, MaxBytes: 512}, true, 512},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := withQueryConfig(typedField("filter", 3, message, "TextContent"), tt.config)
+			plugin := buildValidatePlugin(t, bodylessFile(http.HttpMethod_HTTP_METHOD_GET, field))
+			params := GetQueryParams(plugin.Files[0].Services[0].Methods[0].Input)
+			if len(params) != 1 || params[0].JSON != tt.wantJSON || params[0].MaxJSONBytes != tt.wantMaxBytes {
+				t.Errorf("GetQueryParams = %+v, want JSON %v with MaxJSONBytes %d",
+					params, tt.wantJSON, tt.wantMaxBytes)
+			}
+		})
+	}
+}
+
+func TestValidateQueryEncodings(t *testing.T) {
+	const message = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+	jsonBase64URL := http.QueryEncoding_QUERY_ENCODING_JSON_BASE64URL
+	tests := []struct {
+		name    string
+		field   *descriptorpb.FieldDescriptorProto
+		wantErr string
+	}{
+		{
+			name: "message",
+			field: withQueryConfig(
+				typedField("filter", 3, message, "TextContent"),
+				&http.QueryConfig{Encoding: jsonBase64URL},
+			),
+		},
+		{
+			name: "repeated message with max_bytes",
+			field: withQueryConfig(repeatedField(typedField("filters", 3, message, "TextContent")),
+				&http.QueryConfig{Encoding: jsonBase64URL, MaxBytes: 1024}),
+		},
+		{
+			name:    "scalar",
+			field:   withQueryConfig(scalarField("q", 3), &http.QueryConfig{Encoding: jsonBase64URL}),
+			wantErr: "field GetItemRequest.q uses query encoding QUERY_ENCODING_JSON_BASE64URL",
+		},
+		{
+			name: "map",
+			field: withQueryConfig(repeatedField(typedField("labels", 3, message, "GetItemRequest.LabelsEntry")),
+				&http.QueryConfig{Encoding: jsonBase64URL}),
+			wantErr: "only valid on message and repeated message fields",
+		},
+		{
+			name:    "max_bytes without JSON",
+			field:   withQueryConfig(scalarField("q", 3), &http.QueryConfig{MaxBytes: 1024}),
+			wantErr: "field GetItemRequest.q sets max_bytes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := buildValidatePlugin(t, bodylessFile(http.HttpMethod_HTTP_METHOD_GET, tt.field))
+			service := plugin.Files[0].Services[0]
+			err := ValidateQueryEncodings(service)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateQueryEncodings: %v", err)
+				}
+				if err = ValidateScalarQueryParams(service, "protoc-gen-test"); err == nil ||
+					!strings.Contains(err.Error(), "which protoc-gen-test does not support") {
+					t.Errorf("ValidateScalarQueryParams = %v, want the generator to be named", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateQueryEncodings error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateQueryAliases(t *testing.T) {
+	search := func(aliases ...string) *descriptorpb.FieldDescriptorProto {
+		return withQueryConfig(scalarField("search", 1), &http.QueryConfig{Aliases: aliases})
+	}
+	tests := []struct {
+		name    string
+		fields  []*descriptorpb.FieldDescriptorProto
+		wantErr string
+	}{
+		{
+			name:   "aliases",
+			fields: []*descriptorpb.FieldDescriptorProto{search("q", "query"), withQuery(scalarField("page", 2), "")},
+		},
+		{
+			name:    "another parameter's name",
+			fields:  []*descriptorpb.FieldDescriptorProto{search("page"), withQuery(scalarField("page", 2), "")},
+			wantErr: "alias page of query parameter search (field Req.search) is already the query parameter",
+		},
+		{
+			name: "another parameter's alias",
+			fields: []*descriptorpb.FieldDescriptorProto{
+				search("q"),
+				withQueryConfig(scalarField("filter", 2), &http.QueryConfig{Aliases: []string{"q"}}),
+			},
+			wantErr: "alias q of query parameter filter (field Req.filter) is already the query parameter " +
+				"or an alias of field Req.search",
+		},
+		{
+			name:    "own name",
+			fields:  []*descriptorpb.FieldDescriptorProto{search("search")},
+			wantErr: "alias search of query parameter search",
+		},
+		{
+			name:    "empty",
+			fields:  []*descriptorpb.FieldDescriptorProto{search("")},
+			wantErr: "query parameter search of field Req.search has an empty alias",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateQueryAliases(sourceMethod(t, tt.fields...).Parent)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateQueryAliases: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateQueryAliases error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetQueryPresence(t *testing.T) {
+	optional := func(field *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+		field.Proto3Optional = proto.Bool(true)
+		field.OneofIndex = proto.Int32(0)
+		return field
+	}
+	jsonBase64URL := &http.QueryConfig{Encoding: http.QueryEncoding_QUERY_ENCODING_JSON_BASE64URL}
+	tests := []struct {
+		name         string
+		field        *descriptorpb.FieldDescriptorProto
+		config       *http.QueryConfig
+		wantPresence QueryPresence
+		wantZero     string
+	}{
+		{"string", scalarField("q", 3), nil, QueryPresenceNonZero, ""},
+		{"bool", typedField("active", 3, descriptorpb.FieldDescriptorProto_TYPE_BOOL, ""), nil,
+			QueryPresenceNonZero, "false"},
+		{"int64", typedField("since", 3, descriptorpb.FieldDescriptorProto_TYPE_INT64, ""), nil,
+			QueryPresenceNonZero, "0"},
+		{"enum", typedField("status", 3, descriptorpb.FieldDescriptorProto_TYPE_ENUM, "Status"), nil,
+			QueryPresenceNonZero, "STATUS_UNSPECIFIED"},
+		{"optional int32", optional(typedField("min", 3, descriptorpb.FieldDescriptorProto_TYPE_INT32, "")), nil,
+			QueryPresenceWhenSet, "0"},
+		{"repeated string", repeatedField(scalarField("tags", 3)), nil, QueryPresenceNonEmpty, ""},
+		{"JSON message", typedField("filter", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, "TextContent"),
+			jsonBase64URL, QueryPresenceWhenSet, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			if config == nil {
+				config = &http.QueryConfig{}
+			}
+			fd := bodylessFile(http.HttpMethod_HTTP_METHOD_GET, withQueryConfig(tt.field, config))
+			fd.EnumType = append(fd.EnumType, &descriptorpb.EnumDescriptorProto{
+				Name: proto.String("Status"),
+				Value: []*descriptorpb.EnumValueDescriptorProto{
+					{Name: proto.String("STATUS_UNSPECIFIED"), Number: proto.Int32(0)},
+					{Name: proto.String("STATUS_ACTIVE"), Number: proto.Int32(1)},
+				},
+			})
+			if tt.field.Proto3Optional != nil {
+				request := fd.MessageType[len(fd.MessageType)-1]
+				request.OneofDecl = []*descriptorpb.OneofDescriptorProto{{Name: proto.String("_" + tt.field.GetName())}}
+			}
+			params := GetQueryParams(buildValidatePlugin(t, fd).Files[0].Services[0].Methods[0].Input)
+			if len(params) != 1 {
+				t.Fatalf("GetQueryParams = %+v, want one parameter", params)
+			}
+			if got := GetQueryPresence(params[0]); got != tt.wantPresence {
+				t.Errorf("GetQueryPresence = %v, want %v", got, tt.wantPresence)
+			}
+			if got := QueryZeroValue(params[0]); got != tt.wantZero {
+				t.Errorf("QueryZeroValue = %q, want %q", got, tt.wantZero)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package annotations
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// RawBody holds the fields of a response message written as the raw response
+// body.
+type RawBody struct {
+	// Data is the bytes field marked raw_body, written as the body.
+	Data *protogen.Field
+	// ContentType is the string field marked raw_body_content_type, holding
+	// the Content-Type of the body, or nil when the message has none.
+	ContentType *protogen.Field
+}
+
+// DefaultRawBodyContentType is the Content-Type of a raw body whose message
+// sets none.
+const DefaultRawBodyContentType = "application/octet-stream"
+
+// hasBoolFieldOption reports whether a field sets the bool extension ext.
+func hasBoolFieldOption(field *protogen.Field, ext protoreflect.ExtensionType) bool {
+	fieldOptions, ok := field.Desc.Options().(*descriptorpb.FieldOptions)
+	if !ok || fieldOptions == nil {
+		return false
+	}
+	set, _ := proto.GetExtension(fieldOptions, ext).(bool)
+	return set
+}
+
+// GetRawBody returns the raw body fields of a message, or nil when no field of
+// the message is marked raw_body. It does not check the message's shape (see
+// ValidateRawBody).
+func GetRawBody(message *protogen.Message) *RawBody {
+	var rawBody RawBody
+	for _, field := range message.Fields {
+		if hasBoolFieldOption(field, http.E_RawBody) {
+			rawBody.Data = field
+		}
+		if hasBoolFieldOption(field, http.E_RawBodyContentType) {
+			rawBody.ContentType = field
+		}
+	}
+	if rawBody.Data == nil {
+		return nil
+	}
+	return &rawBody
+}
+
+// IsRawBodyResponse reports whether the response of a method is written as a
+// raw body.
+func IsRawBodyResponse(method *protogen.Method) bool {
+	return GetRawBody(method.Output) != nil
+}
+
+// ValidateRawBody checks the raw_body annotations of a message: raw_body must
+// be on a single singular bytes field, raw_body_content_type on a single
+// singular string field, and the message must have no other fields.
+func ValidateRawBody(message *protogen.Message) error {
+	var data, contentType *protogen.Field
+	for _, field := range message.Fields {
+		where := fmt.Sprintf("%s.%s", message.Desc.FullName(), field.Desc.Name())
+		isData := hasBoolFieldOption(field, http.E_RawBody)
+		isContentType := hasBoolFieldOption(field, http.E_RawBodyContentType)
+		switch {
+		case isData && isContentType:
+			return fmt.Errorf("%s: raw_body and raw_body_content_type cannot be set on the same field", where)
+		case isData:
+			if field.Desc.Kind() != protoreflect.BytesKind || field.Desc.IsList() {
+				return fmt.Errorf("%s: raw_body is only valid on singular bytes fields", where)
+			}
+			if data != nil {
+				return fmt.Errorf("%s: raw_body is already set on %s", where, data.Desc.Name())
+			}
+			data = field
+		case isContentType:
+			if field.Desc.Kind() != protoreflect.StringKind || field.Desc.IsList() || field.Desc.IsMap() {
+				return fmt.Errorf("%s: raw_body_content_type is only valid on singular string fields", where)
+			}
+			if contentType != nil {
+				return fmt.Errorf("%s: raw_body_content_type is already set on %s", where, contentType.Desc.Name())
+			}
+			contentType = field
+		}
+	}
+	if data == nil {
+		if contentType != nil {
+			return fmt.Errorf("%s.%s: raw_body_content_type requires a bytes field marked raw_body",
+				message.Desc.FullName(), contentType.Desc.Name())
+		}
+		return nil
+	}
+	for _, field := range message.Fields {
+		if field != data && field != contentType {
+			return fmt.Errorf("%s: a raw_body message can only hold the raw_body and raw_body_content_type "+
+				"fields, but it has %s", message.Desc.FullName(), field.Desc.Name())
+		}
+	}
+	return nil
+}
+
+// ValidateRawBodyResponses checks the raw body messages of the methods of
+// service: their shape, and that they are only the response of methods
+// answering with a single response.
+func ValidateRawBodyResponses(service *protogen.Service) error {
+	for _, method := range service.Methods {
+		for _, message := range []*protogen.Message{method.Input, method.Output} {
+			if err := ValidateRawBody(message); err != nil {
+				return fmt.Errorf("%s: %w", method.Desc.FullName(), err)
+			}
+		}
+		if GetRawBody(method.Input) != nil {
+			return fmt.Errorf("%s: raw_body is only valid on response messages, not on the request %s",
+				method.Desc.FullName(), method.Input.Desc.Name())
+		}
+		if !IsRawBodyResponse(method) {
+			continue
+		}
+		if config := GetMethodHTTPConfig(method); config != nil && (config.Stream || config.StreamResponse) {
+			return fmt.Errorf("%s: a raw_body response cannot be streamed; remove stream or stream_response",
+				method.Desc.FullName())
+		}
+	}
+	return nil
+}
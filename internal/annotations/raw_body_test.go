@@ -0,0 +1,114 @@
+package annotations
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// rawBodyField returns a field of kind typ, marked with the bool extension ext
+// when it is not nil.
+func rawBodyField(
+	name string,
+	number int32,
+	typ descriptorpb.FieldDescriptorProto_Type,
+	ext protoreflect.ExtensionType,
+) *descriptorpb.FieldDescriptorProto {
+	field := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     typ.Enum(),
+		JsonName: proto.String(name),
+	}
+	if ext != nil {
+		field.Options = &descriptorpb.FieldOptions{}
+		proto.SetExtension(field.Options, ext, true)
+	}
+	return field
+}
+
+// rawBodyFile returns a file whose Download message has fields.
+func rawBodyFile(fields ...*descriptorpb.FieldDescriptorProto) *descriptorpb.FileDescriptorProto {
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("raw_body.proto"),
+		Package: proto.String(validateTestPkg),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{
+			GoPackage: proto.String("github.com/SebastienMelki/sebuf/internal/annotations/validatev1"),
+		},
+		MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("Download"), Field: fields}},
+	}
+}
+
+func TestGetRawBody(t *testing.T) {
+	bytesType := descriptorpb.FieldDescriptorProto_TYPE_BYTES
+	stringType := descriptorpb.FieldDescriptorProto_TYPE_STRING
+
+	plugin := buildValidatePlugin(t, rawBodyFile(
+		rawBodyField("content_type", 1, stringType, http.E_RawBodyContentType),
+		rawBodyField("data", 2, bytesType, http.E_RawBody),
+	))
+	rawBody := GetRawBody(findValidateMessage(t, plugin, "Download"))
+	if rawBody == nil || rawBody.Data.Desc.Name() != "data" || rawBody.ContentType.Desc.Name() != "content_type" {
+		t.Fatalf("GetRawBody = %+v, want data with content_type", rawBody)
+	}
+
+	plain := buildValidatePlugin(t, rawBodyFile(rawBodyField("data", 1, bytesType, nil)))
+	if rawBody := GetRawBody(findValidateMessage(t, plain, "Download")); rawBody != nil {
+		t.Errorf("GetRawBody of an unannotated message = %+v, want nil", rawBody)
+	}
+}
+
+func TestValidateRawBody(t *testing.T) {
+	bytesType := descriptorpb.FieldDescriptorProto_TYPE_BYTES
+	stringType := descriptorpb.FieldDescriptorProto_TYPE_STRING
+
+	tests := []struct {
+		name   string
+		fields []*descriptorpb.FieldDescriptorProto
+		want   string
+	}{
+		{"bytes only", []*descriptorpb.FieldDescriptorProto{
+			rawBodyField("data", 1, bytesType, http.E_RawBody),
+		}, ""},
+		{"raw_body on a string", []*descriptorpb.FieldDescriptorProto{
+			rawBodyField("data", 1, stringType, http.E_RawBody),
+		}, "only valid on singular bytes fields"},
+		{"content type on bytes", []*descriptorpb.FieldDescriptorProto{
+			rawBodyField("data", 1, bytesType, http.E_RawBody),
+			rawBodyField("content_type", 2, bytesType, http.E_RawBodyContentType),
+		}, "only valid on singular string fields"},
+		{"content type without raw_body", []*descriptorpb.FieldDescriptorProto{
+			rawBodyField("content_type", 1, stringType, http.E_RawBodyContentType),
+		}, "requires a bytes field marked raw_body"},
+		{"two raw bodies", []*descriptorpb.FieldDescriptorProto{
+			rawBodyField("data", 1, bytesType, http.E_RawBody),
+			rawBodyField("more", 2, bytesType, http.E_RawBody),
+		}, "raw_body is already set on data"},
+		{"other field", []*descriptorpb.FieldDescriptorProto{
+			rawBodyField("data", 1, bytesType, http.E_RawBody),
+			rawBodyField("name", 2, stringType, nil),
+		}, "it has name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := buildValidatePlugin(t, rawBodyFile(tt.fields...))
+			err := ValidateRawBody(findValidateMessage(t, plugin, "Download"))
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("ValidateRawBody: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ValidateRawBody = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package annotations
+
+import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// ResponseHeader is a static header a generated server sets on every
+// response of a method.
+type ResponseHeader struct {
+	Name  string // Canonical name of the header
+	Value string
+}
+
+// GetResponseHeaders returns the response_headers of a method and of its
+// service, with the method's replacing the service's of the same name. Names
+// are canonical, and the headers are sorted by name for deterministic output.
+func GetResponseHeaders(method *protogen.Method) []ResponseHeader {
+	values := make(map[string]string)
+	for _, header := range getServiceConfig(method.Parent).GetResponseHeaders() {
+		values[CanonicalHeaderName(header.GetName())] = header.GetValue()
+	}
+	for _, header := range methodResponseHeaders(method) {
+		values[CanonicalHeaderName(header.GetName())] = header.GetValue()
+	}
+	headers := make([]ResponseHeader, 0, len(values))
+	for name, value := range values {
+		headers = append(headers, ResponseHeader{Name: name, Value: value})
+	}
+	sort.Slice(headers, func(i, j int) bool {
+		return headers[i].Name < headers[j].Name
+	})
+	return headers
+}
+
+// ValidateResponseHeaders checks the response_headers of a service and of each
+// of its methods: a name must be a valid HTTP field name declared once per
+// list, even with different casing, and a value must hold no control
+// characters other than tabs.
+func ValidateResponseHeaders(service *protogen.Service) error {
+	if err := validateResponseHeaderList(service.Desc, getServiceConfig(service).GetResponseHeaders()); err != nil {
+		return err
+	}
+	for _, method := range service.Methods {
+		if err := validateResponseHeaderList(method.Desc, methodResponseHeaders(method)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// methodResponseHeaders returns the response_headers a method declares.
+func methodResponseHeaders(method *protogen.Method) []*http.ResponseHeader {
+	if config := GetMethodHTTPConfig(method); config != nil {
+		return config.ResponseHeaders
+	}
+	return nil
+}
+
+// validateResponseHeaderList checks the response_headers element declares.
+func validateResponseHeaderList(element protoreflect.Descriptor, headers []*http.ResponseHeader) error {
+	declared := make(map[string]string, len(headers))
+	for _, header := range headers {
+		name := header.GetName()
+		if !isHeaderFieldName(name) {
+			return fmt.Errorf("%s: response header name %q is not a valid HTTP field name", element.FullName(), name)
+		}
+		canonical := CanonicalHeaderName(name)
+		if previous, ok := declared[canonical]; ok {
+			return fmt.Errorf("%s: response headers %q and %q name the same header %q",
+				element.FullName(), previous, name, canonical)
+		}
+		declared[canonical] = name
+		for _, r := range header.GetValue() {
+			if (r < ' ' && r != '\t') || r == 0x7f {
+				return fmt.Errorf("%s: value of response header %q holds a control character",
+					element.FullName(), name)
+			}
+		}
+	}
+	return nil
+}
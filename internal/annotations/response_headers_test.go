@@ -0,0 +1,98 @@
+package annotations
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// responseHeadersMethod builds a service Svc with a method Do, annotated with
+// the given service and method response_headers.
+func responseHeadersMethod(t *testing.T, service, method []*http.ResponseHeader) *protogen.Method {
+	t.Helper()
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("response_headers.proto"),
+		Package: proto.String(validateTestPkg),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{
+			GoPackage: proto.String("github.com/SebastienMelki/sebuf/internal/annotations/validatev1"),
+		},
+		MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("Req")}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name:    proto.String("Svc"),
+			Options: &descriptorpb.ServiceOptions{},
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Do"),
+				InputType:  proto.String("." + validateTestPkg + ".Req"),
+				OutputType: proto.String("." + validateTestPkg + ".Req"),
+				Options:    &descriptorpb.MethodOptions{},
+			}},
+		}},
+	}
+	proto.SetExtension(fd.GetService()[0].GetOptions(), http.E_ServiceConfig,
+		&http.ServiceConfig{ResponseHeaders: service})
+	proto.SetExtension(fd.GetService()[0].GetMethod()[0].GetOptions(), http.E_Config,
+		&http.HttpConfig{Path: "/do", ResponseHeaders: method})
+	return buildValidatePlugin(t, fd).Files[0].Services[0].Methods[0]
+}
+
+func TestGetResponseHeaders(t *testing.T) {
+	method := responseHeadersMethod(t,
+		[]*http.ResponseHeader{{Name: "x-frame-options", Value: "DENY"}, {Name: "Cache-Control", Value: "no-store"}},
+		[]*http.ResponseHeader{{Name: "cache-control", Value: "private"}},
+	)
+	want := []ResponseHeader{{Name: "Cache-Control", Value: "private"}, {Name: "X-Frame-Options", Value: "DENY"}}
+	if got := GetResponseHeaders(method); !slices.Equal(got, want) {
+		t.Errorf("GetResponseHeaders = %v, want %v", got, want)
+	}
+}
+
+func TestValidateResponseHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		service []*http.ResponseHeader
+		method  []*http.ResponseHeader
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			service: []*http.ResponseHeader{{Name: "X-Frame-Options", Value: "DENY"}},
+			method:  []*http.ResponseHeader{{Name: "X-Frame-Options", Value: "SAMEORIGIN"}},
+		},
+		{
+			name:    "invalid name",
+			service: []*http.ResponseHeader{{Name: "X Frame", Value: "DENY"}},
+			wantErr: `Svc: response header name "X Frame" is not a valid HTTP field name`,
+		},
+		{
+			name:    "declared twice",
+			method:  []*http.ResponseHeader{{Name: "Cache-Control"}, {Name: "cache-control"}},
+			wantErr: `Svc.Do: response headers "Cache-Control" and "cache-control" name the same header`,
+		},
+		{
+			name:    "control character",
+			method:  []*http.ResponseHeader{{Name: "X-Note", Value: "a\r\nSet-Cookie: b"}},
+			wantErr: `Svc.Do: value of response header "X-Note" holds a control character`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateResponseHeaders(responseHeadersMethod(t, tt.service, tt.method).Parent)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateResponseHeaders: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateResponseHeaders error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package annotations
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// ResponseVariant is one variant of a result message: the message field sent as
+// the response body and the HTTP status it is answered with.
+type ResponseVariant struct {
+	Field  *protogen.Field
+	Status int
+}
+
+// getResponseStatuses returns the response_statuses annotation of a oneof, or
+// nil if it is not annotated.
+func getResponseStatuses(oneof *protogen.Oneof) *http.ResponseStatuses {
+	oneofOptions, ok := oneof.Desc.Options().(*descriptorpb.OneofOptions)
+	if !ok || oneofOptions == nil {
+		return nil
+	}
+	if !proto.HasExtension(oneofOptions, http.E_ResponseStatuses) {
+		return nil
+	}
+	statuses, ok := proto.GetExtension(oneofOptions, http.E_ResponseStatuses).(*http.ResponseStatuses)
+	if !ok {
+		return nil
+	}
+	return statuses
+}
+
+// IsResultMessage reports whether a message has a oneof annotated with
+// response_statuses.
+func IsResultMessage(message *protogen.Message) bool {
+	return slices.ContainsFunc(message.Oneofs, func(oneof *protogen.Oneof) bool {
+		return getResponseStatuses(oneof) != nil
+	})
+}
+
+// GetResponseVariants returns the variants of a result message in declaration
+// order, or nil if the message is not a result message. It returns an error
+// when the annotation does not map every variant of a message made only of its
+// oneof to a distinct status carrying a body.
+func GetResponseVariants(message *protogen.Message) ([]ResponseVariant, error) {
+	var oneof *protogen.Oneof
+	var statuses *http.ResponseStatuses
+	for _, candidate := range message.Oneofs {
+		annotated := getResponseStatuses(candidate)
+		if annotated == nil {
+			continue
+		}
+		if oneof != nil {
+			return nil, fmt.Errorf("response_statuses is set on both oneofs %s and %s of %s; a result message has one",
+				oneof.Desc.Name(), candidate.Desc.Name(), message.Desc.FullName())
+		}
+		oneof, statuses = candidate, annotated
+	}
+	if oneof == nil {
+		return nil, nil
+	}
+
+	name := fmt.Sprintf("response_statuses of %s.%s", message.Desc.FullName(), oneof.Desc.Name())
+	if GetOneofConfig(oneof) != nil {
+		return nil, fmt.Errorf("%s cannot be combined with oneof_config", name)
+	}
+	for _, field := range message.Fields {
+		if field.Oneof != oneof {
+			return nil, fmt.Errorf("%s requires the message to have no fields besides the oneof, but it has %s",
+				name, field.Desc.Name())
+		}
+	}
+
+	mapped := statuses.GetStatuses()
+	variants := make([]ResponseVariant, 0, len(oneof.Fields))
+	seen := make(map[int]string, len(oneof.Fields))
+	for _, field := range oneof.Fields {
+		variantName := string(field.Desc.Name())
+		if field.Message == nil {
+			return nil, fmt.Errorf("%s: variant %s must be a message field", name, variantName)
+		}
+		status, ok := mapped[variantName]
+		if !ok {
+			return nil, fmt.Errorf("%s: variant %s has no status", name, variantName)
+		}
+		if err := validateResponseStatus(int(status)); err != nil {
+			return nil, fmt.Errorf("%s: variant %s: %w", name, variantName, err)
+		}
+		if other, dup := seen[int(status)]; dup {
+			return nil, fmt.Errorf("%s: variants %s and %s both map to status %d", name, other, variantName, status)
+		}
+		seen[int(status)] = variantName
+		variants = append(variants, ResponseVariant{Field: field, Status: int(status)})
+	}
+	for _, key := range slices.Sorted(maps.Keys(mapped)) {
+		if !slices.ContainsFunc(oneof.Fields, func(field *protogen.Field) bool {
+			return string(field.Desc.Name()) == key
+		}) {
+			return nil, fmt.Errorf("%s: %s is not a variant of the oneof", name, key)
+		}
+	}
+	return variants, nil
+}
+
+// validateResponseStatus checks that a variant status is an error or success
+// status whose response carries a body.
+func validateResponseStatus(status int) error {
+	switch {
+	case status < 200 || status > 599:
+		return fmt.Errorf("status %d is outside 200-599", status)
+	case status == 204 || status == 205 || status == 304:
+		return fmt.Errorf("status %d cannot carry a response body", status)
+	}
+	return nil
+}
+
+// ValidateResponseStatuses checks the result messages returned by the methods
+// of service, and that those methods answer with a single response.
+func ValidateResponseStatuses(service *protogen.Service) error {
+	for _, method := range service.Methods {
+		variants, err := GetResponseVariants(method.Output)
+		if err != nil {
+			return fmt.Errorf("%s: %w", method.Desc.FullName(), err)
+		}
+		if variants == nil {
+			continue
+		}
+		if config := GetMethodHTTPConfig(method); config != nil && (config.Stream || config.StreamResponse) {
+			return fmt.Errorf("%s: a result message cannot be streamed; remove stream or stream_response",
+				method.Desc.FullName())
+		}
+	}
+	return nil
+}
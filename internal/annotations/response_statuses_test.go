@@ -0,0 +1,69 @@
+package annotations
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// resultMessageFile builds the validateOneofFile messages with the content
+// oneof of Event annotated with statuses. Without the id field, Event is a
+// well-formed result message when statuses map text and image.
+func resultMessageFile(withID bool, statuses map[string]int32) *descriptorpb.FileDescriptorProto {
+	fd := validateOneofFile()
+	event := fd.GetMessageType()[2]
+	if !withID {
+		event.Field = event.GetField()[1:]
+	}
+	options := &descriptorpb.OneofOptions{}
+	proto.SetExtension(options, http.E_ResponseStatuses, &http.ResponseStatuses{Statuses: statuses})
+	event.GetOneofDecl()[0].Options = options
+	return fd
+}
+
+func TestGetResponseVariants(t *testing.T) {
+	plugin := buildValidatePlugin(t, resultMessageFile(false, map[string]int32{"text": 200, "image": 409}))
+	variants, err := GetResponseVariants(findValidateMessage(t, plugin, "Event"))
+	if err != nil {
+		t.Fatalf("GetResponseVariants: %v", err)
+	}
+	if len(variants) != 2 ||
+		variants[0].Field.Desc.Name() != "text" || variants[0].Status != 200 ||
+		variants[1].Field.Desc.Name() != "image" || variants[1].Status != 409 {
+		t.Errorf("variants = %+v, want text: 200 then image: 409", variants)
+	}
+
+	plain := buildValidatePlugin(t, validateOneofFile())
+	if variants, err = GetResponseVariants(findValidateMessage(t, plain, "Event")); variants != nil || err != nil {
+		t.Errorf("unannotated message: variants %+v, err %v, want neither", variants, err)
+	}
+}
+
+func TestGetResponseVariantsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		withID   bool
+		statuses map[string]int32
+		want     string
+	}{
+		{"field besides the oneof", true, map[string]int32{"text": 200, "image": 409}, "no fields besides the oneof"},
+		{"unmapped variant", false, map[string]int32{"text": 200}, "variant image has no status"},
+		{"unknown variant", false, map[string]int32{"text": 200, "image": 409, "video": 410}, "video is not a variant"},
+		{"duplicate status", false, map[string]int32{"text": 200, "image": 200}, "both map to status 200"},
+		{"status out of range", false, map[string]int32{"text": 200, "image": 600}, "outside 200-599"},
+		{"status without body", false, map[string]int32{"text": 200, "image": 204}, "cannot carry a response body"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := buildValidatePlugin(t, resultMessageFile(tt.withID, tt.statuses))
+			_, err := GetResponseVariants(findValidateMessage(t, plugin, "Event"))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("GetResponseVariants error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
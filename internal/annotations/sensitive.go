@@ -0,0 +1,70 @@
+package annotations
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// SensitiveValidationError represents an error in sensitive annotation validation.
+type SensitiveValidationError struct {
+	MessageName string
+	FieldName   string
+	Reason      string
+}
+
+func (e *SensitiveValidationError) Error() string {
+	return "invalid sensitive annotation on " + e.MessageName + "." + e.FieldName + ": " + e.Reason
+}
+
+// IsSensitiveField returns true if the field has sensitive=true annotation.
+func IsSensitiveField(field *protogen.Field) bool {
+	options := field.Desc.Options()
+	if options == nil {
+		return false
+	}
+
+	fieldOptions, ok := options.(*descriptorpb.FieldOptions)
+	if !ok {
+		return false
+	}
+
+	ext := proto.GetExtension(fieldOptions, http.E_Sensitive)
+	if ext == nil {
+		return false
+	}
+
+	sensitive, ok := ext.(bool)
+	return ok && sensitive
+}
+
+// SensitiveValueField returns the field holding a sensitive field's values: the
+// value field of a map entry, or the field itself.
+func SensitiveValueField(field *protogen.Field) *protogen.Field {
+	if field.Desc.IsMap() {
+		return field.Message.Fields[1]
+	}
+	return field
+}
+
+// ValidateSensitiveAnnotation checks if sensitive annotation is valid for a field.
+// Returns error if sensitive=true on a field whose values are not strings or bytes.
+func ValidateSensitiveAnnotation(field *protogen.Field, messageName string) error {
+	if !IsSensitiveField(field) {
+		return nil // No annotation, nothing to validate
+	}
+
+	kind := SensitiveValueField(field).Desc.Kind()
+	if kind != protoreflect.StringKind && kind != protoreflect.BytesKind {
+		return &SensitiveValidationError{
+			MessageName: messageName,
+			FieldName:   string(field.Desc.Name()),
+			Reason:      "sensitive annotation is only valid on string and bytes fields",
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package annotations
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// withSensitive marks a field descriptor as sensitive.
+func withSensitive(field *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	if field.Options == nil {
+		field.Options = &descriptorpb.FieldOptions{}
+	}
+	proto.SetExtension(field.Options, http.E_Sensitive, true)
+	return field
+}
+
+func TestSensitiveFields(t *testing.T) {
+	msg := sourceMethod(t,
+		withSensitive(withExamples(scalarField("password", 1), "hunter2")),
+		withSensitive(typedField("key", 2, descriptorpb.FieldDescriptorProto_TYPE_BYTES, "")),
+		withExamples(scalarField("username", 3), "alice"),
+	).Input
+
+	if !IsSensitiveField(msg.Fields[0]) || IsSensitiveField(msg.Fields[2]) {
+		t.Error("IsSensitiveField should only report annotated fields")
+	}
+	if got := ResolveExampleValue(msg.Fields[0]).String(); got != http.RedactedValue {
+		t.Errorf("sensitive string example = %q, want %q", got, http.RedactedValue)
+	}
+	if got := ResolveExampleValue(msg.Fields[1]).Bytes(); len(got) != 0 {
+		t.Errorf("sensitive bytes example = %q, want empty", got)
+	}
+	if got := ResolveExampleValue(msg.Fields[2]).String(); got != "alice" {
+		t.Errorf("non-sensitive example = %q, want alice", got)
+	}
+	for _, field := range msg.Fields {
+		if err := ValidateSensitiveAnnotation(field, "Req"); err != nil {
+			t.Errorf("ValidateSensitiveAnnotation(%s): %v", field.Desc.Name(), err)
+		}
+	}
+}
+
+func TestValidateSensitiveAnnotation_NonString(t *testing.T) {
+	field := sourceMethod(t,
+		withSensitive(typedField("pin", 1, descriptorpb.FieldDescriptorProto_TYPE_INT32, "")),
+	).Input.Fields[0]
+
+	err := ValidateSensitiveAnnotation(field, "Req")
+	if err == nil || !strings.Contains(err.Error(), "only valid on string and bytes fields") {
+		t.Errorf("error = %v, want a string and bytes error", err)
+	}
+}
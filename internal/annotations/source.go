@@ -0,0 +1,152 @@
+package annotations
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// HeaderFieldParam represents a request field read from a request header
+// (source = FIELD_SOURCE_HEADER).
+type HeaderFieldParam struct {
+	FieldName     string          // Proto field name (e.g., "tenant_id")
+	FieldGoName   string          // Go field name (e.g., "TenantId")
+	FieldJSONName string          // JSON field name / camelCase (e.g., "tenantId")
+	HeaderName    string          // Canonical header name (e.g., "Tenant-Id")
+	Field         *protogen.Field // Raw protogen field reference
+}
+
+// GetFieldSource returns the source declared by a field's sebuf.http.source
+// annotation, or FIELD_SOURCE_UNSPECIFIED when the field has none and keeps the
+// implicit binding (path variable, query annotation, or body).
+func GetFieldSource(field *protogen.Field) http.FieldSource {
+	options := field.Desc.Options()
+	if options == nil {
+		return http.FieldSource_FIELD_SOURCE_UNSPECIFIED
+	}
+
+	fieldOptions, ok := options.(*descriptorpb.FieldOptions)
+	if !ok {
+		return http.FieldSource_FIELD_SOURCE_UNSPECIFIED
+	}
+
+	if !proto.HasExtension(fieldOptions, http.E_Source) {
+		return http.FieldSource_FIELD_SOURCE_UNSPECIFIED
+	}
+
+	source, ok := proto.GetExtension(fieldOptions, http.E_Source).(http.FieldSource)
+	if !ok {
+		return http.FieldSource_FIELD_SOURCE_UNSPECIFIED
+	}
+	return source
+}
+
+// IsBodyExcluded returns true if the field declares a source other than the
+// body. Such fields are never read from or written to the request body.
+func IsBodyExcluded(field *protogen.Field) bool {
+	switch GetFieldSource(field) {
+	case http.FieldSource_FIELD_SOURCE_QUERY, http.FieldSource_FIELD_SOURCE_PATH, http.FieldSource_FIELD_SOURCE_HEADER:
+		return true
+	case http.FieldSource_FIELD_SOURCE_UNSPECIFIED, http.FieldSource_FIELD_SOURCE_BODY:
+		return false
+	}
+	return false
+}
+
+// GetBodyExcludedFields returns the fields of a message that declare a source
+// other than the body, in declaration order.
+func GetBodyExcludedFields(message *protogen.Message) []*protogen.Field {
+	var fields []*protogen.Field
+	for _, field := range message.Fields {
+		if IsBodyExcluded(field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// FieldHeaderName returns the header a HEADER-sourced field is read from: the
+// proto field name with underscores as hyphens, in canonical form
+// (x_tenant_id reads X-Tenant-Id).
+func FieldHeaderName(field *protogen.Field) string {
+	return CanonicalHeaderName(strings.ReplaceAll(string(field.Desc.Name()), "_", "-"))
+}
+
+// GetHeaderFieldParams returns the fields of a message declared with
+// source = FIELD_SOURCE_HEADER.
+func GetHeaderFieldParams(message *protogen.Message) []HeaderFieldParam {
+	var params []HeaderFieldParam
+	for _, field := range message.Fields {
+		if GetFieldSource(field) != http.FieldSource_FIELD_SOURCE_HEADER {
+			continue
+		}
+		params = append(params, HeaderFieldParam{
+			FieldName:     string(field.Desc.Name()),
+			FieldGoName:   field.GoName,
+			FieldJSONName: JSONFieldName(field),
+			HeaderName:    FieldHeaderName(field),
+			Field:         field,
+		})
+	}
+	return params
+}
+
+// ValidateFieldSources validates the source annotations of a method's request
+// fields against the method's path variables. Oneof members can only use the
+// body, PATH fields must be named in the path, path variables cannot be
+// declared with another source, HEADER fields must be singular strings, and a
+// query annotation requires the QUERY source.
+func ValidateFieldSources(method *protogen.Method, pathParams []string) error {
+	msgName := method.Input.Desc.Name()
+	for _, field := range method.Input.Fields {
+		source := GetFieldSource(field)
+		if source == http.FieldSource_FIELD_SOURCE_UNSPECIFIED {
+			continue
+		}
+		name := string(field.Desc.Name())
+		inPath := slices.Contains(pathParams, name)
+
+		switch {
+		case source != http.FieldSource_FIELD_SOURCE_BODY && field.Oneof != nil && !field.Oneof.Desc.IsSynthetic():
+			return fmt.Errorf(
+				"field %s.%s: oneof members can only be read from the body",
+				msgName, name,
+			)
+		case source == http.FieldSource_FIELD_SOURCE_PATH && !inPath:
+			return fmt.Errorf(
+				"field %s.%s: source is PATH but the method path has no '{%s}' variable",
+				msgName, name, name,
+			)
+		case source != http.FieldSource_FIELD_SOURCE_PATH && inPath:
+			return fmt.Errorf(
+				"field %s.%s: bound to a path variable but its source is %s",
+				msgName, name, source,
+			)
+		case source == http.FieldSource_FIELD_SOURCE_HEADER &&
+			(field.Desc.Kind() != protoreflect.StringKind || field.Desc.IsList()):
+			return fmt.Errorf(
+				"field %s.%s: source HEADER is only valid on singular string fields",
+				msgName, name,
+			)
+		case source != http.FieldSource_FIELD_SOURCE_QUERY && hasQueryAnnotation(field):
+			return fmt.Errorf(
+				"field %s.%s: has a query annotation but its source is %s",
+				msgName, name, source,
+			)
+		}
+	}
+	return nil
+}
+
+// hasQueryAnnotation returns true if the field carries a sebuf.http.query annotation.
+func hasQueryAnnotation(field *protogen.Field) bool {
+	fieldOptions, ok := field.Desc.Options().(*descriptorpb.FieldOptions)
+	return ok && fieldOptions != nil && proto.HasExtension(fieldOptions, http.E_Query)
+}
@@ -0,0 +1,142 @@
+package annotations
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// withSource attaches a source annotation to a field descriptor.
+func withSource(field *descriptorpb.FieldDescriptorProto, source http.FieldSource) *descriptorpb.FieldDescriptorProto {
+	if field.Options == nil {
+		field.Options = &descriptorpb.FieldOptions{}
+	}
+	proto.SetExtension(field.Options, http.E_Source, source)
+	return field
+}
+
+// withQuery attaches a query annotation to a field descriptor.
+func withQuery(field *descriptorpb.FieldDescriptorProto, name string) *descriptorpb.FieldDescriptorProto {
+	if field.Options == nil {
+		field.Options = &descriptorpb.FieldOptions{}
+	}
+	proto.SetExtension(field.Options, http.E_Query, &http.QueryConfig{Name: name})
+	return field
+}
+
+// sourceMethod builds a Do(Req) method whose request carries fields.
+func sourceMethod(t *testing.T, fields ...*descriptorpb.FieldDescriptorProto) *protogen.Method {
+	t.Helper()
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("source.proto"),
+		Package: proto.String(validateTestPkg),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{
+			GoPackage: proto.String("github.com/SebastienMelki/sebuf/internal/annotations/validatev1"),
+		},
+		MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("Req"), Field: fields}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Svc"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Do"),
+				InputType:  proto.String("." + validateTestPkg + ".Req"),
+				OutputType: proto.String("." + validateTestPkg + ".Req"),
+			}},
+		}},
+	}
+	return buildValidatePlugin(t, fd).Files[0].Services[0].Methods[0]
+}
+
+func TestFieldSourceParams(t *testing.T) {
+	method := sourceMethod(t,
+		scalarField("note", 1),
+		withSource(scalarField("page", 2), http.FieldSource_FIELD_SOURCE_QUERY),
+		withSource(withQuery(scalarField("sort", 3), "order_by"), http.FieldSource_FIELD_SOURCE_QUERY),
+		withSource(scalarField("x_tenant_id", 4), http.FieldSource_FIELD_SOURCE_HEADER),
+		withSource(scalarField("id", 5), http.FieldSource_FIELD_SOURCE_PATH),
+	)
+	msg := method.Input
+
+	var queryNames []string
+	for _, qp := range GetQueryParams(msg) {
+		queryNames = append(queryNames, qp.ParamName)
+	}
+	if got := strings.Join(queryNames, ","); got != "page,order_by" {
+		t.Errorf("query params = %q, want page,order_by", got)
+	}
+
+	headers := GetHeaderFieldParams(msg)
+	if len(headers) != 1 || headers[0].HeaderName != "X-Tenant-Id" {
+		t.Errorf("header params = %+v, want one X-Tenant-Id", headers)
+	}
+
+	var excluded []string
+	for _, field := range GetBodyExcludedFields(msg) {
+		excluded = append(excluded, string(field.Desc.Name()))
+	}
+	if got := strings.Join(excluded, ","); got != "page,sort,x_tenant_id,id" {
+		t.Errorf("body-excluded fields = %q", got)
+	}
+
+	if err := ValidateFieldSources(method, []string{"id"}); err != nil {
+		t.Errorf("ValidateFieldSources: %v", err)
+	}
+}
+
+func TestValidateFieldSources(t *testing.T) {
+	tests := []struct {
+		name       string
+		field      *descriptorpb.FieldDescriptorProto
+		pathParams []string
+		wantErr    string
+	}{
+		{
+			name:    "path source not in path",
+			field:   withSource(scalarField("id", 1), http.FieldSource_FIELD_SOURCE_PATH),
+			wantErr: "has no '{id}' variable",
+		},
+		{
+			name:       "path variable declared as query",
+			field:      withSource(scalarField("id", 1), http.FieldSource_FIELD_SOURCE_QUERY),
+			pathParams: []string{"id"},
+			wantErr:    "bound to a path variable",
+		},
+		{
+			name: "header on non-string field",
+			field: withSource(
+				typedField("count", 1, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""),
+				http.FieldSource_FIELD_SOURCE_HEADER,
+			),
+			wantErr: "singular string fields",
+		},
+		{
+			name:    "query annotation with body source",
+			field:   withSource(withQuery(scalarField("page", 1), ""), http.FieldSource_FIELD_SOURCE_BODY),
+			wantErr: "has a query annotation",
+		},
+		{
+			name:       "unannotated path variable",
+			field:      scalarField("id", 1),
+			pathParams: []string{"id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFieldSources(sourceMethod(t, tt.field), tt.pathParams)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package annotations
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// IsStreamResponse reports whether a method is annotated with stream_response.
+func IsStreamResponse(method *protogen.Method) bool {
+	config := GetMethodHTTPConfig(method)
+	return config != nil && config.StreamResponse
+}
+
+// StreamResponseField returns the repeated message field holding the items a
+// stream_response method writes as they are produced: the only field of its
+// response message. The response JSON is that field's array when the field is
+// unwrapped, otherwise an object with that field as its only key.
+func StreamResponseField(method *protogen.Method) (*protogen.Field, error) {
+	output := method.Output
+	if len(output.Fields) != 1 {
+		return nil, fmt.Errorf(
+			"stream_response requires the response message %s to have a single repeated message field, "+
+				"but it has %d fields", output.Desc.Name(), len(output.Fields))
+	}
+	field := output.Fields[0]
+	if !field.Desc.IsList() || field.Desc.Kind() != protoreflect.MessageKind {
+		return nil, fmt.Errorf(
+			"stream_response requires the field %s of the response message %s to be a repeated message field",
+			field.Desc.Name(), output.Desc.Name())
+	}
+	return field, nil
+}
+
+// ValidateStreamResponses checks the response shape of the stream_response
+// methods of service, for the client generators, which do not run the server's
+// validation but need the item type.
+func ValidateStreamResponses(service *protogen.Service) error {
+	for _, method := range service.Methods {
+		if !IsStreamResponse(method) {
+			continue
+		}
+		if _, err := StreamResponseField(method); err != nil {
+			return fmt.Errorf("%s: %w", method.Desc.FullName(), err)
+		}
+	}
+	return nil
+}
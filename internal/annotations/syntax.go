@@ -0,0 +1,121 @@
+package annotations
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// MaxSupportedEdition is the newest edition the generators support. proto2 and
+// proto3 files are supported too.
+const MaxSupportedEdition = descriptorpb.Edition_EDITION_2023
+
+// ValidateSyntax checks the files plugin generates, and the messages they
+// reach, for protobuf language features the generators do not support: an
+// edition newer than MaxSupportedEdition, and group fields, declared with the
+// proto2 group syntax or features.message_encoding = DELIMITED, whose JSON and
+// HTTP mapping sebuf does not define. The error names the file, the field and
+// the feature.
+func ValidateSyntax(plugin *protogen.Plugin) error {
+	seen := make(map[*protogen.Message]bool)
+	for _, file := range plugin.Files {
+		if !file.Generate {
+			continue
+		}
+		if file.Proto.GetSyntax() == "editions" && file.Proto.GetEdition() > MaxSupportedEdition {
+			return fmt.Errorf("%s: edition %s is not supported; sebuf supports proto2, proto3 and editions up to %s",
+				file.Desc.Path(), editionName(file.Proto.GetEdition()), editionName(MaxSupportedEdition))
+		}
+		for _, message := range file.Messages {
+			if err := validateMessageSyntax(message, seen); err != nil {
+				return err
+			}
+		}
+		for _, service := range file.Services {
+			for _, method := range service.Methods {
+				if err := validateMessageSyntax(method.Input, seen); err != nil {
+					return err
+				}
+				if err := validateMessageSyntax(method.Output, seen); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateMessageSyntax checks message, its nested messages and the messages
+// its fields hold, skipping those already in seen.
+func validateMessageSyntax(message *protogen.Message, seen map[*protogen.Message]bool) error {
+	if message == nil || seen[message] {
+		return nil
+	}
+	seen[message] = true
+	for _, field := range message.Fields {
+		if field.Desc.Kind() == protoreflect.GroupKind {
+			return groupFieldError(field)
+		}
+		if err := validateMessageSyntax(field.Message, seen); err != nil {
+			return err
+		}
+	}
+	for _, nested := range message.Messages {
+		if err := validateMessageSyntax(nested, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// groupFieldError returns the error for the group field, named after the way
+// its file declares it.
+func groupFieldError(field *protogen.Field) error {
+	file := field.Desc.ParentFile()
+	if file.Syntax() == protoreflect.Editions {
+		return fmt.Errorf("%s: field %s sets features.message_encoding = DELIMITED, which sebuf does not support; "+
+			"use the default LENGTH_PREFIXED encoding", file.Path(), field.Desc.FullName())
+	}
+	return fmt.Errorf("%s: field %s is a proto2 group, which sebuf does not support; "+
+		"declare a message and a field of it instead", file.Path(), field.Desc.FullName())
+}
+
+// editionName returns the name edition is written with in a .proto file,
+// such as 2023.
+func editionName(edition descriptorpb.Edition) string {
+	return strings.TrimPrefix(edition.String(), "EDITION_")
+}
+
+// HasExplicitPresence reports whether the singular scalar or enum field,
+// outside a oneof, records whether it is set: a proto3 optional field, a
+// proto2 optional or required field, or a field of an editions file with
+// explicit presence, the edition 2023 default. Its Go struct field is a
+// pointer, and it is left out of JSON when unset.
+func HasExplicitPresence(field *protogen.Field) bool {
+	desc := field.Desc
+	if desc.IsList() || desc.Message() != nil {
+		return false
+	}
+	// A proto3 optional field sits in a synthetic oneof
+	if desc.HasOptionalKeyword() {
+		return true
+	}
+	return desc.HasPresence() && desc.ContainingOneof() == nil
+}
+
+// IsRequiredField reports whether the field is a proto2 required field, or a
+// field of an editions file with features.field_presence = LEGACY_REQUIRED,
+// which every message must set.
+func IsRequiredField(field *protogen.Field) bool {
+	return field.Desc.Cardinality() == protoreflect.Required
+}
+
+// IsOptionalScalarField reports whether the singular scalar or enum field may
+// be left unset, so that it has no value rather than its zero value: it has
+// explicit presence and is not required.
+func IsOptionalScalarField(field *protogen.Field) bool {
+	return HasExplicitPresence(field) && !IsRequiredField(field)
+}
@@ -0,0 +1,173 @@
+package annotations
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// syntaxFile builds a file of syntax ("proto2", "proto3" or "editions" of
+// edition) with an Item message holding fields.
+func syntaxFile(
+	syntax string,
+	edition descriptorpb.Edition,
+	fields ...*descriptorpb.FieldDescriptorProto,
+) *descriptorpb.FileDescriptorProto {
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("syntax.proto"),
+		Package: proto.String(validateTestPkg),
+		Syntax:  proto.String(syntax),
+		Options: &descriptorpb.FileOptions{
+			GoPackage: proto.String("github.com/SebastienMelki/sebuf/internal/annotations/validatev1"),
+		},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name:  proto.String("Item"),
+			Field: fields,
+		}},
+	}
+	if syntax == "editions" {
+		fd.Edition = edition.Enum()
+	}
+	return fd
+}
+
+// withLabel sets the label of a field descriptor.
+func withLabel(
+	field *descriptorpb.FieldDescriptorProto,
+	label descriptorpb.FieldDescriptorProto_Label,
+) *descriptorpb.FieldDescriptorProto {
+	field.Label = label.Enum()
+	return field
+}
+
+// withPresence sets features.field_presence on a field descriptor.
+func withPresence(
+	field *descriptorpb.FieldDescriptorProto,
+	presence descriptorpb.FeatureSet_FieldPresence,
+) *descriptorpb.FieldDescriptorProto {
+	field.Options = &descriptorpb.FieldOptions{
+		Features: &descriptorpb.FeatureSet{FieldPresence: presence.Enum()},
+	}
+	return field
+}
+
+// syntaxField returns the Item field named name of plugin.
+func syntaxField(t *testing.T, plugin *protogen.Plugin, name string) *protogen.Field {
+	t.Helper()
+	for _, field := range findValidateMessage(t, plugin, "Item").Fields {
+		if string(field.Desc.Name()) == name {
+			return field
+		}
+	}
+	t.Fatalf("field %q not found in Item", name)
+	return nil
+}
+
+func TestValidateSyntax(t *testing.T) {
+	group := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("data"),
+		Number:   proto.Int32(2),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     descriptorpb.FieldDescriptorProto_TYPE_GROUP.Enum(),
+		TypeName: proto.String("." + validateTestPkg + ".Item.Data"),
+	}
+	withGroup := func(fd *descriptorpb.FileDescriptorProto) *descriptorpb.FileDescriptorProto {
+		fd.MessageType[0].NestedType = []*descriptorpb.DescriptorProto{{
+			Name:  proto.String("Data"),
+			Field: []*descriptorpb.FieldDescriptorProto{scalarField("value", 1)},
+		}}
+		return fd
+	}
+	tests := []struct {
+		name    string
+		fd      *descriptorpb.FileDescriptorProto
+		wantErr string
+	}{
+		{
+			name: "proto2",
+			fd: syntaxFile("proto2", 0,
+				withLabel(scalarField("name", 1), descriptorpb.FieldDescriptorProto_LABEL_REQUIRED)),
+		},
+		{
+			name: "edition 2023",
+			fd:   syntaxFile("editions", descriptorpb.Edition_EDITION_2023, scalarField("name", 1)),
+		},
+		{
+			name: "edition 2024",
+			fd:   syntaxFile("editions", descriptorpb.Edition_EDITION_2024, scalarField("name", 1)),
+			wantErr: "syntax.proto: edition 2024 is not supported; " +
+				"sebuf supports proto2, proto3 and editions up to 2023",
+		},
+		{
+			name:    "proto2 group",
+			fd:      withGroup(syntaxFile("proto2", 0, scalarField("name", 1), group)),
+			wantErr: "syntax.proto: field test.validate.v1.Item.data is a proto2 group",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSyntax(buildValidatePlugin(t, tt.fd))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateSyntax: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateSyntax error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFieldPresence(t *testing.T) {
+	optionalKeyword := scalarField("nickname", 2)
+	optionalKeyword.Proto3Optional = proto.Bool(true)
+	optionalKeyword.OneofIndex = proto.Int32(0)
+	proto3 := syntaxFile("proto3", 0, scalarField("name", 1), optionalKeyword)
+	proto3.MessageType[0].OneofDecl = []*descriptorpb.OneofDescriptorProto{{Name: proto.String("_nickname")}}
+
+	files := map[string]*descriptorpb.FileDescriptorProto{
+		"proto3": proto3,
+		"proto2": syntaxFile("proto2", 0,
+			withLabel(scalarField("name", 1), descriptorpb.FieldDescriptorProto_LABEL_REQUIRED),
+			scalarField("nickname", 2),
+			withLabel(scalarField("tags", 3), descriptorpb.FieldDescriptorProto_LABEL_REPEATED)),
+		"editions": syntaxFile("editions", descriptorpb.Edition_EDITION_2023,
+			withPresence(scalarField("name", 1), descriptorpb.FeatureSet_LEGACY_REQUIRED),
+			scalarField("nickname", 2),
+			withPresence(scalarField("body", 3), descriptorpb.FeatureSet_IMPLICIT)),
+	}
+	tests := []struct {
+		file         string
+		field        string
+		wantPresence bool
+		wantRequired bool
+	}{
+		{file: "proto3", field: "name"},
+		{file: "proto3", field: "nickname", wantPresence: true},
+		{file: "proto2", field: "name", wantPresence: true, wantRequired: true},
+		{file: "proto2", field: "nickname", wantPresence: true},
+		{file: "proto2", field: "tags"},
+		{file: "editions", field: "name", wantPresence: true, wantRequired: true},
+		{file: "editions", field: "nickname", wantPresence: true},
+		{file: "editions", field: "body"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file+"/"+tt.field, func(t *testing.T) {
+			field := syntaxField(t, buildValidatePlugin(t, files[tt.file]), tt.field)
+			if got := HasExplicitPresence(field); got != tt.wantPresence {
+				t.Errorf("HasExplicitPresence = %v, want %v", got, tt.wantPresence)
+			}
+			if got := IsRequiredField(field); got != tt.wantRequired {
+				t.Errorf("IsRequiredField = %v, want %v", got, tt.wantRequired)
+			}
+			if got := IsOptionalScalarField(field); got != (tt.wantPresence && !tt.wantRequired) {
+				t.Errorf("IsOptionalScalarField = %v, want %v", got, tt.wantPresence && !tt.wantRequired)
+			}
+		})
+	}
+}
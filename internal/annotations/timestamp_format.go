@@ -46,10 +46,11 @@ func GetTimestampFormat(field *protogen.Field) http.TimestampFormat {
 	return format
 }
 
-// HasTimestampFormatAnnotation returns true if the field has any non-default timestamp_format.
+// HasTimestampFormatAnnotation returns true if the field has any non-default timestamp_format,
+// by its own annotation or a scoped default (see ResolveTimestampFormat).
 // Returns false for UNSPECIFIED and RFC3339 (both use protojson default behavior).
 func HasTimestampFormatAnnotation(field *protogen.Field) bool {
-	format := GetTimestampFormat(field)
+	format := ResolveTimestampFormat(field)
 	return format != http.TimestampFormat_TIMESTAMP_FORMAT_UNSPECIFIED &&
 		format != http.TimestampFormat_TIMESTAMP_FORMAT_RFC3339
 }
@@ -0,0 +1,79 @@
+package annotations
+
+import (
+	"slices"
+
+	validate "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// HasValidationRules reports whether a message, or any message reachable through
+// its fields, carries buf.validate message, oneof or field rules.
+func HasValidationRules(desc protoreflect.MessageDescriptor) bool {
+	return hasValidationRules(desc, make(map[protoreflect.FullName]bool))
+}
+
+// hasValidationRules implements HasValidationRules. Messages in visited have
+// already been inspected, so recursive messages terminate.
+func hasValidationRules(desc protoreflect.MessageDescriptor, visited map[protoreflect.FullName]bool) bool {
+	if visited[desc.FullName()] {
+		return false
+	}
+	visited[desc.FullName()] = true
+
+	if proto.HasExtension(desc.Options(), validate.E_Message) {
+		return true
+	}
+	oneofs := desc.Oneofs()
+	for i := range oneofs.Len() {
+		if proto.HasExtension(oneofs.Get(i).Options(), validate.E_Oneof) {
+			return true
+		}
+	}
+	fields := desc.Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		if proto.HasExtension(field.Options(), validate.E_Field) {
+			return true
+		}
+		// Map fields recurse into their entry message, covering map values
+		if field.Message() != nil && hasValidationRules(field.Message(), visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetFieldValidationRules returns the buf.validate rules of a field, or nil.
+func GetFieldValidationRules(field protoreflect.FieldDescriptor) *validate.FieldRules {
+	rules, ok := proto.GetExtension(field.Options(), validate.E_Field).(*validate.FieldRules)
+	if !ok {
+		return nil
+	}
+	return rules
+}
+
+// HasRequiredBodyFields reports whether a request message has a field bound
+// from the body that must be set: a proto2 required field, a field with the
+// buf.validate required rule, or a member of a oneof with the required rule.
+// Fields declared with another source and the method's path variables
+// (pathParams) are not bound from the body.
+func HasRequiredBodyFields(message *protogen.Message, pathParams []string) bool {
+	for _, field := range message.Fields {
+		if IsBodyExcluded(field) || slices.Contains(pathParams, string(field.Desc.Name())) {
+			continue
+		}
+		if field.Desc.Cardinality() == protoreflect.Required || GetFieldValidationRules(field.Desc).GetRequired() {
+			return true
+		}
+		if field.Oneof != nil {
+			rules, ok := proto.GetExtension(field.Oneof.Desc.Options(), validate.E_Oneof).(*validate.OneofRules)
+			if ok && rules.GetRequired() {
+				return true
+			}
+		}
+	}
+	return false
+}
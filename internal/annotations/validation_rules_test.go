@@ -0,0 +1,205 @@
+package annotations
+
+import (
+	"testing"
+
+	validate "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// withRules attaches buf.validate field rules to a field descriptor.
+func withRules(
+	field *descriptorpb.FieldDescriptorProto,
+	rules *validate.FieldRules,
+) *descriptorpb.FieldDescriptorProto {
+	if field.Options == nil {
+		field.Options = &descriptorpb.FieldOptions{}
+	}
+	proto.SetExtension(field.Options, validate.E_Field, rules)
+	return field
+}
+
+// minLen returns string rules requiring at least n characters.
+func minLen(n uint64) *validate.FieldRules {
+	return &validate.FieldRules{
+		Type: &validate.FieldRules_String_{String_: &validate.StringRules{MinLen: proto.Uint64(n)}},
+	}
+}
+
+// required returns the buf.validate required rule.
+func required() *validate.FieldRules {
+	return &validate.FieldRules{Required: proto.Bool(true)}
+}
+
+// msgField builds a message-typed field descriptor of the given label.
+func msgField(
+	name string,
+	number int32,
+	msgName string,
+	label descriptorpb.FieldDescriptorProto_Label,
+) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    label.Enum(),
+		Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+		TypeName: proto.String("." + validateTestPkg + "." + msgName),
+		JsonName: proto.String(validateJSONName(name)),
+	}
+}
+
+// validationRulesFile builds a proto3 file whose messages carry buf.validate
+// rules at different depths.
+func validationRulesFile() *descriptorpb.FileDescriptorProto {
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	repeated := descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+
+	oneofRules := &descriptorpb.OneofOptions{}
+	proto.SetExtension(oneofRules, validate.E_Oneof, &validate.OneofRules{Required: proto.Bool(true)})
+
+	labelsEntry := &descriptorpb.DescriptorProto{
+		Name: proto.String("LabelsEntry"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			scalarField("key", 1),
+			msgField("value", 2, "Leaf", optional),
+		},
+		Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+	}
+	labelsField := msgField("labels", 1, "MapValues.LabelsEntry", repeated)
+
+	contact := scalarField("email", 1)
+	contact.OneofIndex = proto.Int32(0)
+
+	messages := []*descriptorpb.DescriptorProto{
+		{Name: proto.String("Plain"), Field: []*descriptorpb.FieldDescriptorProto{scalarField("name", 1)}},
+		{Name: proto.String("Leaf"), Field: []*descriptorpb.FieldDescriptorProto{
+			withRules(scalarField("code", 1), minLen(2)),
+		}},
+		{Name: proto.String("Direct"), Field: []*descriptorpb.FieldDescriptorProto{
+			withRules(scalarField("name", 1), minLen(3)),
+		}},
+		{Name: proto.String("Nested"), Field: []*descriptorpb.FieldDescriptorProto{
+			scalarField("name", 1),
+			msgField("leaf", 2, "Leaf", optional),
+		}},
+		{Name: proto.String("DeeplyNested"), Field: []*descriptorpb.FieldDescriptorProto{
+			msgField("items", 1, "Nested", repeated),
+		}},
+		{
+			Name:       proto.String("MapValues"),
+			Field:      []*descriptorpb.FieldDescriptorProto{labelsField},
+			NestedType: []*descriptorpb.DescriptorProto{labelsEntry},
+		},
+		{Name: proto.String("Node"), Field: []*descriptorpb.FieldDescriptorProto{
+			scalarField("name", 1),
+			msgField("children", 2, "Node", repeated),
+		}},
+		{Name: proto.String("RecursiveLeaf"), Field: []*descriptorpb.FieldDescriptorProto{
+			msgField("self", 1, "RecursiveLeaf", optional),
+			msgField("leaf", 2, "Leaf", optional),
+		}},
+		{
+			Name:      proto.String("RequiredOneof"),
+			Field:     []*descriptorpb.FieldDescriptorProto{contact},
+			OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("contact"), Options: oneofRules}},
+		},
+	}
+
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("validation_rules.proto"),
+		Package: proto.String(validateTestPkg),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{
+			GoPackage: proto.String("github.com/SebastienMelki/sebuf/internal/annotations/validatev1"),
+		},
+		MessageType: messages,
+	}
+}
+
+func TestHasValidationRules(t *testing.T) {
+	plugin := buildValidatePlugin(t, validationRulesFile())
+
+	tests := []struct {
+		message string
+		want    bool
+	}{
+		{"Plain", false},
+		{"Direct", true},
+		{"Nested", true},       // Rules only on a nested message
+		{"DeeplyNested", true}, // Rules two levels down, through a repeated field
+		{"MapValues", true},    // Rules only on the map's value message
+		{"Node", false},        // Recursion without rules terminates
+		{"RecursiveLeaf", true},
+		{"RequiredOneof", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			msg := findValidateMessage(t, plugin, tt.message)
+			if got := HasValidationRules(msg.Desc); got != tt.want {
+				t.Errorf("HasValidationRules(%s) = %v, want %v", tt.message, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasRequiredBodyFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		fields     []*descriptorpb.FieldDescriptorProto
+		pathParams []string
+		want       bool
+	}{
+		{
+			name:   "no rules",
+			fields: []*descriptorpb.FieldDescriptorProto{scalarField("note", 1)},
+		},
+		{
+			name:   "rules without required",
+			fields: []*descriptorpb.FieldDescriptorProto{withRules(scalarField("note", 1), minLen(3))},
+		},
+		{
+			name:   "required body field",
+			fields: []*descriptorpb.FieldDescriptorProto{withRules(scalarField("note", 1), required())},
+			want:   true,
+		},
+		{
+			name:       "required path variable",
+			fields:     []*descriptorpb.FieldDescriptorProto{withRules(scalarField("id", 1), required())},
+			pathParams: []string{"id"},
+		},
+		{
+			name: "required header field",
+			fields: []*descriptorpb.FieldDescriptorProto{
+				withSource(withRules(scalarField("x_tenant_id", 1), required()), http.FieldSource_FIELD_SOURCE_HEADER),
+			},
+		},
+		{
+			name: "required query field",
+			fields: []*descriptorpb.FieldDescriptorProto{
+				withSource(withRules(scalarField("page", 1), required()), http.FieldSource_FIELD_SOURCE_QUERY),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := sourceMethod(t, tt.fields...)
+			if got := HasRequiredBodyFields(method.Input, tt.pathParams); got != tt.want {
+				t.Errorf("HasRequiredBodyFields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	plugin := buildValidatePlugin(t, validationRulesFile())
+	for name, want := range map[string]bool{
+		"RequiredOneof": true,  // A oneof with the required rule needs one of its members
+		"Nested":        false, // Rules on a nested message are not required fields
+	} {
+		msg := findValidateMessage(t, plugin, name)
+		if got := HasRequiredBodyFields(msg, nil); got != want {
+			t.Errorf("HasRequiredBodyFields(%s) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package annotations
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+const (
+	// sunsetDateLayout is the layout of ApiVersion.sunset.
+	sunsetDateLayout = "2006-01-02"
+	// httpDateLayout is the HTTP-date layout (net/http's TimeFormat).
+	httpDateLayout = "Mon, 02 Jan 2006 15:04:05 GMT"
+)
+
+// APIVersion represents one base path a versioned service is served under.
+type APIVersion struct {
+	Name       string // Name clients select the version by (e.g., "v2")
+	BasePath   string // Base path without a trailing slash (e.g., "/api/v2")
+	Deprecated bool   // When true, responses carry a Deprecation header
+	Sunset     string // Sunset date as YYYY-MM-DD, empty when unset
+}
+
+// SunsetHTTPDate returns the sunset date formatted as an HTTP-date for the
+// Sunset header (RFC 8594), or "" when the version has no valid sunset date.
+func (v APIVersion) SunsetHTTPDate() string {
+	if v.Sunset == "" {
+		return ""
+	}
+	date, err := time.Parse(sunsetDateLayout, v.Sunset)
+	if err != nil {
+		return ""
+	}
+	return date.UTC().Format(httpDateLayout)
+}
+
+// GetServiceVersions returns the API versions of a service, oldest first, or
+// nil when the service is not versioned.
+func GetServiceVersions(service *protogen.Service) []APIVersion {
+	serviceConfig := getServiceConfig(service)
+	if serviceConfig == nil || len(serviceConfig.GetVersions()) == 0 {
+		return nil
+	}
+
+	versions := make([]APIVersion, 0, len(serviceConfig.GetVersions()))
+	for _, v := range serviceConfig.GetVersions() {
+		basePath := strings.TrimSuffix(v.GetBasePath(), "/")
+		if basePath != "" {
+			basePath = EnsureLeadingSlash(basePath)
+		}
+		name := v.GetName()
+		if name == "" {
+			name = basePath[strings.LastIndex(basePath, "/")+1:]
+		}
+		versions = append(versions, APIVersion{
+			Name:       name,
+			BasePath:   basePath,
+			Deprecated: v.GetDeprecated(),
+			Sunset:     v.GetSunset(),
+		})
+	}
+	return versions
+}
+
+// DefaultAPIVersion returns the version clients call by default: the newest
+// non-deprecated one, or the newest one when all are deprecated. It returns
+// nil when versions is empty.
+func DefaultAPIVersion(versions []APIVersion) *APIVersion {
+	if len(versions) == 0 {
+		return nil
+	}
+	for i := len(versions) - 1; i >= 0; i-- {
+		if !versions[i].Deprecated {
+			return &versions[i]
+		}
+	}
+	return &versions[len(versions)-1]
+}
+
+// ValidateServiceVersions checks the versions annotation of a service: it must
+// not be combined with base_path, every version needs a distinct base path and
+// name, sunset dates must be valid and only set on deprecated versions, and no
+// method path may already include a version's base path, which would bypass
+// versioning.
+func ValidateServiceVersions(service *protogen.Service) error {
+	versions := GetServiceVersions(service)
+	if len(versions) == 0 {
+		return nil
+	}
+	if getServiceConfig(service).GetBasePath() != "" {
+		return errors.New("versions and base_path are mutually exclusive. " +
+			"Move the base path into each version's base_path")
+	}
+
+	names := make(map[string]bool, len(versions))
+	basePaths := make(map[string]bool, len(versions))
+	for _, v := range versions {
+		if v.BasePath == "" || v.BasePath == "/" {
+			return fmt.Errorf("version %q has no base_path. Every version needs its own base path", v.Name)
+		}
+		if basePaths[v.BasePath] {
+			return fmt.Errorf("base_path %q is used by more than one version", v.BasePath)
+		}
+		if names[v.Name] {
+			return fmt.Errorf("version name %q is used by more than one version. Set a distinct name", v.Name)
+		}
+		basePaths[v.BasePath] = true
+		names[v.Name] = true
+
+		if v.Sunset == "" {
+			continue
+		}
+		if _, err := time.Parse(sunsetDateLayout, v.Sunset); err != nil {
+			return fmt.Errorf("version %q has an invalid sunset %q. Use a YYYY-MM-DD date", v.Name, v.Sunset)
+		}
+		if !v.Deprecated {
+			return fmt.Errorf("version %q has a sunset but is not deprecated. Add deprecated: true", v.Name)
+		}
+	}
+
+	for _, method := range service.Methods {
+		config := GetMethodHTTPConfig(method)
+		if config == nil || config.Path == "" {
+			continue
+		}
+		path := EnsureLeadingSlash(config.Path)
+		for _, v := range versions {
+			if path == v.BasePath || strings.HasPrefix(path, v.BasePath+"/") {
+				return fmt.Errorf("%s: path %q already includes the base path of version %q, which bypasses "+
+					"versioning. Method paths of versioned services are relative to each version's base path",
+					method.Desc.Name(), config.Path, v.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// getServiceConfig returns the sebuf.http.service_config annotation of a
+// service, or nil.
+func getServiceConfig(service *protogen.Service) *http.ServiceConfig {
+	options := service.Desc.Options()
+	if options == nil {
+		return nil
+	}
+
+	serviceOptions, ok := options.(*descriptorpb.ServiceOptions)
+	if !ok {
+		return nil
+	}
+
+	ext := proto.GetExtension(serviceOptions, http.E_ServiceConfig)
+	if ext == nil {
+		return nil
+	}
+
+	serviceConfig, ok := ext.(*http.ServiceConfig)
+	if !ok || serviceConfig == nil {
+		return nil
+	}
+	return serviceConfig
+}
@@ -0,0 +1,46 @@
+package annotations
+
+import "testing"
+
+func TestDefaultAPIVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []APIVersion
+		want     string
+	}{
+		{name: "unversioned", versions: nil, want: ""},
+		{
+			name:     "newest non-deprecated",
+			versions: []APIVersion{{Name: "v1"}, {Name: "v2"}, {Name: "v3", Deprecated: true}},
+			want:     "v2",
+		},
+		{
+			name:     "all deprecated",
+			versions: []APIVersion{{Name: "v1", Deprecated: true}, {Name: "v2", Deprecated: true}},
+			want:     "v2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DefaultAPIVersion(tt.versions)
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("DefaultAPIVersion() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Name != tt.want {
+				t.Errorf("DefaultAPIVersion() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIVersionSunsetHTTPDate(t *testing.T) {
+	if got := (APIVersion{Sunset: "2026-01-01"}).SunsetHTTPDate(); got != "Thu, 01 Jan 2026 00:00:00 GMT" {
+		t.Errorf("SunsetHTTPDate() = %q", got)
+	}
+	if got := (APIVersion{}).SunsetHTTPDate(); got != "" {
+		t.Errorf("SunsetHTTPDate() without sunset = %q, want empty", got)
+	}
+}
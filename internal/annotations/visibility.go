@@ -0,0 +1,112 @@
+package annotations
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// Generator names, as listed in visibility annotations.
+const (
+	GeneratorGoHTTP    = "go-http"
+	GeneratorGoClient  = "go-client"
+	GeneratorOpenAPIv3 = "openapiv3"
+	GeneratorTSClient  = "ts-client"
+	GeneratorTSServer  = "ts-server"
+	GeneratorPyClient  = "py-client"
+	GeneratorKtClient  = "kt-client"
+)
+
+// generatorNames lists the generators a visibility annotation may name.
+var generatorNames = []string{
+	GeneratorGoHTTP, GeneratorGoClient, GeneratorOpenAPIv3, GeneratorTSClient, GeneratorTSServer, GeneratorPyClient,
+	GeneratorKtClient,
+}
+
+// getServiceVisibility returns the service_visibility annotation of a service,
+// or nil if it is not annotated.
+func getServiceVisibility(service *protogen.Service) *http.Visibility {
+	serviceOptions, ok := service.Desc.Options().(*descriptorpb.ServiceOptions)
+	if !ok || serviceOptions == nil || !proto.HasExtension(serviceOptions, http.E_ServiceVisibility) {
+		return nil
+	}
+	visibility, _ := proto.GetExtension(serviceOptions, http.E_ServiceVisibility).(*http.Visibility)
+	return visibility
+}
+
+// getMethodVisibility returns the visibility annotation of a method, or nil if
+// it is not annotated.
+func getMethodVisibility(method *protogen.Method) *http.Visibility {
+	methodOptions, ok := method.Desc.Options().(*descriptorpb.MethodOptions)
+	if !ok || methodOptions == nil || !proto.HasExtension(methodOptions, http.E_Visibility) {
+		return nil
+	}
+	visibility, _ := proto.GetExtension(methodOptions, http.E_Visibility).(*http.Visibility)
+	return visibility
+}
+
+// isVisible reports whether visibility lets generator emit the element named
+// name. It returns an error when the annotation sets both include_only and
+// exclude, or names an unknown generator.
+func isVisible(visibility *http.Visibility, generator, name string) (bool, error) {
+	if visibility == nil {
+		return true, nil
+	}
+	exclude, includeOnly := visibility.GetExclude(), visibility.GetIncludeOnly()
+	if len(exclude) > 0 && len(includeOnly) > 0 {
+		return false, fmt.Errorf("visibility of %s sets both include_only and exclude; set one of them", name)
+	}
+	for _, listed := range slices.Concat(exclude, includeOnly) {
+		if !slices.Contains(generatorNames, listed) {
+			return false, fmt.Errorf("visibility of %s names unknown generator %q; expected one of %s",
+				name, listed, strings.Join(generatorNames, ", "))
+		}
+	}
+	if len(includeOnly) > 0 {
+		return slices.Contains(includeOnly, generator), nil
+	}
+	return !slices.Contains(exclude, generator), nil
+}
+
+// ApplyVisibility removes from the generated files of plugin the services and
+// methods whose visibility annotations exclude generator, so that generators
+// walking services from there also skip the messages only they referenced.
+// It returns an error for an annotation setting both include_only and exclude,
+// or naming an unknown generator.
+func ApplyVisibility(plugin *protogen.Plugin, generator string) error {
+	for _, file := range plugin.Files {
+		if !file.Generate {
+			continue
+		}
+		services := file.Services[:0:0]
+		for _, service := range file.Services {
+			visible, err := isVisible(getServiceVisibility(service), generator, string(service.Desc.FullName()))
+			if err != nil {
+				return err
+			}
+			methods := service.Methods[:0:0]
+			for _, method := range service.Methods {
+				methodVisible, methodErr := isVisible(getMethodVisibility(method), generator,
+					string(method.Desc.FullName()))
+				if methodErr != nil {
+					return methodErr
+				}
+				if methodVisible {
+					methods = append(methods, method)
+				}
+			}
+			if visible {
+				service.Methods = methods
+				services = append(services, service)
+			}
+		}
+		file.Services = services
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package annotations
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// visibilityFile builds the validateOneofFile messages with a Public service
+// whose Internal method is hidden by methodVisibility, and an Admin service
+// hidden by serviceVisibility.
+func visibilityFile(methodVisibility, serviceVisibility *http.Visibility) *descriptorpb.FileDescriptorProto {
+	fd := validateOneofFile()
+	method := func(name string, visibility *http.Visibility) *descriptorpb.MethodDescriptorProto {
+		m := &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(name),
+			InputType:  proto.String("." + validateTestPkg + ".TextContent"),
+			OutputType: proto.String("." + validateTestPkg + ".ImageContent"),
+		}
+		if visibility != nil {
+			m.Options = &descriptorpb.MethodOptions{}
+			proto.SetExtension(m.GetOptions(), http.E_Visibility, visibility)
+		}
+		return m
+	}
+	admin := &descriptorpb.ServiceDescriptorProto{
+		Name:   proto.String("Admin"),
+		Method: []*descriptorpb.MethodDescriptorProto{method("Purge", nil)},
+	}
+	if serviceVisibility != nil {
+		admin.Options = &descriptorpb.ServiceOptions{}
+		proto.SetExtension(admin.GetOptions(), http.E_ServiceVisibility, serviceVisibility)
+	}
+	fd.Service = []*descriptorpb.ServiceDescriptorProto{
+		{
+			Name:   proto.String("Public"),
+			Method: []*descriptorpb.MethodDescriptorProto{method("Get", nil), method("Internal", methodVisibility)},
+		},
+		admin,
+	}
+	return fd
+}
+
+// visibleNames returns the Service.Method names of fd that generator emits.
+func visibleNames(t *testing.T, fd *descriptorpb.FileDescriptorProto, generator string) []string {
+	t.Helper()
+	plugin := buildValidatePlugin(t, fd)
+	if err := ApplyVisibility(plugin, generator); err != nil {
+		t.Fatalf("ApplyVisibility: %v", err)
+	}
+	var names []string
+	for _, service := range plugin.Files[0].Services {
+		for _, method := range service.Methods {
+			names = append(names, service.GoName+"."+method.GoName)
+		}
+	}
+	return names
+}
+
+func TestApplyVisibility(t *testing.T) {
+	tests := []struct {
+		name              string
+		methodVisibility  *http.Visibility
+		serviceVisibility *http.Visibility
+		generator         string
+		want              []string
+	}{
+		{
+			name:      "no annotations",
+			generator: GeneratorOpenAPIv3,
+			want:      []string{"Public.Get", "Public.Internal", "Admin.Purge"},
+		},
+		{
+			name:              "excluded generator",
+			methodVisibility:  &http.Visibility{Exclude: []string{GeneratorOpenAPIv3, GeneratorTSClient}},
+			serviceVisibility: &http.Visibility{Exclude: []string{GeneratorOpenAPIv3}},
+			generator:         GeneratorOpenAPIv3,
+			want:              []string{"Public.Get"},
+		},
+		{
+			name:              "other generator",
+			methodVisibility:  &http.Visibility{Exclude: []string{GeneratorOpenAPIv3, GeneratorTSClient}},
+			serviceVisibility: &http.Visibility{Exclude: []string{GeneratorOpenAPIv3}},
+			generator:         GeneratorGoHTTP,
+			want:              []string{"Public.Get", "Public.Internal", "Admin.Purge"},
+		},
+		{
+			name:              "include_only",
+			methodVisibility:  &http.Visibility{IncludeOnly: []string{GeneratorGoHTTP}},
+			serviceVisibility: &http.Visibility{IncludeOnly: []string{GeneratorGoHTTP, GeneratorGoClient}},
+			generator:         GeneratorGoClient,
+			want:              []string{"Public.Get", "Admin.Purge"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := visibleNames(t, visibilityFile(tt.methodVisibility, tt.serviceVisibility), tt.generator)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("visible methods = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyVisibilityErrors(t *testing.T) {
+	tests := []struct {
+		name              string
+		methodVisibility  *http.Visibility
+		serviceVisibility *http.Visibility
+		want              string
+	}{
+		{
+			name: "include_only and exclude on a method",
+			methodVisibility: &http.Visibility{
+				Exclude: []string{GeneratorOpenAPIv3}, IncludeOnly: []string{GeneratorGoHTTP},
+			},
+			want: "visibility of test.validate.v1.Public.Internal sets both include_only and exclude",
+		},
+		{
+			name: "include_only and exclude on a service",
+			serviceVisibility: &http.Visibility{
+				Exclude: []string{GeneratorOpenAPIv3}, IncludeOnly: []string{GeneratorGoHTTP},
+			},
+			want: "visibility of test.validate.v1.Admin sets both include_only and exclude",
+		},
+		{
+			name:             "unknown generator",
+			methodVisibility: &http.Visibility{Exclude: []string{"openapi"}},
+			want:             `names unknown generator "openapi"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := buildValidatePlugin(t, visibilityFile(tt.methodVisibility, tt.serviceVisibility))
+			err := ApplyVisibility(plugin, GeneratorGoHTTP)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ApplyVisibility error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package annotations
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// Webhook describes a message annotated as a webhook payload.
+type Webhook struct {
+	Message         *protogen.Message
+	Path            string                         // Path appended to the endpoint URL, empty for none
+	SignatureHeader string                         // Header carrying the signature, never empty
+	Algorithm       http.WebhookSignatureAlgorithm // Never UNSPECIFIED
+}
+
+// GetWebhook returns the webhook annotation of a message with defaults
+// applied, or nil when the message is not a webhook payload.
+func GetWebhook(message *protogen.Message) *Webhook {
+	messageOptions, ok := message.Desc.Options().(*descriptorpb.MessageOptions)
+	if !ok || messageOptions == nil || !proto.HasExtension(messageOptions, http.E_Webhook) {
+		return nil
+	}
+	config, ok := proto.GetExtension(messageOptions, http.E_Webhook).(*http.WebhookConfig)
+	if !ok {
+		return nil
+	}
+
+	webhook := &Webhook{
+		Message:         message,
+		SignatureHeader: config.GetSignatureHeader(),
+		Algorithm:       config.GetAlgorithm(),
+	}
+	if path := strings.TrimSuffix(config.GetPath(), "/"); path != "" {
+		webhook.Path = EnsureLeadingSlash(path)
+	}
+	if webhook.SignatureHeader == "" {
+		webhook.SignatureHeader = http.DefaultWebhookSignatureHeader
+	}
+	if webhook.Algorithm == http.WebhookSignatureAlgorithm_WEBHOOK_SIGNATURE_ALGORITHM_UNSPECIFIED {
+		webhook.Algorithm = http.WebhookSignatureAlgorithm_WEBHOOK_SIGNATURE_ALGORITHM_SHA256
+	}
+	return webhook
+}
+
+// GetFileWebhooks returns the webhooks of a file's messages, nested messages
+// included, in declaration order.
+func GetFileWebhooks(file *protogen.File) []*Webhook {
+	var webhooks []*Webhook
+	var collect func(messages []*protogen.Message)
+	collect = func(messages []*protogen.Message) {
+		for _, message := range messages {
+			if webhook := GetWebhook(message); webhook != nil {
+				webhooks = append(webhooks, webhook)
+			}
+			collect(message.Messages)
+		}
+	}
+	collect(file.Messages)
+	return webhooks
+}
+
+// ValidateWebhook checks that a webhook's signature header is a valid header
+// name and its path has no path variables, which Send<Message> has no values for.
+func ValidateWebhook(webhook *Webhook) error {
+	name := webhook.Message.Desc.FullName()
+	if !isHeaderFieldName(webhook.SignatureHeader) {
+		return fmt.Errorf("%s: signature_header %q is not a valid header name", name, webhook.SignatureHeader)
+	}
+	if len(ExtractPathParams(webhook.Path)) > 0 {
+		return fmt.Errorf("%s: webhook path %q has path variables, which are not supported", name, webhook.Path)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package clientgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestCassetteIntegration generates the client of cassetteProto, records its
+// calls to an httptest server as cassettes, then replays them with the network
+// disabled and checks the typed responses match: sensitive fields and
+// credential headers never reach the cassettes, volatile headers are left out
+// of the match, and a call without a cassette names the nearest one.
+func TestCassetteIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "cassette_test",
+		Protos:  map[string]string{"shop.proto": cassetteProto},
+		Plugins: []plugintest.Plugin{{Name: "go-client"}},
+		Files:   map[string]string{"cassette_test.go": cassetteIntegrationTestCode},
+	})
+}
+
+const cassetteProto = `syntax = "proto3";
+package test.cassette;
+option go_package = "cassette_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service ShopService {
+  option (sebuf.http.service_config) = { base_path: "/api/v1" };
+  rpc GetProduct(GetProductRequest) returns (Product) {
+    option (sebuf.http.config) = { path: "/products/{id}" method: HTTP_METHOD_GET };
+  }
+  rpc Login(LoginRequest) returns (Session) {
+    option (sebuf.http.config) = { path: "/login" method: HTTP_METHOD_POST };
+  }
+}
+
+message GetProductRequest {
+  string id = 1;
+}
+
+message Product {
+  string id = 1;
+  string name = 2;
+  int64 price_cents = 3;
+  repeated string tags = 4;
+}
+
+message LoginRequest {
+  string username = 1;
+  string password = 2 [(sebuf.http.sensitive) = true];
+}
+
+message Session {
+  string username = 1;
+  string token = 2 [(sebuf.http.sensitive) = true];
+}
+`
+
+const cassetteIntegrationTestCode = `package cassette_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/SebastienMelki/sebuf/http/cassette"
+
+	gen "cassette_test/gen"
+)
+
+// offline is a transport failing every request, so replayed calls prove they
+// never reach the network.
+type offline struct{ t *testing.T }
+
+func (o offline) RoundTrip(r *http.Request) (*http.Response, error) {
+	o.t.Errorf("request sent in replay mode: %s %s", r.Method, r.URL)
+	return nil, errors.New("network disabled")
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg proto.Message
+		switch r.URL.Path {
+		case "/api/v1/products/42":
+			msg = &gen.Product{Id: "42", Name: "Lamp", PriceCents: 1999, Tags: []string{"home", "light"}}
+		case "/api/v1/login":
+			msg = &gen.Session{Username: "ada", Token: "tok-123"}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Set-Cookie", "session=tok-123")
+		if r.Header.Get("Content-Type") == "application/x-protobuf" {
+			w.Header().Set("Content-Type", "application/x-protobuf")
+			data, _ := proto.Marshal(msg)
+			_, _ = w.Write(data)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		data, _ := protojson.Marshal(msg)
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// calls makes the calls the test records and replays.
+func calls(t *testing.T, client gen.ShopServiceClient, requestID string) []proto.Message {
+	t.Helper()
+	ctx := context.Background()
+	product, err := client.GetProduct(ctx, &gen.GetProductRequest{Id: "42"},
+		gen.WithShopServiceHeader("X-Request-Id", requestID))
+	if err != nil {
+		t.Fatalf("GetProduct: %v", err)
+	}
+	protoProduct, err := client.GetProduct(ctx, &gen.GetProductRequest{Id: "42"},
+		gen.WithShopServiceCallContentType(gen.ContentTypeProto))
+	if err != nil {
+		t.Fatalf("GetProduct as protobuf: %v", err)
+	}
+	session, err := client.Login(ctx, &gen.LoginRequest{Username: "ada", Password: "hunter2"})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	return []proto.Message{product, protoProduct, session}
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cassettes")
+	srv := newServer(t)
+	recording := gen.NewShopServiceClient(srv.URL,
+		gen.WithShopServiceDefaultHeader("Authorization", "Bearer top-secret"),
+		gen.WithShopServiceRecorder(cassette.New(dir, cassette.Record)))
+	recorded := calls(t, recording, "req-1")
+	if got := recorded[2].(*gen.Session).GetToken(); got != "tok-123" {
+		t.Errorf("recorded token = %q, recording must not alter the response", got)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+		data, readErr := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if readErr != nil {
+			t.Fatal(readErr)
+		}
+		for _, secret := range []string{"top-secret", "hunter2", "tok-123"} {
+			if strings.Contains(string(data), secret) {
+				t.Errorf("%s leaks %q:\n%s", entry.Name(), secret, data)
+			}
+		}
+	}
+	if len(names) != 4 || names[0] != "README.md" || !strings.HasPrefix(names[1], "ShopService.GetProduct_") {
+		t.Fatalf("cassettes = %v, want the README and one per call", names)
+	}
+
+	replaying := gen.NewShopServiceClient("http://backend.invalid",
+		gen.WithShopServiceHTTPClient(&http.Client{Transport: offline{t}}),
+		gen.WithShopServiceDefaultHeader("Authorization", "Bearer other-token"),
+		gen.WithShopServiceRecorder(cassette.New(dir, cassette.Replay)))
+	replayed := calls(t, replaying, "req-2")
+	for i := range 2 {
+		if !proto.Equal(replayed[i], recorded[i]) {
+			t.Errorf("call %d replayed %v, recorded %v", i, replayed[i], recorded[i])
+		}
+	}
+	want := &gen.Session{Username: "ada", Token: "[REDACTED]"}
+	if !proto.Equal(replayed[2], want) {
+		t.Errorf("Login replayed %v, want %v with the token redacted", replayed[2], want)
+	}
+}
+
+func TestReplayMiss(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cassettes")
+	srv := newServer(t)
+	recording := gen.NewShopServiceClient(srv.URL, gen.WithShopServiceRecorder(cassette.New(dir, cassette.Record)))
+	calls(t, recording, "req-1")
+
+	replaying := gen.NewShopServiceClient(srv.URL,
+		gen.WithShopServiceHTTPClient(&http.Client{Transport: offline{t}}),
+		gen.WithShopServiceRecorder(cassette.New(dir, cassette.Replay)))
+	_, err := replaying.GetProduct(context.Background(), &gen.GetProductRequest{Id: "43"})
+	var miss *cassette.MissError
+	if !errors.As(err, &miss) {
+		t.Fatalf("GetProduct of an unrecorded product: %v, want a *cassette.MissError", err)
+	}
+	if !strings.HasPrefix(miss.Nearest, "ShopService.GetProduct_") ||
+		len(miss.Differences) != 1 || !strings.Contains(miss.Differences[0], "/api/v1/products/42") {
+		t.Errorf("nearest = %s, differences = %q, want the product 42 cassette differing by url", miss.Nearest,
+			miss.Differences)
+	}
+
+	empty := gen.NewShopServiceClient(srv.URL,
+		gen.WithShopServiceRecorder(cassette.New(filepath.Join(t.TempDir(), "none"), cassette.Replay)))
+	if _, err := empty.GetProduct(context.Background(), &gen.GetProductRequest{Id: "42"}); err == nil ||
+		!strings.Contains(err.Error(), "holds no cassettes") {
+		t.Errorf("GetProduct without cassettes: %v", err)
+	}
+}
+`
@@ -0,0 +1,153 @@
+package clientgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestClientLogIntegration generates the client of clientLogProto and checks
+// the debug dump and log events of its calls: sensitive body fields, sensitive
+// header fields and credential headers are redacted, and every call reaches
+// the hook with its method, status and attempt.
+func TestClientLogIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "client_log_test",
+		Protos:  map[string]string{"auth.proto": clientLogProto},
+		Plugins: []plugintest.Plugin{{Name: "go-client"}},
+		Files:   map[string]string{"client_log_test.go": clientLogIntegrationTestCode},
+	})
+}
+
+const clientLogProto = `syntax = "proto3";
+package test.clientlog;
+option go_package = "client_log_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service AuthService {
+  option (sebuf.http.service_config) = { base_path: "/api/v1" };
+  rpc Login(LoginRequest) returns (Session) {
+    option (sebuf.http.config) = { path: "/login" method: HTTP_METHOD_POST };
+  }
+  rpc GetSession(GetSessionRequest) returns (Session) {
+    option (sebuf.http.config) = { path: "/sessions/{id}" method: HTTP_METHOD_GET };
+  }
+}
+
+message LoginRequest {
+  string username = 1;
+  string password = 2 [(sebuf.http.sensitive) = true];
+  string x_device_secret = 3 [
+    (sebuf.http.source) = FIELD_SOURCE_HEADER,
+    (sebuf.http.sensitive) = true
+  ];
+}
+
+message GetSessionRequest {
+  string id = 1;
+}
+
+message Session {
+  string id = 1;
+  string token = 2 [(sebuf.http.sensitive) = true];
+}
+`
+
+const clientLogIntegrationTestCode = `package client_log_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "client_log_test/gen"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/sessions/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, ` + "`" + `{"message":"no such session"}` + "`" + `)
+			return
+		}
+		_, _ = io.WriteString(w, ` + "`" + `{"id":"s-1","token":"tok-123"}` + "`" + `)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDebugDump(t *testing.T) {
+	srv := newServer(t)
+	var out bytes.Buffer
+	client := gen.NewAuthServiceClient(srv.URL,
+		gen.WithAuthServiceDebugLogging(&out),
+		gen.WithAuthServiceDefaultHeader("Authorization", "Bearer top-secret"),
+	)
+	session, err := client.Login(context.Background(), &gen.LoginRequest{
+		Username:      "ada",
+		Password:      "hunter2",
+		XDeviceSecret: "device-secret",
+	})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if session.GetToken() != "tok-123" {
+		t.Errorf("token = %q, the dump must not alter the response", session.GetToken())
+	}
+
+	dump := out.String()
+	for _, want := range []string{
+		"--> AuthService.Login\ncurl -X POST '" + srv.URL + "/api/v1/login'",
+		"-H 'Authorization: [REDACTED]'",
+		"-H 'X-Device-Secret: [REDACTED]'",
+		` + "`" + `--data-raw '{"username":"ada","password":"[REDACTED]"}'` + "`" + `,
+		"<-- 200 OK in ",
+		` + "`" + `"token": "[REDACTED]"` + "`" + `,
+	} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("dump does not contain %q:\n%s", want, dump)
+		}
+	}
+	for _, secret := range []string{"top-secret", "hunter2", "device-secret", "tok-123"} {
+		if strings.Contains(dump, secret) {
+			t.Errorf("dump leaks %q:\n%s", secret, dump)
+		}
+	}
+}
+
+func TestLogHook(t *testing.T) {
+	srv := newServer(t)
+	var events []sebufhttp.ClientLogEvent
+	client := gen.NewAuthServiceClient(srv.URL,
+		gen.WithAuthServiceLogHook(func(e sebufhttp.ClientLogEvent) { events = append(events, e) }),
+	)
+	if _, err := client.GetSession(context.Background(), &gen.GetSessionRequest{Id: "s-1"}); err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if _, err := client.GetSession(context.Background(), &gen.GetSessionRequest{Id: "missing"}); err == nil {
+		t.Fatal("GetSession of a missing session succeeded")
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	for i, want := range []int{http.StatusOK, http.StatusNotFound} {
+		e := events[i]
+		if e.Method != "AuthService.GetSession" || e.HTTPMethod != http.MethodGet || e.Status != want ||
+			e.Attempt != 1 || e.Err != nil {
+			t.Errorf("event %d = %+v, want status %d", i, e, want)
+		}
+	}
+	if events[1].URL != srv.URL+"/api/v1/sessions/missing" {
+		t.Errorf("URL = %s", events[1].URL)
+	}
+}
+`
@@ -0,0 +1,99 @@
+package clientgen
+
+import (
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// ExtraCodec names a wire format the extra_codecs parameter adds to the
+// generated client besides JSON and protobuf. The client converts its bodies
+// from and to JSON with the sebufhttp.Codec registered for the format, so the
+// generated code does not depend on the format's library.
+type ExtraCodec string
+
+const (
+	// ExtraCodecMsgpack sends application/msgpack bodies with the codec the
+	// github.com/SebastienMelki/sebuf/http/msgpackcodec package registers.
+	ExtraCodecMsgpack ExtraCodec = "msgpack"
+)
+
+// validateExtraCodecs checks the extra_codecs parameter.
+func (g *Generator) validateExtraCodecs() error {
+	for _, codec := range g.extraCodecs {
+		if codec != ExtraCodecMsgpack {
+			return fmt.Errorf("unsupported extra_codecs %q: expected %q", codec, ExtraCodecMsgpack)
+		}
+	}
+	return nil
+}
+
+// sendsMsgpack reports whether the client can send and receive msgpack bodies.
+func (g *Generator) sendsMsgpack() bool {
+	return slices.Contains(g.extraCodecs, ExtraCodecMsgpack)
+}
+
+// generateWireFormats generates the WireFormat type and its values, which
+// With<Service>WireFormat takes.
+func (g *Generator) generateWireFormats(gf *protogen.GeneratedFile) {
+	gf.P("// WireFormat is the encoding of request and response bodies.")
+	gf.P("type WireFormat string")
+	gf.P()
+	gf.P("const (")
+	gf.P("// WireJSON sends and receives JSON bodies.")
+	gf.P("WireJSON WireFormat = ContentTypeJSON")
+	gf.P("// WireProto sends and receives binary protobuf bodies.")
+	gf.P("WireProto WireFormat = ContentTypeProto")
+	gf.P("// WireMsgpack sends and receives msgpack bodies keyed by the JSON field names.")
+	gf.P("// It requires importing github.com/SebastienMelki/sebuf/http/msgpackcodec.")
+	gf.P("WireMsgpack WireFormat = ContentTypeMsgpack")
+	gf.P(")")
+	gf.P()
+}
+
+// generateWireFormatOption generates With<Service>WireFormat.
+func (g *Generator) generateWireFormatOption(gf *protogen.GeneratedFile, serviceName string) {
+	lowerName := annotations.LowerFirst(serviceName)
+	gf.P("// With", serviceName, "WireFormat sets the encoding of request and response bodies.")
+	gf.P("func With", serviceName, "WireFormat(format WireFormat) ", serviceName, "ClientOption {")
+	gf.P("return func(c *", lowerName, "Client) {")
+	gf.P("c.contentType = string(format)")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+}
+
+// generateMsgpackRequestCase generates the case of marshalRequest that converts
+// the JSON body of a msgpack request.
+func (g *Generator) generateMsgpackRequestCase(gf *protogen.GeneratedFile) {
+	gf.P("case ContentTypeMsgpack:")
+	gf.P("codec, ok := sebufhttp.LookupCodec(ContentTypeMsgpack)")
+	gf.P("if !ok {")
+	gf.P("return nil, sebufhttp.CodecNotRegisteredError(ContentTypeMsgpack)")
+	gf.P("}")
+	gf.P("data, err := c.marshalRequest(req, ContentTypeJSON)")
+	gf.P("if err != nil {")
+	gf.P("return nil, err")
+	gf.P("}")
+	gf.P("return codec.FromJSON(data)")
+}
+
+// generateMsgpackResponseConversion generates the start of unmarshalResponse
+// that converts a msgpack body to JSON before it is unmarshaled.
+func (g *Generator) generateMsgpackResponseConversion(gf *protogen.GeneratedFile) {
+	gf.P("if contentType == ContentTypeMsgpack {")
+	gf.P("codec, ok := sebufhttp.LookupCodec(ContentTypeMsgpack)")
+	gf.P("if !ok {")
+	gf.P("return sebufhttp.CodecNotRegisteredError(ContentTypeMsgpack)")
+	gf.P("}")
+	gf.P("jsonBody, err := codec.ToJSON(body)")
+	gf.P("if err != nil {")
+	gf.P("return err")
+	gf.P("}")
+	gf.P("body, contentType = jsonBody, ContentTypeJSON")
+	gf.P("}")
+	gf.P()
+}
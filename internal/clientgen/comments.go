@@ -0,0 +1,133 @@
+package clientgen
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// maxCommentWidth is the maximum width of a generated doc comment line, including the "// " marker.
+const maxCommentWidth = 100
+
+// deprecatedNotice matches the notice protoc-gen-go emits for deprecated declarations.
+const deprecatedNotice = "Deprecated: Do not use."
+
+// wrapComment reflows a proto comment into doc comment lines no wider than
+// maxCommentWidth. Blank lines in the source are kept as paragraph breaks.
+func wrapComment(text string) []string {
+	width := maxCommentWidth - len("// ")
+
+	var lines []string
+	var current string
+	flush := func() {
+		if current != "" {
+			lines = append(lines, current)
+			current = ""
+		}
+	}
+
+	for _, raw := range strings.Split(strings.TrimSpace(text), "\n") {
+		words := strings.Fields(raw)
+		if len(words) == 0 {
+			flush()
+			if len(lines) > 0 && lines[len(lines)-1] != "" {
+				lines = append(lines, "")
+			}
+			continue
+		}
+		for _, word := range words {
+			switch {
+			case current == "":
+				current = word
+			case len(current)+1+len(word) > width:
+				lines = append(lines, current)
+				current = word
+			default:
+				current += " " + word
+			}
+		}
+	}
+	flush()
+
+	return lines
+}
+
+// prefixWithName makes a proto comment read as a Go doc comment by starting it
+// with the declared name: "Retrieves a user." becomes "GetUser retrieves a user.".
+func prefixWithName(name, text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.HasPrefix(text, name+" ") {
+		return text
+	}
+
+	first, size := utf8.DecodeRuneInString(text)
+	next, _ := utf8.DecodeRuneInString(text[size:])
+	// Keep acronyms such as "HTTP" or "ID" as written.
+	if unicode.IsUpper(first) && !unicode.IsUpper(next) {
+		text = string(unicode.ToLower(first)) + text[size:]
+	}
+	return name + " " + text
+}
+
+// writeDocLines writes lines as a Go doc comment, emitting "//" for paragraph breaks.
+func writeDocLines(gf *protogen.GeneratedFile, lines []string) {
+	for _, line := range lines {
+		if line == "" {
+			gf.P("//")
+			continue
+		}
+		gf.P("// ", line)
+	}
+}
+
+// writeMethodDoc writes the doc comment for a generated client method. The proto
+// method comment is used when present, otherwise fallback; a request with
+// deprecated fields gets a paragraph naming them, and deprecated RPCs get a
+// trailing Deprecated paragraph.
+func writeMethodDoc(gf *protogen.GeneratedFile, method *protogen.Method, fallback string) {
+	lines := wrapComment(prefixWithName(method.GoName, string(method.Comments.Leading)))
+	if len(lines) == 0 && fallback != "" {
+		lines = []string{fallback}
+	}
+	if fields := annotations.DeprecatedFieldPaths(method.Input.Desc); len(fields) > 0 {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, wrapComment(deprecatedFieldsNotice(fields))...)
+	}
+	if annotations.IsMethodDeprecated(method) {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, deprecatedNotice)
+	}
+	writeDocLines(gf, lines)
+}
+
+// writeServiceDoc writes the doc comment for the generated client interface:
+// the summary line, followed by the proto service comment and deprecation notice.
+func writeServiceDoc(gf *protogen.GeneratedFile, service *protogen.Service, summary string) {
+	lines := []string{summary}
+	if comment := wrapComment(string(service.Comments.Leading)); len(comment) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, comment...)
+	}
+	if annotations.IsServiceDeprecated(service) {
+		lines = append(lines, "", deprecatedNotice)
+	}
+	writeDocLines(gf, lines)
+}
+
+// deprecatedFieldsNotice names the deprecated fields of a request message. It
+// does not start with "Deprecated:", which would mark the method itself.
+func deprecatedFieldsNotice(fields []string) string {
+	if len(fields) == 1 {
+		return "The request field " + fields[0] + " is deprecated."
+	}
+	return "The request fields " + strings.Join(fields[:len(fields)-1], ", ") + " and " +
+		fields[len(fields)-1] + " are deprecated."
+}
@@ -0,0 +1,48 @@
+package clientgen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrefixWithName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Retrieves a user by ID.", "GetUser retrieves a user by ID."},
+		{"GetUser retrieves a user by ID.", "GetUser retrieves a user by ID."},
+		{"HTTP-only lookup.", "GetUser HTTP-only lookup."},
+		{" leading space", "GetUser leading space"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := prefixWithName("GetUser", tt.input)
+			if got != tt.want {
+				t.Errorf("prefixWithName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapComment(t *testing.T) {
+	long := strings.Repeat("word ", 40)
+	lines := wrapComment(long + "\n\nSecond paragraph.")
+
+	for _, line := range lines {
+		if len("// "+line) > maxCommentWidth {
+			t.Errorf("line exceeds %d chars: %q", maxCommentWidth, line)
+		}
+	}
+	if len(lines) < 4 {
+		t.Fatalf("expected wrapped first paragraph plus second paragraph, got %q", lines)
+	}
+	if lines[len(lines)-2] != "" {
+		t.Errorf("expected blank paragraph separator, got %q", lines[len(lines)-2])
+	}
+	if lines[len(lines)-1] != "Second paragraph." {
+		t.Errorf("expected second paragraph last, got %q", lines[len(lines)-1])
+	}
+}
@@ -1,12 +1,14 @@
 package clientgen
 
 import (
+	"slices"
 	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
 
 	"github.com/SebastienMelki/sebuf/http"
 	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/encodinggen"
 )
 
 // EmptyBehaviorContext holds information about messages that need custom JSON encoding
@@ -24,6 +26,23 @@ type EmptyBehaviorFieldInfo struct {
 	Behavior http.EmptyBehavior
 }
 
+// hasNullBehavior reports whether a field of the message has empty_behavior
+// NULL, the only behavior that needs a custom UnmarshalJSON.
+func (ctx *EmptyBehaviorContext) hasNullBehavior() bool {
+	return slices.ContainsFunc(ctx.EmptyBehaviorFields, func(f *EmptyBehaviorFieldInfo) bool {
+		return f.Behavior == http.EmptyBehavior_EMPTY_BEHAVIOR_NULL
+	})
+}
+
+// usesSebufHTTP reports whether the encoders of the message call sebufhttp.
+// Without a NULL field it has no UnmarshalJSON to resolve alternate names in.
+func (ctx *EmptyBehaviorContext) usesSebufHTTP() bool {
+	if ctx.hasNullBehavior() {
+		return encodinggen.UsesSebufHTTP(ctx.Message)
+	}
+	return annotations.HasJSONNaming(ctx.Message)
+}
+
 // hasEmptyBehaviorFields returns true if any message field has empty_behavior annotation.
 func hasEmptyBehaviorFields(message *protogen.Message) bool {
 	for _, field := range message.Fields {
@@ -104,7 +123,7 @@ func (g *Generator) generateEmptyBehaviorEncodingFile(file *protogen.File) error
 	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
 
 	g.writeHeader(gf, file)
-	g.writeEmptyBehaviorImports(gf)
+	g.writeEmptyBehaviorImports(gf, slices.ContainsFunc(contexts, (*EmptyBehaviorContext).usesSebufHTTP))
 
 	for _, ctx := range contexts {
 		g.generateEmptyBehaviorMarshalJSON(gf, ctx)
@@ -115,12 +134,13 @@ func (g *Generator) generateEmptyBehaviorEncodingFile(file *protogen.File) error
 }
 
 // writeEmptyBehaviorImports writes the imports needed for empty_behavior encoding.
-func (g *Generator) writeEmptyBehaviorImports(gf *protogen.GeneratedFile) {
+func (g *Generator) writeEmptyBehaviorImports(gf *protogen.GeneratedFile, jsonNaming bool) {
 	gf.P("import (")
 	gf.P(`"encoding/json"`)
 	gf.P()
 	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
 	gf.P(`"google.golang.org/protobuf/proto"`)
+	encodinggen.WriteJSONNamingImport(gf, jsonNaming)
 	gf.P(")")
 	gf.P()
 }
@@ -149,7 +169,7 @@ func (g *Generator) generateEmptyBehaviorMarshalJSON(gf *protogen.GeneratedFile,
 	gf.P()
 
 	gf.P("// Use protojson for base serialization")
-	gf.P("data, err := opts.Marshal(x)")
+	gf.P("data, err := ", encodinggen.ProtoMarshalCall(ctx.Message))
 	gf.P("if err != nil {")
 	gf.P("return nil, err")
 	gf.P("}")
@@ -182,7 +202,7 @@ func (g *Generator) generateEmptyBehaviorMarshalJSON(gf *protogen.GeneratedFile,
 // generateEmptyBehaviorFieldMarshal generates marshaling code for a single empty_behavior field.
 func (g *Generator) generateEmptyBehaviorFieldMarshal(gf *protogen.GeneratedFile, fieldInfo *EmptyBehaviorFieldInfo) {
 	field := fieldInfo.Field
-	jsonName := field.Desc.JSONName()
+	jsonName := annotations.JSONFieldName(field)
 	goName := field.GoName
 	behavior := fieldInfo.Behavior
 
@@ -213,16 +233,7 @@ func (g *Generator) generateEmptyBehaviorFieldMarshal(gf *protogen.GeneratedFile
 func (g *Generator) generateEmptyBehaviorUnmarshalJSON(gf *protogen.GeneratedFile, ctx *EmptyBehaviorContext) {
 	msgName := ctx.Message.GoIdent.GoName
 
-	// Check if any field has NULL behavior (needs special handling)
-	hasNullBehavior := false
-	for _, f := range ctx.EmptyBehaviorFields {
-		if f.Behavior == http.EmptyBehavior_EMPTY_BEHAVIOR_NULL {
-			hasNullBehavior = true
-			break
-		}
-	}
-
-	if !hasNullBehavior {
+	if !ctx.hasNullBehavior() {
 		// No special unmarshal needed for PRESERVE/OMIT
 		return
 	}
@@ -232,6 +243,7 @@ func (g *Generator) generateEmptyBehaviorUnmarshalJSON(gf *protogen.GeneratedFil
 		fieldNames = append(fieldNames, string(f.Field.Desc.Name()))
 	}
 
+	encodinggen.WriteAlternateJSONNames(gf, ctx.Message)
 	gf.P("// UnmarshalJSONSebuf implements sebufUnmarshaler for ", msgName, ".")
 	gf.P("// This method handles empty_behavior fields: ", strings.Join(fieldNames, ", "))
 	gf.P("func (x *", msgName, ") UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error {")
@@ -240,13 +252,14 @@ func (g *Generator) generateEmptyBehaviorUnmarshalJSON(gf *protogen.GeneratedFil
 	gf.P("if err := json.Unmarshal(data, &raw); err != nil {")
 	gf.P("return err")
 	gf.P("}")
+	encodinggen.WriteResolveAlternateJSONNames(gf, ctx.Message)
 	gf.P()
 
 	// For NULL fields, convert null to empty object for protojson
 	for _, fieldInfo := range ctx.EmptyBehaviorFields {
 		if fieldInfo.Behavior == http.EmptyBehavior_EMPTY_BEHAVIOR_NULL {
 			field := fieldInfo.Field
-			jsonName := field.Desc.JSONName()
+			jsonName := annotations.JSONFieldName(field)
 
 			gf.P("// Handle empty_behavior=NULL: convert null to {} for protojson")
 			gf.P(`if rawVal, ok := raw["`, jsonName, `"]; ok && string(rawVal) == "null" {`)
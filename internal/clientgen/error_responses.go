@@ -0,0 +1,32 @@
+package clientgen
+
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// generateErrorStatusHandling generates the return of the error of a response
+// with an error status: a status documented in the method's error_responses
+// returns its body as a *sebufhttp.ErrorResponse of the message, other
+// statuses, and bodies not decoding into the message, the error
+// handleErrorResponse decodes.
+func (g *Generator) generateErrorStatusHandling(gf *protogen.GeneratedFile, method *protogen.Method) {
+	responses, _ := annotations.GetErrorResponses(g.plugin, method)
+	if len(responses) > 0 {
+		gf.P("// Decode the bodies of the documented error statuses")
+		gf.P("switch resp.StatusCode {")
+		for _, response := range responses {
+			gf.P("case ", strconv.Itoa(response.Status), ":")
+			gf.P("errBody := &", response.Message.GoIdent, "{}")
+			gf.P("if c.unmarshalResponse(respBody, errBody, contentType, false) == nil {")
+			gf.P("return nil, &sebufhttp.ErrorResponse[*", response.Message.GoIdent,
+				"]{StatusCode: resp.StatusCode, Body: errBody}")
+			gf.P("}")
+		}
+		gf.P("}")
+	}
+	gf.P("return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)")
+}
@@ -0,0 +1,148 @@
+package clientgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestErrorResponsesIntegration generates the client of errorResponsesProto
+// and calls an httptest server answering with error statuses: documented
+// statuses return a *sebufhttp.ErrorResponse of their message, matched with
+// errors.As, and other statuses, or bodies that are not the message, keep
+// returning the errors they did.
+func TestErrorResponsesIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "errorresponses_test",
+		Protos:  map[string]string{"library.proto": errorResponsesProto},
+		Plugins: []plugintest.Plugin{{Name: "go-client"}},
+		Files:   map[string]string{"error_responses_test.go": errorResponsesIntegrationTestCode},
+	})
+}
+
+const errorResponsesProto = `syntax = "proto3";
+package test.library;
+option go_package = "errorresponses_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service LibraryService {
+  option (sebuf.http.service_config) = { base_path: "/api/v1" };
+  rpc GetBook(GetBookRequest) returns (Book) {
+    option (sebuf.http.config) = {
+      path: "/books/{id}"
+      method: HTTP_METHOD_GET
+      error_responses: { key: 404 value: "NotFoundError" }
+    };
+  }
+  rpc ListBooks(ListBooksRequest) returns (ListBooksResponse) {
+    option (sebuf.http.config) = { path: "/books" method: HTTP_METHOD_GET };
+  }
+}
+
+message GetBookRequest {
+  string id = 1;
+}
+
+message Book {
+  string id = 1;
+  string title = 2;
+}
+
+message ListBooksRequest {}
+
+message ListBooksResponse {
+  repeated Book books = 1;
+}
+
+message NotFoundError {
+  string resource = 1;
+  string id = 2;
+}
+`
+
+const errorResponsesIntegrationTestCode = `package errorresponses_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "errorresponses_test/gen"
+)
+
+// newServer answers every request with status and body, in the encoding the
+// request asks for.
+func newServer(t *testing.T, status int, body proto.Message) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := protojson.Marshal(body)
+		contentType := "application/json"
+		if r.Header.Get("Content-Type") == "application/x-protobuf" {
+			data, _ = proto.Marshal(body)
+			contentType = "application/x-protobuf"
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDocumentedStatus(t *testing.T) {
+	notFound := &gen.NotFoundError{Resource: "book", Id: "b-1"}
+	srv := newServer(t, http.StatusNotFound, notFound)
+	for _, contentType := range []string{gen.ContentTypeJSON, gen.ContentTypeProto} {
+		client := gen.NewLibraryServiceClient(srv.URL, gen.WithLibraryServiceContentType(contentType))
+		_, err := client.GetBook(context.Background(), &gen.GetBookRequest{Id: "b-1"})
+		var typed *sebufhttp.ErrorResponse[*gen.NotFoundError]
+		if !errors.As(err, &typed) {
+			t.Fatalf("%s: GetBook = %v (%T), want a *sebufhttp.ErrorResponse[*gen.NotFoundError]",
+				contentType, err, err)
+		}
+		if typed.StatusCode != http.StatusNotFound || !proto.Equal(typed.Body, notFound) {
+			t.Errorf("%s: got status %d and body %v, want 404 and %v",
+				contentType, typed.StatusCode, typed.Body, notFound)
+		}
+		if err.Error() != "request failed with status 404: NotFoundError" {
+			t.Errorf("%s: Error() = %q", contentType, err.Error())
+		}
+	}
+}
+
+func TestUndocumentedStatus(t *testing.T) {
+	var typed *sebufhttp.ErrorResponse[*gen.NotFoundError]
+	var generic *sebufhttp.Error
+
+	// The status is not documented by the method
+	srv := newServer(t, http.StatusNotFound, &sebufhttp.Error{Message: "no such route"})
+	client := gen.NewLibraryServiceClient(srv.URL)
+	_, err := client.ListBooks(context.Background(), &gen.ListBooksRequest{})
+	if errors.As(err, &typed) || !errors.As(err, &generic) {
+		t.Errorf("ListBooks = %v (%T), want a *sebufhttp.Error", err, err)
+	}
+
+	// The status is documented by another status of the method
+	srv = newServer(t, http.StatusInternalServerError, &sebufhttp.Error{Message: "boom", Code: "INTERNAL"})
+	client = gen.NewLibraryServiceClient(srv.URL)
+	_, err = client.GetBook(context.Background(), &gen.GetBookRequest{Id: "b-1"})
+	if errors.As(err, &typed) || !errors.As(err, &generic) || generic.GetCode() != "INTERNAL" {
+		t.Errorf("GetBook = %v (%T), want the *sebufhttp.Error of the 500", err, err)
+	}
+
+	// The body of a documented status is not its message
+	srv = newServer(t, http.StatusNotFound, &sebufhttp.Error{Message: "route not found"})
+	client = gen.NewLibraryServiceClient(srv.URL)
+	_, err = client.GetBook(context.Background(), &gen.GetBookRequest{Id: "b-1"})
+	if errors.As(err, &typed) || !errors.As(err, &generic) || generic.GetMessage() != "route not found" {
+		t.Errorf("GetBook = %v (%T), want the *sebufhttp.Error of the body", err, err)
+	}
+}
+`
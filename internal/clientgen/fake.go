@@ -0,0 +1,99 @@
+package clientgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// generateFakeFile generates, for every service of file, Fake<Service>Client:
+// a <Service>Client whose methods call function fields set by the test and
+// record their calls, for unit tests of code that takes the client interface.
+func (g *Generator) generateFakeFile(file *protogen.File) {
+	filename := file.GeneratedFilenamePrefix + "_client_fake.pb.go"
+	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
+
+	g.writeHeader(gf, file)
+	gf.P("import (")
+	gf.P(`"context"`)
+	gf.P(`"errors"`)
+	gf.P(`"sync"`)
+	gf.P(")")
+	gf.P()
+
+	for _, service := range file.Services {
+		g.generateFakeClient(gf, service)
+	}
+}
+
+func (g *Generator) generateFakeClient(gf *protogen.GeneratedFile, service *protogen.Service) {
+	serviceName := service.GoName
+	fakeName := "Fake" + serviceName + "Client"
+
+	gf.P("// ", fakeName, " is a ", serviceName, "Client for tests. Each method calls the")
+	gf.P("// function field named after it, ignoring the call options, and records the")
+	gf.P("// call. A method whose function field is unset returns an error. It is safe")
+	gf.P("// for concurrent use.")
+	gf.P("type ", fakeName, " struct {")
+	for _, method := range service.Methods {
+		gf.P(method.GoName, "Func func(ctx context.Context, req *", method.Input.GoIdent, ") (",
+			clientResultType(gf, service, method), ", error)")
+	}
+	gf.P()
+	gf.P("mu sync.Mutex")
+	for _, method := range service.Methods {
+		lowerName := annotations.LowerFirst(method.GoName)
+		gf.P(lowerName, "Calls int")
+		gf.P(lowerName, "LastRequest *", method.Input.GoIdent)
+	}
+	gf.P("}")
+	gf.P()
+	gf.P("var _ ", serviceName, "Client = (*", fakeName, ")(nil)")
+	gf.P()
+
+	for _, method := range service.Methods {
+		g.generateFakeMethod(gf, service, method, fakeName)
+	}
+}
+
+func (g *Generator) generateFakeMethod(
+	gf *protogen.GeneratedFile,
+	service *protogen.Service,
+	method *protogen.Method,
+	fakeName string,
+) {
+	methodName := method.GoName
+	lowerName := annotations.LowerFirst(methodName)
+
+	gf.P("// ", methodName, " records the call and returns the result of ", methodName, "Func.")
+	gf.P("func (f *", fakeName, ") ", methodName, "(ctx context.Context, req *", method.Input.GoIdent,
+		", _ ...", service.GoName, "CallOption) (", clientResultType(gf, service, method), ", error) {")
+	gf.P("f.mu.Lock()")
+	gf.P("f.", lowerName, "Calls++")
+	gf.P("f.", lowerName, "LastRequest = req")
+	gf.P("fn := f.", methodName, "Func")
+	gf.P("f.mu.Unlock()")
+	gf.P("if fn == nil {")
+	gf.P(`return nil, errors.New("`, fakeName, `: `, methodName, ` is not faked: set `, methodName, `Func")`)
+	gf.P("}")
+	gf.P("return fn(ctx, req)")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// ", methodName, "Calls returns the number of ", methodName, " calls.")
+	gf.P("func (f *", fakeName, ") ", methodName, "Calls() int {")
+	gf.P("f.mu.Lock()")
+	gf.P("defer f.mu.Unlock()")
+	gf.P("return f.", lowerName, "Calls")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// ", methodName, "LastRequest returns the request of the last ", methodName,
+		" call, or nil before the first.")
+	gf.P("func (f *", fakeName, ") ", methodName, "LastRequest() *", method.Input.GoIdent, " {")
+	gf.P("f.mu.Lock()")
+	gf.P("defer f.mu.Unlock()")
+	gf.P("return f.", lowerName, "LastRequest")
+	gf.P("}")
+	gf.P()
+}
@@ -0,0 +1,133 @@
+package clientgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestFakeClientIntegration generates the client and fake of fakeClientProto
+// into a temporary module holding a small consumer of the client interface,
+// and runs the consumer's unit test, which injects the fake in place of the
+// HTTP client. It is the example of the docs' "Faking the Client" section.
+func TestFakeClientIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "fake_client_test",
+		Protos:  map[string]string{"users.proto": fakeClientProto},
+		Plugins: []plugintest.Plugin{{Name: "go-client"}},
+		Files: map[string]string{
+			"greeter.go":      fakeClientConsumerCode,
+			"greeter_test.go": fakeClientConsumerTestCode,
+		},
+	}, "-race")
+}
+
+const fakeClientProto = `syntax = "proto3";
+package test.fakeclient;
+option go_package = "fake_client_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service UserService {
+  rpc GetUser(GetUserRequest) returns (User) {
+    option (sebuf.http.config) = { path: "/users/{id}" method: HTTP_METHOD_GET };
+  }
+}
+
+message GetUserRequest {
+  string id = 1;
+}
+
+message User {
+  string id = 1;
+  string name = 2;
+}
+`
+
+// fakeClientConsumerCode is application code that depends on the generated
+// client interface rather than on the HTTP client.
+const fakeClientConsumerCode = `package greeter
+
+import (
+	"context"
+	"fmt"
+
+	gen "fake_client_test/gen"
+)
+
+// Greeter greets users by name.
+type Greeter struct {
+	Users gen.UserServiceClient
+}
+
+// Greet returns the greeting of the user with the given ID.
+func (g *Greeter) Greet(ctx context.Context, id string) (string, error) {
+	user, err := g.Users.GetUser(ctx, &gen.GetUserRequest{Id: id})
+	if err != nil {
+		return "", fmt.Errorf("greet %s: %w", id, err)
+	}
+	return "Hello, " + user.GetName() + "!", nil
+}
+`
+
+// fakeClientConsumerTestCode is the consumer's unit test, which injects the
+// generated fake.
+const fakeClientConsumerTestCode = `package greeter
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	gen "fake_client_test/gen"
+)
+
+func TestGreet(t *testing.T) {
+	users := &gen.FakeUserServiceClient{
+		GetUserFunc: func(_ context.Context, req *gen.GetUserRequest) (*gen.User, error) {
+			return &gen.User{Id: req.GetId(), Name: "Ada"}, nil
+		},
+	}
+	greeter := &Greeter{Users: users}
+
+	greeting, err := greeter.Greet(context.Background(), "u-1")
+	if err != nil {
+		t.Fatalf("Greet: %v", err)
+	}
+	if greeting != "Hello, Ada!" {
+		t.Errorf("greeting = %q, want %q", greeting, "Hello, Ada!")
+	}
+	if users.GetUserCalls() != 1 || users.GetUserLastRequest().GetId() != "u-1" {
+		t.Errorf("GetUser called %d times, last with %v, want once with u-1",
+			users.GetUserCalls(), users.GetUserLastRequest())
+	}
+}
+
+func TestUnfakedMethodFails(t *testing.T) {
+	greeter := &Greeter{Users: &gen.FakeUserServiceClient{}}
+	_, err := greeter.Greet(context.Background(), "u-1")
+	if err == nil || !strings.Contains(err.Error(), "GetUser is not faked") {
+		t.Errorf("Greet error = %v, want the unset GetUserFunc reported", err)
+	}
+}
+
+func TestFakeRecordsConcurrentCalls(t *testing.T) {
+	users := &gen.FakeUserServiceClient{
+		GetUserFunc: func(_ context.Context, req *gen.GetUserRequest) (*gen.User, error) {
+			return &gen.User{Id: req.GetId()}, nil
+		},
+	}
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = users.GetUser(context.Background(), &gen.GetUserRequest{Id: "u-1"})
+		}()
+	}
+	wg.Wait()
+	if got := users.GetUserCalls(); got != 10 {
+		t.Errorf("GetUserCalls() = %d, want 10", got)
+	}
+}
+`
@@ -292,16 +292,17 @@ func TestRepeatedQueryParamsUseAddNotSet(t *testing.T) {
 	repeatedFields := []struct {
 		fieldName string
 		paramName string
+		valueExpr string
 	}{
-		{"Countries", "countries"},
-		{"Years", "years"},
-		{"Flags", "flags"},
-		{"Regions", "regions"},
+		{"Countries", "countries", "fmt.Sprint(v)"},
+		{"Years", "years", "fmt.Sprint(v)"},
+		{"Flags", "flags", "fmt.Sprint(v)"},
+		{"Regions", "regions", "enumParamString(v)"},
 	}
 
 	for _, rf := range repeatedFields {
 		// Must contain for-range loop with Add()
-		addPattern := "queryParams.Add(\"" + rf.paramName + "\", fmt.Sprint(v))"
+		addPattern := "queryParams.Add(\"" + rf.paramName + "\", " + rf.valueExpr + ")"
 		if !strings.Contains(s, addPattern) {
 			t.Errorf("repeated field %s: missing queryParams.Add() pattern", rf.fieldName)
 		}
@@ -3,29 +3,75 @@ package clientgen
 import (
 	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/encodinggen"
+	"github.com/SebastienMelki/sebuf/internal/manifest"
 )
 
 // Generator handles HTTP client code generation for protobuf services.
 type Generator struct {
 	plugin       *protogen.Plugin
 	fileNeedsSSE *bool // set per-file before writeImports
+	// encoding emits the JSON encoders shared with protoc-gen-go-http.
+	encoding *encodinggen.Emitter
+	// validateRequests runs protovalidate on requests before they are sent.
+	validateRequests bool
+	// webhooks generates senders and signature verifiers for webhook messages.
+	webhooks bool
+	// extraCodecs lists the wire formats sent besides JSON and protobuf.
+	extraCodecs []ExtraCodec
+	// schemaFingerprint sends a fingerprint of the schema with every request.
+	schemaFingerprint bool
+	// manifest records the called routes when Run writes a manifest.
+	manifest *manifest.Builder
 }
 
 // New creates a new HTTP client generator.
 func New(plugin *protogen.Plugin) *Generator {
+	return NewWithOptions(plugin, Options{})
+}
+
+// NewWithOptions creates a new HTTP client generator with options.
+func NewWithOptions(plugin *protogen.Plugin, opts Options) *Generator {
 	return &Generator{
-		plugin: plugin,
+		plugin:            plugin,
+		encoding:          newEncodingEmitter(plugin, opts.AllEnumHelpers),
+		validateRequests:  opts.ValidateRequests,
+		webhooks:          opts.Webhooks,
+		extraCodecs:       opts.ExtraCodecs,
+		schemaFingerprint: opts.SchemaFingerprint,
 	}
 }
 
+// newEncodingEmitter creates the emitter of the shared JSON encoders. The client
+// forwards its protojson.UnmarshalOptions through UnmarshalJSONSebuf.
+func newEncodingEmitter(plugin *protogen.Plugin, allEnumHelpers bool) *encodinggen.Emitter {
+	return encodinggen.New(plugin, encodinggen.Options{
+		Generator:        "protoc-gen-go-client",
+		SebufUnmarshaler: true,
+		AllEnumHelpers:   allEnumHelpers,
+	})
+}
+
 // Generate processes all files and generates HTTP clients.
 func (g *Generator) Generate() error {
+	if err := g.validateExtraCodecs(); err != nil {
+		return err
+	}
+	if err := annotations.ValidateSyntax(g.plugin); err != nil {
+		return err
+	}
+	if err := annotations.ApplyVisibility(g.plugin, annotations.GeneratorGoClient); err != nil {
+		return err
+	}
 	for _, file := range g.plugin.Files {
 		if !file.Generate {
 			continue
@@ -39,10 +85,15 @@ func (g *Generator) Generate() error {
 
 func (g *Generator) generateFile(file *protogen.File) error {
 	// Validate enum annotations first - fail fast if conflicting annotations exist
-	if err := g.validateEnumAnnotationsInFile(file); err != nil {
+	if err := encodinggen.ValidateEnumAnnotationsInFile(file); err != nil {
 		return fmt.Errorf("enum annotation validation failed: %w", err)
 	}
 
+	// Messages protoc-gen-go-http gives an unwrap MarshalJSON in the same package.
+	// The encoders leave them out to avoid duplicate method declarations.
+	unwrapMsgNames := make(map[string]bool)
+	collectUnwrapMessageNames(file.Messages, unwrapMsgNames)
+
 	// Generate nullable encoding file if there are messages with nullable fields
 	if err := g.generateNullableEncodingFile(file); err != nil {
 		return err
@@ -54,12 +105,12 @@ func (g *Generator) generateFile(file *protogen.File) error {
 	}
 
 	// Generate timestamp_format encoding file if there are messages with timestamp format annotations
-	if err := g.generateTimestampFormatEncodingFile(file); err != nil {
+	if err := g.encoding.GenerateTimestampFormatEncodingFile(file); err != nil {
 		return err
 	}
 
 	// Generate bytes_encoding file if there are messages with non-default bytes encoding
-	if err := g.generateBytesEncodingFile(file); err != nil {
+	if err := g.encoding.GenerateBytesEncodingFile(file); err != nil {
 		return err
 	}
 
@@ -73,28 +124,50 @@ func (g *Generator) generateFile(file *protogen.File) error {
 		return err
 	}
 
-	if len(file.Services) == 0 {
+	// Generate json_naming file for messages whose JSON field names follow a json_naming
+	// policy and that no other encoder gives a MarshalJSON
+	if err := g.generateJSONNamingFile(file, unwrapMsgNames); err != nil {
+		return err
+	}
+
+	hasWebhooks := g.webhooks && len(annotations.GetFileWebhooks(file)) > 0
+	if len(file.Services) == 0 && !hasWebhooks {
 		return nil
 	}
 
 	// Generate client file
-	if err := g.generateClientFile(file); err != nil {
-		return err
+	if len(file.Services) > 0 {
+		if err := g.generateClientFile(file); err != nil {
+			return err
+		}
+		g.generateFakeFile(file)
+	}
+
+	// Generate webhooks file if there are messages with webhook annotations
+	if hasWebhooks {
+		if err := g.generateWebhooksFile(file); err != nil {
+			return err
+		}
 	}
 
 	// Generate encoding file if there are messages with int64_encoding=NUMBER annotations
-	if err := g.generateInt64EncodingFile(file); err != nil {
+	if err := g.encoding.GenerateInt64EncodingFile(file, unwrapMsgNames); err != nil {
 		return err
 	}
 
 	// Generate enum encoding file if there are enums with custom enum_value annotations
-	if err := g.generateEnumEncodingFile(file); err != nil {
+	if err := g.encoding.GenerateEnumEncodingFile(file); err != nil {
 		return err
 	}
 
 	// Generate enum-field encoding file so the client sends/receives custom enum_value strings
 	// (protojson emits raw proto value names). Depends on the lookup maps emitted above.
-	if err := g.generateEnumFieldEncodingFile(file); err != nil {
+	if err := g.encoding.GenerateEnumFieldEncodingFile(file); err != nil {
+		return err
+	}
+
+	// Generate enum helpers (Parse<Enum>, <Enum>Values, WireString) for application code
+	if err := g.encoding.GenerateEnumHelpersFile(file); err != nil {
 		return err
 	}
 
@@ -112,9 +185,13 @@ func (g *Generator) generateClientFile(file *protogen.File) error {
 	// Check if any method uses SSE streaming
 	hasSSE := g.fileHasSSEMethods(file)
 	g.fileNeedsSSE = &hasSSE
+	// Check if any path or query parameter is an enum
+	needsEnumParams := g.fileHasEnumParams(file)
+	// Check if any request is validated before it is sent
+	needsValidation := g.fileValidatesRequests(file)
 
 	g.writeHeader(gf, file)
-	g.writeImports(gf, needsBytes, needsURL)
+	g.writeImports(gf, needsBytes, needsURL, needsEnumParams, needsValidation)
 
 	// Generate content type constants once at file level
 	g.generateContentTypeConstants(gf)
@@ -122,6 +199,12 @@ func (g *Generator) generateClientFile(file *protogen.File) error {
 	// Generate sebufUnmarshaler interface once at file level
 	g.generateSebufUnmarshalerInterface(gf)
 
+	if needsEnumParams {
+		g.generateEnumParamStringFunc(gf)
+	}
+
+	g.generateResultTypes(gf, file)
+
 	for _, service := range file.Services {
 		if err := g.generateServiceClient(gf, file, service); err != nil {
 			return err
@@ -132,24 +215,40 @@ func (g *Generator) generateClientFile(file *protogen.File) error {
 }
 
 // fileNeedsURLImport checks if any method in the file needs the "net/url" import.
-// This is true when path parameters (url.PathEscape) or query parameters (url.Values) are used.
+// This is true when path or base path parameters (url.PathEscape) or query parameters (url.Values) are used.
 func (g *Generator) fileNeedsURLImport(file *protogen.File) bool {
 	for _, service := range file.Services {
+		// Base path params use url.PathEscape
+		if len(annotations.GetBasePathParams(service)) > 0 {
+			return true
+		}
 		for _, method := range service.Methods {
 			httpConfig := annotations.GetMethodHTTPConfig(method)
-			// Path params use url.PathEscape
-			if httpConfig != nil && len(httpConfig.PathParams) > 0 {
+			// Path params use url.PathEscape, except a wildcard
+			if httpConfig != nil && slices.ContainsFunc(httpConfig.PathParams, func(param string) bool {
+				return !annotations.IsPathWildcard(httpConfig.Path, param)
+			}) {
 				return true
 			}
-			// Determine HTTP method
-			httpMethod := http.MethodPost
-			if httpConfig != nil && httpConfig.Method != "" {
-				httpMethod = httpConfig.Method
+			// Query params use url.Values
+			if len(g.buildRPCMethodConfig(service, method).queryParams) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fileHasEnumParams checks if any method in the file binds an enum field as a path or query parameter.
+func (g *Generator) fileHasEnumParams(file *protogen.File) bool {
+	for _, service := range file.Services {
+		for _, method := range service.Methods {
+			cfg := g.buildRPCMethodConfig(service, method)
+			if len(cfg.enumPathParams) > 0 {
+				return true
 			}
-			// GET/DELETE with query params use url.Values
-			if httpMethod == http.MethodGet || httpMethod == http.MethodDelete {
-				queryParams := annotations.GetQueryParams(method.Input)
-				if len(queryParams) > 0 {
+			for _, qp := range cfg.queryParams {
+				if qp.Field != nil && qp.Field.Desc.Kind() == protoreflect.EnumKind {
 					return true
 				}
 			}
@@ -158,6 +257,32 @@ func (g *Generator) fileNeedsURLImport(file *protogen.File) bool {
 	return false
 }
 
+// validatesRequest reports whether the client validates the method's request
+// before sending it: validate_requests is on and the request carries rules.
+func (g *Generator) validatesRequest(method *protogen.Method) bool {
+	return g.validateRequests && annotations.HasValidationRules(method.Input.Desc)
+}
+
+// serviceValidatesRequests reports whether any method of the service validates its request.
+func (g *Generator) serviceValidatesRequests(service *protogen.Service) bool {
+	for _, method := range service.Methods {
+		if g.validatesRequest(method) {
+			return true
+		}
+	}
+	return false
+}
+
+// fileValidatesRequests reports whether any service of the file validates requests.
+func (g *Generator) fileValidatesRequests(file *protogen.File) bool {
+	for _, service := range file.Services {
+		if g.serviceValidatesRequests(service) {
+			return true
+		}
+	}
+	return false
+}
+
 // fileNeedsRequestBody checks if any method in the file needs a request body.
 func (g *Generator) fileNeedsRequestBody(file *protogen.File) bool {
 	for _, service := range file.Services {
@@ -183,7 +308,10 @@ func (g *Generator) writeHeader(gf *protogen.GeneratedFile, file *protogen.File)
 	gf.P()
 }
 
-func (g *Generator) writeImports(gf *protogen.GeneratedFile, needsBytes, needsURL bool) {
+func (g *Generator) writeImports(
+	gf *protogen.GeneratedFile,
+	needsBytes, needsURL, needsEnumParams, needsValidation bool,
+) {
 	needsSSE := g.fileNeedsSSE != nil && *g.fileNeedsSSE
 	gf.P("import (")
 	if needsSSE {
@@ -194,6 +322,9 @@ func (g *Generator) writeImports(gf *protogen.GeneratedFile, needsBytes, needsUR
 	}
 	gf.P(`"context"`)
 	gf.P(`"encoding/json"`)
+	if needsValidation {
+		gf.P(`"errors"`)
+	}
 	gf.P(`"fmt"`)
 	gf.P(`"io"`)
 	gf.P(`"net/http"`)
@@ -201,9 +332,17 @@ func (g *Generator) writeImports(gf *protogen.GeneratedFile, needsBytes, needsUR
 		gf.P(`"net/url"`)
 	}
 	gf.P(`"strings"`)
+	gf.P(`"time"`)
 	gf.P()
+	if needsValidation {
+		gf.P(`protovalidate "buf.build/go/protovalidate"`)
+	}
 	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
 	gf.P(`"google.golang.org/protobuf/proto"`)
+	if needsEnumParams {
+		gf.P(`"google.golang.org/protobuf/reflect/protoreflect"`)
+		gf.P(`"google.golang.org/protobuf/types/descriptorpb"`)
+	}
 	gf.P()
 	gf.P(`sebufhttp "github.com/SebastienMelki/sebuf/http"`)
 	gf.P(")")
@@ -216,12 +355,45 @@ func (g *Generator) generateServiceClient(
 	service *protogen.Service,
 ) error {
 	serviceName := service.GoName
+	if err := annotations.ValidateServiceVersions(service); err != nil {
+		return fmt.Errorf("%s: %w", service.Desc.Name(), err)
+	}
+	if err := annotations.ValidateStreamResponses(service); err != nil {
+		return err
+	}
+	if err := annotations.ValidateServiceHeaders(service); err != nil {
+		return err
+	}
+	if err := annotations.ValidateResponseStatuses(service); err != nil {
+		return err
+	}
+	if err := annotations.ValidateErrorResponses(g.plugin, service); err != nil {
+		return err
+	}
+	if err := annotations.ValidateRawBodyResponses(service); err != nil {
+		return err
+	}
+	if err := annotations.ValidateQueryEncodings(service); err != nil {
+		return err
+	}
+	if err := annotations.ValidateQueryAliases(service); err != nil {
+		return err
+	}
+	if err := annotations.ValidateBasePathParams(service); err != nil {
+		return err
+	}
+	if err := annotations.ValidateBodylessRequestFields(service); err != nil {
+		return err
+	}
+	versions := annotations.GetServiceVersions(service)
+	basePathParams := annotations.GetBasePathParams(service)
+	validates := g.serviceValidatesRequests(service)
 
 	// Generate client interface
 	g.generateClientInterface(gf, service)
 
 	// Generate client struct
-	g.generateClientStruct(gf, serviceName)
+	g.generateClientStruct(gf, serviceName, len(versions) > 0, len(basePathParams) > 0, validates)
 
 	// Generate ClientOption type and options
 	g.generateClientOptions(gf, serviceName)
@@ -232,14 +404,34 @@ func (g *Generator) generateServiceClient(
 	// Generate header helper options from annotations
 	g.generateHeaderHelperOptions(gf, service)
 
+	// Generate the API version option of versioned services
+	g.generateAPIVersionOption(gf, serviceName, versions)
+
+	// Generate the options setting the base path parameters
+	g.generateBasePathParamOptions(gf, serviceName, basePathParams)
+
 	// Generate constructor
-	g.generateConstructor(gf, serviceName)
+	g.generateConstructor(gf, serviceName, annotations.DefaultAPIVersion(versions), validates)
+	if g.schemaFingerprint {
+		g.generateSchemaFingerprint(gf, service)
+	}
 
 	// Generate EventStream type if any SSE methods
 	if g.serviceHasSSEMethods(service) {
 		g.generateEventStreamType(gf, serviceName)
 	}
 
+	// Generate ArrayStream type if any stream_response methods
+	if slices.ContainsFunc(service.Methods, annotations.IsStreamResponse) {
+		g.generateArrayStreamType(gf, serviceName)
+	}
+
+	// Generate routes and URL builders
+	g.generateRoutes(gf, service)
+	for _, method := range service.Methods {
+		g.generateURLBuilder(gf, g.buildRPCMethodConfig(service, method), method)
+	}
+
 	// Generate RPC methods
 	for _, method := range service.Methods {
 		if err := g.generateRPCMethod(gf, file, service, method); err != nil {
@@ -249,6 +441,12 @@ func (g *Generator) generateServiceClient(
 
 	// Generate helper methods
 	g.generateHelperMethods(gf, serviceName)
+	if len(basePathParams) > 0 {
+		g.generateBasePathMethod(gf, service, basePathParams)
+	}
+	if validates {
+		g.generateValidateRequestMethod(gf, serviceName)
+	}
 
 	return nil
 }
@@ -256,42 +454,45 @@ func (g *Generator) generateServiceClient(
 func (g *Generator) generateClientInterface(gf *protogen.GeneratedFile, service *protogen.Service) {
 	serviceName := service.GoName
 
-	gf.P("// ", serviceName, "Client is the client API for ", serviceName, " service.")
+	writeServiceDoc(gf, service, serviceName+"Client is the client API for "+serviceName+" service.")
 	gf.P("type ", serviceName, "Client interface {")
 	for _, method := range service.Methods {
-		httpConfig := annotations.GetMethodHTTPConfig(method)
-		isSSE := httpConfig != nil && httpConfig.Stream
-		if isSSE {
-			gf.P(
-				method.GoName,
-				"(ctx context.Context, req *",
-				method.Input.GoIdent,
-				", opts ...",
-				serviceName,
-				"CallOption) (*",
-				serviceName,
-				"EventStream[*",
-				method.Output.GoIdent,
-				"], error)",
-			)
-		} else {
-			gf.P(
-				method.GoName,
-				"(ctx context.Context, req *",
-				method.Input.GoIdent,
-				", opts ...",
-				serviceName,
-				"CallOption) (*",
-				method.Output.GoIdent,
-				", error)",
-			)
-		}
+		writeMethodDoc(gf, method, "")
+		gf.P(
+			method.GoName,
+			"(ctx context.Context, req *",
+			method.Input.GoIdent,
+			", opts ...",
+			serviceName,
+			"CallOption) (",
+			clientResultType(gf, service, method),
+			", error)",
+		)
 	}
 	gf.P("}")
 	gf.P()
 }
 
-func (g *Generator) generateClientStruct(gf *protogen.GeneratedFile, serviceName string) {
+// clientResultType returns the type a client method returns with its error:
+// an event stream for SSE methods, an array stream for stream_response methods,
+// and a pointer to the response otherwise.
+func clientResultType(gf *protogen.GeneratedFile, service *protogen.Service, method *protogen.Method) string {
+	httpConfig := annotations.GetMethodHTTPConfig(method)
+	switch {
+	case httpConfig != nil && httpConfig.Stream:
+		return "*" + service.GoName + "EventStream[*" + gf.QualifiedGoIdent(method.Output.GoIdent) + "]"
+	case annotations.IsStreamResponse(method):
+		return "*" + service.GoName + "ArrayStream[*" + gf.QualifiedGoIdent(streamResponseItem(method).GoIdent) + "]"
+	default:
+		return "*" + responseType(gf, method)
+	}
+}
+
+func (g *Generator) generateClientStruct(
+	gf *protogen.GeneratedFile,
+	serviceName string,
+	versioned, hasBasePathParams, validates bool,
+) {
 	lowerName := annotations.LowerFirst(serviceName)
 
 	gf.P("// ", lowerName, "Client is the implementation of ", serviceName, "Client.")
@@ -300,7 +501,24 @@ func (g *Generator) generateClientStruct(gf *protogen.GeneratedFile, serviceName
 	gf.P("httpClient *http.Client")
 	gf.P("contentType string")
 	gf.P("defaultHeaders map[string]string")
+	gf.P("propagatedHeaders []string")
+	gf.P("deadlineHeader string")
 	gf.P("discardUnknownFields bool")
+	gf.P("hedgeDelay time.Duration")
+	gf.P("maxHedges int")
+	gf.P("breaker *sebufhttp.CircuitBreaker")
+	gf.P("logger *sebufhttp.ClientLogger")
+	gf.P("shadow *sebufhttp.ShadowMirror")
+	gf.P("recorder sebufhttp.CallRecorder")
+	if versioned {
+		gf.P("apiVersion string")
+	}
+	if hasBasePathParams {
+		gf.P("basePathParams map[string]string")
+	}
+	if validates {
+		gf.P("validator protovalidate.Validator")
+	}
 	gf.P("}")
 	gf.P()
 
@@ -312,11 +530,18 @@ func (g *Generator) generateClientStruct(gf *protogen.GeneratedFile, serviceName
 func (g *Generator) generateContentTypeConstants(gf *protogen.GeneratedFile) {
 	gf.P("const (")
 	gf.P("// ContentTypeJSON is the content type for JSON requests/responses.")
-	gf.P(`ContentTypeJSON = "application/json"`)
+	gf.P(`ContentTypeJSON = sebufhttp.JSONContentType`)
 	gf.P("// ContentTypeProto is the content type for binary protobuf requests/responses.")
-	gf.P(`ContentTypeProto = "application/x-protobuf"`)
+	gf.P(`ContentTypeProto = sebufhttp.ProtoContentType`)
+	if g.sendsMsgpack() {
+		gf.P("// ContentTypeMsgpack is the content type for msgpack requests/responses.")
+		gf.P(`ContentTypeMsgpack = sebufhttp.MsgpackContentType`)
+	}
 	gf.P(")")
 	gf.P()
+	if g.sendsMsgpack() {
+		g.generateWireFormats(gf)
+	}
 }
 
 func (g *Generator) generateSebufUnmarshalerInterface(gf *protogen.GeneratedFile) {
@@ -328,6 +553,26 @@ func (g *Generator) generateSebufUnmarshalerInterface(gf *protogen.GeneratedFile
 	gf.P()
 }
 
+// generateEnumParamStringFunc generates the helper that serializes enum path and query
+// parameters using the same representation the server prefers when binding them.
+func (g *Generator) generateEnumParamStringFunc(gf *protogen.GeneratedFile) {
+	gf.P("// enumParamString returns the wire string for an enum path or query parameter:")
+	gf.P("// the custom enum_value string if annotated, otherwise the proto value name.")
+	gf.P("func enumParamString(v protoreflect.Enum) string {")
+	gf.P("desc := v.Descriptor().Values().ByNumber(v.Number())")
+	gf.P("if desc == nil {")
+	gf.P("return fmt.Sprint(v.Number())")
+	gf.P("}")
+	gf.P("if opts, ok := desc.Options().(*descriptorpb.EnumValueOptions); ok {")
+	gf.P("if custom, _ := proto.GetExtension(opts, sebufhttp.E_EnumValue).(string); custom != \"\" {")
+	gf.P("return custom")
+	gf.P("}")
+	gf.P("}")
+	gf.P("return string(desc.Name())")
+	gf.P("}")
+	gf.P()
+}
+
 func (g *Generator) generateClientOptions(gf *protogen.GeneratedFile, serviceName string) {
 	lowerName := annotations.LowerFirst(serviceName)
 
@@ -353,6 +598,9 @@ func (g *Generator) generateClientOptions(gf *protogen.GeneratedFile, serviceNam
 	gf.P("}")
 	gf.P("}")
 	gf.P()
+	if g.sendsMsgpack() {
+		g.generateWireFormatOption(gf, serviceName)
+	}
 
 	// With{Service}DefaultHeader
 	gf.P("// With", serviceName, "DefaultHeader sets a default header to include in all requests.")
@@ -366,6 +614,31 @@ func (g *Generator) generateClientOptions(gf *protogen.GeneratedFile, serviceNam
 	gf.P("}")
 	gf.P()
 
+	// With{Service}HeaderPropagation
+	gf.P("// With", serviceName, "HeaderPropagation copies the headers named in allowlist from the validated")
+	gf.P("// headers of the request a generated server is handling, when the call context carries them,")
+	gf.P("// onto every request, so calls made from a handler forward them without manual plumbing.")
+	gf.P("// They override default headers; headers set on the call override them.")
+	gf.P("func With", serviceName, "HeaderPropagation(allowlist ...string) ", serviceName, "ClientOption {")
+	gf.P("return func(c *", lowerName, "Client) {")
+	gf.P("c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	// With{Service}DeadlinePropagation
+	gf.P("// With", serviceName, "DeadlinePropagation sends the time left before the deadline of the call")
+	gf.P("// context, when it has one, in the header headerName of every request: in milliseconds, or")
+	gf.P("// in grpc-timeout form when headerName is grpc-timeout. Servers configured with")
+	gf.P("// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.")
+	gf.P("// Headers set on the call override it.")
+	gf.P("func With", serviceName, "DeadlinePropagation(headerName string) ", serviceName, "ClientOption {")
+	gf.P("return func(c *", lowerName, "Client) {")
+	gf.P("c.deadlineHeader = headerName")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
 	// With{Service}DiscardUnknownFields
 	gf.P("// With", serviceName, "DiscardUnknownFields sets whether to discard unknown fields in JSON responses.")
 	gf.P("// When true, unknown fields are silently ignored instead of causing unmarshal errors.")
@@ -375,6 +648,113 @@ func (g *Generator) generateClientOptions(gf *protogen.GeneratedFile, serviceNam
 	gf.P("}")
 	gf.P("}")
 	gf.P()
+
+	// With{Service}Hedging
+	gf.P("// With", serviceName, "Hedging sends up to maxHedges extra copies of a request, one every delay,")
+	gf.P("// while no response has arrived. The first response wins and the others are cancelled.")
+	gf.P("// Only GET methods and methods annotated with idempotency: true are hedged.")
+	gf.P("func With", serviceName, "Hedging(delay time.Duration, maxHedges int) ", serviceName, "ClientOption {")
+	gf.P("return func(c *", lowerName, "Client) {")
+	gf.P("c.hedgeDelay = delay")
+	gf.P("c.maxHedges = maxHedges")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	// With{Service}CircuitBreaker
+	gf.P("// With", serviceName, "CircuitBreaker stops sending requests while the server keeps failing.")
+	gf.P("// Transport errors and 5xx responses count as failures; rejected calls return an error")
+	gf.P("// wrapping sebufhttp.ErrCircuitOpen.")
+	gf.P("func With", serviceName, "CircuitBreaker(cfg sebufhttp.BreakerConfig) ", serviceName, "ClientOption {")
+	gf.P("return func(c *", lowerName, "Client) {")
+	gf.P("c.breaker = sebufhttp.NewCircuitBreaker(cfg)")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	// With{Service}Recorder
+	gf.P("// With", serviceName, "Recorder passes every call through recorder, which records it or")
+	gf.P("// answers it from an earlier recording without sending it, such as a cassette.Recorder")
+	gf.P("// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.")
+	gf.P("func With", serviceName, "Recorder(recorder sebufhttp.CallRecorder) ", serviceName, "ClientOption {")
+	gf.P("return func(c *", lowerName, "Client) {")
+	gf.P("c.recorder = recorder")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	g.generateLoggingOptions(gf, serviceName)
+	g.generateShadowOptions(gf, serviceName)
+}
+
+// generateLoggingOptions generates the options configuring the client's
+// sebufhttp.ClientLogger, which each create it on first use.
+func (g *Generator) generateLoggingOptions(gf *protogen.GeneratedFile, serviceName string) {
+	lowerName := annotations.LowerFirst(serviceName)
+	option := func(name, param, field string) {
+		gf.P("func With", serviceName, name, "(", param, ") ", serviceName, "ClientOption {")
+		gf.P("return func(c *", lowerName, "Client) {")
+		gf.P("if c.logger == nil {")
+		gf.P("c.logger = &sebufhttp.ClientLogger{}")
+		gf.P("}")
+		gf.P("c.logger.", field)
+		gf.P("}")
+		gf.P("}")
+		gf.P()
+	}
+
+	// With{Service}DebugLogging
+	gf.P("// With", serviceName, "DebugLogging writes a dump of every call to w: a curl command")
+	gf.P("// equivalent to the request, then the response status, latency and body. Sensitive")
+	gf.P("// fields and credential headers are redacted. Meant for development, not production.")
+	option("DebugLogging", "w io.Writer", "Debug = w")
+
+	// With{Service}DebugBodyLimit
+	gf.P("// With", serviceName, "DebugBodyLimit sets the number of bytes of a body the debug dump")
+	gf.P("// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.")
+	option("DebugBodyLimit", "limit int", "BodyLimit = limit")
+
+	// With{Service}LogHook
+	gf.P("// With", serviceName, "LogHook calls hook after every call with its method, URL, status,")
+	gf.P("// latency, error and hedge attempt, for structured logging and metrics.")
+	option("LogHook", "hook func(sebufhttp.ClientLogEvent)", "Hook = hook")
+}
+
+// generateShadowOptions generates the options configuring the client's
+// sebufhttp.ShadowMirror, which each create it on first use.
+func (g *Generator) generateShadowOptions(gf *protogen.GeneratedFile, serviceName string) {
+	lowerName := annotations.LowerFirst(serviceName)
+	option := func(name, param string, fields ...string) {
+		gf.P("func With", serviceName, name, "(", param, ") ", serviceName, "ClientOption {")
+		gf.P("return func(c *", lowerName, "Client) {")
+		gf.P("if c.shadow == nil {")
+		gf.P("c.shadow = &sebufhttp.ShadowMirror{}")
+		gf.P("}")
+		for _, field := range fields {
+			gf.P("c.shadow.", field)
+		}
+		gf.P("}")
+		gf.P("}")
+		gf.P()
+	}
+
+	// With{Service}Shadow
+	gf.P("// With", serviceName, "Shadow mirrors a sampleRate fraction of calls to the server at baseURL")
+	gf.P("// once their response arrived, and passes both responses to compare in the background.")
+	gf.P("// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD")
+	gf.P("// and OPTIONS methods are mirrored unless With", serviceName, "ShadowMutations is set.")
+	option("Shadow", "baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)",
+		"BaseURL = baseURL", "SampleRate = sampleRate", "Compare = compare")
+
+	// With{Service}ShadowMutations
+	gf.P("// With", serviceName, "ShadowMutations also mirrors methods that may change state, such as POST,")
+	gf.P("// which then execute on the shadow server too.")
+	option("ShadowMutations", "", "Mutations = true")
+
+	// With{Service}ShadowTimeout
+	gf.P("// With", serviceName, "ShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout")
+	gf.P("// by default, independently of the caller's context.")
+	option("ShadowTimeout", "timeout time.Duration", "Timeout = timeout")
 }
 
 func (g *Generator) generateCallOptions(gf *protogen.GeneratedFile, serviceName string) {
@@ -391,6 +771,7 @@ func (g *Generator) generateCallOptions(gf *protogen.GeneratedFile, serviceName
 	gf.P("headers map[string]string")
 	gf.P("contentType string")
 	gf.P("discardUnknownFields *bool")
+	gf.P("responseMetadata *sebufhttp.ResponseMetadata")
 	gf.P("}")
 	gf.P()
 
@@ -427,6 +808,17 @@ func (g *Generator) generateCallOptions(gf *protogen.GeneratedFile, serviceName
 	gf.P("}")
 	gf.P("}")
 	gf.P()
+
+	// With{Service}ResponseMetadata
+	gf.P("// With", serviceName, "ResponseMetadata fills metadata with the status, headers and warnings")
+	gf.P("// of the response to a unary call, such as those of a partial response.")
+	gf.P("func With", serviceName, "ResponseMetadata(metadata *sebufhttp.ResponseMetadata) ", serviceName,
+		"CallOption {")
+	gf.P("return func(o *", lowerName, "CallOptions) {")
+	gf.P("o.responseMetadata = metadata")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
 }
 
 func (g *Generator) generateHeaderHelperOptions(gf *protogen.GeneratedFile, service *protogen.Service) {
@@ -453,31 +845,135 @@ func (g *Generator) generateHeaderOption(
 	header *sebufhttp.Header,
 	isClientOption bool,
 ) {
-	headerName := header.GetName()
-	funcName := headerNameToFuncName(headerName)
+	headerName := annotations.CanonicalHeaderName(header.GetName())
+	funcName := headerNameToFuncName(header.GetName())
 	description := header.GetDescription()
 	if description == "" {
 		description = fmt.Sprintf("sets the %s header", headerName)
 	}
 
+	// A multiple header takes its values as a list, sent as one comma-separated line
+	params, value := "value string", "value"
+	if header.GetMultiple() {
+		params, value = "values ...string", `strings.Join(values, ", ")`
+	}
+
 	if isClientOption {
 		// Generate ClientOption for service-level headers
 		gf.P("// With", serviceName, funcName, " ", description)
-		gf.P("func With", serviceName, funcName, "(value string) ", serviceName, "ClientOption {")
-		gf.P("return With", serviceName, "DefaultHeader(\"", headerName, "\", value)")
+		gf.P("func With", serviceName, funcName, "(", params, ") ", serviceName, "ClientOption {")
+		gf.P("return With", serviceName, "DefaultHeader(\"", headerName, "\", ", value, ")")
 		gf.P("}")
 		gf.P()
 	}
 
 	// Generate CallOption for both service and method headers
 	gf.P("// With", serviceName, "Call", funcName, " ", description, " for a single request.")
-	gf.P("func With", serviceName, "Call", funcName, "(value string) ", serviceName, "CallOption {")
-	gf.P("return With", serviceName, "Header(\"", headerName, "\", value)")
+	gf.P("func With", serviceName, "Call", funcName, "(", params, ") ", serviceName, "CallOption {")
+	gf.P("return With", serviceName, "Header(\"", headerName, "\", ", value, ")")
+	gf.P("}")
+	gf.P()
+}
+
+// generateAPIVersionOption generates, for a versioned service, the map from version names
+// to base paths and the With{Service}APIVersion option choosing the version to call.
+func (g *Generator) generateAPIVersionOption(
+	gf *protogen.GeneratedFile,
+	serviceName string,
+	versions []annotations.APIVersion,
+) {
+	defaultVersion := annotations.DefaultAPIVersion(versions)
+	if defaultVersion == nil {
+		return
+	}
+	lowerName := annotations.LowerFirst(serviceName)
+
+	names := make([]string, 0, len(versions))
+	gf.P("// ", lowerName, "APIVersions maps the API versions of ", serviceName, " to their base paths.")
+	gf.P("var ", lowerName, "APIVersions = map[string]string{")
+	for _, version := range versions {
+		gf.P(`"`, version.Name, `": "`, version.BasePath, `",`)
+		name := version.Name
+		if version.Deprecated {
+			name += " (deprecated)"
+		}
+		names = append(names, name)
+	}
+	gf.P("}")
+	gf.P()
+
+	gf.P("// With", serviceName, "APIVersion selects the API version the client calls: ", strings.Join(names, ", "), ".")
+	gf.P("// Defaults to ", defaultVersion.Name, ". Calls fail when version is not one of them.")
+	gf.P("func With", serviceName, "APIVersion(version string) ", serviceName, "ClientOption {")
+	gf.P("return func(c *", lowerName, "Client) {")
+	gf.P("c.apiVersion = version")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+}
+
+// generateBasePathParamOptions generates one option per base path parameter,
+// setting the value the client substitutes into the base path of every call.
+func (g *Generator) generateBasePathParamOptions(
+	gf *protogen.GeneratedFile,
+	serviceName string,
+	params []annotations.BasePathParam,
+) {
+	lowerName := annotations.LowerFirst(serviceName)
+	for _, param := range params {
+		optionName := "With" + serviceName + snakeToUpperCamel(param.Name)
+		gf.P("// ", optionName, " sets the {", param.Name, "} parameter of the ", serviceName, " base path.")
+		gf.P("// Calls fail when it is not set.")
+		gf.P("func ", optionName, "(value string) ", serviceName, "ClientOption {")
+		gf.P("return func(c *", lowerName, "Client) {")
+		gf.P("if c.basePathParams == nil {")
+		gf.P("c.basePathParams = make(map[string]string)")
+		gf.P("}")
+		gf.P(`c.basePathParams["`, param.Name, `"] = value`)
+		gf.P("}")
+		gf.P("}")
+		gf.P()
+	}
+}
+
+// generateBasePathMethod generates the method returning the base path of a
+// service with its parameters filled in from the client options.
+func (g *Generator) generateBasePathMethod(
+	gf *protogen.GeneratedFile,
+	service *protogen.Service,
+	params []annotations.BasePathParam,
+) {
+	serviceName := service.GoName
+	lowerName := annotations.LowerFirst(serviceName)
+	gf.P("// basePath returns the ", serviceName, " base path with its parameters filled in.")
+	gf.P("func (c *", lowerName, "Client) basePath() (string, error) {")
+	if len(annotations.GetServiceVersions(service)) > 0 {
+		gf.P("basePath, ok := ", lowerName, "APIVersions[c.apiVersion]")
+		gf.P("if !ok {")
+		gf.P(`return "", fmt.Errorf("unknown `, serviceName, ` API version %q", c.apiVersion)`)
+		gf.P("}")
+	} else {
+		gf.P(`basePath := "`, annotations.GetServiceBasePath(service), `"`)
+	}
+	for _, param := range params {
+		gf.P(`if c.basePathParams["`, param.Name, `"] == "" {`)
+		gf.P(`return "", fmt.Errorf("`, serviceName, ` base path parameter `, param.Name,
+			` is not set: use With`, serviceName, snakeToUpperCamel(param.Name), `")`)
+		gf.P("}")
+		gf.P(`basePath = strings.ReplaceAll(basePath, "{`, param.Name, `}", url.PathEscape(c.basePathParams["`,
+			param.Name, `"]))`)
+	}
+	gf.P("return basePath, nil")
 	gf.P("}")
 	gf.P()
 }
 
-func (g *Generator) generateConstructor(gf *protogen.GeneratedFile, serviceName string) {
+func (g *Generator) generateConstructor(
+	gf *protogen.GeneratedFile,
+	serviceName string,
+	defaultVersion *annotations.APIVersion,
+	validates bool,
+) {
 	lowerName := annotations.LowerFirst(serviceName)
 
 	gf.P("// New", serviceName, "Client creates a new ", serviceName, " client.")
@@ -494,8 +990,19 @@ func (g *Generator) generateConstructor(gf *protogen.GeneratedFile, serviceName
 	gf.P("baseURL: strings.TrimSuffix(baseURL, \"/\"),")
 	gf.P("httpClient: http.DefaultClient,")
 	gf.P("contentType: ContentTypeJSON,")
-	gf.P("defaultHeaders: make(map[string]string),")
+	if g.schemaFingerprint {
+		gf.P("defaultHeaders: map[string]string{sebufhttp.SchemaHeader: ", schemaFingerprintConst(serviceName), "},")
+	} else {
+		gf.P("defaultHeaders: make(map[string]string),")
+	}
+	if defaultVersion != nil {
+		gf.P(`apiVersion: "`, defaultVersion.Name, `",`)
+	}
 	gf.P("}")
+	if validates {
+		gf.P("// Like the server, send requests unvalidated if no validator can be built")
+		gf.P("c.validator, _ = protovalidate.New()")
+	}
 	gf.P()
 	gf.P("for _, opt := range opts {")
 	gf.P("opt(c)")
@@ -513,10 +1020,33 @@ type rpcMethodConfig struct {
 	methodName  string
 	httpMethod  string
 	fullPath    string
-	pathParams  []string
-	queryParams []annotations.QueryParam
-	hasBody     bool
-	isSSE       bool
+	// versioned is true for services with versions; fullPath is then relative to the
+	// base path of the version the client calls.
+	versioned bool
+	// hasBasePathParams is true for services whose base path has parameters;
+	// fullPath is then relative to the base path, which basePath fills in.
+	hasBasePathParams bool
+	pathParams        []string
+	queryParams       []annotations.QueryParam // sent in the URL query string
+	hasBody           bool
+	isSSE             bool
+	// streamResponse is true for stream_response methods, whose items are read as they arrive.
+	streamResponse bool
+	// hedge is true for methods safe to hedge: GET and idempotency-annotated methods.
+	hedge bool
+	// timeoutMs is the timeout_ms annotation, applied when ctx has no deadline.
+	timeoutMs int32
+	// headerParams holds the request fields declared with source HEADER.
+	headerParams []annotations.HeaderFieldParam
+	// bodyExcluded holds the request fields declared with a non-body source.
+	bodyExcluded []*protogen.Field
+	// enumPathParams holds the path parameters bound to enum fields.
+	enumPathParams map[string]bool
+	// presencePathParams holds the path parameters bound to fields with
+	// explicit presence, whose Go struct fields are pointers.
+	presencePathParams map[string]bool
+	// headerDefaults holds the service and method headers with a default_value.
+	headerDefaults []*sebufhttp.Header
 }
 
 func (g *Generator) buildRPCMethodConfig(service *protogen.Service, method *protogen.Method) *rpcMethodConfig {
@@ -539,13 +1069,26 @@ func (g *Generator) buildRPCMethodConfig(service *protogen.Service, method *prot
 		pathParams = httpConfig.PathParams
 	}
 
-	// Get base path from service config
+	// Get base path from service config. Versioned services and services with base
+	// path parameters prepend their base path at request time.
 	basePath := annotations.GetServiceBasePath(service)
+	versioned := len(annotations.GetServiceVersions(service)) > 0
+	hasBasePathParams := len(annotations.GetBasePathParams(service)) > 0
+	if versioned || hasBasePathParams {
+		basePath = ""
+	}
 
 	// Combine base path and method path
 	fullPath := annotations.BuildHTTPPath(basePath, httpPath)
 
 	isSSE := httpConfig != nil && httpConfig.Stream
+	streamResponse := httpConfig != nil && httpConfig.StreamResponse
+	hasBody := httpMethod == "POST" || httpMethod == "PUT" || httpMethod == "PATCH"
+	idempotent := httpConfig != nil && httpConfig.Idempotency
+	var timeoutMs int32
+	if httpConfig != nil {
+		timeoutMs = httpConfig.TimeoutMs
+	}
 
 	return &rpcMethodConfig{
 		serviceName: serviceName,
@@ -553,13 +1096,48 @@ func (g *Generator) buildRPCMethodConfig(service *protogen.Service, method *prot
 		methodName:  methodName,
 		httpMethod:  httpMethod,
 		fullPath:    fullPath,
+		versioned:   versioned,
 		pathParams:  pathParams,
-		queryParams: annotations.GetQueryParams(method.Input),
-		hasBody:     httpMethod == "POST" || httpMethod == "PUT" || httpMethod == "PATCH",
+
+		hasBasePathParams: hasBasePathParams,
+
+		queryParams: annotations.GetURLQueryParams(method.Input, hasBody),
+		hasBody:     hasBody,
 		isSSE:       isSSE,
+		hedge:       !isSSE && !streamResponse && (httpMethod == http.MethodGet || idempotent),
+		timeoutMs:   timeoutMs,
+
+		streamResponse: streamResponse,
+
+		headerParams: annotations.GetHeaderFieldParams(method.Input),
+		bodyExcluded: annotations.GetBodyExcludedFields(method.Input),
+		enumPathParams: pathParamSet(method.Input, pathParams, func(field *protogen.Field) bool {
+			return field.Desc.Kind() == protoreflect.EnumKind
+		}),
+		presencePathParams: pathParamSet(method.Input, pathParams, annotations.HasExplicitPresence),
+		headerDefaults: annotations.HeadersWithDefaults(annotations.CombineHeaders(
+			annotations.GetServiceHeaders(service), annotations.GetMethodHeaders(method),
+		)),
 	}
 }
 
+// pathParamSet returns the subset of path parameters that bind to fields
+// matching match.
+func pathParamSet(input *protogen.Message, pathParams []string, match func(*protogen.Field) bool) map[string]bool {
+	var out map[string]bool
+	for _, param := range pathParams {
+		for _, field := range input.Fields {
+			if string(field.Desc.Name()) == param && match(field) {
+				if out == nil {
+					out = make(map[string]bool)
+				}
+				out[param] = true
+			}
+		}
+	}
+	return out
+}
+
 func (g *Generator) generateRPCMethod(
 	gf *protogen.GeneratedFile,
 	_ *protogen.File,
@@ -567,41 +1145,100 @@ func (g *Generator) generateRPCMethod(
 	method *protogen.Method,
 ) error {
 	cfg := g.buildRPCMethodConfig(service, method)
+	pathParams := append(annotations.GetBoundBasePathParams(service), cfg.pathParams...)
+	if err := annotations.ValidateFieldSources(method, pathParams); err != nil {
+		return err
+	}
+	g.recordRoutes(service, method, cfg)
 
 	if cfg.isSSE {
 		return g.generateSSERPCMethod(gf, cfg, method)
 	}
+	if cfg.streamResponse {
+		return g.generateStreamResponseRPCMethod(gf, cfg, method)
+	}
 
 	g.generateRPCMethodSignature(gf, cfg, method)
 	g.generateRPCMethodCallOptions(gf, cfg)
+	g.generateRPCMethodValidation(gf, method)
+	g.generateRPCMethodDeadline(gf, cfg)
 	g.generateRPCMethodURLBuilding(gf, cfg)
 	g.generateRPCMethodRequest(gf, cfg)
 	g.generateRPCMethodHeaders(gf, cfg)
-	g.generateRPCMethodExecution(gf)
+	g.generateRPCMethodExecution(gf, cfg, method)
 	g.generateRPCMethodResponse(gf, method)
 
 	return nil
 }
 
+// recordRoutes records the routes the method calls in the manifest: one per API
+// version for versioned services.
+func (g *Generator) recordRoutes(service *protogen.Service, method *protogen.Method, cfg *rpcMethodConfig) {
+	if !cfg.versioned {
+		path := cfg.fullPath
+		if cfg.hasBasePathParams {
+			path = annotations.GetServiceBasePath(service) + path
+		}
+		g.manifest.AddRoute(method, cfg.httpMethod, path)
+		return
+	}
+	for _, version := range annotations.GetServiceVersions(service) {
+		g.manifest.AddRoute(method, cfg.httpMethod, version.BasePath+cfg.fullPath)
+	}
+}
+
 // generateSSERPCMethod generates a client method for SSE streaming endpoints.
-//
-//nolint:funlen // SSE method generation requires many sequential code blocks
 func (g *Generator) generateSSERPCMethod(
 	gf *protogen.GeneratedFile,
 	cfg *rpcMethodConfig,
 	method *protogen.Method,
 ) error {
+	writeMethodDoc(gf, method, cfg.methodName+" calls the "+cfg.methodName+" SSE streaming RPC.")
+	streamType := cfg.serviceName + "EventStream[*" + gf.QualifiedGoIdent(method.Output.GoIdent) + "]"
+	g.generateStreamingRPCMethod(gf, cfg, method, streamType, "text/event-stream", func() {
+		gf.P("reader:               bufio.NewReader(resp.Body),")
+	})
+	return nil
+}
+
+// generateStreamResponseRPCMethod generates a client method for stream_response
+// endpoints, which returns once the response starts and decodes the items as
+// they arrive.
+func (g *Generator) generateStreamResponseRPCMethod(
+	gf *protogen.GeneratedFile,
+	cfg *rpcMethodConfig,
+	method *protogen.Method,
+) error {
+	writeMethodDoc(gf, method, cfg.methodName+" calls the "+cfg.methodName+
+		" RPC and reads the items of its response as they arrive.")
+	streamType := cfg.serviceName + "ArrayStream[*" + gf.QualifiedGoIdent(streamResponseItem(method).GoIdent) + "]"
+	g.generateStreamingRPCMethod(gf, cfg, method, streamType, "application/json", func() {
+		gf.P("decoder:              json.NewDecoder(resp.Body),")
+	})
+	return nil
+}
+
+// generateStreamingRPCMethod generates a client method returning streamType
+// over the open response body; fields writes the stream's reader field.
+//
+//nolint:funlen // streaming method generation requires many sequential code blocks
+func (g *Generator) generateStreamingRPCMethod(
+	gf *protogen.GeneratedFile,
+	cfg *rpcMethodConfig,
+	method *protogen.Method,
+	streamType, accept string,
+	fields func(),
+) {
 	// Method signature
-	gf.P("// ", cfg.methodName, " calls the ", cfg.methodName, " SSE streaming RPC.")
 	gf.P(
 		"func (c *", cfg.lowerName, "Client) ", cfg.methodName,
 		"(ctx context.Context, req *", method.Input.GoIdent,
-		", opts ...", cfg.serviceName, "CallOption) (*",
-		cfg.serviceName, "EventStream[*", method.Output.GoIdent, "], error) {",
+		", opts ...", cfg.serviceName, "CallOption) (*", streamType, ", error) {",
 	)
 
 	// Call options
 	g.generateRPCMethodCallOptions(gf, cfg)
+	g.generateRPCMethodValidation(gf, method)
 
 	// URL building
 	g.generateRPCMethodURLBuilding(gf, cfg)
@@ -616,8 +1253,7 @@ func (g *Generator) generateSSERPCMethod(
 
 	// Create request
 	if cfg.hasBody {
-		gf.P("// Marshal request body")
-		gf.P("body, err := c.marshalRequest(req, contentType)")
+		g.generateRequestBodyMarshal(gf, cfg)
 		gf.P("if err != nil {")
 		gf.P("return nil, fmt.Errorf(\"failed to marshal request: %w\", err)")
 		gf.P("}")
@@ -636,18 +1272,28 @@ func (g *Generator) generateSSERPCMethod(
 	gf.P()
 	gf.P("// Set headers")
 	gf.P("httpReq.Header.Set(\"Content-Type\", contentType)")
-	gf.P("httpReq.Header.Set(\"Accept\", \"text/event-stream\")")
+	gf.P("httpReq.Header.Set(\"Accept\", \"", accept, "\")")
+	g.generateHeaderDefaults(gf, cfg)
 	gf.P("for k, v := range c.defaultHeaders {")
 	gf.P("httpReq.Header.Set(k, v)")
 	gf.P("}")
+	gf.P("for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {")
+	gf.P("httpReq.Header.Set(k, v)")
+	gf.P("}")
+	gf.P("if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {")
+	gf.P("httpReq.Header.Set(c.deadlineHeader, timeout)")
+	gf.P("}")
 	gf.P("for k, v := range callOpts.headers {")
 	gf.P("httpReq.Header.Set(k, v)")
 	gf.P("}")
+	g.generateHeaderFieldParams(gf, cfg)
 
 	// Execute - do NOT defer resp.Body.Close() since caller owns the stream
 	gf.P()
 	gf.P("// Execute request")
-	gf.P("resp, err := c.httpClient.Do(httpReq)")
+	g.generateLoggedCall(gf, cfg, "", true, func() {
+		gf.P("return c.httpClient.Do(httpReq)")
+	})
 	gf.P("if err != nil {")
 	gf.P("return nil, fmt.Errorf(\"failed to execute request: %w\", err)")
 	gf.P("}")
@@ -661,7 +1307,7 @@ func (g *Generator) generateSSERPCMethod(
 	gf.P("if readErr != nil {")
 	gf.P("return nil, fmt.Errorf(\"failed to read error response: %w\", readErr)")
 	gf.P("}")
-	gf.P("return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)")
+	g.generateErrorStatusHandling(gf, method)
 	gf.P("}")
 	gf.P()
 
@@ -672,16 +1318,14 @@ func (g *Generator) generateSSERPCMethod(
 	gf.P("}")
 	gf.P()
 
-	// Return EventStream
-	gf.P("return &", cfg.serviceName, "EventStream[*", method.Output.GoIdent, "]{")
+	// Return the stream
+	gf.P("return &", streamType, "{")
 	gf.P("resp:                 resp,")
-	gf.P("reader:               bufio.NewReader(resp.Body),")
+	fields()
 	gf.P("discardUnknownFields: discardUnknown,")
 	gf.P("}, nil")
 	gf.P("}")
 	gf.P()
-
-	return nil
 }
 
 func (g *Generator) generateRPCMethodSignature(
@@ -689,11 +1333,11 @@ func (g *Generator) generateRPCMethodSignature(
 	cfg *rpcMethodConfig,
 	method *protogen.Method,
 ) {
-	gf.P("// ", cfg.methodName, " calls the ", cfg.methodName, " RPC.")
+	writeMethodDoc(gf, method, cfg.methodName+" calls the "+cfg.methodName+" RPC.")
 	gf.P(
 		"func (c *", cfg.lowerName, "Client) ", cfg.methodName,
 		"(ctx context.Context, req *", method.Input.GoIdent,
-		", opts ...", cfg.serviceName, "CallOption) (*", method.Output.GoIdent, ", error) {",
+		", opts ...", cfg.serviceName, "CallOption) (*", responseType(gf, method), ", error) {",
 	)
 }
 
@@ -705,9 +1349,51 @@ func (g *Generator) generateRPCMethodCallOptions(gf *protogen.GeneratedFile, cfg
 	gf.P()
 }
 
+// generateRPCMethodValidation rejects an invalid request before anything is
+// sent, with the ValidationError the server would return for it.
+func (g *Generator) generateRPCMethodValidation(gf *protogen.GeneratedFile, method *protogen.Method) {
+	if !g.validatesRequest(method) {
+		return
+	}
+	gf.P("if err := c.validateRequest(req); err != nil {")
+	gf.P("return nil, err")
+	gf.P("}")
+	gf.P()
+}
+
+// generateRPCMethodDeadline bounds the call by the method's timeout_ms when the
+// caller's context has no deadline of its own.
+func (g *Generator) generateRPCMethodDeadline(gf *protogen.GeneratedFile, cfg *rpcMethodConfig) {
+	if cfg.timeoutMs <= 0 {
+		return
+	}
+	gf.P("if _, ok := ctx.Deadline(); !ok {")
+	gf.P("var cancel context.CancelFunc")
+	gf.P("ctx, cancel = context.WithTimeout(ctx, ", cfg.timeoutMs, "*time.Millisecond)")
+	gf.P("defer cancel()")
+	gf.P("}")
+	gf.P()
+}
+
 func (g *Generator) generateRPCMethodURLBuilding(gf *protogen.GeneratedFile, cfg *rpcMethodConfig) {
 	gf.P("// Build URL")
-	g.generateURLBuilding(gf, cfg.fullPath, cfg.pathParams, cfg.queryParams, cfg.httpMethod)
+	if cfg.hasBasePathParams {
+		gf.P("basePath, err := c.basePath()")
+		gf.P("if err != nil {")
+		gf.P("return nil, err")
+		gf.P("}")
+		gf.P("reqURL := c.baseURL + basePath + ", urlBuilderName(cfg), "(req)")
+		return
+	}
+	if !cfg.versioned {
+		gf.P("reqURL := c.baseURL + ", urlBuilderName(cfg), "(req)")
+		return
+	}
+	gf.P("basePath, ok := ", cfg.lowerName, "APIVersions[c.apiVersion]")
+	gf.P("if !ok {")
+	gf.P(`return nil, fmt.Errorf("unknown `, cfg.serviceName, ` API version %q", c.apiVersion)`)
+	gf.P("}")
+	gf.P("reqURL := c.baseURL + basePath + ", urlBuilderName(cfg), "(req)")
 }
 
 func (g *Generator) generateRPCMethodRequest(gf *protogen.GeneratedFile, cfg *rpcMethodConfig) {
@@ -719,8 +1405,7 @@ func (g *Generator) generateRPCMethodRequest(gf *protogen.GeneratedFile, cfg *rp
 	gf.P()
 
 	if cfg.hasBody {
-		gf.P("// Marshal request body")
-		gf.P("body, err := c.marshalRequest(req, contentType)")
+		g.generateRequestBodyMarshal(gf, cfg)
 		gf.P("if err != nil {")
 		gf.P("return nil, fmt.Errorf(\"failed to marshal request: %w\", err)")
 		gf.P("}")
@@ -737,28 +1422,143 @@ func (g *Generator) generateRPCMethodRequest(gf *protogen.GeneratedFile, cfg *rp
 	gf.P("}")
 }
 
-func (g *Generator) generateRPCMethodHeaders(gf *protogen.GeneratedFile, _ *rpcMethodConfig) {
+func (g *Generator) generateRPCMethodHeaders(gf *protogen.GeneratedFile, cfg *rpcMethodConfig) {
 	gf.P()
 	gf.P("// Set headers")
 	gf.P("httpReq.Header.Set(\"Content-Type\", contentType)")
+	g.generateHeaderDefaults(gf, cfg)
 	gf.P("for k, v := range c.defaultHeaders {")
 	gf.P("httpReq.Header.Set(k, v)")
 	gf.P("}")
+	gf.P("for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {")
+	gf.P("httpReq.Header.Set(k, v)")
+	gf.P("}")
+	gf.P("if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {")
+	gf.P("httpReq.Header.Set(c.deadlineHeader, timeout)")
+	gf.P("}")
 	gf.P("for k, v := range callOpts.headers {")
 	gf.P("httpReq.Header.Set(k, v)")
 	gf.P("}")
+	g.generateHeaderFieldParams(gf, cfg)
+}
+
+// generateHeaderDefaults sets the declared header defaults. It must precede the
+// default and call header loops so that both override the defaults.
+func (g *Generator) generateHeaderDefaults(gf *protogen.GeneratedFile, cfg *rpcMethodConfig) {
+	for _, header := range cfg.headerDefaults {
+		name := annotations.CanonicalHeaderName(header.GetName())
+		gf.P("httpReq.Header.Set(", strconv.Quote(name), ", ", strconv.Quote(header.GetDefaultValue()), ")")
+	}
+}
+
+// generateRequestBodyMarshal marshals the request body, leaving out the fields
+// declared with a query, path, or header source.
+func (g *Generator) generateRequestBodyMarshal(gf *protogen.GeneratedFile, cfg *rpcMethodConfig) {
+	gf.P("// Marshal request body")
+	if len(cfg.bodyExcluded) == 0 {
+		gf.P("body, err := c.marshalRequest(req, contentType)")
+		return
+	}
+	gf.P("// Fields declared with a query, path, or header source stay out of the body")
+	gf.P("bodyReq := proto.CloneOf(req)")
+	gf.P("bodyMsg := bodyReq.ProtoReflect()")
+	for _, field := range cfg.bodyExcluded {
+		gf.P("bodyMsg.Clear(bodyMsg.Descriptor().Fields().ByName(", strconv.Quote(string(field.Desc.Name())), "))")
+	}
+	gf.P("body, err := c.marshalRequest(bodyReq, contentType)")
+}
+
+// generateHeaderFieldParams sends the request fields declared with source
+// HEADER. They are set last, so they override default and per-call headers.
+func (g *Generator) generateHeaderFieldParams(gf *protogen.GeneratedFile, cfg *rpcMethodConfig) {
+	for _, hp := range cfg.headerParams {
+		if hp.Field.Desc.HasPresence() {
+			gf.P("if req.", hp.FieldGoName, " != nil {")
+			gf.P("httpReq.Header.Set(", strconv.Quote(hp.HeaderName), ", *req.", hp.FieldGoName, ")")
+		} else {
+			gf.P("if req.", hp.FieldGoName, " != \"\" {")
+			gf.P("httpReq.Header.Set(", strconv.Quote(hp.HeaderName), ", req.", hp.FieldGoName, ")")
+		}
+		gf.P("}")
+	}
 }
 
-func (g *Generator) generateRPCMethodExecution(gf *protogen.GeneratedFile) {
+func (g *Generator) generateRPCMethodExecution(
+	gf *protogen.GeneratedFile,
+	cfg *rpcMethodConfig,
+	method *protogen.Method,
+) {
 	gf.P()
 	gf.P("// Execute request")
-	gf.P("resp, err := c.httpClient.Do(httpReq)")
+	var response string
+	if !annotations.IsResultMessage(method.Output) && !annotations.IsRawBodyResponse(method) {
+		response = "&" + gf.QualifiedGoIdent(method.Output.GoIdent) + "{}"
+	}
+	g.generateLoggedCall(gf, cfg, response, false, func() {
+		if cfg.hedge {
+			gf.P("return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)")
+		} else {
+			gf.P("return c.httpClient.Do(httpReq)")
+		}
+	})
 	gf.P("if err != nil {")
 	gf.P("return nil, fmt.Errorf(\"failed to execute request: %w\", err)")
 	gf.P("}")
+	gf.P("resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)")
 	gf.P("defer resp.Body.Close()")
 }
 
+// generateLoggedCall sends httpReq through the client's logger, circuit breaker
+// and recorder; send writes the statement that finally sends it. response is
+// the empty response message the debug dump decodes a successful body into, if
+// any.
+func (g *Generator) generateLoggedCall(
+	gf *protogen.GeneratedFile,
+	cfg *rpcMethodConfig,
+	response string,
+	stream bool,
+	send func(),
+) {
+	gf.P("call := sebufhttp.ClientCall{")
+	gf.P("Method: ", breakerKey(cfg), ",")
+	if cfg.hasBody {
+		if len(cfg.bodyExcluded) == 0 {
+			gf.P("Body: req,")
+		} else {
+			gf.P("Body: bodyReq,")
+		}
+	}
+	if response != "" {
+		gf.P("Response: ", response, ",")
+	}
+	if stream {
+		gf.P("Stream: true,")
+	}
+	var sensitive []string
+	for _, hp := range cfg.headerParams {
+		if annotations.IsSensitiveField(hp.Field) {
+			sensitive = append(sensitive, strconv.Quote(hp.HeaderName))
+		}
+	}
+	if len(sensitive) > 0 {
+		gf.P("SensitiveHeaders: []string{", strings.Join(sensitive, ", "), "},")
+	}
+	gf.P("}")
+	gf.P("resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {")
+	gf.P("return c.breaker.Do(", breakerKey(cfg), ", httpReq, func() (*http.Response, error) {")
+	gf.P("return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {")
+	send()
+	gf.P("})")
+	gf.P("})")
+	gf.P("})")
+}
+
+// breakerKey returns the quoted method name the circuit breaker keys
+// per-method circuits by.
+func breakerKey(cfg *rpcMethodConfig) string {
+	return strconv.Quote(cfg.serviceName + "." + cfg.methodName)
+}
+
 func (g *Generator) generateRPCMethodResponse(gf *protogen.GeneratedFile, method *protogen.Method) {
 	gf.P()
 	gf.P("// Read response body")
@@ -767,17 +1567,29 @@ func (g *Generator) generateRPCMethodResponse(gf *protogen.GeneratedFile, method
 	gf.P("return nil, fmt.Errorf(\"failed to read response body: %w\", err)")
 	gf.P("}")
 	gf.P()
-	gf.P("// Check for error status codes")
-	gf.P("if resp.StatusCode >= 400 {")
-	gf.P("return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)")
-	gf.P("}")
-	gf.P()
+	isResult := annotations.IsResultMessage(method.Output)
+	if !isResult {
+		gf.P("// Check for error status codes")
+		gf.P("if resp.StatusCode >= 400 {")
+		g.generateErrorStatusHandling(gf, method)
+		gf.P("}")
+		gf.P()
+	}
+	generateResponseMetadata(gf, method)
+	if rawBody := annotations.GetRawBody(method.Output); rawBody != nil {
+		generateRawBodyResult(gf, method, rawBody)
+		return
+	}
 	gf.P("// Resolve discardUnknownFields: per-call option overrides client default")
 	gf.P("discardUnknown := c.discardUnknownFields")
 	gf.P("if callOpts.discardUnknownFields != nil {")
 	gf.P("discardUnknown = *callOpts.discardUnknownFields")
 	gf.P("}")
 	gf.P()
+	if isResult {
+		g.generateResultResponse(gf, method)
+		return
+	}
 	gf.P("// Unmarshal response")
 	gf.P("result := &", method.Output.GoIdent, "{}")
 	gf.P("if err := c.unmarshalResponse(respBody, result, contentType, discardUnknown); err != nil {")
@@ -789,34 +1601,63 @@ func (g *Generator) generateRPCMethodResponse(gf *protogen.GeneratedFile, method
 	gf.P()
 }
 
-func (g *Generator) generateURLBuilding(
-	gf *protogen.GeneratedFile,
-	fullPath string,
-	pathParams []string,
-	queryParams []annotations.QueryParam,
-	httpMethod string,
-) {
-	// Start with base path
-	gf.P("path := \"", fullPath, "\"")
+// generateResponseMetadata reads the metadata of the response, for the
+// ResponseMetadata call option. The warnings of a server with
+// WithWarningsInBody are dropped from JSON bodies before they are decoded,
+// except for raw bodies and root-unwrapped messages, which never carry them.
+func generateResponseMetadata(gf *protogen.GeneratedFile, method *protogen.Method) {
+	fromBody := "contentType == ContentTypeJSON"
+	if annotations.IsRawBodyResponse(method) || annotations.IsRootUnwrap(method.Output) {
+		fromBody = "false"
+	}
+	variants, _ := annotations.GetResponseVariants(method.Output)
+	for _, variant := range variants {
+		if annotations.IsRootUnwrap(variant.Field.Message) {
+			fromBody = "false"
+		}
+	}
+	gf.P("// Read the status, headers and warnings of the response")
+	gf.P("respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, ", fromBody, ")")
+	gf.P("if callOpts.responseMetadata != nil {")
+	gf.P("*callOpts.responseMetadata = metadata")
+	gf.P("}")
+	gf.P()
+}
+
+// generateURLBuilding generates the body of a URL builder: the method path with
+// its parameters substituted, followed by the query string.
+func (g *Generator) generateURLBuilding(gf *protogen.GeneratedFile, cfg *rpcMethodConfig) {
+	queryParams := cfg.queryParams
+	if len(cfg.pathParams) == 0 && len(queryParams) == 0 {
+		gf.P("return \"", cfg.fullPath, "\"")
+		return
+	}
+
+	gf.P("path := \"", cfg.fullPath, "\"")
 
 	// Replace path parameters
-	if len(pathParams) > 0 {
-		for _, param := range pathParams {
-			goFieldName := snakeToUpperCamel(param)
-			gf.P(
-				"path = strings.Replace(path, \"{",
-				param,
-				"}\", url.PathEscape(fmt.Sprint(req.",
-				goFieldName,
-				")), 1)",
-			)
+	for _, param := range cfg.pathParams {
+		goFieldName := snakeToUpperCamel(param)
+		fieldExpr := "req." + goFieldName
+		if cfg.presencePathParams[param] {
+			// A pointer field is read through its getter, as a path value is always sent
+			fieldExpr = "req.Get" + goFieldName + "()"
+		}
+		valueExpr := "fmt.Sprint(" + fieldExpr + ")"
+		if cfg.enumPathParams[param] {
+			valueExpr = "enumParamString(" + fieldExpr + ")"
+		}
+		if annotations.IsPathWildcard(cfg.fullPath, param) {
+			// The wildcard matches the rest of the path, so its slashes are kept
+			gf.P("path = strings.Replace(path, \"{", param, "...}\", sebufhttp.EscapePathWildcard(", fieldExpr,
+				"), 1)")
+			continue
 		}
+		gf.P("path = strings.Replace(path, \"{", param, "}\", url.PathEscape(", valueExpr, "), 1)")
 	}
 
-	gf.P("reqURL := c.baseURL + path")
-
-	// Add query parameters for GET/DELETE
-	if (httpMethod == "GET" || httpMethod == "DELETE") && len(queryParams) > 0 {
+	// Add query parameters
+	if len(queryParams) > 0 {
 		gf.P()
 		gf.P("// Add query parameters")
 		gf.P("queryParams := url.Values{}")
@@ -824,26 +1665,60 @@ func (g *Generator) generateURLBuilding(
 			g.generateQueryParamEncoding(gf, qp)
 		}
 		gf.P("if len(queryParams) > 0 {")
-		gf.P("reqURL += \"?\" + queryParams.Encode()")
+		gf.P("path += \"?\" + queryParams.Encode()")
 		gf.P("}")
 	}
+	gf.P("return path")
 }
 
 func (g *Generator) generateQueryParamEncoding(gf *protogen.GeneratedFile, qp annotations.QueryParam) {
 	fieldGoName := qp.FieldGoName
 	paramName := qp.ParamName
 
-	// Handle repeated fields: iterate and Add() each value individually
-	if qp.Field != nil && qp.Field.Desc.IsList() {
+	if qp.JSON {
+		generateJSONQueryParamEncoding(gf, qp)
+		return
+	}
+
+	// Enums use the preferred wire string (custom enum_value, else proto name)
+	stringify := "fmt.Sprint"
+	if qp.Field != nil && qp.Field.Desc.Kind() == protoreflect.EnumKind {
+		stringify = "enumParamString"
+	}
+
+	switch annotations.GetQueryPresence(qp) {
+	case annotations.QueryPresenceNonEmpty:
+		// Repeated fields: iterate and Add() each value individually
 		gf.P("for _, v := range req.", fieldGoName, " {")
-		gf.P("queryParams.Add(\"", paramName, "\", fmt.Sprint(v))")
+		gf.P("queryParams.Add(\"", paramName, "\", ", stringify, "(v))")
+		gf.P("}")
+	case annotations.QueryPresenceWhenSet:
+		// Fields with explicit presence are pointers, sent when set
+		gf.P("if req.", fieldGoName, " != nil {")
+		gf.P("queryParams.Set(\"", paramName, "\", ", stringify, "(*req.", fieldGoName, "))")
+		gf.P("}")
+	case annotations.QueryPresenceNonZero:
+		// Scalar fields: zero-value check + Set()
+		gf.P("if req.", fieldGoName, " != ", getZeroValue(qp), " {")
+		gf.P("queryParams.Set(\"", paramName, "\", ", stringify, "(req.", fieldGoName, "))")
 		gf.P("}")
-		return
 	}
+}
 
-	// Scalar fields: zero-value check + Set()
-	gf.P("if req.", fieldGoName, " != ", getZeroValue(qp), " {")
-	gf.P("queryParams.Set(\"", paramName, "\", fmt.Sprint(req.", fieldGoName, "))")
+// generateJSONQueryParamEncoding generates the encoding of a JSON_BASE64URL
+// query parameter: the message, or the list of messages when there are any, as
+// one value. The URL builders return no error, so a message protojson cannot
+// encode, which could not be sent as a body either, leaves the parameter out.
+func generateJSONQueryParamEncoding(gf *protogen.GeneratedFile, qp annotations.QueryParam) {
+	if qp.Field.Desc.IsList() {
+		gf.P("if len(req.", qp.FieldGoName, ") > 0 {")
+		gf.P("if v, err := sebufhttp.MarshalJSONQueryParamList(req.", qp.FieldGoName, "); err == nil {")
+	} else {
+		gf.P("if req.", qp.FieldGoName, " != nil {")
+		gf.P("if v, err := sebufhttp.MarshalJSONQueryParam(req.", qp.FieldGoName, "); err == nil {")
+	}
+	gf.P("queryParams.Set(\"", qp.ParamName, "\", v)")
+	gf.P("}")
 	gf.P("}")
 }
 
@@ -865,6 +1740,9 @@ func (g *Generator) generateMarshalRequestMethod(gf *protogen.GeneratedFile, low
 	gf.P("return protojson.Marshal(req)")
 	gf.P("case ContentTypeProto:")
 	gf.P("return proto.Marshal(req)")
+	if g.sendsMsgpack() {
+		g.generateMsgpackRequestCase(gf)
+	}
 	gf.P("default:")
 	gf.P("return protojson.Marshal(req)")
 	gf.P("}")
@@ -873,7 +1751,17 @@ func (g *Generator) generateMarshalRequestMethod(gf *protogen.GeneratedFile, low
 }
 
 func (g *Generator) generateHandleErrorResponseMethod(gf *protogen.GeneratedFile, lowerName string) {
-	gf.P("func (c *", lowerName, "Client) handleErrorResponse(statusCode int, body []byte, contentType string) error {")
+	gf.P(
+		"func (c *", lowerName,
+		"Client) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {",
+	)
+	gf.P("// Problem Details (RFC 9457) carry the error as application/problem+json")
+	gf.P(`if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {`)
+	gf.P("if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {")
+	gf.P("return problem.Err()")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
 	gf.P("// Try to parse as ValidationError first (for 400 errors)")
 	gf.P("// Always use strict mode (false) for error parsing to avoid loose JSON")
 	gf.P("// falsely matching ValidationError or Error types.")
@@ -896,6 +1784,49 @@ func (g *Generator) generateHandleErrorResponseMethod(gf *protogen.GeneratedFile
 	gf.P()
 }
 
+// generateValidateRequestMethod generates validateRequest, which converts
+// protovalidate violations into a ValidationError exactly as the server's
+// convertProtovalidateError does, so both sides report the same field paths.
+func (g *Generator) generateValidateRequestMethod(gf *protogen.GeneratedFile, serviceName string) {
+	lowerName := annotations.LowerFirst(serviceName)
+	gf.P("// validateRequest validates req against its buf.validate rules.")
+	gf.P("func (c *", lowerName, "Client) validateRequest(req proto.Message) error {")
+	gf.P("if c.validator == nil {")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P("err := c.validator.Validate(req)")
+	gf.P("if err == nil {")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P()
+	gf.P("validationErr := &sebufhttp.ValidationError{}")
+	gf.P("var valErr *protovalidate.ValidationError")
+	gf.P("if !errors.As(err, &valErr) {")
+	gf.P("validationErr.Violations = append(validationErr.Violations, &sebufhttp.FieldViolation{")
+	gf.P(`Field: "unknown",`)
+	gf.P("Description: err.Error(),")
+	gf.P("})")
+	gf.P("return validationErr")
+	gf.P("}")
+	gf.P("for _, violation := range valErr.Violations {")
+	gf.P("var fieldNames []string")
+	gf.P("for _, element := range violation.Proto.GetField().GetElements() {")
+	gf.P("fieldNames = append(fieldNames, element.GetFieldName())")
+	gf.P("}")
+	gf.P(`fieldPath := strings.Join(fieldNames, ".")`)
+	gf.P(`if fieldPath == "" {`)
+	gf.P(`fieldPath = "unknown"`)
+	gf.P("}")
+	gf.P("validationErr.Violations = append(validationErr.Violations, &sebufhttp.FieldViolation{")
+	gf.P("Field: fieldPath,")
+	gf.P("Description: violation.Proto.GetMessage(),")
+	gf.P("})")
+	gf.P("}")
+	gf.P("return validationErr")
+	gf.P("}")
+	gf.P()
+}
+
 func (g *Generator) generateUnmarshalResponseMethod(gf *protogen.GeneratedFile, lowerName string) {
 	gf.P(
 		"func (c *",
@@ -906,6 +1837,9 @@ func (g *Generator) generateUnmarshalResponseMethod(gf *protogen.GeneratedFile,
 	gf.P("return nil")
 	gf.P("}")
 	gf.P()
+	if g.sendsMsgpack() {
+		g.generateMsgpackResponseConversion(gf)
+	}
 	gf.P("opts := protojson.UnmarshalOptions{DiscardUnknown: discardUnknown}")
 	gf.P()
 	gf.P("switch contentType {")
@@ -1031,6 +1965,143 @@ func (g *Generator) generateEventStreamType(gf *protogen.GeneratedFile, serviceN
 	gf.P()
 }
 
+// streamResponseItem returns the message of the items a stream_response method
+// returns. Validation has already checked the response shape.
+func streamResponseItem(method *protogen.Method) *protogen.Message {
+	field, _ := annotations.StreamResponseField(method)
+	return field.Message
+}
+
+// generateArrayStreamType generates the ArrayStream generic type for
+// stream_response methods. It accepts both JSON shapes of a list response, a
+// bare array and an object whose only key holds the array, and reports a
+// response cut short by the server as an error rather than as the end of the list.
+//
+//nolint:funlen // array stream generation requires many sequential code blocks
+func (g *Generator) generateArrayStreamType(gf *protogen.GeneratedFile, serviceName string) {
+	streamName := serviceName + "ArrayStream"
+	gf.P("// ", streamName, " reads the items of a streamed list response as they arrive.")
+	gf.P("type ", streamName, "[T proto.Message] struct {")
+	gf.P("resp                 *http.Response")
+	gf.P("decoder              *json.Decoder")
+	gf.P("opened               bool")
+	gf.P("object               bool")
+	gf.P("done                 bool")
+	gf.P("err                  error")
+	gf.P("discardUnknownFields bool")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// Next decodes the next item of the list into item.")
+	gf.P("// Returns false when the list ends or an error occurs.")
+	gf.P("func (s *", streamName, "[T]) Next(item T) bool {")
+	gf.P("if s.done || s.err != nil {")
+	gf.P("return false")
+	gf.P("}")
+	gf.P("if !s.opened {")
+	gf.P("s.opened = true")
+	gf.P("if err := s.open(); err != nil {")
+	gf.P("return s.fail(err)")
+	gf.P("}")
+	gf.P("if s.done {")
+	gf.P("return false")
+	gf.P("}")
+	gf.P("}")
+	gf.P("if !s.decoder.More() {")
+	gf.P("s.done = true")
+	gf.P("if err := s.expect(']'); err != nil {")
+	gf.P("return s.fail(err)")
+	gf.P("}")
+	gf.P("if s.object {")
+	gf.P("if err := s.expect('}'); err != nil {")
+	gf.P("return s.fail(err)")
+	gf.P("}")
+	gf.P("}")
+	gf.P("return false")
+	gf.P("}")
+	gf.P("var data json.RawMessage")
+	gf.P("if err := s.decoder.Decode(&data); err != nil {")
+	gf.P("return s.fail(err)")
+	gf.P("}")
+	gf.P("opts := protojson.UnmarshalOptions{DiscardUnknown: s.discardUnknownFields}")
+	gf.P("var unmarshalErr error")
+	gf.P("if u, ok := any(item).(sebufUnmarshaler); ok {")
+	gf.P("unmarshalErr = u.UnmarshalJSONSebuf(data, opts)")
+	gf.P("} else if u, ok := any(item).(json.Unmarshaler); ok {")
+	gf.P("unmarshalErr = u.UnmarshalJSON(data)")
+	gf.P("} else {")
+	gf.P("unmarshalErr = opts.Unmarshal(data, item)")
+	gf.P("}")
+	gf.P("if unmarshalErr != nil {")
+	gf.P(`s.err = fmt.Errorf("failed to unmarshal list item: %w", unmarshalErr)`)
+	gf.P("return false")
+	gf.P("}")
+	gf.P("return true")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// open reads up to the first item: the opening bracket of a bare array, or the")
+	gf.P("// opening brace and key of the object holding it. An empty object ends the list.")
+	gf.P("func (s *", streamName, "[T]) open() error {")
+	gf.P("tok, err := s.decoder.Token()")
+	gf.P("if err != nil {")
+	gf.P("return err")
+	gf.P("}")
+	gf.P("if tok == json.Delim('[') {")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P("if tok != json.Delim('{') {")
+	gf.P(`return fmt.Errorf("unexpected %v at the start of the response", tok)`)
+	gf.P("}")
+	gf.P("s.object = true")
+	gf.P("if !s.decoder.More() {")
+	gf.P("s.done = true")
+	gf.P("return s.expect('}')")
+	gf.P("}")
+	gf.P("if _, err := s.decoder.Token(); err != nil {")
+	gf.P("return err")
+	gf.P("}")
+	gf.P("return s.expect('[')")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// expect reads the delimiter delim.")
+	gf.P("func (s *", streamName, "[T]) expect(delim json.Delim) error {")
+	gf.P("tok, err := s.decoder.Token()")
+	gf.P("if err != nil {")
+	gf.P("return err")
+	gf.P("}")
+	gf.P("if tok != delim {")
+	gf.P(`return fmt.Errorf("unexpected %v in the response, want %v", tok, delim)`)
+	gf.P("}")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// fail records err, turning the end of a response cut short into io.ErrUnexpectedEOF.")
+	gf.P("func (s *", streamName, "[T]) fail(err error) bool {")
+	gf.P("if err == io.EOF {")
+	gf.P("err = io.ErrUnexpectedEOF")
+	gf.P("}")
+	gf.P(`s.err = fmt.Errorf("failed to read list response: %w", err)`)
+	gf.P("return false")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// Err returns any error encountered during streaming, including a response")
+	gf.P("// the server cut short after a failure.")
+	gf.P("func (s *", streamName, "[T]) Err() error {")
+	gf.P("return s.err")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// Close closes the underlying HTTP response body.")
+	gf.P("func (s *", streamName, "[T]) Close() error {")
+	gf.P("return s.resp.Body.Close()")
+	gf.P("}")
+	gf.P()
+}
+
 func getZeroValue(qp annotations.QueryParam) string {
 	// Return the appropriate zero value based on field kind
 	switch qp.FieldKind {
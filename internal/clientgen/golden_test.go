@@ -24,6 +24,8 @@ func TestClientGenGoldenFiles(t *testing.T) {
 	testCases := []struct {
 		name      string
 		protoFile string
+		// opts, when set, is appended to the plugin options.
+		opts string
 		// Expected generated files (without path prefix)
 		expectedFiles []string
 	}{
@@ -32,6 +34,7 @@ func TestClientGenGoldenFiles(t *testing.T) {
 			protoFile: "http_verbs_comprehensive.proto",
 			expectedFiles: []string{
 				"http_verbs_comprehensive_client.pb.go",
+				"http_verbs_comprehensive_client_fake.pb.go",
 			},
 		},
 		{
@@ -41,6 +44,13 @@ func TestClientGenGoldenFiles(t *testing.T) {
 				"query_params_client.pb.go",
 			},
 		},
+		{
+			name:      "field sources",
+			protoFile: "field_sources.proto",
+			expectedFiles: []string{
+				"field_sources_client.pb.go",
+			},
+		},
 		{
 			name:      "backward compatibility",
 			protoFile: "backward_compat.proto",
@@ -62,6 +72,17 @@ func TestClientGenGoldenFiles(t *testing.T) {
 				"complex_features_client.pb.go",
 			},
 		},
+		{
+			name:      "webhooks",
+			protoFile: "webhooks.proto",
+			opts:      "webhooks=true",
+			expectedFiles: []string{
+				"webhooks_webhooks.pb.go",
+				"webhooks_encoding.pb.go",
+				"webhooks_enum_encoding.pb.go",
+				"webhooks_enum_field_encoding.pb.go",
+			},
+		},
 		{
 			name:      "int64 encoding",
 			protoFile: "int64_encoding.proto",
@@ -85,6 +106,8 @@ func TestClientGenGoldenFiles(t *testing.T) {
 				"enum_encoding_client.pb.go",
 				"enum_encoding_enum_encoding.pb.go",
 				"enum_encoding_enum_field_encoding.pb.go",
+				"enum_encoding_enums.pb.go",
+				"enum_encoding_enums_test.go",
 			},
 		},
 		{
@@ -94,6 +117,8 @@ func TestClientGenGoldenFiles(t *testing.T) {
 				"enum_nested_client.pb.go",
 				"enum_nested_enum_encoding.pb.go",
 				"enum_nested_enum_field_encoding.pb.go",
+				"enum_nested_enums.pb.go",
+				"enum_nested_enums_test.go",
 			},
 		},
 		{
@@ -135,6 +160,16 @@ func TestClientGenGoldenFiles(t *testing.T) {
 				"bytes_encoding_bytes_encoding.pb.go",
 			},
 		},
+		{
+			name:      "scoped encoding defaults",
+			protoFile: "scoped_encoding.proto",
+			expectedFiles: []string{
+				"scoped_encoding_client.pb.go",
+				"scoped_encoding_encoding.pb.go",
+				"scoped_encoding_timestamp_format.pb.go",
+				"scoped_encoding_bytes_encoding.pb.go",
+			},
+		},
 		{
 			name:      "flatten",
 			protoFile: "flatten.proto",
@@ -151,11 +186,144 @@ func TestClientGenGoldenFiles(t *testing.T) {
 				"oneof_discriminator_oneof_discriminator.pb.go",
 			},
 		},
+		{
+			name:      "custom json names",
+			protoFile: "json_names.proto",
+			expectedFiles: []string{
+				"json_names_client.pb.go",
+				"json_names_encoding.pb.go",
+				"json_names_nullable.pb.go",
+				"json_names_bytes_encoding.pb.go",
+				"json_names_flatten.pb.go",
+				"json_names_oneof_discriminator.pb.go",
+			},
+		},
+		{
+			name:      "json naming policies",
+			protoFile: "json_naming.proto",
+			expectedFiles: []string{
+				"json_naming_client.pb.go",
+				"json_naming_flatten.pb.go",
+				"json_naming_json_naming.pb.go",
+			},
+		},
 		{
 			name:      "SSE streaming",
 			protoFile: "sse.proto",
 			expectedFiles: []string{
 				"sse_client.pb.go",
+				"sse_client_fake.pb.go",
+			},
+		},
+		{
+			name:      "streamed responses",
+			protoFile: "stream_response.proto",
+			expectedFiles: []string{
+				"stream_response_client.pb.go",
+				"stream_response_client_fake.pb.go",
+			},
+		},
+		{
+			name:      "raw body responses",
+			protoFile: "raw_body.proto",
+			expectedFiles: []string{
+				"raw_body_client.pb.go",
+				"raw_body_client_fake.pb.go",
+			},
+		},
+		{
+			name:      "JSON query parameters",
+			protoFile: "json_query.proto",
+			expectedFiles: []string{
+				"json_query_client.pb.go",
+				"json_query_client_fake.pb.go",
+			},
+		},
+		{
+			name:      "result messages",
+			protoFile: "response_statuses.proto",
+			expectedFiles: []string{
+				"response_statuses_client.pb.go",
+				"response_statuses_client_fake.pb.go",
+			},
+		},
+		{
+			name:      "versioned routes",
+			protoFile: "versioned_routes.proto",
+			expectedFiles: []string{
+				"versioned_routes_client.pb.go",
+			},
+		},
+		{
+			name:      "request validation",
+			protoFile: "request_validation.proto",
+			opts:      "validate_requests=true",
+			expectedFiles: []string{
+				"request_validation_client.pb.go",
+			},
+		},
+		{
+			name:      "visibility",
+			protoFile: "visibility.proto",
+			expectedFiles: []string{
+				"visibility_client.pb.go",
+			},
+		},
+		{
+			name:      "schema fingerprint",
+			protoFile: "schema_fingerprint.proto",
+			opts:      "schema_fingerprint=true",
+			expectedFiles: []string{
+				"schema_fingerprint_client.pb.go",
+			},
+		},
+		{
+			name:      "problem json",
+			protoFile: "problem_json.proto",
+			expectedFiles: []string{
+				"problem_json_client.pb.go",
+			},
+		},
+		{
+			name:      "deprecated fields",
+			protoFile: "deprecated_fields.proto",
+			expectedFiles: []string{
+				"deprecated_fields_client.pb.go",
+			},
+		},
+		{
+			name:      "path wildcards",
+			protoFile: "path_wildcard.proto",
+			expectedFiles: []string{
+				"path_wildcard_client.pb.go",
+			},
+		},
+		{
+			name:      "base path parameters",
+			protoFile: "base_path_params.proto",
+			expectedFiles: []string{
+				"base_path_params_client.pb.go",
+			},
+		},
+		{
+			name:      "error responses",
+			protoFile: "error_responses.proto",
+			expectedFiles: []string{
+				"error_responses_client.pb.go",
+			},
+		},
+		{
+			name:      "edition 2023",
+			protoFile: "editions.proto",
+			expectedFiles: []string{
+				"editions_client.pb.go",
+			},
+		},
+		{
+			name:      "proto2 syntax",
+			protoFile: "proto2_syntax.proto",
+			expectedFiles: []string{
+				"proto2_syntax_client.pb.go",
 			},
 		},
 	}
@@ -204,13 +372,18 @@ func TestClientGenGoldenFiles(t *testing.T) {
 				t.Fatalf("Proto file not found: %s", protoPath)
 			}
 
+			pluginOpts := "paths=source_relative"
+			if tc.opts != "" {
+				pluginOpts += "," + tc.opts
+			}
+
 			// Run protoc with go-client plugin (using explicit plugin path)
 			cmd := exec.Command("protoc",
 				"--plugin=protoc-gen-go-client="+pluginPath,
 				"--go_out="+tempDir,
 				"--go_opt=paths=source_relative",
 				"--go-client_out="+tempDir,
-				"--go-client_opt=paths=source_relative",
+				"--go-client_opt="+pluginOpts,
 				"--proto_path="+protoDir,
 				"--proto_path="+filepath.Join(projectRoot, "proto"),
 				tc.protoFile,
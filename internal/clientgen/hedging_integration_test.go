@@ -0,0 +1,88 @@
+package clientgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestHedgedIdempotentCallIntegration generates a Go server and client for a
+// POST method annotated with idempotency: true, and checks that a hedged call
+// whose hedge the server answers with 409 IDEMPOTENCY_IN_PROGRESS, while the
+// original is still being processed, returns the original's response.
+func TestHedgedIdempotentCallIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "hedging_test",
+		Protos:  map[string]string{"orders.proto": hedgingProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}, {Name: "go-client"}},
+		Files:   map[string]string{"hedging_test.go": hedgingIntegrationTestCode},
+	}, "-race")
+}
+
+const hedgingProto = `syntax = "proto3";
+package test.hedging;
+option go_package = "hedging_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service OrderService {
+  rpc CreateOrder(CreateOrderRequest) returns (Order) {
+    option (sebuf.http.config) = { path: "/orders" method: HTTP_METHOD_POST idempotency: true };
+  }
+}
+
+message CreateOrderRequest {
+  string item = 1;
+}
+
+message Order {
+  string id = 1;
+  string item = 2;
+}
+`
+
+const hedgingIntegrationTestCode = `package hedging_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gen "hedging_test/gen"
+)
+
+type orderServer struct {
+	calls atomic.Int32
+}
+
+func (s *orderServer) CreateOrder(_ context.Context, req *gen.CreateOrderRequest) (*gen.Order, error) {
+	s.calls.Add(1)
+	time.Sleep(200 * time.Millisecond)
+	return &gen.Order{Id: "order-1", Item: req.GetItem()}, nil
+}
+
+func TestHedgedIdempotentCall(t *testing.T) {
+	server := &orderServer{}
+	mux := http.NewServeMux()
+	if err := gen.RegisterOrderServiceServer(server, gen.WithMux(mux)); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := gen.NewOrderServiceClient(srv.URL, gen.WithOrderServiceHedging(20*time.Millisecond, 2))
+	order, err := client.CreateOrder(context.Background(), &gen.CreateOrderRequest{Item: "book"},
+		gen.WithOrderServiceHeader("Idempotency-Key", "order-key-1"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v, want the original attempt's response", err)
+	}
+	if order.GetId() != "order-1" || order.GetItem() != "book" {
+		t.Errorf("order = %v", order)
+	}
+	if n := server.calls.Load(); n != 1 {
+		t.Errorf("service ran %d times, want 1", n)
+	}
+}
+`
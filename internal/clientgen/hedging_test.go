@@ -0,0 +1,108 @@
+package clientgen
+
+import (
+	"strings"
+	"testing"
+)
+
+// goldenMethodBody returns the body of a generated client method.
+func goldenMethodBody(t *testing.T, src, receiver, method string) string {
+	t.Helper()
+	start := strings.Index(src, "func (c *"+receiver+") "+method+"(")
+	if start < 0 {
+		t.Fatalf("method %s not found", method)
+	}
+	end := strings.Index(src[start:], "\n}\n")
+	if end < 0 {
+		t.Fatalf("end of method %s not found", method)
+	}
+	return src[start : start+end]
+}
+
+// TestHedgingOnlySafeMethods verifies that only GET and idempotency-annotated
+// methods are sent through DoHedged.
+func TestHedgingOnlySafeMethods(t *testing.T) {
+	s := readGolden(t, "http_verbs_comprehensive_client.pb.go")
+
+	if !strings.Contains(s, "func WithRESTfulAPIServiceHedging(delay time.Duration, maxHedges int)") {
+		t.Error("missing WithRESTfulAPIServiceHedging option")
+	}
+
+	const hedged = "sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)"
+	tests := []struct {
+		method string
+		hedge  bool
+	}{
+		{"GetResource", true},
+		{"ListResources", true},
+		{"CreateResource", true}, // POST with idempotency: true
+		{"UpdateResource", false},
+		{"PatchResource", false},
+		{"DeleteResource", false},
+		{"DefaultPostMethod", false},
+	}
+	for _, tt := range tests {
+		body := goldenMethodBody(t, s, "rESTfulAPIServiceClient", tt.method)
+		if got := strings.Contains(body, hedged); got != tt.hedge {
+			t.Errorf("%s: hedged = %v, want %v", tt.method, got, tt.hedge)
+		}
+	}
+}
+
+// TestTimeoutDefaultDeadline verifies timeout_ms becomes the call deadline
+// only when the caller's context has none.
+func TestTimeoutDefaultDeadline(t *testing.T) {
+	s := readGolden(t, "http_verbs_comprehensive_client.pb.go")
+	want := "if _, ok := ctx.Deadline(); !ok {\n" +
+		"\t\tvar cancel context.CancelFunc\n" +
+		"\t\tctx, cancel = context.WithTimeout(ctx, 5000*time.Millisecond)\n" +
+		"\t\tdefer cancel()\n" +
+		"\t}"
+
+	if !strings.Contains(goldenMethodBody(t, s, "rESTfulAPIServiceClient", "UpdateResource"), want) {
+		t.Error("UpdateResource should default to its timeout_ms deadline")
+	}
+	if strings.Contains(goldenMethodBody(t, s, "rESTfulAPIServiceClient", "PatchResource"), "ctx.Deadline()") {
+		t.Error("PatchResource has no timeout_ms and should not set a deadline")
+	}
+}
+
+// TestCircuitBreakerWrapsEveryCall verifies that every method, hedged or not,
+// sends its request through the client's circuit breaker keyed by its name.
+func TestCircuitBreakerWrapsEveryCall(t *testing.T) {
+	s := readGolden(t, "http_verbs_comprehensive_client.pb.go")
+
+	if !strings.Contains(s, "func WithRESTfulAPIServiceCircuitBreaker(cfg sebufhttp.BreakerConfig)") {
+		t.Error("missing WithRESTfulAPIServiceCircuitBreaker option")
+	}
+	for _, method := range []string{"GetResource", "CreateResource", "UpdateResource", "DefaultPostMethod"} {
+		body := goldenMethodBody(t, s, "rESTfulAPIServiceClient", method)
+		want := `c.breaker.Do("RESTfulAPIService.` + method + `", httpReq, func() (*http.Response, error) {`
+		if !strings.Contains(body, want) {
+			t.Errorf("%s does not send its request through the circuit breaker", method)
+		}
+	}
+}
+
+// TestShadowMirrorsEveryUnaryCall verifies that every unary method hands its
+// response to the client's shadow mirror, which decides what to mirror.
+func TestShadowMirrorsEveryUnaryCall(t *testing.T) {
+	s := readGolden(t, "http_verbs_comprehensive_client.pb.go")
+
+	for _, option := range []string{
+		"func WithRESTfulAPIServiceShadow(baseURL string, sampleRate float64, " +
+			"compare func(primary, shadow *http.Response))",
+		"func WithRESTfulAPIServiceShadowMutations()",
+		"func WithRESTfulAPIServiceShadowTimeout(timeout time.Duration)",
+	} {
+		if !strings.Contains(s, option) {
+			t.Errorf("missing option %s", option)
+		}
+	}
+	const mirrored = "resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)"
+	for _, method := range []string{"GetResource", "CreateResource", "DeleteResource", "DefaultPostMethod"} {
+		if !strings.Contains(goldenMethodBody(t, s, "rESTfulAPIServiceClient", method), mirrored) {
+			t.Errorf("%s does not hand its response to the shadow mirror", method)
+		}
+	}
+}
@@ -2,11 +2,13 @@ package clientgen
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
 
 	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/encodinggen"
 )
 
 // OneofDiscriminatorContext holds information about a message that needs custom JSON encoding
@@ -83,7 +85,7 @@ func validateOneofDiscriminatorInMessages(messages []*protogen.Message) error {
 func checkMarshalJSONConflict(message *protogen.Message) error {
 	var conflicts []string
 
-	if hasInt64NumberFields(message) {
+	if encodinggen.HasInt64NumberFields(message) {
 		conflicts = append(conflicts, "int64_encoding=NUMBER")
 	}
 	if hasNullableFields(message) {
@@ -92,13 +94,13 @@ func checkMarshalJSONConflict(message *protogen.Message) error {
 	if hasEmptyBehaviorFields(message) {
 		conflicts = append(conflicts, "empty_behavior")
 	}
-	if hasTimestampFormatFields(message) {
+	if encodinggen.HasTimestampFormatFields(message) {
 		conflicts = append(conflicts, "timestamp_format")
 	}
-	if hasBytesEncodingFields(message) {
+	if encodinggen.HasBytesEncodingFields(message) {
 		conflicts = append(conflicts, "bytes_encoding")
 	}
-	if hasCustomEnumFields(message) {
+	if encodinggen.HasCustomEnumFields(message) {
 		conflicts = append(conflicts, "enum_value")
 	}
 
@@ -137,8 +139,10 @@ func (g *Generator) generateOneofDiscriminatorFile(file *protogen.File) error {
 	filename := file.GeneratedFilenamePrefix + "_oneof_discriminator.pb.go"
 	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
 
-	g.writeEncodingHeader(gf, file)
-	g.writeOneofDiscriminatorImports(gf)
+	g.writeHeader(gf, file)
+	g.writeOneofDiscriminatorImports(gf, slices.ContainsFunc(contexts, func(ctx *OneofDiscriminatorContext) bool {
+		return encodinggen.UsesSebufHTTP(ctx.Message)
+	}))
 
 	for _, ctx := range contexts {
 		g.generateOneofMarshalJSON(gf, ctx)
@@ -149,12 +153,13 @@ func (g *Generator) generateOneofDiscriminatorFile(file *protogen.File) error {
 }
 
 // writeOneofDiscriminatorImports writes the imports needed for oneof discriminator encoding.
-func (g *Generator) writeOneofDiscriminatorImports(gf *protogen.GeneratedFile) {
+func (g *Generator) writeOneofDiscriminatorImports(gf *protogen.GeneratedFile, jsonNaming bool) {
 	gf.P("import (")
 	gf.P(`"encoding/json"`)
 	gf.P(`"fmt"`)
 	gf.P()
 	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
+	encodinggen.WriteJSONNamingImport(gf, jsonNaming)
 	gf.P(")")
 	gf.P()
 }
@@ -184,7 +189,7 @@ func (g *Generator) generateOneofMarshalJSON(gf *protogen.GeneratedFile, ctx *On
 	gf.P()
 
 	gf.P("// Use protojson for base serialization")
-	gf.P("data, err := opts.Marshal(x)")
+	gf.P("data, err := ", encodinggen.ProtoMarshalCall(ctx.Message))
 	gf.P("if err != nil {")
 	gf.P("return nil, err")
 	gf.P("}")
@@ -246,7 +251,7 @@ func (g *Generator) generateFlattenedMarshal(
 	variant annotations.OneofVariant,
 ) {
 	fieldGoName := variant.Field.GoName
-	fieldJSONName := variant.Field.Desc.JSONName()
+	fieldJSONName := annotations.JSONFieldName(variant.Field)
 
 	gf.P("// Flatten: forward opts to variant via MarshalJSONSebuf when available")
 	gf.P("if inner := x.Get", fieldGoName, "(); inner != nil {")
@@ -287,6 +292,7 @@ func (g *Generator) generateOneofUnmarshalJSON(gf *protogen.GeneratedFile, ctx *
 		oneofNames = append(oneofNames, string(info.Oneof.Desc.Name()))
 	}
 
+	encodinggen.WriteAlternateJSONNames(gf, ctx.Message)
 	gf.P("// UnmarshalJSONSebuf implements sebufUnmarshaler for ", msgName, ".")
 	gf.P("// This method handles oneof discriminator fields: ", strings.Join(oneofNames, ", "))
 	gf.P("func (x *", msgName, ") UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error {")
@@ -295,6 +301,7 @@ func (g *Generator) generateOneofUnmarshalJSON(gf *protogen.GeneratedFile, ctx *
 	gf.P("if err := json.Unmarshal(data, &raw); err != nil {")
 	gf.P("return err")
 	gf.P("}")
+	encodinggen.WriteResolveAlternateJSONNames(gf, ctx.Message)
 	gf.P()
 
 	for _, info := range ctx.Oneofs {
@@ -368,12 +375,12 @@ func (g *Generator) generateFlattenedUnmarshal(
 	fieldGoName := variant.Field.GoName
 	wrapperType := variant.Field.GoIdent.GoName
 	msgType := variant.Field.Message.GoIdent.GoName
-	fieldJSONName := variant.Field.Desc.JSONName()
+	fieldJSONName := annotations.JSONFieldName(variant.Field)
 
 	// Collect all child field JSON names for this variant
 	var childJSONNames []string
 	for _, childField := range variant.Field.Message.Fields {
-		childJSONNames = append(childJSONNames, childField.Desc.JSONName())
+		childJSONNames = append(childJSONNames, annotations.JSONFieldName(childField))
 	}
 
 	gf.P("// Flatten unmarshal: extract ", fieldGoName, " fields from flat map")
@@ -393,18 +400,18 @@ func (g *Generator) generateFlattenedUnmarshal(
 	gf.P("if err := u.UnmarshalJSONSebuf(variantData, opts); err != nil {")
 	gf.P(`return fmt.Errorf("failed to unmarshal variant %s: %%w", "`, fieldGoName, `", err)`)
 	gf.P("}")
-	gf.P("} else if err := json.Unmarshal(variantData, variant); err != nil {")
+	gf.P("} else if err := opts.Unmarshal(variantData, variant); err != nil {")
 	gf.P(`return fmt.Errorf("failed to unmarshal variant %s: %%w", "`, fieldGoName, `", err)`)
 	gf.P("}")
 	gf.P("x.", info.Oneof.GoName, " = &", wrapperType, "{", fieldGoName, ": variant}")
 
 	// Add the variant back to raw under its original field name for protojson
-	// (protojson expects the oneof wrapper format)
-	gf.P(`raw["`, fieldJSONName, `"], _ = json.Marshal(variant)`)
+	// (protojson expects the oneof wrapper format, in its own encoding)
+	gf.P(`raw["`, fieldJSONName, `"], _ = protojson.Marshal(variant)`)
 }
 
 // generateNestedUnmarshal generates non-flattened unmarshal code for a message variant.
-// For non-flattened mode, the variant is already nested under its field name.
+// Plain messages are decoded with protojson, since their struct tags carry proto names.
 // We use json.Unmarshal to invoke the child's UnmarshalJSON if it has one.
 func (g *Generator) generateNestedUnmarshal(
 	gf *protogen.GeneratedFile,
@@ -412,7 +419,7 @@ func (g *Generator) generateNestedUnmarshal(
 	info *annotations.OneofDiscriminatorInfo,
 ) {
 	fieldGoName := variant.Field.GoName
-	fieldJSONName := variant.Field.Desc.JSONName()
+	fieldJSONName := annotations.JSONFieldName(variant.Field)
 	wrapperType := variant.Field.GoIdent.GoName
 	msgType := variant.Field.Message.GoIdent.GoName
 
@@ -423,7 +430,7 @@ func (g *Generator) generateNestedUnmarshal(
 	gf.P("if err := u.UnmarshalJSONSebuf(variantRaw, opts); err != nil {")
 	gf.P(`return fmt.Errorf("failed to unmarshal variant %s: %%w", "`, fieldGoName, `", err)`)
 	gf.P("}")
-	gf.P("} else if err := json.Unmarshal(variantRaw, variant); err != nil {")
+	gf.P("} else if err := opts.Unmarshal(variantRaw, variant); err != nil {")
 	gf.P(`return fmt.Errorf("failed to unmarshal variant %s: %%w", "`, fieldGoName, `", err)`)
 	gf.P("}")
 	gf.P("x.", info.Oneof.GoName, " = &", wrapperType, "{", fieldGoName, ": variant}")
@@ -0,0 +1,22 @@
+package clientgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// generateRawBodyResult generates the end of a method whose response message
+// has a raw_body field: the response body is that field as is, and its
+// Content-Type the raw_body_content_type field, if any.
+func generateRawBodyResult(gf *protogen.GeneratedFile, method *protogen.Method, rawBody *annotations.RawBody) {
+	gf.P("// The response body is the raw ", rawBody.Data.Desc.Name(), " of the response")
+	gf.P("return &", method.Output.GoIdent, "{")
+	gf.P(rawBody.Data.GoName, ": respBody,")
+	if rawBody.ContentType != nil {
+		gf.P(rawBody.ContentType.GoName, `: resp.Header.Get("Content-Type"),`)
+	}
+	gf.P("}, nil")
+	gf.P("}")
+	gf.P()
+}
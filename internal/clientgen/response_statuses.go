@@ -0,0 +1,104 @@
+package clientgen
+
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// resultTypeName returns the name of the type a method returning the result
+// message msg answers with: the variant the server sent and its status.
+func resultTypeName(msg *protogen.Message) string {
+	return msg.GoIdent.GoName + "Response"
+}
+
+// responseType returns the type a unary method returns a pointer to: the
+// result type for result messages, otherwise the response message.
+func responseType(gf *protogen.GeneratedFile, method *protogen.Method) string {
+	if annotations.IsResultMessage(method.Output) {
+		return resultTypeName(method.Output)
+	}
+	return gf.QualifiedGoIdent(method.Output.GoIdent)
+}
+
+// generateResultTypes generates, once per result message returned by the
+// methods of file, the type those methods return.
+func (g *Generator) generateResultTypes(gf *protogen.GeneratedFile, file *protogen.File) {
+	generated := make(map[*protogen.Message]bool)
+	for _, service := range file.Services {
+		for _, method := range service.Methods {
+			msg := method.Output
+			if generated[msg] || !annotations.IsResultMessage(msg) {
+				continue
+			}
+			generated[msg] = true
+			variants, err := annotations.GetResponseVariants(msg)
+			if err != nil {
+				continue // reported by generateServiceClient
+			}
+			g.generateResultType(gf, msg, variants)
+		}
+	}
+}
+
+func (g *Generator) generateResultType(
+	gf *protogen.GeneratedFile,
+	msg *protogen.Message,
+	variants []annotations.ResponseVariant,
+) {
+	name := resultTypeName(msg)
+	gf.P("// ", name, " is the response of a method returning the result message ", msg.GoIdent.GoName, ":")
+	gf.P("// the variant the server answered with, selected by the HTTP status.")
+	gf.P("type ", name, " struct {")
+	gf.P("// StatusCode is the HTTP status of the response.")
+	gf.P("StatusCode int")
+	gf.P("// Result holds the variant sent as the response body.")
+	gf.P("Result *", msg.GoIdent)
+	gf.P("}")
+	gf.P()
+	for _, variant := range variants {
+		field := variant.Field
+		gf.P("// Get", field.GoName, " returns the ", field.Desc.Name(), " variant, answered with status ",
+			variant.Status, ",")
+		gf.P("// or nil if the server answered with another variant.")
+		gf.P("func (r *", name, ") Get", field.GoName, "() *", field.Message.GoIdent, " {")
+		gf.P("if r == nil {")
+		gf.P("return nil")
+		gf.P("}")
+		gf.P("return r.Result.Get", field.GoName, "()")
+		gf.P("}")
+		gf.P()
+	}
+}
+
+// generateResultResponse generates the decoding of the response of a method
+// returning a result message: the status selects the variant the body holds.
+// Other error statuses are decoded as errors, as for any method.
+func (g *Generator) generateResultResponse(gf *protogen.GeneratedFile, method *protogen.Method) {
+	variants, _ := annotations.GetResponseVariants(method.Output)
+	gf.P("// Decode the variant the status selects")
+	gf.P("result := &", resultTypeName(method.Output), "{StatusCode: resp.StatusCode, Result: &",
+		method.Output.GoIdent, "{}}")
+	gf.P("switch resp.StatusCode {")
+	for _, variant := range variants {
+		field := variant.Field
+		gf.P("case ", strconv.Itoa(variant.Status), ":")
+		gf.P("variant := &", field.Message.GoIdent, "{}")
+		gf.P("if err := c.unmarshalResponse(respBody, variant, contentType, discardUnknown); err != nil {")
+		gf.P("return nil, fmt.Errorf(\"failed to unmarshal response: %w\", err)")
+		gf.P("}")
+		gf.P("result.Result.", field.Oneof.GoName, " = &", field.GoIdent, "{", field.GoName, ": variant}")
+	}
+	gf.P("default:")
+	gf.P("if resp.StatusCode >= 400 {")
+	g.generateErrorStatusHandling(gf, method)
+	gf.P("}")
+	gf.P("return nil, fmt.Errorf(\"unexpected response status %d: %s\", resp.StatusCode, string(respBody))")
+	gf.P("}")
+	gf.P()
+	gf.P("return result, nil")
+	gf.P("}")
+	gf.P()
+}
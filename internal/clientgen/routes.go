@@ -0,0 +1,62 @@
+package clientgen
+
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// urlBuilderName returns the name of the exported URL builder of a method:
+// <Service><Method>URL.
+func urlBuilderName(cfg *rpcMethodConfig) string {
+	return cfg.serviceName + cfg.methodName + "URL"
+}
+
+// generateRoutes generates the <Service>Routes variable, holding the HTTP verb
+// and path template of every method of service.
+func (g *Generator) generateRoutes(gf *protogen.GeneratedFile, service *protogen.Service) {
+	serviceName := service.GoName
+	cfgs := make([]*rpcMethodConfig, 0, len(service.Methods))
+	for _, method := range service.Methods {
+		cfgs = append(cfgs, g.buildRPCMethodConfig(service, method))
+	}
+
+	gf.P("// ", serviceName, "Routes holds the HTTP verb and path template of every ", serviceName, " method.")
+	if len(annotations.GetServiceVersions(service)) > 0 {
+		gf.P("// Paths are relative to the base path of the API version the client calls.")
+	} else if len(annotations.GetBasePathParams(service)) > 0 {
+		gf.P("// Paths are relative to the base path, ", strconv.Quote(annotations.GetServiceBasePath(service)), ".")
+	}
+	gf.P("var ", serviceName, "Routes = struct {")
+	for _, cfg := range cfgs {
+		gf.P(cfg.methodName, " sebufhttp.Route")
+	}
+	gf.P("}{")
+	for _, cfg := range cfgs {
+		gf.P(cfg.methodName, ": sebufhttp.Route{Method: ", strconv.Quote(cfg.httpMethod),
+			", Path: ", strconv.Quote(cfg.fullPath), "},")
+	}
+	gf.P("}")
+	gf.P()
+}
+
+// generateURLBuilder generates the exported URL builder of a method. It returns
+// the path and query string of the call with req, relative to the client's base
+// URL: the client methods build their URLs with it, so the two cannot disagree.
+func (g *Generator) generateURLBuilder(gf *protogen.GeneratedFile, cfg *rpcMethodConfig, method *protogen.Method) {
+	name := urlBuilderName(cfg)
+	gf.P("// ", name, " returns the path and query string of a ", cfg.methodName, " call with req,")
+	if cfg.versioned {
+		gf.P("// relative to the client's base URL and the base path of its API version.")
+	} else if cfg.hasBasePathParams {
+		gf.P("// relative to the client's base URL and base path.")
+	} else {
+		gf.P("// relative to the client's base URL.")
+	}
+	gf.P("func ", name, "(req *", method.Input.GoIdent, ") string {")
+	g.generateURLBuilding(gf, cfg)
+	gf.P("}")
+	gf.P()
+}
@@ -0,0 +1,86 @@
+package clientgen
+
+import (
+	"flag"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/internal/manifest"
+	"github.com/SebastienMelki/sebuf/internal/pluginrun"
+)
+
+// Options configures the generator.
+type Options struct {
+	// ValidateRequests runs protovalidate on each request before it is sent,
+	// returning the ValidationError the server would have answered with.
+	ValidateRequests bool
+	// Webhooks generates Send<Message> and Verify<Message>Signature for every
+	// message annotated with sebuf.http.webhook.
+	Webhooks bool
+	// Manifest makes Run append sebuf.manifest.json, describing the called
+	// routes and the generated files, to its response.
+	Manifest bool
+	// AllEnumHelpers generates Parse<Enum>, <Enum>Values and WireString for
+	// every enum, not only for those with enum_value mappings.
+	AllEnumHelpers bool
+	// ExtraCodecs lists the wire formats the client can send besides JSON and
+	// protobuf.
+	ExtraCodecs []ExtraCodec
+	// SchemaFingerprint embeds a fingerprint of each service's schema, which
+	// the client sends so servers can tell it was generated from another one.
+	SchemaFingerprint bool
+}
+
+// Run generates the Go HTTP client of req in memory, without reading stdin or
+// writing stdout. opts supplies the defaults for plugin parameters; the
+// validate_requests, webhooks, extra_codecs, all_enum_helpers,
+// schema_fingerprint and manifest parameters in req override the matching
+// options. Invalid input is reported in the response's Error field; the error
+// is only set if generation panicked.
+func Run(req *pluginpb.CodeGeneratorRequest, opts Options) (*pluginpb.CodeGeneratorResponse, error) {
+	var flags flag.FlagSet
+	flags.BoolVar(&opts.ValidateRequests, "validate_requests", opts.ValidateRequests,
+		"validate requests against their buf.validate rules before sending them")
+	flags.BoolVar(&opts.Webhooks, "webhooks", opts.Webhooks,
+		"generate senders and signature verifiers for messages annotated with sebuf.http.webhook")
+	flags.BoolVar(&opts.AllEnumHelpers, "all_enum_helpers", opts.AllEnumHelpers,
+		"generate enum helpers for every enum, not only those with enum_value mappings")
+	flags.BoolVar(&opts.SchemaFingerprint, "schema_fingerprint", opts.SchemaFingerprint,
+		"embed a fingerprint of each service's schema and send it with every request")
+	flags.BoolVar(&opts.Manifest, "manifest", opts.Manifest,
+		"write "+manifest.FileName+" describing the called routes and generated files")
+
+	// extra_codecs is a comma-separated list, which protoc splits into
+	// separate parameters, so it is taken out of a copy of the request
+	// (sharing its descriptors) before protogen sees it.
+	if req != nil {
+		codecs, param := pluginrun.TakeListParam(req.GetParameter(), "extra_codecs")
+		if codecs != nil {
+			opts.ExtraCodecs = opts.ExtraCodecs[:0:0]
+			for _, codec := range codecs {
+				opts.ExtraCodecs = append(opts.ExtraCodecs, ExtraCodec(codec))
+			}
+		}
+		req = &pluginpb.CodeGeneratorRequest{
+			FileToGenerate:        req.GetFileToGenerate(),
+			Parameter:             proto.String(param),
+			ProtoFile:             req.GetProtoFile(),
+			SourceFileDescriptors: req.GetSourceFileDescriptors(),
+			CompilerVersion:       req.GetCompilerVersion(),
+		}
+	}
+
+	var routes *manifest.Builder
+	resp, err := pluginrun.Run(req, flags.Set, func(plugin *protogen.Plugin) error {
+		g := NewWithOptions(plugin, opts)
+		if opts.Manifest {
+			routes = manifest.NewBuilder("protoc-gen-go-client")
+			g.manifest = routes
+		}
+		return g.Generate()
+	})
+	routes.Write(resp)
+	return resp, err
+}
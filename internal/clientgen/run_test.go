@@ -0,0 +1,99 @@
+package clientgen
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/manifest"
+	"github.com/SebastienMelki/sebuf/internal/pluginrun/pluginruntest"
+)
+
+func TestRun(t *testing.T) {
+	resp, err := Run(pluginruntest.Request("paths=source_relative"), Options{})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if resp.GetError() != "" {
+		t.Fatalf("response error: %s", resp.GetError())
+	}
+	client := pluginruntest.Content(resp, "notes_client.pb.go")
+	if !strings.Contains(client, "func NewNoteServiceClient(") {
+		t.Errorf("notes_client.pb.go should define NewNoteServiceClient, got files %v", pluginruntest.FileNames(resp))
+	}
+}
+
+func TestRunReportsInvalidInput(t *testing.T) {
+	req := pluginruntest.Request("")
+	req.FileToGenerate = []string{"missing.proto"}
+	resp, err := Run(req, Options{})
+	if err != nil {
+		t.Fatalf("Run returned error %v, want it in the response", err)
+	}
+	if !strings.Contains(resp.GetError(), "missing.proto") {
+		t.Errorf("response error = %q, want the missing file reported", resp.GetError())
+	}
+}
+
+func TestRunManifestOption(t *testing.T) {
+	resp, err := Run(pluginruntest.Request("paths=source_relative,manifest=true"), Options{})
+	if err != nil || resp.GetError() != "" {
+		t.Fatalf("Run: %v %s", err, resp.GetError())
+	}
+	var got manifest.Manifest
+	if err = json.Unmarshal([]byte(pluginruntest.Content(resp, manifest.FileName)), &got); err != nil {
+		t.Fatalf("decode manifest: %v", err)
+	}
+	if len(got.Outputs) != 2 || got.Outputs[0].Name != "notes_client.pb.go" ||
+		got.Outputs[1].Name != "notes_client_fake.pb.go" || got.Outputs[0].Plugin != "protoc-gen-go-client" {
+		t.Errorf("outputs = %+v, want notes_client.pb.go and its fake from protoc-gen-go-client", got.Outputs)
+	}
+	var routes []string
+	for _, service := range got.Services {
+		for _, method := range service.Methods {
+			for _, route := range method.Routes {
+				routes = append(routes, method.Name+" "+route.Method+" "+route.Path)
+			}
+		}
+	}
+	want := []string{"GetNote GET /api/v1/notes/{id}", "CreateNote POST /api/v1/notes"}
+	if !slices.Equal(routes, want) {
+		t.Errorf("routes = %v, want %v", routes, want)
+	}
+}
+
+func TestRunExtraCodecsOption(t *testing.T) {
+	for param, want := range map[string]bool{"": false, ",extra_codecs=msgpack": true} {
+		resp, err := Run(pluginruntest.Request("paths=source_relative"+param), Options{})
+		if err != nil || resp.GetError() != "" {
+			t.Fatalf("Run: %v %s", err, resp.GetError())
+		}
+		client := pluginruntest.Content(resp, "notes_client.pb.go")
+		if got := strings.Contains(client, "func WithNoteServiceWireFormat(format WireFormat)"); got != want {
+			t.Errorf("%q: WithNoteServiceWireFormat generated = %v, want %v", param, got, want)
+		}
+	}
+
+	resp, err := Run(pluginruntest.Request("extra_codecs=cbor"), Options{})
+	if err != nil {
+		t.Fatalf("Run returned error %v, want it in the response", err)
+	}
+	if !strings.Contains(resp.GetError(), `unsupported extra_codecs "cbor"`) {
+		t.Errorf("response error = %q, want the invalid codec reported", resp.GetError())
+	}
+}
+
+func TestRunSchemaFingerprintOption(t *testing.T) {
+	for param, want := range map[string]bool{"": false, ",schema_fingerprint=true": true} {
+		resp, err := Run(pluginruntest.Request("paths=source_relative"+param), Options{})
+		if err != nil || resp.GetError() != "" {
+			t.Fatalf("Run: %v %s", err, resp.GetError())
+		}
+		client := pluginruntest.Content(resp, "notes_client.pb.go")
+		sent := strings.Contains(client, "{sebufhttp.SchemaHeader: NoteServiceClientSchemaFingerprint}")
+		if sent != want {
+			t.Errorf("%q: schema fingerprint sent = %v, want %v", param, sent, want)
+		}
+	}
+}
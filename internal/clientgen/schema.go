@@ -0,0 +1,27 @@
+package clientgen
+
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/schemahash"
+)
+
+// schemaFingerprintConst returns the name of the constant holding the schema
+// fingerprint of the service named serviceName.
+func schemaFingerprintConst(serviceName string) string {
+	return serviceName + "ClientSchemaFingerprint"
+}
+
+// generateSchemaFingerprint generates the constant holding the schema
+// fingerprint of service, which the client sends with every request.
+func (g *Generator) generateSchemaFingerprint(gf *protogen.GeneratedFile, service *protogen.Service) {
+	name := schemaFingerprintConst(service.GoName)
+	gf.P("// ", name, " is the fingerprint of the methods and messages of the")
+	gf.P("// ", service.GoName, " service this client was generated from. The client sends it in")
+	gf.P("// the X-Sebuf-Schema header of every request, so that servers generated from")
+	gf.P("// another schema can tell; With", service.GoName, "DefaultHeader can override it.")
+	gf.P("const ", name, " = ", strconv.Quote(schemahash.Fingerprint(service.Desc)))
+	gf.P()
+}
@@ -11,6 +11,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -20,9 +21,9 @@ import (
 
 const (
 	// ContentTypeJSON is the content type for JSON requests/responses.
-	ContentTypeJSON = "application/json"
+	ContentTypeJSON = sebufhttp.JSONContentType
 	// ContentTypeProto is the content type for binary protobuf requests/responses.
-	ContentTypeProto = "application/x-protobuf"
+	ContentTypeProto = sebufhttp.ProtoContentType
 )
 
 // sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
@@ -32,6 +33,8 @@ type sebufUnmarshaler interface {
 }
 
 // NoAnnotationsServiceClient is the client API for NoAnnotationsService service.
+//
+// Service without any HTTP annotations - should use defaults
 type NoAnnotationsServiceClient interface {
 	SimpleAction(ctx context.Context, req *SimpleRequest, opts ...NoAnnotationsServiceCallOption) (*SimpleResponse, error)
 	AnotherAction(ctx context.Context, req *AnotherRequest, opts ...NoAnnotationsServiceCallOption) (*AnotherResponse, error)
@@ -43,7 +46,15 @@ type noAnnotationsServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ NoAnnotationsServiceClient = (*noAnnotationsServiceClient)(nil)
@@ -76,6 +87,27 @@ func WithNoAnnotationsServiceDefaultHeader(key, value string) NoAnnotationsServi
 	}
 }
 
+// WithNoAnnotationsServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithNoAnnotationsServiceHeaderPropagation(allowlist ...string) NoAnnotationsServiceClientOption {
+	return func(c *noAnnotationsServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithNoAnnotationsServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithNoAnnotationsServiceDeadlinePropagation(headerName string) NoAnnotationsServiceClientOption {
+	return func(c *noAnnotationsServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithNoAnnotationsServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithNoAnnotationsServiceDiscardUnknownFields(discard bool) NoAnnotationsServiceClientOption {
@@ -84,6 +116,105 @@ func WithNoAnnotationsServiceDiscardUnknownFields(discard bool) NoAnnotationsSer
 	}
 }
 
+// WithNoAnnotationsServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithNoAnnotationsServiceHedging(delay time.Duration, maxHedges int) NoAnnotationsServiceClientOption {
+	return func(c *noAnnotationsServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithNoAnnotationsServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithNoAnnotationsServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) NoAnnotationsServiceClientOption {
+	return func(c *noAnnotationsServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithNoAnnotationsServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithNoAnnotationsServiceRecorder(recorder sebufhttp.CallRecorder) NoAnnotationsServiceClientOption {
+	return func(c *noAnnotationsServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithNoAnnotationsServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithNoAnnotationsServiceDebugLogging(w io.Writer) NoAnnotationsServiceClientOption {
+	return func(c *noAnnotationsServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithNoAnnotationsServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithNoAnnotationsServiceDebugBodyLimit(limit int) NoAnnotationsServiceClientOption {
+	return func(c *noAnnotationsServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithNoAnnotationsServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithNoAnnotationsServiceLogHook(hook func(sebufhttp.ClientLogEvent)) NoAnnotationsServiceClientOption {
+	return func(c *noAnnotationsServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithNoAnnotationsServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithNoAnnotationsServiceShadowMutations is set.
+func WithNoAnnotationsServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) NoAnnotationsServiceClientOption {
+	return func(c *noAnnotationsServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithNoAnnotationsServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithNoAnnotationsServiceShadowMutations() NoAnnotationsServiceClientOption {
+	return func(c *noAnnotationsServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithNoAnnotationsServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithNoAnnotationsServiceShadowTimeout(timeout time.Duration) NoAnnotationsServiceClientOption {
+	return func(c *noAnnotationsServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // NoAnnotationsServiceCallOption configures a single RPC call.
 type NoAnnotationsServiceCallOption func(*noAnnotationsServiceCallOptions)
 
@@ -92,6 +223,7 @@ type noAnnotationsServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithNoAnnotationsServiceHeader adds a header to a single request.
@@ -119,6 +251,14 @@ func WithNoAnnotationsServiceCallDiscardUnknownFields(discard bool) NoAnnotation
 	}
 }
 
+// WithNoAnnotationsServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithNoAnnotationsServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) NoAnnotationsServiceCallOption {
+	return func(o *noAnnotationsServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // NewNoAnnotationsServiceClient creates a new NoAnnotationsService client.
 func NewNoAnnotationsServiceClient(baseURL string, opts ...NoAnnotationsServiceClientOption) NoAnnotationsServiceClient {
 	c := &noAnnotationsServiceClient{
@@ -135,6 +275,27 @@ func NewNoAnnotationsServiceClient(baseURL string, opts ...NoAnnotationsServiceC
 	return c
 }
 
+// NoAnnotationsServiceRoutes holds the HTTP verb and path template of every NoAnnotationsService method.
+var NoAnnotationsServiceRoutes = struct {
+	SimpleAction  sebufhttp.Route
+	AnotherAction sebufhttp.Route
+}{
+	SimpleAction:  sebufhttp.Route{Method: "POST", Path: "/simpleAction"},
+	AnotherAction: sebufhttp.Route{Method: "POST", Path: "/anotherAction"},
+}
+
+// NoAnnotationsServiceSimpleActionURL returns the path and query string of a SimpleAction call with req,
+// relative to the client's base URL.
+func NoAnnotationsServiceSimpleActionURL(req *SimpleRequest) string {
+	return "/simpleAction"
+}
+
+// NoAnnotationsServiceAnotherActionURL returns the path and query string of a AnotherAction call with req,
+// relative to the client's base URL.
+func NoAnnotationsServiceAnotherActionURL(req *AnotherRequest) string {
+	return "/anotherAction"
+}
+
 // SimpleAction calls the SimpleAction RPC.
 func (c *noAnnotationsServiceClient) SimpleAction(ctx context.Context, req *SimpleRequest, opts ...NoAnnotationsServiceCallOption) (*SimpleResponse, error) {
 	callOpts := &noAnnotationsServiceCallOptions{}
@@ -143,8 +304,7 @@ func (c *noAnnotationsServiceClient) SimpleAction(ctx context.Context, req *Simp
 	}
 
 	// Build URL
-	path := "/simpleAction"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + NoAnnotationsServiceSimpleActionURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -168,15 +328,33 @@ func (c *noAnnotationsServiceClient) SimpleAction(ctx context.Context, req *Simp
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "NoAnnotationsService.SimpleAction",
+		Body:     req,
+		Response: &SimpleResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("NoAnnotationsService.SimpleAction", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -187,7 +365,13 @@ func (c *noAnnotationsServiceClient) SimpleAction(ctx context.Context, req *Simp
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -213,8 +397,7 @@ func (c *noAnnotationsServiceClient) AnotherAction(ctx context.Context, req *Ano
 	}
 
 	// Build URL
-	path := "/anotherAction"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + NoAnnotationsServiceAnotherActionURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -238,15 +421,33 @@ func (c *noAnnotationsServiceClient) AnotherAction(ctx context.Context, req *Ano
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "NoAnnotationsService.AnotherAction",
+		Body:     req,
+		Response: &AnotherResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("NoAnnotationsService.AnotherAction", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -257,7 +458,13 @@ func (c *noAnnotationsServiceClient) AnotherAction(ctx context.Context, req *Ano
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -290,7 +497,14 @@ func (c *noAnnotationsServiceClient) marshalRequest(req proto.Message, contentTy
 	}
 }
 
-func (c *noAnnotationsServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *noAnnotationsServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
@@ -337,6 +551,8 @@ func (c *noAnnotationsServiceClient) unmarshalResponse(body []byte, msg proto.Me
 }
 
 // BasePathOnlyServiceClient is the client API for BasePathOnlyService service.
+//
+// Service with only base_path but no method annotations
 type BasePathOnlyServiceClient interface {
 	ActionOne(ctx context.Context, req *ActionRequest, opts ...BasePathOnlyServiceCallOption) (*ActionResponse, error)
 	ActionTwo(ctx context.Context, req *ActionRequest, opts ...BasePathOnlyServiceCallOption) (*ActionResponse, error)
@@ -348,7 +564,15 @@ type basePathOnlyServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ BasePathOnlyServiceClient = (*basePathOnlyServiceClient)(nil)
@@ -381,6 +605,27 @@ func WithBasePathOnlyServiceDefaultHeader(key, value string) BasePathOnlyService
 	}
 }
 
+// WithBasePathOnlyServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithBasePathOnlyServiceHeaderPropagation(allowlist ...string) BasePathOnlyServiceClientOption {
+	return func(c *basePathOnlyServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithBasePathOnlyServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithBasePathOnlyServiceDeadlinePropagation(headerName string) BasePathOnlyServiceClientOption {
+	return func(c *basePathOnlyServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithBasePathOnlyServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithBasePathOnlyServiceDiscardUnknownFields(discard bool) BasePathOnlyServiceClientOption {
@@ -389,6 +634,105 @@ func WithBasePathOnlyServiceDiscardUnknownFields(discard bool) BasePathOnlyServi
 	}
 }
 
+// WithBasePathOnlyServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithBasePathOnlyServiceHedging(delay time.Duration, maxHedges int) BasePathOnlyServiceClientOption {
+	return func(c *basePathOnlyServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithBasePathOnlyServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithBasePathOnlyServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) BasePathOnlyServiceClientOption {
+	return func(c *basePathOnlyServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithBasePathOnlyServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithBasePathOnlyServiceRecorder(recorder sebufhttp.CallRecorder) BasePathOnlyServiceClientOption {
+	return func(c *basePathOnlyServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithBasePathOnlyServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithBasePathOnlyServiceDebugLogging(w io.Writer) BasePathOnlyServiceClientOption {
+	return func(c *basePathOnlyServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithBasePathOnlyServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithBasePathOnlyServiceDebugBodyLimit(limit int) BasePathOnlyServiceClientOption {
+	return func(c *basePathOnlyServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithBasePathOnlyServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithBasePathOnlyServiceLogHook(hook func(sebufhttp.ClientLogEvent)) BasePathOnlyServiceClientOption {
+	return func(c *basePathOnlyServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithBasePathOnlyServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithBasePathOnlyServiceShadowMutations is set.
+func WithBasePathOnlyServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) BasePathOnlyServiceClientOption {
+	return func(c *basePathOnlyServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithBasePathOnlyServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithBasePathOnlyServiceShadowMutations() BasePathOnlyServiceClientOption {
+	return func(c *basePathOnlyServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithBasePathOnlyServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithBasePathOnlyServiceShadowTimeout(timeout time.Duration) BasePathOnlyServiceClientOption {
+	return func(c *basePathOnlyServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // BasePathOnlyServiceCallOption configures a single RPC call.
 type BasePathOnlyServiceCallOption func(*basePathOnlyServiceCallOptions)
 
@@ -397,6 +741,7 @@ type basePathOnlyServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithBasePathOnlyServiceHeader adds a header to a single request.
@@ -424,6 +769,14 @@ func WithBasePathOnlyServiceCallDiscardUnknownFields(discard bool) BasePathOnlyS
 	}
 }
 
+// WithBasePathOnlyServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithBasePathOnlyServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) BasePathOnlyServiceCallOption {
+	return func(o *basePathOnlyServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // NewBasePathOnlyServiceClient creates a new BasePathOnlyService client.
 func NewBasePathOnlyServiceClient(baseURL string, opts ...BasePathOnlyServiceClientOption) BasePathOnlyServiceClient {
 	c := &basePathOnlyServiceClient{
@@ -440,6 +793,27 @@ func NewBasePathOnlyServiceClient(baseURL string, opts ...BasePathOnlyServiceCli
 	return c
 }
 
+// BasePathOnlyServiceRoutes holds the HTTP verb and path template of every BasePathOnlyService method.
+var BasePathOnlyServiceRoutes = struct {
+	ActionOne sebufhttp.Route
+	ActionTwo sebufhttp.Route
+}{
+	ActionOne: sebufhttp.Route{Method: "POST", Path: "/api/v2/actionOne"},
+	ActionTwo: sebufhttp.Route{Method: "POST", Path: "/api/v2/actionTwo"},
+}
+
+// BasePathOnlyServiceActionOneURL returns the path and query string of a ActionOne call with req,
+// relative to the client's base URL.
+func BasePathOnlyServiceActionOneURL(req *ActionRequest) string {
+	return "/api/v2/actionOne"
+}
+
+// BasePathOnlyServiceActionTwoURL returns the path and query string of a ActionTwo call with req,
+// relative to the client's base URL.
+func BasePathOnlyServiceActionTwoURL(req *ActionRequest) string {
+	return "/api/v2/actionTwo"
+}
+
 // ActionOne calls the ActionOne RPC.
 func (c *basePathOnlyServiceClient) ActionOne(ctx context.Context, req *ActionRequest, opts ...BasePathOnlyServiceCallOption) (*ActionResponse, error) {
 	callOpts := &basePathOnlyServiceCallOptions{}
@@ -448,8 +822,7 @@ func (c *basePathOnlyServiceClient) ActionOne(ctx context.Context, req *ActionRe
 	}
 
 	// Build URL
-	path := "/api/v2/actionOne"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + BasePathOnlyServiceActionOneURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -473,15 +846,33 @@ func (c *basePathOnlyServiceClient) ActionOne(ctx context.Context, req *ActionRe
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "BasePathOnlyService.ActionOne",
+		Body:     req,
+		Response: &ActionResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("BasePathOnlyService.ActionOne", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -492,7 +883,13 @@ func (c *basePathOnlyServiceClient) ActionOne(ctx context.Context, req *ActionRe
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -518,8 +915,7 @@ func (c *basePathOnlyServiceClient) ActionTwo(ctx context.Context, req *ActionRe
 	}
 
 	// Build URL
-	path := "/api/v2/actionTwo"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + BasePathOnlyServiceActionTwoURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -543,15 +939,33 @@ func (c *basePathOnlyServiceClient) ActionTwo(ctx context.Context, req *ActionRe
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "BasePathOnlyService.ActionTwo",
+		Body:     req,
+		Response: &ActionResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("BasePathOnlyService.ActionTwo", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -562,7 +976,13 @@ func (c *basePathOnlyServiceClient) ActionTwo(ctx context.Context, req *ActionRe
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -595,7 +1015,14 @@ func (c *basePathOnlyServiceClient) marshalRequest(req proto.Message, contentTyp
 	}
 }
 
-func (c *basePathOnlyServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *basePathOnlyServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
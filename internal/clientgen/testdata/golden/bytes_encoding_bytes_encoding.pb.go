@@ -9,6 +9,8 @@ import (
 	"encoding/json"
 
 	"google.golang.org/protobuf/encoding/protojson"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
 
 // MarshalJSONSebuf implements sebufMarshaler for BytesEncodingTest.
@@ -58,6 +60,17 @@ func (x *BytesEncodingTest) MarshalJSON() ([]byte, error) {
 	return x.MarshalJSONSebuf(protojson.MarshalOptions{})
 }
 
+// bytesEncodingTestAlternateJSONNames maps the proto names and JSON names protojson
+// accepts for the fields of BytesEncodingTest to the keys UnmarshalJSON reads.
+var bytesEncodingTestAlternateJSONNames = map[string]string{
+	"base64_data":        "base64Data",
+	"base64_raw_data":    "base64RawData",
+	"base64url_data":     "base64urlData",
+	"base64url_raw_data": "base64urlRawData",
+	"default_data":       "defaultData",
+	"hex_data":           "hexData",
+}
+
 // UnmarshalJSONSebuf implements sebufUnmarshaler for BytesEncodingTest.
 // This method handles bytes_encoding fields: base64_raw_data, base64url_data, base64url_raw_data, hex_data
 func (x *BytesEncodingTest) UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error {
@@ -66,6 +79,9 @@ func (x *BytesEncodingTest) UnmarshalJSONSebuf(data []byte, opts protojson.Unmar
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	if err := sebufhttp.ResolveAlternateJSONNames(raw, bytesEncodingTestAlternateJSONNames); err != nil {
+		return err
+	}
 
 	// Decode base64_raw_data from BYTES_ENCODING_BASE64_RAW to standard base64
 	if v, ok := raw["base64RawData"]; ok {
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -21,9 +22,9 @@ import (
 
 const (
 	// ContentTypeJSON is the content type for JSON requests/responses.
-	ContentTypeJSON = "application/json"
+	ContentTypeJSON = sebufhttp.JSONContentType
 	// ContentTypeProto is the content type for binary protobuf requests/responses.
-	ContentTypeProto = "application/x-protobuf"
+	ContentTypeProto = sebufhttp.ProtoContentType
 )
 
 // sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
@@ -33,6 +34,8 @@ type sebufUnmarshaler interface {
 }
 
 // BytesEncodingServiceClient is the client API for BytesEncodingService service.
+//
+// BytesEncodingService tests bytes encoding in responses.
 type BytesEncodingServiceClient interface {
 	TestBytesEncoding(ctx context.Context, req *BytesEncodingTest, opts ...BytesEncodingServiceCallOption) (*BytesEncodingTest, error)
 	GetBytesEncoding(ctx context.Context, req *BytesEncodingRequest, opts ...BytesEncodingServiceCallOption) (*BytesEncodingTest, error)
@@ -44,7 +47,15 @@ type bytesEncodingServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ BytesEncodingServiceClient = (*bytesEncodingServiceClient)(nil)
@@ -77,6 +88,27 @@ func WithBytesEncodingServiceDefaultHeader(key, value string) BytesEncodingServi
 	}
 }
 
+// WithBytesEncodingServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithBytesEncodingServiceHeaderPropagation(allowlist ...string) BytesEncodingServiceClientOption {
+	return func(c *bytesEncodingServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithBytesEncodingServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithBytesEncodingServiceDeadlinePropagation(headerName string) BytesEncodingServiceClientOption {
+	return func(c *bytesEncodingServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithBytesEncodingServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithBytesEncodingServiceDiscardUnknownFields(discard bool) BytesEncodingServiceClientOption {
@@ -85,6 +117,105 @@ func WithBytesEncodingServiceDiscardUnknownFields(discard bool) BytesEncodingSer
 	}
 }
 
+// WithBytesEncodingServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithBytesEncodingServiceHedging(delay time.Duration, maxHedges int) BytesEncodingServiceClientOption {
+	return func(c *bytesEncodingServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithBytesEncodingServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithBytesEncodingServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) BytesEncodingServiceClientOption {
+	return func(c *bytesEncodingServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithBytesEncodingServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithBytesEncodingServiceRecorder(recorder sebufhttp.CallRecorder) BytesEncodingServiceClientOption {
+	return func(c *bytesEncodingServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithBytesEncodingServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithBytesEncodingServiceDebugLogging(w io.Writer) BytesEncodingServiceClientOption {
+	return func(c *bytesEncodingServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithBytesEncodingServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithBytesEncodingServiceDebugBodyLimit(limit int) BytesEncodingServiceClientOption {
+	return func(c *bytesEncodingServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithBytesEncodingServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithBytesEncodingServiceLogHook(hook func(sebufhttp.ClientLogEvent)) BytesEncodingServiceClientOption {
+	return func(c *bytesEncodingServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithBytesEncodingServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithBytesEncodingServiceShadowMutations is set.
+func WithBytesEncodingServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) BytesEncodingServiceClientOption {
+	return func(c *bytesEncodingServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithBytesEncodingServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithBytesEncodingServiceShadowMutations() BytesEncodingServiceClientOption {
+	return func(c *bytesEncodingServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithBytesEncodingServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithBytesEncodingServiceShadowTimeout(timeout time.Duration) BytesEncodingServiceClientOption {
+	return func(c *bytesEncodingServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // BytesEncodingServiceCallOption configures a single RPC call.
 type BytesEncodingServiceCallOption func(*bytesEncodingServiceCallOptions)
 
@@ -93,6 +224,7 @@ type bytesEncodingServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithBytesEncodingServiceHeader adds a header to a single request.
@@ -120,6 +252,14 @@ func WithBytesEncodingServiceCallDiscardUnknownFields(discard bool) BytesEncodin
 	}
 }
 
+// WithBytesEncodingServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithBytesEncodingServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) BytesEncodingServiceCallOption {
+	return func(o *bytesEncodingServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // NewBytesEncodingServiceClient creates a new BytesEncodingService client.
 func NewBytesEncodingServiceClient(baseURL string, opts ...BytesEncodingServiceClientOption) BytesEncodingServiceClient {
 	c := &bytesEncodingServiceClient{
@@ -136,6 +276,29 @@ func NewBytesEncodingServiceClient(baseURL string, opts ...BytesEncodingServiceC
 	return c
 }
 
+// BytesEncodingServiceRoutes holds the HTTP verb and path template of every BytesEncodingService method.
+var BytesEncodingServiceRoutes = struct {
+	TestBytesEncoding sebufhttp.Route
+	GetBytesEncoding  sebufhttp.Route
+}{
+	TestBytesEncoding: sebufhttp.Route{Method: "POST", Path: "/api/v1/bytes-encoding"},
+	GetBytesEncoding:  sebufhttp.Route{Method: "GET", Path: "/api/v1/bytes-encoding/{id}"},
+}
+
+// BytesEncodingServiceTestBytesEncodingURL returns the path and query string of a TestBytesEncoding call with req,
+// relative to the client's base URL.
+func BytesEncodingServiceTestBytesEncodingURL(req *BytesEncodingTest) string {
+	return "/api/v1/bytes-encoding"
+}
+
+// BytesEncodingServiceGetBytesEncodingURL returns the path and query string of a GetBytesEncoding call with req,
+// relative to the client's base URL.
+func BytesEncodingServiceGetBytesEncodingURL(req *BytesEncodingRequest) string {
+	path := "/api/v1/bytes-encoding/{id}"
+	path = strings.Replace(path, "{id}", url.PathEscape(fmt.Sprint(req.Id)), 1)
+	return path
+}
+
 // TestBytesEncoding calls the TestBytesEncoding RPC.
 func (c *bytesEncodingServiceClient) TestBytesEncoding(ctx context.Context, req *BytesEncodingTest, opts ...BytesEncodingServiceCallOption) (*BytesEncodingTest, error) {
 	callOpts := &bytesEncodingServiceCallOptions{}
@@ -144,8 +307,7 @@ func (c *bytesEncodingServiceClient) TestBytesEncoding(ctx context.Context, req
 	}
 
 	// Build URL
-	path := "/api/v1/bytes-encoding"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + BytesEncodingServiceTestBytesEncodingURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -169,15 +331,33 @@ func (c *bytesEncodingServiceClient) TestBytesEncoding(ctx context.Context, req
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "BytesEncodingService.TestBytesEncoding",
+		Body:     req,
+		Response: &BytesEncodingTest{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("BytesEncodingService.TestBytesEncoding", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -188,7 +368,13 @@ func (c *bytesEncodingServiceClient) TestBytesEncoding(ctx context.Context, req
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -214,9 +400,7 @@ func (c *bytesEncodingServiceClient) GetBytesEncoding(ctx context.Context, req *
 	}
 
 	// Build URL
-	path := "/api/v1/bytes-encoding/{id}"
-	path = strings.Replace(path, "{id}", url.PathEscape(fmt.Sprint(req.Id)), 1)
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + BytesEncodingServiceGetBytesEncodingURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -234,15 +418,32 @@ func (c *bytesEncodingServiceClient) GetBytesEncoding(ctx context.Context, req *
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "BytesEncodingService.GetBytesEncoding",
+		Response: &BytesEncodingTest{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("BytesEncodingService.GetBytesEncoding", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -253,7 +454,13 @@ func (c *bytesEncodingServiceClient) GetBytesEncoding(ctx context.Context, req *
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -286,7 +493,14 @@ func (c *bytesEncodingServiceClient) marshalRequest(req proto.Message, contentTy
 	}
 }
 
-func (c *bytesEncodingServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *bytesEncodingServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -21,9 +22,9 @@ import (
 
 const (
 	// ContentTypeJSON is the content type for JSON requests/responses.
-	ContentTypeJSON = "application/json"
+	ContentTypeJSON = sebufhttp.JSONContentType
 	// ContentTypeProto is the content type for binary protobuf requests/responses.
-	ContentTypeProto = "application/x-protobuf"
+	ContentTypeProto = sebufhttp.ProtoContentType
 )
 
 // sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
@@ -34,13 +35,21 @@ type sebufUnmarshaler interface {
 
 // FeatureServiceClient is the client API for FeatureService service.
 type FeatureServiceClient interface {
+	// ListNotes GET with query params and a multiple method header (X-Note-Tag)
 	ListNotes(ctx context.Context, req *ListNotesRequest, opts ...FeatureServiceCallOption) (*ListNotesResponse, error)
+	// GetNote GET with path param
 	GetNote(ctx context.Context, req *GetNoteRequest, opts ...FeatureServiceCallOption) (*Note, error)
+	// CreateNote POST with method header (X-Request-ID) - has enums, repeated, maps, optional
 	CreateNote(ctx context.Context, req *CreateNoteRequest, opts ...FeatureServiceCallOption) (*Note, error)
+	// UpdateNote PUT with different method header (X-Idempotency-Key) - tests header dedup
 	UpdateNote(ctx context.Context, req *UpdateNoteRequest, opts ...FeatureServiceCallOption) (*Note, error)
+	// GetNoteList root repeated unwrap -> Note[]
 	GetNoteList(ctx context.Context, req *GetNoteListRequest, opts ...FeatureServiceCallOption) (*NoteList, error)
+	// GetNoteMap root map unwrap -> Record<string, Note>
 	GetNoteMap(ctx context.Context, req *GetNoteMapRequest, opts ...FeatureServiceCallOption) (*NoteMap, error)
+	// GetBarsBySymbol map-value unwrap -> data: Record<string, Bar[]>
 	GetBarsBySymbol(ctx context.Context, req *GetBarsBySymbolRequest, opts ...FeatureServiceCallOption) (*BarsBySymbol, error)
+	// GetCombinedUnwrap combined root + value unwrap -> Record<string, Bar[]>
 	GetCombinedUnwrap(ctx context.Context, req *GetCombinedUnwrapRequest, opts ...FeatureServiceCallOption) (*CombinedUnwrap, error)
 }
 
@@ -50,7 +59,15 @@ type featureServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ FeatureServiceClient = (*featureServiceClient)(nil)
@@ -83,6 +100,27 @@ func WithFeatureServiceDefaultHeader(key, value string) FeatureServiceClientOpti
 	}
 }
 
+// WithFeatureServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithFeatureServiceHeaderPropagation(allowlist ...string) FeatureServiceClientOption {
+	return func(c *featureServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithFeatureServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithFeatureServiceDeadlinePropagation(headerName string) FeatureServiceClientOption {
+	return func(c *featureServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithFeatureServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithFeatureServiceDiscardUnknownFields(discard bool) FeatureServiceClientOption {
@@ -91,6 +129,105 @@ func WithFeatureServiceDiscardUnknownFields(discard bool) FeatureServiceClientOp
 	}
 }
 
+// WithFeatureServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithFeatureServiceHedging(delay time.Duration, maxHedges int) FeatureServiceClientOption {
+	return func(c *featureServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithFeatureServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithFeatureServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) FeatureServiceClientOption {
+	return func(c *featureServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithFeatureServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithFeatureServiceRecorder(recorder sebufhttp.CallRecorder) FeatureServiceClientOption {
+	return func(c *featureServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithFeatureServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithFeatureServiceDebugLogging(w io.Writer) FeatureServiceClientOption {
+	return func(c *featureServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithFeatureServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithFeatureServiceDebugBodyLimit(limit int) FeatureServiceClientOption {
+	return func(c *featureServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithFeatureServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithFeatureServiceLogHook(hook func(sebufhttp.ClientLogEvent)) FeatureServiceClientOption {
+	return func(c *featureServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithFeatureServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithFeatureServiceShadowMutations is set.
+func WithFeatureServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) FeatureServiceClientOption {
+	return func(c *featureServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithFeatureServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithFeatureServiceShadowMutations() FeatureServiceClientOption {
+	return func(c *featureServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithFeatureServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithFeatureServiceShadowTimeout(timeout time.Duration) FeatureServiceClientOption {
+	return func(c *featureServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // FeatureServiceCallOption configures a single RPC call.
 type FeatureServiceCallOption func(*featureServiceCallOptions)
 
@@ -99,6 +236,7 @@ type featureServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithFeatureServiceHeader adds a header to a single request.
@@ -126,29 +264,42 @@ func WithFeatureServiceCallDiscardUnknownFields(discard bool) FeatureServiceCall
 	}
 }
 
+// WithFeatureServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithFeatureServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) FeatureServiceCallOption {
+	return func(o *featureServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // WithFeatureServiceAPIKey API authentication key
 func WithFeatureServiceAPIKey(value string) FeatureServiceClientOption {
-	return WithFeatureServiceDefaultHeader("X-API-Key", value)
+	return WithFeatureServiceDefaultHeader("X-Api-Key", value)
 }
 
 // WithFeatureServiceCallAPIKey API authentication key for a single request.
 func WithFeatureServiceCallAPIKey(value string) FeatureServiceCallOption {
-	return WithFeatureServiceHeader("X-API-Key", value)
+	return WithFeatureServiceHeader("X-Api-Key", value)
 }
 
 // WithFeatureServiceTenantID Tenant identifier
 func WithFeatureServiceTenantID(value string) FeatureServiceClientOption {
-	return WithFeatureServiceDefaultHeader("X-Tenant-ID", value)
+	return WithFeatureServiceDefaultHeader("X-Tenant-Id", value)
 }
 
 // WithFeatureServiceCallTenantID Tenant identifier for a single request.
 func WithFeatureServiceCallTenantID(value string) FeatureServiceCallOption {
-	return WithFeatureServiceHeader("X-Tenant-ID", value)
+	return WithFeatureServiceHeader("X-Tenant-Id", value)
 }
 
-// WithFeatureServiceCallRequestID sets the X-Request-ID header for a single request.
+// WithFeatureServiceCallNoteTag Tags the notes must carry for a single request.
+func WithFeatureServiceCallNoteTag(values ...string) FeatureServiceCallOption {
+	return WithFeatureServiceHeader("X-Note-Tag", strings.Join(values, ", "))
+}
+
+// WithFeatureServiceCallRequestID sets the X-Request-Id header for a single request.
 func WithFeatureServiceCallRequestID(value string) FeatureServiceCallOption {
-	return WithFeatureServiceHeader("X-Request-ID", value)
+	return WithFeatureServiceHeader("X-Request-Id", value)
 }
 
 // WithFeatureServiceCallIdempotencyKey sets the X-Idempotency-Key header for a single request.
@@ -172,16 +323,31 @@ func NewFeatureServiceClient(baseURL string, opts ...FeatureServiceClientOption)
 	return c
 }
 
-// ListNotes calls the ListNotes RPC.
-func (c *featureServiceClient) ListNotes(ctx context.Context, req *ListNotesRequest, opts ...FeatureServiceCallOption) (*ListNotesResponse, error) {
-	callOpts := &featureServiceCallOptions{}
-	for _, opt := range opts {
-		opt(callOpts)
-	}
+// FeatureServiceRoutes holds the HTTP verb and path template of every FeatureService method.
+var FeatureServiceRoutes = struct {
+	ListNotes         sebufhttp.Route
+	GetNote           sebufhttp.Route
+	CreateNote        sebufhttp.Route
+	UpdateNote        sebufhttp.Route
+	GetNoteList       sebufhttp.Route
+	GetNoteMap        sebufhttp.Route
+	GetBarsBySymbol   sebufhttp.Route
+	GetCombinedUnwrap sebufhttp.Route
+}{
+	ListNotes:         sebufhttp.Route{Method: "GET", Path: "/api/v1/notes"},
+	GetNote:           sebufhttp.Route{Method: "GET", Path: "/api/v1/notes/{note_id}"},
+	CreateNote:        sebufhttp.Route{Method: "POST", Path: "/api/v1/notes"},
+	UpdateNote:        sebufhttp.Route{Method: "PUT", Path: "/api/v1/notes/{note_id}"},
+	GetNoteList:       sebufhttp.Route{Method: "POST", Path: "/api/v1/notes/list"},
+	GetNoteMap:        sebufhttp.Route{Method: "POST", Path: "/api/v1/notes/map"},
+	GetBarsBySymbol:   sebufhttp.Route{Method: "POST", Path: "/api/v1/bars"},
+	GetCombinedUnwrap: sebufhttp.Route{Method: "POST", Path: "/api/v1/bars/combined"},
+}
 
-	// Build URL
+// FeatureServiceListNotesURL returns the path and query string of a ListNotes call with req,
+// relative to the client's base URL.
+func FeatureServiceListNotesURL(req *ListNotesRequest) string {
 	path := "/api/v1/notes"
-	reqURL := c.baseURL + path
 
 	// Add query parameters
 	queryParams := url.Values{}
@@ -195,9 +361,67 @@ func (c *featureServiceClient) ListNotes(ctx context.Context, req *ListNotesRequ
 		queryParams.Set("filter", fmt.Sprint(req.Filter))
 	}
 	if len(queryParams) > 0 {
-		reqURL += "?" + queryParams.Encode()
+		path += "?" + queryParams.Encode()
+	}
+	return path
+}
+
+// FeatureServiceGetNoteURL returns the path and query string of a GetNote call with req,
+// relative to the client's base URL.
+func FeatureServiceGetNoteURL(req *GetNoteRequest) string {
+	path := "/api/v1/notes/{note_id}"
+	path = strings.Replace(path, "{note_id}", url.PathEscape(fmt.Sprint(req.NoteId)), 1)
+	return path
+}
+
+// FeatureServiceCreateNoteURL returns the path and query string of a CreateNote call with req,
+// relative to the client's base URL.
+func FeatureServiceCreateNoteURL(req *CreateNoteRequest) string {
+	return "/api/v1/notes"
+}
+
+// FeatureServiceUpdateNoteURL returns the path and query string of a UpdateNote call with req,
+// relative to the client's base URL.
+func FeatureServiceUpdateNoteURL(req *UpdateNoteRequest) string {
+	path := "/api/v1/notes/{note_id}"
+	path = strings.Replace(path, "{note_id}", url.PathEscape(fmt.Sprint(req.NoteId)), 1)
+	return path
+}
+
+// FeatureServiceGetNoteListURL returns the path and query string of a GetNoteList call with req,
+// relative to the client's base URL.
+func FeatureServiceGetNoteListURL(req *GetNoteListRequest) string {
+	return "/api/v1/notes/list"
+}
+
+// FeatureServiceGetNoteMapURL returns the path and query string of a GetNoteMap call with req,
+// relative to the client's base URL.
+func FeatureServiceGetNoteMapURL(req *GetNoteMapRequest) string {
+	return "/api/v1/notes/map"
+}
+
+// FeatureServiceGetBarsBySymbolURL returns the path and query string of a GetBarsBySymbol call with req,
+// relative to the client's base URL.
+func FeatureServiceGetBarsBySymbolURL(req *GetBarsBySymbolRequest) string {
+	return "/api/v1/bars"
+}
+
+// FeatureServiceGetCombinedUnwrapURL returns the path and query string of a GetCombinedUnwrap call with req,
+// relative to the client's base URL.
+func FeatureServiceGetCombinedUnwrapURL(req *GetCombinedUnwrapRequest) string {
+	return "/api/v1/bars/combined"
+}
+
+// ListNotes GET with query params and a multiple method header (X-Note-Tag)
+func (c *featureServiceClient) ListNotes(ctx context.Context, req *ListNotesRequest, opts ...FeatureServiceCallOption) (*ListNotesResponse, error) {
+	callOpts := &featureServiceCallOptions{}
+	for _, opt := range opts {
+		opt(callOpts)
 	}
 
+	// Build URL
+	reqURL := c.baseURL + FeatureServiceListNotesURL(req)
+
 	contentType := c.contentType
 	if callOpts.contentType != "" {
 		contentType = callOpts.contentType
@@ -214,15 +438,32 @@ func (c *featureServiceClient) ListNotes(ctx context.Context, req *ListNotesRequ
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "FeatureService.ListNotes",
+		Response: &ListNotesResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("FeatureService.ListNotes", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -233,7 +474,13 @@ func (c *featureServiceClient) ListNotes(ctx context.Context, req *ListNotesRequ
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -251,7 +498,7 @@ func (c *featureServiceClient) ListNotes(ctx context.Context, req *ListNotesRequ
 	return result, nil
 }
 
-// GetNote calls the GetNote RPC.
+// GetNote GET with path param
 func (c *featureServiceClient) GetNote(ctx context.Context, req *GetNoteRequest, opts ...FeatureServiceCallOption) (*Note, error) {
 	callOpts := &featureServiceCallOptions{}
 	for _, opt := range opts {
@@ -259,9 +506,7 @@ func (c *featureServiceClient) GetNote(ctx context.Context, req *GetNoteRequest,
 	}
 
 	// Build URL
-	path := "/api/v1/notes/{note_id}"
-	path = strings.Replace(path, "{note_id}", url.PathEscape(fmt.Sprint(req.NoteId)), 1)
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + FeatureServiceGetNoteURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -279,15 +524,32 @@ func (c *featureServiceClient) GetNote(ctx context.Context, req *GetNoteRequest,
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "FeatureService.GetNote",
+		Response: &Note{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("FeatureService.GetNote", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -298,7 +560,13 @@ func (c *featureServiceClient) GetNote(ctx context.Context, req *GetNoteRequest,
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -316,7 +584,7 @@ func (c *featureServiceClient) GetNote(ctx context.Context, req *GetNoteRequest,
 	return result, nil
 }
 
-// CreateNote calls the CreateNote RPC.
+// CreateNote POST with method header (X-Request-ID) - has enums, repeated, maps, optional
 func (c *featureServiceClient) CreateNote(ctx context.Context, req *CreateNoteRequest, opts ...FeatureServiceCallOption) (*Note, error) {
 	callOpts := &featureServiceCallOptions{}
 	for _, opt := range opts {
@@ -324,8 +592,7 @@ func (c *featureServiceClient) CreateNote(ctx context.Context, req *CreateNoteRe
 	}
 
 	// Build URL
-	path := "/api/v1/notes"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + FeatureServiceCreateNoteURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -349,15 +616,33 @@ func (c *featureServiceClient) CreateNote(ctx context.Context, req *CreateNoteRe
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "FeatureService.CreateNote",
+		Body:     req,
+		Response: &Note{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("FeatureService.CreateNote", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -368,7 +653,13 @@ func (c *featureServiceClient) CreateNote(ctx context.Context, req *CreateNoteRe
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -386,7 +677,7 @@ func (c *featureServiceClient) CreateNote(ctx context.Context, req *CreateNoteRe
 	return result, nil
 }
 
-// UpdateNote calls the UpdateNote RPC.
+// UpdateNote PUT with different method header (X-Idempotency-Key) - tests header dedup
 func (c *featureServiceClient) UpdateNote(ctx context.Context, req *UpdateNoteRequest, opts ...FeatureServiceCallOption) (*Note, error) {
 	callOpts := &featureServiceCallOptions{}
 	for _, opt := range opts {
@@ -394,9 +685,7 @@ func (c *featureServiceClient) UpdateNote(ctx context.Context, req *UpdateNoteRe
 	}
 
 	// Build URL
-	path := "/api/v1/notes/{note_id}"
-	path = strings.Replace(path, "{note_id}", url.PathEscape(fmt.Sprint(req.NoteId)), 1)
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + FeatureServiceUpdateNoteURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -420,15 +709,33 @@ func (c *featureServiceClient) UpdateNote(ctx context.Context, req *UpdateNoteRe
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "FeatureService.UpdateNote",
+		Body:     req,
+		Response: &Note{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("FeatureService.UpdateNote", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -439,7 +746,13 @@ func (c *featureServiceClient) UpdateNote(ctx context.Context, req *UpdateNoteRe
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -457,7 +770,7 @@ func (c *featureServiceClient) UpdateNote(ctx context.Context, req *UpdateNoteRe
 	return result, nil
 }
 
-// GetNoteList calls the GetNoteList RPC.
+// GetNoteList root repeated unwrap -> Note[]
 func (c *featureServiceClient) GetNoteList(ctx context.Context, req *GetNoteListRequest, opts ...FeatureServiceCallOption) (*NoteList, error) {
 	callOpts := &featureServiceCallOptions{}
 	for _, opt := range opts {
@@ -465,8 +778,7 @@ func (c *featureServiceClient) GetNoteList(ctx context.Context, req *GetNoteList
 	}
 
 	// Build URL
-	path := "/api/v1/notes/list"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + FeatureServiceGetNoteListURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -490,15 +802,33 @@ func (c *featureServiceClient) GetNoteList(ctx context.Context, req *GetNoteList
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "FeatureService.GetNoteList",
+		Body:     req,
+		Response: &NoteList{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("FeatureService.GetNoteList", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -509,7 +839,13 @@ func (c *featureServiceClient) GetNoteList(ctx context.Context, req *GetNoteList
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, false)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -527,7 +863,7 @@ func (c *featureServiceClient) GetNoteList(ctx context.Context, req *GetNoteList
 	return result, nil
 }
 
-// GetNoteMap calls the GetNoteMap RPC.
+// GetNoteMap root map unwrap -> Record<string, Note>
 func (c *featureServiceClient) GetNoteMap(ctx context.Context, req *GetNoteMapRequest, opts ...FeatureServiceCallOption) (*NoteMap, error) {
 	callOpts := &featureServiceCallOptions{}
 	for _, opt := range opts {
@@ -535,8 +871,7 @@ func (c *featureServiceClient) GetNoteMap(ctx context.Context, req *GetNoteMapRe
 	}
 
 	// Build URL
-	path := "/api/v1/notes/map"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + FeatureServiceGetNoteMapURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -560,15 +895,33 @@ func (c *featureServiceClient) GetNoteMap(ctx context.Context, req *GetNoteMapRe
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "FeatureService.GetNoteMap",
+		Body:     req,
+		Response: &NoteMap{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("FeatureService.GetNoteMap", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -579,7 +932,13 @@ func (c *featureServiceClient) GetNoteMap(ctx context.Context, req *GetNoteMapRe
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, false)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -597,7 +956,7 @@ func (c *featureServiceClient) GetNoteMap(ctx context.Context, req *GetNoteMapRe
 	return result, nil
 }
 
-// GetBarsBySymbol calls the GetBarsBySymbol RPC.
+// GetBarsBySymbol map-value unwrap -> data: Record<string, Bar[]>
 func (c *featureServiceClient) GetBarsBySymbol(ctx context.Context, req *GetBarsBySymbolRequest, opts ...FeatureServiceCallOption) (*BarsBySymbol, error) {
 	callOpts := &featureServiceCallOptions{}
 	for _, opt := range opts {
@@ -605,8 +964,7 @@ func (c *featureServiceClient) GetBarsBySymbol(ctx context.Context, req *GetBars
 	}
 
 	// Build URL
-	path := "/api/v1/bars"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + FeatureServiceGetBarsBySymbolURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -630,15 +988,33 @@ func (c *featureServiceClient) GetBarsBySymbol(ctx context.Context, req *GetBars
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "FeatureService.GetBarsBySymbol",
+		Body:     req,
+		Response: &BarsBySymbol{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("FeatureService.GetBarsBySymbol", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -649,7 +1025,13 @@ func (c *featureServiceClient) GetBarsBySymbol(ctx context.Context, req *GetBars
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -667,7 +1049,7 @@ func (c *featureServiceClient) GetBarsBySymbol(ctx context.Context, req *GetBars
 	return result, nil
 }
 
-// GetCombinedUnwrap calls the GetCombinedUnwrap RPC.
+// GetCombinedUnwrap combined root + value unwrap -> Record<string, Bar[]>
 func (c *featureServiceClient) GetCombinedUnwrap(ctx context.Context, req *GetCombinedUnwrapRequest, opts ...FeatureServiceCallOption) (*CombinedUnwrap, error) {
 	callOpts := &featureServiceCallOptions{}
 	for _, opt := range opts {
@@ -675,8 +1057,7 @@ func (c *featureServiceClient) GetCombinedUnwrap(ctx context.Context, req *GetCo
 	}
 
 	// Build URL
-	path := "/api/v1/bars/combined"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + FeatureServiceGetCombinedUnwrapURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -700,15 +1081,33 @@ func (c *featureServiceClient) GetCombinedUnwrap(ctx context.Context, req *GetCo
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "FeatureService.GetCombinedUnwrap",
+		Body:     req,
+		Response: &CombinedUnwrap{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("FeatureService.GetCombinedUnwrap", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -719,7 +1118,13 @@ func (c *featureServiceClient) GetCombinedUnwrap(ctx context.Context, req *GetCo
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, false)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -752,7 +1157,14 @@ func (c *featureServiceClient) marshalRequest(req proto.Message, contentType str
 	}
 }
 
-func (c *featureServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *featureServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
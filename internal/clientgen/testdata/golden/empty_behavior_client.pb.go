@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -20,9 +21,9 @@ import (
 
 const (
 	// ContentTypeJSON is the content type for JSON requests/responses.
-	ContentTypeJSON = "application/json"
+	ContentTypeJSON = sebufhttp.JSONContentType
 	// ContentTypeProto is the content type for binary protobuf requests/responses.
-	ContentTypeProto = "application/x-protobuf"
+	ContentTypeProto = sebufhttp.ProtoContentType
 )
 
 // sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
@@ -32,6 +33,8 @@ type sebufUnmarshaler interface {
 }
 
 // EmptyBehaviorServiceClient is the client API for EmptyBehaviorService service.
+//
+// EmptyBehaviorService tests empty behavior in responses.
 type EmptyBehaviorServiceClient interface {
 	GetResponse(ctx context.Context, req *GetResponseRequest, opts ...EmptyBehaviorServiceCallOption) (*Response, error)
 }
@@ -42,7 +45,15 @@ type emptyBehaviorServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ EmptyBehaviorServiceClient = (*emptyBehaviorServiceClient)(nil)
@@ -75,6 +86,27 @@ func WithEmptyBehaviorServiceDefaultHeader(key, value string) EmptyBehaviorServi
 	}
 }
 
+// WithEmptyBehaviorServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithEmptyBehaviorServiceHeaderPropagation(allowlist ...string) EmptyBehaviorServiceClientOption {
+	return func(c *emptyBehaviorServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithEmptyBehaviorServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithEmptyBehaviorServiceDeadlinePropagation(headerName string) EmptyBehaviorServiceClientOption {
+	return func(c *emptyBehaviorServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithEmptyBehaviorServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithEmptyBehaviorServiceDiscardUnknownFields(discard bool) EmptyBehaviorServiceClientOption {
@@ -83,6 +115,105 @@ func WithEmptyBehaviorServiceDiscardUnknownFields(discard bool) EmptyBehaviorSer
 	}
 }
 
+// WithEmptyBehaviorServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithEmptyBehaviorServiceHedging(delay time.Duration, maxHedges int) EmptyBehaviorServiceClientOption {
+	return func(c *emptyBehaviorServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithEmptyBehaviorServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithEmptyBehaviorServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) EmptyBehaviorServiceClientOption {
+	return func(c *emptyBehaviorServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithEmptyBehaviorServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithEmptyBehaviorServiceRecorder(recorder sebufhttp.CallRecorder) EmptyBehaviorServiceClientOption {
+	return func(c *emptyBehaviorServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithEmptyBehaviorServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithEmptyBehaviorServiceDebugLogging(w io.Writer) EmptyBehaviorServiceClientOption {
+	return func(c *emptyBehaviorServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithEmptyBehaviorServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithEmptyBehaviorServiceDebugBodyLimit(limit int) EmptyBehaviorServiceClientOption {
+	return func(c *emptyBehaviorServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithEmptyBehaviorServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithEmptyBehaviorServiceLogHook(hook func(sebufhttp.ClientLogEvent)) EmptyBehaviorServiceClientOption {
+	return func(c *emptyBehaviorServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithEmptyBehaviorServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithEmptyBehaviorServiceShadowMutations is set.
+func WithEmptyBehaviorServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) EmptyBehaviorServiceClientOption {
+	return func(c *emptyBehaviorServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithEmptyBehaviorServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithEmptyBehaviorServiceShadowMutations() EmptyBehaviorServiceClientOption {
+	return func(c *emptyBehaviorServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithEmptyBehaviorServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithEmptyBehaviorServiceShadowTimeout(timeout time.Duration) EmptyBehaviorServiceClientOption {
+	return func(c *emptyBehaviorServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // EmptyBehaviorServiceCallOption configures a single RPC call.
 type EmptyBehaviorServiceCallOption func(*emptyBehaviorServiceCallOptions)
 
@@ -91,6 +222,7 @@ type emptyBehaviorServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithEmptyBehaviorServiceHeader adds a header to a single request.
@@ -118,6 +250,14 @@ func WithEmptyBehaviorServiceCallDiscardUnknownFields(discard bool) EmptyBehavio
 	}
 }
 
+// WithEmptyBehaviorServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithEmptyBehaviorServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) EmptyBehaviorServiceCallOption {
+	return func(o *emptyBehaviorServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // NewEmptyBehaviorServiceClient creates a new EmptyBehaviorService client.
 func NewEmptyBehaviorServiceClient(baseURL string, opts ...EmptyBehaviorServiceClientOption) EmptyBehaviorServiceClient {
 	c := &emptyBehaviorServiceClient{
@@ -134,6 +274,21 @@ func NewEmptyBehaviorServiceClient(baseURL string, opts ...EmptyBehaviorServiceC
 	return c
 }
 
+// EmptyBehaviorServiceRoutes holds the HTTP verb and path template of every EmptyBehaviorService method.
+var EmptyBehaviorServiceRoutes = struct {
+	GetResponse sebufhttp.Route
+}{
+	GetResponse: sebufhttp.Route{Method: "GET", Path: "/api/v1/responses/{id}"},
+}
+
+// EmptyBehaviorServiceGetResponseURL returns the path and query string of a GetResponse call with req,
+// relative to the client's base URL.
+func EmptyBehaviorServiceGetResponseURL(req *GetResponseRequest) string {
+	path := "/api/v1/responses/{id}"
+	path = strings.Replace(path, "{id}", url.PathEscape(fmt.Sprint(req.Id)), 1)
+	return path
+}
+
 // GetResponse calls the GetResponse RPC.
 func (c *emptyBehaviorServiceClient) GetResponse(ctx context.Context, req *GetResponseRequest, opts ...EmptyBehaviorServiceCallOption) (*Response, error) {
 	callOpts := &emptyBehaviorServiceCallOptions{}
@@ -142,9 +297,7 @@ func (c *emptyBehaviorServiceClient) GetResponse(ctx context.Context, req *GetRe
 	}
 
 	// Build URL
-	path := "/api/v1/responses/{id}"
-	path = strings.Replace(path, "{id}", url.PathEscape(fmt.Sprint(req.Id)), 1)
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + EmptyBehaviorServiceGetResponseURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -162,15 +315,32 @@ func (c *emptyBehaviorServiceClient) GetResponse(ctx context.Context, req *GetRe
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "EmptyBehaviorService.GetResponse",
+		Response: &Response{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("EmptyBehaviorService.GetResponse", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -181,7 +351,13 @@ func (c *emptyBehaviorServiceClient) GetResponse(ctx context.Context, req *GetRe
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -214,7 +390,14 @@ func (c *emptyBehaviorServiceClient) marshalRequest(req proto.Message, contentTy
 	}
 }
 
-func (c *emptyBehaviorServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *emptyBehaviorServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
@@ -8,6 +8,8 @@ import (
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
 
 // MarshalJSONSebuf implements sebufMarshaler for Response.
@@ -61,6 +63,15 @@ func (x *Response) MarshalJSON() ([]byte, error) {
 	return x.MarshalJSONSebuf(protojson.MarshalOptions{})
 }
 
+// responseAlternateJSONNames maps the proto names and JSON names protojson
+// accepts for the fields of Response to the keys UnmarshalJSON reads.
+var responseAlternateJSONNames = map[string]string{
+	"metadata_default":  "metadataDefault",
+	"metadata_null":     "metadataNull",
+	"metadata_omit":     "metadataOmit",
+	"metadata_preserve": "metadataPreserve",
+}
+
 // UnmarshalJSONSebuf implements sebufUnmarshaler for Response.
 // This method handles empty_behavior fields: metadata_preserve, metadata_null, metadata_omit, settings
 func (x *Response) UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error {
@@ -69,6 +80,9 @@ func (x *Response) UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptio
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	if err := sebufhttp.ResolveAlternateJSONNames(raw, responseAlternateJSONNames); err != nil {
+		return err
+	}
 
 	// Handle empty_behavior=NULL: convert null to {} for protojson
 	if rawVal, ok := raw["metadataNull"]; ok && string(rawVal) == "null" {
@@ -11,6 +11,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -20,9 +21,9 @@ import (
 
 const (
 	// ContentTypeJSON is the content type for JSON requests/responses.
-	ContentTypeJSON = "application/json"
+	ContentTypeJSON = sebufhttp.JSONContentType
 	// ContentTypeProto is the content type for binary protobuf requests/responses.
-	ContentTypeProto = "application/x-protobuf"
+	ContentTypeProto = sebufhttp.ProtoContentType
 )
 
 // sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
@@ -32,8 +33,13 @@ type sebufUnmarshaler interface {
 }
 
 // EmptyRequestBodyServiceClient is the client API for EmptyRequestBodyService service.
+//
+// EmptyRequestBodyService exercises requests whose message has no fields, across a verb that sends
+// a body (POST) and one that does not (GET).
 type EmptyRequestBodyServiceClient interface {
+	// Ping sends an empty JSON body over POST.
 	Ping(ctx context.Context, req *PingRequest, opts ...EmptyRequestBodyServiceCallOption) (*PingResponse, error)
+	// NoArgs is a GET endpoint that takes no parameters.
 	NoArgs(ctx context.Context, req *NoArgsRequest, opts ...EmptyRequestBodyServiceCallOption) (*NoArgsResponse, error)
 }
 
@@ -43,7 +49,15 @@ type emptyRequestBodyServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ EmptyRequestBodyServiceClient = (*emptyRequestBodyServiceClient)(nil)
@@ -76,6 +90,27 @@ func WithEmptyRequestBodyServiceDefaultHeader(key, value string) EmptyRequestBod
 	}
 }
 
+// WithEmptyRequestBodyServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithEmptyRequestBodyServiceHeaderPropagation(allowlist ...string) EmptyRequestBodyServiceClientOption {
+	return func(c *emptyRequestBodyServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithEmptyRequestBodyServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithEmptyRequestBodyServiceDeadlinePropagation(headerName string) EmptyRequestBodyServiceClientOption {
+	return func(c *emptyRequestBodyServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithEmptyRequestBodyServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithEmptyRequestBodyServiceDiscardUnknownFields(discard bool) EmptyRequestBodyServiceClientOption {
@@ -84,6 +119,105 @@ func WithEmptyRequestBodyServiceDiscardUnknownFields(discard bool) EmptyRequestB
 	}
 }
 
+// WithEmptyRequestBodyServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithEmptyRequestBodyServiceHedging(delay time.Duration, maxHedges int) EmptyRequestBodyServiceClientOption {
+	return func(c *emptyRequestBodyServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithEmptyRequestBodyServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithEmptyRequestBodyServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) EmptyRequestBodyServiceClientOption {
+	return func(c *emptyRequestBodyServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithEmptyRequestBodyServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithEmptyRequestBodyServiceRecorder(recorder sebufhttp.CallRecorder) EmptyRequestBodyServiceClientOption {
+	return func(c *emptyRequestBodyServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithEmptyRequestBodyServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithEmptyRequestBodyServiceDebugLogging(w io.Writer) EmptyRequestBodyServiceClientOption {
+	return func(c *emptyRequestBodyServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithEmptyRequestBodyServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithEmptyRequestBodyServiceDebugBodyLimit(limit int) EmptyRequestBodyServiceClientOption {
+	return func(c *emptyRequestBodyServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithEmptyRequestBodyServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithEmptyRequestBodyServiceLogHook(hook func(sebufhttp.ClientLogEvent)) EmptyRequestBodyServiceClientOption {
+	return func(c *emptyRequestBodyServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithEmptyRequestBodyServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithEmptyRequestBodyServiceShadowMutations is set.
+func WithEmptyRequestBodyServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) EmptyRequestBodyServiceClientOption {
+	return func(c *emptyRequestBodyServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithEmptyRequestBodyServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithEmptyRequestBodyServiceShadowMutations() EmptyRequestBodyServiceClientOption {
+	return func(c *emptyRequestBodyServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithEmptyRequestBodyServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithEmptyRequestBodyServiceShadowTimeout(timeout time.Duration) EmptyRequestBodyServiceClientOption {
+	return func(c *emptyRequestBodyServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // EmptyRequestBodyServiceCallOption configures a single RPC call.
 type EmptyRequestBodyServiceCallOption func(*emptyRequestBodyServiceCallOptions)
 
@@ -92,6 +226,7 @@ type emptyRequestBodyServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithEmptyRequestBodyServiceHeader adds a header to a single request.
@@ -119,6 +254,14 @@ func WithEmptyRequestBodyServiceCallDiscardUnknownFields(discard bool) EmptyRequ
 	}
 }
 
+// WithEmptyRequestBodyServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithEmptyRequestBodyServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) EmptyRequestBodyServiceCallOption {
+	return func(o *emptyRequestBodyServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // NewEmptyRequestBodyServiceClient creates a new EmptyRequestBodyService client.
 func NewEmptyRequestBodyServiceClient(baseURL string, opts ...EmptyRequestBodyServiceClientOption) EmptyRequestBodyServiceClient {
 	c := &emptyRequestBodyServiceClient{
@@ -135,7 +278,28 @@ func NewEmptyRequestBodyServiceClient(baseURL string, opts ...EmptyRequestBodySe
 	return c
 }
 
-// Ping calls the Ping RPC.
+// EmptyRequestBodyServiceRoutes holds the HTTP verb and path template of every EmptyRequestBodyService method.
+var EmptyRequestBodyServiceRoutes = struct {
+	Ping   sebufhttp.Route
+	NoArgs sebufhttp.Route
+}{
+	Ping:   sebufhttp.Route{Method: "POST", Path: "/api/v1/ping"},
+	NoArgs: sebufhttp.Route{Method: "GET", Path: "/api/v1/no-args"},
+}
+
+// EmptyRequestBodyServicePingURL returns the path and query string of a Ping call with req,
+// relative to the client's base URL.
+func EmptyRequestBodyServicePingURL(req *PingRequest) string {
+	return "/api/v1/ping"
+}
+
+// EmptyRequestBodyServiceNoArgsURL returns the path and query string of a NoArgs call with req,
+// relative to the client's base URL.
+func EmptyRequestBodyServiceNoArgsURL(req *NoArgsRequest) string {
+	return "/api/v1/no-args"
+}
+
+// Ping sends an empty JSON body over POST.
 func (c *emptyRequestBodyServiceClient) Ping(ctx context.Context, req *PingRequest, opts ...EmptyRequestBodyServiceCallOption) (*PingResponse, error) {
 	callOpts := &emptyRequestBodyServiceCallOptions{}
 	for _, opt := range opts {
@@ -143,8 +307,7 @@ func (c *emptyRequestBodyServiceClient) Ping(ctx context.Context, req *PingReque
 	}
 
 	// Build URL
-	path := "/api/v1/ping"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + EmptyRequestBodyServicePingURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -168,15 +331,33 @@ func (c *emptyRequestBodyServiceClient) Ping(ctx context.Context, req *PingReque
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "EmptyRequestBodyService.Ping",
+		Body:     req,
+		Response: &PingResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("EmptyRequestBodyService.Ping", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -187,7 +368,13 @@ func (c *emptyRequestBodyServiceClient) Ping(ctx context.Context, req *PingReque
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -205,7 +392,7 @@ func (c *emptyRequestBodyServiceClient) Ping(ctx context.Context, req *PingReque
 	return result, nil
 }
 
-// NoArgs calls the NoArgs RPC.
+// NoArgs is a GET endpoint that takes no parameters.
 func (c *emptyRequestBodyServiceClient) NoArgs(ctx context.Context, req *NoArgsRequest, opts ...EmptyRequestBodyServiceCallOption) (*NoArgsResponse, error) {
 	callOpts := &emptyRequestBodyServiceCallOptions{}
 	for _, opt := range opts {
@@ -213,8 +400,7 @@ func (c *emptyRequestBodyServiceClient) NoArgs(ctx context.Context, req *NoArgsR
 	}
 
 	// Build URL
-	path := "/api/v1/no-args"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + EmptyRequestBodyServiceNoArgsURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -232,15 +418,32 @@ func (c *emptyRequestBodyServiceClient) NoArgs(ctx context.Context, req *NoArgsR
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "EmptyRequestBodyService.NoArgs",
+		Response: &NoArgsResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("EmptyRequestBodyService.NoArgs", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -251,7 +454,13 @@ func (c *emptyRequestBodyServiceClient) NoArgs(ctx context.Context, req *NoArgsR
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -284,7 +493,14 @@ func (c *emptyRequestBodyServiceClient) marshalRequest(req proto.Message, conten
 	}
 }
 
-func (c *emptyRequestBodyServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *emptyRequestBodyServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -20,9 +21,9 @@ import (
 
 const (
 	// ContentTypeJSON is the content type for JSON requests/responses.
-	ContentTypeJSON = "application/json"
+	ContentTypeJSON = sebufhttp.JSONContentType
 	// ContentTypeProto is the content type for binary protobuf requests/responses.
-	ContentTypeProto = "application/x-protobuf"
+	ContentTypeProto = sebufhttp.ProtoContentType
 )
 
 // sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
@@ -32,6 +33,8 @@ type sebufUnmarshaler interface {
 }
 
 // EnumEncodingServiceClient is the client API for EnumEncodingService service.
+//
+// Service with enum encoding test
 type EnumEncodingServiceClient interface {
 	GetEnumTest(ctx context.Context, req *GetEnumTestRequest, opts ...EnumEncodingServiceCallOption) (*EnumEncodingTest, error)
 }
@@ -42,7 +45,15 @@ type enumEncodingServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ EnumEncodingServiceClient = (*enumEncodingServiceClient)(nil)
@@ -75,6 +86,27 @@ func WithEnumEncodingServiceDefaultHeader(key, value string) EnumEncodingService
 	}
 }
 
+// WithEnumEncodingServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithEnumEncodingServiceHeaderPropagation(allowlist ...string) EnumEncodingServiceClientOption {
+	return func(c *enumEncodingServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithEnumEncodingServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithEnumEncodingServiceDeadlinePropagation(headerName string) EnumEncodingServiceClientOption {
+	return func(c *enumEncodingServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithEnumEncodingServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithEnumEncodingServiceDiscardUnknownFields(discard bool) EnumEncodingServiceClientOption {
@@ -83,6 +115,105 @@ func WithEnumEncodingServiceDiscardUnknownFields(discard bool) EnumEncodingServi
 	}
 }
 
+// WithEnumEncodingServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithEnumEncodingServiceHedging(delay time.Duration, maxHedges int) EnumEncodingServiceClientOption {
+	return func(c *enumEncodingServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithEnumEncodingServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithEnumEncodingServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) EnumEncodingServiceClientOption {
+	return func(c *enumEncodingServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithEnumEncodingServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithEnumEncodingServiceRecorder(recorder sebufhttp.CallRecorder) EnumEncodingServiceClientOption {
+	return func(c *enumEncodingServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithEnumEncodingServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithEnumEncodingServiceDebugLogging(w io.Writer) EnumEncodingServiceClientOption {
+	return func(c *enumEncodingServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithEnumEncodingServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithEnumEncodingServiceDebugBodyLimit(limit int) EnumEncodingServiceClientOption {
+	return func(c *enumEncodingServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithEnumEncodingServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithEnumEncodingServiceLogHook(hook func(sebufhttp.ClientLogEvent)) EnumEncodingServiceClientOption {
+	return func(c *enumEncodingServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithEnumEncodingServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithEnumEncodingServiceShadowMutations is set.
+func WithEnumEncodingServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) EnumEncodingServiceClientOption {
+	return func(c *enumEncodingServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithEnumEncodingServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithEnumEncodingServiceShadowMutations() EnumEncodingServiceClientOption {
+	return func(c *enumEncodingServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithEnumEncodingServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithEnumEncodingServiceShadowTimeout(timeout time.Duration) EnumEncodingServiceClientOption {
+	return func(c *enumEncodingServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // EnumEncodingServiceCallOption configures a single RPC call.
 type EnumEncodingServiceCallOption func(*enumEncodingServiceCallOptions)
 
@@ -91,6 +222,7 @@ type enumEncodingServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithEnumEncodingServiceHeader adds a header to a single request.
@@ -118,6 +250,14 @@ func WithEnumEncodingServiceCallDiscardUnknownFields(discard bool) EnumEncodingS
 	}
 }
 
+// WithEnumEncodingServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithEnumEncodingServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) EnumEncodingServiceCallOption {
+	return func(o *enumEncodingServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // NewEnumEncodingServiceClient creates a new EnumEncodingService client.
 func NewEnumEncodingServiceClient(baseURL string, opts ...EnumEncodingServiceClientOption) EnumEncodingServiceClient {
 	c := &enumEncodingServiceClient{
@@ -134,6 +274,21 @@ func NewEnumEncodingServiceClient(baseURL string, opts ...EnumEncodingServiceCli
 	return c
 }
 
+// EnumEncodingServiceRoutes holds the HTTP verb and path template of every EnumEncodingService method.
+var EnumEncodingServiceRoutes = struct {
+	GetEnumTest sebufhttp.Route
+}{
+	GetEnumTest: sebufhttp.Route{Method: "GET", Path: "/api/v1/test/enum/{id}"},
+}
+
+// EnumEncodingServiceGetEnumTestURL returns the path and query string of a GetEnumTest call with req,
+// relative to the client's base URL.
+func EnumEncodingServiceGetEnumTestURL(req *GetEnumTestRequest) string {
+	path := "/api/v1/test/enum/{id}"
+	path = strings.Replace(path, "{id}", url.PathEscape(fmt.Sprint(req.Id)), 1)
+	return path
+}
+
 // GetEnumTest calls the GetEnumTest RPC.
 func (c *enumEncodingServiceClient) GetEnumTest(ctx context.Context, req *GetEnumTestRequest, opts ...EnumEncodingServiceCallOption) (*EnumEncodingTest, error) {
 	callOpts := &enumEncodingServiceCallOptions{}
@@ -142,9 +297,7 @@ func (c *enumEncodingServiceClient) GetEnumTest(ctx context.Context, req *GetEnu
 	}
 
 	// Build URL
-	path := "/api/v1/test/enum/{id}"
-	path = strings.Replace(path, "{id}", url.PathEscape(fmt.Sprint(req.Id)), 1)
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + EnumEncodingServiceGetEnumTestURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -162,15 +315,32 @@ func (c *enumEncodingServiceClient) GetEnumTest(ctx context.Context, req *GetEnu
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "EnumEncodingService.GetEnumTest",
+		Response: &EnumEncodingTest{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("EnumEncodingService.GetEnumTest", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -181,7 +351,13 @@ func (c *enumEncodingServiceClient) GetEnumTest(ctx context.Context, req *GetEnu
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -214,7 +390,14 @@ func (c *enumEncodingServiceClient) marshalRequest(req proto.Message, contentTyp
 	}
 }
 
-func (c *enumEncodingServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *enumEncodingServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
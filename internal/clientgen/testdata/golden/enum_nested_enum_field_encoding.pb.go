@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 
 	"google.golang.org/protobuf/encoding/protojson"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
 
 // MarshalJSONSebuf implements sebufMarshaler for Item.
@@ -201,6 +203,13 @@ func (x *ItemGroup) MarshalJSON() ([]byte, error) {
 	return x.MarshalJSONSebuf(protojson.MarshalOptions{})
 }
 
+// itemGroupAlternateJSONNames maps the proto names and JSON names protojson
+// accepts for the fields of ItemGroup to the keys UnmarshalJSON reads.
+var itemGroupAlternateJSONNames = map[string]string{
+	"item_list": "itemList",
+	"lead_item": "leadItem",
+}
+
 // UnmarshalJSONSebuf implements sebufUnmarshaler for ItemGroup.
 // This method handles enum_value fields and nested messages: lead_item, item_list
 func (x *ItemGroup) UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error {
@@ -209,6 +218,9 @@ func (x *ItemGroup) UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOpti
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	if err := sebufhttp.ResolveAlternateJSONNames(raw, itemGroupAlternateJSONNames); err != nil {
+		return err
+	}
 
 	// Handle "leadItem" using its custom unmarshaler
 	for _, k := range []string{"leadItem", "lead_item"} {
@@ -327,6 +339,12 @@ func (x *GetItemsResponse) MarshalJSON() ([]byte, error) {
 	return x.MarshalJSONSebuf(protojson.MarshalOptions{})
 }
 
+// getItemsResponseAlternateJSONNames maps the proto names and JSON names protojson
+// accepts for the fields of GetItemsResponse to the keys UnmarshalJSON reads.
+var getItemsResponseAlternateJSONNames = map[string]string{
+	"item_group": "itemGroup",
+}
+
 // UnmarshalJSONSebuf implements sebufUnmarshaler for GetItemsResponse.
 // This method handles enum_value fields and nested messages: item_group
 func (x *GetItemsResponse) UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error {
@@ -335,6 +353,9 @@ func (x *GetItemsResponse) UnmarshalJSONSebuf(data []byte, opts protojson.Unmars
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	if err := sebufhttp.ResolveAlternateJSONNames(raw, getItemsResponseAlternateJSONNames); err != nil {
+		return err
+	}
 
 	// Handle "itemGroup" using its custom unmarshaler
 	for _, k := range []string{"itemGroup", "item_group"} {
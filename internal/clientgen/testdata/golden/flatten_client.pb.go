@@ -11,6 +11,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -20,9 +21,9 @@ import (
 
 const (
 	// ContentTypeJSON is the content type for JSON requests/responses.
-	ContentTypeJSON = "application/json"
+	ContentTypeJSON = sebufhttp.JSONContentType
 	// ContentTypeProto is the content type for binary protobuf requests/responses.
-	ContentTypeProto = "application/x-protobuf"
+	ContentTypeProto = sebufhttp.ProtoContentType
 )
 
 // sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
@@ -32,6 +33,8 @@ type sebufUnmarshaler interface {
 }
 
 // FlattenServiceClient is the client API for FlattenService service.
+//
+// FlattenService tests flatten in service RPCs.
 type FlattenServiceClient interface {
 	TestSimpleFlatten(ctx context.Context, req *SimpleFlatten, opts ...FlattenServiceCallOption) (*SimpleFlatten, error)
 	TestDualFlatten(ctx context.Context, req *DualFlatten, opts ...FlattenServiceCallOption) (*DualFlatten, error)
@@ -45,7 +48,15 @@ type flattenServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ FlattenServiceClient = (*flattenServiceClient)(nil)
@@ -78,6 +89,27 @@ func WithFlattenServiceDefaultHeader(key, value string) FlattenServiceClientOpti
 	}
 }
 
+// WithFlattenServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithFlattenServiceHeaderPropagation(allowlist ...string) FlattenServiceClientOption {
+	return func(c *flattenServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithFlattenServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithFlattenServiceDeadlinePropagation(headerName string) FlattenServiceClientOption {
+	return func(c *flattenServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithFlattenServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithFlattenServiceDiscardUnknownFields(discard bool) FlattenServiceClientOption {
@@ -86,6 +118,105 @@ func WithFlattenServiceDiscardUnknownFields(discard bool) FlattenServiceClientOp
 	}
 }
 
+// WithFlattenServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithFlattenServiceHedging(delay time.Duration, maxHedges int) FlattenServiceClientOption {
+	return func(c *flattenServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithFlattenServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithFlattenServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) FlattenServiceClientOption {
+	return func(c *flattenServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithFlattenServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithFlattenServiceRecorder(recorder sebufhttp.CallRecorder) FlattenServiceClientOption {
+	return func(c *flattenServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithFlattenServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithFlattenServiceDebugLogging(w io.Writer) FlattenServiceClientOption {
+	return func(c *flattenServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithFlattenServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithFlattenServiceDebugBodyLimit(limit int) FlattenServiceClientOption {
+	return func(c *flattenServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithFlattenServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithFlattenServiceLogHook(hook func(sebufhttp.ClientLogEvent)) FlattenServiceClientOption {
+	return func(c *flattenServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithFlattenServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithFlattenServiceShadowMutations is set.
+func WithFlattenServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) FlattenServiceClientOption {
+	return func(c *flattenServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithFlattenServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithFlattenServiceShadowMutations() FlattenServiceClientOption {
+	return func(c *flattenServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithFlattenServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithFlattenServiceShadowTimeout(timeout time.Duration) FlattenServiceClientOption {
+	return func(c *flattenServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // FlattenServiceCallOption configures a single RPC call.
 type FlattenServiceCallOption func(*flattenServiceCallOptions)
 
@@ -94,6 +225,7 @@ type flattenServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithFlattenServiceHeader adds a header to a single request.
@@ -121,6 +253,14 @@ func WithFlattenServiceCallDiscardUnknownFields(discard bool) FlattenServiceCall
 	}
 }
 
+// WithFlattenServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithFlattenServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) FlattenServiceCallOption {
+	return func(o *flattenServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // NewFlattenServiceClient creates a new FlattenService client.
 func NewFlattenServiceClient(baseURL string, opts ...FlattenServiceClientOption) FlattenServiceClient {
 	c := &flattenServiceClient{
@@ -137,6 +277,43 @@ func NewFlattenServiceClient(baseURL string, opts ...FlattenServiceClientOption)
 	return c
 }
 
+// FlattenServiceRoutes holds the HTTP verb and path template of every FlattenService method.
+var FlattenServiceRoutes = struct {
+	TestSimpleFlatten sebufhttp.Route
+	TestDualFlatten   sebufhttp.Route
+	TestMixedFlatten  sebufhttp.Route
+	TestPlainNested   sebufhttp.Route
+}{
+	TestSimpleFlatten: sebufhttp.Route{Method: "POST", Path: "/api/v1/flatten/simple"},
+	TestDualFlatten:   sebufhttp.Route{Method: "POST", Path: "/api/v1/flatten/dual"},
+	TestMixedFlatten:  sebufhttp.Route{Method: "POST", Path: "/api/v1/flatten/mixed"},
+	TestPlainNested:   sebufhttp.Route{Method: "POST", Path: "/api/v1/flatten/plain"},
+}
+
+// FlattenServiceTestSimpleFlattenURL returns the path and query string of a TestSimpleFlatten call with req,
+// relative to the client's base URL.
+func FlattenServiceTestSimpleFlattenURL(req *SimpleFlatten) string {
+	return "/api/v1/flatten/simple"
+}
+
+// FlattenServiceTestDualFlattenURL returns the path and query string of a TestDualFlatten call with req,
+// relative to the client's base URL.
+func FlattenServiceTestDualFlattenURL(req *DualFlatten) string {
+	return "/api/v1/flatten/dual"
+}
+
+// FlattenServiceTestMixedFlattenURL returns the path and query string of a TestMixedFlatten call with req,
+// relative to the client's base URL.
+func FlattenServiceTestMixedFlattenURL(req *MixedFlatten) string {
+	return "/api/v1/flatten/mixed"
+}
+
+// FlattenServiceTestPlainNestedURL returns the path and query string of a TestPlainNested call with req,
+// relative to the client's base URL.
+func FlattenServiceTestPlainNestedURL(req *PlainNested) string {
+	return "/api/v1/flatten/plain"
+}
+
 // TestSimpleFlatten calls the TestSimpleFlatten RPC.
 func (c *flattenServiceClient) TestSimpleFlatten(ctx context.Context, req *SimpleFlatten, opts ...FlattenServiceCallOption) (*SimpleFlatten, error) {
 	callOpts := &flattenServiceCallOptions{}
@@ -145,8 +322,7 @@ func (c *flattenServiceClient) TestSimpleFlatten(ctx context.Context, req *Simpl
 	}
 
 	// Build URL
-	path := "/api/v1/flatten/simple"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + FlattenServiceTestSimpleFlattenURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -170,15 +346,33 @@ func (c *flattenServiceClient) TestSimpleFlatten(ctx context.Context, req *Simpl
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "FlattenService.TestSimpleFlatten",
+		Body:     req,
+		Response: &SimpleFlatten{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("FlattenService.TestSimpleFlatten", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -189,7 +383,13 @@ func (c *flattenServiceClient) TestSimpleFlatten(ctx context.Context, req *Simpl
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -215,8 +415,7 @@ func (c *flattenServiceClient) TestDualFlatten(ctx context.Context, req *DualFla
 	}
 
 	// Build URL
-	path := "/api/v1/flatten/dual"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + FlattenServiceTestDualFlattenURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -240,15 +439,33 @@ func (c *flattenServiceClient) TestDualFlatten(ctx context.Context, req *DualFla
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "FlattenService.TestDualFlatten",
+		Body:     req,
+		Response: &DualFlatten{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("FlattenService.TestDualFlatten", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -259,7 +476,13 @@ func (c *flattenServiceClient) TestDualFlatten(ctx context.Context, req *DualFla
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -285,8 +508,7 @@ func (c *flattenServiceClient) TestMixedFlatten(ctx context.Context, req *MixedF
 	}
 
 	// Build URL
-	path := "/api/v1/flatten/mixed"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + FlattenServiceTestMixedFlattenURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -310,15 +532,33 @@ func (c *flattenServiceClient) TestMixedFlatten(ctx context.Context, req *MixedF
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "FlattenService.TestMixedFlatten",
+		Body:     req,
+		Response: &MixedFlatten{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("FlattenService.TestMixedFlatten", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -329,7 +569,13 @@ func (c *flattenServiceClient) TestMixedFlatten(ctx context.Context, req *MixedF
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -355,8 +601,7 @@ func (c *flattenServiceClient) TestPlainNested(ctx context.Context, req *PlainNe
 	}
 
 	// Build URL
-	path := "/api/v1/flatten/plain"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + FlattenServiceTestPlainNestedURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -380,15 +625,33 @@ func (c *flattenServiceClient) TestPlainNested(ctx context.Context, req *PlainNe
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "FlattenService.TestPlainNested",
+		Body:     req,
+		Response: &PlainNested{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("FlattenService.TestPlainNested", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -399,7 +662,13 @@ func (c *flattenServiceClient) TestPlainNested(ctx context.Context, req *PlainNe
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -432,7 +701,14 @@ func (c *flattenServiceClient) marshalRequest(req proto.Message, contentType str
 	}
 }
 
-func (c *flattenServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *flattenServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
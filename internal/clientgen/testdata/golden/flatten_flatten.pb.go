@@ -89,17 +89,19 @@ func (x *SimpleFlatten) UnmarshalJSONSebuf(data []byte, opts protojson.Unmarshal
 			if childErr != nil {
 				return childErr
 			}
-			x.Address = &Address{}
+			child := &Address{}
 			// Forward opts to child's UnmarshalJSONSebuf if available (annotation composability)
-			if u, ok := any(x.Address).(interface {
+			if u, ok := any(child).(interface {
 				UnmarshalJSONSebuf([]byte, protojson.UnmarshalOptions) error
 			}); ok {
 				if childErr = u.UnmarshalJSONSebuf(childData, opts); childErr != nil {
 					return childErr
 				}
-			} else if childErr = json.Unmarshal(childData, x.Address); childErr != nil {
+			} else if childErr = opts.Unmarshal(childData, child); childErr != nil {
 				return childErr
 			}
+			// Nest the child back for protojson, which resets x before unmarshaling
+			raw["address"], _ = protojson.Marshal(child)
 		}
 	}
 
@@ -222,17 +224,19 @@ func (x *DualFlatten) UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOp
 			if childErr != nil {
 				return childErr
 			}
-			x.Billing = &Address{}
+			child := &Address{}
 			// Forward opts to child's UnmarshalJSONSebuf if available (annotation composability)
-			if u, ok := any(x.Billing).(interface {
+			if u, ok := any(child).(interface {
 				UnmarshalJSONSebuf([]byte, protojson.UnmarshalOptions) error
 			}); ok {
 				if childErr = u.UnmarshalJSONSebuf(childData, opts); childErr != nil {
 					return childErr
 				}
-			} else if childErr = json.Unmarshal(childData, x.Billing); childErr != nil {
+			} else if childErr = opts.Unmarshal(childData, child); childErr != nil {
 				return childErr
 			}
+			// Nest the child back for protojson, which resets x before unmarshaling
+			raw["billing"], _ = protojson.Marshal(child)
 		}
 	}
 
@@ -256,17 +260,19 @@ func (x *DualFlatten) UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOp
 			if childErr != nil {
 				return childErr
 			}
-			x.Shipping = &Address{}
+			child := &Address{}
 			// Forward opts to child's UnmarshalJSONSebuf if available (annotation composability)
-			if u, ok := any(x.Shipping).(interface {
+			if u, ok := any(child).(interface {
 				UnmarshalJSONSebuf([]byte, protojson.UnmarshalOptions) error
 			}); ok {
 				if childErr = u.UnmarshalJSONSebuf(childData, opts); childErr != nil {
 					return childErr
 				}
-			} else if childErr = json.Unmarshal(childData, x.Shipping); childErr != nil {
+			} else if childErr = opts.Unmarshal(childData, child); childErr != nil {
 				return childErr
 			}
+			// Nest the child back for protojson, which resets x before unmarshaling
+			raw["shipping"], _ = protojson.Marshal(child)
 		}
 	}
 
@@ -364,17 +370,19 @@ func (x *MixedFlatten) UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalO
 			if childErr != nil {
 				return childErr
 			}
-			x.Address = &Address{}
+			child := &Address{}
 			// Forward opts to child's UnmarshalJSONSebuf if available (annotation composability)
-			if u, ok := any(x.Address).(interface {
+			if u, ok := any(child).(interface {
 				UnmarshalJSONSebuf([]byte, protojson.UnmarshalOptions) error
 			}); ok {
 				if childErr = u.UnmarshalJSONSebuf(childData, opts); childErr != nil {
 					return childErr
 				}
-			} else if childErr = json.Unmarshal(childData, x.Address); childErr != nil {
+			} else if childErr = opts.Unmarshal(childData, child); childErr != nil {
 				return childErr
 			}
+			// Nest the child back for protojson, which resets x before unmarshaling
+			raw["address"], _ = protojson.Marshal(child)
 		}
 	}
 
@@ -12,18 +12,21 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
 
 const (
 	// ContentTypeJSON is the content type for JSON requests/responses.
-	ContentTypeJSON = "application/json"
+	ContentTypeJSON = sebufhttp.JSONContentType
 	// ContentTypeProto is the content type for binary protobuf requests/responses.
-	ContentTypeProto = "application/x-protobuf"
+	ContentTypeProto = sebufhttp.ProtoContentType
 )
 
 // sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
@@ -32,16 +35,46 @@ type sebufUnmarshaler interface {
 	UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error
 }
 
+// enumParamString returns the wire string for an enum path or query parameter:
+// the custom enum_value string if annotated, otherwise the proto value name.
+func enumParamString(v protoreflect.Enum) string {
+	desc := v.Descriptor().Values().ByNumber(v.Number())
+	if desc == nil {
+		return fmt.Sprint(v.Number())
+	}
+	if opts, ok := desc.Options().(*descriptorpb.EnumValueOptions); ok {
+		if custom, _ := proto.GetExtension(opts, sebufhttp.E_EnumValue).(string); custom != "" {
+			return custom
+		}
+	}
+	return string(desc.Name())
+}
+
 // RESTfulAPIServiceClient is the client API for RESTfulAPIService service.
+//
+// RESTfulAPIService tests all HTTP verbs with various parameter combinations
 type RESTfulAPIServiceClient interface {
+	// ListResources GET - List all resources with query parameters
 	ListResources(ctx context.Context, req *ListResourcesRequest, opts ...RESTfulAPIServiceCallOption) (*ListResourcesResponse, error)
+	// GetResource GET - Get single resource with path parameter
 	GetResource(ctx context.Context, req *GetResourceRequest, opts ...RESTfulAPIServiceCallOption) (*Resource, error)
+	// GetNestedResource GET - Nested resource with multiple path parameters
 	GetNestedResource(ctx context.Context, req *GetNestedResourceRequest, opts ...RESTfulAPIServiceCallOption) (*Resource, error)
+	// CreateResource POST - Create new resource with request body
 	CreateResource(ctx context.Context, req *CreateResourceRequest, opts ...RESTfulAPIServiceCallOption) (*Resource, error)
+	// UpdateResource PUT - Full update with path param and body
 	UpdateResource(ctx context.Context, req *UpdateResourceRequest, opts ...RESTfulAPIServiceCallOption) (*Resource, error)
+	// PatchResource PATCH - Partial update with path param and body
 	PatchResource(ctx context.Context, req *PatchResourceRequest, opts ...RESTfulAPIServiceCallOption) (*Resource, error)
+	// DeleteResource DELETE - Delete resource with path parameter
 	DeleteResource(ctx context.Context, req *DeleteResourceRequest, opts ...RESTfulAPIServiceCallOption) (*DeleteResourceResponse, error)
+	// DefaultPostMethod default POST - Method without explicit HTTP method should default to POST
+	//
+	// The request field action is deprecated.
+	//
+	// Deprecated: Do not use.
 	DefaultPostMethod(ctx context.Context, req *DefaultPostRequest, opts ...RESTfulAPIServiceCallOption) (*DefaultPostResponse, error)
+	// SearchResources GET - Search resources with enum and string query params
 	SearchResources(ctx context.Context, req *SearchResourcesRequest, opts ...RESTfulAPIServiceCallOption) (*ListResourcesResponse, error)
 }
 
@@ -51,7 +84,15 @@ type rESTfulAPIServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ RESTfulAPIServiceClient = (*rESTfulAPIServiceClient)(nil)
@@ -84,6 +125,27 @@ func WithRESTfulAPIServiceDefaultHeader(key, value string) RESTfulAPIServiceClie
 	}
 }
 
+// WithRESTfulAPIServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithRESTfulAPIServiceHeaderPropagation(allowlist ...string) RESTfulAPIServiceClientOption {
+	return func(c *rESTfulAPIServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithRESTfulAPIServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithRESTfulAPIServiceDeadlinePropagation(headerName string) RESTfulAPIServiceClientOption {
+	return func(c *rESTfulAPIServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithRESTfulAPIServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithRESTfulAPIServiceDiscardUnknownFields(discard bool) RESTfulAPIServiceClientOption {
@@ -92,6 +154,105 @@ func WithRESTfulAPIServiceDiscardUnknownFields(discard bool) RESTfulAPIServiceCl
 	}
 }
 
+// WithRESTfulAPIServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithRESTfulAPIServiceHedging(delay time.Duration, maxHedges int) RESTfulAPIServiceClientOption {
+	return func(c *rESTfulAPIServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithRESTfulAPIServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithRESTfulAPIServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) RESTfulAPIServiceClientOption {
+	return func(c *rESTfulAPIServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithRESTfulAPIServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithRESTfulAPIServiceRecorder(recorder sebufhttp.CallRecorder) RESTfulAPIServiceClientOption {
+	return func(c *rESTfulAPIServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithRESTfulAPIServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithRESTfulAPIServiceDebugLogging(w io.Writer) RESTfulAPIServiceClientOption {
+	return func(c *rESTfulAPIServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithRESTfulAPIServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithRESTfulAPIServiceDebugBodyLimit(limit int) RESTfulAPIServiceClientOption {
+	return func(c *rESTfulAPIServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithRESTfulAPIServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithRESTfulAPIServiceLogHook(hook func(sebufhttp.ClientLogEvent)) RESTfulAPIServiceClientOption {
+	return func(c *rESTfulAPIServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithRESTfulAPIServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithRESTfulAPIServiceShadowMutations is set.
+func WithRESTfulAPIServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) RESTfulAPIServiceClientOption {
+	return func(c *rESTfulAPIServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithRESTfulAPIServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithRESTfulAPIServiceShadowMutations() RESTfulAPIServiceClientOption {
+	return func(c *rESTfulAPIServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithRESTfulAPIServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithRESTfulAPIServiceShadowTimeout(timeout time.Duration) RESTfulAPIServiceClientOption {
+	return func(c *rESTfulAPIServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // RESTfulAPIServiceCallOption configures a single RPC call.
 type RESTfulAPIServiceCallOption func(*rESTfulAPIServiceCallOptions)
 
@@ -100,6 +261,7 @@ type rESTfulAPIServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithRESTfulAPIServiceHeader adds a header to a single request.
@@ -127,19 +289,47 @@ func WithRESTfulAPIServiceCallDiscardUnknownFields(discard bool) RESTfulAPIServi
 	}
 }
 
+// WithRESTfulAPIServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithRESTfulAPIServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) RESTfulAPIServiceCallOption {
+	return func(o *rESTfulAPIServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // WithRESTfulAPIServiceAPIKey API key for authentication
 func WithRESTfulAPIServiceAPIKey(value string) RESTfulAPIServiceClientOption {
-	return WithRESTfulAPIServiceDefaultHeader("X-API-Key", value)
+	return WithRESTfulAPIServiceDefaultHeader("X-Api-Key", value)
 }
 
 // WithRESTfulAPIServiceCallAPIKey API key for authentication for a single request.
 func WithRESTfulAPIServiceCallAPIKey(value string) RESTfulAPIServiceCallOption {
-	return WithRESTfulAPIServiceHeader("X-API-Key", value)
+	return WithRESTfulAPIServiceHeader("X-Api-Key", value)
+}
+
+// WithRESTfulAPIServiceClientVersion Version of the calling client
+func WithRESTfulAPIServiceClientVersion(value string) RESTfulAPIServiceClientOption {
+	return WithRESTfulAPIServiceDefaultHeader("X-Client-Version", value)
+}
+
+// WithRESTfulAPIServiceCallClientVersion Version of the calling client for a single request.
+func WithRESTfulAPIServiceCallClientVersion(value string) RESTfulAPIServiceCallOption {
+	return WithRESTfulAPIServiceHeader("X-Client-Version", value)
+}
+
+// WithRESTfulAPIServiceCallAcceptLanguage sets the Accept-Language header for a single request.
+func WithRESTfulAPIServiceCallAcceptLanguage(value string) RESTfulAPIServiceCallOption {
+	return WithRESTfulAPIServiceHeader("Accept-Language", value)
 }
 
-// WithRESTfulAPIServiceCallRequestID sets the X-Request-ID header for a single request.
+// WithRESTfulAPIServiceCallResourceTag sets the X-Resource-Tag header for a single request.
+func WithRESTfulAPIServiceCallResourceTag(values ...string) RESTfulAPIServiceCallOption {
+	return WithRESTfulAPIServiceHeader("X-Resource-Tag", strings.Join(values, ", "))
+}
+
+// WithRESTfulAPIServiceCallRequestID sets the X-Request-Id header for a single request.
 func WithRESTfulAPIServiceCallRequestID(value string) RESTfulAPIServiceCallOption {
-	return WithRESTfulAPIServiceHeader("X-Request-ID", value)
+	return WithRESTfulAPIServiceHeader("X-Request-Id", value)
 }
 
 // NewRESTfulAPIServiceClient creates a new RESTfulAPIService client.
@@ -158,16 +348,33 @@ func NewRESTfulAPIServiceClient(baseURL string, opts ...RESTfulAPIServiceClientO
 	return c
 }
 
-// ListResources calls the ListResources RPC.
-func (c *rESTfulAPIServiceClient) ListResources(ctx context.Context, req *ListResourcesRequest, opts ...RESTfulAPIServiceCallOption) (*ListResourcesResponse, error) {
-	callOpts := &rESTfulAPIServiceCallOptions{}
-	for _, opt := range opts {
-		opt(callOpts)
-	}
+// RESTfulAPIServiceRoutes holds the HTTP verb and path template of every RESTfulAPIService method.
+var RESTfulAPIServiceRoutes = struct {
+	ListResources     sebufhttp.Route
+	GetResource       sebufhttp.Route
+	GetNestedResource sebufhttp.Route
+	CreateResource    sebufhttp.Route
+	UpdateResource    sebufhttp.Route
+	PatchResource     sebufhttp.Route
+	DeleteResource    sebufhttp.Route
+	DefaultPostMethod sebufhttp.Route
+	SearchResources   sebufhttp.Route
+}{
+	ListResources:     sebufhttp.Route{Method: "GET", Path: "/api/v1/resources"},
+	GetResource:       sebufhttp.Route{Method: "GET", Path: "/api/v1/resources/{resource_id}"},
+	GetNestedResource: sebufhttp.Route{Method: "GET", Path: "/api/v1/orgs/{org_id}/teams/{team_id}/resources/{resource_id}"},
+	CreateResource:    sebufhttp.Route{Method: "POST", Path: "/api/v1/resources"},
+	UpdateResource:    sebufhttp.Route{Method: "PUT", Path: "/api/v1/resources/{resource_id}"},
+	PatchResource:     sebufhttp.Route{Method: "PATCH", Path: "/api/v1/resources/{resource_id}"},
+	DeleteResource:    sebufhttp.Route{Method: "DELETE", Path: "/api/v1/resources/{resource_id}"},
+	DefaultPostMethod: sebufhttp.Route{Method: "POST", Path: "/api/v1/legacy/action"},
+	SearchResources:   sebufhttp.Route{Method: "GET", Path: "/api/v1/resources/search"},
+}
 
-	// Build URL
+// RESTfulAPIServiceListResourcesURL returns the path and query string of a ListResources call with req,
+// relative to the client's base URL.
+func RESTfulAPIServiceListResourcesURL(req *ListResourcesRequest) string {
 	path := "/api/v1/resources"
-	reqURL := c.baseURL + path
 
 	// Add query parameters
 	queryParams := url.Values{}
@@ -196,8 +403,93 @@ func (c *rESTfulAPIServiceClient) ListResources(ctx context.Context, req *ListRe
 		queryParams.Set("max_score", fmt.Sprint(req.MaxScore))
 	}
 	if len(queryParams) > 0 {
-		reqURL += "?" + queryParams.Encode()
+		path += "?" + queryParams.Encode()
 	}
+	return path
+}
+
+// RESTfulAPIServiceGetResourceURL returns the path and query string of a GetResource call with req,
+// relative to the client's base URL.
+func RESTfulAPIServiceGetResourceURL(req *GetResourceRequest) string {
+	path := "/api/v1/resources/{resource_id}"
+	path = strings.Replace(path, "{resource_id}", url.PathEscape(fmt.Sprint(req.ResourceId)), 1)
+	return path
+}
+
+// RESTfulAPIServiceGetNestedResourceURL returns the path and query string of a GetNestedResource call with req,
+// relative to the client's base URL.
+func RESTfulAPIServiceGetNestedResourceURL(req *GetNestedResourceRequest) string {
+	path := "/api/v1/orgs/{org_id}/teams/{team_id}/resources/{resource_id}"
+	path = strings.Replace(path, "{org_id}", url.PathEscape(fmt.Sprint(req.OrgId)), 1)
+	path = strings.Replace(path, "{team_id}", url.PathEscape(fmt.Sprint(req.TeamId)), 1)
+	path = strings.Replace(path, "{resource_id}", url.PathEscape(fmt.Sprint(req.ResourceId)), 1)
+	return path
+}
+
+// RESTfulAPIServiceCreateResourceURL returns the path and query string of a CreateResource call with req,
+// relative to the client's base URL.
+func RESTfulAPIServiceCreateResourceURL(req *CreateResourceRequest) string {
+	return "/api/v1/resources"
+}
+
+// RESTfulAPIServiceUpdateResourceURL returns the path and query string of a UpdateResource call with req,
+// relative to the client's base URL.
+func RESTfulAPIServiceUpdateResourceURL(req *UpdateResourceRequest) string {
+	path := "/api/v1/resources/{resource_id}"
+	path = strings.Replace(path, "{resource_id}", url.PathEscape(fmt.Sprint(req.ResourceId)), 1)
+	return path
+}
+
+// RESTfulAPIServicePatchResourceURL returns the path and query string of a PatchResource call with req,
+// relative to the client's base URL.
+func RESTfulAPIServicePatchResourceURL(req *PatchResourceRequest) string {
+	path := "/api/v1/resources/{resource_id}"
+	path = strings.Replace(path, "{resource_id}", url.PathEscape(fmt.Sprint(req.ResourceId)), 1)
+	return path
+}
+
+// RESTfulAPIServiceDeleteResourceURL returns the path and query string of a DeleteResource call with req,
+// relative to the client's base URL.
+func RESTfulAPIServiceDeleteResourceURL(req *DeleteResourceRequest) string {
+	path := "/api/v1/resources/{resource_id}"
+	path = strings.Replace(path, "{resource_id}", url.PathEscape(fmt.Sprint(req.ResourceId)), 1)
+	return path
+}
+
+// RESTfulAPIServiceDefaultPostMethodURL returns the path and query string of a DefaultPostMethod call with req,
+// relative to the client's base URL.
+func RESTfulAPIServiceDefaultPostMethodURL(req *DefaultPostRequest) string {
+	return "/api/v1/legacy/action"
+}
+
+// RESTfulAPIServiceSearchResourcesURL returns the path and query string of a SearchResources call with req,
+// relative to the client's base URL.
+func RESTfulAPIServiceSearchResourcesURL(req *SearchResourcesRequest) string {
+	path := "/api/v1/resources/search"
+
+	// Add query parameters
+	queryParams := url.Values{}
+	if req.StatusFilter != 0 {
+		queryParams.Set("status", enumParamString(req.StatusFilter))
+	}
+	if req.Query != "" {
+		queryParams.Set("q", fmt.Sprint(req.Query))
+	}
+	if len(queryParams) > 0 {
+		path += "?" + queryParams.Encode()
+	}
+	return path
+}
+
+// ListResources GET - List all resources with query parameters
+func (c *rESTfulAPIServiceClient) ListResources(ctx context.Context, req *ListResourcesRequest, opts ...RESTfulAPIServiceCallOption) (*ListResourcesResponse, error) {
+	callOpts := &rESTfulAPIServiceCallOptions{}
+	for _, opt := range opts {
+		opt(callOpts)
+	}
+
+	// Build URL
+	reqURL := c.baseURL + RESTfulAPIServiceListResourcesURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -212,18 +504,37 @@ func (c *rESTfulAPIServiceClient) ListResources(ctx context.Context, req *ListRe
 
 	// Set headers
 	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("Accept-Language", "en-US")
+	httpReq.Header.Set("X-Client-Version", "1.0.0")
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "RESTfulAPIService.ListResources",
+		Response: &ListResourcesResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("RESTfulAPIService.ListResources", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -234,7 +545,13 @@ func (c *rESTfulAPIServiceClient) ListResources(ctx context.Context, req *ListRe
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -252,7 +569,7 @@ func (c *rESTfulAPIServiceClient) ListResources(ctx context.Context, req *ListRe
 	return result, nil
 }
 
-// GetResource calls the GetResource RPC.
+// GetResource GET - Get single resource with path parameter
 func (c *rESTfulAPIServiceClient) GetResource(ctx context.Context, req *GetResourceRequest, opts ...RESTfulAPIServiceCallOption) (*Resource, error) {
 	callOpts := &rESTfulAPIServiceCallOptions{}
 	for _, opt := range opts {
@@ -260,9 +577,7 @@ func (c *rESTfulAPIServiceClient) GetResource(ctx context.Context, req *GetResou
 	}
 
 	// Build URL
-	path := "/api/v1/resources/{resource_id}"
-	path = strings.Replace(path, "{resource_id}", url.PathEscape(fmt.Sprint(req.ResourceId)), 1)
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + RESTfulAPIServiceGetResourceURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -277,18 +592,36 @@ func (c *rESTfulAPIServiceClient) GetResource(ctx context.Context, req *GetResou
 
 	// Set headers
 	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("X-Client-Version", "1.0.0")
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "RESTfulAPIService.GetResource",
+		Response: &Resource{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("RESTfulAPIService.GetResource", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -299,7 +632,13 @@ func (c *rESTfulAPIServiceClient) GetResource(ctx context.Context, req *GetResou
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -317,7 +656,7 @@ func (c *rESTfulAPIServiceClient) GetResource(ctx context.Context, req *GetResou
 	return result, nil
 }
 
-// GetNestedResource calls the GetNestedResource RPC.
+// GetNestedResource GET - Nested resource with multiple path parameters
 func (c *rESTfulAPIServiceClient) GetNestedResource(ctx context.Context, req *GetNestedResourceRequest, opts ...RESTfulAPIServiceCallOption) (*Resource, error) {
 	callOpts := &rESTfulAPIServiceCallOptions{}
 	for _, opt := range opts {
@@ -325,11 +664,7 @@ func (c *rESTfulAPIServiceClient) GetNestedResource(ctx context.Context, req *Ge
 	}
 
 	// Build URL
-	path := "/api/v1/orgs/{org_id}/teams/{team_id}/resources/{resource_id}"
-	path = strings.Replace(path, "{org_id}", url.PathEscape(fmt.Sprint(req.OrgId)), 1)
-	path = strings.Replace(path, "{team_id}", url.PathEscape(fmt.Sprint(req.TeamId)), 1)
-	path = strings.Replace(path, "{resource_id}", url.PathEscape(fmt.Sprint(req.ResourceId)), 1)
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + RESTfulAPIServiceGetNestedResourceURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -344,18 +679,36 @@ func (c *rESTfulAPIServiceClient) GetNestedResource(ctx context.Context, req *Ge
 
 	// Set headers
 	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("X-Client-Version", "1.0.0")
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "RESTfulAPIService.GetNestedResource",
+		Response: &Resource{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("RESTfulAPIService.GetNestedResource", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -366,7 +719,13 @@ func (c *rESTfulAPIServiceClient) GetNestedResource(ctx context.Context, req *Ge
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -384,7 +743,7 @@ func (c *rESTfulAPIServiceClient) GetNestedResource(ctx context.Context, req *Ge
 	return result, nil
 }
 
-// CreateResource calls the CreateResource RPC.
+// CreateResource POST - Create new resource with request body
 func (c *rESTfulAPIServiceClient) CreateResource(ctx context.Context, req *CreateResourceRequest, opts ...RESTfulAPIServiceCallOption) (*Resource, error) {
 	callOpts := &rESTfulAPIServiceCallOptions{}
 	for _, opt := range opts {
@@ -392,8 +751,7 @@ func (c *rESTfulAPIServiceClient) CreateResource(ctx context.Context, req *Creat
 	}
 
 	// Build URL
-	path := "/api/v1/resources"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + RESTfulAPIServiceCreateResourceURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -414,18 +772,37 @@ func (c *rESTfulAPIServiceClient) CreateResource(ctx context.Context, req *Creat
 
 	// Set headers
 	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("X-Client-Version", "1.0.0")
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "RESTfulAPIService.CreateResource",
+		Body:     req,
+		Response: &Resource{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("RESTfulAPIService.CreateResource", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -436,7 +813,13 @@ func (c *rESTfulAPIServiceClient) CreateResource(ctx context.Context, req *Creat
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -454,17 +837,21 @@ func (c *rESTfulAPIServiceClient) CreateResource(ctx context.Context, req *Creat
 	return result, nil
 }
 
-// UpdateResource calls the UpdateResource RPC.
+// UpdateResource PUT - Full update with path param and body
 func (c *rESTfulAPIServiceClient) UpdateResource(ctx context.Context, req *UpdateResourceRequest, opts ...RESTfulAPIServiceCallOption) (*Resource, error) {
 	callOpts := &rESTfulAPIServiceCallOptions{}
 	for _, opt := range opts {
 		opt(callOpts)
 	}
 
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5000*time.Millisecond)
+		defer cancel()
+	}
+
 	// Build URL
-	path := "/api/v1/resources/{resource_id}"
-	path = strings.Replace(path, "{resource_id}", url.PathEscape(fmt.Sprint(req.ResourceId)), 1)
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + RESTfulAPIServiceUpdateResourceURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -485,18 +872,37 @@ func (c *rESTfulAPIServiceClient) UpdateResource(ctx context.Context, req *Updat
 
 	// Set headers
 	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("X-Client-Version", "1.0.0")
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "RESTfulAPIService.UpdateResource",
+		Body:     req,
+		Response: &Resource{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("RESTfulAPIService.UpdateResource", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -507,7 +913,13 @@ func (c *rESTfulAPIServiceClient) UpdateResource(ctx context.Context, req *Updat
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -525,7 +937,7 @@ func (c *rESTfulAPIServiceClient) UpdateResource(ctx context.Context, req *Updat
 	return result, nil
 }
 
-// PatchResource calls the PatchResource RPC.
+// PatchResource PATCH - Partial update with path param and body
 func (c *rESTfulAPIServiceClient) PatchResource(ctx context.Context, req *PatchResourceRequest, opts ...RESTfulAPIServiceCallOption) (*Resource, error) {
 	callOpts := &rESTfulAPIServiceCallOptions{}
 	for _, opt := range opts {
@@ -533,9 +945,7 @@ func (c *rESTfulAPIServiceClient) PatchResource(ctx context.Context, req *PatchR
 	}
 
 	// Build URL
-	path := "/api/v1/resources/{resource_id}"
-	path = strings.Replace(path, "{resource_id}", url.PathEscape(fmt.Sprint(req.ResourceId)), 1)
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + RESTfulAPIServicePatchResourceURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -556,18 +966,37 @@ func (c *rESTfulAPIServiceClient) PatchResource(ctx context.Context, req *PatchR
 
 	// Set headers
 	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("X-Client-Version", "1.0.0")
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "RESTfulAPIService.PatchResource",
+		Body:     req,
+		Response: &Resource{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("RESTfulAPIService.PatchResource", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -578,7 +1007,13 @@ func (c *rESTfulAPIServiceClient) PatchResource(ctx context.Context, req *PatchR
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -596,7 +1031,7 @@ func (c *rESTfulAPIServiceClient) PatchResource(ctx context.Context, req *PatchR
 	return result, nil
 }
 
-// DeleteResource calls the DeleteResource RPC.
+// DeleteResource DELETE - Delete resource with path parameter
 func (c *rESTfulAPIServiceClient) DeleteResource(ctx context.Context, req *DeleteResourceRequest, opts ...RESTfulAPIServiceCallOption) (*DeleteResourceResponse, error) {
 	callOpts := &rESTfulAPIServiceCallOptions{}
 	for _, opt := range opts {
@@ -604,9 +1039,7 @@ func (c *rESTfulAPIServiceClient) DeleteResource(ctx context.Context, req *Delet
 	}
 
 	// Build URL
-	path := "/api/v1/resources/{resource_id}"
-	path = strings.Replace(path, "{resource_id}", url.PathEscape(fmt.Sprint(req.ResourceId)), 1)
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + RESTfulAPIServiceDeleteResourceURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -621,18 +1054,36 @@ func (c *rESTfulAPIServiceClient) DeleteResource(ctx context.Context, req *Delet
 
 	// Set headers
 	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("X-Client-Version", "1.0.0")
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "RESTfulAPIService.DeleteResource",
+		Response: &DeleteResourceResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("RESTfulAPIService.DeleteResource", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -643,7 +1094,13 @@ func (c *rESTfulAPIServiceClient) DeleteResource(ctx context.Context, req *Delet
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -661,7 +1118,11 @@ func (c *rESTfulAPIServiceClient) DeleteResource(ctx context.Context, req *Delet
 	return result, nil
 }
 
-// DefaultPostMethod calls the DefaultPostMethod RPC.
+// DefaultPostMethod default POST - Method without explicit HTTP method should default to POST
+//
+// The request field action is deprecated.
+//
+// Deprecated: Do not use.
 func (c *rESTfulAPIServiceClient) DefaultPostMethod(ctx context.Context, req *DefaultPostRequest, opts ...RESTfulAPIServiceCallOption) (*DefaultPostResponse, error) {
 	callOpts := &rESTfulAPIServiceCallOptions{}
 	for _, opt := range opts {
@@ -669,8 +1130,7 @@ func (c *rESTfulAPIServiceClient) DefaultPostMethod(ctx context.Context, req *De
 	}
 
 	// Build URL
-	path := "/api/v1/legacy/action"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + RESTfulAPIServiceDefaultPostMethodURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -691,18 +1151,37 @@ func (c *rESTfulAPIServiceClient) DefaultPostMethod(ctx context.Context, req *De
 
 	// Set headers
 	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("X-Client-Version", "1.0.0")
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "RESTfulAPIService.DefaultPostMethod",
+		Body:     req,
+		Response: &DefaultPostResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("RESTfulAPIService.DefaultPostMethod", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -713,7 +1192,13 @@ func (c *rESTfulAPIServiceClient) DefaultPostMethod(ctx context.Context, req *De
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -731,7 +1216,7 @@ func (c *rESTfulAPIServiceClient) DefaultPostMethod(ctx context.Context, req *De
 	return result, nil
 }
 
-// SearchResources calls the SearchResources RPC.
+// SearchResources GET - Search resources with enum and string query params
 func (c *rESTfulAPIServiceClient) SearchResources(ctx context.Context, req *SearchResourcesRequest, opts ...RESTfulAPIServiceCallOption) (*ListResourcesResponse, error) {
 	callOpts := &rESTfulAPIServiceCallOptions{}
 	for _, opt := range opts {
@@ -739,20 +1224,7 @@ func (c *rESTfulAPIServiceClient) SearchResources(ctx context.Context, req *Sear
 	}
 
 	// Build URL
-	path := "/api/v1/resources/search"
-	reqURL := c.baseURL + path
-
-	// Add query parameters
-	queryParams := url.Values{}
-	if req.StatusFilter != 0 {
-		queryParams.Set("status", fmt.Sprint(req.StatusFilter))
-	}
-	if req.Query != "" {
-		queryParams.Set("q", fmt.Sprint(req.Query))
-	}
-	if len(queryParams) > 0 {
-		reqURL += "?" + queryParams.Encode()
-	}
+	reqURL := c.baseURL + RESTfulAPIServiceSearchResourcesURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -767,18 +1239,36 @@ func (c *rESTfulAPIServiceClient) SearchResources(ctx context.Context, req *Sear
 
 	// Set headers
 	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("X-Client-Version", "1.0.0")
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "RESTfulAPIService.SearchResources",
+		Response: &ListResourcesResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("RESTfulAPIService.SearchResources", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -789,7 +1279,13 @@ func (c *rESTfulAPIServiceClient) SearchResources(ctx context.Context, req *Sear
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -822,7 +1318,14 @@ func (c *rESTfulAPIServiceClient) marshalRequest(req proto.Message, contentType
 	}
 }
 
-func (c *rESTfulAPIServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *rESTfulAPIServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
@@ -869,7 +1372,10 @@ func (c *rESTfulAPIServiceClient) unmarshalResponse(body []byte, msg proto.Messa
 }
 
 // BackwardCompatServiceClient is the client API for BackwardCompatService service.
+//
+// BackwardCompatService tests backward compatibility (no HTTP annotations)
 type BackwardCompatServiceClient interface {
+	// LegacyAction RPC without HTTP config - should default to POST
 	LegacyAction(ctx context.Context, req *LegacyRequest, opts ...BackwardCompatServiceCallOption) (*LegacyResponse, error)
 }
 
@@ -879,7 +1385,15 @@ type backwardCompatServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ BackwardCompatServiceClient = (*backwardCompatServiceClient)(nil)
@@ -912,6 +1426,27 @@ func WithBackwardCompatServiceDefaultHeader(key, value string) BackwardCompatSer
 	}
 }
 
+// WithBackwardCompatServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithBackwardCompatServiceHeaderPropagation(allowlist ...string) BackwardCompatServiceClientOption {
+	return func(c *backwardCompatServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithBackwardCompatServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithBackwardCompatServiceDeadlinePropagation(headerName string) BackwardCompatServiceClientOption {
+	return func(c *backwardCompatServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithBackwardCompatServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithBackwardCompatServiceDiscardUnknownFields(discard bool) BackwardCompatServiceClientOption {
@@ -920,6 +1455,105 @@ func WithBackwardCompatServiceDiscardUnknownFields(discard bool) BackwardCompatS
 	}
 }
 
+// WithBackwardCompatServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithBackwardCompatServiceHedging(delay time.Duration, maxHedges int) BackwardCompatServiceClientOption {
+	return func(c *backwardCompatServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithBackwardCompatServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithBackwardCompatServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) BackwardCompatServiceClientOption {
+	return func(c *backwardCompatServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithBackwardCompatServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithBackwardCompatServiceRecorder(recorder sebufhttp.CallRecorder) BackwardCompatServiceClientOption {
+	return func(c *backwardCompatServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithBackwardCompatServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithBackwardCompatServiceDebugLogging(w io.Writer) BackwardCompatServiceClientOption {
+	return func(c *backwardCompatServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithBackwardCompatServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithBackwardCompatServiceDebugBodyLimit(limit int) BackwardCompatServiceClientOption {
+	return func(c *backwardCompatServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithBackwardCompatServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithBackwardCompatServiceLogHook(hook func(sebufhttp.ClientLogEvent)) BackwardCompatServiceClientOption {
+	return func(c *backwardCompatServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithBackwardCompatServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithBackwardCompatServiceShadowMutations is set.
+func WithBackwardCompatServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) BackwardCompatServiceClientOption {
+	return func(c *backwardCompatServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithBackwardCompatServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithBackwardCompatServiceShadowMutations() BackwardCompatServiceClientOption {
+	return func(c *backwardCompatServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithBackwardCompatServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithBackwardCompatServiceShadowTimeout(timeout time.Duration) BackwardCompatServiceClientOption {
+	return func(c *backwardCompatServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // BackwardCompatServiceCallOption configures a single RPC call.
 type BackwardCompatServiceCallOption func(*backwardCompatServiceCallOptions)
 
@@ -928,6 +1562,7 @@ type backwardCompatServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithBackwardCompatServiceHeader adds a header to a single request.
@@ -955,6 +1590,14 @@ func WithBackwardCompatServiceCallDiscardUnknownFields(discard bool) BackwardCom
 	}
 }
 
+// WithBackwardCompatServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithBackwardCompatServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) BackwardCompatServiceCallOption {
+	return func(o *backwardCompatServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // NewBackwardCompatServiceClient creates a new BackwardCompatService client.
 func NewBackwardCompatServiceClient(baseURL string, opts ...BackwardCompatServiceClientOption) BackwardCompatServiceClient {
 	c := &backwardCompatServiceClient{
@@ -971,7 +1614,20 @@ func NewBackwardCompatServiceClient(baseURL string, opts ...BackwardCompatServic
 	return c
 }
 
-// LegacyAction calls the LegacyAction RPC.
+// BackwardCompatServiceRoutes holds the HTTP verb and path template of every BackwardCompatService method.
+var BackwardCompatServiceRoutes = struct {
+	LegacyAction sebufhttp.Route
+}{
+	LegacyAction: sebufhttp.Route{Method: "POST", Path: "/legacyAction"},
+}
+
+// BackwardCompatServiceLegacyActionURL returns the path and query string of a LegacyAction call with req,
+// relative to the client's base URL.
+func BackwardCompatServiceLegacyActionURL(req *LegacyRequest) string {
+	return "/legacyAction"
+}
+
+// LegacyAction RPC without HTTP config - should default to POST
 func (c *backwardCompatServiceClient) LegacyAction(ctx context.Context, req *LegacyRequest, opts ...BackwardCompatServiceCallOption) (*LegacyResponse, error) {
 	callOpts := &backwardCompatServiceCallOptions{}
 	for _, opt := range opts {
@@ -979,8 +1635,7 @@ func (c *backwardCompatServiceClient) LegacyAction(ctx context.Context, req *Leg
 	}
 
 	// Build URL
-	path := "/legacyAction"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + BackwardCompatServiceLegacyActionURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -1004,15 +1659,33 @@ func (c *backwardCompatServiceClient) LegacyAction(ctx context.Context, req *Leg
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "BackwardCompatService.LegacyAction",
+		Body:     req,
+		Response: &LegacyResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("BackwardCompatService.LegacyAction", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -1023,7 +1696,13 @@ func (c *backwardCompatServiceClient) LegacyAction(ctx context.Context, req *Leg
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -1056,7 +1735,14 @@ func (c *backwardCompatServiceClient) marshalRequest(req proto.Message, contentT
 	}
 }
 
-func (c *backwardCompatServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *backwardCompatServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -20,9 +21,9 @@ import (
 
 const (
 	// ContentTypeJSON is the content type for JSON requests/responses.
-	ContentTypeJSON = "application/json"
+	ContentTypeJSON = sebufhttp.JSONContentType
 	// ContentTypeProto is the content type for binary protobuf requests/responses.
-	ContentTypeProto = "application/x-protobuf"
+	ContentTypeProto = sebufhttp.ProtoContentType
 )
 
 // sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
@@ -32,6 +33,8 @@ type sebufUnmarshaler interface {
 }
 
 // Int64EncodingServiceClient is the client API for Int64EncodingService service.
+//
+// Service with int64 encoding test
 type Int64EncodingServiceClient interface {
 	GetInt64Test(ctx context.Context, req *GetInt64TestRequest, opts ...Int64EncodingServiceCallOption) (*Int64EncodingTest, error)
 }
@@ -42,7 +45,15 @@ type int64EncodingServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ Int64EncodingServiceClient = (*int64EncodingServiceClient)(nil)
@@ -75,6 +86,27 @@ func WithInt64EncodingServiceDefaultHeader(key, value string) Int64EncodingServi
 	}
 }
 
+// WithInt64EncodingServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithInt64EncodingServiceHeaderPropagation(allowlist ...string) Int64EncodingServiceClientOption {
+	return func(c *int64EncodingServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithInt64EncodingServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithInt64EncodingServiceDeadlinePropagation(headerName string) Int64EncodingServiceClientOption {
+	return func(c *int64EncodingServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithInt64EncodingServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithInt64EncodingServiceDiscardUnknownFields(discard bool) Int64EncodingServiceClientOption {
@@ -83,6 +115,105 @@ func WithInt64EncodingServiceDiscardUnknownFields(discard bool) Int64EncodingSer
 	}
 }
 
+// WithInt64EncodingServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithInt64EncodingServiceHedging(delay time.Duration, maxHedges int) Int64EncodingServiceClientOption {
+	return func(c *int64EncodingServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithInt64EncodingServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithInt64EncodingServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) Int64EncodingServiceClientOption {
+	return func(c *int64EncodingServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithInt64EncodingServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithInt64EncodingServiceRecorder(recorder sebufhttp.CallRecorder) Int64EncodingServiceClientOption {
+	return func(c *int64EncodingServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithInt64EncodingServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithInt64EncodingServiceDebugLogging(w io.Writer) Int64EncodingServiceClientOption {
+	return func(c *int64EncodingServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithInt64EncodingServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithInt64EncodingServiceDebugBodyLimit(limit int) Int64EncodingServiceClientOption {
+	return func(c *int64EncodingServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithInt64EncodingServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithInt64EncodingServiceLogHook(hook func(sebufhttp.ClientLogEvent)) Int64EncodingServiceClientOption {
+	return func(c *int64EncodingServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithInt64EncodingServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithInt64EncodingServiceShadowMutations is set.
+func WithInt64EncodingServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) Int64EncodingServiceClientOption {
+	return func(c *int64EncodingServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithInt64EncodingServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithInt64EncodingServiceShadowMutations() Int64EncodingServiceClientOption {
+	return func(c *int64EncodingServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithInt64EncodingServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithInt64EncodingServiceShadowTimeout(timeout time.Duration) Int64EncodingServiceClientOption {
+	return func(c *int64EncodingServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // Int64EncodingServiceCallOption configures a single RPC call.
 type Int64EncodingServiceCallOption func(*int64EncodingServiceCallOptions)
 
@@ -91,6 +222,7 @@ type int64EncodingServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithInt64EncodingServiceHeader adds a header to a single request.
@@ -118,6 +250,14 @@ func WithInt64EncodingServiceCallDiscardUnknownFields(discard bool) Int64Encodin
 	}
 }
 
+// WithInt64EncodingServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithInt64EncodingServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) Int64EncodingServiceCallOption {
+	return func(o *int64EncodingServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // NewInt64EncodingServiceClient creates a new Int64EncodingService client.
 func NewInt64EncodingServiceClient(baseURL string, opts ...Int64EncodingServiceClientOption) Int64EncodingServiceClient {
 	c := &int64EncodingServiceClient{
@@ -134,6 +274,21 @@ func NewInt64EncodingServiceClient(baseURL string, opts ...Int64EncodingServiceC
 	return c
 }
 
+// Int64EncodingServiceRoutes holds the HTTP verb and path template of every Int64EncodingService method.
+var Int64EncodingServiceRoutes = struct {
+	GetInt64Test sebufhttp.Route
+}{
+	GetInt64Test: sebufhttp.Route{Method: "GET", Path: "/api/v1/test/int64/{id}"},
+}
+
+// Int64EncodingServiceGetInt64TestURL returns the path and query string of a GetInt64Test call with req,
+// relative to the client's base URL.
+func Int64EncodingServiceGetInt64TestURL(req *GetInt64TestRequest) string {
+	path := "/api/v1/test/int64/{id}"
+	path = strings.Replace(path, "{id}", url.PathEscape(fmt.Sprint(req.Id)), 1)
+	return path
+}
+
 // GetInt64Test calls the GetInt64Test RPC.
 func (c *int64EncodingServiceClient) GetInt64Test(ctx context.Context, req *GetInt64TestRequest, opts ...Int64EncodingServiceCallOption) (*Int64EncodingTest, error) {
 	callOpts := &int64EncodingServiceCallOptions{}
@@ -142,9 +297,7 @@ func (c *int64EncodingServiceClient) GetInt64Test(ctx context.Context, req *GetI
 	}
 
 	// Build URL
-	path := "/api/v1/test/int64/{id}"
-	path = strings.Replace(path, "{id}", url.PathEscape(fmt.Sprint(req.Id)), 1)
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + Int64EncodingServiceGetInt64TestURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -162,15 +315,32 @@ func (c *int64EncodingServiceClient) GetInt64Test(ctx context.Context, req *GetI
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "Int64EncodingService.GetInt64Test",
+		Response: &Int64EncodingTest{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("Int64EncodingService.GetInt64Test", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -181,7 +351,13 @@ func (c *int64EncodingServiceClient) GetInt64Test(ctx context.Context, req *GetI
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -214,7 +390,14 @@ func (c *int64EncodingServiceClient) marshalRequest(req proto.Message, contentTy
 	}
 }
 
-func (c *int64EncodingServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *int64EncodingServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -20,9 +21,9 @@ import (
 
 const (
 	// ContentTypeJSON is the content type for JSON requests/responses.
-	ContentTypeJSON = "application/json"
+	ContentTypeJSON = sebufhttp.JSONContentType
 	// ContentTypeProto is the content type for binary protobuf requests/responses.
-	ContentTypeProto = "application/x-protobuf"
+	ContentTypeProto = sebufhttp.ProtoContentType
 )
 
 // sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
@@ -43,7 +44,15 @@ type sensorServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ SensorServiceClient = (*sensorServiceClient)(nil)
@@ -76,6 +85,27 @@ func WithSensorServiceDefaultHeader(key, value string) SensorServiceClientOption
 	}
 }
 
+// WithSensorServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithSensorServiceHeaderPropagation(allowlist ...string) SensorServiceClientOption {
+	return func(c *sensorServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithSensorServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithSensorServiceDeadlinePropagation(headerName string) SensorServiceClientOption {
+	return func(c *sensorServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithSensorServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithSensorServiceDiscardUnknownFields(discard bool) SensorServiceClientOption {
@@ -84,6 +114,105 @@ func WithSensorServiceDiscardUnknownFields(discard bool) SensorServiceClientOpti
 	}
 }
 
+// WithSensorServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithSensorServiceHedging(delay time.Duration, maxHedges int) SensorServiceClientOption {
+	return func(c *sensorServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithSensorServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithSensorServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) SensorServiceClientOption {
+	return func(c *sensorServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithSensorServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithSensorServiceRecorder(recorder sebufhttp.CallRecorder) SensorServiceClientOption {
+	return func(c *sensorServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithSensorServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithSensorServiceDebugLogging(w io.Writer) SensorServiceClientOption {
+	return func(c *sensorServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithSensorServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithSensorServiceDebugBodyLimit(limit int) SensorServiceClientOption {
+	return func(c *sensorServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithSensorServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithSensorServiceLogHook(hook func(sebufhttp.ClientLogEvent)) SensorServiceClientOption {
+	return func(c *sensorServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithSensorServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithSensorServiceShadowMutations is set.
+func WithSensorServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) SensorServiceClientOption {
+	return func(c *sensorServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithSensorServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithSensorServiceShadowMutations() SensorServiceClientOption {
+	return func(c *sensorServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithSensorServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithSensorServiceShadowTimeout(timeout time.Duration) SensorServiceClientOption {
+	return func(c *sensorServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // SensorServiceCallOption configures a single RPC call.
 type SensorServiceCallOption func(*sensorServiceCallOptions)
 
@@ -92,6 +221,7 @@ type sensorServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithSensorServiceHeader adds a header to a single request.
@@ -119,6 +249,14 @@ func WithSensorServiceCallDiscardUnknownFields(discard bool) SensorServiceCallOp
 	}
 }
 
+// WithSensorServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithSensorServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) SensorServiceCallOption {
+	return func(o *sensorServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // NewSensorServiceClient creates a new SensorService client.
 func NewSensorServiceClient(baseURL string, opts ...SensorServiceClientOption) SensorServiceClient {
 	c := &sensorServiceClient{
@@ -135,6 +273,31 @@ func NewSensorServiceClient(baseURL string, opts ...SensorServiceClientOption) S
 	return c
 }
 
+// SensorServiceRoutes holds the HTTP verb and path template of every SensorService method.
+var SensorServiceRoutes = struct {
+	GetSensorReading sebufhttp.Route
+	GetMultiSensor   sebufhttp.Route
+}{
+	GetSensorReading: sebufhttp.Route{Method: "GET", Path: "/api/v1/sensors/{sensor_id}"},
+	GetMultiSensor:   sebufhttp.Route{Method: "GET", Path: "/api/v1/sensors/{sensor_id}/multi"},
+}
+
+// SensorServiceGetSensorReadingURL returns the path and query string of a GetSensorReading call with req,
+// relative to the client's base URL.
+func SensorServiceGetSensorReadingURL(req *GetSensorRequest) string {
+	path := "/api/v1/sensors/{sensor_id}"
+	path = strings.Replace(path, "{sensor_id}", url.PathEscape(fmt.Sprint(req.SensorId)), 1)
+	return path
+}
+
+// SensorServiceGetMultiSensorURL returns the path and query string of a GetMultiSensor call with req,
+// relative to the client's base URL.
+func SensorServiceGetMultiSensorURL(req *GetSensorRequest) string {
+	path := "/api/v1/sensors/{sensor_id}/multi"
+	path = strings.Replace(path, "{sensor_id}", url.PathEscape(fmt.Sprint(req.SensorId)), 1)
+	return path
+}
+
 // GetSensorReading calls the GetSensorReading RPC.
 func (c *sensorServiceClient) GetSensorReading(ctx context.Context, req *GetSensorRequest, opts ...SensorServiceCallOption) (*GetSensorReadingResponse, error) {
 	callOpts := &sensorServiceCallOptions{}
@@ -143,9 +306,7 @@ func (c *sensorServiceClient) GetSensorReading(ctx context.Context, req *GetSens
 	}
 
 	// Build URL
-	path := "/api/v1/sensors/{sensor_id}"
-	path = strings.Replace(path, "{sensor_id}", url.PathEscape(fmt.Sprint(req.SensorId)), 1)
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + SensorServiceGetSensorReadingURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -163,15 +324,32 @@ func (c *sensorServiceClient) GetSensorReading(ctx context.Context, req *GetSens
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "SensorService.GetSensorReading",
+		Response: &GetSensorReadingResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("SensorService.GetSensorReading", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -182,7 +360,13 @@ func (c *sensorServiceClient) GetSensorReading(ctx context.Context, req *GetSens
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -208,9 +392,7 @@ func (c *sensorServiceClient) GetMultiSensor(ctx context.Context, req *GetSensor
 	}
 
 	// Build URL
-	path := "/api/v1/sensors/{sensor_id}/multi"
-	path = strings.Replace(path, "{sensor_id}", url.PathEscape(fmt.Sprint(req.SensorId)), 1)
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + SensorServiceGetMultiSensorURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -228,15 +410,32 @@ func (c *sensorServiceClient) GetMultiSensor(ctx context.Context, req *GetSensor
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "SensorService.GetMultiSensor",
+		Response: &GetMultiSensorResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("SensorService.GetMultiSensor", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -247,7 +446,13 @@ func (c *sensorServiceClient) GetMultiSensor(ctx context.Context, req *GetSensor
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -280,7 +485,14 @@ func (c *sensorServiceClient) marshalRequest(req proto.Message, contentType stri
 	}
 }
 
-func (c *sensorServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *sensorServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
@@ -8,6 +8,8 @@ import (
 	"strconv"
 
 	"google.golang.org/protobuf/encoding/protojson"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
 
 // MarshalJSONSebuf implements sebufMarshaler for SensorReading.
@@ -51,6 +53,12 @@ func (x *SensorReading) MarshalJSON() ([]byte, error) {
 	return x.MarshalJSONSebuf(protojson.MarshalOptions{})
 }
 
+// sensorReadingAlternateJSONNames maps the proto names and JSON names protojson
+// accepts for the fields of SensorReading to the keys UnmarshalJSON reads.
+var sensorReadingAlternateJSONNames = map[string]string{
+	"timestamp_ms": "timestampMs",
+}
+
 // UnmarshalJSONSebuf implements sebufUnmarshaler for SensorReading.
 // This method handles int64_encoding=NUMBER fields: timestamp_ms, values
 func (x *SensorReading) UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error {
@@ -59,6 +67,9 @@ func (x *SensorReading) UnmarshalJSONSebuf(data []byte, opts protojson.Unmarshal
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	if err := sebufhttp.ResolveAlternateJSONNames(raw, sensorReadingAlternateJSONNames); err != nil {
+		return err
+	}
 
 	// Convert timestampMs from number to string for protojson
 	if rawVal, ok := raw["timestampMs"]; ok {
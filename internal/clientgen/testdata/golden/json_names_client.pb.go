@@ -0,0 +1,573 @@
+// Code generated by protoc-gen-go-client. DO NOT EDIT.
+// source: json_names.proto
+
+package jsonnames
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+)
+
+const (
+	// ContentTypeJSON is the content type for JSON requests/responses.
+	ContentTypeJSON = sebufhttp.JSONContentType
+	// ContentTypeProto is the content type for binary protobuf requests/responses.
+	ContentTypeProto = sebufhttp.ProtoContentType
+)
+
+// sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
+// It allows passing protojson.UnmarshalOptions (e.g. DiscardUnknown) through custom unmarshalers.
+type sebufUnmarshaler interface {
+	UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error
+}
+
+// JSONNameServiceClient is the client API for JSONNameService service.
+//
+// JSONNameService binds fields with custom JSON names from every location.
+type JSONNameServiceClient interface {
+	// GetWidget reads renamed fields from the path, query string and headers
+	GetWidget(ctx context.Context, req *GetWidgetRequest, opts ...JSONNameServiceCallOption) (*Widget, error)
+	// UpdateWidget sends renamed fields in the body alongside a path parameter
+	UpdateWidget(ctx context.Context, req *UpdateWidgetRequest, opts ...JSONNameServiceCallOption) (*Widget, error)
+}
+
+// jSONNameServiceClient is the implementation of JSONNameServiceClient.
+type jSONNameServiceClient struct {
+	baseURL              string
+	httpClient           *http.Client
+	contentType          string
+	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
+	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
+}
+
+var _ JSONNameServiceClient = (*jSONNameServiceClient)(nil)
+
+// JSONNameServiceClientOption configures a JSONNameService client.
+type JSONNameServiceClientOption func(*jSONNameServiceClient)
+
+// WithJSONNameServiceHTTPClient sets the HTTP client to use for requests.
+func WithJSONNameServiceHTTPClient(client *http.Client) JSONNameServiceClientOption {
+	return func(c *jSONNameServiceClient) {
+		c.httpClient = client
+	}
+}
+
+// WithJSONNameServiceContentType sets the default content type for requests.
+// Use ContentTypeJSON or ContentTypeProto.
+func WithJSONNameServiceContentType(contentType string) JSONNameServiceClientOption {
+	return func(c *jSONNameServiceClient) {
+		c.contentType = contentType
+	}
+}
+
+// WithJSONNameServiceDefaultHeader sets a default header to include in all requests.
+func WithJSONNameServiceDefaultHeader(key, value string) JSONNameServiceClientOption {
+	return func(c *jSONNameServiceClient) {
+		if c.defaultHeaders == nil {
+			c.defaultHeaders = make(map[string]string)
+		}
+		c.defaultHeaders[key] = value
+	}
+}
+
+// WithJSONNameServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithJSONNameServiceHeaderPropagation(allowlist ...string) JSONNameServiceClientOption {
+	return func(c *jSONNameServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithJSONNameServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithJSONNameServiceDeadlinePropagation(headerName string) JSONNameServiceClientOption {
+	return func(c *jSONNameServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
+// WithJSONNameServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
+// When true, unknown fields are silently ignored instead of causing unmarshal errors.
+func WithJSONNameServiceDiscardUnknownFields(discard bool) JSONNameServiceClientOption {
+	return func(c *jSONNameServiceClient) {
+		c.discardUnknownFields = discard
+	}
+}
+
+// WithJSONNameServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithJSONNameServiceHedging(delay time.Duration, maxHedges int) JSONNameServiceClientOption {
+	return func(c *jSONNameServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithJSONNameServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithJSONNameServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) JSONNameServiceClientOption {
+	return func(c *jSONNameServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithJSONNameServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithJSONNameServiceRecorder(recorder sebufhttp.CallRecorder) JSONNameServiceClientOption {
+	return func(c *jSONNameServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithJSONNameServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithJSONNameServiceDebugLogging(w io.Writer) JSONNameServiceClientOption {
+	return func(c *jSONNameServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithJSONNameServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithJSONNameServiceDebugBodyLimit(limit int) JSONNameServiceClientOption {
+	return func(c *jSONNameServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithJSONNameServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithJSONNameServiceLogHook(hook func(sebufhttp.ClientLogEvent)) JSONNameServiceClientOption {
+	return func(c *jSONNameServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithJSONNameServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithJSONNameServiceShadowMutations is set.
+func WithJSONNameServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) JSONNameServiceClientOption {
+	return func(c *jSONNameServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithJSONNameServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithJSONNameServiceShadowMutations() JSONNameServiceClientOption {
+	return func(c *jSONNameServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithJSONNameServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithJSONNameServiceShadowTimeout(timeout time.Duration) JSONNameServiceClientOption {
+	return func(c *jSONNameServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
+// JSONNameServiceCallOption configures a single RPC call.
+type JSONNameServiceCallOption func(*jSONNameServiceCallOptions)
+
+// jSONNameServiceCallOptions holds options for a single RPC call.
+type jSONNameServiceCallOptions struct {
+	headers              map[string]string
+	contentType          string
+	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
+}
+
+// WithJSONNameServiceHeader adds a header to a single request.
+func WithJSONNameServiceHeader(key, value string) JSONNameServiceCallOption {
+	return func(o *jSONNameServiceCallOptions) {
+		if o.headers == nil {
+			o.headers = make(map[string]string)
+		}
+		o.headers[key] = value
+	}
+}
+
+// WithJSONNameServiceCallContentType sets the content type for a single request.
+func WithJSONNameServiceCallContentType(contentType string) JSONNameServiceCallOption {
+	return func(o *jSONNameServiceCallOptions) {
+		o.contentType = contentType
+	}
+}
+
+// WithJSONNameServiceCallDiscardUnknownFields sets whether to discard unknown fields for a single request.
+// Overrides the client-level setting from WithJSONNameServiceDiscardUnknownFields.
+func WithJSONNameServiceCallDiscardUnknownFields(discard bool) JSONNameServiceCallOption {
+	return func(o *jSONNameServiceCallOptions) {
+		o.discardUnknownFields = &discard
+	}
+}
+
+// WithJSONNameServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithJSONNameServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) JSONNameServiceCallOption {
+	return func(o *jSONNameServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
+// NewJSONNameServiceClient creates a new JSONNameService client.
+func NewJSONNameServiceClient(baseURL string, opts ...JSONNameServiceClientOption) JSONNameServiceClient {
+	c := &jSONNameServiceClient{
+		baseURL:        strings.TrimSuffix(baseURL, "/"),
+		httpClient:     http.DefaultClient,
+		contentType:    ContentTypeJSON,
+		defaultHeaders: make(map[string]string),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// JSONNameServiceRoutes holds the HTTP verb and path template of every JSONNameService method.
+var JSONNameServiceRoutes = struct {
+	GetWidget    sebufhttp.Route
+	UpdateWidget sebufhttp.Route
+}{
+	GetWidget:    sebufhttp.Route{Method: "GET", Path: "/api/v1/widgets/{widget_id}"},
+	UpdateWidget: sebufhttp.Route{Method: "PATCH", Path: "/api/v1/widgets/{widget_id}"},
+}
+
+// JSONNameServiceGetWidgetURL returns the path and query string of a GetWidget call with req,
+// relative to the client's base URL.
+func JSONNameServiceGetWidgetURL(req *GetWidgetRequest) string {
+	path := "/api/v1/widgets/{widget_id}"
+	path = strings.Replace(path, "{widget_id}", url.PathEscape(fmt.Sprint(req.WidgetId)), 1)
+
+	// Add query parameters
+	queryParams := url.Values{}
+	if req.PageSize != 0 {
+		queryParams.Set("limit", fmt.Sprint(req.PageSize))
+	}
+	for _, v := range req.Tags {
+		queryParams.Add("tags", fmt.Sprint(v))
+	}
+	if len(queryParams) > 0 {
+		path += "?" + queryParams.Encode()
+	}
+	return path
+}
+
+// JSONNameServiceUpdateWidgetURL returns the path and query string of a UpdateWidget call with req,
+// relative to the client's base URL.
+func JSONNameServiceUpdateWidgetURL(req *UpdateWidgetRequest) string {
+	path := "/api/v1/widgets/{widget_id}"
+	path = strings.Replace(path, "{widget_id}", url.PathEscape(fmt.Sprint(req.WidgetId)), 1)
+	return path
+}
+
+// GetWidget reads renamed fields from the path, query string and headers
+func (c *jSONNameServiceClient) GetWidget(ctx context.Context, req *GetWidgetRequest, opts ...JSONNameServiceCallOption) (*Widget, error) {
+	callOpts := &jSONNameServiceCallOptions{}
+	for _, opt := range opts {
+		opt(callOpts)
+	}
+
+	// Build URL
+	reqURL := c.baseURL + JSONNameServiceGetWidgetURL(req)
+
+	contentType := c.contentType
+	if callOpts.contentType != "" {
+		contentType = callOpts.contentType
+	}
+
+	// Create HTTP request
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	httpReq.Header.Set("Content-Type", contentType)
+	for k, v := range c.defaultHeaders {
+		httpReq.Header.Set(k, v)
+	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
+	for k, v := range callOpts.headers {
+		httpReq.Header.Set(k, v)
+	}
+	if req.Tenant != "" {
+		httpReq.Header.Set("Tenant", req.Tenant)
+	}
+
+	// Execute request
+	call := sebufhttp.ClientCall{
+		Method:   "JSONNameService.GetWidget",
+		Response: &Widget{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("JSONNameService.GetWidget", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Check for error status codes
+	if resp.StatusCode >= 400 {
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
+	}
+
+	// Resolve discardUnknownFields: per-call option overrides client default
+	discardUnknown := c.discardUnknownFields
+	if callOpts.discardUnknownFields != nil {
+		discardUnknown = *callOpts.discardUnknownFields
+	}
+
+	// Unmarshal response
+	result := &Widget{}
+	if err := c.unmarshalResponse(respBody, result, contentType, discardUnknown); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdateWidget sends renamed fields in the body alongside a path parameter
+func (c *jSONNameServiceClient) UpdateWidget(ctx context.Context, req *UpdateWidgetRequest, opts ...JSONNameServiceCallOption) (*Widget, error) {
+	callOpts := &jSONNameServiceCallOptions{}
+	for _, opt := range opts {
+		opt(callOpts)
+	}
+
+	// Build URL
+	reqURL := c.baseURL + JSONNameServiceUpdateWidgetURL(req)
+
+	contentType := c.contentType
+	if callOpts.contentType != "" {
+		contentType = callOpts.contentType
+	}
+
+	// Marshal request body
+	// Fields declared with a query, path, or header source stay out of the body
+	bodyReq := proto.CloneOf(req)
+	bodyMsg := bodyReq.ProtoReflect()
+	bodyMsg.Clear(bodyMsg.Descriptor().Fields().ByName("request_id"))
+	body, err := c.marshalRequest(bodyReq, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Create HTTP request
+	httpReq, err := http.NewRequestWithContext(ctx, "PATCH", reqURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	httpReq.Header.Set("Content-Type", contentType)
+	for k, v := range c.defaultHeaders {
+		httpReq.Header.Set(k, v)
+	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
+	for k, v := range callOpts.headers {
+		httpReq.Header.Set(k, v)
+	}
+	if req.RequestId != "" {
+		httpReq.Header.Set("Request-Id", req.RequestId)
+	}
+
+	// Execute request
+	call := sebufhttp.ClientCall{
+		Method:   "JSONNameService.UpdateWidget",
+		Body:     bodyReq,
+		Response: &Widget{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("JSONNameService.UpdateWidget", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Check for error status codes
+	if resp.StatusCode >= 400 {
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
+	}
+
+	// Resolve discardUnknownFields: per-call option overrides client default
+	discardUnknown := c.discardUnknownFields
+	if callOpts.discardUnknownFields != nil {
+		discardUnknown = *callOpts.discardUnknownFields
+	}
+
+	// Unmarshal response
+	result := &Widget{}
+	if err := c.unmarshalResponse(respBody, result, contentType, discardUnknown); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *jSONNameServiceClient) marshalRequest(req proto.Message, contentType string) ([]byte, error) {
+	switch contentType {
+	case ContentTypeJSON:
+		// Check for custom JSON marshaler (unwrap support)
+		if marshaler, ok := req.(json.Marshaler); ok {
+			return marshaler.MarshalJSON()
+		}
+		return protojson.Marshal(req)
+	case ContentTypeProto:
+		return proto.Marshal(req)
+	default:
+		return protojson.Marshal(req)
+	}
+}
+
+func (c *jSONNameServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
+	// Try to parse as ValidationError first (for 400 errors)
+	// Always use strict mode (false) for error parsing to avoid loose JSON
+	// falsely matching ValidationError or Error types.
+	if statusCode == http.StatusBadRequest {
+		validationErr := &sebufhttp.ValidationError{}
+		if unmarshalErr := c.unmarshalResponse(body, validationErr, contentType, false); unmarshalErr == nil {
+			return validationErr
+		}
+	}
+
+	// Try to parse as generic Error
+	genericErr := &sebufhttp.Error{}
+	if unmarshalErr := c.unmarshalResponse(body, genericErr, contentType, false); unmarshalErr == nil {
+		return genericErr
+	}
+
+	// Fallback to raw error message
+	return fmt.Errorf("request failed with status %d: %s", statusCode, string(body))
+}
+
+func (c *jSONNameServiceClient) unmarshalResponse(body []byte, msg proto.Message, contentType string, discardUnknown bool) error {
+	if len(body) == 0 {
+		return nil
+	}
+
+	opts := protojson.UnmarshalOptions{DiscardUnknown: discardUnknown}
+
+	switch contentType {
+	case ContentTypeJSON:
+		// Check for sebuf-generated custom unmarshaler (passes options through)
+		if u, ok := msg.(sebufUnmarshaler); ok {
+			return u.UnmarshalJSONSebuf(body, opts)
+		}
+		// Check for third-party json.Unmarshaler (best effort, cannot pass options)
+		if u, ok := msg.(json.Unmarshaler); ok {
+			return u.UnmarshalJSON(body)
+		}
+		return opts.Unmarshal(body, msg)
+	case ContentTypeProto:
+		return proto.Unmarshal(body, msg)
+	default:
+		return opts.Unmarshal(body, msg)
+	}
+}
@@ -0,0 +1,656 @@
+// Code generated by protoc-gen-go-client. DO NOT EDIT.
+// source: json_naming.proto
+
+package jsonnaming
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+)
+
+const (
+	// ContentTypeJSON is the content type for JSON requests/responses.
+	ContentTypeJSON = sebufhttp.JSONContentType
+	// ContentTypeProto is the content type for binary protobuf requests/responses.
+	ContentTypeProto = sebufhttp.ProtoContentType
+)
+
+// sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
+// It allows passing protojson.UnmarshalOptions (e.g. DiscardUnknown) through custom unmarshalers.
+type sebufUnmarshaler interface {
+	UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error
+}
+
+// OrderServiceClient is the client API for OrderService service.
+//
+// OrderService exchanges snake_case messages nesting camelCase ones.
+type OrderServiceClient interface {
+	// CreateOrder sends a snake_case body alongside a path parameter
+	CreateOrder(ctx context.Context, req *CreateOrderRequest, opts ...OrderServiceCallOption) (*Order, error)
+	// GetOrder reads a path parameter and a query parameter
+	GetOrder(ctx context.Context, req *GetOrderRequest, opts ...OrderServiceCallOption) (*Order, error)
+	// GetCatalog returns a message with an unwrapped map
+	GetCatalog(ctx context.Context, req *GetCatalogRequest, opts ...OrderServiceCallOption) (*Catalog, error)
+}
+
+// orderServiceClient is the implementation of OrderServiceClient.
+type orderServiceClient struct {
+	baseURL              string
+	httpClient           *http.Client
+	contentType          string
+	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
+	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
+}
+
+var _ OrderServiceClient = (*orderServiceClient)(nil)
+
+// OrderServiceClientOption configures a OrderService client.
+type OrderServiceClientOption func(*orderServiceClient)
+
+// WithOrderServiceHTTPClient sets the HTTP client to use for requests.
+func WithOrderServiceHTTPClient(client *http.Client) OrderServiceClientOption {
+	return func(c *orderServiceClient) {
+		c.httpClient = client
+	}
+}
+
+// WithOrderServiceContentType sets the default content type for requests.
+// Use ContentTypeJSON or ContentTypeProto.
+func WithOrderServiceContentType(contentType string) OrderServiceClientOption {
+	return func(c *orderServiceClient) {
+		c.contentType = contentType
+	}
+}
+
+// WithOrderServiceDefaultHeader sets a default header to include in all requests.
+func WithOrderServiceDefaultHeader(key, value string) OrderServiceClientOption {
+	return func(c *orderServiceClient) {
+		if c.defaultHeaders == nil {
+			c.defaultHeaders = make(map[string]string)
+		}
+		c.defaultHeaders[key] = value
+	}
+}
+
+// WithOrderServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithOrderServiceHeaderPropagation(allowlist ...string) OrderServiceClientOption {
+	return func(c *orderServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithOrderServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithOrderServiceDeadlinePropagation(headerName string) OrderServiceClientOption {
+	return func(c *orderServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
+// WithOrderServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
+// When true, unknown fields are silently ignored instead of causing unmarshal errors.
+func WithOrderServiceDiscardUnknownFields(discard bool) OrderServiceClientOption {
+	return func(c *orderServiceClient) {
+		c.discardUnknownFields = discard
+	}
+}
+
+// WithOrderServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithOrderServiceHedging(delay time.Duration, maxHedges int) OrderServiceClientOption {
+	return func(c *orderServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithOrderServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithOrderServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) OrderServiceClientOption {
+	return func(c *orderServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithOrderServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithOrderServiceRecorder(recorder sebufhttp.CallRecorder) OrderServiceClientOption {
+	return func(c *orderServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithOrderServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithOrderServiceDebugLogging(w io.Writer) OrderServiceClientOption {
+	return func(c *orderServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithOrderServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithOrderServiceDebugBodyLimit(limit int) OrderServiceClientOption {
+	return func(c *orderServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithOrderServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithOrderServiceLogHook(hook func(sebufhttp.ClientLogEvent)) OrderServiceClientOption {
+	return func(c *orderServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithOrderServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithOrderServiceShadowMutations is set.
+func WithOrderServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) OrderServiceClientOption {
+	return func(c *orderServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithOrderServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithOrderServiceShadowMutations() OrderServiceClientOption {
+	return func(c *orderServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithOrderServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithOrderServiceShadowTimeout(timeout time.Duration) OrderServiceClientOption {
+	return func(c *orderServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
+// OrderServiceCallOption configures a single RPC call.
+type OrderServiceCallOption func(*orderServiceCallOptions)
+
+// orderServiceCallOptions holds options for a single RPC call.
+type orderServiceCallOptions struct {
+	headers              map[string]string
+	contentType          string
+	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
+}
+
+// WithOrderServiceHeader adds a header to a single request.
+func WithOrderServiceHeader(key, value string) OrderServiceCallOption {
+	return func(o *orderServiceCallOptions) {
+		if o.headers == nil {
+			o.headers = make(map[string]string)
+		}
+		o.headers[key] = value
+	}
+}
+
+// WithOrderServiceCallContentType sets the content type for a single request.
+func WithOrderServiceCallContentType(contentType string) OrderServiceCallOption {
+	return func(o *orderServiceCallOptions) {
+		o.contentType = contentType
+	}
+}
+
+// WithOrderServiceCallDiscardUnknownFields sets whether to discard unknown fields for a single request.
+// Overrides the client-level setting from WithOrderServiceDiscardUnknownFields.
+func WithOrderServiceCallDiscardUnknownFields(discard bool) OrderServiceCallOption {
+	return func(o *orderServiceCallOptions) {
+		o.discardUnknownFields = &discard
+	}
+}
+
+// WithOrderServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithOrderServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) OrderServiceCallOption {
+	return func(o *orderServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
+// NewOrderServiceClient creates a new OrderService client.
+func NewOrderServiceClient(baseURL string, opts ...OrderServiceClientOption) OrderServiceClient {
+	c := &orderServiceClient{
+		baseURL:        strings.TrimSuffix(baseURL, "/"),
+		httpClient:     http.DefaultClient,
+		contentType:    ContentTypeJSON,
+		defaultHeaders: make(map[string]string),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// OrderServiceRoutes holds the HTTP verb and path template of every OrderService method.
+var OrderServiceRoutes = struct {
+	CreateOrder sebufhttp.Route
+	GetOrder    sebufhttp.Route
+	GetCatalog  sebufhttp.Route
+}{
+	CreateOrder: sebufhttp.Route{Method: "POST", Path: "/api/v1/customers/{customer_id}/orders"},
+	GetOrder:    sebufhttp.Route{Method: "GET", Path: "/api/v1/orders/{order_id}"},
+	GetCatalog:  sebufhttp.Route{Method: "GET", Path: "/api/v1/catalog"},
+}
+
+// OrderServiceCreateOrderURL returns the path and query string of a CreateOrder call with req,
+// relative to the client's base URL.
+func OrderServiceCreateOrderURL(req *CreateOrderRequest) string {
+	path := "/api/v1/customers/{customer_id}/orders"
+	path = strings.Replace(path, "{customer_id}", url.PathEscape(fmt.Sprint(req.CustomerId)), 1)
+	return path
+}
+
+// OrderServiceGetOrderURL returns the path and query string of a GetOrder call with req,
+// relative to the client's base URL.
+func OrderServiceGetOrderURL(req *GetOrderRequest) string {
+	path := "/api/v1/orders/{order_id}"
+	path = strings.Replace(path, "{order_id}", url.PathEscape(fmt.Sprint(req.OrderId)), 1)
+
+	// Add query parameters
+	queryParams := url.Values{}
+	if req.IncludeItems != false {
+		queryParams.Set("include_items", fmt.Sprint(req.IncludeItems))
+	}
+	if len(queryParams) > 0 {
+		path += "?" + queryParams.Encode()
+	}
+	return path
+}
+
+// OrderServiceGetCatalogURL returns the path and query string of a GetCatalog call with req,
+// relative to the client's base URL.
+func OrderServiceGetCatalogURL(req *GetCatalogRequest) string {
+	return "/api/v1/catalog"
+}
+
+// CreateOrder sends a snake_case body alongside a path parameter
+func (c *orderServiceClient) CreateOrder(ctx context.Context, req *CreateOrderRequest, opts ...OrderServiceCallOption) (*Order, error) {
+	callOpts := &orderServiceCallOptions{}
+	for _, opt := range opts {
+		opt(callOpts)
+	}
+
+	// Build URL
+	reqURL := c.baseURL + OrderServiceCreateOrderURL(req)
+
+	contentType := c.contentType
+	if callOpts.contentType != "" {
+		contentType = callOpts.contentType
+	}
+
+	// Marshal request body
+	body, err := c.marshalRequest(req, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Create HTTP request
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", reqURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	httpReq.Header.Set("Content-Type", contentType)
+	for k, v := range c.defaultHeaders {
+		httpReq.Header.Set(k, v)
+	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
+	for k, v := range callOpts.headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	// Execute request
+	call := sebufhttp.ClientCall{
+		Method:   "OrderService.CreateOrder",
+		Body:     req,
+		Response: &Order{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("OrderService.CreateOrder", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Check for error status codes
+	if resp.StatusCode >= 400 {
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
+	}
+
+	// Resolve discardUnknownFields: per-call option overrides client default
+	discardUnknown := c.discardUnknownFields
+	if callOpts.discardUnknownFields != nil {
+		discardUnknown = *callOpts.discardUnknownFields
+	}
+
+	// Unmarshal response
+	result := &Order{}
+	if err := c.unmarshalResponse(respBody, result, contentType, discardUnknown); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetOrder reads a path parameter and a query parameter
+func (c *orderServiceClient) GetOrder(ctx context.Context, req *GetOrderRequest, opts ...OrderServiceCallOption) (*Order, error) {
+	callOpts := &orderServiceCallOptions{}
+	for _, opt := range opts {
+		opt(callOpts)
+	}
+
+	// Build URL
+	reqURL := c.baseURL + OrderServiceGetOrderURL(req)
+
+	contentType := c.contentType
+	if callOpts.contentType != "" {
+		contentType = callOpts.contentType
+	}
+
+	// Create HTTP request
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	httpReq.Header.Set("Content-Type", contentType)
+	for k, v := range c.defaultHeaders {
+		httpReq.Header.Set(k, v)
+	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
+	for k, v := range callOpts.headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	// Execute request
+	call := sebufhttp.ClientCall{
+		Method:   "OrderService.GetOrder",
+		Response: &Order{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("OrderService.GetOrder", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Check for error status codes
+	if resp.StatusCode >= 400 {
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
+	}
+
+	// Resolve discardUnknownFields: per-call option overrides client default
+	discardUnknown := c.discardUnknownFields
+	if callOpts.discardUnknownFields != nil {
+		discardUnknown = *callOpts.discardUnknownFields
+	}
+
+	// Unmarshal response
+	result := &Order{}
+	if err := c.unmarshalResponse(respBody, result, contentType, discardUnknown); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetCatalog returns a message with an unwrapped map
+func (c *orderServiceClient) GetCatalog(ctx context.Context, req *GetCatalogRequest, opts ...OrderServiceCallOption) (*Catalog, error) {
+	callOpts := &orderServiceCallOptions{}
+	for _, opt := range opts {
+		opt(callOpts)
+	}
+
+	// Build URL
+	reqURL := c.baseURL + OrderServiceGetCatalogURL(req)
+
+	contentType := c.contentType
+	if callOpts.contentType != "" {
+		contentType = callOpts.contentType
+	}
+
+	// Create HTTP request
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	httpReq.Header.Set("Content-Type", contentType)
+	for k, v := range c.defaultHeaders {
+		httpReq.Header.Set(k, v)
+	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
+	for k, v := range callOpts.headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	// Execute request
+	call := sebufhttp.ClientCall{
+		Method:   "OrderService.GetCatalog",
+		Response: &Catalog{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("OrderService.GetCatalog", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Check for error status codes
+	if resp.StatusCode >= 400 {
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
+	}
+
+	// Resolve discardUnknownFields: per-call option overrides client default
+	discardUnknown := c.discardUnknownFields
+	if callOpts.discardUnknownFields != nil {
+		discardUnknown = *callOpts.discardUnknownFields
+	}
+
+	// Unmarshal response
+	result := &Catalog{}
+	if err := c.unmarshalResponse(respBody, result, contentType, discardUnknown); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *orderServiceClient) marshalRequest(req proto.Message, contentType string) ([]byte, error) {
+	switch contentType {
+	case ContentTypeJSON:
+		// Check for custom JSON marshaler (unwrap support)
+		if marshaler, ok := req.(json.Marshaler); ok {
+			return marshaler.MarshalJSON()
+		}
+		return protojson.Marshal(req)
+	case ContentTypeProto:
+		return proto.Marshal(req)
+	default:
+		return protojson.Marshal(req)
+	}
+}
+
+func (c *orderServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
+	// Try to parse as ValidationError first (for 400 errors)
+	// Always use strict mode (false) for error parsing to avoid loose JSON
+	// falsely matching ValidationError or Error types.
+	if statusCode == http.StatusBadRequest {
+		validationErr := &sebufhttp.ValidationError{}
+		if unmarshalErr := c.unmarshalResponse(body, validationErr, contentType, false); unmarshalErr == nil {
+			return validationErr
+		}
+	}
+
+	// Try to parse as generic Error
+	genericErr := &sebufhttp.Error{}
+	if unmarshalErr := c.unmarshalResponse(body, genericErr, contentType, false); unmarshalErr == nil {
+		return genericErr
+	}
+
+	// Fallback to raw error message
+	return fmt.Errorf("request failed with status %d: %s", statusCode, string(body))
+}
+
+func (c *orderServiceClient) unmarshalResponse(body []byte, msg proto.Message, contentType string, discardUnknown bool) error {
+	if len(body) == 0 {
+		return nil
+	}
+
+	opts := protojson.UnmarshalOptions{DiscardUnknown: discardUnknown}
+
+	switch contentType {
+	case ContentTypeJSON:
+		// Check for sebuf-generated custom unmarshaler (passes options through)
+		if u, ok := msg.(sebufUnmarshaler); ok {
+			return u.UnmarshalJSONSebuf(body, opts)
+		}
+		// Check for third-party json.Unmarshaler (best effort, cannot pass options)
+		if u, ok := msg.(json.Unmarshaler); ok {
+			return u.UnmarshalJSON(body)
+		}
+		return opts.Unmarshal(body, msg)
+	case ContentTypeProto:
+		return proto.Unmarshal(body, msg)
+	default:
+		return opts.Unmarshal(body, msg)
+	}
+}
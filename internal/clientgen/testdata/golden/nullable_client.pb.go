@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -21,9 +22,9 @@ import (
 
 const (
 	// ContentTypeJSON is the content type for JSON requests/responses.
-	ContentTypeJSON = "application/json"
+	ContentTypeJSON = sebufhttp.JSONContentType
 	// ContentTypeProto is the content type for binary protobuf requests/responses.
-	ContentTypeProto = "application/x-protobuf"
+	ContentTypeProto = sebufhttp.ProtoContentType
 )
 
 // sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
@@ -33,6 +34,8 @@ type sebufUnmarshaler interface {
 }
 
 // NullableServiceClient is the client API for NullableService service.
+//
+// NullableService tests nullable fields in requests and responses.
 type NullableServiceClient interface {
 	GetUser(ctx context.Context, req *GetUserRequest, opts ...NullableServiceCallOption) (*User, error)
 	UpdateUser(ctx context.Context, req *UpdateUserRequest, opts ...NullableServiceCallOption) (*User, error)
@@ -44,7 +47,15 @@ type nullableServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ NullableServiceClient = (*nullableServiceClient)(nil)
@@ -77,6 +88,27 @@ func WithNullableServiceDefaultHeader(key, value string) NullableServiceClientOp
 	}
 }
 
+// WithNullableServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithNullableServiceHeaderPropagation(allowlist ...string) NullableServiceClientOption {
+	return func(c *nullableServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithNullableServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithNullableServiceDeadlinePropagation(headerName string) NullableServiceClientOption {
+	return func(c *nullableServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithNullableServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithNullableServiceDiscardUnknownFields(discard bool) NullableServiceClientOption {
@@ -85,6 +117,105 @@ func WithNullableServiceDiscardUnknownFields(discard bool) NullableServiceClient
 	}
 }
 
+// WithNullableServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithNullableServiceHedging(delay time.Duration, maxHedges int) NullableServiceClientOption {
+	return func(c *nullableServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithNullableServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithNullableServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) NullableServiceClientOption {
+	return func(c *nullableServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithNullableServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithNullableServiceRecorder(recorder sebufhttp.CallRecorder) NullableServiceClientOption {
+	return func(c *nullableServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithNullableServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithNullableServiceDebugLogging(w io.Writer) NullableServiceClientOption {
+	return func(c *nullableServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithNullableServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithNullableServiceDebugBodyLimit(limit int) NullableServiceClientOption {
+	return func(c *nullableServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithNullableServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithNullableServiceLogHook(hook func(sebufhttp.ClientLogEvent)) NullableServiceClientOption {
+	return func(c *nullableServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithNullableServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithNullableServiceShadowMutations is set.
+func WithNullableServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) NullableServiceClientOption {
+	return func(c *nullableServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithNullableServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithNullableServiceShadowMutations() NullableServiceClientOption {
+	return func(c *nullableServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithNullableServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithNullableServiceShadowTimeout(timeout time.Duration) NullableServiceClientOption {
+	return func(c *nullableServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // NullableServiceCallOption configures a single RPC call.
 type NullableServiceCallOption func(*nullableServiceCallOptions)
 
@@ -93,6 +224,7 @@ type nullableServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithNullableServiceHeader adds a header to a single request.
@@ -120,6 +252,14 @@ func WithNullableServiceCallDiscardUnknownFields(discard bool) NullableServiceCa
 	}
 }
 
+// WithNullableServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithNullableServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) NullableServiceCallOption {
+	return func(o *nullableServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // NewNullableServiceClient creates a new NullableService client.
 func NewNullableServiceClient(baseURL string, opts ...NullableServiceClientOption) NullableServiceClient {
 	c := &nullableServiceClient{
@@ -136,6 +276,31 @@ func NewNullableServiceClient(baseURL string, opts ...NullableServiceClientOptio
 	return c
 }
 
+// NullableServiceRoutes holds the HTTP verb and path template of every NullableService method.
+var NullableServiceRoutes = struct {
+	GetUser    sebufhttp.Route
+	UpdateUser sebufhttp.Route
+}{
+	GetUser:    sebufhttp.Route{Method: "GET", Path: "/api/v1/users/{id}"},
+	UpdateUser: sebufhttp.Route{Method: "PUT", Path: "/api/v1/users/{id}"},
+}
+
+// NullableServiceGetUserURL returns the path and query string of a GetUser call with req,
+// relative to the client's base URL.
+func NullableServiceGetUserURL(req *GetUserRequest) string {
+	path := "/api/v1/users/{id}"
+	path = strings.Replace(path, "{id}", url.PathEscape(fmt.Sprint(req.Id)), 1)
+	return path
+}
+
+// NullableServiceUpdateUserURL returns the path and query string of a UpdateUser call with req,
+// relative to the client's base URL.
+func NullableServiceUpdateUserURL(req *UpdateUserRequest) string {
+	path := "/api/v1/users/{id}"
+	path = strings.Replace(path, "{id}", url.PathEscape(fmt.Sprint(req.Id)), 1)
+	return path
+}
+
 // GetUser calls the GetUser RPC.
 func (c *nullableServiceClient) GetUser(ctx context.Context, req *GetUserRequest, opts ...NullableServiceCallOption) (*User, error) {
 	callOpts := &nullableServiceCallOptions{}
@@ -144,9 +309,7 @@ func (c *nullableServiceClient) GetUser(ctx context.Context, req *GetUserRequest
 	}
 
 	// Build URL
-	path := "/api/v1/users/{id}"
-	path = strings.Replace(path, "{id}", url.PathEscape(fmt.Sprint(req.Id)), 1)
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + NullableServiceGetUserURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -164,15 +327,32 @@ func (c *nullableServiceClient) GetUser(ctx context.Context, req *GetUserRequest
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "NullableService.GetUser",
+		Response: &User{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("NullableService.GetUser", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -183,7 +363,13 @@ func (c *nullableServiceClient) GetUser(ctx context.Context, req *GetUserRequest
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -209,9 +395,7 @@ func (c *nullableServiceClient) UpdateUser(ctx context.Context, req *UpdateUserR
 	}
 
 	// Build URL
-	path := "/api/v1/users/{id}"
-	path = strings.Replace(path, "{id}", url.PathEscape(fmt.Sprint(req.Id)), 1)
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + NullableServiceUpdateUserURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -235,15 +419,33 @@ func (c *nullableServiceClient) UpdateUser(ctx context.Context, req *UpdateUserR
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "NullableService.UpdateUser",
+		Body:     req,
+		Response: &User{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("NullableService.UpdateUser", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -254,7 +456,13 @@ func (c *nullableServiceClient) UpdateUser(ctx context.Context, req *UpdateUserR
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -287,7 +495,14 @@ func (c *nullableServiceClient) marshalRequest(req proto.Message, contentType st
 	}
 }
 
-func (c *nullableServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *nullableServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
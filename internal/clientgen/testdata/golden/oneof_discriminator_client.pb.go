@@ -11,6 +11,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -20,9 +21,9 @@ import (
 
 const (
 	// ContentTypeJSON is the content type for JSON requests/responses.
-	ContentTypeJSON = "application/json"
+	ContentTypeJSON = sebufhttp.JSONContentType
 	// ContentTypeProto is the content type for binary protobuf requests/responses.
-	ContentTypeProto = "application/x-protobuf"
+	ContentTypeProto = sebufhttp.ProtoContentType
 )
 
 // sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
@@ -44,7 +45,15 @@ type oneofDiscriminatorServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ OneofDiscriminatorServiceClient = (*oneofDiscriminatorServiceClient)(nil)
@@ -77,6 +86,27 @@ func WithOneofDiscriminatorServiceDefaultHeader(key, value string) OneofDiscrimi
 	}
 }
 
+// WithOneofDiscriminatorServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithOneofDiscriminatorServiceHeaderPropagation(allowlist ...string) OneofDiscriminatorServiceClientOption {
+	return func(c *oneofDiscriminatorServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithOneofDiscriminatorServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithOneofDiscriminatorServiceDeadlinePropagation(headerName string) OneofDiscriminatorServiceClientOption {
+	return func(c *oneofDiscriminatorServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithOneofDiscriminatorServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithOneofDiscriminatorServiceDiscardUnknownFields(discard bool) OneofDiscriminatorServiceClientOption {
@@ -85,6 +115,105 @@ func WithOneofDiscriminatorServiceDiscardUnknownFields(discard bool) OneofDiscri
 	}
 }
 
+// WithOneofDiscriminatorServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithOneofDiscriminatorServiceHedging(delay time.Duration, maxHedges int) OneofDiscriminatorServiceClientOption {
+	return func(c *oneofDiscriminatorServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithOneofDiscriminatorServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithOneofDiscriminatorServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) OneofDiscriminatorServiceClientOption {
+	return func(c *oneofDiscriminatorServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithOneofDiscriminatorServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithOneofDiscriminatorServiceRecorder(recorder sebufhttp.CallRecorder) OneofDiscriminatorServiceClientOption {
+	return func(c *oneofDiscriminatorServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithOneofDiscriminatorServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithOneofDiscriminatorServiceDebugLogging(w io.Writer) OneofDiscriminatorServiceClientOption {
+	return func(c *oneofDiscriminatorServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithOneofDiscriminatorServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithOneofDiscriminatorServiceDebugBodyLimit(limit int) OneofDiscriminatorServiceClientOption {
+	return func(c *oneofDiscriminatorServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithOneofDiscriminatorServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithOneofDiscriminatorServiceLogHook(hook func(sebufhttp.ClientLogEvent)) OneofDiscriminatorServiceClientOption {
+	return func(c *oneofDiscriminatorServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithOneofDiscriminatorServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithOneofDiscriminatorServiceShadowMutations is set.
+func WithOneofDiscriminatorServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) OneofDiscriminatorServiceClientOption {
+	return func(c *oneofDiscriminatorServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithOneofDiscriminatorServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithOneofDiscriminatorServiceShadowMutations() OneofDiscriminatorServiceClientOption {
+	return func(c *oneofDiscriminatorServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithOneofDiscriminatorServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithOneofDiscriminatorServiceShadowTimeout(timeout time.Duration) OneofDiscriminatorServiceClientOption {
+	return func(c *oneofDiscriminatorServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // OneofDiscriminatorServiceCallOption configures a single RPC call.
 type OneofDiscriminatorServiceCallOption func(*oneofDiscriminatorServiceCallOptions)
 
@@ -93,6 +222,7 @@ type oneofDiscriminatorServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithOneofDiscriminatorServiceHeader adds a header to a single request.
@@ -120,6 +250,14 @@ func WithOneofDiscriminatorServiceCallDiscardUnknownFields(discard bool) OneofDi
 	}
 }
 
+// WithOneofDiscriminatorServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithOneofDiscriminatorServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) OneofDiscriminatorServiceCallOption {
+	return func(o *oneofDiscriminatorServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // NewOneofDiscriminatorServiceClient creates a new OneofDiscriminatorService client.
 func NewOneofDiscriminatorServiceClient(baseURL string, opts ...OneofDiscriminatorServiceClientOption) OneofDiscriminatorServiceClient {
 	c := &oneofDiscriminatorServiceClient{
@@ -136,6 +274,35 @@ func NewOneofDiscriminatorServiceClient(baseURL string, opts ...OneofDiscriminat
 	return c
 }
 
+// OneofDiscriminatorServiceRoutes holds the HTTP verb and path template of every OneofDiscriminatorService method.
+var OneofDiscriminatorServiceRoutes = struct {
+	TestFlattenedEvent sebufhttp.Route
+	TestNestedEvent    sebufhttp.Route
+	TestPlainEvent     sebufhttp.Route
+}{
+	TestFlattenedEvent: sebufhttp.Route{Method: "POST", Path: "/api/v1/events/flattened"},
+	TestNestedEvent:    sebufhttp.Route{Method: "POST", Path: "/api/v1/events/nested"},
+	TestPlainEvent:     sebufhttp.Route{Method: "POST", Path: "/api/v1/events/plain"},
+}
+
+// OneofDiscriminatorServiceTestFlattenedEventURL returns the path and query string of a TestFlattenedEvent call with req,
+// relative to the client's base URL.
+func OneofDiscriminatorServiceTestFlattenedEventURL(req *FlattenedEvent) string {
+	return "/api/v1/events/flattened"
+}
+
+// OneofDiscriminatorServiceTestNestedEventURL returns the path and query string of a TestNestedEvent call with req,
+// relative to the client's base URL.
+func OneofDiscriminatorServiceTestNestedEventURL(req *NestedEvent) string {
+	return "/api/v1/events/nested"
+}
+
+// OneofDiscriminatorServiceTestPlainEventURL returns the path and query string of a TestPlainEvent call with req,
+// relative to the client's base URL.
+func OneofDiscriminatorServiceTestPlainEventURL(req *PlainEvent) string {
+	return "/api/v1/events/plain"
+}
+
 // TestFlattenedEvent calls the TestFlattenedEvent RPC.
 func (c *oneofDiscriminatorServiceClient) TestFlattenedEvent(ctx context.Context, req *FlattenedEvent, opts ...OneofDiscriminatorServiceCallOption) (*FlattenedEvent, error) {
 	callOpts := &oneofDiscriminatorServiceCallOptions{}
@@ -144,8 +311,7 @@ func (c *oneofDiscriminatorServiceClient) TestFlattenedEvent(ctx context.Context
 	}
 
 	// Build URL
-	path := "/api/v1/events/flattened"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + OneofDiscriminatorServiceTestFlattenedEventURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -169,15 +335,33 @@ func (c *oneofDiscriminatorServiceClient) TestFlattenedEvent(ctx context.Context
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "OneofDiscriminatorService.TestFlattenedEvent",
+		Body:     req,
+		Response: &FlattenedEvent{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("OneofDiscriminatorService.TestFlattenedEvent", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -188,7 +372,13 @@ func (c *oneofDiscriminatorServiceClient) TestFlattenedEvent(ctx context.Context
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -214,8 +404,7 @@ func (c *oneofDiscriminatorServiceClient) TestNestedEvent(ctx context.Context, r
 	}
 
 	// Build URL
-	path := "/api/v1/events/nested"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + OneofDiscriminatorServiceTestNestedEventURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -239,15 +428,33 @@ func (c *oneofDiscriminatorServiceClient) TestNestedEvent(ctx context.Context, r
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "OneofDiscriminatorService.TestNestedEvent",
+		Body:     req,
+		Response: &NestedEvent{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("OneofDiscriminatorService.TestNestedEvent", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -258,7 +465,13 @@ func (c *oneofDiscriminatorServiceClient) TestNestedEvent(ctx context.Context, r
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -284,8 +497,7 @@ func (c *oneofDiscriminatorServiceClient) TestPlainEvent(ctx context.Context, re
 	}
 
 	// Build URL
-	path := "/api/v1/events/plain"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + OneofDiscriminatorServiceTestPlainEventURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -309,15 +521,33 @@ func (c *oneofDiscriminatorServiceClient) TestPlainEvent(ctx context.Context, re
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "OneofDiscriminatorService.TestPlainEvent",
+		Body:     req,
+		Response: &PlainEvent{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("OneofDiscriminatorService.TestPlainEvent", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -328,7 +558,13 @@ func (c *oneofDiscriminatorServiceClient) TestPlainEvent(ctx context.Context, re
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -361,7 +597,14 @@ func (c *oneofDiscriminatorServiceClient) marshalRequest(req proto.Message, cont
 	}
 }
 
-func (c *oneofDiscriminatorServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *oneofDiscriminatorServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
@@ -123,11 +123,11 @@ func (x *FlattenedEvent) UnmarshalJSONSebuf(data []byte, opts protojson.Unmarsha
 				if err := u.UnmarshalJSONSebuf(variantData, opts); err != nil {
 					return fmt.Errorf("failed to unmarshal variant %s: %%w", "Text", err)
 				}
-			} else if err := json.Unmarshal(variantData, variant); err != nil {
+			} else if err := opts.Unmarshal(variantData, variant); err != nil {
 				return fmt.Errorf("failed to unmarshal variant %s: %%w", "Text", err)
 			}
 			x.Content = &FlattenedEvent_Text{Text: variant}
-			raw["text"], _ = json.Marshal(variant)
+			raw["text"], _ = protojson.Marshal(variant)
 		case "img":
 			// Flatten unmarshal: extract Image fields from flat map
 			variantMap := make(map[string]json.RawMessage)
@@ -151,11 +151,11 @@ func (x *FlattenedEvent) UnmarshalJSONSebuf(data []byte, opts protojson.Unmarsha
 				if err := u.UnmarshalJSONSebuf(variantData, opts); err != nil {
 					return fmt.Errorf("failed to unmarshal variant %s: %%w", "Image", err)
 				}
-			} else if err := json.Unmarshal(variantData, variant); err != nil {
+			} else if err := opts.Unmarshal(variantData, variant); err != nil {
 				return fmt.Errorf("failed to unmarshal variant %s: %%w", "Image", err)
 			}
 			x.Content = &FlattenedEvent_Image{Image: variant}
-			raw["image"], _ = json.Marshal(variant)
+			raw["image"], _ = protojson.Marshal(variant)
 		}
 	}
 
@@ -242,7 +242,7 @@ func (x *NestedEvent) UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOp
 					if err := u.UnmarshalJSONSebuf(variantRaw, opts); err != nil {
 						return fmt.Errorf("failed to unmarshal variant %s: %%w", "Text", err)
 					}
-				} else if err := json.Unmarshal(variantRaw, variant); err != nil {
+				} else if err := opts.Unmarshal(variantRaw, variant); err != nil {
 					return fmt.Errorf("failed to unmarshal variant %s: %%w", "Text", err)
 				}
 				x.Content = &NestedEvent_Text{Text: variant}
@@ -257,7 +257,7 @@ func (x *NestedEvent) UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOp
 					if err := u.UnmarshalJSONSebuf(variantRaw, opts); err != nil {
 						return fmt.Errorf("failed to unmarshal variant %s: %%w", "Image", err)
 					}
-				} else if err := json.Unmarshal(variantRaw, variant); err != nil {
+				} else if err := opts.Unmarshal(variantRaw, variant); err != nil {
 					return fmt.Errorf("failed to unmarshal variant %s: %%w", "Image", err)
 				}
 				x.Content = &NestedEvent_Image{Image: variant}
@@ -272,7 +272,7 @@ func (x *NestedEvent) UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOp
 					if err := u.UnmarshalJSONSebuf(variantRaw, opts); err != nil {
 						return fmt.Errorf("failed to unmarshal variant %s: %%w", "Video", err)
 					}
-				} else if err := json.Unmarshal(variantRaw, variant); err != nil {
+				} else if err := opts.Unmarshal(variantRaw, variant); err != nil {
 					return fmt.Errorf("failed to unmarshal variant %s: %%w", "Video", err)
 				}
 				x.Content = &NestedEvent_Video{Video: variant}
@@ -11,18 +11,21 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
 
 const (
 	// ContentTypeJSON is the content type for JSON requests/responses.
-	ContentTypeJSON = "application/json"
+	ContentTypeJSON = sebufhttp.JSONContentType
 	// ContentTypeProto is the content type for binary protobuf requests/responses.
-	ContentTypeProto = "application/x-protobuf"
+	ContentTypeProto = sebufhttp.ProtoContentType
 )
 
 // sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
@@ -31,14 +34,38 @@ type sebufUnmarshaler interface {
 	UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error
 }
 
+// enumParamString returns the wire string for an enum path or query parameter:
+// the custom enum_value string if annotated, otherwise the proto value name.
+func enumParamString(v protoreflect.Enum) string {
+	desc := v.Descriptor().Values().ByNumber(v.Number())
+	if desc == nil {
+		return fmt.Sprint(v.Number())
+	}
+	if opts, ok := desc.Options().(*descriptorpb.EnumValueOptions); ok {
+		if custom, _ := proto.GetExtension(opts, sebufhttp.E_EnumValue).(string); custom != "" {
+			return custom
+		}
+	}
+	return string(desc.Name())
+}
+
 // QueryParamServiceClient is the client API for QueryParamService service.
+//
+// QueryParamService tests various query parameter configurations
 type QueryParamServiceClient interface {
+	// SearchWithTypes all scalar types as query params
 	SearchWithTypes(ctx context.Context, req *SearchWithTypesRequest, opts ...QueryParamServiceCallOption) (*SearchResponse, error)
+	// SearchRequired required vs optional query params
 	SearchRequired(ctx context.Context, req *SearchRequiredRequest, opts ...QueryParamServiceCallOption) (*SearchResponse, error)
+	// SearchCustomNames custom query param names
 	SearchCustomNames(ctx context.Context, req *SearchCustomNamesRequest, opts ...QueryParamServiceCallOption) (*SearchResponse, error)
+	// GetWithFilters mixed path and query params
 	GetWithFilters(ctx context.Context, req *GetWithFiltersRequest, opts ...QueryParamServiceCallOption) (*SearchResponse, error)
+	// SearchAdvanced advanced search with enum + repeated params
 	SearchAdvanced(ctx context.Context, req *SearchAdvancedRequest, opts ...QueryParamServiceCallOption) (*SearchResponse, error)
+	// GetByRegion enum as path parameter
 	GetByRegion(ctx context.Context, req *GetByRegionRequest, opts ...QueryParamServiceCallOption) (*SearchResponse, error)
+	// GetDefaults RPC with empty request message
 	GetDefaults(ctx context.Context, req *EmptyRequest, opts ...QueryParamServiceCallOption) (*SearchResponse, error)
 }
 
@@ -48,7 +75,15 @@ type queryParamServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ QueryParamServiceClient = (*queryParamServiceClient)(nil)
@@ -81,6 +116,27 @@ func WithQueryParamServiceDefaultHeader(key, value string) QueryParamServiceClie
 	}
 }
 
+// WithQueryParamServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithQueryParamServiceHeaderPropagation(allowlist ...string) QueryParamServiceClientOption {
+	return func(c *queryParamServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithQueryParamServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithQueryParamServiceDeadlinePropagation(headerName string) QueryParamServiceClientOption {
+	return func(c *queryParamServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithQueryParamServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithQueryParamServiceDiscardUnknownFields(discard bool) QueryParamServiceClientOption {
@@ -89,6 +145,105 @@ func WithQueryParamServiceDiscardUnknownFields(discard bool) QueryParamServiceCl
 	}
 }
 
+// WithQueryParamServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithQueryParamServiceHedging(delay time.Duration, maxHedges int) QueryParamServiceClientOption {
+	return func(c *queryParamServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithQueryParamServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithQueryParamServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) QueryParamServiceClientOption {
+	return func(c *queryParamServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithQueryParamServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithQueryParamServiceRecorder(recorder sebufhttp.CallRecorder) QueryParamServiceClientOption {
+	return func(c *queryParamServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithQueryParamServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithQueryParamServiceDebugLogging(w io.Writer) QueryParamServiceClientOption {
+	return func(c *queryParamServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithQueryParamServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithQueryParamServiceDebugBodyLimit(limit int) QueryParamServiceClientOption {
+	return func(c *queryParamServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithQueryParamServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithQueryParamServiceLogHook(hook func(sebufhttp.ClientLogEvent)) QueryParamServiceClientOption {
+	return func(c *queryParamServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithQueryParamServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithQueryParamServiceShadowMutations is set.
+func WithQueryParamServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) QueryParamServiceClientOption {
+	return func(c *queryParamServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithQueryParamServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithQueryParamServiceShadowMutations() QueryParamServiceClientOption {
+	return func(c *queryParamServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithQueryParamServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithQueryParamServiceShadowTimeout(timeout time.Duration) QueryParamServiceClientOption {
+	return func(c *queryParamServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // QueryParamServiceCallOption configures a single RPC call.
 type QueryParamServiceCallOption func(*queryParamServiceCallOptions)
 
@@ -97,6 +252,7 @@ type queryParamServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithQueryParamServiceHeader adds a header to a single request.
@@ -124,6 +280,14 @@ func WithQueryParamServiceCallDiscardUnknownFields(discard bool) QueryParamServi
 	}
 }
 
+// WithQueryParamServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithQueryParamServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) QueryParamServiceCallOption {
+	return func(o *queryParamServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // NewQueryParamServiceClient creates a new QueryParamService client.
 func NewQueryParamServiceClient(baseURL string, opts ...QueryParamServiceClientOption) QueryParamServiceClient {
 	c := &queryParamServiceClient{
@@ -140,16 +304,29 @@ func NewQueryParamServiceClient(baseURL string, opts ...QueryParamServiceClientO
 	return c
 }
 
-// SearchWithTypes calls the SearchWithTypes RPC.
-func (c *queryParamServiceClient) SearchWithTypes(ctx context.Context, req *SearchWithTypesRequest, opts ...QueryParamServiceCallOption) (*SearchResponse, error) {
-	callOpts := &queryParamServiceCallOptions{}
-	for _, opt := range opts {
-		opt(callOpts)
-	}
+// QueryParamServiceRoutes holds the HTTP verb and path template of every QueryParamService method.
+var QueryParamServiceRoutes = struct {
+	SearchWithTypes   sebufhttp.Route
+	SearchRequired    sebufhttp.Route
+	SearchCustomNames sebufhttp.Route
+	GetWithFilters    sebufhttp.Route
+	SearchAdvanced    sebufhttp.Route
+	GetByRegion       sebufhttp.Route
+	GetDefaults       sebufhttp.Route
+}{
+	SearchWithTypes:   sebufhttp.Route{Method: "GET", Path: "/api/search/typed"},
+	SearchRequired:    sebufhttp.Route{Method: "GET", Path: "/api/search/required"},
+	SearchCustomNames: sebufhttp.Route{Method: "GET", Path: "/api/search/custom"},
+	GetWithFilters:    sebufhttp.Route{Method: "GET", Path: "/api/resources/{resource_id}/items"},
+	SearchAdvanced:    sebufhttp.Route{Method: "GET", Path: "/api/search/advanced"},
+	GetByRegion:       sebufhttp.Route{Method: "GET", Path: "/api/regions/{region}"},
+	GetDefaults:       sebufhttp.Route{Method: "GET", Path: "/api/defaults"},
+}
 
-	// Build URL
+// QueryParamServiceSearchWithTypesURL returns the path and query string of a SearchWithTypes call with req,
+// relative to the client's base URL.
+func QueryParamServiceSearchWithTypesURL(req *SearchWithTypesRequest) string {
 	path := "/api/search/typed"
-	reqURL := c.baseURL + path
 
 	// Add query parameters
 	queryParams := url.Values{}
@@ -178,8 +355,144 @@ func (c *queryParamServiceClient) SearchWithTypes(ctx context.Context, req *Sear
 		queryParams.Set("ts", fmt.Sprint(req.Timestamp))
 	}
 	if len(queryParams) > 0 {
-		reqURL += "?" + queryParams.Encode()
+		path += "?" + queryParams.Encode()
 	}
+	return path
+}
+
+// QueryParamServiceSearchRequiredURL returns the path and query string of a SearchRequired call with req,
+// relative to the client's base URL.
+func QueryParamServiceSearchRequiredURL(req *SearchRequiredRequest) string {
+	path := "/api/search/required"
+
+	// Add query parameters
+	queryParams := url.Values{}
+	if req.Query != "" {
+		queryParams.Set("q", fmt.Sprint(req.Query))
+	}
+	if req.Page != 0 {
+		queryParams.Set("page", fmt.Sprint(req.Page))
+	}
+	if req.PageSize != 0 {
+		queryParams.Set("page_size", fmt.Sprint(req.PageSize))
+	}
+	if len(queryParams) > 0 {
+		path += "?" + queryParams.Encode()
+	}
+	return path
+}
+
+// QueryParamServiceSearchCustomNamesURL returns the path and query string of a SearchCustomNames call with req,
+// relative to the client's base URL.
+func QueryParamServiceSearchCustomNamesURL(req *SearchCustomNamesRequest) string {
+	path := "/api/search/custom"
+
+	// Add query parameters
+	queryParams := url.Values{}
+	if req.SearchTerm != "" {
+		queryParams.Set("q", fmt.Sprint(req.SearchTerm))
+	}
+	if req.ResultsPerPage != 0 {
+		queryParams.Set("limit", fmt.Sprint(req.ResultsPerPage))
+	}
+	if req.PageNumber != 0 {
+		queryParams.Set("page", fmt.Sprint(req.PageNumber))
+	}
+	if req.SortField != "" {
+		queryParams.Set("sort", fmt.Sprint(req.SortField))
+	}
+	if req.DescendingOrder != false {
+		queryParams.Set("desc", fmt.Sprint(req.DescendingOrder))
+	}
+	if len(queryParams) > 0 {
+		path += "?" + queryParams.Encode()
+	}
+	return path
+}
+
+// QueryParamServiceGetWithFiltersURL returns the path and query string of a GetWithFilters call with req,
+// relative to the client's base URL.
+func QueryParamServiceGetWithFiltersURL(req *GetWithFiltersRequest) string {
+	path := "/api/resources/{resource_id}/items"
+	path = strings.Replace(path, "{resource_id}", url.PathEscape(fmt.Sprint(req.ResourceId)), 1)
+
+	// Add query parameters
+	queryParams := url.Values{}
+	if req.Filter != "" {
+		queryParams.Set("filter", fmt.Sprint(req.Filter))
+	}
+	if req.Limit != 0 {
+		queryParams.Set("limit", fmt.Sprint(req.Limit))
+	}
+	if len(queryParams) > 0 {
+		path += "?" + queryParams.Encode()
+	}
+	return path
+}
+
+// QueryParamServiceSearchAdvancedURL returns the path and query string of a SearchAdvanced call with req,
+// relative to the client's base URL.
+func QueryParamServiceSearchAdvancedURL(req *SearchAdvancedRequest) string {
+	path := "/api/search/advanced"
+
+	// Add query parameters
+	queryParams := url.Values{}
+	if req.Region != 0 {
+		queryParams.Set("region", enumParamString(req.Region))
+	}
+	for _, v := range req.Countries {
+		queryParams.Add("countries", fmt.Sprint(v))
+	}
+	if req.Keyword != "" {
+		queryParams.Set("keyword", fmt.Sprint(req.Keyword))
+	}
+	for _, v := range req.Years {
+		queryParams.Add("years", fmt.Sprint(v))
+	}
+	for _, v := range req.Flags {
+		queryParams.Add("flags", fmt.Sprint(v))
+	}
+	for _, v := range req.Regions {
+		queryParams.Add("regions", enumParamString(v))
+	}
+	if len(queryParams) > 0 {
+		path += "?" + queryParams.Encode()
+	}
+	return path
+}
+
+// QueryParamServiceGetByRegionURL returns the path and query string of a GetByRegion call with req,
+// relative to the client's base URL.
+func QueryParamServiceGetByRegionURL(req *GetByRegionRequest) string {
+	path := "/api/regions/{region}"
+	path = strings.Replace(path, "{region}", url.PathEscape(enumParamString(req.Region)), 1)
+
+	// Add query parameters
+	queryParams := url.Values{}
+	if req.Keyword != "" {
+		queryParams.Set("keyword", fmt.Sprint(req.Keyword))
+	}
+	if len(queryParams) > 0 {
+		path += "?" + queryParams.Encode()
+	}
+	return path
+}
+
+// QueryParamServiceGetDefaultsURL returns the path and query string of a GetDefaults call with req,
+// relative to the client's base URL.
+func QueryParamServiceGetDefaultsURL(req *EmptyRequest) string {
+	return "/api/defaults"
+}
+
+// SearchWithTypes all scalar types as query params
+func (c *queryParamServiceClient) SearchWithTypes(ctx context.Context, req *SearchWithTypesRequest, opts ...QueryParamServiceCallOption) (*SearchResponse, error) {
+	callOpts := &queryParamServiceCallOptions{}
+	for _, opt := range opts {
+		opt(callOpts)
+	}
+
+	// Build URL
+	reqURL := c.baseURL + QueryParamServiceSearchWithTypesURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -197,15 +510,32 @@ func (c *queryParamServiceClient) SearchWithTypes(ctx context.Context, req *Sear
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "QueryParamService.SearchWithTypes",
+		Response: &SearchResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("QueryParamService.SearchWithTypes", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -216,7 +546,13 @@ func (c *queryParamServiceClient) SearchWithTypes(ctx context.Context, req *Sear
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -234,7 +570,7 @@ func (c *queryParamServiceClient) SearchWithTypes(ctx context.Context, req *Sear
 	return result, nil
 }
 
-// SearchRequired calls the SearchRequired RPC.
+// SearchRequired required vs optional query params
 func (c *queryParamServiceClient) SearchRequired(ctx context.Context, req *SearchRequiredRequest, opts ...QueryParamServiceCallOption) (*SearchResponse, error) {
 	callOpts := &queryParamServiceCallOptions{}
 	for _, opt := range opts {
@@ -242,23 +578,7 @@ func (c *queryParamServiceClient) SearchRequired(ctx context.Context, req *Searc
 	}
 
 	// Build URL
-	path := "/api/search/required"
-	reqURL := c.baseURL + path
-
-	// Add query parameters
-	queryParams := url.Values{}
-	if req.Query != "" {
-		queryParams.Set("q", fmt.Sprint(req.Query))
-	}
-	if req.Page != 0 {
-		queryParams.Set("page", fmt.Sprint(req.Page))
-	}
-	if req.PageSize != 0 {
-		queryParams.Set("page_size", fmt.Sprint(req.PageSize))
-	}
-	if len(queryParams) > 0 {
-		reqURL += "?" + queryParams.Encode()
-	}
+	reqURL := c.baseURL + QueryParamServiceSearchRequiredURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -276,15 +596,32 @@ func (c *queryParamServiceClient) SearchRequired(ctx context.Context, req *Searc
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "QueryParamService.SearchRequired",
+		Response: &SearchResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("QueryParamService.SearchRequired", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -295,7 +632,13 @@ func (c *queryParamServiceClient) SearchRequired(ctx context.Context, req *Searc
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -313,7 +656,7 @@ func (c *queryParamServiceClient) SearchRequired(ctx context.Context, req *Searc
 	return result, nil
 }
 
-// SearchCustomNames calls the SearchCustomNames RPC.
+// SearchCustomNames custom query param names
 func (c *queryParamServiceClient) SearchCustomNames(ctx context.Context, req *SearchCustomNamesRequest, opts ...QueryParamServiceCallOption) (*SearchResponse, error) {
 	callOpts := &queryParamServiceCallOptions{}
 	for _, opt := range opts {
@@ -321,29 +664,7 @@ func (c *queryParamServiceClient) SearchCustomNames(ctx context.Context, req *Se
 	}
 
 	// Build URL
-	path := "/api/search/custom"
-	reqURL := c.baseURL + path
-
-	// Add query parameters
-	queryParams := url.Values{}
-	if req.SearchTerm != "" {
-		queryParams.Set("q", fmt.Sprint(req.SearchTerm))
-	}
-	if req.ResultsPerPage != 0 {
-		queryParams.Set("limit", fmt.Sprint(req.ResultsPerPage))
-	}
-	if req.PageNumber != 0 {
-		queryParams.Set("page", fmt.Sprint(req.PageNumber))
-	}
-	if req.SortField != "" {
-		queryParams.Set("sort", fmt.Sprint(req.SortField))
-	}
-	if req.DescendingOrder != false {
-		queryParams.Set("desc", fmt.Sprint(req.DescendingOrder))
-	}
-	if len(queryParams) > 0 {
-		reqURL += "?" + queryParams.Encode()
-	}
+	reqURL := c.baseURL + QueryParamServiceSearchCustomNamesURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -361,15 +682,32 @@ func (c *queryParamServiceClient) SearchCustomNames(ctx context.Context, req *Se
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "QueryParamService.SearchCustomNames",
+		Response: &SearchResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("QueryParamService.SearchCustomNames", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -380,7 +718,13 @@ func (c *queryParamServiceClient) SearchCustomNames(ctx context.Context, req *Se
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -398,7 +742,7 @@ func (c *queryParamServiceClient) SearchCustomNames(ctx context.Context, req *Se
 	return result, nil
 }
 
-// GetWithFilters calls the GetWithFilters RPC.
+// GetWithFilters mixed path and query params
 func (c *queryParamServiceClient) GetWithFilters(ctx context.Context, req *GetWithFiltersRequest, opts ...QueryParamServiceCallOption) (*SearchResponse, error) {
 	callOpts := &queryParamServiceCallOptions{}
 	for _, opt := range opts {
@@ -406,21 +750,7 @@ func (c *queryParamServiceClient) GetWithFilters(ctx context.Context, req *GetWi
 	}
 
 	// Build URL
-	path := "/api/resources/{resource_id}/items"
-	path = strings.Replace(path, "{resource_id}", url.PathEscape(fmt.Sprint(req.ResourceId)), 1)
-	reqURL := c.baseURL + path
-
-	// Add query parameters
-	queryParams := url.Values{}
-	if req.Filter != "" {
-		queryParams.Set("filter", fmt.Sprint(req.Filter))
-	}
-	if req.Limit != 0 {
-		queryParams.Set("limit", fmt.Sprint(req.Limit))
-	}
-	if len(queryParams) > 0 {
-		reqURL += "?" + queryParams.Encode()
-	}
+	reqURL := c.baseURL + QueryParamServiceGetWithFiltersURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -438,15 +768,32 @@ func (c *queryParamServiceClient) GetWithFilters(ctx context.Context, req *GetWi
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "QueryParamService.GetWithFilters",
+		Response: &SearchResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("QueryParamService.GetWithFilters", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -457,7 +804,13 @@ func (c *queryParamServiceClient) GetWithFilters(ctx context.Context, req *GetWi
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -475,7 +828,7 @@ func (c *queryParamServiceClient) GetWithFilters(ctx context.Context, req *GetWi
 	return result, nil
 }
 
-// SearchAdvanced calls the SearchAdvanced RPC.
+// SearchAdvanced advanced search with enum + repeated params
 func (c *queryParamServiceClient) SearchAdvanced(ctx context.Context, req *SearchAdvancedRequest, opts ...QueryParamServiceCallOption) (*SearchResponse, error) {
 	callOpts := &queryParamServiceCallOptions{}
 	for _, opt := range opts {
@@ -483,32 +836,7 @@ func (c *queryParamServiceClient) SearchAdvanced(ctx context.Context, req *Searc
 	}
 
 	// Build URL
-	path := "/api/search/advanced"
-	reqURL := c.baseURL + path
-
-	// Add query parameters
-	queryParams := url.Values{}
-	if req.Region != 0 {
-		queryParams.Set("region", fmt.Sprint(req.Region))
-	}
-	for _, v := range req.Countries {
-		queryParams.Add("countries", fmt.Sprint(v))
-	}
-	if req.Keyword != "" {
-		queryParams.Set("keyword", fmt.Sprint(req.Keyword))
-	}
-	for _, v := range req.Years {
-		queryParams.Add("years", fmt.Sprint(v))
-	}
-	for _, v := range req.Flags {
-		queryParams.Add("flags", fmt.Sprint(v))
-	}
-	for _, v := range req.Regions {
-		queryParams.Add("regions", fmt.Sprint(v))
-	}
-	if len(queryParams) > 0 {
-		reqURL += "?" + queryParams.Encode()
-	}
+	reqURL := c.baseURL + QueryParamServiceSearchAdvancedURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -526,15 +854,32 @@ func (c *queryParamServiceClient) SearchAdvanced(ctx context.Context, req *Searc
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "QueryParamService.SearchAdvanced",
+		Response: &SearchResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("QueryParamService.SearchAdvanced", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -545,7 +890,13 @@ func (c *queryParamServiceClient) SearchAdvanced(ctx context.Context, req *Searc
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -563,7 +914,7 @@ func (c *queryParamServiceClient) SearchAdvanced(ctx context.Context, req *Searc
 	return result, nil
 }
 
-// GetByRegion calls the GetByRegion RPC.
+// GetByRegion enum as path parameter
 func (c *queryParamServiceClient) GetByRegion(ctx context.Context, req *GetByRegionRequest, opts ...QueryParamServiceCallOption) (*SearchResponse, error) {
 	callOpts := &queryParamServiceCallOptions{}
 	for _, opt := range opts {
@@ -571,18 +922,7 @@ func (c *queryParamServiceClient) GetByRegion(ctx context.Context, req *GetByReg
 	}
 
 	// Build URL
-	path := "/api/regions/{region}"
-	path = strings.Replace(path, "{region}", url.PathEscape(fmt.Sprint(req.Region)), 1)
-	reqURL := c.baseURL + path
-
-	// Add query parameters
-	queryParams := url.Values{}
-	if req.Keyword != "" {
-		queryParams.Set("keyword", fmt.Sprint(req.Keyword))
-	}
-	if len(queryParams) > 0 {
-		reqURL += "?" + queryParams.Encode()
-	}
+	reqURL := c.baseURL + QueryParamServiceGetByRegionURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -600,15 +940,32 @@ func (c *queryParamServiceClient) GetByRegion(ctx context.Context, req *GetByReg
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "QueryParamService.GetByRegion",
+		Response: &SearchResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("QueryParamService.GetByRegion", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -619,7 +976,13 @@ func (c *queryParamServiceClient) GetByRegion(ctx context.Context, req *GetByReg
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -637,7 +1000,7 @@ func (c *queryParamServiceClient) GetByRegion(ctx context.Context, req *GetByReg
 	return result, nil
 }
 
-// GetDefaults calls the GetDefaults RPC.
+// GetDefaults RPC with empty request message
 func (c *queryParamServiceClient) GetDefaults(ctx context.Context, req *EmptyRequest, opts ...QueryParamServiceCallOption) (*SearchResponse, error) {
 	callOpts := &queryParamServiceCallOptions{}
 	for _, opt := range opts {
@@ -645,8 +1008,7 @@ func (c *queryParamServiceClient) GetDefaults(ctx context.Context, req *EmptyReq
 	}
 
 	// Build URL
-	path := "/api/defaults"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + QueryParamServiceGetDefaultsURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -664,15 +1026,32 @@ func (c *queryParamServiceClient) GetDefaults(ctx context.Context, req *EmptyReq
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "QueryParamService.GetDefaults",
+		Response: &SearchResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("QueryParamService.GetDefaults", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -683,7 +1062,13 @@ func (c *queryParamServiceClient) GetDefaults(ctx context.Context, req *EmptyReq
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -716,7 +1101,14 @@ func (c *queryParamServiceClient) marshalRequest(req proto.Message, contentType
 	}
 }
 
-func (c *queryParamServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *queryParamServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
@@ -0,0 +1,626 @@
+// Code generated by protoc-gen-go-client. DO NOT EDIT.
+// source: raw_body.proto
+
+package generated
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+)
+
+const (
+	// ContentTypeJSON is the content type for JSON requests/responses.
+	ContentTypeJSON = sebufhttp.JSONContentType
+	// ContentTypeProto is the content type for binary protobuf requests/responses.
+	ContentTypeProto = sebufhttp.ProtoContentType
+)
+
+// sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
+// It allows passing protojson.UnmarshalOptions (e.g. DiscardUnknown) through custom unmarshalers.
+type sebufUnmarshaler interface {
+	UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error
+}
+
+// FileServiceClient is the client API for FileService service.
+type FileServiceClient interface {
+	// DownloadFile answers with the file itself, under its own Content-Type
+	DownloadFile(ctx context.Context, req *DownloadFileRequest, opts ...FileServiceCallOption) (*FileContent, error)
+	// ExportFiles answers with an archive, as application/octet-stream
+	ExportFiles(ctx context.Context, req *ExportFilesRequest, opts ...FileServiceCallOption) (*FileArchive, error)
+	// GetFileInfo keeps an encoded response
+	GetFileInfo(ctx context.Context, req *DownloadFileRequest, opts ...FileServiceCallOption) (*FileInfo, error)
+}
+
+// fileServiceClient is the implementation of FileServiceClient.
+type fileServiceClient struct {
+	baseURL              string
+	httpClient           *http.Client
+	contentType          string
+	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
+	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
+}
+
+var _ FileServiceClient = (*fileServiceClient)(nil)
+
+// FileServiceClientOption configures a FileService client.
+type FileServiceClientOption func(*fileServiceClient)
+
+// WithFileServiceHTTPClient sets the HTTP client to use for requests.
+func WithFileServiceHTTPClient(client *http.Client) FileServiceClientOption {
+	return func(c *fileServiceClient) {
+		c.httpClient = client
+	}
+}
+
+// WithFileServiceContentType sets the default content type for requests.
+// Use ContentTypeJSON or ContentTypeProto.
+func WithFileServiceContentType(contentType string) FileServiceClientOption {
+	return func(c *fileServiceClient) {
+		c.contentType = contentType
+	}
+}
+
+// WithFileServiceDefaultHeader sets a default header to include in all requests.
+func WithFileServiceDefaultHeader(key, value string) FileServiceClientOption {
+	return func(c *fileServiceClient) {
+		if c.defaultHeaders == nil {
+			c.defaultHeaders = make(map[string]string)
+		}
+		c.defaultHeaders[key] = value
+	}
+}
+
+// WithFileServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithFileServiceHeaderPropagation(allowlist ...string) FileServiceClientOption {
+	return func(c *fileServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithFileServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithFileServiceDeadlinePropagation(headerName string) FileServiceClientOption {
+	return func(c *fileServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
+// WithFileServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
+// When true, unknown fields are silently ignored instead of causing unmarshal errors.
+func WithFileServiceDiscardUnknownFields(discard bool) FileServiceClientOption {
+	return func(c *fileServiceClient) {
+		c.discardUnknownFields = discard
+	}
+}
+
+// WithFileServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithFileServiceHedging(delay time.Duration, maxHedges int) FileServiceClientOption {
+	return func(c *fileServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithFileServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithFileServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) FileServiceClientOption {
+	return func(c *fileServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithFileServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithFileServiceRecorder(recorder sebufhttp.CallRecorder) FileServiceClientOption {
+	return func(c *fileServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithFileServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithFileServiceDebugLogging(w io.Writer) FileServiceClientOption {
+	return func(c *fileServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithFileServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithFileServiceDebugBodyLimit(limit int) FileServiceClientOption {
+	return func(c *fileServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithFileServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithFileServiceLogHook(hook func(sebufhttp.ClientLogEvent)) FileServiceClientOption {
+	return func(c *fileServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithFileServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithFileServiceShadowMutations is set.
+func WithFileServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) FileServiceClientOption {
+	return func(c *fileServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithFileServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithFileServiceShadowMutations() FileServiceClientOption {
+	return func(c *fileServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithFileServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithFileServiceShadowTimeout(timeout time.Duration) FileServiceClientOption {
+	return func(c *fileServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
+// FileServiceCallOption configures a single RPC call.
+type FileServiceCallOption func(*fileServiceCallOptions)
+
+// fileServiceCallOptions holds options for a single RPC call.
+type fileServiceCallOptions struct {
+	headers              map[string]string
+	contentType          string
+	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
+}
+
+// WithFileServiceHeader adds a header to a single request.
+func WithFileServiceHeader(key, value string) FileServiceCallOption {
+	return func(o *fileServiceCallOptions) {
+		if o.headers == nil {
+			o.headers = make(map[string]string)
+		}
+		o.headers[key] = value
+	}
+}
+
+// WithFileServiceCallContentType sets the content type for a single request.
+func WithFileServiceCallContentType(contentType string) FileServiceCallOption {
+	return func(o *fileServiceCallOptions) {
+		o.contentType = contentType
+	}
+}
+
+// WithFileServiceCallDiscardUnknownFields sets whether to discard unknown fields for a single request.
+// Overrides the client-level setting from WithFileServiceDiscardUnknownFields.
+func WithFileServiceCallDiscardUnknownFields(discard bool) FileServiceCallOption {
+	return func(o *fileServiceCallOptions) {
+		o.discardUnknownFields = &discard
+	}
+}
+
+// WithFileServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithFileServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) FileServiceCallOption {
+	return func(o *fileServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
+// NewFileServiceClient creates a new FileService client.
+func NewFileServiceClient(baseURL string, opts ...FileServiceClientOption) FileServiceClient {
+	c := &fileServiceClient{
+		baseURL:        strings.TrimSuffix(baseURL, "/"),
+		httpClient:     http.DefaultClient,
+		contentType:    ContentTypeJSON,
+		defaultHeaders: make(map[string]string),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// FileServiceRoutes holds the HTTP verb and path template of every FileService method.
+var FileServiceRoutes = struct {
+	DownloadFile sebufhttp.Route
+	ExportFiles  sebufhttp.Route
+	GetFileInfo  sebufhttp.Route
+}{
+	DownloadFile: sebufhttp.Route{Method: "GET", Path: "/api/v1/files/{file_id}/content"},
+	ExportFiles:  sebufhttp.Route{Method: "POST", Path: "/api/v1/files/export"},
+	GetFileInfo:  sebufhttp.Route{Method: "GET", Path: "/api/v1/files/{file_id}"},
+}
+
+// FileServiceDownloadFileURL returns the path and query string of a DownloadFile call with req,
+// relative to the client's base URL.
+func FileServiceDownloadFileURL(req *DownloadFileRequest) string {
+	path := "/api/v1/files/{file_id}/content"
+	path = strings.Replace(path, "{file_id}", url.PathEscape(fmt.Sprint(req.FileId)), 1)
+	return path
+}
+
+// FileServiceExportFilesURL returns the path and query string of a ExportFiles call with req,
+// relative to the client's base URL.
+func FileServiceExportFilesURL(req *ExportFilesRequest) string {
+	return "/api/v1/files/export"
+}
+
+// FileServiceGetFileInfoURL returns the path and query string of a GetFileInfo call with req,
+// relative to the client's base URL.
+func FileServiceGetFileInfoURL(req *DownloadFileRequest) string {
+	path := "/api/v1/files/{file_id}"
+	path = strings.Replace(path, "{file_id}", url.PathEscape(fmt.Sprint(req.FileId)), 1)
+	return path
+}
+
+// DownloadFile answers with the file itself, under its own Content-Type
+func (c *fileServiceClient) DownloadFile(ctx context.Context, req *DownloadFileRequest, opts ...FileServiceCallOption) (*FileContent, error) {
+	callOpts := &fileServiceCallOptions{}
+	for _, opt := range opts {
+		opt(callOpts)
+	}
+
+	// Build URL
+	reqURL := c.baseURL + FileServiceDownloadFileURL(req)
+
+	contentType := c.contentType
+	if callOpts.contentType != "" {
+		contentType = callOpts.contentType
+	}
+
+	// Create HTTP request
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	httpReq.Header.Set("Content-Type", contentType)
+	for k, v := range c.defaultHeaders {
+		httpReq.Header.Set(k, v)
+	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
+	for k, v := range callOpts.headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	// Execute request
+	call := sebufhttp.ClientCall{
+		Method: "FileService.DownloadFile",
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("FileService.DownloadFile", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Check for error status codes
+	if resp.StatusCode >= 400 {
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, false)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
+	}
+
+	// The response body is the raw data of the response
+	return &FileContent{
+		Data:        respBody,
+		ContentType: resp.Header.Get("Content-Type"),
+	}, nil
+}
+
+// ExportFiles answers with an archive, as application/octet-stream
+func (c *fileServiceClient) ExportFiles(ctx context.Context, req *ExportFilesRequest, opts ...FileServiceCallOption) (*FileArchive, error) {
+	callOpts := &fileServiceCallOptions{}
+	for _, opt := range opts {
+		opt(callOpts)
+	}
+
+	// Build URL
+	reqURL := c.baseURL + FileServiceExportFilesURL(req)
+
+	contentType := c.contentType
+	if callOpts.contentType != "" {
+		contentType = callOpts.contentType
+	}
+
+	// Marshal request body
+	body, err := c.marshalRequest(req, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Create HTTP request
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", reqURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	httpReq.Header.Set("Content-Type", contentType)
+	for k, v := range c.defaultHeaders {
+		httpReq.Header.Set(k, v)
+	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
+	for k, v := range callOpts.headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	// Execute request
+	call := sebufhttp.ClientCall{
+		Method: "FileService.ExportFiles",
+		Body:   req,
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("FileService.ExportFiles", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Check for error status codes
+	if resp.StatusCode >= 400 {
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, false)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
+	}
+
+	// The response body is the raw archive of the response
+	return &FileArchive{
+		Archive: respBody,
+	}, nil
+}
+
+// GetFileInfo keeps an encoded response
+func (c *fileServiceClient) GetFileInfo(ctx context.Context, req *DownloadFileRequest, opts ...FileServiceCallOption) (*FileInfo, error) {
+	callOpts := &fileServiceCallOptions{}
+	for _, opt := range opts {
+		opt(callOpts)
+	}
+
+	// Build URL
+	reqURL := c.baseURL + FileServiceGetFileInfoURL(req)
+
+	contentType := c.contentType
+	if callOpts.contentType != "" {
+		contentType = callOpts.contentType
+	}
+
+	// Create HTTP request
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	httpReq.Header.Set("Content-Type", contentType)
+	for k, v := range c.defaultHeaders {
+		httpReq.Header.Set(k, v)
+	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
+	for k, v := range callOpts.headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	// Execute request
+	call := sebufhttp.ClientCall{
+		Method:   "FileService.GetFileInfo",
+		Response: &FileInfo{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("FileService.GetFileInfo", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Check for error status codes
+	if resp.StatusCode >= 400 {
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
+	}
+
+	// Resolve discardUnknownFields: per-call option overrides client default
+	discardUnknown := c.discardUnknownFields
+	if callOpts.discardUnknownFields != nil {
+		discardUnknown = *callOpts.discardUnknownFields
+	}
+
+	// Unmarshal response
+	result := &FileInfo{}
+	if err := c.unmarshalResponse(respBody, result, contentType, discardUnknown); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *fileServiceClient) marshalRequest(req proto.Message, contentType string) ([]byte, error) {
+	switch contentType {
+	case ContentTypeJSON:
+		// Check for custom JSON marshaler (unwrap support)
+		if marshaler, ok := req.(json.Marshaler); ok {
+			return marshaler.MarshalJSON()
+		}
+		return protojson.Marshal(req)
+	case ContentTypeProto:
+		return proto.Marshal(req)
+	default:
+		return protojson.Marshal(req)
+	}
+}
+
+func (c *fileServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
+	// Try to parse as ValidationError first (for 400 errors)
+	// Always use strict mode (false) for error parsing to avoid loose JSON
+	// falsely matching ValidationError or Error types.
+	if statusCode == http.StatusBadRequest {
+		validationErr := &sebufhttp.ValidationError{}
+		if unmarshalErr := c.unmarshalResponse(body, validationErr, contentType, false); unmarshalErr == nil {
+			return validationErr
+		}
+	}
+
+	// Try to parse as generic Error
+	genericErr := &sebufhttp.Error{}
+	if unmarshalErr := c.unmarshalResponse(body, genericErr, contentType, false); unmarshalErr == nil {
+		return genericErr
+	}
+
+	// Fallback to raw error message
+	return fmt.Errorf("request failed with status %d: %s", statusCode, string(body))
+}
+
+func (c *fileServiceClient) unmarshalResponse(body []byte, msg proto.Message, contentType string, discardUnknown bool) error {
+	if len(body) == 0 {
+		return nil
+	}
+
+	opts := protojson.UnmarshalOptions{DiscardUnknown: discardUnknown}
+
+	switch contentType {
+	case ContentTypeJSON:
+		// Check for sebuf-generated custom unmarshaler (passes options through)
+		if u, ok := msg.(sebufUnmarshaler); ok {
+			return u.UnmarshalJSONSebuf(body, opts)
+		}
+		// Check for third-party json.Unmarshaler (best effort, cannot pass options)
+		if u, ok := msg.(json.Unmarshaler); ok {
+			return u.UnmarshalJSON(body)
+		}
+		return opts.Unmarshal(body, msg)
+	case ContentTypeProto:
+		return proto.Unmarshal(body, msg)
+	default:
+		return opts.Unmarshal(body, msg)
+	}
+}
@@ -0,0 +1,599 @@
+// Code generated by protoc-gen-go-client. DO NOT EDIT.
+// source: response_statuses.proto
+
+package generated
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+)
+
+const (
+	// ContentTypeJSON is the content type for JSON requests/responses.
+	ContentTypeJSON = sebufhttp.JSONContentType
+	// ContentTypeProto is the content type for binary protobuf requests/responses.
+	ContentTypeProto = sebufhttp.ProtoContentType
+)
+
+// sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
+// It allows passing protojson.UnmarshalOptions (e.g. DiscardUnknown) through custom unmarshalers.
+type sebufUnmarshaler interface {
+	UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error
+}
+
+// CreateOrderResultResponse is the response of a method returning the result message CreateOrderResult:
+// the variant the server answered with, selected by the HTTP status.
+type CreateOrderResultResponse struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Result holds the variant sent as the response body.
+	Result *CreateOrderResult
+}
+
+// GetOrder returns the order variant, answered with status 201,
+// or nil if the server answered with another variant.
+func (r *CreateOrderResultResponse) GetOrder() *Order {
+	if r == nil {
+		return nil
+	}
+	return r.Result.GetOrder()
+}
+
+// GetConflict returns the conflict variant, answered with status 409,
+// or nil if the server answered with another variant.
+func (r *CreateOrderResultResponse) GetConflict() *Conflict {
+	if r == nil {
+		return nil
+	}
+	return r.Result.GetConflict()
+}
+
+// GetRejection returns the rejection variant, answered with status 422,
+// or nil if the server answered with another variant.
+func (r *CreateOrderResultResponse) GetRejection() *Rejection {
+	if r == nil {
+		return nil
+	}
+	return r.Result.GetRejection()
+}
+
+// CheckoutServiceClient is the client API for CheckoutService service.
+type CheckoutServiceClient interface {
+	// GetOrder standard unary RPC (should be unaffected)
+	GetOrder(ctx context.Context, req *GetOrderRequest, opts ...CheckoutServiceCallOption) (*Order, error)
+	// CreateOrder creates an order, or reports the order it conflicts with
+	CreateOrder(ctx context.Context, req *CreateOrderRequest, opts ...CheckoutServiceCallOption) (*CreateOrderResultResponse, error)
+}
+
+// checkoutServiceClient is the implementation of CheckoutServiceClient.
+type checkoutServiceClient struct {
+	baseURL              string
+	httpClient           *http.Client
+	contentType          string
+	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
+	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
+}
+
+var _ CheckoutServiceClient = (*checkoutServiceClient)(nil)
+
+// CheckoutServiceClientOption configures a CheckoutService client.
+type CheckoutServiceClientOption func(*checkoutServiceClient)
+
+// WithCheckoutServiceHTTPClient sets the HTTP client to use for requests.
+func WithCheckoutServiceHTTPClient(client *http.Client) CheckoutServiceClientOption {
+	return func(c *checkoutServiceClient) {
+		c.httpClient = client
+	}
+}
+
+// WithCheckoutServiceContentType sets the default content type for requests.
+// Use ContentTypeJSON or ContentTypeProto.
+func WithCheckoutServiceContentType(contentType string) CheckoutServiceClientOption {
+	return func(c *checkoutServiceClient) {
+		c.contentType = contentType
+	}
+}
+
+// WithCheckoutServiceDefaultHeader sets a default header to include in all requests.
+func WithCheckoutServiceDefaultHeader(key, value string) CheckoutServiceClientOption {
+	return func(c *checkoutServiceClient) {
+		if c.defaultHeaders == nil {
+			c.defaultHeaders = make(map[string]string)
+		}
+		c.defaultHeaders[key] = value
+	}
+}
+
+// WithCheckoutServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithCheckoutServiceHeaderPropagation(allowlist ...string) CheckoutServiceClientOption {
+	return func(c *checkoutServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithCheckoutServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithCheckoutServiceDeadlinePropagation(headerName string) CheckoutServiceClientOption {
+	return func(c *checkoutServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
+// WithCheckoutServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
+// When true, unknown fields are silently ignored instead of causing unmarshal errors.
+func WithCheckoutServiceDiscardUnknownFields(discard bool) CheckoutServiceClientOption {
+	return func(c *checkoutServiceClient) {
+		c.discardUnknownFields = discard
+	}
+}
+
+// WithCheckoutServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithCheckoutServiceHedging(delay time.Duration, maxHedges int) CheckoutServiceClientOption {
+	return func(c *checkoutServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithCheckoutServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithCheckoutServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) CheckoutServiceClientOption {
+	return func(c *checkoutServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithCheckoutServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithCheckoutServiceRecorder(recorder sebufhttp.CallRecorder) CheckoutServiceClientOption {
+	return func(c *checkoutServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithCheckoutServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithCheckoutServiceDebugLogging(w io.Writer) CheckoutServiceClientOption {
+	return func(c *checkoutServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithCheckoutServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithCheckoutServiceDebugBodyLimit(limit int) CheckoutServiceClientOption {
+	return func(c *checkoutServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithCheckoutServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithCheckoutServiceLogHook(hook func(sebufhttp.ClientLogEvent)) CheckoutServiceClientOption {
+	return func(c *checkoutServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithCheckoutServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithCheckoutServiceShadowMutations is set.
+func WithCheckoutServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) CheckoutServiceClientOption {
+	return func(c *checkoutServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithCheckoutServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithCheckoutServiceShadowMutations() CheckoutServiceClientOption {
+	return func(c *checkoutServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithCheckoutServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithCheckoutServiceShadowTimeout(timeout time.Duration) CheckoutServiceClientOption {
+	return func(c *checkoutServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
+// CheckoutServiceCallOption configures a single RPC call.
+type CheckoutServiceCallOption func(*checkoutServiceCallOptions)
+
+// checkoutServiceCallOptions holds options for a single RPC call.
+type checkoutServiceCallOptions struct {
+	headers              map[string]string
+	contentType          string
+	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
+}
+
+// WithCheckoutServiceHeader adds a header to a single request.
+func WithCheckoutServiceHeader(key, value string) CheckoutServiceCallOption {
+	return func(o *checkoutServiceCallOptions) {
+		if o.headers == nil {
+			o.headers = make(map[string]string)
+		}
+		o.headers[key] = value
+	}
+}
+
+// WithCheckoutServiceCallContentType sets the content type for a single request.
+func WithCheckoutServiceCallContentType(contentType string) CheckoutServiceCallOption {
+	return func(o *checkoutServiceCallOptions) {
+		o.contentType = contentType
+	}
+}
+
+// WithCheckoutServiceCallDiscardUnknownFields sets whether to discard unknown fields for a single request.
+// Overrides the client-level setting from WithCheckoutServiceDiscardUnknownFields.
+func WithCheckoutServiceCallDiscardUnknownFields(discard bool) CheckoutServiceCallOption {
+	return func(o *checkoutServiceCallOptions) {
+		o.discardUnknownFields = &discard
+	}
+}
+
+// WithCheckoutServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithCheckoutServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) CheckoutServiceCallOption {
+	return func(o *checkoutServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
+// NewCheckoutServiceClient creates a new CheckoutService client.
+func NewCheckoutServiceClient(baseURL string, opts ...CheckoutServiceClientOption) CheckoutServiceClient {
+	c := &checkoutServiceClient{
+		baseURL:        strings.TrimSuffix(baseURL, "/"),
+		httpClient:     http.DefaultClient,
+		contentType:    ContentTypeJSON,
+		defaultHeaders: make(map[string]string),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// CheckoutServiceRoutes holds the HTTP verb and path template of every CheckoutService method.
+var CheckoutServiceRoutes = struct {
+	GetOrder    sebufhttp.Route
+	CreateOrder sebufhttp.Route
+}{
+	GetOrder:    sebufhttp.Route{Method: "GET", Path: "/api/v1/orders/{id}"},
+	CreateOrder: sebufhttp.Route{Method: "POST", Path: "/api/v1/orders"},
+}
+
+// CheckoutServiceGetOrderURL returns the path and query string of a GetOrder call with req,
+// relative to the client's base URL.
+func CheckoutServiceGetOrderURL(req *GetOrderRequest) string {
+	path := "/api/v1/orders/{id}"
+	path = strings.Replace(path, "{id}", url.PathEscape(fmt.Sprint(req.Id)), 1)
+	return path
+}
+
+// CheckoutServiceCreateOrderURL returns the path and query string of a CreateOrder call with req,
+// relative to the client's base URL.
+func CheckoutServiceCreateOrderURL(req *CreateOrderRequest) string {
+	return "/api/v1/orders"
+}
+
+// GetOrder standard unary RPC (should be unaffected)
+func (c *checkoutServiceClient) GetOrder(ctx context.Context, req *GetOrderRequest, opts ...CheckoutServiceCallOption) (*Order, error) {
+	callOpts := &checkoutServiceCallOptions{}
+	for _, opt := range opts {
+		opt(callOpts)
+	}
+
+	// Build URL
+	reqURL := c.baseURL + CheckoutServiceGetOrderURL(req)
+
+	contentType := c.contentType
+	if callOpts.contentType != "" {
+		contentType = callOpts.contentType
+	}
+
+	// Create HTTP request
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	httpReq.Header.Set("Content-Type", contentType)
+	for k, v := range c.defaultHeaders {
+		httpReq.Header.Set(k, v)
+	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
+	for k, v := range callOpts.headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	// Execute request
+	call := sebufhttp.ClientCall{
+		Method:   "CheckoutService.GetOrder",
+		Response: &Order{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("CheckoutService.GetOrder", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Check for error status codes
+	if resp.StatusCode >= 400 {
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
+	}
+
+	// Resolve discardUnknownFields: per-call option overrides client default
+	discardUnknown := c.discardUnknownFields
+	if callOpts.discardUnknownFields != nil {
+		discardUnknown = *callOpts.discardUnknownFields
+	}
+
+	// Unmarshal response
+	result := &Order{}
+	if err := c.unmarshalResponse(respBody, result, contentType, discardUnknown); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return result, nil
+}
+
+// CreateOrder creates an order, or reports the order it conflicts with
+func (c *checkoutServiceClient) CreateOrder(ctx context.Context, req *CreateOrderRequest, opts ...CheckoutServiceCallOption) (*CreateOrderResultResponse, error) {
+	callOpts := &checkoutServiceCallOptions{}
+	for _, opt := range opts {
+		opt(callOpts)
+	}
+
+	// Build URL
+	reqURL := c.baseURL + CheckoutServiceCreateOrderURL(req)
+
+	contentType := c.contentType
+	if callOpts.contentType != "" {
+		contentType = callOpts.contentType
+	}
+
+	// Marshal request body
+	body, err := c.marshalRequest(req, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Create HTTP request
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", reqURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	httpReq.Header.Set("Content-Type", contentType)
+	for k, v := range c.defaultHeaders {
+		httpReq.Header.Set(k, v)
+	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
+	for k, v := range callOpts.headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	// Execute request
+	call := sebufhttp.ClientCall{
+		Method: "CheckoutService.CreateOrder",
+		Body:   req,
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("CheckoutService.CreateOrder", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
+	}
+
+	// Resolve discardUnknownFields: per-call option overrides client default
+	discardUnknown := c.discardUnknownFields
+	if callOpts.discardUnknownFields != nil {
+		discardUnknown = *callOpts.discardUnknownFields
+	}
+
+	// Decode the variant the status selects
+	result := &CreateOrderResultResponse{StatusCode: resp.StatusCode, Result: &CreateOrderResult{}}
+	switch resp.StatusCode {
+	case 201:
+		variant := &Order{}
+		if err := c.unmarshalResponse(respBody, variant, contentType, discardUnknown); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		result.Result.Result = &CreateOrderResult_Order{Order: variant}
+	case 409:
+		variant := &Conflict{}
+		if err := c.unmarshalResponse(respBody, variant, contentType, discardUnknown); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		result.Result.Result = &CreateOrderResult_Conflict{Conflict: variant}
+	case 422:
+		variant := &Rejection{}
+		if err := c.unmarshalResponse(respBody, variant, contentType, discardUnknown); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		result.Result.Result = &CreateOrderResult_Rejection{Rejection: variant}
+	default:
+		if resp.StatusCode >= 400 {
+			return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+		}
+		return nil, fmt.Errorf("unexpected response status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return result, nil
+}
+
+func (c *checkoutServiceClient) marshalRequest(req proto.Message, contentType string) ([]byte, error) {
+	switch contentType {
+	case ContentTypeJSON:
+		// Check for custom JSON marshaler (unwrap support)
+		if marshaler, ok := req.(json.Marshaler); ok {
+			return marshaler.MarshalJSON()
+		}
+		return protojson.Marshal(req)
+	case ContentTypeProto:
+		return proto.Marshal(req)
+	default:
+		return protojson.Marshal(req)
+	}
+}
+
+func (c *checkoutServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
+	// Try to parse as ValidationError first (for 400 errors)
+	// Always use strict mode (false) for error parsing to avoid loose JSON
+	// falsely matching ValidationError or Error types.
+	if statusCode == http.StatusBadRequest {
+		validationErr := &sebufhttp.ValidationError{}
+		if unmarshalErr := c.unmarshalResponse(body, validationErr, contentType, false); unmarshalErr == nil {
+			return validationErr
+		}
+	}
+
+	// Try to parse as generic Error
+	genericErr := &sebufhttp.Error{}
+	if unmarshalErr := c.unmarshalResponse(body, genericErr, contentType, false); unmarshalErr == nil {
+		return genericErr
+	}
+
+	// Fallback to raw error message
+	return fmt.Errorf("request failed with status %d: %s", statusCode, string(body))
+}
+
+func (c *checkoutServiceClient) unmarshalResponse(body []byte, msg proto.Message, contentType string, discardUnknown bool) error {
+	if len(body) == 0 {
+		return nil
+	}
+
+	opts := protojson.UnmarshalOptions{DiscardUnknown: discardUnknown}
+
+	switch contentType {
+	case ContentTypeJSON:
+		// Check for sebuf-generated custom unmarshaler (passes options through)
+		if u, ok := msg.(sebufUnmarshaler); ok {
+			return u.UnmarshalJSONSebuf(body, opts)
+		}
+		// Check for third-party json.Unmarshaler (best effort, cannot pass options)
+		if u, ok := msg.(json.Unmarshaler); ok {
+			return u.UnmarshalJSON(body)
+		}
+		return opts.Unmarshal(body, msg)
+	case ContentTypeProto:
+		return proto.Unmarshal(body, msg)
+	default:
+		return opts.Unmarshal(body, msg)
+	}
+}
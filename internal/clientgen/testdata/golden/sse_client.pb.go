@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -21,9 +22,9 @@ import (
 
 const (
 	// ContentTypeJSON is the content type for JSON requests/responses.
-	ContentTypeJSON = "application/json"
+	ContentTypeJSON = sebufhttp.JSONContentType
 	// ContentTypeProto is the content type for binary protobuf requests/responses.
-	ContentTypeProto = "application/x-protobuf"
+	ContentTypeProto = sebufhttp.ProtoContentType
 )
 
 // sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
@@ -34,9 +35,13 @@ type sebufUnmarshaler interface {
 
 // SSEServiceClient is the client API for SSEService service.
 type SSEServiceClient interface {
+	// GetStatus standard unary RPC (should be unaffected)
 	GetStatus(ctx context.Context, req *GetStatusRequest, opts ...SSEServiceCallOption) (*StatusResponse, error)
+	// StreamEvents SSE streaming RPC
 	StreamEvents(ctx context.Context, req *StreamEventsRequest, opts ...SSEServiceCallOption) (*SSEServiceEventStream[*Event], error)
+	// StreamResourceEvents SSE with path params
 	StreamResourceEvents(ctx context.Context, req *StreamResourceEventsRequest, opts ...SSEServiceCallOption) (*SSEServiceEventStream[*ResourceEvent], error)
+	// StreamFilteredEvents SSE with query params
 	StreamFilteredEvents(ctx context.Context, req *StreamFilteredEventsRequest, opts ...SSEServiceCallOption) (*SSEServiceEventStream[*Event], error)
 }
 
@@ -46,7 +51,15 @@ type sSEServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ SSEServiceClient = (*sSEServiceClient)(nil)
@@ -79,6 +92,27 @@ func WithSSEServiceDefaultHeader(key, value string) SSEServiceClientOption {
 	}
 }
 
+// WithSSEServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithSSEServiceHeaderPropagation(allowlist ...string) SSEServiceClientOption {
+	return func(c *sSEServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithSSEServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithSSEServiceDeadlinePropagation(headerName string) SSEServiceClientOption {
+	return func(c *sSEServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithSSEServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithSSEServiceDiscardUnknownFields(discard bool) SSEServiceClientOption {
@@ -87,6 +121,105 @@ func WithSSEServiceDiscardUnknownFields(discard bool) SSEServiceClientOption {
 	}
 }
 
+// WithSSEServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithSSEServiceHedging(delay time.Duration, maxHedges int) SSEServiceClientOption {
+	return func(c *sSEServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithSSEServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithSSEServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) SSEServiceClientOption {
+	return func(c *sSEServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithSSEServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithSSEServiceRecorder(recorder sebufhttp.CallRecorder) SSEServiceClientOption {
+	return func(c *sSEServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithSSEServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithSSEServiceDebugLogging(w io.Writer) SSEServiceClientOption {
+	return func(c *sSEServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithSSEServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithSSEServiceDebugBodyLimit(limit int) SSEServiceClientOption {
+	return func(c *sSEServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithSSEServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithSSEServiceLogHook(hook func(sebufhttp.ClientLogEvent)) SSEServiceClientOption {
+	return func(c *sSEServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithSSEServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithSSEServiceShadowMutations is set.
+func WithSSEServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) SSEServiceClientOption {
+	return func(c *sSEServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithSSEServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithSSEServiceShadowMutations() SSEServiceClientOption {
+	return func(c *sSEServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithSSEServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithSSEServiceShadowTimeout(timeout time.Duration) SSEServiceClientOption {
+	return func(c *sSEServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // SSEServiceCallOption configures a single RPC call.
 type SSEServiceCallOption func(*sSEServiceCallOptions)
 
@@ -95,6 +228,7 @@ type sSEServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithSSEServiceHeader adds a header to a single request.
@@ -122,6 +256,14 @@ func WithSSEServiceCallDiscardUnknownFields(discard bool) SSEServiceCallOption {
 	}
 }
 
+// WithSSEServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithSSEServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) SSEServiceCallOption {
+	return func(o *sSEServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // NewSSEServiceClient creates a new SSEService client.
 func NewSSEServiceClient(baseURL string, opts ...SSEServiceClientOption) SSEServiceClient {
 	c := &sSEServiceClient{
@@ -189,7 +331,59 @@ func (s *SSEServiceEventStream[T]) Close() error {
 	return s.resp.Body.Close()
 }
 
-// GetStatus calls the GetStatus RPC.
+// SSEServiceRoutes holds the HTTP verb and path template of every SSEService method.
+var SSEServiceRoutes = struct {
+	GetStatus            sebufhttp.Route
+	StreamEvents         sebufhttp.Route
+	StreamResourceEvents sebufhttp.Route
+	StreamFilteredEvents sebufhttp.Route
+}{
+	GetStatus:            sebufhttp.Route{Method: "GET", Path: "/api/v1/status"},
+	StreamEvents:         sebufhttp.Route{Method: "GET", Path: "/api/v1/events"},
+	StreamResourceEvents: sebufhttp.Route{Method: "GET", Path: "/api/v1/resources/{resource_id}/events"},
+	StreamFilteredEvents: sebufhttp.Route{Method: "GET", Path: "/api/v1/events/filtered"},
+}
+
+// SSEServiceGetStatusURL returns the path and query string of a GetStatus call with req,
+// relative to the client's base URL.
+func SSEServiceGetStatusURL(req *GetStatusRequest) string {
+	return "/api/v1/status"
+}
+
+// SSEServiceStreamEventsURL returns the path and query string of a StreamEvents call with req,
+// relative to the client's base URL.
+func SSEServiceStreamEventsURL(req *StreamEventsRequest) string {
+	return "/api/v1/events"
+}
+
+// SSEServiceStreamResourceEventsURL returns the path and query string of a StreamResourceEvents call with req,
+// relative to the client's base URL.
+func SSEServiceStreamResourceEventsURL(req *StreamResourceEventsRequest) string {
+	path := "/api/v1/resources/{resource_id}/events"
+	path = strings.Replace(path, "{resource_id}", url.PathEscape(fmt.Sprint(req.ResourceId)), 1)
+	return path
+}
+
+// SSEServiceStreamFilteredEventsURL returns the path and query string of a StreamFilteredEvents call with req,
+// relative to the client's base URL.
+func SSEServiceStreamFilteredEventsURL(req *StreamFilteredEventsRequest) string {
+	path := "/api/v1/events/filtered"
+
+	// Add query parameters
+	queryParams := url.Values{}
+	if req.EventType != "" {
+		queryParams.Set("type", fmt.Sprint(req.EventType))
+	}
+	if req.Limit != 0 {
+		queryParams.Set("limit", fmt.Sprint(req.Limit))
+	}
+	if len(queryParams) > 0 {
+		path += "?" + queryParams.Encode()
+	}
+	return path
+}
+
+// GetStatus standard unary RPC (should be unaffected)
 func (c *sSEServiceClient) GetStatus(ctx context.Context, req *GetStatusRequest, opts ...SSEServiceCallOption) (*StatusResponse, error) {
 	callOpts := &sSEServiceCallOptions{}
 	for _, opt := range opts {
@@ -197,8 +391,7 @@ func (c *sSEServiceClient) GetStatus(ctx context.Context, req *GetStatusRequest,
 	}
 
 	// Build URL
-	path := "/api/v1/status"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + SSEServiceGetStatusURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -216,15 +409,32 @@ func (c *sSEServiceClient) GetStatus(ctx context.Context, req *GetStatusRequest,
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "SSEService.GetStatus",
+		Response: &StatusResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("SSEService.GetStatus", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return sebufhttp.DoHedged(c.httpClient, httpReq, c.hedgeDelay, c.maxHedges)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -235,7 +445,13 @@ func (c *sSEServiceClient) GetStatus(ctx context.Context, req *GetStatusRequest,
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -253,7 +469,7 @@ func (c *sSEServiceClient) GetStatus(ctx context.Context, req *GetStatusRequest,
 	return result, nil
 }
 
-// StreamEvents calls the StreamEvents SSE streaming RPC.
+// StreamEvents SSE streaming RPC
 func (c *sSEServiceClient) StreamEvents(ctx context.Context, req *StreamEventsRequest, opts ...SSEServiceCallOption) (*SSEServiceEventStream[*Event], error) {
 	callOpts := &sSEServiceCallOptions{}
 	for _, opt := range opts {
@@ -261,8 +477,7 @@ func (c *sSEServiceClient) StreamEvents(ctx context.Context, req *StreamEventsRe
 	}
 
 	// Build URL
-	path := "/api/v1/events"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + SSEServiceStreamEventsURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -281,12 +496,28 @@ func (c *sSEServiceClient) StreamEvents(ctx context.Context, req *StreamEventsRe
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method: "SSEService.StreamEvents",
+		Stream: true,
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("SSEService.StreamEvents", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -298,7 +529,7 @@ func (c *sSEServiceClient) StreamEvents(ctx context.Context, req *StreamEventsRe
 		if readErr != nil {
 			return nil, fmt.Errorf("failed to read error response: %w", readErr)
 		}
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
 	}
 
 	discardUnknown := c.discardUnknownFields
@@ -313,7 +544,7 @@ func (c *sSEServiceClient) StreamEvents(ctx context.Context, req *StreamEventsRe
 	}, nil
 }
 
-// StreamResourceEvents calls the StreamResourceEvents SSE streaming RPC.
+// StreamResourceEvents SSE with path params
 func (c *sSEServiceClient) StreamResourceEvents(ctx context.Context, req *StreamResourceEventsRequest, opts ...SSEServiceCallOption) (*SSEServiceEventStream[*ResourceEvent], error) {
 	callOpts := &sSEServiceCallOptions{}
 	for _, opt := range opts {
@@ -321,9 +552,7 @@ func (c *sSEServiceClient) StreamResourceEvents(ctx context.Context, req *Stream
 	}
 
 	// Build URL
-	path := "/api/v1/resources/{resource_id}/events"
-	path = strings.Replace(path, "{resource_id}", url.PathEscape(fmt.Sprint(req.ResourceId)), 1)
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + SSEServiceStreamResourceEventsURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -342,12 +571,28 @@ func (c *sSEServiceClient) StreamResourceEvents(ctx context.Context, req *Stream
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method: "SSEService.StreamResourceEvents",
+		Stream: true,
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("SSEService.StreamResourceEvents", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -359,7 +604,7 @@ func (c *sSEServiceClient) StreamResourceEvents(ctx context.Context, req *Stream
 		if readErr != nil {
 			return nil, fmt.Errorf("failed to read error response: %w", readErr)
 		}
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
 	}
 
 	discardUnknown := c.discardUnknownFields
@@ -374,7 +619,7 @@ func (c *sSEServiceClient) StreamResourceEvents(ctx context.Context, req *Stream
 	}, nil
 }
 
-// StreamFilteredEvents calls the StreamFilteredEvents SSE streaming RPC.
+// StreamFilteredEvents SSE with query params
 func (c *sSEServiceClient) StreamFilteredEvents(ctx context.Context, req *StreamFilteredEventsRequest, opts ...SSEServiceCallOption) (*SSEServiceEventStream[*Event], error) {
 	callOpts := &sSEServiceCallOptions{}
 	for _, opt := range opts {
@@ -382,20 +627,7 @@ func (c *sSEServiceClient) StreamFilteredEvents(ctx context.Context, req *Stream
 	}
 
 	// Build URL
-	path := "/api/v1/events/filtered"
-	reqURL := c.baseURL + path
-
-	// Add query parameters
-	queryParams := url.Values{}
-	if req.EventType != "" {
-		queryParams.Set("type", fmt.Sprint(req.EventType))
-	}
-	if req.Limit != 0 {
-		queryParams.Set("limit", fmt.Sprint(req.Limit))
-	}
-	if len(queryParams) > 0 {
-		reqURL += "?" + queryParams.Encode()
-	}
+	reqURL := c.baseURL + SSEServiceStreamFilteredEventsURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -414,12 +646,28 @@ func (c *sSEServiceClient) StreamFilteredEvents(ctx context.Context, req *Stream
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method: "SSEService.StreamFilteredEvents",
+		Stream: true,
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("SSEService.StreamFilteredEvents", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -431,7 +679,7 @@ func (c *sSEServiceClient) StreamFilteredEvents(ctx context.Context, req *Stream
 		if readErr != nil {
 			return nil, fmt.Errorf("failed to read error response: %w", readErr)
 		}
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
 	}
 
 	discardUnknown := c.discardUnknownFields
@@ -461,7 +709,14 @@ func (c *sSEServiceClient) marshalRequest(req proto.Message, contentType string)
 	}
 }
 
-func (c *sSEServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *sSEServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
@@ -11,6 +11,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -20,9 +21,9 @@ import (
 
 const (
 	// ContentTypeJSON is the content type for JSON requests/responses.
-	ContentTypeJSON = "application/json"
+	ContentTypeJSON = sebufhttp.JSONContentType
 	// ContentTypeProto is the content type for binary protobuf requests/responses.
-	ContentTypeProto = "application/x-protobuf"
+	ContentTypeProto = sebufhttp.ProtoContentType
 )
 
 // sebufUnmarshaler is implemented by generated messages with custom JSON unmarshaling.
@@ -32,7 +33,10 @@ type sebufUnmarshaler interface {
 }
 
 // OptionDataServiceClient is the client API for OptionDataService service.
+//
+// OptionDataService provides option market data
 type OptionDataServiceClient interface {
+	// GetOptionBars retrieves option bar data for multiple symbols
 	GetOptionBars(ctx context.Context, req *GetOptionBarsRequest, opts ...OptionDataServiceCallOption) (*GetOptionBarsResponse, error)
 }
 
@@ -42,7 +46,15 @@ type optionDataServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ OptionDataServiceClient = (*optionDataServiceClient)(nil)
@@ -75,6 +87,27 @@ func WithOptionDataServiceDefaultHeader(key, value string) OptionDataServiceClie
 	}
 }
 
+// WithOptionDataServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithOptionDataServiceHeaderPropagation(allowlist ...string) OptionDataServiceClientOption {
+	return func(c *optionDataServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithOptionDataServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithOptionDataServiceDeadlinePropagation(headerName string) OptionDataServiceClientOption {
+	return func(c *optionDataServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithOptionDataServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithOptionDataServiceDiscardUnknownFields(discard bool) OptionDataServiceClientOption {
@@ -83,6 +116,105 @@ func WithOptionDataServiceDiscardUnknownFields(discard bool) OptionDataServiceCl
 	}
 }
 
+// WithOptionDataServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithOptionDataServiceHedging(delay time.Duration, maxHedges int) OptionDataServiceClientOption {
+	return func(c *optionDataServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithOptionDataServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithOptionDataServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) OptionDataServiceClientOption {
+	return func(c *optionDataServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithOptionDataServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithOptionDataServiceRecorder(recorder sebufhttp.CallRecorder) OptionDataServiceClientOption {
+	return func(c *optionDataServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithOptionDataServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithOptionDataServiceDebugLogging(w io.Writer) OptionDataServiceClientOption {
+	return func(c *optionDataServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithOptionDataServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithOptionDataServiceDebugBodyLimit(limit int) OptionDataServiceClientOption {
+	return func(c *optionDataServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithOptionDataServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithOptionDataServiceLogHook(hook func(sebufhttp.ClientLogEvent)) OptionDataServiceClientOption {
+	return func(c *optionDataServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithOptionDataServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithOptionDataServiceShadowMutations is set.
+func WithOptionDataServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) OptionDataServiceClientOption {
+	return func(c *optionDataServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithOptionDataServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithOptionDataServiceShadowMutations() OptionDataServiceClientOption {
+	return func(c *optionDataServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithOptionDataServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithOptionDataServiceShadowTimeout(timeout time.Duration) OptionDataServiceClientOption {
+	return func(c *optionDataServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // OptionDataServiceCallOption configures a single RPC call.
 type OptionDataServiceCallOption func(*optionDataServiceCallOptions)
 
@@ -91,6 +223,7 @@ type optionDataServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithOptionDataServiceHeader adds a header to a single request.
@@ -118,6 +251,14 @@ func WithOptionDataServiceCallDiscardUnknownFields(discard bool) OptionDataServi
 	}
 }
 
+// WithOptionDataServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithOptionDataServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) OptionDataServiceCallOption {
+	return func(o *optionDataServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // NewOptionDataServiceClient creates a new OptionDataService client.
 func NewOptionDataServiceClient(baseURL string, opts ...OptionDataServiceClientOption) OptionDataServiceClient {
 	c := &optionDataServiceClient{
@@ -134,7 +275,20 @@ func NewOptionDataServiceClient(baseURL string, opts ...OptionDataServiceClientO
 	return c
 }
 
-// GetOptionBars calls the GetOptionBars RPC.
+// OptionDataServiceRoutes holds the HTTP verb and path template of every OptionDataService method.
+var OptionDataServiceRoutes = struct {
+	GetOptionBars sebufhttp.Route
+}{
+	GetOptionBars: sebufhttp.Route{Method: "POST", Path: "/api/v1/options/bars"},
+}
+
+// OptionDataServiceGetOptionBarsURL returns the path and query string of a GetOptionBars call with req,
+// relative to the client's base URL.
+func OptionDataServiceGetOptionBarsURL(req *GetOptionBarsRequest) string {
+	return "/api/v1/options/bars"
+}
+
+// GetOptionBars retrieves option bar data for multiple symbols
 func (c *optionDataServiceClient) GetOptionBars(ctx context.Context, req *GetOptionBarsRequest, opts ...OptionDataServiceCallOption) (*GetOptionBarsResponse, error) {
 	callOpts := &optionDataServiceCallOptions{}
 	for _, opt := range opts {
@@ -142,8 +296,7 @@ func (c *optionDataServiceClient) GetOptionBars(ctx context.Context, req *GetOpt
 	}
 
 	// Build URL
-	path := "/api/v1/options/bars"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + OptionDataServiceGetOptionBarsURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -167,15 +320,33 @@ func (c *optionDataServiceClient) GetOptionBars(ctx context.Context, req *GetOpt
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "OptionDataService.GetOptionBars",
+		Body:     req,
+		Response: &GetOptionBarsResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("OptionDataService.GetOptionBars", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -186,7 +357,13 @@ func (c *optionDataServiceClient) GetOptionBars(ctx context.Context, req *GetOpt
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -219,7 +396,14 @@ func (c *optionDataServiceClient) marshalRequest(req proto.Message, contentType
 	}
 }
 
-func (c *optionDataServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *optionDataServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
@@ -266,10 +450,16 @@ func (c *optionDataServiceClient) unmarshalResponse(body []byte, msg proto.Messa
 }
 
 // UnwrapServiceClient is the client API for UnwrapService service.
+//
+// UnwrapService tests all unwrap variants including root-level unwrap
 type UnwrapServiceClient interface {
+	// GetOptionBars retrieves option bar data
 	GetOptionBars(ctx context.Context, req *GetOptionBarsRequest, opts ...UnwrapServiceCallOption) (*GetOptionBarsResponse, error)
+	// GetRootMap tests root-level map unwrap response
 	GetRootMap(ctx context.Context, req *GetOptionBarsRequest, opts ...UnwrapServiceCallOption) (*RootMapResponse, error)
+	// GetRootRepeated tests root-level repeated unwrap response
 	GetRootRepeated(ctx context.Context, req *GetOptionBarsRequest, opts ...UnwrapServiceCallOption) (*RootRepeatedResponse, error)
+	// GetRootMapWithValueUnwrap tests combined unwrap response
 	GetRootMapWithValueUnwrap(ctx context.Context, req *GetOptionBarsRequest, opts ...UnwrapServiceCallOption) (*RootMapWithValueUnwrapResponse, error)
 }
 
@@ -279,7 +469,15 @@ type unwrapServiceClient struct {
 	httpClient           *http.Client
 	contentType          string
 	defaultHeaders       map[string]string
+	propagatedHeaders    []string
+	deadlineHeader       string
 	discardUnknownFields bool
+	hedgeDelay           time.Duration
+	maxHedges            int
+	breaker              *sebufhttp.CircuitBreaker
+	logger               *sebufhttp.ClientLogger
+	shadow               *sebufhttp.ShadowMirror
+	recorder             sebufhttp.CallRecorder
 }
 
 var _ UnwrapServiceClient = (*unwrapServiceClient)(nil)
@@ -312,6 +510,27 @@ func WithUnwrapServiceDefaultHeader(key, value string) UnwrapServiceClientOption
 	}
 }
 
+// WithUnwrapServiceHeaderPropagation copies the headers named in allowlist from the validated
+// headers of the request a generated server is handling, when the call context carries them,
+// onto every request, so calls made from a handler forward them without manual plumbing.
+// They override default headers; headers set on the call override them.
+func WithUnwrapServiceHeaderPropagation(allowlist ...string) UnwrapServiceClientOption {
+	return func(c *unwrapServiceClient) {
+		c.propagatedHeaders = append(c.propagatedHeaders, allowlist...)
+	}
+}
+
+// WithUnwrapServiceDeadlinePropagation sends the time left before the deadline of the call
+// context, when it has one, in the header headerName of every request: in milliseconds, or
+// in grpc-timeout form when headerName is grpc-timeout. Servers configured with
+// WithTimeoutHeader(headerName, ...) stop working on the call when the caller gives up.
+// Headers set on the call override it.
+func WithUnwrapServiceDeadlinePropagation(headerName string) UnwrapServiceClientOption {
+	return func(c *unwrapServiceClient) {
+		c.deadlineHeader = headerName
+	}
+}
+
 // WithUnwrapServiceDiscardUnknownFields sets whether to discard unknown fields in JSON responses.
 // When true, unknown fields are silently ignored instead of causing unmarshal errors.
 func WithUnwrapServiceDiscardUnknownFields(discard bool) UnwrapServiceClientOption {
@@ -320,6 +539,105 @@ func WithUnwrapServiceDiscardUnknownFields(discard bool) UnwrapServiceClientOpti
 	}
 }
 
+// WithUnwrapServiceHedging sends up to maxHedges extra copies of a request, one every delay,
+// while no response has arrived. The first response wins and the others are cancelled.
+// Only GET methods and methods annotated with idempotency: true are hedged.
+func WithUnwrapServiceHedging(delay time.Duration, maxHedges int) UnwrapServiceClientOption {
+	return func(c *unwrapServiceClient) {
+		c.hedgeDelay = delay
+		c.maxHedges = maxHedges
+	}
+}
+
+// WithUnwrapServiceCircuitBreaker stops sending requests while the server keeps failing.
+// Transport errors and 5xx responses count as failures; rejected calls return an error
+// wrapping sebufhttp.ErrCircuitOpen.
+func WithUnwrapServiceCircuitBreaker(cfg sebufhttp.BreakerConfig) UnwrapServiceClientOption {
+	return func(c *unwrapServiceClient) {
+		c.breaker = sebufhttp.NewCircuitBreaker(cfg)
+	}
+}
+
+// WithUnwrapServiceRecorder passes every call through recorder, which records it or
+// answers it from an earlier recording without sending it, such as a cassette.Recorder
+// of github.com/SebastienMelki/sebuf/http/cassette for integration test fixtures.
+func WithUnwrapServiceRecorder(recorder sebufhttp.CallRecorder) UnwrapServiceClientOption {
+	return func(c *unwrapServiceClient) {
+		c.recorder = recorder
+	}
+}
+
+// WithUnwrapServiceDebugLogging writes a dump of every call to w: a curl command
+// equivalent to the request, then the response status, latency and body. Sensitive
+// fields and credential headers are redacted. Meant for development, not production.
+func WithUnwrapServiceDebugLogging(w io.Writer) UnwrapServiceClientOption {
+	return func(c *unwrapServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Debug = w
+	}
+}
+
+// WithUnwrapServiceDebugBodyLimit sets the number of bytes of a body the debug dump
+// shows before truncating it: sebufhttp.DefaultDebugBodyLimit by default, unlimited when negative.
+func WithUnwrapServiceDebugBodyLimit(limit int) UnwrapServiceClientOption {
+	return func(c *unwrapServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.BodyLimit = limit
+	}
+}
+
+// WithUnwrapServiceLogHook calls hook after every call with its method, URL, status,
+// latency, error and hedge attempt, for structured logging and metrics.
+func WithUnwrapServiceLogHook(hook func(sebufhttp.ClientLogEvent)) UnwrapServiceClientOption {
+	return func(c *unwrapServiceClient) {
+		if c.logger == nil {
+			c.logger = &sebufhttp.ClientLogger{}
+		}
+		c.logger.Hook = hook
+	}
+}
+
+// WithUnwrapServiceShadow mirrors a sampleRate fraction of calls to the server at baseURL
+// once their response arrived, and passes both responses to compare in the background.
+// The caller always gets the primary response; shadow failures are dropped. Only GET, HEAD
+// and OPTIONS methods are mirrored unless WithUnwrapServiceShadowMutations is set.
+func WithUnwrapServiceShadow(baseURL string, sampleRate float64, compare func(primary, shadow *http.Response)) UnwrapServiceClientOption {
+	return func(c *unwrapServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.BaseURL = baseURL
+		c.shadow.SampleRate = sampleRate
+		c.shadow.Compare = compare
+	}
+}
+
+// WithUnwrapServiceShadowMutations also mirrors methods that may change state, such as POST,
+// which then execute on the shadow server too.
+func WithUnwrapServiceShadowMutations() UnwrapServiceClientOption {
+	return func(c *unwrapServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Mutations = true
+	}
+}
+
+// WithUnwrapServiceShadowTimeout bounds each mirrored request, sebufhttp.DefaultShadowTimeout
+// by default, independently of the caller's context.
+func WithUnwrapServiceShadowTimeout(timeout time.Duration) UnwrapServiceClientOption {
+	return func(c *unwrapServiceClient) {
+		if c.shadow == nil {
+			c.shadow = &sebufhttp.ShadowMirror{}
+		}
+		c.shadow.Timeout = timeout
+	}
+}
+
 // UnwrapServiceCallOption configures a single RPC call.
 type UnwrapServiceCallOption func(*unwrapServiceCallOptions)
 
@@ -328,6 +646,7 @@ type unwrapServiceCallOptions struct {
 	headers              map[string]string
 	contentType          string
 	discardUnknownFields *bool
+	responseMetadata     *sebufhttp.ResponseMetadata
 }
 
 // WithUnwrapServiceHeader adds a header to a single request.
@@ -355,6 +674,14 @@ func WithUnwrapServiceCallDiscardUnknownFields(discard bool) UnwrapServiceCallOp
 	}
 }
 
+// WithUnwrapServiceResponseMetadata fills metadata with the status, headers and warnings
+// of the response to a unary call, such as those of a partial response.
+func WithUnwrapServiceResponseMetadata(metadata *sebufhttp.ResponseMetadata) UnwrapServiceCallOption {
+	return func(o *unwrapServiceCallOptions) {
+		o.responseMetadata = metadata
+	}
+}
+
 // NewUnwrapServiceClient creates a new UnwrapService client.
 func NewUnwrapServiceClient(baseURL string, opts ...UnwrapServiceClientOption) UnwrapServiceClient {
 	c := &unwrapServiceClient{
@@ -371,7 +698,44 @@ func NewUnwrapServiceClient(baseURL string, opts ...UnwrapServiceClientOption) U
 	return c
 }
 
-// GetOptionBars calls the GetOptionBars RPC.
+// UnwrapServiceRoutes holds the HTTP verb and path template of every UnwrapService method.
+var UnwrapServiceRoutes = struct {
+	GetOptionBars             sebufhttp.Route
+	GetRootMap                sebufhttp.Route
+	GetRootRepeated           sebufhttp.Route
+	GetRootMapWithValueUnwrap sebufhttp.Route
+}{
+	GetOptionBars:             sebufhttp.Route{Method: "POST", Path: "/api/v1/unwrap/options/bars"},
+	GetRootMap:                sebufhttp.Route{Method: "POST", Path: "/api/v1/root/map"},
+	GetRootRepeated:           sebufhttp.Route{Method: "POST", Path: "/api/v1/root/repeated"},
+	GetRootMapWithValueUnwrap: sebufhttp.Route{Method: "POST", Path: "/api/v1/root/map-value-unwrap"},
+}
+
+// UnwrapServiceGetOptionBarsURL returns the path and query string of a GetOptionBars call with req,
+// relative to the client's base URL.
+func UnwrapServiceGetOptionBarsURL(req *GetOptionBarsRequest) string {
+	return "/api/v1/unwrap/options/bars"
+}
+
+// UnwrapServiceGetRootMapURL returns the path and query string of a GetRootMap call with req,
+// relative to the client's base URL.
+func UnwrapServiceGetRootMapURL(req *GetOptionBarsRequest) string {
+	return "/api/v1/root/map"
+}
+
+// UnwrapServiceGetRootRepeatedURL returns the path and query string of a GetRootRepeated call with req,
+// relative to the client's base URL.
+func UnwrapServiceGetRootRepeatedURL(req *GetOptionBarsRequest) string {
+	return "/api/v1/root/repeated"
+}
+
+// UnwrapServiceGetRootMapWithValueUnwrapURL returns the path and query string of a GetRootMapWithValueUnwrap call with req,
+// relative to the client's base URL.
+func UnwrapServiceGetRootMapWithValueUnwrapURL(req *GetOptionBarsRequest) string {
+	return "/api/v1/root/map-value-unwrap"
+}
+
+// GetOptionBars retrieves option bar data
 func (c *unwrapServiceClient) GetOptionBars(ctx context.Context, req *GetOptionBarsRequest, opts ...UnwrapServiceCallOption) (*GetOptionBarsResponse, error) {
 	callOpts := &unwrapServiceCallOptions{}
 	for _, opt := range opts {
@@ -379,8 +743,7 @@ func (c *unwrapServiceClient) GetOptionBars(ctx context.Context, req *GetOptionB
 	}
 
 	// Build URL
-	path := "/api/v1/options/bars"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + UnwrapServiceGetOptionBarsURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -404,15 +767,33 @@ func (c *unwrapServiceClient) GetOptionBars(ctx context.Context, req *GetOptionB
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "UnwrapService.GetOptionBars",
+		Body:     req,
+		Response: &GetOptionBarsResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("UnwrapService.GetOptionBars", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -423,7 +804,13 @@ func (c *unwrapServiceClient) GetOptionBars(ctx context.Context, req *GetOptionB
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, contentType == ContentTypeJSON)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -441,7 +828,7 @@ func (c *unwrapServiceClient) GetOptionBars(ctx context.Context, req *GetOptionB
 	return result, nil
 }
 
-// GetRootMap calls the GetRootMap RPC.
+// GetRootMap tests root-level map unwrap response
 func (c *unwrapServiceClient) GetRootMap(ctx context.Context, req *GetOptionBarsRequest, opts ...UnwrapServiceCallOption) (*RootMapResponse, error) {
 	callOpts := &unwrapServiceCallOptions{}
 	for _, opt := range opts {
@@ -449,8 +836,7 @@ func (c *unwrapServiceClient) GetRootMap(ctx context.Context, req *GetOptionBars
 	}
 
 	// Build URL
-	path := "/api/v1/root/map"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + UnwrapServiceGetRootMapURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -474,15 +860,33 @@ func (c *unwrapServiceClient) GetRootMap(ctx context.Context, req *GetOptionBars
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "UnwrapService.GetRootMap",
+		Body:     req,
+		Response: &RootMapResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("UnwrapService.GetRootMap", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -493,7 +897,13 @@ func (c *unwrapServiceClient) GetRootMap(ctx context.Context, req *GetOptionBars
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, false)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -511,7 +921,7 @@ func (c *unwrapServiceClient) GetRootMap(ctx context.Context, req *GetOptionBars
 	return result, nil
 }
 
-// GetRootRepeated calls the GetRootRepeated RPC.
+// GetRootRepeated tests root-level repeated unwrap response
 func (c *unwrapServiceClient) GetRootRepeated(ctx context.Context, req *GetOptionBarsRequest, opts ...UnwrapServiceCallOption) (*RootRepeatedResponse, error) {
 	callOpts := &unwrapServiceCallOptions{}
 	for _, opt := range opts {
@@ -519,8 +929,7 @@ func (c *unwrapServiceClient) GetRootRepeated(ctx context.Context, req *GetOptio
 	}
 
 	// Build URL
-	path := "/api/v1/root/repeated"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + UnwrapServiceGetRootRepeatedURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -544,15 +953,33 @@ func (c *unwrapServiceClient) GetRootRepeated(ctx context.Context, req *GetOptio
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "UnwrapService.GetRootRepeated",
+		Body:     req,
+		Response: &RootRepeatedResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("UnwrapService.GetRootRepeated", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -563,7 +990,13 @@ func (c *unwrapServiceClient) GetRootRepeated(ctx context.Context, req *GetOptio
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, false)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -581,7 +1014,7 @@ func (c *unwrapServiceClient) GetRootRepeated(ctx context.Context, req *GetOptio
 	return result, nil
 }
 
-// GetRootMapWithValueUnwrap calls the GetRootMapWithValueUnwrap RPC.
+// GetRootMapWithValueUnwrap tests combined unwrap response
 func (c *unwrapServiceClient) GetRootMapWithValueUnwrap(ctx context.Context, req *GetOptionBarsRequest, opts ...UnwrapServiceCallOption) (*RootMapWithValueUnwrapResponse, error) {
 	callOpts := &unwrapServiceCallOptions{}
 	for _, opt := range opts {
@@ -589,8 +1022,7 @@ func (c *unwrapServiceClient) GetRootMapWithValueUnwrap(ctx context.Context, req
 	}
 
 	// Build URL
-	path := "/api/v1/root/map-value-unwrap"
-	reqURL := c.baseURL + path
+	reqURL := c.baseURL + UnwrapServiceGetRootMapWithValueUnwrapURL(req)
 
 	contentType := c.contentType
 	if callOpts.contentType != "" {
@@ -614,15 +1046,33 @@ func (c *unwrapServiceClient) GetRootMapWithValueUnwrap(ctx context.Context, req
 	for k, v := range c.defaultHeaders {
 		httpReq.Header.Set(k, v)
 	}
+	for k, v := range sebufhttp.OutgoingHeaderPropagation(ctx, c.propagatedHeaders) {
+		httpReq.Header.Set(k, v)
+	}
+	if timeout, ok := sebufhttp.TimeoutHeaderValue(ctx, c.deadlineHeader); ok {
+		httpReq.Header.Set(c.deadlineHeader, timeout)
+	}
 	for k, v := range callOpts.headers {
 		httpReq.Header.Set(k, v)
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	call := sebufhttp.ClientCall{
+		Method:   "UnwrapService.GetRootMapWithValueUnwrap",
+		Body:     req,
+		Response: &RootMapWithValueUnwrapResponse{},
+	}
+	resp, err := c.logger.Do(call, httpReq, func() (*http.Response, error) {
+		return c.breaker.Do("UnwrapService.GetRootMapWithValueUnwrap", httpReq, func() (*http.Response, error) {
+			return sebufhttp.RecordCall(c.recorder, call, httpReq, func() (*http.Response, error) {
+				return c.httpClient.Do(httpReq)
+			})
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	resp = c.shadow.Mirror(c.httpClient, c.baseURL, httpReq, resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -633,7 +1083,13 @@ func (c *unwrapServiceClient) GetRootMapWithValueUnwrap(ctx context.Context, req
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		return nil, c.handleErrorResponse(resp.StatusCode, respBody, contentType)
+		return nil, c.handleErrorResponse(resp.StatusCode, resp.Header, respBody, contentType)
+	}
+
+	// Read the status, headers and warnings of the response
+	respBody, metadata := sebufhttp.ReadResponseMetadata(resp, respBody, false)
+	if callOpts.responseMetadata != nil {
+		*callOpts.responseMetadata = metadata
 	}
 
 	// Resolve discardUnknownFields: per-call option overrides client default
@@ -666,7 +1122,14 @@ func (c *unwrapServiceClient) marshalRequest(req proto.Message, contentType stri
 	}
 }
 
-func (c *unwrapServiceClient) handleErrorResponse(statusCode int, body []byte, contentType string) error {
+func (c *unwrapServiceClient) handleErrorResponse(statusCode int, header http.Header, body []byte, contentType string) error {
+	// Problem Details (RFC 9457) carry the error as application/problem+json
+	if sebufhttp.IsProblemContentType(header.Get("Content-Type")) {
+		if problem, err := sebufhttp.ParseProblemDetails(body); err == nil {
+			return problem.Err()
+		}
+	}
+
 	// Try to parse as ValidationError first (for 400 errors)
 	// Always use strict mode (false) for error parsing to avoid loose JSON
 	// falsely matching ValidationError or Error types.
@@ -0,0 +1,172 @@
+package clientgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestURLBuildersIntegration generates the client of urlBuildersProto and
+// compares the output of its exported URL builders with the URLs the client
+// requests, captured by a recording transport, for escaped string and enum
+// path parameters and for int64, enum, bool and repeated query parameters.
+func TestURLBuildersIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "url_builders_test",
+		Protos:  map[string]string{"users.proto": urlBuildersProto},
+		Plugins: []plugintest.Plugin{{Name: "go-client"}},
+		Files:   map[string]string{"url_builders_test.go": urlBuildersIntegrationTestCode},
+	})
+}
+
+const urlBuildersProto = `syntax = "proto3";
+package test.urlbuilders;
+option go_package = "url_builders_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+enum Region {
+  REGION_UNSPECIFIED = 0;
+  REGION_EU = 1 [(sebuf.http.enum_value) = "eu"];
+}
+
+service UserService {
+  option (sebuf.http.service_config) = { base_path: "/api/v1" };
+  rpc GetUser(GetUserRequest) returns (User) {
+    option (sebuf.http.config) = { path: "/regions/{region}/users/{id}" method: HTTP_METHOD_GET };
+  }
+  rpc ListUsers(ListUsersRequest) returns (ListUsersResponse) {
+    option (sebuf.http.config) = { path: "/users" method: HTTP_METHOD_GET };
+  }
+  rpc CreateUser(User) returns (User) {
+    option (sebuf.http.config) = { path: "/users" method: HTTP_METHOD_POST };
+  }
+}
+
+message GetUserRequest {
+  string id = 1;
+  Region region = 2;
+}
+
+message ListUsersRequest {
+  int64 since_id = 1 [(sebuf.http.query) = { name: "since_id" }];
+  repeated string tags = 2 [(sebuf.http.query) = { name: "tag" }];
+  Region region = 3 [(sebuf.http.query) = { name: "region" }];
+  bool active = 4 [(sebuf.http.query) = { name: "active" }];
+  string q = 5 [(sebuf.http.query) = { name: "q" }];
+}
+
+message User {
+  string id = 1;
+}
+
+message ListUsersResponse {
+  repeated User users = 1;
+}
+`
+
+const urlBuildersIntegrationTestCode = `package url_builders_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "url_builders_test/gen"
+)
+
+const baseURL = "http://api.test/base"
+
+// recorder answers every request with an empty JSON object, recording its URL.
+type recorder struct {
+	url string
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.url = req.URL.String()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request:    req,
+	}, nil
+}
+
+func newClient() (gen.UserServiceClient, *recorder) {
+	rec := &recorder{}
+	client := gen.NewUserServiceClient(baseURL+"/", gen.WithUserServiceHTTPClient(&http.Client{Transport: rec}))
+	return client, rec
+}
+
+func TestGetUserURL(t *testing.T) {
+	client, rec := newClient()
+	req := &gen.GetUserRequest{Id: "a/b c", Region: gen.Region_REGION_EU}
+	if _, err := client.GetUser(context.Background(), req); err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	built := gen.UserServiceGetUserURL(req)
+	if rec.url != baseURL+built {
+		t.Errorf("client requested %s, builder returned %s", rec.url, built)
+	}
+	if want := "/api/v1/regions/eu/users/a%2Fb%20c"; built != want {
+		t.Errorf("builder returned %s, want %s", built, want)
+	}
+}
+
+func TestListUsersURL(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *gen.ListUsersRequest
+		want string
+	}{
+		{"no query", &gen.ListUsersRequest{}, "/api/v1/users"},
+		{
+			"every kind of query parameter",
+			&gen.ListUsersRequest{
+				SinceId: 9007199254740993,
+				Tags:    []string{"x", "y&z"},
+				Region:  gen.Region_REGION_EU,
+				Active:  true,
+				Q:       "hello world",
+			},
+			"/api/v1/users?active=true&q=hello+world&region=eu&since_id=9007199254740993&tag=x&tag=y%26z",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rec := newClient()
+			if _, err := client.ListUsers(context.Background(), tt.req); err != nil {
+				t.Fatalf("ListUsers: %v", err)
+			}
+			built := gen.UserServiceListUsersURL(tt.req)
+			if rec.url != baseURL+built {
+				t.Errorf("client requested %s, builder returned %s", rec.url, built)
+			}
+			if built != tt.want {
+				t.Errorf("builder returned %s, want %s", built, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	routes := map[string]sebufhttp.Route{
+		"GetUser":    gen.UserServiceRoutes.GetUser,
+		"ListUsers":  gen.UserServiceRoutes.ListUsers,
+		"CreateUser": gen.UserServiceRoutes.CreateUser,
+	}
+	want := map[string]sebufhttp.Route{
+		"GetUser":    {Method: http.MethodGet, Path: "/api/v1/regions/{region}/users/{id}"},
+		"ListUsers":  {Method: http.MethodGet, Path: "/api/v1/users"},
+		"CreateUser": {Method: http.MethodPost, Path: "/api/v1/users"},
+	}
+	for name, route := range routes {
+		if route != want[name] {
+			t.Errorf("%s route = %+v, want %+v", name, route, want[name])
+		}
+	}
+}
+`
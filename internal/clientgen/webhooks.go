@@ -0,0 +1,106 @@
+package clientgen
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// generateWebhooksFile generates, for every message annotated with
+// sebuf.http.webhook, Send<Message> to deliver it and Verify<Message>Signature
+// for receivers.
+func (g *Generator) generateWebhooksFile(file *protogen.File) error {
+	webhooks := annotations.GetFileWebhooks(file)
+	if len(webhooks) == 0 {
+		return nil
+	}
+	for _, webhook := range webhooks {
+		if err := annotations.ValidateWebhook(webhook); err != nil {
+			return err
+		}
+	}
+
+	filename := file.GeneratedFilenamePrefix + "_webhooks.pb.go"
+	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
+
+	g.writeHeader(gf, file)
+	gf.P("import (")
+	gf.P(`"context"`)
+	gf.P(`"fmt"`)
+	gf.P(`"net/http"`)
+	if webhooksHavePath(webhooks) {
+		gf.P(`"strings"`)
+	}
+	gf.P()
+	gf.P(`sebufhttp "github.com/SebastienMelki/sebuf/http"`)
+	gf.P(")")
+	gf.P()
+
+	for _, webhook := range webhooks {
+		g.generateWebhookSend(gf, webhook)
+		g.generateWebhookVerify(gf, webhook)
+	}
+	return nil
+}
+
+// webhooksHavePath reports whether any webhook appends a path to the endpoint URL.
+func webhooksHavePath(webhooks []*annotations.Webhook) bool {
+	for _, webhook := range webhooks {
+		if webhook.Path != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Generator) generateWebhookSend(gf *protogen.GeneratedFile, webhook *annotations.Webhook) {
+	msgName := webhook.Message.GoIdent.GoName
+	target, docTarget := "endpointURL", "endpointURL"
+	if webhook.Path != "" {
+		target = fmt.Sprintf("strings.TrimSuffix(endpointURL, %q)+%q", "/", webhook.Path)
+		docTarget = "endpointURL + " + webhook.Path
+	}
+
+	gf.P("// Send", msgName, " POSTs payload as a webhook to ", docTarget, ", encoded")
+	gf.P("// like requests and signed with HMAC-", webhookHashName(webhook.Algorithm),
+		" in ", webhook.SignatureHeader, " when")
+	gf.P("// sebufhttp.WithWebhookSecret is given. Transport errors and 5xx responses are")
+	gf.P("// retried with exponential backoff.")
+	gf.P("func Send", msgName, "(ctx context.Context, endpointURL string, payload *", msgName,
+		", opts ...sebufhttp.WebhookOption) error {")
+	gf.P("body, err := sebufhttp.MarshalWebhookPayload(payload)")
+	gf.P("if err != nil {")
+	gf.P(`return fmt.Errorf("failed to marshal `, msgName, ` webhook: %w", err)`)
+	gf.P("}")
+	gf.P("return sebufhttp.SendWebhook(ctx, ", target, ", body, ", fmt.Sprintf("%q", webhook.SignatureHeader),
+		", ", webhookAlgorithmConst(webhook.Algorithm), ", opts...)")
+	gf.P("}")
+	gf.P()
+}
+
+func (g *Generator) generateWebhookVerify(gf *protogen.GeneratedFile, webhook *annotations.Webhook) {
+	msgName := webhook.Message.GoIdent.GoName
+
+	gf.P("// Verify", msgName, "Signature checks the ", webhook.SignatureHeader, " header of a received ", msgName)
+	gf.P("// webhook against its body, returning sebufhttp.ErrInvalidWebhookSignature when")
+	gf.P("// it is missing or does not match. The body can still be read afterwards.")
+	gf.P("func Verify", msgName, "Signature(r *http.Request, secret []byte) error {")
+	gf.P("return sebufhttp.VerifyWebhookSignature(r, ", fmt.Sprintf("%q", webhook.SignatureHeader),
+		", ", webhookAlgorithmConst(webhook.Algorithm), ", secret)")
+	gf.P("}")
+	gf.P()
+}
+
+// webhookAlgorithmConst returns the Go expression of a signature algorithm.
+func webhookAlgorithmConst(algorithm http.WebhookSignatureAlgorithm) string {
+	return "sebufhttp.WebhookSignatureAlgorithm_" + algorithm.String()
+}
+
+// webhookHashName returns the hash name of a signature algorithm, e.g. SHA256.
+func webhookHashName(algorithm http.WebhookSignatureAlgorithm) string {
+	return strings.TrimPrefix(algorithm.String(), "WEBHOOK_SIGNATURE_ALGORITHM_")
+}
@@ -0,0 +1,146 @@
+package clientgen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestWebhooksIntegration generates the webhook senders and verifiers of
+// webhooks.proto with webhooks=true and round-trips signed payloads through an
+// httptest receiver: the first delivery is answered with a 503 and retried,
+// the receiver verifies the signature with the generated Verify function, and
+// the body keeps the int64 and enum encoding annotations.
+func TestWebhooksIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:     "webhooks_test",
+		Protos:   map[string]string{"webhooks.proto": ""},
+		ProtoDir: filepath.Join("testdata", "proto"),
+		Mappings: []string{"Mwebhooks.proto=webhooks_test/gen;gen"},
+		Plugins:  []plugintest.Plugin{{Name: "go-client", Opt: "webhooks=true"}},
+		Files:    map[string]string{"webhooks_test.go": webhooksIntegrationTestCode},
+	})
+}
+
+const webhooksIntegrationTestCode = `package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "webhooks_test/gen"
+)
+
+var secret = []byte("whsec_test")
+
+func fastRetries() sebufhttp.WebhookOption {
+	return sebufhttp.WithWebhookRetries(3, time.Millisecond)
+}
+
+func TestSendOrderPaidRoundTrip(t *testing.T) {
+	var calls atomic.Int32
+	received := make(chan []byte, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path != "/hooks/orders" {
+			t.Errorf("path = %q, want /hooks/orders", r.URL.Path)
+		}
+		if r.Header.Get("X-Order-Signature") == "" {
+			t.Error("X-Order-Signature header is missing")
+		}
+		if err := gen.VerifyOrderPaidSignature(r, secret); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		received <- body
+	}))
+	defer srv.Close()
+
+	payload := &gen.OrderPaid{OrderId: "ord_1", AmountCents: 1999}
+	if err := gen.SendOrderPaid(context.Background(), srv.URL+"/", payload,
+		sebufhttp.WithWebhookSecret(secret), fastRetries()); err != nil {
+		t.Fatalf("SendOrderPaid: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("receiver called %d times, want 2", got)
+	}
+
+	body := <-received
+	var raw map[string]any
+	if err := json.Unmarshal(body, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if _, isNumber := raw["amountCents"].(float64); !isNumber {
+		t.Errorf("amountCents = %#v, want a JSON number (int64_encoding NUMBER)", raw["amountCents"])
+	}
+	got := &gen.OrderPaid{}
+	if err := json.Unmarshal(body, got); err != nil {
+		t.Fatal(err)
+	}
+	if got.GetOrderId() != "ord_1" || got.GetAmountCents() != 1999 {
+		t.Errorf("received %v, want %v", got, payload)
+	}
+}
+
+func TestSendShipmentUpdatedRoundTrip(t *testing.T) {
+	received := make(chan []byte, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := gen.VerifyShipmentUpdatedSignature(r, secret); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		received <- body
+	}))
+	defer srv.Close()
+
+	payload := &gen.ShipmentUpdated{ShipmentId: "shp_1", Status: gen.ShipmentStatus_SHIPMENT_STATUS_DISPATCHED}
+	if err := gen.SendShipmentUpdated(context.Background(), srv.URL, payload,
+		sebufhttp.WithWebhookSecret(secret), fastRetries()); err != nil {
+		t.Fatalf("SendShipmentUpdated: %v", err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(<-received, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw["status"] != "dispatched" {
+		t.Errorf("status = %#v, want the enum_value \"dispatched\"", raw["status"])
+	}
+}
+
+func TestWrongSecretIsRejected(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if err := gen.VerifyCarrier_PingSignature(r, secret); !errors.Is(err, sebufhttp.ErrInvalidWebhookSignature) {
+			t.Errorf("VerifyCarrier_PingSignature = %v, want ErrInvalidWebhookSignature", err)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	err := gen.SendCarrier_Ping(context.Background(), srv.URL, &gen.Carrier_Ping{CarrierId: "c1"},
+		sebufhttp.WithWebhookSecret([]byte("wrong")), fastRetries())
+	var deliveryErr *sebufhttp.WebhookDeliveryError
+	if !errors.As(err, &deliveryErr) || deliveryErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("SendCarrier_Ping error = %v, want a 401 delivery error", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("receiver called %d times, want 1 (4xx is not retried)", got)
+	}
+}
+`
@@ -0,0 +1,213 @@
+package conformance
+
+import (
+	"bufio"
+	"bytes"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SebastienMelki/sebuf/http/openapitest"
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// serverStartTimeout bounds how long a target may take to print its address.
+const serverStartTimeout = 30 * time.Second
+
+// TestConformance generates every server target from conformance.proto and runs
+// all scenarios against each of them, then verifies each target against the
+// OpenAPI spec generated from the same proto.
+func TestConformance(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping conformance tests")
+	}
+
+	protoDir := filepath.Join("testdata", "proto")
+	serverDir := filepath.Join("testdata", "server")
+
+	targets := map[Target]string{}
+	goServer := buildGoServer(t, protoDir, serverDir)
+	targets[TargetGoServer] = startServer(t, "", goServer)
+	targets[TargetGoMock] = startServer(t, "", goServer, "-mock")
+
+	if node := typeStrippingNode(); node != "" {
+		tsDir := generateTSServer(t, protoDir, serverDir)
+		targets[TargetTSServer] = startServer(t, tsDir, node,
+			"--experimental-strip-types", "--no-warnings", "--import", "./register.mjs", "server.ts")
+	} else {
+		t.Log("node with type stripping (>= 22.6) not found, skipping ts-server target; set CONFORMANCE_NODE to override")
+	}
+
+	spec := generateSpec(t, protoDir)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	for _, target := range []Target{TargetGoServer, TargetGoMock, TargetTSServer} {
+		baseURL, ok := targets[target]
+		if !ok {
+			continue
+		}
+		for _, s := range Scenarios() {
+			t.Run(string(target)+"/"+s.Name, func(t *testing.T) {
+				if reason, skip := s.Skip[target]; skip {
+					t.Skip(reason)
+				}
+				if runErr := Run(client, baseURL, target, s); runErr != nil {
+					t.Error(runErr)
+				}
+			})
+		}
+		t.Run(string(target)+"/openapi", func(t *testing.T) {
+			u, parseErr := url.Parse(baseURL)
+			if parseErr != nil {
+				t.Fatal(parseErr)
+			}
+			openapitest.VerifySpecAgainstServer(t, spec, httputil.NewSingleHostReverseProxy(u), nil)
+		})
+	}
+}
+
+// buildGoServer generates the Go server and mock into a temp module alongside
+// testdata/server/main.go and returns the built binary's path.
+func buildGoServer(t *testing.T, protoDir, serverDir string) string {
+	t.Helper()
+
+	main, err := os.ReadFile(filepath.Join(serverDir, "main.go"))
+	if err != nil {
+		t.Fatalf("Failed to read the server entrypoint: %v", err)
+	}
+	tempDir := plugintest.Generate(t, plugintest.Module{
+		Path:     "testmod",
+		Protos:   map[string]string{"conformance.proto": ""},
+		ProtoDir: protoDir,
+		Plugins:  []plugintest.Plugin{{Name: "go-http", Opt: "generate_mock=true"}},
+		Out:      "generated",
+		Files:    map[string]string{"main.go": string(main)},
+	})
+
+	binPath := filepath.Join(tempDir, "conformance-server")
+	plugintest.GoCommand(t, tempDir, "build", "-o", binPath, ".")
+	return binPath
+}
+
+// generateSpec generates the OpenAPI spec of ConformanceService and returns it.
+func generateSpec(t *testing.T, protoDir string) []byte {
+	t.Helper()
+
+	specDir := t.TempDir()
+	plugintest.Protoc(t,
+		"--plugin=protoc-gen-openapiv3="+plugintest.Build(t, plugintest.ProjectRoot(), "protoc-gen-openapiv3"),
+		"--openapiv3_out="+specDir,
+		"--proto_path="+protoDir,
+		"conformance.proto",
+	)
+	spec, err := os.ReadFile(filepath.Join(specDir, "ConformanceService.openapi.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read the generated spec: %v", err)
+	}
+	return spec
+}
+
+// generateTSServer generates the TS server (runtime=node) next to the echo
+// handler entrypoint and returns the directory to run node from.
+func generateTSServer(t *testing.T, protoDir, serverDir string) string {
+	t.Helper()
+
+	tsDir := t.TempDir()
+	plugintest.Protoc(t,
+		"--plugin=protoc-gen-ts-server="+plugintest.Build(t, plugintest.ProjectRoot(), "protoc-gen-ts-server"),
+		"--ts-server_out="+tsDir,
+		"--ts-server_opt=paths=source_relative,runtime=node",
+		"--proto_path="+protoDir,
+		"conformance.proto",
+	)
+	for _, name := range []string{"server.ts", "register.mjs", "resolve-ts.mjs", "package.json"} {
+		copyFile(t, filepath.Join(serverDir, name), filepath.Join(tsDir, name))
+	}
+	return tsDir
+}
+
+// typeStrippingNode returns a node binary able to run the generated TypeScript
+// directly (--experimental-strip-types), or "" if none is available.
+// CONFORMANCE_NODE overrides the binary looked up on PATH.
+func typeStrippingNode() string {
+	node := os.Getenv("CONFORMANCE_NODE")
+	if node == "" {
+		node = "node"
+	}
+	path, err := exec.LookPath(node)
+	if err != nil {
+		return ""
+	}
+	if exec.Command(path, "--experimental-strip-types", "--no-warnings", "-e", "").Run() != nil {
+		return ""
+	}
+	return path
+}
+
+// startServer starts a target process, waits for it to print its listen
+// address, and returns the base URL. The process is killed on cleanup.
+func startServer(t *testing.T, dir, name string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("Failed to pipe stdout of %s: %v", name, err)
+	}
+	if startErr := cmd.Start(); startErr != nil {
+		t.Fatalf("Failed to start %s: %v", name, startErr)
+	}
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	addrCh := make(chan string, 1)
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		if scanner.Scan() {
+			addrCh <- strings.TrimSpace(scanner.Text())
+		}
+		close(addrCh)
+	}()
+
+	select {
+	case addr, ok := <-addrCh:
+		if !ok {
+			_ = cmd.Wait()
+			t.Fatalf("%s %v exited before listening\nstderr: %s", name, args, stderr.String())
+		}
+		return "http://" + addr
+	case <-time.After(serverStartTimeout):
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		t.Fatalf("%s %v did not report its address within %s\nstderr: %s",
+			name, args, serverStartTimeout, stderr.String())
+	}
+	return ""
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	t.Helper()
+	data, err := os.ReadFile(src)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", src, err)
+	}
+	writeFile(t, dst, data)
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
@@ -0,0 +1,17 @@
+// Package conformance holds the cross-target HTTP conformance suite.
+//
+// The same declarative scenarios run against every server sebuf generates from
+// testdata/proto/conformance.proto: the Go server (protoc-gen-go-http) backed
+// by echo handlers, the Go mock server (generate_mock=true), and the
+// TypeScript server (protoc-gen-ts-server, runtime=node) when a Node.js with
+// type stripping is available. Any drift in status codes, error shapes, or
+// JSON encodings between the targets fails the suite with the scenario name and
+// a body diff. Each target is also verified against the OpenAPI spec
+// protoc-gen-openapiv3 generates from the same proto, with
+// http/openapitest, so the spec cannot drift from what the servers return.
+//
+// New generator features that change observable HTTP behavior must add
+// scenarios here (and RPCs to conformance.proto when the existing ones cannot
+// exercise the feature). A divergence a target cannot fix yet is recorded in
+// the scenario's Skip map with the reason, so it stays visible.
+package conformance
@@ -0,0 +1,240 @@
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Run sends the scenario's request to the target listening at baseURL and
+// checks the response. The returned error names the scenario and target and,
+// for body mismatches, carries a line diff of the indented JSON bodies.
+func Run(client *http.Client, baseURL string, target Target, s Scenario) error {
+	var body io.Reader
+	if s.Request.Body != "" {
+		body = strings.NewReader(s.Request.Body)
+	}
+	req, err := http.NewRequest(s.Request.Method, baseURL+s.Request.Path, body)
+	if err != nil {
+		return fmt.Errorf("%s [%s]: build request: %w", s.Name, target, err)
+	}
+	if s.Request.Body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range s.Request.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s [%s]: %s %s: %w", s.Name, target, s.Request.Method, s.Request.Path, err)
+	}
+	defer resp.Body.Close()
+
+	got, readErr := io.ReadAll(resp.Body)
+	if readErr != nil && !s.Want.Truncated {
+		return fmt.Errorf("%s [%s]: read body: %w", s.Name, target, readErr)
+	}
+
+	if resp.StatusCode != s.Want.Status {
+		return fmt.Errorf("%s [%s]: status = %d, want %d\nbody: %s",
+			s.Name, target, resp.StatusCode, s.Want.Status, got)
+	}
+	if s.Want.Truncated && readErr == nil {
+		return fmt.Errorf("%s [%s]: body was not cut short\nbody: %s", s.Name, target, got)
+	}
+
+	if s.Echoed && target == TargetGoMock {
+		return nil
+	}
+	if s.Want.Events != nil {
+		return compareEvents(s, target, got)
+	}
+	if s.Want.Body == "" {
+		return nil
+	}
+	return compareBody(s, target, got)
+}
+
+// compareBody reports whether the actual body matches the scenario's expected
+// JSON body, exactly or as a subset.
+func compareBody(s Scenario, target Target, got []byte) error {
+	want, err := decodeJSON([]byte(s.Want.Body))
+	if err != nil {
+		return fmt.Errorf("%s: invalid expected body: %w", s.Name, err)
+	}
+	actual, err := decodeJSON(got)
+	if err != nil {
+		return fmt.Errorf("%s [%s]: response is not JSON: %w\nbody: %s", s.Name, target, err, got)
+	}
+
+	if matchJSON(want, actual, s.Want.Subset) {
+		return nil
+	}
+
+	mode := "exact"
+	if s.Want.Subset {
+		mode = "subset"
+	}
+	return fmt.Errorf("%s [%s]: body mismatch (%s match)\n%s",
+		s.Name, target, mode, diffLines(indentJSON(want), indentJSON(actual)))
+}
+
+// compareEvents reports whether the actual text/event-stream body holds the
+// scenario's expected events, in order, with their data matched like bodies.
+func compareEvents(s Scenario, target Target, got []byte) error {
+	events := parseEvents(got)
+	if len(events) != len(s.Want.Events) {
+		return fmt.Errorf("%s [%s]: got %d events, want %d\nbody: %s",
+			s.Name, target, len(events), len(s.Want.Events), got)
+	}
+	for i, want := range s.Want.Events {
+		if events[i].Type != want.Type {
+			return fmt.Errorf("%s [%s]: event %d has type %q, want %q", s.Name, target, i, events[i].Type, want.Type)
+		}
+		wantData, err := decodeJSON([]byte(want.Data))
+		if err != nil {
+			return fmt.Errorf("%s: invalid expected data of event %d: %w", s.Name, i, err)
+		}
+		data, err := decodeJSON([]byte(events[i].Data))
+		if err != nil {
+			return fmt.Errorf("%s [%s]: data of event %d is not JSON: %w\ndata: %s",
+				s.Name, target, i, err, events[i].Data)
+		}
+		if !matchJSON(wantData, data, s.Want.Subset) {
+			return fmt.Errorf("%s [%s]: data mismatch in event %d\n%s",
+				s.Name, target, i, diffLines(indentJSON(wantData), indentJSON(data)))
+		}
+	}
+	return nil
+}
+
+// parseEvents splits a text/event-stream body into its events, keeping the
+// event and data fields; multi-line data is joined with newlines.
+func parseEvents(body []byte) []Event {
+	var events []Event
+	for _, block := range strings.Split(strings.ReplaceAll(string(body), "\r\n", "\n"), "\n\n") {
+		var event Event
+		var data []string
+		for _, line := range strings.Split(block, "\n") {
+			name, value, _ := strings.Cut(line, ":")
+			value = strings.TrimPrefix(value, " ")
+			switch name {
+			case "event":
+				event.Type = value
+			case "data":
+				data = append(data, value)
+			}
+		}
+		if data == nil {
+			continue
+		}
+		event.Data = strings.Join(data, "\n")
+		events = append(events, event)
+	}
+	return events
+}
+
+func decodeJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// matchJSON compares two decoded JSON values. In subset mode objects in want
+// may omit keys present in got; arrays always match element-wise with equal
+// length, so ordering drift is still reported.
+func matchJSON(want, got any, subset bool) bool {
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok || (!subset && len(g) != len(w)) {
+			return false
+		}
+		for key, wv := range w {
+			gv, present := g[key]
+			if !present || !matchJSON(wv, gv, subset) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		g, ok := got.([]any)
+		if !ok || len(g) != len(w) {
+			return false
+		}
+		for i := range w {
+			if !matchJSON(w[i], g[i], subset) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		g, ok := got.(json.Number)
+		return ok && numbersEqual(w, g)
+	default:
+		return want == got
+	}
+}
+
+// numbersEqual compares JSON numbers by value so 3 and 3.0 match.
+func numbersEqual(a, b json.Number) bool {
+	if a == b {
+		return true
+	}
+	af, aErr := a.Float64()
+	bf, bErr := b.Float64()
+	return aErr == nil && bErr == nil && af == bf
+}
+
+func indentJSON(v any) []string {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return []string{fmt.Sprint(v)}
+	}
+	return strings.Split(string(out), "\n")
+}
+
+// diffLines renders a unified-style line diff ("-" want, "+" got) based on the
+// longest common subsequence of the two inputs.
+func diffLines(want, got []string) string {
+	lcs := make([][]int, len(want)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(got)+1)
+	}
+	for i := len(want) - 1; i >= 0; i-- {
+		for j := len(got) - 1; j >= 0; j-- {
+			if want[i] == got[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("--- want\n+++ got\n")
+	i, j := 0, 0
+	for i < len(want) || j < len(got) {
+		switch {
+		case i < len(want) && j < len(got) && want[i] == got[j]:
+			b.WriteString("  " + want[i] + "\n")
+			i++
+			j++
+		case i < len(want) && (j == len(got) || lcs[i+1][j] >= lcs[i][j+1]):
+			b.WriteString("- " + want[i] + "\n")
+			i++
+		default:
+			b.WriteString("+ " + got[j] + "\n")
+			j++
+		}
+	}
+	return b.String()
+}
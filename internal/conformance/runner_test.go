@@ -0,0 +1,138 @@
+package conformance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMatchJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   string
+		got    string
+		subset bool
+		match  bool
+	}{
+		{"exact object", `{"a":1,"b":"x"}`, `{"b":"x","a":1}`, false, true},
+		{"extra key exact", `{"a":1}`, `{"a":1,"b":2}`, false, false},
+		{"extra key subset", `{"a":1}`, `{"a":1,"b":2}`, true, true},
+		{"missing key subset", `{"a":1,"c":3}`, `{"a":1,"b":2}`, true, false},
+		{"numbers by value", `{"a":3}`, `{"a":3.0}`, false, true},
+		{"number vs string", `{"a":3}`, `{"a":"3"}`, false, false},
+		{"array order", `["a","b"]`, `["b","a"]`, false, false},
+		{"array length subset", `[{"f":"x"}]`, `[{"f":"x"},{"f":"y"}]`, true, false},
+		{"nested subset", `{"v":[{"f":"x"}]}`, `{"v":[{"f":"x","d":"msg"}]}`, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := decodeJSON([]byte(tt.want))
+			if err != nil {
+				t.Fatalf("decode want: %v", err)
+			}
+			got, err := decodeJSON([]byte(tt.got))
+			if err != nil {
+				t.Fatalf("decode got: %v", err)
+			}
+			if m := matchJSON(want, got, tt.subset); m != tt.match {
+				t.Errorf("matchJSON(%s, %s, subset=%v) = %v, want %v", tt.want, tt.got, tt.subset, m, tt.match)
+			}
+		})
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	diff := diffLines([]string{"{", `  "a": 1`, "}"}, []string{"{", `  "a": 2`, "}"})
+	want := "--- want\n+++ got\n  {\n-   \"a\": 1\n+   \"a\": 2\n  }\n"
+	if diff != want {
+		t.Errorf("diffLines() =\n%s\nwant:\n%s", diff, want)
+	}
+}
+
+func TestRun(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Request-ID") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"violations":[{"field":"X-Request-ID","description":"required"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"abc"}`))
+	}))
+	defer srv.Close()
+
+	scenario := Scenario{
+		Name:    "sample",
+		Request: Request{Method: http.MethodGet, Path: "/items/abc", Headers: map[string]string{"X-Request-ID": "1"}},
+		Want:    Response{Status: http.StatusOK, Body: `{"id":"abc"}`},
+		Echoed:  true,
+	}
+	if err := Run(srv.Client(), srv.URL, TargetGoServer, scenario); err != nil {
+		t.Errorf("Run() error = %v", err)
+	}
+
+	mismatch := scenario
+	mismatch.Want.Body = `{"id":"xyz"}`
+	err := Run(srv.Client(), srv.URL, TargetGoServer, mismatch)
+	if err == nil || !strings.Contains(err.Error(), "sample [go-server]: body mismatch") {
+		t.Errorf("Run() error = %v, want body mismatch", err)
+	}
+	// The mock returns generated data, so echoed bodies are not compared.
+	if mockErr := Run(srv.Client(), srv.URL, TargetGoMock, mismatch); mockErr != nil {
+		t.Errorf("Run() on mock error = %v", mockErr)
+	}
+
+	status := scenario
+	status.Request.Headers = nil
+	err = Run(srv.Client(), srv.URL, TargetTSServer, status)
+	if err == nil || !strings.Contains(err.Error(), "status = 400, want 200") {
+		t.Errorf("Run() error = %v, want status mismatch", err)
+	}
+}
+
+func TestRunStreams(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/events" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("data: {\"id\": \"a\", \"name\": \"\"}\n\nevent: error\ndata: \"failed\"\n\n"))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":"a"}`))
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}))
+	defer srv.Close()
+
+	events := Scenario{
+		Name:    "events",
+		Request: Request{Method: http.MethodGet, Path: "/events"},
+		Want: Response{
+			Status: http.StatusOK,
+			Events: []Event{{Data: `{"id":"a"}`}, {Type: "error", Data: `"failed"`}},
+			Subset: true,
+		},
+	}
+	if err := Run(srv.Client(), srv.URL, TargetGoServer, events); err != nil {
+		t.Errorf("Run() error = %v", err)
+	}
+	events.Want.Events = events.Want.Events[:1]
+	err := Run(srv.Client(), srv.URL, TargetGoServer, events)
+	if err == nil || !strings.Contains(err.Error(), "got 2 events, want 1") {
+		t.Errorf("Run() error = %v, want an event count mismatch", err)
+	}
+
+	truncated := Scenario{
+		Name:    "truncated",
+		Request: Request{Method: http.MethodGet, Path: "/items"},
+		Want:    Response{Status: http.StatusOK, Truncated: true},
+	}
+	if err := Run(srv.Client(), srv.URL, TargetGoServer, truncated); err != nil {
+		t.Errorf("Run() error = %v", err)
+	}
+	truncated.Request.Path = "/events"
+	err = Run(srv.Client(), srv.URL, TargetGoServer, truncated)
+	if err == nil || !strings.Contains(err.Error(), "body was not cut short") {
+		t.Errorf("Run() error = %v, want a complete body to fail", err)
+	}
+}
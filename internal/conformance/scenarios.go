@@ -0,0 +1,292 @@
+package conformance
+
+import "net/http"
+
+// Target identifies a generated server implementation the scenarios run against.
+type Target string
+
+const (
+	// TargetGoServer is the protoc-gen-go-http server backed by echo handlers.
+	TargetGoServer Target = "go-server"
+	// TargetGoMock is the protoc-gen-go-http mock server (generate_mock=true).
+	TargetGoMock Target = "go-mock"
+	// TargetTSServer is the protoc-gen-ts-server server backed by echo handlers.
+	TargetTSServer Target = "ts-server"
+)
+
+// Request describes the HTTP request a scenario sends.
+type Request struct {
+	Method  string
+	Path    string
+	Headers map[string]string
+	Body    string
+}
+
+// Response describes the response a scenario expects.
+type Response struct {
+	Status int
+	// Body is the expected JSON body. Empty skips the body check.
+	Body string
+	// Subset matches Body as a subset of the actual body: objects may carry
+	// extra keys, arrays must have the same length and match element-wise.
+	// It applies to the data of Events too.
+	Subset bool
+	// Events are the expected Server-Sent Events of a text/event-stream body,
+	// checked instead of Body.
+	Events []Event
+	// Truncated expects the body to be cut short after the status, as when a
+	// streamed JSON array fails after its first item.
+	Truncated bool
+}
+
+// Event is a Server-Sent Event a scenario expects.
+type Event struct {
+	// Type is the event field, empty for messages.
+	Type string
+	// Data is the expected JSON data, matched like Response.Body.
+	Data string
+}
+
+// Scenario is a single request/expectation pair run against every target.
+type Scenario struct {
+	Name    string
+	Request Request
+	Want    Response
+	// Echoed marks expected bodies produced by the reference echo handlers.
+	// The mock returns generated data, so it only checks the status.
+	Echoed bool
+	// Skip records known divergences per target, keyed by target with the
+	// reason as value.
+	Skip map[Target]string
+}
+
+// requestID is a valid value for the X-Request-ID header GetItem requires.
+const requestID = "123e4567-e89b-12d3-a456-426614174000"
+
+// Scenarios returns the conformance scenarios in execution order.
+func Scenarios() []Scenario {
+	withRequestID := map[string]string{"X-Request-ID": requestID}
+
+	return []Scenario{
+		// Headers
+		{
+			Name:    "headers/missing_required",
+			Request: Request{Method: http.MethodGet, Path: "/conformance/items/abc"},
+			Want: Response{
+				Status: http.StatusBadRequest,
+				Body:   `{"violations":[{"field":"X-Request-ID"}]}`,
+				Subset: true,
+			},
+		},
+		{
+			Name: "headers/invalid_format",
+			Request: Request{
+				Method:  http.MethodGet,
+				Path:    "/conformance/items/abc",
+				Headers: map[string]string{"X-Request-ID": "not-a-uuid"},
+			},
+			Want: Response{
+				Status: http.StatusBadRequest,
+				Body:   `{"violations":[{"field":"X-Request-ID"}]}`,
+				Subset: true,
+			},
+		},
+
+		// Path and enum query parameters
+		{
+			Name: "params/path_and_custom_enum_value",
+			Request: Request{
+				Method:  http.MethodGet,
+				Path:    "/conformance/items/abc?priority=high",
+				Headers: withRequestID,
+			},
+			Want:   Response{Status: http.StatusOK, Body: `{"id":"abc","priority":"high"}`, Subset: true},
+			Echoed: true,
+		},
+		{
+			Name: "params/enum_proto_name",
+			Request: Request{
+				Method:  http.MethodGet,
+				Path:    "/conformance/items/abc?priority=PRIORITY_LOW",
+				Headers: withRequestID,
+			},
+			Want:   Response{Status: http.StatusOK, Body: `{"id":"abc","priority":"low"}`, Subset: true},
+			Echoed: true,
+			Skip: map[Target]string{
+				TargetTSServer: "TS server passes enum query strings through without mapping proto names",
+			},
+		},
+		{
+			Name: "params/enum_unknown_value",
+			Request: Request{
+				Method:  http.MethodGet,
+				Path:    "/conformance/items/abc?priority=urgent",
+				Headers: withRequestID,
+			},
+			Want: Response{
+				Status: http.StatusBadRequest,
+				Body:   `{"violations":[{"field":"priority"}]}`,
+				Subset: true,
+			},
+			Skip: map[Target]string{
+				TargetTSServer: "TS server does not validate enum query values",
+			},
+		},
+
+		// Body validation
+		{
+			Name: "validation/body_constraints",
+			Request: Request{
+				Method: http.MethodPost,
+				Path:   "/conformance/items",
+				Body:   `{"name":"","quantity":0}`,
+			},
+			Want: Response{
+				Status: http.StatusBadRequest,
+				Body:   `{"violations":[{"field":"name"},{"field":"quantity"}]}`,
+				Subset: true,
+			},
+			Skip: map[Target]string{
+				TargetTSServer: "TS server delegates body validation to ServerOptions.validateRequest",
+			},
+		},
+		{
+			Name: "validation/valid_body",
+			Request: Request{
+				Method: http.MethodPost,
+				Path:   "/conformance/items",
+				Body:   `{"name":"widget","quantity":3,"priority":"low"}`,
+			},
+			Want: Response{
+				Status: http.StatusOK,
+				Body:   `{"id":"new","name":"widget","quantity":3,"priority":"low"}`,
+			},
+			Echoed: true,
+		},
+
+		// Unwrap
+		{
+			Name:    "unwrap/root_repeated",
+			Request: Request{Method: http.MethodGet, Path: "/conformance/tags?tag=a&tag=b"},
+			Want:    Response{Status: http.StatusOK, Body: `["a","b"]`},
+			Echoed:  true,
+		},
+		{
+			Name:    "unwrap/root_repeated_empty",
+			Request: Request{Method: http.MethodGet, Path: "/conformance/tags"},
+			Want:    Response{Status: http.StatusOK, Body: `[]`},
+			Echoed:  true,
+		},
+
+		// Timestamps
+		{
+			Name: "timestamps/unix_seconds_and_date",
+			Request: Request{
+				Method: http.MethodPost,
+				Path:   "/conformance/schedule",
+				Body:   `{"startsAt":1700000000,"day":"2024-01-15"}`,
+			},
+			Want:   Response{Status: http.StatusOK, Body: `{"startsAt":1700000000,"day":"2024-01-15"}`},
+			Echoed: true,
+		},
+
+		// Oneofs
+		{
+			Name: "oneof/flattened_custom_discriminator_value",
+			Request: Request{
+				Method: http.MethodPost,
+				Path:   "/conformance/events",
+				Body:   `{"id":"e1","type":"url","url":"https://example.com"}`,
+			},
+			Want:   Response{Status: http.StatusOK, Body: `{"id":"e1","type":"url","url":"https://example.com"}`},
+			Echoed: true,
+		},
+		{
+			Name: "oneof/flattened_default_discriminator_value",
+			Request: Request{
+				Method: http.MethodPost,
+				Path:   "/conformance/events",
+				Body:   `{"id":"e2","type":"text","body":"hello"}`,
+			},
+			Want:   Response{Status: http.StatusOK, Body: `{"id":"e2","type":"text","body":"hello"}`},
+			Echoed: true,
+		},
+
+		// Streaming
+		{
+			Name:    "streaming/json_array",
+			Request: Request{Method: http.MethodGet, Path: "/conformance/stream/items?id=a&id=b"},
+			Want: Response{
+				Status: http.StatusOK,
+				Body:   `{"items":[{"id":"a"},{"id":"b"}]}`,
+				Subset: true,
+			},
+			Echoed: true,
+		},
+		{
+			Name:    "streaming/json_array_empty",
+			Request: Request{Method: http.MethodGet, Path: "/conformance/stream/items"},
+			Want:    Response{Status: http.StatusOK, Body: `{"items":[]}`},
+			Echoed:  true,
+		},
+		{
+			Name:    "streaming/json_array_error_before_first_item",
+			Request: Request{Method: http.MethodGet, Path: "/conformance/stream/items?fail=true"},
+			Want:    Response{Status: http.StatusInternalServerError, Body: `{"message":"stream failed"}`},
+			Skip:    map[Target]string{TargetGoMock: "the mock ignores fail"},
+		},
+		{
+			Name:    "streaming/json_array_error_after_first_item",
+			Request: Request{Method: http.MethodGet, Path: "/conformance/stream/items?id=a&fail=true"},
+			Want:    Response{Status: http.StatusOK, Truncated: true},
+			Skip:    map[Target]string{TargetGoMock: "the mock ignores fail"},
+		},
+		{
+			Name:    "streaming/sse_events",
+			Request: Request{Method: http.MethodGet, Path: "/conformance/watch/items?id=a&id=b"},
+			Want: Response{
+				Status: http.StatusOK,
+				Events: []Event{{Data: `{"id":"a"}`}, {Data: `{"id":"b"}`}},
+				Subset: true,
+			},
+			Echoed: true,
+		},
+		{
+			Name:    "streaming/sse_error_before_first_event",
+			Request: Request{Method: http.MethodGet, Path: "/conformance/watch/items?fail=true"},
+			Want:    Response{Status: http.StatusInternalServerError, Body: `{"message":"stream failed"}`},
+			Skip:    map[Target]string{TargetGoMock: "the mock ignores fail"},
+		},
+		{
+			Name:    "streaming/sse_error_after_first_event",
+			Request: Request{Method: http.MethodGet, Path: "/conformance/watch/items?id=a&fail=true"},
+			Want: Response{
+				Status: http.StatusOK,
+				Events: []Event{{Data: `{"id":"a"}`}, {Type: "error", Data: `"stream failed"`}},
+				Subset: true,
+			},
+			Skip: map[Target]string{TargetGoMock: "the mock ignores fail"},
+		},
+
+		// Unimplemented methods
+		{
+			Name:    "unimplemented/embedded_fallback",
+			Request: Request{Method: http.MethodPost, Path: "/conformance/items/abc/archive", Body: `{}`},
+			Want: Response{
+				Status: http.StatusNotImplemented,
+				Body:   `{"code":"UNIMPLEMENTED","message":"method ArchiveItem not implemented"}`,
+			},
+			Skip: map[Target]string{
+				TargetGoMock:   "the mock implements every method",
+				TargetTSServer: "TS handlers must implement every method; there is no Unimplemented fallback",
+			},
+		},
+
+		// Routing
+		{
+			Name:    "routing/unknown_path",
+			Request: Request{Method: http.MethodGet, Path: "/conformance/unknown"},
+			Want:    Response{Status: http.StatusNotFound},
+		},
+	}
+}
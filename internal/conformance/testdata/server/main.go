@@ -0,0 +1,102 @@
+// Command conformance-server serves the generated Go ConformanceService on a
+// random local port and prints the address on its first stdout line. It is
+// copied next to the generated code by the conformance test.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+
+	"testmod/generated"
+)
+
+// echoServer is the reference implementation: every RPC echoes its request,
+// except ArchiveItem, which falls through to the embedded Unimplemented server.
+// The streaming RPCs send an item per requested id, then fail when asked to.
+type echoServer struct {
+	generated.UnimplementedConformanceServiceServer
+}
+
+func (echoServer) GetItem(_ context.Context, req *generated.GetItemRequest) (*generated.Item, error) {
+	return &generated.Item{Id: req.GetItemId(), Priority: req.GetPriority()}, nil
+}
+
+func (echoServer) CreateItem(_ context.Context, req *generated.CreateItemRequest) (*generated.Item, error) {
+	return &generated.Item{
+		Id:       "new",
+		Name:     req.GetName(),
+		Quantity: req.GetQuantity(),
+		Priority: req.GetPriority(),
+	}, nil
+}
+
+func (echoServer) ListTags(_ context.Context, req *generated.ListTagsRequest) (*generated.TagList, error) {
+	return &generated.TagList{Tags: req.GetTags()}, nil
+}
+
+func (echoServer) Schedule(_ context.Context, req *generated.Schedule) (*generated.Schedule, error) {
+	return req, nil
+}
+
+func (echoServer) Publish(_ context.Context, req *generated.Event) (*generated.Event, error) {
+	return req, nil
+}
+
+func (echoServer) StreamItems(
+	_ context.Context,
+	req *generated.StreamItemsRequest,
+	yield func(*generated.Item) error,
+) error {
+	for _, id := range req.GetIds() {
+		if err := yield(&generated.Item{Id: id}); err != nil {
+			return err
+		}
+	}
+	if req.GetFail() {
+		return errors.New("stream failed")
+	}
+	return nil
+}
+
+func (echoServer) WatchItems(
+	_ context.Context,
+	req *generated.StreamItemsRequest,
+	sender generated.SSESender,
+) error {
+	for _, id := range req.GetIds() {
+		if err := sender.Send(&generated.Item{Id: id}); err != nil {
+			return err
+		}
+	}
+	if req.GetFail() {
+		return errors.New("stream failed")
+	}
+	return nil
+}
+
+func main() {
+	mock := flag.Bool("mock", false, "serve the generated mock instead of the echo handlers")
+	flag.Parse()
+
+	var impl generated.ConformanceServiceServer = echoServer{}
+	if *mock {
+		impl = generated.NewMockConformanceServiceServer()
+	}
+
+	mux := http.NewServeMux()
+	if err := generated.RegisterConformanceServiceServer(impl, generated.WithMux(mux)); err != nil {
+		log.Fatal(err)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(ln.Addr().String())
+	log.Fatal(http.Serve(ln, mux))
+}
@@ -0,0 +1,5 @@
+// Registers resolve-ts.mjs so the generated modules' ".js" import specifiers
+// resolve to their ".ts" sources under Node type stripping.
+import { register } from "node:module";
+
+register("./resolve-ts.mjs", import.meta.url);
@@ -0,0 +1,11 @@
+// Resolve hook: fall back from a missing relative "./x.js" to "./x.ts".
+export async function resolve(specifier, context, next) {
+  try {
+    return await next(specifier, context);
+  } catch (err) {
+    if (specifier.startsWith(".") && specifier.endsWith(".js")) {
+      return next(specifier.slice(0, -3) + ".ts", context);
+    }
+    throw err;
+  }
+}
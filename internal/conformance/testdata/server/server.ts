@@ -0,0 +1,50 @@
+// Serves the generated TS ConformanceService through the Node adapter on a
+// random local port and prints the address on its first stdout line. Run with
+// type stripping: node --experimental-strip-types --import ./register.mjs server.ts
+import { createServer } from "node:http";
+import type { AddressInfo } from "node:net";
+import {
+  type ConformanceServiceHandler,
+  createConformanceServiceRoutes,
+  createNodeHandler,
+} from "./conformance_server.ts";
+
+// Reference implementation: every RPC echoes its request, like the Go server.
+const handler: ConformanceServiceHandler = {
+  async getItem(_ctx, req) {
+    return { id: req.itemId, name: "", quantity: 0, priority: req.priority };
+  },
+  async createItem(_ctx, req) {
+    return { id: "new", name: req.name, quantity: req.quantity, priority: req.priority };
+  },
+  async listTags(_ctx, req) {
+    return req.tags;
+  },
+  async schedule(_ctx, req) {
+    return req;
+  },
+  async publish(_ctx, req) {
+    return req;
+  },
+  async streamItems(_ctx, req, send) {
+    for (const id of req.ids) {
+      await send({ id, name: "", quantity: 0, priority: "unspecified" });
+    }
+    if (req.fail) throw new Error("stream failed");
+  },
+  async watchItems(_ctx, req, send) {
+    for (const id of req.ids) {
+      await send({ id, name: "", quantity: 0, priority: "unspecified" });
+    }
+    if (req.fail) throw new Error("stream failed");
+  },
+  async archiveItem() {
+    throw new Error("method ArchiveItem not implemented");
+  },
+};
+
+const server = createServer(createNodeHandler(createConformanceServiceRoutes(handler)));
+server.listen(0, "127.0.0.1", () => {
+  const { address, port } = server.address() as AddressInfo;
+  console.log(`${address}:${port}`);
+});
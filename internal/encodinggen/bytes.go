@@ -1,6 +1,7 @@
-package httpgen
+package encodinggen
 
 import (
+	"slices"
 	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
@@ -25,8 +26,8 @@ type BytesEncodingFieldInfo struct {
 	Encoding http.BytesEncoding
 }
 
-// hasBytesEncodingFields returns true if any bytes field in the message has non-default encoding.
-func hasBytesEncodingFields(message *protogen.Message) bool {
+// HasBytesEncodingFields returns true if any bytes field in the message has non-default encoding.
+func HasBytesEncodingFields(message *protogen.Message) bool {
 	for _, field := range message.Fields {
 		if field.Desc.Kind() == protoreflect.BytesKind && annotations.HasBytesEncodingAnnotation(field) {
 			return true
@@ -42,15 +43,15 @@ func getBytesEncodingFields(message *protogen.Message) []*BytesEncodingFieldInfo
 		if field.Desc.Kind() == protoreflect.BytesKind && annotations.HasBytesEncodingAnnotation(field) {
 			fields = append(fields, &BytesEncodingFieldInfo{
 				Field:    field,
-				Encoding: annotations.GetBytesEncoding(field),
+				Encoding: annotations.ResolveBytesEncoding(field),
 			})
 		}
 	}
 	return fields
 }
 
-// collectBytesEncodingContext analyzes messages in a file and collects bytes encoding information.
-func collectBytesEncodingContext(file *protogen.File) []*BytesEncodingContext {
+// CollectBytesEncodingContext analyzes messages in a file and collects bytes encoding information.
+func CollectBytesEncodingContext(file *protogen.File) []*BytesEncodingContext {
 	var contexts []*BytesEncodingContext
 	collectBytesEncodingMessages(file.Messages, &contexts)
 	return contexts
@@ -59,7 +60,7 @@ func collectBytesEncodingContext(file *protogen.File) []*BytesEncodingContext {
 // collectBytesEncodingMessages recursively collects messages with non-default bytes encoding fields.
 func collectBytesEncodingMessages(messages []*protogen.Message, contexts *[]*BytesEncodingContext) {
 	for _, msg := range messages {
-		if hasBytesEncodingFields(msg) {
+		if HasBytesEncodingFields(msg) {
 			*contexts = append(*contexts, &BytesEncodingContext{
 				Message:     msg,
 				BytesFields: getBytesEncodingFields(msg),
@@ -91,33 +92,35 @@ func validateBytesEncodingInMessages(messages []*protogen.Message) error {
 	return nil
 }
 
-// generateBytesEncodingFile generates the *_bytes_encoding.pb.go file if needed.
-func (g *Generator) generateBytesEncodingFile(file *protogen.File) error {
+// GenerateBytesEncodingFile generates the *_bytes_encoding.pb.go file if needed.
+func (e *Emitter) GenerateBytesEncodingFile(file *protogen.File) error {
 	if err := validateBytesEncodingAnnotations(file); err != nil {
 		return err
 	}
 
-	contexts := collectBytesEncodingContext(file)
+	contexts := CollectBytesEncodingContext(file)
 	if len(contexts) == 0 {
 		return nil
 	}
 
 	filename := file.GeneratedFilenamePrefix + "_bytes_encoding.pb.go"
-	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
+	gf := e.plugin.NewGeneratedFile(filename, file.GoImportPath)
 
-	g.writeHeader(gf, file)
-	g.writeBytesEncodingImports(gf, contexts)
+	e.WriteHeader(gf, file)
+	e.writeBytesEncodingImports(gf, contexts, slices.ContainsFunc(contexts, func(ctx *BytesEncodingContext) bool {
+		return UsesSebufHTTP(ctx.Message)
+	}))
 
 	for _, ctx := range contexts {
-		g.generateBytesMarshalJSON(gf, ctx)
-		g.generateBytesUnmarshalJSON(gf, ctx)
+		e.generateBytesMarshalJSON(gf, ctx)
+		e.generateBytesUnmarshalJSON(gf, ctx)
 	}
 
 	return nil
 }
 
 // writeBytesEncodingImports writes the imports needed for bytes encoding.
-func (g *Generator) writeBytesEncodingImports(gf *protogen.GeneratedFile, contexts []*BytesEncodingContext) {
+func (e *Emitter) writeBytesEncodingImports(gf *protogen.GeneratedFile, contexts []*BytesEncodingContext, jsonNaming bool) {
 	needsBase64 := false
 	needsHex := false
 
@@ -148,6 +151,7 @@ func (g *Generator) writeBytesEncodingImports(gf *protogen.GeneratedFile, contex
 	gf.P(`"encoding/json"`)
 	gf.P()
 	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
+	WriteJSONNamingImport(gf, jsonNaming)
 	gf.P(")")
 	gf.P()
 }
@@ -156,7 +160,7 @@ func (g *Generator) writeBytesEncodingImports(gf *protogen.GeneratedFile, contex
 // with the configured encoding (HEX, BASE64_RAW, BASE64URL, BASE64URL_RAW).
 //
 //nolint:dupl // Code generation patterns naturally have similar structure across encoding types
-func (g *Generator) generateBytesMarshalJSON(gf *protogen.GeneratedFile, ctx *BytesEncodingContext) {
+func (e *Emitter) generateBytesMarshalJSON(gf *protogen.GeneratedFile, ctx *BytesEncodingContext) {
 	msgName := ctx.Message.GoIdent.GoName
 
 	var fieldNames []string
@@ -177,7 +181,7 @@ func (g *Generator) generateBytesMarshalJSON(gf *protogen.GeneratedFile, ctx *By
 	gf.P()
 
 	gf.P("// Use protojson for base serialization (handles all other fields correctly)")
-	gf.P("data, err := opts.Marshal(x)")
+	gf.P("data, err := ", ProtoMarshalCall(ctx.Message))
 	gf.P("if err != nil {")
 	gf.P("return nil, err")
 	gf.P("}")
@@ -191,7 +195,7 @@ func (g *Generator) generateBytesMarshalJSON(gf *protogen.GeneratedFile, ctx *By
 	gf.P()
 
 	for _, fieldInfo := range ctx.BytesFields {
-		g.generateBytesFieldMarshal(gf, fieldInfo)
+		e.generateBytesFieldMarshal(gf, fieldInfo)
 	}
 
 	gf.P("return json.Marshal(raw)")
@@ -207,16 +211,16 @@ func (g *Generator) generateBytesMarshalJSON(gf *protogen.GeneratedFile, ctx *By
 }
 
 // generateBytesFieldMarshal generates marshaling code for a single bytes field.
-func (g *Generator) generateBytesFieldMarshal(gf *protogen.GeneratedFile, fieldInfo *BytesEncodingFieldInfo) {
+func (e *Emitter) generateBytesFieldMarshal(gf *protogen.GeneratedFile, fieldInfo *BytesEncodingFieldInfo) {
 	field := fieldInfo.Field
 	goName := field.GoName
-	jsonName := field.Desc.JSONName()
+	jsonName := annotations.JSONFieldName(field)
 	encoding := fieldInfo.Encoding
 
 	gf.P("// Encode ", field.Desc.Name(), " with ", encoding.String())
 	gf.P("if len(x.", goName, ") > 0 {")
 
-	//exhaustive:ignore -- only non-default encodings reach here; UNSPECIFIED/BASE64 are filtered by hasBytesEncodingFields
+	//exhaustive:ignore -- only non-default encodings reach here; UNSPECIFIED/BASE64 are filtered by HasBytesEncodingFields
 	switch encoding {
 	case http.BytesEncoding_BYTES_ENCODING_HEX:
 		gf.P(`raw["`, jsonName, `"], _ = json.Marshal(hex.EncodeToString(x.`, goName, `))`)
@@ -238,7 +242,7 @@ func (g *Generator) generateBytesFieldMarshal(gf *protogen.GeneratedFile, fieldI
 // from the configured encoding back to standard base64 for protojson.
 //
 //nolint:dupl // Code generation patterns naturally have similar structure across encoding types
-func (g *Generator) generateBytesUnmarshalJSON(gf *protogen.GeneratedFile, ctx *BytesEncodingContext) {
+func (e *Emitter) generateBytesUnmarshalJSON(gf *protogen.GeneratedFile, ctx *BytesEncodingContext) {
 	msgName := ctx.Message.GoIdent.GoName
 
 	var fieldNames []string
@@ -246,18 +250,18 @@ func (g *Generator) generateBytesUnmarshalJSON(gf *protogen.GeneratedFile, ctx *
 		fieldNames = append(fieldNames, string(f.Field.Desc.Name()))
 	}
 
-	gf.P("// UnmarshalJSON implements json.Unmarshaler for ", msgName, ".")
-	gf.P("// This method handles bytes_encoding fields: ", strings.Join(fieldNames, ", "))
-	gf.P("func (x *", msgName, ") UnmarshalJSON(data []byte) error {")
+	WriteAlternateJSONNames(gf, ctx.Message)
+	e.writeUnmarshalJSONSignature(gf, msgName, "This method handles bytes_encoding fields: "+strings.Join(fieldNames, ", "))
 	gf.P("// Parse the raw JSON to extract bytes-encoded fields")
 	gf.P("var raw map[string]json.RawMessage")
 	gf.P("if err := json.Unmarshal(data, &raw); err != nil {")
 	gf.P("return err")
 	gf.P("}")
+	WriteResolveAlternateJSONNames(gf, ctx.Message)
 	gf.P()
 
 	for _, fieldInfo := range ctx.BytesFields {
-		g.generateBytesFieldUnmarshal(gf, fieldInfo)
+		e.generateBytesFieldUnmarshal(gf, fieldInfo)
 	}
 
 	gf.P("// Re-marshal with standard base64 values for protojson")
@@ -267,16 +271,14 @@ func (g *Generator) generateBytesUnmarshalJSON(gf *protogen.GeneratedFile, ctx *
 	gf.P("}")
 	gf.P()
 	gf.P("// Use protojson to unmarshal the rest")
-	gf.P("return protojson.Unmarshal(modified, x)")
-	gf.P("}")
-	gf.P()
+	e.writeProtoUnmarshalReturn(gf, msgName, "modified")
 }
 
 // generateBytesFieldUnmarshal generates unmarshaling code for a single bytes field.
 // It decodes from the configured encoding, then re-encodes as standard base64 for protojson.
-func (g *Generator) generateBytesFieldUnmarshal(gf *protogen.GeneratedFile, fieldInfo *BytesEncodingFieldInfo) {
+func (e *Emitter) generateBytesFieldUnmarshal(gf *protogen.GeneratedFile, fieldInfo *BytesEncodingFieldInfo) {
 	field := fieldInfo.Field
-	jsonName := field.Desc.JSONName()
+	jsonName := annotations.JSONFieldName(field)
 	encoding := fieldInfo.Encoding
 
 	gf.P("// Decode ", field.Desc.Name(), " from ", encoding.String(), " to standard base64")
@@ -284,7 +286,7 @@ func (g *Generator) generateBytesFieldUnmarshal(gf *protogen.GeneratedFile, fiel
 	gf.P("var s string")
 	gf.P("if err := json.Unmarshal(v, &s); err == nil {")
 
-	//exhaustive:ignore -- only non-default encodings reach here; UNSPECIFIED/BASE64 are filtered by hasBytesEncodingFields
+	//exhaustive:ignore -- only non-default encodings reach here; UNSPECIFIED/BASE64 are filtered by HasBytesEncodingFields
 	switch encoding {
 	case http.BytesEncoding_BYTES_ENCODING_HEX:
 		gf.P("decoded, decErr := hex.DecodeString(s)")
@@ -0,0 +1,160 @@
+// Package encodinggen emits the custom JSON encoders shared by protoc-gen-go-http
+// and protoc-gen-go-client: int64 NUMBER encoding, custom enum values, enum
+// field encoding, bytes encoding and timestamp formats.
+//
+// Both plugins may generate into the same Go package, so their encoders must be
+// byte-for-byte compatible. Keeping a single emitter, parameterized by the
+// generator name and the unmarshal style, makes drift between them impossible.
+package encodinggen
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// Options configure an Emitter for the plugin that owns it.
+type Options struct {
+	// Generator is the plugin name written in the "Code generated by" header,
+	// e.g. "protoc-gen-go-http".
+	Generator string
+	// SebufUnmarshaler makes the bytes and timestamp encoders emit
+	// UnmarshalJSONSebuf, which forwards the caller's protojson.UnmarshalOptions,
+	// with UnmarshalJSON as a wrapper using the default options.
+	SebufUnmarshaler bool
+	// AllEnumHelpers generates the enum helpers of every enum, not only of
+	// those with enum_value mappings.
+	AllEnumHelpers bool
+}
+
+// Emitter writes the *_encoding.pb.go family of files for a plugin.
+type Emitter struct {
+	plugin *protogen.Plugin
+	opts   Options
+}
+
+// New creates an Emitter writing into plugin.
+func New(plugin *protogen.Plugin, opts Options) *Emitter {
+	return &Emitter{plugin: plugin, opts: opts}
+}
+
+// WriteHeader writes the generated-code header and package clause of a file.
+func (e *Emitter) WriteHeader(gf *protogen.GeneratedFile, file *protogen.File) {
+	gf.P("// Code generated by ", e.opts.Generator, ". DO NOT EDIT.")
+	gf.P("// source: ", file.Desc.Path())
+	gf.P()
+	gf.P("package ", file.GoPackageName)
+	gf.P()
+}
+
+// writeUnmarshalJSONSignature opens the unmarshal method of msgName, whose doc
+// comment ends with note. With SebufUnmarshaler it opens UnmarshalJSONSebuf,
+// otherwise UnmarshalJSON.
+func (e *Emitter) writeUnmarshalJSONSignature(gf *protogen.GeneratedFile, msgName, note string) {
+	if e.opts.SebufUnmarshaler {
+		gf.P("// UnmarshalJSONSebuf implements sebufUnmarshaler for ", msgName, ".")
+		gf.P("// ", note)
+		gf.P("func (x *", msgName, ") UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error {")
+		return
+	}
+	gf.P("// UnmarshalJSON implements json.Unmarshaler for ", msgName, ".")
+	gf.P("// ", note)
+	gf.P("func (x *", msgName, ") UnmarshalJSON(data []byte) error {")
+}
+
+// writeProtoUnmarshalReturn closes the unmarshal method opened by
+// writeUnmarshalJSONSignature, handing the rewritten JSON to protojson, and
+// writes the UnmarshalJSON wrapper when SebufUnmarshaler is set.
+func (e *Emitter) writeProtoUnmarshalReturn(gf *protogen.GeneratedFile, msgName, data string) {
+	if !e.opts.SebufUnmarshaler {
+		gf.P("return protojson.Unmarshal(", data, ", x)")
+		gf.P("}")
+		gf.P()
+		return
+	}
+	gf.P("return opts.Unmarshal(", data, ", x)")
+	gf.P("}")
+	gf.P()
+
+	// Backward-compatible UnmarshalJSON wrapper for stdlib encoding/json
+	gf.P("// UnmarshalJSON implements json.Unmarshaler for ", msgName, ".")
+	gf.P("func (x *", msgName, ") UnmarshalJSON(data []byte) error {")
+	gf.P("return x.UnmarshalJSONSebuf(data, protojson.UnmarshalOptions{})")
+	gf.P("}")
+	gf.P()
+}
+
+// ProtoMarshalCall returns the call encoders use for the base serialization of
+// x: protojson, or sebufhttp.MarshalProtoJSON when the json_naming policy
+// renames fields of the message or of its nested messages.
+func ProtoMarshalCall(message *protogen.Message) string {
+	if annotations.HasJSONNaming(message) {
+		return "sebufhttp.MarshalProtoJSON(opts, x)"
+	}
+	return "opts.Marshal(x)"
+}
+
+// WriteJSONNamingImport closes an import block with the sebufhttp import when
+// the file's encoders call sebufhttp.MarshalProtoJSON or resolve alternate
+// field names.
+func WriteJSONNamingImport(gf *protogen.GeneratedFile, jsonNaming bool) {
+	if !jsonNaming {
+		return
+	}
+	gf.P()
+	gf.P(`sebufhttp "github.com/SebastienMelki/sebuf/http"`)
+}
+
+// UsesSebufHTTP reports whether the encoders of message call the sebufhttp
+// package: to apply its json_naming policy, or to resolve the alternate names
+// of its fields.
+func UsesSebufHTTP(message *protogen.Message) bool {
+	return annotations.HasJSONNaming(message) || annotations.AlternateJSONNames(message) != nil
+}
+
+// alternateJSONNamesVar returns the name of the variable listing the alternate
+// JSON names of the fields of message.
+func alternateJSONNamesVar(message *protogen.Message) string {
+	name := message.GoIdent.GoName
+	return strings.ToLower(name[:1]) + name[1:] + "AlternateJSONNames"
+}
+
+// WriteAlternateJSONNames declares the variable mapping the alternate JSON names
+// of the fields of message to their JSON keys, which the call written by
+// WriteResolveAlternateJSONNames reads. It writes nothing when no field has an
+// alternate name.
+func WriteAlternateJSONNames(gf *protogen.GeneratedFile, message *protogen.Message) {
+	names := annotations.AlternateJSONNames(message)
+	if names == nil {
+		return
+	}
+	gf.P("// ", alternateJSONNamesVar(message), " maps the proto names and JSON names protojson")
+	gf.P("// accepts for the fields of ", message.GoIdent.GoName, " to the keys UnmarshalJSON reads.")
+	gf.P("var ", alternateJSONNamesVar(message), " = map[string]string{")
+	for _, alternate := range slices.Sorted(maps.Keys(names)) {
+		gf.P(strconv.Quote(alternate), ": ", strconv.Quote(names[alternate]), ",")
+	}
+	gf.P("}")
+	gf.P()
+}
+
+// WriteResolveAlternateJSONNames writes, once the JSON object of message is
+// decoded into raw, the call renaming the alternate names of its fields to
+// their JSON keys, or rejecting them under reject_alternate_names.
+func WriteResolveAlternateJSONNames(gf *protogen.GeneratedFile, message *protogen.Message) {
+	if annotations.AlternateJSONNames(message) == nil {
+		return
+	}
+	resolve := "ResolveAlternateJSONNames"
+	if annotations.RejectsAlternateJSONNames(message) {
+		resolve = "RejectAlternateJSONNames"
+	}
+	gf.P("if err := sebufhttp.", resolve, "(raw, ", alternateJSONNamesVar(message), "); err != nil {")
+	gf.P("return err")
+	gf.P("}")
+}
@@ -0,0 +1,45 @@
+package encodinggen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// featureEmitters are the plugin files allowed to emit a MarshalJSON of their
+// own: features that have no counterpart in this package.
+var featureEmitters = map[string]bool{
+	"empty_behavior.go":      true,
+	"flatten.go":             true,
+	"json_naming.go":         true,
+	"nullable.go":            true,
+	"oneof_discriminator.go": true,
+	"unwrap.go":              true,
+}
+
+// TestEncodersOnlyEmittedHere guards against the int64, enum, bytes and
+// timestamp encoders being copied back into protoc-gen-go-http or
+// protoc-gen-go-client, where the two copies would drift apart.
+func TestEncodersOnlyEmittedHere(t *testing.T) {
+	for _, pkg := range []string{"httpgen", "clientgen"} {
+		paths, err := filepath.Glob(filepath.Join("..", pkg, "*.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, path := range paths {
+			name := filepath.Base(path)
+			if strings.HasSuffix(name, "_test.go") || featureEmitters[name] {
+				continue
+			}
+			content, readErr := os.ReadFile(path)
+			if readErr != nil {
+				t.Fatal(readErr)
+			}
+			if strings.Contains(string(content), `") MarshalJSON(`) {
+				t.Errorf("%s/%s emits a MarshalJSON; encoders shared by the Go plugins belong in encodinggen",
+					pkg, name)
+			}
+		}
+	}
+}
@@ -1,4 +1,4 @@
-package clientgen
+package encodinggen
 
 import (
 	"fmt"
@@ -73,7 +73,8 @@ func hasCustomEnumValues(enum *protogen.Enum) bool {
 	return annotations.HasAnyEnumValueMapping(enum)
 }
 
-func (g *Generator) generateEnumEncodingFile(file *protogen.File) error {
+// GenerateEnumEncodingFile generates the *_enum_encoding.pb.go file if any enum has custom values.
+func (e *Emitter) GenerateEnumEncodingFile(file *protogen.File) error {
 	contexts := collectEnumsWithCustomValues(file)
 
 	if len(contexts) == 0 {
@@ -81,21 +82,21 @@ func (g *Generator) generateEnumEncodingFile(file *protogen.File) error {
 	}
 
 	filename := file.GeneratedFilenamePrefix + "_enum_encoding.pb.go"
-	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
+	gf := e.plugin.NewGeneratedFile(filename, file.GoImportPath)
 
-	g.writeEncodingHeader(gf, file)
-	g.writeEnumEncodingImports(gf)
+	e.WriteHeader(gf, file)
+	e.writeEnumEncodingImports(gf)
 
 	for _, ctx := range contexts {
-		g.generateEnumLookupMaps(gf, ctx.Enum)
-		g.generateEnumMarshalJSON(gf, ctx.Enum)
-		g.generateEnumUnmarshalJSON(gf, ctx.Enum)
+		e.generateEnumLookupMaps(gf, ctx.Enum)
+		e.generateEnumMarshalJSON(gf, ctx.Enum)
+		e.generateEnumUnmarshalJSON(gf, ctx.Enum)
 	}
 
 	return nil
 }
 
-func (g *Generator) writeEnumEncodingImports(gf *protogen.GeneratedFile) {
+func (e *Emitter) writeEnumEncodingImports(gf *protogen.GeneratedFile) {
 	gf.P("import (")
 	gf.P("\"encoding/json\"")
 	gf.P("\"fmt\"")
@@ -103,7 +104,7 @@ func (g *Generator) writeEnumEncodingImports(gf *protogen.GeneratedFile) {
 	gf.P()
 }
 
-func (g *Generator) generateEnumLookupMaps(gf *protogen.GeneratedFile, enum *protogen.Enum) {
+func (e *Emitter) generateEnumLookupMaps(gf *protogen.GeneratedFile, enum *protogen.Enum) {
 	enumName := enum.GoIdent.GoName
 	lowerName := annotations.LowerFirst(enumName)
 
@@ -139,7 +140,7 @@ func (g *Generator) generateEnumLookupMaps(gf *protogen.GeneratedFile, enum *pro
 	gf.P()
 }
 
-func (g *Generator) generateEnumMarshalJSON(gf *protogen.GeneratedFile, enum *protogen.Enum) {
+func (e *Emitter) generateEnumMarshalJSON(gf *protogen.GeneratedFile, enum *protogen.Enum) {
 	enumName := enum.GoIdent.GoName
 	lowerName := annotations.LowerFirst(enumName)
 
@@ -152,7 +153,7 @@ func (g *Generator) generateEnumMarshalJSON(gf *protogen.GeneratedFile, enum *pr
 	gf.P()
 }
 
-func (g *Generator) generateEnumUnmarshalJSON(gf *protogen.GeneratedFile, enum *protogen.Enum) {
+func (e *Emitter) generateEnumUnmarshalJSON(gf *protogen.GeneratedFile, enum *protogen.Enum) {
 	enumName := enum.GoIdent.GoName
 	lowerName := annotations.LowerFirst(enumName)
 
@@ -177,16 +178,17 @@ func (g *Generator) generateEnumUnmarshalJSON(gf *protogen.GeneratedFile, enum *
 	gf.P()
 }
 
-func (g *Generator) validateEnumAnnotationsInFile(file *protogen.File) error {
+// ValidateEnumAnnotationsInFile checks the enum_value annotations of every enum field in file.
+func ValidateEnumAnnotationsInFile(file *protogen.File) error {
 	for _, msg := range file.Messages {
-		if err := g.validateEnumAnnotationsInMessage(msg); err != nil {
+		if err := validateEnumAnnotationsInMessage(msg); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (g *Generator) validateEnumAnnotationsInMessage(msg *protogen.Message) error {
+func validateEnumAnnotationsInMessage(msg *protogen.Message) error {
 	for _, field := range msg.Fields {
 		if field.Desc.Kind() == protoreflect.EnumKind {
 			if err := validateEnumAnnotations(field); err != nil {
@@ -196,7 +198,7 @@ func (g *Generator) validateEnumAnnotationsInMessage(msg *protogen.Message) erro
 	}
 
 	for _, nested := range msg.Messages {
-		if err := g.validateEnumAnnotationsInMessage(nested); err != nil {
+		if err := validateEnumAnnotationsInMessage(nested); err != nil {
 			return err
 		}
 	}
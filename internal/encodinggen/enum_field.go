@@ -1,7 +1,8 @@
-package httpgen
+package encodinggen
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -47,7 +48,7 @@ type EnumFieldEncodingContext struct {
 // map fields), regardless of Go package, or nil if the field does not reference a custom-value,
 // string-encoded enum. NUMBER-encoded enums never carry custom string values.
 func customEnumForField(field *protogen.Field) *protogen.Enum {
-	if annotations.GetEnumEncoding(field) == http.EnumEncoding_ENUM_ENCODING_NUMBER {
+	if annotations.ResolveEnumEncoding(field) == http.EnumEncoding_ENUM_ENCODING_NUMBER {
 		return nil
 	}
 
@@ -147,8 +148,8 @@ func customEnumFieldInfo(field *protogen.Field, pkg protogen.GoImportPath) *Enum
 	return &EnumFieldInfo{Field: field, Enum: enum, Shape: shape}
 }
 
-// getCustomEnumFields returns the direct custom-enum fields of a message.
-func getCustomEnumFields(msg *protogen.Message) []*EnumFieldInfo {
+// GetCustomEnumFields returns the direct custom-enum fields of a message.
+func GetCustomEnumFields(msg *protogen.Message) []*EnumFieldInfo {
 	pkg := msg.GoIdent.GoImportPath
 	var fields []*EnumFieldInfo
 	for _, field := range msg.Fields {
@@ -159,9 +160,9 @@ func getCustomEnumFields(msg *protogen.Message) []*EnumFieldInfo {
 	return fields
 }
 
-// getNestedEnumMessageFields returns the singular/repeated message fields of a message whose type
+// GetNestedEnumMessageFields returns the singular/repeated message fields of a message whose type
 // transitively contains a custom enum (so they must be re-serialized through the child marshaler).
-func getNestedEnumMessageFields(msg *protogen.Message) []*protogen.Field {
+func GetNestedEnumMessageFields(msg *protogen.Message) []*protogen.Field {
 	var fields []*protogen.Field
 	for _, field := range msg.Fields {
 		if nestedMessageChild(field) != nil && fieldTransitivelyHasCustomEnum(field) {
@@ -171,9 +172,9 @@ func getNestedEnumMessageFields(msg *protogen.Message) []*protogen.Field {
 	return fields
 }
 
-// hasCustomEnumFields reports whether a message directly carries any custom enum_value field.
-func hasCustomEnumFields(msg *protogen.Message) bool {
-	return len(getCustomEnumFields(msg)) > 0
+// HasCustomEnumFields reports whether a message directly carries any custom enum_value field.
+func HasCustomEnumFields(msg *protogen.Message) bool {
+	return len(GetCustomEnumFields(msg)) > 0
 }
 
 // validateEnumFieldEncoding fails loudly for cases the Go generator cannot encode:
@@ -192,7 +193,7 @@ func validateEnumFieldEncodingMessages(messages []*protogen.Message) error {
 		if msg.Desc.IsMapEntry() {
 			continue
 		}
-		if err := validateEnumFieldEncodingFields(msg); err != nil {
+		if err := ValidateEnumFieldEncodingFields(msg); err != nil {
 			return err
 		}
 		if err := validateEnumFieldEncodingMessages(msg.Messages); err != nil {
@@ -202,7 +203,9 @@ func validateEnumFieldEncodingMessages(messages []*protogen.Message) error {
 	return nil
 }
 
-func validateEnumFieldEncodingFields(msg *protogen.Message) error {
+// ValidateEnumFieldEncodingFields rejects the custom enum fields of msg the encoder cannot
+// handle: enums from another Go package and enums inside map values.
+func ValidateEnumFieldEncodingFields(msg *protogen.Message) error {
 	pkg := msg.GoIdent.GoImportPath
 	for _, field := range msg.Fields {
 		if enum := customEnumForField(field); enum != nil &&
@@ -226,8 +229,8 @@ func validateEnumFieldEncodingFields(msg *protogen.Message) error {
 	return nil
 }
 
-// collectEnumFieldEncodingContext gathers messages that need a custom enum-field marshaler.
-func collectEnumFieldEncodingContext(file *protogen.File) []*EnumFieldEncodingContext {
+// CollectEnumFieldEncodingContext gathers messages that need a custom enum-field marshaler.
+func CollectEnumFieldEncodingContext(file *protogen.File) []*EnumFieldEncodingContext {
 	var contexts []*EnumFieldEncodingContext
 	collectEnumFieldEncodingMessages(file.Messages, &contexts)
 	return contexts
@@ -241,8 +244,8 @@ func collectEnumFieldEncodingMessages(
 		if msg.Desc.IsMapEntry() {
 			continue
 		}
-		enumFields := getCustomEnumFields(msg)
-		nestedFields := getNestedEnumMessageFields(msg)
+		enumFields := GetCustomEnumFields(msg)
+		nestedFields := GetNestedEnumMessageFields(msg)
 		if len(enumFields) > 0 || len(nestedFields) > 0 {
 			*contexts = append(*contexts, &EnumFieldEncodingContext{
 				Message:      msg,
@@ -254,32 +257,32 @@ func collectEnumFieldEncodingMessages(
 	}
 }
 
-// checkEnumMarshalJSONConflict returns an error if a message that needs a custom enum-field
+// CheckEnumMarshalJSONConflict returns an error if a message that needs a custom enum-field
 // marshaler also carries another MarshalJSON-generating annotation. Only one feature can own a
 // message's MarshalJSON/UnmarshalJSON methods, so combining them would produce duplicate method
 // declarations. Fail fast with a clear message (matching flatten/oneof behavior).
-func checkEnumMarshalJSONConflict(msg *protogen.Message) error {
+func CheckEnumMarshalJSONConflict(msg *protogen.Message) error {
 	var conflicts []string
 
-	if hasInt64NumberFields(msg) {
+	if HasInt64NumberFields(msg) {
 		conflicts = append(conflicts, "int64_encoding=NUMBER")
 	}
-	if hasBytesEncodingFields(msg) {
+	if HasBytesEncodingFields(msg) {
 		conflicts = append(conflicts, "bytes_encoding")
 	}
-	if hasNullableFields(msg) {
+	if slices.ContainsFunc(msg.Fields, annotations.IsNullableField) {
 		conflicts = append(conflicts, "nullable")
 	}
-	if hasEmptyBehaviorFields(msg) {
+	if slices.ContainsFunc(msg.Fields, annotations.HasEmptyBehaviorAnnotation) {
 		conflicts = append(conflicts, "empty_behavior")
 	}
-	if hasTimestampFormatFields(msg) {
+	if HasTimestampFormatFields(msg) {
 		conflicts = append(conflicts, "timestamp_format")
 	}
-	if hasFlattenFields(msg) {
+	if annotations.HasFlattenFields(msg) {
 		conflicts = append(conflicts, "flatten")
 	}
-	if hasOneofDiscriminator(msg) {
+	if annotations.HasOneofDiscriminator(msg) {
 		conflicts = append(conflicts, "oneof_config")
 	}
 	if annotations.IsRootUnwrap(msg) {
@@ -304,53 +307,56 @@ func checkEnumMarshalJSONConflict(msg *protogen.Message) error {
 // which would make it an int64 wrapper (also generating MarshalJSONSebuf).
 func nestsInt64NumberMessage(msg *protogen.Message) bool {
 	for _, field := range msg.Fields {
-		if child := nestedMessageChild(field); child != nil && hasInt64NumberFields(child) {
+		if child := nestedMessageChild(field); child != nil && HasInt64NumberFields(child) {
 			return true
 		}
 	}
 	return false
 }
 
-// generateEnumFieldEncodingFile generates the *_enum_field_encoding.pb.go file if needed.
+// GenerateEnumFieldEncodingFile generates the *_enum_field_encoding.pb.go file if needed.
 // It emits message-level MarshalJSON/UnmarshalJSON that translate enum fields between the raw
 // proto value names protojson uses and the custom enum_value strings (reusing the lookup maps in
 // *_enum_encoding.pb.go), and re-serialize nested messages so custom strings propagate through
 // the whole message tree.
-func (g *Generator) generateEnumFieldEncodingFile(file *protogen.File) error {
+func (e *Emitter) GenerateEnumFieldEncodingFile(file *protogen.File) error {
 	if err := validateEnumFieldEncoding(file); err != nil {
 		return err
 	}
 
-	contexts := collectEnumFieldEncodingContext(file)
+	contexts := CollectEnumFieldEncodingContext(file)
 	if len(contexts) == 0 {
 		return nil
 	}
 
 	for _, ctx := range contexts {
-		if err := checkEnumMarshalJSONConflict(ctx.Message); err != nil {
+		if err := CheckEnumMarshalJSONConflict(ctx.Message); err != nil {
 			return err
 		}
 	}
 
 	filename := file.GeneratedFilenamePrefix + "_enum_field_encoding.pb.go"
-	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
+	gf := e.plugin.NewGeneratedFile(filename, file.GoImportPath)
 
-	g.writeHeader(gf, file)
-	g.writeEnumFieldEncodingImports(gf)
+	e.WriteHeader(gf, file)
+	e.writeEnumFieldEncodingImports(gf, slices.ContainsFunc(contexts, func(ctx *EnumFieldEncodingContext) bool {
+		return UsesSebufHTTP(ctx.Message)
+	}))
 
 	for _, ctx := range contexts {
-		g.generateEnumFieldMarshalJSON(gf, ctx)
-		g.generateEnumFieldUnmarshalJSON(gf, ctx)
+		e.generateEnumFieldMarshalJSON(gf, ctx)
+		e.generateEnumFieldUnmarshalJSON(gf, ctx)
 	}
 
 	return nil
 }
 
-func (g *Generator) writeEnumFieldEncodingImports(gf *protogen.GeneratedFile) {
+func (e *Emitter) writeEnumFieldEncodingImports(gf *protogen.GeneratedFile, jsonNaming bool) {
 	gf.P("import (")
 	gf.P(`"encoding/json"`)
 	gf.P()
 	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
+	WriteJSONNamingImport(gf, jsonNaming)
 	gf.P(")")
 	gf.P()
 }
@@ -359,7 +365,7 @@ func (g *Generator) writeEnumFieldEncodingImports(gf *protogen.GeneratedFile) {
 // under: the camelCase JSON name and, when different, the proto (snake_case) name. protojson emits
 // the proto name when MarshalOptions.UseProtoNames is set, so both must be patched.
 func enumFieldJSONKeys(field *protogen.Field) string {
-	jsonName := field.Desc.JSONName()
+	jsonName := annotations.JSONFieldName(field)
 	protoName := string(field.Desc.Name())
 	if jsonName == protoName {
 		return strconv.Quote(jsonName)
@@ -382,7 +388,7 @@ func marshalerNames(ctx *EnumFieldEncodingContext) string {
 
 // generateEnumFieldMarshalJSON emits MarshalJSONSebuf (+ MarshalJSON wrapper) that rewrites direct
 // enum fields to their custom strings and re-serializes nested messages via their marshaler.
-func (g *Generator) generateEnumFieldMarshalJSON(gf *protogen.GeneratedFile, ctx *EnumFieldEncodingContext) {
+func (e *Emitter) generateEnumFieldMarshalJSON(gf *protogen.GeneratedFile, ctx *EnumFieldEncodingContext) {
 	msgName := ctx.Message.GoIdent.GoName
 
 	gf.P("// MarshalJSONSebuf implements sebufMarshaler for ", msgName, ".")
@@ -394,7 +400,7 @@ func (g *Generator) generateEnumFieldMarshalJSON(gf *protogen.GeneratedFile, ctx
 	gf.P()
 
 	gf.P("// Use protojson for base serialization (handles all other fields correctly)")
-	gf.P("data, err := opts.Marshal(x)")
+	gf.P("data, err := ", ProtoMarshalCall(ctx.Message))
 	gf.P("if err != nil {")
 	gf.P("return nil, err")
 	gf.P("}")
@@ -408,10 +414,10 @@ func (g *Generator) generateEnumFieldMarshalJSON(gf *protogen.GeneratedFile, ctx
 	gf.P()
 
 	for _, info := range ctx.EnumFields {
-		g.generateEnumFieldMarshal(gf, info)
+		e.generateEnumFieldMarshal(gf, info)
 	}
 	for _, field := range ctx.NestedFields {
-		g.generateNestedMessageMarshal(gf, field)
+		e.generateNestedMessageMarshal(gf, field)
 	}
 
 	gf.P("return json.Marshal(raw)")
@@ -427,7 +433,7 @@ func (g *Generator) generateEnumFieldMarshalJSON(gf *protogen.GeneratedFile, ctx
 
 // generateEnumFieldMarshal emits the map-patching code for one enum field (proto name -> custom).
 // It patches both the JSON name and proto name keys so UseProtoNames output is handled.
-func (g *Generator) generateEnumFieldMarshal(gf *protogen.GeneratedFile, info *EnumFieldInfo) {
+func (e *Emitter) generateEnumFieldMarshal(gf *protogen.GeneratedFile, info *EnumFieldInfo) {
 	lower := annotations.LowerFirst(info.Enum.GoIdent.GoName)
 	toJSON := lower + "ToJSON"
 	fromJSON := lower + "FromJSON"
@@ -480,8 +486,8 @@ func (g *Generator) generateEnumFieldMarshal(gf *protogen.GeneratedFile, info *E
 // MarshalJSONSebuf (forwarding opts) so custom enum strings propagate, mirroring the int64 wrapper.
 // The result is written under whichever JSON key protojson emitted (camelCase, or the proto
 // snake_case name under UseProtoNames).
-func (g *Generator) generateNestedMessageMarshal(gf *protogen.GeneratedFile, field *protogen.Field) {
-	jsonName := field.Desc.JSONName()
+func (e *Emitter) generateNestedMessageMarshal(gf *protogen.GeneratedFile, field *protogen.Field) {
+	jsonName := annotations.JSONFieldName(field)
 	keys := enumFieldJSONKeys(field)
 
 	if field.Desc.IsList() {
@@ -537,9 +543,10 @@ func (g *Generator) generateNestedMessageMarshal(gf *protogen.GeneratedFile, fie
 
 // generateEnumFieldUnmarshalJSON emits UnmarshalJSONSebuf (+ UnmarshalJSON wrapper) that rewrites
 // incoming custom enum_value strings back to proto value names and delegates nested message parsing.
-func (g *Generator) generateEnumFieldUnmarshalJSON(gf *protogen.GeneratedFile, ctx *EnumFieldEncodingContext) {
+func (e *Emitter) generateEnumFieldUnmarshalJSON(gf *protogen.GeneratedFile, ctx *EnumFieldEncodingContext) {
 	msgName := ctx.Message.GoIdent.GoName
 
+	WriteAlternateJSONNames(gf, ctx.Message)
 	gf.P("// UnmarshalJSONSebuf implements sebufUnmarshaler for ", msgName, ".")
 	gf.P("// This method handles enum_value fields and nested messages: ", marshalerNames(ctx))
 	gf.P("func (x *", msgName, ") UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error {")
@@ -548,13 +555,14 @@ func (g *Generator) generateEnumFieldUnmarshalJSON(gf *protogen.GeneratedFile, c
 	gf.P("if err := json.Unmarshal(data, &raw); err != nil {")
 	gf.P("return err")
 	gf.P("}")
+	WriteResolveAlternateJSONNames(gf, ctx.Message)
 	gf.P()
 
 	for _, info := range ctx.EnumFields {
-		g.generateEnumFieldUnmarshal(gf, info)
+		e.generateEnumFieldUnmarshal(gf, info)
 	}
 	for _, field := range ctx.NestedFields {
-		g.generateNestedMessageUnmarshal(gf, field)
+		e.generateNestedMessageUnmarshal(gf, field)
 	}
 
 	gf.P("// Re-marshal with proto value names for protojson")
@@ -579,7 +587,7 @@ func (g *Generator) generateEnumFieldUnmarshalJSON(gf *protogen.GeneratedFile, c
 // It patches both the JSON name and proto name keys (protojson.Unmarshal accepts either), and the
 // lookup accepts both custom values and proto names, so it is idempotent for clients that already
 // send proto names; numeric values fall through untouched.
-func (g *Generator) generateEnumFieldUnmarshal(gf *protogen.GeneratedFile, info *EnumFieldInfo) {
+func (e *Emitter) generateEnumFieldUnmarshal(gf *protogen.GeneratedFile, info *EnumFieldInfo) {
 	lower := annotations.LowerFirst(info.Enum.GoIdent.GoName)
 	fromJSON := lower + "FromJSON"
 
@@ -630,8 +638,8 @@ func (g *Generator) generateEnumFieldUnmarshal(gf *protogen.GeneratedFile, info
 // generateNestedMessageUnmarshal delegates nested message parsing to the child's UnmarshalJSONSebuf
 // (forwarding opts), then converts back to protojson form, mirroring the int64 wrapper. It handles
 // whichever JSON key the request used (camelCase or the proto snake_case name).
-func (g *Generator) generateNestedMessageUnmarshal(gf *protogen.GeneratedFile, field *protogen.Field) {
-	jsonName := field.Desc.JSONName()
+func (e *Emitter) generateNestedMessageUnmarshal(gf *protogen.GeneratedFile, field *protogen.Field) {
+	jsonName := annotations.JSONFieldName(field)
 	keys := enumFieldJSONKeys(field)
 	childIdent := gf.QualifiedGoIdent(field.Message.GoIdent)
 
@@ -0,0 +1,173 @@
+package encodinggen
+
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// collectHelperEnums returns the enums of file, nested ones included, that get
+// helpers: those with enum_value mappings, or all of them with AllEnumHelpers.
+func (e *Emitter) collectHelperEnums(file *protogen.File) []*protogen.Enum {
+	var enums []*protogen.Enum
+	add := func(candidates []*protogen.Enum) {
+		for _, enum := range candidates {
+			if e.opts.AllEnumHelpers || hasCustomEnumValues(enum) {
+				enums = append(enums, enum)
+			}
+		}
+	}
+	add(file.Enums)
+	var walk func(msgs []*protogen.Message)
+	walk = func(msgs []*protogen.Message) {
+		for _, msg := range msgs {
+			add(msg.Enums)
+			walk(msg.Messages)
+		}
+	}
+	walk(file.Messages)
+	return enums
+}
+
+// distinctEnumValues returns the values of enum with distinct numbers, keeping
+// the first of aliased values.
+func distinctEnumValues(enum *protogen.Enum) []*protogen.EnumValue {
+	seen := make(map[int32]bool, len(enum.Values))
+	var values []*protogen.EnumValue
+	for _, value := range enum.Values {
+		if number := int32(value.Desc.Number()); !seen[number] {
+			seen[number] = true
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// GenerateEnumHelpersFile generates the *_enums.pb.go file, with Parse<Enum>,
+// <Enum>Values and <Enum>.WireString for every enum with enum_value mappings
+// (every enum with AllEnumHelpers), and the *_enums_test.go file checking that
+// they round-trip.
+func (e *Emitter) GenerateEnumHelpersFile(file *protogen.File) error {
+	enums := e.collectHelperEnums(file)
+	if len(enums) == 0 {
+		return nil
+	}
+
+	gf := e.plugin.NewGeneratedFile(file.GeneratedFilenamePrefix+"_enums.pb.go", file.GoImportPath)
+	e.WriteHeader(gf, file)
+	gf.P("import (")
+	gf.P(`"fmt"`)
+	gf.P(`"strconv"`)
+	gf.P(")")
+	gf.P()
+	for _, enum := range enums {
+		e.generateEnumValues(gf, enum)
+		e.generateEnumWireString(gf, enum)
+		e.generateEnumParse(gf, enum)
+	}
+
+	tf := e.plugin.NewGeneratedFile(file.GeneratedFilenamePrefix+"_enums_test.go", file.GoImportPath)
+	e.WriteHeader(tf, file)
+	tf.P("import (")
+	tf.P(`"strconv"`)
+	tf.P(`"testing"`)
+	tf.P(")")
+	tf.P()
+	for _, enum := range enums {
+		e.generateEnumRoundTripTest(tf, enum)
+	}
+	return nil
+}
+
+func (e *Emitter) generateEnumValues(gf *protogen.GeneratedFile, enum *protogen.Enum) {
+	enumName := enum.GoIdent.GoName
+
+	gf.P("// ", enumName, "Values returns the values of ", enumName, " in declaration order,")
+	gf.P("// without aliases.")
+	gf.P("func ", enumName, "Values() []", enumName, " {")
+	gf.P("return []", enumName, "{")
+	for _, value := range distinctEnumValues(enum) {
+		gf.P(value.GoIdent.GoName, ",")
+	}
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+}
+
+func (e *Emitter) generateEnumWireString(gf *protogen.GeneratedFile, enum *protogen.Enum) {
+	enumName := enum.GoIdent.GoName
+
+	gf.P("// WireString returns the string x is sent as in JSON, query and path parameters:")
+	gf.P("// its enum_value mapping, or else its proto value name.")
+	gf.P("func (x ", enumName, ") WireString() string {")
+	var mapped []*protogen.EnumValue
+	for _, value := range distinctEnumValues(enum) {
+		if annotations.GetEnumValueMapping(value) != "" {
+			mapped = append(mapped, value)
+		}
+	}
+	if len(mapped) > 0 {
+		gf.P("switch x {")
+		for _, value := range mapped {
+			gf.P("case ", value.GoIdent.GoName, ":")
+			gf.P("return ", strconv.Quote(annotations.GetEnumValueMapping(value)))
+		}
+		gf.P("}")
+	}
+	gf.P("return x.String()")
+	gf.P("}")
+	gf.P()
+}
+
+func (e *Emitter) generateEnumParse(gf *protogen.GeneratedFile, enum *protogen.Enum) {
+	enumName := enum.GoIdent.GoName
+
+	gf.P("// Parse", enumName, " returns the ", enumName, " s names: its enum_value mapping, its")
+	gf.P("// proto value name, or its number.")
+	gf.P("func Parse", enumName, "(s string) (", enumName, ", error) {")
+	seen := make(map[string]bool)
+	var mapped []*protogen.EnumValue
+	for _, value := range enum.Values {
+		if custom := annotations.GetEnumValueMapping(value); custom != "" && !seen[custom] {
+			seen[custom] = true
+			mapped = append(mapped, value)
+		}
+	}
+	if len(mapped) > 0 {
+		gf.P("switch s {")
+		for _, value := range mapped {
+			gf.P("case ", strconv.Quote(annotations.GetEnumValueMapping(value)), ":")
+			gf.P("return ", value.GoIdent.GoName, ", nil")
+		}
+		gf.P("}")
+	}
+	gf.P("if n, ok := ", enumName, "_value[s]; ok {")
+	gf.P("return ", enumName, "(n), nil")
+	gf.P("}")
+	gf.P("if n, err := strconv.ParseInt(s, 10, 32); err == nil {")
+	gf.P("return ", enumName, "(n), nil")
+	gf.P("}")
+	gf.P(`return 0, fmt.Errorf("unknown `, enumName, ` value: %q", s)`)
+	gf.P("}")
+	gf.P()
+}
+
+func (e *Emitter) generateEnumRoundTripTest(gf *protogen.GeneratedFile, enum *protogen.Enum) {
+	enumName := enum.GoIdent.GoName
+
+	gf.P("func Test", enumName, "RoundTrip(t *testing.T) {")
+	gf.P("for _, x := range ", enumName, "Values() {")
+	gf.P("for _, s := range []string{x.WireString(), x.String(), strconv.Itoa(int(x))} {")
+	gf.P("if got, err := Parse", enumName, "(s); err != nil || got != x {")
+	gf.P(`t.Errorf("Parse`, enumName, `(%q) = %v, %v, want %v", s, got, err, x)`)
+	gf.P("}")
+	gf.P("}")
+	gf.P("}")
+	gf.P(`if _, err := Parse`, enumName, `("not a `, enumName, `"); err == nil {`)
+	gf.P(`t.Error("Parse`, enumName, ` should reject an unknown value")`)
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+}
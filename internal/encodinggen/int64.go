@@ -1,8 +1,9 @@
-package httpgen
+package encodinggen
 
 import (
 	"io"
 	"os"
+	"slices"
 	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
@@ -11,6 +12,15 @@ import (
 	"github.com/SebastienMelki/sebuf/internal/annotations"
 )
 
+// Constants for proto field kinds used in int64 encoding detection.
+const (
+	kindInt64    = "int64"
+	kindSint64   = "sint64"
+	kindSfixed64 = "sfixed64"
+	kindUint64   = "uint64"
+	kindFixed64  = "fixed64"
+)
+
 // Int64EncodingContext holds information about messages that need custom JSON encoding
 // for int64/uint64 fields with NUMBER encoding.
 type Int64EncodingContext struct {
@@ -20,11 +30,11 @@ type Int64EncodingContext struct {
 	NumberFields []*protogen.Field
 }
 
-// hasInt64NumberFields returns true if any int64/uint64 field in the message has NUMBER encoding.
+// HasInt64NumberFields returns true if any int64/uint64 field in the message has NUMBER encoding.
 // This checks direct fields only (not nested messages).
-func hasInt64NumberFields(message *protogen.Message) bool {
+func HasInt64NumberFields(message *protogen.Message) bool {
 	for _, field := range message.Fields {
-		if isInt64Type(field) && annotations.IsInt64NumberEncoding(field) {
+		if IsInt64Type(field) && annotations.IsInt64NumberEncoding(field) {
 			return true
 		}
 	}
@@ -35,15 +45,15 @@ func hasInt64NumberFields(message *protogen.Message) bool {
 func getInt64NumberFields(message *protogen.Message) []*protogen.Field {
 	var fields []*protogen.Field
 	for _, field := range message.Fields {
-		if isInt64Type(field) && annotations.IsInt64NumberEncoding(field) {
+		if IsInt64Type(field) && annotations.IsInt64NumberEncoding(field) {
 			fields = append(fields, field)
 		}
 	}
 	return fields
 }
 
-// isInt64Type returns true if the field is an int64 or uint64 type (including variants).
-func isInt64Type(field *protogen.Field) bool {
+// IsInt64Type returns true if the field is an int64 or uint64 type (including variants).
+func IsInt64Type(field *protogen.Field) bool {
 	kind := field.Desc.Kind().String()
 	switch kind {
 	case kindInt64, kindSint64, kindSfixed64, kindUint64, kindFixed64:
@@ -53,8 +63,8 @@ func isInt64Type(field *protogen.Field) bool {
 	}
 }
 
-// collectInt64EncodingContext analyzes messages in a file and collects int64 encoding information.
-func collectInt64EncodingContext(file *protogen.File) []*Int64EncodingContext {
+// CollectInt64EncodingContext analyzes messages in a file and collects int64 encoding information.
+func CollectInt64EncodingContext(file *protogen.File) []*Int64EncodingContext {
 	var contexts []*Int64EncodingContext
 	collectInt64EncodingMessages(file.Messages, &contexts)
 	return contexts
@@ -63,7 +73,7 @@ func collectInt64EncodingContext(file *protogen.File) []*Int64EncodingContext {
 // collectInt64EncodingMessages recursively collects messages with int64 NUMBER encoding fields.
 func collectInt64EncodingMessages(messages []*protogen.Message, contexts *[]*Int64EncodingContext) {
 	for _, msg := range messages {
-		if hasInt64NumberFields(msg) {
+		if HasInt64NumberFields(msg) {
 			*contexts = append(*contexts, &Int64EncodingContext{
 				Message:      msg,
 				NumberFields: getInt64NumberFields(msg),
@@ -83,9 +93,9 @@ type Int64WrapperContext struct {
 	NestedFields []*protogen.Field
 }
 
-// collectWrapperContexts finds messages that contain fields whose message type
+// CollectWrapperContexts finds messages that contain fields whose message type
 // has direct int64 NUMBER encoding (i.e., types already in directMsgNames).
-func collectWrapperContexts(
+func CollectWrapperContexts(
 	file *protogen.File,
 	directMsgNames map[string]bool,
 	unwrapMsgNames map[string]bool,
@@ -144,12 +154,12 @@ func collectWrapperMessages(
 	}
 }
 
-// collectDirectEncodingMsgNames returns the set of message full names that will have
+// CollectDirectEncodingMsgNames returns the set of message full names that will have
 // custom MarshalJSON/UnmarshalJSON from the encoding generator (direct NUMBER fields only).
 // This is used by the unwrap generator to call json.Marshal instead of protojson.Marshal
 // for item types that implement json.Marshaler via the encoding generator.
-func collectDirectEncodingMsgNames(file *protogen.File) map[string]bool {
-	contexts := collectInt64EncodingContext(file)
+func CollectDirectEncodingMsgNames(file *protogen.File) map[string]bool {
+	contexts := CollectInt64EncodingContext(file)
 	result := make(map[string]bool, len(contexts))
 	for _, ctx := range contexts {
 		result[string(ctx.Message.Desc.FullName())] = true
@@ -165,9 +175,9 @@ func printInt64PrecisionWarning(w io.Writer, field *protogen.Field, messageName
 	))
 }
 
-// generateInt64EncodingFile generates the *_encoding.pb.go file if needed.
-func (g *Generator) generateInt64EncodingFile(file *protogen.File, unwrapMsgNames map[string]bool) error {
-	contexts := collectInt64EncodingContext(file)
+// GenerateInt64EncodingFile generates the *_encoding.pb.go file if needed.
+func (e *Emitter) GenerateInt64EncodingFile(file *protogen.File, unwrapMsgNames map[string]bool) error {
+	contexts := CollectInt64EncodingContext(file)
 
 	// Build set of message full names that have direct NUMBER fields
 	directMsgNames := make(map[string]bool, len(contexts))
@@ -176,18 +186,24 @@ func (g *Generator) generateInt64EncodingFile(file *protogen.File, unwrapMsgName
 	}
 
 	// Collect wrapper messages, excluding those with unwrap-generated MarshalJSON
-	wrapperContexts := collectWrapperContexts(file, directMsgNames, unwrapMsgNames)
+	wrapperContexts := CollectWrapperContexts(file, directMsgNames, unwrapMsgNames)
 
 	// If no messages need int64 encoding, skip generation
 	if len(contexts) == 0 && len(wrapperContexts) == 0 {
 		return nil
 	}
 
+	jsonNaming := slices.ContainsFunc(contexts, func(ctx *Int64EncodingContext) bool {
+		return UsesSebufHTTP(ctx.Message)
+	}) || slices.ContainsFunc(wrapperContexts, func(ctx *Int64WrapperContext) bool {
+		return UsesSebufHTTP(ctx.Message)
+	})
+
 	filename := file.GeneratedFilenamePrefix + "_encoding.pb.go"
-	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
+	gf := e.plugin.NewGeneratedFile(filename, file.GoImportPath)
 
-	g.writeHeader(gf, file)
-	g.writeInt64EncodingImports(gf)
+	e.WriteHeader(gf, file)
+	e.writeInt64EncodingImports(gf, jsonNaming)
 
 	// Generate marshal/unmarshal for messages with direct NUMBER fields
 	for _, ctx := range contexts {
@@ -195,31 +211,32 @@ func (g *Generator) generateInt64EncodingFile(file *protogen.File, unwrapMsgName
 			printInt64PrecisionWarning(os.Stderr, field, ctx.Message.GoIdent.GoName)
 		}
 
-		g.generateInt64MarshalJSON(gf, ctx)
-		g.generateInt64UnmarshalJSON(gf, ctx)
+		e.generateInt64MarshalJSON(gf, ctx)
+		e.generateInt64UnmarshalJSON(gf, ctx)
 	}
 
 	// Generate transitive marshal/unmarshal for wrapper messages
 	for _, ctx := range wrapperContexts {
-		g.generateWrapperMarshalJSON(gf, ctx)
-		g.generateWrapperUnmarshalJSON(gf, ctx)
+		e.generateWrapperMarshalJSON(gf, ctx)
+		e.generateWrapperUnmarshalJSON(gf, ctx)
 	}
 
 	return nil
 }
 
-func (g *Generator) writeInt64EncodingImports(gf *protogen.GeneratedFile) {
+func (e *Emitter) writeInt64EncodingImports(gf *protogen.GeneratedFile, jsonNaming bool) {
 	gf.P("import (")
 	gf.P(`"encoding/json"`)
 	gf.P(`"strconv"`)
 	gf.P()
 	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
+	WriteJSONNamingImport(gf, jsonNaming)
 	gf.P(")")
 	gf.P()
 }
 
 // generateInt64MarshalJSON generates a MarshalJSON method that encodes int64 NUMBER fields as numbers.
-func (g *Generator) generateInt64MarshalJSON(gf *protogen.GeneratedFile, ctx *Int64EncodingContext) {
+func (e *Emitter) generateInt64MarshalJSON(gf *protogen.GeneratedFile, ctx *Int64EncodingContext) {
 	msgName := ctx.Message.GoIdent.GoName
 
 	// Build list of NUMBER field names for the comment
@@ -243,7 +260,7 @@ func (g *Generator) generateInt64MarshalJSON(gf *protogen.GeneratedFile, ctx *In
 
 	// First, marshal using protojson to get the base JSON
 	gf.P("// Use protojson for base serialization (handles all other fields correctly)")
-	gf.P("data, err := opts.Marshal(x)")
+	gf.P("data, err := ", ProtoMarshalCall(ctx.Message))
 	gf.P("if err != nil {")
 	gf.P("return nil, err")
 	gf.P("}")
@@ -259,7 +276,7 @@ func (g *Generator) generateInt64MarshalJSON(gf *protogen.GeneratedFile, ctx *In
 
 	// For each NUMBER field, replace the string representation with a number
 	for _, field := range ctx.NumberFields {
-		g.generateInt64FieldMarshal(gf, field)
+		e.generateInt64FieldMarshal(gf, field)
 	}
 
 	gf.P("return json.Marshal(raw)")
@@ -275,21 +292,21 @@ func (g *Generator) generateInt64MarshalJSON(gf *protogen.GeneratedFile, ctx *In
 }
 
 // generateInt64FieldMarshal generates code to marshal a single int64 NUMBER field.
-func (g *Generator) generateInt64FieldMarshal(gf *protogen.GeneratedFile, field *protogen.Field) {
+func (e *Emitter) generateInt64FieldMarshal(gf *protogen.GeneratedFile, field *protogen.Field) {
 	fieldName := field.GoName
-	jsonName := field.Desc.JSONName()
+	jsonName := annotations.JSONFieldName(field)
 
 	if field.Desc.IsList() {
 		// Handle repeated int64 fields
-		g.generateRepeatedInt64FieldMarshal(gf, fieldName, jsonName)
+		e.generateRepeatedInt64FieldMarshal(gf, fieldName, jsonName)
 	} else {
 		// Handle singular int64 field
-		g.generateSingularInt64FieldMarshal(gf, fieldName, jsonName)
+		e.generateSingularInt64FieldMarshal(gf, fieldName, jsonName)
 	}
 }
 
 // generateSingularInt64FieldMarshal generates marshal code for a singular int64 NUMBER field.
-func (g *Generator) generateSingularInt64FieldMarshal(
+func (e *Emitter) generateSingularInt64FieldMarshal(
 	gf *protogen.GeneratedFile,
 	fieldName, jsonName string,
 ) {
@@ -304,7 +321,7 @@ func (g *Generator) generateSingularInt64FieldMarshal(
 }
 
 // generateRepeatedInt64FieldMarshal generates marshal code for a repeated int64 NUMBER field.
-func (g *Generator) generateRepeatedInt64FieldMarshal(
+func (e *Emitter) generateRepeatedInt64FieldMarshal(
 	gf *protogen.GeneratedFile,
 	fieldName, jsonName string,
 ) {
@@ -316,7 +333,7 @@ func (g *Generator) generateRepeatedInt64FieldMarshal(
 }
 
 // generateInt64UnmarshalJSON generates an UnmarshalJSON method that decodes int64 NUMBER fields from numbers.
-func (g *Generator) generateInt64UnmarshalJSON(gf *protogen.GeneratedFile, ctx *Int64EncodingContext) {
+func (e *Emitter) generateInt64UnmarshalJSON(gf *protogen.GeneratedFile, ctx *Int64EncodingContext) {
 	msgName := ctx.Message.GoIdent.GoName
 
 	// Build list of NUMBER field names for the comment
@@ -325,6 +342,7 @@ func (g *Generator) generateInt64UnmarshalJSON(gf *protogen.GeneratedFile, ctx *
 		numberFieldNames = append(numberFieldNames, string(f.Desc.Name()))
 	}
 
+	WriteAlternateJSONNames(gf, ctx.Message)
 	gf.P("// UnmarshalJSONSebuf implements sebufUnmarshaler for ", msgName, ".")
 	gf.P("// This method handles int64_encoding=NUMBER fields: ", strings.Join(numberFieldNames, ", "))
 	gf.P("func (x *", msgName, ") UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error {")
@@ -333,11 +351,12 @@ func (g *Generator) generateInt64UnmarshalJSON(gf *protogen.GeneratedFile, ctx *
 	gf.P("if err := json.Unmarshal(data, &raw); err != nil {")
 	gf.P("return err")
 	gf.P("}")
+	WriteResolveAlternateJSONNames(gf, ctx.Message)
 	gf.P()
 
 	// For each NUMBER field, convert number to string for protojson
 	for _, field := range ctx.NumberFields {
-		g.generateInt64FieldUnmarshal(gf, field)
+		e.generateInt64FieldUnmarshal(gf, field)
 	}
 
 	gf.P("// Re-marshal to JSON with string values for protojson")
@@ -360,20 +379,20 @@ func (g *Generator) generateInt64UnmarshalJSON(gf *protogen.GeneratedFile, ctx *
 }
 
 // generateInt64FieldUnmarshal generates code to unmarshal a single int64 NUMBER field.
-func (g *Generator) generateInt64FieldUnmarshal(gf *protogen.GeneratedFile, field *protogen.Field) {
-	jsonName := field.Desc.JSONName()
+func (e *Emitter) generateInt64FieldUnmarshal(gf *protogen.GeneratedFile, field *protogen.Field) {
+	jsonName := annotations.JSONFieldName(field)
 
 	if field.Desc.IsList() {
 		// Handle repeated int64 fields
-		g.generateRepeatedInt64FieldUnmarshal(gf, field, jsonName)
+		e.generateRepeatedInt64FieldUnmarshal(gf, field, jsonName)
 	} else {
 		// Handle singular int64 field
-		g.generateSingularInt64FieldUnmarshal(gf, field, jsonName)
+		e.generateSingularInt64FieldUnmarshal(gf, field, jsonName)
 	}
 }
 
 // generateSingularInt64FieldUnmarshal generates unmarshal code for a singular int64 NUMBER field.
-func (g *Generator) generateSingularInt64FieldUnmarshal(
+func (e *Emitter) generateSingularInt64FieldUnmarshal(
 	gf *protogen.GeneratedFile,
 	field *protogen.Field,
 	jsonName string,
@@ -397,7 +416,7 @@ func (g *Generator) generateSingularInt64FieldUnmarshal(
 }
 
 // generateRepeatedInt64FieldUnmarshal generates unmarshal code for a repeated int64 NUMBER field.
-func (g *Generator) generateRepeatedInt64FieldUnmarshal(
+func (e *Emitter) generateRepeatedInt64FieldUnmarshal(
 	gf *protogen.GeneratedFile,
 	field *protogen.Field,
 	jsonName string,
@@ -438,7 +457,7 @@ func isUint64Type(field *protogen.Field) bool {
 // messages via the sebuf opts pipeline, so their custom MarshalJSONSebuf methods are called.
 //
 //nolint:funlen // Per-field repeated/singular dispatch with opts forwarding is intentionally inlined for clarity
-func (g *Generator) generateWrapperMarshalJSON(gf *protogen.GeneratedFile, ctx *Int64WrapperContext) {
+func (e *Emitter) generateWrapperMarshalJSON(gf *protogen.GeneratedFile, ctx *Int64WrapperContext) {
 	msgName := ctx.Message.GoIdent.GoName
 
 	var nestedFieldNames []string
@@ -461,7 +480,7 @@ func (g *Generator) generateWrapperMarshalJSON(gf *protogen.GeneratedFile, ctx *
 	gf.P("}")
 	gf.P()
 	gf.P("// Use protojson for base serialization (handles all other fields correctly)")
-	gf.P("data, err := opts.Marshal(x)")
+	gf.P("data, err := ", ProtoMarshalCall(ctx.Message))
 	gf.P("if err != nil {")
 	gf.P("return nil, err")
 	gf.P("}")
@@ -474,7 +493,7 @@ func (g *Generator) generateWrapperMarshalJSON(gf *protogen.GeneratedFile, ctx *
 	gf.P()
 
 	for _, field := range ctx.NestedFields {
-		jsonName := field.Desc.JSONName()
+		jsonName := annotations.JSONFieldName(field)
 		if field.Desc.IsList() {
 			// Repeated field: per-element opts forwarding so child MarshalJSONSebuf receives opts.
 			gf.P("// Re-serialize repeated \"", jsonName, "\" forwarding opts to each element")
@@ -539,7 +558,7 @@ func (g *Generator) generateWrapperMarshalJSON(gf *protogen.GeneratedFile, ctx *
 // generateWrapperUnmarshalJSON generates an UnmarshalJSONSebuf that delegates nested
 // message parsing via the sebufUnmarshaler interface (propagating opts), then converts
 // back for protojson. Also emits a backward-compatible UnmarshalJSON wrapper.
-func (g *Generator) generateWrapperUnmarshalJSON(gf *protogen.GeneratedFile, ctx *Int64WrapperContext) {
+func (e *Emitter) generateWrapperUnmarshalJSON(gf *protogen.GeneratedFile, ctx *Int64WrapperContext) {
 	msgName := ctx.Message.GoIdent.GoName
 
 	var nestedFieldNames []string
@@ -547,6 +566,7 @@ func (g *Generator) generateWrapperUnmarshalJSON(gf *protogen.GeneratedFile, ctx
 		nestedFieldNames = append(nestedFieldNames, string(f.Desc.Name()))
 	}
 
+	WriteAlternateJSONNames(gf, ctx.Message)
 	gf.P("// UnmarshalJSONSebuf implements sebufUnmarshaler for ", msgName, ".")
 	gf.P(
 		"// This method handles nested messages that have int64_encoding=NUMBER fields: ",
@@ -557,10 +577,11 @@ func (g *Generator) generateWrapperUnmarshalJSON(gf *protogen.GeneratedFile, ctx
 	gf.P("if err := json.Unmarshal(data, &raw); err != nil {")
 	gf.P("return err")
 	gf.P("}")
+	WriteResolveAlternateJSONNames(gf, ctx.Message)
 	gf.P()
 
 	for _, field := range ctx.NestedFields {
-		jsonName := field.Desc.JSONName()
+		jsonName := annotations.JSONFieldName(field)
 		if field.Desc.IsList() {
 			// Repeated field: decode as raw items so we can dispatch each element
 			// through UnmarshalJSONSebuf (opts propagation) or json.Unmarshaler fallback.
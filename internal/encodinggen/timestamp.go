@@ -1,6 +1,7 @@
-package httpgen
+package encodinggen
 
 import (
+	"slices"
 	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
@@ -24,8 +25,8 @@ type TimestampFormatFieldInfo struct {
 	Format http.TimestampFormat
 }
 
-// hasTimestampFormatFields returns true if any Timestamp field in the message has a non-default format.
-func hasTimestampFormatFields(message *protogen.Message) bool {
+// HasTimestampFormatFields returns true if any Timestamp field in the message has a non-default format.
+func HasTimestampFormatFields(message *protogen.Message) bool {
 	for _, field := range message.Fields {
 		if annotations.IsTimestampField(field) && annotations.HasTimestampFormatAnnotation(field) {
 			return true
@@ -41,15 +42,15 @@ func getTimestampFormatFields(message *protogen.Message) []*TimestampFormatField
 		if annotations.IsTimestampField(field) && annotations.HasTimestampFormatAnnotation(field) {
 			fields = append(fields, &TimestampFormatFieldInfo{
 				Field:  field,
-				Format: annotations.GetTimestampFormat(field),
+				Format: annotations.ResolveTimestampFormat(field),
 			})
 		}
 	}
 	return fields
 }
 
-// collectTimestampFormatContext analyzes messages in a file and collects timestamp format info.
-func collectTimestampFormatContext(file *protogen.File) []*TimestampFormatContext {
+// CollectTimestampFormatContext analyzes messages in a file and collects timestamp format info.
+func CollectTimestampFormatContext(file *protogen.File) []*TimestampFormatContext {
 	var contexts []*TimestampFormatContext
 	collectTimestampFormatMessages(file.Messages, &contexts)
 	return contexts
@@ -58,7 +59,7 @@ func collectTimestampFormatContext(file *protogen.File) []*TimestampFormatContex
 // collectTimestampFormatMessages recursively collects messages with timestamp format fields.
 func collectTimestampFormatMessages(messages []*protogen.Message, contexts *[]*TimestampFormatContext) {
 	for _, msg := range messages {
-		if hasTimestampFormatFields(msg) {
+		if HasTimestampFormatFields(msg) {
 			*contexts = append(*contexts, &TimestampFormatContext{
 				Message:         msg,
 				TimestampFields: getTimestampFormatFields(msg),
@@ -90,39 +91,42 @@ func validateTimestampFormatInMessages(messages []*protogen.Message) error {
 	return nil
 }
 
-// generateTimestampFormatEncodingFile generates the *_timestamp_format.pb.go file if needed.
-func (g *Generator) generateTimestampFormatEncodingFile(file *protogen.File) error {
+// GenerateTimestampFormatEncodingFile generates the *_timestamp_format.pb.go file if needed.
+func (e *Emitter) GenerateTimestampFormatEncodingFile(file *protogen.File) error {
 	// First validate all timestamp_format annotations
 	if err := validateTimestampFormatAnnotations(file); err != nil {
 		return err
 	}
 
-	contexts := collectTimestampFormatContext(file)
+	contexts := CollectTimestampFormatContext(file)
 	if len(contexts) == 0 {
 		return nil
 	}
 
 	filename := file.GeneratedFilenamePrefix + "_timestamp_format.pb.go"
-	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
+	gf := e.plugin.NewGeneratedFile(filename, file.GoImportPath)
 
-	g.writeHeader(gf, file)
-	g.writeTimestampFormatImports(gf)
+	e.WriteHeader(gf, file)
+	e.writeTimestampFormatImports(gf, slices.ContainsFunc(contexts, func(ctx *TimestampFormatContext) bool {
+		return UsesSebufHTTP(ctx.Message)
+	}))
 
 	for _, ctx := range contexts {
-		g.generateTimestampFormatMarshalJSON(gf, ctx)
-		g.generateTimestampFormatUnmarshalJSON(gf, ctx)
+		e.generateTimestampFormatMarshalJSON(gf, ctx)
+		e.generateTimestampFormatUnmarshalJSON(gf, ctx)
 	}
 
 	return nil
 }
 
 // writeTimestampFormatImports writes the imports needed for timestamp format encoding.
-func (g *Generator) writeTimestampFormatImports(gf *protogen.GeneratedFile) {
+func (e *Emitter) writeTimestampFormatImports(gf *protogen.GeneratedFile, jsonNaming bool) {
 	gf.P("import (")
 	gf.P(`"encoding/json"`)
 	gf.P(`"time"`)
 	gf.P()
 	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
+	WriteJSONNamingImport(gf, jsonNaming)
 	gf.P(")")
 	gf.P()
 }
@@ -130,7 +134,7 @@ func (g *Generator) writeTimestampFormatImports(gf *protogen.GeneratedFile) {
 // generateTimestampFormatMarshalJSON generates MarshalJSON that converts Timestamp fields to the specified format.
 //
 //nolint:dupl // Code generation patterns naturally have similar structure across encoding types
-func (g *Generator) generateTimestampFormatMarshalJSON(gf *protogen.GeneratedFile, ctx *TimestampFormatContext) {
+func (e *Emitter) generateTimestampFormatMarshalJSON(gf *protogen.GeneratedFile, ctx *TimestampFormatContext) {
 	msgName := ctx.Message.GoIdent.GoName
 
 	var fieldNames []string
@@ -151,7 +155,7 @@ func (g *Generator) generateTimestampFormatMarshalJSON(gf *protogen.GeneratedFil
 	gf.P()
 
 	gf.P("// Use protojson for base serialization (handles all other fields correctly)")
-	gf.P("data, err := opts.Marshal(x)")
+	gf.P("data, err := ", ProtoMarshalCall(ctx.Message))
 	gf.P("if err != nil {")
 	gf.P("return nil, err")
 	gf.P("}")
@@ -165,7 +169,7 @@ func (g *Generator) generateTimestampFormatMarshalJSON(gf *protogen.GeneratedFil
 	gf.P()
 
 	for _, fieldInfo := range ctx.TimestampFields {
-		g.generateTimestampFieldMarshal(gf, fieldInfo)
+		e.generateTimestampFieldMarshal(gf, fieldInfo)
 	}
 
 	gf.P("return json.Marshal(raw)")
@@ -183,10 +187,10 @@ func (g *Generator) generateTimestampFormatMarshalJSON(gf *protogen.GeneratedFil
 // generateTimestampFieldMarshal generates marshal code for a single Timestamp field.
 //
 //nolint:exhaustive // Only non-default formats need handling; default/RFC3339 are excluded by HasTimestampFormatAnnotation
-func (g *Generator) generateTimestampFieldMarshal(gf *protogen.GeneratedFile, fieldInfo *TimestampFormatFieldInfo) {
+func (e *Emitter) generateTimestampFieldMarshal(gf *protogen.GeneratedFile, fieldInfo *TimestampFormatFieldInfo) {
 	field := fieldInfo.Field
 	goName := field.GoName
-	jsonName := field.Desc.JSONName()
+	jsonName := annotations.JSONFieldName(field)
 	format := fieldInfo.Format
 
 	gf.P("// Convert ", field.Desc.Name(), " to ", format.String(), " format")
@@ -209,7 +213,7 @@ func (g *Generator) generateTimestampFieldMarshal(gf *protogen.GeneratedFile, fi
 // generateTimestampFormatUnmarshalJSON generates UnmarshalJSON that converts timestamp formats back to RFC 3339.
 //
 //nolint:dupl // Code generation patterns naturally have similar structure across encoding types
-func (g *Generator) generateTimestampFormatUnmarshalJSON(gf *protogen.GeneratedFile, ctx *TimestampFormatContext) {
+func (e *Emitter) generateTimestampFormatUnmarshalJSON(gf *protogen.GeneratedFile, ctx *TimestampFormatContext) {
 	msgName := ctx.Message.GoIdent.GoName
 
 	var fieldNames []string
@@ -217,18 +221,18 @@ func (g *Generator) generateTimestampFormatUnmarshalJSON(gf *protogen.GeneratedF
 		fieldNames = append(fieldNames, string(f.Field.Desc.Name()))
 	}
 
-	gf.P("// UnmarshalJSON implements json.Unmarshaler for ", msgName, ".")
-	gf.P("// This method handles timestamp_format fields: ", strings.Join(fieldNames, ", "))
-	gf.P("func (x *", msgName, ") UnmarshalJSON(data []byte) error {")
+	WriteAlternateJSONNames(gf, ctx.Message)
+	e.writeUnmarshalJSONSignature(gf, msgName, "This method handles timestamp_format fields: "+strings.Join(fieldNames, ", "))
 	gf.P("// Parse the raw JSON to extract timestamp format fields")
 	gf.P("var raw map[string]json.RawMessage")
 	gf.P("if err := json.Unmarshal(data, &raw); err != nil {")
 	gf.P("return err")
 	gf.P("}")
+	WriteResolveAlternateJSONNames(gf, ctx.Message)
 	gf.P()
 
 	for _, fieldInfo := range ctx.TimestampFields {
-		g.generateTimestampFieldUnmarshal(gf, fieldInfo)
+		e.generateTimestampFieldUnmarshal(gf, fieldInfo)
 	}
 
 	gf.P("// Re-marshal with RFC 3339 values for protojson")
@@ -238,17 +242,15 @@ func (g *Generator) generateTimestampFormatUnmarshalJSON(gf *protogen.GeneratedF
 	gf.P("}")
 	gf.P()
 	gf.P("// Use protojson to unmarshal the rest")
-	gf.P("return protojson.Unmarshal(modified, x)")
-	gf.P("}")
-	gf.P()
+	e.writeProtoUnmarshalReturn(gf, msgName, "modified")
 }
 
 // generateTimestampFieldUnmarshal generates unmarshal code for a single Timestamp field.
 //
 //nolint:exhaustive // Only non-default formats need handling; default/RFC3339 are excluded by HasTimestampFormatAnnotation
-func (g *Generator) generateTimestampFieldUnmarshal(gf *protogen.GeneratedFile, fieldInfo *TimestampFormatFieldInfo) {
+func (e *Emitter) generateTimestampFieldUnmarshal(gf *protogen.GeneratedFile, fieldInfo *TimestampFormatFieldInfo) {
 	field := fieldInfo.Field
-	jsonName := field.Desc.JSONName()
+	jsonName := annotations.JSONFieldName(field)
 	format := fieldInfo.Format
 
 	gf.P("// Convert ", jsonName, " from ", format.String(), " to RFC 3339 for protojson")
@@ -0,0 +1,206 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestAlternateJSONNamesIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server from a proto with an unwrap message, a
+//     flattened message and a flattened message with reject_alternate_names,
+//  2. writes a temporary Go module that unmarshals snake_case JSON into them
+//     and serves them with httptest,
+//  3. verifies the generated UnmarshalJSON methods accept proto field names,
+//     round-trip to the same message, fail on a field named both ways, and
+//     reject proto field names under reject_alternate_names.
+func TestAlternateJSONNamesIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "alternate_json_names_test",
+		Protos:  map[string]string{"crm.proto": alternateJSONNamesProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"alternate_json_names_test.go": alternateJSONNamesIntegrationTestCode},
+	})
+}
+
+const alternateJSONNamesProto = `syntax = "proto3";
+package test.alternatenames;
+option go_package = "alternate_json_names_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service CustomerService {
+  option (sebuf.http.service_config) = { base_path: "/api/v1" };
+  rpc CreateCustomer(Customer) returns (Customer) {
+    option (sebuf.http.config) = { path: "/customers" method: HTTP_METHOD_POST };
+  }
+  rpc CreateStrictCustomer(StrictCustomer) returns (StrictCustomer) {
+    option (sebuf.http.config) = { path: "/strict-customers" method: HTTP_METHOD_POST };
+  }
+  rpc ImportVisits(VisitLog) returns (VisitLog) {
+    option (sebuf.http.config) = { path: "/visits" method: HTTP_METHOD_POST };
+  }
+}
+
+message Address {
+  string street_name = 1;
+  string postal_code = 2;
+}
+
+message Customer {
+  string display_name = 1;
+  Address home_address = 2 [(sebuf.http.flatten) = true, (sebuf.http.flatten_prefix) = "home_"];
+}
+
+message StrictCustomer {
+  option (sebuf.http.reject_alternate_names) = true;
+  string display_name = 1;
+  Address home_address = 2 [(sebuf.http.flatten) = true, (sebuf.http.flatten_prefix) = "home_"];
+}
+
+message Visit {
+  string visitor_id = 1;
+}
+
+message VisitList {
+  repeated Visit visits = 1 [(sebuf.http.unwrap) = true];
+}
+
+message VisitLog {
+  map<string, VisitList> visits_by_day = 1;
+  string next_page_token = 2;
+}
+`
+
+// alternateJSONNamesIntegrationTestCode is the test source that runs inside the
+// temp module.
+const alternateJSONNamesIntegrationTestCode = `package alternate_json_names_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	gen "alternate_json_names_test/gen"
+)
+
+type customerServer struct {
+	gen.UnimplementedCustomerServiceServer
+}
+
+func (customerServer) CreateCustomer(_ context.Context, req *gen.Customer) (*gen.Customer, error) {
+	return req, nil
+}
+
+func (customerServer) CreateStrictCustomer(_ context.Context, req *gen.StrictCustomer) (*gen.StrictCustomer, error) {
+	return req, nil
+}
+
+func (customerServer) ImportVisits(_ context.Context, req *gen.VisitLog) (*gen.VisitLog, error) {
+	return req, nil
+}
+
+// roundTrip unmarshals data into msg, marshals it back and checks the result
+// unmarshals into a message equal to msg.
+func roundTrip(t *testing.T, data string, msg, again proto.Message) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(data), msg); err != nil {
+		t.Fatalf("unmarshal %s: %v", data, err)
+	}
+	out, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if err := json.Unmarshal(out, again); err != nil {
+		t.Fatalf("unmarshal %s: %v", out, err)
+	}
+	if !proto.Equal(msg, again) {
+		t.Errorf("round trip of %s = %v, want %v", data, again, msg)
+	}
+}
+
+func TestFlattenAcceptsProtoNames(t *testing.T) {
+	var customer, again gen.Customer
+	roundTrip(t, ` + "`" + `{"display_name":"Ada","home_street_name":"Main St","home_postal_code":"75001"}` + "`" + `,
+		&customer, &again)
+	want := &gen.Customer{
+		DisplayName: "Ada",
+		HomeAddress: &gen.Address{StreetName: "Main St", PostalCode: "75001"},
+	}
+	if !proto.Equal(&customer, want) {
+		t.Errorf("customer = %v, want %v", &customer, want)
+	}
+}
+
+func TestUnwrapAcceptsProtoNames(t *testing.T) {
+	var log, again gen.VisitLog
+	roundTrip(t, ` + "`" + `{"visits_by_day":{"monday":[{"visitor_id":"v1"}]},"next_page_token":"t2"}` + "`" + `,
+		&log, &again)
+	want := &gen.VisitLog{
+		VisitsByDay:   map[string]*gen.VisitList{"monday": {Visits: []*gen.Visit{{VisitorId: "v1"}}}},
+		NextPageToken: "t2",
+	}
+	if !proto.Equal(&log, want) {
+		t.Errorf("log = %v, want %v", &log, want)
+	}
+}
+
+func TestFieldNamedBothWays(t *testing.T) {
+	var customer gen.Customer
+	err := json.Unmarshal([]byte(` + "`" + `{"display_name":"Ada","displayName":"Grace"}` + "`" + `), &customer)
+	if err == nil || !strings.Contains(err.Error(), "duplicate field") {
+		t.Errorf("err = %v, want a duplicate field error", err)
+	}
+}
+
+func TestRejectAlternateNames(t *testing.T) {
+	var customer gen.StrictCustomer
+	err := json.Unmarshal([]byte(` + "`" + `{"displayName":"Ada","home_streetName":"Main St"}` + "`" + `), &customer)
+	if err != nil {
+		t.Fatalf("unmarshal JSON names: %v", err)
+	}
+	err = json.Unmarshal([]byte(` + "`" + `{"displayName":"Ada","home_street_name":"Main St"}` + "`" + `), &customer)
+	if err == nil || !strings.Contains(err.Error(), "home_street_name") {
+		t.Errorf("err = %v, want an error naming home_street_name", err)
+	}
+}
+
+func TestServerAcceptsProtoNames(t *testing.T) {
+	mux := http.NewServeMux()
+	if err := gen.RegisterCustomerServiceServer(customerServer{}, gen.WithMux(mux)); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	post := func(path, body string) (int, string) {
+		t.Helper()
+		resp, err := http.Post(srv.URL+path, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		out, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(out)
+	}
+
+	status, body := post("/api/v1/customers", ` + "`" + `{"display_name":"Ada","home_postal_code":"75001"}` + "`" + `)
+	if status != http.StatusOK || !strings.Contains(body, ` + "`" + `"displayName":"Ada"` + "`" + `) ||
+		!strings.Contains(body, ` + "`" + `"home_postalCode":"75001"` + "`" + `) {
+		t.Errorf("customers: %d %s", status, body)
+	}
+	status, body = post("/api/v1/visits", ` + "`" + `{"next_page_token":"t2"}` + "`" + `)
+	if status != http.StatusOK || !strings.Contains(body, ` + "`" + `"nextPageToken":"t2"` + "`" + `) {
+		t.Errorf("visits: %d %s", status, body)
+	}
+	status, body = post("/api/v1/strict-customers", ` + "`" + `{"display_name":"Ada"}` + "`" + `)
+	if status != http.StatusBadRequest {
+		t.Errorf("strict-customers: %d %s, want 400", status, body)
+	}
+}
+`
@@ -0,0 +1,256 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestBackpressureIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server from a proto with one method bounded by
+//     timeout_ms and one relying on WithDefaultTimeout,
+//  2. writes a temporary Go module that serves it with httptest and a
+//     deliberately slow handler,
+//  3. verifies timeouts answer 504 without waiting for the handler, fast
+//     handlers are never answered with an error, and a saturated
+//     WithConcurrencyLimit answers 503 with Retry-After.
+func TestBackpressureIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "backpressure_test",
+		Protos:  map[string]string{"backpressure.proto": backpressureProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"backpressure_test.go": backpressureIntegrationTestCode},
+	})
+}
+
+const backpressureProto = `syntax = "proto3";
+package test.backpressure;
+option go_package = "backpressure_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service ReportService {
+  rpc BuildReport(BuildReportRequest) returns (Report) {
+    option (sebuf.http.config) = { path: "/reports" };
+  }
+
+  rpc QuickReport(BuildReportRequest) returns (Report) {
+    option (sebuf.http.config) = { path: "/reports/quick" timeout_ms: 50 };
+  }
+}
+
+message BuildReportRequest {
+  // "late" ignores the context and finishes after 300ms, "block" waits for the
+  // test to release it, "deadline" fails with context.DeadlineExceeded.
+  string mode = 1;
+}
+
+message Report {
+  string status = 1;
+}
+`
+
+// backpressureIntegrationTestCode is the test source that runs inside the temp
+// module. The handler's behavior is selected by the request's mode.
+const backpressureIntegrationTestCode = `package backpressure_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gen "backpressure_test/gen"
+)
+
+type reportServer struct {
+	started  chan struct{} // Signalled when a "block" handler starts
+	release  chan struct{} // Closed to let "block" handlers finish
+	lateErrs chan error    // Context error seen by "late" handlers once they finish
+}
+
+func newReportServer() *reportServer {
+	return &reportServer{
+		started:  make(chan struct{}, 1),
+		release:  make(chan struct{}),
+		lateErrs: make(chan error, 1),
+	}
+}
+
+func (s *reportServer) BuildReport(ctx context.Context, req *gen.BuildReportRequest) (*gen.Report, error) {
+	switch req.GetMode() {
+	case "late":
+		time.Sleep(300 * time.Millisecond)
+		s.lateErrs <- ctx.Err()
+		return &gen.Report{Status: "late"}, nil
+	case "block":
+		s.started <- struct{}{}
+		<-s.release
+	case "deadline":
+		return nil, fmt.Errorf("upstream: %w", context.DeadlineExceeded)
+	}
+	return &gen.Report{Status: "done"}, nil
+}
+
+func (s *reportServer) QuickReport(ctx context.Context, req *gen.BuildReportRequest) (*gen.Report, error) {
+	return s.BuildReport(ctx, req)
+}
+
+func serve(t *testing.T, server *reportServer, opts ...gen.ServerOption) string {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterReportServiceServer(server, append(opts, gen.WithMux(mux))...); err != nil {
+		t.Fatalf("RegisterReportServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+type reply struct {
+	status     int
+	retryAfter string
+	body       map[string]any
+	elapsed    time.Duration
+}
+
+func post(t *testing.T, url, mode string) reply {
+	t.Helper()
+	start := time.Now()
+	resp, err := http.Post(url, "application/json", strings.NewReader(` + "`" + `{"mode":"` + "`" + `+mode+` + "`" + `"}` + "`" + `))
+	if err != nil {
+		t.Fatalf("POST %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	r := reply{status: resp.StatusCode, retryAfter: resp.Header.Get("Retry-After"), elapsed: time.Since(start)}
+	if err := json.NewDecoder(resp.Body).Decode(&r.body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return r
+}
+
+// TestMethodTimeout checks a slow handler is answered with 504 at its
+// timeout_ms, its late response is discarded, and its context was cancelled.
+func TestMethodTimeout(t *testing.T) {
+	server := newReportServer()
+	url := serve(t, server)
+
+	r := post(t, url+"/reports/quick", "late")
+	if r.status != http.StatusGatewayTimeout || r.body["code"] != "DEADLINE_EXCEEDED" {
+		t.Fatalf("status %d, body %v; want 504 DEADLINE_EXCEEDED", r.status, r.body)
+	}
+	if r.elapsed >= 250*time.Millisecond {
+		t.Errorf("504 took %v, it should not wait for the handler", r.elapsed)
+	}
+	if err := <-server.lateErrs; err != context.DeadlineExceeded {
+		t.Errorf("handler context error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestDefaultTimeout(t *testing.T) {
+	server := newReportServer()
+	url := serve(t, server, gen.WithDefaultTimeout(50*time.Millisecond))
+	if r := post(t, url+"/reports", "late"); r.status != http.StatusGatewayTimeout {
+		t.Errorf("with WithDefaultTimeout: status %d, want 504", r.status)
+	}
+	<-server.lateErrs
+
+	if r := post(t, serve(t, server)+"/reports", "late"); r.status != http.StatusOK || r.body["status"] != "late" {
+		t.Errorf("without a timeout: status %d, body %v; want 200 late", r.status, r.body)
+	}
+	<-server.lateErrs
+}
+
+func TestDeadlineErrorIs504(t *testing.T) {
+	r := post(t, serve(t, newReportServer())+"/reports", "deadline")
+	if r.status != http.StatusGatewayTimeout || r.body["code"] != "DEADLINE_EXCEEDED" {
+		t.Errorf("status %d, body %v; want 504 DEADLINE_EXCEEDED", r.status, r.body)
+	}
+}
+
+func TestConcurrencyLimit(t *testing.T) {
+	server := newReportServer()
+	url := serve(t, server, gen.WithConcurrencyLimit(1))
+
+	first := make(chan reply, 1)
+	go func() { first <- post(t, url+"/reports", "block") }()
+	<-server.started
+
+	r := post(t, url+"/reports", "")
+	if r.status != http.StatusServiceUnavailable || r.retryAfter != "1" || r.body["code"] != "UNAVAILABLE" {
+		t.Errorf("saturated: status %d, Retry-After %q, body %v; want 503 UNAVAILABLE with Retry-After 1",
+			r.status, r.retryAfter, r.body)
+	}
+
+	close(server.release)
+	if r := <-first; r.status != http.StatusOK {
+		t.Errorf("blocked request: status %d, want 200", r.status)
+	}
+	if r := post(t, url+"/reports", ""); r.status != http.StatusOK {
+		t.Errorf("after release: status %d, want 200", r.status)
+	}
+}
+
+// TestFastHandlerUnderTimeout checks a handler returning well within its
+// timeout always gets its response through, however its completion races the
+// cancellation of its context.
+func TestFastHandlerUnderTimeout(t *testing.T) {
+	mux := http.NewServeMux()
+	err := gen.RegisterReportServiceServer(newReportServer(),
+		gen.WithMux(mux), gen.WithDefaultTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("RegisterReportServiceServer: %v", err)
+	}
+	// Concurrent callers on several Ps let handler goroutines run, and finish,
+	// before their caller waits for them, even on a single CPU
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+	var wg sync.WaitGroup
+	for _, path := range []string{"/reports", "/reports/quick"} {
+		for range 16 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 2000 {
+					req := httptest.NewRequest(http.MethodPost, path, strings.NewReader("{}"))
+					req.Header.Set("Content-Type", "application/json")
+					w := httptest.NewRecorder()
+					mux.ServeHTTP(w, req)
+					if w.Code != http.StatusOK {
+						t.Errorf("%s: status %d, body %s; want 200", path, w.Code, w.Body)
+						return
+					}
+				}
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+// TestTimedOutHandlerHoldsSlot checks the slot of a timed-out handler is only
+// released once the handler actually returns.
+func TestTimedOutHandlerHoldsSlot(t *testing.T) {
+	server := newReportServer()
+	url := serve(t, server, gen.WithConcurrencyLimit(1))
+
+	if r := post(t, url+"/reports/quick", "late"); r.status != http.StatusGatewayTimeout {
+		t.Fatalf("status %d, want 504", r.status)
+	}
+	if r := post(t, url+"/reports", ""); r.status != http.StatusServiceUnavailable {
+		t.Errorf("while the late handler runs: status %d, want 503", r.status)
+	}
+	<-server.lateErrs
+	// The slot is released right after the handler returns
+	status := http.StatusServiceUnavailable
+	for deadline := time.Now().Add(time.Second); status == http.StatusServiceUnavailable && time.Now().Before(deadline); {
+		status = post(t, url+"/reports", "").status
+	}
+	if status != http.StatusOK {
+		t.Errorf("after the late handler returned: status %d, want 200", status)
+	}
+}
+`
@@ -0,0 +1,134 @@
+package httpgen
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestBackpressureGeneration verifies that every unary handler shares the
+// service's concurrency limiter, that timeout_ms overrides the default timeout,
+// and that the saturation and timeout error codes are mapped.
+func TestBackpressureGeneration(t *testing.T) {
+	files := generateTestFiles(t, "http_verbs_comprehensive.proto")
+
+	t.Run("limiter is created once per service", func(t *testing.T) {
+		want := "limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)"
+		if n := strings.Count(files.http, want); n != 2 {
+			t.Errorf("expected one limiter for each of the two services, got %d", n)
+		}
+	})
+
+	t.Run("annotated method uses its timeout", func(t *testing.T) {
+		want := "genericHandler(server.UpdateResource, config.errorHandler, config.marshalOpts, limiter, " +
+			"5000*time.Millisecond, config.warningsInBody)"
+		if !strings.Contains(files.http, want) {
+			t.Error("UpdateResource should be bounded by its timeout_ms")
+		}
+	})
+
+	t.Run("other methods use the default timeout", func(t *testing.T) {
+		want := "genericHandler(server.PatchResource, config.errorHandler, config.marshalOpts, limiter, " +
+			"config.defaultTimeout, config.warningsInBody)"
+		if !strings.Contains(files.http, want) {
+			t.Error("PatchResource should be bounded by WithDefaultTimeout")
+		}
+	})
+
+	t.Run("options are generated", func(t *testing.T) {
+		for _, want := range []string{
+			"func WithConcurrencyLimit(n int) ServerOption",
+			"func WithDefaultTimeout(timeout time.Duration) ServerOption",
+		} {
+			if !strings.Contains(files.config, want) {
+				t.Errorf("config file missing %q", want)
+			}
+		}
+	})
+
+	t.Run("error codes map to 503 and 504", func(t *testing.T) {
+		want := "case sebufhttp.ErrorCodeUnavailable:\n" +
+			"\t\t\treturn http.StatusServiceUnavailable\n" +
+			"\t\tcase sebufhttp.ErrorCodeDeadlineExceeded:\n" +
+			"\t\t\treturn http.StatusGatewayTimeout"
+		if !strings.Contains(files.binding, want) {
+			t.Error("defaultErrorStatusCode should map the saturation and timeout codes")
+		}
+	})
+}
+
+// TestTimeoutRejected verifies generation fails for negative timeouts and for
+// timeouts on streaming methods.
+func TestTimeoutRejected(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping timeout validation test")
+	}
+
+	baseDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	projectRoot := filepath.Join(baseDir, "..", "..")
+	pluginPath := filepath.Join(projectRoot, "bin", "protoc-gen-go-http")
+	if _, statErr := os.Stat(pluginPath); os.IsNotExist(statErr) {
+		t.Skip("protoc-gen-go-http not built, run make build")
+	}
+
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name:    "negative",
+			config:  `path: "/reports" timeout_ms: -1`,
+			wantErr: "timeout_ms must not be negative, got -1",
+		},
+		{
+			name:    "stream",
+			config:  `path: "/reports" stream: true timeout_ms: 1000`,
+			wantErr: "timeout_ms is not supported on streaming methods",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			protoDir := t.TempDir()
+			protoSrc := `syntax = "proto3";
+package test.timeout;
+option go_package = "example.com/timeout;timeout";
+import "sebuf/http/annotations.proto";
+service Reports {
+  rpc Build(BuildRequest) returns (BuildResponse) {
+    option (sebuf.http.config) = { ` + tt.config + ` };
+  }
+}
+message BuildRequest {}
+message BuildResponse {}
+`
+			if writeErr := os.WriteFile(filepath.Join(protoDir, "timeout.proto"), []byte(protoSrc), 0o600); writeErr != nil {
+				t.Fatalf("Failed to write proto: %v", writeErr)
+			}
+
+			cmd := exec.Command("protoc",
+				"--plugin=protoc-gen-go-http="+pluginPath,
+				"--go-http_out="+t.TempDir(),
+				"--go-http_opt=paths=source_relative",
+				"--proto_path="+protoDir,
+				"--proto_path="+filepath.Join(projectRoot, "proto"),
+				"timeout.proto",
+			)
+			var stderr bytes.Buffer
+			cmd.Stderr = &stderr
+			if runErr := cmd.Run(); runErr == nil {
+				t.Fatal("expected generation to fail")
+			}
+			if !strings.Contains(stderr.String(), tt.wantErr) {
+				t.Errorf("unexpected error output: %s", stderr.String())
+			}
+		})
+	}
+}
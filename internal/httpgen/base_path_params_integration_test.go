@@ -0,0 +1,188 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestBasePathParamsIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server and Go client from a proto with two services
+//     under /t/{tenant_id}, one binding tenant_id to its requests and one
+//     declaring it context_only,
+//  2. writes a temporary Go module that serves them with httptest,
+//  3. verifies the server binds tenant_id and exposes it through
+//     PathParamFromContext, that the client substitutes the value of its
+//     option into every URL, and that calls fail when the option is not set.
+func TestBasePathParamsIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "base_path_params_test",
+		Protos:  map[string]string{"tenants.proto": basePathParamsProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}, {Name: "go-client"}},
+		Files:   map[string]string{"base_path_params_test.go": basePathParamsIntegrationTestCode},
+	})
+}
+
+const basePathParamsProto = `syntax = "proto3";
+package test.basepathparams;
+option go_package = "base_path_params_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service ProjectService {
+  option (sebuf.http.service_config) = { base_path: "/t/{tenant_id}/api/v1" };
+  rpc GetProject(GetProjectRequest) returns (Project) {
+    option (sebuf.http.config) = { path: "/projects/{project_id}" method: HTTP_METHOD_GET };
+  }
+  rpc CreateProject(CreateProjectRequest) returns (Project) {
+    option (sebuf.http.config) = { path: "/projects" method: HTTP_METHOD_POST };
+  }
+}
+
+service BillingService {
+  option (sebuf.http.service_config) = {
+    base_path: "/t/{tenant_id}/billing"
+    base_path_params: [{ name: "tenant_id" context_only: true }]
+  };
+  rpc GetInvoice(GetInvoiceRequest) returns (Invoice) {
+    option (sebuf.http.config) = { path: "/invoices/{invoice_id}" method: HTTP_METHOD_GET };
+  }
+}
+
+message GetProjectRequest {
+  string tenant_id = 1;
+  string project_id = 2;
+}
+
+message CreateProjectRequest {
+  string tenant_id = 1;
+  string name = 2;
+}
+
+message Project {
+  string tenant_id = 1;
+  string project_id = 2;
+  string name = 3;
+  string context_tenant_id = 4;
+}
+
+message GetInvoiceRequest {
+  string invoice_id = 1;
+}
+
+message Invoice {
+  string tenant_id = 1;
+  string invoice_id = 2;
+}
+`
+
+// basePathParamsIntegrationTestCode is the test source that runs inside the
+// temp module.
+const basePathParamsIntegrationTestCode = `package base_path_params_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "base_path_params_test/gen"
+)
+
+type projectServer struct{}
+
+func (projectServer) GetProject(ctx context.Context, req *gen.GetProjectRequest) (*gen.Project, error) {
+	return &gen.Project{
+		TenantId:        req.GetTenantId(),
+		ProjectId:       req.GetProjectId(),
+		ContextTenantId: sebufhttp.PathParamFromContext(ctx, "tenant_id"),
+	}, nil
+}
+
+func (projectServer) CreateProject(ctx context.Context, req *gen.CreateProjectRequest) (*gen.Project, error) {
+	return &gen.Project{TenantId: req.GetTenantId(), Name: req.GetName()}, nil
+}
+
+type billingServer struct{}
+
+func (billingServer) GetInvoice(ctx context.Context, req *gen.GetInvoiceRequest) (*gen.Invoice, error) {
+	return &gen.Invoice{
+		TenantId:  sebufhttp.PathParamFromContext(ctx, "tenant_id"),
+		InvoiceId: req.GetInvoiceId(),
+	}, nil
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterProjectServiceServer(projectServer{}, gen.WithMux(mux)); err != nil {
+		t.Fatalf("RegisterProjectServiceServer: %v", err)
+	}
+	if err := gen.RegisterBillingServiceServer(billingServer{}, gen.WithMux(mux)); err != nil {
+		t.Fatalf("RegisterBillingServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestBoundBasePathParam(t *testing.T) {
+	srv := newServer(t)
+	client := gen.NewProjectServiceClient(srv.URL, gen.WithProjectServiceTenantId("acme corp"))
+
+	project, err := client.GetProject(context.Background(), &gen.GetProjectRequest{ProjectId: "p1"})
+	if err != nil {
+		t.Fatalf("GetProject: %v", err)
+	}
+	if project.GetTenantId() != "acme corp" || project.GetProjectId() != "p1" {
+		t.Errorf("GetProject = %v, want tenant_id acme corp and project_id p1", project)
+	}
+	if project.GetContextTenantId() != "acme corp" {
+		t.Errorf("PathParamFromContext = %q, want acme corp", project.GetContextTenantId())
+	}
+
+	// The path value wins over the body
+	created, err := client.CreateProject(context.Background(),
+		&gen.CreateProjectRequest{TenantId: "other", Name: "widget"})
+	if err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if created.GetTenantId() != "acme corp" || created.GetName() != "widget" {
+		t.Errorf("CreateProject = %v, want tenant_id acme corp and name widget", created)
+	}
+}
+
+func TestContextOnlyBasePathParam(t *testing.T) {
+	srv := newServer(t)
+	client := gen.NewBillingServiceClient(srv.URL, gen.WithBillingServiceTenantId("acme"))
+
+	invoice, err := client.GetInvoice(context.Background(), &gen.GetInvoiceRequest{InvoiceId: "i1"})
+	if err != nil {
+		t.Fatalf("GetInvoice: %v", err)
+	}
+	if invoice.GetTenantId() != "acme" || invoice.GetInvoiceId() != "i1" {
+		t.Errorf("GetInvoice = %v, want tenant_id acme and invoice_id i1", invoice)
+	}
+
+	resp, err := http.Get(srv.URL + "/t/globex/billing/invoices/i2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestMissingBasePathParam(t *testing.T) {
+	srv := newServer(t)
+	client := gen.NewProjectServiceClient(srv.URL)
+
+	_, err := client.GetProject(context.Background(), &gen.GetProjectRequest{ProjectId: "p1"})
+	if err == nil || !strings.Contains(err.Error(), "WithProjectServiceTenantId") {
+		t.Fatalf("GetProject error = %v, want it to name WithProjectServiceTenantId", err)
+	}
+}
+`
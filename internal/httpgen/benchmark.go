@@ -0,0 +1,290 @@
+package httpgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// benchmarkLargeSize is the number of elements the repeated fields of the large
+// benchmark request hold.
+const benchmarkLargeSize = 100
+
+// generateBenchmarkFile generates a test file with a Benchmark<Service>Binding
+// function per service, measuring BindingMiddleware on each method's requests.
+// Requests are built from the example values the mocks use.
+func (g *Generator) generateBenchmarkFile(file *protogen.File) {
+	filename := file.GeneratedFilenamePrefix + "_http_binding_benchmark_test.go"
+	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
+
+	g.writeHeader(gf, file)
+
+	gf.P("import (")
+	gf.P(`"bytes"`)
+	if g.router == RouterChi {
+		gf.P(`"context"`)
+	}
+	gf.P(`"io"`)
+	gf.P(`"net/http"`)
+	gf.P(`"net/http/httptest"`)
+	gf.P(`"net/url"`)
+	gf.P(`"testing"`)
+	gf.P()
+	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
+	gf.P(`"google.golang.org/protobuf/reflect/protoreflect"`)
+	gf.P()
+	g.generateRouterImport(gf)
+	gf.P(`sebufhttp "github.com/SebastienMelki/sebuf/http"`)
+	gf.P(")")
+	gf.P()
+
+	for _, service := range file.Services {
+		g.generateServiceBenchmark(gf, service)
+		if g.features.messageValidation {
+			g.generateServiceValidatorBenchmark(gf, service)
+		}
+	}
+	g.generateBenchmarkHelpers(gf)
+	if g.features.messageValidation {
+		g.generateValidatorBenchmarkHelper(gf)
+	}
+}
+
+// generateServiceBenchmark generates Benchmark<Service>Binding, with a
+// sub-benchmark per method.
+func (g *Generator) generateServiceBenchmark(gf *protogen.GeneratedFile, service *protogen.Service) {
+	gf.P("// Benchmark", service.GoName, "Binding measures binding and validating the requests of")
+	gf.P("// every ", service.GoName, " method: an empty request, the example request and a")
+	gf.P("// large request whose repeated fields are grown to ", benchmarkLargeSize, " elements.")
+	gf.P("func Benchmark", service.GoName, "Binding(b *testing.B) {")
+	for _, method := range service.Methods {
+		methodName := annotations.LowerFirst(method.GoName)
+		gf.P(`b.Run("`, method.GoName, `", func(b *testing.B) {`)
+		gf.P("example := &", method.Input.GoIdent, "{}")
+		g.generateMockFieldAssignments(gf, method.Input, "example", nil)
+		gf.P("benchmarkBinding[", method.Input.GoIdent, `](b, "`, g.getHTTPMethod(method), `", example,`)
+		gf.P(methodName, "PathParams, ", methodName, "QueryParams, ", methodName, "HeaderFieldParams,")
+		gf.P(g.defaultBodyConfigLiteral(method), ")")
+		gf.P("})")
+	}
+	gf.P("}")
+	gf.P()
+}
+
+// generateServiceValidatorBenchmark generates Benchmark<Service>FirstValidation,
+// with a sub-benchmark per method whose request carries buf.validate rules.
+func (g *Generator) generateServiceValidatorBenchmark(gf *protogen.GeneratedFile, service *protogen.Service) {
+	var methods []*protogen.Method
+	for _, method := range service.Methods {
+		if annotations.HasValidationRules(method.Input.Desc) {
+			methods = append(methods, method)
+		}
+	}
+	if len(methods) == 0 {
+		return
+	}
+	gf.P("// Benchmark", service.GoName, "FirstValidation measures validating the example request")
+	gf.P("// of every ", service.GoName, " method as the first request of its type: cold builds")
+	gf.P("// its validator, as the first request did before ", g.registerFuncName(service))
+	gf.P("// warmed them up, and warm reuses the validator registration built.")
+	gf.P("func Benchmark", service.GoName, "FirstValidation(b *testing.B) {")
+	for _, method := range methods {
+		gf.P(`b.Run("`, method.GoName, `", func(b *testing.B) {`)
+		gf.P("example := &", method.Input.GoIdent, "{}")
+		g.generateMockFieldAssignments(gf, method.Input, "example", nil)
+		gf.P("benchmarkFirstValidation(b, example)")
+		gf.P("})")
+	}
+	gf.P("}")
+	gf.P()
+}
+
+// generateValidatorBenchmarkHelper generates benchmarkFirstValidation.
+func (g *Generator) generateValidatorBenchmarkHelper(gf *protogen.GeneratedFile) {
+	gf.P()
+	gf.P("// benchmarkFirstValidation runs the cold and warm sub-benchmarks of validating")
+	gf.P("// example. Cold drops the cached validator of its type before every iteration.")
+	protoMessage := gf.QualifiedGoIdent(protoPackage.Ident("Message"))
+	gf.P("func benchmarkFirstValidation(b *testing.B, example ", protoMessage, ") {")
+	gf.P("b.Helper()")
+	gf.P("name := example.ProtoReflect().Descriptor().FullName()")
+	gf.P(`b.Run("cold", func(b *testing.B) {`)
+	gf.P("b.ReportAllocs()")
+	gf.P("for range b.N {")
+	gf.P("validators.Delete(name)")
+	gf.P("_ = ValidateMessage(example)")
+	gf.P("}")
+	gf.P("})")
+	gf.P(`b.Run("warm", func(b *testing.B) {`)
+	gf.P("if err := warmUpValidators(example); err != nil {")
+	gf.P(`b.Fatalf("warm up: %v", err)`)
+	gf.P("}")
+	gf.P("b.ReportAllocs()")
+	gf.P("b.ResetTimer()")
+	gf.P("for range b.N {")
+	gf.P("_ = ValidateMessage(example)")
+	gf.P("}")
+	gf.P("})")
+	gf.P("}")
+}
+
+// generateBenchmarkHelpers generates the runtime shared by the benchmarks of a file.
+//
+//nolint:funlen // The helpers are emitted together as one block of the benchmark file
+func (g *Generator) generateBenchmarkHelpers(gf *protogen.GeneratedFile) {
+	protoMessage := gf.QualifiedGoIdent(protoPackage.Ident("Message"))
+	gf.P("// benchmarkLargeSize is the number of elements the repeated fields of the large")
+	gf.P("// benchmark request hold.")
+	gf.P("const benchmarkLargeSize = ", benchmarkLargeSize)
+	gf.P()
+
+	gf.P("// benchmarkBinding runs the empty, example and large sub-benchmarks of a method.")
+	gf.P("// Path values always come from example. Declared headers are not validated, so")
+	gf.P("// only the binding and validation of the request message is measured.")
+	gf.P("func benchmarkBinding[Req any](")
+	gf.P("b *testing.B, httpMethod string, example ", protoMessage, ",")
+	gf.P("pathParams []PathParamConfig, queryParams []QueryParamConfig, headerParams []HeaderParamConfig,")
+	gf.P("body BodyConfig,")
+	gf.P(") {")
+	gf.P("b.Helper()")
+	gf.P("large := ", gf.QualifiedGoIdent(protoPackage.Ident("Clone")), "(example)")
+	gf.P("benchmarkGrowLists(large.ProtoReflect(), benchmarkLargeSize)")
+	gf.P()
+	gf.P("next := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})")
+	gf.P("handler := BindingMiddleware[Req](next, nil, nil, pathParams, queryParams, headerParams,")
+	gf.P("httpMethod, body, nil, protojson.MarshalOptions{}, nil, nil, sebufhttp.ValidationFailClosed, nil, nil,")
+	gf.P("sebufhttp.TimeoutHeader{})")
+	gf.P("hasBody := httpMethod == http.MethodPost || httpMethod == http.MethodPut || httpMethod == http.MethodPatch")
+	gf.P()
+	gf.P("for _, request := range []struct {")
+	gf.P("name string")
+	gf.P("msg  ", protoMessage)
+	gf.P("}{")
+	gf.P(`{"empty", nil},`)
+	gf.P(`{"example", example},`)
+	gf.P(`{"large", large},`)
+	gf.P("} {")
+	gf.P("b.Run(request.name, func(b *testing.B) {")
+	gf.P("query := url.Values{}")
+	gf.P("var payload []byte")
+	gf.P("if request.msg != nil {")
+	gf.P("for _, param := range queryParams {")
+	gf.P("for _, value := range benchmarkFieldValues(request.msg, param.FieldName) {")
+	gf.P("query.Add(param.QueryName, value)")
+	gf.P("}")
+	gf.P("}")
+	gf.P("if hasBody {")
+	gf.P("var err error")
+	gf.P("if payload, err = marshalJSONWithOpts(request.msg, protojson.MarshalOptions{}); err != nil {")
+	gf.P(`b.Fatalf("marshal request: %v", err)`)
+	gf.P("}")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+	gf.P(`r := httptest.NewRequest(httpMethod, "/?"+query.Encode(), nil)`)
+	gf.P(`r.Header.Set("Content-Type", JSONContentType)`)
+	if g.stdlibRouter() {
+		gf.P("for _, param := range pathParams {")
+		gf.P("if values := benchmarkFieldValues(example, param.FieldName); len(values) > 0 {")
+		gf.P("r.SetPathValue(param.URLParam, values[0])")
+		gf.P("}")
+		gf.P("}")
+	} else {
+		gf.P("pathValues := map[string]string{}")
+		gf.P("for _, param := range pathParams {")
+		gf.P("if values := benchmarkFieldValues(example, param.FieldName); len(values) > 0 {")
+		gf.P("pathValues[param.URLParam] = values[0]")
+		gf.P("}")
+		gf.P("}")
+		g.generateSetPathValues(gf, "pathValues")
+	}
+	gf.P("if request.msg != nil {")
+	gf.P("for _, param := range headerParams {")
+	gf.P("if values := benchmarkFieldValues(request.msg, param.FieldName); len(values) > 0 {")
+	gf.P("r.Header.Set(param.HeaderName, values[0])")
+	gf.P("}")
+	gf.P("}")
+	gf.P("}")
+	gf.P("w := benchmarkResponseWriter{header: http.Header{}}")
+	gf.P("reader := bytes.NewReader(payload)")
+	gf.P("readCloser := io.NopCloser(reader)")
+	gf.P()
+	gf.P("b.SetBytes(int64(len(payload)))")
+	gf.P("b.ReportAllocs()")
+	gf.P("b.ResetTimer()")
+	gf.P("for range b.N {")
+	gf.P("// Binding replaces the body, so every iteration starts from a fresh one")
+	gf.P("r.Body, r.ContentLength = http.NoBody, 0")
+	gf.P("if len(payload) > 0 {")
+	gf.P("reader.Reset(payload)")
+	gf.P("r.Body, r.ContentLength = readCloser, int64(len(payload))")
+	gf.P("}")
+	gf.P("handler.ServeHTTP(w, r)")
+	gf.P("}")
+	gf.P("})")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// benchmarkFieldValues returns the values of a populated field of msg as they")
+	gf.P("// appear in a URL or header: one per element of a repeated field, enums by name.")
+	gf.P("func benchmarkFieldValues(msg ", protoMessage, ", fieldName string) []string {")
+	gf.P("m := msg.ProtoReflect()")
+	gf.P("field := m.Descriptor().Fields().ByName(protoreflect.Name(fieldName))")
+	gf.P("if field == nil || !m.Has(field) {")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P("format := func(v protoreflect.Value) string {")
+	gf.P("if field.Kind() == protoreflect.EnumKind {")
+	gf.P("if enumValue := field.Enum().Values().ByNumber(v.Enum()); enumValue != nil {")
+	gf.P("return string(enumValue.Name())")
+	gf.P("}")
+	gf.P("}")
+	gf.P("return v.String()")
+	gf.P("}")
+	gf.P("if !field.IsList() {")
+	gf.P("return []string{format(m.Get(field))}")
+	gf.P("}")
+	gf.P("list := m.Get(field).List()")
+	gf.P("values := make([]string, 0, list.Len())")
+	gf.P("for i := range list.Len() {")
+	gf.P("values = append(values, format(list.Get(i)))")
+	gf.P("}")
+	gf.P("return values")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// benchmarkGrowLists repeats the first element of every non-empty repeated field")
+	gf.P("// of m, and of the messages it holds, until the field has n elements.")
+	gf.P("func benchmarkGrowLists(m protoreflect.Message, n int) {")
+	gf.P("var lists []protoreflect.List")
+	gf.P("m.Range(func(field protoreflect.FieldDescriptor, v protoreflect.Value) bool {")
+	gf.P("switch {")
+	gf.P("case field.IsList():")
+	gf.P("lists = append(lists, v.List())")
+	gf.P("case field.IsMap():")
+	gf.P("// Map entries are left as they are")
+	gf.P("case field.Message() != nil:")
+	gf.P("benchmarkGrowLists(v.Message(), n)")
+	gf.P("}")
+	gf.P("return true")
+	gf.P("})")
+	gf.P("for _, list := range lists {")
+	gf.P("for list.Len() > 0 && list.Len() < n {")
+	gf.P("list.Append(list.Get(0))")
+	gf.P("}")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// benchmarkResponseWriter discards the responses of rejected requests.")
+	gf.P("type benchmarkResponseWriter struct {")
+	gf.P("header http.Header")
+	gf.P("}")
+	gf.P()
+	gf.P("func (w benchmarkResponseWriter) Header() http.Header { return w.header }")
+	gf.P()
+	gf.P("func (w benchmarkResponseWriter) Write(p []byte) (int, error) { return len(p), nil }")
+	gf.P()
+	gf.P("func (w benchmarkResponseWriter) WriteHeader(int) {}")
+}
@@ -0,0 +1,219 @@
+package httpgen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestBindingBenchmarkIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server with generate_benchmarks=true from a proto
+//     whose methods have required body fields, rules only on a nested
+//     message, and no rules at all,
+//  2. writes a temporary Go module that serves it with httptest,
+//  3. verifies the validation policy is only consulted for requests with
+//     rules, empty bodies of methods without required body fields bind in
+//     any encoding, and the generated benchmarks run for every method.
+func TestBindingBenchmarkIntegration(t *testing.T) {
+	dir := plugintest.Generate(t, plugintest.Module{
+		Path:    "binding_benchmark_test",
+		Protos:  map[string]string{"accounts.proto": bindingBenchmarkProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http", Opt: "generate_benchmarks=true"}},
+		Files:   map[string]string{"gen/fast_path_test.go": bindingBenchmarkIntegrationTestCode},
+	})
+	if _, statErr := os.Stat(filepath.Join(dir, "gen", "accounts_http_binding_benchmark_test.go")); statErr != nil {
+		t.Fatalf("benchmark file not generated: %v", statErr)
+	}
+
+	testOut := string(plugintest.GoTest(t, dir, "-bench=.", "-benchtime=1x"))
+	for _, method := range []string{"CreateAccount", "UpdateProfile", "ListAccounts"} {
+		for _, size := range []string{"empty", "example", "large"} {
+			if name := "BenchmarkAccountServiceBinding/" + method + "/" + size; !strings.Contains(testOut, name) {
+				t.Errorf("benchmark %s did not run", name)
+			}
+		}
+	}
+	// ListAccountsRequest has no rules, so it has no first validation benchmark
+	for _, method := range []string{"CreateAccount", "UpdateProfile"} {
+		for _, state := range []string{"cold", "warm"} {
+			name := "BenchmarkAccountServiceFirstValidation/" + method + "/" + state
+			if !strings.Contains(testOut, name) {
+				t.Errorf("benchmark %s did not run", name)
+			}
+		}
+	}
+	if strings.Contains(testOut, "BenchmarkAccountServiceFirstValidation/ListAccounts") {
+		t.Error("ListAccounts has no validation rules to benchmark")
+	}
+}
+
+const bindingBenchmarkProto = `syntax = "proto3";
+package test.bindingbenchmark;
+option go_package = "binding_benchmark_test/gen;gen";
+import "buf/validate/validate.proto";
+import "sebuf/http/annotations.proto";
+
+service AccountService {
+  option (sebuf.http.service_config) = { base_path: "/api" };
+  rpc CreateAccount(CreateAccountRequest) returns (Account) {
+    option (sebuf.http.config) = { path: "/accounts" method: HTTP_METHOD_POST };
+  }
+  rpc UpdateProfile(UpdateProfileRequest) returns (Account) {
+    option (sebuf.http.config) = { path: "/accounts/{account_id}" method: HTTP_METHOD_PUT };
+  }
+  rpc ListAccounts(ListAccountsRequest) returns (Account) {
+    option (sebuf.http.config) = { path: "/accounts" method: HTTP_METHOD_GET };
+  }
+}
+
+message CreateAccountRequest {
+  string display_name = 1 [(buf.validate.field).required = true];
+  repeated string tags = 2 [(buf.validate.field).repeated.max_items = 200];
+}
+
+// Profile holds the only rules of UpdateProfileRequest
+message Profile {
+  string nickname = 1 [(buf.validate.field).string.min_len = 2];
+}
+
+// UpdateProfileRequest requires its path variable, which is not a body field
+message UpdateProfileRequest {
+  string account_id = 1 [(buf.validate.field).required = true];
+  Profile profile = 2;
+  repeated Profile history = 3;
+}
+
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_ACTIVE = 1;
+}
+
+message ListAccountsRequest {
+  repeated string tags = 1 [(sebuf.http.query) = { name: "tag" }];
+  Status status = 2 [(sebuf.http.query) = { name: "status" }];
+  string x_tenant_id = 3 [(sebuf.http.source) = FIELD_SOURCE_HEADER];
+}
+
+message Account {
+  string account_id = 1;
+}
+`
+
+// bindingBenchmarkIntegrationTestCode runs inside the generated package, next
+// to the generated benchmarks. The policy records the requests it is consulted
+// for, and the server answers every request.
+const bindingBenchmarkIntegrationTestCode = `package gen
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+)
+
+type accountServer struct{}
+
+func (accountServer) CreateAccount(context.Context, *CreateAccountRequest) (*Account, error) {
+	return &Account{}, nil
+}
+
+func (accountServer) UpdateProfile(_ context.Context, req *UpdateProfileRequest) (*Account, error) {
+	return &Account{AccountId: req.GetAccountId()}, nil
+}
+
+func (accountServer) ListAccounts(context.Context, *ListAccountsRequest) (*Account, error) {
+	return &Account{}, nil
+}
+
+// serve starts the server, returning its URL and the paths the validation
+// policy was consulted for with a request message.
+func serve(t *testing.T) (string, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var consulted []string
+	policy := func(r *http.Request, msg proto.Message) sebufhttp.ValidationMode {
+		if msg != nil {
+			mu.Lock()
+			consulted = append(consulted, r.Method+" "+r.URL.Path)
+			mu.Unlock()
+		}
+		return sebufhttp.ValidationEnforce
+	}
+	mux := http.NewServeMux()
+	if err := RegisterAccountServiceServer(accountServer{}, WithMux(mux), WithValidationPolicy(policy)); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv.URL, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), consulted...)
+	}
+}
+
+func send(t *testing.T, method, url, contentType, body string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(respBody)
+}
+
+func TestValidationSkippedWithoutRules(t *testing.T) {
+	url, consulted := serve(t)
+
+	if status, body := send(t, "GET", url+"/api/accounts?tag=a", "application/json", ""); status != http.StatusOK {
+		t.Fatalf("list: status %d: %s", status, body)
+	}
+	if got := consulted(); len(got) != 0 {
+		t.Errorf("policy consulted for a request without rules: %v", got)
+	}
+
+	status, body := send(t, "PUT", url+"/api/accounts/a1", "application/json", "{\"profile\":{\"nickname\":\"x\"}}")
+	if status != http.StatusBadRequest || !strings.Contains(body, "profile.nickname") {
+		t.Fatalf("rules on a nested message: status %d: %s", status, body)
+	}
+	if got := consulted(); len(got) != 1 || got[0] != "PUT /api/accounts/a1" {
+		t.Errorf("policy consulted for %v, want the update", got)
+	}
+}
+
+func TestEmptyBodies(t *testing.T) {
+	url, _ := serve(t)
+
+	// The path variable is required, but no body field is
+	for _, contentType := range []string{"application/json", "application/x-protobuf", "multipart/form-data"} {
+		status, body := send(t, "PUT", url+"/api/accounts/a1", contentType, "")
+		if status != http.StatusOK || !strings.Contains(body, "a1") {
+			t.Errorf("empty %s update: status %d: %s", contentType, status, body)
+		}
+	}
+
+	status, body := send(t, "POST", url+"/api/accounts", "application/json", "")
+	if status != http.StatusBadRequest || !strings.Contains(body, "display_name") {
+		t.Errorf("empty create: status %d: %s", status, body)
+	}
+}
+`
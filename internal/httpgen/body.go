@@ -0,0 +1,105 @@
+package httpgen
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// bodyConfigLiteral returns the BodyConfig a method's handler is registered
+// with: its static binding settings (see bodyConfigFields), whether JSON bodies
+// are strict, the server's maximum body size, its Any type resolver, whether
+// it sniffs bodies and, when the request has deprecated fields or query
+// parameter aliases, its deprecation reporter.
+func (g *Generator) bodyConfigLiteral(method *protogen.Method) string {
+	fields := g.bodyConfigFields(method)
+	if annotations.IsStrictJSON(method) {
+		fields = append(fields, "StrictJSON: true")
+	} else {
+		fields = append(fields, "StrictJSON: config.strictJSON")
+	}
+	fields = append(fields, "MaxSize: config.maxBodySize", "TypeResolver: config.typeResolver",
+		"NoContentSniffing: config.noContentSniffing")
+	if hasDeprecatedBodyFields(method) || hasQueryAliases(method) {
+		fields = append(fields, "ReportDeprecated: config.deprecationReporter",
+			`FullMethod: "`+string(method.Desc.FullName())+`"`)
+	}
+	return "BodyConfig{" + strings.Join(fields, ", ") + "}"
+}
+
+// defaultBodyConfigLiteral returns the BodyConfig of a method as registered by a
+// server without options.
+func (g *Generator) defaultBodyConfigLiteral(method *protogen.Method) string {
+	fields := g.bodyConfigFields(method)
+	if annotations.IsStrictJSON(method) {
+		fields = append(fields, "StrictJSON: true")
+	}
+	return "BodyConfig{" + strings.Join(fields, ", ") + "}"
+}
+
+// bodyConfigFields returns the BodyConfig settings known when generating a
+// method: the body encodings it accepts besides JSON and protobuf, whether it
+// reads JSON bodies as merge patches, whether an empty body can skip binding
+// because no body field is required, and whether validation can be skipped
+// because the request carries no buf.validate rules.
+func (g *Generator) bodyConfigFields(method *protogen.Method) []string {
+	fields := []string{}
+	if config := annotations.GetMethodHTTPConfig(method); config != nil {
+		if config.AcceptForm {
+			fields = append(fields, "AcceptForm: true")
+		}
+		if config.AcceptMultipart {
+			fields = append(fields, "AcceptMultipart: true")
+		}
+		if config.MergePatch {
+			fields = append(fields, "MergePatch: true")
+		}
+	}
+	httpMethod := g.getHTTPMethod(method)
+	hasBody := httpMethod == "POST" || httpMethod == "PUT" || httpMethod == "PATCH"
+	if hasBody && !annotations.HasRequiredBodyFields(method.Input, g.getPathParams(method)) {
+		fields = append(fields, "OptionalBody: true")
+	}
+	// Without any rules in the file, the binding never validates
+	if g.features.messageValidation && !annotations.HasValidationRules(method.Input.Desc) {
+		fields = append(fields, "NoValidationRules: true")
+	}
+	return fields
+}
+
+// generateBodyBindingErrorFunc generates bodyBindingError, which turns a body
+// binding failure into the error written to the client.
+func (g *Generator) generateBodyBindingErrorFunc(gf *protogen.GeneratedFile) {
+	gf.P("// bodyBindingError converts a body binding failure into the error written to the")
+	gf.P("// client: form and multipart bodies report their violations per field, bodies over")
+	gf.P("// the size limit are answered with 413, bodies that do not look like their")
+	gf.P("// Content-Type with 415, anything else is a violation of body.")
+	gf.P("func bodyBindingError(err error) error {")
+	gf.P("var fieldErr *sebufhttp.ValidationError")
+	gf.P("if errors.As(err, &fieldErr) {")
+	gf.P("return fieldErr")
+	gf.P("}")
+	gf.P("var mediaTypeErr *sebufhttp.Error")
+	gf.P("if errors.As(err, &mediaTypeErr) {")
+	gf.P("return mediaTypeErr")
+	gf.P("}")
+	gf.P("var maxBytesErr *http.MaxBytesError")
+	gf.P("if errors.As(err, &maxBytesErr) {")
+	gf.P("return &sebufhttp.Error{")
+	gf.P("Code:    sebufhttp.ErrorCodePayloadTooLarge,")
+	gf.P(`Message: fmt.Sprintf("request body exceeds %d bytes", maxBytesErr.Limit),`)
+	gf.P("}")
+	gf.P("}")
+	gf.P("return &sebufhttp.ValidationError{")
+	gf.P("Violations: []*sebufhttp.FieldViolation{")
+	gf.P("{")
+	gf.P(`Field:       "body",`)
+	gf.P(`Description: fmt.Sprintf("failed to parse request body: %v", err),`)
+	gf.P("},")
+	gf.P("},")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+}
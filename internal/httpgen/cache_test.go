@@ -0,0 +1,141 @@
+package httpgen
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestResponseCacheGeneration verifies that methods annotated with cache wrap
+// their service call in sebufhttp.ResponseCacheMiddleware inside
+// BindingMiddleware, so cache hits still run header and request validation.
+func TestResponseCacheGeneration(t *testing.T) {
+	files := generateTestFiles(t, "http_verbs_comprehensive.proto")
+
+	t.Run("cached method wraps the service call", func(t *testing.T) {
+		want := "getResourceHandler := sebufhttp.ResponseCacheMiddleware(\n" +
+			"\t\tgenericHandler(server.GetResource, config.errorHandler, config.marshalOpts, limiter, " +
+			"config.defaultTimeout, config.warningsInBody),\n" +
+			"\t\tresponseCache, \"test.httpgen.RESTfulAPIService.GetResource\",\n" +
+			"\t\tsebufhttp.CachePolicy{MaxAge: 60 * time.Second, Public: true, " +
+			"VaryHeaders: []string{\"X-Api-Key\", \"X-Client-Version\"}},\n" +
+			"\t)\n" +
+			"\tgetResourceHandler = BindingMiddleware[GetResourceRequest](\n" +
+			"\t\tgetResourceHandler, serviceHeaders, methodHeaders,"
+		if !strings.Contains(files.http, want) {
+			t.Error("GetResource should be cached inside BindingMiddleware")
+		}
+	})
+
+	t.Run("other methods are not cached", func(t *testing.T) {
+		if n := strings.Count(files.http, "sebufhttp.ResponseCacheMiddleware("); n != 1 {
+			t.Errorf("expected exactly one ResponseCacheMiddleware call, got %d", n)
+		}
+	})
+
+	t.Run("cache is opt-in per service", func(t *testing.T) {
+		want := "if config.responseCacheSize > 0 {\n" +
+			"\t\tresponseCache = sebufhttp.NewResponseCache(config.responseCacheSize)"
+		if !strings.Contains(files.http, want) {
+			t.Error("responseCache should only be created when WithResponseCache is set")
+		}
+		if !strings.Contains(files.config, "func WithResponseCache(size int) ServerOption") {
+			t.Error("config file missing WithResponseCache")
+		}
+	})
+}
+
+// TestResponseCacheGenerationWithoutCachedMethods verifies files without cached
+// methods do not import time or create a cache.
+func TestResponseCacheGenerationWithoutCachedMethods(t *testing.T) {
+	files := generateTestFiles(t, "backward_compat.proto")
+	if strings.Contains(files.http, `"time"`) || strings.Contains(files.http, "responseCache") {
+		t.Error("files without cached methods should not reference the response cache")
+	}
+}
+
+// TestResponseCacheRejectedOnMutatingMethods verifies generation fails when a
+// non-GET method is annotated with cache.
+func TestResponseCacheRejectedOnMutatingMethods(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping cache validation test")
+	}
+
+	baseDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	projectRoot := filepath.Join(baseDir, "..", "..")
+	pluginPath := filepath.Join(projectRoot, "bin", "protoc-gen-go-http")
+	if _, statErr := os.Stat(pluginPath); os.IsNotExist(statErr) {
+		t.Skip("protoc-gen-go-http not built, run make build")
+	}
+
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name:    "POST",
+			config:  `path: "/orders" method: HTTP_METHOD_POST cache: { max_age_seconds: 60 }`,
+			wantErr: "cache is only supported on GET methods, but this method uses POST",
+		},
+		{
+			name:    "default verb",
+			config:  `path: "/orders" cache: { max_age_seconds: 60 }`,
+			wantErr: "cache is only supported on GET methods, but this method uses POST",
+		},
+		{
+			name:    "stream",
+			config:  `path: "/orders" method: HTTP_METHOD_GET stream: true cache: { max_age_seconds: 60 }`,
+			wantErr: "cache is not supported on streaming methods",
+		},
+		{
+			name:    "zero max age",
+			config:  `path: "/orders" method: HTTP_METHOD_GET cache: { public: true }`,
+			wantErr: "cache.max_age_seconds must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			protoDir := t.TempDir()
+			protoSrc := `syntax = "proto3";
+package test.cache;
+option go_package = "example.com/cache;cache";
+import "sebuf/http/annotations.proto";
+service Orders {
+  rpc Order(OrderRequest) returns (OrderResponse) {
+    option (sebuf.http.config) = { ` + tt.config + ` };
+  }
+}
+message OrderRequest {}
+message OrderResponse {}
+`
+			if writeErr := os.WriteFile(filepath.Join(protoDir, "cache.proto"), []byte(protoSrc), 0o600); writeErr != nil {
+				t.Fatalf("Failed to write proto: %v", writeErr)
+			}
+
+			cmd := exec.Command("protoc",
+				"--plugin=protoc-gen-go-http="+pluginPath,
+				"--go-http_out="+t.TempDir(),
+				"--go-http_opt=paths=source_relative",
+				"--proto_path="+protoDir,
+				"--proto_path="+filepath.Join(projectRoot, "proto"),
+				"cache.proto",
+			)
+			var stderr bytes.Buffer
+			cmd.Stderr = &stderr
+			if runErr := cmd.Run(); runErr == nil {
+				t.Fatal("expected generation to fail")
+			}
+			if !strings.Contains(stderr.String(), tt.wantErr) {
+				t.Errorf("unexpected error output: %s", stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package httpgen
+
+import (
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// ExtraCodec names a wire format the extra_codecs parameter adds to the
+// generated server besides JSON and protobuf. The server converts its bodies
+// from and to JSON with the sebufhttp.Codec registered for the format, so the
+// generated code does not depend on the format's library.
+type ExtraCodec string
+
+const (
+	// ExtraCodecMsgpack serves application/msgpack bodies with the codec the
+	// github.com/SebastienMelki/sebuf/http/msgpackcodec package registers.
+	ExtraCodecMsgpack ExtraCodec = "msgpack"
+)
+
+// validateExtraCodecs checks the extra_codecs parameter.
+func (g *Generator) validateExtraCodecs() error {
+	for _, codec := range g.extraCodecs {
+		if codec != ExtraCodecMsgpack {
+			return fmt.Errorf("unsupported extra_codecs %q: expected %q", codec, ExtraCodecMsgpack)
+		}
+	}
+	return nil
+}
+
+// servesMsgpack reports whether the server binds and writes msgpack bodies.
+func (g *Generator) servesMsgpack() bool {
+	return slices.Contains(g.extraCodecs, ExtraCodecMsgpack)
+}
+
+// generateMsgpackFunctions generates the msgpack binding and marshaling. Both
+// go through JSON: a request body is converted to JSON and bound like a JSON
+// body, a response is marshaled to JSON and converted, so msgpack bodies use
+// the JSON names and encodings of their fields.
+func (g *Generator) generateMsgpackFunctions(gf *protogen.GeneratedFile) {
+	gf.P("// msgpackResponseContentType answers msgpack requests in msgpack if its codec is")
+	gf.P("// registered, and in JSON otherwise.")
+	gf.P("func msgpackResponseContentType() string {")
+	gf.P("if _, ok := sebufhttp.LookupCodec(MsgpackContentType); ok {")
+	gf.P("return MsgpackContentType")
+	gf.P("}")
+	gf.P("return JSONContentType")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// bindDataFromMsgpackRequest converts a msgpack body to JSON and binds it like a")
+	gf.P("// JSON body. Without a registered msgpack codec the body is answered with 415.")
+	gf.P("func bindDataFromMsgpackRequest[Req any](r *http.Request, toBind *Req, body BodyConfig) error {")
+	gf.P("codec, ok := sebufhttp.LookupCodec(MsgpackContentType)")
+	gf.P("if !ok {")
+	gf.P("return sebufhttp.CodecNotRegisteredError(MsgpackContentType)")
+	gf.P("}")
+	gf.P("bodyBytes, err := io.ReadAll(r.Body)")
+	gf.P("if err != nil {")
+	gf.P(`return fmt.Errorf("could not read request body: %w", err)`)
+	gf.P("}")
+	gf.P("if len(bodyBytes) == 0 {")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P("jsonBytes, err := codec.ToJSON(bodyBytes)")
+	gf.P("if err != nil {")
+	gf.P(`return fmt.Errorf("could not decode request msgpack: %w", err)`)
+	gf.P("}")
+	gf.P("r.Body = io.NopCloser(bytes.NewReader(jsonBytes))")
+	gf.P("return bindDataFromJSONRequest(r, toBind, body)")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// marshalMsgpack marshals msg to JSON and converts it to msgpack.")
+	gf.P("func marshalMsgpack(msg proto.Message, marshalOpts protojson.MarshalOptions) ([]byte, error) {")
+	gf.P("codec, ok := sebufhttp.LookupCodec(MsgpackContentType)")
+	gf.P("if !ok {")
+	gf.P("return nil, sebufhttp.CodecNotRegisteredError(MsgpackContentType)")
+	gf.P("}")
+	gf.P("data, err := marshalJSONWithOpts(msg, marshalOpts)")
+	gf.P("if err != nil {")
+	gf.P("return nil, err")
+	gf.P("}")
+	gf.P("return codec.FromJSON(data)")
+	gf.P("}")
+	gf.P()
+}
@@ -0,0 +1,202 @@
+package httpgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// Compat selects a compatibility mode, in which the generated server mimics
+// another HTTP gateway so its clients keep working unchanged.
+type Compat string
+
+const (
+	// CompatNone generates sebuf's own behavior.
+	CompatNone Compat = ""
+	// CompatGRPCGateway mimics grpc-gateway: query parameters of methods
+	// without a body address any request field by its dotted field path, and
+	// errors are written as google.rpc.Status.
+	CompatGRPCGateway Compat = "grpc_gateway"
+)
+
+func (c Compat) valid() bool {
+	return c == CompatNone || c == CompatGRPCGateway
+}
+
+// grpcGateway reports whether the server mimics grpc-gateway.
+func (g *Generator) grpcGateway() bool {
+	return g.compat == CompatGRPCGateway
+}
+
+// compatSkippedRules lists the method checks the compatibility mode lifts.
+// grpc-gateway binds any field of a request without a body from the query,
+// message fields included by their dotted paths, so such requests may have
+// fields no path or query annotation binds.
+func (g *Generator) compatSkippedRules() []string {
+	if g.grpcGateway() {
+		return []string{"get-body-fields", "query-field-type"}
+	}
+	return nil
+}
+
+// generateQueryFieldPathsCall binds the query field paths of the request
+// message held in msgVar, returning a malformed value like any binding error.
+func (g *Generator) generateQueryFieldPathsCall(gf *protogen.GeneratedFile, msgVar string) {
+	if !g.grpcGateway() {
+		return
+	}
+	gf.P("if err := bindQueryFieldPaths(r, ", msgVar, ", httpMethod, pathParams, queryParams); err != nil {")
+	gf.P("return err")
+	gf.P("}")
+}
+
+// generateQueryFieldPathsFuncs generates bindQueryFieldPaths, which binds the
+// query parameters grpc-gateway binds and sebuf's own query binding does not.
+func (g *Generator) generateQueryFieldPathsFuncs(gf *protogen.GeneratedFile) {
+	if !g.grpcGateway() {
+		return
+	}
+	gf.P("// bindQueryFieldPaths binds the query parameters of a request without a body that")
+	gf.P("// no QueryParamConfig claims, as grpc-gateway does: a parameter name is a path of")
+	gf.P("// field names (proto or JSON names, joined with dots) through nested messages,")
+	gf.P("// which are created as needed. Names that address no scalar field, or a field")
+	gf.P("// bound from the path, are ignored.")
+	gf.P("func bindQueryFieldPaths(r *http.Request, msg proto.Message, httpMethod string,")
+	gf.P("pathParams []PathParamConfig, queryParams []QueryParamConfig) *sebufhttp.ValidationError {")
+	gf.P(`if httpMethod == "POST" || httpMethod == "PUT" || httpMethod == "PATCH" {`)
+	gf.P("return nil")
+	gf.P("}")
+	gf.P("query := r.URL.Query()")
+	gf.P("claimed := make(map[string]bool, len(queryParams))")
+	gf.P("for _, param := range queryParams {")
+	gf.P("claimed[param.QueryName] = true")
+	if g.features.queryAliases {
+		gf.P("for _, alias := range param.Aliases {")
+		gf.P("claimed[alias] = true")
+		gf.P("}")
+	}
+	gf.P("}")
+	gf.P("pathFields := make(map[string]bool, len(pathParams))")
+	gf.P("for _, param := range pathParams {")
+	gf.P("pathFields[param.FieldName] = true")
+	gf.P("}")
+	gf.P("names := make([]string, 0, len(query))")
+	gf.P("for name := range query {")
+	gf.P("if !claimed[name] {")
+	gf.P("names = append(names, name)")
+	gf.P("}")
+	gf.P("}")
+	gf.P("sort.Strings(names)")
+	gf.P()
+	gf.P("for _, name := range names {")
+	gf.P("path := resolveQueryFieldPath(msg.ProtoReflect().Descriptor(), name)")
+	gf.P("if len(path) == 0 || pathFields[string(path[0].Name())] {")
+	gf.P("continue")
+	gf.P("}")
+	gf.P("var values []string")
+	gf.P("for _, v := range query[name] {")
+	gf.P(`if v != "" {`)
+	gf.P("values = append(values, v)")
+	gf.P("}")
+	gf.P("}")
+	gf.P("if len(values) == 0 {")
+	gf.P("continue")
+	gf.P("}")
+	gf.P()
+	gf.P("// Create the intermediate messages, then set the field")
+	gf.P("target := msg.ProtoReflect()")
+	gf.P("fieldNames := make([]string, len(path))")
+	gf.P("for i, field := range path {")
+	gf.P("fieldNames[i] = string(field.Name())")
+	gf.P("if i < len(path)-1 {")
+	gf.P("target = target.Mutable(field).Message()")
+	gf.P("}")
+	gf.P("}")
+	gf.P("field := path[len(path)-1]")
+	gf.P("if !field.IsList() {")
+	gf.P("values = values[:1]")
+	gf.P("}")
+	gf.P("for _, v := range values {")
+	gf.P("converted, err := convertStringToFieldValue(v, field)")
+	gf.P("if err != nil {")
+	gf.P("return &sebufhttp.ValidationError{")
+	gf.P("Violations: []*sebufhttp.FieldViolation{{")
+	gf.P(`Field:       strings.Join(fieldNames, "."),`)
+	gf.P(`Description: fmt.Sprintf("invalid value for query parameter %s: %v", name, err),`)
+	gf.P("}},")
+	gf.P("}")
+	gf.P("}")
+	gf.P("if field.IsList() {")
+	gf.P("target.Mutable(field).List().Append(converted)")
+	gf.P("} else {")
+	gf.P("target.Set(field, converted)")
+	gf.P("}")
+	gf.P("}")
+	gf.P("}")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// resolveQueryFieldPath returns the fields a dotted query parameter name goes")
+	gf.P("// through, ending with a scalar, enum or repeated scalar field, or nil if the")
+	gf.P("// name addresses no such field. Each segment is a proto or JSON field name.")
+	gf.P(
+		"func resolveQueryFieldPath(desc protoreflect.MessageDescriptor, name string) []protoreflect.FieldDescriptor {",
+	)
+	gf.P(`segments := strings.Split(name, ".")`)
+	gf.P("path := make([]protoreflect.FieldDescriptor, 0, len(segments))")
+	gf.P("for i, segment := range segments {")
+	gf.P("if desc == nil {")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P("field := desc.Fields().ByName(protoreflect.Name(segment))")
+	gf.P("if field == nil {")
+	gf.P("field = desc.Fields().ByJSONName(segment)")
+	gf.P("}")
+	gf.P("if field == nil || field.IsMap() {")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P("path = append(path, field)")
+	gf.P("if i == len(segments)-1 {")
+	gf.P("if field.Message() != nil {")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P("return path")
+	gf.P("}")
+	gf.P("if field.IsList() {")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P("desc = field.Message()")
+	gf.P("}")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P()
+}
+
+// generateGatewayWriteErrorWithHandlerFunc generates the writeErrorWithHandler
+// of grpc-gateway compatibility, which answers errors the error handler leaves
+// to it with a google.rpc.Status. A status the handler wrote selects the code.
+func (g *Generator) generateGatewayWriteErrorWithHandlerFunc(gf *protogen.GeneratedFile) {
+	gf.P("// writeErrorWithHandler calls custom handler if set, then marshals response.")
+	gf.P("// Errors without a handler-provided response are written as google.rpc.Status.")
+	gf.P(
+		"func writeErrorWithHandler(w http.ResponseWriter, r *http.Request, err error, handler ErrorHandler, marshalOpts protojson.MarshalOptions) {",
+	)
+	g.generateErrorCaptureSetup(gf)
+	gf.P()
+	gf.P("statusCode := defaultErrorStatusCode(err)")
+	gf.P("if capture.wroteHeader {")
+	gf.P("statusCode = capture.statusCode")
+	gf.P("}")
+	gf.P("if response == nil {")
+	gf.P("response, statusCode = sebufhttp.GatewayStatus(err, statusCode)")
+	gf.P("marshalOpts = sebufhttp.GatewayMarshalOptions(marshalOpts)")
+	gf.P("}")
+	gf.P()
+	gf.P("// If handler already set status, don't set it again")
+	gf.P("if capture.wroteHeader {")
+	gf.P("responder(marshalOpts).WriteBody(w, r, response)")
+	gf.P("return")
+	gf.P("}")
+	gf.P(`responder(marshalOpts).WriteMessage(w, r, response, statusCode, "error processing request")`)
+	gf.P("}")
+	gf.P()
+}
@@ -0,0 +1,62 @@
+package httpgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// generateContentSniffingFunctions generates the helpers the JSON and binary
+// body bindings use to reject bodies whose first byte contradicts their
+// Content-Type, unless the server is built with WithoutContentSniffing.
+func (g *Generator) generateContentSniffingFunctions(gf *protogen.GeneratedFile) {
+	gf.P("// checkJSONBodyShape rejects a body declared as contentType whose first")
+	gf.P("// non-whitespace byte cannot start a JSON value, such as a protobuf body.")
+	gf.P("func checkJSONBodyShape(contentType string, data []byte) error {")
+	gf.P(`trimmed := bytes.TrimLeft(data, " \t\r\n")`)
+	gf.P("if len(trimmed) == 0 {")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P("switch c := trimmed[0]; {")
+	gf.P("case c == '{', c == '[', c == '\"', c == '-', c >= '0' && c <= '9', c == 't', c == 'f', c == 'n':")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P("return &sebufhttp.Error{")
+	gf.P("Code: sebufhttp.ErrorCodeUnsupportedMediaType,")
+	gf.P("Message: fmt.Sprintf(")
+	gf.P(`"request body does not look like JSON: Content-Type is %s but the body starts with %s",`)
+	gf.P("contentType, bodyShape(trimmed[0]),")
+	gf.P("),")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// checkBinaryBodyShape rejects a body declared as the protobuf contentType that")
+	gf.P("// starts like a JSON object or array. Only the first byte is checked: protobuf")
+	gf.P("// messages often start with bytes JSON treats as whitespace.")
+	gf.P("func checkBinaryBodyShape(contentType string, data []byte) error {")
+	gf.P("if data[0] != '{' && data[0] != '[' {")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P("return &sebufhttp.Error{")
+	gf.P("Code: sebufhttp.ErrorCodeUnsupportedMediaType,")
+	gf.P("Message: fmt.Sprintf(")
+	gf.P(`"request body looks like JSON: Content-Type is %s but the body starts with %s; did you mean %s?",`)
+	gf.P("contentType, bodyShape(data[0]), JSONContentType,")
+	gf.P("),")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// bodyShape describes the first byte of a body in content sniffing errors.")
+	gf.P("func bodyShape(c byte) string {")
+	gf.P("switch {")
+	gf.P("case c == '{':")
+	gf.P(`return "a JSON object"`)
+	gf.P("case c == '[':")
+	gf.P(`return "a JSON array"`)
+	gf.P("case c >= ' ' && c <= '~':")
+	gf.P(`return fmt.Sprintf("text (%q)", c)`)
+	gf.P("}")
+	gf.P(`return fmt.Sprintf("binary data (byte 0x%02x)", c)`)
+	gf.P("}")
+	gf.P()
+}
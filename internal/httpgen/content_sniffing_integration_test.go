@@ -0,0 +1,166 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestContentSniffingIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server from a proto with one POST method,
+//  2. writes a temporary Go module that serves it with httptest, with and
+//     without WithoutContentSniffing,
+//  3. sends JSON and protobuf bodies under each declared content type and
+//     verifies mismatches are answered with 415 naming the declared type and
+//     the detected shape, unless sniffing is turned off.
+func TestContentSniffingIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "content_sniffing_test",
+		Protos:  map[string]string{"items.proto": contentSniffingProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"content_sniffing_test.go": contentSniffingIntegrationTestCode},
+	})
+}
+
+const contentSniffingProto = `syntax = "proto3";
+package test.contentsniffing;
+option go_package = "content_sniffing_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service ItemService {
+  rpc CreateItem(Item) returns (Item) {
+    option (sebuf.http.config) = { path: "/items" method: HTTP_METHOD_POST };
+  }
+}
+
+message Item {
+  string name = 1;
+}
+`
+
+// contentSniffingIntegrationTestCode is the test source that runs inside the
+// temp module.
+const contentSniffingIntegrationTestCode = `package content_sniffing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	gen "content_sniffing_test/gen"
+)
+
+type itemServer struct{}
+
+func (itemServer) CreateItem(_ context.Context, req *gen.Item) (*gen.Item, error) {
+	return req, nil
+}
+
+func newServer(t *testing.T, options ...gen.ServerOption) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterItemServiceServer(itemServer{}, append(options, gen.WithMux(mux))...); err != nil {
+		t.Fatalf("RegisterItemServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestContentSniffing(t *testing.T) {
+	protoBody, err := proto.Marshal(&gen.Item{Name: "widget"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonBody := []byte(` + "`" + `{"name":"widget"}` + "`" + `)
+
+	sniffing := newServer(t)
+	notSniffing := newServer(t, gen.WithoutContentSniffing())
+
+	tests := []struct {
+		name        string
+		server      *httptest.Server
+		contentType string
+		body        []byte
+		wantStatus  int
+		wantMessage []string
+	}{
+		{name: "JSON as JSON", server: sniffing, contentType: "application/json", body: jsonBody,
+			wantStatus: http.StatusOK},
+		{name: "JSON with leading whitespace", server: sniffing, contentType: "application/json; charset=utf-8",
+			body: append([]byte(" \r\n\t"), jsonBody...), wantStatus: http.StatusOK},
+		{name: "JSON array as JSON", server: sniffing, contentType: "application/json", body: []byte("[1]"),
+			wantStatus: http.StatusBadRequest},
+		{name: "protobuf as JSON", server: sniffing, contentType: "application/json", body: protoBody,
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantMessage: []string{"does not look like JSON", "application/json", "binary data (byte 0x06)"}},
+		{name: "XML as JSON", server: sniffing, contentType: "application/json", body: []byte("<item/>"),
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantMessage: []string{"application/json", "text ('<')"}},
+		{name: "protobuf without content type", server: sniffing, body: protoBody,
+			wantStatus: http.StatusBadRequest},
+		{name: "protobuf as protobuf", server: sniffing, contentType: "application/x-protobuf", body: protoBody,
+			wantStatus: http.StatusOK},
+		{name: "protobuf as octet-stream", server: sniffing, contentType: "application/octet-stream", body: protoBody,
+			wantStatus: http.StatusOK},
+		{name: "JSON object as protobuf", server: sniffing, contentType: "application/x-protobuf", body: jsonBody,
+			wantStatus: http.StatusUnsupportedMediaType,
+			wantMessage: []string{
+				"looks like JSON", "application/x-protobuf", "a JSON object", "did you mean application/json?",
+			}},
+		{name: "JSON array as octet-stream", server: sniffing, contentType: "application/octet-stream",
+			body: []byte("[1]"), wantStatus: http.StatusUnsupportedMediaType,
+			wantMessage: []string{"application/octet-stream", "a JSON array"}},
+		{name: "protobuf as JSON without sniffing", server: notSniffing, contentType: "application/json",
+			body: protoBody, wantStatus: http.StatusBadRequest},
+		{name: "JSON object as protobuf without sniffing", server: notSniffing,
+			contentType: "application/x-protobuf", body: jsonBody, wantStatus: http.StatusBadRequest},
+		{name: "JSON as JSON without sniffing", server: notSniffing, contentType: "application/json",
+			body: jsonBody, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, tt.server.URL+"/items", bytes.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			req.Header.Set("Accept", "application/json")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusUnsupportedMediaType {
+				return
+			}
+			var body struct {
+				Code    string
+				Message string
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if body.Code != "UNSUPPORTED_MEDIA_TYPE" {
+				t.Errorf("code = %q, want UNSUPPORTED_MEDIA_TYPE", body.Code)
+			}
+			for _, want := range tt.wantMessage {
+				if !strings.Contains(body.Message, want) {
+					t.Errorf("message = %q, want it to contain %q", body.Message, want)
+				}
+			}
+		})
+	}
+}
+`
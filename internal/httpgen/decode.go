@@ -0,0 +1,85 @@
+package httpgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// generateBindRequestFunc generates bindRequest, the binding of a request message
+// shared by BindingMiddleware, the streaming handlers and Decode<Method>Request.
+func (g *Generator) generateBindRequestFunc(gf *protogen.GeneratedFile) {
+	gf.P("// bindRequest binds r to toBind: the body of POST, PUT and PATCH requests first,")
+	gf.P("// because protojson.Unmarshal calls proto.Reset(), which would wipe any")
+	gf.P("// previously-set fields, then the path, query and header-sourced fields, so")
+	gf.P("// URL-stated values always win. Body failures are converted by bodyBindingError.")
+	gf.P("// w only reports bodies over the size limit to the server, and may be nil.")
+	gf.P("func bindRequest[Req any](")
+	gf.P("w http.ResponseWriter, r *http.Request, toBind *Req,")
+	gf.P("pathParams []PathParamConfig, queryParams []QueryParamConfig, headerParams []HeaderParamConfig,")
+	gf.P("httpMethod string, body BodyConfig,")
+	gf.P(") error {")
+	gf.P(`if httpMethod == "POST" || httpMethod == "PUT" || httpMethod == "PATCH" {`)
+	gf.P("if err := bindDataBasedOnContentType(w, r, toBind, body); err != nil {")
+	gf.P("return bodyBindingError(err)")
+	gf.P("}")
+	if g.features.deprecatedFields {
+		gf.P("if body.ReportDeprecated != nil {")
+		gf.P("reportDeprecatedFields(w, r, toBind, body)")
+		gf.P("}")
+	}
+	gf.P("}")
+	gf.P()
+	gf.P("msg, ok := any(toBind).(proto.Message)")
+	gf.P("if !ok {")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P("if err := bindPathParams(r, msg, pathParams); err != nil {")
+	gf.P("return err")
+	gf.P("}")
+	gf.P("if err := bindQueryParams(r, msg, queryParams); err != nil {")
+	gf.P("return err")
+	gf.P("}")
+	if g.features.queryAliases {
+		gf.P("if body.ReportDeprecated != nil {")
+		gf.P("reportQueryAliases(w, r, queryParams, body)")
+		gf.P("}")
+	}
+	g.generateQueryFieldPathsCall(gf, "msg")
+	gf.P("bindHeaderParams(r, msg, headerParams)")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P()
+}
+
+// generateDecodeRequestFuncs generates Decode<Method>Request for every method of
+// a service, binding a request outside the handlers Register<Service>Server
+// registers.
+func (g *Generator) generateDecodeRequestFuncs(gf *protogen.GeneratedFile, service *protogen.Service) {
+	for _, method := range service.Methods {
+		methodName := annotations.LowerFirst(method.GoName)
+		input := method.Input.GoIdent
+
+		gf.P("// Decode", method.GoName, "Request binds r to a ", input.GoName, " as the ", method.GoName,
+			" handler does")
+		gf.P("// without server options: its body, path, query and header-sourced fields, so")
+		gf.P("// routers other than the one ", g.registerFuncName(service), " configures reuse the")
+		gf.P("// generated binding. Path values are read with ", g.pathValueSource(), ".")
+		if g.features.messageValidation {
+			gf.P("// Declared headers and buf.validate rules are not checked (see ValidateMessage).")
+		} else {
+			gf.P("// Declared headers are not checked.")
+		}
+		gf.P("func Decode", method.GoName, "Request(r *http.Request) (*", input, ", error) {")
+		gf.P("req := new(", input, ")")
+		gf.P("err := bindRequest(nil, r, req, ", methodName, "PathParams, ", methodName, "QueryParams, ",
+			methodName, "HeaderFieldParams,")
+		gf.P(`"`, g.getHTTPMethod(method), `", `, g.defaultBodyConfigLiteral(method), ")")
+		gf.P("if err != nil {")
+		gf.P("return nil, err")
+		gf.P("}")
+		gf.P("return req, nil")
+		gf.P("}")
+		gf.P()
+	}
+}
@@ -0,0 +1,64 @@
+package httpgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// hasDeprecatedBodyFields reports whether a method reads a request body in
+// which a deprecated field, nested ones included, can be set. Methods without
+// an HTTP config are bound as POST.
+func hasDeprecatedBodyFields(method *protogen.Method) bool {
+	if config := annotations.GetMethodHTTPConfig(method); config != nil {
+		switch config.Method {
+		case "", "POST", "PUT", "PATCH":
+		default:
+			return false
+		}
+	}
+	return len(annotations.DeprecatedFieldPaths(method.Input.Desc)) > 0
+}
+
+// generateReportDeprecatedFieldsFunc generates reportDeprecatedFields, which
+// bindRequest calls under WithDeprecationReporting for methods whose request
+// has deprecated fields.
+func (g *Generator) generateReportDeprecatedFieldsFunc(gf *protogen.GeneratedFile) {
+	gf.P("// reportDeprecatedFields reports the deprecated fields the JSON body of r sets to")
+	gf.P("// body.ReportDeprecated and lists them in the Deprecation header of w. The body,")
+	gf.P("// which bindDataFromJSONRequest left readable, is read again for the keys it")
+	gf.P("// holds; protobuf, form and multipart bodies are not checked.")
+	gf.P("func reportDeprecatedFields[Req any](w http.ResponseWriter, r *http.Request, toBind *Req, body BodyConfig) {")
+	gf.P("msg, ok := any(toBind).(proto.Message)")
+	gf.P("if !ok {")
+	gf.P("return")
+	gf.P("}")
+	gf.P(`switch filterFlags(r.Header.Get("Content-Type")) {`)
+	if g.servesMsgpack() {
+		gf.P("case BinaryContentType, ProtoContentType, MsgpackContentType:")
+	} else {
+		gf.P("case BinaryContentType, ProtoContentType:")
+	}
+	gf.P("return")
+	gf.P("case FormContentType:")
+	gf.P("if body.AcceptForm {")
+	gf.P("return")
+	gf.P("}")
+	if g.features.multipart {
+		gf.P("case MultipartContentType:")
+		gf.P("if body.AcceptMultipart {")
+		gf.P("return")
+		gf.P("}")
+	}
+	gf.P("}")
+	gf.P()
+	gf.P("bodyBytes, err := io.ReadAll(r.Body)")
+	gf.P("r.Body = io.NopCloser(bytes.NewReader(bodyBytes))")
+	gf.P("if err != nil {")
+	gf.P("return")
+	gf.P("}")
+	gf.P("fields := sebufhttp.DeprecatedJSONFields(bodyBytes, msg.ProtoReflect().Descriptor())")
+	gf.P("sebufhttp.ReportDeprecatedFields(w, body.ReportDeprecated, body.FullMethod, fields)")
+	gf.P("}")
+	gf.P()
+}
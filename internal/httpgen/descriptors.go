@@ -0,0 +1,168 @@
+package httpgen
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// descriptorBytesPerLine is the number of bytes written per line of the
+// embedded descriptor set.
+const descriptorBytesPerLine = 16
+
+// descriptorsVarName returns the name of the variable holding the compressed
+// descriptor set of file.
+func descriptorsVarName(file *protogen.File) string {
+	return "file_" + strings.TrimPrefix(file.GoDescriptorIdent.GoName, "File_") + "_httpDescriptors"
+}
+
+// compressedDescriptorSet returns the gzip-compressed FileDescriptorSet of file
+// and of the files it imports, directly or not, each listed after its imports
+// as protoc --include_imports does. Source code info is left out.
+func compressedDescriptorSet(file protoreflect.FileDescriptor) ([]byte, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	var visit func(fd protoreflect.FileDescriptor)
+	visit = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		imports := fd.Imports()
+		for i := range imports.Len() {
+			visit(imports.Get(i).FileDescriptor)
+		}
+		fdp := protodesc.ToFileDescriptorProto(fd)
+		fdp.SourceCodeInfo = nil
+		set.File = append(set.File, fdp)
+	}
+	visit(file)
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(set)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	writer, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// generateDescriptorsFile generates the file embedding the descriptors of the
+// services of file, with a <Service>Descriptors accessor and the function
+// registering GET /__sebuf/descriptors/<service> for each service.
+func (g *Generator) generateDescriptorsFile(file *protogen.File) error {
+	compressed, err := compressedDescriptorSet(file.Desc)
+	if err != nil {
+		return fmt.Errorf("embedding descriptors of %s: %w", file.Desc.Path(), err)
+	}
+
+	filename := file.GeneratedFilenamePrefix + "_http_descriptors.pb.go"
+	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
+
+	g.writeHeader(gf, file)
+
+	gf.P("import (")
+	if !g.stdlibRouter() {
+		gf.P(`"net/http"`)
+		gf.P()
+	}
+	g.generateRouterImport(gf)
+	gf.P(`"google.golang.org/protobuf/types/descriptorpb"`)
+	gf.P()
+	gf.P(`sebufhttp "github.com/SebastienMelki/sebuf/http"`)
+	gf.P(")")
+	gf.P()
+
+	for _, service := range file.Services {
+		g.generateServiceDescriptors(gf, file, service)
+	}
+
+	varName := descriptorsVarName(file)
+	gf.P("// ", varName, " is the gzip-compressed FileDescriptorSet")
+	gf.P("// of ", file.Desc.Path(), " and of the files it imports.")
+	gf.P("var ", varName, " = []byte{")
+	for start := 0; start < len(compressed); start += descriptorBytesPerLine {
+		end := min(start+descriptorBytesPerLine, len(compressed))
+		var line strings.Builder
+		for _, b := range compressed[start:end] {
+			fmt.Fprintf(&line, "0x%02x, ", b)
+		}
+		gf.P(strings.TrimSuffix(line.String(), " "))
+	}
+	gf.P("}")
+	return nil
+}
+
+// generateServiceDescriptors generates <Service>Descriptors and the function
+// registering the endpoint serving them.
+func (g *Generator) generateServiceDescriptors(
+	gf *protogen.GeneratedFile,
+	file *protogen.File,
+	service *protogen.Service,
+) {
+	serviceName := service.GoName
+	gf.P("// ", serviceName, "Descriptors returns the descriptors of the ", serviceName, " service:")
+	gf.P("// the FileDescriptorSet of ", file.Desc.Path(), " and of the files it imports,")
+	gf.P("// each listed after its imports. Each call returns a new set.")
+	gf.P("func ", serviceName, "Descriptors() *descriptorpb.FileDescriptorSet {")
+	gf.P("return sebufhttp.DecodeDescriptorSet(", descriptorsVarName(file), ")")
+	gf.P("}")
+	gf.P()
+
+	path := "sebufhttp.DescriptorsPath + " + strconv.Quote(string(service.Desc.FullName()))
+	handler := "sebufhttp.DescriptorsHandler(" + serviceName + "Descriptors(), config.routeDebugAuth)"
+	switch g.router {
+	case RouterChi:
+		name := "Mount" + serviceName + "Reflection"
+		gf.P("// ", name, " serves ", serviceName, "Descriptors on the chi router r at")
+		g.generateReflectionDoc(gf, service)
+		gf.P("func ", name, "(r chi.Router, opts ...ServerOption) {")
+		gf.P("config := getConfiguration(opts...)")
+		gf.P("r.Method(http.MethodGet, ", path, ", ", handler, ")")
+	case RouterGorilla:
+		name := "Mount" + serviceName + "Reflection"
+		gf.P("// ", name, " serves ", serviceName, "Descriptors on the gorilla/mux router r at")
+		g.generateReflectionDoc(gf, service)
+		gf.P("func ", name, "(r *mux.Router, opts ...ServerOption) {")
+		gf.P("config := getConfiguration(opts...)")
+		gf.P("r.Handle(", path, ", ", handler, ").Methods(http.MethodGet)")
+	default:
+		name := "Register" + serviceName + "Reflection"
+		gf.P("// ", name, " serves ", serviceName, "Descriptors on the mux of")
+		gf.P("// WithMux at")
+		g.generateReflectionDoc(gf, service)
+		gf.P("func ", name, "(opts ...ServerOption) {")
+		gf.P("config := getConfiguration(opts...)")
+		gf.P(`config.mux.Handle("GET "+`, path, ", ", handler, ")")
+	}
+	gf.P("}")
+	gf.P()
+}
+
+// generateReflectionDoc emits the end of the doc comment of the function
+// registering the descriptors endpoint of service.
+func (g *Generator) generateReflectionDoc(gf *protogen.GeneratedFile, service *protogen.Service) {
+	gf.P("// GET ", http.DescriptorsPath, service.Desc.FullName(), ",")
+	gf.P("// in protobuf or JSON as the request's Accept header asks. Requests the allow")
+	gf.P("// of WithRouteDebugAuth rejects are answered with 404 Not Found. The endpoint")
+	gf.P("// only exists once registered.")
+}
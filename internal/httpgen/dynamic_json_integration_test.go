@@ -0,0 +1,198 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestDynamicJSONIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server and Go client from a proto with Any, Struct,
+//     Value and ListValue fields,
+//  2. writes a temporary Go module that serves the generated handlers with httptest,
+//  3. verifies a document holding an Any of a generated message and a Struct
+//     round-trips through the client, and that an Any of a type known only to
+//     a local registry is rejected until the server is given WithTypeResolver.
+func TestDynamicJSONIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "dynamic_json_test",
+		Protos:  map[string]string{"documents.proto": dynamicJSONProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}, {Name: "go-client"}},
+		Files:   map[string]string{"dynamic_json_test.go": dynamicJSONIntegrationTestCode},
+	})
+}
+
+const dynamicJSONProto = `syntax = "proto3";
+package test.dynamicjson;
+option go_package = "dynamic_json_test/gen;gen";
+import "google/protobuf/any.proto";
+import "google/protobuf/struct.proto";
+import "sebuf/http/annotations.proto";
+
+service DocumentService {
+  rpc SaveDocument(Document) returns (Document) {
+    option (sebuf.http.config) = { path: "/documents" method: HTTP_METHOD_POST };
+  }
+}
+
+message Note {
+  string text = 1;
+}
+
+message Document {
+  string id = 1;
+  google.protobuf.Any payload = 2;
+  google.protobuf.Struct attributes = 3;
+  google.protobuf.Value score = 4;
+  repeated google.protobuf.Any attachments = 5;
+}
+`
+
+// dynamicJSONIntegrationTestCode is the test source that runs inside the temp
+// module. The server echoes every document back.
+const dynamicJSONIntegrationTestCode = `package dynamic_json_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	gen "dynamic_json_test/gen"
+)
+
+type documentServer struct{}
+
+func (documentServer) SaveDocument(_ context.Context, req *gen.Document) (*gen.Document, error) {
+	return req, nil
+}
+
+func newServer(t *testing.T, opts ...gen.ServerOption) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	opts = append(opts, gen.WithMux(mux))
+	if err := gen.RegisterDocumentServiceServer(documentServer{}, opts...); err != nil {
+		t.Fatalf("RegisterDocumentServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClientRoundTrip(t *testing.T) {
+	srv := newServer(t)
+	payload, err := anypb.New(&gen.Note{Text: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	attributes, err := structpb.NewStruct(map[string]any{"color": "blue", "sizes": []any{1.0, 2.0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	attachment, err := anypb.New(&gen.Note{Text: "attached"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &gen.Document{
+		Id:          "d1",
+		Payload:     payload,
+		Attributes:  attributes,
+		Score:       structpb.NewNumberValue(4.5),
+		Attachments: []*anypb.Any{attachment},
+	}
+
+	client := gen.NewDocumentServiceClient(srv.URL)
+	got, err := client.SaveDocument(context.Background(), want)
+	if err != nil {
+		t.Fatalf("SaveDocument: %v", err)
+	}
+	if !proto.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	var note gen.Note
+	if err := got.GetPayload().UnmarshalTo(&note); err != nil || note.GetText() != "hello" {
+		t.Fatalf("payload = %v (%v), want the hello note", &note, err)
+	}
+}
+
+// localTypes returns a registry holding the generated Note and local.Secret, a
+// message no generated code registers globally.
+func localTypes(t *testing.T) *protoregistry.Types {
+	t.Helper()
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("local/secret.proto"),
+		Package: proto.String("local"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Secret"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("code"),
+				JsonName: proto.String("code"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			}},
+		}},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := new(protoregistry.Types)
+	for _, messageType := range []protoreflect.MessageType{
+		(&gen.Note{}).ProtoReflect().Type(),
+		dynamicpb.NewMessageType(file.Messages().Get(0)),
+	} {
+		if err := types.RegisterMessage(messageType); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return types
+}
+
+func post(t *testing.T, url, body string) (int, string) {
+	t.Helper()
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(respBody)
+}
+
+func TestTypeResolver(t *testing.T) {
+	const secret = ` + "`" + `{"id":"d2","payload":{"@type":"type.googleapis.com/local.Secret","code":"s3cret"}}` + "`" + `
+
+	status, body := post(t, newServer(t).URL+"/documents", secret)
+	if status != http.StatusBadRequest {
+		t.Fatalf("without a resolver: got %d %s, want 400", status, body)
+	}
+
+	srv := newServer(t, gen.WithTypeResolver(localTypes(t)))
+	status, body = post(t, srv.URL+"/documents", secret)
+	if status != http.StatusOK || !strings.Contains(body, "s3cret") || !strings.Contains(body, "local.Secret") {
+		t.Fatalf("with a resolver: got %d %s, want 200 echoing the secret", status, body)
+	}
+
+	// The resolver replaces the global registry, so it carries the generated Note
+	status, body = post(t, srv.URL+"/documents",
+		` + "`" + `{"payload":{"@type":"type.googleapis.com/test.dynamicjson.Note","text":"hi"}}` + "`" + `)
+	if status != http.StatusOK || !strings.Contains(body, "hi") {
+		t.Fatalf("global type: got %d %s, want 200 echoing the note", status, body)
+	}
+}
+`
@@ -0,0 +1,220 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestEmbedDescriptorsIntegration generates a Go HTTP server with
+// embed_descriptors=true, and checks that the descriptors endpoint only exists
+// once registered, that WithRouteDebugAuth hides it, and that the descriptors
+// it serves, in protobuf and JSON, rebuild the types of the service well
+// enough to read a response body without the generated code.
+func TestEmbedDescriptorsIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "embed_descriptors_test",
+		Protos:  map[string]string{"inventory.proto": embedDescriptorsProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http", Opt: "embed_descriptors=true"}},
+		Files:   map[string]string{"embed_descriptors_test.go": embedDescriptorsIntegrationTestCode},
+	})
+}
+
+const embedDescriptorsProto = `syntax = "proto3";
+package test.embeddescriptors;
+option go_package = "embed_descriptors_test/gen;gen";
+import "google/protobuf/timestamp.proto";
+import "sebuf/http/annotations.proto";
+
+service InventoryService {
+  option (sebuf.http.service_config) = { base_path: "/api/v1" };
+
+  rpc GetStock(GetStockRequest) returns (Stock) {
+    option (sebuf.http.config) = { path: "/stock/{sku}" method: HTTP_METHOD_GET };
+  }
+}
+
+message GetStockRequest {
+  string sku = 1;
+}
+
+message Stock {
+  string sku = 1;
+  int32 quantity = 2;
+  Warehouse warehouse = 3;
+  google.protobuf.Timestamp counted_at = 4;
+}
+
+enum Warehouse {
+  WAREHOUSE_UNSPECIFIED = 0;
+  WAREHOUSE_NORTH = 1;
+}
+`
+
+// embedDescriptorsIntegrationTestCode is the test source that runs inside the
+// temp module.
+const embedDescriptorsIntegrationTestCode = `package embed_descriptors_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	gen "embed_descriptors_test/gen"
+)
+
+const descriptorsPath = "/__sebuf/descriptors/test.embeddescriptors.InventoryService"
+
+var countedAt = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+type inventoryServer struct{}
+
+func (inventoryServer) GetStock(_ context.Context, req *gen.GetStockRequest) (*gen.Stock, error) {
+	return &gen.Stock{
+		Sku:       req.GetSku(),
+		Quantity:  7,
+		Warehouse: gen.Warehouse_WAREHOUSE_NORTH,
+		CountedAt: timestamppb.New(countedAt),
+	}, nil
+}
+
+// newServer registers the service on a new mux, and its descriptors too when
+// reflection is set, with WithRouteDebugAuth requiring X-Debug: yes.
+func newServer(t *testing.T, reflection bool) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	opts := []gen.ServerOption{
+		gen.WithMux(mux),
+		gen.WithRouteDebugAuth(func(r *http.Request) bool { return r.Header.Get("X-Debug") == "yes" }),
+	}
+	if err := gen.RegisterInventoryServiceServer(inventoryServer{}, opts...); err != nil {
+		t.Fatal(err)
+	}
+	if reflection {
+		gen.RegisterInventoryServiceReflection(opts...)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// get sends a GET request with headers to the server and returns its status
+// and body.
+func get(t *testing.T, srv *httptest.Server, path string, headers map[string]string) (int, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, body
+}
+
+func TestEndpointAbsentUnlessRegistered(t *testing.T) {
+	status, _ := get(t, newServer(t, false), descriptorsPath, map[string]string{"X-Debug": "yes"})
+	if status != http.StatusNotFound {
+		t.Fatalf("status %d without RegisterInventoryServiceReflection, want 404", status)
+	}
+}
+
+func TestEndpointHiddenByAuth(t *testing.T) {
+	status, _ := get(t, newServer(t, true), descriptorsPath, nil)
+	if status != http.StatusNotFound {
+		t.Fatalf("status %d without X-Debug, want 404", status)
+	}
+}
+
+func TestAccessorMatchesEndpoint(t *testing.T) {
+	status, body := get(t, newServer(t, true), descriptorsPath,
+		map[string]string{"X-Debug": "yes", "Accept": "application/x-protobuf"})
+	if status != http.StatusOK {
+		t.Fatalf("status %d, want 200: %s", status, body)
+	}
+	served := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(body, served); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(served, gen.InventoryServiceDescriptors()) {
+		t.Error("served descriptors differ from InventoryServiceDescriptors")
+	}
+}
+
+func TestDynamicDecoding(t *testing.T) {
+	srv := newServer(t, true)
+	status, captured := get(t, srv, "/api/v1/stock/A-1", nil)
+	if status != http.StatusOK {
+		t.Fatalf("GetStock status %d: %s", status, captured)
+	}
+
+	for _, accept := range []string{"application/x-protobuf", "application/json"} {
+		t.Run(accept, func(t *testing.T) {
+			status, body := get(t, srv, descriptorsPath, map[string]string{"X-Debug": "yes", "Accept": accept})
+			if status != http.StatusOK {
+				t.Fatalf("status %d, want 200: %s", status, body)
+			}
+			set := &descriptorpb.FileDescriptorSet{}
+			unmarshal := proto.Unmarshal
+			if accept == "application/json" {
+				unmarshal = protojson.Unmarshal
+			}
+			if err := unmarshal(body, set); err != nil {
+				t.Fatalf("decode descriptors: %v", err)
+			}
+
+			files, err := protodesc.NewFiles(set)
+			if err != nil {
+				t.Fatalf("rebuild files: %v", err)
+			}
+			desc, err := files.FindDescriptorByName("test.embeddescriptors.Stock")
+			if err != nil {
+				t.Fatal(err)
+			}
+			stockType := dynamicpb.NewMessageType(desc.(protoreflect.MessageDescriptor))
+			stock := stockType.New().Interface()
+			if err := protojson.Unmarshal(captured, stock); err != nil {
+				t.Fatalf("decode captured response %s: %v", captured, err)
+			}
+
+			wire, err := proto.Marshal(stock)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := &gen.Stock{}
+			if err := proto.Unmarshal(wire, got); err != nil {
+				t.Fatal(err)
+			}
+			want := &gen.Stock{
+				Sku:       "A-1",
+				Quantity:  7,
+				Warehouse: gen.Warehouse_WAREHOUSE_NORTH,
+				CountedAt: timestamppb.New(countedAt),
+			}
+			if !proto.Equal(got, want) {
+				t.Errorf("dynamically decoded %v, want %v", got, want)
+			}
+		})
+	}
+}
+`
@@ -51,13 +51,13 @@ func TestGoGeneratorsProduceIdenticalEnumEncoding(t *testing.T) {
 		t.Fatalf("Failed to read clientgen enum encoding golden file: %v", clientErr)
 	}
 
-	// Normalize the source comment (generator name differs)
-	httpgenNormalized := normalizeGeneratorComment(string(httpgenContent), "go-http")
-	clientgenNormalized := normalizeGeneratorComment(string(clientgenContent), "go-client")
+	// Only the header naming the generator may differ
+	httpgenBody := stripGeneratedHeader(t, string(httpgenContent), "protoc-gen-go-http")
+	clientgenBody := stripGeneratedHeader(t, string(clientgenContent), "protoc-gen-go-client")
 
-	if httpgenNormalized != clientgenNormalized {
-		t.Errorf("go-http and go-client enum encoding code differs after normalization")
-		t.Logf("First difference:\n%s", findFirstDifference(httpgenNormalized, clientgenNormalized))
+	if httpgenBody != clientgenBody {
+		t.Errorf("go-http and go-client enum encoding code differs")
+		t.Logf("First difference:\n%s", findFirstDifference(httpgenBody, clientgenBody))
 	} else {
 		t.Log("go-http and go-client produce identical enum encoding code")
 	}
@@ -230,6 +230,100 @@ func TestOpenAPIEnumSchemasMatchGoEncoding(t *testing.T) {
 	t.Log("OpenAPI enum schemas correctly match Go encoding")
 }
 
+// TestScopedEncodingDefaultsConsistency verifies every generator resolves
+// encodings with the same precedence: field, then message encoding_defaults,
+// then file_encoding_defaults. scoped_encoding.proto sets all three to
+// different values.
+func TestScopedEncodingDefaultsConsistency(t *testing.T) {
+	baseDir, baseErr := os.Getwd()
+	if baseErr != nil {
+		t.Fatalf("Failed to get working directory: %v", baseErr)
+	}
+
+	for _, suffix := range []string{"_encoding.pb.go", "_timestamp_format.pb.go", "_bytes_encoding.pb.go"} {
+		compareEncodingFiles(t,
+			filepath.Join(baseDir, "testdata", "golden", "scoped_encoding"+suffix),
+			filepath.Join(baseDir, "..", "clientgen", "testdata", "golden", "scoped_encoding"+suffix),
+			"scoped encoding"+strings.TrimSuffix(suffix, ".pb.go"),
+		)
+	}
+
+	goContent, goErr := os.ReadFile(
+		filepath.Join(baseDir, "testdata", "golden", "scoped_encoding_timestamp_format.pb.go"),
+	)
+	if goErr != nil {
+		t.Fatalf("Failed to read scoped timestamp format golden file: %v", goErr)
+	}
+	for _, want := range []string{
+		`raw["fileTime"], _ = json.Marshal(t.Unix())`,
+		`raw["messageTime"], _ = json.Marshal(t.UnixMilli())`,
+		`raw["fieldTime"], _ = json.Marshal(t.Format("2006-01-02"))`,
+	} {
+		if !strings.Contains(string(goContent), want) {
+			t.Errorf("Go scoped timestamp encoding should contain %q", want)
+		}
+	}
+
+	tsContent := readCombinedTSGolden(t, baseDir, "scoped_encoding")
+	tsTypes := map[string]map[string]string{
+		"FileScopedTest": {
+			"fileInt64":  "number",
+			"fileLevel":  "number",
+			"fieldInt64": "string",
+			"name":       "string",
+		},
+		"MessageScopedTest": {
+			"messageInt64": "string",
+			"messageLevel": "Level",
+			"fieldInt64":   "number",
+			"fieldLevel":   "number",
+		},
+	}
+	for message, fields := range tsTypes {
+		body := regexp.MustCompile(`(?s)interface ` + message + ` \{(.*?)\n\}`).FindStringSubmatch(tsContent)
+		if body == nil {
+			t.Fatalf("TypeScript interface %s not found", message)
+		}
+		for field, typ := range fields {
+			if !regexp.MustCompile(`\b` + field + `\??: ` + typ + `;`).MatchString(body[1]) {
+				t.Errorf("TypeScript %s.%s should have type %q", message, field, typ)
+			}
+		}
+	}
+
+	yamlContent, yamlErr := os.ReadFile(filepath.Join(
+		baseDir, "..", "openapiv3", "testdata", "golden", "yaml", "ScopedEncodingService.openapi.yaml",
+	))
+	if yamlErr != nil {
+		t.Fatalf("Failed to read OpenAPI scoped encoding golden file: %v", yamlErr)
+	}
+	openAPITypes := map[string]map[string]string{
+		"FileScopedTest": {
+			"fileInt64":  "integer",
+			"fileLevel":  "integer",
+			"fieldInt64": "string",
+		},
+		"MessageScopedTest": {
+			"messageInt64": "string",
+			"messageLevel": "string",
+			"fieldInt64":   "integer",
+			"fieldLevel":   "integer",
+		},
+	}
+	for message, fields := range openAPITypes {
+		pattern := regexp.MustCompile(`(?s)\n        ` + message + `:\n(.*?)(?:\n        \S|$)`)
+		schema := pattern.FindStringSubmatch(string(yamlContent))
+		if schema == nil {
+			t.Fatalf("OpenAPI schema %s not found", message)
+		}
+		for field, typ := range fields {
+			if !strings.Contains(schema[1], field+":\n                    type: "+typ) {
+				t.Errorf("OpenAPI %s.%s should have type: %s", message, field, typ)
+			}
+		}
+	}
+}
+
 // TestPhase4SuccessCriteria explicitly verifies each Phase 4 success criterion from ROADMAP.md.
 
 func TestPhase4SuccessCriteria(t *testing.T) {
@@ -470,11 +564,16 @@ func verifyCriterion6CrossGenerator(t *testing.T, baseDir string) {
 	t.Log("PASS: Criterion 6 verified - All generators have encoding golden files")
 }
 
-// normalizeGeneratorComment replaces the generator name in the "Code generated by" comment
-// to allow comparison between go-http and go-client output.
-func normalizeGeneratorComment(content, generatorName string) string {
-	content = strings.ReplaceAll(content, "protoc-gen-"+generatorName, "protoc-gen-NORMALIZED")
-	return content
+// stripGeneratedHeader checks that content starts with the "Code generated by"
+// header of generator and returns the rest of it.
+func stripGeneratedHeader(t *testing.T, content, generator string) string {
+	t.Helper()
+
+	header, body, _ := strings.Cut(content, "\n")
+	if want := "// Code generated by " + generator + ". DO NOT EDIT."; header != want {
+		t.Errorf("header = %q, want %q", header, want)
+	}
+	return body
 }
 
 // extractMarshalJSON extracts all MarshalJSON function blocks from Go source code.
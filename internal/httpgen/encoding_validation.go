@@ -0,0 +1,34 @@
+package httpgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/encodinggen"
+)
+
+// ValidateMessageEncoding returns the error the generator fails with when
+// msg's annotations cannot be encoded by the generated Go code: two
+// MarshalJSON-generating features on one message, or custom enum mappings the
+// encoders cannot reach. Nested messages are not visited.
+func ValidateMessageEncoding(msg *protogen.Message) error {
+	if msg.Desc.IsMapEntry() {
+		return nil
+	}
+	if err := encodinggen.ValidateEnumFieldEncodingFields(msg); err != nil {
+		return err
+	}
+	if len(encodinggen.GetCustomEnumFields(msg)) > 0 || len(encodinggen.GetNestedEnumMessageFields(msg)) > 0 {
+		if err := encodinggen.CheckEnumMarshalJSONConflict(msg); err != nil {
+			return err
+		}
+	}
+	if hasFlattenFields(msg) {
+		if err := validateFlattenMarshalJSONConflict(msg); err != nil {
+			return err
+		}
+	}
+	if hasOneofDiscriminator(msg) {
+		return checkMarshalJSONConflict(msg)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestEnumHelpersIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server with all_enum_helpers from a proto holding an
+//     enum with enum_value mappings, an unannotated nested enum, and an enum
+//     with aliases,
+//  2. runs the generated round-trip tests in a temporary Go module,
+//  3. verifies Parse<Enum>, <Enum>Values and WireString from application code.
+func TestEnumHelpersIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "enum_helpers_test",
+		Protos:  map[string]string{"accounts.proto": enumHelpersProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http", Opt: "all_enum_helpers=true"}},
+		Files:   map[string]string{"enum_helpers_test.go": enumHelpersIntegrationTestCode},
+	})
+}
+
+const enumHelpersProto = `syntax = "proto3";
+package test.enumhelpers;
+option go_package = "enum_helpers_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_ACTIVE = 1 [(sebuf.http.enum_value) = "active"];
+  STATUS_INACTIVE = 2 [(sebuf.http.enum_value) = "inactive"];
+}
+
+enum Priority {
+  option allow_alias = true;
+  PRIORITY_UNSPECIFIED = 0;
+  PRIORITY_HIGH = 1;
+  PRIORITY_URGENT = 1;
+}
+
+service AccountService {
+  rpc GetAccount(GetAccountRequest) returns (Account) {
+    option (sebuf.http.config) = { path: "/accounts/{id}" method: HTTP_METHOD_GET };
+  }
+}
+
+message GetAccountRequest {
+  string id = 1;
+}
+
+message Account {
+  enum Kind {
+    KIND_UNSPECIFIED = 0;
+    KIND_PERSONAL = 1;
+  }
+  string id = 1;
+  Status status = 2;
+  Kind kind = 3;
+  Priority priority = 4;
+}
+`
+
+// enumHelpersIntegrationTestCode is application code using the helpers, next
+// to the generated round-trip tests.
+const enumHelpersIntegrationTestCode = `package enum_helpers_test
+
+import (
+	"reflect"
+	"testing"
+
+	gen "enum_helpers_test/gen"
+)
+
+func TestParseStatus(t *testing.T) {
+	for _, s := range []string{"inactive", "STATUS_INACTIVE", "2"} {
+		if got, err := gen.ParseStatus(s); err != nil || got != gen.Status_STATUS_INACTIVE {
+			t.Errorf("ParseStatus(%q) = %v, %v, want STATUS_INACTIVE", s, got, err)
+		}
+	}
+	if _, err := gen.ParseStatus("deleted"); err == nil {
+		t.Error("ParseStatus should reject deleted")
+	}
+}
+
+func TestWireString(t *testing.T) {
+	if got := gen.Status_STATUS_ACTIVE.WireString(); got != "active" {
+		t.Errorf("WireString() = %q, want active", got)
+	}
+	if got := gen.Status_STATUS_UNSPECIFIED.WireString(); got != "STATUS_UNSPECIFIED" {
+		t.Errorf("WireString() = %q, want the proto name of an unmapped value", got)
+	}
+	if got := gen.Account_KIND_PERSONAL.WireString(); got != "KIND_PERSONAL" {
+		t.Errorf("nested WireString() = %q, want KIND_PERSONAL", got)
+	}
+}
+
+func TestValuesSkipAliases(t *testing.T) {
+	want := []gen.Priority{gen.Priority_PRIORITY_UNSPECIFIED, gen.Priority_PRIORITY_HIGH}
+	if got := gen.PriorityValues(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PriorityValues() = %v, want %v", got, want)
+	}
+	if got, err := gen.ParsePriority("PRIORITY_URGENT"); err != nil || got != gen.Priority_PRIORITY_HIGH {
+		t.Errorf("ParsePriority(PRIORITY_URGENT) = %v, %v, want the aliased value", got, err)
+	}
+}
+`
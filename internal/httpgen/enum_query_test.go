@@ -1,84 +1,16 @@
 package httpgen
 
 import (
-	"bytes"
-	"fmt"
-	"os"
-	"os/exec"
 	"path/filepath"
 	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
 )
 
 // TestEnumQueryAndPathParams exercises the generated enum handling at runtime.
 // It generates code from query_params.proto, compiles it into a test binary
 // with an httptest.Server, and verifies enum query + path parameter behavior.
 func TestEnumQueryAndPathParams(t *testing.T) {
-	if _, err := exec.LookPath("protoc"); err != nil {
-		t.Skip("protoc not found, skipping enum runtime tests")
-	}
-
-	baseDir, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-
-	projectRoot := filepath.Join(baseDir, "..", "..")
-	protoDir := filepath.Join(baseDir, "testdata", "proto")
-	pluginPath := filepath.Join(projectRoot, "bin", "protoc-gen-go-http")
-
-	// Build the plugin
-	if _, statErr := os.Stat(pluginPath); os.IsNotExist(statErr) {
-		buildCmd := exec.Command("make", "build")
-		buildCmd.Dir = projectRoot
-		if buildErr := buildCmd.Run(); buildErr != nil {
-			t.Fatalf("Failed to build plugin: %v", buildErr)
-		}
-	}
-
-	// Create temp directory for generated code + test
-	tempDir := t.TempDir()
-	genDir := filepath.Join(tempDir, "generated")
-	if mkErr := os.MkdirAll(genDir, 0o755); mkErr != nil {
-		t.Fatalf("Failed to create gen dir: %v", mkErr)
-	}
-
-	// Run protoc to generate both Go types and HTTP handlers
-	cmd := exec.Command("protoc",
-		"--plugin=protoc-gen-go-http="+pluginPath,
-		"--go_out="+genDir,
-		"--go_opt=paths=source_relative",
-		"--go-http_out="+genDir,
-		"--go-http_opt=paths=source_relative",
-		"--proto_path="+protoDir,
-		"--proto_path="+filepath.Join(projectRoot, "proto"),
-		"query_params.proto",
-	)
-	cmd.Dir = protoDir
-
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-	if runErr := cmd.Run(); runErr != nil {
-		t.Fatalf("protoc failed: %v\nstderr: %s", runErr, stderr.String())
-	}
-
-	// Write go.mod for the temp module
-	goMod := fmt.Sprintf(`module testmod
-
-go 1.26.0
-
-require (
-	github.com/SebastienMelki/sebuf v0.0.0
-	buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go v1.36.11-20260209202127-80ab13bee0bf.1
-	google.golang.org/protobuf v1.36.11
-)
-
-replace github.com/SebastienMelki/sebuf => %s
-`, projectRoot)
-	if writeErr := os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte(goMod), 0o644); writeErr != nil {
-		t.Fatalf("Failed to write go.mod: %v", writeErr)
-	}
-
-	// Write the runtime test file
 	testCode := `package generated
 
 import (
@@ -172,6 +104,24 @@ func TestEnumQuery_ValidName(t *testing.T) {
 	}
 }
 
+// Test 1b: Query param, custom enum_value string
+func TestEnumQuery_ValidCustomValue(t *testing.T) {
+	srv := setupServer(t)
+	defer srv.Close()
+
+	status, body := doGet(t, srv.URL+"/api/search/advanced?region=americas")
+	if status != 200 {
+		t.Fatalf("expected 200, got %d: %s", status, body)
+	}
+	var resp SearchResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if resp.Total != int32(Region_REGION_AMERICAS) {
+		t.Errorf("expected Total=%d (REGION_AMERICAS), got %d", Region_REGION_AMERICAS, resp.Total)
+	}
+}
+
 // Test 2: Query param, valid numeric value
 func TestEnumQuery_ValidNumeric(t *testing.T) {
 	srv := setupServer(t)
@@ -228,6 +178,11 @@ func TestEnumQuery_UnknownName(t *testing.T) {
 	if !strings.Contains(desc, "Region") {
 		t.Errorf("violation should mention enum type 'Region', got: %s", desc)
 	}
+	for _, accepted := range []string{"americas", "REGION_AMERICAS"} {
+		if !strings.Contains(desc, accepted) {
+			t.Errorf("violation should list accepted value %q, got: %s", accepted, desc)
+		}
+	}
 }
 
 // Test 5: Query param, unknown number -> accepted (forward-compat)
@@ -322,6 +277,24 @@ func TestEnumPath_ValidName(t *testing.T) {
 	}
 }
 
+// Test 9a2: Path param, custom enum_value string
+func TestEnumPath_ValidCustomValue(t *testing.T) {
+	srv := setupServer(t)
+	defer srv.Close()
+
+	status, body := doGet(t, srv.URL+"/api/regions/europe")
+	if status != 200 {
+		t.Fatalf("expected 200, got %d: %s", status, body)
+	}
+	var resp SearchResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if resp.Total != int32(Region_REGION_EUROPE) {
+		t.Errorf("expected Total=%d (REGION_EUROPE), got %d", Region_REGION_EUROPE, resp.Total)
+	}
+}
+
 // Test 9b: Path param, valid number
 func TestEnumPath_ValidNumber(t *testing.T) {
 	srv := setupServer(t)
@@ -362,31 +335,13 @@ func TestEnumPath_UnknownName(t *testing.T) {
 	}
 }
 `
-	testFilePath := filepath.Join(genDir, "enum_test.go")
-	if writeErr := os.WriteFile(testFilePath, []byte(testCode), 0o644); writeErr != nil {
-		t.Fatalf("Failed to write test file: %v", writeErr)
-	}
-
-	// Run go mod tidy
-	tidyCmd := exec.Command("go", "mod", "tidy")
-	tidyCmd.Dir = tempDir
-	tidyCmd.Stderr = &stderr
-	stderr.Reset()
-	if tidyErr := tidyCmd.Run(); tidyErr != nil {
-		t.Fatalf("go mod tidy failed: %v\nstderr: %s", tidyErr, stderr.String())
-	}
-
-	// Run the tests
-	testCmd := exec.Command("go", "test", "-v", "-count=1", "./generated/")
-	testCmd.Dir = tempDir
-	var stdout bytes.Buffer
-	testCmd.Stdout = &stdout
-	stderr.Reset()
-	testCmd.Stderr = &stderr
-
-	if testErr := testCmd.Run(); testErr != nil {
-		t.Fatalf("enum runtime tests failed: %v\nstdout:\n%s\nstderr:\n%s", testErr, stdout.String(), stderr.String())
-	}
 
-	t.Logf("All enum runtime tests passed:\n%s", stdout.String())
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:     "testmod",
+		Protos:   map[string]string{"query_params.proto": ""},
+		ProtoDir: filepath.Join("testdata", "proto"),
+		Plugins:  []plugintest.Plugin{{Name: "go-http"}},
+		Out:      "generated",
+		Files:    map[string]string{"generated/enum_test.go": testCode},
+	})
 }
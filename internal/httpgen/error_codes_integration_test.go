@@ -0,0 +1,162 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestErrorCodesIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server and Go client from a proto with one method,
+//  2. writes a temporary Go module whose handler returns sebufhttp.NewError
+//     errors, served with httptest,
+//  3. verifies well-known codes get their HTTP status, that code and details
+//     are written in the body, and that the client matches them with errors.Is.
+func TestErrorCodesIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "error_codes_test",
+		Protos:  map[string]string{"users.proto": errorCodesProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}, {Name: "go-client"}},
+		Files:   map[string]string{"error_codes_test.go": errorCodesIntegrationTestCode},
+	})
+}
+
+const errorCodesProto = `syntax = "proto3";
+package test.errorcodes;
+option go_package = "error_codes_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service UserService {
+  rpc GetUser(GetUserRequest) returns (User) {
+    option (sebuf.http.config) = { path: "/users/{id}" method: HTTP_METHOD_GET };
+  }
+}
+
+message GetUserRequest {
+  string id = 1;
+}
+
+message User {
+  string id = 1;
+}
+`
+
+// errorCodesIntegrationTestCode is the test source that runs inside the temp
+// module. The handler answers each user ID with the error of the same name.
+const errorCodesIntegrationTestCode = `package error_codes_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "error_codes_test/gen"
+)
+
+var errUserNotFound = sebufhttp.NewError(sebufhttp.ErrorCodeNotFound, "user not found")
+
+type userServer struct{}
+
+func (userServer) GetUser(_ context.Context, req *gen.GetUserRequest) (*gen.User, error) {
+	switch req.GetId() {
+	case "missing":
+		return nil, sebufhttp.WithDetail(errUserNotFound, "id", req.GetId())
+	case "taken":
+		return nil, sebufhttp.NewError(sebufhttp.ErrorCodeAlreadyExists, "user exists")
+	case "forbidden":
+		return nil, sebufhttp.NewError(sebufhttp.ErrorCodePermissionDenied, "not yours")
+	case "anonymous":
+		return nil, sebufhttp.NewError(sebufhttp.ErrorCodeUnauthenticated, "log in first")
+	case "bad":
+		return nil, sebufhttp.NewError(sebufhttp.ErrorCodeInvalidArgument, "bad id")
+	case "down":
+		return nil, sebufhttp.NewError(sebufhttp.ErrorCodeUnavailable, "try later")
+	case "custom":
+		return nil, sebufhttp.NewError("QUOTA_EXCEEDED", "too many users")
+	}
+	return &gen.User{Id: req.GetId()}, nil
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterUserServiceServer(userServer{}, gen.WithMux(mux)); err != nil {
+		t.Fatalf("RegisterUserServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestStatusOfWellKnownCodes(t *testing.T) {
+	srv := newServer(t)
+	for id, want := range map[string]int{
+		"missing":   http.StatusNotFound,
+		"taken":     http.StatusConflict,
+		"forbidden": http.StatusForbidden,
+		"anonymous": http.StatusUnauthorized,
+		"bad":       http.StatusBadRequest,
+		"down":      http.StatusServiceUnavailable,
+		"custom":    http.StatusInternalServerError,
+	} {
+		resp, err := http.Get(srv.URL + "/users/" + id)
+		if err != nil {
+			t.Fatalf("GET %s: %v", id, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("GET %s status = %d, want %d", id, resp.StatusCode, want)
+		}
+	}
+}
+
+func TestBodyCarriesCodeAndDetails(t *testing.T) {
+	srv := newServer(t)
+	resp, err := http.Get(srv.URL + "/users/missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var body struct {
+		Message string
+		Code    string
+		Details map[string]string
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Code != "NOT_FOUND" || body.Message != "user not found" || body.Details["id"] != "missing" {
+		t.Errorf("body = %+v, want NOT_FOUND with the id detail", body)
+	}
+	if len(errUserNotFound.GetDetails()) != 0 {
+		t.Errorf("WithDetail modified the shared error: %v", errUserNotFound.GetDetails())
+	}
+}
+
+func TestClientMatchesCodes(t *testing.T) {
+	srv := newServer(t)
+	client := gen.NewUserServiceClient(srv.URL)
+
+	_, err := client.GetUser(context.Background(), &gen.GetUserRequest{Id: "missing"})
+	if !errors.Is(err, sebufhttp.NewError(sebufhttp.ErrorCodeNotFound, "")) {
+		t.Fatalf("GetUser error = %v, want a NOT_FOUND error", err)
+	}
+	if errors.Is(err, sebufhttp.NewError(sebufhttp.ErrorCodeAlreadyExists, "")) {
+		t.Error("a NOT_FOUND error should not match ALREADY_EXISTS")
+	}
+	var apiErr *sebufhttp.Error
+	if !errors.As(err, &apiErr) || apiErr.GetDetails()["id"] != "missing" {
+		t.Errorf("GetUser error = %v, want the id detail", err)
+	}
+
+	_, err = client.GetUser(context.Background(), &gen.GetUserRequest{Id: "bad"})
+	if !errors.Is(err, sebufhttp.NewError(sebufhttp.ErrorCodeInvalidArgument, "")) {
+		t.Errorf("GetUser error = %v, want an INVALID_ARGUMENT error rather than a ValidationError", err)
+	}
+}
+`
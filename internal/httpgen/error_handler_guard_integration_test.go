@@ -0,0 +1,215 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestErrorHandlerGuardIntegration generates a Go HTTP server and checks that
+// every misuse of the response writer by a custom error handler, or a failed
+// write of a successful response, still produces a single clean response.
+func TestErrorHandlerGuardIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "error_handler_guard_test",
+		Protos:  map[string]string{"notes.proto": errorHandlerGuardProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"error_handler_guard_test.go": errorHandlerGuardIntegrationTestCode},
+	})
+}
+
+const errorHandlerGuardProto = `syntax = "proto3";
+package test.errorhandlerguard;
+option go_package = "error_handler_guard_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service NoteService {
+  rpc GetNote(GetNoteRequest) returns (Note) {
+    option (sebuf.http.config) = { path: "/notes/{id}" method: HTTP_METHOD_GET };
+  }
+}
+
+message GetNoteRequest {
+  string id = 1;
+}
+
+message Note {
+  string id = 1;
+  string text = 2;
+}
+`
+
+// errorHandlerGuardIntegrationTestCode is the test source that runs inside the
+// temp module.
+const errorHandlerGuardIntegrationTestCode = `package error_handler_guard_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+	"google.golang.org/protobuf/proto"
+
+	gen "error_handler_guard_test/gen"
+)
+
+type noteServer struct{}
+
+func (noteServer) GetNote(ctx context.Context, req *gen.GetNoteRequest) (*gen.Note, error) {
+	if req.GetId() == "missing" {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeNotFound, Message: "no such note"}
+	}
+	sebufhttp.SetStatus(ctx, http.StatusAccepted)
+	return &gen.Note{Id: req.GetId(), Text: "hello"}, nil
+}
+
+// countingWriter records a response and counts the WriteHeader calls that
+// reach it, which net/http would log as superfluous after the first.
+type countingWriter struct {
+	*httptest.ResponseRecorder
+	headerCalls int
+	failWrites  bool
+}
+
+func (w *countingWriter) WriteHeader(code int) {
+	w.headerCalls++
+	w.ResponseRecorder.WriteHeader(code)
+}
+
+func (w *countingWriter) Write(b []byte) (int, error) {
+	if w.failWrites {
+		w.ResponseRecorder.Write(nil) // Sends the implicit 200 like a broken connection would
+		return 0, errors.New("connection reset")
+	}
+	return w.ResponseRecorder.Write(b)
+}
+
+// serve registers the server with opts, sends GET path to it and returns the
+// response and the warnings logged.
+func serve(t *testing.T, path string, w *countingWriter, handler gen.ErrorHandler) string {
+	t.Helper()
+	var logs bytes.Buffer
+	mux := http.NewServeMux()
+	opts := []gen.ServerOption{
+		gen.WithMux(mux),
+		gen.WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+	}
+	if handler != nil {
+		opts = append(opts, gen.WithErrorHandler(handler))
+	}
+	if err := gen.RegisterNoteServiceServer(noteServer{}, opts...); err != nil {
+		t.Fatalf("RegisterNoteServiceServer: %v", err)
+	}
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return logs.String()
+}
+
+func newWriter() *countingWriter {
+	return &countingWriter{ResponseRecorder: httptest.NewRecorder()}
+}
+
+func TestHandlerWritesAndReturnsMessage(t *testing.T) {
+	w := newWriter()
+	logs := serve(t, "/notes/missing", w, func(w http.ResponseWriter, _ *http.Request, _ error) proto.Message {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, "gone")
+		return &sebufhttp.Error{Message: "should not be written"}
+	})
+	if w.Code != http.StatusNotFound || w.Body.String() != "gone" || w.headerCalls != 1 {
+		t.Errorf("got %d %q with %d WriteHeader calls, want only the handler's response",
+			w.Code, w.Body.String(), w.headerCalls)
+	}
+	if !strings.Contains(logs, "error handler wrote the response and returned a message") {
+		t.Errorf("no warning logged: %q", logs)
+	}
+}
+
+func TestHandlerWritesBodyWithoutStatus(t *testing.T) {
+	w := newWriter()
+	logs := serve(t, "/notes/missing", w, func(w http.ResponseWriter, _ *http.Request, _ error) proto.Message {
+		_, _ = io.WriteString(w, "plain")
+		w.WriteHeader(http.StatusTeapot)
+		return nil
+	})
+	// The first Write sent an implicit 200, so the later WriteHeader never reaches the writer.
+	if w.Code != http.StatusOK || w.Body.String() != "plain" || w.headerCalls != 0 {
+		t.Errorf("got %d %q with %d WriteHeader calls", w.Code, w.Body.String(), w.headerCalls)
+	}
+	if logs != "" {
+		t.Errorf("unexpected warning: %q", logs)
+	}
+}
+
+func TestHandlerWritesHeaderTwice(t *testing.T) {
+	w := newWriter()
+	serve(t, "/notes/missing", w, func(w http.ResponseWriter, _ *http.Request, _ error) proto.Message {
+		w.WriteHeader(http.StatusGone)
+		w.WriteHeader(http.StatusTeapot)
+		return nil
+	})
+	if w.Code != http.StatusGone || w.headerCalls != 1 {
+		t.Errorf("got %d with %d WriteHeader calls, want the first status once", w.Code, w.headerCalls)
+	}
+	if !strings.Contains(w.Body.String(), "no such note") {
+		t.Errorf("body = %q, want the default error", w.Body.String())
+	}
+}
+
+func TestHandlerSetsStatusAndReturnsMessage(t *testing.T) {
+	w := newWriter()
+	serve(t, "/notes/missing", w, func(w http.ResponseWriter, _ *http.Request, _ error) proto.Message {
+		w.WriteHeader(http.StatusGone)
+		return &sebufhttp.Error{Message: "custom"}
+	})
+	if w.Code != http.StatusGone || w.headerCalls != 1 || !strings.Contains(w.Body.String(), "custom") {
+		t.Errorf("got %d %q with %d WriteHeader calls", w.Code, w.Body.String(), w.headerCalls)
+	}
+}
+
+func TestHandlerSeesWritten(t *testing.T) {
+	var before, after bool
+	serve(t, "/notes/missing", newWriter(), func(w http.ResponseWriter, _ *http.Request, _ error) proto.Message {
+		written, ok := w.(interface{ Written() bool })
+		if !ok {
+			t.Fatal("the error handler's writer has no Written method")
+		}
+		before = written.Written()
+		w.WriteHeader(http.StatusGone)
+		after = written.Written()
+		return nil
+	})
+	if before || !after {
+		t.Errorf("Written() = %v before and %v after WriteHeader", before, after)
+	}
+}
+
+func TestFailedSuccessWriteGetsNoErrorResponse(t *testing.T) {
+	var handlerCalls int
+	w := newWriter()
+	w.failWrites = true
+	serve(t, "/notes/1", w, func(http.ResponseWriter, *http.Request, error) proto.Message {
+		handlerCalls++
+		return nil
+	})
+	if w.Code != http.StatusAccepted || w.headerCalls != 1 || handlerCalls != 0 {
+		t.Errorf("got %d with %d WriteHeader calls and %d error handler calls, want the status of SetStatus once",
+			w.Code, w.headerCalls, handlerCalls)
+	}
+}
+
+func TestDefaultErrorResponseUnchanged(t *testing.T) {
+	w := newWriter()
+	serve(t, "/notes/missing", w, nil)
+	if w.Code != http.StatusNotFound || w.headerCalls != 1 || !strings.Contains(w.Body.String(), "no such note") {
+		t.Errorf("got %d %q with %d WriteHeader calls", w.Code, w.Body.String(), w.headerCalls)
+	}
+}
+`
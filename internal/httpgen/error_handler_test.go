@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 )
@@ -109,7 +110,7 @@ func TestErrorHandlerConfigGeneration(t *testing.T) {
 	})
 
 	t.Run("serverConfiguration has errorHandler field", func(t *testing.T) {
-		if !strings.Contains(files.config, "errorHandler ErrorHandler") {
+		if !regexp.MustCompile(`errorHandler\s+ErrorHandler`).MatchString(files.config) {
 			t.Error("errorHandler field not found in serverConfiguration")
 		}
 	})
@@ -266,18 +267,52 @@ func TestDefaultErrorFunctionsGeneration(t *testing.T) {
 		}
 	})
 
-	t.Run("convertProtovalidateError is generated", func(t *testing.T) {
-		if !strings.Contains(files.binding, "func convertProtovalidateError(err error) *sebufhttp.ValidationError") {
-			t.Error("convertProtovalidateError function not found")
+	t.Run("responder is generated", func(t *testing.T) {
+		if !strings.Contains(
+			files.binding,
+			"func responder(marshalOpts protojson.MarshalOptions) sebufhttp.Responder",
+		) {
+			t.Error("responder function not found")
+		}
+	})
+}
+
+// TestUnimplementedServerGeneration tests the Unimplemented<Service>Server struct and its 501 mapping.
+func TestUnimplementedServerGeneration(t *testing.T) {
+	files := generateTestFiles(t, "http_verbs_comprehensive.proto")
+
+	t.Run("Unimplemented struct is generated", func(t *testing.T) {
+		if !strings.Contains(files.http, "type UnimplementedRESTfulAPIServiceServer struct{}") {
+			t.Error("UnimplementedRESTfulAPIServiceServer struct not found")
+		}
+	})
+
+	t.Run("Unimplemented methods return coded Error", func(t *testing.T) {
+		want := "func (UnimplementedRESTfulAPIServiceServer) GetResource(" +
+			"context.Context, *GetResourceRequest) (*Resource, error) {\n" +
+			"\treturn nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, " +
+			"Message: \"method GetResource not implemented\"}"
+		if !strings.Contains(files.http, want) {
+			t.Error("Unimplemented GetResource should return an Error with ErrorCodeUnimplemented")
 		}
 	})
 
-	t.Run("writeResponseBody is generated", func(t *testing.T) {
+	t.Run("defaultErrorStatusCode returns NotImplemented", func(t *testing.T) {
 		if !strings.Contains(
 			files.binding,
-			"func writeResponseBody(w http.ResponseWriter, r *http.Request, msg proto.Message, marshalOpts protojson.MarshalOptions)",
+			"case sebufhttp.ErrorCodeUnimplemented:\n"+
+				"\t\t\treturn http.StatusNotImplemented",
 		) {
-			t.Error("writeResponseBody function not found")
+			t.Error("defaultErrorStatusCode should map ErrorCodeUnimplemented to 501")
+		}
+	})
+
+	t.Run("501 goes through the error handler", func(t *testing.T) {
+		// The status is only a default: writeErrorWithHandler consults the
+		// configured ErrorHandler before it is applied.
+		if !strings.Contains(files.binding, "response = handler(capture, r, err)") ||
+			!strings.Contains(files.binding, "statusCode := defaultErrorStatusCode(err)") {
+			t.Error("writeErrorWithHandler should consult the ErrorHandler before the default status")
 		}
 	})
 }
@@ -289,7 +324,8 @@ func TestErrorHandlerIntegration(t *testing.T) {
 	t.Run("genericHandler receives errorHandler", func(t *testing.T) {
 		if !strings.Contains(
 			files.http,
-			"config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders",
+			"config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), "+
+				"serviceHeaders, methodHeaders",
 		) {
 			t.Error("genericHandler should receive config.errorHandler and config.marshalOpts")
 		}
@@ -309,16 +345,18 @@ func TestErrorHandlerEdgeCases(t *testing.T) {
 	t.Run("BindingMiddleware signature includes errorHandler", func(t *testing.T) {
 		if !strings.Contains(
 			files.binding,
-			"httpMethod string, errorHandler ErrorHandler, marshalOpts protojson.MarshalOptions) http.Handler",
+			"\thttpMethod string, body BodyConfig,\n"+
+				"\terrorHandler ErrorHandler, marshalOpts protojson.MarshalOptions,",
 		) {
-			t.Error("BindingMiddleware should have errorHandler and marshalOpts as trailing parameters")
+			t.Error("BindingMiddleware should have errorHandler and marshalOpts after httpMethod and body")
 		}
 	})
 
 	t.Run("genericHandler signature includes errorHandler", func(t *testing.T) {
 		if !strings.Contains(
 			files.binding,
-			"func genericHandler[Req any, Res any](serve func(context.Context, Req) (Res, error), errorHandler ErrorHandler, marshalOpts protojson.MarshalOptions) http.HandlerFunc",
+			"func genericHandler[Req any, Res any](serve func(context.Context, Req) (Res, error), "+
+				"errorHandler ErrorHandler, marshalOpts protojson.MarshalOptions,",
 		) {
 			t.Error("genericHandler should have errorHandler and marshalOpts parameters")
 		}
@@ -336,15 +374,6 @@ func TestErrorHandlerEdgeCases(t *testing.T) {
 		}
 	})
 
-	t.Run("body validation uses writeErrorWithHandler", func(t *testing.T) {
-		if !strings.Contains(
-			files.binding,
-			"writeErrorWithHandler(w, r, convertProtovalidateError(err), errorHandler, marshalOpts)",
-		) {
-			t.Error("Body validation should use writeErrorWithHandler with convertProtovalidateError")
-		}
-	})
-
 	t.Run("handler errors use writeErrorWithHandler", func(t *testing.T) {
 		if !strings.Contains(files.binding, "writeErrorWithHandler(w, r, errorMsg, errorHandler, marshalOpts)") {
 			t.Error("Handler errors should use writeErrorWithHandler")
@@ -363,9 +392,9 @@ func TestErrorHandlerEdgeCases(t *testing.T) {
 		}
 	})
 
-	t.Run("writeErrorWithHandler uses writeResponseBody for custom status", func(t *testing.T) {
-		if !strings.Contains(files.binding, "writeResponseBody(w, r, response, marshalOpts)") {
-			t.Error("writeErrorWithHandler should use writeResponseBody when handler set status")
+	t.Run("writeErrorWithHandler writes only the body for custom status", func(t *testing.T) {
+		if !strings.Contains(files.binding, "responder(marshalOpts).WriteBody(w, r, response)") {
+			t.Error("writeErrorWithHandler should use Responder.WriteBody when handler set status")
 		}
 	})
 }
@@ -386,15 +415,15 @@ func TestErrorHandlerBackwardCompatibility(t *testing.T) {
 		}
 	})
 
-	t.Run("original error functions still exist", func(t *testing.T) {
-		if !strings.Contains(files.binding, "func writeProtoMessageResponse") {
-			t.Error("writeProtoMessageResponse should still exist")
-		}
-		if !strings.Contains(files.binding, "func writeValidationErrorResponse") {
-			t.Error("writeValidationErrorResponse should still exist")
+	t.Run("error responses are written by the runtime", func(t *testing.T) {
+		locals := []string{"func writeProtoMessageResponse", "func writeErrorResponse", "func writeResponseBody"}
+		for _, local := range locals {
+			if strings.Contains(files.binding, local) {
+				t.Errorf("%s should not be generated: sebufhttp.Responder writes error responses", local)
+			}
 		}
-		if !strings.Contains(files.binding, "func writeErrorResponse") {
-			t.Error("writeErrorResponse should still exist")
+		if !strings.Contains(files.binding, "return sebufhttp.NegotiateResponseContentType(r)") {
+			t.Error("resolveResponseContentType should use sebufhttp.NegotiateResponseContentType")
 		}
 	})
 }
@@ -0,0 +1,91 @@
+package httpgen
+
+import (
+	"slices"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// bindingFeatures records which optional sections of the binding runtime a file
+// needs. The sections are shared by every service of the file, so detection
+// looks across all of them and a section is emitted when any service uses it.
+type bindingFeatures struct {
+	headers            bool            // Some service or method declares headers
+	messageValidation  bool            // Some request message carries buf.validate rules
+	responseValidation bool            // Some response message carries buf.validate rules
+	headerFormats      map[string]bool // Formats referenced by declared headers
+	multipart          bool            // Some method is annotated with accept_multipart
+	responseStatuses   bool            // Some method returns a result message
+	rawBody            bool            // Some method returns a raw_body message
+	jsonQueryParams    bool            // Some query parameter is encoded as JSON_BASE64URL
+	mergePatch         bool            // Some method reads its body as a JSON Merge Patch
+	deprecatedFields   bool            // Some method's request body can set deprecated fields
+	queryAliases       bool            // Some query parameter accepts aliases
+}
+
+// detectBindingFeatures inspects the services of a file to decide which
+// optional binding sections it needs.
+func detectBindingFeatures(file *protogen.File) bindingFeatures {
+	features := bindingFeatures{headerFormats: make(map[string]bool)}
+
+	addHeaders := func(headers []*http.Header) {
+		for _, header := range headers {
+			features.headers = true
+			if format := header.GetFormat(); format != "" {
+				features.headerFormats[format] = true
+			}
+		}
+	}
+
+	for _, service := range file.Services {
+		addHeaders(annotations.GetServiceHeaders(service))
+		for _, method := range service.Methods {
+			addHeaders(annotations.GetMethodHeaders(method))
+			if !features.messageValidation && annotations.HasValidationRules(method.Input.Desc) {
+				features.messageValidation = true
+			}
+			if !features.responseValidation && annotations.HasValidationRules(method.Output.Desc) {
+				features.responseValidation = true
+			}
+			if config := annotations.GetMethodHTTPConfig(method); config != nil && config.AcceptMultipart {
+				features.multipart = true
+			}
+			if annotations.IsResultMessage(method.Output) {
+				features.responseStatuses = true
+			}
+			if annotations.IsRawBodyResponse(method) {
+				features.rawBody = true
+			}
+			if slices.ContainsFunc(annotations.GetQueryParams(method.Input), func(qp annotations.QueryParam) bool {
+				return qp.JSON
+			}) {
+				features.jsonQueryParams = true
+			}
+			if annotations.IsMergePatch(method) {
+				features.mergePatch = true
+			}
+			if hasDeprecatedBodyFields(method) {
+				features.deprecatedFields = true
+			}
+			if hasQueryAliases(method) {
+				features.queryAliases = true
+			}
+		}
+	}
+	return features
+}
+
+// validatesMessages reports whether the binding runtime validates request or
+// response messages, and so needs the protovalidate validators.
+func (f bindingFeatures) validatesMessages() bool {
+	return f.messageValidation || f.responseValidation
+}
+
+// reportsDeprecation reports whether the binding reports deprecated request
+// fields or query parameter aliases to a sebufhttp.DeprecationReporter.
+func (f bindingFeatures) reportsDeprecation() bool {
+	return f.deprecatedFields || f.queryAliases
+}
@@ -0,0 +1,191 @@
+package httpgen
+
+import (
+	"strings"
+	"testing"
+
+	validate "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+const featuresTestPkg = "test.features.v1"
+
+// featuresTestFile builds a proto3 file with two services. OrderService takes a
+// CreateOrderRequest whose nested Item message holds the only field that can
+// carry buf.validate rules (itemRules); AuditService declares auditHeaders, so
+// header detection has to look past the first service.
+func featuresTestFile(itemRules *validate.FieldRules, auditHeaders []*http.Header) *descriptorpb.FileDescriptorProto {
+	skuOptions := &descriptorpb.FieldOptions{}
+	if itemRules != nil {
+		proto.SetExtension(skuOptions, validate.E_Field, itemRules)
+	}
+	item := &descriptorpb.DescriptorProto{
+		Name: proto.String("Item"),
+		Field: []*descriptorpb.FieldDescriptorProto{{
+			Name:     proto.String("sku"),
+			Number:   proto.Int32(1),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			JsonName: proto.String("sku"),
+			Options:  skuOptions,
+		}},
+	}
+	request := &descriptorpb.DescriptorProto{
+		Name: proto.String("CreateOrderRequest"),
+		Field: []*descriptorpb.FieldDescriptorProto{{
+			Name:     proto.String("item"),
+			Number:   proto.Int32(1),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+			TypeName: proto.String("." + featuresTestPkg + ".Item"),
+			JsonName: proto.String("item"),
+		}},
+	}
+	response := &descriptorpb.DescriptorProto{Name: proto.String("Ack")}
+
+	method := func(name, input string) *descriptorpb.MethodDescriptorProto {
+		return &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(name),
+			InputType:  proto.String("." + featuresTestPkg + "." + input),
+			OutputType: proto.String("." + featuresTestPkg + ".Ack"),
+		}
+	}
+	auditOptions := &descriptorpb.ServiceOptions{}
+	if len(auditHeaders) > 0 {
+		proto.SetExtension(auditOptions, http.E_ServiceHeaders, &http.ServiceHeaders{RequiredHeaders: auditHeaders})
+	}
+
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("features.proto"),
+		Package: proto.String(featuresTestPkg),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{
+			GoPackage: proto.String("github.com/SebastienMelki/sebuf/internal/httpgen/featuresv1"),
+		},
+		MessageType: []*descriptorpb.DescriptorProto{item, request, response},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name:   proto.String("OrderService"),
+				Method: []*descriptorpb.MethodDescriptorProto{method("CreateOrder", "CreateOrderRequest")},
+			},
+			{
+				Name:    proto.String("AuditService"),
+				Method:  []*descriptorpb.MethodDescriptorProto{method("Ping", "Ack")},
+				Options: auditOptions,
+			},
+		},
+	}
+}
+
+// generateFeaturesFiles runs the generator (with mocks) in-process and returns
+// the generated binding and mock files.
+func generateFeaturesFiles(t *testing.T, fd *descriptorpb.FileDescriptorProto) (string, string) {
+	t.Helper()
+	plugin, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{fd.GetName()},
+		ProtoFile:      []*descriptorpb.FileDescriptorProto{fd},
+	})
+	if err != nil {
+		t.Fatalf("protogen.Options{}.New: %v", err)
+	}
+	if err = NewWithOptions(plugin, Options{GenerateMock: true}).Generate(); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	resp := plugin.Response()
+	if resp.GetError() != "" {
+		t.Fatalf("generation failed: %s", resp.GetError())
+	}
+	var binding, mock string
+	for _, file := range resp.GetFile() {
+		switch {
+		case strings.HasSuffix(file.GetName(), "_http_binding.pb.go"):
+			binding = file.GetContent()
+		case strings.HasSuffix(file.GetName(), "_http_mock.pb.go"):
+			mock = file.GetContent()
+		}
+	}
+	if binding == "" || mock == "" {
+		t.Fatal("binding or mock file not generated")
+	}
+	return binding, mock
+}
+
+func TestBindingFeaturesMinimal(t *testing.T) {
+	binding, mock := generateFeaturesFiles(t, featuresTestFile(nil, nil))
+
+	for _, absent := range []string{
+		`"buf.build/go/protovalidate"`,
+		`"sync"`,
+		`"unicode/utf8"`,
+		"func ValidateMessage(",
+		"func convertProtovalidateError(",
+		"func withViolationPointers(",
+		"func validateHeaders(",
+		"func validateStringHeader(",
+	} {
+		if strings.Contains(binding, absent) {
+			t.Errorf("binding without headers or validation rules should not contain %s", absent)
+		}
+	}
+	if strings.Contains(mock, "ValidateMessage(") {
+		t.Error("mock should not call ValidateMessage when the binding does not define it")
+	}
+}
+
+func TestBindingFeaturesMessageValidation(t *testing.T) {
+	rules := &validate.FieldRules{
+		Type: &validate.FieldRules_String_{String_: &validate.StringRules{MinLen: proto.Uint64(3)}},
+	}
+	binding, mock := generateFeaturesFiles(t, featuresTestFile(rules, nil))
+
+	for _, want := range []string{
+		`protovalidate "buf.build/go/protovalidate"`,
+		"func ValidateMessage(msg proto.Message) error {",
+		"func convertProtovalidateError(err error, formatter sebufhttp.ViolationFormatter)",
+		"validationErr := convertProtovalidateError(err, violationFormatter)",
+		"r = withViolationPointers(r, msg, valErr, validationErr)",
+	} {
+		if !strings.Contains(binding, want) {
+			t.Errorf("rules on a nested request field should emit %s", want)
+		}
+	}
+	if strings.Contains(binding, "func validateHeaders(") {
+		t.Error("header validation should not be emitted without declared headers")
+	}
+	if !strings.Contains(mock, "if err := ValidateMessage(msg); err != nil {") {
+		t.Error("mock should validate requests when the binding defines ValidateMessage")
+	}
+}
+
+func TestBindingFeaturesHeaderFormats(t *testing.T) {
+	headers := []*http.Header{{Name: "X-Audit-Contact", Type: "string", Format: "email", Required: true}}
+	binding, _ := generateFeaturesFiles(t, featuresTestFile(nil, headers))
+
+	for _, want := range []string{
+		"func validateHeaders(",
+		`headers, validationErr := validateHeaders(r, serviceHeaders, methodHeaders, violationFormatter)`,
+		"func validateEmailFormat(value string) error {",
+		`case "email":`,
+	} {
+		if !strings.Contains(binding, want) {
+			t.Errorf("headers on the second service should emit %s", want)
+		}
+	}
+	for _, absent := range []string{
+		"func validateUUIDFormat(",
+		"func validateDateTimeFormat(",
+		"func validateDateFormat(",
+		"func validateTimeFormat(",
+		"func ValidateMessage(",
+	} {
+		if strings.Contains(binding, absent) {
+			t.Errorf("binding should only emit referenced validators, found %s", absent)
+		}
+	}
+}
@@ -2,11 +2,13 @@ package httpgen
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
 
 	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/encodinggen"
 )
 
 // FlattenContext holds information about messages that need custom JSON encoding
@@ -122,7 +124,7 @@ func detectMarshalJSONConflicts(msg *protogen.Message) []string {
 		if annotations.IsFlattenField(field) {
 			continue
 		}
-		if isInt64Type(field) && annotations.IsInt64NumberEncoding(field) {
+		if encodinggen.IsInt64Type(field) && annotations.IsInt64NumberEncoding(field) {
 			conflicts = append(conflicts, "int64_encoding=NUMBER")
 		}
 		if annotations.IsNullableField(field) {
@@ -139,7 +141,7 @@ func detectMarshalJSONConflicts(msg *protogen.Message) []string {
 		}
 	}
 
-	if hasCustomEnumFields(msg) {
+	if encodinggen.HasCustomEnumFields(msg) {
 		conflicts = append(conflicts, "enum_value")
 	}
 
@@ -161,7 +163,9 @@ func (g *Generator) generateFlattenFile(file *protogen.File) error {
 	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
 
 	g.writeHeader(gf, file)
-	g.writeFlattenImports(gf)
+	g.writeFlattenImports(gf, slices.ContainsFunc(contexts, func(ctx *FlattenContext) bool {
+		return encodinggen.UsesSebufHTTP(ctx.Message)
+	}))
 
 	for _, ctx := range contexts {
 		g.generateFlattenMarshalJSON(gf, ctx)
@@ -172,11 +176,12 @@ func (g *Generator) generateFlattenFile(file *protogen.File) error {
 }
 
 // writeFlattenImports writes the imports needed for flatten encoding.
-func (g *Generator) writeFlattenImports(gf *protogen.GeneratedFile) {
+func (g *Generator) writeFlattenImports(gf *protogen.GeneratedFile, jsonNaming bool) {
 	gf.P("import (")
 	gf.P(`"encoding/json"`)
 	gf.P()
 	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
+	encodinggen.WriteJSONNamingImport(gf, jsonNaming)
 	gf.P(")")
 	gf.P()
 }
@@ -205,7 +210,7 @@ func (g *Generator) generateFlattenMarshalJSON(gf *protogen.GeneratedFile, ctx *
 	gf.P()
 
 	gf.P("// Use protojson for base serialization (handles all other fields correctly)")
-	gf.P("data, err := opts.Marshal(x)")
+	gf.P("data, err := ", encodinggen.ProtoMarshalCall(ctx.Message))
 	gf.P("if err != nil {")
 	gf.P("return nil, err")
 	gf.P("}")
@@ -240,7 +245,7 @@ func (g *Generator) generateFlattenMarshalJSON(gf *protogen.GeneratedFile, ctx *
 func (g *Generator) generateFlattenFieldMarshal(gf *protogen.GeneratedFile, info *FlattenFieldInfo) {
 	field := info.Field
 	goName := field.GoName
-	jsonName := field.Desc.JSONName()
+	jsonName := annotations.JSONFieldName(field)
 	prefix := info.Prefix
 
 	gf.P("// Flatten field: ", field.Desc.Name())
@@ -286,6 +291,7 @@ func (g *Generator) generateFlattenUnmarshalJSON(gf *protogen.GeneratedFile, ctx
 		fieldNames = append(fieldNames, string(info.Field.Desc.Name()))
 	}
 
+	encodinggen.WriteAlternateJSONNames(gf, ctx.Message)
 	gf.P("// UnmarshalJSON implements json.Unmarshaler for ", msgName, ".")
 	gf.P("// This method handles flatten fields: ", strings.Join(fieldNames, ", "))
 	gf.P("func (x *", msgName, ") UnmarshalJSON(data []byte) error {")
@@ -293,6 +299,7 @@ func (g *Generator) generateFlattenUnmarshalJSON(gf *protogen.GeneratedFile, ctx
 	gf.P("if err := json.Unmarshal(data, &raw); err != nil {")
 	gf.P("return err")
 	gf.P("}")
+	encodinggen.WriteResolveAlternateJSONNames(gf, ctx.Message)
 	gf.P()
 
 	for _, info := range ctx.FlattenInfos {
@@ -314,7 +321,6 @@ func (g *Generator) generateFlattenUnmarshalJSON(gf *protogen.GeneratedFile, ctx
 // It enumerates all child fields at generation time and extracts them from the parent map.
 func (g *Generator) generateFlattenFieldUnmarshal(gf *protogen.GeneratedFile, info *FlattenFieldInfo) {
 	field := info.Field
-	goName := field.GoName
 	prefix := info.Prefix
 
 	if field.Message == nil {
@@ -330,7 +336,7 @@ func (g *Generator) generateFlattenFieldUnmarshal(gf *protogen.GeneratedFile, in
 
 	// Enumerate all child fields at generation time
 	for _, childField := range childMsg.Fields {
-		childJSONName := childField.Desc.JSONName()
+		childJSONName := annotations.JSONFieldName(childField)
 		flattenedKey := prefix + childJSONName
 
 		gf.P(`if v, ok := raw["`, flattenedKey, `"]; ok {`)
@@ -344,11 +350,19 @@ func (g *Generator) generateFlattenFieldUnmarshal(gf *protogen.GeneratedFile, in
 	gf.P("if childErr != nil {")
 	gf.P("return childErr")
 	gf.P("}")
-	gf.P("x.", goName, " = &", childTypeName, "{}")
-	gf.P("// Use json.Unmarshal to invoke child's UnmarshalJSON (annotation composability)")
-	gf.P("if childErr = json.Unmarshal(childData, x.", goName, "); childErr != nil {")
+	gf.P("child := &", childTypeName, "{}")
+	gf.P("// Use the child's UnmarshalJSON when it has one (annotation composability);")
+	gf.P("// plain messages need protojson, since their struct tags carry proto names")
+	gf.P("if u, ok := any(child).(json.Unmarshaler); ok {")
+	gf.P("childErr = u.UnmarshalJSON(childData)")
+	gf.P("} else {")
+	gf.P("childErr = protojson.Unmarshal(childData, child)")
+	gf.P("}")
+	gf.P("if childErr != nil {")
 	gf.P("return childErr")
 	gf.P("}")
+	gf.P("// Nest the child back for protojson, which resets x before unmarshaling")
+	gf.P(`raw["`, annotations.JSONFieldName(field), `"], _ = protojson.Marshal(child)`)
 	gf.P("}")
 	gf.P("}")
 	gf.P()
@@ -81,7 +81,7 @@ func TestFlattenTypeScriptTypes(t *testing.T) {
 }
 
 // TestFlattenOpenAPISchemas verifies OpenAPI schemas accurately document flatten
-// (correct property names and types using allOf composition).
+// (correct property names and types inlined in a single object schema).
 func TestFlattenOpenAPISchemas(t *testing.T) {
 	baseDir, baseErr := os.Getwd()
 	if baseErr != nil {
@@ -105,13 +105,13 @@ func TestFlattenOpenAPISchemas(t *testing.T) {
 
 	yamlContent := string(content)
 
-	// SimpleFlatten: should have street, city, zip as direct properties (via allOf)
+	// SimpleFlatten: should have street, city, zip as direct properties
 	verifyOpenAPIFlattenedProperties(t, yamlContent, "SimpleFlatten",
 		[]string{"street", "city", "zip"})
 
-	// SimpleFlatten: should use allOf for flattening
-	if !strings.Contains(yamlContent, "SimpleFlatten:\n            allOf:") {
-		t.Error("OpenAPI SimpleFlatten should use allOf for flattened schema")
+	// SimpleFlatten: should inline the flattened properties in one object
+	if !strings.Contains(yamlContent, "SimpleFlatten:\n            type: object") {
+		t.Error("OpenAPI SimpleFlatten should be a single object schema")
 	}
 
 	// DualFlatten: should have billing_street, billing_city, etc.
@@ -119,9 +119,12 @@ func TestFlattenOpenAPISchemas(t *testing.T) {
 		[]string{"billing_street", "billing_city", "billing_zip",
 			"shipping_street", "shipping_city", "shipping_zip"})
 
-	// DualFlatten: should use allOf
-	if !strings.Contains(yamlContent, "DualFlatten:\n            allOf:") {
-		t.Error("OpenAPI DualFlatten should use allOf for flattened schema")
+	// DualFlatten: should inline the flattened properties in one object
+	if !strings.Contains(yamlContent, "DualFlatten:\n            type: object") {
+		t.Error("OpenAPI DualFlatten should be a single object schema")
+	}
+	if strings.Contains(yamlContent, "allOf:") {
+		t.Error("OpenAPI flatten schemas should not use allOf")
 	}
 
 	// MixedFlatten: should have both flattened and nested properties
@@ -0,0 +1,124 @@
+package httpgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// generateFormBindingFunctions generates the binding of application/x-www-form-urlencoded
+// bodies. Form keys follow the query parameter rules: a key names a field by its proto
+// name (or JSON name), values convert with convertStringToFieldValue, empty values are
+// unset and repeated fields take one value per occurrence of their key. Dotted keys
+// address fields of nested messages.
+func (g *Generator) generateFormBindingFunctions(gf *protogen.GeneratedFile) {
+	gf.P("// bindDataFromFormRequest binds an application/x-www-form-urlencoded body. A key names")
+	gf.P("// a field by its proto or JSON name, with dots addressing nested message fields")
+	gf.P("// (address.city=Beirut); a repeated field takes one value per occurrence of its key.")
+	gf.P("// Unknown keys are ignored. Invalid values are reported as a *sebufhttp.ValidationError.")
+	gf.P("func bindDataFromFormRequest[Req any](r *http.Request, toBind *Req) error {")
+	gf.P("protoRequest, ok := any(toBind).(proto.Message)")
+	gf.P("if !ok {")
+	gf.P(`return errors.New("form request is not a protocol buffer message")`)
+	gf.P("}")
+	gf.P()
+	gf.P("if err := r.ParseForm(); err != nil {")
+	gf.P(`return fmt.Errorf("could not parse form body: %w", err)`)
+	gf.P("}")
+	gf.P()
+	gf.P("// Bind keys in a stable order so violations are reported deterministically")
+	gf.P("keys := make([]string, 0, len(r.PostForm))")
+	gf.P("for key := range r.PostForm {")
+	gf.P("keys = append(keys, key)")
+	gf.P("}")
+	gf.P("sort.Strings(keys)")
+	gf.P()
+	gf.P("var violations []*sebufhttp.FieldViolation")
+	gf.P("for _, key := range keys {")
+	gf.P("if violation := bindFormValue(protoRequest.ProtoReflect(), key, r.PostForm[key]); violation != nil {")
+	gf.P("violations = append(violations, violation)")
+	gf.P("}")
+	gf.P("}")
+	gf.P("if len(violations) > 0 {")
+	gf.P("return &sebufhttp.ValidationError{Violations: violations}")
+	gf.P("}")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// bindFormValue binds the values of one form key to the field it addresses.")
+	gf.P("func bindFormValue(reflectMsg protoreflect.Message, key string, values []string) *sebufhttp.FieldViolation {")
+	gf.P("// Filter empty values (e.g., name= treated as unset)")
+	gf.P("var filtered []string")
+	gf.P("for _, v := range values {")
+	gf.P(`if v != "" {`)
+	gf.P("filtered = append(filtered, v)")
+	gf.P("}")
+	gf.P("}")
+	gf.P("if len(filtered) == 0 {")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P()
+	gf.P("// Resolve the whole path before mutating, so unknown keys leave no empty messages behind")
+	gf.P(`names := strings.Split(key, ".")`)
+	gf.P("path := make([]protoreflect.FieldDescriptor, 0, len(names))")
+	gf.P("fieldNames := make([]string, 0, len(names))")
+	gf.P("desc := reflectMsg.Descriptor()")
+	gf.P("for i, name := range names {")
+	gf.P("field := desc.Fields().ByName(protoreflect.Name(name))")
+	gf.P("if field == nil {")
+	gf.P("field = desc.Fields().ByJSONName(name)")
+	gf.P("}")
+	gf.P("if field == nil {")
+	gf.P("return nil // Field not found, skip")
+	gf.P("}")
+	gf.P("path = append(path, field)")
+	gf.P("fieldNames = append(fieldNames, string(field.Name()))")
+	gf.P("if i < len(names)-1 {")
+	gf.P("if field.Message() == nil || field.IsList() || field.IsMap() {")
+	gf.P("return &sebufhttp.FieldViolation{")
+	gf.P(`Field:       strings.Join(fieldNames, "."),`)
+	gf.P(`Description: fmt.Sprintf("form field %s is not a nested message", key),`)
+	gf.P("}")
+	gf.P("}")
+	gf.P("desc = field.Message()")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+	gf.P("for _, field := range path[:len(path)-1] {")
+	gf.P("reflectMsg = reflectMsg.Mutable(field).Message()")
+	gf.P("}")
+	gf.P("field := path[len(path)-1]")
+	gf.P()
+	gf.P("// Handle repeated fields (arrays)")
+	gf.P("if field.IsList() {")
+	gf.P("list := reflectMsg.Mutable(field).List()")
+	gf.P("for _, v := range filtered {")
+	gf.P("converted, err := convertStringToFieldValue(v, field)")
+	gf.P("if err != nil {")
+	gf.P("return &sebufhttp.FieldViolation{")
+	gf.P(`Field:       strings.Join(fieldNames, "."),`)
+	gf.P(`Description: fmt.Sprintf("invalid value for form field %s: %v", key, err),`)
+	gf.P("}")
+	gf.P("}")
+	gf.P("list.Append(converted)")
+	gf.P("}")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P()
+	gf.P("if field.IsMap() {")
+	gf.P("return &sebufhttp.FieldViolation{")
+	gf.P(`Field:       strings.Join(fieldNames, "."),`)
+	gf.P(`Description: fmt.Sprintf("map field %s cannot be bound from a form", key),`)
+	gf.P("}")
+	gf.P("}")
+	gf.P("converted, err := convertStringToFieldValue(filtered[0], field)")
+	gf.P("if err != nil {")
+	gf.P("return &sebufhttp.FieldViolation{")
+	gf.P(`Field:       strings.Join(fieldNames, "."),`)
+	gf.P(`Description: fmt.Sprintf("invalid value for form field %s: %v", key, err),`)
+	gf.P("}")
+	gf.P("}")
+	gf.P("reflectMsg.Set(field, converted)")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P()
+}
@@ -0,0 +1,189 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestFormBodyIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server from a proto with one method annotated with
+//     accept_form: true and one without,
+//  2. writes a temporary Go module that serves it with httptest,
+//  3. verifies form bodies bind scalars, enums, repeated keys and dotted nested
+//     keys, report invalid values per field, and are rejected by the method that
+//     did not opt in.
+func TestFormBodyIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "form_body_test",
+		Protos:  map[string]string{"form.proto": formBodyProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"form_body_test.go": formBodyIntegrationTestCode},
+	})
+}
+
+const formBodyProto = `syntax = "proto3";
+package test.formbody;
+option go_package = "form_body_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service ContactService {
+  rpc SubmitContact(SubmitContactRequest) returns (SubmitContactRequest) {
+    option (sebuf.http.config) = { path: "/contacts/{list_id}" method: HTTP_METHOD_POST accept_form: true };
+  }
+  rpc ImportContact(SubmitContactRequest) returns (SubmitContactRequest) {
+    option (sebuf.http.config) = { path: "/imports/{list_id}" method: HTTP_METHOD_POST };
+  }
+}
+
+enum Reason {
+  REASON_UNSPECIFIED = 0;
+  REASON_SALES = 1;
+}
+
+message Address {
+  string city = 1;
+  string country_code = 2;
+}
+
+message SubmitContactRequest {
+  string list_id = 1;
+  string name = 2;
+  int32 age = 3;
+  bool subscribe = 4;
+  Reason reason = 5;
+  repeated string interests = 6;
+  Address address = 7;
+  map<string, string> labels = 8;
+}
+`
+
+// formBodyIntegrationTestCode is the test source that runs inside the temp
+// module. Both methods echo the request they were bound with.
+const formBodyIntegrationTestCode = `package form_body_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "form_body_test/gen"
+)
+
+type contactServer struct{}
+
+func (contactServer) SubmitContact(_ context.Context, req *gen.SubmitContactRequest) (*gen.SubmitContactRequest, error) {
+	return req, nil
+}
+
+func (contactServer) ImportContact(_ context.Context, req *gen.SubmitContactRequest) (*gen.SubmitContactRequest, error) {
+	return req, nil
+}
+
+func newServer(t *testing.T) string {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterContactServiceServer(contactServer{}, gen.WithMux(mux)); err != nil {
+		t.Fatalf("RegisterContactServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func postForm(t *testing.T, target string, form url.Values) (int, []byte) {
+	t.Helper()
+	resp, err := http.Post(target, "application/x-www-form-urlencoded; charset=utf-8", strings.NewReader(form.Encode()))
+	if err != nil {
+		t.Fatalf("POST %s: %v", target, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, body
+}
+
+func TestFormBindsFields(t *testing.T) {
+	base := newServer(t)
+	form := url.Values{
+		"name":                {"Rima"},
+		"age":                 {"31"},
+		"subscribe":           {"true"},
+		"reason":              {"REASON_SALES"},
+		"interests":           {"maps", "", "music"},
+		"address.city":        {"Beirut"},
+		"address.countryCode": {"LB"},
+		"list_id":             {"ignored"},
+		"unknown":             {"x"},
+	}
+	status, body := postForm(t, base+"/contacts/vip", form)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", status, body)
+	}
+
+	got := &gen.SubmitContactRequest{}
+	if err := protojson.Unmarshal(body, got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := &gen.SubmitContactRequest{
+		ListId:    "vip",
+		Name:      "Rima",
+		Age:       31,
+		Subscribe: true,
+		Reason:    gen.Reason_REASON_SALES,
+		Interests: []string{"maps", "music"},
+		Address:   &gen.Address{City: "Beirut", CountryCode: "LB"},
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("bound request = %v, want %v", got, want)
+	}
+}
+
+func TestFormReportsViolationsPerField(t *testing.T) {
+	base := newServer(t)
+	form := url.Values{
+		"age":          {"old"},
+		"address.city": {"Beirut"},
+		"name.first":   {"Rima"},
+		"labels":       {"vip"},
+	}
+	status, body := postForm(t, base+"/contacts/vip", form)
+	if status != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400; body = %s", status, body)
+	}
+
+	validationErr := &sebufhttp.ValidationError{}
+	if err := protojson.Unmarshal(body, validationErr); err != nil {
+		t.Fatalf("decode validation error: %v (%s)", err, body)
+	}
+	var fields []string
+	for _, v := range validationErr.GetViolations() {
+		fields = append(fields, v.GetField())
+	}
+	if got, want := strings.Join(fields, ","), "age,labels,name"; got != want {
+		t.Errorf("violation fields = %s, want %s (%s)", got, want, body)
+	}
+}
+
+func TestFormRejectedWithoutOptIn(t *testing.T) {
+	base := newServer(t)
+	status, body := postForm(t, base+"/imports/vip", url.Values{"name": {"Rima"}})
+	if status != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400; body = %s", status, body)
+	}
+	if !strings.Contains(string(body), "body") {
+		t.Errorf("expected a body violation, got %s", body)
+	}
+}
+`
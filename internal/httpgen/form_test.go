@@ -0,0 +1,90 @@
+package httpgen
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// bodyConfigTail ends the BodyConfig of every registered handler, followed by
+// the next BindingMiddleware argument.
+const bodyConfigTail = "MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, " +
+	"NoContentSniffing: config.noContentSniffing}, config.errorHandler,"
+
+// TestFormBindingGeneration verifies that only methods annotated with
+// accept_form: true pass AcceptForm to BindingMiddleware.
+func TestFormBindingGeneration(t *testing.T) {
+	files := generateTestFiles(t, "form_body.proto")
+
+	for _, want := range []string{
+		"\"POST\", BodyConfig{AcceptForm: true, OptionalBody: true, StrictJSON: config.strictJSON, " + bodyConfigTail,
+		"\"PUT\", BodyConfig{AcceptForm: true, OptionalBody: true, StrictJSON: config.strictJSON, " + bodyConfigTail,
+		"\"POST\", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, " + bodyConfigTail,
+	} {
+		if n := strings.Count(files.http, want); n != 1 {
+			t.Errorf("expected one handler registered with %q, got %d", want, n)
+		}
+	}
+	if !strings.Contains(files.binding, "case FormContentType:") {
+		t.Error("bindDataBasedOnContentType should handle FormContentType")
+	}
+}
+
+// TestFormRejectedWithoutBody verifies generation fails when a GET or DELETE
+// method is annotated with accept_form: true.
+func TestFormRejectedWithoutBody(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping accept_form validation test")
+	}
+
+	baseDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	projectRoot := filepath.Join(baseDir, "..", "..")
+	pluginPath := filepath.Join(projectRoot, "bin", "protoc-gen-go-http")
+	if _, statErr := os.Stat(pluginPath); os.IsNotExist(statErr) {
+		t.Skip("protoc-gen-go-http not built, run make build")
+	}
+
+	for _, verb := range []string{"GET", "DELETE"} {
+		t.Run(verb, func(t *testing.T) {
+			protoDir := t.TempDir()
+			protoSrc := `syntax = "proto3";
+package test.form;
+option go_package = "example.com/form;form";
+import "sebuf/http/annotations.proto";
+service Contacts {
+  rpc Lookup(LookupRequest) returns (LookupResponse) {
+    option (sebuf.http.config) = { path: "/contacts" method: HTTP_METHOD_` + verb + ` accept_form: true };
+  }
+}
+message LookupRequest {}
+message LookupResponse {}
+`
+			if writeErr := os.WriteFile(filepath.Join(protoDir, "form.proto"), []byte(protoSrc), 0o600); writeErr != nil {
+				t.Fatalf("Failed to write proto: %v", writeErr)
+			}
+
+			cmd := exec.Command("protoc",
+				"--plugin=protoc-gen-go-http="+pluginPath,
+				"--go-http_out="+t.TempDir(),
+				"--go-http_opt=paths=source_relative",
+				"--proto_path="+protoDir,
+				"--proto_path="+filepath.Join(projectRoot, "proto"),
+				"form.proto",
+			)
+			var stderr bytes.Buffer
+			cmd.Stderr = &stderr
+			if runErr := cmd.Run(); runErr == nil {
+				t.Fatalf("expected generation to fail for accept_form on %s", verb)
+			}
+			if !strings.Contains(stderr.String(), "accept_form is only supported on methods with a request body") {
+				t.Errorf("unexpected error output: %s", stderr.String())
+			}
+		})
+	}
+}
@@ -2,20 +2,31 @@ package httpgen
 
 import (
 	"fmt"
+	"io"
+	"slices"
 	"strconv"
 	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"github.com/SebastienMelki/sebuf/http"
 	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/encodinggen"
+	"github.com/SebastienMelki/sebuf/internal/manifest"
 )
 
 // Generator handles HTTP code generation for protobuf services.
 type Generator struct {
-	plugin       *protogen.Plugin
-	generateMock bool
-	globalUnwrap *GlobalUnwrapInfo // Global unwrap info collected from all files
+	plugin             *protogen.Plugin
+	generateMock       bool
+	mockArtifacts      []MockArtifact
+	generateBenchmarks bool
+	generateTests      bool
+	globalUnwrap       *GlobalUnwrapInfo // Global unwrap info collected from all files
+
+	// encoding emits the JSON encoders shared with protoc-gen-go-client.
+	encoding *encodinggen.Emitter
 
 	// directEncodingMsgNames is set per-file before generateUnwrapFile runs.
 	// It holds the full names of messages that will have custom MarshalJSON/UnmarshalJSON
@@ -23,34 +34,163 @@ type Generator struct {
 	// The unwrap generator uses this to call json.Marshal instead of protojson.Marshal
 	// for those types, ensuring the custom encoding is applied.
 	directEncodingMsgNames map[string]bool
+
+	// features is set per-file before the binding and mock files are generated.
+	// It decides which optional sections of the binding runtime are emitted.
+	features bindingFeatures
+
+	// trailingSlash selects how the trailing-slash form of each route is served.
+	trailingSlash TrailingSlash
+
+	// compat selects the gateway the generated server mimics, if any.
+	compat Compat
+
+	// router selects the router the handlers are registered on.
+	router Router
+
+	// extraCodecs lists the wire formats served besides JSON and protobuf.
+	extraCodecs []ExtraCodec
+
+	// schemaFingerprint checks the schema fingerprint clients send.
+	schemaFingerprint bool
+
+	// embedDescriptors embeds the descriptors of each service and generates
+	// the functions serving them.
+	embedDescriptors bool
+
+	// autoOptions registers an OPTIONS handler on the path of every route,
+	// answering with the verbs served on it.
+	autoOptions bool
+
+	// strictHeaders fails on method headers overriding the type or format of a
+	// service header, which are otherwise reported to warnings.
+	strictHeaders bool
+
+	// warnings receives generation-time warnings. Defaults to os.Stderr.
+	warnings io.Writer
+
+	// manifest records the registered routes when Run writes a manifest.
+	manifest *manifest.Builder
+
+	// serviceRoutes collects the routes registered for the service being
+	// generated, for its route constants and route debug listing.
+	serviceRoutes []serviceRoute
 }
 
 // Options configures the generator.
 type Options struct {
 	GenerateMock bool
+	// MockArtifacts lists the files written next to the mock server to call
+	// it from other tools. It requires GenerateMock.
+	MockArtifacts []MockArtifact
+	// GenerateBenchmarks adds a test file per proto file with a
+	// Benchmark<Service>Binding function for each service.
+	GenerateBenchmarks bool
+	// GenerateTests adds a test scaffold per proto file, built under the
+	// sebuf_scaffold tag, with a test helper for each method.
+	GenerateTests bool
+	// TrailingSlash selects how the trailing-slash form of each route is
+	// served. Defaults to TrailingSlashStrict.
+	TrailingSlash TrailingSlash
+	// Compat makes the generated server mimic another gateway's query binding
+	// and error bodies. Defaults to CompatNone.
+	Compat Compat
+	// Router selects the router the handlers are registered on. Defaults to
+	// RouterStdlib.
+	Router Router
+	// Manifest makes Run append sebuf.manifest.json, describing the registered
+	// routes and the generated files, to its response.
+	Manifest bool
+	// AllEnumHelpers generates Parse<Enum>, <Enum>Values and WireString for
+	// every enum, not only for those with enum_value mappings.
+	AllEnumHelpers bool
+	// ExtraCodecs lists the wire formats the server binds and writes besides
+	// JSON and protobuf.
+	ExtraCodecs []ExtraCodec
+	// SchemaFingerprint embeds a fingerprint of each service's schema and
+	// checks it against the one generated clients send.
+	SchemaFingerprint bool
+	// EmbedDescriptors embeds the descriptors of each service, returned by
+	// <Service>Descriptors and served at GET /__sebuf/descriptors/<service>
+	// once registered with Register<Service>Reflection.
+	EmbedDescriptors bool
+	// AutoOptions registers a handler answering OPTIONS requests on the path
+	// of every route with 204 No Content and an Allow header listing the verbs
+	// of every route of the path, across the services registered on the same
+	// mux or router.
+	AutoOptions bool
+	// StrictHeaders fails generation when a method header overrides a service
+	// header with another type or format, instead of warning about it.
+	StrictHeaders bool
 }
 
 // New creates a new HTTP generator.
 func New(plugin *protogen.Plugin) *Generator {
-	return &Generator{
-		plugin: plugin,
-	}
+	return NewWithOptions(plugin, Options{})
 }
 
 // NewWithOptions creates a new HTTP generator with options.
 func NewWithOptions(plugin *protogen.Plugin, opts Options) *Generator {
 	return &Generator{
-		plugin:       plugin,
-		generateMock: opts.GenerateMock,
+		plugin:             plugin,
+		encoding:           newEncodingEmitter(plugin, opts.AllEnumHelpers),
+		generateMock:       opts.GenerateMock,
+		mockArtifacts:      opts.MockArtifacts,
+		generateBenchmarks: opts.GenerateBenchmarks,
+		generateTests:      opts.GenerateTests,
+		trailingSlash:      opts.TrailingSlash,
+		compat:             opts.Compat,
+		router:             opts.Router,
+		extraCodecs:        opts.ExtraCodecs,
+		schemaFingerprint:  opts.SchemaFingerprint,
+		embedDescriptors:   opts.EmbedDescriptors,
+		autoOptions:        opts.AutoOptions,
+		strictHeaders:      opts.StrictHeaders,
 	}
 }
 
+// newEncodingEmitter creates the emitter of the shared JSON encoders. The server
+// unmarshals with the protojson defaults.
+func newEncodingEmitter(plugin *protogen.Plugin, allEnumHelpers bool) *encodinggen.Emitter {
+	return encodinggen.New(plugin, encodinggen.Options{Generator: "protoc-gen-go-http", AllEnumHelpers: allEnumHelpers})
+}
+
 // Generate processes all files and generates HTTP handlers.
 func (g *Generator) Generate() error {
+	if g.trailingSlash == "" {
+		g.trailingSlash = TrailingSlashStrict
+	}
+	if !g.trailingSlash.valid() {
+		return fmt.Errorf("unsupported trailing_slash %q: expected %q, %q, or %q",
+			g.trailingSlash, TrailingSlashRedirect, TrailingSlashStrict, TrailingSlashIgnore)
+	}
+	if !g.compat.valid() {
+		return fmt.Errorf("unsupported compat %q: expected %q", g.compat, CompatGRPCGateway)
+	}
+	if g.router == "" {
+		g.router = RouterStdlib
+	}
+	if !g.router.valid() {
+		return fmt.Errorf("unsupported router %q: expected %q, %q, or %q",
+			g.router, RouterStdlib, RouterChi, RouterGorilla)
+	}
+	if err := g.validateMockArtifacts(); err != nil {
+		return err
+	}
+	if err := g.validateExtraCodecs(); err != nil {
+		return err
+	}
+	if err := annotations.ValidateSyntax(g.plugin); err != nil {
+		return err
+	}
+	if err := annotations.ApplyVisibility(g.plugin, annotations.GeneratorGoHTTP); err != nil {
+		return err
+	}
+
 	// Phase 1: Collect global unwrap information from ALL files first.
 	// This enables cross-file unwrap resolution within the same package.
 	var err error
-	g.globalUnwrap, err = CollectGlobalUnwrapInfo(g.plugin.Files)
+	g.globalUnwrap, err = CollectGlobalUnwrapInfo(annotations.GeneratedFiles(g.plugin))
 	if err != nil {
 		return fmt.Errorf("collecting global unwrap info: %w", err)
 	}
@@ -70,7 +210,7 @@ func (g *Generator) Generate() error {
 //nolint:gocognit // Sequential encoding file generation adds unavoidable branching
 func (g *Generator) generateFile(file *protogen.File) error {
 	// Validate enum annotations first - fail fast if conflicting annotations exist
-	if err := g.validateEnumAnnotationsInFile(file); err != nil {
+	if err := encodinggen.ValidateEnumAnnotationsInFile(file); err != nil {
 		return fmt.Errorf("enum annotation validation failed: %w", err)
 	}
 
@@ -82,7 +222,7 @@ func (g *Generator) generateFile(file *protogen.File) error {
 	// Pre-compute the set of messages with direct NUMBER encoding.
 	// Must be done before generateUnwrapFile so the unwrap generator can use json.Marshal
 	// for those types.
-	g.directEncodingMsgNames = collectDirectEncodingMsgNames(file)
+	g.directEncodingMsgNames = encodinggen.CollectDirectEncodingMsgNames(file)
 
 	// Generate unwrap file if there are messages with unwrap annotations
 	if err := g.generateUnwrapFile(file); err != nil {
@@ -97,19 +237,24 @@ func (g *Generator) generateFile(file *protogen.File) error {
 	}
 
 	// Generate encoding file if there are messages with int64_encoding=NUMBER annotations
-	if err := g.generateInt64EncodingFile(file, unwrapMsgNames); err != nil {
+	if err := g.encoding.GenerateInt64EncodingFile(file, unwrapMsgNames); err != nil {
 		return err
 	}
 
 	// Generate enum encoding file if there are enums with custom enum_value annotations
-	if err := g.generateEnumEncodingFile(file); err != nil {
+	if err := g.encoding.GenerateEnumEncodingFile(file); err != nil {
 		return err
 	}
 
 	// Generate enum-field encoding file so the server applies custom enum_value strings on the
 	// message JSON (protojson emits raw proto value names; this patches them). Depends on the
 	// lookup maps emitted by generateEnumEncodingFile above.
-	if err := g.generateEnumFieldEncodingFile(file); err != nil {
+	if err := g.encoding.GenerateEnumFieldEncodingFile(file); err != nil {
+		return err
+	}
+
+	// Generate enum helpers (Parse<Enum>, <Enum>Values, WireString) for application code
+	if err := g.encoding.GenerateEnumHelpersFile(file); err != nil {
 		return err
 	}
 
@@ -124,12 +269,12 @@ func (g *Generator) generateFile(file *protogen.File) error {
 	}
 
 	// Generate timestamp_format encoding file if there are messages with timestamp format annotations
-	if err := g.generateTimestampFormatEncodingFile(file); err != nil {
+	if err := g.encoding.GenerateTimestampFormatEncodingFile(file); err != nil {
 		return err
 	}
 
 	// Generate bytes_encoding file if there are messages with non-default bytes encoding
-	if err := g.generateBytesEncodingFile(file); err != nil {
+	if err := g.encoding.GenerateBytesEncodingFile(file); err != nil {
 		return err
 	}
 
@@ -143,16 +288,44 @@ func (g *Generator) generateFile(file *protogen.File) error {
 		return err
 	}
 
+	// Generate json_naming file for messages whose JSON field names follow a json_naming
+	// policy and that no other encoder gives a MarshalJSON
+	if err := g.generateJSONNamingFile(file, unwrapMsgNames); err != nil {
+		return err
+	}
+
+	// Generate Marshal<Message>JSON/Unmarshal<Message>JSON for the messages given
+	// custom JSON serialization above, for code outside the HTTP handlers
+	g.generateJSONHelpersFile(file, unwrapMsgNames)
+
+	// Generate redact file if there are messages with sensitive fields
+	if err := g.generateRedactFile(file); err != nil {
+		return err
+	}
+
+	// Generate raw_body file if there are messages written as raw response bodies
+	if err := g.generateRawBodyFile(file); err != nil {
+		return err
+	}
+
 	if len(file.Services) == 0 {
 		return nil
 	}
 
 	// Validate HTTP configurations - fail fast on any errors
 	for _, service := range file.Services {
-		if err := ValidateService(service); err != nil {
+		if err := ValidateService(service, g.compatSkippedRules()...); err != nil {
+			return fmt.Errorf("validation error: %w", err)
+		}
+		if err := annotations.ReportHeaderOverrides(service, g.strictHeaders, g.warningWriter()); err != nil {
 			return fmt.Errorf("validation error: %w", err)
 		}
 	}
+	if err := g.validateRoutes(file); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+
+	g.features = detectBindingFeatures(file)
 
 	// Generate main HTTP file
 	if err := g.generateHTTPFile(file); err != nil {
@@ -169,11 +342,33 @@ func (g *Generator) generateFile(file *protogen.File) error {
 		return err
 	}
 
+	// Generate descriptors file if requested
+	if g.embedDescriptors {
+		if err := g.generateDescriptorsFile(file); err != nil {
+			return err
+		}
+	}
+
 	// Generate mock file if requested
 	if g.generateMock {
 		if err := g.generateMockFile(file); err != nil {
 			return err
 		}
+		if err := g.generateMockArtifacts(file); err != nil {
+			return err
+		}
+	}
+
+	// Generate binding benchmarks if requested
+	if g.generateBenchmarks {
+		g.generateBenchmarkFile(file)
+	}
+
+	// Generate the test scaffold if requested
+	if g.generateTests {
+		if err := g.generateTestScaffoldFile(file); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -187,7 +382,12 @@ func (g *Generator) generateHTTPFile(file *protogen.File) error {
 
 	gf.P("import (")
 	gf.P(`"context"`)
+	gf.P(`"net/http"`)
+	if g.fileHasCachedMethods(file) || g.fileHasTimeoutMethods(file) {
+		gf.P(`"time"`)
+	}
 	gf.P()
+	g.generateRouterImport(gf)
 	gf.P(`sebufhttp "github.com/SebastienMelki/sebuf/http"`)
 	gf.P(")")
 	gf.P()
@@ -197,6 +397,7 @@ func (g *Generator) generateHTTPFile(file *protogen.File) error {
 			return err
 		}
 	}
+	g.generateMergePatchViews(gf, file)
 
 	return nil
 }
@@ -208,9 +409,13 @@ func (g *Generator) generateService(gf *protogen.GeneratedFile, file *protogen.F
 	gf.P("// ", serviceName, "Server is the server API for ", serviceName, " service.")
 	gf.P("type ", serviceName, "Server interface {")
 	for _, method := range service.Methods {
-		if g.isSSEMethod(method) {
+		switch {
+		case g.isSSEMethod(method):
 			gf.P(method.GoName, "(context.Context, *", method.Input.GoIdent, ", SSESender) error")
-		} else {
+		case annotations.IsStreamResponse(method):
+			gf.P(method.GoName, "(context.Context, *", method.Input.GoIdent, ", func(*",
+				g.streamResponseItem(method).GoIdent, ") error) error")
+		default:
 			gf.P(method.GoName, "(context.Context, *", method.Input.GoIdent, ") (*", method.Output.GoIdent, ", error)")
 		}
 	}
@@ -218,24 +423,44 @@ func (g *Generator) generateService(gf *protogen.GeneratedFile, file *protogen.F
 	gf.P()
 
 	// Generate registration function
-	gf.P(
-		"// Register",
-		serviceName,
-		"Server registers the HTTP handlers for service ",
-		serviceName,
-		" to the given mux.",
-	)
-	gf.P("func Register", serviceName, "Server(server ", serviceName, "Server, opts ...ServerOption) error {")
-	gf.P("config := getConfiguration(opts...)")
+	g.generateRegisterFuncSignature(gf, service)
+	gf.P("config := getConfiguration(", registerOptions(service), ")")
+	if g.features.validatesMessages() {
+		g.generateValidatorWarmUp(gf, service)
+	}
+	gf.P("server = dispatching", serviceName, "Server{slot: registered", serviceName, "Servers.Add(server)}")
 	gf.P()
 
 	// Get service-level base path if configured
 	basePath := g.getServiceBasePath(service)
+	basePathParams := quotedBasePathParams(service)
 
 	// Get service-level headers
 	gf.P("serviceHeaders := get", serviceName, "Headers()")
 	gf.P()
 
+	if g.serviceHasIdempotentMethods(service) {
+		gf.P("idempotencyStore := config.idempotencyStore")
+		gf.P("if idempotencyStore == nil {")
+		gf.P("idempotencyStore = sebufhttp.NewMemoryIdempotencyStore()")
+		gf.P("}")
+		gf.P()
+	}
+
+	if g.serviceHasUnaryMethods(service) {
+		gf.P("limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)")
+		gf.P()
+	}
+
+	if g.serviceHasCachedMethods(service) {
+		gf.P("var responseCache *sebufhttp.ResponseCache")
+		gf.P("if config.responseCacheSize > 0 {")
+		gf.P("responseCache = sebufhttp.NewResponseCache(config.responseCacheSize)")
+		gf.P("}")
+		gf.P()
+	}
+
+	g.serviceRoutes = nil
 	for i, method := range service.Methods {
 		httpPath := g.getMethodPath(method, basePath, file.GoPackageName)
 		httpMethod := g.getHTTPMethod(method)
@@ -255,41 +480,116 @@ func (g *Generator) generateService(gf *protogen.GeneratedFile, file *protogen.F
 				annotations.LowerFirst(method.GoName),
 				"PathParams, ",
 				annotations.LowerFirst(method.GoName),
-				"QueryParams,",
+				"QueryParams, ",
+				annotations.LowerFirst(method.GoName),
+				"HeaderFieldParams,",
 			)
-			gf.P(`"`, httpMethod, `", config.marshalOpts,`)
+			gf.P(`"`, httpMethod, `", `, g.bodyConfigLiteral(method), `, config.marshalOpts,`)
+			gf.P("config.validationPolicy, config.violationFormatter, config.validationFailureMode,")
+			gf.P("config.logger, config.headerAuthenticators,")
 			gf.P(")")
-		} else {
-			// Standard handler registration
-			gf.P(handlerName, " := BindingMiddleware[", method.Input.GoIdent, "](")
+		} else if annotations.IsStreamResponse(method) {
+			// Streamed response handler registration
+			key, protoKey := g.streamResponseKeys(method)
+			gf.P(handlerName, " := StreamResponseHandler[", method.Input.GoIdent, "](")
+			gf.P("server.", method.GoName, ", config.errorHandler, serviceHeaders, methodHeaders,")
 			gf.P(
-				"genericHandler(server.",
-				method.GoName,
-				", config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,",
+				annotations.LowerFirst(method.GoName),
+				"PathParams, ",
+				annotations.LowerFirst(method.GoName),
+				"QueryParams, ",
+				annotations.LowerFirst(method.GoName),
+				"HeaderFieldParams,",
 			)
+			gf.P(`"`, httpMethod, `", `, g.bodyConfigLiteral(method), `, config.marshalOpts,`)
+			gf.P("config.validationPolicy, config.violationFormatter, config.validationFailureMode,")
+			gf.P("config.logger, config.headerAuthenticators,")
+			gf.P(strconv.Quote(key), ", ", strconv.Quote(protoKey), ",")
+			gf.P(")")
+		} else {
+			// Standard handler registration
+			serviceCall := "genericHandler(server." + method.GoName + ", config.errorHandler, config.marshalOpts, limiter, " +
+				g.methodTimeoutExpr(method) + ", " + methodWarningsInBodyExpr(method) +
+				g.responseValidationArgs(method) + ")"
+			// Caching and deduplication run inside binding, once the request is
+			// validated and authenticated
+			inner, assign := serviceCall, " := "
+			if cache := g.getMethodCache(method); cache != nil {
+				gf.P(handlerName, assign, "sebufhttp.ResponseCacheMiddleware(")
+				gf.P(inner, ",")
+				gf.P(`responseCache, "`, method.Desc.FullName(), `",`)
+				gf.P(g.cachePolicyLiteral(service, method, cache), ",")
+				gf.P(")")
+				inner, assign = handlerName, " = "
+			}
+			if g.isIdempotentMethod(method) {
+				gf.P(handlerName, assign, "sebufhttp.IdempotencyMiddleware(")
+				gf.P(inner, ",")
+				gf.P(`idempotencyStore, "`, method.Desc.FullName(), `", config.idempotencyTTL, config.maxBodySize,`)
+				gf.P("config.writeError,")
+				gf.P(")")
+				inner = handlerName
+			}
+			if inner == handlerName {
+				gf.P(handlerName, " = BindingMiddleware[", method.Input.GoIdent, "](")
+				gf.P(handlerName, ", serviceHeaders, methodHeaders,")
+			} else {
+				gf.P(handlerName, " := BindingMiddleware[", method.Input.GoIdent, "](")
+				gf.P(serviceCall, ", serviceHeaders, methodHeaders,")
+			}
 			gf.P(
 				annotations.LowerFirst(method.GoName),
 				"PathParams, ",
 				annotations.LowerFirst(method.GoName),
-				"QueryParams,",
+				"QueryParams, ",
+				annotations.LowerFirst(method.GoName),
+				"HeaderFieldParams,",
 			)
-			gf.P(`"`, httpMethod, `", config.errorHandler, config.marshalOpts,`)
+			gf.P(`"`, httpMethod, `", `, g.bodyConfigLiteral(method), `, config.errorHandler, config.marshalOpts,`)
+			gf.P("config.validationPolicy, config.violationFormatter, config.validationFailureMode,")
+			gf.P("config.logger, config.headerAuthenticators, config.timeoutHeader,")
 			gf.P(")")
 		}
+		if g.schemaFingerprint {
+			g.generateSchemaMiddleware(gf, service, handlerName)
+		}
+		gf.P(handlerName, ` = sebufhttp.MetricsMiddleware(`, handlerName, `, config.metrics, "`, method.Desc.FullName(), `")`)
+		if len(basePathParams) > 0 {
+			g.generateBasePathParamsMiddleware(gf, handlerName, basePathParams)
+		}
+		g.generateResponseHeadersMiddleware(gf, method, handlerName)
 		gf.P()
-		gf.P(`config.mux.Handle("`, httpMethod, ` `, httpPath, `", `, handlerName, `)`)
+		g.generateRouteRegistration(gf, service, method, httpMethod, httpPath, handlerName, file.GoPackageName)
 		gf.P()
 	}
 
+	routeInfos := annotations.LowerFirst(serviceName) + "RouteInfos"
+	g.generateRouteDebugRegistration(gf, routeInfos)
+	gf.P()
 	gf.P("return nil")
 	gf.P("}")
 	gf.P()
 
+	g.generateRouteConstants(gf, service)
+	if g.schemaFingerprint {
+		g.generateSchemaFingerprint(gf, service)
+	}
+	g.generateRouteInfos(gf, service, routeInfos)
+
+	g.generateServerSwap(gf, service)
+	g.generateUnimplementedServer(gf, service)
+	g.generateDecodeRequestFuncs(gf, service)
+
 	// Generate header getter functions
 	if err := g.generateHeaderGetters(gf, service); err != nil {
 		return err
 	}
 
+	// Generate exported header names and declarations
+	if err := g.generateServiceHeaders(gf, service); err != nil {
+		return err
+	}
+
 	// Generate path and query param configs
 	if err := g.generateParamConfigs(gf, service); err != nil {
 		return err
@@ -312,18 +612,28 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	gf.P(`"errors"`)
 	gf.P(`"fmt"`)
 	gf.P(`"io"`)
+	gf.P(`"log/slog"`)
 	gf.P(`"net/http"`)
+	gf.P(`"sort"`)
 	gf.P(`"strconv"`)
 	gf.P(`"strings"`)
-	gf.P(`"sync"`)
+	if g.features.validatesMessages() {
+		gf.P(`"sync"`)
+	}
 	gf.P(`"time"`)
-	gf.P(`"unicode/utf8"`)
+	if g.features.headers {
+		gf.P(`"unicode/utf8"`)
+	}
 	gf.P()
-	gf.P(`protovalidate "buf.build/go/protovalidate"`)
+	if g.features.validatesMessages() {
+		gf.P(`protovalidate "buf.build/go/protovalidate"`)
+	}
 	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
 	gf.P(`"google.golang.org/protobuf/proto"`)
 	gf.P(`"google.golang.org/protobuf/reflect/protoreflect"`)
+	gf.P(`"google.golang.org/protobuf/types/descriptorpb"`)
 	gf.P()
+	g.generateRouterImport(gf)
 	gf.P(`sebufhttp "github.com/SebastienMelki/sebuf/http"`)
 	gf.P(")")
 	gf.P()
@@ -331,11 +641,23 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	// Content type constants
 	gf.P("const (")
 	gf.P(`// JSONContentType is the content type for JSON`)
-	gf.P(`JSONContentType = "application/json"`)
+	gf.P(`JSONContentType = sebufhttp.JSONContentType`)
 	gf.P(`// BinaryContentType is the content type for binary protobuf`)
-	gf.P(`BinaryContentType = "application/octet-stream"`)
+	gf.P(`BinaryContentType = sebufhttp.BinaryContentType`)
 	gf.P(`// ProtoContentType is the content type for protobuf`)
-	gf.P(`ProtoContentType = "application/x-protobuf"`)
+	gf.P(`ProtoContentType = sebufhttp.ProtoContentType`)
+	if g.features.mergePatch {
+		gf.P(`// MergePatchContentType is the content type for JSON Merge Patch (methods with patch_format)`)
+		gf.P(`MergePatchContentType = sebufhttp.MergePatchContentType`)
+	}
+	gf.P(`// FormContentType is the content type for URL-encoded forms (methods with accept_form)`)
+	gf.P(`FormContentType = sebufhttp.FormContentType`)
+	gf.P(`// MultipartContentType is the content type for multipart forms (methods with accept_multipart)`)
+	gf.P(`MultipartContentType = sebufhttp.MultipartContentType`)
+	if g.servesMsgpack() {
+		gf.P(`// MsgpackContentType is the content type for msgpack (extra_codecs=msgpack)`)
+		gf.P(`MsgpackContentType = sebufhttp.MsgpackContentType`)
+	}
 	gf.P(")")
 	gf.P()
 
@@ -367,6 +689,43 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	gf.P("QueryName string // Parameter name in query string")
 	gf.P("FieldName string // Proto field name to bind to")
 	gf.P("Required  bool   // Whether this parameter is required")
+	gf.P("Exclusive bool   // Declared with source QUERY: any value from the body is discarded")
+	if g.features.jsonQueryParams {
+		gf.P("MaxJSONBytes int // Encoded as JSON_BASE64URL: the largest decoded value accepted")
+	}
+	if g.features.queryAliases {
+		gf.P("Aliases []string // Other names, read in order when QueryName is absent (aliases)")
+	}
+	gf.P("}")
+	gf.P()
+
+	// HeaderParamConfig type
+	gf.P("// HeaderParamConfig defines a request field read from a request header")
+	gf.P("// (declared with source HEADER). Any value from the body is discarded.")
+	gf.P("type HeaderParamConfig struct {")
+	gf.P("HeaderName string // Request header name")
+	gf.P("FieldName  string // Proto field name to bind to")
+	gf.P("}")
+	gf.P()
+
+	// BodyConfig type
+	gf.P("// BodyConfig defines how a method's request body is bound and validated.")
+	gf.P("type BodyConfig struct {")
+	gf.P("AcceptForm        bool                   // Also bind application/x-www-form-urlencoded bodies (accept_form)")
+	gf.P("AcceptMultipart   bool                   // Also bind multipart/form-data bodies (accept_multipart)")
+	gf.P("StrictJSON        bool                   // Reject JSON bodies with unknown keys (strict_json, WithStrictJSON)")
+	gf.P("MaxSize           int64                  // Larger bodies are answered with 413; zero means no limit")
+	gf.P("TypeResolver      sebufhttp.TypeResolver // Resolves Any type URLs in JSON bodies (WithTypeResolver)")
+	gf.P("OptionalBody      bool                   // No body field is required: empty bodies are not read")
+	gf.P("NoValidationRules bool                   // The request has no buf.validate rules: it is not validated")
+	gf.P("NoContentSniffing bool                   // Skip the Content-Type sniffing (WithoutContentSniffing)")
+	if g.features.mergePatch {
+		gf.P("MergePatch        bool                   // Read JSON bodies as merge patches (patch_format MERGE_PATCH)")
+	}
+	if g.features.reportsDeprecation() {
+		gf.P("ReportDeprecated  sebufhttp.DeprecationReporter // Deprecated field callback (WithDeprecationReporting)")
+		gf.P("FullMethod        string                        // Full name of the RPC, passed to ReportDeprecated")
+	}
 	gf.P("}")
 	gf.P()
 
@@ -384,69 +743,77 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	// BindingMiddleware function
 	gf.P("// BindingMiddleware creates a middleware that binds HTTP requests to protobuf messages")
 	gf.P("// and validates them using protovalidate and header validation.")
-	gf.P("// It supports path parameters, query parameters, and request body binding.")
+	gf.P("// It supports path parameters, query parameters, header fields, and request body binding.")
+	gf.P("// validationPolicy may relax validation per request (see WithValidationPolicy), and")
+	gf.P("// violationFormatter describes the violations (see WithViolationFormatter),")
+	gf.P("// validationFailureMode handles messages that cannot be validated (see")
+	gf.P("// WithValidationFailureMode), and authenticators verify the credentials of")
+	gf.P("// request headers (see WithHeaderAuthenticator).")
+	gf.P("// body configures the accepted body encodings and the maximum body size, and")
+	gf.P("// timeoutHeader bounds the request's context (see WithTimeoutHeader).")
 	gf.P("func BindingMiddleware[Req any](next http.Handler, serviceHeaders, methodHeaders []*sebufhttp.Header,")
 	gf.P(
-		"pathParams []PathParamConfig, queryParams []QueryParamConfig, httpMethod string, errorHandler ErrorHandler, marshalOpts protojson.MarshalOptions) http.Handler {",
+		"pathParams []PathParamConfig, queryParams []QueryParamConfig, headerParams []HeaderParamConfig,",
+	)
+	gf.P("httpMethod string, body BodyConfig,")
+	gf.P(
+		"errorHandler ErrorHandler, marshalOpts protojson.MarshalOptions,",
 	)
+	gf.P("validationPolicy sebufhttp.ValidationPolicy, violationFormatter sebufhttp.ViolationFormatter,")
+	gf.P("validationFailureMode sebufhttp.ValidationFailureMode,")
+	gf.P("logger *slog.Logger, authenticators []sebufhttp.HeaderAuthenticator,")
+	gf.P("timeoutHeader sebufhttp.TimeoutHeader) http.Handler {")
 	gf.P("return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {")
-	gf.P("// Validate headers first")
-	gf.P("if validationErr := validateHeaders(r, serviceHeaders, methodHeaders); validationErr != nil {")
-	gf.P("writeErrorWithHandler(w, r, validationErr, errorHandler, marshalOpts)")
-	gf.P("return")
-	gf.P("}")
-	gf.P()
-	gf.P("toBind := new(Req)")
-	gf.P()
-	gf.P("// Bind body FIRST for POST, PUT, PATCH methods.")
-	gf.P("// This must happen before path/query binding because protojson.Unmarshal")
-	gf.P("// calls proto.Reset(), which would wipe any previously-set fields.")
-	gf.P("// By binding body first, path and query params applied afterwards take precedence.")
-	gf.P(`if httpMethod == "POST" || httpMethod == "PUT" || httpMethod == "PATCH" {`)
-	gf.P("err := bindDataBasedOnContentType(r, toBind)")
-	gf.P("if err != nil {")
-	gf.P("// For binding errors, return a simple validation error")
-	gf.P("validationErr := &sebufhttp.ValidationError{")
-	gf.P("Violations: []*sebufhttp.FieldViolation{")
-	gf.P("{")
-	gf.P(`Field: "body",`)
-	gf.P(`Description: fmt.Sprintf("failed to parse request body: %v", err),`)
-	gf.P("},")
-	gf.P("},")
-	gf.P("}")
-	gf.P("writeErrorWithHandler(w, r, validationErr, errorHandler, marshalOpts)")
+	gf.P("r, cancel, timeoutErr := timeoutHeader.Apply(w, r)")
+	gf.P("defer cancel()")
+	gf.P("if timeoutErr != nil {")
+	gf.P("writeErrorWithHandler(w, r, timeoutErr, errorHandler, marshalOpts)")
 	gf.P("return")
 	gf.P("}")
-	gf.P("}")
 	gf.P()
-	gf.P("// Bind path and query parameters AFTER body, so URL-stated values always win")
-	gf.P("if msg, ok := any(toBind).(proto.Message); ok {")
-	gf.P("if err := bindPathParams(r, msg, pathParams); err != nil {")
-	gf.P("writeErrorWithHandler(w, r, err, errorHandler, marshalOpts)")
-	gf.P("return")
-	gf.P("}")
+	if g.features.headers {
+		gf.P("// Validate headers first")
+		g.generateHeaderValidationCall(gf)
+	}
+	g.generateHeaderAuthenticationCall(gf)
 	gf.P()
-	gf.P("// Bind query parameters")
-	gf.P("if err := bindQueryParams(r, msg, queryParams); err != nil {")
+	gf.P("toBind := new(Req)")
+	gf.P("if err := bindRequest(w, r, toBind, pathParams, queryParams, headerParams, httpMethod, body); err != nil {")
 	gf.P("writeErrorWithHandler(w, r, err, errorHandler, marshalOpts)")
 	gf.P("return")
 	gf.P("}")
-	gf.P("}")
-	gf.P()
-	gf.P("// Validate the complete message")
-	gf.P("if msg, ok := any(toBind).(proto.Message); ok {")
-	gf.P("if err := ValidateMessage(msg); err != nil {")
-	gf.P("writeErrorWithHandler(w, r, convertProtovalidateError(err), errorHandler, marshalOpts)")
-	gf.P("return")
-	gf.P("}")
-	gf.P("}")
 	gf.P()
+	if g.features.mergePatch {
+		gf.P("var mergePatch *sebufhttp.MergePatch")
+		gf.P("if body.MergePatch {")
+		gf.P("var err error")
+		gf.P("if mergePatch, err = bindMergePatch(r, toBind); err != nil {")
+		gf.P("writeErrorWithHandler(w, r, err, errorHandler, marshalOpts)")
+		gf.P("return")
+		gf.P("}")
+		gf.P("}")
+		gf.P()
+	}
+	if g.features.messageValidation {
+		gf.P("// Validate the complete message, unless it has no rules to check")
+		gf.P("if msg, ok := any(toBind).(proto.Message); ok && !body.NoValidationRules {")
+		g.generateMessageValidationCall(gf)
+		gf.P("}")
+		gf.P()
+	}
 	gf.P("ctx := context.WithValue(r.Context(), bodyCtxKey{}, toBind)")
+	if g.features.mergePatch {
+		gf.P("if mergePatch != nil {")
+		gf.P("ctx = sebufhttp.ContextWithMergePatch(ctx, mergePatch)")
+		gf.P("}")
+	}
 	gf.P("next.ServeHTTP(w, r.WithContext(ctx))")
 	gf.P("})")
 	gf.P("}")
 	gf.P()
 
+	g.generateBindRequestFunc(gf)
+
 	// filterFlags helper
 	gf.P("func filterFlags(content string) string {")
 	gf.P("for i, char := range content {")
@@ -460,48 +827,87 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 
 	// resolveResponseContentType determines the response content type from the Accept header.
 	// Falls back to request Content-Type, then JSON.
-	gf.P("// resolveResponseContentType determines the response serialization format.")
-	gf.P("// Per HTTP semantics (RFC 9110), the Accept header governs the desired response format.")
-	gf.P("// Falls back to request Content-Type if Accept is absent, then defaults to JSON.")
+	gf.P("// resolveResponseContentType determines the response serialization format with")
+	gf.P("// sebufhttp.NegotiateResponseContentType: the Accept header governs it (RFC 9110),")
+	gf.P("// then the request Content-Type if Accept is absent, then JSON.")
 	gf.P("func resolveResponseContentType(r *http.Request) string {")
-	gf.P(`accept := filterFlags(r.Header.Get("Accept"))`)
-	gf.P("switch accept {")
-	gf.P("case BinaryContentType, ProtoContentType:")
-	gf.P("return accept")
-	gf.P("case JSONContentType:")
-	gf.P("return JSONContentType")
-	gf.P(`case "", "*/*":`)
-	gf.P("// No Accept or wildcard: fall back to request Content-Type")
-	gf.P(`ct := filterFlags(r.Header.Get("Content-Type"))`)
-	gf.P("switch ct {")
-	gf.P("case BinaryContentType, ProtoContentType:")
-	gf.P("return ct")
-	gf.P("default:")
-	gf.P("return JSONContentType")
-	gf.P("}")
-	gf.P("default:")
-	gf.P("return JSONContentType")
-	gf.P("}")
+	if g.servesMsgpack() {
+		gf.P(`mediaType := filterFlags(r.Header.Get("Accept"))`)
+		gf.P(`if mediaType == "" || mediaType == "*/*" {`)
+		gf.P(`mediaType = filterFlags(r.Header.Get("Content-Type"))`)
+		gf.P("}")
+		gf.P("if mediaType == MsgpackContentType {")
+		gf.P("return msgpackResponseContentType()")
+		gf.P("}")
+	}
+	gf.P("return sebufhttp.NegotiateResponseContentType(r)")
 	gf.P("}")
 	gf.P()
 
 	// bindDataBasedOnContentType function
-	gf.P("func bindDataBasedOnContentType[Req any](r *http.Request, toBind *Req) error {")
+	gf.P("// bindDataBasedOnContentType binds the request body in the encoding named by its")
+	gf.P("// Content-Type, reading at most body.MaxSize bytes. Empty bodies of methods with")
+	gf.P("// body.OptionalBody are not read at all.")
+	gf.P("func bindDataBasedOnContentType[Req any](")
+	gf.P("w http.ResponseWriter, r *http.Request, toBind *Req, body BodyConfig,")
+	gf.P(") error {")
+	gf.P("if body.OptionalBody && r.ContentLength == 0 {")
+	gf.P("// An empty body leaves the message empty, so there is nothing to read or unmarshal")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P()
 	gf.P(`contentType := filterFlags(r.Header.Get("Content-Type"))`)
+	gf.P("maxSize := body.MaxSize")
+	if g.features.multipart {
+		gf.P("if maxSize <= 0 && body.AcceptMultipart && contentType == MultipartContentType {")
+		gf.P("// Uploads are bounded even without WithMaxBodySize")
+		gf.P("maxSize = sebufhttp.DefaultMaxMultipartBodySize")
+		gf.P("}")
+	}
+	gf.P("if maxSize > 0 {")
+	gf.P("r.Body = http.MaxBytesReader(w, r.Body, maxSize)")
+	gf.P("}")
+	gf.P()
 	gf.P("switch contentType {")
-	gf.P("case JSONContentType:")
-	gf.P("return bindDataFromJSONRequest(r, toBind)")
+	if g.features.mergePatch {
+		gf.P("case JSONContentType, MergePatchContentType:")
+	} else {
+		gf.P("case JSONContentType:")
+	}
+	gf.P("return bindDataFromJSONRequest(r, toBind, body)")
 	gf.P("case BinaryContentType, ProtoContentType:")
-	gf.P("return bindDataFromBinaryRequest(r, toBind)")
+	gf.P("return bindDataFromBinaryRequest(r, toBind, body)")
+	if g.servesMsgpack() {
+		gf.P("case MsgpackContentType:")
+		gf.P("return bindDataFromMsgpackRequest(r, toBind, body)")
+	}
+	gf.P("case FormContentType:")
+	gf.P("if !body.AcceptForm {")
+	gf.P("// Methods without accept_form treat forms like any unrecognized content type")
+	gf.P("return bindDataFromJSONRequest(r, toBind, body)")
+	gf.P("}")
+	gf.P("return bindDataFromFormRequest(r, toBind)")
+	if g.features.multipart {
+		gf.P("case MultipartContentType:")
+		gf.P("if !body.AcceptMultipart {")
+		gf.P("// Methods without accept_multipart treat multipart forms like any unrecognized content type")
+		gf.P("return bindDataFromJSONRequest(r, toBind, body)")
+		gf.P("}")
+		gf.P("return bindDataFromMultipartRequest(r, toBind)")
+	}
 	gf.P("default:")
 	gf.P("// Default to JSON for unrecognized content types")
-	gf.P("return bindDataFromJSONRequest(r, toBind)")
+	gf.P("return bindDataFromJSONRequest(r, toBind, body)")
 	gf.P("}")
 	gf.P("}")
 	gf.P()
 
 	// bindDataFromJSONRequest function
-	gf.P("func bindDataFromJSONRequest[Req any](r *http.Request, toBind *Req) error {")
+	gf.P("// bindDataFromJSONRequest binds a JSON body. Unknown keys are ignored unless")
+	gf.P("// body.StrictJSON, in which case each is reported as a violation. Bodies declared")
+	gf.P("// as JSON that do not start like JSON are answered with 415, unless")
+	gf.P("// body.NoContentSniffing.")
+	gf.P("func bindDataFromJSONRequest[Req any](r *http.Request, toBind *Req, body BodyConfig) error {")
 	gf.P("bodyBytes, err := io.ReadAll(r.Body)")
 	gf.P("r.Body = io.NopCloser(bytes.NewReader(bodyBytes))")
 	gf.P("if err != nil {")
@@ -512,9 +918,31 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	gf.P("return nil")
 	gf.P("}")
 	gf.P()
+	gf.P(`if contentType := filterFlags(r.Header.Get("Content-Type")); contentType == JSONContentType &&`)
+	gf.P("!body.NoContentSniffing {")
+	gf.P("if err = checkJSONBodyShape(contentType, bodyBytes); err != nil {")
+	gf.P("return err")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
 	gf.P("// Check for custom JSON unmarshaler (unwrap support)")
 	gf.P("if unmarshaler, ok := any(toBind).(json.Unmarshaler); ok {")
-	gf.P("return unmarshaler.UnmarshalJSON(bodyBytes)")
+	gf.P("if !body.StrictJSON {")
+	gf.P("return unmarshalCustomJSON(unmarshaler, bodyBytes, body.TypeResolver)")
+	gf.P("}")
+	gf.P("// Generated unmarshalers that read keys protojson does not know report")
+	gf.P("// the ones they would ignore; the others fail on unknown keys themselves")
+	gf.P("if checker, ok := any(toBind).(unknownJSONFieldsChecker); ok {")
+	gf.P("if unknown := checker.UnknownJSONFields(bodyBytes); len(unknown) > 0 {")
+	gf.P("return unknownJSONFieldsError(unknown...)")
+	gf.P("}")
+	gf.P("}")
+	gf.P("if err = unmarshalCustomJSON(unmarshaler, bodyBytes, body.TypeResolver); err != nil {")
+	gf.P("if key, ok := unknownJSONField(err); ok {")
+	gf.P("return unknownJSONFieldsError(key)")
+	gf.P("}")
+	gf.P("}")
+	gf.P("return err")
 	gf.P("}")
 	gf.P()
 	gf.P("protoRequest, ok := any(toBind).(proto.Message)")
@@ -522,16 +950,46 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	gf.P(`return errors.New("JSON request is not a protocol buffer message")`)
 	gf.P("}")
 	gf.P()
-	gf.P("err = protojson.Unmarshal(bodyBytes, protoRequest)")
+	gf.P("opts := protojson.UnmarshalOptions{DiscardUnknown: !body.StrictJSON, Resolver: body.TypeResolver}")
+	gf.P("err = opts.Unmarshal(bodyBytes, protoRequest)")
 	gf.P("if err != nil {")
+	gf.P("if key, ok := unknownJSONField(err); ok {")
+	gf.P("return unknownJSONFieldsError(key)")
+	gf.P("}")
 	gf.P(`return fmt.Errorf("could not unmarshal request JSON: %w", err)`)
 	gf.P("}")
 	gf.P("return nil")
 	gf.P("}")
 	gf.P()
+	g.generateStrictJSONFunctions(gf)
+	if g.features.mergePatch {
+		g.generateBindMergePatchFunc(gf)
+	}
+	if g.features.deprecatedFields {
+		g.generateReportDeprecatedFieldsFunc(gf)
+	}
+	if g.features.queryAliases {
+		g.generateReportQueryAliasesFunc(gf)
+	}
+	if g.servesMsgpack() {
+		g.generateMsgpackFunctions(gf)
+	}
+
+	gf.P("// unmarshalCustomJSON binds a JSON body with a custom unmarshaler through")
+	gf.P("// sebufhttp.UnmarshalMessageJSON, as the exported Unmarshal<Message>JSON helpers")
+	gf.P("// do. Generated unmarshalers take the resolver of the Any type URLs in the body.")
+	gf.P("func unmarshalCustomJSON(unmarshaler json.Unmarshaler, data []byte, resolver sebufhttp.TypeResolver) error {")
+	gf.P("if msg, ok := unmarshaler.(proto.Message); ok {")
+	gf.P("return sebufhttp.UnmarshalMessageJSON(data, msg, protojson.UnmarshalOptions{Resolver: resolver})")
+	gf.P("}")
+	gf.P("return unmarshaler.UnmarshalJSON(data)")
+	gf.P("}")
+	gf.P()
 
 	// bindDataFromBinaryRequest function
-	gf.P("func bindDataFromBinaryRequest[Req any](r *http.Request, toBind *Req) error {")
+	gf.P("// bindDataFromBinaryRequest binds a protobuf body. Bodies that start like JSON")
+	gf.P("// are answered with 415, unless body.NoContentSniffing.")
+	gf.P("func bindDataFromBinaryRequest[Req any](r *http.Request, toBind *Req, body BodyConfig) error {")
 	gf.P("bodyBytes, err := io.ReadAll(r.Body)")
 	gf.P("r.Body = io.NopCloser(bytes.NewReader(bodyBytes))")
 	gf.P()
@@ -543,6 +1001,12 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	gf.P(`return fmt.Errorf("could not read request body: %w", err)`)
 	gf.P("}")
 	gf.P()
+	gf.P("if !body.NoContentSniffing {")
+	gf.P(`if err = checkBinaryBodyShape(filterFlags(r.Header.Get("Content-Type")), bodyBytes); err != nil {`)
+	gf.P("return err")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
 	gf.P("protoRequest, ok := any(toBind).(proto.Message)")
 	gf.P("if !ok {")
 	gf.P(`return errors.New("binary request is not a protocol buffer message")`)
@@ -556,8 +1020,16 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	gf.P("}")
 	gf.P()
 
+	g.generateContentSniffingFunctions(gf)
+	g.generateBodyBindingErrorFunc(gf)
+	g.generateFormBindingFunctions(gf)
+	if g.features.multipart {
+		g.generateMultipartBindingFunctions(gf)
+	}
+
 	// bindPathParams function - binds URL path parameters to proto message fields
-	gf.P("// bindPathParams binds URL path parameters to proto message fields using Go 1.22+ PathValue.")
+	g.generatePathValueFunc(gf)
+	gf.P("// bindPathParams binds URL path parameters to proto message fields, read with pathValue.")
 	gf.P(
 		"func bindPathParams(r *http.Request, msg proto.Message, params []PathParamConfig) *sebufhttp.ValidationError {",
 	)
@@ -569,7 +1041,7 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	gf.P("fields := reflectMsg.Descriptor().Fields()")
 	gf.P()
 	gf.P("for _, param := range params {")
-	gf.P("value := r.PathValue(param.URLParam)")
+	gf.P("value := pathValue(r, param.URLParam)")
 	gf.P("if value == \"\" {")
 	gf.P("return &sebufhttp.ValidationError{")
 	gf.P("Violations: []*sebufhttp.FieldViolation{{")
@@ -601,6 +1073,26 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	gf.P("}")
 	gf.P()
 
+	// bindHeaderParams function - binds request headers to header-sourced fields
+	gf.P("// bindHeaderParams binds request headers to the string fields declared with source HEADER.")
+	gf.P("// A missing header leaves the field unset, even if the body carried a value.")
+	gf.P("func bindHeaderParams(r *http.Request, msg proto.Message, params []HeaderParamConfig) {")
+	gf.P("reflectMsg := msg.ProtoReflect()")
+	gf.P("fields := reflectMsg.Descriptor().Fields()")
+	gf.P("for _, param := range params {")
+	gf.P("field := fields.ByName(protoreflect.Name(param.FieldName))")
+	gf.P("if field == nil {")
+	gf.P("continue // Field not found, skip")
+	gf.P("}")
+	gf.P("if value := r.Header.Get(param.HeaderName); value != \"\" {")
+	gf.P("reflectMsg.Set(field, protoreflect.ValueOfString(value))")
+	gf.P("} else {")
+	gf.P("reflectMsg.Clear(field)")
+	gf.P("}")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
 	// bindQueryParams function - binds URL query parameters to proto message fields
 	gf.P("// bindQueryParams binds URL query parameters to proto message fields.")
 	gf.P(
@@ -615,7 +1107,16 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	gf.P("fields := reflectMsg.Descriptor().Fields()")
 	gf.P()
 	gf.P("for _, param := range params {")
-	gf.P("values := query[param.QueryName]")
+	gf.P("if param.Exclusive {")
+	gf.P("if field := fields.ByName(protoreflect.Name(param.FieldName)); field != nil {")
+	gf.P("reflectMsg.Clear(field)")
+	gf.P("}")
+	gf.P("}")
+	if g.features.queryAliases {
+		gf.P("values := queryParamValues(query, param)")
+	} else {
+		gf.P("values := query[param.QueryName]")
+	}
 	gf.P("// Filter empty values (e.g., ?param= treated as unset)")
 	gf.P("var filtered []string")
 	gf.P("for _, v := range values {")
@@ -641,6 +1142,22 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	gf.P("continue // Field not found, skip")
 	gf.P("}")
 	gf.P()
+	if g.features.jsonQueryParams {
+		gf.P("// A JSON_BASE64URL parameter carries the whole message or list of messages")
+		gf.P("if param.MaxJSONBytes > 0 {")
+		gf.P("err := sebufhttp.UnmarshalJSONQueryParam(values[0], param.MaxJSONBytes, reflectMsg, field)")
+		gf.P("if err != nil {")
+		gf.P("return &sebufhttp.ValidationError{")
+		gf.P("Violations: []*sebufhttp.FieldViolation{{")
+		gf.P(`Field: param.FieldName,`)
+		gf.P(`Description: fmt.Sprintf("invalid value for query parameter %s: %v", param.QueryName, err),`)
+		gf.P("}},")
+		gf.P("}")
+		gf.P("}")
+		gf.P("continue")
+		gf.P("}")
+		gf.P()
+	}
 	gf.P("// Handle repeated fields (arrays)")
 	gf.P("if field.IsList() {")
 	gf.P("list := reflectMsg.Mutable(field).List()")
@@ -673,6 +1190,10 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	gf.P("return nil")
 	gf.P("}")
 	gf.P()
+	if g.features.queryAliases {
+		g.generateQueryParamValuesFunc(gf)
+	}
+	g.generateQueryFieldPathsFuncs(gf)
 
 	// convertStringToFieldValue function - converts string values to protoreflect.Value
 	gf.P("// convertStringToFieldValue converts a string value to the appropriate protoreflect.Value.")
@@ -681,17 +1202,7 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	)
 	gf.P("switch field.Kind() {")
 	gf.P("case protoreflect.EnumKind:")
-	gf.P("// Try numeric value first — accept unknown numbers for proto3 forward-compat")
-	gf.P("if v, err := strconv.ParseInt(value, 10, 32); err == nil {")
-	gf.P("return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v)), nil")
-	gf.P("}")
-	gf.P("// Fall back to enum name lookup")
-	gf.P("enumDesc := field.Enum()")
-	gf.P("enumVal := enumDesc.Values().ByName(protoreflect.Name(value))")
-	gf.P("if enumVal != nil {")
-	gf.P("return protoreflect.ValueOfEnum(enumVal.Number()), nil")
-	gf.P("}")
-	gf.P(`return protoreflect.Value{}, fmt.Errorf("invalid value %q for enum %s", value, enumDesc.Name())`)
+	gf.P("return convertStringToEnumValue(value, field.Enum())")
 	gf.P("case protoreflect.StringKind:")
 	gf.P("return protoreflect.ValueOfString(value), nil")
 	gf.P("case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:")
@@ -742,40 +1253,103 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	gf.P("}")
 	gf.P()
 
+	g.generateEnumParamFunctions(gf)
+
 	// genericHandler function
+	gf.P("// genericHandler serves a bound request with serve. It holds a limiter slot while serve runs,")
+	gf.P("// answering 503 when none is free, and bounds serve by timeout when positive, answering 504")
+	gf.P("// when it expires. The headers, trailers, status and warnings serve sets through its context")
+	gf.P("// are applied to a successful response, with the warnings also in its JSON object when")
+	gf.P("// warningsInBody, and w is guarded so a failure to write it cannot be followed by an error")
+	if g.features.responseValidation {
+		gf.P("// response. The response is validated under responseValidation, logging to logger.")
+	} else {
+		gf.P("// response.")
+	}
 	gf.P(
-		"func genericHandler[Req any, Res any](serve func(context.Context, Req) (Res, error), errorHandler ErrorHandler, marshalOpts protojson.MarshalOptions) http.HandlerFunc {",
+		"func genericHandler[Req any, Res any](serve func(context.Context, Req) (Res, error), errorHandler ErrorHandler, marshalOpts protojson.MarshalOptions,",
 	)
+	if g.features.responseValidation {
+		gf.P("limiter *sebufhttp.ConcurrencyLimiter, timeout time.Duration, warningsInBody bool,")
+		gf.P("responseValidation sebufhttp.ResponseValidationMode, logger *slog.Logger) http.HandlerFunc {")
+	} else {
+		gf.P("limiter *sebufhttp.ConcurrencyLimiter, timeout time.Duration, warningsInBody bool) http.HandlerFunc {")
+	}
 	gf.P("return func(w http.ResponseWriter, r *http.Request) {")
+	gf.P("w = guardResponse(w)")
+	gf.P("if !limiter.TryAcquire() {")
+	gf.P(`w.Header().Set("Retry-After", sebufhttp.RetryAfterSaturated)`)
+	gf.P("writeErrorWithHandler(w, r, &sebufhttp.Error{")
+	gf.P("Code:    sebufhttp.ErrorCodeUnavailable,")
+	gf.P(`Message: "server is at its concurrency limit",`)
+	gf.P("}, errorHandler, marshalOpts)")
+	gf.P("return")
+	gf.P("}")
+	gf.P()
+	gf.P("ctx, responseControl := sebufhttp.ContextWithResponseControl(r.Context())")
+	gf.P("defer responseControl.Close()")
 	gf.P("request := getRequest[Req](r.Context())")
 	gf.P()
-	gf.P("response, err := serve(r.Context(), request)")
+	gf.P("response, err := serveWithTimeout(ctx, serve, request, limiter, timeout)")
 	gf.P("if err != nil {")
-	gf.P("// Check if error is already a proto.Message (e.g., custom proto error types)")
-	gf.P("// If so, pass it directly - defaultErrorResponse will preserve its structure")
-	gf.P("if _, ok := err.(proto.Message); ok {")
-	gf.P("writeErrorWithHandler(w, r, err, errorHandler, marshalOpts)")
+	gf.P("if errors.Is(err, context.DeadlineExceeded) {")
+	gf.P("writeErrorWithHandler(w, r, &sebufhttp.Error{")
+	gf.P("Code:    sebufhttp.ErrorCodeDeadlineExceeded,")
+	gf.P(`Message: "request timed out",`)
+	gf.P("}, errorHandler, marshalOpts)")
 	gf.P("return")
 	gf.P("}")
-	gf.P("errorMsg := &sebufhttp.Error{")
-	gf.P("Message: err.Error(),")
-	gf.P("}")
-	gf.P("writeErrorWithHandler(w, r, errorMsg, errorHandler, marshalOpts)")
-	gf.P("return")
+	if g.grpcGateway() {
+		gf.P("// Pass the error as is, so the gRPC code it may carry is kept")
+		gf.P("writeErrorWithHandler(w, r, err, errorHandler, marshalOpts)")
+		gf.P("return")
+	} else {
+		gf.P("// Check if error is already a proto.Message (e.g., custom proto error types)")
+		gf.P("// If so, pass it directly - defaultErrorResponse will preserve its structure")
+		gf.P("if _, ok := err.(proto.Message); ok {")
+		gf.P("writeErrorWithHandler(w, r, err, errorHandler, marshalOpts)")
+		gf.P("return")
+		gf.P("}")
+		gf.P("errorMsg := &sebufhttp.Error{")
+		gf.P("Message: err.Error(),")
+		gf.P("}")
+		gf.P("writeErrorWithHandler(w, r, errorMsg, errorHandler, marshalOpts)")
+		gf.P("return")
+	}
 	gf.P("}")
 	gf.P()
-	gf.P("responseBytes, err := marshalResponse(r, response, marshalOpts)")
-	gf.P("if err != nil {")
-	gf.P("errorMsg := &sebufhttp.Error{")
-	gf.P("Message: fmt.Sprintf(\"failed to marshal response: %v\", err),")
-	gf.P("}")
-	gf.P("writeErrorWithHandler(w, r, errorMsg, errorHandler, marshalOpts)")
-	gf.P("return")
+	if g.features.responseValidation {
+		gf.P("if !validateResponse(r, response, responseValidation, logger) {")
+		gf.P("writeErrorWithHandler(w, r, sebufhttp.ResponseValidationFailed(), errorHandler, marshalOpts)")
+		gf.P("return")
+		gf.P("}")
+		gf.P()
+	}
+	body := "response"
+	if g.features.responseStatuses {
+		body = "body"
+		g.generateResponseVariantSelection(gf)
+	}
+	if g.features.rawBody {
+		g.generateRawBodyResponse(gf, body)
+	} else {
+		gf.P("responseBytes, err := marshalResponse(r, ", body, ", marshalOpts)")
+		gf.P("if err != nil {")
+		gf.P("errorMsg := &sebufhttp.Error{")
+		gf.P("Message: fmt.Sprintf(\"failed to marshal response: %v\", err),")
+		gf.P("}")
+		gf.P("writeErrorWithHandler(w, r, errorMsg, errorHandler, marshalOpts)")
+		gf.P("return")
+		gf.P("}")
+		gf.P()
+		gf.P("// Set response Content-Type based on Accept header (RFC 9110)")
+		gf.P("respContentType := resolveResponseContentType(r)")
+		gf.P(`w.Header().Set("Content-Type", respContentType)`)
+	}
+	gf.P("if warningsInBody && w.Header().Get(\"Content-Type\") == JSONContentType {")
+	gf.P("responseBytes = sebufhttp.AppendJSONWarnings(responseBytes, responseControl.Warnings())")
 	gf.P("}")
-	gf.P()
-	gf.P("// Set response Content-Type based on Accept header (RFC 9110)")
-	gf.P("respContentType := resolveResponseContentType(r)")
-	gf.P(`w.Header().Set("Content-Type", respContentType)`)
+	gf.P("responseControl.WriteHeader(w)")
 	gf.P()
 	gf.P("_, err = w.Write(responseBytes)")
 	gf.P("if err != nil {")
@@ -785,10 +1359,16 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	gf.P("writeErrorWithHandler(w, r, errorMsg, errorHandler, marshalOpts)")
 	gf.P("return")
 	gf.P("}")
+	gf.P("responseControl.WriteTrailer(w)")
 	gf.P("}")
 	gf.P("}")
 	gf.P()
 
+	g.generateServeWithTimeoutFunc(gf)
+	if g.features.responseValidation {
+		g.generateValidateResponseFunc(gf)
+	}
+
 	// marshalResponse function
 	gf.P("func marshalResponse(r *http.Request, response any, marshalOpts protojson.MarshalOptions) ([]byte, error) {")
 	gf.P("contentType := resolveResponseContentType(r)")
@@ -801,6 +1381,10 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	gf.P("switch contentType {")
 	gf.P("case BinaryContentType, ProtoContentType:")
 	gf.P("return proto.Marshal(msg)")
+	if g.servesMsgpack() {
+		gf.P("case MsgpackContentType:")
+		gf.P("return marshalMsgpack(msg, marshalOpts)")
+	}
 	gf.P("default:")
 	gf.P("return marshalJSONWithOpts(msg, marshalOpts)")
 	gf.P("}")
@@ -808,29 +1392,28 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 	gf.P()
 
 	// marshalJSONWithOpts dispatches: sebufMarshaler → json.Marshaler → protojson.
-	gf.P("// marshalJSONWithOpts dispatches JSON marshaling:")
+	gf.P("// marshalJSONWithOpts dispatches JSON marshaling through sebufhttp.MarshalMessageJSON,")
+	gf.P("// as the exported Marshal<Message>JSON helpers do:")
 	gf.P("//   - sebufMarshaler (sebuf-generated custom marshalers) receives marshalOpts")
 	gf.P("//   - json.Marshaler (third-party / back-compat) is called with no options")
 	gf.P("//   - otherwise marshalOpts.Marshal is used")
 	gf.P("func marshalJSONWithOpts(msg proto.Message, marshalOpts protojson.MarshalOptions) ([]byte, error) {")
-	gf.P("if m, ok := msg.(sebufMarshaler); ok {")
-	gf.P("return m.MarshalJSONSebuf(marshalOpts)")
-	gf.P("}")
-	gf.P("if m, ok := msg.(json.Marshaler); ok {")
-	gf.P("return m.MarshalJSON()")
-	gf.P("}")
-	gf.P("return marshalOpts.Marshal(msg)")
+	gf.P("return sebufhttp.MarshalMessageJSON(msg, marshalOpts)")
 	gf.P("}")
 	gf.P()
 
 	// Generate error response helpers
 	g.generateErrorResponseFunctions(gf)
 
-	// Generate validation support
-	g.generateValidationFunctions(gf)
+	// Generate validation support when a request or response message has buf.validate rules
+	if g.features.validatesMessages() {
+		g.generateValidationFunctions(gf)
+	}
 
-	// Generate header validation support
-	g.generateHeaderValidationFunctions(gf)
+	// Generate header validation support when a service or method declares headers
+	if g.features.headers {
+		g.generateHeaderValidationFunctions(gf)
+	}
 
 	// Generate SSE support if any service has SSE methods
 	for _, service := range file.Services {
@@ -840,6 +1423,14 @@ func (g *Generator) generateBindingFile(file *protogen.File) error {
 		}
 	}
 
+	// Generate streamed response support if any service has stream_response methods
+	for _, service := range file.Services {
+		if g.serviceHasStreamResponseMethods(service) {
+			g.generateStreamResponseHandler(gf)
+			break
+		}
+	}
+
 	return nil
 }
 
@@ -860,10 +1451,16 @@ func (g *Generator) generateConfigFile(file *protogen.File) error {
 
 func (g *Generator) generateConfigImports(gf *protogen.GeneratedFile) {
 	gf.P("import (")
+	gf.P(`"context"`)
+	gf.P(`"log/slog"`)
 	gf.P(`"net/http"`)
+	gf.P(`"time"`)
 	gf.P()
 	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
 	gf.P(`"google.golang.org/protobuf/proto"`)
+	gf.P(`"google.golang.org/protobuf/reflect/protoregistry"`)
+	gf.P()
+	gf.P(`sebufhttp "github.com/SebastienMelki/sebuf/http"`)
 	gf.P(")")
 	gf.P()
 }
@@ -878,7 +1475,10 @@ func (g *Generator) generateErrorHandlerType(gf *protogen.GeneratedFile) {
 	gf.P("//   - Return nil to use the default error response (ValidationError or Error)")
 	gf.P("//")
 	gf.P("// If you write directly to w (via w.Write()), the response is considered")
-	gf.P("// complete and no further writing occurs.")
+	gf.P("// complete and no further writing occurs: a message returned as well is dropped,")
+	gf.P("// with a warning to the WithLogger logger. Only the first status w.WriteHeader")
+	gf.P("// sets is sent, and w has a Written() bool method reporting whether the response")
+	gf.P("// has started.")
 	gf.P("//")
 	gf.P("// Use errors.As() to inspect error types: *sebufhttp.ValidationError or *sebufhttp.Error")
 	gf.P("type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error) proto.Message")
@@ -893,20 +1493,55 @@ func (g *Generator) generateServerOptionType(gf *protogen.GeneratedFile) {
 
 func (g *Generator) generateServerConfigurationStruct(gf *protogen.GeneratedFile) {
 	gf.P("type serverConfiguration struct {")
-	gf.P("mux *http.ServeMux")
-	gf.P("withMux bool")
+	if g.stdlibRouter() {
+		gf.P("mux *http.ServeMux")
+		gf.P("withMux bool")
+	}
 	gf.P("errorHandler ErrorHandler")
 	gf.P("marshalOpts protojson.MarshalOptions")
+	gf.P("idempotencyStore sebufhttp.IdempotencyStore")
+	gf.P("idempotencyTTL time.Duration")
+	gf.P("responseCacheSize int")
+	gf.P("validationPolicy sebufhttp.ValidationPolicy")
+	gf.P("violationFormatter sebufhttp.ViolationFormatter")
+	gf.P("validationFailureMode sebufhttp.ValidationFailureMode")
+	gf.P("schemaMismatchMode sebufhttp.SchemaMismatchMode")
+	gf.P("logger *slog.Logger")
+	gf.P("concurrencyLimit int")
+	gf.P("defaultTimeout time.Duration")
+	gf.P("timeoutHeader sebufhttp.TimeoutHeader")
+	gf.P("metrics *sebufhttp.ServerMetrics")
+	gf.P("maxBodySize int64")
+	gf.P("strictJSON bool")
+	gf.P("noContentSniffing bool")
+	gf.P("warningsInBody bool")
+	gf.P("typeResolver sebufhttp.TypeResolver")
+	gf.P("routeDebug bool")
+	gf.P("routeDebugAuth func(*http.Request) bool")
+	gf.P("headerAuthenticators []sebufhttp.HeaderAuthenticator")
+	gf.P("problemJSON bool")
+	gf.P("deprecationReporter sebufhttp.DeprecationReporter")
+	gf.P("responseValidation sebufhttp.ResponseValidationMode")
+	gf.P("responseHeaderOverrides map[string]string")
+	gf.P("}")
+	gf.P()
+	gf.P("// writeError writes err through the configured error handler.")
+	gf.P("func (c *serverConfiguration) writeError(w http.ResponseWriter, r *http.Request, err error) {")
+	gf.P("writeErrorWithHandler(w, r, err, c.errorHandler, c.marshalOpts)")
 	gf.P("}")
 	gf.P()
 }
 
 func (g *Generator) generateConfigFunctions(gf *protogen.GeneratedFile) {
 	gf.P("func getDefaultConfiguration() *serverConfiguration {")
-	gf.P("return &serverConfiguration{")
-	gf.P("mux: http.DefaultServeMux,")
-	gf.P("withMux: false,")
-	gf.P("}")
+	if g.stdlibRouter() {
+		gf.P("return &serverConfiguration{")
+		gf.P("mux: http.DefaultServeMux,")
+		gf.P("withMux: false,")
+		gf.P("}")
+	} else {
+		gf.P("return &serverConfiguration{}")
+	}
 	gf.P("}")
 	gf.P()
 
@@ -915,20 +1550,32 @@ func (g *Generator) generateConfigFunctions(gf *protogen.GeneratedFile) {
 	gf.P("for _, option := range options {")
 	gf.P("option(configuration)")
 	gf.P("}")
+	gf.P("if configuration.marshalOpts.Resolver == nil && configuration.typeResolver != nil {")
+	gf.P("// Responses resolve Any type URLs like request bodies, unless WithMarshalOptions set a resolver")
+	gf.P("configuration.marshalOpts.Resolver = configuration.typeResolver")
+	gf.P("}")
+	gf.P("if configuration.errorHandler != nil {")
+	gf.P("configuration.errorHandler = guardErrorHandler(configuration.errorHandler, configuration.logger)")
+	gf.P("}")
+	gf.P("if configuration.problemJSON {")
+	gf.P("configuration.errorHandler = problemErrorHandler(configuration.errorHandler)")
+	gf.P("}")
 	gf.P("return configuration")
 	gf.P("}")
 	gf.P()
 }
 
 func (g *Generator) generateServerOptions(gf *protogen.GeneratedFile) {
-	gf.P("// WithMux configures the Server to use the given ServeMux")
-	gf.P("func WithMux(mux *http.ServeMux) ServerOption {")
-	gf.P("return func(c *serverConfiguration) {")
-	gf.P("c.mux = mux")
-	gf.P("c.withMux = true")
-	gf.P("}")
-	gf.P("}")
-	gf.P()
+	if g.stdlibRouter() {
+		gf.P("// WithMux configures the Server to use the given ServeMux")
+		gf.P("func WithMux(mux *http.ServeMux) ServerOption {")
+		gf.P("return func(c *serverConfiguration) {")
+		gf.P("c.mux = mux")
+		gf.P("c.withMux = true")
+		gf.P("}")
+		gf.P("}")
+		gf.P()
+	}
 
 	gf.P("// WithErrorHandler configures a custom error handler for the server.")
 	gf.P("func WithErrorHandler(handler ErrorHandler) ServerOption {")
@@ -948,6 +1595,305 @@ func (g *Generator) generateServerOptions(gf *protogen.GeneratedFile) {
 	gf.P("}")
 	gf.P("}")
 	gf.P()
+
+	gf.P("// WithIdempotencyStore configures the store backing methods annotated with")
+	gf.P("// idempotency: true. Without it, each registered service keeps an in-process")
+	gf.P("// sebufhttp.MemoryIdempotencyStore, which does not deduplicate across replicas.")
+	gf.P("func WithIdempotencyStore(store sebufhttp.IdempotencyStore) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.idempotencyStore = store")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithIdempotencyTTL configures how long responses of idempotent methods are kept")
+	gf.P("// for replay. The zero value selects sebufhttp.DefaultIdempotencyTTL.")
+	gf.P("func WithIdempotencyTTL(ttl time.Duration) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.idempotencyTTL = ttl")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithResponseCache enables an in-process cache of up to size responses for methods")
+	gf.P("// annotated with cache. A cached response is served for its max_age_seconds without")
+	gf.P("// calling the service implementation; header and request validation still run.")
+	gf.P("// Each registered service keeps its own cache.")
+	gf.P("func WithResponseCache(size int) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.responseCacheSize = size")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithValidationPolicy configures how requests failing header or protovalidate")
+	gf.P("// validation are treated. The policy can relax validation for trusted callers:")
+	gf.P("// sebufhttp.ValidationWarn logs the violations and exposes them through")
+	gf.P("// sebufhttp.ViolationsFromContext, sebufhttp.ValidationSkip does not validate.")
+	gf.P("// Without a policy, validation is always enforced.")
+	gf.P("func WithValidationPolicy(policy sebufhttp.ValidationPolicy) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.validationPolicy = policy")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithValidationFailureMode configures how requests whose message cannot be")
+	gf.P("// validated are treated, such as when building its protovalidate validator fails.")
+	gf.P("// Register*Server builds the validators of its messages and fails on such errors,")
+	gf.P("// so this only matters for validation failing at run time. By default, with")
+	gf.P("// sebufhttp.ValidationFailClosed, they are rejected with an internal error;")
+	gf.P("// sebufhttp.ValidationFailOpen lets them through unvalidated. Both log the error.")
+	gf.P("func WithValidationFailureMode(mode sebufhttp.ValidationFailureMode) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.validationFailureMode = mode")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithResponseValidation validates the responses handlers return against their")
+	gf.P("// buf.validate rules, for catching handlers that break the service's contract in")
+	gf.P("// development and staging. sebufhttp.ResponseValidationWarn logs the violations")
+	gf.P("// to the logger of WithLogger and sends the response anyway;")
+	gf.P("// sebufhttp.ResponseValidationEnforce logs them and answers with a 500 whose")
+	gf.P("// message reveals none of the response. Responses whose message has no rules")
+	gf.P("// are never validated. The default, sebufhttp.ResponseValidationOff, costs nothing.")
+	gf.P("func WithResponseValidation(mode sebufhttp.ResponseValidationMode) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.responseValidation = mode")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithProblemJSON answers errors with Problem Details (RFC 9457) as")
+	gf.P("// application/problem+json instead of sebufhttp.Error and sebufhttp.ValidationError")
+	gf.P("// messages: type, title, status, detail and instance (the request path), with the")
+	gf.P("// code and details of an Error, and the violations of a ValidationError as an")
+	gf.P("// errors array of JSON Pointers. A WithErrorHandler handler still runs first, and")
+	gf.P("// the errors it answers itself are written as it chooses. Services with")
+	gf.P("// error_format PROBLEM_JSON always use it.")
+	gf.P("func WithProblemJSON() ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.problemJSON = true")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithSchemaMismatchMode configures how requests from clients generated from")
+	gf.P("// another schema are treated, for services generated with schema_fingerprint. By")
+	gf.P("// default, with sebufhttp.SchemaMismatchWarn, they are logged and served;")
+	gf.P("// sebufhttp.SchemaMismatchReject answers them with a SCHEMA_MISMATCH error and")
+	gf.P("// sebufhttp.SchemaMismatchIgnore serves them silently. Handlers can read the")
+	gf.P("// outcome with sebufhttp.SchemaMatchFromContext either way.")
+	gf.P("func WithSchemaMismatchMode(mode sebufhttp.SchemaMismatchMode) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.schemaMismatchMode = mode")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithViolationFormatter sets the function producing the description of each header")
+	gf.P("// or protovalidate violation in a ValidationError, for example to localize it. It")
+	gf.P("// receives the field, the constraint ID and parameters of the failed rule, and the")
+	gf.P("// default message, which is kept when it returns an empty string.")
+	gf.P("func WithViolationFormatter(formatter func(v sebufhttp.Violation) string) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.violationFormatter = formatter")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithViolationCatalog describes violations with the text/template catalog holds for")
+	gf.P("// their constraint ID, such as \"string.min_len\" or \"header.required\". Templates read")
+	gf.P("// the sebufhttp.Violation, as in {{.Field}} or {{.Params.min_len}}; violations without")
+	gf.P("// a template keep their default message. It panics if a template does not parse,")
+	gf.P("// and replaces any WithViolationFormatter.")
+	gf.P("func WithViolationCatalog(catalog map[string]string) ServerOption {")
+	gf.P("formatter := sebufhttp.MustViolationCatalog(catalog)")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.violationFormatter = formatter")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithHeaderAuthenticator verifies the credentials of the header headerName with fn,")
+	gf.P("// typically a header declared with an auth_type, on every method of the service,")
+	gf.P("// whether or not it declares the header. fn runs after header validation with the")
+	gf.P("// header as sent, and returns the context the handler runs with, which may carry a")
+	gf.P("// principal (see sebufhttp.ContextWithPrincipal). When fn fails, or the request lacks")
+	gf.P("// a header it does not declare optional, the request is answered with 401")
+	gf.P("// Unauthorized and an Error with code UNAUTHENTICATED, unless fn returns a")
+	gf.P("// *sebufhttp.Error, which is written as is. A missing required header is still")
+	gf.P("// answered with the 400 ValidationError. Authenticators run in the order of the options.")
+	gf.P("func WithHeaderAuthenticator(")
+	gf.P("headerName string, fn func(ctx context.Context, value string) (context.Context, error),")
+	gf.P(") ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.headerAuthenticators = append(c.headerAuthenticators,")
+	gf.P("sebufhttp.HeaderAuthenticator{Header: headerName, Authenticate: fn})")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithConcurrencyLimit caps how many handlers of each registered service execute at")
+	gf.P("// once. A slot is taken after the request is bound and validated, and released when the")
+	gf.P("// handler returns; requests arriving while all slots are taken are answered with")
+	gf.P("// 503 Service Unavailable and a Retry-After header. Streaming methods are not limited.")
+	gf.P("// The zero value means no limit.")
+	gf.P("func WithConcurrencyLimit(n int) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.concurrencyLimit = n")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithDefaultTimeout bounds how long the handler of a method without a timeout_ms")
+	gf.P("// annotation may run. When it expires, the handler's context is cancelled and the")
+	gf.P("// request is answered with 504 Gateway Timeout; the handler's late result is discarded.")
+	gf.P("// Streaming methods are not bounded. The zero value means no timeout.")
+	gf.P("func WithDefaultTimeout(timeout time.Duration) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.defaultTimeout = timeout")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithTimeoutHeader bounds the context of each request by the timeout it carries in the")
+	gf.P("// header name, clamped to max when positive: a number of milliseconds, as in")
+	gf.P("// X-Request-Timeout-Ms, or a grpc-timeout value such as \"250m\" or \"2S\". The timeout")
+	gf.P("// applied is reported in the X-Timeout-Applied-Ms response header. A handler still running")
+	gf.P("// when it expires is answered with 504 Gateway Timeout, as for timeout_ms, and a malformed")
+	gf.P("// value with a validation error naming the header. Streaming methods are not bounded.")
+	gf.P("func WithTimeoutHeader(name string, max time.Duration) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.timeoutHeader = sebufhttp.TimeoutHeader{Name: name, Max: max}")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithMetrics records request metrics into registry; the sebuf/http/metrics/prometheus")
+	gf.P("// package adapts a Prometheus registerer. Every method records:")
+	gf.P("//   - sebuf_http_requests_total{method, code}: requests by status code")
+	gf.P("//   - sebuf_http_request_duration_seconds{method}: request duration")
+	gf.P("//   - sebuf_http_request_size_bytes{method}: request body size")
+	gf.P("//   - sebuf_http_response_size_bytes{method}: response body size")
+	gf.P("//   - sebuf_http_validation_failures_total{method, field}: enforced or warned violations")
+	gf.P("// The method label is the full protobuf name of the RPC.")
+	gf.P("func WithMetrics(registry sebufhttp.MetricsRegistry) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.metrics = sebufhttp.NewServerMetrics(registry)")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithMaxBodySize bounds the size of request bodies. Larger bodies are answered")
+	gf.P("// with 413 Content Too Large. The zero value means no limit, except for multipart")
+	gf.P("// bodies, which are bounded by sebufhttp.DefaultMaxMultipartBodySize.")
+	gf.P("func WithMaxBodySize(n int64) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.maxBodySize = n")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithStrictJSON rejects JSON request bodies with keys that name no field of the")
+	gf.P("// request message on every method, as if each set strict_json. Each unknown key")
+	gf.P("// is reported as a violation of a 400 ValidationError.")
+	gf.P("func WithStrictJSON() ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.strictJSON = true")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithDeprecationReporting calls report for each field marked deprecated in the")
+	gf.P("// proto, nested ones included, that a JSON request body sets, even to null or to")
+	gf.P("// its zero value, with the full name of the RPC and the dotted proto name path")
+	gf.P("// of the field. The fields are also listed in the Deprecation response header.")
+	gf.P("func WithDeprecationReporting(report func(method, field string)) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.deprecationReporter = report")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithResponseHeaderOverrides changes the response_headers the services and")
+	gf.P("// methods annotate at deployment, by canonical name: an override replaces the")
+	gf.P("// value of the header of its name, or sets it on every response when no")
+	gf.P("// annotation declares it, and an empty value removes the header.")
+	gf.P("func WithResponseHeaderOverrides(overrides map[string]string) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.responseHeaderOverrides = overrides")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithoutContentSniffing binds request bodies that do not look like their")
+	gf.P("// Content-Type, for payloads the check would misjudge. By default a JSON body")
+	gf.P("// whose first non-whitespace byte cannot start a JSON value, or a protobuf body")
+	gf.P("// starting with { or [, is answered with 415 Unsupported Media Type.")
+	gf.P("func WithoutContentSniffing() ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.noContentSniffing = true")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithWarningsInBody adds the warnings handlers add with sebufhttp.AddWarning to")
+	gf.P("// JSON response objects, as a top-level _warnings array of {code, message}, besides")
+	gf.P("// the Warning headers always sent. Responses that are not JSON objects, such as")
+	gf.P("// root-unwrapped messages and raw bodies, carry them in the headers only.")
+	gf.P("func WithWarningsInBody() ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.warningsInBody = true")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithTypeResolver resolves the type URLs of google.protobuf.Any fields against")
+	gf.P("// resolver when binding JSON request bodies and writing JSON responses, so Any")
+	gf.P("// payloads may hold messages missing from protoregistry.GlobalTypes. resolver")
+	gf.P("// replaces protoregistry.GlobalTypes for messages, so it must also hold the")
+	gf.P("// generated types Any fields carry. Extensions resolve against")
+	gf.P("// protoregistry.GlobalTypes unless resolver also resolves them.")
+	gf.P("func WithTypeResolver(resolver protoregistry.MessageTypeResolver) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.typeResolver = sebufhttp.NewTypeResolver(resolver)")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithRouteDebug logs every route the server registers, with its service, RPC and")
+	gf.P("// required headers, to the logger of WithLogger, and lists them as JSON at")
+	gf.P("// GET /__sebuf/routes of the mux. Services registered on the same mux share the")
+	gf.P("// listing. Restrict it with WithRouteDebugAuth outside of development.")
+	gf.P("func WithRouteDebug() ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.routeDebug = true")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithRouteDebugAuth answers requests for the WithRouteDebug listing that allow")
+	gf.P("// rejects with 404 Not Found, as if the listing did not exist.")
+	if g.embedDescriptors {
+		gf.P("// The descriptors endpoints of the Reflection functions are restricted alike.")
+	}
+	gf.P("func WithRouteDebugAuth(allow func(r *http.Request) bool) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.routeDebugAuth = allow")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// WithLogger configures the logger used for request diagnostics, such as the")
+	gf.P("// violations let through by a sebufhttp.ValidationWarn policy. Defaults to slog.Default().")
+	gf.P("func WithLogger(logger *slog.Logger) ServerOption {")
+	gf.P("return func(c *serverConfiguration) {")
+	gf.P("c.logger = logger")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
 }
 
 func (g *Generator) writeHeader(gf *protogen.GeneratedFile, file *protogen.File) {
@@ -958,6 +1904,53 @@ func (g *Generator) writeHeader(gf *protogen.GeneratedFile, file *protogen.File)
 	gf.P()
 }
 
+// generateRouteRegistration registers a method's handler on the mux. A versioned service
+// registers it under every version's base path, wrapping the routes of deprecated versions
+// in sebufhttp.DeprecatedVersionMiddleware.
+func (g *Generator) generateRouteRegistration(
+	gf *protogen.GeneratedFile,
+	service *protogen.Service,
+	method *protogen.Method,
+	httpMethod, httpPath, handlerName string,
+	packageName protogen.GoPackageName,
+) {
+	versions := annotations.GetServiceVersions(service)
+	if len(versions) == 0 {
+		g.generateHandle(gf, method, httpMethod, httpPath, routeConstName(method, ""), handlerName)
+		return
+	}
+	for _, version := range versions {
+		versionPath := g.getMethodPath(method, version.BasePath, packageName)
+		route := routeConstName(method, version.Name)
+		if version.Deprecated {
+			g.generateHandle(gf, method, httpMethod, versionPath, route,
+				`sebufhttp.DeprecatedVersionMiddleware(`+handlerName+`, "`+version.SunsetHTTPDate()+`")`)
+		} else {
+			g.generateHandle(gf, method, httpMethod, versionPath, route, handlerName)
+		}
+	}
+}
+
+// generateResponseHeadersMiddleware wraps a method's handler so that every
+// response carries its response_headers, changed by WithResponseHeaderOverrides.
+func (g *Generator) generateResponseHeadersMiddleware(
+	gf *protogen.GeneratedFile,
+	method *protogen.Method,
+	handlerName string,
+) {
+	headers := annotations.GetResponseHeaders(method)
+	wrap := handlerName + " = sebufhttp.ResponseHeadersMiddleware(" + handlerName
+	if len(headers) == 0 {
+		gf.P(wrap, ", nil, config.responseHeaderOverrides)")
+		return
+	}
+	gf.P(wrap, ", map[string]string{")
+	for _, header := range headers {
+		gf.P(strconv.Quote(header.Name), ": ", strconv.Quote(header.Value), ",")
+	}
+	gf.P("}, config.responseHeaderOverrides)")
+}
+
 // getMethodPath determines the HTTP path for a method.
 func (g *Generator) getMethodPath(method *protogen.Method, basePath string, packageName protogen.GoPackageName) string {
 	// Try to get custom path from options
@@ -1001,6 +1994,17 @@ func (g *Generator) getServiceBasePath(service *protogen.Service) string {
 	return annotations.GetServiceBasePath(service)
 }
 
+// quotedBasePathParams returns the names of the base path parameters of a
+// service as Go string literals separated by commas, or "" when it has none.
+func quotedBasePathParams(service *protogen.Service) string {
+	params := annotations.GetBasePathParams(service)
+	quoted := make([]string, 0, len(params))
+	for _, param := range params {
+		quoted = append(quoted, strconv.Quote(param.Name))
+	}
+	return strings.Join(quoted, ", ")
+}
+
 // getHTTPMethod returns the HTTP method for a method. Defaults to POST for backward compatibility.
 func (g *Generator) getHTTPMethod(method *protogen.Method) string {
 	config := annotations.GetMethodHTTPConfig(method)
@@ -1010,13 +2014,14 @@ func (g *Generator) getHTTPMethod(method *protogen.Method) string {
 	return "POST"
 }
 
-// getPathParams extracts path parameter names from method configuration.
+// getPathParams returns the path parameter names bound to request fields: those
+// of the base path not declared with context_only, then those of the method path.
 func (g *Generator) getPathParams(method *protogen.Method) []string {
-	config := annotations.GetMethodHTTPConfig(method)
-	if config != nil {
-		return config.PathParams
+	params := annotations.GetBoundBasePathParams(method.Parent)
+	if config := annotations.GetMethodHTTPConfig(method); config != nil {
+		params = append(params, config.PathParams...)
 	}
-	return nil
+	return params
 }
 
 // isSSEMethod checks if a method is annotated as SSE streaming.
@@ -1025,6 +2030,167 @@ func (g *Generator) isSSEMethod(method *protogen.Method) bool {
 	return config != nil && config.Stream
 }
 
+// isIdempotentMethod checks if a method is annotated with idempotency: true.
+func (g *Generator) isIdempotentMethod(method *protogen.Method) bool {
+	config := annotations.GetMethodHTTPConfig(method)
+	return config != nil && config.Idempotency
+}
+
+// serviceHasIdempotentMethods checks if any method in the service is idempotent.
+func (g *Generator) serviceHasIdempotentMethods(service *protogen.Service) bool {
+	for _, method := range service.Methods {
+		if g.isIdempotentMethod(method) {
+			return true
+		}
+	}
+	return false
+}
+
+// getMethodCache returns the cache annotation of a method, or nil if it has none.
+func (g *Generator) getMethodCache(method *protogen.Method) *http.CacheConfig {
+	config := annotations.GetMethodHTTPConfig(method)
+	if config == nil {
+		return nil
+	}
+	return config.Cache
+}
+
+// serviceHasCachedMethods checks if any method in the service is annotated with cache.
+func (g *Generator) serviceHasCachedMethods(service *protogen.Service) bool {
+	for _, method := range service.Methods {
+		if g.getMethodCache(method) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// fileHasCachedMethods checks if any service in the file has cached methods.
+func (g *Generator) fileHasCachedMethods(file *protogen.File) bool {
+	for _, service := range file.Services {
+		if g.serviceHasCachedMethods(service) {
+			return true
+		}
+	}
+	return false
+}
+
+// cachePolicyLiteral renders the sebufhttp.CachePolicy of a cached method. The
+// service and method headers vary the cached response, since they are the
+// request headers the API declares as meaningful.
+func (g *Generator) cachePolicyLiteral(
+	service *protogen.Service,
+	method *protogen.Method,
+	cache *http.CacheConfig,
+) string {
+	fields := []string{fmt.Sprintf("MaxAge: %d * time.Second", cache.GetMaxAgeSeconds())}
+	if cache.GetPublic() {
+		fields = append(fields, "Public: true")
+	}
+	headers := annotations.CombineHeaders(annotations.GetServiceHeaders(service), annotations.GetMethodHeaders(method))
+	if len(headers) > 0 {
+		names := make([]string, 0, len(headers))
+		for _, header := range headers {
+			names = append(names, strconv.Quote(annotations.CanonicalHeaderName(header.GetName())))
+		}
+		fields = append(fields, "VaryHeaders: []string{"+strings.Join(names, ", ")+"}")
+	}
+	return "sebufhttp.CachePolicy{" + strings.Join(fields, ", ") + "}"
+}
+
+// getMethodTimeoutMs returns the timeout_ms annotation of a method, or 0 if it has none.
+func (g *Generator) getMethodTimeoutMs(method *protogen.Method) int32 {
+	config := annotations.GetMethodHTTPConfig(method)
+	if config == nil {
+		return 0
+	}
+	return config.TimeoutMs
+}
+
+// methodTimeoutExpr renders the handler timeout of a method: its timeout_ms
+// annotation, or the server's WithDefaultTimeout.
+func (g *Generator) methodTimeoutExpr(method *protogen.Method) string {
+	if timeoutMs := g.getMethodTimeoutMs(method); timeoutMs > 0 {
+		return fmt.Sprintf("%d * time.Millisecond", timeoutMs)
+	}
+	return "config.defaultTimeout"
+}
+
+// methodWarningsInBodyExpr returns the expression of whether a method adds its
+// warnings to its JSON response: config.warningsInBody, or false when its
+// response is a raw body or a root-unwrapped message, or may be one of those as
+// a result message variant, which has no object to hold them.
+func methodWarningsInBodyExpr(method *protogen.Method) string {
+	if annotations.IsRawBodyResponse(method) || annotations.IsRootUnwrap(method.Output) {
+		return "false"
+	}
+	variants, _ := annotations.GetResponseVariants(method.Output)
+	for _, variant := range variants {
+		if annotations.IsRootUnwrap(variant.Field.Message) {
+			return "false"
+		}
+	}
+	return "config.warningsInBody"
+}
+
+// responseValidationArgs returns the response validation arguments of a
+// method's genericHandler, when the file validates responses: the mode of
+// WithResponseValidation, or off for a response message without rules to check.
+func (g *Generator) responseValidationArgs(method *protogen.Method) string {
+	if !g.features.responseValidation {
+		return ""
+	}
+	if !annotations.HasValidationRules(method.Output.Desc) {
+		return ", sebufhttp.ResponseValidationOff, config.logger"
+	}
+	return ", config.responseValidation, config.logger"
+}
+
+// fileHasTimeoutMethods checks if any method in the file is annotated with timeout_ms.
+func (g *Generator) fileHasTimeoutMethods(file *protogen.File) bool {
+	for _, service := range file.Services {
+		for _, method := range service.Methods {
+			if g.getMethodTimeoutMs(method) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// serviceHasUnaryMethods checks if any method in the service is served by genericHandler.
+func (g *Generator) serviceHasUnaryMethods(service *protogen.Service) bool {
+	for _, method := range service.Methods {
+		if !g.isSSEMethod(method) && !annotations.IsStreamResponse(method) {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceHasStreamResponseMethods checks if any method in the service streams its response items.
+func (g *Generator) serviceHasStreamResponseMethods(service *protogen.Service) bool {
+	return slices.ContainsFunc(service.Methods, annotations.IsStreamResponse)
+}
+
+// streamResponseItem returns the message of the items a stream_response method
+// yields. Validation has already checked the response shape.
+func (g *Generator) streamResponseItem(method *protogen.Method) *protogen.Message {
+	field, _ := annotations.StreamResponseField(method)
+	return field.Message
+}
+
+// streamResponseKeys returns the JSON key holding the items of a stream_response
+// method, and the key used when the server marshals with proto names. Both are
+// empty when the response is a root unwrap, which is written as a bare array.
+func (g *Generator) streamResponseKeys(method *protogen.Method) (string, string) {
+	if annotations.IsRootUnwrap(method.Output) {
+		return "", ""
+	}
+	field := method.Output.Fields[0]
+	return annotations.JSONFieldName(field), string(field.Desc.Name())
+}
+
 // serviceHasSSEMethods checks if any method in the service uses SSE streaming.
 func (g *Generator) serviceHasSSEMethods(service *protogen.Service) bool {
 	for _, method := range service.Methods {
@@ -1049,112 +2215,335 @@ func camelToSnake(s string) string {
 	return string(result)
 }
 
+// generateEnumParamFunctions generates the enum resolution used by path and query binding.
+// Accepted forms mirror the client serialization: the custom enum_value string first, then
+// the proto value name, then a numeric value.
+func (g *Generator) generateEnumParamFunctions(gf *protogen.GeneratedFile) {
+	gf.P("// convertStringToEnumValue resolves an enum parameter, accepting (in order) the custom")
+	gf.P("// enum_value string, the proto value name, and a numeric value.")
+	gf.P(
+		"func convertStringToEnumValue(value string, enumDesc protoreflect.EnumDescriptor) (protoreflect.Value, error) {",
+	)
+	gf.P("values := enumDesc.Values()")
+	gf.P("for i := range values.Len() {")
+	gf.P("if custom := enumValueMapping(values.Get(i)); custom != \"\" && custom == value {")
+	gf.P("return protoreflect.ValueOfEnum(values.Get(i).Number()), nil")
+	gf.P("}")
+	gf.P("}")
+	gf.P("if enumVal := values.ByName(protoreflect.Name(value)); enumVal != nil {")
+	gf.P("return protoreflect.ValueOfEnum(enumVal.Number()), nil")
+	gf.P("}")
+	gf.P("// Accept unknown numbers for proto3 forward-compat")
+	gf.P("if v, err := strconv.ParseInt(value, 10, 32); err == nil {")
+	gf.P("return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v)), nil")
+	gf.P("}")
+	gf.P()
+	gf.P("accepted := make([]string, 0, values.Len())")
+	gf.P("for i := range values.Len() {")
+	gf.P("if custom := enumValueMapping(values.Get(i)); custom != \"\" {")
+	gf.P("accepted = append(accepted, custom)")
+	gf.P("}")
+	gf.P("accepted = append(accepted, string(values.Get(i).Name()))")
+	gf.P("}")
+	gf.P("return protoreflect.Value{}, fmt.Errorf(")
+	gf.P(`"invalid value %q for enum %s, expected one of: %s",`)
+	gf.P("value, enumDesc.Name(), strings.Join(accepted, \", \"),")
+	gf.P(")")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// enumValueMapping returns the custom enum_value string for an enum value, or \"\" if unset.")
+	gf.P("func enumValueMapping(value protoreflect.EnumValueDescriptor) string {")
+	gf.P("opts, ok := value.Options().(*descriptorpb.EnumValueOptions)")
+	gf.P("if !ok || opts == nil {")
+	gf.P(`return ""`)
+	gf.P("}")
+	gf.P("custom, _ := proto.GetExtension(opts, sebufhttp.E_EnumValue).(string)")
+	gf.P("return custom")
+	gf.P("}")
+	gf.P()
+}
+
+// generateResponseVariantSelection generates the part of genericHandler that
+// answers a result message with its set variant, under the variant's status.
+func (g *Generator) generateResponseVariantSelection(gf *protogen.GeneratedFile) {
+	gf.P("// A result message is answered with the message of its set variant, under that variant's status")
+	gf.P("var body any = response")
+	gf.P("if msg, ok := any(response).(proto.Message); ok {")
+	gf.P("status, variant, isResult, variantErr := sebufhttp.ResponseVariant(msg)")
+	gf.P("if variantErr != nil {")
+	gf.P("writeErrorWithHandler(w, r, &sebufhttp.Error{Message: variantErr.Error()}, errorHandler, marshalOpts)")
+	gf.P("return")
+	gf.P("}")
+	gf.P("if isResult {")
+	gf.P("sebufhttp.SetStatus(ctx, status)")
+	gf.P("body = variant")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+}
+
+// generateServeWithTimeoutFunc generates serveWithTimeout, which runs a handler
+// under its timeout and frees its concurrency limiter slot.
+func (g *Generator) generateServeWithTimeoutFunc(gf *protogen.GeneratedFile) {
+	gf.P("// serveWithTimeout calls serve and releases its limiter slot once serve returns. When timeout")
+	gf.P("// is positive, serve's context is cancelled after timeout. Once it or a deadline ctx already")
+	gf.P("// has (see WithTimeoutHeader) expires, serveWithTimeout returns context.DeadlineExceeded")
+	gf.P("// without waiting for serve, whose late result is discarded; a result serve returned by then")
+	gf.P("// is kept.")
+	gf.P("func serveWithTimeout[Req any, Res any](ctx context.Context, serve func(context.Context, Req) (Res, error),")
+	gf.P("request Req, limiter *sebufhttp.ConcurrencyLimiter, timeout time.Duration) (Res, error) {")
+	gf.P("_, bounded := ctx.Deadline()")
+	gf.P("if timeout <= 0 && !bounded {")
+	gf.P("defer limiter.Release()")
+	gf.P("return serve(ctx, request)")
+	gf.P("}")
+	gf.P()
+	gf.P("var cancel context.CancelFunc")
+	gf.P("if timeout > 0 {")
+	gf.P("ctx, cancel = context.WithTimeout(ctx, timeout)")
+	gf.P("} else {")
+	gf.P("ctx, cancel = context.WithCancel(ctx)")
+	gf.P("}")
+	gf.P("defer cancel()")
+	gf.P("type result struct {")
+	gf.P("response Res")
+	gf.P("err      error")
+	gf.P("panicked any")
+	gf.P("}")
+	gf.P("done := make(chan result, 1)")
+	gf.P("go func() {")
+	gf.P("defer limiter.Release()")
+	gf.P("var res result")
+	gf.P("defer func() {")
+	gf.P("// Re-raise panics on the request goroutine, where net/http recovers them")
+	gf.P("res.panicked = recover()")
+	gf.P("done <- res")
+	gf.P("}()")
+	gf.P("res.response, res.err = serve(ctx, request)")
+	gf.P("}()")
+	gf.P()
+	gf.P("var res result")
+	gf.P("select {")
+	gf.P("case res = <-done:")
+	gf.P("case <-ctx.Done():")
+	gf.P("// serve may have returned just as ctx expired: its result wins")
+	gf.P("select {")
+	gf.P("case res = <-done:")
+	gf.P("default:")
+	gf.P("var zero Res")
+	gf.P("return zero, ctx.Err()")
+	gf.P("}")
+	gf.P("}")
+	gf.P("if res.panicked != nil {")
+	gf.P("panic(res.panicked)")
+	gf.P("}")
+	gf.P("return res.response, res.err")
+	gf.P("}")
+	gf.P()
+}
+
 // generateErrorResponseFunctions generates error response helper functions.
 func (g *Generator) generateErrorResponseFunctions(gf *protogen.GeneratedFile) {
 	g.generateResponseCaptureType(gf)
-	g.generateWriteProtoMessageResponseFunc(gf)
-	g.generateWriteValidationErrorResponseFunc(gf)
-	g.generateWriteValidationErrorFunc(gf)
-	g.generateWriteErrorResponseFunc(gf)
-	g.generateConvertProtovalidateErrorFunc(gf)
+	g.generateResponderFunc(gf)
+	if g.features.validatesMessages() {
+		g.generateWriteValidationErrorFunc(gf)
+		g.generateConvertProtovalidateErrorFunc(gf)
+	}
+	if g.features.messageValidation {
+		g.generateWithViolationPointersFunc(gf)
+	}
 	g.generateDefaultErrorResponseFunc(gf)
 	g.generateDefaultErrorStatusCodeFunc(gf)
 	g.generateWriteErrorWithHandlerFunc(gf)
-	g.generateWriteResponseBodyFunc(gf)
 }
 
-// generateWriteProtoMessageResponseFunc generates a helper function for writing protobuf messages as responses.
-func (g *Generator) generateWriteProtoMessageResponseFunc(gf *protogen.GeneratedFile) {
-	gf.P("// writeProtoMessageResponse writes a protobuf message as an HTTP response")
-	gf.P(
-		"func writeProtoMessageResponse(w http.ResponseWriter, r *http.Request, msg proto.Message, statusCode int, fallbackMsg string, marshalOpts protojson.MarshalOptions) {",
-	)
-	gf.P("respContentType := resolveResponseContentType(r)")
+// generateResponderFunc generates the helper returning the sebufhttp.Responder
+// that writes error responses in the negotiated content type.
+func (g *Generator) generateResponderFunc(gf *protogen.GeneratedFile) {
+	gf.P("// responder returns the sebufhttp.Responder writing messages with marshalOpts, in")
+	gf.P("// the content type of resolveResponseContentType.")
+	gf.P("func responder(marshalOpts protojson.MarshalOptions) sebufhttp.Responder {")
+	gf.P("return sebufhttp.Responder{MarshalOptions: marshalOpts, Negotiate: resolveResponseContentType}")
+	gf.P("}")
 	gf.P()
-	gf.P("var responseBytes []byte")
-	gf.P("var err error")
+}
+
+// generateUnimplementedServer generates the Unimplemented<Service>Server struct.
+// Embedding it keeps an implementation compiling when methods are added to the
+// service; methods it does not override respond with HTTP 501.
+// generateServerSwap generates Update<Service>Server and Unregister<Service>Server,
+// and the dispatcher the handlers registered by Register<Service>Server call
+// the current implementation through.
+func (g *Generator) generateServerSwap(gf *protogen.GeneratedFile, service *protogen.Service) {
+	serviceName := service.GoName
+	slotsName := "registered" + serviceName + "Servers"
+	dispatcherName := "dispatching" + serviceName + "Server"
+
+	gf.P("// ", slotsName, " holds the implementation of every ", serviceName, " registration.")
+	gf.P("var ", slotsName, " sebufhttp.ServerSlots[", serviceName, "Server]")
 	gf.P()
-	gf.P("switch respContentType {")
-	gf.P("case BinaryContentType, ProtoContentType:")
-	gf.P("responseBytes, err = proto.Marshal(msg)")
-	gf.P("default:")
-	gf.P("responseBytes, err = marshalJSONWithOpts(msg, marshalOpts)")
+
+	gf.P("// Update", serviceName, "Server makes every handler registered by ", g.registerFuncName(service))
+	gf.P("// call server from now on. Requests already being handled finish on the")
+	gf.P("// implementation they started with.")
+	gf.P("func Update", serviceName, "Server(server ", serviceName, "Server) {")
+	gf.P(slotsName, ".Store(server)")
 	gf.P("}")
 	gf.P()
-	gf.P("if err != nil {")
-	gf.P("// Fallback to plain text error if marshaling fails")
-	gf.P("http.Error(w, fallbackMsg, statusCode)")
-	gf.P("return")
+
+	gf.P("// Unregister", serviceName, "Server detaches the implementation from every handler")
+	gf.P("// registered by ", g.registerFuncName(service), ". The routes stay on their mux and answer")
+	gf.P("// HTTP 503 until Update", serviceName, "Server installs a new implementation.")
+	gf.P("func Unregister", serviceName, "Server() {")
+	gf.P(slotsName, ".Clear()")
 	gf.P("}")
 	gf.P()
-	gf.P(`w.Header().Set("Content-Type", respContentType)`)
-	gf.P("w.WriteHeader(statusCode)")
-	gf.P("_, _ = w.Write(responseBytes)")
+
+	gf.P("// ", dispatcherName, " forwards each call to the implementation installed in its slot.")
+	gf.P("type ", dispatcherName, " struct {")
+	gf.P("slot *sebufhttp.ServerSlot[", serviceName, "Server]")
 	gf.P("}")
 	gf.P()
+
+	unavailable := `&sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service ` +
+		serviceName + ` is not registered"}`
+	for _, method := range service.Methods {
+		if g.isSSEMethod(method) {
+			gf.P("func (d ", dispatcherName, ") ", method.GoName,
+				"(ctx context.Context, req *", method.Input.GoIdent, ", sender SSESender) error {")
+			gf.P("server, ok := d.slot.Load()")
+			gf.P("if !ok {")
+			gf.P("return ", unavailable)
+			gf.P("}")
+			gf.P("return server.", method.GoName, "(ctx, req, sender)")
+		} else if annotations.IsStreamResponse(method) {
+			gf.P("func (d ", dispatcherName, ") ", method.GoName,
+				"(ctx context.Context, req *", method.Input.GoIdent, ", yield func(*",
+				g.streamResponseItem(method).GoIdent, ") error) error {")
+			gf.P("server, ok := d.slot.Load()")
+			gf.P("if !ok {")
+			gf.P("return ", unavailable)
+			gf.P("}")
+			gf.P("return server.", method.GoName, "(ctx, req, yield)")
+		} else {
+			gf.P("func (d ", dispatcherName, ") ", method.GoName,
+				"(ctx context.Context, req *", method.Input.GoIdent, ") (*", method.Output.GoIdent, ", error) {")
+			gf.P("server, ok := d.slot.Load()")
+			gf.P("if !ok {")
+			gf.P("return nil, ", unavailable)
+			gf.P("}")
+			gf.P("return server.", method.GoName, "(ctx, req)")
+		}
+		gf.P("}")
+		gf.P()
+	}
 }
 
-// generateWriteValidationErrorResponseFunc generates the writeValidationErrorResponse function.
-func (g *Generator) generateWriteValidationErrorResponseFunc(gf *protogen.GeneratedFile) {
-	gf.P("// writeValidationErrorResponse writes a ValidationError as a response")
-	gf.P(
-		"func writeValidationErrorResponse(w http.ResponseWriter, r *http.Request, validationErr *sebufhttp.ValidationError, marshalOpts protojson.MarshalOptions) {",
-	)
-	gf.P(`writeProtoMessageResponse(w, r, validationErr, http.StatusBadRequest, "validation failed", marshalOpts)`)
-	gf.P("}")
+func (g *Generator) generateUnimplementedServer(gf *protogen.GeneratedFile, service *protogen.Service) {
+	structName := "Unimplemented" + service.GoName + "Server"
+
+	gf.P("// ", structName, " can be embedded in ", service.GoName, "Server implementations for")
+	gf.P("// forward compatibility. Methods that are not overridden return a *sebufhttp.Error")
+	gf.P("// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.")
+	gf.P("type ", structName, " struct{}")
 	gf.P()
+	for _, method := range service.Methods {
+		unimplemented := `&sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method ` +
+			method.GoName + ` not implemented"}`
+		if g.isSSEMethod(method) {
+			gf.P("func (", structName, ") ", method.GoName,
+				"(context.Context, *", method.Input.GoIdent, ", SSESender) error {")
+			gf.P("return ", unimplemented)
+		} else if annotations.IsStreamResponse(method) {
+			gf.P("func (", structName, ") ", method.GoName,
+				"(context.Context, *", method.Input.GoIdent, ", func(*",
+				g.streamResponseItem(method).GoIdent, ") error) error {")
+			gf.P("return ", unimplemented)
+		} else {
+			gf.P("func (", structName, ") ", method.GoName,
+				"(context.Context, *", method.Input.GoIdent, ") (*", method.Output.GoIdent, ", error) {")
+			gf.P("return nil, ", unimplemented)
+		}
+		gf.P("}")
+		gf.P()
+	}
 }
 
 // generateWriteValidationErrorFunc generates the writeValidationError function for protovalidate errors.
 func (g *Generator) generateWriteValidationErrorFunc(gf *protogen.GeneratedFile) {
 	gf.P("// writeValidationError converts a protovalidate error to ValidationError and writes it as response")
 	gf.P(
-		"func writeValidationError(w http.ResponseWriter, r *http.Request, err error, marshalOpts protojson.MarshalOptions) {",
-	)
-	gf.P("validationErr := convertProtovalidateError(err)")
-	gf.P("writeValidationErrorResponse(w, r, validationErr, marshalOpts)")
-	gf.P("}")
-	gf.P()
-}
-
-// generateWriteErrorResponseFunc generates the writeErrorResponse function.
-func (g *Generator) generateWriteErrorResponseFunc(gf *protogen.GeneratedFile) {
-	gf.P("// writeErrorResponse writes an Error as a response")
-	gf.P(
-		"func writeErrorResponse(w http.ResponseWriter, r *http.Request, errorMsg *sebufhttp.Error, marshalOpts protojson.MarshalOptions) {",
-	)
-	gf.P(
-		`writeProtoMessageResponse(w, r, errorMsg, http.StatusInternalServerError, "internal server error", marshalOpts)`,
+		"func writeValidationError(w http.ResponseWriter, r *http.Request, err error, formatter sebufhttp.ViolationFormatter, marshalOpts protojson.MarshalOptions) {",
 	)
+	gf.P("responder(marshalOpts).WriteValidationError(w, r, convertProtovalidateError(err, formatter))")
 	gf.P("}")
 	gf.P()
 }
 
 // generateResponseCaptureType generates the responseCapture type for tracking handler writes.
 func (g *Generator) generateResponseCaptureType(gf *protogen.GeneratedFile) {
-	gf.P("// responseCapture wraps ResponseWriter to track if Write or WriteHeader was called")
+	gf.P("// responseCapture wraps ResponseWriter to track if Write or WriteHeader was called.")
+	gf.P("// It sends the status of a response once: WriteHeader calls after the first, or")
+	gf.P("// after the first Write, are dropped instead of reaching the ResponseWriter.")
 	gf.P("type responseCapture struct {")
 	gf.P("http.ResponseWriter")
 	gf.P("wroteHeader bool")
 	gf.P("written bool")
+	gf.P("statusCode  int")
+	gf.P("}")
+	gf.P()
+	gf.P("// guardResponse returns w as a responseCapture, wrapping it unless it is one.")
+	gf.P("func guardResponse(w http.ResponseWriter) *responseCapture {")
+	gf.P("if capture, ok := w.(*responseCapture); ok {")
+	gf.P("return capture")
+	gf.P("}")
+	gf.P("return &responseCapture{ResponseWriter: w}")
 	gf.P("}")
 	gf.P()
 	gf.P("func (rc *responseCapture) WriteHeader(code int) {")
+	gf.P("if rc.wroteHeader {")
+	gf.P("return // The status is on the wire; a second one would only be logged as superfluous")
+	gf.P("}")
+	gf.P("if code >= 100 && code < 200 && code != http.StatusSwitchingProtocols {")
+	gf.P("rc.ResponseWriter.WriteHeader(code) // Informational, the final status follows")
+	gf.P("return")
+	gf.P("}")
 	gf.P("rc.wroteHeader = true")
+	gf.P("rc.statusCode = code")
 	gf.P("rc.ResponseWriter.WriteHeader(code)")
 	gf.P("}")
 	gf.P()
 	gf.P("func (rc *responseCapture) Write(b []byte) (int, error) {")
+	gf.P("if !rc.wroteHeader {")
+	gf.P("rc.wroteHeader = true")
+	gf.P("rc.statusCode = http.StatusOK")
+	gf.P("}")
 	gf.P("rc.written = true")
 	gf.P("return rc.ResponseWriter.Write(b)")
 	gf.P("}")
 	gf.P()
+	gf.P("// Written reports whether the status or part of the body of the response was")
+	gf.P("// sent, after which no other response can be written.")
+	gf.P("func (rc *responseCapture) Written() bool {")
+	gf.P("return rc.wroteHeader || rc.written")
+	gf.P("}")
+	gf.P()
+	gf.P("// Unwrap returns the wrapped ResponseWriter, for http.ResponseController.")
+	gf.P("func (rc *responseCapture) Unwrap() http.ResponseWriter {")
+	gf.P("return rc.ResponseWriter")
+	gf.P("}")
+	gf.P()
 }
 
 // generateConvertProtovalidateErrorFunc generates a function to convert protovalidate errors.
 func (g *Generator) generateConvertProtovalidateErrorFunc(gf *protogen.GeneratedFile) {
-	gf.P("// convertProtovalidateError converts a protovalidate error to ValidationError")
-	gf.P("func convertProtovalidateError(err error) *sebufhttp.ValidationError {")
+	gf.P("// convertProtovalidateError converts a protovalidate error to ValidationError,")
+	gf.P("// describing each violation with formatter")
+	gf.P(
+		"func convertProtovalidateError(err error, formatter sebufhttp.ViolationFormatter) *sebufhttp.ValidationError {",
+	)
 	gf.P("validationErr := &sebufhttp.ValidationError{}")
 	gf.P()
 	gf.P("// Handle protovalidate.ValidationError")
@@ -1164,7 +2553,12 @@ func (g *Generator) generateConvertProtovalidateErrorFunc(gf *protogen.Generated
 	g.generateFieldPathExtraction(gf)
 	gf.P("validationErr.Violations = append(validationErr.Violations, &sebufhttp.FieldViolation{")
 	gf.P("Field: fieldPath,")
-	gf.P("Description: violation.Proto.GetMessage(),")
+	gf.P("Description: formatter.Format(sebufhttp.Violation{")
+	gf.P("Field:        fieldPath,")
+	gf.P("ConstraintID: violation.Proto.GetRuleId(),")
+	gf.P("Params:       sebufhttp.RuleParams(violation.RuleDescriptor, violation.RuleValue),")
+	gf.P("Message:      violation.Proto.GetMessage(),")
+	gf.P("}),")
 	gf.P("})")
 	gf.P("}")
 	gf.P("} else {")
@@ -1180,6 +2574,25 @@ func (g *Generator) generateConvertProtovalidateErrorFunc(gf *protogen.Generated
 	gf.P()
 }
 
+// generateWithViolationPointersFunc generates withViolationPointers, which
+// records the JSON Pointers of the violations of a request body for Problem
+// Details.
+func (g *Generator) generateWithViolationPointersFunc(gf *protogen.GeneratedFile) {
+	gf.P("// withViolationPointers returns r recording the JSON Pointer into msg of each")
+	gf.P("// violation of validationErr, converted from valErr by convertProtovalidateError")
+	gf.P("func withViolationPointers(")
+	gf.P("r *http.Request, msg proto.Message, valErr *protovalidate.ValidationError, validationErr *sebufhttp.ValidationError,")
+	gf.P(") *http.Request {")
+	gf.P("md := msg.ProtoReflect().Descriptor()")
+	gf.P("pointers := make(map[*sebufhttp.FieldViolation]string, len(valErr.Violations))")
+	gf.P("for i, violation := range valErr.Violations {")
+	gf.P("pointers[validationErr.Violations[i]] = sebufhttp.ViolationPointer(md, violation.Proto.GetField())")
+	gf.P("}")
+	gf.P("return sebufhttp.WithViolationPointers(r, pointers)")
+	gf.P("}")
+	gf.P()
+}
+
 // generateDefaultErrorResponseFunc generates the defaultErrorResponse helper function.
 func (g *Generator) generateDefaultErrorResponseFunc(gf *protogen.GeneratedFile) {
 	gf.P("// defaultErrorResponse returns the appropriate error response message based on error type")
@@ -1209,6 +2622,35 @@ func (g *Generator) generateDefaultErrorStatusCodeFunc(gf *protogen.GeneratedFil
 	gf.P("if errors.As(err, &valErr) {")
 	gf.P("return http.StatusBadRequest")
 	gf.P("}")
+	gf.P("var handlerErr *sebufhttp.Error")
+	gf.P("if errors.As(err, &handlerErr) {")
+	gf.P("switch handlerErr.GetCode() {")
+	gf.P("case sebufhttp.ErrorCodeUnimplemented:")
+	gf.P("return http.StatusNotImplemented")
+	gf.P("case sebufhttp.ErrorCodeIdempotencyConflict, sebufhttp.ErrorCodeIdempotencyInProgress:")
+	gf.P("return http.StatusConflict")
+	gf.P("case sebufhttp.ErrorCodeUnavailable:")
+	gf.P("return http.StatusServiceUnavailable")
+	gf.P("case sebufhttp.ErrorCodeDeadlineExceeded:")
+	gf.P("return http.StatusGatewayTimeout")
+	gf.P("case sebufhttp.ErrorCodePayloadTooLarge:")
+	gf.P("return http.StatusRequestEntityTooLarge")
+	gf.P("case sebufhttp.ErrorCodeUnsupportedMediaType:")
+	gf.P("return http.StatusUnsupportedMediaType")
+	gf.P("case sebufhttp.ErrorCodeNotFound:")
+	gf.P("return http.StatusNotFound")
+	gf.P("case sebufhttp.ErrorCodeAlreadyExists:")
+	gf.P("return http.StatusConflict")
+	gf.P("case sebufhttp.ErrorCodeSchemaMismatch:")
+	gf.P("return http.StatusPreconditionFailed")
+	gf.P("case sebufhttp.ErrorCodePermissionDenied:")
+	gf.P("return http.StatusForbidden")
+	gf.P("case sebufhttp.ErrorCodeUnauthenticated:")
+	gf.P("return http.StatusUnauthorized")
+	gf.P("case sebufhttp.ErrorCodeInvalidArgument:")
+	gf.P("return http.StatusBadRequest")
+	gf.P("}")
+	gf.P("}")
 	gf.P("return http.StatusInternalServerError")
 	gf.P("}")
 	gf.P()
@@ -1216,20 +2658,18 @@ func (g *Generator) generateDefaultErrorStatusCodeFunc(gf *protogen.GeneratedFil
 
 // generateWriteErrorWithHandlerFunc generates the writeErrorWithHandler function.
 func (g *Generator) generateWriteErrorWithHandlerFunc(gf *protogen.GeneratedFile) {
-	gf.P("// writeErrorWithHandler calls custom handler if set, then marshals response")
+	if g.grpcGateway() {
+		g.generateGatewayWriteErrorWithHandlerFunc(gf)
+		g.generateGuardErrorHandlerFunc(gf)
+		g.generateProblemErrorHandlerFunc(gf)
+		return
+	}
+	gf.P("// writeErrorWithHandler calls custom handler if set, then marshals response.")
+	gf.P("// Nothing is written once the body of the response has started.")
 	gf.P(
 		"func writeErrorWithHandler(w http.ResponseWriter, r *http.Request, err error, handler ErrorHandler, marshalOpts protojson.MarshalOptions) {",
 	)
-	gf.P("var response proto.Message")
-	gf.P("var capture *responseCapture")
-	gf.P()
-	gf.P("if handler != nil {")
-	gf.P("capture = &responseCapture{ResponseWriter: w}")
-	gf.P("response = handler(capture, r, err)")
-	gf.P("if capture.written {")
-	gf.P("return // Handler wrote directly, done")
-	gf.P("}")
-	gf.P("}")
+	g.generateErrorCaptureSetup(gf)
 	gf.P()
 	gf.P("// Determine response if handler didn't provide one")
 	gf.P("if response == nil {")
@@ -1240,41 +2680,59 @@ func (g *Generator) generateWriteErrorWithHandlerFunc(gf *protogen.GeneratedFile
 	gf.P("statusCode := defaultErrorStatusCode(err)")
 	gf.P()
 	gf.P("// If handler already set status, don't set it again")
-	gf.P("if capture != nil && capture.wroteHeader {")
+	gf.P("if capture.wroteHeader {")
 	gf.P("// Handler set status, just write the body")
-	gf.P("writeResponseBody(w, r, response, marshalOpts)")
+	gf.P("responder(marshalOpts).WriteBody(w, r, response)")
 	gf.P("return")
 	gf.P("}")
 	gf.P()
 	gf.P("// Write full response with status code")
-	gf.P(`writeProtoMessageResponse(w, r, response, statusCode, "error processing request", marshalOpts)`)
+	gf.P(`responder(marshalOpts).WriteMessage(w, r, response, statusCode, "error processing request")`)
 	gf.P("}")
 	gf.P()
+	g.generateGuardErrorHandlerFunc(gf)
+	g.generateProblemErrorHandlerFunc(gf)
 }
 
-func (g *Generator) generateWriteResponseBodyFunc(gf *protogen.GeneratedFile) {
-	gf.P("// writeResponseBody writes the response body without setting status code")
-	gf.P(
-		"func writeResponseBody(w http.ResponseWriter, r *http.Request, msg proto.Message, marshalOpts protojson.MarshalOptions) {",
-	)
-	gf.P("respContentType := resolveResponseContentType(r)")
-	gf.P()
-	gf.P("var responseBytes []byte")
-	gf.P("var err error")
-	gf.P()
-	gf.P("switch respContentType {")
-	gf.P("case BinaryContentType, ProtoContentType:")
-	gf.P("responseBytes, err = proto.Marshal(msg)")
-	gf.P("default:")
-	gf.P("responseBytes, err = marshalJSONWithOpts(msg, marshalOpts)")
+// generateErrorCaptureSetup generates the start of writeErrorWithHandler: w is
+// guarded, left alone when its body started, and passed to the error handler.
+func (g *Generator) generateErrorCaptureSetup(gf *protogen.GeneratedFile) {
+	gf.P("capture, guarded := w.(*responseCapture)")
+	gf.P("if !guarded {")
+	gf.P("capture = &responseCapture{ResponseWriter: w}")
 	gf.P("}")
-	gf.P()
-	gf.P("if err != nil {")
-	gf.P("return // Can't write anything meaningful")
+	gf.P("if capture.written {")
+	gf.P("return // The body started (e.g. a failed write of a successful response); a second would corrupt it")
 	gf.P("}")
 	gf.P()
-	gf.P(`w.Header().Set("Content-Type", respContentType)`)
-	gf.P("_, _ = w.Write(responseBytes)")
+	gf.P("var response proto.Message")
+	gf.P("if handler != nil {")
+	gf.P("response = handler(capture, r, err)")
+	gf.P("if capture.written {")
+	gf.P("return // Handler wrote directly, done")
+	gf.P("}")
+	gf.P("}")
+}
+
+// generateGuardErrorHandlerFunc generates guardErrorHandler, which getConfiguration
+// wraps the custom error handler with.
+func (g *Generator) generateGuardErrorHandlerFunc(gf *protogen.GeneratedFile) {
+	gf.P("// guardErrorHandler wraps handler to drop the message it returns after writing")
+	gf.P("// the body itself, warning logger (slog.Default() when nil): the response is")
+	gf.P("// complete, and marshaling the message too would corrupt it.")
+	gf.P("func guardErrorHandler(handler ErrorHandler, logger *slog.Logger) ErrorHandler {")
+	gf.P("return func(w http.ResponseWriter, r *http.Request, err error) proto.Message {")
+	gf.P("response := handler(w, r, err)")
+	gf.P("if capture, ok := w.(*responseCapture); ok && capture.written && response != nil {")
+	gf.P("if logger == nil {")
+	gf.P("logger = slog.Default()")
+	gf.P("}")
+	gf.P(`logger.Warn("sebufhttp: error handler wrote the response and returned a message; the message is dropped",`)
+	gf.P(`slog.String("method", r.Method), slog.String("path", r.URL.Path))`)
+	gf.P("return nil")
+	gf.P("}")
+	gf.P("return response")
+	gf.P("}")
 	gf.P("}")
 	gf.P()
 }
@@ -1300,45 +2758,121 @@ func (g *Generator) generateFieldPathExtraction(gf *protogen.GeneratedFile) {
 
 // generateValidationFunctions generates the validation support code.
 func (g *Generator) generateValidationFunctions(gf *protogen.GeneratedFile) {
-	// Global validator instance
-	gf.P("var (")
-	gf.P("// Global validator instance - created once and reused")
-	gf.P("validatorOnce sync.Once")
+	gf.P("// validators caches the protovalidate.Validator of each message type, keyed by")
+	gf.P("// its full name, as built by getValidator")
+	gf.P("var validators sync.Map")
+	gf.P()
+	gf.P("// cachedValidator is the validator of a message type, or why it failed to build.")
+	gf.P("type cachedValidator struct {")
 	gf.P("validator protovalidate.Validator")
-	gf.P("validatorErr error")
-	gf.P(")")
+	gf.P("err       error")
+	gf.P("}")
 	gf.P()
 
-	// getValidator function
-	gf.P("// getValidator returns a cached validator instance")
-	gf.P("func getValidator() (protovalidate.Validator, error) {")
-	gf.P("validatorOnce.Do(func() {")
-	gf.P("validator, validatorErr = protovalidate.New()")
-	gf.P("})")
-	gf.P("return validator, validatorErr")
+	gf.P("// getValidator returns the validator of the type of msg, building it the first")
+	gf.P("// time the type is seen: Register*Server warms up those of its methods, so no")
+	gf.P("// request pays for compiling their constraints. A failed build is cached too.")
+	gf.P("func getValidator(msg proto.Message) (protovalidate.Validator, error) {")
+	gf.P("name := msg.ProtoReflect().Descriptor().FullName()")
+	gf.P("cached, ok := validators.Load(name)")
+	gf.P("if !ok {")
+	gf.P("v, err := newValidator(msg)")
+	gf.P("cached, _ = validators.LoadOrStore(name, &cachedValidator{validator: v, err: err})")
+	gf.P("}")
+	gf.P("c := cached.(*cachedValidator)")
+	gf.P("return c.validator, c.err")
 	gf.P("}")
 	gf.P()
 
-	// ValidateMessage function
-	gf.P("// ValidateMessage validates a protobuf message using protovalidate")
-	gf.P("func ValidateMessage(msg proto.Message) error {")
-	gf.P("// Get cached validator")
-	gf.P("v, err := getValidator()")
+	gf.P("// newValidator builds the validator of the type of msg and compiles its")
+	gf.P("// constraints by validating an empty message, on which violations are expected")
+	gf.P("// but a constraint that does not compile is an error.")
+	gf.P("func newValidator(msg proto.Message) (protovalidate.Validator, error) {")
+	gf.P("name := msg.ProtoReflect().Descriptor().FullName()")
+	gf.P("v, err := protovalidate.New(protovalidate.WithMessages(msg))")
 	gf.P("if err != nil {")
-	gf.P("// If we can't create a validator, log and continue")
-	gf.P("// This allows the service to run even if validation setup fails")
+	gf.P(`return nil, fmt.Errorf("creating the validator of %s: %w", name, err)`)
+	gf.P("}")
+	gf.P("var compileErr *protovalidate.CompilationError")
+	gf.P("if err := v.Validate(msg.ProtoReflect().Type().Zero().Interface()); errors.As(err, &compileErr) {")
+	gf.P(`return nil, fmt.Errorf("compiling the validation rules of %s: %w", name, err)`)
+	gf.P("}")
+	gf.P("return v, nil")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// warmUpValidators builds the validators of msgs, returning the first error.")
+	gf.P("func warmUpValidators(msgs ...proto.Message) error {")
+	gf.P("for _, msg := range msgs {")
+	gf.P("if _, err := getValidator(msg); err != nil {")
+	gf.P("return err")
+	gf.P("}")
+	gf.P("}")
 	gf.P("return nil")
 	gf.P("}")
 	gf.P()
-	gf.P("// Validate the message and return any error")
+
+	gf.P("// ValidateMessage validates a protobuf message using protovalidate. It returns a")
+	gf.P("// *protovalidate.ValidationError listing the violations of an invalid message,")
+	gf.P("// and any other error when the message could not be validated.")
+	gf.P("func ValidateMessage(msg proto.Message) error {")
+	gf.P("v, err := getValidator(msg)")
+	gf.P("if err != nil {")
+	gf.P("return err")
+	gf.P("}")
 	gf.P("return v.Validate(msg)")
 	gf.P("}")
 	gf.P()
 }
 
+// generateValidatorWarmUp generates the warm-up of the validators of the request
+// and response messages of service, so a constraint that does not compile fails
+// its registration instead of its first request.
+func (g *Generator) generateValidatorWarmUp(gf *protogen.GeneratedFile, service *protogen.Service) {
+	seen := make(map[protoreflect.FullName]bool)
+	gf.P("if err := warmUpValidators(")
+	for _, method := range service.Methods {
+		for _, msg := range []*protogen.Message{method.Input, method.Output} {
+			if !seen[msg.Desc.FullName()] {
+				seen[msg.Desc.FullName()] = true
+				gf.P("&", msg.GoIdent, "{},")
+			}
+		}
+	}
+	gf.P("); err != nil {")
+	gf.P("return err")
+	gf.P("}")
+}
+
+// generateValidateResponseFunc generates validateResponse, which validates the
+// responses of genericHandler with the cached validators of their types.
+func (g *Generator) generateValidateResponseFunc(gf *protogen.GeneratedFile) {
+	gf.P("// validateResponse validates response under mode, logging its violations to")
+	gf.P("// logger, and reports whether it may be sent.")
+	gf.P("func validateResponse(")
+	gf.P("r *http.Request, response any, mode sebufhttp.ResponseValidationMode, logger *slog.Logger,")
+	gf.P(") bool {")
+	gf.P("msg, ok := response.(proto.Message)")
+	gf.P("if mode == sebufhttp.ResponseValidationOff || !ok {")
+	gf.P("return true")
+	gf.P("}")
+	gf.P("err := ValidateMessage(msg)")
+	gf.P("if err == nil {")
+	gf.P("return true")
+	gf.P("}")
+	gf.P("var valErr *protovalidate.ValidationError")
+	gf.P("if errors.As(err, &valErr) {")
+	gf.P("err = convertProtovalidateError(err, nil)")
+	gf.P("}")
+	gf.P("return sebufhttp.ApplyResponseValidation(r, mode, err, logger)")
+	gf.P("}")
+	gf.P()
+}
+
 // generateHeaderValidationFunctions generates header validation support code.
 func (g *Generator) generateHeaderValidationFunctions(gf *protogen.GeneratedFile) {
 	g.generateValidateHeadersFunction(gf)
+	g.generateHeaderValuesFunction(gf)
 	g.generateValidateHeaderValueFunction(gf)
 	g.generateTypeValidators(gf)
 	g.generateFormatValidators(gf)
@@ -1346,11 +2880,13 @@ func (g *Generator) generateHeaderValidationFunctions(gf *protogen.GeneratedFile
 
 // generateValidateHeadersFunction generates the main header validation function.
 func (g *Generator) generateValidateHeadersFunction(gf *protogen.GeneratedFile) {
-	gf.P("// validateHeaders validates required headers for a service and method")
-	gf.P("// Returns a ValidationError if any required headers are missing or invalid")
-	gf.P(
-		"func validateHeaders(r *http.Request, serviceHeaders, methodHeaders []*sebufhttp.Header) *sebufhttp.ValidationError {",
-	)
+	gf.P("// validateHeaders validates required headers for a service and method, filling in")
+	gf.P("// the default_value of omitted headers. It returns the valid headers, and a")
+	gf.P("// ValidationError if any required headers are missing or invalid, whose")
+	gf.P("// violations formatter describes")
+	gf.P("func validateHeaders(")
+	gf.P("r *http.Request, serviceHeaders, methodHeaders []*sebufhttp.Header, formatter sebufhttp.ViolationFormatter,")
+	gf.P(") (http.Header, *sebufhttp.ValidationError) {")
 	g.generateHeaderMergeLogic(gf)
 	g.generateHeaderValidationLoop(gf)
 	g.generateValidationErrorReturn(gf)
@@ -1365,14 +2901,14 @@ func (g *Generator) generateHeaderMergeLogic(gf *protogen.GeneratedFile) {
 	gf.P()
 	gf.P("// Add service headers first")
 	gf.P("for _, header := range serviceHeaders {")
-	gf.P("if header.GetRequired() {")
+	gf.P(`if header.GetRequired() || header.GetDefaultValue() != "" {`)
 	gf.P("allHeaders[strings.ToLower(header.GetName())] = header")
 	gf.P("}")
 	gf.P("}")
 	gf.P()
 	gf.P("// Add method headers (override service headers if same name)")
 	gf.P("for _, header := range methodHeaders {")
-	gf.P("if header.GetRequired() {")
+	gf.P(`if header.GetRequired() || header.GetDefaultValue() != "" {`)
 	gf.P("allHeaders[strings.ToLower(header.GetName())] = header")
 	gf.P("}")
 	gf.P("}")
@@ -1383,24 +2919,34 @@ func (g *Generator) generateHeaderMergeLogic(gf *protogen.GeneratedFile) {
 func (g *Generator) generateHeaderValidationLoop(gf *protogen.GeneratedFile) {
 	gf.P("// Collect all validation violations")
 	gf.P("var violations []*sebufhttp.FieldViolation")
+	gf.P("validated := make(http.Header, len(allHeaders))")
 	gf.P()
-	gf.P("// Validate each required header")
+	gf.P("// Validate each required header, treating an omitted header with a default as present")
 	gf.P("for _, headerSpec := range allHeaders {")
-	gf.P("value := r.Header.Get(headerSpec.GetName())")
-	gf.P("if value == \"\" {")
+	gf.P("values := headerValues(r, headerSpec)")
+	gf.P("if len(values) == 0 {")
 	gf.P("violations = append(violations, &sebufhttp.FieldViolation{")
 	gf.P("Field: headerSpec.GetName(),")
-	gf.P(`Description: fmt.Sprintf("required header '%s' is missing", headerSpec.GetName()),`)
+	gf.P("Description: formatter.Format(sebufhttp.HeaderViolation(headerSpec, nil)),")
 	gf.P("})")
 	gf.P("continue")
 	gf.P("}")
 	gf.P()
-	gf.P("if err := validateHeaderValue(headerSpec, value); err != nil {")
+	gf.P("// A multiple header is valid when each of its values is")
+	gf.P("var invalid error")
+	gf.P("for _, value := range values {")
+	gf.P("if invalid = validateHeaderValue(headerSpec, value); invalid != nil {")
+	gf.P("break")
+	gf.P("}")
+	gf.P("}")
+	gf.P("if invalid != nil {")
 	gf.P("violations = append(violations, &sebufhttp.FieldViolation{")
 	gf.P("Field: headerSpec.GetName(),")
-	gf.P(`Description: fmt.Sprintf("header '%s' validation failed: %v", headerSpec.GetName(), err),`)
+	gf.P("Description: formatter.Format(sebufhttp.HeaderViolation(headerSpec, invalid)),")
 	gf.P("})")
+	gf.P("continue")
 	gf.P("}")
+	gf.P("validated[http.CanonicalHeaderKey(headerSpec.GetName())] = values")
 	gf.P("}")
 	gf.P()
 }
@@ -1409,12 +2955,38 @@ func (g *Generator) generateHeaderValidationLoop(gf *protogen.GeneratedFile) {
 func (g *Generator) generateValidationErrorReturn(gf *protogen.GeneratedFile) {
 	gf.P("// Return ValidationError if there are violations")
 	gf.P("if len(violations) > 0 {")
-	gf.P("return &sebufhttp.ValidationError{")
+	gf.P("return validated, &sebufhttp.ValidationError{")
 	gf.P("Violations: violations,")
 	gf.P("}")
 	gf.P("}")
 	gf.P()
+	gf.P("return validated, nil")
+}
+
+// generateHeaderValuesFunction generates the function reading the values of a
+// declared header.
+func (g *Generator) generateHeaderValuesFunction(gf *protogen.GeneratedFile) {
+	gf.P("// headerValues returns the values r carries for a declared header, or its")
+	gf.P("// default_value when r omits it: each comma-separated value of a multiple")
+	gf.P("// header, the first value of any other")
+	gf.P("func headerValues(r *http.Request, headerSpec *sebufhttp.Header) []string {")
+	gf.P("if headerSpec.GetMultiple() {")
+	gf.P("values := sebufhttp.SplitHeaderValues(r.Header.Values(headerSpec.GetName()))")
+	gf.P("if len(values) == 0 {")
+	gf.P("values = sebufhttp.SplitHeaderValues([]string{headerSpec.GetDefaultValue()})")
+	gf.P("}")
+	gf.P("return values")
+	gf.P("}")
+	gf.P("value := r.Header.Get(headerSpec.GetName())")
+	gf.P(`if value == "" {`)
+	gf.P("value = headerSpec.GetDefaultValue()")
+	gf.P("}")
+	gf.P(`if value == "" {`)
 	gf.P("return nil")
+	gf.P("}")
+	gf.P("return []string{value}")
+	gf.P("}")
+	gf.P()
 }
 
 // generateValidateHeaderValueFunction generates the header value validation function.
@@ -1459,20 +3031,23 @@ func (g *Generator) generateStringValidator(gf *protogen.GeneratedFile) {
 	gf.P(`return fmt.Errorf("value is not valid UTF-8")`)
 	gf.P("}")
 	gf.P()
-	gf.P("// Apply format-specific validation")
-	gf.P("switch format {")
-	gf.P("case \"uuid\":")
-	gf.P("return validateUUIDFormat(value)")
-	gf.P("case \"email\":")
-	gf.P("return validateEmailFormat(value)")
-	gf.P("case \"date-time\":")
-	gf.P("return validateDateTimeFormat(value)")
-	gf.P("case \"date\":")
-	gf.P("return validateDateFormat(value)")
-	gf.P("case \"time\":")
-	gf.P("return validateTimeFormat(value)")
-	gf.P("}")
-	gf.P()
+	// Only formats referenced by declared headers get a case and a validator
+	var cases []string
+	for _, format := range []string{"uuid", "email", "date-time", "date", "time"} {
+		if g.features.headerFormats[format] {
+			cases = append(cases, format)
+		}
+	}
+	if len(cases) > 0 {
+		gf.P("// Apply format-specific validation")
+		gf.P("switch format {")
+		for _, format := range cases {
+			gf.P("case ", strconv.Quote(format), ":")
+			gf.P("return ", formatValidatorName(format), "(value)")
+		}
+		gf.P("}")
+		gf.P()
+	}
 	gf.P("return nil")
 	gf.P("}")
 	gf.P()
@@ -1530,13 +3105,34 @@ func (g *Generator) generateArrayValidator(gf *protogen.GeneratedFile) {
 	gf.P()
 }
 
-// generateFormatValidators generates format-specific validation functions.
+// generateFormatValidators generates the format-specific validation functions
+// referenced by declared header formats.
 func (g *Generator) generateFormatValidators(gf *protogen.GeneratedFile) {
-	g.generateUUIDValidator(gf)
-	g.generateEmailValidator(gf)
+	if g.features.headerFormats["uuid"] {
+		g.generateUUIDValidator(gf)
+	}
+	if g.features.headerFormats["email"] {
+		g.generateEmailValidator(gf)
+	}
 	g.generateDateTimeValidators(gf)
 }
 
+// formatValidatorName returns the generated validator of a header format.
+func formatValidatorName(format string) string {
+	switch format {
+	case "uuid":
+		return "validateUUIDFormat"
+	case "email":
+		return "validateEmailFormat"
+	case "date-time":
+		return "validateDateTimeFormat"
+	case "date":
+		return "validateDateFormat"
+	default:
+		return "validateTimeFormat"
+	}
+}
+
 // generateUUIDValidator generates UUID format validation function.
 func (g *Generator) generateUUIDValidator(gf *protogen.GeneratedFile) {
 	gf.P("// validateUUIDFormat validates UUID format (basic check)")
@@ -1575,10 +3171,22 @@ func (g *Generator) generateEmailValidator(gf *protogen.GeneratedFile) {
 	gf.P()
 }
 
-// generateDateTimeValidators generates date/time format validation functions.
-//
-//nolint:dupl // Code generation patterns naturally have similar structure
+// generateDateTimeValidators generates the date/time format validation functions
+// referenced by declared header formats.
 func (g *Generator) generateDateTimeValidators(gf *protogen.GeneratedFile) {
+	if g.features.headerFormats["date-time"] {
+		g.generateDateTimeValidator(gf)
+	}
+	if g.features.headerFormats["date"] {
+		g.generateDateValidator(gf)
+	}
+	if g.features.headerFormats["time"] {
+		g.generateTimeValidator(gf)
+	}
+}
+
+// generateDateTimeValidator generates the RFC3339 date-time validation function.
+func (g *Generator) generateDateTimeValidator(gf *protogen.GeneratedFile) {
 	gf.P("// validateDateTimeFormat validates RFC3339 date-time format")
 	gf.P("func validateDateTimeFormat(value string) error {")
 	gf.P("_, err := time.Parse(time.RFC3339, value)")
@@ -1588,7 +3196,12 @@ func (g *Generator) generateDateTimeValidators(gf *protogen.GeneratedFile) {
 	gf.P("return nil")
 	gf.P("}")
 	gf.P()
+}
 
+// generateDateValidator generates the date (YYYY-MM-DD) validation function.
+//
+//nolint:dupl // Code generation patterns naturally have similar structure
+func (g *Generator) generateDateValidator(gf *protogen.GeneratedFile) {
 	gf.P("// validateDateFormat validates date format (YYYY-MM-DD)")
 	gf.P("func validateDateFormat(value string) error {")
 	gf.P("_, err := time.Parse(\"2006-01-02\", value)")
@@ -1598,7 +3211,12 @@ func (g *Generator) generateDateTimeValidators(gf *protogen.GeneratedFile) {
 	gf.P("return nil")
 	gf.P("}")
 	gf.P()
+}
 
+// generateTimeValidator generates the time (HH:MM:SS) validation function.
+//
+//nolint:dupl // Code generation patterns naturally have similar structure
+func (g *Generator) generateTimeValidator(gf *protogen.GeneratedFile) {
 	gf.P("// validateTimeFormat validates time format (HH:MM:SS)")
 	gf.P("func validateTimeFormat(value string) error {")
 	gf.P("_, err := time.Parse(\"15:04:05\", value)")
@@ -1657,17 +3275,40 @@ func (g *Generator) generateHeaderGetters(gf *protogen.GeneratedFile, service *p
 // generateHeaderLiteral generates a header literal in Go code.
 func (g *Generator) generateHeaderLiteral(gf *protogen.GeneratedFile, header *http.Header) {
 	gf.P("{")
-	gf.P(`Name: "`, header.GetName(), `",`)
+	gf.P(`Name: "`, annotations.CanonicalHeaderName(header.GetName()), `",`)
 	gf.P(`Description: "`, header.GetDescription(), `",`)
 	gf.P(`Type: "`, header.GetType(), `",`)
 	gf.P(`Required: `, strconv.FormatBool(header.GetRequired()), `,`)
 	gf.P(`Format: "`, header.GetFormat(), `",`)
 	gf.P(`Example: "`, header.GetExample(), `",`)
 	gf.P(`Deprecated: `, strconv.FormatBool(header.GetDeprecated()), `,`)
+	if header.GetDefaultValue() != "" {
+		gf.P(`DefaultValue: `, strconv.Quote(header.GetDefaultValue()), `,`)
+	}
+	if header.GetMultiple() {
+		gf.P(`Multiple: true,`)
+	}
 	gf.P("},")
 }
 
-// generateParamConfigs generates path and query parameter configurations for each method.
+// queryParamSettings returns the Exclusive and MaxJSONBytes settings of a query
+// parameter config literal, set only for fields declared with source QUERY and
+// parameters encoded as JSON_BASE64URL respectively.
+func queryParamSettings(qp annotations.QueryParam) string {
+	var settings string
+	if annotations.GetFieldSource(qp.Field) == http.FieldSource_FIELD_SOURCE_QUERY {
+		settings += ", Exclusive: true"
+	}
+	if qp.JSON {
+		settings += ", MaxJSONBytes: " + strconv.Itoa(qp.MaxJSONBytes)
+	}
+	if len(qp.Aliases) > 0 {
+		settings += ", Aliases: " + queryAliasesLiteral(qp)
+	}
+	return settings
+}
+
+// generateParamConfigs generates path, query, and header-sourced field configurations for each method.
 func (g *Generator) generateParamConfigs(gf *protogen.GeneratedFile, service *protogen.Service) error {
 	for _, method := range service.Methods {
 		methodName := annotations.LowerFirst(method.GoName)
@@ -1694,16 +3335,82 @@ func (g *Generator) generateParamConfigs(gf *protogen.GeneratedFile, service *pr
 				qp.FieldName,
 				"\", Required: ",
 				strconv.FormatBool(qp.Required),
+				queryParamSettings(qp),
 				"},",
 			)
 		}
 		gf.P("}")
 		gf.P()
+
+		// Generate header-sourced field config
+		gf.P("// ", methodName, "HeaderFieldParams contains header-sourced field configuration for ", method.GoName)
+		gf.P("var ", methodName, "HeaderFieldParams = []HeaderParamConfig{")
+		for _, hp := range annotations.GetHeaderFieldParams(method.Input) {
+			gf.P("{HeaderName: \"", hp.HeaderName, "\", FieldName: \"", hp.FieldName, "\"},")
+		}
+		gf.P("}")
+		gf.P()
 	}
 
 	return nil
 }
 
+// generateHeaderValidationCall generates header validation subject to the validation
+// policy, storing the validated headers in the request context. It expects r, w,
+// serviceHeaders, methodHeaders, validationPolicy, violationFormatter, logger,
+// errorHandler and marshalOpts in scope.
+func (g *Generator) generateHeaderValidationCall(gf *protogen.GeneratedFile) {
+	gf.P("headers, validationErr := validateHeaders(r, serviceHeaders, methodHeaders, violationFormatter)")
+	gf.P("r = r.WithContext(sebufhttp.ContextWithHeaders(r.Context(), headers))")
+	gf.P("if validationErr != nil {")
+	gf.P("var proceed bool")
+	gf.P("mode := validationPolicy.Mode(r, nil)")
+	gf.P("if r, proceed = sebufhttp.ApplyValidationMode(r, mode, validationErr, logger); !proceed {")
+	gf.P("writeErrorWithHandler(w, r, validationErr, errorHandler, marshalOpts)")
+	gf.P("return")
+	gf.P("}")
+	gf.P("}")
+}
+
+// generateHeaderAuthenticationCall generates the header authenticators, which run
+// whether or not the file declares headers so that WithHeaderAuthenticator always
+// applies. It expects r, w, serviceHeaders, methodHeaders, authenticators,
+// errorHandler and marshalOpts in scope.
+func (g *Generator) generateHeaderAuthenticationCall(gf *protogen.GeneratedFile) {
+	gf.P("authenticated, authErr := sebufhttp.AuthenticateHeaders(r, authenticators, serviceHeaders, methodHeaders)")
+	gf.P("if authErr != nil {")
+	gf.P("writeErrorWithHandler(w, r, authErr, errorHandler, marshalOpts)")
+	gf.P("return")
+	gf.P("}")
+	gf.P("r = authenticated")
+}
+
+// generateMessageValidationCall generates protovalidate validation of msg subject to the
+// validation policy, which is consulted after binding so it can inspect the message.
+// Messages that cannot be validated at all are subject to the validation failure mode.
+func (g *Generator) generateMessageValidationCall(gf *protogen.GeneratedFile) {
+	gf.P("if mode := validationPolicy.Mode(r, msg); mode != sebufhttp.ValidationSkip {")
+	gf.P("err := ValidateMessage(msg)")
+	gf.P("var valErr *protovalidate.ValidationError")
+	gf.P("switch {")
+	gf.P("case errors.As(err, &valErr):")
+	gf.P("validationErr := convertProtovalidateError(err, violationFormatter)")
+	gf.P("r = withViolationPointers(r, msg, valErr, validationErr)")
+	gf.P("var proceed bool")
+	gf.P("if r, proceed = sebufhttp.ApplyValidationMode(r, mode, validationErr, logger); !proceed {")
+	gf.P("writeErrorWithHandler(w, r, validationErr, errorHandler, marshalOpts)")
+	gf.P("return")
+	gf.P("}")
+	gf.P("case err != nil:")
+	gf.P("if !sebufhttp.ApplyValidationFailure(r, validationFailureMode, err, logger) {")
+	gf.P(`unavailable := &sebufhttp.Error{Message: "request validation is unavailable"}`)
+	gf.P("writeErrorWithHandler(w, r, unavailable, errorHandler, marshalOpts)")
+	gf.P("return")
+	gf.P("}")
+	gf.P("}")
+	gf.P("}")
+}
+
 // generateSSETypes generates SSE sender interface, implementation, and handler function.
 //
 //nolint:funlen // SSE support requires generating many types and functions together
@@ -1780,62 +3487,19 @@ func (g *Generator) generateSSETypes(gf *protogen.GeneratedFile) {
 	gf.P("serviceHeaders, methodHeaders []*sebufhttp.Header,")
 	gf.P("pathParams []PathParamConfig,")
 	gf.P("queryParams []QueryParamConfig,")
+	gf.P("headerParams []HeaderParamConfig,")
 	gf.P("httpMethod string,")
+	gf.P("body BodyConfig,")
 	gf.P("marshalOpts protojson.MarshalOptions,")
+	gf.P("validationPolicy sebufhttp.ValidationPolicy,")
+	gf.P("violationFormatter sebufhttp.ViolationFormatter,")
+	gf.P("validationFailureMode sebufhttp.ValidationFailureMode,")
+	gf.P("logger *slog.Logger,")
+	gf.P("authenticators []sebufhttp.HeaderAuthenticator,")
 	gf.P(") http.Handler {")
 	gf.P("return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {")
 
-	// Header validation
-	gf.P("// Validate headers")
-	gf.P("if validationErr := validateHeaders(r, serviceHeaders, methodHeaders); validationErr != nil {")
-	gf.P("writeErrorWithHandler(w, r, validationErr, errorHandler, marshalOpts)")
-	gf.P("return")
-	gf.P("}")
-	gf.P()
-
-	// Bind request — body first, then path/query (protojson.Unmarshal resets the message)
-	gf.P("req := new(Req)")
-	gf.P()
-
-	// Body binding for POST/PUT/PATCH — must happen before path/query binding
-	gf.P("// Bind body FIRST (protojson.Unmarshal calls proto.Reset, which would wipe path/query values)")
-	gf.P(`if httpMethod == "POST" || httpMethod == "PUT" || httpMethod == "PATCH" {`)
-	gf.P("if err := bindDataBasedOnContentType(r, req); err != nil {")
-	gf.P("validationErr := &sebufhttp.ValidationError{")
-	gf.P("Violations: []*sebufhttp.FieldViolation{")
-	gf.P("{")
-	gf.P(`Field: "body",`)
-	gf.P(`Description: fmt.Sprintf("failed to parse request body: %v", err),`)
-	gf.P("},")
-	gf.P("},")
-	gf.P("}")
-	gf.P("writeErrorWithHandler(w, r, validationErr, errorHandler, marshalOpts)")
-	gf.P("return")
-	gf.P("}")
-	gf.P("}")
-	gf.P()
-	gf.P("// Bind path and query parameters AFTER body, so URL-stated values always win")
-	gf.P("if msg, ok := any(req).(proto.Message); ok {")
-	gf.P("if err := bindPathParams(r, msg, pathParams); err != nil {")
-	gf.P("writeErrorWithHandler(w, r, err, errorHandler, marshalOpts)")
-	gf.P("return")
-	gf.P("}")
-	gf.P("if err := bindQueryParams(r, msg, queryParams); err != nil {")
-	gf.P("writeErrorWithHandler(w, r, err, errorHandler, marshalOpts)")
-	gf.P("return")
-	gf.P("}")
-	gf.P("}")
-	gf.P()
-
-	// Validate request body
-	gf.P("// Validate request body")
-	gf.P("if msg, ok := any(req).(proto.Message); ok {")
-	gf.P("if err := ValidateMessage(msg); err != nil {")
-	gf.P("writeErrorWithHandler(w, r, convertProtovalidateError(err), errorHandler, marshalOpts)")
-	gf.P("return")
-	gf.P("}")
-	gf.P("}")
-	gf.P()
+	g.generateStreamingRequestBinding(gf)
 
 	// Check Flusher support
 	gf.P("// Check Flusher support")
@@ -1875,6 +3539,93 @@ func (g *Generator) generateSSETypes(gf *protogen.GeneratedFile) {
 	gf.P()
 }
 
+// generateStreamingRequestBinding generates the header validation, binding and
+// message validation of req shared by the streaming handlers, which do not go
+// through BindingMiddleware.
+func (g *Generator) generateStreamingRequestBinding(gf *protogen.GeneratedFile) {
+	// Header validation
+	if g.features.headers {
+		gf.P("// Validate headers")
+		g.generateHeaderValidationCall(gf)
+	}
+	g.generateHeaderAuthenticationCall(gf)
+	gf.P()
+
+	// Bind request: body, then path, query and header-sourced fields
+	gf.P("req := new(Req)")
+	gf.P("if err := bindRequest(w, r, req, pathParams, queryParams, headerParams, httpMethod, body); err != nil {")
+	gf.P("writeErrorWithHandler(w, r, err, errorHandler, marshalOpts)")
+	gf.P("return")
+	gf.P("}")
+	gf.P()
+
+	// Validate request body
+	if g.features.messageValidation {
+		gf.P("// Validate request body, unless it has no rules to check")
+		gf.P("if msg, ok := any(req).(proto.Message); ok && !body.NoValidationRules {")
+		g.generateMessageValidationCall(gf)
+		gf.P("}")
+		gf.P()
+	}
+}
+
+// generateStreamResponseHandler generates the handler of stream_response
+// methods, which writes the items the service yields as a JSON array.
+func (g *Generator) generateStreamResponseHandler(gf *protogen.GeneratedFile) {
+	gf.P("// StreamResponseHandler creates an HTTP handler for stream_response methods. The")
+	gf.P("// items the handler yields are written as a JSON array, under key unless the")
+	gf.P("// response is unwrapped (protoKey when marshalOpts.UseProtoNames is set), and")
+	gf.P("// flushed as they are produced instead of being held in memory.")
+	gf.P("func StreamResponseHandler[Req any, Item proto.Message](")
+	gf.P("handler func(context.Context, *Req, func(Item) error) error,")
+	gf.P("errorHandler ErrorHandler,")
+	gf.P("serviceHeaders, methodHeaders []*sebufhttp.Header,")
+	gf.P("pathParams []PathParamConfig,")
+	gf.P("queryParams []QueryParamConfig,")
+	gf.P("headerParams []HeaderParamConfig,")
+	gf.P("httpMethod string,")
+	gf.P("body BodyConfig,")
+	gf.P("marshalOpts protojson.MarshalOptions,")
+	gf.P("validationPolicy sebufhttp.ValidationPolicy,")
+	gf.P("violationFormatter sebufhttp.ViolationFormatter,")
+	gf.P("validationFailureMode sebufhttp.ValidationFailureMode,")
+	gf.P("logger *slog.Logger,")
+	gf.P("authenticators []sebufhttp.HeaderAuthenticator,")
+	gf.P("key, protoKey string,")
+	gf.P(") http.Handler {")
+	gf.P("if marshalOpts.UseProtoNames {")
+	gf.P("key = protoKey")
+	gf.P("}")
+	gf.P("marshal := func(item proto.Message) ([]byte, error) {")
+	gf.P("return marshalJSONWithOpts(item, marshalOpts)")
+	gf.P("}")
+	gf.P("return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {")
+	g.generateStreamingRequestBinding(gf)
+	gf.P()
+	gf.P("stream := sebufhttp.NewJSONArrayWriter(w, r, key, marshal)")
+	gf.P("err := handler(r.Context(), req, func(item Item) error {")
+	gf.P("return stream.Write(item)")
+	gf.P("})")
+	gf.P("if err == nil {")
+	gf.P("err = stream.Close()")
+	gf.P("}")
+	gf.P("if err == nil {")
+	gf.P("return")
+	gf.P("}")
+	gf.P("if !stream.Started() {")
+	gf.P("// Nothing written yet, so the error still gets a proper error response.")
+	gf.P("writeErrorWithHandler(w, r, err, errorHandler, marshalOpts)")
+	gf.P("return")
+	gf.P("}")
+	gf.P("// The status and the first items are on the wire. Abort the response so the")
+	gf.P("// client sees a truncated document, never a complete but partial list.")
+	gf.P("_ = stream.Flush()")
+	gf.P("panic(http.ErrAbortHandler)")
+	gf.P("})")
+	gf.P("}")
+	gf.P()
+}
+
 func (g *Generator) generateErrorImplFile(file *protogen.File) error {
 	// Find messages ending with "Error"
 	var errorMessages []*protogen.Message
@@ -30,6 +30,8 @@ func TestHTTPGenGoldenFiles(t *testing.T) {
 		// extraProtoFiles holds additional proto files to pass to protoc alongside protoFile.
 		// Used for cross-file scenarios where two or more files must be compiled together.
 		extraProtoFiles []string
+		// params holds plugin parameters added after paths=source_relative.
+		params string
 		// Expected generated files (without path prefix)
 		expectedFiles []string
 	}{
@@ -51,6 +53,15 @@ func TestHTTPGenGoldenFiles(t *testing.T) {
 				"query_params_http_config.pb.go",
 			},
 		},
+		{
+			name:      "field sources",
+			protoFile: "field_sources.proto",
+			expectedFiles: []string{
+				"field_sources_http.pb.go",
+				"field_sources_http_binding.pb.go",
+				"field_sources_http_config.pb.go",
+			},
+		},
 		{
 			name:      "backward compatibility",
 			protoFile: "backward_compat.proto",
@@ -68,6 +79,7 @@ func TestHTTPGenGoldenFiles(t *testing.T) {
 				"unwrap_http_binding.pb.go",
 				"unwrap_http_config.pb.go",
 				"unwrap_unwrap.pb.go",
+				"unwrap_json_helpers.pb.go",
 			},
 		},
 		{
@@ -78,6 +90,7 @@ func TestHTTPGenGoldenFiles(t *testing.T) {
 				"int64_encoding_http_binding.pb.go",
 				"int64_encoding_http_config.pb.go",
 				"int64_encoding_encoding.pb.go",
+				"int64_encoding_json_helpers.pb.go",
 			},
 		},
 		{
@@ -99,6 +112,8 @@ func TestHTTPGenGoldenFiles(t *testing.T) {
 				"enum_encoding_http_config.pb.go",
 				"enum_encoding_enum_encoding.pb.go",
 				"enum_encoding_enum_field_encoding.pb.go",
+				"enum_encoding_enums.pb.go",
+				"enum_encoding_enums_test.go",
 			},
 		},
 		{
@@ -110,6 +125,8 @@ func TestHTTPGenGoldenFiles(t *testing.T) {
 				"enum_nested_http_config.pb.go",
 				"enum_nested_enum_encoding.pb.go",
 				"enum_nested_enum_field_encoding.pb.go",
+				"enum_nested_enums.pb.go",
+				"enum_nested_enums_test.go",
 			},
 		},
 		{
@@ -161,6 +178,18 @@ func TestHTTPGenGoldenFiles(t *testing.T) {
 				"bytes_encoding_bytes_encoding.pb.go",
 			},
 		},
+		{
+			name:      "scoped encoding defaults",
+			protoFile: "scoped_encoding.proto",
+			expectedFiles: []string{
+				"scoped_encoding_http.pb.go",
+				"scoped_encoding_http_binding.pb.go",
+				"scoped_encoding_http_config.pb.go",
+				"scoped_encoding_encoding.pb.go",
+				"scoped_encoding_timestamp_format.pb.go",
+				"scoped_encoding_bytes_encoding.pb.go",
+			},
+		},
 		{
 			name:      "flatten",
 			protoFile: "flatten.proto",
@@ -169,6 +198,7 @@ func TestHTTPGenGoldenFiles(t *testing.T) {
 				"flatten_http_binding.pb.go",
 				"flatten_http_config.pb.go",
 				"flatten_flatten.pb.go",
+				"flatten_json_helpers.pb.go",
 			},
 		},
 		{
@@ -217,10 +247,334 @@ func TestHTTPGenGoldenFiles(t *testing.T) {
 		{
 			name:      "SSE streaming",
 			protoFile: "sse.proto",
+			params:    ",generate_mock=true",
 			expectedFiles: []string{
 				"sse_http.pb.go",
 				"sse_http_binding.pb.go",
 				"sse_http_config.pb.go",
+				"sse_http_mock.pb.go",
+			},
+		},
+		{
+			name:      "streamed responses",
+			protoFile: "stream_response.proto",
+			expectedFiles: []string{
+				"stream_response_http.pb.go",
+				"stream_response_http_binding.pb.go",
+				"stream_response_http_config.pb.go",
+				"stream_response_unwrap.pb.go",
+			},
+		},
+		{
+			name:      "result messages",
+			protoFile: "response_statuses.proto",
+			params:    ",generate_mock=true",
+			expectedFiles: []string{
+				"response_statuses_http.pb.go",
+				"response_statuses_http_binding.pb.go",
+				"response_statuses_http_config.pb.go",
+				"response_statuses_http_mock.pb.go",
+			},
+		},
+		{
+			name:      "method examples",
+			protoFile: "method_examples.proto",
+			params:    ",generate_mock=true,mock_artifacts=insomnia",
+			expectedFiles: []string{
+				"method_examples_http.pb.go",
+				"method_examples_http_binding.pb.go",
+				"method_examples_http_config.pb.go",
+				"method_examples_http_mock.pb.go",
+				"method_examples_mock.openapi.json",
+			},
+		},
+		{
+			name:      "method examples with sensitive fields",
+			protoFile: "method_examples_sensitive.proto",
+			params:    ",generate_mock=true",
+			expectedFiles: []string{
+				"method_examples_sensitive_http_mock.pb.go",
+			},
+		},
+		{
+			name:      "sensitive fields",
+			protoFile: "sensitive.proto",
+			expectedFiles: []string{
+				"sensitive_http.pb.go",
+				"sensitive_http_binding.pb.go",
+				"sensitive_http_config.pb.go",
+				"sensitive_redact.pb.go",
+			},
+		},
+		{
+			name:      "custom json names",
+			protoFile: "json_names.proto",
+			expectedFiles: []string{
+				"json_names_http.pb.go",
+				"json_names_http_binding.pb.go",
+				"json_names_http_config.pb.go",
+				"json_names_encoding.pb.go",
+				"json_names_nullable.pb.go",
+				"json_names_bytes_encoding.pb.go",
+				"json_names_flatten.pb.go",
+				"json_names_oneof_discriminator.pb.go",
+			},
+		},
+		{
+			name:      "json naming policies",
+			protoFile: "json_naming.proto",
+			expectedFiles: []string{
+				"json_naming_http.pb.go",
+				"json_naming_http_binding.pb.go",
+				"json_naming_http_config.pb.go",
+				"json_naming_unwrap.pb.go",
+				"json_naming_flatten.pb.go",
+				"json_naming_json_naming.pb.go",
+				"json_naming_json_helpers.pb.go",
+			},
+		},
+		{
+			name:      "form-encoded request bodies",
+			protoFile: "form_body.proto",
+			expectedFiles: []string{
+				"form_body_http.pb.go",
+				"form_body_http_binding.pb.go",
+				"form_body_http_config.pb.go",
+			},
+		},
+		{
+			name:      "multipart request bodies",
+			protoFile: "multipart_upload.proto",
+			expectedFiles: []string{
+				"multipart_upload_http.pb.go",
+				"multipart_upload_http_binding.pb.go",
+				"multipart_upload_http_config.pb.go",
+			},
+		},
+		{
+			name:      "raw body responses",
+			protoFile: "raw_body.proto",
+			expectedFiles: []string{
+				"raw_body_http.pb.go",
+				"raw_body_http_binding.pb.go",
+				"raw_body_http_config.pb.go",
+				"raw_body_raw_body.pb.go",
+			},
+		},
+		{
+			name:      "JSON query parameters",
+			protoFile: "json_query.proto",
+			expectedFiles: []string{
+				"json_query_http.pb.go",
+				"json_query_http_binding.pb.go",
+				"json_query_http_config.pb.go",
+			},
+		},
+		{
+			name:      "merge patch bodies",
+			protoFile: "merge_patch.proto",
+			expectedFiles: []string{
+				"merge_patch_http.pb.go",
+				"merge_patch_http_binding.pb.go",
+				"merge_patch_http_config.pb.go",
+			},
+		},
+		{
+			name:      "versioned routes",
+			protoFile: "versioned_routes.proto",
+			expectedFiles: []string{
+				"versioned_routes_http.pb.go",
+				"versioned_routes_http_binding.pb.go",
+				"versioned_routes_http_config.pb.go",
+			},
+		},
+		{
+			name:      "mock artifacts",
+			protoFile: "restful_crud.proto",
+			params:    ",generate_mock=true,mock_artifacts=http,insomnia",
+			expectedFiles: []string{
+				"restful_crud_http_mock.pb.go",
+				"restful_crud_mock.http",
+				"restful_crud_mock.openapi.json",
+			},
+		},
+		{
+			name:      "test scaffold",
+			protoFile: "restful_crud.proto",
+			params:    ",generate_tests=true",
+			expectedFiles: []string{
+				"restful_crud_http_test.scaffold.go",
+			},
+		},
+		{
+			name:      "test scaffold of result messages",
+			protoFile: "response_statuses.proto",
+			params:    ",generate_tests=true",
+			expectedFiles: []string{
+				"response_statuses_http_test.scaffold.go",
+			},
+		},
+		{
+			name:      "grpc-gateway compatibility",
+			protoFile: "grpc_gateway_compat.proto",
+			params:    ",compat=grpc_gateway",
+			expectedFiles: []string{
+				"grpc_gateway_compat_http.pb.go",
+				"grpc_gateway_compat_http_binding.pb.go",
+				"grpc_gateway_compat_http_config.pb.go",
+			},
+		},
+		{
+			name:      "visibility",
+			protoFile: "visibility.proto",
+			expectedFiles: []string{
+				"visibility_http.pb.go",
+				"visibility_http_binding.pb.go",
+				"visibility_http_config.pb.go",
+			},
+		},
+		{
+			name:      "schema fingerprint",
+			protoFile: "schema_fingerprint.proto",
+			params:    ",schema_fingerprint=true",
+			expectedFiles: []string{
+				"schema_fingerprint_http.pb.go",
+				"schema_fingerprint_http_binding.pb.go",
+				"schema_fingerprint_http_config.pb.go",
+			},
+		},
+		{
+			name:      "embed descriptors",
+			protoFile: "embed_descriptors.proto",
+			params:    ",embed_descriptors=true",
+			expectedFiles: []string{
+				"embed_descriptors_http.pb.go",
+				"embed_descriptors_http_binding.pb.go",
+				"embed_descriptors_http_config.pb.go",
+				"embed_descriptors_http_descriptors.pb.go",
+			},
+		},
+		{
+			name:      "auto options",
+			protoFile: "auto_options.proto",
+			params:    ",auto_options=true,trailing_slash=ignore",
+			expectedFiles: []string{
+				"auto_options_http.pb.go",
+				"auto_options_http_binding.pb.go",
+				"auto_options_http_config.pb.go",
+			},
+		},
+		{
+			name:      "problem json",
+			protoFile: "problem_json.proto",
+			expectedFiles: []string{
+				"problem_json_http.pb.go",
+				"problem_json_http_binding.pb.go",
+				"problem_json_http_config.pb.go",
+			},
+		},
+		{
+			name:      "deprecated fields",
+			protoFile: "deprecated_fields.proto",
+			expectedFiles: []string{
+				"deprecated_fields_http.pb.go",
+				"deprecated_fields_http_binding.pb.go",
+				"deprecated_fields_http_config.pb.go",
+			},
+		},
+		{
+			name:      "path wildcards",
+			protoFile: "path_wildcard.proto",
+			expectedFiles: []string{
+				"path_wildcard_http.pb.go",
+				"path_wildcard_http_binding.pb.go",
+				"path_wildcard_http_config.pb.go",
+			},
+		},
+		{
+			name:      "base path parameters",
+			protoFile: "base_path_params.proto",
+			expectedFiles: []string{
+				"base_path_params_http.pb.go",
+				"base_path_params_http_binding.pb.go",
+				"base_path_params_http_config.pb.go",
+			},
+		},
+		{
+			name:      "stdlib router",
+			protoFile: "router_stdlib.proto",
+			params:    ",router=stdlib",
+			expectedFiles: []string{
+				"router_stdlib_http.pb.go",
+				"router_stdlib_http_binding.pb.go",
+				"router_stdlib_http_config.pb.go",
+			},
+		},
+		{
+			name:      "chi router",
+			protoFile: "router_chi.proto",
+			params:    ",router=chi,trailing_slash=redirect,generate_tests=true,generate_benchmarks=true",
+			expectedFiles: []string{
+				"router_chi_http.pb.go",
+				"router_chi_http_binding.pb.go",
+				"router_chi_http_config.pb.go",
+				"router_chi_http_test.scaffold.go",
+				"router_chi_http_binding_benchmark_test.go",
+			},
+		},
+		{
+			name:      "gorilla router",
+			protoFile: "router_gorilla.proto",
+			params:    ",router=gorilla,trailing_slash=ignore,generate_tests=true,generate_benchmarks=true",
+			expectedFiles: []string{
+				"router_gorilla_http.pb.go",
+				"router_gorilla_http_binding.pb.go",
+				"router_gorilla_http_config.pb.go",
+				"router_gorilla_http_test.scaffold.go",
+				"router_gorilla_http_binding_benchmark_test.go",
+			},
+		},
+		{
+			name:      "response validation",
+			protoFile: "response_validation.proto",
+			expectedFiles: []string{
+				"response_validation_http.pb.go",
+				"response_validation_http_binding.pb.go",
+				"response_validation_http_config.pb.go",
+			},
+		},
+		{
+			name:      "query aliases",
+			protoFile: "query_aliases.proto",
+			expectedFiles: []string{
+				"query_aliases_http.pb.go",
+				"query_aliases_http_binding.pb.go",
+				"query_aliases_http_config.pb.go",
+			},
+		},
+		{
+			name:      "edition 2023",
+			protoFile: "editions.proto",
+			expectedFiles: []string{
+				"editions_http.pb.go",
+				"editions_http_binding.pb.go",
+				"editions_http_config.pb.go",
+			},
+		},
+		{
+			name:      "proto2 syntax",
+			protoFile: "proto2_syntax.proto",
+			expectedFiles: []string{
+				"proto2_syntax_http.pb.go",
+				"proto2_syntax_http_binding.pb.go",
+				"proto2_syntax_http_config.pb.go",
+			},
+		},
+		{
+			name:      "response headers",
+			protoFile: "response_headers.proto",
+			expectedFiles: []string{
+				"response_headers_http.pb.go",
 			},
 		},
 	}
@@ -275,7 +629,7 @@ func TestHTTPGenGoldenFiles(t *testing.T) {
 				"--go_out=" + tempDir,
 				"--go_opt=paths=source_relative",
 				"--go-http_out=" + tempDir,
-				"--go-http_opt=paths=source_relative",
+				"--go-http_opt=paths=source_relative" + tc.params,
 				"--proto_path=" + protoDir,
 				"--proto_path=" + filepath.Join(projectRoot, "proto"),
 				tc.protoFile,
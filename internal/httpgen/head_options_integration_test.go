@@ -0,0 +1,181 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestHeadOptionsIntegration generates a Go HTTP server with auto_options=true
+// for two services sharing a path, and checks that HEAD answers GET routes with
+// the status and headers of GET and no body, running the handler once, and
+// that OPTIONS lists every verb served on a path, whichever service serves it.
+func TestHeadOptionsIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "head_options_test",
+		Protos:  map[string]string{"items.proto": headOptionsProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http", Opt: "auto_options=true"}},
+		Files:   map[string]string{"head_options_test.go": headOptionsIntegrationTestCode},
+	})
+}
+
+const headOptionsProto = `syntax = "proto3";
+package test.headoptions;
+option go_package = "head_options_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service ItemService {
+  option (sebuf.http.service_config) = {
+    response_headers: [{ name: "Cache-Control", value: "private, max-age=60" }]
+  };
+
+  rpc GetItem(GetItemRequest) returns (Item) {
+    option (sebuf.http.config) = { path: "/items/{id}" method: HTTP_METHOD_GET };
+  }
+
+  rpc CreateItem(Item) returns (Item) {
+    option (sebuf.http.config) = { path: "/items" method: HTTP_METHOD_POST };
+  }
+}
+
+service ItemAdminService {
+  rpc DeleteItem(DeleteItemRequest) returns (DeleteItemResponse) {
+    option (sebuf.http.config) = { path: "/items/{item_id}" method: HTTP_METHOD_DELETE };
+  }
+}
+
+message GetItemRequest {
+  string id = 1;
+}
+
+message DeleteItemRequest {
+  string item_id = 1;
+}
+
+message DeleteItemResponse {}
+
+message Item {
+  string id = 1;
+  string name = 2;
+}
+`
+
+// headOptionsIntegrationTestCode is the test source that runs inside the temp
+// module. The server fails GetItem for the id "missing".
+const headOptionsIntegrationTestCode = `package head_options_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	gen "head_options_test/gen"
+)
+
+type itemServer struct {
+	gets atomic.Int32
+}
+
+func (s *itemServer) GetItem(_ context.Context, req *gen.GetItemRequest) (*gen.Item, error) {
+	s.gets.Add(1)
+	if req.GetId() == "missing" {
+		return nil, errors.New("item store unavailable")
+	}
+	return &gen.Item{Id: req.GetId(), Name: "widget"}, nil
+}
+
+func (s *itemServer) CreateItem(_ context.Context, req *gen.Item) (*gen.Item, error) {
+	return req, nil
+}
+
+type itemAdminServer struct{}
+
+func (itemAdminServer) DeleteItem(context.Context, *gen.DeleteItemRequest) (*gen.DeleteItemResponse, error) {
+	return &gen.DeleteItemResponse{}, nil
+}
+
+func newServer(t *testing.T) (*httptest.Server, *itemServer) {
+	t.Helper()
+	mux := http.NewServeMux()
+	items := &itemServer{}
+	if err := gen.RegisterItemServiceServer(items, gen.WithMux(mux)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.RegisterItemAdminServiceServer(itemAdminServer{}, gen.WithMux(mux)); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, items
+}
+
+// send sends a request without a body to the server and returns its response
+// with the body read.
+func send(t *testing.T, srv *httptest.Server, method, path string) (*http.Response, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, body
+}
+
+func TestHeadMatchesGet(t *testing.T) {
+	srv, items := newServer(t)
+	for _, path := range []string{"/items/1", "/items/missing"} {
+		t.Run(path, func(t *testing.T) {
+			get, getBody := send(t, srv, http.MethodGet, path)
+			calls := items.gets.Load()
+			head, headBody := send(t, srv, http.MethodHead, path)
+			if got := items.gets.Load() - calls; got != 1 {
+				t.Errorf("HEAD ran GetItem %d times, want 1", got)
+			}
+			if head.StatusCode != get.StatusCode {
+				t.Errorf("HEAD status = %d, GET status = %d", head.StatusCode, get.StatusCode)
+			}
+			if len(headBody) != 0 {
+				t.Errorf("HEAD body = %q, want none", headBody)
+			}
+			if got, want := head.Header.Get("Content-Length"), strconv.Itoa(len(getBody)); got != want {
+				t.Errorf("HEAD Content-Length = %q, want %q", got, want)
+			}
+			head.Header.Del("Date")
+			get.Header.Del("Date")
+			if !reflect.DeepEqual(head.Header, get.Header) {
+				t.Errorf("HEAD headers = %v, GET headers = %v", head.Header, get.Header)
+			}
+		})
+	}
+}
+
+func TestOptionsAllow(t *testing.T) {
+	srv, _ := newServer(t)
+	for path, want := range map[string]string{
+		"/items/1": "DELETE, GET, HEAD, OPTIONS",
+		"/items":   "OPTIONS, POST",
+	} {
+		resp, _ := send(t, srv, http.MethodOptions, path)
+		if resp.StatusCode != http.StatusNoContent {
+			t.Errorf("OPTIONS %s status = %d, want 204", path, resp.StatusCode)
+		}
+		if got := resp.Header.Get("Allow"); got != want {
+			t.Errorf("OPTIONS %s Allow = %q, want %q", path, got, want)
+		}
+	}
+}
+`
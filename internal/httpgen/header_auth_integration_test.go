@@ -0,0 +1,274 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestHeaderAuthIntegration generates a Go HTTP server declaring an API key
+// header and checks that WithHeaderAuthenticator hands the principal it
+// establishes to the handler, answers invalid keys with 401 UNAUTHENTICATED and
+// leaves missing keys to the required-header 400. Methods that do not declare
+// the header are authenticated too, a missing key answered with 401.
+func TestHeaderAuthIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "header_auth_test",
+		Protos:  map[string]string{"keys.proto": headerAuthProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"header_auth_test.go": headerAuthIntegrationTestCode},
+	})
+}
+
+const headerAuthProto = `syntax = "proto3";
+package test.headerauth;
+option go_package = "header_auth_test/gen;gen";
+import "sebuf/http/annotations.proto";
+import "sebuf/http/headers.proto";
+
+service AccountService {
+  rpc Whoami(WhoamiRequest) returns (WhoamiResponse) {
+    option (sebuf.http.config) = { path: "/whoami" method: HTTP_METHOD_GET };
+    option (sebuf.http.method_headers) = {
+      required_headers: [
+        { name: "X-API-Key" type: "string" required: true auth_type: AUTH_TYPE_API_KEY }
+      ]
+    };
+  }
+  rpc Ping(WhoamiRequest) returns (WhoamiResponse) {
+    option (sebuf.http.config) = { path: "/ping" method: HTTP_METHOD_GET };
+  }
+}
+
+message WhoamiRequest {}
+
+message WhoamiResponse {
+  string principal = 1;
+}
+`
+
+// headerAuthIntegrationTestCode is the test source that runs inside the temp
+// module. The server answers with the principal its handlers see.
+const headerAuthIntegrationTestCode = `package header_auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "header_auth_test/gen"
+)
+
+type accountServer struct{}
+
+func (accountServer) Whoami(ctx context.Context, _ *gen.WhoamiRequest) (*gen.WhoamiResponse, error) {
+	principal, _ := sebufhttp.PrincipalFromContext[string](ctx)
+	return &gen.WhoamiResponse{Principal: principal}, nil
+}
+
+func (accountServer) Ping(ctx context.Context, _ *gen.WhoamiRequest) (*gen.WhoamiResponse, error) {
+	principal, _ := sebufhttp.PrincipalFromContext[string](ctx)
+	return &gen.WhoamiResponse{Principal: principal}, nil
+}
+
+func authenticateKey(ctx context.Context, key string) (context.Context, error) {
+	if key != "key-alice" {
+		return nil, errors.New("unknown API key")
+	}
+	return sebufhttp.ContextWithPrincipal(ctx, "alice"), nil
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	err := gen.RegisterAccountServiceServer(accountServer{},
+		gen.WithMux(mux),
+		gen.WithHeaderAuthenticator("x-api-key", authenticateKey),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// get requests path with the API key, if any, and decodes the JSON response.
+func get(t *testing.T, srv *httptest.Server, path, key string) (int, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "" {
+		req.Header.Set("X-API-Key", key)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(data, &body); err != nil {
+		t.Fatalf("status %d, body %s: %v", resp.StatusCode, data, err)
+	}
+	return resp.StatusCode, body
+}
+
+func TestValidKeyPassesPrincipal(t *testing.T) {
+	status, body := get(t, newServer(t), "/whoami", "key-alice")
+	if status != http.StatusOK || body["principal"] != "alice" {
+		t.Errorf("status %d, body %v, want 200 with principal alice", status, body)
+	}
+}
+
+func TestInvalidKeyIsUnauthenticated(t *testing.T) {
+	status, body := get(t, newServer(t), "/whoami", "key-mallory")
+	if status != http.StatusUnauthorized {
+		t.Errorf("status %d, want 401", status)
+	}
+	if body["code"] != sebufhttp.ErrorCodeUnauthenticated {
+		t.Errorf("body %v, want an Error with code UNAUTHENTICATED", body)
+	}
+}
+
+func TestMissingKeyFailsValidation(t *testing.T) {
+	status, body := get(t, newServer(t), "/whoami", "")
+	if status != http.StatusBadRequest {
+		t.Errorf("status %d, want 400", status)
+	}
+	if _, ok := body["violations"]; !ok {
+		t.Errorf("body %v, want the required-header ValidationError", body)
+	}
+}
+
+func TestMethodsWithoutTheHeaderAreAuthenticated(t *testing.T) {
+	srv := newServer(t)
+	if status, body := get(t, srv, "/ping", "key-alice"); status != http.StatusOK || body["principal"] != "alice" {
+		t.Errorf("status %d, body %v, want 200 with principal alice", status, body)
+	}
+	for _, key := range []string{"key-mallory", ""} {
+		status, body := get(t, srv, "/ping", key)
+		if status != http.StatusUnauthorized || body["code"] != sebufhttp.ErrorCodeUnauthenticated {
+			t.Errorf("key %q: status %d, body %v, want 401 UNAUTHENTICATED", key, status, body)
+		}
+	}
+}
+`
+
+// TestHeaderAuthWithoutDeclaredHeadersIntegration generates a Go HTTP server
+// from a file that declares no headers at all and checks that
+// WithHeaderAuthenticator still authenticates every request, answering a
+// missing key with 401 UNAUTHENTICATED.
+func TestHeaderAuthWithoutDeclaredHeadersIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "header_auth_undeclared_test",
+		Protos:  map[string]string{"ping.proto": headerAuthUndeclaredProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"header_auth_test.go": headerAuthUndeclaredTestCode},
+	})
+}
+
+const headerAuthUndeclaredProto = `syntax = "proto3";
+package test.headerauthundeclared;
+option go_package = "header_auth_undeclared_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service PingService {
+  rpc Ping(PingRequest) returns (PingResponse) {
+    option (sebuf.http.config) = { path: "/ping" method: HTTP_METHOD_GET };
+  }
+}
+
+message PingRequest {}
+
+message PingResponse {
+  string principal = 1;
+}
+`
+
+const headerAuthUndeclaredTestCode = `package header_auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "header_auth_undeclared_test/gen"
+)
+
+type pingServer struct{}
+
+func (pingServer) Ping(ctx context.Context, _ *gen.PingRequest) (*gen.PingResponse, error) {
+	principal, _ := sebufhttp.PrincipalFromContext[string](ctx)
+	return &gen.PingResponse{Principal: principal}, nil
+}
+
+func TestUndeclaredHeaderIsAuthenticated(t *testing.T) {
+	mux := http.NewServeMux()
+	err := gen.RegisterPingServiceServer(pingServer{},
+		gen.WithMux(mux),
+		gen.WithHeaderAuthenticator("Authorization", func(ctx context.Context, value string) (context.Context, error) {
+			if value != "Bearer alice" {
+				return nil, errors.New("unknown token")
+			}
+			return sebufhttp.ContextWithPrincipal(ctx, "alice"), nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		token      string
+		wantStatus int
+		want       string
+	}{
+		{"", http.StatusUnauthorized, sebufhttp.ErrorCodeUnauthenticated},
+		{"Bearer mallory", http.StatusUnauthorized, sebufhttp.ErrorCodeUnauthenticated},
+		{"Bearer alice", http.StatusOK, "alice"},
+	} {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/ping", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tc.token != "" {
+			req.Header.Set("Authorization", tc.token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var body map[string]any
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := body["code"]
+		if tc.wantStatus == http.StatusOK {
+			got = body["principal"]
+		}
+		if resp.StatusCode != tc.wantStatus || got != tc.want {
+			t.Errorf("token %q: status %d, body %v, want %d with %s", tc.token, resp.StatusCode, body, tc.wantStatus, tc.want)
+		}
+	}
+}
+`
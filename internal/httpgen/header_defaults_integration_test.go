@@ -0,0 +1,147 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestHeaderDefaultsIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server and Go client from a proto declaring headers
+//     with default_value,
+//  2. writes a temporary Go module that serves the generated handlers with httptest,
+//  3. verifies the server sees the defaults via sebufhttp.HeadersFromContext when
+//     the client omits the headers, and that client and call overrides win.
+func TestHeaderDefaultsIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "header_defaults_test",
+		Protos:  map[string]string{"header_defaults.proto": headerDefaultsProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}, {Name: "go-client"}},
+		Files:   map[string]string{"header_defaults_test.go": headerDefaultsIntegrationTestCode},
+	})
+}
+
+const headerDefaultsProto = `syntax = "proto3";
+package test.headerdefaults;
+option go_package = "header_defaults_test/gen;gen";
+import "sebuf/http/annotations.proto";
+import "sebuf/http/headers.proto";
+
+service VersionService {
+  option (sebuf.http.service_headers) = {
+    required_headers: [
+      { name: "X-Client-Version" type: "string" required: true default_value: "1.0.0" }
+    ]
+  };
+
+  rpc Echo(EchoRequest) returns (EchoResponse) {
+    option (sebuf.http.config) = { path: "/echo" };
+    option (sebuf.http.method_headers) = {
+      required_headers: [
+        { name: "Accept-Language" type: "string" default_value: "en-US" }
+      ]
+    };
+  }
+}
+
+message EchoRequest {}
+
+message EchoResponse {
+  string client_version = 1;
+  string language = 2;
+}
+`
+
+// headerDefaultsIntegrationTestCode is the test source that runs inside the
+// temp module. The server echoes the validated headers it sees back to the
+// generated client.
+const headerDefaultsIntegrationTestCode = `package header_defaults_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "header_defaults_test/gen"
+)
+
+type echoServer struct{}
+
+func (echoServer) Echo(ctx context.Context, _ *gen.EchoRequest) (*gen.EchoResponse, error) {
+	headers := sebufhttp.HeadersFromContext(ctx)
+	return &gen.EchoResponse{
+		ClientVersion: headers.Get("X-Client-Version"),
+		Language:      headers.Get("Accept-Language"),
+	}, nil
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterVersionServiceServer(echoServer{}, gen.WithMux(mux)); err != nil {
+		t.Fatalf("RegisterVersionServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func echo(t *testing.T, client gen.VersionServiceClient, opts ...gen.VersionServiceCallOption) *gen.EchoResponse {
+	t.Helper()
+	resp, err := client.Echo(context.Background(), &gen.EchoRequest{}, opts...)
+	if err != nil {
+		t.Fatalf("Echo: %v", err)
+	}
+	return resp
+}
+
+func TestClientSendsDefaults(t *testing.T) {
+	srv := newServer(t)
+	resp := echo(t, gen.NewVersionServiceClient(srv.URL))
+	if resp.ClientVersion != "1.0.0" || resp.Language != "en-US" {
+		t.Errorf("server saw %q / %q, want the defaults 1.0.0 / en-US", resp.ClientVersion, resp.Language)
+	}
+}
+
+func TestCallOverrideWins(t *testing.T) {
+	srv := newServer(t)
+	client := gen.NewVersionServiceClient(srv.URL, gen.WithVersionServiceClientVersion("2.0.0"))
+
+	if resp := echo(t, client); resp.ClientVersion != "2.0.0" {
+		t.Errorf("client option: server saw %q, want 2.0.0", resp.ClientVersion)
+	}
+	resp := echo(t, client,
+		gen.WithVersionServiceCallClientVersion("3.1.4"),
+		gen.WithVersionServiceCallAcceptLanguage("fr-FR"),
+	)
+	if resp.ClientVersion != "3.1.4" || resp.Language != "fr-FR" {
+		t.Errorf("call options: server saw %q / %q, want 3.1.4 / fr-FR", resp.ClientVersion, resp.Language)
+	}
+}
+
+// TestServerFillsDefaults sends a bare request: the required header has a
+// default, so it must not fail validation and the handler must see the default.
+func TestServerFillsDefaults(t *testing.T) {
+	srv := newServer(t)
+	resp, err := http.Post(srv.URL+"/echo", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d, want 200", resp.StatusCode)
+	}
+	var body strings.Builder
+	if _, err := io.Copy(&body, resp.Body); err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	if !strings.Contains(body.String(), ` + "`" + `"clientVersion":"1.0.0"` + "`" + `) {
+		t.Errorf("body = %s, want the default client version", body.String())
+	}
+}
+`
@@ -0,0 +1,215 @@
+package httpgen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestHeaderMultipleIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server and Go client from a proto declaring a header
+//     with multiple: true,
+//  2. writes a temporary Go module that serves the generated handlers with httptest,
+//  3. verifies the client sends every value, the server validates each of them,
+//     whether they arrive on one line or several, and the handler reads them all
+//     via sebufhttp.HeadersFromContext.
+func TestHeaderMultipleIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "header_multiple_test",
+		Protos:  map[string]string{"header_multiple.proto": headerMultipleProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}, {Name: "go-client"}},
+		Files:   map[string]string{"header_multiple_test.go": headerMultipleIntegrationTestCode},
+	})
+}
+
+// TestHeaderCaseDuplicatesRejected checks that both Go generators fail on a
+// method declaring the same header twice in different cases.
+func TestHeaderCaseDuplicatesRejected(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping integration test")
+	}
+
+	out, err := runHeaderProtoc(t, `syntax = "proto3";
+package test.headermultiple;
+option go_package = "header_multiple_test/gen;gen";
+import "sebuf/http/annotations.proto";
+import "sebuf/http/headers.proto";
+
+service TagService {
+  rpc Echo(EchoRequest) returns (EchoResponse) {
+    option (sebuf.http.config) = { path: "/echo" };
+    option (sebuf.http.method_headers) = {
+      required_headers: [
+        { name: "X-Tag-ID" type: "string" },
+        { name: "x-tag-id" type: "string" }
+      ]
+    };
+  }
+}
+
+message EchoRequest {}
+message EchoResponse {}
+`)
+	if err == nil {
+		t.Fatal("protoc succeeded, want an error for headers differing only by case")
+	}
+	if want := `headers "X-Tag-ID" and "x-tag-id" differ only by case`; !strings.Contains(string(out), want) {
+		t.Errorf("protoc output = %s, want it to contain %q", out, want)
+	}
+}
+
+// runHeaderProtoc generates the Go messages, server and client of the
+// header_multiple.proto source into a temporary directory and returns the
+// protoc output, for tests that expect the generators to fail.
+func runHeaderProtoc(t *testing.T, source string) ([]byte, error) {
+	t.Helper()
+	protoDir, genDir := t.TempDir(), t.TempDir()
+	if err := os.WriteFile(filepath.Join(protoDir, "header_multiple.proto"), []byte(source), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	projectRoot := plugintest.ProjectRoot()
+	cmd := exec.Command("protoc",
+		"--plugin=protoc-gen-go-http="+plugintest.Build(t, projectRoot, "protoc-gen-go-http"),
+		"--plugin=protoc-gen-go-client="+plugintest.Build(t, projectRoot, "protoc-gen-go-client"),
+		"--go_out="+genDir,
+		"--go_opt=paths=source_relative",
+		"--go-http_out="+genDir,
+		"--go-http_opt=paths=source_relative",
+		"--go-client_out="+genDir,
+		"--go-client_opt=paths=source_relative",
+		"--proto_path="+protoDir,
+		"--proto_path="+filepath.Join(projectRoot, "proto"),
+		"header_multiple.proto",
+	)
+	return cmd.CombinedOutput()
+}
+
+const headerMultipleProto = `syntax = "proto3";
+package test.headermultiple;
+option go_package = "header_multiple_test/gen;gen";
+import "sebuf/http/annotations.proto";
+import "sebuf/http/headers.proto";
+
+service TagService {
+  rpc Echo(EchoRequest) returns (EchoResponse) {
+    option (sebuf.http.config) = { path: "/echo" };
+    option (sebuf.http.method_headers) = {
+      required_headers: [
+        { name: "X-Tag-ID" type: "integer" required: true multiple: true }
+      ]
+    };
+  }
+}
+
+message EchoRequest {}
+
+message EchoResponse {
+  repeated string tag_ids = 1;
+}
+`
+
+// headerMultipleIntegrationTestCode is the test source that runs inside the
+// temp module. The server echoes the validated values it sees back to the
+// generated client.
+const headerMultipleIntegrationTestCode = `package header_multiple_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "header_multiple_test/gen"
+)
+
+type echoServer struct{}
+
+func (echoServer) Echo(ctx context.Context, _ *gen.EchoRequest) (*gen.EchoResponse, error) {
+	return &gen.EchoResponse{TagIds: sebufhttp.HeadersFromContext(ctx).Values("x-tag-id")}, nil
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterTagServiceServer(echoServer{}, gen.WithMux(mux)); err != nil {
+		t.Fatalf("RegisterTagServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClientSendsEveryValue(t *testing.T) {
+	srv := newServer(t)
+	client := gen.NewTagServiceClient(srv.URL)
+	resp, err := client.Echo(context.Background(), &gen.EchoRequest{}, gen.WithTagServiceCallTagID("1", "2"))
+	if err != nil {
+		t.Fatalf("Echo: %v", err)
+	}
+	if want := []string{"1", "2"}; !slices.Equal(resp.TagIds, want) {
+		t.Errorf("server saw %q, want %q", resp.TagIds, want)
+	}
+}
+
+func TestServerValidatesEveryValue(t *testing.T) {
+	srv := newServer(t)
+	client := gen.NewTagServiceClient(srv.URL)
+	_, err := client.Echo(context.Background(), &gen.EchoRequest{}, gen.WithTagServiceCallTagID("1", "two"))
+	var validationErr *sebufhttp.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Echo error = %v, want a ValidationError for the second value", err)
+	}
+}
+
+// post sends the header lines as given, in any case, and returns the response.
+func post(t *testing.T, srv *httptest.Server, lines map[string][]string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/echo", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, values := range lines {
+		req.Header[name] = values
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestServerReadsRepeatedLines(t *testing.T) {
+	srv := newServer(t)
+	status, body := post(t, srv, map[string][]string{"x-tag-id": {"1", "2, 3"}})
+	if status != http.StatusOK {
+		t.Fatalf("status %d, want 200: %s", status, body)
+	}
+	// protojson may add spaces, which want leaves out
+	if want := ` + "`" + `"tagIds":["1","2","3"]` + "`" + `; !strings.Contains(strings.ReplaceAll(body, " ", ""), want) {
+		t.Errorf("body = %s, want it to contain %s", body, want)
+	}
+
+	if status, body = post(t, srv, map[string][]string{"X-Tag-Id": {"1", "two"}}); status != http.StatusBadRequest {
+		t.Errorf("invalid second line: status %d, want 400: %s", status, body)
+	}
+	if status, body = post(t, srv, map[string][]string{"X-Tag-Id": {" , "}}); status != http.StatusBadRequest {
+		t.Errorf("no values: status %d, want 400: %s", status, body)
+	}
+}
+`
@@ -0,0 +1,130 @@
+package httpgen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// declaredHeader is a header a service or one of its methods declares, with
+// the name of the field that holds it in <Service>Headers.
+type declaredHeader struct {
+	goName string
+	header *http.Header
+}
+
+// serviceDeclaredHeaders returns the headers declared by a service and its
+// methods, service headers first, each once. Two distinct headers mapping to
+// the same Go name are an error.
+func serviceDeclaredHeaders(service *protogen.Service) ([]declaredHeader, error) {
+	headers := annotations.GetServiceHeaders(service)
+	for _, method := range service.Methods {
+		headers = append(headers, annotations.GetMethodHeaders(method)...)
+	}
+
+	var out []declaredHeader
+	byGoName := make(map[string]string)
+	for _, header := range headers {
+		name := annotations.CanonicalHeaderName(header.GetName())
+		goName := headerGoName(header.GetName())
+		if prev, ok := byGoName[goName]; ok {
+			if prev == name {
+				continue
+			}
+			return nil, fmt.Errorf("%s: headers %s and %s both map to the Go name %s",
+				service.Desc.FullName(), prev, name, goName)
+		}
+		byGoName[goName] = name
+		out = append(out, declaredHeader{goName: goName, header: header})
+	}
+	return out, nil
+}
+
+// headerGoName returns the exported Go name of a header: its dash-separated
+// words with their first letter upper-cased, so X-API-Key becomes XAPIKey.
+func headerGoName(name string) string {
+	var b strings.Builder
+	for word := range strings.FieldsFuncSeq(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	goName := b.String()
+	if goName == "" || !unicode.IsLetter(rune(goName[0])) {
+		goName = "H" + goName
+	}
+	return goName
+}
+
+// generateServiceHeaders generates <Service>Headers, the names of the headers
+// a service declares, and <Service>HeaderSpecs, their declarations, so
+// handlers and middleware need not repeat them as string literals.
+func (g *Generator) generateServiceHeaders(gf *protogen.GeneratedFile, service *protogen.Service) error {
+	headers, err := serviceDeclaredHeaders(service)
+	if err != nil || len(headers) == 0 {
+		return err
+	}
+	serviceName := service.GoName
+
+	gf.P("// ", serviceName, "Headers holds the names of the headers ", serviceName, " and its methods declare.")
+	gf.P("var ", serviceName, "Headers = struct {")
+	for _, h := range headers {
+		doc := "// " + h.goName + " is the " + h.header.GetName() + " header"
+		if description := strings.Join(strings.Fields(h.header.GetDescription()), " "); description != "" {
+			doc += ": " + description
+		}
+		gf.P(strings.TrimSuffix(doc, "."), ".")
+		gf.P(h.goName, " string")
+	}
+	gf.P("}{")
+	for _, h := range headers {
+		gf.P(h.goName, ": ", strconv.Quote(h.header.GetName()), ",")
+	}
+	gf.P("}")
+	gf.P()
+
+	gf.P("// ", serviceName, "HeaderSpecs describes the headers of ", serviceName, "Headers, under the same names.")
+	gf.P("var ", serviceName, "HeaderSpecs = struct {")
+	for _, h := range headers {
+		gf.P(h.goName, " sebufhttp.HeaderSpec")
+	}
+	gf.P("}{")
+	for _, h := range headers {
+		gf.P(h.goName, ": ", headerSpecLiteral(h.header), ",")
+	}
+	gf.P("}")
+	gf.P()
+	return nil
+}
+
+// headerSpecLiteral returns the sebufhttp.HeaderSpec literal describing header,
+// leaving out its zero fields.
+func headerSpecLiteral(header *http.Header) string {
+	typ := header.GetType()
+	if typ == "" {
+		typ = "string"
+	}
+	fields := []string{"Name: " + strconv.Quote(header.GetName()), "Type: " + strconv.Quote(typ)}
+	if header.GetFormat() != "" {
+		fields = append(fields, "Format: "+strconv.Quote(header.GetFormat()))
+	}
+	if header.GetRequired() {
+		fields = append(fields, "Required: true")
+	}
+	if header.GetMultiple() {
+		fields = append(fields, "Multiple: true")
+	}
+	if header.GetDeprecated() {
+		fields = append(fields, "Deprecated: true")
+	}
+	if header.GetDefaultValue() != "" {
+		fields = append(fields, "DefaultValue: "+strconv.Quote(header.GetDefaultValue()))
+	}
+	return "sebufhttp.HeaderSpec{" + strings.Join(fields, ", ") + "}"
+}
@@ -0,0 +1,18 @@
+package httpgen
+
+import "testing"
+
+func TestHeaderGoName(t *testing.T) {
+	for name, want := range map[string]string{
+		"X-API-Key":       "XAPIKey",
+		"x-tenant-id":     "XTenantId",
+		"Accept-Language": "AcceptLanguage",
+		"X-Request-ID":    "XRequestID",
+		"X_Trace.Span":    "XTraceSpan",
+		"1-Time":          "H1Time",
+	} {
+		if got := headerGoName(name); got != want {
+			t.Errorf("headerGoName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestHeaderPropagationIntegration generates a Go HTTP server and Go client for
+// a gateway service whose handler calls an inventory service on a second
+// server, and checks that a client built with WithHeaderPropagation forwards
+// the tenant header the gateway validated without the handler passing it on,
+// that per-call headers override it, and that nothing is forwarded outside a
+// handler.
+func TestHeaderPropagationIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "header_propagation_test",
+		Protos:  map[string]string{"tenants.proto": headerPropagationProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}, {Name: "go-client"}},
+		Files:   map[string]string{"header_propagation_test.go": headerPropagationIntegrationTestCode},
+	})
+}
+
+const headerPropagationProto = `syntax = "proto3";
+package test.headerpropagation;
+option go_package = "header_propagation_test/gen;gen";
+import "sebuf/http/annotations.proto";
+import "sebuf/http/headers.proto";
+
+service GatewayService {
+  option (sebuf.http.service_headers) = {
+    required_headers: [
+      { name: "X-Tenant-ID" type: "string" required: true }
+    ]
+  };
+  rpc Lookup(LookupRequest) returns (StockResponse) {
+    option (sebuf.http.config) = { path: "/lookup" method: HTTP_METHOD_POST };
+  }
+}
+
+service InventoryService {
+  option (sebuf.http.service_headers) = {
+    required_headers: [
+      { name: "X-Tenant-ID" type: "string" required: true }
+    ]
+  };
+  rpc GetStock(GetStockRequest) returns (StockResponse) {
+    option (sebuf.http.config) = { path: "/stock/{sku}" method: HTTP_METHOD_GET };
+  }
+}
+
+message LookupRequest {
+  string sku = 1;
+  // Tenant to send to the inventory service instead of the propagated one.
+  string override_tenant = 2;
+}
+
+message GetStockRequest {
+  string sku = 1;
+}
+
+message StockResponse {
+  string sku = 1;
+  string tenant = 2;
+}
+`
+
+// headerPropagationIntegrationTestCode is the test source that runs inside the
+// temp module. The inventory server answers with the tenant it received; the
+// gateway handler calls it without touching headers unless told to override
+// the tenant.
+const headerPropagationIntegrationTestCode = `package header_propagation_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "header_propagation_test/gen"
+)
+
+type inventoryServer struct{}
+
+func (inventoryServer) GetStock(ctx context.Context, req *gen.GetStockRequest) (*gen.StockResponse, error) {
+	tenant := sebufhttp.HeadersFromContext(ctx).Get("X-Tenant-ID")
+	return &gen.StockResponse{Sku: req.GetSku(), Tenant: tenant}, nil
+}
+
+type gatewayServer struct {
+	inventory gen.InventoryServiceClient
+}
+
+func (s gatewayServer) Lookup(ctx context.Context, req *gen.LookupRequest) (*gen.StockResponse, error) {
+	var opts []gen.InventoryServiceCallOption
+	if req.GetOverrideTenant() != "" {
+		opts = append(opts, gen.WithInventoryServiceHeader("X-Tenant-ID", req.GetOverrideTenant()))
+	}
+	return s.inventory.GetStock(ctx, &gen.GetStockRequest{Sku: req.GetSku()}, opts...)
+}
+
+// newServers starts the inventory server and a gateway server whose client of
+// it propagates X-Tenant-ID, and returns the inventory URL and a client of the
+// gateway.
+func newServers(t *testing.T) (string, gen.GatewayServiceClient) {
+	t.Helper()
+	inventoryMux := http.NewServeMux()
+	if err := gen.RegisterInventoryServiceServer(inventoryServer{}, gen.WithMux(inventoryMux)); err != nil {
+		t.Fatal(err)
+	}
+	inventory := httptest.NewServer(inventoryMux)
+	t.Cleanup(inventory.Close)
+
+	gatewayMux := http.NewServeMux()
+	gateway := gatewayServer{
+		inventory: gen.NewInventoryServiceClient(inventory.URL,
+			gen.WithInventoryServiceHeaderPropagation("x-tenant-id"),
+		),
+	}
+	if err := gen.RegisterGatewayServiceServer(gateway, gen.WithMux(gatewayMux)); err != nil {
+		t.Fatal(err)
+	}
+	gatewaySrv := httptest.NewServer(gatewayMux)
+	t.Cleanup(gatewaySrv.Close)
+	return inventory.URL, gen.NewGatewayServiceClient(gatewaySrv.URL)
+}
+
+func TestTenantReachesDownstreamService(t *testing.T) {
+	_, gateway := newServers(t)
+	resp, err := gateway.Lookup(context.Background(), &gen.LookupRequest{Sku: "sku-1"},
+		gen.WithGatewayServiceHeader("X-Tenant-ID", "acme"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetSku() != "sku-1" || resp.GetTenant() != "acme" {
+		t.Errorf("Lookup = %v, want sku-1 for tenant acme", resp)
+	}
+}
+
+func TestCallHeadersOverridePropagatedOnes(t *testing.T) {
+	_, gateway := newServers(t)
+	resp, err := gateway.Lookup(context.Background(), &gen.LookupRequest{Sku: "sku-1", OverrideTenant: "globex"},
+		gen.WithGatewayServiceHeader("X-Tenant-ID", "acme"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetTenant() != "globex" {
+		t.Errorf("tenant %q, want the per-call globex", resp.GetTenant())
+	}
+}
+
+func TestNothingIsPropagatedOutsideAHandler(t *testing.T) {
+	inventoryURL, _ := newServers(t)
+	client := gen.NewInventoryServiceClient(inventoryURL, gen.WithInventoryServiceHeaderPropagation("X-Tenant-ID"))
+	_, err := client.GetStock(context.Background(), &gen.GetStockRequest{Sku: "sku-1"})
+	if err == nil {
+		t.Fatal("GetStock without a tenant succeeded, want the required-header error")
+	}
+}
+`
@@ -0,0 +1,222 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestHotReloadIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server from a proto with a unary method,
+//  2. writes a temporary Go module that serves it with httptest,
+//  3. verifies, under the race detector, that UpdateGreeterServiceServer swaps
+//     the implementation of every registration while requests are in flight,
+//     that each request is served wholly by one implementation, and that
+//     UnregisterGreeterServiceServer answers 503 until the next update.
+func TestHotReloadIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "hot_reload_test",
+		Protos:  map[string]string{"greeter.proto": hotReloadProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"hot_reload_test.go": hotReloadIntegrationTestCode},
+	}, "-race")
+}
+
+const hotReloadProto = `syntax = "proto3";
+package test.hotreload;
+option go_package = "hot_reload_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service GreeterService {
+  rpc Greet(GreetRequest) returns (Greeting) {
+    option (sebuf.http.config) = { path: "/greet" };
+  }
+}
+
+message GreetRequest {
+  string name = 1;
+}
+
+// Greeting names the implementation that started and finished the request.
+message Greeting {
+  string started_by = 1;
+  string finished_by = 2;
+  string message = 3;
+}
+`
+
+// hotReloadIntegrationTestCode is the test source that runs inside the temp
+// module. Each implementation stamps its version on the requests it serves.
+const hotReloadIntegrationTestCode = `package hot_reload_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	gen "hot_reload_test/gen"
+)
+
+type greeter struct {
+	version string
+	started chan struct{} // Signalled when a request starts, if set
+	release chan struct{} // Closed to let started requests finish, if set
+}
+
+func (g *greeter) Greet(_ context.Context, req *gen.GreetRequest) (*gen.Greeting, error) {
+	startedBy := g.version
+	if g.started != nil {
+		g.started <- struct{}{}
+		<-g.release
+	}
+	runtime.Gosched()
+	return &gen.Greeting{StartedBy: startedBy, FinishedBy: g.version, Message: g.version + ":" + req.GetName()}, nil
+}
+
+func serve(t *testing.T) string {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterGreeterServiceServer(&greeter{version: "v0"}, gen.WithMux(mux)); err != nil {
+		t.Fatalf("RegisterGreeterServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	// Every test starts from a registered v0, whatever the previous one left
+	gen.UpdateGreeterServiceServer(&greeter{version: "v0"})
+	return srv.URL
+}
+
+type reply struct {
+	status int
+	body   map[string]any
+}
+
+func greet(url, name string) (reply, error) {
+	resp, err := http.Post(url+"/greet", "application/json", strings.NewReader("{\"name\":\""+name+"\"}"))
+	if err != nil {
+		return reply{}, err
+	}
+	defer resp.Body.Close()
+	r := reply{status: resp.StatusCode}
+	return r, json.NewDecoder(resp.Body).Decode(&r.body)
+}
+
+func mustGreet(t *testing.T, url string) reply {
+	t.Helper()
+	r, err := greet(url, "ada")
+	if err != nil {
+		t.Fatalf("greet: %v", err)
+	}
+	return r
+}
+
+func TestUpdateReachesEveryRegistration(t *testing.T) {
+	first, second := serve(t), serve(t)
+	gen.UpdateGreeterServiceServer(&greeter{version: "v1"})
+	for _, url := range []string{first, second} {
+		if r := mustGreet(t, url); r.status != http.StatusOK || r.body["message"] != "v1:ada" {
+			t.Errorf("after update: status %d, body %v; want 200 v1:ada", r.status, r.body)
+		}
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	url := serve(t)
+	gen.UnregisterGreeterServiceServer()
+	if r := mustGreet(t, url); r.status != http.StatusServiceUnavailable || r.body["code"] != "UNAVAILABLE" {
+		t.Errorf("unregistered: status %d, body %v; want 503 UNAVAILABLE", r.status, r.body)
+	}
+	gen.UpdateGreeterServiceServer(&greeter{version: "v2"})
+	if r := mustGreet(t, url); r.status != http.StatusOK || r.body["message"] != "v2:ada" {
+		t.Errorf("re-registered: status %d, body %v; want 200 v2:ada", r.status, r.body)
+	}
+}
+
+// TestInFlightRequestKeepsImplementation checks a request started before an
+// update finishes on the implementation it started with.
+func TestInFlightRequestKeepsImplementation(t *testing.T) {
+	url := serve(t)
+	old := &greeter{version: "old", started: make(chan struct{}, 1), release: make(chan struct{})}
+	gen.UpdateGreeterServiceServer(old)
+
+	inFlight := make(chan reply, 1)
+	go func() {
+		r, err := greet(url, "ada")
+		if err != nil {
+			t.Errorf("greet: %v", err)
+		}
+		inFlight <- r
+	}()
+	<-old.started
+
+	gen.UpdateGreeterServiceServer(&greeter{version: "new"})
+	if r := mustGreet(t, url); r.body["message"] != "new:ada" {
+		t.Errorf("request after update: body %v, want new:ada", r.body)
+	}
+	close(old.release)
+	if r := <-inFlight; r.status != http.StatusOK || r.body["message"] != "old:ada" {
+		t.Errorf("in-flight request: status %d, body %v; want 200 old:ada", r.status, r.body)
+	}
+}
+
+// TestSwapUnderLoad swaps implementations and unregisters while clients send
+// requests; every response must come wholly from a single implementation.
+func TestSwapUnderLoad(t *testing.T) {
+	url := serve(t)
+	versions := []*greeter{{version: "a"}, {version: "b"}, {version: "c"}}
+
+	var stop atomic.Bool
+	var served, unavailable atomic.Int64
+	var clients sync.WaitGroup
+	for range 8 {
+		clients.Go(func() {
+			for !stop.Load() {
+				r, err := greet(url, "ada")
+				if err != nil {
+					t.Errorf("greet: %v", err)
+					return
+				}
+				switch r.status {
+				case http.StatusOK:
+					served.Add(1)
+					version, _ := r.body["startedBy"].(string)
+					if r.body["finishedBy"] != version || r.body["message"] != version+":ada" {
+						t.Errorf("torn response: %v", r.body)
+					}
+				case http.StatusServiceUnavailable:
+					unavailable.Add(1)
+				default:
+					t.Errorf("unexpected status %d: %v", r.status, r.body)
+				}
+			}
+		})
+	}
+
+	// Keep swapping until enough requests overlapped the swaps
+	for i := 0; served.Load() < 500 && !t.Failed(); i++ {
+		if i%10 == 9 {
+			gen.UnregisterGreeterServiceServer()
+		} else {
+			gen.UpdateGreeterServiceServer(versions[i%len(versions)])
+		}
+		runtime.Gosched()
+	}
+	gen.UpdateGreeterServiceServer(versions[0])
+	stop.Store(true)
+	clients.Wait()
+
+	if served.Load() == 0 {
+		t.Error("no request was served during the swaps")
+	}
+	t.Logf("served %d, unavailable %d", served.Load(), unavailable.Load())
+	if r := mustGreet(t, url); r.status != http.StatusOK || r.body["message"] != "a:ada" {
+		t.Errorf("after the swaps: status %d, body %v; want 200 a:ada", r.status, r.body)
+	}
+}
+`
@@ -0,0 +1,116 @@
+package httpgen
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestIdempotencyGeneration verifies that the service calls of methods annotated
+// with idempotency: true are wrapped in sebufhttp.IdempotencyMiddleware, inside
+// binding and authentication, sharing one store per service, and that the 409
+// error codes are mapped.
+func TestIdempotencyGeneration(t *testing.T) {
+	files := generateTestFiles(t, "http_verbs_comprehensive.proto")
+
+	t.Run("idempotent method is wrapped", func(t *testing.T) {
+		want := "createResourceHandler := sebufhttp.IdempotencyMiddleware(\n" +
+			"\t\tgenericHandler(server.CreateResource,"
+		if !strings.Contains(files.http, want) {
+			t.Error("CreateResource service call should be wrapped in IdempotencyMiddleware")
+		}
+		want = "\t\tidempotencyStore, \"test.httpgen.RESTfulAPIService.CreateResource\", config.idempotencyTTL, " +
+			"config.maxBodySize,\n\t\tconfig.writeError,\n\t)\n" +
+			"\tcreateResourceHandler = BindingMiddleware[CreateResourceRequest](\n" +
+			"\t\tcreateResourceHandler, serviceHeaders, methodHeaders,"
+		if !strings.Contains(files.http, want) {
+			t.Error("IdempotencyMiddleware should run inside BindingMiddleware, bounded by the max body size")
+		}
+	})
+
+	t.Run("other methods are not wrapped", func(t *testing.T) {
+		if n := strings.Count(files.http, "sebufhttp.IdempotencyMiddleware("); n != 1 {
+			t.Errorf("expected exactly one IdempotencyMiddleware call, got %d", n)
+		}
+	})
+
+	t.Run("store defaults to in-memory once per service", func(t *testing.T) {
+		if n := strings.Count(files.http, "idempotencyStore = sebufhttp.NewMemoryIdempotencyStore()"); n != 1 {
+			t.Errorf("expected one default store for RESTfulAPIService, got %d", n)
+		}
+	})
+
+	t.Run("options are generated", func(t *testing.T) {
+		for _, want := range []string{
+			"func WithIdempotencyStore(store sebufhttp.IdempotencyStore) ServerOption",
+			"func WithIdempotencyTTL(ttl time.Duration) ServerOption",
+		} {
+			if !strings.Contains(files.config, want) {
+				t.Errorf("config file missing %q", want)
+			}
+		}
+	})
+
+	t.Run("conflict codes map to 409", func(t *testing.T) {
+		want := "case sebufhttp.ErrorCodeIdempotencyConflict, sebufhttp.ErrorCodeIdempotencyInProgress:\n" +
+			"\t\t\treturn http.StatusConflict"
+		if !strings.Contains(files.binding, want) {
+			t.Error("defaultErrorStatusCode should map the idempotency error codes to 409")
+		}
+	})
+}
+
+// TestIdempotencyRejectedOnStream verifies generation fails when a streaming
+// method is annotated with idempotency: true.
+func TestIdempotencyRejectedOnStream(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping idempotency validation test")
+	}
+
+	baseDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	projectRoot := filepath.Join(baseDir, "..", "..")
+	pluginPath := filepath.Join(projectRoot, "bin", "protoc-gen-go-http")
+	if _, statErr := os.Stat(pluginPath); os.IsNotExist(statErr) {
+		t.Skip("protoc-gen-go-http not built, run make build")
+	}
+
+	protoDir := t.TempDir()
+	const protoSrc = `syntax = "proto3";
+package test.idempotency;
+option go_package = "example.com/idempotency;idempotency";
+import "sebuf/http/annotations.proto";
+service Events {
+  rpc Watch(WatchRequest) returns (Event) {
+    option (sebuf.http.config) = { path: "/watch" method: HTTP_METHOD_POST stream: true idempotency: true };
+  }
+}
+message WatchRequest {}
+message Event {}
+`
+	if writeErr := os.WriteFile(filepath.Join(protoDir, "stream.proto"), []byte(protoSrc), 0o600); writeErr != nil {
+		t.Fatalf("Failed to write proto: %v", writeErr)
+	}
+
+	cmd := exec.Command("protoc",
+		"--plugin=protoc-gen-go-http="+pluginPath,
+		"--go-http_out="+t.TempDir(),
+		"--go-http_opt=paths=source_relative",
+		"--proto_path="+protoDir,
+		"--proto_path="+filepath.Join(projectRoot, "proto"),
+		"stream.proto",
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if runErr := cmd.Run(); runErr == nil {
+		t.Fatal("expected generation to fail for an idempotent streaming method")
+	}
+	if !strings.Contains(stderr.String(), "idempotency is not supported on streaming methods") {
+		t.Errorf("unexpected error output: %s", stderr.String())
+	}
+}
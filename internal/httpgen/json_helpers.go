@@ -0,0 +1,63 @@
+package httpgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// collectCustomJSONMessages recursively collects the messages of a file that an
+// encoder gives custom JSON serialization, in declaration order.
+func collectCustomJSONMessages(messages []*protogen.Message, encoded map[string]bool, out *[]*protogen.Message) {
+	for _, msg := range messages {
+		if msg.Desc.IsMapEntry() {
+			continue
+		}
+		if encoded[string(msg.Desc.FullName())] || annotations.HasJSONNaming(msg) {
+			*out = append(*out, msg)
+		}
+		collectCustomJSONMessages(msg.Messages, encoded, out)
+	}
+}
+
+// generateJSONHelpersFile generates the *_json_helpers.pb.go file if needed. It
+// gives every message with custom JSON serialization exported Marshal<Message>JSON
+// and Unmarshal<Message>JSON functions, so code outside the HTTP handlers (queue
+// consumers, caches) reads and writes the JSON the handlers do. Both go through
+// the sebufhttp functions the generated binding uses.
+func (g *Generator) generateJSONHelpersFile(file *protogen.File, unwrapMsgNames map[string]bool) {
+	var messages []*protogen.Message
+	collectCustomJSONMessages(file.Messages, collectEncodedMessageNames(file, unwrapMsgNames), &messages)
+	if len(messages) == 0 {
+		return
+	}
+
+	filename := file.GeneratedFilenamePrefix + "_json_helpers.pb.go"
+	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
+
+	g.writeHeader(gf, file)
+	gf.P("import (")
+	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
+	gf.P()
+	gf.P(`sebufhttp "github.com/SebastienMelki/sebuf/http"`)
+	gf.P(")")
+	gf.P()
+
+	for _, msg := range messages {
+		msgName := msg.GoIdent.GoName
+
+		gf.P("// Marshal", msgName, "JSON encodes msg as the generated HTTP handlers of a server")
+		gf.P("// without options write it, applying the custom JSON mapping of ", msgName, ".")
+		gf.P("func Marshal", msgName, "JSON(msg *", msgName, ") ([]byte, error) {")
+		gf.P("return sebufhttp.MarshalMessageJSON(msg, protojson.MarshalOptions{})")
+		gf.P("}")
+		gf.P()
+
+		gf.P("// Unmarshal", msgName, "JSON decodes data into msg as the generated HTTP handlers")
+		gf.P("// bind a JSON body, applying the custom JSON mapping of ", msgName, ".")
+		gf.P("func Unmarshal", msgName, "JSON(data []byte, msg *", msgName, ") error {")
+		gf.P("return sebufhttp.UnmarshalMessageJSON(data, msg, protojson.UnmarshalOptions{})")
+		gf.P("}")
+		gf.P()
+	}
+}
@@ -0,0 +1,229 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestJSONHelpersIntegration generates a Go HTTP server and checks that the
+// exported Marshal<Message>JSON/Unmarshal<Message>JSON helpers and
+// Decode<Method>Request produce byte-for-byte what the HTTP handlers read and
+// write, for messages with flatten, int64 NUMBER and enum_value mappings.
+func TestJSONHelpersIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "json_helpers_test",
+		Protos:  map[string]string{"orders.proto": jsonHelpersProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"json_helpers_test.go": jsonHelpersIntegrationTestCode},
+	})
+}
+
+const jsonHelpersProto = `syntax = "proto3";
+package test.jsonhelpers;
+option go_package = "json_helpers_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service OrderService {
+  rpc PutOrder(Order) returns (Order) {
+    option (sebuf.http.config) = { path: "/orders/{id}" method: HTTP_METHOD_PUT };
+  }
+  rpc CreateShipment(Shipment) returns (Shipment) {
+    option (sebuf.http.config) = { path: "/shipments" method: HTTP_METHOD_POST };
+  }
+  rpc GetShipment(GetShipmentRequest) returns (Shipment) {
+    option (sebuf.http.config) = { path: "/shipments/{id}" method: HTTP_METHOD_GET };
+  }
+}
+
+enum Status {
+  STATUS_UNSPECIFIED = 0 [(sebuf.http.enum_value) = "unknown"];
+  STATUS_PAID = 1 [(sebuf.http.enum_value) = "paid"];
+}
+
+message Order {
+  string id = 1;
+  int64 total_cents = 2 [(sebuf.http.int64_encoding) = INT64_ENCODING_NUMBER];
+}
+
+// Receipt is only exchanged through queues
+message Receipt {
+  string order_id = 1;
+  Status status = 2;
+}
+
+message Address {
+  string city = 1;
+  string zip = 2;
+}
+
+message Shipment {
+  string id = 1;
+  Address destination = 2 [(sebuf.http.flatten) = true, (sebuf.http.flatten_prefix) = "dest_"];
+}
+
+message GetShipmentRequest {
+  string id = 1;
+  string view = 2 [(sebuf.http.query) = { name: "view" }];
+}
+`
+
+// jsonHelpersIntegrationTestCode is the test source that runs inside the temp
+// module. The server echoes the requests it binds, so its response bodies are
+// what the handlers read and write.
+const jsonHelpersIntegrationTestCode = `package json_helpers_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	gen "json_helpers_test/gen"
+)
+
+type echoServer struct{}
+
+func (echoServer) PutOrder(_ context.Context, req *gen.Order) (*gen.Order, error) {
+	return req, nil
+}
+
+func (echoServer) CreateShipment(_ context.Context, req *gen.Shipment) (*gen.Shipment, error) {
+	return req, nil
+}
+
+func (echoServer) GetShipment(_ context.Context, req *gen.GetShipmentRequest) (*gen.Shipment, error) {
+	return &gen.Shipment{Id: req.GetId() + "/" + req.GetView()}, nil
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterOrderServiceServer(echoServer{}, gen.WithMux(mux)); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// roundTrip sends body to the server and returns the response body.
+func roundTrip(t *testing.T, srv *httptest.Server, method, path string, body []byte) []byte {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	got, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d: %s", resp.StatusCode, got)
+	}
+	return got
+}
+
+func TestQueueAndHTTPPathsAreByteIdentical(t *testing.T) {
+	srv := newServer(t)
+
+	order := &gen.Order{Id: "o-1", TotalCents: 1234}
+	queued, err := gen.MarshalOrderJSON(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(strings.ReplaceAll(string(queued), " ", ""), "\"totalCents\":1234") {
+		t.Errorf("MarshalOrderJSON = %s, want totalCents as a number", queued)
+	}
+	if served := roundTrip(t, srv, http.MethodPut, "/orders/o-1", queued); !bytes.Equal(served, queued) {
+		t.Errorf("HTTP response %s, queue payload %s", served, queued)
+	}
+
+	shipment := &gen.Shipment{Id: "s-1", Destination: &gen.Address{City: "Paris", Zip: "75001"}}
+	queued, err = gen.MarshalShipmentJSON(shipment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(queued), "dest_city") {
+		t.Errorf("MarshalShipmentJSON = %s, want the flattened fields", queued)
+	}
+	if served := roundTrip(t, srv, http.MethodPost, "/shipments", queued); !bytes.Equal(served, queued) {
+		t.Errorf("HTTP response %s, queue payload %s", served, queued)
+	}
+
+	// A message consumed from the queue is the one the handler binds
+	var consumed gen.Shipment
+	if err := gen.UnmarshalShipmentJSON(queued, &consumed); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(&consumed, shipment) {
+		t.Errorf("UnmarshalShipmentJSON = %v, want %v", &consumed, shipment)
+	}
+}
+
+func TestHelpersOfMessagesWithoutMethods(t *testing.T) {
+	receipt := &gen.Receipt{OrderId: "o-1", Status: gen.Status_STATUS_PAID}
+	data, err := gen.MarshalReceiptJSON(receipt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(strings.ReplaceAll(string(data), " ", ""), "\"status\":\"paid\"") {
+		t.Errorf("MarshalReceiptJSON = %s, want the enum_value mapping", data)
+	}
+	var consumed gen.Receipt
+	if err := gen.UnmarshalReceiptJSON(data, &consumed); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(&consumed, receipt) {
+		t.Errorf("UnmarshalReceiptJSON = %v, want %v", &consumed, receipt)
+	}
+}
+
+func TestDecodeRequestBindsLikeTheHandler(t *testing.T) {
+	payload := []byte("{\"totalCents\":99}")
+	r := httptest.NewRequest(http.MethodPut, "/orders/o-2", bytes.NewReader(payload))
+	r.Header.Set("Content-Type", "application/json")
+	r.SetPathValue("id", "o-2")
+
+	order, err := gen.DecodePutOrderRequest(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &gen.Order{Id: "o-2", TotalCents: 99}
+	if !proto.Equal(order, want) {
+		t.Errorf("DecodePutOrderRequest = %v, want %v", order, want)
+	}
+	served := roundTrip(t, newServer(t), http.MethodPut, "/orders/o-2", payload)
+	if encoded, _ := gen.MarshalOrderJSON(order); !bytes.Equal(served, encoded) {
+		t.Errorf("HTTP response %s, decoded request %s", served, encoded)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/shipments/s-3?view=full", nil)
+	r.SetPathValue("id", "s-3")
+	get, err := gen.DecodeGetShipmentRequest(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if get.GetId() != "s-3" || get.GetView() != "full" {
+		t.Errorf("DecodeGetShipmentRequest = %v", get)
+	}
+
+	r = httptest.NewRequest(http.MethodPut, "/orders/o-4", strings.NewReader("{not json"))
+	r.Header.Set("Content-Type", "application/json")
+	r.SetPathValue("id", "o-4")
+	if _, err := gen.DecodePutOrderRequest(r); err == nil {
+		t.Error("DecodePutOrderRequest accepted a malformed body")
+	}
+}
+`
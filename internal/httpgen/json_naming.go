@@ -0,0 +1,153 @@
+package httpgen
+
+import (
+	"maps"
+	"slices"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/encodinggen"
+)
+
+// collectEncodedMessageNames returns the full names of the messages that another
+// encoder gives a custom MarshalJSON. Those encoders apply the json_naming policy
+// themselves, so the json_naming file must not redeclare their methods.
+func collectEncodedMessageNames(file *protogen.File, unwrapMsgNames map[string]bool) map[string]bool {
+	names := make(map[string]bool)
+	maps.Copy(names, unwrapMsgNames)
+	add := func(msg *protogen.Message) {
+		names[string(msg.Desc.FullName())] = true
+	}
+
+	directMsgNames := encodinggen.CollectDirectEncodingMsgNames(file)
+	maps.Copy(names, directMsgNames)
+	for _, ctx := range encodinggen.CollectWrapperContexts(file, directMsgNames, unwrapMsgNames) {
+		add(ctx.Message)
+	}
+	for _, ctx := range encodinggen.CollectEnumFieldEncodingContext(file) {
+		add(ctx.Message)
+	}
+	for _, ctx := range collectNullableContext(file) {
+		add(ctx.Message)
+	}
+	for _, ctx := range collectEmptyBehaviorContext(file) {
+		add(ctx.Message)
+	}
+	for _, ctx := range encodinggen.CollectTimestampFormatContext(file) {
+		add(ctx.Message)
+	}
+	for _, ctx := range encodinggen.CollectBytesEncodingContext(file) {
+		add(ctx.Message)
+	}
+	for _, ctx := range collectFlattenContexts(file) {
+		add(ctx.Message)
+	}
+	for _, ctx := range collectOneofDiscriminatorContext(file) {
+		add(ctx.Message)
+	}
+	return names
+}
+
+// collectJSONNamingMessages recursively collects the messages serialized through
+// sebufhttp.MarshalProtoJSON that no other encoder handles.
+func collectJSONNamingMessages(messages []*protogen.Message, encoded map[string]bool, out *[]*protogen.Message) {
+	for _, msg := range messages {
+		if msg.Desc.IsMapEntry() {
+			continue
+		}
+		if annotations.HasJSONNaming(msg) && !encoded[string(msg.Desc.FullName())] {
+			*out = append(*out, msg)
+		}
+		collectJSONNamingMessages(msg.Messages, encoded, out)
+	}
+}
+
+// generateJSONNamingFile generates the *_json_naming.pb.go file if needed. It
+// gives messages whose JSON field names follow a json_naming policy, and messages
+// nesting them, the MarshalJSON methods that apply it.
+func (g *Generator) generateJSONNamingFile(file *protogen.File, unwrapMsgNames map[string]bool) error {
+	var messages []*protogen.Message
+	collectJSONNamingMessages(file.Messages, collectEncodedMessageNames(file, unwrapMsgNames), &messages)
+	if len(messages) == 0 {
+		return nil
+	}
+
+	filename := file.GeneratedFilenamePrefix + "_json_naming.pb.go"
+	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
+
+	g.writeHeader(gf, file)
+	gf.P("import (")
+	if slices.ContainsFunc(messages, rejectsAlternateJSONNames) {
+		gf.P(`"encoding/json"`)
+		gf.P()
+	}
+	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
+	encodinggen.WriteJSONNamingImport(gf, true)
+	gf.P(")")
+	gf.P()
+
+	for _, msg := range messages {
+		g.generateJSONNamingMarshalJSON(gf, msg)
+		g.generateJSONNamingUnmarshalJSON(gf, msg)
+	}
+
+	return nil
+}
+
+// generateJSONNamingMarshalJSON generates MarshalJSON that renames fields after
+// their json_naming policy.
+func (g *Generator) generateJSONNamingMarshalJSON(gf *protogen.GeneratedFile, msg *protogen.Message) {
+	msgName := msg.GoIdent.GoName
+
+	gf.P("// MarshalJSONSebuf implements sebufMarshaler for ", msgName, ".")
+	gf.P("// This method names JSON fields after their json_naming policy.")
+	gf.P(
+		"func (x *",
+		msgName,
+		") MarshalJSONSebuf(opts protojson.MarshalOptions) ([]byte, error) {",
+	)
+	gf.P("if x == nil {")
+	gf.P("return []byte(\"null\"), nil")
+	gf.P("}")
+	gf.P("return sebufhttp.MarshalProtoJSON(opts, x)")
+	gf.P("}")
+	gf.P()
+
+	// Backward-compatible MarshalJSON wrapper for stdlib encoding/json.
+	gf.P("// MarshalJSON implements json.Marshaler for ", msgName, ".")
+	gf.P("func (x *", msgName, ") MarshalJSON() ([]byte, error) {")
+	gf.P("return x.MarshalJSONSebuf(protojson.MarshalOptions{})")
+	gf.P("}")
+	gf.P()
+}
+
+// rejectsAlternateJSONNames reports whether the UnmarshalJSON of a json_naming
+// message must reject the alternate names protojson would accept.
+func rejectsAlternateJSONNames(msg *protogen.Message) bool {
+	return annotations.RejectsAlternateJSONNames(msg) && annotations.AlternateJSONNames(msg) != nil
+}
+
+// generateJSONNamingUnmarshalJSON generates UnmarshalJSON, which protojson
+// handles alone since it accepts proto field names as well as JSON names,
+// unless the message rejects the names it does not use.
+func (g *Generator) generateJSONNamingUnmarshalJSON(gf *protogen.GeneratedFile, msg *protogen.Message) {
+	msgName := msg.GoIdent.GoName
+
+	if rejectsAlternateJSONNames(msg) {
+		encodinggen.WriteAlternateJSONNames(gf, msg)
+	}
+	gf.P("// UnmarshalJSON implements json.Unmarshaler for ", msgName, ".")
+	gf.P("// protojson accepts the proto field names of json_naming SNAKE_CASE messages.")
+	gf.P("func (x *", msgName, ") UnmarshalJSON(data []byte) error {")
+	if rejectsAlternateJSONNames(msg) {
+		gf.P("var raw map[string]json.RawMessage")
+		gf.P("if err := json.Unmarshal(data, &raw); err != nil {")
+		gf.P("return err")
+		gf.P("}")
+		encodinggen.WriteResolveAlternateJSONNames(gf, msg)
+	}
+	gf.P("return protojson.Unmarshal(data, x)")
+	gf.P("}")
+	gf.P()
+}
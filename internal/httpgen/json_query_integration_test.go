@@ -0,0 +1,184 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestJSONQueryParamsIntegration generates a Go HTTP server and Go client for a
+// GET method taking a list of filters, nested messages with an enum, as a
+// JSON_BASE64URL query parameter, and checks that the filters the client sends
+// reach the handler intact, that malformed and oversized values are answered
+// with a violation naming the parameter, and that the value of equal filters
+// does not change between calls.
+func TestJSONQueryParamsIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "json_query_test",
+		Protos:  map[string]string{"orders.proto": jsonQueryProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}, {Name: "go-client"}},
+		Files:   map[string]string{"json_query_test.go": jsonQueryIntegrationTestCode},
+	})
+}
+
+const jsonQueryProto = `syntax = "proto3";
+package test.jsonquery;
+option go_package = "json_query_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service OrderService {
+  rpc SearchOrders(SearchOrdersRequest) returns (SearchOrdersResponse) {
+    option (sebuf.http.config) = { path: "/orders" method: HTTP_METHOD_GET };
+  }
+}
+
+enum FilterOp {
+  FILTER_OP_UNSPECIFIED = 0;
+  FILTER_OP_EQ = 1 [(sebuf.http.enum_value) = "eq"];
+  FILTER_OP_GT = 2 [(sebuf.http.enum_value) = "gt"];
+}
+
+message Filter {
+  string field = 1;
+  FilterOp op = 2;
+  FilterValue value = 3;
+}
+
+message FilterValue {
+  string text = 1;
+  repeated int64 numbers = 2;
+}
+
+message SearchOrdersRequest {
+  repeated Filter filters = 1 [(sebuf.http.query) = {
+    name: "filter"
+    encoding: QUERY_ENCODING_JSON_BASE64URL
+    max_bytes: 256
+  }];
+  int32 page_size = 2 [(sebuf.http.query) = { name: "page_size" }];
+}
+
+message SearchOrdersResponse {
+  // The filters the server bound, echoed back.
+  repeated Filter filters = 1;
+  int32 page_size = 2;
+}
+`
+
+// jsonQueryIntegrationTestCode is the test source that runs inside the temp
+// module. The server echoes the filters it bound.
+const jsonQueryIntegrationTestCode = `package json_query_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "json_query_test/gen"
+)
+
+type orderServer struct{}
+
+func (orderServer) SearchOrders(_ context.Context, req *gen.SearchOrdersRequest) (*gen.SearchOrdersResponse, error) {
+	return &gen.SearchOrdersResponse{Filters: req.GetFilters(), PageSize: req.GetPageSize()}, nil
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterOrderServiceServer(orderServer{}, gen.WithMux(mux)); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+var filters = []*gen.Filter{
+	{Field: "status", Op: gen.FilterOp_FILTER_OP_EQ, Value: &gen.FilterValue{Text: "shipped"}},
+	{Field: "total", Op: gen.FilterOp_FILTER_OP_GT, Value: &gen.FilterValue{Numbers: []int64{100, 9007199254740993}}},
+}
+
+func TestFiltersRoundTrip(t *testing.T) {
+	client := gen.NewOrderServiceClient(newServer(t).URL)
+	resp, err := client.SearchOrders(context.Background(), &gen.SearchOrdersRequest{Filters: filters, PageSize: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &gen.SearchOrdersResponse{Filters: filters, PageSize: 20}
+	if !proto.Equal(resp, want) {
+		t.Errorf("SearchOrders = %v, want %v", resp, want)
+	}
+}
+
+func TestFilterValueUsesEnumValueNames(t *testing.T) {
+	path := gen.OrderServiceSearchOrdersURL(&gen.SearchOrdersRequest{Filters: filters})
+	u, err := url.Parse(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := base64.RawURLEncoding.DecodeString(u.Query().Get("filter"))
+	if err != nil {
+		t.Fatalf("filter %q is not unpadded base64url: %v", u.Query().Get("filter"), err)
+	}
+	var decoded []map[string]any
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("filter is not a JSON array: %v\n%s", err, data)
+	}
+	if len(decoded) != 2 || decoded[0]["op"] != "eq" || decoded[1]["op"] != "gt" {
+		t.Errorf("filter = %s, want ops eq and gt", data)
+	}
+	if again := gen.OrderServiceSearchOrdersURL(&gen.SearchOrdersRequest{Filters: filters}); again != path {
+		t.Errorf("equal filters gave %q and %q, want the same URL", path, again)
+	}
+}
+
+func TestBadFilterValuesAreRejected(t *testing.T) {
+	srv := newServer(t)
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"not base64url", "%%%", "not base64url"},
+		{"not an array", encode("{}"), "not a JSON array"},
+		{"bad item", encode("[{\"value\":{\"numbers\":[\"x\"]}}]"), "item 0 is not a JSON Filter"},
+		{"too large", encode("[" + strings.Repeat("{},", 100) + "{}]"), "exceeds the limit of 256 bytes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(srv.URL + "/orders?filter=" + url.QueryEscape(tt.value))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Fatalf("status %d, want 400: %s", resp.StatusCode, body)
+			}
+			var verr sebufhttp.ValidationError
+			if err = protojson.Unmarshal(body, &verr); err != nil {
+				t.Fatalf("body is not a ValidationError: %v\n%s", err, body)
+			}
+			violations := verr.GetViolations()
+			if len(violations) != 1 || violations[0].GetField() != "filters" ||
+				!strings.Contains(violations[0].GetDescription(), "query parameter filter") ||
+				!strings.Contains(violations[0].GetDescription(), tt.want) {
+				t.Errorf("violations = %v, want one on filters mentioning %q", violations, tt.want)
+			}
+		})
+	}
+}
+`
@@ -0,0 +1,183 @@
+package httpgen
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/internal/manifest"
+)
+
+// registeredRoutePattern matches the routes the generated Go code registers on
+// a ServeMux, chi and gorilla/mux router, capturing their verb and path.
+var registeredRoutePattern = regexp.MustCompile(`config\.mux\.Handle\("(?P<verb>[A-Z]+) (?P<path>[^"]+)"|` +
+	`r\.Method\("(?P<verb>[A-Z]+)", "(?P<path>[^"]+)"|` +
+	`r\.Handle\("(?P<path>[^"]+)", .*\)\.Methods\("(?P<verb>[A-Z]+)"\)`)
+
+// fixtureParams holds the plugin parameters a golden fixture needs to generate.
+var fixtureParams = map[string]string{
+	"auto_options":        ",auto_options=true,trailing_slash=ignore",
+	"grpc_gateway_compat": ",compat=grpc_gateway",
+	"router_chi":          ",router=chi,trailing_slash=redirect",
+	"router_gorilla":      ",router=gorilla,trailing_slash=ignore",
+}
+
+// fixtureRouter returns the router a golden fixture is generated for.
+func fixtureRouter(prefix string) Router {
+	params := fixtureParams[prefix]
+	for _, router := range []Router{RouterChi, RouterGorilla} {
+		if strings.Contains(params, "router="+string(router)) {
+			return router
+		}
+	}
+	return RouterStdlib
+}
+
+// registeredRoutes returns the verb and path of the canonical routes golden
+// registers, leaving out the trailing-slash forms and the HEAD and OPTIONS
+// routes the manifest does not list.
+func registeredRoutes(golden string) []string {
+	var routes []string
+	for _, match := range registeredRoutePattern.FindAllStringSubmatch(golden, -1) {
+		var verb, path string
+		for i, name := range registeredRoutePattern.SubexpNames() {
+			switch {
+			case match[i] == "":
+			case name == "verb":
+				verb = match[i]
+			case name == "path":
+				path = match[i]
+			}
+		}
+		if strings.HasSuffix(path, "/{$}") || (path != "/" && strings.HasSuffix(path, "/")) {
+			continue
+		}
+		if verb == "HEAD" || verb == "OPTIONS" {
+			continue
+		}
+		routes = append(routes, verb+" "+path)
+	}
+	return routes
+}
+
+// TestManifestMatchesRegisteredRoutes verifies that, for every golden fixture,
+// the routes listed in sebuf.manifest.json are exactly the routes registered by
+// the golden *_http.pb.go file.
+func TestManifestMatchesRegisteredRoutes(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping manifest test")
+	}
+
+	baseDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	projectRoot := filepath.Join(baseDir, "..", "..")
+	protoDir := filepath.Join(baseDir, "testdata", "proto")
+	goldenFiles, err := filepath.Glob(filepath.Join(baseDir, "testdata", "golden", "*_http.pb.go"))
+	if err != nil || len(goldenFiles) == 0 {
+		t.Fatalf("no golden files found: %v", err)
+	}
+
+	for _, goldenPath := range goldenFiles {
+		prefix := strings.TrimSuffix(filepath.Base(goldenPath), "_http.pb.go")
+		protoFile := prefix + ".proto"
+		if _, statErr := os.Stat(filepath.Join(protoDir, protoFile)); statErr != nil {
+			continue
+		}
+		t.Run(prefix, func(t *testing.T) {
+			golden, readErr := os.ReadFile(goldenPath)
+			if readErr != nil {
+				t.Fatal(readErr)
+			}
+			want := registeredRoutes(string(golden))
+			g := Generator{router: fixtureRouter(prefix)}
+
+			req := descriptorRequest(t, protoDir, projectRoot, protoFile, "paths=source_relative,manifest=true"+fixtureParams[prefix])
+			resp, runErr := Run(req, Options{})
+			if runErr != nil || resp.GetError() != "" {
+				t.Fatalf("Run: %v %s", runErr, resp.GetError())
+			}
+			m := readManifest(t, resp)
+			var got []string
+			for _, service := range m.Services {
+				if service.ProtoFile != protoFile {
+					t.Errorf("service %s has protoFile %q, want %q", service.Name, service.ProtoFile, protoFile)
+				}
+				for _, method := range service.Methods {
+					for _, route := range method.Routes {
+						got = append(got, route.Method+" "+g.routerPattern(route.Path))
+					}
+				}
+			}
+			slices.Sort(got)
+			slices.Sort(want)
+			if !slices.Equal(got, want) {
+				t.Errorf("manifest routes = %v\nregistered routes = %v", got, want)
+			}
+			if !slices.ContainsFunc(m.Outputs, func(o manifest.Output) bool { return o.Name == filepath.Base(goldenPath) }) {
+				t.Errorf("manifest outputs %v do not list %s", m.Outputs, filepath.Base(goldenPath))
+			}
+		})
+	}
+}
+
+// descriptorRequest compiles protoFile with protoc into a CodeGeneratorRequest
+// carrying parameter.
+func descriptorRequest(
+	t *testing.T,
+	protoDir, projectRoot, protoFile, parameter string,
+) *pluginpb.CodeGeneratorRequest {
+	t.Helper()
+	descPath := filepath.Join(t.TempDir(), "descriptors.pb")
+	cmd := exec.Command("protoc",
+		"--descriptor_set_out="+descPath,
+		"--include_imports",
+		"--include_source_info",
+		"--proto_path="+protoDir,
+		"--proto_path="+filepath.Join(projectRoot, "proto"),
+		protoFile,
+	)
+	if out, runErr := cmd.CombinedOutput(); runErr != nil {
+		t.Fatalf("protoc descriptor_set_out failed: %v\noutput: %s", runErr, out)
+	}
+	raw, err := os.ReadFile(descPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fds descriptorpb.FileDescriptorSet
+	if err = proto.Unmarshal(raw, &fds); err != nil {
+		t.Fatal(err)
+	}
+	return &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{protoFile},
+		Parameter:      proto.String(parameter),
+		ProtoFile:      fds.GetFile(),
+	}
+}
+
+// readManifest decodes the manifest of resp.
+func readManifest(t *testing.T, resp *pluginpb.CodeGeneratorResponse) *manifest.Manifest {
+	t.Helper()
+	for _, file := range resp.GetFile() {
+		if file.GetName() != manifest.FileName {
+			continue
+		}
+		m := &manifest.Manifest{}
+		if err := json.Unmarshal([]byte(file.GetContent()), m); err != nil {
+			t.Fatalf("decode manifest: %v", err)
+		}
+		return m
+	}
+	t.Fatalf("response has no %s", manifest.FileName)
+	return nil
+}
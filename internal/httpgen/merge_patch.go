@@ -0,0 +1,130 @@
+package httpgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// generateBindMergePatchFunc generates bindMergePatch, which BindingMiddleware
+// calls for methods with patch_format MERGE_PATCH once the body is bound.
+func (g *Generator) generateBindMergePatchFunc(gf *protogen.GeneratedFile) {
+	gf.P("// bindMergePatch returns the merge patch of a request to a method with")
+	gf.P("// patch_format MERGE_PATCH, whose body bindRequest bound to toBind. JSON bodies,")
+	gf.P("// which bindRequest left readable, are read again for the fields they set and")
+	gf.P("// clear; protobuf bodies, which cannot clear fields, set the populated fields.")
+	gf.P("func bindMergePatch[Req any](r *http.Request, toBind *Req) (*sebufhttp.MergePatch, error) {")
+	gf.P("msg, ok := any(toBind).(proto.Message)")
+	gf.P("if !ok {")
+	gf.P("return nil, nil")
+	gf.P("}")
+	gf.P(`switch filterFlags(r.Header.Get("Content-Type")) {`)
+	if g.servesMsgpack() {
+		gf.P("case BinaryContentType, ProtoContentType, MsgpackContentType:")
+	} else {
+		gf.P("case BinaryContentType, ProtoContentType:")
+	}
+	gf.P("return sebufhttp.MergePatchOfMessage(msg.ProtoReflect()), nil")
+	gf.P("}")
+	gf.P()
+	gf.P("bodyBytes, err := io.ReadAll(r.Body)")
+	gf.P("r.Body = io.NopCloser(bytes.NewReader(bodyBytes))")
+	gf.P("if err != nil {")
+	gf.P("return nil, bodyBindingError(err)")
+	gf.P("}")
+	gf.P("patch, err := sebufhttp.ParseMergePatch(bodyBytes, msg.ProtoReflect().Descriptor())")
+	gf.P("if err != nil {")
+	gf.P("return nil, bodyBindingError(err)")
+	gf.P("}")
+	gf.P("return patch, nil")
+	gf.P("}")
+	gf.P()
+}
+
+// generateMergePatchViews generates the MergePatch<Message> view of the request
+// message of every method of file with patch_format MERGE_PATCH.
+func (g *Generator) generateMergePatchViews(gf *protogen.GeneratedFile, file *protogen.File) {
+	generated := make(map[protoreflect.FullName]bool)
+	for _, service := range file.Services {
+		for _, method := range service.Methods {
+			if !annotations.IsMergePatch(method) || generated[method.Input.Desc.FullName()] {
+				continue
+			}
+			generated[method.Input.Desc.FullName()] = true
+			g.generateMergePatchView(gf, method.Input)
+		}
+	}
+}
+
+// generateMergePatchView generates the MergePatch<Message> view of msg, with a
+// typed getter per field reporting whether the body sets it.
+func (g *Generator) generateMergePatchView(gf *protogen.GeneratedFile, msg *protogen.Message) {
+	msgType := gf.QualifiedGoIdent(msg.GoIdent)
+	viewName := "MergePatch" + msg.GoIdent.GoName
+
+	gf.P("// ", viewName, " is the JSON Merge Patch (RFC 7386) view of a ", msg.GoIdent.GoName)
+	gf.P("// bound by a method with patch_format MERGE_PATCH. It tells the fields the body")
+	gf.P("// sets apart from those it clears with null or leaves out, which both hold their")
+	gf.P("// zero value in the request.")
+	gf.P("type ", viewName, " struct {")
+	gf.P("patch *sebufhttp.MergePatch")
+	gf.P("req   *", msgType)
+	gf.P("}")
+	gf.P()
+
+	gf.P("// ", viewName, "FromContext returns the view of the ", msg.GoIdent.GoName)
+	gf.P("// being handled. Outside a method with patch_format MERGE_PATCH it sets no field.")
+	gf.P("func ", viewName, "FromContext(ctx context.Context) ", viewName, " {")
+	gf.P("return ", viewName, "{")
+	gf.P("patch: sebufhttp.MergePatchFromContext(ctx),")
+	gf.P("req:   getRequest[*", msgType, "](ctx),")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// Has reports whether the body names field, by its proto or JSON name, with a")
+	gf.P("// value or with null.")
+	gf.P("func (p ", viewName, ") Has(field string) bool {")
+	gf.P("return p.patch.Has(field)")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// IsNull reports whether the body clears field, named by its proto or JSON name,")
+	gf.P("// with null.")
+	gf.P("func (p ", viewName, ") IsNull(field string) bool {")
+	gf.P("return p.patch.IsNull(field)")
+	gf.P("}")
+	gf.P()
+
+	for _, field := range msg.Fields {
+		name := string(field.Desc.Name())
+		gf.P("// Get", field.GoName, " returns the ", name, " the body sets, and false when the body")
+		gf.P("// leaves it out or clears it.")
+		gf.P("func (p ", viewName, ") Get", field.GoName, "() (", g.mergePatchGoType(gf, field), ", bool) {")
+		gf.P("return p.req.Get", field.GoName, `(), p.patch.IsSet("`, name, `")`)
+		gf.P("}")
+		gf.P()
+	}
+}
+
+// mergePatchGoType returns the Go type of the getter of field in its message.
+func (g *Generator) mergePatchGoType(gf *protogen.GeneratedFile, field *protogen.Field) string {
+	switch {
+	case field.Desc.IsMap():
+		return "map[" + g.mergePatchElemType(gf, field.Message.Fields[0]) + "]" +
+			g.mergePatchElemType(gf, field.Message.Fields[1])
+	case field.Desc.IsList():
+		return "[]" + g.mergePatchElemType(gf, field)
+	default:
+		return g.mergePatchElemType(gf, field)
+	}
+}
+
+// mergePatchElemType returns the Go type of a single value of field.
+func (g *Generator) mergePatchElemType(gf *protogen.GeneratedFile, field *protogen.Field) string {
+	if field.Message != nil {
+		return "*" + gf.QualifiedGoIdent(field.Message.GoIdent)
+	}
+	return g.mockGoType(gf, field)
+}
@@ -0,0 +1,272 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestMergePatchIntegration generates a Go HTTP server for a PATCH method with
+// patch_format MERGE_PATCH and checks, for a field of each kind, what the
+// generated MergePatch view tells the handler of a body setting the field,
+// leaving it out or clearing it with null, and that null is rejected on fields
+// without presence.
+func TestMergePatchIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "merge_patch_test",
+		Protos:  map[string]string{"listings.proto": mergePatchProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"merge_patch_test.go": mergePatchIntegrationTestCode},
+	})
+}
+
+const mergePatchProto = `syntax = "proto3";
+package test.mergepatch;
+option go_package = "merge_patch_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service ListingService {
+  rpc PatchListing(PatchListingRequest) returns (Listing) {
+    option (sebuf.http.config) = {
+      path: "/listings/{listing_id}"
+      method: HTTP_METHOD_PATCH
+      patch_format: PATCH_FORMAT_MERGE_PATCH
+    };
+  }
+  rpc ReplaceListing(PatchListingRequest) returns (Listing) {
+    option (sebuf.http.config) = { path: "/listings/{listing_id}" method: HTTP_METHOD_PUT };
+  }
+}
+
+enum ListingStatus {
+  LISTING_STATUS_UNSPECIFIED = 0;
+  LISTING_STATUS_ACTIVE = 1;
+  LISTING_STATUS_SOLD = 2;
+}
+
+message Dimensions {
+  int32 width = 1;
+  int32 height = 2;
+}
+
+message PatchListingRequest {
+  string listing_id = 1;
+  string title = 2;
+  int32 stock = 3;
+  optional string description = 4;
+  optional int64 price_cents = 5;
+  optional ListingStatus status = 6;
+  Dimensions dimensions = 7;
+  repeated string tags = 8;
+  map<string, string> attributes = 9;
+  oneof discount {
+    int32 discount_percent = 10;
+    int64 discount_cents = 11;
+  }
+}
+
+message Listing {
+  string listing_id = 1;
+}
+`
+
+// mergePatchIntegrationTestCode is the test source that runs inside the temp
+// module. The server records the view and request of the last call.
+const mergePatchIntegrationTestCode = `package merge_patch_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "merge_patch_test/gen"
+)
+
+type listingServer struct {
+	view gen.MergePatchPatchListingRequest
+	req  *gen.PatchListingRequest
+}
+
+func (s *listingServer) PatchListing(ctx context.Context, req *gen.PatchListingRequest) (*gen.Listing, error) {
+	s.view, s.req = gen.MergePatchPatchListingRequestFromContext(ctx), req
+	return &gen.Listing{ListingId: req.GetListingId()}, nil
+}
+
+func (s *listingServer) ReplaceListing(ctx context.Context, req *gen.PatchListingRequest) (*gen.Listing, error) {
+	s.view, s.req = gen.MergePatchPatchListingRequestFromContext(ctx), req
+	return &gen.Listing{ListingId: req.GetListingId()}, nil
+}
+
+func newServer(t *testing.T) (*listingServer, *httptest.Server) {
+	t.Helper()
+	server := &listingServer{}
+	mux := http.NewServeMux()
+	if err := gen.RegisterListingServiceServer(server, gen.WithMux(mux)); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return server, srv
+}
+
+func send(t *testing.T, method, url, contentType string, body []byte) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(string(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(data)
+}
+
+// getter reads a field through its typed getter on the view.
+type getter func(v gen.MergePatchPatchListingRequest) (any, bool)
+
+var fieldKinds = []struct {
+	kind     string
+	field    string
+	value    string
+	want     any
+	get      getter
+	nullable bool
+}{
+	{"string", "title", ` + "`" + `"Lamp"` + "`" + `, "Lamp",
+		func(v gen.MergePatchPatchListingRequest) (any, bool) { return v.GetTitle() }, false},
+	{"int32", "stock", "3", int32(3),
+		func(v gen.MergePatchPatchListingRequest) (any, bool) { return v.GetStock() }, false},
+	{"optional string", "description", ` + "`" + `"Brass"` + "`" + `, "Brass",
+		func(v gen.MergePatchPatchListingRequest) (any, bool) { return v.GetDescription() }, true},
+	{"optional int64", "priceCents", ` + "`" + `"1999"` + "`" + `, int64(1999),
+		func(v gen.MergePatchPatchListingRequest) (any, bool) { return v.GetPriceCents() }, true},
+	{"optional enum", "status", ` + "`" + `"LISTING_STATUS_SOLD"` + "`" + `, gen.ListingStatus_LISTING_STATUS_SOLD,
+		func(v gen.MergePatchPatchListingRequest) (any, bool) { return v.GetStatus() }, true},
+	{"message", "dimensions", ` + "`" + `{"width": 20}` + "`" + `, int32(20),
+		func(v gen.MergePatchPatchListingRequest) (any, bool) {
+			d, ok := v.GetDimensions()
+			return d.GetWidth(), ok
+		}, true},
+	{"repeated", "tags", ` + "`" + `["a", "b"]` + "`" + `, []string{"a", "b"},
+		func(v gen.MergePatchPatchListingRequest) (any, bool) { return v.GetTags() }, false},
+	{"map", "attributes", ` + "`" + `{"color": "red"}` + "`" + `, map[string]string{"color": "red"},
+		func(v gen.MergePatchPatchListingRequest) (any, bool) { return v.GetAttributes() }, false},
+	{"oneof", "discountPercent", "15", int32(15),
+		func(v gen.MergePatchPatchListingRequest) (any, bool) { return v.GetDiscountPercent() }, true},
+}
+
+func TestMergePatchFieldKinds(t *testing.T) {
+	server, srv := newServer(t)
+	for _, kind := range fieldKinds {
+		t.Run(kind.kind+" set", func(t *testing.T) {
+			status, body := send(t, http.MethodPatch, srv.URL+"/listings/l-1", sebufhttp.MergePatchContentType,
+				[]byte("{\"" + kind.field + "\": " + kind.value + "}"))
+			if status != http.StatusOK {
+				t.Fatalf("status %d: %s", status, body)
+			}
+			got, ok := kind.get(server.view)
+			if !ok || !reflect.DeepEqual(got, kind.want) {
+				t.Errorf("getter = %v, %v, want %v, true", got, ok, kind.want)
+			}
+			if !server.view.Has(kind.field) || server.view.IsNull(kind.field) {
+				t.Errorf("Has = %v, IsNull = %v, want true, false",
+					server.view.Has(kind.field), server.view.IsNull(kind.field))
+			}
+		})
+
+		t.Run(kind.kind+" absent", func(t *testing.T) {
+			status, body := send(t, http.MethodPatch, srv.URL+"/listings/l-1", sebufhttp.MergePatchContentType,
+				[]byte(` + "`" + `{"listingId": "ignored"}` + "`" + `))
+			if status != http.StatusOK {
+				t.Fatalf("status %d: %s", status, body)
+			}
+			if _, ok := kind.get(server.view); ok || server.view.Has(kind.field) || server.view.IsNull(kind.field) {
+				t.Errorf("an absent field should be neither set, named nor null")
+			}
+		})
+
+		t.Run(kind.kind+" null", func(t *testing.T) {
+			status, body := send(t, http.MethodPatch, srv.URL+"/listings/l-1", sebufhttp.MergePatchContentType,
+				[]byte("{\"" + kind.field + "\": null}"))
+			if !kind.nullable {
+				if status != http.StatusBadRequest {
+					t.Fatalf("status %d, want 400: %s", status, body)
+				}
+				var verr sebufhttp.ValidationError
+				if err := protojson.Unmarshal([]byte(body), &verr); err != nil {
+					t.Fatalf("body is not a ValidationError: %v\n%s", err, body)
+				}
+				v := verr.GetViolations()
+				if len(v) != 1 || !strings.Contains(v[0].GetDescription(), "cannot be null") {
+					t.Errorf("violations = %v, want one saying the field cannot be null", v)
+				}
+				return
+			}
+			if status != http.StatusOK {
+				t.Fatalf("status %d: %s", status, body)
+			}
+			if _, ok := kind.get(server.view); ok || !server.view.Has(kind.field) || !server.view.IsNull(kind.field) {
+				t.Errorf("a null field should be named and null, and not set")
+			}
+		})
+	}
+}
+
+func TestMergePatchKeepsPathAndPlainJSON(t *testing.T) {
+	server, srv := newServer(t)
+	status, body := send(t, http.MethodPatch, srv.URL+"/listings/l-2", "application/json",
+		[]byte(` + "`" + `{"title": "Desk", "description": null}` + "`" + `))
+	if status != http.StatusOK {
+		t.Fatalf("status %d: %s", status, body)
+	}
+	if server.req.GetListingId() != "l-2" || server.req.GetTitle() != "Desk" || server.req.Description != nil {
+		t.Errorf("request = %v, want the path id, the title and no description", server.req)
+	}
+	if title, ok := server.view.GetTitle(); !ok || title != "Desk" || !server.view.IsNull("description") {
+		t.Errorf("application/json bodies should be read as merge patches too")
+	}
+	if server.view.Has("listing_id") {
+		t.Error("the view should describe the body, not the path")
+	}
+}
+
+func TestMergePatchOfProtobufBodies(t *testing.T) {
+	server, srv := newServer(t)
+	data, err := proto.Marshal(&gen.PatchListingRequest{Description: proto.String(""), Stock: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, body := send(t, http.MethodPatch, srv.URL+"/listings/l-3", "application/x-protobuf", data)
+	if status != http.StatusOK {
+		t.Fatalf("status %d: %s", status, body)
+	}
+	if !server.view.Has("description") || !server.view.Has("stock") || server.view.Has("title") {
+		t.Error("protobuf bodies should set their populated fields")
+	}
+}
+
+func TestOtherMethodsReadPlainJSON(t *testing.T) {
+	server, srv := newServer(t)
+	status, body := send(t, http.MethodPut, srv.URL+"/listings/l-4", "application/json",
+		[]byte(` + "`" + `{"title": null}` + "`" + `))
+	if status != http.StatusOK {
+		t.Fatalf("status %d: %s", status, body)
+	}
+	if server.view.Has("title") {
+		t.Error("methods without patch_format should carry no merge patch")
+	}
+}
+`
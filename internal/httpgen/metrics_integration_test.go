@@ -0,0 +1,137 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestMetricsIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server from a proto with a min_len constraint,
+//  2. writes a temporary Go module that serves it with httptest under
+//     WithMetrics backed by a fake registry,
+//  3. verifies a success and a 400 validation failure are recorded with the
+//     expected metric names and label values.
+func TestMetricsIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "metrics_test",
+		Protos:  map[string]string{"metrics.proto": metricsProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"metrics_test.go": metricsIntegrationTestCode},
+	})
+}
+
+const metricsProto = `syntax = "proto3";
+package test.metrics;
+option go_package = "metrics_test/gen;gen";
+import "buf/validate/validate.proto";
+import "sebuf/http/annotations.proto";
+
+service NoteService {
+  rpc CreateNote(CreateNoteRequest) returns (Note) {
+    option (sebuf.http.config) = { path: "/notes" };
+  }
+}
+
+message CreateNoteRequest {
+  string title = 1 [(buf.validate.field).string.min_len = 3];
+}
+
+message Note {
+  string title = 1;
+}
+`
+
+// metricsIntegrationTestCode is the test source that runs inside the temp
+// module. The fake registry records every sample as "name{label values}".
+const metricsIntegrationTestCode = `package metrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "metrics_test/gen"
+)
+
+type noteServer struct{}
+
+func (noteServer) CreateNote(_ context.Context, req *gen.CreateNoteRequest) (*gen.Note, error) {
+	return &gen.Note{Title: req.GetTitle()}, nil
+}
+
+type fakeRegistry struct {
+	mu      sync.Mutex
+	samples []string
+}
+
+func (f *fakeRegistry) CounterVec(name, _ string, _ []string) sebufhttp.CounterVec {
+	return fakeVec{f, name}
+}
+
+func (f *fakeRegistry) HistogramVec(name, _ string, _ []string) sebufhttp.HistogramVec {
+	return fakeVec{f, name}
+}
+
+type fakeVec struct {
+	registry *fakeRegistry
+	name     string
+}
+
+func (v fakeVec) Inc(labelValues ...string) { v.record(labelValues) }
+
+func (v fakeVec) Observe(_ float64, labelValues ...string) { v.record(labelValues) }
+
+func (v fakeVec) record(labelValues []string) {
+	v.registry.mu.Lock()
+	defer v.registry.mu.Unlock()
+	v.registry.samples = append(v.registry.samples, v.name+"{"+strings.Join(labelValues, ",")+"}")
+}
+
+func TestMetricsLabels(t *testing.T) {
+	registry := &fakeRegistry{}
+	mux := http.NewServeMux()
+	if err := gen.RegisterNoteServiceServer(noteServer{}, gen.WithMux(mux), gen.WithMetrics(registry)); err != nil {
+		t.Fatalf("RegisterNoteServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	for title, wantStatus := range map[string]int{"groceries": http.StatusOK, "ab": http.StatusBadRequest} {
+		resp, err := http.Post(srv.URL+"/notes", "application/json", strings.NewReader(` + "`" + `{"title":"` + "`" + `+title+` + "`" + `"}` + "`" + `))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != wantStatus {
+			t.Fatalf("title %q: status %d, want %d", title, resp.StatusCode, wantStatus)
+		}
+	}
+
+	const method = "test.metrics.NoteService.CreateNote"
+	for _, want := range []string{
+		"sebuf_http_requests_total{" + method + ",200}",
+		"sebuf_http_requests_total{" + method + ",400}",
+		"sebuf_http_request_duration_seconds{" + method + "}",
+		"sebuf_http_request_size_bytes{" + method + "}",
+		"sebuf_http_response_size_bytes{" + method + "}",
+		"sebuf_http_validation_failures_total{" + method + ",title}",
+	} {
+		if !slices.Contains(registry.samples, want) {
+			t.Errorf("missing sample %s in %v", want, registry.samples)
+		}
+	}
+	for _, sample := range registry.samples {
+		if strings.HasPrefix(sample, "sebuf_http_validation_failures_total") &&
+			sample != "sebuf_http_validation_failures_total{"+method+",title}" {
+			t.Errorf("unexpected validation failure sample %s", sample)
+		}
+	}
+}
+`
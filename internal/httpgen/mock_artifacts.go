@@ -0,0 +1,712 @@
+package httpgen
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// MockArtifact names a file the mock_artifacts parameter writes next to the
+// mock server, so other tools can call the mock or serve the same data.
+type MockArtifact string
+
+const (
+	// MockArtifactHTTP writes <file>_mock.http: one request per method, for the
+	// VS Code REST Client and the JetBrains HTTP Client.
+	MockArtifactHTTP MockArtifact = "http"
+	// MockArtifactInsomnia writes <file>_mock.openapi.json: an OpenAPI 3.1
+	// document whose examples are the mock server's requests and responses.
+	// Insomnia imports it as a collection; Prism and Hoppscotch mock from it.
+	MockArtifactInsomnia MockArtifact = "insomnia"
+)
+
+// mockBaseURL is the server the artifacts send their requests to.
+const mockBaseURL = "http://localhost:8080"
+
+// validateMockArtifacts checks the mock_artifacts parameter.
+func (g *Generator) validateMockArtifacts() error {
+	for _, artifact := range g.mockArtifacts {
+		if artifact != MockArtifactHTTP && artifact != MockArtifactInsomnia {
+			return fmt.Errorf("unsupported mock_artifacts %q: expected %q or %q",
+				artifact, MockArtifactHTTP, MockArtifactInsomnia)
+		}
+	}
+	if len(g.mockArtifacts) > 0 && !g.generateMock {
+		return errors.New("mock_artifacts requires generate_mock=true")
+	}
+	return nil
+}
+
+// generateMockArtifacts writes the mock artifacts requested for a file with
+// services.
+func (g *Generator) generateMockArtifacts(file *protogen.File) error {
+	if len(g.mockArtifacts) == 0 || len(file.Services) == 0 {
+		return nil
+	}
+	calls := g.mockCalls(file)
+	if slices.Contains(g.mockArtifacts, MockArtifactHTTP) {
+		if err := g.generateMockHTTPFile(file, calls); err != nil {
+			return err
+		}
+	}
+	if slices.Contains(g.mockArtifacts, MockArtifactInsomnia) {
+		return g.generateMockOpenAPIFile(file, calls)
+	}
+	return nil
+}
+
+// mockCall is the example request of a method and the response the mock
+// server answers it with.
+type mockCall struct {
+	service    *protogen.Service
+	method     *protogen.Method
+	httpMethod string
+	route      string                   // path the method is registered on
+	url        string                   // route with the example path values, and the query string
+	params     []mockParam              // path, query and header parameters, in that order
+	body       any                      // nil for methods without a request body
+	bound      map[*protogen.Field]bool // request fields sent in the path, query string or headers
+	response   any
+	status     string // status the mock server answers with
+	sse        bool
+}
+
+// mockParam is a parameter of a mockCall and its example value.
+type mockParam struct {
+	name     string
+	in       string // path, query or header
+	value    string // the value as sent
+	required bool
+	send     bool // whether the .http request sends the header
+	schema   jsonObject
+	example  any // value typed as schema describes it
+}
+
+// mockCalls returns the calls of every method of file's services. Versioned
+// services are called on their default version.
+func (g *Generator) mockCalls(file *protogen.File) []mockCall {
+	var calls []mockCall
+	for _, service := range file.Services {
+		basePath := g.getServiceBasePath(service)
+		if version := annotations.DefaultAPIVersion(annotations.GetServiceVersions(service)); version != nil {
+			basePath = version.BasePath
+		}
+		for _, method := range service.Methods {
+			calls = append(calls, g.mockCall(file, service, method, basePath))
+		}
+	}
+	return calls
+}
+
+// mockCall builds the call of a method. Path, query and header values come
+// from the request message's examples; the body holds the other fields.
+func (g *Generator) mockCall(
+	file *protogen.File,
+	service *protogen.Service,
+	method *protogen.Method,
+	basePath string,
+) mockCall {
+	httpMethod := g.getHTTPMethod(method)
+	hasBody := httpMethod == "POST" || httpMethod == "PUT" || httpMethod == "PATCH"
+	call := mockCall{
+		service:    service,
+		method:     method,
+		httpMethod: httpMethod,
+		route:      canonicalRoutePath(g.getMethodPath(method, basePath, file.GoPackageName)),
+		sse:        g.isSSEMethod(method),
+	}
+	bound := make(map[*protogen.Field]bool)
+
+	call.url = pathWildcardPattern.ReplaceAllStringFunc(call.route, func(wildcard string) string {
+		name := strings.TrimSuffix(strings.Trim(wildcard, "{}"), "...")
+		field := annotations.FindFieldByProtoName(method.Input, name)
+		if field == nil || field.Message != nil {
+			return url.PathEscape(annotations.ExampleString)
+		}
+		bound[field] = true
+		param := newMockFieldParam(field, name, "path", true)
+		call.params = append(call.params, param)
+		return url.PathEscape(param.value)
+	})
+
+	var query []string
+	for _, qp := range annotations.GetURLQueryParams(method.Input, hasBody) {
+		if qp.Field.Message != nil {
+			continue
+		}
+		bound[qp.Field] = true
+		param := newMockFieldParam(qp.Field, qp.ParamName, "query", qp.Required)
+		call.params = append(call.params, param)
+		query = append(query, url.QueryEscape(param.name)+"="+url.QueryEscape(param.value))
+	}
+	if len(query) > 0 {
+		call.url += "?" + strings.Join(query, "&")
+	}
+
+	headers := annotations.CombineHeaders(annotations.GetServiceHeaders(service), annotations.GetMethodHeaders(method))
+	for _, header := range headers {
+		call.params = append(call.params, newMockHeaderParam(header))
+	}
+	for _, hp := range annotations.GetHeaderFieldParams(method.Input) {
+		if hp.Field.Message != nil {
+			continue
+		}
+		bound[hp.Field] = true
+		param := newMockFieldParam(hp.Field, hp.HeaderName, "header", false)
+		param.send = true
+		call.params = append(call.params, param)
+	}
+
+	call.bound = bound
+	if hasBody {
+		call.body = mockMessageJSON(method.Input, func(field *protogen.Field) bool {
+			return bound[field] || annotations.IsBodyExcluded(field)
+		})
+	}
+	// The mock server answers a result message with its first variant
+	call.response, call.status = mockMessageJSON(method.Output, nil), "200"
+	variants, _ := annotations.GetResponseVariants(method.Output)
+	if len(variants) > 0 {
+		call.response = mockMessageJSON(variants[0].Field.Message, nil)
+		call.status = strconv.Itoa(variants[0].Status)
+	}
+	if example := annotations.GetDefaultExample(method); example.GetResponse() != "" {
+		response := redactExampleJSON(method.Output, json.RawMessage(example.GetResponse()))
+		call.response, call.status = defaultExampleResponse(method, response, variants, call)
+	}
+	return call
+}
+
+// defaultExampleResponse returns the response the mock server answers with
+// when the method has a "default" example, and its status: the example's JSON,
+// the value of its field for a root unwrap message, or the set variant's JSON
+// and status for a result message. It returns those of call otherwise.
+func defaultExampleResponse(
+	method *protogen.Method,
+	data json.RawMessage,
+	variants []annotations.ResponseVariant,
+	call mockCall,
+) (any, string) {
+	if len(variants) == 0 && !annotations.IsRootUnwrap(method.Output) {
+		return data, "200"
+	}
+	var members map[string]json.RawMessage
+	if json.Unmarshal(data, &members) != nil {
+		return call.response, call.status
+	}
+	member := func(field *protogen.Field) (json.RawMessage, bool) {
+		if value, set := members[annotations.JSONFieldName(field)]; set {
+			return value, true
+		}
+		value, set := members[string(field.Desc.Name())]
+		return value, set
+	}
+	if len(variants) == 0 {
+		if value, set := member(method.Output.Fields[0]); set {
+			return value, call.status
+		}
+		return call.response, call.status
+	}
+	for _, variant := range variants {
+		if value, set := member(variant.Field); set {
+			return value, strconv.Itoa(variant.Status)
+		}
+	}
+	return call.response, call.status
+}
+
+// newMockFieldParam returns the parameter bound to a request field, holding
+// the field's example value.
+func newMockFieldParam(field *protogen.Field, name, in string, required bool) mockParam {
+	value := mockText(field)
+	schemaType := mockSchemaType(field)
+	schema := jsonObject{{"type", schemaType}}
+	example := typedExample(schemaType, value)
+	if field.Desc.IsList() {
+		schema = jsonObject{{"type", "array"}, {"items", schema}}
+		example = []any{example}
+	}
+	return mockParam{name: name, in: in, value: value, required: required, schema: schema, example: example}
+}
+
+// newMockHeaderParam returns the parameter of a declared header. Its value is
+// the header's example, its default value, or a fallback for its type and
+// format. The .http request sends the required headers.
+func newMockHeaderParam(header *http.Header) mockParam {
+	value := mockHeaderValue(header)
+	schemaType := header.GetType()
+	if schemaType == "" {
+		schemaType = "string"
+	}
+	var schema jsonObject
+	var example any
+	switch {
+	case header.GetMultiple() || schemaType == "array":
+		itemType := schemaType
+		if itemType == "array" {
+			itemType = "string"
+		}
+		items := make([]any, 0)
+		for _, item := range http.SplitHeaderValues([]string{value}) {
+			items = append(items, typedExample(itemType, item))
+		}
+		schema, example = jsonObject{{"type", "array"}, {"items", jsonObject{{"type", itemType}}}}, items
+	default:
+		schema, example = jsonObject{{"type", schemaType}}, typedExample(schemaType, value)
+		if header.GetFormat() != "" {
+			schema = append(schema, jsonMember{"format", header.GetFormat()})
+		}
+	}
+	return mockParam{
+		name:     annotations.CanonicalHeaderName(header.GetName()),
+		in:       "header",
+		value:    value,
+		required: header.GetRequired(),
+		send:     header.GetRequired(),
+		schema:   schema,
+		example:  example,
+	}
+}
+
+// mockHeaderValue returns the value sent for a declared header.
+func mockHeaderValue(header *http.Header) string {
+	switch {
+	case header.GetExample() != "":
+		return header.GetExample()
+	case header.GetDefaultValue() != "":
+		return header.GetDefaultValue()
+	}
+	switch header.GetFormat() {
+	case "uuid":
+		return annotations.ExampleUUID
+	case "email":
+		return annotations.ExampleEmail
+	case "uri", "url":
+		return annotations.ExampleURL
+	}
+	switch header.GetType() {
+	case "integer":
+		return strconv.Itoa(annotations.ExampleInt)
+	case "number":
+		return strconv.FormatFloat(annotations.ExampleFloat, 'g', -1, 64)
+	case "boolean":
+		return "true"
+	}
+	return annotations.ExampleString
+}
+
+// mockSchemaType returns the OpenAPI type of a field's value in a path, query
+// string or header.
+func mockSchemaType(field *protogen.Field) string {
+	//exhaustive:ignore - every other kind is sent as a string
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return "boolean"
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "integer"
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+// typedExample returns value as a JSON value of an OpenAPI type, or as a
+// string when it does not parse as one.
+func typedExample(schemaType, value string) any {
+	switch schemaType {
+	case "integer", "number":
+		if f, err := strconv.ParseFloat(value, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+			return json.Number(value)
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+// generateMockHTTPFile writes <file>_mock.http, a request per method in the
+// format of the VS Code REST Client and the JetBrains HTTP Client.
+func (g *Generator) generateMockHTTPFile(file *protogen.File, calls []mockCall) error {
+	gf := g.plugin.NewGeneratedFile(file.GeneratedFilenamePrefix+"_mock.http", "")
+	gf.P("# Code generated by protoc-gen-go-http. DO NOT EDIT.")
+	gf.P("# source: ", file.Desc.Path())
+	gf.P("#")
+	gf.P("# One request per method, filled in from the same field examples as the mock")
+	gf.P("# server (generate_mock=true).")
+	gf.P()
+	gf.P("@baseUrl = ", mockBaseURL)
+	for _, call := range calls {
+		gf.P()
+		gf.P("### ", call.service.GoName, ".", call.method.GoName)
+		gf.P("# @name ", call.service.GoName, "_", call.method.GoName)
+		gf.P(call.httpMethod, " {{baseUrl}}", call.url)
+		for _, param := range call.params {
+			if param.in == "header" && param.send {
+				gf.P(param.name, ": ", param.value)
+			}
+		}
+		if call.sse {
+			gf.P("Accept: text/event-stream")
+		}
+		if call.body == nil {
+			continue
+		}
+		body, err := renderJSON(call.body)
+		if err != nil {
+			return fmt.Errorf("rendering the example request of %s: %w", call.method.Desc.FullName(), err)
+		}
+		gf.P("Content-Type: application/json")
+		gf.P()
+		gf.P(body)
+	}
+	return nil
+}
+
+// generateMockOpenAPIFile writes <file>_mock.openapi.json, an OpenAPI 3.1
+// document with an operation per method whose parameters, request body and
+// 200 response carry the mock's examples. Schemas are left to the OpenAPI
+// generator: the document describes what the mock sends and answers.
+func (g *Generator) generateMockOpenAPIFile(file *protogen.File, calls []mockCall) error {
+	var paths jsonObject
+	for _, call := range calls {
+		i := slices.IndexFunc(paths, func(member jsonMember) bool { return member.key == call.route })
+		if i < 0 {
+			i = len(paths)
+			paths = append(paths, jsonMember{call.route, jsonObject{}})
+		}
+		operations, _ := paths[i].value.(jsonObject)
+		paths[i].value = append(operations, jsonMember{strings.ToLower(call.httpMethod), mockOperation(call)})
+	}
+
+	doc := jsonObject{
+		{"openapi", "3.1.0"},
+		{"info", jsonObject{
+			{"title", string(file.Desc.Package()) + " mock"},
+			{"description", "Code generated by protoc-gen-go-http from " + file.Desc.Path() +
+				". DO NOT EDIT. The examples are the requests and responses of the mock server."},
+			{"version", "1.0.0"},
+		}},
+		{"servers", []any{jsonObject{{"url", mockBaseURL}}}},
+		{"paths", paths},
+	}
+	content, err := renderJSON(doc)
+	if err != nil {
+		return fmt.Errorf("rendering the mock OpenAPI document of %s: %w", file.Desc.Path(), err)
+	}
+	gf := g.plugin.NewGeneratedFile(file.GeneratedFilenamePrefix+"_mock.openapi.json", "")
+	gf.P(content)
+	return nil
+}
+
+// mockOperation returns the OpenAPI operation of a call.
+func mockOperation(call mockCall) jsonObject {
+	operation := jsonObject{
+		{"operationId", call.service.GoName + "_" + call.method.GoName},
+		{"tags", []any{call.service.GoName}},
+	}
+	if summary, _, _ := strings.Cut(strings.TrimSpace(string(call.method.Comments.Leading)), "\n"); summary != "" {
+		operation = append(operation, jsonMember{"summary", summary})
+	}
+	if len(call.params) > 0 {
+		params := make([]any, 0, len(call.params))
+		for _, param := range call.params {
+			params = append(params, jsonObject{
+				{"name", param.name},
+				{"in", param.in},
+				{"required", param.required},
+				{"schema", param.schema},
+				{"example", param.example},
+			})
+		}
+		operation = append(operation, jsonMember{"parameters", params})
+	}
+	if call.body != nil {
+		operation = append(operation, jsonMember{"requestBody", jsonObject{
+			{"required", true},
+			{"content", jsonObject{{"application/json", jsonObject{{"example", call.body}}}}},
+		}})
+	}
+
+	mediaType, example := "application/json", call.response
+	if call.sse {
+		var event bytes.Buffer
+		if err := encodeJSON(&event, call.response); err == nil {
+			mediaType, example = "text/event-stream", "data: "+event.String()+"\n\n"
+		}
+	}
+	return append(operation, jsonMember{"responses", jsonObject{
+		{call.status, jsonObject{
+			{"description", "Mock response"},
+			{"content", jsonObject{{mediaType, jsonObject{{"example", example}}}}},
+		}},
+	}})
+}
+
+// mockMessageJSON returns the JSON value the mock server's protojson encoding
+// gives the example of msg: fields are chosen by annotations.PopulatesExample
+// and valued by annotations.ResolveExampleValue, as in the Go mock. A root
+// unwrap message is its field's value. omit, which may be nil, leaves out
+// fields of msg itself.
+func mockMessageJSON(msg *protogen.Message, omit func(*protogen.Field) bool) any {
+	if annotations.IsRootUnwrap(msg) {
+		field, path := msg.Fields[0], []*protogen.Message{msg}
+		if annotations.PopulatesExample(field, path) {
+			if value, ok := mockFieldJSON(field, path); ok {
+				return value
+			}
+		}
+		if field.Desc.IsMap() {
+			return jsonObject{}
+		}
+		return []any{}
+	}
+	return mockObjectJSON(msg, nil, "", omit)
+}
+
+// mockObjectJSON returns the members msg contributes to its JSON object.
+// Flattened fields contribute their child's members under the flatten prefix,
+// which prefixes every key. path holds the enclosing messages being populated.
+func mockObjectJSON(
+	msg *protogen.Message,
+	path []*protogen.Message,
+	prefix string,
+	omit func(*protogen.Field) bool,
+) jsonObject {
+	path = append(path[:len(path):len(path)], msg)
+
+	object := jsonObject{}
+	for _, field := range msg.Fields {
+		if (omit != nil && omit(field)) || !annotations.PopulatesExample(field, path) {
+			continue
+		}
+		if annotations.IsFlattenField(field) && field.Message != nil {
+			childPrefix := prefix + annotations.GetFlattenPrefix(field)
+			object = append(object, mockObjectJSON(field.Message, path, childPrefix, nil)...)
+			continue
+		}
+		if value, ok := mockFieldJSON(field, path); ok {
+			object = append(object, jsonMember{prefix + annotations.JSONFieldName(field), value})
+		}
+	}
+	return object
+}
+
+// mockFieldJSON returns a populated field's JSON value: one element for
+// repeated fields and one entry for maps. It reports false for a scalar without
+// presence whose example is its zero value, which protojson leaves out.
+func mockFieldJSON(field *protogen.Field, path []*protogen.Message) (any, bool) {
+	switch {
+	case field.Desc.IsMap():
+		keyField, valueField := field.Message.Fields[0], field.Message.Fields[1]
+		key := annotations.ResolveExampleMapKey(keyField).String()
+		return jsonObject{{key, mockMapValueJSON(valueField, path)}}, true
+	case field.Desc.IsList():
+		return []any{mockElementJSON(field, path)}, true
+	case field.Message == nil && !field.Desc.HasPresence() &&
+		annotations.ResolveExampleValue(field).Equal(field.Desc.Default()):
+		return nil, false
+	default:
+		return mockElementJSON(field, path), true
+	}
+}
+
+// mockMapValueJSON returns a map entry's value. A message value carrying an
+// unwrap annotation collapses to its unwrapped repeated field, as it does on
+// the wire.
+func mockMapValueJSON(valueField *protogen.Field, path []*protogen.Message) any {
+	if valueField.Message == nil {
+		return mockScalarJSON(valueField)
+	}
+	unwrapField := annotations.FindUnwrapField(valueField.Message)
+	if unwrapField == nil || unwrapField.Desc.IsMap() {
+		return mockObjectJSON(valueField.Message, path, "", nil)
+	}
+	wrapperPath := append(path[:len(path):len(path)], valueField.Message)
+	if !annotations.PopulatesExample(unwrapField, wrapperPath) {
+		return []any{}
+	}
+	return []any{mockElementJSON(unwrapField, wrapperPath)}
+}
+
+// mockElementJSON returns a single (element) value of a message or scalar field.
+func mockElementJSON(field *protogen.Field, path []*protogen.Message) any {
+	if field.Message != nil {
+		return mockObjectJSON(field.Message, path, "", nil)
+	}
+	return mockScalarJSON(field)
+}
+
+// mockScalarJSON returns the JSON value of a scalar or enum field's resolved
+// example, honoring the field's encoding annotations.
+func mockScalarJSON(field *protogen.Field) any {
+	v := annotations.ResolveExampleValue(field)
+	switch field.Desc.Kind() {
+	case protoreflect.StringKind:
+		return v.String()
+	case protoreflect.BytesKind:
+		return mockBytesText(field, v.Bytes())
+	case protoreflect.BoolKind:
+		return v.Bool()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return json.Number(strconv.FormatInt(v.Int(), 10))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return json.Number(strconv.FormatUint(v.Uint(), 10))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return mockInt64JSON(field, strconv.FormatInt(v.Int(), 10))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return mockInt64JSON(field, strconv.FormatUint(v.Uint(), 10))
+	case protoreflect.FloatKind:
+		return mockFloatJSON(v.Float(), 32)
+	case protoreflect.DoubleKind:
+		return mockFloatJSON(v.Float(), 64)
+	case protoreflect.EnumKind:
+		return mockEnumJSON(field, v)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return nil
+	default:
+		return nil
+	}
+}
+
+// mockInt64JSON quotes a 64-bit integer unless the field uses NUMBER encoding.
+func mockInt64JSON(field *protogen.Field, digits string) any {
+	if annotations.IsInt64NumberEncoding(field) {
+		return json.Number(digits)
+	}
+	return digits
+}
+
+// mockFloatJSON returns a float as protojson writes it: the special values as
+// strings, the others as numbers.
+func mockFloatJSON(f float64, bitSize int) any {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+	return json.Number(strconv.FormatFloat(f, 'g', -1, bitSize))
+}
+
+// mockEnumJSON returns an enum value by its JSON name (honoring enum_value
+// mappings), or by number under NUMBER encoding.
+func mockEnumJSON(field *protogen.Field, v protoreflect.Value) any {
+	value := annotations.ExampleEnumValue(field, v)
+	if value == nil || annotations.ResolveEnumEncoding(field) == http.EnumEncoding_ENUM_ENCODING_NUMBER {
+		return json.Number(strconv.Itoa(int(v.Enum())))
+	}
+	if custom := annotations.GetEnumValueMapping(value); custom != "" {
+		return custom
+	}
+	return string(value.Desc.Name())
+}
+
+// mockBytesText encodes b as the field's bytes_encoding serializes it.
+//
+//nolint:exhaustive // UNSPECIFIED and BASE64 both use the protojson default
+func mockBytesText(field *protogen.Field, b []byte) string {
+	switch annotations.ResolveBytesEncoding(field) {
+	case http.BytesEncoding_BYTES_ENCODING_BASE64_RAW:
+		return base64.RawStdEncoding.EncodeToString(b)
+	case http.BytesEncoding_BYTES_ENCODING_BASE64URL:
+		return base64.URLEncoding.EncodeToString(b)
+	case http.BytesEncoding_BYTES_ENCODING_BASE64URL_RAW:
+		return base64.RawURLEncoding.EncodeToString(b)
+	case http.BytesEncoding_BYTES_ENCODING_HEX:
+		return hex.EncodeToString(b)
+	default:
+		return base64.StdEncoding.EncodeToString(b)
+	}
+}
+
+// mockText returns the example value of a scalar or enum field as it appears
+// in a path, query string or header.
+func mockText(field *protogen.Field) string {
+	switch value := mockScalarJSON(field).(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		return ""
+	}
+}
+
+// jsonMember is a member of a jsonObject.
+type jsonMember struct {
+	key   string
+	value any
+}
+
+// jsonObject is a JSON object that keeps its members in the order they were
+// added, so the artifacts list fields in declaration order and are
+// reproducible.
+type jsonObject []jsonMember
+
+// MarshalJSON implements json.Marshaler.
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, member := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := encodeJSON(&buf, member.key); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(':')
+		if err := encodeJSON(&buf, member.value); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// encodeJSON appends the compact encoding of v to buf, leaving <, > and &
+// unescaped.
+func encodeJSON(buf *bytes.Buffer, v any) error {
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	buf.Truncate(buf.Len() - 1) // Encode ends the value with a newline
+	return nil
+}
+
+// renderJSON returns the encoding of v indented by two spaces.
+func renderJSON(v any) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
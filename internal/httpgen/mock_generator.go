@@ -1,14 +1,25 @@
 package httpgen
 
 import (
-	"strings"
+	"bytes"
+	"encoding/json"
+	"strconv"
 
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/reflect/protoreflect"
 
+	"github.com/SebastienMelki/sebuf/http"
 	"github.com/SebastienMelki/sebuf/internal/annotations"
 )
 
+// protoPackage is the import path of the proto runtime package, imported by
+// mock files only when they use it.
+const protoPackage = protogen.GoImportPath("google.golang.org/protobuf/proto")
+
+// protojsonPackage is the import path of the protojson package, imported by
+// mock files answering with a method's default example.
+const protojsonPackage = protogen.GoImportPath("google.golang.org/protobuf/encoding/protojson")
+
 // generateMockFile generates a mock server implementation file.
 func (g *Generator) generateMockFile(file *protogen.File) error {
 	filename := file.GeneratedFilenamePrefix + "_http_mock.pb.go"
@@ -19,21 +30,9 @@ func (g *Generator) generateMockFile(file *protogen.File) error {
 	// Imports
 	gf.P("import (")
 	gf.P(`"context"`)
-	gf.P(`cryptorand "crypto/rand"`)
-	gf.P(`"fmt"`)
-	gf.P(`"math/rand"`)
-	gf.P(`"strconv"`)
-	gf.P(`"time"`)
-	gf.P()
-	gf.P(`"google.golang.org/protobuf/proto"`)
 	gf.P(")")
 	gf.P()
 
-	// Generate field examples storage
-	if err := g.generateFieldExamplesStorage(gf, file); err != nil {
-		return err
-	}
-
 	// Generate mock servers for each service
 	for _, service := range file.Services {
 		if err := g.generateMockService(gf, file, service); err != nil {
@@ -41,50 +40,9 @@ func (g *Generator) generateMockFile(file *protogen.File) error {
 		}
 	}
 
-	// Generate helper functions
-	g.generateMockHelpers(gf)
-
-	return nil
-}
-
-// generateFieldExamplesStorage generates storage for field examples.
-func (g *Generator) generateFieldExamplesStorage(gf *protogen.GeneratedFile, file *protogen.File) error {
-	gf.P("// Field examples extracted from proto definitions")
-	gf.P("var fieldExamples = map[string][]string{")
-
-	// Collect all field examples from all messages
-	for _, message := range file.Messages {
-		g.collectMessageFieldExamples(gf, message, "")
-	}
-
-	gf.P("}")
-	gf.P()
-
 	return nil
 }
 
-// collectMessageFieldExamples recursively collects field examples.
-func (g *Generator) collectMessageFieldExamples(gf *protogen.GeneratedFile, message *protogen.Message, prefix string) {
-	messagePath := prefix + string(message.Desc.Name())
-
-	for _, field := range message.Fields {
-		examples := annotations.GetFieldExamples(field)
-		if len(examples) > 0 {
-			fieldPath := messagePath + "." + string(field.Desc.Name())
-			gf.P(`"`, fieldPath, `": {`)
-			for _, example := range examples {
-				gf.P(`"`, example, `",`)
-			}
-			gf.P("},")
-		}
-	}
-
-	// Process nested messages
-	for _, nested := range message.Messages {
-		g.collectMessageFieldExamples(gf, nested, messagePath+".")
-	}
-}
-
 // generateMockService generates a mock implementation for a service.
 func (g *Generator) generateMockService(
 	gf *protogen.GeneratedFile,
@@ -127,145 +85,342 @@ func (g *Generator) generateMockMethod(
 	inputType := method.Input.GoIdent
 	outputType := method.Output.GoIdent
 
+	streamed := annotations.IsStreamResponse(method)
+	sse := g.isSSEMethod(method)
+	failure := "return nil, err"
 	gf.P("// ", methodName, " is a mock implementation of ", service.GoName, "Server.", methodName, ".")
-	gf.P(
-		"func (m *Mock",
-		service.GoName,
-		"Server) ",
-		methodName,
-		"(ctx context.Context, req *",
-		inputType,
-		") (*",
-		outputType,
-		", error) {",
-	)
-
-	// Validate request
-	gf.P("// Validate the request")
-	gf.P("if msg, ok := any(req).(proto.Message); ok {")
-	gf.P("if err := ValidateMessage(msg); err != nil {")
-	gf.P("return nil, err")
-	gf.P("}")
-	gf.P("}")
-	gf.P()
+	switch {
+	case streamed:
+		failure = "return err"
+		gf.P("func (m *Mock", service.GoName, "Server) ", methodName, "(ctx context.Context, req *", inputType,
+			", yield func(*", g.streamResponseItem(method).GoIdent, ") error) error {")
+	case sse:
+		failure = "return err"
+		gf.P("func (m *Mock", service.GoName, "Server) ", methodName, "(ctx context.Context, req *", inputType,
+			", sender SSESender) error {")
+	default:
+		gf.P(
+			"func (m *Mock",
+			service.GoName,
+			"Server) ",
+			methodName,
+			"(ctx context.Context, req *",
+			inputType,
+			") (*",
+			outputType,
+			", error) {",
+		)
+	}
+
+	// Validate request, when the binding file generated ValidateMessage
+	if g.features.messageValidation {
+		gf.P("// Validate the request")
+		gf.P("if msg, ok := any(req).(", gf.QualifiedGoIdent(protoPackage.Ident("Message")), "); ok {")
+		gf.P("if err := ValidateMessage(msg); err != nil {")
+		gf.P(failure)
+		gf.P("}")
+		gf.P("}")
+		gf.P()
+	}
 
 	// Generate response
 	gf.P("// Generate mock response")
 	gf.P("resp := &", outputType, "{}")
 	gf.P()
 
-	// Fill response fields
-	g.generateMockFieldAssignments(gf, method.Output, "resp")
+	if example := annotations.GetDefaultExample(method); example.GetResponse() != "" {
+		// The response of the example named "default", its sensitive fields redacted
+		response := redactExampleJSON(method.Output, json.RawMessage(example.GetResponse()))
+		gf.P("if err := ", protojsonPackage.Ident("Unmarshal"), "([]byte(", strconv.Quote(string(response)),
+			"), resp); err != nil {")
+		gf.P(failure)
+		gf.P("}")
+		gf.P()
+	} else {
+		g.generateMockResponseFields(gf, method)
+	}
 
-	gf.P("return resp, nil")
+	switch {
+	case streamed:
+		// Yield the items one at a time, as a real implementation would
+		field, _ := annotations.StreamResponseField(method)
+		gf.P("for _, item := range resp.", field.GoName, " {")
+		gf.P("if err := yield(item); err != nil {")
+		gf.P("return err")
+		gf.P("}")
+		gf.P("}")
+		gf.P("return nil")
+	case sse:
+		// A single event, then the stream ends
+		gf.P("return sender.Send(resp)")
+	default:
+		gf.P("return resp, nil")
+	}
 	gf.P("}")
 	gf.P()
 
 	return nil
 }
 
+// redactExampleJSON returns the JSON of an example of msg with the values of
+// its sensitive fields redacted the way ResolveExampleValue redacts field
+// examples: strings become http.RedactedValue and bytes are emptied, in nested
+// messages, lists and maps too. data is returned as written when it has no
+// sensitive value or does not parse.
+func redactExampleJSON(msg *protogen.Message, data json.RawMessage) json.RawMessage {
+	var members map[string]json.RawMessage
+	if msg == nil || json.Unmarshal(data, &members) != nil || members == nil {
+		return data
+	}
+	redacted := false
+	for _, field := range msg.Fields {
+		key := annotations.JSONFieldName(field)
+		value, set := members[key]
+		if !set {
+			key = string(field.Desc.Name())
+			value, set = members[key]
+		}
+		if !set || bytes.Equal(value, []byte("null")) {
+			continue
+		}
+		var redact func(json.RawMessage) json.RawMessage
+		switch {
+		case annotations.IsSensitiveField(field):
+			replacement := json.RawMessage(strconv.Quote(http.RedactedValue))
+			if annotations.SensitiveValueField(field).Desc.Kind() == protoreflect.BytesKind {
+				replacement = json.RawMessage(`""`)
+			}
+			redact = func(json.RawMessage) json.RawMessage { return replacement }
+		case field.Desc.IsMap() && field.Message.Fields[1].Message != nil:
+			valueMessage := field.Message.Fields[1].Message
+			redact = func(v json.RawMessage) json.RawMessage { return redactExampleJSON(valueMessage, v) }
+		case field.Message != nil && !field.Desc.IsMap():
+			redact = func(v json.RawMessage) json.RawMessage { return redactExampleJSON(field.Message, v) }
+		default:
+			continue
+		}
+		if changed := redactExampleValue(field, value, redact); !bytes.Equal(changed, value) {
+			members[key] = changed
+			redacted = true
+		}
+	}
+	if !redacted {
+		return data
+	}
+	out, err := json.Marshal(members)
+	if err != nil {
+		return data
+	}
+	return out
+}
+
+// redactExampleValue applies redact to the JSON value of field: to each of its
+// elements or map values when it is a list or a map. value is returned as
+// written when redact changes none of them.
+func redactExampleValue(
+	field *protogen.Field,
+	value json.RawMessage,
+	redact func(json.RawMessage) json.RawMessage,
+) json.RawMessage {
+	changed := false
+	apply := func(v json.RawMessage) json.RawMessage {
+		out := redact(v)
+		changed = changed || !bytes.Equal(out, v)
+		return out
+	}
+	var out any
+	switch {
+	case field.Desc.IsList():
+		var elements []json.RawMessage
+		if json.Unmarshal(value, &elements) != nil {
+			return value
+		}
+		for i, element := range elements {
+			elements[i] = apply(element)
+		}
+		out = elements
+	case field.Desc.IsMap():
+		var entries map[string]json.RawMessage
+		if json.Unmarshal(value, &entries) != nil {
+			return value
+		}
+		for key, entry := range entries {
+			entries[key] = apply(entry)
+		}
+		out = entries
+	default:
+		return redact(value)
+	}
+	if !changed {
+		return value
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return value
+	}
+	return data
+}
+
+// generateMockResponseFields fills the fields of the mock response resp with
+// their examples.
+func (g *Generator) generateMockResponseFields(gf *protogen.GeneratedFile, method *protogen.Method) {
+	g.generateMockFieldAssignments(gf, method.Output, "resp", nil)
+
+	// A result message answers with its first variant
+	if variants, _ := annotations.GetResponseVariants(method.Output); len(variants) > 0 {
+		variant := variants[0].Field
+		gf.P("variant := &", variant.Message.GoIdent, "{}")
+		g.generateMockFieldAssignments(gf, variant.Message, "variant", []*protogen.Message{method.Output})
+		gf.P("resp.", variant.Oneof.GoName, " = &", variant.GoIdent, "{", variant.GoName, ": variant}")
+		gf.P()
+	}
+}
+
 // generateMockFieldAssignments generates field assignments for a message.
+// Values come from annotations.ResolveExampleValue and fields are chosen by
+// annotations.PopulatesExample, the rules the TypeScript fixtures share; path
+// holds the enclosing messages being populated.
 func (g *Generator) generateMockFieldAssignments(
 	gf *protogen.GeneratedFile,
 	message *protogen.Message,
 	varName string,
+	path []*protogen.Message,
 ) {
-	messageName := string(message.Desc.Name())
+	path = append(path[:len(path):len(path)], message)
 
 	for _, field := range message.Fields {
-		fieldName := field.GoName
-		fieldPath := messageName + "." + string(field.Desc.Name())
-
-		// Generate assignment based on field type
-		switch field.Desc.Kind() {
-		case protoreflect.StringKind:
-			gf.P(
-				varName,
-				".",
-				fieldName,
-				" = selectStringExample(\"",
-				fieldPath,
-				"\", ",
-				g.getDefaultGenerator(field),
-				")",
-			)
-		case protoreflect.Int32Kind, protoreflect.Int64Kind:
-			gf.P(varName, ".", fieldName, " = selectIntExample(\"", fieldPath, "\", ", g.getDefaultValue(field), ")")
-		case protoreflect.BoolKind:
-			gf.P(varName, ".", fieldName, " = selectBoolExample(\"", fieldPath, "\", ", g.getDefaultValue(field), ")")
-		case protoreflect.FloatKind, protoreflect.DoubleKind:
-			gf.P(varName, ".", fieldName, " = selectFloatExample(\"", fieldPath, "\", ", g.getDefaultValue(field), ")")
-		case protoreflect.MessageKind:
-			switch {
-			case field.Desc.IsMap():
-				// Handle map fields
-				g.generateMockMapFieldAssignment(gf, field, varName)
-			case field.Desc.IsList():
-				gf.P("// TODO: Handle repeated message field ", fieldName)
-			default:
-				gf.P(varName, ".", fieldName, " = &", field.Message.GoIdent, "{}")
-				g.generateMockFieldAssignments(gf, field.Message, varName+"."+fieldName)
-			}
-		case protoreflect.EnumKind,
-			protoreflect.Sint32Kind,
-			protoreflect.Uint32Kind,
-			protoreflect.Sint64Kind,
-			protoreflect.Uint64Kind,
-			protoreflect.Sfixed32Kind,
-			protoreflect.Fixed32Kind,
-			protoreflect.Sfixed64Kind,
-			protoreflect.Fixed64Kind,
-			protoreflect.BytesKind,
-			protoreflect.GroupKind:
-			gf.P("// TODO: Handle field ", fieldName, " of type ", field.Desc.Kind())
+		if !annotations.PopulatesExample(field, path) {
+			continue
+		}
+		target := varName + "." + field.GoName
+
+		switch {
+		case field.Desc.IsMap():
+			g.generateMockMapFieldAssignment(gf, field, target, path)
+		case field.Message != nil && field.Desc.IsList():
+			// Repeated message fields get a single element
+			gf.P(target, " = []*", field.Message.GoIdent, "{{}}")
+			g.generateMockFieldAssignments(gf, field.Message, target+"[0]", path)
+		case field.Message != nil:
+			gf.P(target, " = &", field.Message.GoIdent, "{}")
+			g.generateMockFieldAssignments(gf, field.Message, target, path)
 		default:
-			gf.P("// TODO: Handle field ", fieldName, " of type ", field.Desc.Kind())
+			gf.P(target, " = ", g.mockFieldValueExpr(gf, field))
 		}
 	}
 }
 
-// generateMockMapFieldAssignment generates code to populate a map field with sample data.
+// mockFieldValueExpr returns the example value of a scalar or enum field,
+// wrapped to match the field's Go type: a one-element slice for repeated
+// fields and a pointer for proto3 optional fields.
+func (g *Generator) mockFieldValueExpr(gf *protogen.GeneratedFile, field *protogen.Field) string {
+	expr := g.mockScalarExpr(gf, field)
+	switch {
+	case field.Desc.IsList():
+		return "[]" + g.mockGoType(gf, field) + "{" + expr + "}"
+	case !field.Desc.HasPresence() || field.Desc.Kind() == protoreflect.BytesKind:
+		return expr
+	case field.Desc.Kind() == protoreflect.EnumKind:
+		return expr + ".Enum()"
+	default:
+		return gf.QualifiedGoIdent(protoPackage.Ident(mockProtoPointerFunc(field.Desc.Kind()))) + "(" + expr + ")"
+	}
+}
+
+// mockScalarExpr returns a Go literal for the field's resolved example value.
+func (g *Generator) mockScalarExpr(gf *protogen.GeneratedFile, field *protogen.Field) string {
+	v := annotations.ResolveExampleValue(field)
+	switch field.Desc.Kind() {
+	case protoreflect.StringKind:
+		return strconv.Quote(v.String())
+	case protoreflect.BytesKind:
+		return "[]byte(" + strconv.Quote(string(v.Bytes())) + ")"
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(v.Bool())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return strconv.FormatInt(v.Int(), 10)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return strconv.FormatUint(v.Uint(), 10)
+	case protoreflect.FloatKind:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32)
+	case protoreflect.DoubleKind:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case protoreflect.EnumKind:
+		if value := annotations.ExampleEnumValue(field, v); value != nil {
+			return gf.QualifiedGoIdent(value.GoIdent)
+		}
+		return gf.QualifiedGoIdent(field.Enum.GoIdent) + "(" + strconv.Itoa(int(v.Enum())) + ")"
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return "nil"
+	default:
+		return "nil"
+	}
+}
+
+// mockProtoPointerFunc returns the proto package helper (proto.String, ...)
+// that takes the address of a scalar for proto3 optional fields.
+//
+//nolint:exhaustive // Enums, bytes and messages are never wrapped with a proto helper
+func mockProtoPointerFunc(kind protoreflect.Kind) string {
+	switch kind {
+	case protoreflect.StringKind:
+		return "String"
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "Int32"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "Int64"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "Uint32"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "Uint64"
+	case protoreflect.BoolKind:
+		return "Bool"
+	case protoreflect.FloatKind:
+		return "Float32"
+	default:
+		return "Float64"
+	}
+}
+
+// generateMockMapFieldAssignment populates a map field with a single entry.
 func (g *Generator) generateMockMapFieldAssignment(
 	gf *protogen.GeneratedFile,
 	field *protogen.Field,
-	varName string,
+	target string,
+	path []*protogen.Message,
 ) {
-	fieldName := field.GoName
-
-	// Get the map's key and value types
 	keyField := field.Message.Fields[0]
 	valueField := field.Message.Fields[1]
 
-	// Initialize the map with properly qualified types
 	keyType := g.getGoTypeScalar(keyField)
+	key := mockMapKeyExpr(keyField)
 
-	// Generate a sample key
-	sampleKey := g.getSampleMapKey(keyField)
+	if valueField.Message != nil {
+		gf.P(target, " = map[", keyType, "]*", valueField.Message.GoIdent, "{", key, ": {}}")
+		g.generateMockFieldAssignments(gf, valueField.Message, target+"["+key+"]", path)
+		return
+	}
+	valueType := g.mockGoType(gf, valueField)
+	gf.P(target, " = map[", keyType, "]", valueType, "{", key, ": ", g.mockScalarExpr(gf, valueField), "}")
+}
 
-	// Generate map entry based on value type
-	if valueField.Desc.Kind() == protoreflect.MessageKind {
-		// Value is a message type - use QualifiedGoIdent for proper imports
-		gf.P(
-			varName,
-			".",
-			fieldName,
-			" = make(map[",
-			keyType,
-			"]*",
-			gf.QualifiedGoIdent(valueField.Message.GoIdent),
-			")",
-		)
-		gf.P(varName, ".", fieldName, "[", sampleKey, "] = &", valueField.Message.GoIdent, "{}")
-		// Populate the value message fields
-		mapValueVar := varName + "." + fieldName + "[" + sampleKey + "]"
-		g.generateMockFieldAssignments(gf, valueField.Message, mapValueVar)
-	} else {
-		// Value is a scalar type
-		valueType := g.getGoTypeScalar(valueField)
-		gf.P(varName, ".", fieldName, " = make(map[", keyType, "]", valueType, ")")
-		defaultValue := g.getDefaultValue(valueField)
-		gf.P(varName, ".", fieldName, "[", sampleKey, "] = ", defaultValue)
+// mockMapKeyExpr returns a Go literal for the map key annotations.ResolveExampleMapKey picks.
+func mockMapKeyExpr(keyField *protogen.Field) string {
+	key := annotations.ResolveExampleMapKey(keyField)
+	if keyField.Desc.Kind() == protoreflect.StringKind {
+		return strconv.Quote(key.String())
 	}
+	return key.String()
+}
+
+// mockGoType returns the Go element type of a scalar or enum field.
+func (g *Generator) mockGoType(gf *protogen.GeneratedFile, field *protogen.Field) string {
+	if field.Enum != nil {
+		return gf.QualifiedGoIdent(field.Enum.GoIdent)
+	}
+	return g.getGoTypeScalar(field)
 }
 
 // getGoTypeScalar returns the Go type string for scalar fields only.
@@ -299,192 +454,3 @@ func (g *Generator) getGoTypeScalar(field *protogen.Field) string {
 		return kindInterface
 	}
 }
-
-// getSampleMapKey returns a sample key value for a map field.
-func (g *Generator) getSampleMapKey(keyField *protogen.Field) string {
-	switch keyField.Desc.Kind() {
-	case protoreflect.StringKind:
-		return `"sample_key"`
-	case protoreflect.Int32Kind, protoreflect.Int64Kind,
-		protoreflect.Sint32Kind, protoreflect.Sint64Kind,
-		protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
-		return "1"
-	case protoreflect.Uint32Kind, protoreflect.Uint64Kind,
-		protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
-		return "1"
-	case protoreflect.BoolKind:
-		return "true"
-	case protoreflect.EnumKind:
-		return "0"
-	case protoreflect.FloatKind, protoreflect.DoubleKind:
-		return "1.0"
-	case protoreflect.BytesKind, protoreflect.MessageKind, protoreflect.GroupKind:
-		return `"key"`
-	default:
-		return `"key"`
-	}
-}
-
-// getDefaultGenerator returns a function name for generating default values.
-func (g *Generator) getDefaultGenerator(field *protogen.Field) string {
-	fieldName := strings.ToLower(string(field.Desc.Name()))
-
-	// Check for common field names
-	switch {
-	case strings.Contains(fieldName, "id"):
-		return "generateUUID"
-	case strings.Contains(fieldName, "email"):
-		return "generateEmail"
-	case strings.Contains(fieldName, "name"):
-		return "generateName"
-	case strings.Contains(fieldName, "phone"):
-		return "generatePhone"
-	case strings.Contains(fieldName, "address"):
-		return "generateAddress"
-	case strings.Contains(fieldName, "url"):
-		return "generateURL"
-	default:
-		return "generateString"
-	}
-}
-
-// getDefaultValue returns a default value for a field.
-func (g *Generator) getDefaultValue(field *protogen.Field) string {
-	switch field.Desc.Kind() {
-	case protoreflect.Int32Kind, protoreflect.Int64Kind:
-		return "42"
-	case protoreflect.BoolKind:
-		return "true"
-	case protoreflect.FloatKind, protoreflect.DoubleKind:
-		return "3.14"
-	case protoreflect.EnumKind,
-		protoreflect.Sint32Kind,
-		protoreflect.Uint32Kind,
-		protoreflect.Sint64Kind,
-		protoreflect.Uint64Kind,
-		protoreflect.Sfixed32Kind,
-		protoreflect.Fixed32Kind,
-		protoreflect.Sfixed64Kind,
-		protoreflect.Fixed64Kind,
-		protoreflect.StringKind,
-		protoreflect.BytesKind,
-		protoreflect.MessageKind,
-		protoreflect.GroupKind:
-		return `""`
-	default:
-		return `""`
-	}
-}
-
-// generateMockHelpers generates helper functions for mock data generation.
-func (g *Generator) generateMockHelpers(gf *protogen.GeneratedFile) {
-	g.generateExampleSelectors(gf)
-	g.generateDefaultGenerators(gf)
-	g.generateInitFunction(gf)
-}
-
-// generateExampleSelectors generates functions to select examples from predefined values.
-func (g *Generator) generateExampleSelectors(gf *protogen.GeneratedFile) {
-	// String example selector
-	gf.P("// selectStringExample selects a random example or generates a default value.")
-	gf.P("func selectStringExample(fieldPath string, defaultGenerator func() string) string {")
-	gf.P("if examples, ok := fieldExamples[fieldPath]; ok && len(examples) > 0 {")
-	gf.P("return examples[rand.Intn(len(examples))]")
-	gf.P("}")
-	gf.P("return defaultGenerator()")
-	gf.P("}")
-	gf.P()
-
-	// Int example selector
-	gf.P("// selectIntExample selects a random example or returns a default value.")
-	gf.P("func selectIntExample(fieldPath string, defaultValue int64) int64 {")
-	gf.P("if examples, ok := fieldExamples[fieldPath]; ok && len(examples) > 0 {")
-	gf.P("example := examples[rand.Intn(len(examples))]")
-	gf.P("if v, err := strconv.ParseInt(example, 10, 64); err == nil {")
-	gf.P("return v")
-	gf.P("}")
-	gf.P("}")
-	gf.P("return defaultValue")
-	gf.P("}")
-	gf.P()
-
-	// Bool example selector
-	gf.P("// selectBoolExample selects a random example or returns a default value.")
-	gf.P("func selectBoolExample(fieldPath string, defaultValue bool) bool {")
-	gf.P("if examples, ok := fieldExamples[fieldPath]; ok && len(examples) > 0 {")
-	gf.P("example := examples[rand.Intn(len(examples))]")
-	gf.P("if v, err := strconv.ParseBool(example); err == nil {")
-	gf.P("return v")
-	gf.P("}")
-	gf.P("}")
-	gf.P("return defaultValue")
-	gf.P("}")
-	gf.P()
-
-	// Float example selector
-	gf.P("// selectFloatExample selects a random example or returns a default value.")
-	gf.P("func selectFloatExample(fieldPath string, defaultValue float64) float64 {")
-	gf.P("if examples, ok := fieldExamples[fieldPath]; ok && len(examples) > 0 {")
-	gf.P("example := examples[rand.Intn(len(examples))]")
-	gf.P("if v, err := strconv.ParseFloat(example, 64); err == nil {")
-	gf.P("return v")
-	gf.P("}")
-	gf.P("}")
-	gf.P("return defaultValue")
-	gf.P("}")
-	gf.P()
-}
-
-// generateDefaultGenerators generates default value generator functions.
-func (g *Generator) generateDefaultGenerators(gf *protogen.GeneratedFile) {
-	gf.P("// Default value generators")
-	gf.P("func generateUUID() string {")
-	gf.P("var b [16]byte")
-	gf.P("_, err := cryptorand.Read(b[:])")
-	gf.P("if err != nil {")
-	gf.P(`return "550e8400-e29b-41d4-a716-446655440000" // fallback`)
-	gf.P("}")
-	gf.P("b[6] = (b[6] & 0x0f) | 0x40 // Version 4")
-	gf.P("b[8] = (b[8] & 0x3f) | 0x80 // Variant bits")
-	gf.P("return fmt.Sprintf(\"%x-%x-%x-%x-%x\", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])")
-	gf.P("}")
-	gf.P()
-
-	gf.P("func generateEmail() string {")
-	gf.P(`return "user@example.com"`)
-	gf.P("}")
-	gf.P()
-
-	gf.P("func generateName() string {")
-	gf.P(`names := []string{"Alice Johnson", "Bob Smith", "Charlie Davis", "Diana Wilson"}`)
-	gf.P("return names[rand.Intn(len(names))]")
-	gf.P("}")
-	gf.P()
-
-	gf.P("func generatePhone() string {")
-	gf.P(`return "+1-555-0123"`)
-	gf.P("}")
-	gf.P()
-
-	gf.P("func generateAddress() string {")
-	gf.P(`return "123 Main Street, Anytown, USA"`)
-	gf.P("}")
-	gf.P()
-
-	gf.P("func generateURL() string {")
-	gf.P(`return "https://example.com"`)
-	gf.P("}")
-	gf.P()
-
-	gf.P("func generateString() string {")
-	gf.P(`return "example string"`)
-	gf.P("}")
-	gf.P()
-}
-
-// generateInitFunction generates the init function for seeding random number generator.
-func (g *Generator) generateInitFunction(gf *protogen.GeneratedFile) {
-	gf.P("func init() {")
-	gf.P("rand.Seed(time.Now().UnixNano())")
-	gf.P("}")
-}
@@ -0,0 +1,273 @@
+package httpgen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestMsgpackIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server and Go client with extra_codecs=msgpack,
+//  2. writes a temporary Go module with one package that imports msgpackcodec
+//     and one that does not,
+//  3. verifies a client using WireMsgpack round-trips requests and responses
+//     through the server, that the msgpack bodies are keyed by the JSON field
+//     names, and that without the codec the server answers msgpack bodies with
+//     415 and the client refuses to send them.
+func TestMsgpackIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:   "msgpack_test",
+		Protos: map[string]string{"orders.proto": msgpackProto},
+		Plugins: []plugintest.Plugin{
+			{Name: "go-http", Opt: "extra_codecs=msgpack"},
+			{Name: "go-client", Opt: "extra_codecs=msgpack"},
+		},
+		Files: map[string]string{
+			"msgpack_test.go":              msgpackIntegrationTestCode,
+			"nocodec/nocodec_test.go":      msgpackNoCodecTestCode,
+			"nocodec/order_server_test.go": msgpackOrderServerCode("nocodec_test"),
+			"order_server_test.go":         msgpackOrderServerCode("msgpack_test"),
+		},
+		Require: []string{"github.com/SebastienMelki/sebuf/http/msgpackcodec v0.0.0"},
+		Replace: []string{
+			"github.com/SebastienMelki/sebuf/http/msgpackcodec => " +
+				filepath.Join(plugintest.ProjectRoot(), "http", "msgpackcodec"),
+		},
+		// -e because msgpack's go.mod predates module graph pruning, so tidy
+		// also looks up the modules of its tests, which the build never needs.
+		TidyArgs: []string{"-e"},
+	})
+}
+
+const msgpackProto = `syntax = "proto3";
+package test.msgpack;
+option go_package = "msgpack_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service OrderService {
+  option (sebuf.http.service_config) = { base_path: "/api/v1" };
+  rpc CreateOrder(Order) returns (Order) {
+    option (sebuf.http.config) = { path: "/orders" method: HTTP_METHOD_POST };
+  }
+  rpc GetOrder(GetOrderRequest) returns (Order) {
+    option (sebuf.http.config) = { path: "/orders/{id}" method: HTTP_METHOD_GET };
+  }
+}
+
+enum OrderStatus {
+  ORDER_STATUS_UNSPECIFIED = 0;
+  ORDER_STATUS_PAID = 1;
+}
+
+message LineItem {
+  string product_id = 1;
+  int32 quantity = 2;
+}
+
+message Order {
+  string id = 1;
+  string customer_name = 2;
+  int64 total_cents = 3;
+  repeated LineItem line_items = 4;
+  OrderStatus status = 5;
+}
+
+message GetOrderRequest {
+  string id = 1;
+}
+`
+
+// msgpackOrderServerCode is the server implementation shared by both test
+// packages of the temp module.
+func msgpackOrderServerCode(pkg string) string {
+	return `package ` + pkg + `
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gen "msgpack_test/gen"
+)
+
+type orderServer struct {
+	gen.UnimplementedOrderServiceServer
+}
+
+func (orderServer) CreateOrder(_ context.Context, req *gen.Order) (*gen.Order, error) {
+	req.Id = "order-1"
+	return req, nil
+}
+
+func (orderServer) GetOrder(_ context.Context, req *gen.GetOrderRequest) (*gen.Order, error) {
+	return &gen.Order{Id: req.GetId(), CustomerName: "Ada", Status: gen.OrderStatus_ORDER_STATUS_PAID}, nil
+}
+
+// newServer serves the order service, passing each request body to record.
+func newServer(t *testing.T, record func(body []byte)) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterOrderServiceServer(orderServer{}, gen.WithMux(mux)); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(recordBodies(mux, record))
+	t.Cleanup(srv.Close)
+	return srv
+}
+`
+}
+
+// msgpackIntegrationTestCode is the test source of the package that imports
+// msgpackcodec.
+const msgpackIntegrationTestCode = `package msgpack_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/SebastienMelki/sebuf/http/msgpackcodec"
+
+	gen "msgpack_test/gen"
+)
+
+func recordBodies(next http.Handler, record func(body []byte)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if record != nil {
+			record(body)
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+func TestClientRoundTrip(t *testing.T) {
+	var sent []byte
+	srv := newServer(t, func(body []byte) { sent = body })
+	client := gen.NewOrderServiceClient(srv.URL, gen.WithOrderServiceWireFormat(gen.WireMsgpack))
+
+	order := &gen.Order{
+		CustomerName: "Ada",
+		TotalCents:   1999,
+		LineItems:    []*gen.LineItem{{ProductId: "p1", Quantity: 2}},
+		Status:       gen.OrderStatus_ORDER_STATUS_PAID,
+	}
+	got, err := client.CreateOrder(context.Background(), order)
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	want := proto.Clone(order).(*gen.Order)
+	want.Id = "order-1"
+	if !proto.Equal(got, want) {
+		t.Errorf("CreateOrder = %v, want %v", got, want)
+	}
+
+	fields, err := msgpackcodec.Codec{}.ToJSON(sent)
+	if err != nil {
+		t.Fatalf("request body is not msgpack: %v", err)
+	}
+	wantFields := ` + "`" + `{"customerName":"Ada","lineItems":[{"productId":"p1","quantity":2}],` + "`" + ` +
+		` + "`" + `"status":"ORDER_STATUS_PAID","totalCents":"1999"}` + "`" + `
+	if string(fields) != wantFields {
+		t.Errorf("request fields = %s, want %s", fields, wantFields)
+	}
+
+	got, err = client.GetOrder(context.Background(), &gen.GetOrderRequest{Id: "order-2"})
+	if err != nil {
+		t.Fatalf("GetOrder: %v", err)
+	}
+	if got.GetId() != "order-2" || got.GetStatus() != gen.OrderStatus_ORDER_STATUS_PAID {
+		t.Errorf("GetOrder = %v", got)
+	}
+}
+
+func TestServerAnswersInMsgpack(t *testing.T) {
+	srv := newServer(t, nil)
+	var codec msgpackcodec.Codec
+	body, err := codec.FromJSON([]byte(` + "`" + `{"customer_name":"Ada","totalCents":"5"}` + "`" + `))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Post(srv.URL+"/api/v1/orders", msgpackcodec.ContentType, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != msgpackcodec.ContentType {
+		t.Fatalf("status = %d, Content-Type = %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	respBody, _ := io.ReadAll(resp.Body)
+	out, err := codec.ToJSON(respBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := ` + "`" + `{"customerName":"Ada","id":"order-1","totalCents":"5"}` + "`" + `; string(out) != want {
+		t.Errorf("response = %s, want %s", out, want)
+	}
+}
+
+func TestInvalidMsgpackIsRejected(t *testing.T) {
+	srv := newServer(t, nil)
+	resp, err := http.Post(srv.URL+"/api/v1/orders", msgpackcodec.ContentType, bytes.NewReader([]byte{0xc1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", resp.StatusCode)
+	}
+}
+`
+
+// msgpackNoCodecTestCode is the test source of the package that does not
+// import msgpackcodec.
+const msgpackNoCodecTestCode = `package nocodec_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "msgpack_test/gen"
+)
+
+func recordBodies(next http.Handler, _ func(body []byte)) http.Handler {
+	return next
+}
+
+func TestServerWithoutCodec(t *testing.T) {
+	srv := newServer(t, nil)
+	resp, err := http.Post(srv.URL+"/api/v1/orders", "application/msgpack", strings.NewReader("\x81"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusUnsupportedMediaType ||
+		!strings.Contains(string(body), "no codec is registered for application/msgpack") {
+		t.Errorf("status = %d, body = %s, want 415", resp.StatusCode, body)
+	}
+}
+
+func TestClientWithoutCodec(t *testing.T) {
+	srv := newServer(t, nil)
+	client := gen.NewOrderServiceClient(srv.URL, gen.WithOrderServiceWireFormat(gen.WireMsgpack))
+	_, err := client.CreateOrder(context.Background(), &gen.Order{CustomerName: "Ada"})
+	var sebufErr *sebufhttp.Error
+	if !errors.As(err, &sebufErr) || sebufErr.GetCode() != sebufhttp.ErrorCodeUnsupportedMediaType {
+		t.Errorf("err = %v, want an UNSUPPORTED_MEDIA_TYPE error", err)
+	}
+}
+`
@@ -0,0 +1,135 @@
+package httpgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// generateMultipartBindingFunctions generates the binding of multipart/form-data bodies
+// for methods annotated with accept_multipart. File parts bind to bytes fields by the
+// part's form name, and their filename to the string field whose multipart_filename
+// annotation names that bytes field. Other parts bind with bindFormValue, like forms.
+// Required parts are enforced by the request's buf.validate rules. The raw body is
+// left for the handlers after binding to read again, as the other binders do.
+func (g *Generator) generateMultipartBindingFunctions(gf *protogen.GeneratedFile) {
+	gf.P("// bindDataFromMultipartRequest binds a multipart/form-data body. A file part binds its")
+	gf.P("// content to the bytes field named by the part's form name (a repeated bytes field takes")
+	gf.P("// one part per occurrence) and its filename to the field annotated multipart_filename")
+	gf.P("// for it. Other parts bind like form values. Unknown names are ignored.")
+	gf.P("func bindDataFromMultipartRequest[Req any](r *http.Request, toBind *Req) error {")
+	gf.P("protoRequest, ok := any(toBind).(proto.Message)")
+	gf.P("if !ok {")
+	gf.P(`return errors.New("multipart request is not a protocol buffer message")`)
+	gf.P("}")
+	gf.P()
+	gf.P("// Keep the raw body for the handlers after binding, such as idempotency, to read again")
+	gf.P("var raw bytes.Buffer")
+	gf.P("r.Body = io.NopCloser(io.TeeReader(r.Body, &raw))")
+	gf.P("reader, err := r.MultipartReader()")
+	gf.P("if err != nil {")
+	gf.P(`return fmt.Errorf("could not parse multipart body: %w", err)`)
+	gf.P("}")
+	gf.P()
+	gf.P("reflectMsg := protoRequest.ProtoReflect()")
+	gf.P("values := make(map[string][]string)")
+	gf.P("var violations []*sebufhttp.FieldViolation")
+	gf.P("for {")
+	gf.P("part, partErr := reader.NextPart()")
+	gf.P("if errors.Is(partErr, io.EOF) {")
+	gf.P("break")
+	gf.P("}")
+	gf.P("if partErr != nil {")
+	gf.P(`return fmt.Errorf("could not read multipart body: %w", partErr)`)
+	gf.P("}")
+	gf.P("data, readErr := io.ReadAll(part)")
+	gf.P("_ = part.Close()")
+	gf.P("if readErr != nil {")
+	gf.P(`return fmt.Errorf("could not read multipart part %q: %w", part.FormName(), readErr)`)
+	gf.P("}")
+	gf.P("name := part.FormName()")
+	gf.P(`if name == "" {`)
+	gf.P("continue")
+	gf.P("}")
+	gf.P("// Parts without a filename bind like form values, unless they name a bytes field")
+	gf.P("field := multipartField(reflectMsg.Descriptor(), name)")
+	gf.P(`if part.FileName() == "" && (field == nil || field.Kind() != protoreflect.BytesKind) {`)
+	gf.P("values[name] = append(values[name], string(data))")
+	gf.P("continue")
+	gf.P("}")
+	gf.P("if violation := bindMultipartFile(reflectMsg, field, name, part.FileName(), data); violation != nil {")
+	gf.P("violations = append(violations, violation)")
+	gf.P("}")
+	gf.P("}")
+	gf.P("r.Body = io.NopCloser(&raw)")
+	gf.P()
+	gf.P("// Bind values in a stable order so violations are reported deterministically")
+	gf.P("keys := make([]string, 0, len(values))")
+	gf.P("for key := range values {")
+	gf.P("keys = append(keys, key)")
+	gf.P("}")
+	gf.P("sort.Strings(keys)")
+	gf.P("for _, key := range keys {")
+	gf.P("if violation := bindFormValue(reflectMsg, key, values[key]); violation != nil {")
+	gf.P("violations = append(violations, violation)")
+	gf.P("}")
+	gf.P("}")
+	gf.P("if len(violations) > 0 {")
+	gf.P("return &sebufhttp.ValidationError{Violations: violations}")
+	gf.P("}")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// multipartField returns the field a part binds to by its proto or JSON name, or nil.")
+	gf.P("func multipartField(desc protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {")
+	gf.P("field := desc.Fields().ByName(protoreflect.Name(name))")
+	gf.P("if field == nil {")
+	gf.P("field = desc.Fields().ByJSONName(name)")
+	gf.P("}")
+	gf.P("return field")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// bindMultipartFile binds a file part to field, and its filename to the string field")
+	gf.P("// annotated with multipart_filename for that field.")
+	gf.P("func bindMultipartFile(")
+	gf.P("reflectMsg protoreflect.Message, field protoreflect.FieldDescriptor, name, filename string, data []byte,")
+	gf.P(") *sebufhttp.FieldViolation {")
+	gf.P("if field == nil {")
+	gf.P("return nil // Field not found, skip")
+	gf.P("}")
+	gf.P("if field.Kind() != protoreflect.BytesKind || field.IsMap() {")
+	gf.P("return &sebufhttp.FieldViolation{")
+	gf.P("Field:       string(field.Name()),")
+	gf.P(`Description: fmt.Sprintf("multipart file %s must bind to a bytes field", name),`)
+	gf.P("}")
+	gf.P("}")
+	gf.P("if field.IsList() {")
+	gf.P("reflectMsg.Mutable(field).List().Append(protoreflect.ValueOfBytes(data))")
+	gf.P("} else {")
+	gf.P("reflectMsg.Set(field, protoreflect.ValueOfBytes(data))")
+	gf.P("}")
+	gf.P(`if filename == "" {`)
+	gf.P("return nil")
+	gf.P("}")
+	gf.P()
+	gf.P("fields := reflectMsg.Descriptor().Fields()")
+	gf.P("for i := 0; i < fields.Len(); i++ {")
+	gf.P("filenameField := fields.Get(i)")
+	gf.P("opts, ok := filenameField.Options().(*descriptorpb.FieldOptions)")
+	gf.P("if !ok {")
+	gf.P("continue")
+	gf.P("}")
+	gf.P("target, _ := proto.GetExtension(opts, sebufhttp.E_MultipartFilename).(string)")
+	gf.P("if target != string(field.Name()) {")
+	gf.P("continue")
+	gf.P("}")
+	gf.P("if filenameField.IsList() {")
+	gf.P("reflectMsg.Mutable(filenameField).List().Append(protoreflect.ValueOfString(filename))")
+	gf.P("} else {")
+	gf.P("reflectMsg.Set(filenameField, protoreflect.ValueOfString(filename))")
+	gf.P("}")
+	gf.P("}")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P()
+}
@@ -0,0 +1,258 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestMultipartBodyIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server from a proto with one method annotated with
+//     accept_multipart: true and one without,
+//  2. writes a temporary Go module that serves it with httptest,
+//  3. verifies multipart bodies bind file parts and their filenames alongside
+//     ordinary values, report missing required parts per field, answer oversized
+//     uploads with 413, are rejected by the method that did not opt in, and are
+//     deduplicated by their parts, files included, by an idempotent method.
+func TestMultipartBodyIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "multipart_body_test",
+		Protos:  map[string]string{"upload.proto": multipartBodyProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"multipart_body_test.go": multipartBodyIntegrationTestCode},
+	})
+}
+
+const multipartBodyProto = `syntax = "proto3";
+package test.multipartbody;
+option go_package = "multipart_body_test/gen;gen";
+import "buf/validate/validate.proto";
+import "sebuf/http/annotations.proto";
+
+service UploadService {
+  rpc Upload(UploadRequest) returns (UploadRequest) {
+    option (sebuf.http.config) = { path: "/folders/{folder_id}/files" method: HTTP_METHOD_POST accept_multipart: true };
+  }
+  rpc Import(UploadRequest) returns (UploadRequest) {
+    option (sebuf.http.config) = { path: "/imports/{folder_id}" method: HTTP_METHOD_POST };
+  }
+  rpc Publish(UploadRequest) returns (UploadRequest) {
+    option (sebuf.http.config) = {
+      path: "/folders/{folder_id}/publications" method: HTTP_METHOD_POST accept_multipart: true idempotency: true
+    };
+  }
+}
+
+message UploadRequest {
+  string folder_id = 1;
+  bytes content = 2 [(buf.validate.field).required = true];
+  string filename = 3 [(sebuf.http.multipart_filename) = "content"];
+  int32 revision = 4;
+  repeated string tags = 5;
+  repeated bytes attachments = 6;
+  repeated string attachment_names = 7 [(sebuf.http.multipart_filename) = "attachments"];
+}
+`
+
+// multipartBodyIntegrationTestCode is the test source that runs inside the temp
+// module. Every method echoes the request it was bound with.
+const multipartBodyIntegrationTestCode = `package multipart_body_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "multipart_body_test/gen"
+)
+
+type uploadServer struct{}
+
+func (uploadServer) Upload(_ context.Context, req *gen.UploadRequest) (*gen.UploadRequest, error) {
+	return req, nil
+}
+
+func (uploadServer) Import(_ context.Context, req *gen.UploadRequest) (*gen.UploadRequest, error) {
+	return req, nil
+}
+
+var publications atomic.Int32
+
+func (uploadServer) Publish(_ context.Context, req *gen.UploadRequest) (*gen.UploadRequest, error) {
+	publications.Add(1)
+	return req, nil
+}
+
+func newServer(t *testing.T, opts ...gen.ServerOption) string {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterUploadServiceServer(uploadServer{}, append(opts, gen.WithMux(mux))...); err != nil {
+		t.Fatalf("RegisterUploadServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+type part struct {
+	name, filename, content string
+}
+
+func postMultipart(t *testing.T, target string, parts ...part) (int, []byte) {
+	t.Helper()
+	return postIdempotentMultipart(t, target, "", parts...)
+}
+
+// postIdempotentMultipart posts parts with Idempotency-Key key, unless it is
+// empty. Every call picks a new boundary, as clients do.
+func postIdempotentMultipart(t *testing.T, target, key string, parts ...part) (int, []byte) {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	for _, p := range parts {
+		var w io.Writer
+		var err error
+		if p.filename != "" {
+			w, err = writer.CreateFormFile(p.name, p.filename)
+		} else {
+			w, err = writer.CreateFormField(p.name)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(w, p.content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest(http.MethodPost, target, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if key != "" {
+		req.Header.Set(sebufhttp.IdempotencyKeyHeader, key)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST %s: %v", target, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, body
+}
+
+func TestMultipartBindsFilesAndValues(t *testing.T) {
+	base := newServer(t)
+	status, body := postMultipart(t, base+"/folders/docs/files",
+		part{name: "content", filename: "report.pdf", content: "%PDF-1.7"},
+		part{name: "revision", content: "3"},
+		part{name: "tags", content: "q3"},
+		part{name: "tags", content: "final"},
+		part{name: "attachments", filename: "a.txt", content: "A"},
+		part{name: "attachments", filename: "b.txt", content: "B"},
+		part{name: "unknown", content: "x"},
+	)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", status, body)
+	}
+
+	got := &gen.UploadRequest{}
+	if err := protojson.Unmarshal(body, got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := &gen.UploadRequest{
+		FolderId:        "docs",
+		Content:         []byte("%PDF-1.7"),
+		Filename:        "report.pdf",
+		Revision:        3,
+		Tags:            []string{"q3", "final"},
+		Attachments:     [][]byte{[]byte("A"), []byte("B")},
+		AttachmentNames: []string{"a.txt", "b.txt"},
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("bound request = %v, want %v", got, want)
+	}
+}
+
+func TestMultipartReportsViolationsPerField(t *testing.T) {
+	base := newServer(t)
+	status, body := postMultipart(t, base+"/folders/docs/files", part{name: "revision", content: "latest"})
+	if status != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400; body = %s", status, body)
+	}
+	if !strings.Contains(string(body), "revision") {
+		t.Errorf("expected a revision violation, got %s", body)
+	}
+
+	status, body = postMultipart(t, base+"/folders/docs/files", part{name: "revision", content: "1"})
+	if status != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400; body = %s", status, body)
+	}
+	validationErr := &sebufhttp.ValidationError{}
+	if err := protojson.Unmarshal(body, validationErr); err != nil {
+		t.Fatalf("decode validation error: %v (%s)", err, body)
+	}
+	if len(validationErr.GetViolations()) != 1 || validationErr.GetViolations()[0].GetField() != "content" {
+		t.Errorf("expected a content violation for the missing file part, got %s", body)
+	}
+}
+
+func TestMultipartOversizedUpload(t *testing.T) {
+	base := newServer(t, gen.WithMaxBodySize(1024))
+	status, body := postMultipart(t, base+"/folders/docs/files",
+		part{name: "content", filename: "big.bin", content: strings.Repeat("x", 4096)},
+	)
+	if status != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want 413; body = %s", status, body)
+	}
+}
+
+func TestMultipartRejectedWithoutOptIn(t *testing.T) {
+	base := newServer(t)
+	status, body := postMultipart(t, base+"/imports/docs", part{name: "content", filename: "a.txt", content: "A"})
+	if status != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400; body = %s", status, body)
+	}
+	if !strings.Contains(string(body), "body") {
+		t.Errorf("expected a body violation, got %s", body)
+	}
+}
+
+func TestMultipartIdempotency(t *testing.T) {
+	base := newServer(t)
+	target := base + "/folders/docs/publications"
+	status, first := postIdempotentMultipart(t, target, "k1", part{name: "content", filename: "a.txt", content: "A"})
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", status, first)
+	}
+
+	status, replay := postIdempotentMultipart(t, target, "k1", part{name: "content", filename: "a.txt", content: "A"})
+	if status != http.StatusOK || !bytes.Equal(replay, first) {
+		t.Errorf("retry = %d %s, want the replay of %s", status, replay, first)
+	}
+	status, body := postIdempotentMultipart(t, target, "k1", part{name: "content", filename: "a.txt", content: "B"})
+	if status != http.StatusConflict {
+		t.Errorf("status with a changed file = %d, want 409; body = %s", status, body)
+	}
+	if got := publications.Load(); got != 1 {
+		t.Errorf("Publish ran %d times, want 1", got)
+	}
+}
+`
@@ -0,0 +1,130 @@
+package httpgen
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMultipartBindingGeneration verifies that only methods annotated with
+// accept_multipart: true pass AcceptMultipart to BindingMiddleware, and that
+// the multipart binding is only generated for files that use it.
+func TestMultipartBindingGeneration(t *testing.T) {
+	files := generateTestFiles(t, "multipart_upload.proto")
+
+	for want, count := range map[string]int{
+		"\"POST\", BodyConfig{AcceptMultipart: true, StrictJSON: config.strictJSON, " + bodyConfigTail: 1,
+		"\"POST\", BodyConfig{AcceptMultipart: true, OptionalBody: true, NoValidationRules: true, " +
+			"StrictJSON: config.strictJSON, " + bodyConfigTail: 1,
+		"\"PATCH\", BodyConfig{OptionalBody: true, NoValidationRules: true, StrictJSON: config.strictJSON, " +
+			bodyConfigTail: 1,
+	} {
+		if n := strings.Count(files.http, want); n != count {
+			t.Errorf("expected %d handlers registered with %q, got %d", count, want, n)
+		}
+	}
+	for _, want := range []string{
+		"case MultipartContentType:",
+		"func bindDataFromMultipartRequest[Req any](",
+		"maxSize = sebufhttp.DefaultMaxMultipartBodySize",
+	} {
+		if !strings.Contains(files.binding, want) {
+			t.Errorf("binding file should contain %q", want)
+		}
+	}
+
+	formFiles := generateTestFiles(t, "form_body.proto")
+	if strings.Contains(formFiles.binding, "bindDataFromMultipartRequest") {
+		t.Error("files without accept_multipart methods should not generate multipart binding")
+	}
+}
+
+// TestMultipartRejectedConfigs verifies generation fails when accept_multipart
+// is set on a method without a body, or a multipart_filename annotation does not
+// name a bytes field.
+func TestMultipartRejectedConfigs(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping accept_multipart validation test")
+	}
+
+	baseDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	projectRoot := filepath.Join(baseDir, "..", "..")
+	pluginPath := filepath.Join(projectRoot, "bin", "protoc-gen-go-http")
+	if _, statErr := os.Stat(pluginPath); os.IsNotExist(statErr) {
+		t.Skip("protoc-gen-go-http not built, run make build")
+	}
+
+	tests := []struct {
+		name    string
+		verb    string
+		fields  string
+		wantErr string
+	}{
+		{
+			name:    "GET",
+			verb:    "GET",
+			wantErr: "accept_multipart is only supported on methods with a request body",
+		},
+		{
+			name:    "DELETE",
+			verb:    "DELETE",
+			wantErr: "accept_multipart is only supported on methods with a request body",
+		},
+		{
+			name:    "filename of a string field",
+			verb:    "POST",
+			fields:  `string note = 1; string name = 2 [(sebuf.http.multipart_filename) = "note"];`,
+			wantErr: `multipart_filename "note" does not name a bytes field`,
+		},
+		{
+			name:    "filename on a non-string field",
+			verb:    "POST",
+			fields:  `bytes file = 1; int32 name = 2 [(sebuf.http.multipart_filename) = "file"];`,
+			wantErr: "multipart_filename is only valid on string fields",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			protoDir := t.TempDir()
+			protoSrc := `syntax = "proto3";
+package test.multipart;
+option go_package = "example.com/multipart;multipart";
+import "sebuf/http/annotations.proto";
+service Files {
+  rpc Upload(UploadRequest) returns (UploadResponse) {
+    option (sebuf.http.config) = { path: "/files" method: HTTP_METHOD_` + tt.verb + ` accept_multipart: true };
+  }
+}
+message UploadRequest { ` + tt.fields + ` }
+message UploadResponse {}
+`
+			if writeErr := os.WriteFile(filepath.Join(protoDir, "multipart.proto"), []byte(protoSrc), 0o600); writeErr != nil {
+				t.Fatalf("Failed to write proto: %v", writeErr)
+			}
+
+			cmd := exec.Command("protoc",
+				"--plugin=protoc-gen-go-http="+pluginPath,
+				"--go-http_out="+t.TempDir(),
+				"--go-http_opt=paths=source_relative",
+				"--proto_path="+protoDir,
+				"--proto_path="+filepath.Join(projectRoot, "proto"),
+				"multipart.proto",
+			)
+			var stderr bytes.Buffer
+			cmd.Stderr = &stderr
+			if runErr := cmd.Run(); runErr == nil {
+				t.Fatalf("expected generation to fail")
+			}
+			if !strings.Contains(stderr.String(), tt.wantErr) {
+				t.Errorf("unexpected error output: %s", stderr.String())
+			}
+		})
+	}
+}
@@ -1,11 +1,13 @@
 package httpgen
 
 import (
+	"slices"
 	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
 
 	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/encodinggen"
 )
 
 // NullableContext holds information about messages that need custom JSON encoding
@@ -94,7 +96,9 @@ func (g *Generator) generateNullableEncodingFile(file *protogen.File) error {
 	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
 
 	g.writeHeader(gf, file)
-	g.writeNullableImports(gf)
+	g.writeNullableImports(gf, slices.ContainsFunc(contexts, func(ctx *NullableContext) bool {
+		return encodinggen.UsesSebufHTTP(ctx.Message)
+	}))
 
 	for _, ctx := range contexts {
 		g.generateNullableMarshalJSON(gf, ctx)
@@ -104,11 +108,12 @@ func (g *Generator) generateNullableEncodingFile(file *protogen.File) error {
 	return nil
 }
 
-func (g *Generator) writeNullableImports(gf *protogen.GeneratedFile) {
+func (g *Generator) writeNullableImports(gf *protogen.GeneratedFile, jsonNaming bool) {
 	gf.P("import (")
 	gf.P(`"encoding/json"`)
 	gf.P()
 	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
+	encodinggen.WriteJSONNamingImport(gf, jsonNaming)
 	gf.P(")")
 	gf.P()
 }
@@ -135,7 +140,7 @@ func (g *Generator) generateNullableMarshalJSON(gf *protogen.GeneratedFile, ctx
 	gf.P()
 
 	gf.P("// Use protojson for base serialization")
-	gf.P("data, err := opts.Marshal(x)")
+	gf.P("data, err := ", encodinggen.ProtoMarshalCall(ctx.Message))
 	gf.P("if err != nil {")
 	gf.P("return nil, err")
 	gf.P("}")
@@ -150,7 +155,7 @@ func (g *Generator) generateNullableMarshalJSON(gf *protogen.GeneratedFile, ctx
 
 	// For each nullable field, emit null when not set
 	for _, field := range ctx.NullableFields {
-		jsonName := field.Desc.JSONName()
+		jsonName := annotations.JSONFieldName(field)
 		goName := field.GoName
 
 		gf.P("// Handle nullable field: ", field.Desc.Name())
@@ -182,6 +187,7 @@ func (g *Generator) generateNullableUnmarshalJSON(gf *protogen.GeneratedFile, ct
 		fieldNames = append(fieldNames, string(f.Desc.Name()))
 	}
 
+	encodinggen.WriteAlternateJSONNames(gf, ctx.Message)
 	gf.P("// UnmarshalJSON implements json.Unmarshaler for ", msgName, ".")
 	gf.P("// This method handles nullable fields: ", strings.Join(fieldNames, ", "))
 	gf.P("func (x *", msgName, ") UnmarshalJSON(data []byte) error {")
@@ -190,12 +196,13 @@ func (g *Generator) generateNullableUnmarshalJSON(gf *protogen.GeneratedFile, ct
 	gf.P("if err := json.Unmarshal(data, &raw); err != nil {")
 	gf.P("return err")
 	gf.P("}")
+	encodinggen.WriteResolveAlternateJSONNames(gf, ctx.Message)
 	gf.P()
 
 	// For nullable fields, remove explicit nulls before protojson unmarshal
 	// protojson doesn't handle null for scalar optionals, so we remove them
 	for _, field := range ctx.NullableFields {
-		jsonName := field.Desc.JSONName()
+		jsonName := annotations.JSONFieldName(field)
 
 		gf.P("// Handle nullable field: ", field.Desc.Name())
 		gf.P("// Remove explicit null so protojson leaves field unset")
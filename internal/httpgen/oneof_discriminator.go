@@ -2,11 +2,13 @@ package httpgen
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
 
 	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/encodinggen"
 )
 
 // OneofDiscriminatorContext holds information about a message that needs custom JSON encoding
@@ -83,7 +85,7 @@ func validateOneofDiscriminatorInMessages(messages []*protogen.Message) error {
 func checkMarshalJSONConflict(message *protogen.Message) error {
 	var conflicts []string
 
-	if hasInt64NumberFields(message) {
+	if encodinggen.HasInt64NumberFields(message) {
 		conflicts = append(conflicts, "int64_encoding=NUMBER")
 	}
 	if hasNullableFields(message) {
@@ -92,13 +94,13 @@ func checkMarshalJSONConflict(message *protogen.Message) error {
 	if hasEmptyBehaviorFields(message) {
 		conflicts = append(conflicts, "empty_behavior")
 	}
-	if hasTimestampFormatFields(message) {
+	if encodinggen.HasTimestampFormatFields(message) {
 		conflicts = append(conflicts, "timestamp_format")
 	}
-	if hasBytesEncodingFields(message) {
+	if encodinggen.HasBytesEncodingFields(message) {
 		conflicts = append(conflicts, "bytes_encoding")
 	}
-	if hasCustomEnumFields(message) {
+	if encodinggen.HasCustomEnumFields(message) {
 		conflicts = append(conflicts, "enum_value")
 	}
 
@@ -137,7 +139,9 @@ func (g *Generator) generateOneofDiscriminatorFile(file *protogen.File) error {
 	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
 
 	g.writeHeader(gf, file)
-	g.writeOneofDiscriminatorImports(gf)
+	g.writeOneofDiscriminatorImports(gf, slices.ContainsFunc(contexts, func(ctx *OneofDiscriminatorContext) bool {
+		return encodinggen.UsesSebufHTTP(ctx.Message)
+	}))
 
 	for _, ctx := range contexts {
 		g.generateOneofMarshalJSON(gf, ctx)
@@ -148,12 +152,13 @@ func (g *Generator) generateOneofDiscriminatorFile(file *protogen.File) error {
 }
 
 // writeOneofDiscriminatorImports writes the imports needed for oneof discriminator encoding.
-func (g *Generator) writeOneofDiscriminatorImports(gf *protogen.GeneratedFile) {
+func (g *Generator) writeOneofDiscriminatorImports(gf *protogen.GeneratedFile, jsonNaming bool) {
 	gf.P("import (")
 	gf.P(`"encoding/json"`)
 	gf.P(`"fmt"`)
 	gf.P()
 	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
+	encodinggen.WriteJSONNamingImport(gf, jsonNaming)
 	gf.P(")")
 	gf.P()
 }
@@ -182,7 +187,7 @@ func (g *Generator) generateOneofMarshalJSON(gf *protogen.GeneratedFile, ctx *On
 	gf.P()
 
 	gf.P("// Use protojson for base serialization")
-	gf.P("data, err := opts.Marshal(x)")
+	gf.P("data, err := ", encodinggen.ProtoMarshalCall(ctx.Message))
 	gf.P("if err != nil {")
 	gf.P("return nil, err")
 	gf.P("}")
@@ -244,7 +249,7 @@ func (g *Generator) generateFlattenedMarshal(
 	variant annotations.OneofVariant,
 ) {
 	fieldGoName := variant.Field.GoName
-	fieldJSONName := variant.Field.Desc.JSONName()
+	fieldJSONName := annotations.JSONFieldName(variant.Field)
 
 	gf.P("// Flatten: forward opts to variant via MarshalJSONSebuf when available")
 	gf.P("if inner := x.Get", fieldGoName, "(); inner != nil {")
@@ -284,6 +289,7 @@ func (g *Generator) generateOneofUnmarshalJSON(gf *protogen.GeneratedFile, ctx *
 		oneofNames = append(oneofNames, string(info.Oneof.Desc.Name()))
 	}
 
+	encodinggen.WriteAlternateJSONNames(gf, ctx.Message)
 	gf.P("// UnmarshalJSON implements json.Unmarshaler for ", msgName, ".")
 	gf.P("// This method handles oneof discriminator fields: ", strings.Join(oneofNames, ", "))
 	gf.P("func (x *", msgName, ") UnmarshalJSON(data []byte) error {")
@@ -292,6 +298,7 @@ func (g *Generator) generateOneofUnmarshalJSON(gf *protogen.GeneratedFile, ctx *
 	gf.P("if err := json.Unmarshal(data, &raw); err != nil {")
 	gf.P("return err")
 	gf.P("}")
+	encodinggen.WriteResolveAlternateJSONNames(gf, ctx.Message)
 	gf.P()
 
 	for _, info := range ctx.Oneofs {
@@ -358,12 +365,12 @@ func (g *Generator) generateFlattenedUnmarshal(
 	fieldGoName := variant.Field.GoName
 	wrapperType := variant.Field.GoIdent.GoName
 	msgType := variant.Field.Message.GoIdent.GoName
-	fieldJSONName := variant.Field.Desc.JSONName()
+	fieldJSONName := annotations.JSONFieldName(variant.Field)
 
 	// Collect all child field JSON names for this variant
 	var childJSONNames []string
 	for _, childField := range variant.Field.Message.Fields {
-		childJSONNames = append(childJSONNames, childField.Desc.JSONName())
+		childJSONNames = append(childJSONNames, annotations.JSONFieldName(childField))
 	}
 
 	gf.P("// Flatten unmarshal: extract ", fieldGoName, " fields from flat map")
@@ -379,35 +386,45 @@ func (g *Generator) generateFlattenedUnmarshal(
 
 	gf.P("variantData, _ := json.Marshal(variantMap)")
 	gf.P("variant := &", msgType, "{}")
-	gf.P("if err := json.Unmarshal(variantData, variant); err != nil {")
-	gf.P(`return fmt.Errorf("failed to unmarshal variant %s: %%w", "`, fieldGoName, `", err)`)
-	gf.P("}")
+	generateVariantUnmarshal(gf, "variantData", fieldGoName)
 	gf.P("x.", info.Oneof.GoName, " = &", wrapperType, "{", fieldGoName, ": variant}")
 
 	// Add the variant back to raw under its original field name for protojson
-	// (protojson expects the oneof wrapper format)
-	gf.P(`raw["`, fieldJSONName, `"], _ = json.Marshal(variant)`)
+	// (protojson expects the oneof wrapper format, in its own encoding)
+	gf.P(`raw["`, fieldJSONName, `"], _ = protojson.Marshal(variant)`)
+}
+
+// generateVariantUnmarshal decodes dataVar into variant, through the variant's
+// own UnmarshalJSON when it has one and protojson otherwise. encoding/json must
+// not decode a plain message: its struct tags carry proto names, not JSON names.
+func generateVariantUnmarshal(gf *protogen.GeneratedFile, dataVar, fieldGoName string) {
+	gf.P("var variantErr error")
+	gf.P("if u, ok := any(variant).(json.Unmarshaler); ok {")
+	gf.P("variantErr = u.UnmarshalJSON(", dataVar, ")")
+	gf.P("} else {")
+	gf.P("variantErr = protojson.Unmarshal(", dataVar, ", variant)")
+	gf.P("}")
+	gf.P("if variantErr != nil {")
+	gf.P(`return fmt.Errorf("failed to unmarshal variant %s: %%w", "`, fieldGoName, `", variantErr)`)
+	gf.P("}")
 }
 
 // generateNestedUnmarshal generates non-flattened unmarshal code for a message variant.
 // For non-flattened mode, the variant is already nested under its field name.
-// We use json.Unmarshal to invoke the child's UnmarshalJSON if it has one.
 func (g *Generator) generateNestedUnmarshal(
 	gf *protogen.GeneratedFile,
 	variant annotations.OneofVariant,
 	info *annotations.OneofDiscriminatorInfo,
 ) {
 	fieldGoName := variant.Field.GoName
-	fieldJSONName := variant.Field.Desc.JSONName()
+	fieldJSONName := annotations.JSONFieldName(variant.Field)
 	wrapperType := variant.Field.GoIdent.GoName
 	msgType := variant.Field.Message.GoIdent.GoName
 
-	gf.P("// Non-flattened unmarshal: use json.Unmarshal for child UnmarshalJSON support")
+	gf.P("// Non-flattened unmarshal: use the child's UnmarshalJSON when it has one")
 	gf.P(`if variantRaw, exists := raw["`, fieldJSONName, `"]; exists {`)
 	gf.P("variant := &", msgType, "{}")
-	gf.P("if err := json.Unmarshal(variantRaw, variant); err != nil {")
-	gf.P(`return fmt.Errorf("failed to unmarshal variant %s: %%w", "`, fieldGoName, `", err)`)
-	gf.P("}")
+	generateVariantUnmarshal(gf, "variantRaw", fieldGoName)
 	gf.P("x.", info.Oneof.GoName, " = &", wrapperType, "{", fieldGoName, ": variant}")
 	gf.P("}")
 }
@@ -168,8 +168,8 @@ func TestOneofDiscriminatorOpenAPISchemas(t *testing.T) {
 			endIdx = len(yamlContent)
 		}
 		window := yamlContent[nestedDiscIdx:endIdx]
-		if !strings.Contains(window, "vid: '#/components/schemas/VideoContent'") {
-			t.Error("OpenAPI NestedEvent should map 'vid' to VideoContent (custom oneof_value)")
+		if !strings.Contains(window, "vid: '#/components/schemas/NestedEvent_vid'") {
+			t.Error("OpenAPI NestedEvent should map 'vid' to NestedEvent_vid variant schema (custom oneof_value)")
 		}
 	}
 
@@ -0,0 +1,42 @@
+package httpgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// generateProblemErrorHandlerFunc generates problemErrorHandler, which
+// getConfiguration wraps the error handler with under WithProblemJSON.
+func (g *Generator) generateProblemErrorHandlerFunc(gf *protogen.GeneratedFile) {
+	gf.P("// problemErrorHandler wraps handler, which may be nil, to answer the errors it")
+	gf.P("// leaves to the server with Problem Details (RFC 9457) as application/problem+json.")
+	gf.P("// A message the handler returns is written as usual, and a status it sets is kept.")
+	gf.P("func problemErrorHandler(handler ErrorHandler) ErrorHandler {")
+	gf.P("return func(w http.ResponseWriter, r *http.Request, err error) proto.Message {")
+	gf.P("capture := guardResponse(w)")
+	gf.P("if handler != nil {")
+	gf.P("if response := handler(capture, r, err); response != nil || capture.written {")
+	gf.P("return response")
+	gf.P("}")
+	gf.P("}")
+	gf.P("statusCode := defaultErrorStatusCode(err)")
+	gf.P("if capture.wroteHeader {")
+	gf.P("statusCode = capture.statusCode")
+	gf.P("}")
+	gf.P("sebufhttp.WriteProblem(capture, r, err, statusCode, capture.wroteHeader)")
+	gf.P("return nil")
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+}
+
+// registerOptions returns the options expression Register<Service>Server reads
+// its configuration from: opts, after WithProblemJSON for services with
+// error_format PROBLEM_JSON.
+func registerOptions(service *protogen.Service) string {
+	if annotations.IsProblemJSON(service) {
+		return "append([]ServerOption{WithProblemJSON()}, opts...)..."
+	}
+	return "opts..."
+}
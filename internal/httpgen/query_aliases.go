@@ -0,0 +1,85 @@
+package httpgen
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// hasQueryAliases reports whether a query parameter of a method accepts
+// aliases.
+func hasQueryAliases(method *protogen.Method) bool {
+	return slices.ContainsFunc(annotations.GetQueryParams(method.Input), func(qp annotations.QueryParam) bool {
+		return len(qp.Aliases) > 0
+	})
+}
+
+// queryAliasesLiteral returns the []string literal of the aliases of qp.
+func queryAliasesLiteral(qp annotations.QueryParam) string {
+	quoted := make([]string, len(qp.Aliases))
+	for i, alias := range qp.Aliases {
+		quoted[i] = strconv.Quote(alias)
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
+
+// generateQueryParamValuesFunc generates queryParamValues, through which
+// bindQueryParams reads a parameter under its name or one of its aliases.
+func (g *Generator) generateQueryParamValuesFunc(gf *protogen.GeneratedFile) {
+	gf.P("// queryParamValues returns the values of the query parameter of param, or those")
+	gf.P("// of its first alias sent with a non-empty value when it has none, so the")
+	gf.P("// parameter's name wins over its aliases.")
+	gf.P("func queryParamValues(query map[string][]string, param QueryParamConfig) []string {")
+	gf.P("values := query[param.QueryName]")
+	gf.P("for _, alias := range param.Aliases {")
+	gf.P("if hasQueryValue(values) {")
+	gf.P("break")
+	gf.P("}")
+	gf.P("values = query[alias]")
+	gf.P("}")
+	gf.P("return values")
+	gf.P("}")
+	gf.P()
+	gf.P("// hasQueryValue reports whether values holds a non-empty value.")
+	gf.P("func hasQueryValue(values []string) bool {")
+	gf.P("for _, v := range values {")
+	gf.P(`if v != "" {`)
+	gf.P("return true")
+	gf.P("}")
+	gf.P("}")
+	gf.P("return false")
+	gf.P("}")
+	gf.P()
+}
+
+// generateReportQueryAliasesFunc generates reportQueryAliases, which
+// bindRequest calls under WithDeprecationReporting to report the aliases a
+// request relies on.
+func (g *Generator) generateReportQueryAliasesFunc(gf *protogen.GeneratedFile) {
+	gf.P("// reportQueryAliases reports the query parameter aliases r sends with a non-empty")
+	gf.P("// value to body.ReportDeprecated, as ?<alias>, and lists them in the Deprecation")
+	gf.P("// header of w. An alias sent along with the parameter's name is reported too,")
+	gf.P("// although the name's value was bound.")
+	gf.P("func reportQueryAliases(")
+	gf.P("w http.ResponseWriter,")
+	gf.P("r *http.Request,")
+	gf.P("params []QueryParamConfig,")
+	gf.P("body BodyConfig,")
+	gf.P(") {")
+	gf.P("query := r.URL.Query()")
+	gf.P("var aliases []string")
+	gf.P("for _, param := range params {")
+	gf.P("for _, alias := range param.Aliases {")
+	gf.P("if hasQueryValue(query[alias]) {")
+	gf.P(`aliases = append(aliases, "?"+alias)`)
+	gf.P("}")
+	gf.P("}")
+	gf.P("}")
+	gf.P("sebufhttp.ReportDeprecatedFields(w, body.ReportDeprecated, body.FullMethod, aliases)")
+	gf.P("}")
+	gf.P()
+}
@@ -0,0 +1,173 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestQueryAliasesIntegration generates a Go HTTP server and Go client for a
+// GET method whose query parameters accept aliases, and checks that an alias
+// alone populates its field, that the parameter's name wins when both are
+// sent, that WithDeprecationReporting reports the aliases as ?<alias>, and that
+// the client sends only the parameter's name.
+func TestQueryAliasesIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "query_aliases_test",
+		Protos:  map[string]string{"articles.proto": queryAliasesProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}, {Name: "go-client"}},
+		Files:   map[string]string{"query_aliases_test.go": queryAliasesIntegrationTestCode},
+	})
+}
+
+const queryAliasesProto = `syntax = "proto3";
+package test.queryaliases;
+option go_package = "query_aliases_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service ArticleService {
+  rpc SearchArticles(SearchArticlesRequest) returns (SearchArticlesResponse) {
+    option (sebuf.http.config) = { path: "/articles" method: HTTP_METHOD_GET };
+  }
+}
+
+message SearchArticlesRequest {
+  string search = 1 [(sebuf.http.query) = { name: "search", aliases: ["q", "query"] }];
+  int32 page_size = 2 [(sebuf.http.query) = { name: "page_size", aliases: ["limit"] }];
+  repeated string tags = 3 [(sebuf.http.query) = { name: "tag", aliases: ["tags"] }];
+}
+
+message SearchArticlesResponse {
+  // The request the server bound, echoed back.
+  SearchArticlesRequest request = 1;
+}
+`
+
+// queryAliasesIntegrationTestCode is the test source that runs inside the
+// temp module. The server echoes the request it bound.
+const queryAliasesIntegrationTestCode = `package query_aliases_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	gen "query_aliases_test/gen"
+)
+
+type articleServer struct{}
+
+func (articleServer) SearchArticles(
+	_ context.Context,
+	req *gen.SearchArticlesRequest,
+) (*gen.SearchArticlesResponse, error) {
+	return &gen.SearchArticlesResponse{Request: req}, nil
+}
+
+func newServer(t *testing.T, opts ...gen.ServerOption) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterArticleServiceServer(articleServer{}, append(opts, gen.WithMux(mux))...); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// search sends query to the server and returns the request it bound and the
+// response's Deprecation header.
+func search(t *testing.T, srv *httptest.Server, query string) (*gen.SearchArticlesRequest, string) {
+	t.Helper()
+	resp, err := http.Get(srv.URL + "/articles?" + query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET ?%s: status %d, want 200: %s", query, resp.StatusCode, body)
+	}
+	var out gen.SearchArticlesResponse
+	if err = protojson.Unmarshal(body, &out); err != nil {
+		t.Fatalf("GET ?%s: %v\n%s", query, err, body)
+	}
+	return out.GetRequest(), resp.Header.Get("Deprecation")
+}
+
+func TestAliasAlonePopulatesField(t *testing.T) {
+	srv := newServer(t)
+	got, _ := search(t, srv, "q=go&limit=5&tags=a&tags=b")
+	want := &gen.SearchArticlesRequest{Search: "go", PageSize: 5, Tags: []string{"a", "b"}}
+	if !proto.Equal(got, want) {
+		t.Errorf("bound %v, want %v", got, want)
+	}
+	if got, _ = search(t, srv, "query=rust"); got.GetSearch() != "rust" {
+		t.Errorf("second alias bound search %q, want rust", got.GetSearch())
+	}
+	if got, _ = search(t, srv, "q=&query=rust"); got.GetSearch() != "rust" {
+		t.Errorf("an empty alias should yield to the next one, bound search %q", got.GetSearch())
+	}
+}
+
+func TestNameWinsOverAlias(t *testing.T) {
+	srv := newServer(t)
+	got, _ := search(t, srv, "q=alias&search=name&limit=5&page_size=10&tags=x&tag=y")
+	want := &gen.SearchArticlesRequest{Search: "name", PageSize: 10, Tags: []string{"y"}}
+	if !proto.Equal(got, want) {
+		t.Errorf("bound %v, want %v", got, want)
+	}
+}
+
+func TestAliasesAreReported(t *testing.T) {
+	var reported []string
+	srv := newServer(t, gen.WithDeprecationReporting(func(method, field string) {
+		reported = append(reported, method+" "+field)
+	}))
+	_, header := search(t, srv, "q=go&page_size=5")
+	want := []string{"test.queryaliases.ArticleService.SearchArticles ?q"}
+	if !slices.Equal(reported, want) {
+		t.Errorf("reported %v, want %v", reported, want)
+	}
+	if header != "?q" {
+		t.Errorf("Deprecation header %q, want ?q", header)
+	}
+
+	reported = nil
+	if _, header = search(t, srv, "search=go"); len(reported) != 0 || header != "" {
+		t.Errorf("a request without aliases reported %v, header %q", reported, header)
+	}
+}
+
+func TestClientSendsName(t *testing.T) {
+	req := &gen.SearchArticlesRequest{Search: "go", PageSize: 5, Tags: []string{"a"}}
+	path := gen.ArticleServiceSearchArticlesURL(req)
+	u, err := url.Parse(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := url.Values{"search": {"go"}, "page_size": {"5"}, "tag": {"a"}}
+	if u.Query().Encode() != want.Encode() {
+		t.Errorf("query %q, want %q", u.RawQuery, want.Encode())
+	}
+
+	client := gen.NewArticleServiceClient(newServer(t).URL)
+	resp, err := client.SearchArticles(context.Background(), &gen.SearchArticlesRequest{Search: "go", PageSize: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetRequest().GetSearch() != "go" || resp.GetRequest().GetPageSize() != 5 {
+		t.Errorf("client call bound %v", resp.GetRequest())
+	}
+}
+`
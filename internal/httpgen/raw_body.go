@@ -0,0 +1,89 @@
+package httpgen
+
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// collectRawBodyMessages recursively collects the messages of a file with a
+// raw_body field, in declaration order, checking their annotations.
+func collectRawBodyMessages(messages []*protogen.Message, out *[]*protogen.Message) error {
+	for _, msg := range messages {
+		if err := annotations.ValidateRawBody(msg); err != nil {
+			return err
+		}
+		if annotations.GetRawBody(msg) != nil {
+			*out = append(*out, msg)
+		}
+		if err := collectRawBodyMessages(msg.Messages, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateRawBodyFile generates the *_raw_body.pb.go file if needed. It gives
+// every message with a raw_body field a RawBodySebuf method returning the bytes
+// and Content-Type the generated handlers write instead of encoding the message.
+func (g *Generator) generateRawBodyFile(file *protogen.File) error {
+	var messages []*protogen.Message
+	if err := collectRawBodyMessages(file.Messages, &messages); err != nil {
+		return err
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+
+	filename := file.GeneratedFilenamePrefix + "_raw_body.pb.go"
+	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
+	g.writeHeader(gf, file)
+
+	for _, msg := range messages {
+		rawBody := annotations.GetRawBody(msg)
+		msgName := msg.GoIdent.GoName
+
+		gf.P("// RawBodySebuf returns the bytes the generated HTTP handlers write as the body of a")
+		gf.P("// ", msgName, " response, and their Content-Type.")
+		gf.P("func (x *", msgName, ") RawBodySebuf() ([]byte, string) {")
+		if rawBody.ContentType == nil {
+			gf.P("return x.Get", rawBody.Data.GoName, "(), ", strconv.Quote(annotations.DefaultRawBodyContentType))
+		} else {
+			gf.P("contentType := x.Get", rawBody.ContentType.GoName, "()")
+			gf.P(`if contentType == "" {`)
+			gf.P("contentType = ", strconv.Quote(annotations.DefaultRawBodyContentType))
+			gf.P("}")
+			gf.P("return x.Get", rawBody.Data.GoName, "(), contentType")
+		}
+		gf.P("}")
+		gf.P()
+	}
+	return nil
+}
+
+// generateRawBodyResponse generates the encoding of the response held in the
+// variable body by genericHandler: the raw body of a message with a raw_body
+// field, whatever the Accept header, and the negotiated encoding otherwise.
+func (g *Generator) generateRawBodyResponse(gf *protogen.GeneratedFile, body string) {
+	gf.P("var responseBytes []byte")
+	gf.P("if raw, ok := any(", body, ").(interface{ RawBodySebuf() ([]byte, string) }); ok {")
+	gf.P("var respContentType string")
+	gf.P("responseBytes, respContentType = raw.RawBodySebuf()")
+	gf.P(`w.Header().Set("Content-Type", respContentType)`)
+	gf.P(`w.Header().Set("Content-Length", strconv.Itoa(len(responseBytes)))`)
+	gf.P("} else {")
+	gf.P("responseBytes, err = marshalResponse(r, ", body, ", marshalOpts)")
+	gf.P("if err != nil {")
+	gf.P("errorMsg := &sebufhttp.Error{")
+	gf.P(`Message: fmt.Sprintf("failed to marshal response: %v", err),`)
+	gf.P("}")
+	gf.P("writeErrorWithHandler(w, r, errorMsg, errorHandler, marshalOpts)")
+	gf.P("return")
+	gf.P("}")
+	gf.P()
+	gf.P("// Set response Content-Type based on Accept header (RFC 9110)")
+	gf.P(`w.Header().Set("Content-Type", resolveResponseContentType(r))`)
+	gf.P("}")
+}
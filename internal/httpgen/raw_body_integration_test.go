@@ -0,0 +1,169 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestRawBodyIntegration generates a Go HTTP server and Go client from a
+// service whose responses have raw_body fields and checks that a 1 MB body is
+// written as is, under the Content-Type of its message or
+// application/octet-stream, that the client hands it back with that
+// Content-Type, and that errors keep their encoded bodies.
+func TestRawBodyIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "raw_body_test",
+		Protos:  map[string]string{"files.proto": rawBodyProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}, {Name: "go-client"}},
+		Files:   map[string]string{"raw_body_test.go": rawBodyIntegrationTestCode},
+	})
+}
+
+const rawBodyProto = `syntax = "proto3";
+package test.rawbody;
+option go_package = "raw_body_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service FileService {
+  rpc DownloadFile(DownloadFileRequest) returns (FileContent) {
+    option (sebuf.http.config) = { path: "/files/{file_id}" method: HTTP_METHOD_GET };
+  }
+  rpc ExportFiles(DownloadFileRequest) returns (FileArchive) {
+    option (sebuf.http.config) = { path: "/files/{file_id}/export" method: HTTP_METHOD_GET };
+  }
+}
+
+message DownloadFileRequest {
+  string file_id = 1;
+}
+
+message FileContent {
+  bytes data = 1 [(sebuf.http.raw_body) = true];
+  string content_type = 2 [(sebuf.http.raw_body_content_type) = true];
+}
+
+message FileArchive {
+  bytes archive = 1 [(sebuf.http.raw_body) = true];
+}
+`
+
+// rawBodyIntegrationTestCode is the test source that runs inside the temp
+// module.
+const rawBodyIntegrationTestCode = `package raw_body_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "raw_body_test/gen"
+)
+
+// payload is 1 MB of bytes that are not valid UTF-8, so any encoding of them
+// would show.
+var payload = func() []byte {
+	data := make([]byte, 1<<20)
+	for i := range data {
+		data[i] = byte(i*7 + 0x80)
+	}
+	return data
+}()
+
+type fileServer struct{}
+
+func (fileServer) DownloadFile(_ context.Context, req *gen.DownloadFileRequest) (*gen.FileContent, error) {
+	if req.GetFileId() == "missing" {
+		return nil, sebufhttp.NewError(sebufhttp.ErrorCodeNotFound, "no such file")
+	}
+	return &gen.FileContent{Data: payload, ContentType: "image/png"}, nil
+}
+
+func (fileServer) ExportFiles(_ context.Context, _ *gen.DownloadFileRequest) (*gen.FileArchive, error) {
+	return &gen.FileArchive{Archive: payload}, nil
+}
+
+func serve(t *testing.T) string {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterFileServiceServer(fileServer{}, gen.WithMux(mux)); err != nil {
+		t.Fatalf("RegisterFileServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestServerWritesRawBody(t *testing.T) {
+	for _, tc := range []struct {
+		path, contentType string
+	}{
+		{"/files/logo", "image/png"},
+		{"/files/logo/export", "application/octet-stream"},
+	} {
+		req, err := http.NewRequest(http.MethodGet, serve(t)+tc.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Accept", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("%s: status %d, body %s", tc.path, resp.StatusCode, body)
+		}
+		if got := resp.Header.Get("Content-Type"); got != tc.contentType {
+			t.Errorf("%s: Content-Type %q, want %q whatever the Accept header", tc.path, got, tc.contentType)
+		}
+		if got := resp.Header.Get("Content-Length"); got != strconv.Itoa(len(payload)) {
+			t.Errorf("%s: Content-Length %q, want %d", tc.path, got, len(payload))
+		}
+		if !bytes.Equal(body, payload) {
+			t.Errorf("%s: body of %d bytes is not the %d bytes of the payload", tc.path, len(body), len(payload))
+		}
+	}
+}
+
+func TestClientReturnsRawBody(t *testing.T) {
+	client := gen.NewFileServiceClient(serve(t))
+
+	content, err := client.DownloadFile(context.Background(), &gen.DownloadFileRequest{FileId: "logo"})
+	if err != nil {
+		t.Fatalf("DownloadFile: %v", err)
+	}
+	if !bytes.Equal(content.GetData(), payload) || content.GetContentType() != "image/png" {
+		t.Errorf("DownloadFile = %d bytes of %q, want the payload as image/png",
+			len(content.GetData()), content.GetContentType())
+	}
+
+	archive, err := client.ExportFiles(context.Background(), &gen.DownloadFileRequest{FileId: "logo"})
+	if err != nil {
+		t.Fatalf("ExportFiles: %v", err)
+	}
+	if !bytes.Equal(archive.GetArchive(), payload) {
+		t.Errorf("ExportFiles = %d bytes, want the payload", len(archive.GetArchive()))
+	}
+}
+
+func TestErrorsKeepEncodedBodies(t *testing.T) {
+	client := gen.NewFileServiceClient(serve(t))
+	_, err := client.DownloadFile(context.Background(), &gen.DownloadFileRequest{FileId: "missing"})
+	var apiErr *sebufhttp.Error
+	if !errors.As(err, &apiErr) || apiErr.GetCode() != sebufhttp.ErrorCodeNotFound {
+		t.Errorf("DownloadFile of a missing file: %v, want a NOT_FOUND *Error", err)
+	}
+}
+`
@@ -0,0 +1,217 @@
+package httpgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// redactContext tracks which messages need a generated redaction helper while
+// generating a file's *_redact.pb.go.
+type redactContext struct {
+	importPath protogen.GoImportPath
+	memo       map[*protogen.Message]bool
+}
+
+// needsRedaction returns true if the message has sensitive fields, directly or
+// through message fields of the same Go package. Messages from other packages
+// are not recursed into, since their helpers are unexported.
+func (c *redactContext) needsRedaction(msg *protogen.Message) bool {
+	if needs, seen := c.memo[msg]; seen {
+		return needs
+	}
+	needs := c.reachesSensitive(msg, make(map[*protogen.Message]bool))
+	c.memo[msg] = needs
+	return needs
+}
+
+// reachesSensitive walks the message graph from msg, skipping messages already
+// visited so recursive messages terminate.
+func (c *redactContext) reachesSensitive(msg *protogen.Message, visited map[*protogen.Message]bool) bool {
+	if msg == nil || msg.GoIdent.GoImportPath != c.importPath || visited[msg] {
+		return false
+	}
+	visited[msg] = true
+	for _, field := range msg.Fields {
+		if annotations.IsSensitiveField(field) || c.reachesSensitive(annotations.ExampleMessage(field), visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectRedactMessages recursively collects the messages that need redaction.
+func (c *redactContext) collectRedactMessages(messages []*protogen.Message, out *[]*protogen.Message) {
+	for _, msg := range messages {
+		if msg.Desc.IsMapEntry() {
+			continue
+		}
+		if c.needsRedaction(msg) {
+			*out = append(*out, msg)
+		}
+		c.collectRedactMessages(msg.Messages, out)
+	}
+}
+
+// validateSensitiveAnnotations validates all sensitive annotations in a file.
+func validateSensitiveAnnotations(messages []*protogen.Message) error {
+	for _, msg := range messages {
+		for _, field := range msg.Fields {
+			if err := annotations.ValidateSensitiveAnnotation(field, msg.GoIdent.GoName); err != nil {
+				return err
+			}
+		}
+		if err := validateSensitiveAnnotations(msg.Messages); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateRedactFile generates the *_redact.pb.go file if needed.
+func (g *Generator) generateRedactFile(file *protogen.File) error {
+	if err := validateSensitiveAnnotations(file.Messages); err != nil {
+		return err
+	}
+
+	ctx := &redactContext{importPath: file.GoImportPath, memo: make(map[*protogen.Message]bool)}
+	var messages []*protogen.Message
+	ctx.collectRedactMessages(file.Messages, &messages)
+	if len(messages) == 0 {
+		return nil
+	}
+
+	filename := file.GeneratedFilenamePrefix + "_redact.pb.go"
+	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
+
+	g.writeHeader(gf, file)
+	gf.P("import (")
+	gf.P(`"log/slog"`)
+	gf.P()
+	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
+	gf.P(`"google.golang.org/protobuf/proto"`)
+	if redactsStrings(messages) {
+		gf.P()
+		gf.P(`sebufhttp "github.com/SebastienMelki/sebuf/http"`)
+	}
+	gf.P(")")
+	gf.P()
+
+	for _, msg := range messages {
+		g.generateRedacted(gf, msg)
+		g.generateRedactSensitive(gf, ctx, msg)
+	}
+
+	return nil
+}
+
+// redactsStrings returns true if any of the messages has a sensitive string
+// field, which is redacted with sebufhttp.RedactedValue.
+func redactsStrings(messages []*protogen.Message) bool {
+	for _, msg := range messages {
+		for _, field := range msg.Fields {
+			if annotations.IsSensitiveField(field) &&
+				annotations.SensitiveValueField(field).Desc.Kind() == protoreflect.StringKind {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// generateRedacted generates the exported Redacted and LogValue methods.
+func (g *Generator) generateRedacted(gf *protogen.GeneratedFile, msg *protogen.Message) {
+	msgName := msg.GoIdent.GoName
+
+	gf.P("// Redacted returns a deep copy of x with its sensitive fields redacted: strings")
+	gf.P("// become sebufhttp.RedactedValue, bytes are emptied and nested messages are")
+	gf.P("// redacted in turn. x is not modified.")
+	gf.P("func (x *", msgName, ") Redacted() proto.Message {")
+	gf.P("if x == nil {")
+	gf.P("return x")
+	gf.P("}")
+	gf.P("clone := proto.Clone(x).(*", msgName, ")")
+	gf.P("clone.redactSensitive()")
+	gf.P("return clone")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// LogValue implements slog.LogValuer so that logging x records its redacted form.")
+	gf.P("func (x *", msgName, ") LogValue() slog.Value {")
+	gf.P("return slog.StringValue(protojson.Format(x.Redacted()))")
+	gf.P("}")
+	gf.P()
+}
+
+// generateRedactSensitive generates the in-place redaction Redacted applies to its clone.
+func (g *Generator) generateRedactSensitive(gf *protogen.GeneratedFile, ctx *redactContext, msg *protogen.Message) {
+	gf.P("// redactSensitive redacts x in place.")
+	gf.P("func (x *", msg.GoIdent.GoName, ") redactSensitive() {")
+	gf.P("if x == nil {")
+	gf.P("return")
+	gf.P("}")
+	for _, field := range msg.Fields {
+		sensitive := annotations.IsSensitiveField(field)
+		if !sensitive && !ctx.needsRedaction(annotations.ExampleMessage(field)) {
+			continue
+		}
+
+		if field.Oneof != nil && !field.Oneof.Desc.IsSynthetic() {
+			gf.P("if v, ok := x.", field.Oneof.GoName, ".(*", field.GoIdent.GoName, "); ok {")
+			g.generateFieldRedaction(gf, field, "v."+field.GoName, sensitive)
+			gf.P("}")
+			continue
+		}
+		g.generateFieldRedaction(gf, field, "x."+field.GoName, sensitive)
+	}
+	gf.P("}")
+	gf.P()
+}
+
+// generateFieldRedaction redacts target, the Go expression for field: sensitive
+// values are replaced, and messages needing redaction are recursed into.
+func (g *Generator) generateFieldRedaction(
+	gf *protogen.GeneratedFile,
+	field *protogen.Field,
+	target string,
+	sensitive bool,
+) {
+	inOneof := field.Oneof != nil && !field.Oneof.Desc.IsSynthetic()
+	isString := annotations.SensitiveValueField(field).Desc.Kind() == protoreflect.StringKind
+	value := "[]byte{}"
+	if isString {
+		value = "sebufhttp.RedactedValue"
+	}
+
+	switch {
+	case !sensitive && (field.Desc.IsList() || field.Desc.IsMap()):
+		gf.P("for _, m := range ", target, " {")
+		gf.P("m.redactSensitive()")
+		gf.P("}")
+	case !sensitive:
+		gf.P(target, ".redactSensitive()")
+	case field.Desc.IsList():
+		gf.P("for i := range ", target, " {")
+		gf.P(target, "[i] = ", value)
+		gf.P("}")
+	case field.Desc.IsMap():
+		gf.P("for k := range ", target, " {")
+		gf.P(target, "[k] = ", value)
+		gf.P("}")
+	case !isString:
+		gf.P("if ", target, " != nil {")
+		gf.P(target, " = ", value)
+		gf.P("}")
+	case inOneof:
+		gf.P(target, " = ", value)
+	case field.Desc.HasPresence():
+		gf.P("if ", target, " != nil {")
+		gf.P(target, " = proto.String(", value, ")")
+		gf.P("}")
+	default:
+		gf.P("if ", target, " != \"\" {")
+		gf.P(target, " = ", value)
+		gf.P("}")
+	}
+}
@@ -0,0 +1,139 @@
+package httpgen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestRedactIntegration is an end-to-end integration test that:
+//  1. generates Go code from a proto with sensitive fields,
+//  2. writes a temporary Go module exercising the generated Redacted helpers,
+//  3. verifies sensitive values are redacted in the copy, in nested messages,
+//     collections and oneofs, that the original message is not mutated, and
+//     that slog records the redacted form.
+func TestRedactIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:     "redact_test",
+		Protos:   map[string]string{"sensitive.proto": ""},
+		ProtoDir: filepath.Join("testdata", "proto"),
+		Mappings: []string{"Msensitive.proto=redact_test/gen;gen"},
+		Plugins:  []plugintest.Plugin{{Name: "go-http"}},
+		Files:    map[string]string{"redact_test.go": redactIntegrationTestCode},
+	})
+}
+
+// redactIntegrationTestCode is the test source that runs inside the temp module.
+const redactIntegrationTestCode = `package redact_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	"redact_test/gen"
+)
+
+func newLoginRequest() *gen.LoginRequest {
+	return &gen.LoginRequest{
+		Credentials:   &gen.Credentials{Username: "alice", Password: "hunter2", PrivateKey: []byte("key")},
+		Otp:           proto.String("123456"),
+		RecoveryCodes: []string{"code-1", "code-2"},
+		ApiKeys:       map[string]string{"prod": "sk-live"},
+		Fallbacks:     []*gen.Credentials{{Username: "bob", Password: "swordfish"}, nil},
+		ByRealm:       map[string]*gen.Credentials{"corp": {Password: "letmein"}},
+		SecondFactor:  &gen.LoginRequest_TotpCode{TotpCode: "654321"},
+		ClientId:      "cli",
+	}
+}
+
+func TestRedactedDoesNotMutateOriginal(t *testing.T) {
+	req := newLoginRequest()
+	before := proto.Clone(req)
+
+	redacted, ok := req.Redacted().(*gen.LoginRequest)
+	if !ok {
+		t.Fatal("Redacted should return a *LoginRequest")
+	}
+	if !proto.Equal(req, before) {
+		t.Fatal("Redacted mutated the original message")
+	}
+
+	if redacted.GetCredentials().GetPassword() != sebufhttp.RedactedValue {
+		t.Errorf("nested password = %q", redacted.GetCredentials().GetPassword())
+	}
+	if len(redacted.GetCredentials().GetPrivateKey()) != 0 {
+		t.Error("nested bytes should be emptied")
+	}
+	if redacted.GetCredentials().GetUsername() != "alice" {
+		t.Error("non-sensitive fields must be kept")
+	}
+	if redacted.GetOtp() != sebufhttp.RedactedValue {
+		t.Errorf("optional otp = %q", redacted.GetOtp())
+	}
+	for _, code := range redacted.GetRecoveryCodes() {
+		if code != sebufhttp.RedactedValue {
+			t.Errorf("recovery code = %q", code)
+		}
+	}
+	if redacted.GetApiKeys()["prod"] != sebufhttp.RedactedValue {
+		t.Errorf("api key = %q", redacted.GetApiKeys()["prod"])
+	}
+	if redacted.GetFallbacks()[0].GetPassword() != sebufhttp.RedactedValue {
+		t.Error("repeated nested messages should be redacted")
+	}
+	if redacted.GetByRealm()["corp"].GetPassword() != sebufhttp.RedactedValue {
+		t.Error("map message values should be redacted")
+	}
+	if redacted.GetTotpCode() != sebufhttp.RedactedValue {
+		t.Errorf("oneof totp = %q", redacted.GetTotpCode())
+	}
+	if redacted.GetClientId() != "cli" {
+		t.Error("non-sensitive fields must be kept")
+	}
+}
+
+func TestRedactedRecursiveMessage(t *testing.T) {
+	session := &gen.Session{
+		Token: "tok",
+		Root:  &gen.Node{Secret: "a", Children: []*gen.Node{{Secret: "b"}}},
+	}
+	redacted := session.Redacted().(*gen.Session)
+	if redacted.GetRoot().GetChildren()[0].GetSecret() != sebufhttp.RedactedValue {
+		t.Error("recursive messages should be redacted at every depth")
+	}
+	if session.GetRoot().GetChildren()[0].GetSecret() != "b" {
+		t.Error("Redacted mutated the original message")
+	}
+}
+
+func TestLogValueAndRedact(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("login", "request", newLoginRequest())
+
+	out := buf.String()
+	for _, secret := range []string{"hunter2", "swordfish", "sk-live", "654321"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log output leaks %q: %s", secret, out)
+		}
+	}
+	if !strings.Contains(out, "alice") {
+		t.Errorf("log output should keep non-sensitive fields: %s", out)
+	}
+
+	if _, ok := sebufhttp.Redact(newLoginRequest()).(*gen.LoginRequest); !ok {
+		t.Error("Redact should return the redacted copy")
+	}
+	plain := &gen.Plain{Value: "v"}
+	if sebufhttp.Redact(plain) != plain {
+		t.Error("Redact should return messages without sensitive fields as-is")
+	}
+}
+`
@@ -0,0 +1,149 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestResponseControlIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server from a proto with a plain and a root-unwrap
+//     response,
+//  2. writes a temporary Go module that serves it with httptest,
+//  3. verifies the headers, trailers and status handlers set through
+//     sebufhttp reach the wire on both paths, and are dropped for errors.
+func TestResponseControlIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "response_control_test",
+		Protos:  map[string]string{"users.proto": responseControlProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"response_control_test.go": responseControlIntegrationTestCode},
+	})
+}
+
+const responseControlProto = `syntax = "proto3";
+package test.responsecontrol;
+option go_package = "response_control_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service UserService {
+  rpc CreateUser(User) returns (User) {
+    option (sebuf.http.config) = { path: "/users" method: HTTP_METHOD_POST };
+  }
+  rpc ListUsers(ListUsersRequest) returns (UserList) {
+    option (sebuf.http.config) = { path: "/users" method: HTTP_METHOD_GET };
+  }
+}
+
+message User {
+  string id = 1;
+  string name = 2;
+}
+
+message ListUsersRequest {}
+
+message UserList {
+  repeated User users = 1 [(sebuf.http.unwrap) = true];
+}
+`
+
+// responseControlIntegrationTestCode is the test source that runs inside the
+// temp module.
+const responseControlIntegrationTestCode = `package response_control_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "response_control_test/gen"
+)
+
+type userServer struct{}
+
+func (userServer) CreateUser(ctx context.Context, req *gen.User) (*gen.User, error) {
+	sebufhttp.SetResponseHeader(ctx, "Location", "/users/42")
+	sebufhttp.SetStatus(ctx, http.StatusAccepted)
+	if req.GetName() == "" {
+		return nil, errors.New("name is required")
+	}
+	sebufhttp.SetStatus(ctx, http.StatusCreated)
+	return &gen.User{Id: "42", Name: req.GetName()}, nil
+}
+
+func (userServer) ListUsers(ctx context.Context, _ *gen.ListUsersRequest) (*gen.UserList, error) {
+	sebufhttp.AddResponseHeader(ctx, "Link", ` + "`" + `</users?page=2>; rel="next"` + "`" + `)
+	sebufhttp.SetResponseTrailer(ctx, "X-Total-Count", "1")
+	return &gen.UserList{Users: []*gen.User{{Id: "42", Name: "Rima"}}}, nil
+}
+
+func newServer(t *testing.T) string {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterUserServiceServer(userServer{}, gen.WithMux(mux)); err != nil {
+		t.Fatalf("RegisterUserServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestCreateSetsLocationAndStatus(t *testing.T) {
+	resp, err := http.Post(newServer(t)+"/users", "application/json", strings.NewReader(` + "`" + `{"name":"Rima"}` + "`" + `))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("status = %d, want the last SetStatus (201); body = %s", resp.StatusCode, body)
+	}
+	if got := resp.Header.Get("Location"); got != "/users/42" {
+		t.Errorf("Location = %q, want /users/42", got)
+	}
+	if !strings.Contains(string(body), "Rima") {
+		t.Errorf("body = %s, want the created user", body)
+	}
+}
+
+func TestErrorResponseIgnoresControl(t *testing.T) {
+	resp, err := http.Post(newServer(t)+"/users", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusAccepted || resp.StatusCode < 400 {
+		t.Errorf("status = %d, want the error status", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Location"); got != "" {
+		t.Errorf("Location = %q, want none on an error response", got)
+	}
+}
+
+func TestUnwrapResponseSetsHeadersAndTrailers(t *testing.T) {
+	resp, err := http.Get(newServer(t) + "/users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(strings.TrimSpace(string(body)), "[") {
+		t.Errorf("response = %d %s, want 200 with the unwrapped array", resp.StatusCode, body)
+	}
+	if got := resp.Header.Get("Link"); got != ` + "`" + `</users?page=2>; rel="next"` + "`" + ` {
+		t.Errorf("Link = %q", got)
+	}
+	if got := resp.Trailer.Get("X-Total-Count"); got != "1" {
+		t.Errorf("X-Total-Count trailer = %q, want 1", got)
+	}
+}
+`
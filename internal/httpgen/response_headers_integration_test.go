@@ -0,0 +1,170 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestResponseHeadersIntegration generates a Go HTTP server for a service with
+// response_headers, and checks that they are set on a successful response, a
+// 400 validation error and a handler error, that a method's header replaces
+// the service's, and that WithResponseHeaderOverrides changes and removes them.
+func TestResponseHeadersIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "response_headers_test",
+		Protos:  map[string]string{"profiles.proto": responseHeadersProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"response_headers_test.go": responseHeadersIntegrationTestCode},
+	})
+}
+
+const responseHeadersProto = `syntax = "proto3";
+package test.responseheaders;
+option go_package = "response_headers_test/gen;gen";
+import "sebuf/http/annotations.proto";
+import "sebuf/http/headers.proto";
+
+service ProfileService {
+  option (sebuf.http.service_config) = {
+    response_headers: [
+      { name: "Cache-Control", value: "no-store" },
+      { name: "X-Frame-Options", value: "DENY" }
+    ]
+  };
+
+  rpc GetProfile(GetProfileRequest) returns (Profile) {
+    option (sebuf.http.config) = {
+      path: "/profiles/{id}"
+      method: HTTP_METHOD_GET
+      response_headers: [{ name: "cache-control", value: "private, max-age=60" }]
+    };
+  }
+
+  rpc UpdateProfile(UpdateProfileRequest) returns (Profile) {
+    option (sebuf.http.config) = { path: "/profiles/{id}" method: HTTP_METHOD_PUT };
+    option (sebuf.http.method_headers) = {
+      required_headers: [{ name: "X-Request-Id", type: "string", required: true }]
+    };
+  }
+}
+
+message GetProfileRequest {
+  string id = 1;
+}
+
+message UpdateProfileRequest {
+  string id = 1;
+  string display_name = 2;
+}
+
+message Profile {
+  string id = 1;
+  string display_name = 2;
+}
+`
+
+// responseHeadersIntegrationTestCode is the test source that runs inside the
+// temp module. The server fails GetProfile for the id "missing".
+const responseHeadersIntegrationTestCode = `package response_headers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gen "response_headers_test/gen"
+)
+
+type profileServer struct{}
+
+func (profileServer) GetProfile(_ context.Context, req *gen.GetProfileRequest) (*gen.Profile, error) {
+	if req.GetId() == "missing" {
+		return nil, errors.New("profile store unavailable")
+	}
+	return &gen.Profile{Id: req.GetId()}, nil
+}
+
+func (profileServer) UpdateProfile(_ context.Context, req *gen.UpdateProfileRequest) (*gen.Profile, error) {
+	return &gen.Profile{Id: req.GetId(), DisplayName: req.GetDisplayName()}, nil
+}
+
+func newServer(t *testing.T, opts ...gen.ServerOption) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterProfileServiceServer(profileServer{}, append(opts, gen.WithMux(mux))...); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// send sends a request with an empty JSON body to the server and returns its
+// response.
+func send(t *testing.T, srv *httptest.Server, method, path string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func checkHeaders(t *testing.T, resp *http.Response, want map[string]string) {
+	t.Helper()
+	for name, value := range want {
+		if got := resp.Header.Get(name); got != value {
+			t.Errorf("status %d: %s = %q, want %q", resp.StatusCode, name, got, value)
+		}
+	}
+}
+
+func TestHeadersOnSuccess(t *testing.T) {
+	resp := send(t, newServer(t), http.MethodGet, "/profiles/1")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d, want 200", resp.StatusCode)
+	}
+	checkHeaders(t, resp, map[string]string{"Cache-Control": "private, max-age=60", "X-Frame-Options": "DENY"})
+}
+
+func TestHeadersOnValidationError(t *testing.T) {
+	resp := send(t, newServer(t), http.MethodPut, "/profiles/1")
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status %d, want 400 for the missing X-Request-Id", resp.StatusCode)
+	}
+	checkHeaders(t, resp, map[string]string{"Cache-Control": "no-store", "X-Frame-Options": "DENY"})
+}
+
+func TestHeadersOnHandlerError(t *testing.T) {
+	resp := send(t, newServer(t), http.MethodGet, "/profiles/missing")
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("status %d, want 500", resp.StatusCode)
+	}
+	checkHeaders(t, resp, map[string]string{"Cache-Control": "private, max-age=60", "X-Frame-Options": "DENY"})
+}
+
+func TestOverrides(t *testing.T) {
+	srv := newServer(t, gen.WithResponseHeaderOverrides(map[string]string{
+		"x-frame-options":           "",
+		"Strict-Transport-Security": "max-age=31536000",
+	}))
+	resp := send(t, srv, http.MethodGet, "/profiles/1")
+	if _, ok := resp.Header["X-Frame-Options"]; ok {
+		t.Error("X-Frame-Options should be removed by its empty override")
+	}
+	checkHeaders(t, resp, map[string]string{
+		"Cache-Control":             "private, max-age=60",
+		"Strict-Transport-Security": "max-age=31536000",
+	})
+}
+`
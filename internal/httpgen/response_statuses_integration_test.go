@@ -0,0 +1,184 @@
+package httpgen
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestResponseStatusesIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server and Go client from a proto whose method returns
+//     a result message, a oneof annotated with response_statuses,
+//  2. writes a temporary Go module that serves the generated handlers with httptest,
+//  3. verifies the server answers with the set variant under its status, and 500
+//     when none is set, and that the client decodes the variant the status selects.
+func TestResponseStatusesIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "response_statuses_test",
+		Protos:  map[string]string{"response_statuses.proto": responseStatusesProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}, {Name: "go-client"}},
+		Files:   map[string]string{"response_statuses_test.go": responseStatusesIntegrationTestCode},
+	})
+}
+
+// TestResponseStatusesRejected checks that both Go generators fail on a result
+// message whose variant has no status.
+func TestResponseStatusesRejected(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping integration test")
+	}
+
+	out, err := runHeaderProtoc(t,
+		strings.Replace(responseStatusesProto, `statuses: { key: "conflict" value: 409 }`, "", 1))
+	if err == nil {
+		t.Fatal("protoc succeeded, want an error for a variant without status")
+	}
+	if want := "variant conflict has no status"; !strings.Contains(string(out), want) {
+		t.Errorf("protoc output = %s, want it to contain %q", out, want)
+	}
+}
+
+const responseStatusesProto = `syntax = "proto3";
+package test.responsestatuses;
+option go_package = "response_statuses_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service OrderService {
+  rpc CreateOrder(CreateOrderRequest) returns (CreateOrderResult) {
+    option (sebuf.http.config) = { path: "/orders" };
+  }
+}
+
+message CreateOrderRequest {
+  string id = 1;
+}
+
+message Order {
+  string id = 1;
+}
+
+message Conflict {
+  string existing_id = 1;
+}
+
+message CreateOrderResult {
+  oneof result {
+    option (sebuf.http.response_statuses) = {
+      statuses: { key: "order" value: 201 }
+      statuses: { key: "conflict" value: 409 }
+    };
+    Order order = 1;
+    Conflict conflict = 2;
+  }
+}
+`
+
+// responseStatusesIntegrationTestCode is the test source that runs inside the
+// temp module. The server answers "dup" with a conflict and "none" with no
+// variant.
+const responseStatusesIntegrationTestCode = `package response_statuses_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "response_statuses_test/gen"
+)
+
+type orderServer struct{}
+
+func (orderServer) CreateOrder(_ context.Context, req *gen.CreateOrderRequest) (*gen.CreateOrderResult, error) {
+	switch req.GetId() {
+	case "dup":
+		return &gen.CreateOrderResult{Result: &gen.CreateOrderResult_Conflict{
+			Conflict: &gen.Conflict{ExistingId: "o-1"},
+		}}, nil
+	case "none":
+		return &gen.CreateOrderResult{}, nil
+	}
+	return &gen.CreateOrderResult{Result: &gen.CreateOrderResult_Order{Order: &gen.Order{Id: req.GetId()}}}, nil
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterOrderServiceServer(orderServer{}, gen.WithMux(mux)); err != nil {
+		t.Fatalf("RegisterOrderServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// compact drops the spaces protojson may add, which the expected bodies leave out.
+func compact(body string) string {
+	return strings.ReplaceAll(body, " ", "")
+}
+
+func post(t *testing.T, srv *httptest.Server, id string) (int, string) {
+	t.Helper()
+	body := strings.NewReader(` + "`" + `{"id":"` + "`" + `+id+` + "`" + `"}` + "`" + `)
+	resp, err := http.Post(srv.URL+"/orders", "application/json", body)
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return resp.StatusCode, string(data)
+}
+
+func TestServerAnswersWithTheVariant(t *testing.T) {
+	srv := newServer(t)
+	status, body := post(t, srv, "o-2")
+	if status != http.StatusCreated || compact(body) != ` + "`" + `{"id":"o-2"}` + "`" + ` {
+		t.Errorf("order: status %d, body %s, want 201 and the order alone", status, body)
+	}
+	status, body = post(t, srv, "dup")
+	if status != http.StatusConflict || compact(body) != ` + "`" + `{"existingId":"o-1"}` + "`" + ` {
+		t.Errorf("conflict: status %d, body %s, want 409 and the conflict alone", status, body)
+	}
+	status, body = post(t, srv, "none")
+	if status != http.StatusInternalServerError || !strings.Contains(body, "no variant of result is set") {
+		t.Errorf("no variant: status %d, body %s, want 500 naming the unset oneof", status, body)
+	}
+}
+
+func TestClientDecodesTheVariant(t *testing.T) {
+	srv := newServer(t)
+	client := gen.NewOrderServiceClient(srv.URL)
+
+	created, err := client.CreateOrder(context.Background(), &gen.CreateOrderRequest{Id: "o-2"})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if created.StatusCode != http.StatusCreated || created.GetOrder().GetId() != "o-2" || created.GetConflict() != nil {
+		t.Errorf("created = %d %v, want 201 and the order", created.StatusCode, created.Result)
+	}
+
+	conflict, err := client.CreateOrder(context.Background(), &gen.CreateOrderRequest{Id: "dup"})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if conflict.StatusCode != http.StatusConflict || conflict.GetConflict().GetExistingId() != "o-1" {
+		t.Errorf("conflict = %d %v, want 409 and the conflict", conflict.StatusCode, conflict.Result)
+	}
+
+	_, err = client.CreateOrder(context.Background(), &gen.CreateOrderRequest{Id: "none"})
+	var handlerErr *sebufhttp.Error
+	if !errors.As(err, &handlerErr) {
+		t.Errorf("CreateOrder(none) error = %v, want a sebufhttp.Error", err)
+	}
+}
+`
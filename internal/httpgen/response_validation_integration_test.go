@@ -0,0 +1,165 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestResponseValidationIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server from a proto whose response message carries
+//     buf.validate rules,
+//  2. writes a temporary Go module that serves a handler returning an invalid
+//     response under each WithResponseValidation mode,
+//  3. verifies Off sends it silently, Warn logs the violations and sends it,
+//     and Enforce logs them and answers with a 500 revealing none of its values.
+func TestResponseValidationIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "response_validation_test",
+		Protos:  map[string]string{"response_validation.proto": responseValidationProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"response_validation_test.go": responseValidationIntegrationTestCode},
+	})
+}
+
+const responseValidationProto = `syntax = "proto3";
+package test.responsevalidation;
+option go_package = "response_validation_test/gen;gen";
+import "buf/validate/validate.proto";
+import "sebuf/http/annotations.proto";
+
+service AccountService {
+  rpc GetAccount(GetAccountRequest) returns (Account) {
+    option (sebuf.http.config) = { path: "/accounts/{account_id}" method: HTTP_METHOD_GET };
+  }
+  rpc CountAccounts(CountAccountsRequest) returns (AccountCount) {
+    option (sebuf.http.config) = { path: "/accounts:count" method: HTTP_METHOD_GET };
+  }
+}
+
+message GetAccountRequest {
+  string account_id = 1;
+}
+
+message Account {
+  string id = 1 [(buf.validate.field).string.min_len = 1];
+  string owner = 2;
+}
+
+message CountAccountsRequest {}
+
+message AccountCount {
+  int64 count = 1;
+}
+`
+
+// responseValidationIntegrationTestCode is the test source that runs inside
+// the temp module. The server loses the ID of account "partial", as after a
+// partial read, and answers "ok" with a valid account.
+const responseValidationIntegrationTestCode = `package response_validation_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "response_validation_test/gen"
+)
+
+type accountServer struct{}
+
+func (accountServer) GetAccount(_ context.Context, req *gen.GetAccountRequest) (*gen.Account, error) {
+	if req.GetAccountId() == "partial" {
+		return &gen.Account{Owner: "secret-owner"}, nil
+	}
+	return &gen.Account{Id: req.GetAccountId(), Owner: "alice"}, nil
+}
+
+func (accountServer) CountAccounts(context.Context, *gen.CountAccountsRequest) (*gen.AccountCount, error) {
+	return &gen.AccountCount{}, nil
+}
+
+// serve registers the server under mode and returns its URL and log buffer.
+func serve(t *testing.T, opts ...gen.ServerOption) (string, *bytes.Buffer) {
+	t.Helper()
+	logs := &bytes.Buffer{}
+	mux := http.NewServeMux()
+	opts = append(opts, gen.WithMux(mux), gen.WithLogger(slog.New(slog.NewTextHandler(logs, nil))))
+	if err := gen.RegisterAccountServiceServer(accountServer{}, opts...); err != nil {
+		t.Fatalf("RegisterAccountServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv.URL, logs
+}
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestOffSendsInvalidResponse(t *testing.T) {
+	url, logs := serve(t)
+	status, body := get(t, url+"/accounts/partial")
+	if status != http.StatusOK || !strings.Contains(body, "secret-owner") {
+		t.Errorf("status %d, body %s; want 200 with the response", status, body)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("off should not log, got:\n%s", logs)
+	}
+}
+
+func TestWarnLogsAndSends(t *testing.T) {
+	url, logs := serve(t, gen.WithResponseValidation(sebufhttp.ResponseValidationWarn))
+	status, body := get(t, url+"/accounts/partial")
+	if status != http.StatusOK || !strings.Contains(body, "secret-owner") {
+		t.Errorf("status %d, body %s; want 200 with the response", status, body)
+	}
+	if !strings.Contains(logs.String(), "level=WARN") || !strings.Contains(logs.String(), "field=id") {
+		t.Errorf("warn should log the violation, got:\n%s", logs)
+	}
+}
+
+func TestEnforceRejectsWithSanitizedError(t *testing.T) {
+	url, logs := serve(t, gen.WithResponseValidation(sebufhttp.ResponseValidationEnforce))
+	status, body := get(t, url+"/accounts/partial")
+	if status != http.StatusInternalServerError {
+		t.Fatalf("status %d, want 500", status)
+	}
+	if !strings.Contains(body, "response validation failed") || strings.Contains(body, "secret-owner") {
+		t.Errorf("body %s should be the sanitized error", body)
+	}
+	if !strings.Contains(logs.String(), "level=ERROR") || !strings.Contains(logs.String(), "field=id") {
+		t.Errorf("enforce should log the violation, got:\n%s", logs)
+	}
+}
+
+func TestEnforceSendsValidResponse(t *testing.T) {
+	url, logs := serve(t, gen.WithResponseValidation(sebufhttp.ResponseValidationEnforce))
+	if status, body := get(t, url+"/accounts/ok"); status != http.StatusOK || !strings.Contains(body, "alice") {
+		t.Errorf("status %d, body %s; want 200 with the account", status, body)
+	}
+	if status, _ := get(t, url+"/accounts:count"); status != http.StatusOK {
+		t.Errorf("a response without rules: status %d, want 200", status)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("valid responses should not be logged, got:\n%s", logs)
+	}
+}
+`
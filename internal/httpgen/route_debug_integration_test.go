@@ -0,0 +1,228 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestRouteDebugIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server from a proto with two services, one of which
+//     requires a header,
+//  2. writes a temporary Go module that registers both on one mux,
+//  3. verifies GET /__sebuf/routes is absent by default, lists the routes of
+//     both services with WithRouteDebug, logs them through WithLogger, and
+//     honors WithRouteDebugAuth,
+//  4. verifies the exported route constants and <Service>ServerRoutes match
+//     the registered routes, and that a path built by a <Service>Path<Method>For
+//     function reaches its handler with the unescaped wildcard value.
+func TestRouteDebugIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "route_debug_test",
+		Protos:  map[string]string{"catalog.proto": routeDebugProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"route_debug_test.go": routeDebugIntegrationTestCode},
+	})
+}
+
+const routeDebugProto = `syntax = "proto3";
+package test.routedebug;
+option go_package = "route_debug_test/gen;gen";
+import "sebuf/http/annotations.proto";
+import "sebuf/http/headers.proto";
+
+service ProductService {
+  option (sebuf.http.service_config) = { base_path: "/api/v1" };
+  option (sebuf.http.service_headers) = {
+    required_headers: [{ name: "X-API-Key" type: "string" required: true }]
+  };
+  rpc GetProduct(GetProductRequest) returns (Product) {
+    option (sebuf.http.config) = { path: "/products/{id}" method: HTTP_METHOD_GET };
+  }
+  rpc CreateProduct(Product) returns (Product) {
+    option (sebuf.http.config) = { path: "/products" method: HTTP_METHOD_POST };
+  }
+}
+
+service StockService {
+  option (sebuf.http.service_config) = { base_path: "/api/v1" };
+  rpc GetStock(GetProductRequest) returns (Stock) {
+    option (sebuf.http.config) = { path: "/stock/{id}" method: HTTP_METHOD_GET };
+  }
+}
+
+message GetProductRequest {
+  string id = 1;
+}
+
+message Product {
+  string id = 1;
+  string name = 2;
+}
+
+message Stock {
+  string id = 1;
+  int32 quantity = 2;
+}
+`
+
+// routeDebugIntegrationTestCode is the test source that runs inside the temp
+// module.
+const routeDebugIntegrationTestCode = `package route_debug_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "route_debug_test/gen"
+)
+
+type productServer struct {
+	gen.UnimplementedProductServiceServer
+}
+
+func (productServer) GetProduct(_ context.Context, req *gen.GetProductRequest) (*gen.Product, error) {
+	return &gen.Product{Id: req.GetId(), Name: "product"}, nil
+}
+
+type stockServer struct {
+	gen.UnimplementedStockServiceServer
+}
+
+func newServer(t *testing.T, opts ...gen.ServerOption) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	opts = append(opts, gen.WithMux(mux))
+	if err := gen.RegisterProductServiceServer(productServer{}, opts...); err != nil {
+		t.Fatalf("RegisterProductServiceServer: %v", err)
+	}
+	if err := gen.RegisterStockServiceServer(stockServer{}, opts...); err != nil {
+		t.Fatalf("RegisterStockServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func getRoutes(t *testing.T, srv *httptest.Server, header string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/__sebuf/routes", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header != "" {
+		req.Header.Set("X-Debug", header)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestRouteDebugAbsentByDefault(t *testing.T) {
+	srv := newServer(t)
+	if resp := getRoutes(t, srv, ""); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestRouteDebugListsAllRoutes(t *testing.T) {
+	var logs bytes.Buffer
+	srv := newServer(t, gen.WithRouteDebug(), gen.WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+
+	resp := getRoutes(t, srv, "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	var listing struct {
+		Routes []sebufhttp.RouteInfo ` + "`json:\"routes\"`" + `
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&listing); err != nil {
+		t.Fatalf("decode listing: %v", err)
+	}
+	want := []sebufhttp.RouteInfo{
+		{Method: "GET", Path: "/api/v1/products/{id}", Service: "test.routedebug.ProductService", RPC: "GetProduct",
+			RequiredHeaders: []string{"X-API-Key"}},
+		{Method: "POST", Path: "/api/v1/products", Service: "test.routedebug.ProductService", RPC: "CreateProduct",
+			RequiredHeaders: []string{"X-API-Key"}},
+		{Method: "GET", Path: "/api/v1/stock/{id}", Service: "test.routedebug.StockService", RPC: "GetStock"},
+	}
+	if !reflect.DeepEqual(listing.Routes, want) {
+		t.Errorf("routes = %+v, want %+v", listing.Routes, want)
+	}
+
+	for _, route := range want {
+		if !strings.Contains(logs.String(), "path="+route.Path) {
+			t.Errorf("logs do not mention %s:\n%s", route.Path, logs.String())
+		}
+	}
+}
+
+func TestRouteDebugAuth(t *testing.T) {
+	srv := newServer(t, gen.WithRouteDebug(), gen.WithRouteDebugAuth(func(r *http.Request) bool {
+		return r.Header.Get("X-Debug") == "secret"
+	}))
+
+	if resp := getRoutes(t, srv, "wrong"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status with a rejected request = %d, want 404", resp.StatusCode)
+	}
+	if resp := getRoutes(t, srv, "secret"); resp.StatusCode != http.StatusOK {
+		t.Errorf("status with an allowed request = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestRouteConstants(t *testing.T) {
+	if gen.ProductServicePathGetProduct != "/api/v1/products/{id}" {
+		t.Errorf("ProductServicePathGetProduct = %q", gen.ProductServicePathGetProduct)
+	}
+	routes := gen.ProductServiceServerRoutes()
+	if len(routes) != 2 || routes[0].Path != gen.ProductServicePathGetProduct ||
+		routes[1].Path != gen.ProductServicePathCreateProduct || routes[1].Method != http.MethodPost {
+		t.Errorf("ProductServiceServerRoutes() = %+v", routes)
+	}
+	routes[0].Path = "/changed"
+	if gen.ProductServiceServerRoutes()[0].Path != gen.ProductServicePathGetProduct {
+		t.Error("ProductServiceServerRoutes() shares its slice with the registration")
+	}
+}
+
+func TestRoutePathBuilder(t *testing.T) {
+	srv := newServer(t)
+
+	path := gen.ProductServicePathGetProductFor("a b/c")
+	if path != "/api/v1/products/a%20b%2Fc" {
+		t.Fatalf("ProductServicePathGetProductFor = %q", path)
+	}
+	req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-API-Key", "key")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	var product gen.Product
+	if err := json.NewDecoder(resp.Body).Decode(&product); err != nil {
+		t.Fatal(err)
+	}
+	if product.GetId() != "a b/c" {
+		t.Errorf("id = %q, want %q", product.GetId(), "a b/c")
+	}
+}
+`
@@ -0,0 +1,208 @@
+package httpgen
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// Router selects the router the generated handlers are registered on.
+type Router string
+
+const (
+	// RouterStdlib registers the handlers on a net/http ServeMux with
+	// Register<Service>Server, using Go 1.22 method and wildcard patterns.
+	RouterStdlib Router = "stdlib"
+	// RouterChi mounts the handlers on a chi router with Mount<Service>Routes.
+	RouterChi Router = "chi"
+	// RouterGorilla mounts the handlers on a gorilla/mux router with
+	// Mount<Service>Routes.
+	RouterGorilla Router = "gorilla"
+)
+
+func (r Router) valid() bool {
+	return r == RouterStdlib || r == RouterChi || r == RouterGorilla
+}
+
+const (
+	chiImportPath     = "github.com/go-chi/chi/v5"
+	gorillaImportPath = "github.com/gorilla/mux"
+)
+
+// stdlibRouter reports whether the handlers are registered on a ServeMux.
+func (g *Generator) stdlibRouter() bool {
+	return g.router == RouterStdlib || g.router == ""
+}
+
+// registerFuncName returns the name of the function registering the handlers
+// of service: Register<Service>Server on a ServeMux, Mount<Service>Routes on
+// the other routers.
+func (g *Generator) registerFuncName(service *protogen.Service) string {
+	if g.stdlibRouter() {
+		return "Register" + service.GoName + "Server"
+	}
+	return "Mount" + service.GoName + "Routes"
+}
+
+// generateRouterImport emits the import of the selected router's package, in
+// its own group of an import block, when it is not net/http.
+func (g *Generator) generateRouterImport(gf *protogen.GeneratedFile) {
+	switch g.router {
+	case RouterChi:
+		gf.P(strconv.Quote(chiImportPath))
+		gf.P()
+	case RouterGorilla:
+		gf.P(strconv.Quote(gorillaImportPath))
+		gf.P()
+	case RouterStdlib:
+	}
+}
+
+// pathValueSource returns the call pathValue reads the path wildcards with.
+func (g *Generator) pathValueSource() string {
+	switch g.router {
+	case RouterChi:
+		return "chi.URLParam"
+	case RouterGorilla:
+		return "mux.Vars"
+	case RouterStdlib:
+	}
+	return "r.PathValue"
+}
+
+// generateRegisterFuncSignature emits the doc comment and signature of the
+// function registering the handlers of service.
+func (g *Generator) generateRegisterFuncSignature(gf *protogen.GeneratedFile, service *protogen.Service) {
+	serviceName := service.GoName
+	name := g.registerFuncName(service)
+	params := "server " + serviceName + "Server, opts ...ServerOption"
+	switch g.router {
+	case RouterChi:
+		gf.P("// ", name, " mounts the HTTP handlers for service ", serviceName, " on the chi router r.")
+		params = "r chi.Router, " + params
+	case RouterGorilla:
+		gf.P("// ", name, " mounts the HTTP handlers for service ", serviceName, " on the gorilla/mux")
+		gf.P("// router r.")
+		params = "r *mux.Router, " + params
+	default:
+		gf.P("// ", name, " registers the HTTP handlers for service ", serviceName, " to the given mux.")
+	}
+	if g.features.validatesMessages() {
+		gf.P("// It builds the validators of the messages of the service first, failing when")
+		gf.P("// their buf.validate rules do not compile.")
+	}
+	gf.P("func ", name, "(", params, ") error {")
+}
+
+// routerPattern returns path in the wildcard syntax of the selected router. A
+// wildcard suffix, {name...}, is chi's catch-all * and the gorilla/mux
+// variable {name:.*}; the other wildcards are written alike by all routers.
+func (g *Generator) routerPattern(path string) string {
+	if !strings.HasSuffix(path, "...}") {
+		return path
+	}
+	start := strings.LastIndex(path, "{")
+	switch g.router {
+	case RouterChi:
+		return path[:start] + "*"
+	case RouterGorilla:
+		return path[:start] + strings.TrimSuffix(path[start:], "...}") + ":.*}"
+	case RouterStdlib:
+	}
+	return path
+}
+
+// generateRouteHandle emits the registration of handler on the route of
+// httpMethod and path. trailingSlash registers the trailing-slash form of
+// path instead.
+func (g *Generator) generateRouteHandle(
+	gf *protogen.GeneratedFile,
+	httpMethod, path, handler string,
+	trailingSlash bool,
+) {
+	switch g.router {
+	case RouterChi:
+		if trailingSlash {
+			path += "/"
+		}
+		gf.P(`r.Method("`, httpMethod, `", `, strconv.Quote(g.routerPattern(path)), `, `, handler, `)`)
+	case RouterGorilla:
+		if trailingSlash {
+			path += "/"
+		}
+		gf.P(`r.Handle(`, strconv.Quote(g.routerPattern(path)), `, `, handler, `).Methods("`, httpMethod, `")`)
+	default:
+		if trailingSlash {
+			path += "/{$}"
+		}
+		gf.P(`config.mux.Handle("`, httpMethod, ` `, path, `", `, handler, `)`)
+	}
+}
+
+// generateRouteDebugRegistration emits the listing of the routes of service at
+// GET /__sebuf/routes when WithRouteDebug is set.
+func (g *Generator) generateRouteDebugRegistration(gf *protogen.GeneratedFile, routeInfos string) {
+	args := routeInfos + ", config.logger, config.routeDebugAuth"
+	gf.P("if config.routeDebug {")
+	switch g.router {
+	case RouterChi:
+		gf.P("r.Method(http.MethodGet, sebufhttp.RouteDebugPath, sebufhttp.RouteDebugHandler(r, ", args, "))")
+	case RouterGorilla:
+		gf.P("r.Handle(sebufhttp.RouteDebugPath, sebufhttp.RouteDebugHandler(r, ", args, ")).Methods(http.MethodGet)")
+	default:
+		gf.P("sebufhttp.RegisterRouteDebug(config.mux, ", args, ")")
+	}
+	gf.P("}")
+}
+
+// generateBasePathParamsMiddleware wraps handlerName in the middleware storing
+// the base path parameters of its requests in their context.
+func (g *Generator) generateBasePathParamsMiddleware(gf *protogen.GeneratedFile, handlerName, basePathParams string) {
+	if g.stdlibRouter() {
+		gf.P(handlerName, " = sebufhttp.PathParamsMiddleware(", handlerName, ", ", basePathParams, ")")
+		return
+	}
+	gf.P(handlerName, " = sebufhttp.PathParamsMiddlewareFunc(", handlerName, ", pathValue, ", basePathParams, ")")
+}
+
+// generatePathValueFunc emits pathValue, through which the binding reads the
+// path wildcards of a request, the one binding function that differs between
+// routers.
+func (g *Generator) generatePathValueFunc(gf *protogen.GeneratedFile) {
+	gf.P("// pathValue returns the value of the path wildcard name of r.")
+	switch g.router {
+	case RouterChi:
+		gf.P("// A wildcard suffix ({name...}) is routed as chi's catch-all, named *.")
+		gf.P("func pathValue(r *http.Request, name string) string {")
+		gf.P("if value := chi.URLParam(r, name); value != \"\" {")
+		gf.P("return value")
+		gf.P("}")
+		gf.P(`return chi.URLParam(r, "*")`)
+	case RouterGorilla:
+		gf.P("func pathValue(r *http.Request, name string) string {")
+		gf.P("return mux.Vars(r)[name]")
+	default:
+		gf.P("func pathValue(r *http.Request, name string) string {")
+		gf.P("return r.PathValue(name)")
+	}
+	gf.P("}")
+	gf.P()
+}
+
+// generateSetPathValues emits the statements storing the path wildcard values
+// of the request r where chi or gorilla/mux store them when routing it. values
+// is an expression of type map[string]string.
+func (g *Generator) generateSetPathValues(gf *protogen.GeneratedFile, values string) {
+	switch g.router {
+	case RouterChi:
+		gf.P("routeContext := chi.NewRouteContext()")
+		gf.P("for name, value := range ", values, " {")
+		gf.P("routeContext.URLParams.Add(name, value)")
+		gf.P("}")
+		gf.P("r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, routeContext))")
+	case RouterGorilla:
+		gf.P("r = mux.SetURLVars(r, ", values, ")")
+	case RouterStdlib:
+	}
+}
@@ -0,0 +1,280 @@
+package httpgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestRouterIntegration is an end-to-end integration test that:
+//  1. generates the Go HTTP server of routerProto once per router parameter,
+//     stdlib, chi and gorilla, each in its own package,
+//  2. writes a temporary Go module mounting each of them on its router,
+//  3. verifies every router binds the path and base path parameters, the
+//     wildcard suffix, trailing-slash redirects, HEAD, OPTIONS and the route
+//     listing alike, and runs the generated test scaffold against each.
+func TestRouterIntegration(t *testing.T) {
+	dir := t.TempDir()
+	for _, router := range []string{"stdlib", "chi", "gorilla"} {
+		plugintest.GenerateCode(t, dir, plugintest.Module{
+			Protos: map[string]string{router + ".proto": strings.ReplaceAll(routerProto, "ROUTER", router)},
+			Plugins: []plugintest.Plugin{{
+				Name: "go-http",
+				Opt:  "router=" + router + ",trailing_slash=redirect,auto_options=true,generate_tests=true,generate_benchmarks=true",
+			}},
+			Out: router,
+		})
+	}
+	plugintest.WriteModule(t, dir, plugintest.Module{
+		Path:    "router_test",
+		Files:   map[string]string{"router_test.go": routerIntegrationTestCode},
+		Require: []string{"github.com/go-chi/chi/v5 v5.2.3", "github.com/gorilla/mux v1.8.1"},
+	})
+	plugintest.GoTest(t, dir, "-tags", "sebuf_scaffold")
+}
+
+// routerProto is generated once per router, with ROUTER replaced by its name.
+const routerProto = `syntax = "proto3";
+package test.router.ROUTER;
+option go_package = "router_test/ROUTER;ROUTER";
+import "sebuf/http/annotations.proto";
+
+service LibraryService {
+  option (sebuf.http.service_config) = { base_path: "/t/{tenant_id}/api" };
+  rpc GetBook(GetBookRequest) returns (Book) {
+    option (sebuf.http.config) = { path: "/books/{book_id}" method: HTTP_METHOD_GET };
+  }
+  rpc CreateBook(CreateBookRequest) returns (Book) {
+    option (sebuf.http.config) = { path: "/books" method: HTTP_METHOD_POST };
+  }
+  rpc GetFile(GetFileRequest) returns (File) {
+    option (sebuf.http.config) = { path: "/files/{path...}" method: HTTP_METHOD_GET };
+  }
+}
+
+message GetBookRequest {
+  string tenant_id = 1;
+  int64 book_id = 2;
+}
+
+message CreateBookRequest {
+  string tenant_id = 1;
+  string title = 2;
+}
+
+message Book {
+  string tenant_id = 1;
+  int64 book_id = 2;
+  string title = 3;
+}
+
+message GetFileRequest {
+  string tenant_id = 1;
+  string path = 2;
+}
+
+message File {
+  string tenant_id = 1;
+  string path = 2;
+}
+`
+
+// routerIntegrationTestCode is the test source that runs inside the temp
+// module. The implementation of each router's package reports the context's
+// base path parameter as the title of a book.
+const routerIntegrationTestCode = `package router_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/mux"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	chigen "router_test/chi"
+	gorillagen "router_test/gorilla"
+	stdlibgen "router_test/stdlib"
+)
+
+type stdlibServer struct{}
+
+func (stdlibServer) GetBook(ctx context.Context, req *stdlibgen.GetBookRequest) (*stdlibgen.Book, error) {
+	return &stdlibgen.Book{TenantId: req.GetTenantId(), BookId: req.GetBookId(),
+		Title: sebufhttp.PathParamFromContext(ctx, "tenant_id")}, nil
+}
+
+func (stdlibServer) CreateBook(ctx context.Context, req *stdlibgen.CreateBookRequest) (*stdlibgen.Book, error) {
+	return &stdlibgen.Book{TenantId: req.GetTenantId(), Title: req.GetTitle()}, nil
+}
+
+func (stdlibServer) GetFile(ctx context.Context, req *stdlibgen.GetFileRequest) (*stdlibgen.File, error) {
+	return &stdlibgen.File{TenantId: req.GetTenantId(), Path: req.GetPath()}, nil
+}
+
+type chiServer struct{}
+
+func (chiServer) GetBook(ctx context.Context, req *chigen.GetBookRequest) (*chigen.Book, error) {
+	return &chigen.Book{TenantId: req.GetTenantId(), BookId: req.GetBookId(),
+		Title: sebufhttp.PathParamFromContext(ctx, "tenant_id")}, nil
+}
+
+func (chiServer) CreateBook(ctx context.Context, req *chigen.CreateBookRequest) (*chigen.Book, error) {
+	return &chigen.Book{TenantId: req.GetTenantId(), Title: req.GetTitle()}, nil
+}
+
+func (chiServer) GetFile(ctx context.Context, req *chigen.GetFileRequest) (*chigen.File, error) {
+	return &chigen.File{TenantId: req.GetTenantId(), Path: req.GetPath()}, nil
+}
+
+type gorillaServer struct{}
+
+func (gorillaServer) GetBook(ctx context.Context, req *gorillagen.GetBookRequest) (*gorillagen.Book, error) {
+	return &gorillagen.Book{TenantId: req.GetTenantId(), BookId: req.GetBookId(),
+		Title: sebufhttp.PathParamFromContext(ctx, "tenant_id")}, nil
+}
+
+func (gorillaServer) CreateBook(ctx context.Context, req *gorillagen.CreateBookRequest) (*gorillagen.Book, error) {
+	return &gorillagen.Book{TenantId: req.GetTenantId(), Title: req.GetTitle()}, nil
+}
+
+func (gorillaServer) GetFile(ctx context.Context, req *gorillagen.GetFileRequest) (*gorillagen.File, error) {
+	return &gorillagen.File{TenantId: req.GetTenantId(), Path: req.GetPath()}, nil
+}
+
+// routers builds the handler of each router, with the service registered on it.
+var routers = map[string]func(t *testing.T) http.Handler{
+	"stdlib": func(t *testing.T) http.Handler {
+		mux := http.NewServeMux()
+		if err := stdlibgen.RegisterLibraryServiceServer(stdlibServer{},
+			stdlibgen.WithMux(mux), stdlibgen.WithRouteDebug()); err != nil {
+			t.Fatalf("RegisterLibraryServiceServer: %v", err)
+		}
+		return mux
+	},
+	"chi": func(t *testing.T) http.Handler {
+		r := chi.NewRouter()
+		if err := chigen.MountLibraryServiceRoutes(r, chiServer{}, chigen.WithRouteDebug()); err != nil {
+			t.Fatalf("MountLibraryServiceRoutes: %v", err)
+		}
+		return r
+	},
+	"gorilla": func(t *testing.T) http.Handler {
+		r := mux.NewRouter()
+		if err := gorillagen.MountLibraryServiceRoutes(r, gorillaServer{}, gorillagen.WithRouteDebug()); err != nil {
+			t.Fatalf("MountLibraryServiceRoutes: %v", err)
+		}
+		return r
+	},
+}
+
+// sameJSON reports whether got and want encode the same JSON value, whatever
+// the spacing protojson chose.
+func sameJSON(got []byte, want string) bool {
+	var gotValue, wantValue any
+	if json.Unmarshal(got, &gotValue) != nil || json.Unmarshal([]byte(want), &wantValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(gotValue, wantValue)
+}
+
+func TestRouters(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"path parameters", "GET", "/t/acme/api/books/42", "", 200,
+			` + "`" + `{"tenantId":"acme","bookId":"42","title":"acme"}` + "`" + `},
+		{"body", "POST", "/t/acme/api/books", ` + "`" + `{"title":"Dune"}` + "`" + `, 200,
+			` + "`" + `{"tenantId":"acme","title":"Dune"}` + "`" + `},
+		{"wildcard suffix", "GET", "/t/acme/api/files/docs/guide.md", "", 200,
+			` + "`" + `{"tenantId":"acme","path":"docs/guide.md"}` + "`" + `},
+		{"invalid path parameter", "GET", "/t/acme/api/books/forty-two", "", 400, ""},
+		{"trailing slash", "GET", "/t/acme/api/books/42/", "", 308, ""},
+		{"unknown route", "GET", "/t/acme/api/authors", "", 404, ""},
+		{"head", "HEAD", "/t/acme/api/books/42", "", 200, ""},
+		{"head of wildcard suffix", "HEAD", "/t/acme/api/files/docs/guide.md", "", 200, ""},
+		{"head of trailing slash", "HEAD", "/t/acme/api/books/42/", "", 308, ""},
+		{"options", "OPTIONS", "/t/acme/api/books/42", "", 204, ""},
+	}
+	for name, newRouter := range routers {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(newRouter(t))
+			t.Cleanup(srv.Close)
+			client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			}}
+			for _, tt := range tests {
+				req, err := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+				if err != nil {
+					t.Fatal(err)
+				}
+				req.Header.Set("Content-Type", "application/json")
+				resp, err := client.Do(req)
+				if err != nil {
+					t.Fatalf("%s: %v", tt.name, err)
+				}
+				body, _ := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if resp.StatusCode != tt.wantStatus {
+					t.Errorf("%s: status %d, want %d (body %s)", tt.name, resp.StatusCode, tt.wantStatus, body)
+					continue
+				}
+				if tt.wantBody != "" && !sameJSON(body, tt.wantBody) {
+					t.Errorf("%s: body %s, want %s", tt.name, body, tt.wantBody)
+				}
+				if tt.wantStatus == 308 && resp.Header.Get("Location") != "/t/acme/api/books/42" {
+					t.Errorf("%s: redirected to %q", tt.name, resp.Header.Get("Location"))
+				}
+				if tt.method == "HEAD" && tt.wantStatus == 200 && (len(body) != 0 || resp.ContentLength <= 0) {
+					t.Errorf("%s: body %q, Content-Length %d, want no body and its length",
+						tt.name, body, resp.ContentLength)
+				}
+				if tt.method == "OPTIONS" && resp.Header.Get("Allow") != "GET, HEAD, OPTIONS" {
+					t.Errorf("%s: Allow %q, want GET, HEAD, OPTIONS", tt.name, resp.Header.Get("Allow"))
+				}
+			}
+
+			resp, err := http.Get(srv.URL + sebufhttp.RouteDebugPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			var listing struct {
+				Routes []sebufhttp.RouteInfo ` + "`" + `json:"routes"` + "`" + `
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&listing); err != nil || len(listing.Routes) != 3 {
+				t.Errorf("route listing: %d routes, %v, want 3", len(listing.Routes), err)
+			}
+		})
+	}
+}
+
+func TestScaffolds(t *testing.T) {
+	t.Run("stdlib", func(t *testing.T) {
+		stdlibgen.RunLibraryServiceScaffold(t, func(*testing.T) stdlibgen.LibraryServiceServer {
+			return stdlibServer{}
+		})
+	})
+	t.Run("chi", func(t *testing.T) {
+		chigen.RunLibraryServiceScaffold(t, func(*testing.T) chigen.LibraryServiceServer { return chiServer{} })
+	})
+	t.Run("gorilla", func(t *testing.T) {
+		gorillagen.RunLibraryServiceScaffold(t, func(*testing.T) gorillagen.LibraryServiceServer {
+			return gorillaServer{}
+		})
+	})
+}
+`
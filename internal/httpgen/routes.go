@@ -0,0 +1,325 @@
+package httpgen
+
+import (
+	"fmt"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// TrailingSlash selects how generated servers answer the trailing-slash form
+// ("/users/") of a route registered in its canonical form ("/users").
+type TrailingSlash string
+
+const (
+	// TrailingSlashStrict registers only the canonical form, so the
+	// trailing-slash form is answered with 404 Not Found.
+	TrailingSlashStrict TrailingSlash = "strict"
+	// TrailingSlashRedirect answers the trailing-slash form with a 308
+	// Permanent Redirect to the canonical form.
+	TrailingSlashRedirect TrailingSlash = "redirect"
+	// TrailingSlashIgnore serves both forms with the same handler.
+	TrailingSlashIgnore TrailingSlash = "ignore"
+)
+
+func (t TrailingSlash) valid() bool {
+	return t == TrailingSlashStrict || t == TrailingSlashRedirect || t == TrailingSlashIgnore
+}
+
+// pathWildcardPattern matches the {name} and {name...} wildcards of a route path.
+var pathWildcardPattern = regexp.MustCompile(`\{[^}]*\}`)
+
+// canonicalRoutePath returns the form a route path is registered in: without
+// a trailing slash, except for the root path.
+func canonicalRoutePath(path string) string {
+	if trimmed := strings.TrimRight(path, "/"); trimmed != "" {
+		return trimmed
+	}
+	return "/"
+}
+
+// routeConflictKey returns the key two routes collide on: the verb and the
+// canonical path with wildcard names erased, since net/http cannot tell
+// "/users/{id}" and "/users/{user_id}" apart. A multi-segment wildcard keeps
+// its "..." marker: "/files/{name}" and "/files/{path...}" are distinct routes.
+func routeConflictKey(httpMethod, path string) string {
+	return httpMethod + " " + pathWildcardPattern.ReplaceAllStringFunc(canonicalRoutePath(path), func(wildcard string) string {
+		if strings.HasSuffix(wildcard, "...}") {
+			return "{}..."
+		}
+		return "{}"
+	})
+}
+
+// RouteConflict is a method served on the same verb and path as an earlier
+// method of its file, which net/http would reject with a panic when the second
+// route is registered.
+type RouteConflict struct {
+	Method     *protogen.Method
+	Path       string
+	Owner      *protogen.Method // the method that claimed the route first
+	OwnerPath  string
+	HTTPMethod string
+}
+
+func (c *RouteConflict) Error() string {
+	return fmt.Sprintf("route conflict: %s (%s %s) and %s (%s %s) are served on the same route",
+		c.Owner.Desc.FullName(), c.HTTPMethod, c.OwnerPath,
+		c.Method.Desc.FullName(), c.HTTPMethod, c.Path)
+}
+
+// routeOwner is the method that first claimed a route during conflict detection.
+type routeOwner struct {
+	method *protogen.Method
+	path   string
+}
+
+// RouteConflicts returns the route conflicts between the methods of file's
+// services, checking each API version's routes.
+func RouteConflicts(file *protogen.File) []*RouteConflict {
+	var g Generator // the route helpers read no generator state
+	var conflicts []*RouteConflict
+	owners := make(map[string]routeOwner)
+	for _, service := range file.Services {
+		basePaths := []string{g.getServiceBasePath(service)}
+		if versions := annotations.GetServiceVersions(service); len(versions) > 0 {
+			basePaths = basePaths[:0]
+			for _, version := range versions {
+				basePaths = append(basePaths, version.BasePath)
+			}
+		}
+		for _, method := range service.Methods {
+			httpMethod := g.getHTTPMethod(method)
+			for _, basePath := range basePaths {
+				path := g.getMethodPath(method, basePath, file.GoPackageName)
+				key := routeConflictKey(httpMethod, path)
+				if owner, exists := owners[key]; exists {
+					conflicts = append(conflicts, &RouteConflict{
+						Method:     method,
+						Path:       path,
+						Owner:      owner.method,
+						OwnerPath:  owner.path,
+						HTTPMethod: httpMethod,
+					})
+					continue
+				}
+				owners[key] = routeOwner{method: method, path: path}
+			}
+		}
+	}
+	return conflicts
+}
+
+// validateRoutes fails on the first route conflict of file.
+func (g *Generator) validateRoutes(file *protogen.File) error {
+	if conflicts := RouteConflicts(file); len(conflicts) > 0 {
+		return conflicts[0]
+	}
+	return nil
+}
+
+// generateHandle registers the handler of method on the canonical form of path,
+// and on its trailing-slash form as the trailing_slash parameter requires. Only
+// the canonical form is recorded in the manifest, the route debug listing and
+// the constant named route.
+func (g *Generator) generateHandle(
+	gf *protogen.GeneratedFile,
+	method *protogen.Method,
+	httpMethod, path, route, handler string,
+) {
+	canonical := canonicalRoutePath(path)
+	g.manifest.AddRoute(method, httpMethod, canonical)
+	g.serviceRoutes = append(g.serviceRoutes, serviceRoute{
+		method:     method,
+		httpMethod: httpMethod,
+		path:       canonical,
+		constName:  route,
+	})
+	streaming := g.isSSEMethod(method) || annotations.IsStreamResponse(method)
+	g.generateVerbHandles(gf, httpMethod, canonical, handler, streaming, false)
+	g.generateOptionsHandle(gf, httpMethod, canonical, false)
+	if canonical == "/" || strings.HasSuffix(canonical, "...}") {
+		// The root and catch-all wildcards already match the trailing slash
+		return
+	}
+	switch g.trailingSlash {
+	case TrailingSlashRedirect:
+		g.generateVerbHandles(gf, httpMethod, canonical, "sebufhttp.TrailingSlashRedirectHandler()", false, true)
+	case TrailingSlashIgnore:
+		g.generateVerbHandles(gf, httpMethod, canonical, handler, streaming, true)
+		g.generateOptionsHandle(gf, httpMethod, canonical, true)
+	case TrailingSlashStrict:
+		// Only the canonical form is served
+	}
+}
+
+// routeVerbs returns the verbs a route annotated with httpMethod is served on:
+// GET routes also answer HEAD.
+func routeVerbs(httpMethod string) []string {
+	if httpMethod == "GET" {
+		return []string{"GET", "HEAD"}
+	}
+	return []string{httpMethod}
+}
+
+// generateVerbHandles registers handler on path for every verb of
+// routeVerbs(httpMethod), the HEAD form of a GET route through
+// sebufhttp.HeadHandler, which sends the headers of GET without its body. A
+// streaming handler would hold HEAD until its stream ends, so it is registered
+// as is: net/http drops the body of HEAD responses, and sends the headers on
+// the first flush.
+func (g *Generator) generateVerbHandles(
+	gf *protogen.GeneratedFile,
+	httpMethod, path, handler string,
+	streaming, trailingSlash bool,
+) {
+	for _, verb := range routeVerbs(httpMethod) {
+		if verb == "HEAD" && !streaming {
+			g.generateRouteHandle(gf, verb, path, "sebufhttp.HeadHandler("+handler+")", trailingSlash)
+			continue
+		}
+		g.generateRouteHandle(gf, verb, path, handler, trailingSlash)
+	}
+}
+
+// generateOptionsHandle records the verbs of a route with auto_options=true,
+// registering the OPTIONS handler answering with the verbs of every route of
+// path on the first route registered for it.
+func (g *Generator) generateOptionsHandle(
+	gf *protogen.GeneratedFile,
+	httpMethod, path string,
+	trailingSlash bool,
+) {
+	if !g.autoOptions {
+		return
+	}
+	router := "r"
+	if g.stdlibRouter() {
+		router = "config.mux"
+	}
+	key := path
+	if trailingSlash {
+		key += "/"
+	}
+	verbs := make([]string, 0, 2)
+	for _, verb := range routeVerbs(httpMethod) {
+		verbs = append(verbs, strconv.Quote(verb))
+	}
+	gf.P("if options, first := sebufhttp.OptionsHandler(", router, ", ", strconv.Quote(key), ", ",
+		strings.Join(verbs, ", "), "); first {")
+	g.generateRouteHandle(gf, "OPTIONS", path, "options", trailingSlash)
+	gf.P("}")
+}
+
+// serviceRoute is a route registered by generateHandle.
+type serviceRoute struct {
+	method     *protogen.Method
+	httpMethod string
+	path       string
+	constName  string // name of the exported constant holding path
+}
+
+// routeConstName returns the name of the constant holding the path of a route
+// of method: <Service>Path<Method>, followed by the version name in upper camel
+// case (e.g. GetUserV2) for the routes of a versioned service.
+func routeConstName(method *protogen.Method, version string) string {
+	name := method.Parent.GoName + "Path" + method.GoName
+	for _, part := range strings.FieldsFunc(version, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		name += strings.ToUpper(part[:1]) + part[1:]
+	}
+	return name
+}
+
+// wildcardInitialisms are the words wildcardParamName spells in upper case.
+var wildcardInitialisms = map[string]string{
+	"api": "API", "http": "HTTP", "id": "ID", "ip": "IP", "json": "JSON", "uri": "URI", "url": "URL", "uuid": "UUID",
+}
+
+// wildcardParamName returns the Go parameter name of a path wildcard in the
+// builder of its route: its lowerCamelCase name with Go initialisms, e.g. orgID
+// for {org_id}.
+func wildcardParamName(wildcard string) string {
+	parts := strings.Split(strings.TrimSuffix(strings.Trim(wildcard, "{}"), "..."), "_")
+	name := parts[0]
+	for _, part := range parts[1:] {
+		if initialism, ok := wildcardInitialisms[strings.ToLower(part)]; ok {
+			name += initialism
+		} else if part != "" {
+			name += strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	if token.IsKeyword(name) {
+		name += "Value"
+	}
+	return name
+}
+
+// generateRouteConstants emits the exported constant holding the path of each
+// route registered for service, the functions building those paths from the
+// values of their wildcards, and <Service>ServerRoutes. The constants hold the
+// paths generateHandle registered, so the two cannot drift apart.
+func (g *Generator) generateRouteConstants(gf *protogen.GeneratedFile, service *protogen.Service) {
+	gf.P("// Paths of the routes ", g.registerFuncName(service), " registers.")
+	gf.P("const (")
+	for _, route := range g.serviceRoutes {
+		gf.P(route.constName, " = ", strconv.Quote(route.path))
+	}
+	gf.P(")")
+	gf.P()
+
+	for _, route := range g.serviceRoutes {
+		wildcards := pathWildcardPattern.FindAllString(route.path, -1)
+		if len(wildcards) == 0 {
+			continue
+		}
+		params := make([]string, len(wildcards))
+		for i, wildcard := range wildcards {
+			params[i] = wildcardParamName(wildcard)
+		}
+		gf.P("// ", route.constName, "For returns ", route.constName, " with its wildcards replaced by")
+		gf.P("// the URL-escaped values of ", strings.Join(params, ", "), ".")
+		gf.P("func ", route.constName, "For(", strings.Join(params, ", "), " string) string {")
+		gf.P("return sebufhttp.BuildPath(", route.constName, ", ", strings.Join(params, ", "), ")")
+		gf.P("}")
+		gf.P()
+	}
+
+	routeInfos := annotations.LowerFirst(service.GoName) + "RouteInfos"
+	gf.P("// ", service.GoName, "ServerRoutes returns the routes ", g.registerFuncName(service), " registers.")
+	gf.P("func ", service.GoName, "ServerRoutes() []sebufhttp.RouteInfo {")
+	gf.P("return append([]sebufhttp.RouteInfo(nil), ", routeInfos, "...)")
+	gf.P("}")
+	gf.P()
+}
+
+// generateRouteInfos emits the list of the routes registered for service, which
+// the registration function logs and serves under WithRouteDebug.
+func (g *Generator) generateRouteInfos(gf *protogen.GeneratedFile, service *protogen.Service, name string) {
+	serviceHeaders := annotations.GetServiceHeaders(service)
+	gf.P("// ", name, " lists the routes ", g.registerFuncName(service), " registers.")
+	gf.P("var ", name, " = []sebufhttp.RouteInfo{")
+	for _, route := range g.serviceRoutes {
+		var required []string
+		for _, header := range annotations.CombineHeaders(serviceHeaders, annotations.GetMethodHeaders(route.method)) {
+			if header.GetRequired() {
+				required = append(required, strconv.Quote(header.GetName()))
+			}
+		}
+		fields := fmt.Sprintf("Method: %q, Path: %s, Service: %q, RPC: %q",
+			route.httpMethod, route.constName, route.method.Parent.Desc.FullName(), route.method.Desc.Name())
+		if len(required) > 0 {
+			fields += ", RequiredHeaders: []string{" + strings.Join(required, ", ") + "}"
+		}
+		gf.P("{", fields, "},")
+	}
+	gf.P("}")
+	gf.P()
+}
@@ -0,0 +1,137 @@
+package httpgen
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalRoutePath(t *testing.T) {
+	for path, want := range map[string]string{
+		"/":                 "/",
+		"//":                "/",
+		"/users":            "/users",
+		"/users/":           "/users",
+		"/users/{id}/":      "/users/{id}",
+		"/files/{path...}/": "/files/{path...}",
+	} {
+		if got := canonicalRoutePath(path); got != want {
+			t.Errorf("canonicalRoutePath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+// TestRouteConflictKey verifies routes collide regardless of their wildcard
+// names, but a single-segment and a multi-segment wildcard at the same prefix
+// do not.
+func TestRouteConflictKey(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		collide bool
+	}{
+		{"/users/{id}", "/users/{user_id}", true},
+		{"/files/{path...}", "/files/{rest...}/", true},
+		{"/files/{name}", "/files/{path...}", false},
+		{"/files/{name}/raw", "/files/{path...}", false},
+	}
+	for _, tt := range tests {
+		a, b := routeConflictKey("GET", tt.a), routeConflictKey("GET", tt.b)
+		if (a == b) != tt.collide {
+			t.Errorf("routeConflictKey(%q) = %q, routeConflictKey(%q) = %q, want collide=%t",
+				tt.a, a, tt.b, b, tt.collide)
+		}
+	}
+}
+
+// TestRouteConflictsRejected verifies generation fails, naming both methods,
+// when two methods of a file are served on the same verb and normalized path.
+func TestRouteConflictsRejected(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping route conflict test")
+	}
+
+	baseDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	projectRoot := filepath.Join(baseDir, "..", "..")
+	pluginPath := filepath.Join(projectRoot, "bin", "protoc-gen-go-http")
+	if _, statErr := os.Stat(pluginPath); os.IsNotExist(statErr) {
+		t.Skip("protoc-gen-go-http not built, run make build")
+	}
+
+	tests := []struct {
+		name     string
+		services string
+		wantErr  string
+	}{
+		{
+			name: "wildcard names across services",
+			services: `service Users {
+  rpc GetUser(UserReq) returns (Res) { option (sebuf.http.config) = { path: "/users/{id}" method: HTTP_METHOD_GET }; }
+}
+service Accounts {
+  rpc GetAccount(AccountReq) returns (Res) { option (sebuf.http.config) = { path: "/users/{user_id}" method: HTTP_METHOD_GET }; }
+}
+message UserReq { string id = 1; }
+message AccountReq { string user_id = 1; }`,
+			wantErr: "route conflict: test.routes.Users.GetUser (GET /users/{id}) and " +
+				"test.routes.Accounts.GetAccount (GET /users/{user_id}) are served on the same route",
+		},
+		{
+			name: "trailing slash",
+			services: `service Users {
+  rpc ListUsers(Req) returns (Res) { option (sebuf.http.config) = { path: "/users" method: HTTP_METHOD_GET }; }
+  rpc SearchUsers(Req) returns (Res) { option (sebuf.http.config) = { path: "/users/" method: HTTP_METHOD_GET }; }
+}
+message Req {}`,
+			wantErr: "test.routes.Users.ListUsers (GET /users) and test.routes.Users.SearchUsers (GET /users/)",
+		},
+		{
+			name: "default path",
+			services: `service Users {
+  option (sebuf.http.service_config) = { base_path: "/api" };
+  rpc GetUser(Req) returns (Res);
+  rpc FetchUser(Req) returns (Res) { option (sebuf.http.config) = { path: "/get_user" }; }
+}
+message Req {}`,
+			wantErr: "test.routes.Users.GetUser (POST /api/get_user) and test.routes.Users.FetchUser (POST /api/get_user)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			protoDir := t.TempDir()
+			protoSrc := `syntax = "proto3";
+package test.routes;
+option go_package = "example.com/routes;routes";
+import "sebuf/http/annotations.proto";
+` + tt.services + `
+message Res {}
+`
+			if writeErr := os.WriteFile(filepath.Join(protoDir, "routes.proto"), []byte(protoSrc), 0o600); writeErr != nil {
+				t.Fatalf("Failed to write proto: %v", writeErr)
+			}
+
+			cmd := exec.Command("protoc",
+				"--plugin=protoc-gen-go-http="+pluginPath,
+				"--go-http_out="+t.TempDir(),
+				"--go-http_opt=paths=source_relative",
+				"--proto_path="+protoDir,
+				"--proto_path="+filepath.Join(projectRoot, "proto"),
+				"routes.proto",
+			)
+			var stderr bytes.Buffer
+			cmd.Stderr = &stderr
+			if runErr := cmd.Run(); runErr == nil {
+				t.Fatalf("expected generation to fail")
+			}
+			if !strings.Contains(stderr.String(), tt.wantErr) {
+				t.Errorf("unexpected error output: %s", stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package httpgen
+
+import (
+	"flag"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/internal/manifest"
+	"github.com/SebastienMelki/sebuf/internal/pluginrun"
+)
+
+// Run generates the HTTP handlers of req in memory, without reading stdin or
+// writing stdout. opts supplies the defaults for plugin parameters; the
+// generate_mock, mock_artifacts, generate_benchmarks, generate_tests,
+// trailing_slash, compat, router, extra_codecs, all_enum_helpers,
+// schema_fingerprint, embed_descriptors, auto_options, strict_headers and
+// manifest parameters in req override them. Invalid input is reported in the
+// response's Error field; the error is only set if generation panicked.
+func Run(req *pluginpb.CodeGeneratorRequest, opts Options) (*pluginpb.CodeGeneratorResponse, error) {
+	var flags flag.FlagSet
+	flags.BoolVar(&opts.GenerateMock, "generate_mock", opts.GenerateMock, "generate mock server implementation")
+	flags.BoolVar(&opts.GenerateBenchmarks, "generate_benchmarks", opts.GenerateBenchmarks,
+		"generate request binding benchmarks")
+	flags.BoolVar(&opts.GenerateTests, "generate_tests", opts.GenerateTests,
+		"generate a test scaffold built under the "+scaffoldBuildTag+" tag")
+	trailingSlash := flags.String("trailing_slash", string(opts.TrailingSlash),
+		"serving of trailing-slash paths: redirect, strict, or ignore")
+	compat := flags.String("compat", string(opts.Compat),
+		"compatibility mode: "+string(CompatGRPCGateway)+" mimics grpc-gateway query binding and errors")
+	router := flags.String("router", string(opts.Router),
+		"router the handlers are registered on: stdlib, chi, or gorilla")
+	flags.BoolVar(&opts.AllEnumHelpers, "all_enum_helpers", opts.AllEnumHelpers,
+		"generate enum helpers for every enum, not only those with enum_value mappings")
+	flags.BoolVar(&opts.SchemaFingerprint, "schema_fingerprint", opts.SchemaFingerprint,
+		"embed a fingerprint of each service's schema and check the one clients send")
+	flags.BoolVar(&opts.EmbedDescriptors, "embed_descriptors", opts.EmbedDescriptors,
+		"embed the descriptors of each service and generate the functions serving them")
+	flags.BoolVar(&opts.AutoOptions, "auto_options", opts.AutoOptions,
+		"answer OPTIONS on the path of every route with the verbs served on it")
+	flags.BoolVar(&opts.StrictHeaders, "strict_headers", opts.StrictHeaders,
+		"fail on method headers changing the type or format of a service header instead of warning")
+	flags.BoolVar(&opts.Manifest, "manifest", opts.Manifest,
+		"write "+manifest.FileName+" describing the generated routes and files")
+
+	// mock_artifacts and extra_codecs are comma-separated lists, which protoc
+	// splits into separate parameters, so they are taken out of a copy of the
+	// request (sharing its descriptors) before protogen sees it.
+	if req != nil {
+		artifacts, param := pluginrun.TakeListParam(req.GetParameter(), "mock_artifacts")
+		if artifacts != nil {
+			opts.MockArtifacts = opts.MockArtifacts[:0:0]
+			for _, artifact := range artifacts {
+				opts.MockArtifacts = append(opts.MockArtifacts, MockArtifact(artifact))
+			}
+		}
+		codecs, param := pluginrun.TakeListParam(param, "extra_codecs")
+		if codecs != nil {
+			opts.ExtraCodecs = opts.ExtraCodecs[:0:0]
+			for _, codec := range codecs {
+				opts.ExtraCodecs = append(opts.ExtraCodecs, ExtraCodec(codec))
+			}
+		}
+		req = &pluginpb.CodeGeneratorRequest{
+			FileToGenerate:        req.GetFileToGenerate(),
+			Parameter:             proto.String(param),
+			ProtoFile:             req.GetProtoFile(),
+			SourceFileDescriptors: req.GetSourceFileDescriptors(),
+			CompilerVersion:       req.GetCompilerVersion(),
+		}
+	}
+
+	var routes *manifest.Builder
+	resp, err := pluginrun.Run(req, flags.Set, func(plugin *protogen.Plugin) error {
+		opts.TrailingSlash = TrailingSlash(*trailingSlash)
+		opts.Compat = Compat(*compat)
+		opts.Router = Router(*router)
+		g := NewWithOptions(plugin, opts)
+		if opts.Manifest {
+			routes = manifest.NewBuilder("protoc-gen-go-http")
+			g.manifest = routes
+		}
+		return g.Generate()
+	})
+	routes.Write(resp)
+	return resp, err
+}
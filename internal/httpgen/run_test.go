@@ -0,0 +1,327 @@
+package httpgen
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/internal/manifest"
+	"github.com/SebastienMelki/sebuf/internal/pluginrun/pluginruntest"
+)
+
+func TestRun(t *testing.T) {
+	resp, err := Run(pluginruntest.Request("paths=source_relative"), Options{})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if resp.GetError() != "" {
+		t.Fatalf("response error: %s", resp.GetError())
+	}
+	want := []string{"notes_http.pb.go", "notes_http_binding.pb.go", "notes_http_config.pb.go"}
+	if got := pluginruntest.FileNames(resp); !slices.Equal(got, want) {
+		t.Errorf("files = %v, want %v", got, want)
+	}
+	if !strings.Contains(pluginruntest.Content(resp, "notes_http.pb.go"), `"GET /api/v1/notes/{id}"`) {
+		t.Error("GetNote should be registered under the service base path")
+	}
+}
+
+func TestRunMockOption(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		param string
+		opts  Options
+		mock  bool
+	}{
+		{name: "option", opts: Options{GenerateMock: true}, mock: true},
+		{name: "parameter", param: ",generate_mock=true", mock: true},
+		{name: "parameter overrides option", param: ",generate_mock=false", opts: Options{GenerateMock: true}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := Run(pluginruntest.Request("paths=source_relative"+tt.param), tt.opts)
+			if err != nil || resp.GetError() != "" {
+				t.Fatalf("Run: %v %s", err, resp.GetError())
+			}
+			if got := pluginruntest.Content(resp, "notes_http_mock.pb.go") != ""; got != tt.mock {
+				t.Errorf("mock generated = %v, want %v", got, tt.mock)
+			}
+		})
+	}
+}
+
+func TestRunReportsInvalidInput(t *testing.T) {
+	resp, err := Run(pluginruntest.Request("generate_mock=maybe"), Options{})
+	if err != nil {
+		t.Fatalf("Run returned error %v, want it in the response", err)
+	}
+	if !strings.Contains(resp.GetError(), "generate_mock") {
+		t.Errorf("response error = %q, want the invalid parameter reported", resp.GetError())
+	}
+}
+
+func TestRunTrailingSlashOption(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		param string
+		opts  Options
+		want  string
+	}{
+		{name: "default", want: ""},
+		{name: "option", opts: Options{TrailingSlash: TrailingSlashIgnore}, want: `, getNoteHandler)`},
+		{name: "parameter", param: ",trailing_slash=redirect", want: `, sebufhttp.TrailingSlashRedirectHandler())`},
+		{
+			name:  "parameter overrides option",
+			param: ",trailing_slash=strict",
+			opts:  Options{TrailingSlash: TrailingSlashRedirect},
+			want:  "",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := Run(pluginruntest.Request("paths=source_relative"+tt.param), tt.opts)
+			if err != nil || resp.GetError() != "" {
+				t.Fatalf("Run: %v %s", err, resp.GetError())
+			}
+			content := pluginruntest.Content(resp, "notes_http.pb.go")
+			route := `config.mux.Handle("GET /api/v1/notes/{id}/{$}"`
+			if tt.want == "" {
+				if strings.Contains(content, route) {
+					t.Error("strict mode should not register the trailing-slash route")
+				}
+			} else if !strings.Contains(content, route+tt.want) {
+				t.Errorf("expected the trailing-slash route registered with %q", tt.want)
+			}
+		})
+	}
+
+	resp, err := Run(pluginruntest.Request("trailing_slash=loose"), Options{})
+	if err != nil {
+		t.Fatalf("Run returned error %v, want it in the response", err)
+	}
+	if !strings.Contains(resp.GetError(), `unsupported trailing_slash "loose"`) {
+		t.Errorf("response error = %q, want the invalid mode reported", resp.GetError())
+	}
+}
+
+func TestRunRouterOption(t *testing.T) {
+	for _, tt := range []struct {
+		param string
+		opts  Options
+		want  string
+	}{
+		{want: `config.mux.Handle("GET /api/v1/notes/{id}", getNoteHandler)`},
+		{param: ",router=chi", want: `r.Method("GET", "/api/v1/notes/{id}", getNoteHandler)`},
+		{opts: Options{Router: RouterGorilla}, want: `r.Handle("/api/v1/notes/{id}", getNoteHandler).Methods("GET")`},
+		{param: ",router=stdlib", opts: Options{Router: RouterChi}, want: `config.mux.Handle("GET /api/v1/notes/{id}"`},
+	} {
+		resp, err := Run(pluginruntest.Request("paths=source_relative"+tt.param), tt.opts)
+		if err != nil || resp.GetError() != "" {
+			t.Fatalf("Run(%q, %+v): %v %s", tt.param, tt.opts, err, resp.GetError())
+		}
+		if content := pluginruntest.Content(resp, "notes_http.pb.go"); !strings.Contains(content, tt.want) {
+			t.Errorf("Run(%q, %+v): routes not registered with %s", tt.param, tt.opts, tt.want)
+		}
+	}
+
+	resp, err := Run(pluginruntest.Request("router=echo"), Options{})
+	if err != nil {
+		t.Fatalf("Run returned error %v, want it in the response", err)
+	}
+	if !strings.Contains(resp.GetError(), `unsupported router "echo"`) {
+		t.Errorf("response error = %q, want the invalid router reported", resp.GetError())
+	}
+}
+
+func TestRunManifestOption(t *testing.T) {
+	resp, err := Run(pluginruntest.Request("paths=source_relative"), Options{})
+	if err != nil || resp.GetError() != "" {
+		t.Fatalf("Run: %v %s", err, resp.GetError())
+	}
+	if pluginruntest.Content(resp, manifest.FileName) != "" {
+		t.Errorf("%s should only be written with manifest=true", manifest.FileName)
+	}
+
+	resp, err = Run(pluginruntest.Request("paths=source_relative,manifest=true"), Options{})
+	if err != nil || resp.GetError() != "" {
+		t.Fatalf("Run: %v %s", err, resp.GetError())
+	}
+	var got manifest.Manifest
+	if err = json.Unmarshal([]byte(pluginruntest.Content(resp, manifest.FileName)), &got); err != nil {
+		t.Fatalf("decode manifest: %v", err)
+	}
+	if got.ManifestVersion != manifest.Version {
+		t.Errorf("manifestVersion = %d, want %d", got.ManifestVersion, manifest.Version)
+	}
+	var outputs []string
+	for _, output := range got.Outputs {
+		outputs = append(outputs, output.Name)
+		if output.Plugin != "protoc-gen-go-http" || output.PluginVersion == "" {
+			t.Errorf("output %s produced by %q %q, want protoc-gen-go-http and a version",
+				output.Name, output.Plugin, output.PluginVersion)
+		}
+	}
+	wantOutputs := []string{"notes_http.pb.go", "notes_http_binding.pb.go", "notes_http_config.pb.go"}
+	if !slices.Equal(outputs, wantOutputs) {
+		t.Errorf("outputs = %v, want %v", outputs, wantOutputs)
+	}
+	want := []manifest.Service{{
+		Name:          "test.notes.v1.NoteService",
+		ProtoFile:     pluginruntest.FileName,
+		Plugin:        "protoc-gen-go-http",
+		PluginVersion: got.Outputs[0].PluginVersion,
+		Methods: []manifest.Method{
+			{
+				Name:         "GetNote",
+				RequestType:  "test.notes.v1.GetNoteRequest",
+				ResponseType: "test.notes.v1.Note",
+				Routes:       []manifest.Route{{Method: "GET", Path: "/api/v1/notes/{id}"}},
+			},
+			{
+				Name:         "CreateNote",
+				RequestType:  "test.notes.v1.CreateNoteRequest",
+				ResponseType: "test.notes.v1.Note",
+				Routes:       []manifest.Route{{Method: "POST", Path: "/api/v1/notes"}},
+			},
+		},
+	}}
+	if !reflect.DeepEqual(got.Services, want) {
+		t.Errorf("services = %+v, want %+v", got.Services, want)
+	}
+}
+
+func TestRunMockArtifactsOption(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		param string
+		opts  Options
+		want  []string
+	}{
+		{name: "none", param: ",generate_mock=true"},
+		{name: "http", param: ",generate_mock=true,mock_artifacts=http", want: []string{"notes_mock.http"}},
+		{
+			name:  "comma-separated list",
+			param: ",generate_mock=true,mock_artifacts=http,insomnia",
+			want:  []string{"notes_mock.http", "notes_mock.openapi.json"},
+		},
+		{
+			name:  "parameter overrides option",
+			param: ",mock_artifacts=insomnia",
+			opts:  Options{GenerateMock: true, MockArtifacts: []MockArtifact{MockArtifactHTTP}},
+			want:  []string{"notes_mock.openapi.json"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := Run(pluginruntest.Request("paths=source_relative"+tt.param), tt.opts)
+			if err != nil || resp.GetError() != "" {
+				t.Fatalf("Run: %v %s", err, resp.GetError())
+			}
+			var got []string
+			for _, name := range pluginruntest.FileNames(resp) {
+				if strings.Contains(name, "_mock.") && !strings.HasSuffix(name, ".go") {
+					got = append(got, name)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("artifacts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for param, want := range map[string]string{
+		"mock_artifacts=http":                       "mock_artifacts requires generate_mock=true",
+		"generate_mock=true,mock_artifacts=postman": `unsupported mock_artifacts "postman"`,
+	} {
+		resp, err := Run(pluginruntest.Request(param), Options{})
+		if err != nil {
+			t.Fatalf("Run returned error %v, want it in the response", err)
+		}
+		if !strings.Contains(resp.GetError(), want) {
+			t.Errorf("%s: response error = %q, want %q", param, resp.GetError(), want)
+		}
+	}
+}
+
+func TestRunExtraCodecsOption(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		param string
+		opts  Options
+		want  bool
+	}{
+		{name: "default", want: false},
+		{name: "option", opts: Options{ExtraCodecs: []ExtraCodec{ExtraCodecMsgpack}}, want: true},
+		{name: "parameter", param: ",extra_codecs=msgpack", want: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := Run(pluginruntest.Request("paths=source_relative"+tt.param), tt.opts)
+			if err != nil || resp.GetError() != "" {
+				t.Fatalf("Run: %v %s", err, resp.GetError())
+			}
+			content := pluginruntest.Content(resp, "notes_http_binding.pb.go")
+			if got := strings.Contains(content, "return bindDataFromMsgpackRequest(r, toBind, body)"); got != tt.want {
+				t.Errorf("msgpack binding generated = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	resp, err := Run(pluginruntest.Request("extra_codecs=msgpack,cbor"), Options{})
+	if err != nil {
+		t.Fatalf("Run returned error %v, want it in the response", err)
+	}
+	if !strings.Contains(resp.GetError(), `unsupported extra_codecs "cbor"`) {
+		t.Errorf("response error = %q, want the invalid codec reported", resp.GetError())
+	}
+}
+
+func TestRunSchemaFingerprintOption(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		param string
+		opts  Options
+		want  bool
+	}{
+		{name: "default", want: false},
+		{name: "option", opts: Options{SchemaFingerprint: true}, want: true},
+		{name: "parameter", param: ",schema_fingerprint=true", want: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := Run(pluginruntest.Request("paths=source_relative"+tt.param), tt.opts)
+			if err != nil || resp.GetError() != "" {
+				t.Fatalf("Run: %v %s", err, resp.GetError())
+			}
+			content := pluginruntest.Content(resp, "notes_http.pb.go")
+			if got := strings.Contains(content, "const NoteServiceServerSchemaFingerprint = "); got != tt.want {
+				t.Errorf("fingerprint generated = %v, want %v", got, tt.want)
+			}
+			if got := strings.Contains(content, "sebufhttp.SchemaMiddleware("); got != tt.want {
+				t.Errorf("schema check registered = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunStrictHeadersOption(t *testing.T) {
+	for param, wantErr := range map[string]bool{"": false, ",strict_headers=true": true} {
+		req := pluginruntest.Request("paths=source_relative" + param)
+		service := req.GetProtoFile()[len(req.GetProtoFile())-1].GetService()[0]
+		proto.SetExtension(service.GetOptions(), http.E_ServiceHeaders, &http.ServiceHeaders{
+			RequiredHeaders: []*http.Header{{Name: "X-Limit", Type: "integer"}},
+		})
+		proto.SetExtension(service.GetMethod()[0].GetOptions(), http.E_MethodHeaders, &http.MethodHeaders{
+			RequiredHeaders: []*http.Header{{Name: "X-Limit", Type: "string"}},
+		})
+
+		resp, err := Run(req, Options{})
+		if err != nil {
+			t.Fatalf("%q: Run: %v", param, err)
+		}
+		got := strings.Contains(resp.GetError(), `header "X-Limit" overrides the service header`)
+		if got != wantErr {
+			t.Errorf("%q: response error = %q, want the override reported: %v", param, resp.GetError(), wantErr)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package httpgen
+
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/schemahash"
+)
+
+// schemaFingerprintConst returns the name of the constant holding the schema
+// fingerprint of service.
+func schemaFingerprintConst(service *protogen.Service) string {
+	return service.GoName + "ServerSchemaFingerprint"
+}
+
+// generateSchemaFingerprint generates the constant holding the schema
+// fingerprint of service, which Register<Service>Server checks clients against.
+func (g *Generator) generateSchemaFingerprint(gf *protogen.GeneratedFile, service *protogen.Service) {
+	name := schemaFingerprintConst(service)
+	gf.P("// ", name, " is the fingerprint of the methods and messages of the")
+	gf.P("// ", service.GoName, " service this server was generated from. Generated clients send")
+	gf.P("// theirs in the X-Sebuf-Schema header, and the server reacts to a mismatch")
+	gf.P("// according to WithSchemaMismatchMode.")
+	gf.P("const ", name, " = ", strconv.Quote(schemahash.Fingerprint(service.Desc)))
+	gf.P()
+}
+
+// generateSchemaMiddleware wraps the handler of a method in the check of the
+// schema fingerprint clients send.
+func (g *Generator) generateSchemaMiddleware(
+	gf *protogen.GeneratedFile,
+	service *protogen.Service,
+	handlerName string,
+) {
+	gf.P(handlerName, " = sebufhttp.SchemaMiddleware(", handlerName, ", ", schemaFingerprintConst(service), ",")
+	gf.P("config.schemaMismatchMode, config.writeError, config.logger)")
+}
@@ -0,0 +1,289 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestStreamResponseIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server and Go client from a service with
+//     stream_response methods, one keyed and one root-unwrapped,
+//  2. writes a temporary Go module that serves the generated handlers with httptest,
+//  3. verifies that 50k items are served and decoded in bounded memory, that the
+//     JSON written is the document the buffered response would have been, that
+//     an error before the first item is an ordinary error response while one
+//     after it cuts the response short, and that a client disconnect stops the
+//     handler.
+func TestStreamResponseIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "stream_response_test",
+		Protos:  map[string]string{"stream_response.proto": streamResponseProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}, {Name: "go-client"}},
+		Files:   map[string]string{"stream_response_test.go": streamResponseIntegrationTestCode},
+	})
+}
+
+const streamResponseProto = `syntax = "proto3";
+package test.streamresponse;
+option go_package = "stream_response_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service AuditService {
+  rpc ListEvents(ListEventsRequest) returns (ListEventsResponse) {
+    option (sebuf.http.config) = { path: "/events" method: HTTP_METHOD_GET stream_response: true };
+  }
+  rpc ExportEvents(ListEventsRequest) returns (AuditEventList) {
+    option (sebuf.http.config) = { path: "/events/export" method: HTTP_METHOD_POST stream_response: true };
+  }
+}
+
+message ListEventsRequest {
+  // Number of events to list; negative lists events until the client goes away.
+  int32 count = 1 [(sebuf.http.query) = { name: "count" }];
+  // Fails the listing after this many events, when positive.
+  int32 fail_after = 2 [(sebuf.http.query) = { name: "fail_after" }];
+}
+
+message ListEventsResponse {
+  repeated AuditEvent events = 1;
+}
+
+message AuditEventList {
+  repeated AuditEvent events = 1 [(sebuf.http.unwrap) = true];
+}
+
+message AuditEvent {
+  int64 seq = 1;
+  string actor = 2;
+  string detail = 3;
+}
+`
+
+// streamResponseIntegrationTestCode is the test source that runs inside the
+// temp module. The server builds each event as it is yielded, so the memory a
+// listing holds is the server's and client's own, never the whole list.
+const streamResponseIntegrationTestCode = `package stream_response_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gen "stream_response_test/gen"
+)
+
+// detail pads every event so that 50k of them weigh about 15 MB of JSON.
+var detail = strings.Repeat("x", 256)
+
+type auditServer struct {
+	stopped chan error // Receives the yield error that stopped an endless listing
+}
+
+func (s *auditServer) list(ctx context.Context, req *gen.ListEventsRequest, yield func(*gen.AuditEvent) error) error {
+	for i := int64(0); req.GetCount() < 0 || i < int64(req.GetCount()); i++ {
+		if req.GetFailAfter() > 0 && i == int64(req.GetFailAfter()) {
+			return errors.New("audit log unavailable")
+		}
+		if err := yield(&gen.AuditEvent{Seq: i, Actor: fmt.Sprintf("user-%d", i%7), Detail: detail}); err != nil {
+			if s.stopped != nil {
+				s.stopped <- err
+			}
+			return err
+		}
+	}
+	if req.GetFailAfter() > 0 && req.GetFailAfter() >= req.GetCount() {
+		return errors.New("audit log unavailable")
+	}
+	return nil
+}
+
+func (s *auditServer) ListEvents(ctx context.Context, req *gen.ListEventsRequest, yield func(*gen.AuditEvent) error) error {
+	return s.list(ctx, req, yield)
+}
+
+func (s *auditServer) ExportEvents(ctx context.Context, req *gen.ListEventsRequest, yield func(*gen.AuditEvent) error) error {
+	return s.list(ctx, req, yield)
+}
+
+func serve(t *testing.T, server *auditServer) string {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterAuditServiceServer(server, gen.WithMux(mux)); err != nil {
+		t.Fatalf("RegisterAuditServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+// readAll lists count events through the generated client, checking their
+// order, and returns how many it read and the peak heap in use meanwhile.
+func readAll(t *testing.T, client gen.AuditServiceClient, count int32) (int64, uint64) {
+	t.Helper()
+	var peak atomic.Uint64
+	done := make(chan struct{})
+	sampled := make(chan struct{})
+	go func() {
+		defer close(sampled)
+		var stats runtime.MemStats
+		for {
+			runtime.ReadMemStats(&stats)
+			if stats.HeapInuse > peak.Load() {
+				peak.Store(stats.HeapInuse)
+			}
+			select {
+			case <-done:
+				return
+			case <-time.After(time.Millisecond):
+			}
+		}
+	}()
+
+	stream, err := client.ListEvents(context.Background(), &gen.ListEventsRequest{Count: count})
+	if err != nil {
+		t.Fatalf("ListEvents: %v", err)
+	}
+	defer stream.Close()
+	var read int64
+	event := &gen.AuditEvent{}
+	for stream.Next(event) {
+		if event.GetSeq() != read {
+			t.Fatalf("event %d has seq %d", read, event.GetSeq())
+		}
+		read++
+	}
+	close(done)
+	<-sampled
+	if err := stream.Err(); err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	return read, peak.Load()
+}
+
+func TestLargeListInBoundedMemory(t *testing.T) {
+	// Collect eagerly, so the heap in use tracks what is live
+	defer debug.SetGCPercent(debug.SetGCPercent(10))
+	client := gen.NewAuditServiceClient(serve(t, &auditServer{}))
+
+	if read, _ := readAll(t, client, 1000); read != 1000 {
+		t.Fatalf("warm-up read %d events, want 1000", read)
+	}
+	runtime.GC()
+	_, small := readAll(t, client, 1000)
+	runtime.GC()
+	read, large := readAll(t, client, 50000)
+	if read != 50000 {
+		t.Fatalf("read %d events, want 50000", read)
+	}
+	t.Logf("peak heap: %d KB for 1k events, %d KB for 50k events", small>>10, large>>10)
+	// A buffered response would hold 15 MB of JSON, and the events decoded from it.
+	if large > small+4<<20 {
+		t.Errorf("peak heap grew from %d KB for 1k events to %d KB for 50k events", small>>10, large>>10)
+	}
+}
+
+func TestDocumentMatchesBufferedResponse(t *testing.T) {
+	url := serve(t, &auditServer{})
+	for _, tt := range []struct {
+		name, method, path, prefix string
+	}{
+		{name: "keyed", method: http.MethodGet, path: "/events?count=3", prefix: ` + "`" + `{"events":[` + "`" + `},
+		{name: "root unwrap", method: http.MethodPost, path: "/events/export?count=3", prefix: "["},
+		{name: "empty keyed", method: http.MethodGet, path: "/events?count=0", prefix: ` + "`" + `{"events":[]}` + "`" + `},
+		{name: "empty root unwrap", method: http.MethodPost, path: "/events/export?count=0", prefix: "[]"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, url+tt.path, strings.NewReader("{}"))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/json" {
+				t.Fatalf("status %d, Content-Type %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+			}
+			if !strings.HasPrefix(string(body), tt.prefix) || !json.Valid(body) {
+				t.Errorf("body = %s, want a JSON document starting with %s", body, tt.prefix)
+			}
+		})
+	}
+}
+
+func TestRootUnwrapThroughClient(t *testing.T) {
+	client := gen.NewAuditServiceClient(serve(t, &auditServer{}))
+	stream, err := client.ExportEvents(context.Background(), &gen.ListEventsRequest{Count: 5})
+	if err != nil {
+		t.Fatalf("ExportEvents: %v", err)
+	}
+	defer stream.Close()
+	var seqs []int64
+	for event := (&gen.AuditEvent{}); stream.Next(event); {
+		seqs = append(seqs, event.GetSeq())
+	}
+	if stream.Err() != nil || fmt.Sprint(seqs) != "[0 1 2 3 4]" {
+		t.Errorf("read %v, err %v; want [0 1 2 3 4]", seqs, stream.Err())
+	}
+}
+
+func TestErrorBeforeFirstItemIsErrorResponse(t *testing.T) {
+	client := gen.NewAuditServiceClient(serve(t, &auditServer{}))
+	_, err := client.ListEvents(context.Background(), &gen.ListEventsRequest{Count: 0, FailAfter: 1})
+	if err == nil || !strings.Contains(err.Error(), "audit log unavailable") {
+		t.Fatalf("ListEvents error = %v, want the handler's error", err)
+	}
+}
+
+func TestErrorAfterFirstItemCutsResponseShort(t *testing.T) {
+	client := gen.NewAuditServiceClient(serve(t, &auditServer{}))
+	stream, err := client.ListEvents(context.Background(), &gen.ListEventsRequest{Count: 1000, FailAfter: 300})
+	if err != nil {
+		t.Fatalf("ListEvents: %v", err)
+	}
+	defer stream.Close()
+	var read int
+	for event := (&gen.AuditEvent{}); stream.Next(event); {
+		read++
+	}
+	if read != 300 {
+		t.Errorf("read %d events before the failure, want 300", read)
+	}
+	if stream.Err() == nil {
+		t.Error("a response cut short must surface an error, not end the list")
+	}
+}
+
+func TestClientDisconnectStopsHandler(t *testing.T) {
+	server := &auditServer{stopped: make(chan error, 1)}
+	client := gen.NewAuditServiceClient(serve(t, server))
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.ListEvents(ctx, &gen.ListEventsRequest{Count: -1})
+	if err != nil {
+		t.Fatalf("ListEvents: %v", err)
+	}
+	for event, i := (&gen.AuditEvent{}), 0; i < 10 && stream.Next(event); i++ {
+	}
+	cancel()
+	stream.Close()
+	select {
+	case err := <-server.stopped:
+		if err == nil {
+			t.Error("yield stopped the handler without an error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the handler kept listing after the client went away")
+	}
+}
+`
@@ -0,0 +1,70 @@
+package httpgen
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// generateStrictJSONFunctions generates the helpers bindDataFromJSONRequest
+// uses to report the unknown keys of a strict JSON body.
+func (g *Generator) generateStrictJSONFunctions(gf *protogen.GeneratedFile) {
+	gf.P("// unknownJSONFieldsChecker is implemented by messages whose generated UnmarshalJSON")
+	gf.P("// ignores keys it does not read, such as those with unwrap fields.")
+	gf.P("type unknownJSONFieldsChecker interface {")
+	gf.P("UnknownJSONFields(data []byte) []string")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// unknownJSONField returns the key of a protojson error for a key naming no field.")
+	gf.P("func unknownJSONField(err error) (string, bool) {")
+	gf.P(`_, quoted, found := strings.Cut(err.Error(), "unknown field ")`)
+	gf.P("if !found {")
+	gf.P(`return "", false`)
+	gf.P("}")
+	gf.P("key, unquoteErr := strconv.Unquote(quoted)")
+	gf.P("return key, unquoteErr == nil")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// unknownJSONFieldsError reports each unknown key of a strict JSON body as a violation.")
+	gf.P("func unknownJSONFieldsError(keys ...string) *sebufhttp.ValidationError {")
+	gf.P("sort.Strings(keys)")
+	gf.P("violations := make([]*sebufhttp.FieldViolation, 0, len(keys))")
+	gf.P("for _, key := range keys {")
+	gf.P(`violations = append(violations, &sebufhttp.FieldViolation{Field: key, Description: "unknown field"})`)
+	gf.P("}")
+	gf.P("return &sebufhttp.ValidationError{Violations: violations}")
+	gf.P("}")
+	gf.P()
+}
+
+// generateUnknownJSONFieldsMethod generates UnknownJSONFields for a message
+// whose generated UnmarshalJSON silently skips keys other than known, so that
+// strict request binding can reject them.
+func (g *Generator) generateUnknownJSONFieldsMethod(gf *protogen.GeneratedFile, msgName string, known []string) {
+	quoted := make([]string, len(known))
+	for i, key := range known {
+		quoted[i] = strconv.Quote(key)
+	}
+
+	gf.P("// UnknownJSONFields returns the keys of the JSON object data that UnmarshalJSON")
+	gf.P("// does not bind to a field of ", msgName, ", in no particular order.")
+	gf.P("func (x *", msgName, ") UnknownJSONFields(data []byte) []string {")
+	gf.P("var raw map[string]json.RawMessage")
+	gf.P("if err := json.Unmarshal(data, &raw); err != nil {")
+	gf.P("return nil // UnmarshalJSON reports malformed bodies")
+	gf.P("}")
+	gf.P("var unknown []string")
+	gf.P("for key := range raw {")
+	gf.P("switch key {")
+	gf.P("case ", strings.Join(quoted, ", "), ":")
+	gf.P("default:")
+	gf.P("unknown = append(unknown, key)")
+	gf.P("}")
+	gf.P("}")
+	gf.P("return unknown")
+	gf.P("}")
+	gf.P()
+}
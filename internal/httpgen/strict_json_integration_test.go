@@ -0,0 +1,177 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestStrictJSONIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server from a proto with strict_json set on a
+//     service and on a single method,
+//  2. writes a temporary Go module that serves it with httptest,
+//  3. verifies a typo'd key is ignored by default and rejected with a
+//     violation naming it under strict_json and WithStrictJSON, for a plain
+//     request message and for one with a generated unwrap UnmarshalJSON.
+func TestStrictJSONIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "strict_json_test",
+		Protos:  map[string]string{"catalog.proto": strictJSONProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"gen/strict_json_test.go": strictJSONIntegrationTestCode},
+	})
+}
+
+const strictJSONProto = `syntax = "proto3";
+package test.strictjson;
+option go_package = "strict_json_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service CatalogService {
+  option (sebuf.http.service_config) = { base_path: "/lenient" };
+  rpc CreateItem(Item) returns (Item) {
+    option (sebuf.http.config) = { path: "/items" method: HTTP_METHOD_POST };
+  }
+  rpc UpdateItem(Item) returns (Item) {
+    option (sebuf.http.config) = { path: "/items" method: HTTP_METHOD_PUT strict_json: true };
+  }
+  rpc SetPrices(PriceBook) returns (PriceBook) {
+    option (sebuf.http.config) = { path: "/prices" method: HTTP_METHOD_POST };
+  }
+}
+
+service StrictCatalogService {
+  option (sebuf.http.service_config) = { base_path: "/strict" strict_json: true };
+  rpc CreateStrictItem(Item) returns (Item) {
+    option (sebuf.http.config) = { path: "/items" method: HTTP_METHOD_POST };
+  }
+  rpc SetStrictPrices(PriceBook) returns (PriceBook) {
+    option (sebuf.http.config) = { path: "/prices" method: HTTP_METHOD_POST };
+  }
+}
+
+message Item {
+  string name = 1;
+  double price = 2;
+}
+
+message Prices {
+  repeated double values = 1 [(sebuf.http.unwrap) = true];
+}
+
+// PriceBook has a generated UnmarshalJSON for its unwrapped map values
+message PriceBook {
+  map<string, Prices> prices = 1;
+  string currency = 2;
+}
+`
+
+const strictJSONIntegrationTestCode = `package gen
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type catalogServer struct{}
+
+func (catalogServer) CreateItem(_ context.Context, req *Item) (*Item, error)       { return req, nil }
+func (catalogServer) UpdateItem(_ context.Context, req *Item) (*Item, error)       { return req, nil }
+func (catalogServer) CreateStrictItem(_ context.Context, req *Item) (*Item, error) { return req, nil }
+
+func (catalogServer) SetPrices(_ context.Context, req *PriceBook) (*PriceBook, error) { return req, nil }
+
+func (catalogServer) SetStrictPrices(_ context.Context, req *PriceBook) (*PriceBook, error) {
+	return req, nil
+}
+
+func serve(t *testing.T, opts ...ServerOption) string {
+	t.Helper()
+	mux := http.NewServeMux()
+	opts = append(opts, WithMux(mux))
+	if err := RegisterCatalogServiceServer(catalogServer{}, opts...); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterStrictCatalogServiceServer(catalogServer{}, opts...); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func send(t *testing.T, method, url, body string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(respBody)
+}
+
+// compact drops the spaces protojson inserts at random between tokens.
+func compact(s string) string {
+	return strings.ReplaceAll(s, " ", "")
+}
+
+const (
+	typoItem      = ` + "`" + `{"name":"pen","pirce":2.5}` + "`" + `
+	typoPriceBook = ` + "`" + `{"prices":{"pen":[2.5]},"curency":"USD"}` + "`" + `
+)
+
+func TestStrictJSON(t *testing.T) {
+	lenient := serve(t)
+	strict := serve(t, WithStrictJSON())
+
+	tests := []struct {
+		name    string
+		method  string
+		url     string
+		body    string
+		wantKey string // empty when the body must be accepted
+	}{
+		{"plain message by default", http.MethodPost, lenient + "/lenient/items", typoItem, ""},
+		{"unwrap message by default", http.MethodPost, lenient + "/lenient/prices", typoPriceBook, ""},
+		{"plain message with method strict_json", http.MethodPut, lenient + "/lenient/items", typoItem, "pirce"},
+		{"plain message with service strict_json", http.MethodPost, lenient + "/strict/items", typoItem, "pirce"},
+		{"unwrap message with service strict_json", http.MethodPost, lenient + "/strict/prices", typoPriceBook, "curency"},
+		{"plain message with WithStrictJSON", http.MethodPost, strict + "/lenient/items", typoItem, "pirce"},
+		{"unwrap message with WithStrictJSON", http.MethodPost, strict + "/lenient/prices", typoPriceBook, "curency"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := send(t, tt.method, tt.url, tt.body)
+			if tt.wantKey == "" {
+				if status != http.StatusOK {
+					t.Fatalf("status = %d %s, want 200", status, body)
+				}
+				return
+			}
+			want := ` + "`" + `{"field":"` + "`" + ` + tt.wantKey + ` + "`" + `","description":"unknown field"}` + "`" + `
+			if status != http.StatusBadRequest || !strings.Contains(compact(body), compact(want)) {
+				t.Fatalf("got %d %s, want 400 with %s", status, body, want)
+			}
+		})
+	}
+
+	// Known keys are still accepted in strict mode
+	status, body := send(t, http.MethodPost, strict+"/strict/prices", ` + "`" + `{"prices":{"pen":[2.5]},"currency":"USD"}` + "`" + `)
+	if status != http.StatusOK || !strings.Contains(body, "USD") {
+		t.Fatalf("got %d %s, want 200 echoing the price book", status, body)
+	}
+}
+`
@@ -0,0 +1,479 @@
+package httpgen
+
+import (
+	"bytes"
+	"slices"
+	"strconv"
+	"strings"
+
+	validate "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// scaffoldBuildTag is the build tag the test scaffold is compiled under, so it
+// only joins the package when tests ask for it.
+const scaffoldBuildTag = "sebuf_scaffold"
+
+// scaffoldViolation is the change the negative scaffold test makes to the
+// example body: the member of field is replaced by value, or removed.
+type scaffoldViolation struct {
+	field  *protogen.Field
+	value  any
+	remove bool
+}
+
+// generateTestScaffoldFile generates <file>_http_test.scaffold.go, the test
+// helpers of every method of file's services, built from the same example
+// requests as the mock artifacts.
+func (g *Generator) generateTestScaffoldFile(file *protogen.File) error {
+	if len(file.Services) == 0 {
+		return nil
+	}
+	filename := file.GeneratedFilenamePrefix + "_http_test.scaffold.go"
+	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
+
+	gf.P("//go:build ", scaffoldBuildTag)
+	gf.P()
+	g.writeHeader(gf, file)
+
+	gf.P("import (")
+	gf.P(`"encoding/json"`)
+	gf.P(`"io"`)
+	gf.P(`"net/http"`)
+	gf.P(`"net/http/httptest"`)
+	gf.P(`"strings"`)
+	gf.P(`"testing"`)
+	gf.P()
+	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
+	gf.P(`"google.golang.org/protobuf/proto"`)
+	gf.P()
+	g.generateRouterImport(gf)
+	gf.P(`sebufhttp "github.com/SebastienMelki/sebuf/http"`)
+	gf.P(")")
+	gf.P()
+
+	calls := g.mockCalls(file)
+	for _, service := range file.Services {
+		var serviceCalls []mockCall
+		for _, call := range calls {
+			if call.service == service {
+				serviceCalls = append(serviceCalls, call)
+			}
+		}
+		if err := g.generateServiceScaffold(gf, service, serviceCalls); err != nil {
+			return err
+		}
+	}
+	g.generateScaffoldHelpers(gf)
+	return nil
+}
+
+// generateServiceScaffold generates the factory type of a service, its
+// Run<Service>Scaffold function and the helpers of each method.
+func (g *Generator) generateServiceScaffold(
+	gf *protogen.GeneratedFile,
+	service *protogen.Service,
+	calls []mockCall,
+) error {
+	factory := service.GoName + "ScaffoldFactory"
+	gf.P("// ", factory, " returns the ", service.GoName, "Server a scaffold test serves. It is")
+	gf.P("// called once per test, so every test starts from a fresh implementation.")
+	gf.P("type ", factory, " func(t *testing.T) ", service.GoName, "Server")
+	gf.P()
+
+	gf.P("// Run", service.GoName, "Scaffold runs the scaffold test of every ", service.GoName, " method,")
+	gf.P("// and the negative test of the methods whose example body can break a rule.")
+	gf.P("func Run", service.GoName, "Scaffold(t *testing.T, newServer ", factory, ") {")
+	gf.P("t.Helper()")
+	violations := make([]*scaffoldViolation, len(calls))
+	for i, call := range calls {
+		violations[i] = scaffoldBodyViolation(call)
+		name := "Scaffold" + service.GoName + call.method.GoName
+		gf.P(`t.Run("`, call.method.GoName, `", func(t *testing.T) { `, name, "(t, newServer) })")
+		if violations[i] != nil {
+			gf.P(`t.Run("`, call.method.GoName, `Invalid", func(t *testing.T) { `, name, "Invalid(t, newServer) })")
+		}
+	}
+	gf.P("}")
+	gf.P()
+
+	gf.P("// serve", service.GoName, "Scaffold serves the server newServer returns with httptest")
+	gf.P("// and returns its URL.")
+	gf.P("func serve", service.GoName, "Scaffold(t *testing.T, newServer ", factory, ") string {")
+	gf.P("t.Helper()")
+	register := g.registerFuncName(service)
+	router := "router"
+	switch g.router {
+	case RouterChi:
+		gf.P("router := chi.NewRouter()")
+		gf.P("if err := ", register, "(router, newServer(t)); err != nil {")
+	case RouterGorilla:
+		gf.P("router := mux.NewRouter()")
+		gf.P("if err := ", register, "(router, newServer(t)); err != nil {")
+	default:
+		router = "mux"
+		gf.P("mux := http.NewServeMux()")
+		gf.P("if err := ", register, "(newServer(t), WithMux(mux)); err != nil {")
+	}
+	gf.P(`t.Fatalf("`, register, `: %v", err)`)
+	gf.P("}")
+	gf.P("srv := httptest.NewServer(", router, ")")
+	gf.P("t.Cleanup(srv.Close)")
+	gf.P("return srv.URL")
+	gf.P("}")
+	gf.P()
+
+	for i, call := range calls {
+		if err := g.generateMethodScaffold(gf, call, violations[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateMethodScaffold generates the request builder of a method, its
+// scaffold test and, with a violation, its negative test.
+func (g *Generator) generateMethodScaffold(
+	gf *protogen.GeneratedFile,
+	call mockCall,
+	violation *scaffoldViolation,
+) error {
+	service, method := call.service, call.method
+	name := service.GoName + method.GoName
+	factory := service.GoName + "ScaffoldFactory"
+
+	body, err := scaffoldBodyLiteral(call.body)
+	if err != nil {
+		return err
+	}
+	if call.body != nil {
+		gf.P("// ", name, "ScaffoldBody is the example body of ", method.GoName, ".")
+		gf.P("const ", name, "ScaffoldBody = ", body)
+		gf.P()
+	}
+
+	gf.P("// New", name, "ScaffoldRequest returns the example request of ", method.GoName, " sent to")
+	gf.P("// baseURL: path and query values from the field examples, and the required headers.")
+	if call.body != nil {
+		gf.P("func New", name, "ScaffoldRequest(baseURL, body string) (*http.Request, error) {")
+		gf.P("req, err := http.NewRequest(", strconv.Quote(call.httpMethod), ", baseURL+",
+			strconv.Quote(call.url), ", strings.NewReader(body))")
+	} else {
+		gf.P("func New", name, "ScaffoldRequest(baseURL string) (*http.Request, error) {")
+		gf.P("req, err := http.NewRequest(", strconv.Quote(call.httpMethod), ", baseURL+",
+			strconv.Quote(call.url), ", nil)")
+	}
+	gf.P("if err != nil {")
+	gf.P("return nil, err")
+	gf.P("}")
+	if call.body != nil {
+		gf.P(`req.Header.Set("Content-Type", "application/json")`)
+	}
+	if call.sse {
+		gf.P(`req.Header.Set("Accept", "text/event-stream")`)
+	}
+	if g.isIdempotentMethod(method) {
+		gf.P(`req.Header.Set(sebufhttp.IdempotencyKeyHeader, "scaffold-`, annotations.LowerFirst(method.GoName), `")`)
+	}
+	for _, param := range call.params {
+		if param.in == "header" && param.send {
+			gf.P("req.Header.Set(", strconv.Quote(param.name), ", ", strconv.Quote(param.value), ")")
+		}
+	}
+	gf.P("return req, nil")
+	gf.P("}")
+	gf.P()
+
+	newRequest := "New" + name + "ScaffoldRequest(baseURL)"
+	if call.body != nil {
+		newRequest = "New" + name + "ScaffoldRequest(baseURL, " + name + "ScaffoldBody)"
+	}
+	g.generateScaffoldTest(gf, call, newRequest)
+
+	if violation == nil {
+		return nil
+	}
+	invalid, err := scaffoldBodyLiteral(violation.apply(call.body.(jsonObject)))
+	if err != nil {
+		return err
+	}
+	fieldName := string(violation.field.Desc.Name())
+	gf.P("// Scaffold", name, "Invalid sends the example request of ", method.GoName, " with a")
+	gf.P("// body whose ", fieldName, " field breaks its rules, and checks it is rejected with a")
+	gf.P("// 400 naming the field.")
+	gf.P("func Scaffold", name, "Invalid(t *testing.T, newServer ", factory, ") {")
+	gf.P("t.Helper()")
+	gf.P("baseURL := serve", service.GoName, "Scaffold(t, newServer)")
+	gf.P("req, err := New", name, "ScaffoldRequest(baseURL, ", invalid, ")")
+	gf.P("if err != nil {")
+	gf.P(`t.Fatalf("New`, name, `ScaffoldRequest: %v", err)`)
+	gf.P("}")
+	gf.P("status, body := doScaffoldRequest(t, req)")
+	gf.P("expectScaffoldViolation(t, status, body, ", strconv.Quote(fieldName), ")")
+	gf.P("}")
+	gf.P()
+	return nil
+}
+
+// generateScaffoldTest generates Scaffold<Service><Method>, which sends the
+// example request and decodes the response. Streaming methods return the raw
+// body; methods returning a result message decode the variant the status
+// selects.
+func (g *Generator) generateScaffoldTest(gf *protogen.GeneratedFile, call mockCall, newRequest string) {
+	service, method := call.service, call.method
+	name := service.GoName + method.GoName
+	streaming := call.sse || annotations.IsStreamResponse(method)
+	variants, _ := annotations.GetResponseVariants(method.Output)
+
+	gf.P("// Scaffold", name, " serves the server newServer returns, sends it the example")
+	switch {
+	case streaming:
+		gf.P("// request of ", method.GoName, " and checks it is answered with a 2xx status. It returns")
+		gf.P("// the streamed body.")
+		gf.P("func Scaffold", name, "(t *testing.T, newServer ", service.GoName, "ScaffoldFactory) []byte {")
+	case len(variants) > 0:
+		gf.P("// request of ", method.GoName, " and checks its status selects a variant. It returns")
+		gf.P("// the response with that variant set.")
+		gf.P("func Scaffold", name, "(t *testing.T, newServer ", service.GoName, "ScaffoldFactory) *",
+			method.Output.GoIdent, " {")
+	default:
+		gf.P("// request of ", method.GoName, " and checks it is answered with a 2xx status. It returns")
+		gf.P("// the decoded response.")
+		gf.P("func Scaffold", name, "(t *testing.T, newServer ", service.GoName, "ScaffoldFactory) *",
+			method.Output.GoIdent, " {")
+	}
+	gf.P("t.Helper()")
+	gf.P("baseURL := serve", service.GoName, "Scaffold(t, newServer)")
+	gf.P("req, err := ", newRequest)
+	gf.P("if err != nil {")
+	gf.P(`t.Fatalf("New`, name, `ScaffoldRequest: %v", err)`)
+	gf.P("}")
+	gf.P("status, body := doScaffoldRequest(t, req)")
+
+	if len(variants) > 0 {
+		gf.P("response := &", method.Output.GoIdent, "{}")
+		gf.P("switch status {")
+		for _, variant := range variants {
+			field := variant.Field
+			gf.P("case ", variant.Status, ":")
+			gf.P("variant := &", field.Message.GoIdent, "{}")
+			gf.P("decodeScaffoldResponse(t, body, variant)")
+			gf.P("response.", field.Oneof.GoName, " = &", field.GoIdent, "{", field.GoName, ": variant}")
+		}
+		gf.P("default:")
+		gf.P(`t.Fatalf("`, method.GoName, `: status %d selects no variant: %s", status, body)`)
+		gf.P("}")
+		gf.P("return response")
+		gf.P("}")
+		gf.P()
+		return
+	}
+
+	gf.P("if status < 200 || status > 299 {")
+	gf.P(`t.Fatalf("`, method.GoName, `: status %d, want 2xx: %s", status, body)`)
+	gf.P("}")
+	if streaming {
+		gf.P("return body")
+	} else {
+		gf.P("response := &", method.Output.GoIdent, "{}")
+		gf.P("decodeScaffoldResponse(t, body, response)")
+		gf.P("return response")
+	}
+	gf.P("}")
+	gf.P()
+}
+
+// generateScaffoldHelpers generates the functions shared by the scaffold
+// tests of a file.
+func (g *Generator) generateScaffoldHelpers(gf *protogen.GeneratedFile) {
+	gf.P("// doScaffoldRequest sends req and returns the status and body of its response.")
+	gf.P("func doScaffoldRequest(t *testing.T, req *http.Request) (int, []byte) {")
+	gf.P("t.Helper()")
+	gf.P("resp, err := http.DefaultClient.Do(req)")
+	gf.P("if err != nil {")
+	gf.P(`t.Fatalf("%s %s: %v", req.Method, req.URL, err)`)
+	gf.P("}")
+	gf.P("defer resp.Body.Close()")
+	gf.P("body, err := io.ReadAll(resp.Body)")
+	gf.P("if err != nil {")
+	gf.P(`t.Fatalf("reading the response of %s %s: %v", req.Method, req.URL, err)`)
+	gf.P("}")
+	gf.P("return resp.StatusCode, body")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// decodeScaffoldResponse decodes a response body into msg, with the message's own")
+	gf.P("// JSON decoding when its encoding annotations give it one.")
+	gf.P("func decodeScaffoldResponse(t *testing.T, body []byte, msg proto.Message) {")
+	gf.P("t.Helper()")
+	gf.P("var err error")
+	gf.P("if unmarshaler, ok := msg.(json.Unmarshaler); ok {")
+	gf.P("err = unmarshaler.UnmarshalJSON(body)")
+	gf.P("} else {")
+	gf.P("err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(body, msg)")
+	gf.P("}")
+	gf.P("if err != nil {")
+	gf.P(`t.Fatalf("decoding %T: %v: %s", msg, err, body)`)
+	gf.P("}")
+	gf.P("}")
+	gf.P()
+
+	gf.P("// expectScaffoldViolation checks a response is a 400 whose validation error has a")
+	gf.P("// violation of field.")
+	gf.P("func expectScaffoldViolation(t *testing.T, status int, body []byte, field string) {")
+	gf.P("t.Helper()")
+	gf.P("if status != http.StatusBadRequest {")
+	gf.P(`t.Fatalf("status %d, want 400 for a violation of %s: %s", status, field, body)`)
+	gf.P("}")
+	gf.P("validationErr := &sebufhttp.ValidationError{}")
+	gf.P("if err := protojson.Unmarshal(body, validationErr); err != nil {")
+	gf.P(`t.Fatalf("decoding the validation error: %v: %s", err, body)`)
+	gf.P("}")
+	gf.P("for _, violation := range validationErr.GetViolations() {")
+	gf.P("if violation.GetField() == field {")
+	gf.P("return")
+	gf.P("}")
+	gf.P("}")
+	gf.P(`t.Errorf("violations %v, want one of %s", validationErr.GetViolations(), field)`)
+	gf.P("}")
+}
+
+// scaffoldBodyLiteral returns the Go string literal of a body's compact JSON,
+// raw unless the JSON holds a backquote, or "" without a body.
+func scaffoldBodyLiteral(body any) (string, error) {
+	if body == nil {
+		return "", nil
+	}
+	var buf bytes.Buffer
+	if err := encodeJSON(&buf, body); err != nil {
+		return "", err
+	}
+	if strings.Contains(buf.String(), "`") {
+		return strconv.Quote(buf.String()), nil
+	}
+	return "`" + buf.String() + "`", nil
+}
+
+// scaffoldBodyViolation returns the change to a call's example body that
+// breaks the rules of its first body field with a rule the scaffold knows how
+// to break, or nil if there is none. Flattened, oneof and ignored fields are
+// left alone, as are root unwrap bodies.
+func scaffoldBodyViolation(call mockCall) *scaffoldViolation {
+	if _, ok := call.body.(jsonObject); !ok {
+		return nil
+	}
+	for _, field := range call.method.Input.Fields {
+		if call.bound[field] || annotations.IsBodyExcluded(field) || annotations.IsFlattenField(field) ||
+			field.Oneof != nil && !field.Oneof.Desc.IsSynthetic() {
+			continue
+		}
+		rules := annotations.GetFieldValidationRules(field.Desc)
+		if rules == nil || rules.GetIgnore() != validate.Ignore_IGNORE_UNSPECIFIED {
+			continue
+		}
+		if rules.GetRequired() {
+			return &scaffoldViolation{field: field, remove: true}
+		}
+		if value, ok := scaffoldRuleViolation(field, rules); ok {
+			return &scaffoldViolation{field: field, value: value}
+		}
+	}
+	return nil
+}
+
+// scaffoldRuleViolation returns a JSON value of field that breaks one of its
+// rules: a repeated field below its minimum item count, a string outside its
+// length bounds or not the well-known format it must have, or a number on the
+// wrong side of a bound.
+func scaffoldRuleViolation(field *protogen.Field, rules *validate.FieldRules) (any, bool) {
+	if field.Desc.IsList() {
+		if rules.GetRepeated().GetMinItems() > 0 {
+			return []any{}, true
+		}
+		return nil, false
+	}
+	if field.Desc.IsMap() || field.Message != nil {
+		return nil, false
+	}
+	if field.Desc.Kind() == protoreflect.StringKind {
+		stringRules := rules.GetString()
+		switch {
+		case stringRules.HasMinLen() && stringRules.GetMinLen() > 0:
+			return strings.Repeat("a", int(stringRules.GetMinLen())-1), true
+		case stringRules.HasMaxLen():
+			return strings.Repeat("a", int(stringRules.GetMaxLen())+1), true
+		case stringRules.HasLen():
+			return strings.Repeat("a", int(stringRules.GetLen())+1), true
+		case stringRules.GetEmail(), stringRules.GetUuid():
+			return "not valid", true
+		}
+		return nil, false
+	}
+	return scaffoldNumberViolation(rules)
+}
+
+// scaffoldNumberViolation returns a number breaking the first of the gt, gte,
+// lt, lte and const rules of a numeric field. The rules of every numeric kind
+// share these names, so they are read by reflection.
+func scaffoldNumberViolation(rules *validate.FieldRules) (any, bool) {
+	m := rules.ProtoReflect()
+	kindField := m.WhichOneof(m.Descriptor().Oneofs().ByName("type"))
+	if kindField == nil || kindField.Message() == nil {
+		return nil, false
+	}
+	kindRules := m.Get(kindField).Message()
+	for _, bound := range []struct {
+		name  protoreflect.Name
+		delta int64
+	}{{"gt", 0}, {"gte", -1}, {"lt", 0}, {"lte", 1}, {"const", 1}} {
+		field := kindRules.Descriptor().Fields().ByName(bound.name)
+		if field == nil || !kindRules.Has(field) {
+			continue
+		}
+		switch v := kindRules.Get(field).Interface().(type) {
+		case int32:
+			return int64(v) + bound.delta, true
+		case int64:
+			return v + bound.delta, true
+		case uint32:
+			if int64(v)+bound.delta >= 0 {
+				return int64(v) + bound.delta, true
+			}
+		case uint64:
+			switch {
+			case bound.delta > 0:
+				return v + 1, true
+			case bound.delta == 0:
+				return v, true
+			case v > 0:
+				return v - 1, true
+			}
+		case float32:
+			return float64(v) + float64(bound.delta), true
+		case float64:
+			return v + float64(bound.delta), true
+		}
+	}
+	return nil, false
+}
+
+// apply returns a copy of body with the violation made. A replaced member keeps
+// its place; a member the example leaves out is added at the end.
+func (v *scaffoldViolation) apply(body jsonObject) jsonObject {
+	key := annotations.JSONFieldName(v.field)
+	invalid := slices.Clone(body)
+	i := slices.IndexFunc(invalid, func(member jsonMember) bool { return member.key == key })
+	switch {
+	case v.remove && i >= 0:
+		return slices.Delete(invalid, i, i+1)
+	case v.remove:
+		return invalid
+	case i >= 0:
+		invalid[i].value = v.value
+		return invalid
+	}
+	return append(invalid, jsonMember{key, v.value})
+}
@@ -0,0 +1,132 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestTestScaffoldIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server with generate_tests=true from a proto with a
+//     required header, a path parameter, a body and a result message,
+//  2. writes a temporary Go module whose test runs the scaffold against a
+//     small implementation, under the sebuf_scaffold tag,
+//  3. verifies the scaffold sends the example requests with their headers and
+//     decodes the responses, and that the package builds without the tag.
+func TestTestScaffoldIntegration(t *testing.T) {
+	dir := plugintest.Generate(t, plugintest.Module{
+		Path:    "test_scaffold_test",
+		Protos:  map[string]string{"orders.proto": testScaffoldProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http", Opt: "generate_tests=true"}},
+		Files:   map[string]string{"scaffold_test.go": testScaffoldIntegrationTestCode},
+	})
+	plugintest.GoCommand(t, dir, "vet", "./gen/")
+	plugintest.GoTest(t, dir, "-tags", "sebuf_scaffold")
+}
+
+const testScaffoldProto = `syntax = "proto3";
+package test.scaffold;
+option go_package = "test_scaffold_test/gen;gen";
+import "sebuf/http/annotations.proto";
+import "sebuf/http/headers.proto";
+
+service OrderService {
+  option (sebuf.http.service_config) = { base_path: "/api" };
+  option (sebuf.http.service_headers) = {
+    required_headers: [
+      { name: "X-Tenant" type: "string" example: "acme" required: true }
+    ]
+  };
+  rpc GetOrder(GetOrderRequest) returns (Order) {
+    option (sebuf.http.config) = { path: "/orders/{id}" method: HTTP_METHOD_GET };
+  }
+  rpc CreateOrder(CreateOrderRequest) returns (CreateOrderResult) {
+    option (sebuf.http.config) = { path: "/orders" };
+  }
+}
+
+message GetOrderRequest {
+  string id = 1 [(sebuf.http.field_examples) = { values: ["o-1"] }];
+}
+
+message CreateOrderRequest {
+  string item = 1 [(sebuf.http.field_examples) = { values: ["book"] }];
+  int32 quantity = 2 [(sebuf.http.field_examples) = { values: ["3"] }];
+}
+
+message Order {
+  string id = 1;
+  string item = 2;
+  int32 quantity = 3;
+}
+
+message Conflict {
+  string existing_id = 1;
+}
+
+message CreateOrderResult {
+  oneof result {
+    option (sebuf.http.response_statuses) = {
+      statuses: { key: "order" value: 201 }
+      statuses: { key: "conflict" value: 409 }
+    };
+    Order order = 1;
+    Conflict conflict = 2;
+  }
+}
+`
+
+// testScaffoldIntegrationTestCode is the test source that runs inside the temp
+// module. The implementation echoes the example requests.
+const testScaffoldIntegrationTestCode = `//go:build sebuf_scaffold
+
+package scaffold_test
+
+import (
+	"context"
+	"testing"
+
+	gen "test_scaffold_test/gen"
+)
+
+type orderServer struct{}
+
+func (orderServer) GetOrder(_ context.Context, req *gen.GetOrderRequest) (*gen.Order, error) {
+	return &gen.Order{Id: req.GetId()}, nil
+}
+
+func (orderServer) CreateOrder(_ context.Context, req *gen.CreateOrderRequest) (*gen.CreateOrderResult, error) {
+	return &gen.CreateOrderResult{Result: &gen.CreateOrderResult_Order{Order: &gen.Order{
+		Id: "o-2", Item: req.GetItem(), Quantity: req.GetQuantity(),
+	}}}, nil
+}
+
+func newServer(*testing.T) gen.OrderServiceServer { return orderServer{} }
+
+func TestScaffold(t *testing.T) {
+	gen.RunOrderServiceScaffold(t, newServer)
+}
+
+func TestScaffoldDecodesResponses(t *testing.T) {
+	if order := gen.ScaffoldOrderServiceGetOrder(t, newServer); order.GetId() != "o-1" {
+		t.Errorf("GetOrder = %v, want the order of the example path value", order)
+	}
+	created := gen.ScaffoldOrderServiceCreateOrder(t, newServer).GetOrder()
+	if created.GetItem() != "book" || created.GetQuantity() != 3 {
+		t.Errorf("CreateOrder = %v, want the order of the example body", created)
+	}
+}
+
+func TestScaffoldRequestSendsRequiredHeaders(t *testing.T) {
+	req, err := gen.NewOrderServiceGetOrderScaffoldRequest("http://localhost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := req.Header.Get("X-Tenant"); got != "acme" {
+		t.Errorf("X-Tenant = %q, want the header's example", got)
+	}
+	if req.URL.Path != "/api/orders/o-1" {
+		t.Errorf("path = %q, want the example path value", req.URL.Path)
+	}
+}
+`
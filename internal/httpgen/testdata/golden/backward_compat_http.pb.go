@@ -5,6 +5,7 @@ package generated
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -18,30 +19,143 @@ type NoAnnotationsServiceServer interface {
 // RegisterNoAnnotationsServiceServer registers the HTTP handlers for service NoAnnotationsService to the given mux.
 func RegisterNoAnnotationsServiceServer(server NoAnnotationsServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingNoAnnotationsServiceServer{slot: registeredNoAnnotationsServiceServers.Add(server)}
 
 	serviceHeaders := getNoAnnotationsServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getSimpleActionHeaders()
 	simpleActionHandler := BindingMiddleware[SimpleRequest](
-		genericHandler(server.SimpleAction, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		simpleActionPathParams, simpleActionQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.SimpleAction, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		simpleActionPathParams, simpleActionQueryParams, simpleActionHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	simpleActionHandler = sebufhttp.MetricsMiddleware(simpleActionHandler, config.metrics, "test.httpgen.compat.NoAnnotationsService.SimpleAction")
+	simpleActionHandler = sebufhttp.ResponseHeadersMiddleware(simpleActionHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /generated/simple_action", simpleActionHandler)
 
 	methodHeaders = getAnotherActionHeaders()
 	anotherActionHandler := BindingMiddleware[AnotherRequest](
-		genericHandler(server.AnotherAction, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		anotherActionPathParams, anotherActionQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.AnotherAction, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		anotherActionPathParams, anotherActionQueryParams, anotherActionHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	anotherActionHandler = sebufhttp.MetricsMiddleware(anotherActionHandler, config.metrics, "test.httpgen.compat.NoAnnotationsService.AnotherAction")
+	anotherActionHandler = sebufhttp.ResponseHeadersMiddleware(anotherActionHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /generated/another_action", anotherActionHandler)
 
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, noAnnotationsServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
+
 	return nil
 }
 
+// Paths of the routes RegisterNoAnnotationsServiceServer registers.
+const (
+	NoAnnotationsServicePathSimpleAction  = "/generated/simple_action"
+	NoAnnotationsServicePathAnotherAction = "/generated/another_action"
+)
+
+// NoAnnotationsServiceServerRoutes returns the routes RegisterNoAnnotationsServiceServer registers.
+func NoAnnotationsServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), noAnnotationsServiceRouteInfos...)
+}
+
+// noAnnotationsServiceRouteInfos lists the routes RegisterNoAnnotationsServiceServer registers.
+var noAnnotationsServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "POST", Path: NoAnnotationsServicePathSimpleAction, Service: "test.httpgen.compat.NoAnnotationsService", RPC: "SimpleAction"},
+	{Method: "POST", Path: NoAnnotationsServicePathAnotherAction, Service: "test.httpgen.compat.NoAnnotationsService", RPC: "AnotherAction"},
+}
+
+// registeredNoAnnotationsServiceServers holds the implementation of every NoAnnotationsService registration.
+var registeredNoAnnotationsServiceServers sebufhttp.ServerSlots[NoAnnotationsServiceServer]
+
+// UpdateNoAnnotationsServiceServer makes every handler registered by RegisterNoAnnotationsServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateNoAnnotationsServiceServer(server NoAnnotationsServiceServer) {
+	registeredNoAnnotationsServiceServers.Store(server)
+}
+
+// UnregisterNoAnnotationsServiceServer detaches the implementation from every handler
+// registered by RegisterNoAnnotationsServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateNoAnnotationsServiceServer installs a new implementation.
+func UnregisterNoAnnotationsServiceServer() {
+	registeredNoAnnotationsServiceServers.Clear()
+}
+
+// dispatchingNoAnnotationsServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingNoAnnotationsServiceServer struct {
+	slot *sebufhttp.ServerSlot[NoAnnotationsServiceServer]
+}
+
+func (d dispatchingNoAnnotationsServiceServer) SimpleAction(ctx context.Context, req *SimpleRequest) (*SimpleResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service NoAnnotationsService is not registered"}
+	}
+	return server.SimpleAction(ctx, req)
+}
+
+func (d dispatchingNoAnnotationsServiceServer) AnotherAction(ctx context.Context, req *AnotherRequest) (*AnotherResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service NoAnnotationsService is not registered"}
+	}
+	return server.AnotherAction(ctx, req)
+}
+
+// UnimplementedNoAnnotationsServiceServer can be embedded in NoAnnotationsServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedNoAnnotationsServiceServer struct{}
+
+func (UnimplementedNoAnnotationsServiceServer) SimpleAction(context.Context, *SimpleRequest) (*SimpleResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method SimpleAction not implemented"}
+}
+
+func (UnimplementedNoAnnotationsServiceServer) AnotherAction(context.Context, *AnotherRequest) (*AnotherResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method AnotherAction not implemented"}
+}
+
+// DecodeSimpleActionRequest binds r to a SimpleRequest as the SimpleAction handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterNoAnnotationsServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeSimpleActionRequest(r *http.Request) (*SimpleRequest, error) {
+	req := new(SimpleRequest)
+	err := bindRequest(nil, r, req, simpleActionPathParams, simpleActionQueryParams, simpleActionHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeAnotherActionRequest binds r to a AnotherRequest as the AnotherAction handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterNoAnnotationsServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeAnotherActionRequest(r *http.Request) (*AnotherRequest, error) {
+	req := new(AnotherRequest)
+	err := bindRequest(nil, r, req, anotherActionPathParams, anotherActionQueryParams, anotherActionHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getNoAnnotationsServiceHeaders returns the service-level required headers for NoAnnotationsService
 func getNoAnnotationsServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -63,12 +177,18 @@ var simpleActionPathParams = []PathParamConfig{}
 // simpleActionQueryParams contains query parameter configuration for SimpleAction
 var simpleActionQueryParams = []QueryParamConfig{}
 
+// simpleActionHeaderFieldParams contains header-sourced field configuration for SimpleAction
+var simpleActionHeaderFieldParams = []HeaderParamConfig{}
+
 // anotherActionPathParams contains path parameter configuration for AnotherAction
 var anotherActionPathParams = []PathParamConfig{}
 
 // anotherActionQueryParams contains query parameter configuration for AnotherAction
 var anotherActionQueryParams = []QueryParamConfig{}
 
+// anotherActionHeaderFieldParams contains header-sourced field configuration for AnotherAction
+var anotherActionHeaderFieldParams = []HeaderParamConfig{}
+
 // BasePathOnlyServiceServer is the server API for BasePathOnlyService service.
 type BasePathOnlyServiceServer interface {
 	ActionOne(context.Context, *ActionRequest) (*ActionResponse, error)
@@ -78,30 +198,143 @@ type BasePathOnlyServiceServer interface {
 // RegisterBasePathOnlyServiceServer registers the HTTP handlers for service BasePathOnlyService to the given mux.
 func RegisterBasePathOnlyServiceServer(server BasePathOnlyServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingBasePathOnlyServiceServer{slot: registeredBasePathOnlyServiceServers.Add(server)}
 
 	serviceHeaders := getBasePathOnlyServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getActionOneHeaders()
 	actionOneHandler := BindingMiddleware[ActionRequest](
-		genericHandler(server.ActionOne, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		actionOnePathParams, actionOneQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.ActionOne, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		actionOnePathParams, actionOneQueryParams, actionOneHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	actionOneHandler = sebufhttp.MetricsMiddleware(actionOneHandler, config.metrics, "test.httpgen.compat.BasePathOnlyService.ActionOne")
+	actionOneHandler = sebufhttp.ResponseHeadersMiddleware(actionOneHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v2/action_one", actionOneHandler)
 
 	methodHeaders = getActionTwoHeaders()
 	actionTwoHandler := BindingMiddleware[ActionRequest](
-		genericHandler(server.ActionTwo, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		actionTwoPathParams, actionTwoQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.ActionTwo, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		actionTwoPathParams, actionTwoQueryParams, actionTwoHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	actionTwoHandler = sebufhttp.MetricsMiddleware(actionTwoHandler, config.metrics, "test.httpgen.compat.BasePathOnlyService.ActionTwo")
+	actionTwoHandler = sebufhttp.ResponseHeadersMiddleware(actionTwoHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v2/action_two", actionTwoHandler)
 
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, basePathOnlyServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
+
 	return nil
 }
 
+// Paths of the routes RegisterBasePathOnlyServiceServer registers.
+const (
+	BasePathOnlyServicePathActionOne = "/api/v2/action_one"
+	BasePathOnlyServicePathActionTwo = "/api/v2/action_two"
+)
+
+// BasePathOnlyServiceServerRoutes returns the routes RegisterBasePathOnlyServiceServer registers.
+func BasePathOnlyServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), basePathOnlyServiceRouteInfos...)
+}
+
+// basePathOnlyServiceRouteInfos lists the routes RegisterBasePathOnlyServiceServer registers.
+var basePathOnlyServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "POST", Path: BasePathOnlyServicePathActionOne, Service: "test.httpgen.compat.BasePathOnlyService", RPC: "ActionOne"},
+	{Method: "POST", Path: BasePathOnlyServicePathActionTwo, Service: "test.httpgen.compat.BasePathOnlyService", RPC: "ActionTwo"},
+}
+
+// registeredBasePathOnlyServiceServers holds the implementation of every BasePathOnlyService registration.
+var registeredBasePathOnlyServiceServers sebufhttp.ServerSlots[BasePathOnlyServiceServer]
+
+// UpdateBasePathOnlyServiceServer makes every handler registered by RegisterBasePathOnlyServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateBasePathOnlyServiceServer(server BasePathOnlyServiceServer) {
+	registeredBasePathOnlyServiceServers.Store(server)
+}
+
+// UnregisterBasePathOnlyServiceServer detaches the implementation from every handler
+// registered by RegisterBasePathOnlyServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateBasePathOnlyServiceServer installs a new implementation.
+func UnregisterBasePathOnlyServiceServer() {
+	registeredBasePathOnlyServiceServers.Clear()
+}
+
+// dispatchingBasePathOnlyServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingBasePathOnlyServiceServer struct {
+	slot *sebufhttp.ServerSlot[BasePathOnlyServiceServer]
+}
+
+func (d dispatchingBasePathOnlyServiceServer) ActionOne(ctx context.Context, req *ActionRequest) (*ActionResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service BasePathOnlyService is not registered"}
+	}
+	return server.ActionOne(ctx, req)
+}
+
+func (d dispatchingBasePathOnlyServiceServer) ActionTwo(ctx context.Context, req *ActionRequest) (*ActionResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service BasePathOnlyService is not registered"}
+	}
+	return server.ActionTwo(ctx, req)
+}
+
+// UnimplementedBasePathOnlyServiceServer can be embedded in BasePathOnlyServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedBasePathOnlyServiceServer struct{}
+
+func (UnimplementedBasePathOnlyServiceServer) ActionOne(context.Context, *ActionRequest) (*ActionResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method ActionOne not implemented"}
+}
+
+func (UnimplementedBasePathOnlyServiceServer) ActionTwo(context.Context, *ActionRequest) (*ActionResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method ActionTwo not implemented"}
+}
+
+// DecodeActionOneRequest binds r to a ActionRequest as the ActionOne handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterBasePathOnlyServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeActionOneRequest(r *http.Request) (*ActionRequest, error) {
+	req := new(ActionRequest)
+	err := bindRequest(nil, r, req, actionOnePathParams, actionOneQueryParams, actionOneHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeActionTwoRequest binds r to a ActionRequest as the ActionTwo handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterBasePathOnlyServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeActionTwoRequest(r *http.Request) (*ActionRequest, error) {
+	req := new(ActionRequest)
+	err := bindRequest(nil, r, req, actionTwoPathParams, actionTwoQueryParams, actionTwoHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getBasePathOnlyServiceHeaders returns the service-level required headers for BasePathOnlyService
 func getBasePathOnlyServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -123,8 +356,14 @@ var actionOnePathParams = []PathParamConfig{}
 // actionOneQueryParams contains query parameter configuration for ActionOne
 var actionOneQueryParams = []QueryParamConfig{}
 
+// actionOneHeaderFieldParams contains header-sourced field configuration for ActionOne
+var actionOneHeaderFieldParams = []HeaderParamConfig{}
+
 // actionTwoPathParams contains path parameter configuration for ActionTwo
 var actionTwoPathParams = []PathParamConfig{}
 
 // actionTwoQueryParams contains query parameter configuration for ActionTwo
 var actionTwoQueryParams = []QueryParamConfig{}
+
+// actionTwoHeaderFieldParams contains header-sourced field configuration for ActionTwo
+var actionTwoHeaderFieldParams = []HeaderParamConfig{}
@@ -5,6 +5,7 @@ package bytesencoding
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -18,30 +19,150 @@ type BytesEncodingServiceServer interface {
 // RegisterBytesEncodingServiceServer registers the HTTP handlers for service BytesEncodingService to the given mux.
 func RegisterBytesEncodingServiceServer(server BytesEncodingServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingBytesEncodingServiceServer{slot: registeredBytesEncodingServiceServers.Add(server)}
 
 	serviceHeaders := getBytesEncodingServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getTestBytesEncodingHeaders()
 	testBytesEncodingHandler := BindingMiddleware[BytesEncodingTest](
-		genericHandler(server.TestBytesEncoding, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		testBytesEncodingPathParams, testBytesEncodingQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.TestBytesEncoding, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		testBytesEncodingPathParams, testBytesEncodingQueryParams, testBytesEncodingHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	testBytesEncodingHandler = sebufhttp.MetricsMiddleware(testBytesEncodingHandler, config.metrics, "testdata.bytes_encoding.BytesEncodingService.TestBytesEncoding")
+	testBytesEncodingHandler = sebufhttp.ResponseHeadersMiddleware(testBytesEncodingHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v1/bytes-encoding", testBytesEncodingHandler)
 
 	methodHeaders = getGetBytesEncodingHeaders()
 	getBytesEncodingHandler := BindingMiddleware[BytesEncodingRequest](
-		genericHandler(server.GetBytesEncoding, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getBytesEncodingPathParams, getBytesEncodingQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetBytesEncoding, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getBytesEncodingPathParams, getBytesEncodingQueryParams, getBytesEncodingHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getBytesEncodingHandler = sebufhttp.MetricsMiddleware(getBytesEncodingHandler, config.metrics, "testdata.bytes_encoding.BytesEncodingService.GetBytesEncoding")
+	getBytesEncodingHandler = sebufhttp.ResponseHeadersMiddleware(getBytesEncodingHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/bytes-encoding/{id}", getBytesEncodingHandler)
+	config.mux.Handle("HEAD /api/v1/bytes-encoding/{id}", sebufhttp.HeadHandler(getBytesEncodingHandler))
+
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, bytesEncodingServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
 
 	return nil
 }
 
+// Paths of the routes RegisterBytesEncodingServiceServer registers.
+const (
+	BytesEncodingServicePathTestBytesEncoding = "/api/v1/bytes-encoding"
+	BytesEncodingServicePathGetBytesEncoding  = "/api/v1/bytes-encoding/{id}"
+)
+
+// BytesEncodingServicePathGetBytesEncodingFor returns BytesEncodingServicePathGetBytesEncoding with its wildcards replaced by
+// the URL-escaped values of id.
+func BytesEncodingServicePathGetBytesEncodingFor(id string) string {
+	return sebufhttp.BuildPath(BytesEncodingServicePathGetBytesEncoding, id)
+}
+
+// BytesEncodingServiceServerRoutes returns the routes RegisterBytesEncodingServiceServer registers.
+func BytesEncodingServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), bytesEncodingServiceRouteInfos...)
+}
+
+// bytesEncodingServiceRouteInfos lists the routes RegisterBytesEncodingServiceServer registers.
+var bytesEncodingServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "POST", Path: BytesEncodingServicePathTestBytesEncoding, Service: "testdata.bytes_encoding.BytesEncodingService", RPC: "TestBytesEncoding"},
+	{Method: "GET", Path: BytesEncodingServicePathGetBytesEncoding, Service: "testdata.bytes_encoding.BytesEncodingService", RPC: "GetBytesEncoding"},
+}
+
+// registeredBytesEncodingServiceServers holds the implementation of every BytesEncodingService registration.
+var registeredBytesEncodingServiceServers sebufhttp.ServerSlots[BytesEncodingServiceServer]
+
+// UpdateBytesEncodingServiceServer makes every handler registered by RegisterBytesEncodingServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateBytesEncodingServiceServer(server BytesEncodingServiceServer) {
+	registeredBytesEncodingServiceServers.Store(server)
+}
+
+// UnregisterBytesEncodingServiceServer detaches the implementation from every handler
+// registered by RegisterBytesEncodingServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateBytesEncodingServiceServer installs a new implementation.
+func UnregisterBytesEncodingServiceServer() {
+	registeredBytesEncodingServiceServers.Clear()
+}
+
+// dispatchingBytesEncodingServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingBytesEncodingServiceServer struct {
+	slot *sebufhttp.ServerSlot[BytesEncodingServiceServer]
+}
+
+func (d dispatchingBytesEncodingServiceServer) TestBytesEncoding(ctx context.Context, req *BytesEncodingTest) (*BytesEncodingTest, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service BytesEncodingService is not registered"}
+	}
+	return server.TestBytesEncoding(ctx, req)
+}
+
+func (d dispatchingBytesEncodingServiceServer) GetBytesEncoding(ctx context.Context, req *BytesEncodingRequest) (*BytesEncodingTest, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service BytesEncodingService is not registered"}
+	}
+	return server.GetBytesEncoding(ctx, req)
+}
+
+// UnimplementedBytesEncodingServiceServer can be embedded in BytesEncodingServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedBytesEncodingServiceServer struct{}
+
+func (UnimplementedBytesEncodingServiceServer) TestBytesEncoding(context.Context, *BytesEncodingTest) (*BytesEncodingTest, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method TestBytesEncoding not implemented"}
+}
+
+func (UnimplementedBytesEncodingServiceServer) GetBytesEncoding(context.Context, *BytesEncodingRequest) (*BytesEncodingTest, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetBytesEncoding not implemented"}
+}
+
+// DecodeTestBytesEncodingRequest binds r to a BytesEncodingTest as the TestBytesEncoding handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterBytesEncodingServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeTestBytesEncodingRequest(r *http.Request) (*BytesEncodingTest, error) {
+	req := new(BytesEncodingTest)
+	err := bindRequest(nil, r, req, testBytesEncodingPathParams, testBytesEncodingQueryParams, testBytesEncodingHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeGetBytesEncodingRequest binds r to a BytesEncodingRequest as the GetBytesEncoding handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterBytesEncodingServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetBytesEncodingRequest(r *http.Request) (*BytesEncodingRequest, error) {
+	req := new(BytesEncodingRequest)
+	err := bindRequest(nil, r, req, getBytesEncodingPathParams, getBytesEncodingQueryParams, getBytesEncodingHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getBytesEncodingServiceHeaders returns the service-level required headers for BytesEncodingService
 func getBytesEncodingServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -63,6 +184,9 @@ var testBytesEncodingPathParams = []PathParamConfig{}
 // testBytesEncodingQueryParams contains query parameter configuration for TestBytesEncoding
 var testBytesEncodingQueryParams = []QueryParamConfig{}
 
+// testBytesEncodingHeaderFieldParams contains header-sourced field configuration for TestBytesEncoding
+var testBytesEncodingHeaderFieldParams = []HeaderParamConfig{}
+
 // getBytesEncodingPathParams contains path parameter configuration for GetBytesEncoding
 var getBytesEncodingPathParams = []PathParamConfig{
 	{URLParam: "id", FieldName: "id"},
@@ -70,3 +194,6 @@ var getBytesEncodingPathParams = []PathParamConfig{
 
 // getBytesEncodingQueryParams contains query parameter configuration for GetBytesEncoding
 var getBytesEncodingQueryParams = []QueryParamConfig{}
+
+// getBytesEncodingHeaderFieldParams contains header-sourced field configuration for GetBytesEncoding
+var getBytesEncodingHeaderFieldParams = []HeaderParamConfig{}
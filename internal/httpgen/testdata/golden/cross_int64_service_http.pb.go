@@ -5,6 +5,7 @@ package crossint64
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -17,21 +18,102 @@ type BarsServiceServer interface {
 // RegisterBarsServiceServer registers the HTTP handlers for service BarsService to the given mux.
 func RegisterBarsServiceServer(server BarsServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingBarsServiceServer{slot: registeredBarsServiceServers.Add(server)}
 
 	serviceHeaders := getBarsServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getGetBarsHeaders()
 	getBarsHandler := BindingMiddleware[GetBarsRequest](
-		genericHandler(server.GetBars, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getBarsPathParams, getBarsQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetBars, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getBarsPathParams, getBarsQueryParams, getBarsHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getBarsHandler = sebufhttp.MetricsMiddleware(getBarsHandler, config.metrics, "test.httpgen.crossint64.BarsService.GetBars")
+	getBarsHandler = sebufhttp.ResponseHeadersMiddleware(getBarsHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /v2/bars", getBarsHandler)
+	config.mux.Handle("HEAD /v2/bars", sebufhttp.HeadHandler(getBarsHandler))
+
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, barsServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
 
 	return nil
 }
 
+// Paths of the routes RegisterBarsServiceServer registers.
+const (
+	BarsServicePathGetBars = "/v2/bars"
+)
+
+// BarsServiceServerRoutes returns the routes RegisterBarsServiceServer registers.
+func BarsServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), barsServiceRouteInfos...)
+}
+
+// barsServiceRouteInfos lists the routes RegisterBarsServiceServer registers.
+var barsServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "GET", Path: BarsServicePathGetBars, Service: "test.httpgen.crossint64.BarsService", RPC: "GetBars"},
+}
+
+// registeredBarsServiceServers holds the implementation of every BarsService registration.
+var registeredBarsServiceServers sebufhttp.ServerSlots[BarsServiceServer]
+
+// UpdateBarsServiceServer makes every handler registered by RegisterBarsServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateBarsServiceServer(server BarsServiceServer) {
+	registeredBarsServiceServers.Store(server)
+}
+
+// UnregisterBarsServiceServer detaches the implementation from every handler
+// registered by RegisterBarsServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateBarsServiceServer installs a new implementation.
+func UnregisterBarsServiceServer() {
+	registeredBarsServiceServers.Clear()
+}
+
+// dispatchingBarsServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingBarsServiceServer struct {
+	slot *sebufhttp.ServerSlot[BarsServiceServer]
+}
+
+func (d dispatchingBarsServiceServer) GetBars(ctx context.Context, req *GetBarsRequest) (*GetBarsResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service BarsService is not registered"}
+	}
+	return server.GetBars(ctx, req)
+}
+
+// UnimplementedBarsServiceServer can be embedded in BarsServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedBarsServiceServer struct{}
+
+func (UnimplementedBarsServiceServer) GetBars(context.Context, *GetBarsRequest) (*GetBarsResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetBars not implemented"}
+}
+
+// DecodeGetBarsRequest binds r to a GetBarsRequest as the GetBars handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterBarsServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetBarsRequest(r *http.Request) (*GetBarsRequest, error) {
+	req := new(GetBarsRequest)
+	err := bindRequest(nil, r, req, getBarsPathParams, getBarsQueryParams, getBarsHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getBarsServiceHeaders returns the service-level required headers for BarsService
 func getBarsServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -49,3 +131,6 @@ var getBarsPathParams = []PathParamConfig{}
 var getBarsQueryParams = []QueryParamConfig{
 	{QueryName: "symbols", FieldName: "symbols", Required: false},
 }
+
+// getBarsHeaderFieldParams contains header-sourced field configuration for GetBars
+var getBarsHeaderFieldParams = []HeaderParamConfig{}
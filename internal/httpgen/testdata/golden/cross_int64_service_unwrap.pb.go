@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 
 	"google.golang.org/protobuf/encoding/protojson"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
 
 // MarshalJSONSebuf implements sebufMarshaler for GetBarsResponse.
@@ -71,6 +73,12 @@ func (x *GetBarsResponse) MarshalJSON() ([]byte, error) {
 	return x.MarshalJSONSebuf(protojson.MarshalOptions{})
 }
 
+// getBarsResponseAlternateJSONNames maps the proto names and JSON names protojson
+// accepts for the fields of GetBarsResponse to the keys UnmarshalJSON reads.
+var getBarsResponseAlternateJSONNames = map[string]string{
+	"next_page_token": "nextPageToken",
+}
+
 // UnmarshalJSON implements json.Unmarshaler for GetBarsResponse.
 // This method handles unwrap field deserialization for map values.
 func (x *GetBarsResponse) UnmarshalJSON(data []byte) error {
@@ -78,6 +86,9 @@ func (x *GetBarsResponse) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	if err := sebufhttp.ResolveAlternateJSONNames(raw, getBarsResponseAlternateJSONNames); err != nil {
+		return err
+	}
 
 	// Handle unwrap map field: Bars
 	if rawField, ok := raw["bars"]; ok {
@@ -112,3 +123,21 @@ func (x *GetBarsResponse) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// UnknownJSONFields returns the keys of the JSON object data that UnmarshalJSON
+// does not bind to a field of GetBarsResponse, in no particular order.
+func (x *GetBarsResponse) UnknownJSONFields(data []byte) []string {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil // UnmarshalJSON reports malformed bodies
+	}
+	var unknown []string
+	for key := range raw {
+		switch key {
+		case "bars", "nextPageToken", "next_page_token":
+		default:
+			unknown = append(unknown, key)
+		}
+	}
+	return unknown
+}
@@ -5,6 +5,7 @@ package emptybehavior
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -17,21 +18,108 @@ type EmptyBehaviorServiceServer interface {
 // RegisterEmptyBehaviorServiceServer registers the HTTP handlers for service EmptyBehaviorService to the given mux.
 func RegisterEmptyBehaviorServiceServer(server EmptyBehaviorServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingEmptyBehaviorServiceServer{slot: registeredEmptyBehaviorServiceServers.Add(server)}
 
 	serviceHeaders := getEmptyBehaviorServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getGetResponseHeaders()
 	getResponseHandler := BindingMiddleware[GetResponseRequest](
-		genericHandler(server.GetResponse, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getResponsePathParams, getResponseQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetResponse, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getResponsePathParams, getResponseQueryParams, getResponseHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getResponseHandler = sebufhttp.MetricsMiddleware(getResponseHandler, config.metrics, "testdata.empty_behavior.EmptyBehaviorService.GetResponse")
+	getResponseHandler = sebufhttp.ResponseHeadersMiddleware(getResponseHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/responses/{id}", getResponseHandler)
+	config.mux.Handle("HEAD /api/v1/responses/{id}", sebufhttp.HeadHandler(getResponseHandler))
+
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, emptyBehaviorServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
 
 	return nil
 }
 
+// Paths of the routes RegisterEmptyBehaviorServiceServer registers.
+const (
+	EmptyBehaviorServicePathGetResponse = "/api/v1/responses/{id}"
+)
+
+// EmptyBehaviorServicePathGetResponseFor returns EmptyBehaviorServicePathGetResponse with its wildcards replaced by
+// the URL-escaped values of id.
+func EmptyBehaviorServicePathGetResponseFor(id string) string {
+	return sebufhttp.BuildPath(EmptyBehaviorServicePathGetResponse, id)
+}
+
+// EmptyBehaviorServiceServerRoutes returns the routes RegisterEmptyBehaviorServiceServer registers.
+func EmptyBehaviorServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), emptyBehaviorServiceRouteInfos...)
+}
+
+// emptyBehaviorServiceRouteInfos lists the routes RegisterEmptyBehaviorServiceServer registers.
+var emptyBehaviorServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "GET", Path: EmptyBehaviorServicePathGetResponse, Service: "testdata.empty_behavior.EmptyBehaviorService", RPC: "GetResponse"},
+}
+
+// registeredEmptyBehaviorServiceServers holds the implementation of every EmptyBehaviorService registration.
+var registeredEmptyBehaviorServiceServers sebufhttp.ServerSlots[EmptyBehaviorServiceServer]
+
+// UpdateEmptyBehaviorServiceServer makes every handler registered by RegisterEmptyBehaviorServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateEmptyBehaviorServiceServer(server EmptyBehaviorServiceServer) {
+	registeredEmptyBehaviorServiceServers.Store(server)
+}
+
+// UnregisterEmptyBehaviorServiceServer detaches the implementation from every handler
+// registered by RegisterEmptyBehaviorServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateEmptyBehaviorServiceServer installs a new implementation.
+func UnregisterEmptyBehaviorServiceServer() {
+	registeredEmptyBehaviorServiceServers.Clear()
+}
+
+// dispatchingEmptyBehaviorServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingEmptyBehaviorServiceServer struct {
+	slot *sebufhttp.ServerSlot[EmptyBehaviorServiceServer]
+}
+
+func (d dispatchingEmptyBehaviorServiceServer) GetResponse(ctx context.Context, req *GetResponseRequest) (*Response, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service EmptyBehaviorService is not registered"}
+	}
+	return server.GetResponse(ctx, req)
+}
+
+// UnimplementedEmptyBehaviorServiceServer can be embedded in EmptyBehaviorServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedEmptyBehaviorServiceServer struct{}
+
+func (UnimplementedEmptyBehaviorServiceServer) GetResponse(context.Context, *GetResponseRequest) (*Response, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetResponse not implemented"}
+}
+
+// DecodeGetResponseRequest binds r to a GetResponseRequest as the GetResponse handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterEmptyBehaviorServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetResponseRequest(r *http.Request) (*GetResponseRequest, error) {
+	req := new(GetResponseRequest)
+	err := bindRequest(nil, r, req, getResponsePathParams, getResponseQueryParams, getResponseHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getEmptyBehaviorServiceHeaders returns the service-level required headers for EmptyBehaviorService
 func getEmptyBehaviorServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -49,3 +137,6 @@ var getResponsePathParams = []PathParamConfig{
 
 // getResponseQueryParams contains query parameter configuration for GetResponse
 var getResponseQueryParams = []QueryParamConfig{}
+
+// getResponseHeaderFieldParams contains header-sourced field configuration for GetResponse
+var getResponseHeaderFieldParams = []HeaderParamConfig{}
@@ -4,10 +4,16 @@
 package emptybehavior
 
 import (
+	"context"
+	"log/slog"
 	"net/http"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
 
 // ErrorHandler is called when an error occurs.
@@ -19,7 +25,10 @@ import (
 //   - Return nil to use the default error response (ValidationError or Error)
 //
 // If you write directly to w (via w.Write()), the response is considered
-// complete and no further writing occurs.
+// complete and no further writing occurs: a message returned as well is dropped,
+// with a warning to the WithLogger logger. Only the first status w.WriteHeader
+// sets is sent, and w has a Written() bool method reporting whether the response
+// has started.
 //
 // Use errors.As() to inspect error types: *sebufhttp.ValidationError or *sebufhttp.Error
 type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error) proto.Message
@@ -28,10 +37,39 @@ type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error) proto.
 type ServerOption func(c *serverConfiguration)
 
 type serverConfiguration struct {
-	mux          *http.ServeMux
-	withMux      bool
-	errorHandler ErrorHandler
-	marshalOpts  protojson.MarshalOptions
+	mux                     *http.ServeMux
+	withMux                 bool
+	errorHandler            ErrorHandler
+	marshalOpts             protojson.MarshalOptions
+	idempotencyStore        sebufhttp.IdempotencyStore
+	idempotencyTTL          time.Duration
+	responseCacheSize       int
+	validationPolicy        sebufhttp.ValidationPolicy
+	violationFormatter      sebufhttp.ViolationFormatter
+	validationFailureMode   sebufhttp.ValidationFailureMode
+	schemaMismatchMode      sebufhttp.SchemaMismatchMode
+	logger                  *slog.Logger
+	concurrencyLimit        int
+	defaultTimeout          time.Duration
+	timeoutHeader           sebufhttp.TimeoutHeader
+	metrics                 *sebufhttp.ServerMetrics
+	maxBodySize             int64
+	strictJSON              bool
+	noContentSniffing       bool
+	warningsInBody          bool
+	typeResolver            sebufhttp.TypeResolver
+	routeDebug              bool
+	routeDebugAuth          func(*http.Request) bool
+	headerAuthenticators    []sebufhttp.HeaderAuthenticator
+	problemJSON             bool
+	deprecationReporter     sebufhttp.DeprecationReporter
+	responseValidation      sebufhttp.ResponseValidationMode
+	responseHeaderOverrides map[string]string
+}
+
+// writeError writes err through the configured error handler.
+func (c *serverConfiguration) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	writeErrorWithHandler(w, r, err, c.errorHandler, c.marshalOpts)
 }
 
 func getDefaultConfiguration() *serverConfiguration {
@@ -46,6 +84,16 @@ func getConfiguration(options ...ServerOption) *serverConfiguration {
 	for _, option := range options {
 		option(configuration)
 	}
+	if configuration.marshalOpts.Resolver == nil && configuration.typeResolver != nil {
+		// Responses resolve Any type URLs like request bodies, unless WithMarshalOptions set a resolver
+		configuration.marshalOpts.Resolver = configuration.typeResolver
+	}
+	if configuration.errorHandler != nil {
+		configuration.errorHandler = guardErrorHandler(configuration.errorHandler, configuration.logger)
+	}
+	if configuration.problemJSON {
+		configuration.errorHandler = problemErrorHandler(configuration.errorHandler)
+	}
 	return configuration
 }
 
@@ -73,3 +121,275 @@ func WithMarshalOptions(opts protojson.MarshalOptions) ServerOption {
 		c.marshalOpts = opts
 	}
 }
+
+// WithIdempotencyStore configures the store backing methods annotated with
+// idempotency: true. Without it, each registered service keeps an in-process
+// sebufhttp.MemoryIdempotencyStore, which does not deduplicate across replicas.
+func WithIdempotencyStore(store sebufhttp.IdempotencyStore) ServerOption {
+	return func(c *serverConfiguration) {
+		c.idempotencyStore = store
+	}
+}
+
+// WithIdempotencyTTL configures how long responses of idempotent methods are kept
+// for replay. The zero value selects sebufhttp.DefaultIdempotencyTTL.
+func WithIdempotencyTTL(ttl time.Duration) ServerOption {
+	return func(c *serverConfiguration) {
+		c.idempotencyTTL = ttl
+	}
+}
+
+// WithResponseCache enables an in-process cache of up to size responses for methods
+// annotated with cache. A cached response is served for its max_age_seconds without
+// calling the service implementation; header and request validation still run.
+// Each registered service keeps its own cache.
+func WithResponseCache(size int) ServerOption {
+	return func(c *serverConfiguration) {
+		c.responseCacheSize = size
+	}
+}
+
+// WithValidationPolicy configures how requests failing header or protovalidate
+// validation are treated. The policy can relax validation for trusted callers:
+// sebufhttp.ValidationWarn logs the violations and exposes them through
+// sebufhttp.ViolationsFromContext, sebufhttp.ValidationSkip does not validate.
+// Without a policy, validation is always enforced.
+func WithValidationPolicy(policy sebufhttp.ValidationPolicy) ServerOption {
+	return func(c *serverConfiguration) {
+		c.validationPolicy = policy
+	}
+}
+
+// WithValidationFailureMode configures how requests whose message cannot be
+// validated are treated, such as when building its protovalidate validator fails.
+// Register*Server builds the validators of its messages and fails on such errors,
+// so this only matters for validation failing at run time. By default, with
+// sebufhttp.ValidationFailClosed, they are rejected with an internal error;
+// sebufhttp.ValidationFailOpen lets them through unvalidated. Both log the error.
+func WithValidationFailureMode(mode sebufhttp.ValidationFailureMode) ServerOption {
+	return func(c *serverConfiguration) {
+		c.validationFailureMode = mode
+	}
+}
+
+// WithResponseValidation validates the responses handlers return against their
+// buf.validate rules, for catching handlers that break the service's contract in
+// development and staging. sebufhttp.ResponseValidationWarn logs the violations
+// to the logger of WithLogger and sends the response anyway;
+// sebufhttp.ResponseValidationEnforce logs them and answers with a 500 whose
+// message reveals none of the response. Responses whose message has no rules
+// are never validated. The default, sebufhttp.ResponseValidationOff, costs nothing.
+func WithResponseValidation(mode sebufhttp.ResponseValidationMode) ServerOption {
+	return func(c *serverConfiguration) {
+		c.responseValidation = mode
+	}
+}
+
+// WithProblemJSON answers errors with Problem Details (RFC 9457) as
+// application/problem+json instead of sebufhttp.Error and sebufhttp.ValidationError
+// messages: type, title, status, detail and instance (the request path), with the
+// code and details of an Error, and the violations of a ValidationError as an
+// errors array of JSON Pointers. A WithErrorHandler handler still runs first, and
+// the errors it answers itself are written as it chooses. Services with
+// error_format PROBLEM_JSON always use it.
+func WithProblemJSON() ServerOption {
+	return func(c *serverConfiguration) {
+		c.problemJSON = true
+	}
+}
+
+// WithSchemaMismatchMode configures how requests from clients generated from
+// another schema are treated, for services generated with schema_fingerprint. By
+// default, with sebufhttp.SchemaMismatchWarn, they are logged and served;
+// sebufhttp.SchemaMismatchReject answers them with a SCHEMA_MISMATCH error and
+// sebufhttp.SchemaMismatchIgnore serves them silently. Handlers can read the
+// outcome with sebufhttp.SchemaMatchFromContext either way.
+func WithSchemaMismatchMode(mode sebufhttp.SchemaMismatchMode) ServerOption {
+	return func(c *serverConfiguration) {
+		c.schemaMismatchMode = mode
+	}
+}
+
+// WithViolationFormatter sets the function producing the description of each header
+// or protovalidate violation in a ValidationError, for example to localize it. It
+// receives the field, the constraint ID and parameters of the failed rule, and the
+// default message, which is kept when it returns an empty string.
+func WithViolationFormatter(formatter func(v sebufhttp.Violation) string) ServerOption {
+	return func(c *serverConfiguration) {
+		c.violationFormatter = formatter
+	}
+}
+
+// WithViolationCatalog describes violations with the text/template catalog holds for
+// their constraint ID, such as "string.min_len" or "header.required". Templates read
+// the sebufhttp.Violation, as in {{.Field}} or {{.Params.min_len}}; violations without
+// a template keep their default message. It panics if a template does not parse,
+// and replaces any WithViolationFormatter.
+func WithViolationCatalog(catalog map[string]string) ServerOption {
+	formatter := sebufhttp.MustViolationCatalog(catalog)
+	return func(c *serverConfiguration) {
+		c.violationFormatter = formatter
+	}
+}
+
+// WithHeaderAuthenticator verifies the credentials of the header headerName with fn,
+// typically a header declared with an auth_type, on every method of the service,
+// whether or not it declares the header. fn runs after header validation with the
+// header as sent, and returns the context the handler runs with, which may carry a
+// principal (see sebufhttp.ContextWithPrincipal). When fn fails, or the request lacks
+// a header it does not declare optional, the request is answered with 401
+// Unauthorized and an Error with code UNAUTHENTICATED, unless fn returns a
+// *sebufhttp.Error, which is written as is. A missing required header is still
+// answered with the 400 ValidationError. Authenticators run in the order of the options.
+func WithHeaderAuthenticator(
+	headerName string, fn func(ctx context.Context, value string) (context.Context, error),
+) ServerOption {
+	return func(c *serverConfiguration) {
+		c.headerAuthenticators = append(c.headerAuthenticators,
+			sebufhttp.HeaderAuthenticator{Header: headerName, Authenticate: fn})
+	}
+}
+
+// WithConcurrencyLimit caps how many handlers of each registered service execute at
+// once. A slot is taken after the request is bound and validated, and released when the
+// handler returns; requests arriving while all slots are taken are answered with
+// 503 Service Unavailable and a Retry-After header. Streaming methods are not limited.
+// The zero value means no limit.
+func WithConcurrencyLimit(n int) ServerOption {
+	return func(c *serverConfiguration) {
+		c.concurrencyLimit = n
+	}
+}
+
+// WithDefaultTimeout bounds how long the handler of a method without a timeout_ms
+// annotation may run. When it expires, the handler's context is cancelled and the
+// request is answered with 504 Gateway Timeout; the handler's late result is discarded.
+// Streaming methods are not bounded. The zero value means no timeout.
+func WithDefaultTimeout(timeout time.Duration) ServerOption {
+	return func(c *serverConfiguration) {
+		c.defaultTimeout = timeout
+	}
+}
+
+// WithTimeoutHeader bounds the context of each request by the timeout it carries in the
+// header name, clamped to max when positive: a number of milliseconds, as in
+// X-Request-Timeout-Ms, or a grpc-timeout value such as "250m" or "2S". The timeout
+// applied is reported in the X-Timeout-Applied-Ms response header. A handler still running
+// when it expires is answered with 504 Gateway Timeout, as for timeout_ms, and a malformed
+// value with a validation error naming the header. Streaming methods are not bounded.
+func WithTimeoutHeader(name string, max time.Duration) ServerOption {
+	return func(c *serverConfiguration) {
+		c.timeoutHeader = sebufhttp.TimeoutHeader{Name: name, Max: max}
+	}
+}
+
+// WithMetrics records request metrics into registry; the sebuf/http/metrics/prometheus
+// package adapts a Prometheus registerer. Every method records:
+//   - sebuf_http_requests_total{method, code}: requests by status code
+//   - sebuf_http_request_duration_seconds{method}: request duration
+//   - sebuf_http_request_size_bytes{method}: request body size
+//   - sebuf_http_response_size_bytes{method}: response body size
+//   - sebuf_http_validation_failures_total{method, field}: enforced or warned violations
+//
+// The method label is the full protobuf name of the RPC.
+func WithMetrics(registry sebufhttp.MetricsRegistry) ServerOption {
+	return func(c *serverConfiguration) {
+		c.metrics = sebufhttp.NewServerMetrics(registry)
+	}
+}
+
+// WithMaxBodySize bounds the size of request bodies. Larger bodies are answered
+// with 413 Content Too Large. The zero value means no limit, except for multipart
+// bodies, which are bounded by sebufhttp.DefaultMaxMultipartBodySize.
+func WithMaxBodySize(n int64) ServerOption {
+	return func(c *serverConfiguration) {
+		c.maxBodySize = n
+	}
+}
+
+// WithStrictJSON rejects JSON request bodies with keys that name no field of the
+// request message on every method, as if each set strict_json. Each unknown key
+// is reported as a violation of a 400 ValidationError.
+func WithStrictJSON() ServerOption {
+	return func(c *serverConfiguration) {
+		c.strictJSON = true
+	}
+}
+
+// WithDeprecationReporting calls report for each field marked deprecated in the
+// proto, nested ones included, that a JSON request body sets, even to null or to
+// its zero value, with the full name of the RPC and the dotted proto name path
+// of the field. The fields are also listed in the Deprecation response header.
+func WithDeprecationReporting(report func(method, field string)) ServerOption {
+	return func(c *serverConfiguration) {
+		c.deprecationReporter = report
+	}
+}
+
+// WithResponseHeaderOverrides changes the response_headers the services and
+// methods annotate at deployment, by canonical name: an override replaces the
+// value of the header of its name, or sets it on every response when no
+// annotation declares it, and an empty value removes the header.
+func WithResponseHeaderOverrides(overrides map[string]string) ServerOption {
+	return func(c *serverConfiguration) {
+		c.responseHeaderOverrides = overrides
+	}
+}
+
+// WithoutContentSniffing binds request bodies that do not look like their
+// Content-Type, for payloads the check would misjudge. By default a JSON body
+// whose first non-whitespace byte cannot start a JSON value, or a protobuf body
+// starting with { or [, is answered with 415 Unsupported Media Type.
+func WithoutContentSniffing() ServerOption {
+	return func(c *serverConfiguration) {
+		c.noContentSniffing = true
+	}
+}
+
+// WithWarningsInBody adds the warnings handlers add with sebufhttp.AddWarning to
+// JSON response objects, as a top-level _warnings array of {code, message}, besides
+// the Warning headers always sent. Responses that are not JSON objects, such as
+// root-unwrapped messages and raw bodies, carry them in the headers only.
+func WithWarningsInBody() ServerOption {
+	return func(c *serverConfiguration) {
+		c.warningsInBody = true
+	}
+}
+
+// WithTypeResolver resolves the type URLs of google.protobuf.Any fields against
+// resolver when binding JSON request bodies and writing JSON responses, so Any
+// payloads may hold messages missing from protoregistry.GlobalTypes. resolver
+// replaces protoregistry.GlobalTypes for messages, so it must also hold the
+// generated types Any fields carry. Extensions resolve against
+// protoregistry.GlobalTypes unless resolver also resolves them.
+func WithTypeResolver(resolver protoregistry.MessageTypeResolver) ServerOption {
+	return func(c *serverConfiguration) {
+		c.typeResolver = sebufhttp.NewTypeResolver(resolver)
+	}
+}
+
+// WithRouteDebug logs every route the server registers, with its service, RPC and
+// required headers, to the logger of WithLogger, and lists them as JSON at
+// GET /__sebuf/routes of the mux. Services registered on the same mux share the
+// listing. Restrict it with WithRouteDebugAuth outside of development.
+func WithRouteDebug() ServerOption {
+	return func(c *serverConfiguration) {
+		c.routeDebug = true
+	}
+}
+
+// WithRouteDebugAuth answers requests for the WithRouteDebug listing that allow
+// rejects with 404 Not Found, as if the listing did not exist.
+func WithRouteDebugAuth(allow func(r *http.Request) bool) ServerOption {
+	return func(c *serverConfiguration) {
+		c.routeDebugAuth = allow
+	}
+}
+
+// WithLogger configures the logger used for request diagnostics, such as the
+// violations let through by a sebufhttp.ValidationWarn policy. Defaults to slog.Default().
+func WithLogger(logger *slog.Logger) ServerOption {
+	return func(c *serverConfiguration) {
+		c.logger = logger
+	}
+}
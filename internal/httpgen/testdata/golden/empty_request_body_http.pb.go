@@ -5,6 +5,7 @@ package emptyrequestbody
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -18,30 +19,144 @@ type EmptyRequestBodyServiceServer interface {
 // RegisterEmptyRequestBodyServiceServer registers the HTTP handlers for service EmptyRequestBodyService to the given mux.
 func RegisterEmptyRequestBodyServiceServer(server EmptyRequestBodyServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingEmptyRequestBodyServiceServer{slot: registeredEmptyRequestBodyServiceServers.Add(server)}
 
 	serviceHeaders := getEmptyRequestBodyServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getPingHeaders()
 	pingHandler := BindingMiddleware[PingRequest](
-		genericHandler(server.Ping, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		pingPathParams, pingQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.Ping, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		pingPathParams, pingQueryParams, pingHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	pingHandler = sebufhttp.MetricsMiddleware(pingHandler, config.metrics, "testdata.empty_request_body.EmptyRequestBodyService.Ping")
+	pingHandler = sebufhttp.ResponseHeadersMiddleware(pingHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v1/ping", pingHandler)
 
 	methodHeaders = getNoArgsHeaders()
 	noArgsHandler := BindingMiddleware[NoArgsRequest](
-		genericHandler(server.NoArgs, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		noArgsPathParams, noArgsQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.NoArgs, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		noArgsPathParams, noArgsQueryParams, noArgsHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	noArgsHandler = sebufhttp.MetricsMiddleware(noArgsHandler, config.metrics, "testdata.empty_request_body.EmptyRequestBodyService.NoArgs")
+	noArgsHandler = sebufhttp.ResponseHeadersMiddleware(noArgsHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/no-args", noArgsHandler)
+	config.mux.Handle("HEAD /api/v1/no-args", sebufhttp.HeadHandler(noArgsHandler))
+
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, emptyRequestBodyServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
 
 	return nil
 }
 
+// Paths of the routes RegisterEmptyRequestBodyServiceServer registers.
+const (
+	EmptyRequestBodyServicePathPing   = "/api/v1/ping"
+	EmptyRequestBodyServicePathNoArgs = "/api/v1/no-args"
+)
+
+// EmptyRequestBodyServiceServerRoutes returns the routes RegisterEmptyRequestBodyServiceServer registers.
+func EmptyRequestBodyServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), emptyRequestBodyServiceRouteInfos...)
+}
+
+// emptyRequestBodyServiceRouteInfos lists the routes RegisterEmptyRequestBodyServiceServer registers.
+var emptyRequestBodyServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "POST", Path: EmptyRequestBodyServicePathPing, Service: "testdata.empty_request_body.EmptyRequestBodyService", RPC: "Ping"},
+	{Method: "GET", Path: EmptyRequestBodyServicePathNoArgs, Service: "testdata.empty_request_body.EmptyRequestBodyService", RPC: "NoArgs"},
+}
+
+// registeredEmptyRequestBodyServiceServers holds the implementation of every EmptyRequestBodyService registration.
+var registeredEmptyRequestBodyServiceServers sebufhttp.ServerSlots[EmptyRequestBodyServiceServer]
+
+// UpdateEmptyRequestBodyServiceServer makes every handler registered by RegisterEmptyRequestBodyServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateEmptyRequestBodyServiceServer(server EmptyRequestBodyServiceServer) {
+	registeredEmptyRequestBodyServiceServers.Store(server)
+}
+
+// UnregisterEmptyRequestBodyServiceServer detaches the implementation from every handler
+// registered by RegisterEmptyRequestBodyServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateEmptyRequestBodyServiceServer installs a new implementation.
+func UnregisterEmptyRequestBodyServiceServer() {
+	registeredEmptyRequestBodyServiceServers.Clear()
+}
+
+// dispatchingEmptyRequestBodyServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingEmptyRequestBodyServiceServer struct {
+	slot *sebufhttp.ServerSlot[EmptyRequestBodyServiceServer]
+}
+
+func (d dispatchingEmptyRequestBodyServiceServer) Ping(ctx context.Context, req *PingRequest) (*PingResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service EmptyRequestBodyService is not registered"}
+	}
+	return server.Ping(ctx, req)
+}
+
+func (d dispatchingEmptyRequestBodyServiceServer) NoArgs(ctx context.Context, req *NoArgsRequest) (*NoArgsResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service EmptyRequestBodyService is not registered"}
+	}
+	return server.NoArgs(ctx, req)
+}
+
+// UnimplementedEmptyRequestBodyServiceServer can be embedded in EmptyRequestBodyServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedEmptyRequestBodyServiceServer struct{}
+
+func (UnimplementedEmptyRequestBodyServiceServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method Ping not implemented"}
+}
+
+func (UnimplementedEmptyRequestBodyServiceServer) NoArgs(context.Context, *NoArgsRequest) (*NoArgsResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method NoArgs not implemented"}
+}
+
+// DecodePingRequest binds r to a PingRequest as the Ping handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterEmptyRequestBodyServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodePingRequest(r *http.Request) (*PingRequest, error) {
+	req := new(PingRequest)
+	err := bindRequest(nil, r, req, pingPathParams, pingQueryParams, pingHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeNoArgsRequest binds r to a NoArgsRequest as the NoArgs handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterEmptyRequestBodyServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeNoArgsRequest(r *http.Request) (*NoArgsRequest, error) {
+	req := new(NoArgsRequest)
+	err := bindRequest(nil, r, req, noArgsPathParams, noArgsQueryParams, noArgsHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getEmptyRequestBodyServiceHeaders returns the service-level required headers for EmptyRequestBodyService
 func getEmptyRequestBodyServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -63,8 +178,14 @@ var pingPathParams = []PathParamConfig{}
 // pingQueryParams contains query parameter configuration for Ping
 var pingQueryParams = []QueryParamConfig{}
 
+// pingHeaderFieldParams contains header-sourced field configuration for Ping
+var pingHeaderFieldParams = []HeaderParamConfig{}
+
 // noArgsPathParams contains path parameter configuration for NoArgs
 var noArgsPathParams = []PathParamConfig{}
 
 // noArgsQueryParams contains query parameter configuration for NoArgs
 var noArgsQueryParams = []QueryParamConfig{}
+
+// noArgsHeaderFieldParams contains header-sourced field configuration for NoArgs
+var noArgsHeaderFieldParams = []HeaderParamConfig{}
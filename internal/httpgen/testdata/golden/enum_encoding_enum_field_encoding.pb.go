@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 
 	"google.golang.org/protobuf/encoding/protojson"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
 
 // MarshalJSONSebuf implements sebufMarshaler for EnumEncodingTest.
@@ -102,6 +104,18 @@ func (x *EnumEncodingTest) MarshalJSON() ([]byte, error) {
 	return x.MarshalJSONSebuf(protojson.MarshalOptions{})
 }
 
+// enumEncodingTestAlternateJSONNames maps the proto names and JSON names protojson
+// accepts for the fields of EnumEncodingTest to the keys UnmarshalJSON reads.
+var enumEncodingTestAlternateJSONNames = map[string]string{
+	"default_priority":     "defaultPriority",
+	"number_priority_list": "numberPriorityList",
+	"optional_status":      "optionalStatus",
+	"priority_as_number":   "priorityAsNumber",
+	"priority_as_string":   "priorityAsString",
+	"status_list":          "statusList",
+	"status_map":           "statusMap",
+}
+
 // UnmarshalJSONSebuf implements sebufUnmarshaler for EnumEncodingTest.
 // This method handles enum_value fields and nested messages: status, status_list, optional_status, status_map
 func (x *EnumEncodingTest) UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error {
@@ -110,6 +124,9 @@ func (x *EnumEncodingTest) UnmarshalJSONSebuf(data []byte, opts protojson.Unmars
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	if err := sebufhttp.ResolveAlternateJSONNames(raw, enumEncodingTestAlternateJSONNames); err != nil {
+		return err
+	}
 
 	// Rewrite status from custom enum_value strings to proto names
 	for _, k := range []string{"status"} {
@@ -5,6 +5,7 @@ package enumencoding
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -17,21 +18,108 @@ type EnumEncodingServiceServer interface {
 // RegisterEnumEncodingServiceServer registers the HTTP handlers for service EnumEncodingService to the given mux.
 func RegisterEnumEncodingServiceServer(server EnumEncodingServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingEnumEncodingServiceServer{slot: registeredEnumEncodingServiceServers.Add(server)}
 
 	serviceHeaders := getEnumEncodingServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getGetEnumTestHeaders()
 	getEnumTestHandler := BindingMiddleware[GetEnumTestRequest](
-		genericHandler(server.GetEnumTest, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getEnumTestPathParams, getEnumTestQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetEnumTest, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getEnumTestPathParams, getEnumTestQueryParams, getEnumTestHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getEnumTestHandler = sebufhttp.MetricsMiddleware(getEnumTestHandler, config.metrics, "testdata.enumencoding.EnumEncodingService.GetEnumTest")
+	getEnumTestHandler = sebufhttp.ResponseHeadersMiddleware(getEnumTestHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/test/enum/{id}", getEnumTestHandler)
+	config.mux.Handle("HEAD /api/v1/test/enum/{id}", sebufhttp.HeadHandler(getEnumTestHandler))
+
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, enumEncodingServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
 
 	return nil
 }
 
+// Paths of the routes RegisterEnumEncodingServiceServer registers.
+const (
+	EnumEncodingServicePathGetEnumTest = "/api/v1/test/enum/{id}"
+)
+
+// EnumEncodingServicePathGetEnumTestFor returns EnumEncodingServicePathGetEnumTest with its wildcards replaced by
+// the URL-escaped values of id.
+func EnumEncodingServicePathGetEnumTestFor(id string) string {
+	return sebufhttp.BuildPath(EnumEncodingServicePathGetEnumTest, id)
+}
+
+// EnumEncodingServiceServerRoutes returns the routes RegisterEnumEncodingServiceServer registers.
+func EnumEncodingServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), enumEncodingServiceRouteInfos...)
+}
+
+// enumEncodingServiceRouteInfos lists the routes RegisterEnumEncodingServiceServer registers.
+var enumEncodingServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "GET", Path: EnumEncodingServicePathGetEnumTest, Service: "testdata.enumencoding.EnumEncodingService", RPC: "GetEnumTest"},
+}
+
+// registeredEnumEncodingServiceServers holds the implementation of every EnumEncodingService registration.
+var registeredEnumEncodingServiceServers sebufhttp.ServerSlots[EnumEncodingServiceServer]
+
+// UpdateEnumEncodingServiceServer makes every handler registered by RegisterEnumEncodingServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateEnumEncodingServiceServer(server EnumEncodingServiceServer) {
+	registeredEnumEncodingServiceServers.Store(server)
+}
+
+// UnregisterEnumEncodingServiceServer detaches the implementation from every handler
+// registered by RegisterEnumEncodingServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateEnumEncodingServiceServer installs a new implementation.
+func UnregisterEnumEncodingServiceServer() {
+	registeredEnumEncodingServiceServers.Clear()
+}
+
+// dispatchingEnumEncodingServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingEnumEncodingServiceServer struct {
+	slot *sebufhttp.ServerSlot[EnumEncodingServiceServer]
+}
+
+func (d dispatchingEnumEncodingServiceServer) GetEnumTest(ctx context.Context, req *GetEnumTestRequest) (*EnumEncodingTest, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service EnumEncodingService is not registered"}
+	}
+	return server.GetEnumTest(ctx, req)
+}
+
+// UnimplementedEnumEncodingServiceServer can be embedded in EnumEncodingServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedEnumEncodingServiceServer struct{}
+
+func (UnimplementedEnumEncodingServiceServer) GetEnumTest(context.Context, *GetEnumTestRequest) (*EnumEncodingTest, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetEnumTest not implemented"}
+}
+
+// DecodeGetEnumTestRequest binds r to a GetEnumTestRequest as the GetEnumTest handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterEnumEncodingServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetEnumTestRequest(r *http.Request) (*GetEnumTestRequest, error) {
+	req := new(GetEnumTestRequest)
+	err := bindRequest(nil, r, req, getEnumTestPathParams, getEnumTestQueryParams, getEnumTestHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getEnumEncodingServiceHeaders returns the service-level required headers for EnumEncodingService
 func getEnumEncodingServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -49,3 +137,6 @@ var getEnumTestPathParams = []PathParamConfig{
 
 // getEnumTestQueryParams contains query parameter configuration for GetEnumTest
 var getEnumTestQueryParams = []QueryParamConfig{}
+
+// getEnumTestHeaderFieldParams contains header-sourced field configuration for GetEnumTest
+var getEnumTestHeaderFieldParams = []HeaderParamConfig{}
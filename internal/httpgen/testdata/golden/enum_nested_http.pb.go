@@ -5,6 +5,7 @@ package enumnested
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -17,21 +18,108 @@ type NestedEnumServiceServer interface {
 // RegisterNestedEnumServiceServer registers the HTTP handlers for service NestedEnumService to the given mux.
 func RegisterNestedEnumServiceServer(server NestedEnumServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingNestedEnumServiceServer{slot: registeredNestedEnumServiceServers.Add(server)}
 
 	serviceHeaders := getNestedEnumServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getGetItemsHeaders()
 	getItemsHandler := BindingMiddleware[GetItemsRequest](
-		genericHandler(server.GetItems, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getItemsPathParams, getItemsQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetItems, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getItemsPathParams, getItemsQueryParams, getItemsHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getItemsHandler = sebufhttp.MetricsMiddleware(getItemsHandler, config.metrics, "testdata.enumnested.NestedEnumService.GetItems")
+	getItemsHandler = sebufhttp.ResponseHeadersMiddleware(getItemsHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/items/{id}", getItemsHandler)
+	config.mux.Handle("HEAD /api/v1/items/{id}", sebufhttp.HeadHandler(getItemsHandler))
+
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, nestedEnumServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
 
 	return nil
 }
 
+// Paths of the routes RegisterNestedEnumServiceServer registers.
+const (
+	NestedEnumServicePathGetItems = "/api/v1/items/{id}"
+)
+
+// NestedEnumServicePathGetItemsFor returns NestedEnumServicePathGetItems with its wildcards replaced by
+// the URL-escaped values of id.
+func NestedEnumServicePathGetItemsFor(id string) string {
+	return sebufhttp.BuildPath(NestedEnumServicePathGetItems, id)
+}
+
+// NestedEnumServiceServerRoutes returns the routes RegisterNestedEnumServiceServer registers.
+func NestedEnumServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), nestedEnumServiceRouteInfos...)
+}
+
+// nestedEnumServiceRouteInfos lists the routes RegisterNestedEnumServiceServer registers.
+var nestedEnumServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "GET", Path: NestedEnumServicePathGetItems, Service: "testdata.enumnested.NestedEnumService", RPC: "GetItems"},
+}
+
+// registeredNestedEnumServiceServers holds the implementation of every NestedEnumService registration.
+var registeredNestedEnumServiceServers sebufhttp.ServerSlots[NestedEnumServiceServer]
+
+// UpdateNestedEnumServiceServer makes every handler registered by RegisterNestedEnumServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateNestedEnumServiceServer(server NestedEnumServiceServer) {
+	registeredNestedEnumServiceServers.Store(server)
+}
+
+// UnregisterNestedEnumServiceServer detaches the implementation from every handler
+// registered by RegisterNestedEnumServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateNestedEnumServiceServer installs a new implementation.
+func UnregisterNestedEnumServiceServer() {
+	registeredNestedEnumServiceServers.Clear()
+}
+
+// dispatchingNestedEnumServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingNestedEnumServiceServer struct {
+	slot *sebufhttp.ServerSlot[NestedEnumServiceServer]
+}
+
+func (d dispatchingNestedEnumServiceServer) GetItems(ctx context.Context, req *GetItemsRequest) (*GetItemsResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service NestedEnumService is not registered"}
+	}
+	return server.GetItems(ctx, req)
+}
+
+// UnimplementedNestedEnumServiceServer can be embedded in NestedEnumServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedNestedEnumServiceServer struct{}
+
+func (UnimplementedNestedEnumServiceServer) GetItems(context.Context, *GetItemsRequest) (*GetItemsResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetItems not implemented"}
+}
+
+// DecodeGetItemsRequest binds r to a GetItemsRequest as the GetItems handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterNestedEnumServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetItemsRequest(r *http.Request) (*GetItemsRequest, error) {
+	req := new(GetItemsRequest)
+	err := bindRequest(nil, r, req, getItemsPathParams, getItemsQueryParams, getItemsHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getNestedEnumServiceHeaders returns the service-level required headers for NestedEnumService
 func getNestedEnumServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -49,3 +137,6 @@ var getItemsPathParams = []PathParamConfig{
 
 // getItemsQueryParams contains query parameter configuration for GetItems
 var getItemsQueryParams = []QueryParamConfig{}
+
+// getItemsHeaderFieldParams contains header-sourced field configuration for GetItems
+var getItemsHeaderFieldParams = []HeaderParamConfig{}
@@ -89,11 +89,19 @@ func (x *SimpleFlatten) UnmarshalJSON(data []byte) error {
 			if childErr != nil {
 				return childErr
 			}
-			x.Address = &Address{}
-			// Use json.Unmarshal to invoke child's UnmarshalJSON (annotation composability)
-			if childErr = json.Unmarshal(childData, x.Address); childErr != nil {
+			child := &Address{}
+			// Use the child's UnmarshalJSON when it has one (annotation composability);
+			// plain messages need protojson, since their struct tags carry proto names
+			if u, ok := any(child).(json.Unmarshaler); ok {
+				childErr = u.UnmarshalJSON(childData)
+			} else {
+				childErr = protojson.Unmarshal(childData, child)
+			}
+			if childErr != nil {
 				return childErr
 			}
+			// Nest the child back for protojson, which resets x before unmarshaling
+			raw["address"], _ = protojson.Marshal(child)
 		}
 	}
 
@@ -211,11 +219,19 @@ func (x *DualFlatten) UnmarshalJSON(data []byte) error {
 			if childErr != nil {
 				return childErr
 			}
-			x.Billing = &Address{}
-			// Use json.Unmarshal to invoke child's UnmarshalJSON (annotation composability)
-			if childErr = json.Unmarshal(childData, x.Billing); childErr != nil {
+			child := &Address{}
+			// Use the child's UnmarshalJSON when it has one (annotation composability);
+			// plain messages need protojson, since their struct tags carry proto names
+			if u, ok := any(child).(json.Unmarshaler); ok {
+				childErr = u.UnmarshalJSON(childData)
+			} else {
+				childErr = protojson.Unmarshal(childData, child)
+			}
+			if childErr != nil {
 				return childErr
 			}
+			// Nest the child back for protojson, which resets x before unmarshaling
+			raw["billing"], _ = protojson.Marshal(child)
 		}
 	}
 
@@ -239,11 +255,19 @@ func (x *DualFlatten) UnmarshalJSON(data []byte) error {
 			if childErr != nil {
 				return childErr
 			}
-			x.Shipping = &Address{}
-			// Use json.Unmarshal to invoke child's UnmarshalJSON (annotation composability)
-			if childErr = json.Unmarshal(childData, x.Shipping); childErr != nil {
+			child := &Address{}
+			// Use the child's UnmarshalJSON when it has one (annotation composability);
+			// plain messages need protojson, since their struct tags carry proto names
+			if u, ok := any(child).(json.Unmarshaler); ok {
+				childErr = u.UnmarshalJSON(childData)
+			} else {
+				childErr = protojson.Unmarshal(childData, child)
+			}
+			if childErr != nil {
 				return childErr
 			}
+			// Nest the child back for protojson, which resets x before unmarshaling
+			raw["shipping"], _ = protojson.Marshal(child)
 		}
 	}
 
@@ -336,11 +360,19 @@ func (x *MixedFlatten) UnmarshalJSON(data []byte) error {
 			if childErr != nil {
 				return childErr
 			}
-			x.Address = &Address{}
-			// Use json.Unmarshal to invoke child's UnmarshalJSON (annotation composability)
-			if childErr = json.Unmarshal(childData, x.Address); childErr != nil {
+			child := &Address{}
+			// Use the child's UnmarshalJSON when it has one (annotation composability);
+			// plain messages need protojson, since their struct tags carry proto names
+			if u, ok := any(child).(json.Unmarshaler); ok {
+				childErr = u.UnmarshalJSON(childData)
+			} else {
+				childErr = protojson.Unmarshal(childData, child)
+			}
+			if childErr != nil {
 				return childErr
 			}
+			// Nest the child back for protojson, which resets x before unmarshaling
+			raw["address"], _ = protojson.Marshal(child)
 		}
 	}
 
@@ -5,6 +5,7 @@ package flatten
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -20,48 +21,227 @@ type FlattenServiceServer interface {
 // RegisterFlattenServiceServer registers the HTTP handlers for service FlattenService to the given mux.
 func RegisterFlattenServiceServer(server FlattenServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingFlattenServiceServer{slot: registeredFlattenServiceServers.Add(server)}
 
 	serviceHeaders := getFlattenServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getTestSimpleFlattenHeaders()
 	testSimpleFlattenHandler := BindingMiddleware[SimpleFlatten](
-		genericHandler(server.TestSimpleFlatten, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		testSimpleFlattenPathParams, testSimpleFlattenQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.TestSimpleFlatten, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		testSimpleFlattenPathParams, testSimpleFlattenQueryParams, testSimpleFlattenHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	testSimpleFlattenHandler = sebufhttp.MetricsMiddleware(testSimpleFlattenHandler, config.metrics, "testdata.flatten.FlattenService.TestSimpleFlatten")
+	testSimpleFlattenHandler = sebufhttp.ResponseHeadersMiddleware(testSimpleFlattenHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v1/flatten/simple", testSimpleFlattenHandler)
 
 	methodHeaders = getTestDualFlattenHeaders()
 	testDualFlattenHandler := BindingMiddleware[DualFlatten](
-		genericHandler(server.TestDualFlatten, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		testDualFlattenPathParams, testDualFlattenQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.TestDualFlatten, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		testDualFlattenPathParams, testDualFlattenQueryParams, testDualFlattenHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	testDualFlattenHandler = sebufhttp.MetricsMiddleware(testDualFlattenHandler, config.metrics, "testdata.flatten.FlattenService.TestDualFlatten")
+	testDualFlattenHandler = sebufhttp.ResponseHeadersMiddleware(testDualFlattenHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v1/flatten/dual", testDualFlattenHandler)
 
 	methodHeaders = getTestMixedFlattenHeaders()
 	testMixedFlattenHandler := BindingMiddleware[MixedFlatten](
-		genericHandler(server.TestMixedFlatten, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		testMixedFlattenPathParams, testMixedFlattenQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.TestMixedFlatten, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		testMixedFlattenPathParams, testMixedFlattenQueryParams, testMixedFlattenHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	testMixedFlattenHandler = sebufhttp.MetricsMiddleware(testMixedFlattenHandler, config.metrics, "testdata.flatten.FlattenService.TestMixedFlatten")
+	testMixedFlattenHandler = sebufhttp.ResponseHeadersMiddleware(testMixedFlattenHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v1/flatten/mixed", testMixedFlattenHandler)
 
 	methodHeaders = getTestPlainNestedHeaders()
 	testPlainNestedHandler := BindingMiddleware[PlainNested](
-		genericHandler(server.TestPlainNested, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		testPlainNestedPathParams, testPlainNestedQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.TestPlainNested, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		testPlainNestedPathParams, testPlainNestedQueryParams, testPlainNestedHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	testPlainNestedHandler = sebufhttp.MetricsMiddleware(testPlainNestedHandler, config.metrics, "testdata.flatten.FlattenService.TestPlainNested")
+	testPlainNestedHandler = sebufhttp.ResponseHeadersMiddleware(testPlainNestedHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v1/flatten/plain", testPlainNestedHandler)
 
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, flattenServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
+
 	return nil
 }
 
+// Paths of the routes RegisterFlattenServiceServer registers.
+const (
+	FlattenServicePathTestSimpleFlatten = "/api/v1/flatten/simple"
+	FlattenServicePathTestDualFlatten   = "/api/v1/flatten/dual"
+	FlattenServicePathTestMixedFlatten  = "/api/v1/flatten/mixed"
+	FlattenServicePathTestPlainNested   = "/api/v1/flatten/plain"
+)
+
+// FlattenServiceServerRoutes returns the routes RegisterFlattenServiceServer registers.
+func FlattenServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), flattenServiceRouteInfos...)
+}
+
+// flattenServiceRouteInfos lists the routes RegisterFlattenServiceServer registers.
+var flattenServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "POST", Path: FlattenServicePathTestSimpleFlatten, Service: "testdata.flatten.FlattenService", RPC: "TestSimpleFlatten"},
+	{Method: "POST", Path: FlattenServicePathTestDualFlatten, Service: "testdata.flatten.FlattenService", RPC: "TestDualFlatten"},
+	{Method: "POST", Path: FlattenServicePathTestMixedFlatten, Service: "testdata.flatten.FlattenService", RPC: "TestMixedFlatten"},
+	{Method: "POST", Path: FlattenServicePathTestPlainNested, Service: "testdata.flatten.FlattenService", RPC: "TestPlainNested"},
+}
+
+// registeredFlattenServiceServers holds the implementation of every FlattenService registration.
+var registeredFlattenServiceServers sebufhttp.ServerSlots[FlattenServiceServer]
+
+// UpdateFlattenServiceServer makes every handler registered by RegisterFlattenServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateFlattenServiceServer(server FlattenServiceServer) {
+	registeredFlattenServiceServers.Store(server)
+}
+
+// UnregisterFlattenServiceServer detaches the implementation from every handler
+// registered by RegisterFlattenServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateFlattenServiceServer installs a new implementation.
+func UnregisterFlattenServiceServer() {
+	registeredFlattenServiceServers.Clear()
+}
+
+// dispatchingFlattenServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingFlattenServiceServer struct {
+	slot *sebufhttp.ServerSlot[FlattenServiceServer]
+}
+
+func (d dispatchingFlattenServiceServer) TestSimpleFlatten(ctx context.Context, req *SimpleFlatten) (*SimpleFlatten, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service FlattenService is not registered"}
+	}
+	return server.TestSimpleFlatten(ctx, req)
+}
+
+func (d dispatchingFlattenServiceServer) TestDualFlatten(ctx context.Context, req *DualFlatten) (*DualFlatten, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service FlattenService is not registered"}
+	}
+	return server.TestDualFlatten(ctx, req)
+}
+
+func (d dispatchingFlattenServiceServer) TestMixedFlatten(ctx context.Context, req *MixedFlatten) (*MixedFlatten, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service FlattenService is not registered"}
+	}
+	return server.TestMixedFlatten(ctx, req)
+}
+
+func (d dispatchingFlattenServiceServer) TestPlainNested(ctx context.Context, req *PlainNested) (*PlainNested, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service FlattenService is not registered"}
+	}
+	return server.TestPlainNested(ctx, req)
+}
+
+// UnimplementedFlattenServiceServer can be embedded in FlattenServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedFlattenServiceServer struct{}
+
+func (UnimplementedFlattenServiceServer) TestSimpleFlatten(context.Context, *SimpleFlatten) (*SimpleFlatten, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method TestSimpleFlatten not implemented"}
+}
+
+func (UnimplementedFlattenServiceServer) TestDualFlatten(context.Context, *DualFlatten) (*DualFlatten, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method TestDualFlatten not implemented"}
+}
+
+func (UnimplementedFlattenServiceServer) TestMixedFlatten(context.Context, *MixedFlatten) (*MixedFlatten, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method TestMixedFlatten not implemented"}
+}
+
+func (UnimplementedFlattenServiceServer) TestPlainNested(context.Context, *PlainNested) (*PlainNested, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method TestPlainNested not implemented"}
+}
+
+// DecodeTestSimpleFlattenRequest binds r to a SimpleFlatten as the TestSimpleFlatten handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterFlattenServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeTestSimpleFlattenRequest(r *http.Request) (*SimpleFlatten, error) {
+	req := new(SimpleFlatten)
+	err := bindRequest(nil, r, req, testSimpleFlattenPathParams, testSimpleFlattenQueryParams, testSimpleFlattenHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeTestDualFlattenRequest binds r to a DualFlatten as the TestDualFlatten handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterFlattenServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeTestDualFlattenRequest(r *http.Request) (*DualFlatten, error) {
+	req := new(DualFlatten)
+	err := bindRequest(nil, r, req, testDualFlattenPathParams, testDualFlattenQueryParams, testDualFlattenHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeTestMixedFlattenRequest binds r to a MixedFlatten as the TestMixedFlatten handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterFlattenServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeTestMixedFlattenRequest(r *http.Request) (*MixedFlatten, error) {
+	req := new(MixedFlatten)
+	err := bindRequest(nil, r, req, testMixedFlattenPathParams, testMixedFlattenQueryParams, testMixedFlattenHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeTestPlainNestedRequest binds r to a PlainNested as the TestPlainNested handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterFlattenServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeTestPlainNestedRequest(r *http.Request) (*PlainNested, error) {
+	req := new(PlainNested)
+	err := bindRequest(nil, r, req, testPlainNestedPathParams, testPlainNestedQueryParams, testPlainNestedHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getFlattenServiceHeaders returns the service-level required headers for FlattenService
 func getFlattenServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -93,20 +273,32 @@ var testSimpleFlattenPathParams = []PathParamConfig{}
 // testSimpleFlattenQueryParams contains query parameter configuration for TestSimpleFlatten
 var testSimpleFlattenQueryParams = []QueryParamConfig{}
 
+// testSimpleFlattenHeaderFieldParams contains header-sourced field configuration for TestSimpleFlatten
+var testSimpleFlattenHeaderFieldParams = []HeaderParamConfig{}
+
 // testDualFlattenPathParams contains path parameter configuration for TestDualFlatten
 var testDualFlattenPathParams = []PathParamConfig{}
 
 // testDualFlattenQueryParams contains query parameter configuration for TestDualFlatten
 var testDualFlattenQueryParams = []QueryParamConfig{}
 
+// testDualFlattenHeaderFieldParams contains header-sourced field configuration for TestDualFlatten
+var testDualFlattenHeaderFieldParams = []HeaderParamConfig{}
+
 // testMixedFlattenPathParams contains path parameter configuration for TestMixedFlatten
 var testMixedFlattenPathParams = []PathParamConfig{}
 
 // testMixedFlattenQueryParams contains query parameter configuration for TestMixedFlatten
 var testMixedFlattenQueryParams = []QueryParamConfig{}
 
+// testMixedFlattenHeaderFieldParams contains header-sourced field configuration for TestMixedFlatten
+var testMixedFlattenHeaderFieldParams = []HeaderParamConfig{}
+
 // testPlainNestedPathParams contains path parameter configuration for TestPlainNested
 var testPlainNestedPathParams = []PathParamConfig{}
 
 // testPlainNestedQueryParams contains query parameter configuration for TestPlainNested
 var testPlainNestedQueryParams = []QueryParamConfig{}
+
+// testPlainNestedHeaderFieldParams contains header-sourced field configuration for TestPlainNested
+var testPlainNestedHeaderFieldParams = []HeaderParamConfig{}
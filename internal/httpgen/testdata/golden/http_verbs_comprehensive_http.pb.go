@@ -5,6 +5,8 @@ package generated
 
 import (
 	"context"
+	"net/http"
+	"time"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -25,98 +27,496 @@ type RESTfulAPIServiceServer interface {
 // RegisterRESTfulAPIServiceServer registers the HTTP handlers for service RESTfulAPIService to the given mux.
 func RegisterRESTfulAPIServiceServer(server RESTfulAPIServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingRESTfulAPIServiceServer{slot: registeredRESTfulAPIServiceServers.Add(server)}
 
 	serviceHeaders := getRESTfulAPIServiceHeaders()
 
+	idempotencyStore := config.idempotencyStore
+	if idempotencyStore == nil {
+		idempotencyStore = sebufhttp.NewMemoryIdempotencyStore()
+	}
+
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
+	var responseCache *sebufhttp.ResponseCache
+	if config.responseCacheSize > 0 {
+		responseCache = sebufhttp.NewResponseCache(config.responseCacheSize)
+	}
+
 	methodHeaders := getListResourcesHeaders()
 	listResourcesHandler := BindingMiddleware[ListResourcesRequest](
-		genericHandler(server.ListResources, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		listResourcesPathParams, listResourcesQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.ListResources, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		listResourcesPathParams, listResourcesQueryParams, listResourcesHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	listResourcesHandler = sebufhttp.MetricsMiddleware(listResourcesHandler, config.metrics, "test.httpgen.RESTfulAPIService.ListResources")
+	listResourcesHandler = sebufhttp.ResponseHeadersMiddleware(listResourcesHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/resources", listResourcesHandler)
+	config.mux.Handle("HEAD /api/v1/resources", sebufhttp.HeadHandler(listResourcesHandler))
 
 	methodHeaders = getGetResourceHeaders()
-	getResourceHandler := BindingMiddleware[GetResourceRequest](
-		genericHandler(server.GetResource, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getResourcePathParams, getResourceQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+	getResourceHandler := sebufhttp.ResponseCacheMiddleware(
+		genericHandler(server.GetResource, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody),
+		responseCache, "test.httpgen.RESTfulAPIService.GetResource",
+		sebufhttp.CachePolicy{MaxAge: 60 * time.Second, Public: true, VaryHeaders: []string{"X-Api-Key", "X-Client-Version"}},
 	)
+	getResourceHandler = BindingMiddleware[GetResourceRequest](
+		getResourceHandler, serviceHeaders, methodHeaders,
+		getResourcePathParams, getResourceQueryParams, getResourceHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
+	)
+	getResourceHandler = sebufhttp.MetricsMiddleware(getResourceHandler, config.metrics, "test.httpgen.RESTfulAPIService.GetResource")
+	getResourceHandler = sebufhttp.ResponseHeadersMiddleware(getResourceHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/resources/{resource_id}", getResourceHandler)
+	config.mux.Handle("HEAD /api/v1/resources/{resource_id}", sebufhttp.HeadHandler(getResourceHandler))
 
 	methodHeaders = getGetNestedResourceHeaders()
 	getNestedResourceHandler := BindingMiddleware[GetNestedResourceRequest](
-		genericHandler(server.GetNestedResource, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getNestedResourcePathParams, getNestedResourceQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetNestedResource, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getNestedResourcePathParams, getNestedResourceQueryParams, getNestedResourceHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getNestedResourceHandler = sebufhttp.MetricsMiddleware(getNestedResourceHandler, config.metrics, "test.httpgen.RESTfulAPIService.GetNestedResource")
+	getNestedResourceHandler = sebufhttp.ResponseHeadersMiddleware(getNestedResourceHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/orgs/{org_id}/teams/{team_id}/resources/{resource_id}", getNestedResourceHandler)
+	config.mux.Handle("HEAD /api/v1/orgs/{org_id}/teams/{team_id}/resources/{resource_id}", sebufhttp.HeadHandler(getNestedResourceHandler))
 
 	methodHeaders = getCreateResourceHeaders()
-	createResourceHandler := BindingMiddleware[CreateResourceRequest](
-		genericHandler(server.CreateResource, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		createResourcePathParams, createResourceQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+	createResourceHandler := sebufhttp.IdempotencyMiddleware(
+		genericHandler(server.CreateResource, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody),
+		idempotencyStore, "test.httpgen.RESTfulAPIService.CreateResource", config.idempotencyTTL, config.maxBodySize,
+		config.writeError,
+	)
+	createResourceHandler = BindingMiddleware[CreateResourceRequest](
+		createResourceHandler, serviceHeaders, methodHeaders,
+		createResourcePathParams, createResourceQueryParams, createResourceHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	createResourceHandler = sebufhttp.MetricsMiddleware(createResourceHandler, config.metrics, "test.httpgen.RESTfulAPIService.CreateResource")
+	createResourceHandler = sebufhttp.ResponseHeadersMiddleware(createResourceHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v1/resources", createResourceHandler)
 
 	methodHeaders = getUpdateResourceHeaders()
 	updateResourceHandler := BindingMiddleware[UpdateResourceRequest](
-		genericHandler(server.UpdateResource, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		updateResourcePathParams, updateResourceQueryParams,
-		"PUT", config.errorHandler, config.marshalOpts,
+		genericHandler(server.UpdateResource, config.errorHandler, config.marshalOpts, limiter, 5000*time.Millisecond, config.warningsInBody), serviceHeaders, methodHeaders,
+		updateResourcePathParams, updateResourceQueryParams, updateResourceHeaderFieldParams,
+		"PUT", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	updateResourceHandler = sebufhttp.MetricsMiddleware(updateResourceHandler, config.metrics, "test.httpgen.RESTfulAPIService.UpdateResource")
+	updateResourceHandler = sebufhttp.ResponseHeadersMiddleware(updateResourceHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("PUT /api/v1/resources/{resource_id}", updateResourceHandler)
 
 	methodHeaders = getPatchResourceHeaders()
 	patchResourceHandler := BindingMiddleware[PatchResourceRequest](
-		genericHandler(server.PatchResource, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		patchResourcePathParams, patchResourceQueryParams,
-		"PATCH", config.errorHandler, config.marshalOpts,
+		genericHandler(server.PatchResource, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		patchResourcePathParams, patchResourceQueryParams, patchResourceHeaderFieldParams,
+		"PATCH", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	patchResourceHandler = sebufhttp.MetricsMiddleware(patchResourceHandler, config.metrics, "test.httpgen.RESTfulAPIService.PatchResource")
+	patchResourceHandler = sebufhttp.ResponseHeadersMiddleware(patchResourceHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("PATCH /api/v1/resources/{resource_id}", patchResourceHandler)
 
 	methodHeaders = getDeleteResourceHeaders()
 	deleteResourceHandler := BindingMiddleware[DeleteResourceRequest](
-		genericHandler(server.DeleteResource, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		deleteResourcePathParams, deleteResourceQueryParams,
-		"DELETE", config.errorHandler, config.marshalOpts,
+		genericHandler(server.DeleteResource, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		deleteResourcePathParams, deleteResourceQueryParams, deleteResourceHeaderFieldParams,
+		"DELETE", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	deleteResourceHandler = sebufhttp.MetricsMiddleware(deleteResourceHandler, config.metrics, "test.httpgen.RESTfulAPIService.DeleteResource")
+	deleteResourceHandler = sebufhttp.ResponseHeadersMiddleware(deleteResourceHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("DELETE /api/v1/resources/{resource_id}", deleteResourceHandler)
 
 	methodHeaders = getDefaultPostMethodHeaders()
 	defaultPostMethodHandler := BindingMiddleware[DefaultPostRequest](
-		genericHandler(server.DefaultPostMethod, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		defaultPostMethodPathParams, defaultPostMethodQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.DefaultPostMethod, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		defaultPostMethodPathParams, defaultPostMethodQueryParams, defaultPostMethodHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing, ReportDeprecated: config.deprecationReporter, FullMethod: "test.httpgen.RESTfulAPIService.DefaultPostMethod"}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	defaultPostMethodHandler = sebufhttp.MetricsMiddleware(defaultPostMethodHandler, config.metrics, "test.httpgen.RESTfulAPIService.DefaultPostMethod")
+	defaultPostMethodHandler = sebufhttp.ResponseHeadersMiddleware(defaultPostMethodHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v1/legacy/action", defaultPostMethodHandler)
 
 	methodHeaders = getSearchResourcesHeaders()
 	searchResourcesHandler := BindingMiddleware[SearchResourcesRequest](
-		genericHandler(server.SearchResources, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		searchResourcesPathParams, searchResourcesQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.SearchResources, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		searchResourcesPathParams, searchResourcesQueryParams, searchResourcesHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	searchResourcesHandler = sebufhttp.MetricsMiddleware(searchResourcesHandler, config.metrics, "test.httpgen.RESTfulAPIService.SearchResources")
+	searchResourcesHandler = sebufhttp.ResponseHeadersMiddleware(searchResourcesHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/resources/search", searchResourcesHandler)
+	config.mux.Handle("HEAD /api/v1/resources/search", sebufhttp.HeadHandler(searchResourcesHandler))
+
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, rESTfulAPIServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
 
 	return nil
 }
 
+// Paths of the routes RegisterRESTfulAPIServiceServer registers.
+const (
+	RESTfulAPIServicePathListResources     = "/api/v1/resources"
+	RESTfulAPIServicePathGetResource       = "/api/v1/resources/{resource_id}"
+	RESTfulAPIServicePathGetNestedResource = "/api/v1/orgs/{org_id}/teams/{team_id}/resources/{resource_id}"
+	RESTfulAPIServicePathCreateResource    = "/api/v1/resources"
+	RESTfulAPIServicePathUpdateResource    = "/api/v1/resources/{resource_id}"
+	RESTfulAPIServicePathPatchResource     = "/api/v1/resources/{resource_id}"
+	RESTfulAPIServicePathDeleteResource    = "/api/v1/resources/{resource_id}"
+	RESTfulAPIServicePathDefaultPostMethod = "/api/v1/legacy/action"
+	RESTfulAPIServicePathSearchResources   = "/api/v1/resources/search"
+)
+
+// RESTfulAPIServicePathGetResourceFor returns RESTfulAPIServicePathGetResource with its wildcards replaced by
+// the URL-escaped values of resourceID.
+func RESTfulAPIServicePathGetResourceFor(resourceID string) string {
+	return sebufhttp.BuildPath(RESTfulAPIServicePathGetResource, resourceID)
+}
+
+// RESTfulAPIServicePathGetNestedResourceFor returns RESTfulAPIServicePathGetNestedResource with its wildcards replaced by
+// the URL-escaped values of orgID, teamID, resourceID.
+func RESTfulAPIServicePathGetNestedResourceFor(orgID, teamID, resourceID string) string {
+	return sebufhttp.BuildPath(RESTfulAPIServicePathGetNestedResource, orgID, teamID, resourceID)
+}
+
+// RESTfulAPIServicePathUpdateResourceFor returns RESTfulAPIServicePathUpdateResource with its wildcards replaced by
+// the URL-escaped values of resourceID.
+func RESTfulAPIServicePathUpdateResourceFor(resourceID string) string {
+	return sebufhttp.BuildPath(RESTfulAPIServicePathUpdateResource, resourceID)
+}
+
+// RESTfulAPIServicePathPatchResourceFor returns RESTfulAPIServicePathPatchResource with its wildcards replaced by
+// the URL-escaped values of resourceID.
+func RESTfulAPIServicePathPatchResourceFor(resourceID string) string {
+	return sebufhttp.BuildPath(RESTfulAPIServicePathPatchResource, resourceID)
+}
+
+// RESTfulAPIServicePathDeleteResourceFor returns RESTfulAPIServicePathDeleteResource with its wildcards replaced by
+// the URL-escaped values of resourceID.
+func RESTfulAPIServicePathDeleteResourceFor(resourceID string) string {
+	return sebufhttp.BuildPath(RESTfulAPIServicePathDeleteResource, resourceID)
+}
+
+// RESTfulAPIServiceServerRoutes returns the routes RegisterRESTfulAPIServiceServer registers.
+func RESTfulAPIServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), rESTfulAPIServiceRouteInfos...)
+}
+
+// rESTfulAPIServiceRouteInfos lists the routes RegisterRESTfulAPIServiceServer registers.
+var rESTfulAPIServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "GET", Path: RESTfulAPIServicePathListResources, Service: "test.httpgen.RESTfulAPIService", RPC: "ListResources", RequiredHeaders: []string{"X-API-Key", "X-Client-Version"}},
+	{Method: "GET", Path: RESTfulAPIServicePathGetResource, Service: "test.httpgen.RESTfulAPIService", RPC: "GetResource", RequiredHeaders: []string{"X-API-Key", "X-Client-Version"}},
+	{Method: "GET", Path: RESTfulAPIServicePathGetNestedResource, Service: "test.httpgen.RESTfulAPIService", RPC: "GetNestedResource", RequiredHeaders: []string{"X-API-Key", "X-Client-Version"}},
+	{Method: "POST", Path: RESTfulAPIServicePathCreateResource, Service: "test.httpgen.RESTfulAPIService", RPC: "CreateResource", RequiredHeaders: []string{"X-API-Key", "X-Client-Version", "X-Request-ID"}},
+	{Method: "PUT", Path: RESTfulAPIServicePathUpdateResource, Service: "test.httpgen.RESTfulAPIService", RPC: "UpdateResource", RequiredHeaders: []string{"X-API-Key", "X-Client-Version"}},
+	{Method: "PATCH", Path: RESTfulAPIServicePathPatchResource, Service: "test.httpgen.RESTfulAPIService", RPC: "PatchResource", RequiredHeaders: []string{"X-API-Key", "X-Client-Version"}},
+	{Method: "DELETE", Path: RESTfulAPIServicePathDeleteResource, Service: "test.httpgen.RESTfulAPIService", RPC: "DeleteResource", RequiredHeaders: []string{"X-API-Key", "X-Client-Version"}},
+	{Method: "POST", Path: RESTfulAPIServicePathDefaultPostMethod, Service: "test.httpgen.RESTfulAPIService", RPC: "DefaultPostMethod", RequiredHeaders: []string{"X-API-Key", "X-Client-Version"}},
+	{Method: "GET", Path: RESTfulAPIServicePathSearchResources, Service: "test.httpgen.RESTfulAPIService", RPC: "SearchResources", RequiredHeaders: []string{"X-API-Key", "X-Client-Version"}},
+}
+
+// registeredRESTfulAPIServiceServers holds the implementation of every RESTfulAPIService registration.
+var registeredRESTfulAPIServiceServers sebufhttp.ServerSlots[RESTfulAPIServiceServer]
+
+// UpdateRESTfulAPIServiceServer makes every handler registered by RegisterRESTfulAPIServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateRESTfulAPIServiceServer(server RESTfulAPIServiceServer) {
+	registeredRESTfulAPIServiceServers.Store(server)
+}
+
+// UnregisterRESTfulAPIServiceServer detaches the implementation from every handler
+// registered by RegisterRESTfulAPIServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateRESTfulAPIServiceServer installs a new implementation.
+func UnregisterRESTfulAPIServiceServer() {
+	registeredRESTfulAPIServiceServers.Clear()
+}
+
+// dispatchingRESTfulAPIServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingRESTfulAPIServiceServer struct {
+	slot *sebufhttp.ServerSlot[RESTfulAPIServiceServer]
+}
+
+func (d dispatchingRESTfulAPIServiceServer) ListResources(ctx context.Context, req *ListResourcesRequest) (*ListResourcesResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service RESTfulAPIService is not registered"}
+	}
+	return server.ListResources(ctx, req)
+}
+
+func (d dispatchingRESTfulAPIServiceServer) GetResource(ctx context.Context, req *GetResourceRequest) (*Resource, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service RESTfulAPIService is not registered"}
+	}
+	return server.GetResource(ctx, req)
+}
+
+func (d dispatchingRESTfulAPIServiceServer) GetNestedResource(ctx context.Context, req *GetNestedResourceRequest) (*Resource, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service RESTfulAPIService is not registered"}
+	}
+	return server.GetNestedResource(ctx, req)
+}
+
+func (d dispatchingRESTfulAPIServiceServer) CreateResource(ctx context.Context, req *CreateResourceRequest) (*Resource, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service RESTfulAPIService is not registered"}
+	}
+	return server.CreateResource(ctx, req)
+}
+
+func (d dispatchingRESTfulAPIServiceServer) UpdateResource(ctx context.Context, req *UpdateResourceRequest) (*Resource, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service RESTfulAPIService is not registered"}
+	}
+	return server.UpdateResource(ctx, req)
+}
+
+func (d dispatchingRESTfulAPIServiceServer) PatchResource(ctx context.Context, req *PatchResourceRequest) (*Resource, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service RESTfulAPIService is not registered"}
+	}
+	return server.PatchResource(ctx, req)
+}
+
+func (d dispatchingRESTfulAPIServiceServer) DeleteResource(ctx context.Context, req *DeleteResourceRequest) (*DeleteResourceResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service RESTfulAPIService is not registered"}
+	}
+	return server.DeleteResource(ctx, req)
+}
+
+func (d dispatchingRESTfulAPIServiceServer) DefaultPostMethod(ctx context.Context, req *DefaultPostRequest) (*DefaultPostResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service RESTfulAPIService is not registered"}
+	}
+	return server.DefaultPostMethod(ctx, req)
+}
+
+func (d dispatchingRESTfulAPIServiceServer) SearchResources(ctx context.Context, req *SearchResourcesRequest) (*ListResourcesResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service RESTfulAPIService is not registered"}
+	}
+	return server.SearchResources(ctx, req)
+}
+
+// UnimplementedRESTfulAPIServiceServer can be embedded in RESTfulAPIServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedRESTfulAPIServiceServer struct{}
+
+func (UnimplementedRESTfulAPIServiceServer) ListResources(context.Context, *ListResourcesRequest) (*ListResourcesResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method ListResources not implemented"}
+}
+
+func (UnimplementedRESTfulAPIServiceServer) GetResource(context.Context, *GetResourceRequest) (*Resource, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetResource not implemented"}
+}
+
+func (UnimplementedRESTfulAPIServiceServer) GetNestedResource(context.Context, *GetNestedResourceRequest) (*Resource, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetNestedResource not implemented"}
+}
+
+func (UnimplementedRESTfulAPIServiceServer) CreateResource(context.Context, *CreateResourceRequest) (*Resource, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method CreateResource not implemented"}
+}
+
+func (UnimplementedRESTfulAPIServiceServer) UpdateResource(context.Context, *UpdateResourceRequest) (*Resource, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method UpdateResource not implemented"}
+}
+
+func (UnimplementedRESTfulAPIServiceServer) PatchResource(context.Context, *PatchResourceRequest) (*Resource, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method PatchResource not implemented"}
+}
+
+func (UnimplementedRESTfulAPIServiceServer) DeleteResource(context.Context, *DeleteResourceRequest) (*DeleteResourceResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method DeleteResource not implemented"}
+}
+
+func (UnimplementedRESTfulAPIServiceServer) DefaultPostMethod(context.Context, *DefaultPostRequest) (*DefaultPostResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method DefaultPostMethod not implemented"}
+}
+
+func (UnimplementedRESTfulAPIServiceServer) SearchResources(context.Context, *SearchResourcesRequest) (*ListResourcesResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method SearchResources not implemented"}
+}
+
+// DecodeListResourcesRequest binds r to a ListResourcesRequest as the ListResources handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterRESTfulAPIServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeListResourcesRequest(r *http.Request) (*ListResourcesRequest, error) {
+	req := new(ListResourcesRequest)
+	err := bindRequest(nil, r, req, listResourcesPathParams, listResourcesQueryParams, listResourcesHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeGetResourceRequest binds r to a GetResourceRequest as the GetResource handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterRESTfulAPIServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetResourceRequest(r *http.Request) (*GetResourceRequest, error) {
+	req := new(GetResourceRequest)
+	err := bindRequest(nil, r, req, getResourcePathParams, getResourceQueryParams, getResourceHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeGetNestedResourceRequest binds r to a GetNestedResourceRequest as the GetNestedResource handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterRESTfulAPIServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetNestedResourceRequest(r *http.Request) (*GetNestedResourceRequest, error) {
+	req := new(GetNestedResourceRequest)
+	err := bindRequest(nil, r, req, getNestedResourcePathParams, getNestedResourceQueryParams, getNestedResourceHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeCreateResourceRequest binds r to a CreateResourceRequest as the CreateResource handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterRESTfulAPIServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeCreateResourceRequest(r *http.Request) (*CreateResourceRequest, error) {
+	req := new(CreateResourceRequest)
+	err := bindRequest(nil, r, req, createResourcePathParams, createResourceQueryParams, createResourceHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeUpdateResourceRequest binds r to a UpdateResourceRequest as the UpdateResource handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterRESTfulAPIServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeUpdateResourceRequest(r *http.Request) (*UpdateResourceRequest, error) {
+	req := new(UpdateResourceRequest)
+	err := bindRequest(nil, r, req, updateResourcePathParams, updateResourceQueryParams, updateResourceHeaderFieldParams,
+		"PUT", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodePatchResourceRequest binds r to a PatchResourceRequest as the PatchResource handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterRESTfulAPIServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodePatchResourceRequest(r *http.Request) (*PatchResourceRequest, error) {
+	req := new(PatchResourceRequest)
+	err := bindRequest(nil, r, req, patchResourcePathParams, patchResourceQueryParams, patchResourceHeaderFieldParams,
+		"PATCH", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeDeleteResourceRequest binds r to a DeleteResourceRequest as the DeleteResource handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterRESTfulAPIServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeDeleteResourceRequest(r *http.Request) (*DeleteResourceRequest, error) {
+	req := new(DeleteResourceRequest)
+	err := bindRequest(nil, r, req, deleteResourcePathParams, deleteResourceQueryParams, deleteResourceHeaderFieldParams,
+		"DELETE", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeDefaultPostMethodRequest binds r to a DefaultPostRequest as the DefaultPostMethod handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterRESTfulAPIServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeDefaultPostMethodRequest(r *http.Request) (*DefaultPostRequest, error) {
+	req := new(DefaultPostRequest)
+	err := bindRequest(nil, r, req, defaultPostMethodPathParams, defaultPostMethodQueryParams, defaultPostMethodHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeSearchResourcesRequest binds r to a SearchResourcesRequest as the SearchResources handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterRESTfulAPIServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeSearchResourcesRequest(r *http.Request) (*SearchResourcesRequest, error) {
+	req := new(SearchResourcesRequest)
+	err := bindRequest(nil, r, req, searchResourcesPathParams, searchResourcesQueryParams, searchResourcesHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getRESTfulAPIServiceHeaders returns the service-level required headers for RESTfulAPIService
 func getRESTfulAPIServiceHeaders() []*sebufhttp.Header {
 	return []*sebufhttp.Header{
 		{
-			Name:        "X-API-Key",
+			Name:        "X-Api-Key",
 			Description: "API key for authentication",
 			Type:        "string",
 			Required:    true,
@@ -124,12 +524,43 @@ func getRESTfulAPIServiceHeaders() []*sebufhttp.Header {
 			Example:     "",
 			Deprecated:  false,
 		},
+		{
+			Name:         "X-Client-Version",
+			Description:  "Version of the calling client",
+			Type:         "string",
+			Required:     true,
+			Format:       "",
+			Example:      "",
+			Deprecated:   false,
+			DefaultValue: "1.0.0",
+		},
 	}
 }
 
 // getListResourcesHeaders returns the method-level required headers for ListResources
 func getListResourcesHeaders() []*sebufhttp.Header {
-	return nil
+	return []*sebufhttp.Header{
+		{
+			Name:         "Accept-Language",
+			Description:  "",
+			Type:         "string",
+			Required:     false,
+			Format:       "",
+			Example:      "",
+			Deprecated:   false,
+			DefaultValue: "en-US",
+		},
+		{
+			Name:        "X-Resource-Tag",
+			Description: "",
+			Type:        "string",
+			Required:    false,
+			Format:      "",
+			Example:     "",
+			Deprecated:  false,
+			Multiple:    true,
+		},
+	}
 }
 
 // getGetResourceHeaders returns the method-level required headers for GetResource
@@ -146,7 +577,7 @@ func getGetNestedResourceHeaders() []*sebufhttp.Header {
 func getCreateResourceHeaders() []*sebufhttp.Header {
 	return []*sebufhttp.Header{
 		{
-			Name:        "X-Request-ID",
+			Name:        "X-Request-Id",
 			Description: "",
 			Type:        "string",
 			Required:    true,
@@ -182,6 +613,41 @@ func getSearchResourcesHeaders() []*sebufhttp.Header {
 	return nil
 }
 
+// RESTfulAPIServiceHeaders holds the names of the headers RESTfulAPIService and its methods declare.
+var RESTfulAPIServiceHeaders = struct {
+	// XAPIKey is the X-API-Key header: API key for authentication.
+	XAPIKey string
+	// XClientVersion is the X-Client-Version header: Version of the calling client.
+	XClientVersion string
+	// AcceptLanguage is the Accept-Language header.
+	AcceptLanguage string
+	// XResourceTag is the X-Resource-Tag header.
+	XResourceTag string
+	// XRequestID is the X-Request-ID header.
+	XRequestID string
+}{
+	XAPIKey:        "X-API-Key",
+	XClientVersion: "X-Client-Version",
+	AcceptLanguage: "Accept-Language",
+	XResourceTag:   "X-Resource-Tag",
+	XRequestID:     "X-Request-ID",
+}
+
+// RESTfulAPIServiceHeaderSpecs describes the headers of RESTfulAPIServiceHeaders, under the same names.
+var RESTfulAPIServiceHeaderSpecs = struct {
+	XAPIKey        sebufhttp.HeaderSpec
+	XClientVersion sebufhttp.HeaderSpec
+	AcceptLanguage sebufhttp.HeaderSpec
+	XResourceTag   sebufhttp.HeaderSpec
+	XRequestID     sebufhttp.HeaderSpec
+}{
+	XAPIKey:        sebufhttp.HeaderSpec{Name: "X-API-Key", Type: "string", Format: "uuid", Required: true},
+	XClientVersion: sebufhttp.HeaderSpec{Name: "X-Client-Version", Type: "string", Required: true, DefaultValue: "1.0.0"},
+	AcceptLanguage: sebufhttp.HeaderSpec{Name: "Accept-Language", Type: "string", DefaultValue: "en-US"},
+	XResourceTag:   sebufhttp.HeaderSpec{Name: "X-Resource-Tag", Type: "string", Multiple: true},
+	XRequestID:     sebufhttp.HeaderSpec{Name: "X-Request-ID", Type: "string", Format: "uuid", Required: true},
+}
+
 // listResourcesPathParams contains path parameter configuration for ListResources
 var listResourcesPathParams = []PathParamConfig{}
 
@@ -197,6 +663,9 @@ var listResourcesQueryParams = []QueryParamConfig{
 	{QueryName: "max_score", FieldName: "max_score", Required: false},
 }
 
+// listResourcesHeaderFieldParams contains header-sourced field configuration for ListResources
+var listResourcesHeaderFieldParams = []HeaderParamConfig{}
+
 // getResourcePathParams contains path parameter configuration for GetResource
 var getResourcePathParams = []PathParamConfig{
 	{URLParam: "resource_id", FieldName: "resource_id"},
@@ -205,6 +674,9 @@ var getResourcePathParams = []PathParamConfig{
 // getResourceQueryParams contains query parameter configuration for GetResource
 var getResourceQueryParams = []QueryParamConfig{}
 
+// getResourceHeaderFieldParams contains header-sourced field configuration for GetResource
+var getResourceHeaderFieldParams = []HeaderParamConfig{}
+
 // getNestedResourcePathParams contains path parameter configuration for GetNestedResource
 var getNestedResourcePathParams = []PathParamConfig{
 	{URLParam: "org_id", FieldName: "org_id"},
@@ -215,12 +687,18 @@ var getNestedResourcePathParams = []PathParamConfig{
 // getNestedResourceQueryParams contains query parameter configuration for GetNestedResource
 var getNestedResourceQueryParams = []QueryParamConfig{}
 
+// getNestedResourceHeaderFieldParams contains header-sourced field configuration for GetNestedResource
+var getNestedResourceHeaderFieldParams = []HeaderParamConfig{}
+
 // createResourcePathParams contains path parameter configuration for CreateResource
 var createResourcePathParams = []PathParamConfig{}
 
 // createResourceQueryParams contains query parameter configuration for CreateResource
 var createResourceQueryParams = []QueryParamConfig{}
 
+// createResourceHeaderFieldParams contains header-sourced field configuration for CreateResource
+var createResourceHeaderFieldParams = []HeaderParamConfig{}
+
 // updateResourcePathParams contains path parameter configuration for UpdateResource
 var updateResourcePathParams = []PathParamConfig{
 	{URLParam: "resource_id", FieldName: "resource_id"},
@@ -229,6 +707,9 @@ var updateResourcePathParams = []PathParamConfig{
 // updateResourceQueryParams contains query parameter configuration for UpdateResource
 var updateResourceQueryParams = []QueryParamConfig{}
 
+// updateResourceHeaderFieldParams contains header-sourced field configuration for UpdateResource
+var updateResourceHeaderFieldParams = []HeaderParamConfig{}
+
 // patchResourcePathParams contains path parameter configuration for PatchResource
 var patchResourcePathParams = []PathParamConfig{
 	{URLParam: "resource_id", FieldName: "resource_id"},
@@ -237,6 +718,9 @@ var patchResourcePathParams = []PathParamConfig{
 // patchResourceQueryParams contains query parameter configuration for PatchResource
 var patchResourceQueryParams = []QueryParamConfig{}
 
+// patchResourceHeaderFieldParams contains header-sourced field configuration for PatchResource
+var patchResourceHeaderFieldParams = []HeaderParamConfig{}
+
 // deleteResourcePathParams contains path parameter configuration for DeleteResource
 var deleteResourcePathParams = []PathParamConfig{
 	{URLParam: "resource_id", FieldName: "resource_id"},
@@ -245,12 +729,18 @@ var deleteResourcePathParams = []PathParamConfig{
 // deleteResourceQueryParams contains query parameter configuration for DeleteResource
 var deleteResourceQueryParams = []QueryParamConfig{}
 
+// deleteResourceHeaderFieldParams contains header-sourced field configuration for DeleteResource
+var deleteResourceHeaderFieldParams = []HeaderParamConfig{}
+
 // defaultPostMethodPathParams contains path parameter configuration for DefaultPostMethod
 var defaultPostMethodPathParams = []PathParamConfig{}
 
 // defaultPostMethodQueryParams contains query parameter configuration for DefaultPostMethod
 var defaultPostMethodQueryParams = []QueryParamConfig{}
 
+// defaultPostMethodHeaderFieldParams contains header-sourced field configuration for DefaultPostMethod
+var defaultPostMethodHeaderFieldParams = []HeaderParamConfig{}
+
 // searchResourcesPathParams contains path parameter configuration for SearchResources
 var searchResourcesPathParams = []PathParamConfig{}
 
@@ -260,6 +750,9 @@ var searchResourcesQueryParams = []QueryParamConfig{
 	{QueryName: "q", FieldName: "query", Required: false},
 }
 
+// searchResourcesHeaderFieldParams contains header-sourced field configuration for SearchResources
+var searchResourcesHeaderFieldParams = []HeaderParamConfig{}
+
 // BackwardCompatServiceServer is the server API for BackwardCompatService service.
 type BackwardCompatServiceServer interface {
 	LegacyAction(context.Context, *LegacyRequest) (*LegacyResponse, error)
@@ -268,21 +761,101 @@ type BackwardCompatServiceServer interface {
 // RegisterBackwardCompatServiceServer registers the HTTP handlers for service BackwardCompatService to the given mux.
 func RegisterBackwardCompatServiceServer(server BackwardCompatServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingBackwardCompatServiceServer{slot: registeredBackwardCompatServiceServers.Add(server)}
 
 	serviceHeaders := getBackwardCompatServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getLegacyActionHeaders()
 	legacyActionHandler := BindingMiddleware[LegacyRequest](
-		genericHandler(server.LegacyAction, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		legacyActionPathParams, legacyActionQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.LegacyAction, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		legacyActionPathParams, legacyActionQueryParams, legacyActionHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	legacyActionHandler = sebufhttp.MetricsMiddleware(legacyActionHandler, config.metrics, "test.httpgen.BackwardCompatService.LegacyAction")
+	legacyActionHandler = sebufhttp.ResponseHeadersMiddleware(legacyActionHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /generated/legacy_action", legacyActionHandler)
 
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, backwardCompatServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
+
 	return nil
 }
 
+// Paths of the routes RegisterBackwardCompatServiceServer registers.
+const (
+	BackwardCompatServicePathLegacyAction = "/generated/legacy_action"
+)
+
+// BackwardCompatServiceServerRoutes returns the routes RegisterBackwardCompatServiceServer registers.
+func BackwardCompatServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), backwardCompatServiceRouteInfos...)
+}
+
+// backwardCompatServiceRouteInfos lists the routes RegisterBackwardCompatServiceServer registers.
+var backwardCompatServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "POST", Path: BackwardCompatServicePathLegacyAction, Service: "test.httpgen.BackwardCompatService", RPC: "LegacyAction"},
+}
+
+// registeredBackwardCompatServiceServers holds the implementation of every BackwardCompatService registration.
+var registeredBackwardCompatServiceServers sebufhttp.ServerSlots[BackwardCompatServiceServer]
+
+// UpdateBackwardCompatServiceServer makes every handler registered by RegisterBackwardCompatServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateBackwardCompatServiceServer(server BackwardCompatServiceServer) {
+	registeredBackwardCompatServiceServers.Store(server)
+}
+
+// UnregisterBackwardCompatServiceServer detaches the implementation from every handler
+// registered by RegisterBackwardCompatServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateBackwardCompatServiceServer installs a new implementation.
+func UnregisterBackwardCompatServiceServer() {
+	registeredBackwardCompatServiceServers.Clear()
+}
+
+// dispatchingBackwardCompatServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingBackwardCompatServiceServer struct {
+	slot *sebufhttp.ServerSlot[BackwardCompatServiceServer]
+}
+
+func (d dispatchingBackwardCompatServiceServer) LegacyAction(ctx context.Context, req *LegacyRequest) (*LegacyResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service BackwardCompatService is not registered"}
+	}
+	return server.LegacyAction(ctx, req)
+}
+
+// UnimplementedBackwardCompatServiceServer can be embedded in BackwardCompatServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedBackwardCompatServiceServer struct{}
+
+func (UnimplementedBackwardCompatServiceServer) LegacyAction(context.Context, *LegacyRequest) (*LegacyResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method LegacyAction not implemented"}
+}
+
+// DecodeLegacyActionRequest binds r to a LegacyRequest as the LegacyAction handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterBackwardCompatServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeLegacyActionRequest(r *http.Request) (*LegacyRequest, error) {
+	req := new(LegacyRequest)
+	err := bindRequest(nil, r, req, legacyActionPathParams, legacyActionQueryParams, legacyActionHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getBackwardCompatServiceHeaders returns the service-level required headers for BackwardCompatService
 func getBackwardCompatServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -298,3 +871,6 @@ var legacyActionPathParams = []PathParamConfig{}
 
 // legacyActionQueryParams contains query parameter configuration for LegacyAction
 var legacyActionQueryParams = []QueryParamConfig{}
+
+// legacyActionHeaderFieldParams contains header-sourced field configuration for LegacyAction
+var legacyActionHeaderFieldParams = []HeaderParamConfig{}
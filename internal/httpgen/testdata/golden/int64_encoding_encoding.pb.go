@@ -8,6 +8,8 @@ import (
 	"strconv"
 
 	"google.golang.org/protobuf/encoding/protojson"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
 
 // MarshalJSONSebuf implements sebufMarshaler for Int64EncodingTest.
@@ -99,6 +101,23 @@ func (x *Int64EncodingTest) MarshalJSON() ([]byte, error) {
 	return x.MarshalJSONSebuf(protojson.MarshalOptions{})
 }
 
+// int64EncodingTestAlternateJSONNames maps the proto names and JSON names protojson
+// accepts for the fields of Int64EncodingTest to the keys UnmarshalJSON reads.
+var int64EncodingTestAlternateJSONNames = map[string]string{
+	"commented_number_int64": "commentedNumberInt64",
+	"default_int64":          "defaultInt64",
+	"default_uint64":         "defaultUint64",
+	"number_fixed64":         "numberFixed64",
+	"number_int64":           "numberInt64",
+	"number_sfixed64":        "numberSfixed64",
+	"number_sint64":          "numberSint64",
+	"number_uint64":          "numberUint64",
+	"optional_number_int64":  "optionalNumberInt64",
+	"repeated_default_int64": "repeatedDefaultInt64",
+	"repeated_number_int64":  "repeatedNumberInt64",
+	"string_int64":           "stringInt64",
+}
+
 // UnmarshalJSONSebuf implements sebufUnmarshaler for Int64EncodingTest.
 // This method handles int64_encoding=NUMBER fields: number_int64, number_uint64, number_sint64, number_sfixed64, number_fixed64, repeated_number_int64, optional_number_int64, commented_number_int64
 func (x *Int64EncodingTest) UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error {
@@ -107,6 +126,9 @@ func (x *Int64EncodingTest) UnmarshalJSONSebuf(data []byte, opts protojson.Unmar
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	if err := sebufhttp.ResolveAlternateJSONNames(raw, int64EncodingTestAlternateJSONNames); err != nil {
+		return err
+	}
 
 	// Convert numberInt64 from number to string for protojson
 	if rawVal, ok := raw["numberInt64"]; ok {
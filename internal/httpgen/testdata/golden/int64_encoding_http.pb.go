@@ -5,6 +5,7 @@ package int64encoding
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -17,21 +18,108 @@ type Int64EncodingServiceServer interface {
 // RegisterInt64EncodingServiceServer registers the HTTP handlers for service Int64EncodingService to the given mux.
 func RegisterInt64EncodingServiceServer(server Int64EncodingServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingInt64EncodingServiceServer{slot: registeredInt64EncodingServiceServers.Add(server)}
 
 	serviceHeaders := getInt64EncodingServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getGetInt64TestHeaders()
 	getInt64TestHandler := BindingMiddleware[GetInt64TestRequest](
-		genericHandler(server.GetInt64Test, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getInt64TestPathParams, getInt64TestQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetInt64Test, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getInt64TestPathParams, getInt64TestQueryParams, getInt64TestHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getInt64TestHandler = sebufhttp.MetricsMiddleware(getInt64TestHandler, config.metrics, "testdata.int64encoding.Int64EncodingService.GetInt64Test")
+	getInt64TestHandler = sebufhttp.ResponseHeadersMiddleware(getInt64TestHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/test/int64/{id}", getInt64TestHandler)
+	config.mux.Handle("HEAD /api/v1/test/int64/{id}", sebufhttp.HeadHandler(getInt64TestHandler))
+
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, int64EncodingServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
 
 	return nil
 }
 
+// Paths of the routes RegisterInt64EncodingServiceServer registers.
+const (
+	Int64EncodingServicePathGetInt64Test = "/api/v1/test/int64/{id}"
+)
+
+// Int64EncodingServicePathGetInt64TestFor returns Int64EncodingServicePathGetInt64Test with its wildcards replaced by
+// the URL-escaped values of id.
+func Int64EncodingServicePathGetInt64TestFor(id string) string {
+	return sebufhttp.BuildPath(Int64EncodingServicePathGetInt64Test, id)
+}
+
+// Int64EncodingServiceServerRoutes returns the routes RegisterInt64EncodingServiceServer registers.
+func Int64EncodingServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), int64EncodingServiceRouteInfos...)
+}
+
+// int64EncodingServiceRouteInfos lists the routes RegisterInt64EncodingServiceServer registers.
+var int64EncodingServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "GET", Path: Int64EncodingServicePathGetInt64Test, Service: "testdata.int64encoding.Int64EncodingService", RPC: "GetInt64Test"},
+}
+
+// registeredInt64EncodingServiceServers holds the implementation of every Int64EncodingService registration.
+var registeredInt64EncodingServiceServers sebufhttp.ServerSlots[Int64EncodingServiceServer]
+
+// UpdateInt64EncodingServiceServer makes every handler registered by RegisterInt64EncodingServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateInt64EncodingServiceServer(server Int64EncodingServiceServer) {
+	registeredInt64EncodingServiceServers.Store(server)
+}
+
+// UnregisterInt64EncodingServiceServer detaches the implementation from every handler
+// registered by RegisterInt64EncodingServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateInt64EncodingServiceServer installs a new implementation.
+func UnregisterInt64EncodingServiceServer() {
+	registeredInt64EncodingServiceServers.Clear()
+}
+
+// dispatchingInt64EncodingServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingInt64EncodingServiceServer struct {
+	slot *sebufhttp.ServerSlot[Int64EncodingServiceServer]
+}
+
+func (d dispatchingInt64EncodingServiceServer) GetInt64Test(ctx context.Context, req *GetInt64TestRequest) (*Int64EncodingTest, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service Int64EncodingService is not registered"}
+	}
+	return server.GetInt64Test(ctx, req)
+}
+
+// UnimplementedInt64EncodingServiceServer can be embedded in Int64EncodingServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedInt64EncodingServiceServer struct{}
+
+func (UnimplementedInt64EncodingServiceServer) GetInt64Test(context.Context, *GetInt64TestRequest) (*Int64EncodingTest, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetInt64Test not implemented"}
+}
+
+// DecodeGetInt64TestRequest binds r to a GetInt64TestRequest as the GetInt64Test handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterInt64EncodingServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetInt64TestRequest(r *http.Request) (*GetInt64TestRequest, error) {
+	req := new(GetInt64TestRequest)
+	err := bindRequest(nil, r, req, getInt64TestPathParams, getInt64TestQueryParams, getInt64TestHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getInt64EncodingServiceHeaders returns the service-level required headers for Int64EncodingService
 func getInt64EncodingServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -49,3 +137,6 @@ var getInt64TestPathParams = []PathParamConfig{
 
 // getInt64TestQueryParams contains query parameter configuration for GetInt64Test
 var getInt64TestQueryParams = []QueryParamConfig{}
+
+// getInt64TestHeaderFieldParams contains header-sourced field configuration for GetInt64Test
+var getInt64TestHeaderFieldParams = []HeaderParamConfig{}
@@ -5,6 +5,7 @@ package int64nestedencoding
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -18,30 +19,157 @@ type SensorServiceServer interface {
 // RegisterSensorServiceServer registers the HTTP handlers for service SensorService to the given mux.
 func RegisterSensorServiceServer(server SensorServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingSensorServiceServer{slot: registeredSensorServiceServers.Add(server)}
 
 	serviceHeaders := getSensorServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getGetSensorReadingHeaders()
 	getSensorReadingHandler := BindingMiddleware[GetSensorRequest](
-		genericHandler(server.GetSensorReading, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getSensorReadingPathParams, getSensorReadingQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetSensorReading, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getSensorReadingPathParams, getSensorReadingQueryParams, getSensorReadingHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getSensorReadingHandler = sebufhttp.MetricsMiddleware(getSensorReadingHandler, config.metrics, "testdata.int64nestedencoding.SensorService.GetSensorReading")
+	getSensorReadingHandler = sebufhttp.ResponseHeadersMiddleware(getSensorReadingHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/sensors/{sensor_id}", getSensorReadingHandler)
+	config.mux.Handle("HEAD /api/v1/sensors/{sensor_id}", sebufhttp.HeadHandler(getSensorReadingHandler))
 
 	methodHeaders = getGetMultiSensorHeaders()
 	getMultiSensorHandler := BindingMiddleware[GetSensorRequest](
-		genericHandler(server.GetMultiSensor, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getMultiSensorPathParams, getMultiSensorQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetMultiSensor, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getMultiSensorPathParams, getMultiSensorQueryParams, getMultiSensorHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getMultiSensorHandler = sebufhttp.MetricsMiddleware(getMultiSensorHandler, config.metrics, "testdata.int64nestedencoding.SensorService.GetMultiSensor")
+	getMultiSensorHandler = sebufhttp.ResponseHeadersMiddleware(getMultiSensorHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/sensors/{sensor_id}/multi", getMultiSensorHandler)
+	config.mux.Handle("HEAD /api/v1/sensors/{sensor_id}/multi", sebufhttp.HeadHandler(getMultiSensorHandler))
+
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, sensorServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
 
 	return nil
 }
 
+// Paths of the routes RegisterSensorServiceServer registers.
+const (
+	SensorServicePathGetSensorReading = "/api/v1/sensors/{sensor_id}"
+	SensorServicePathGetMultiSensor   = "/api/v1/sensors/{sensor_id}/multi"
+)
+
+// SensorServicePathGetSensorReadingFor returns SensorServicePathGetSensorReading with its wildcards replaced by
+// the URL-escaped values of sensorID.
+func SensorServicePathGetSensorReadingFor(sensorID string) string {
+	return sebufhttp.BuildPath(SensorServicePathGetSensorReading, sensorID)
+}
+
+// SensorServicePathGetMultiSensorFor returns SensorServicePathGetMultiSensor with its wildcards replaced by
+// the URL-escaped values of sensorID.
+func SensorServicePathGetMultiSensorFor(sensorID string) string {
+	return sebufhttp.BuildPath(SensorServicePathGetMultiSensor, sensorID)
+}
+
+// SensorServiceServerRoutes returns the routes RegisterSensorServiceServer registers.
+func SensorServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), sensorServiceRouteInfos...)
+}
+
+// sensorServiceRouteInfos lists the routes RegisterSensorServiceServer registers.
+var sensorServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "GET", Path: SensorServicePathGetSensorReading, Service: "testdata.int64nestedencoding.SensorService", RPC: "GetSensorReading"},
+	{Method: "GET", Path: SensorServicePathGetMultiSensor, Service: "testdata.int64nestedencoding.SensorService", RPC: "GetMultiSensor"},
+}
+
+// registeredSensorServiceServers holds the implementation of every SensorService registration.
+var registeredSensorServiceServers sebufhttp.ServerSlots[SensorServiceServer]
+
+// UpdateSensorServiceServer makes every handler registered by RegisterSensorServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateSensorServiceServer(server SensorServiceServer) {
+	registeredSensorServiceServers.Store(server)
+}
+
+// UnregisterSensorServiceServer detaches the implementation from every handler
+// registered by RegisterSensorServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateSensorServiceServer installs a new implementation.
+func UnregisterSensorServiceServer() {
+	registeredSensorServiceServers.Clear()
+}
+
+// dispatchingSensorServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingSensorServiceServer struct {
+	slot *sebufhttp.ServerSlot[SensorServiceServer]
+}
+
+func (d dispatchingSensorServiceServer) GetSensorReading(ctx context.Context, req *GetSensorRequest) (*GetSensorReadingResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service SensorService is not registered"}
+	}
+	return server.GetSensorReading(ctx, req)
+}
+
+func (d dispatchingSensorServiceServer) GetMultiSensor(ctx context.Context, req *GetSensorRequest) (*GetMultiSensorResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service SensorService is not registered"}
+	}
+	return server.GetMultiSensor(ctx, req)
+}
+
+// UnimplementedSensorServiceServer can be embedded in SensorServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedSensorServiceServer struct{}
+
+func (UnimplementedSensorServiceServer) GetSensorReading(context.Context, *GetSensorRequest) (*GetSensorReadingResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetSensorReading not implemented"}
+}
+
+func (UnimplementedSensorServiceServer) GetMultiSensor(context.Context, *GetSensorRequest) (*GetMultiSensorResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetMultiSensor not implemented"}
+}
+
+// DecodeGetSensorReadingRequest binds r to a GetSensorRequest as the GetSensorReading handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterSensorServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetSensorReadingRequest(r *http.Request) (*GetSensorRequest, error) {
+	req := new(GetSensorRequest)
+	err := bindRequest(nil, r, req, getSensorReadingPathParams, getSensorReadingQueryParams, getSensorReadingHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeGetMultiSensorRequest binds r to a GetSensorRequest as the GetMultiSensor handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterSensorServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetMultiSensorRequest(r *http.Request) (*GetSensorRequest, error) {
+	req := new(GetSensorRequest)
+	err := bindRequest(nil, r, req, getMultiSensorPathParams, getMultiSensorQueryParams, getMultiSensorHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getSensorServiceHeaders returns the service-level required headers for SensorService
 func getSensorServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -65,6 +193,9 @@ var getSensorReadingPathParams = []PathParamConfig{
 // getSensorReadingQueryParams contains query parameter configuration for GetSensorReading
 var getSensorReadingQueryParams = []QueryParamConfig{}
 
+// getSensorReadingHeaderFieldParams contains header-sourced field configuration for GetSensorReading
+var getSensorReadingHeaderFieldParams = []HeaderParamConfig{}
+
 // getMultiSensorPathParams contains path parameter configuration for GetMultiSensor
 var getMultiSensorPathParams = []PathParamConfig{
 	{URLParam: "sensor_id", FieldName: "sensor_id"},
@@ -72,3 +203,6 @@ var getMultiSensorPathParams = []PathParamConfig{
 
 // getMultiSensorQueryParams contains query parameter configuration for GetMultiSensor
 var getMultiSensorQueryParams = []QueryParamConfig{}
+
+// getMultiSensorHeaderFieldParams contains header-sourced field configuration for GetMultiSensor
+var getMultiSensorHeaderFieldParams = []HeaderParamConfig{}
@@ -10,28 +10,32 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
-	"unicode/utf8"
 
-	protovalidate "buf.build/go/protovalidate"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
 
 const (
 	// JSONContentType is the content type for JSON
-	JSONContentType = "application/json"
+	JSONContentType = sebufhttp.JSONContentType
 	// BinaryContentType is the content type for binary protobuf
-	BinaryContentType = "application/octet-stream"
+	BinaryContentType = sebufhttp.BinaryContentType
 	// ProtoContentType is the content type for protobuf
-	ProtoContentType = "application/x-protobuf"
+	ProtoContentType = sebufhttp.ProtoContentType
+	// FormContentType is the content type for URL-encoded forms (methods with accept_form)
+	FormContentType = sebufhttp.FormContentType
+	// MultipartContentType is the content type for multipart forms (methods with accept_multipart)
+	MultipartContentType = sebufhttp.MultipartContentType
 )
 
 type bodyCtxKey struct{}
@@ -54,6 +58,26 @@ type QueryParamConfig struct {
 	QueryName string // Parameter name in query string
 	FieldName string // Proto field name to bind to
 	Required  bool   // Whether this parameter is required
+	Exclusive bool   // Declared with source QUERY: any value from the body is discarded
+}
+
+// HeaderParamConfig defines a request field read from a request header
+// (declared with source HEADER). Any value from the body is discarded.
+type HeaderParamConfig struct {
+	HeaderName string // Request header name
+	FieldName  string // Proto field name to bind to
+}
+
+// BodyConfig defines how a method's request body is bound and validated.
+type BodyConfig struct {
+	AcceptForm        bool                   // Also bind application/x-www-form-urlencoded bodies (accept_form)
+	AcceptMultipart   bool                   // Also bind multipart/form-data bodies (accept_multipart)
+	StrictJSON        bool                   // Reject JSON bodies with unknown keys (strict_json, WithStrictJSON)
+	MaxSize           int64                  // Larger bodies are answered with 413; zero means no limit
+	TypeResolver      sebufhttp.TypeResolver // Resolves Any type URLs in JSON bodies (WithTypeResolver)
+	OptionalBody      bool                   // No body field is required: empty bodies are not read
+	NoValidationRules bool                   // The request has no buf.validate rules: it is not validated
+	NoContentSniffing bool                   // Skip the Content-Type sniffing (WithoutContentSniffing)
 }
 
 func getRequest[Req any](ctx context.Context) Req {
@@ -67,59 +91,41 @@ func getRequest[Req any](ctx context.Context) Req {
 
 // BindingMiddleware creates a middleware that binds HTTP requests to protobuf messages
 // and validates them using protovalidate and header validation.
-// It supports path parameters, query parameters, and request body binding.
+// It supports path parameters, query parameters, header fields, and request body binding.
+// validationPolicy may relax validation per request (see WithValidationPolicy), and
+// violationFormatter describes the violations (see WithViolationFormatter),
+// validationFailureMode handles messages that cannot be validated (see
+// WithValidationFailureMode), and authenticators verify the credentials of
+// request headers (see WithHeaderAuthenticator).
+// body configures the accepted body encodings and the maximum body size, and
+// timeoutHeader bounds the request's context (see WithTimeoutHeader).
 func BindingMiddleware[Req any](next http.Handler, serviceHeaders, methodHeaders []*sebufhttp.Header,
-	pathParams []PathParamConfig, queryParams []QueryParamConfig, httpMethod string, errorHandler ErrorHandler, marshalOpts protojson.MarshalOptions) http.Handler {
+	pathParams []PathParamConfig, queryParams []QueryParamConfig, headerParams []HeaderParamConfig,
+	httpMethod string, body BodyConfig,
+	errorHandler ErrorHandler, marshalOpts protojson.MarshalOptions,
+	validationPolicy sebufhttp.ValidationPolicy, violationFormatter sebufhttp.ViolationFormatter,
+	validationFailureMode sebufhttp.ValidationFailureMode,
+	logger *slog.Logger, authenticators []sebufhttp.HeaderAuthenticator,
+	timeoutHeader sebufhttp.TimeoutHeader) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Validate headers first
-		if validationErr := validateHeaders(r, serviceHeaders, methodHeaders); validationErr != nil {
-			writeErrorWithHandler(w, r, validationErr, errorHandler, marshalOpts)
+		r, cancel, timeoutErr := timeoutHeader.Apply(w, r)
+		defer cancel()
+		if timeoutErr != nil {
+			writeErrorWithHandler(w, r, timeoutErr, errorHandler, marshalOpts)
 			return
 		}
 
-		toBind := new(Req)
-
-		// Bind body FIRST for POST, PUT, PATCH methods.
-		// This must happen before path/query binding because protojson.Unmarshal
-		// calls proto.Reset(), which would wipe any previously-set fields.
-		// By binding body first, path and query params applied afterwards take precedence.
-		if httpMethod == "POST" || httpMethod == "PUT" || httpMethod == "PATCH" {
-			err := bindDataBasedOnContentType(r, toBind)
-			if err != nil {
-				// For binding errors, return a simple validation error
-				validationErr := &sebufhttp.ValidationError{
-					Violations: []*sebufhttp.FieldViolation{
-						{
-							Field:       "body",
-							Description: fmt.Sprintf("failed to parse request body: %v", err),
-						},
-					},
-				}
-				writeErrorWithHandler(w, r, validationErr, errorHandler, marshalOpts)
-				return
-			}
-		}
-
-		// Bind path and query parameters AFTER body, so URL-stated values always win
-		if msg, ok := any(toBind).(proto.Message); ok {
-			if err := bindPathParams(r, msg, pathParams); err != nil {
-				writeErrorWithHandler(w, r, err, errorHandler, marshalOpts)
-				return
-			}
-
-			// Bind query parameters
-			if err := bindQueryParams(r, msg, queryParams); err != nil {
-				writeErrorWithHandler(w, r, err, errorHandler, marshalOpts)
-				return
-			}
+		authenticated, authErr := sebufhttp.AuthenticateHeaders(r, authenticators, serviceHeaders, methodHeaders)
+		if authErr != nil {
+			writeErrorWithHandler(w, r, authErr, errorHandler, marshalOpts)
+			return
 		}
+		r = authenticated
 
-		// Validate the complete message
-		if msg, ok := any(toBind).(proto.Message); ok {
-			if err := ValidateMessage(msg); err != nil {
-				writeErrorWithHandler(w, r, convertProtovalidateError(err), errorHandler, marshalOpts)
-				return
-			}
+		toBind := new(Req)
+		if err := bindRequest(w, r, toBind, pathParams, queryParams, headerParams, httpMethod, body); err != nil {
+			writeErrorWithHandler(w, r, err, errorHandler, marshalOpts)
+			return
 		}
 
 		ctx := context.WithValue(r.Context(), bodyCtxKey{}, toBind)
@@ -127,6 +133,36 @@ func BindingMiddleware[Req any](next http.Handler, serviceHeaders, methodHeaders
 	})
 }
 
+// bindRequest binds r to toBind: the body of POST, PUT and PATCH requests first,
+// because protojson.Unmarshal calls proto.Reset(), which would wipe any
+// previously-set fields, then the path, query and header-sourced fields, so
+// URL-stated values always win. Body failures are converted by bodyBindingError.
+// w only reports bodies over the size limit to the server, and may be nil.
+func bindRequest[Req any](
+	w http.ResponseWriter, r *http.Request, toBind *Req,
+	pathParams []PathParamConfig, queryParams []QueryParamConfig, headerParams []HeaderParamConfig,
+	httpMethod string, body BodyConfig,
+) error {
+	if httpMethod == "POST" || httpMethod == "PUT" || httpMethod == "PATCH" {
+		if err := bindDataBasedOnContentType(w, r, toBind, body); err != nil {
+			return bodyBindingError(err)
+		}
+	}
+
+	msg, ok := any(toBind).(proto.Message)
+	if !ok {
+		return nil
+	}
+	if err := bindPathParams(r, msg, pathParams); err != nil {
+		return err
+	}
+	if err := bindQueryParams(r, msg, queryParams); err != nil {
+		return err
+	}
+	bindHeaderParams(r, msg, headerParams)
+	return nil
+}
+
 func filterFlags(content string) string {
 	for i, char := range content {
 		if char == ' ' || char == ';' {
@@ -136,44 +172,52 @@ func filterFlags(content string) string {
 	return content
 }
 
-// resolveResponseContentType determines the response serialization format.
-// Per HTTP semantics (RFC 9110), the Accept header governs the desired response format.
-// Falls back to request Content-Type if Accept is absent, then defaults to JSON.
+// resolveResponseContentType determines the response serialization format with
+// sebufhttp.NegotiateResponseContentType: the Accept header governs it (RFC 9110),
+// then the request Content-Type if Accept is absent, then JSON.
 func resolveResponseContentType(r *http.Request) string {
-	accept := filterFlags(r.Header.Get("Accept"))
-	switch accept {
-	case BinaryContentType, ProtoContentType:
-		return accept
-	case JSONContentType:
-		return JSONContentType
-	case "", "*/*":
-		// No Accept or wildcard: fall back to request Content-Type
-		ct := filterFlags(r.Header.Get("Content-Type"))
-		switch ct {
-		case BinaryContentType, ProtoContentType:
-			return ct
-		default:
-			return JSONContentType
-		}
-	default:
-		return JSONContentType
-	}
+	return sebufhttp.NegotiateResponseContentType(r)
 }
 
-func bindDataBasedOnContentType[Req any](r *http.Request, toBind *Req) error {
+// bindDataBasedOnContentType binds the request body in the encoding named by its
+// Content-Type, reading at most body.MaxSize bytes. Empty bodies of methods with
+// body.OptionalBody are not read at all.
+func bindDataBasedOnContentType[Req any](
+	w http.ResponseWriter, r *http.Request, toBind *Req, body BodyConfig,
+) error {
+	if body.OptionalBody && r.ContentLength == 0 {
+		// An empty body leaves the message empty, so there is nothing to read or unmarshal
+		return nil
+	}
+
 	contentType := filterFlags(r.Header.Get("Content-Type"))
+	maxSize := body.MaxSize
+	if maxSize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+	}
+
 	switch contentType {
 	case JSONContentType:
-		return bindDataFromJSONRequest(r, toBind)
+		return bindDataFromJSONRequest(r, toBind, body)
 	case BinaryContentType, ProtoContentType:
-		return bindDataFromBinaryRequest(r, toBind)
+		return bindDataFromBinaryRequest(r, toBind, body)
+	case FormContentType:
+		if !body.AcceptForm {
+			// Methods without accept_form treat forms like any unrecognized content type
+			return bindDataFromJSONRequest(r, toBind, body)
+		}
+		return bindDataFromFormRequest(r, toBind)
 	default:
 		// Default to JSON for unrecognized content types
-		return bindDataFromJSONRequest(r, toBind)
+		return bindDataFromJSONRequest(r, toBind, body)
 	}
 }
 
-func bindDataFromJSONRequest[Req any](r *http.Request, toBind *Req) error {
+// bindDataFromJSONRequest binds a JSON body. Unknown keys are ignored unless
+// body.StrictJSON, in which case each is reported as a violation. Bodies declared
+// as JSON that do not start like JSON are answered with 415, unless
+// body.NoContentSniffing.
+func bindDataFromJSONRequest[Req any](r *http.Request, toBind *Req, body BodyConfig) error {
 	bodyBytes, err := io.ReadAll(r.Body)
 	r.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 	if err != nil {
@@ -184,9 +228,31 @@ func bindDataFromJSONRequest[Req any](r *http.Request, toBind *Req) error {
 		return nil
 	}
 
+	if contentType := filterFlags(r.Header.Get("Content-Type")); contentType == JSONContentType &&
+		!body.NoContentSniffing {
+		if err = checkJSONBodyShape(contentType, bodyBytes); err != nil {
+			return err
+		}
+	}
+
 	// Check for custom JSON unmarshaler (unwrap support)
 	if unmarshaler, ok := any(toBind).(json.Unmarshaler); ok {
-		return unmarshaler.UnmarshalJSON(bodyBytes)
+		if !body.StrictJSON {
+			return unmarshalCustomJSON(unmarshaler, bodyBytes, body.TypeResolver)
+		}
+		// Generated unmarshalers that read keys protojson does not know report
+		// the ones they would ignore; the others fail on unknown keys themselves
+		if checker, ok := any(toBind).(unknownJSONFieldsChecker); ok {
+			if unknown := checker.UnknownJSONFields(bodyBytes); len(unknown) > 0 {
+				return unknownJSONFieldsError(unknown...)
+			}
+		}
+		if err = unmarshalCustomJSON(unmarshaler, bodyBytes, body.TypeResolver); err != nil {
+			if key, ok := unknownJSONField(err); ok {
+				return unknownJSONFieldsError(key)
+			}
+		}
+		return err
 	}
 
 	protoRequest, ok := any(toBind).(proto.Message)
@@ -194,14 +260,56 @@ func bindDataFromJSONRequest[Req any](r *http.Request, toBind *Req) error {
 		return errors.New("JSON request is not a protocol buffer message")
 	}
 
-	err = protojson.Unmarshal(bodyBytes, protoRequest)
+	opts := protojson.UnmarshalOptions{DiscardUnknown: !body.StrictJSON, Resolver: body.TypeResolver}
+	err = opts.Unmarshal(bodyBytes, protoRequest)
 	if err != nil {
+		if key, ok := unknownJSONField(err); ok {
+			return unknownJSONFieldsError(key)
+		}
 		return fmt.Errorf("could not unmarshal request JSON: %w", err)
 	}
 	return nil
 }
 
-func bindDataFromBinaryRequest[Req any](r *http.Request, toBind *Req) error {
+// unknownJSONFieldsChecker is implemented by messages whose generated UnmarshalJSON
+// ignores keys it does not read, such as those with unwrap fields.
+type unknownJSONFieldsChecker interface {
+	UnknownJSONFields(data []byte) []string
+}
+
+// unknownJSONField returns the key of a protojson error for a key naming no field.
+func unknownJSONField(err error) (string, bool) {
+	_, quoted, found := strings.Cut(err.Error(), "unknown field ")
+	if !found {
+		return "", false
+	}
+	key, unquoteErr := strconv.Unquote(quoted)
+	return key, unquoteErr == nil
+}
+
+// unknownJSONFieldsError reports each unknown key of a strict JSON body as a violation.
+func unknownJSONFieldsError(keys ...string) *sebufhttp.ValidationError {
+	sort.Strings(keys)
+	violations := make([]*sebufhttp.FieldViolation, 0, len(keys))
+	for _, key := range keys {
+		violations = append(violations, &sebufhttp.FieldViolation{Field: key, Description: "unknown field"})
+	}
+	return &sebufhttp.ValidationError{Violations: violations}
+}
+
+// unmarshalCustomJSON binds a JSON body with a custom unmarshaler through
+// sebufhttp.UnmarshalMessageJSON, as the exported Unmarshal<Message>JSON helpers
+// do. Generated unmarshalers take the resolver of the Any type URLs in the body.
+func unmarshalCustomJSON(unmarshaler json.Unmarshaler, data []byte, resolver sebufhttp.TypeResolver) error {
+	if msg, ok := unmarshaler.(proto.Message); ok {
+		return sebufhttp.UnmarshalMessageJSON(data, msg, protojson.UnmarshalOptions{Resolver: resolver})
+	}
+	return unmarshaler.UnmarshalJSON(data)
+}
+
+// bindDataFromBinaryRequest binds a protobuf body. Bodies that start like JSON
+// are answered with 415, unless body.NoContentSniffing.
+func bindDataFromBinaryRequest[Req any](r *http.Request, toBind *Req, body BodyConfig) error {
 	bodyBytes, err := io.ReadAll(r.Body)
 	r.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 
@@ -213,6 +321,12 @@ func bindDataFromBinaryRequest[Req any](r *http.Request, toBind *Req) error {
 		return fmt.Errorf("could not read request body: %w", err)
 	}
 
+	if !body.NoContentSniffing {
+		if err = checkBinaryBodyShape(filterFlags(r.Header.Get("Content-Type")), bodyBytes); err != nil {
+			return err
+		}
+	}
+
 	protoRequest, ok := any(toBind).(proto.Message)
 	if !ok {
 		return errors.New("binary request is not a protocol buffer message")
@@ -225,7 +339,201 @@ func bindDataFromBinaryRequest[Req any](r *http.Request, toBind *Req) error {
 	return nil
 }
 
-// bindPathParams binds URL path parameters to proto message fields using Go 1.22+ PathValue.
+// checkJSONBodyShape rejects a body declared as contentType whose first
+// non-whitespace byte cannot start a JSON value, such as a protobuf body.
+func checkJSONBodyShape(contentType string, data []byte) error {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	if len(trimmed) == 0 {
+		return nil
+	}
+	switch c := trimmed[0]; {
+	case c == '{', c == '[', c == '"', c == '-', c >= '0' && c <= '9', c == 't', c == 'f', c == 'n':
+		return nil
+	}
+	return &sebufhttp.Error{
+		Code: sebufhttp.ErrorCodeUnsupportedMediaType,
+		Message: fmt.Sprintf(
+			"request body does not look like JSON: Content-Type is %s but the body starts with %s",
+			contentType, bodyShape(trimmed[0]),
+		),
+	}
+}
+
+// checkBinaryBodyShape rejects a body declared as the protobuf contentType that
+// starts like a JSON object or array. Only the first byte is checked: protobuf
+// messages often start with bytes JSON treats as whitespace.
+func checkBinaryBodyShape(contentType string, data []byte) error {
+	if data[0] != '{' && data[0] != '[' {
+		return nil
+	}
+	return &sebufhttp.Error{
+		Code: sebufhttp.ErrorCodeUnsupportedMediaType,
+		Message: fmt.Sprintf(
+			"request body looks like JSON: Content-Type is %s but the body starts with %s; did you mean %s?",
+			contentType, bodyShape(data[0]), JSONContentType,
+		),
+	}
+}
+
+// bodyShape describes the first byte of a body in content sniffing errors.
+func bodyShape(c byte) string {
+	switch {
+	case c == '{':
+		return "a JSON object"
+	case c == '[':
+		return "a JSON array"
+	case c >= ' ' && c <= '~':
+		return fmt.Sprintf("text (%q)", c)
+	}
+	return fmt.Sprintf("binary data (byte 0x%02x)", c)
+}
+
+// bodyBindingError converts a body binding failure into the error written to the
+// client: form and multipart bodies report their violations per field, bodies over
+// the size limit are answered with 413, bodies that do not look like their
+// Content-Type with 415, anything else is a violation of body.
+func bodyBindingError(err error) error {
+	var fieldErr *sebufhttp.ValidationError
+	if errors.As(err, &fieldErr) {
+		return fieldErr
+	}
+	var mediaTypeErr *sebufhttp.Error
+	if errors.As(err, &mediaTypeErr) {
+		return mediaTypeErr
+	}
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return &sebufhttp.Error{
+			Code:    sebufhttp.ErrorCodePayloadTooLarge,
+			Message: fmt.Sprintf("request body exceeds %d bytes", maxBytesErr.Limit),
+		}
+	}
+	return &sebufhttp.ValidationError{
+		Violations: []*sebufhttp.FieldViolation{
+			{
+				Field:       "body",
+				Description: fmt.Sprintf("failed to parse request body: %v", err),
+			},
+		},
+	}
+}
+
+// bindDataFromFormRequest binds an application/x-www-form-urlencoded body. A key names
+// a field by its proto or JSON name, with dots addressing nested message fields
+// (address.city=Beirut); a repeated field takes one value per occurrence of its key.
+// Unknown keys are ignored. Invalid values are reported as a *sebufhttp.ValidationError.
+func bindDataFromFormRequest[Req any](r *http.Request, toBind *Req) error {
+	protoRequest, ok := any(toBind).(proto.Message)
+	if !ok {
+		return errors.New("form request is not a protocol buffer message")
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return fmt.Errorf("could not parse form body: %w", err)
+	}
+
+	// Bind keys in a stable order so violations are reported deterministically
+	keys := make([]string, 0, len(r.PostForm))
+	for key := range r.PostForm {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var violations []*sebufhttp.FieldViolation
+	for _, key := range keys {
+		if violation := bindFormValue(protoRequest.ProtoReflect(), key, r.PostForm[key]); violation != nil {
+			violations = append(violations, violation)
+		}
+	}
+	if len(violations) > 0 {
+		return &sebufhttp.ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// bindFormValue binds the values of one form key to the field it addresses.
+func bindFormValue(reflectMsg protoreflect.Message, key string, values []string) *sebufhttp.FieldViolation {
+	// Filter empty values (e.g., name= treated as unset)
+	var filtered []string
+	for _, v := range values {
+		if v != "" {
+			filtered = append(filtered, v)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil
+	}
+
+	// Resolve the whole path before mutating, so unknown keys leave no empty messages behind
+	names := strings.Split(key, ".")
+	path := make([]protoreflect.FieldDescriptor, 0, len(names))
+	fieldNames := make([]string, 0, len(names))
+	desc := reflectMsg.Descriptor()
+	for i, name := range names {
+		field := desc.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			field = desc.Fields().ByJSONName(name)
+		}
+		if field == nil {
+			return nil // Field not found, skip
+		}
+		path = append(path, field)
+		fieldNames = append(fieldNames, string(field.Name()))
+		if i < len(names)-1 {
+			if field.Message() == nil || field.IsList() || field.IsMap() {
+				return &sebufhttp.FieldViolation{
+					Field:       strings.Join(fieldNames, "."),
+					Description: fmt.Sprintf("form field %s is not a nested message", key),
+				}
+			}
+			desc = field.Message()
+		}
+	}
+
+	for _, field := range path[:len(path)-1] {
+		reflectMsg = reflectMsg.Mutable(field).Message()
+	}
+	field := path[len(path)-1]
+
+	// Handle repeated fields (arrays)
+	if field.IsList() {
+		list := reflectMsg.Mutable(field).List()
+		for _, v := range filtered {
+			converted, err := convertStringToFieldValue(v, field)
+			if err != nil {
+				return &sebufhttp.FieldViolation{
+					Field:       strings.Join(fieldNames, "."),
+					Description: fmt.Sprintf("invalid value for form field %s: %v", key, err),
+				}
+			}
+			list.Append(converted)
+		}
+		return nil
+	}
+
+	if field.IsMap() {
+		return &sebufhttp.FieldViolation{
+			Field:       strings.Join(fieldNames, "."),
+			Description: fmt.Sprintf("map field %s cannot be bound from a form", key),
+		}
+	}
+	converted, err := convertStringToFieldValue(filtered[0], field)
+	if err != nil {
+		return &sebufhttp.FieldViolation{
+			Field:       strings.Join(fieldNames, "."),
+			Description: fmt.Sprintf("invalid value for form field %s: %v", key, err),
+		}
+	}
+	reflectMsg.Set(field, converted)
+	return nil
+}
+
+// pathValue returns the value of the path wildcard name of r.
+func pathValue(r *http.Request, name string) string {
+	return r.PathValue(name)
+}
+
+// bindPathParams binds URL path parameters to proto message fields, read with pathValue.
 func bindPathParams(r *http.Request, msg proto.Message, params []PathParamConfig) *sebufhttp.ValidationError {
 	if len(params) == 0 {
 		return nil
@@ -235,7 +543,7 @@ func bindPathParams(r *http.Request, msg proto.Message, params []PathParamConfig
 	fields := reflectMsg.Descriptor().Fields()
 
 	for _, param := range params {
-		value := r.PathValue(param.URLParam)
+		value := pathValue(r, param.URLParam)
 		if value == "" {
 			return &sebufhttp.ValidationError{
 				Violations: []*sebufhttp.FieldViolation{{
@@ -266,6 +574,24 @@ func bindPathParams(r *http.Request, msg proto.Message, params []PathParamConfig
 	return nil
 }
 
+// bindHeaderParams binds request headers to the string fields declared with source HEADER.
+// A missing header leaves the field unset, even if the body carried a value.
+func bindHeaderParams(r *http.Request, msg proto.Message, params []HeaderParamConfig) {
+	reflectMsg := msg.ProtoReflect()
+	fields := reflectMsg.Descriptor().Fields()
+	for _, param := range params {
+		field := fields.ByName(protoreflect.Name(param.FieldName))
+		if field == nil {
+			continue // Field not found, skip
+		}
+		if value := r.Header.Get(param.HeaderName); value != "" {
+			reflectMsg.Set(field, protoreflect.ValueOfString(value))
+		} else {
+			reflectMsg.Clear(field)
+		}
+	}
+}
+
 // bindQueryParams binds URL query parameters to proto message fields.
 func bindQueryParams(r *http.Request, msg proto.Message, params []QueryParamConfig) *sebufhttp.ValidationError {
 	if len(params) == 0 {
@@ -277,6 +603,11 @@ func bindQueryParams(r *http.Request, msg proto.Message, params []QueryParamConf
 	fields := reflectMsg.Descriptor().Fields()
 
 	for _, param := range params {
+		if param.Exclusive {
+			if field := fields.ByName(protoreflect.Name(param.FieldName)); field != nil {
+				reflectMsg.Clear(field)
+			}
+		}
 		values := query[param.QueryName]
 		// Filter empty values (e.g., ?param= treated as unset)
 		var filtered []string
@@ -339,17 +670,7 @@ func bindQueryParams(r *http.Request, msg proto.Message, params []QueryParamConf
 func convertStringToFieldValue(value string, field protoreflect.FieldDescriptor) (protoreflect.Value, error) {
 	switch field.Kind() {
 	case protoreflect.EnumKind:
-		// Try numeric value first — accept unknown numbers for proto3 forward-compat
-		if v, err := strconv.ParseInt(value, 10, 32); err == nil {
-			return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v)), nil
-		}
-		// Fall back to enum name lookup
-		enumDesc := field.Enum()
-		enumVal := enumDesc.Values().ByName(protoreflect.Name(value))
-		if enumVal != nil {
-			return protoreflect.ValueOfEnum(enumVal.Number()), nil
-		}
-		return protoreflect.Value{}, fmt.Errorf("invalid value %q for enum %s", value, enumDesc.Name())
+		return convertStringToEnumValue(value, field.Enum())
 	case protoreflect.StringKind:
 		return protoreflect.ValueOfString(value), nil
 	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
@@ -399,12 +720,78 @@ func convertStringToFieldValue(value string, field protoreflect.FieldDescriptor)
 	}
 }
 
-func genericHandler[Req any, Res any](serve func(context.Context, Req) (Res, error), errorHandler ErrorHandler, marshalOpts protojson.MarshalOptions) http.HandlerFunc {
+// convertStringToEnumValue resolves an enum parameter, accepting (in order) the custom
+// enum_value string, the proto value name, and a numeric value.
+func convertStringToEnumValue(value string, enumDesc protoreflect.EnumDescriptor) (protoreflect.Value, error) {
+	values := enumDesc.Values()
+	for i := range values.Len() {
+		if custom := enumValueMapping(values.Get(i)); custom != "" && custom == value {
+			return protoreflect.ValueOfEnum(values.Get(i).Number()), nil
+		}
+	}
+	if enumVal := values.ByName(protoreflect.Name(value)); enumVal != nil {
+		return protoreflect.ValueOfEnum(enumVal.Number()), nil
+	}
+	// Accept unknown numbers for proto3 forward-compat
+	if v, err := strconv.ParseInt(value, 10, 32); err == nil {
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v)), nil
+	}
+
+	accepted := make([]string, 0, values.Len())
+	for i := range values.Len() {
+		if custom := enumValueMapping(values.Get(i)); custom != "" {
+			accepted = append(accepted, custom)
+		}
+		accepted = append(accepted, string(values.Get(i).Name()))
+	}
+	return protoreflect.Value{}, fmt.Errorf(
+		"invalid value %q for enum %s, expected one of: %s",
+		value, enumDesc.Name(), strings.Join(accepted, ", "),
+	)
+}
+
+// enumValueMapping returns the custom enum_value string for an enum value, or "" if unset.
+func enumValueMapping(value protoreflect.EnumValueDescriptor) string {
+	opts, ok := value.Options().(*descriptorpb.EnumValueOptions)
+	if !ok || opts == nil {
+		return ""
+	}
+	custom, _ := proto.GetExtension(opts, sebufhttp.E_EnumValue).(string)
+	return custom
+}
+
+// genericHandler serves a bound request with serve. It holds a limiter slot while serve runs,
+// answering 503 when none is free, and bounds serve by timeout when positive, answering 504
+// when it expires. The headers, trailers, status and warnings serve sets through its context
+// are applied to a successful response, with the warnings also in its JSON object when
+// warningsInBody, and w is guarded so a failure to write it cannot be followed by an error
+// response.
+func genericHandler[Req any, Res any](serve func(context.Context, Req) (Res, error), errorHandler ErrorHandler, marshalOpts protojson.MarshalOptions,
+	limiter *sebufhttp.ConcurrencyLimiter, timeout time.Duration, warningsInBody bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		w = guardResponse(w)
+		if !limiter.TryAcquire() {
+			w.Header().Set("Retry-After", sebufhttp.RetryAfterSaturated)
+			writeErrorWithHandler(w, r, &sebufhttp.Error{
+				Code:    sebufhttp.ErrorCodeUnavailable,
+				Message: "server is at its concurrency limit",
+			}, errorHandler, marshalOpts)
+			return
+		}
+
+		ctx, responseControl := sebufhttp.ContextWithResponseControl(r.Context())
+		defer responseControl.Close()
 		request := getRequest[Req](r.Context())
 
-		response, err := serve(r.Context(), request)
+		response, err := serveWithTimeout(ctx, serve, request, limiter, timeout)
 		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				writeErrorWithHandler(w, r, &sebufhttp.Error{
+					Code:    sebufhttp.ErrorCodeDeadlineExceeded,
+					Message: "request timed out",
+				}, errorHandler, marshalOpts)
+				return
+			}
 			// Check if error is already a proto.Message (e.g., custom proto error types)
 			// If so, pass it directly - defaultErrorResponse will preserve its structure
 			if _, ok := err.(proto.Message); ok {
@@ -430,6 +817,10 @@ func genericHandler[Req any, Res any](serve func(context.Context, Req) (Res, err
 		// Set response Content-Type based on Accept header (RFC 9110)
 		respContentType := resolveResponseContentType(r)
 		w.Header().Set("Content-Type", respContentType)
+		if warningsInBody && w.Header().Get("Content-Type") == JSONContentType {
+			responseBytes = sebufhttp.AppendJSONWarnings(responseBytes, responseControl.Warnings())
+		}
+		responseControl.WriteHeader(w)
 
 		_, err = w.Write(responseBytes)
 		if err != nil {
@@ -439,7 +830,63 @@ func genericHandler[Req any, Res any](serve func(context.Context, Req) (Res, err
 			writeErrorWithHandler(w, r, errorMsg, errorHandler, marshalOpts)
 			return
 		}
+		responseControl.WriteTrailer(w)
+	}
+}
+
+// serveWithTimeout calls serve and releases its limiter slot once serve returns. When timeout
+// is positive, serve's context is cancelled after timeout. Once it or a deadline ctx already
+// has (see WithTimeoutHeader) expires, serveWithTimeout returns context.DeadlineExceeded
+// without waiting for serve, whose late result is discarded; a result serve returned by then
+// is kept.
+func serveWithTimeout[Req any, Res any](ctx context.Context, serve func(context.Context, Req) (Res, error),
+	request Req, limiter *sebufhttp.ConcurrencyLimiter, timeout time.Duration) (Res, error) {
+	_, bounded := ctx.Deadline()
+	if timeout <= 0 && !bounded {
+		defer limiter.Release()
+		return serve(ctx, request)
+	}
+
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+	type result struct {
+		response Res
+		err      error
+		panicked any
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer limiter.Release()
+		var res result
+		defer func() {
+			// Re-raise panics on the request goroutine, where net/http recovers them
+			res.panicked = recover()
+			done <- res
+		}()
+		res.response, res.err = serve(ctx, request)
+	}()
+
+	var res result
+	select {
+	case res = <-done:
+	case <-ctx.Done():
+		// serve may have returned just as ctx expired: its result wins
+		select {
+		case res = <-done:
+		default:
+			var zero Res
+			return zero, ctx.Err()
+		}
+	}
+	if res.panicked != nil {
+		panic(res.panicked)
 	}
+	return res.response, res.err
 }
 
 func marshalResponse(r *http.Request, response any, marshalOpts protojson.MarshalOptions) ([]byte, error) {
@@ -458,115 +905,70 @@ func marshalResponse(r *http.Request, response any, marshalOpts protojson.Marsha
 	}
 }
 
-// marshalJSONWithOpts dispatches JSON marshaling:
+// marshalJSONWithOpts dispatches JSON marshaling through sebufhttp.MarshalMessageJSON,
+// as the exported Marshal<Message>JSON helpers do:
 //   - sebufMarshaler (sebuf-generated custom marshalers) receives marshalOpts
 //   - json.Marshaler (third-party / back-compat) is called with no options
 //   - otherwise marshalOpts.Marshal is used
 func marshalJSONWithOpts(msg proto.Message, marshalOpts protojson.MarshalOptions) ([]byte, error) {
-	if m, ok := msg.(sebufMarshaler); ok {
-		return m.MarshalJSONSebuf(marshalOpts)
-	}
-	if m, ok := msg.(json.Marshaler); ok {
-		return m.MarshalJSON()
-	}
-	return marshalOpts.Marshal(msg)
+	return sebufhttp.MarshalMessageJSON(msg, marshalOpts)
 }
 
-// responseCapture wraps ResponseWriter to track if Write or WriteHeader was called
+// responseCapture wraps ResponseWriter to track if Write or WriteHeader was called.
+// It sends the status of a response once: WriteHeader calls after the first, or
+// after the first Write, are dropped instead of reaching the ResponseWriter.
 type responseCapture struct {
 	http.ResponseWriter
 	wroteHeader bool
 	written     bool
+	statusCode  int
+}
+
+// guardResponse returns w as a responseCapture, wrapping it unless it is one.
+func guardResponse(w http.ResponseWriter) *responseCapture {
+	if capture, ok := w.(*responseCapture); ok {
+		return capture
+	}
+	return &responseCapture{ResponseWriter: w}
 }
 
 func (rc *responseCapture) WriteHeader(code int) {
+	if rc.wroteHeader {
+		return // The status is on the wire; a second one would only be logged as superfluous
+	}
+	if code >= 100 && code < 200 && code != http.StatusSwitchingProtocols {
+		rc.ResponseWriter.WriteHeader(code) // Informational, the final status follows
+		return
+	}
 	rc.wroteHeader = true
+	rc.statusCode = code
 	rc.ResponseWriter.WriteHeader(code)
 }
 
 func (rc *responseCapture) Write(b []byte) (int, error) {
+	if !rc.wroteHeader {
+		rc.wroteHeader = true
+		rc.statusCode = http.StatusOK
+	}
 	rc.written = true
 	return rc.ResponseWriter.Write(b)
 }
 
-// writeProtoMessageResponse writes a protobuf message as an HTTP response
-func writeProtoMessageResponse(w http.ResponseWriter, r *http.Request, msg proto.Message, statusCode int, fallbackMsg string, marshalOpts protojson.MarshalOptions) {
-	respContentType := resolveResponseContentType(r)
-
-	var responseBytes []byte
-	var err error
-
-	switch respContentType {
-	case BinaryContentType, ProtoContentType:
-		responseBytes, err = proto.Marshal(msg)
-	default:
-		responseBytes, err = marshalJSONWithOpts(msg, marshalOpts)
-	}
-
-	if err != nil {
-		// Fallback to plain text error if marshaling fails
-		http.Error(w, fallbackMsg, statusCode)
-		return
-	}
-
-	w.Header().Set("Content-Type", respContentType)
-	w.WriteHeader(statusCode)
-	_, _ = w.Write(responseBytes)
-}
-
-// writeValidationErrorResponse writes a ValidationError as a response
-func writeValidationErrorResponse(w http.ResponseWriter, r *http.Request, validationErr *sebufhttp.ValidationError, marshalOpts protojson.MarshalOptions) {
-	writeProtoMessageResponse(w, r, validationErr, http.StatusBadRequest, "validation failed", marshalOpts)
+// Written reports whether the status or part of the body of the response was
+// sent, after which no other response can be written.
+func (rc *responseCapture) Written() bool {
+	return rc.wroteHeader || rc.written
 }
 
-// writeValidationError converts a protovalidate error to ValidationError and writes it as response
-func writeValidationError(w http.ResponseWriter, r *http.Request, err error, marshalOpts protojson.MarshalOptions) {
-	validationErr := convertProtovalidateError(err)
-	writeValidationErrorResponse(w, r, validationErr, marshalOpts)
+// Unwrap returns the wrapped ResponseWriter, for http.ResponseController.
+func (rc *responseCapture) Unwrap() http.ResponseWriter {
+	return rc.ResponseWriter
 }
 
-// writeErrorResponse writes an Error as a response
-func writeErrorResponse(w http.ResponseWriter, r *http.Request, errorMsg *sebufhttp.Error, marshalOpts protojson.MarshalOptions) {
-	writeProtoMessageResponse(w, r, errorMsg, http.StatusInternalServerError, "internal server error", marshalOpts)
-}
-
-// convertProtovalidateError converts a protovalidate error to ValidationError
-func convertProtovalidateError(err error) *sebufhttp.ValidationError {
-	validationErr := &sebufhttp.ValidationError{}
-
-	// Handle protovalidate.ValidationError
-	var valErr *protovalidate.ValidationError
-	if errors.As(err, &valErr) {
-		for _, violation := range valErr.Violations {
-			// Extract field path from violation
-			fieldPath := ""
-			if violation.Proto != nil && violation.Proto.GetField() != nil {
-				elements := violation.Proto.GetField().GetElements()
-				if len(elements) > 0 {
-					fieldPath = elements[0].GetFieldName()
-					for i := 1; i < len(elements); i++ {
-						fieldPath += "." + elements[i].GetFieldName()
-					}
-				}
-			}
-			if fieldPath == "" {
-				fieldPath = "unknown"
-			}
-
-			validationErr.Violations = append(validationErr.Violations, &sebufhttp.FieldViolation{
-				Field:       fieldPath,
-				Description: violation.Proto.GetMessage(),
-			})
-		}
-	} else {
-		// Shouldn't happen, but handle as generic error
-		validationErr.Violations = append(validationErr.Violations, &sebufhttp.FieldViolation{
-			Field:       "unknown",
-			Description: err.Error(),
-		})
-	}
-
-	return validationErr
+// responder returns the sebufhttp.Responder writing messages with marshalOpts, in
+// the content type of resolveResponseContentType.
+func responder(marshalOpts protojson.MarshalOptions) sebufhttp.Responder {
+	return sebufhttp.Responder{MarshalOptions: marshalOpts, Negotiate: resolveResponseContentType}
 }
 
 // defaultErrorResponse returns the appropriate error response message based on error type
@@ -592,16 +994,51 @@ func defaultErrorStatusCode(err error) int {
 	if errors.As(err, &valErr) {
 		return http.StatusBadRequest
 	}
+	var handlerErr *sebufhttp.Error
+	if errors.As(err, &handlerErr) {
+		switch handlerErr.GetCode() {
+		case sebufhttp.ErrorCodeUnimplemented:
+			return http.StatusNotImplemented
+		case sebufhttp.ErrorCodeIdempotencyConflict, sebufhttp.ErrorCodeIdempotencyInProgress:
+			return http.StatusConflict
+		case sebufhttp.ErrorCodeUnavailable:
+			return http.StatusServiceUnavailable
+		case sebufhttp.ErrorCodeDeadlineExceeded:
+			return http.StatusGatewayTimeout
+		case sebufhttp.ErrorCodePayloadTooLarge:
+			return http.StatusRequestEntityTooLarge
+		case sebufhttp.ErrorCodeUnsupportedMediaType:
+			return http.StatusUnsupportedMediaType
+		case sebufhttp.ErrorCodeNotFound:
+			return http.StatusNotFound
+		case sebufhttp.ErrorCodeAlreadyExists:
+			return http.StatusConflict
+		case sebufhttp.ErrorCodeSchemaMismatch:
+			return http.StatusPreconditionFailed
+		case sebufhttp.ErrorCodePermissionDenied:
+			return http.StatusForbidden
+		case sebufhttp.ErrorCodeUnauthenticated:
+			return http.StatusUnauthorized
+		case sebufhttp.ErrorCodeInvalidArgument:
+			return http.StatusBadRequest
+		}
+	}
 	return http.StatusInternalServerError
 }
 
-// writeErrorWithHandler calls custom handler if set, then marshals response
+// writeErrorWithHandler calls custom handler if set, then marshals response.
+// Nothing is written once the body of the response has started.
 func writeErrorWithHandler(w http.ResponseWriter, r *http.Request, err error, handler ErrorHandler, marshalOpts protojson.MarshalOptions) {
-	var response proto.Message
-	var capture *responseCapture
+	capture, guarded := w.(*responseCapture)
+	if !guarded {
+		capture = &responseCapture{ResponseWriter: w}
+	}
+	if capture.written {
+		return // The body started (e.g. a failed write of a successful response); a second would corrupt it
+	}
 
+	var response proto.Message
 	if handler != nil {
-		capture = &responseCapture{ResponseWriter: w}
 		response = handler(capture, r, err)
 		if capture.written {
 			return // Handler wrote directly, done
@@ -617,255 +1054,50 @@ func writeErrorWithHandler(w http.ResponseWriter, r *http.Request, err error, ha
 	statusCode := defaultErrorStatusCode(err)
 
 	// If handler already set status, don't set it again
-	if capture != nil && capture.wroteHeader {
+	if capture.wroteHeader {
 		// Handler set status, just write the body
-		writeResponseBody(w, r, response, marshalOpts)
+		responder(marshalOpts).WriteBody(w, r, response)
 		return
 	}
 
 	// Write full response with status code
-	writeProtoMessageResponse(w, r, response, statusCode, "error processing request", marshalOpts)
-}
-
-// writeResponseBody writes the response body without setting status code
-func writeResponseBody(w http.ResponseWriter, r *http.Request, msg proto.Message, marshalOpts protojson.MarshalOptions) {
-	respContentType := resolveResponseContentType(r)
-
-	var responseBytes []byte
-	var err error
-
-	switch respContentType {
-	case BinaryContentType, ProtoContentType:
-		responseBytes, err = proto.Marshal(msg)
-	default:
-		responseBytes, err = marshalJSONWithOpts(msg, marshalOpts)
-	}
-
-	if err != nil {
-		return // Can't write anything meaningful
-	}
-
-	w.Header().Set("Content-Type", respContentType)
-	_, _ = w.Write(responseBytes)
-}
-
-var (
-	// Global validator instance - created once and reused
-	validatorOnce sync.Once
-	validator     protovalidate.Validator
-	validatorErr  error
-)
-
-// getValidator returns a cached validator instance
-func getValidator() (protovalidate.Validator, error) {
-	validatorOnce.Do(func() {
-		validator, validatorErr = protovalidate.New()
-	})
-	return validator, validatorErr
+	responder(marshalOpts).WriteMessage(w, r, response, statusCode, "error processing request")
 }
 
-// ValidateMessage validates a protobuf message using protovalidate
-func ValidateMessage(msg proto.Message) error {
-	// Get cached validator
-	v, err := getValidator()
-	if err != nil {
-		// If we can't create a validator, log and continue
-		// This allows the service to run even if validation setup fails
-		return nil
-	}
-
-	// Validate the message and return any error
-	return v.Validate(msg)
-}
-
-// validateHeaders validates required headers for a service and method
-// Returns a ValidationError if any required headers are missing or invalid
-func validateHeaders(r *http.Request, serviceHeaders, methodHeaders []*sebufhttp.Header) *sebufhttp.ValidationError {
-	// Merge service and method headers, with method headers taking precedence
-	allHeaders := make(map[string]*sebufhttp.Header)
-
-	// Add service headers first
-	for _, header := range serviceHeaders {
-		if header.GetRequired() {
-			allHeaders[strings.ToLower(header.GetName())] = header
-		}
-	}
-
-	// Add method headers (override service headers if same name)
-	for _, header := range methodHeaders {
-		if header.GetRequired() {
-			allHeaders[strings.ToLower(header.GetName())] = header
+// guardErrorHandler wraps handler to drop the message it returns after writing
+// the body itself, warning logger (slog.Default() when nil): the response is
+// complete, and marshaling the message too would corrupt it.
+func guardErrorHandler(handler ErrorHandler, logger *slog.Logger) ErrorHandler {
+	return func(w http.ResponseWriter, r *http.Request, err error) proto.Message {
+		response := handler(w, r, err)
+		if capture, ok := w.(*responseCapture); ok && capture.written && response != nil {
+			if logger == nil {
+				logger = slog.Default()
+			}
+			logger.Warn("sebufhttp: error handler wrote the response and returned a message; the message is dropped",
+				slog.String("method", r.Method), slog.String("path", r.URL.Path))
+			return nil
 		}
+		return response
 	}
+}
 
-	// Collect all validation violations
-	var violations []*sebufhttp.FieldViolation
-
-	// Validate each required header
-	for _, headerSpec := range allHeaders {
-		value := r.Header.Get(headerSpec.GetName())
-		if value == "" {
-			violations = append(violations, &sebufhttp.FieldViolation{
-				Field:       headerSpec.GetName(),
-				Description: fmt.Sprintf("required header '%s' is missing", headerSpec.GetName()),
-			})
-			continue
-		}
-
-		if err := validateHeaderValue(headerSpec, value); err != nil {
-			violations = append(violations, &sebufhttp.FieldViolation{
-				Field:       headerSpec.GetName(),
-				Description: fmt.Sprintf("header '%s' validation failed: %v", headerSpec.GetName(), err),
-			})
+// problemErrorHandler wraps handler, which may be nil, to answer the errors it
+// leaves to the server with Problem Details (RFC 9457) as application/problem+json.
+// A message the handler returns is written as usual, and a status it sets is kept.
+func problemErrorHandler(handler ErrorHandler) ErrorHandler {
+	return func(w http.ResponseWriter, r *http.Request, err error) proto.Message {
+		capture := guardResponse(w)
+		if handler != nil {
+			if response := handler(capture, r, err); response != nil || capture.written {
+				return response
+			}
 		}
-	}
-
-	// Return ValidationError if there are violations
-	if len(violations) > 0 {
-		return &sebufhttp.ValidationError{
-			Violations: violations,
+		statusCode := defaultErrorStatusCode(err)
+		if capture.wroteHeader {
+			statusCode = capture.statusCode
 		}
+		sebufhttp.WriteProblem(capture, r, err, statusCode, capture.wroteHeader)
+		return nil
 	}
-
-	return nil
-}
-
-// validateHeaderValue validates a single header value against its specification
-func validateHeaderValue(headerSpec *sebufhttp.Header, value string) error {
-	headerType := headerSpec.GetType()
-	format := headerSpec.GetFormat()
-
-	// Validate based on type
-	switch headerType {
-	case "string":
-		return validateStringHeader(value, format)
-	case "integer":
-		return validateIntegerHeader(value)
-	case "number":
-		return validateNumberHeader(value)
-	case "boolean":
-		return validateBooleanHeader(value)
-	case "array":
-		return validateArrayHeader(value)
-	default:
-		// Default to string validation if type is not specified
-		return validateStringHeader(value, format)
-	}
-}
-
-// validateStringHeader validates string headers with optional format validation
-func validateStringHeader(value, format string) error {
-	if !utf8.ValidString(value) {
-		return fmt.Errorf("value is not valid UTF-8")
-	}
-
-	// Apply format-specific validation
-	switch format {
-	case "uuid":
-		return validateUUIDFormat(value)
-	case "email":
-		return validateEmailFormat(value)
-	case "date-time":
-		return validateDateTimeFormat(value)
-	case "date":
-		return validateDateFormat(value)
-	case "time":
-		return validateTimeFormat(value)
-	}
-
-	return nil
-}
-
-// validateIntegerHeader validates integer headers
-func validateIntegerHeader(value string) error {
-	_, err := strconv.ParseInt(value, 10, 64)
-	if err != nil {
-		return fmt.Errorf("value is not a valid integer: %w", err)
-	}
-	return nil
-}
-
-// validateNumberHeader validates numeric headers (float)
-func validateNumberHeader(value string) error {
-	_, err := strconv.ParseFloat(value, 64)
-	if err != nil {
-		return fmt.Errorf("value is not a valid number: %w", err)
-	}
-	return nil
-}
-
-// validateBooleanHeader validates boolean headers
-func validateBooleanHeader(value string) error {
-	_, err := strconv.ParseBool(value)
-	if err != nil {
-		return fmt.Errorf("value is not a valid boolean: %w", err)
-	}
-	return nil
-}
-
-// validateArrayHeader validates array headers (comma-separated values)
-func validateArrayHeader(value string) error {
-	// Arrays are typically comma-separated values
-	// Basic validation: ensure it's not empty
-	if strings.TrimSpace(value) == "" {
-		return fmt.Errorf("array value cannot be empty")
-	}
-	return nil
-}
-
-// validateUUIDFormat validates UUID format (basic check)
-func validateUUIDFormat(value string) error {
-	// Basic UUID format check: 8-4-4-4-12 hex digits
-	if len(value) != 36 {
-		return fmt.Errorf("UUID must be 36 characters long")
-	}
-
-	// Check for correct dash positions
-	if value[8] != '-' || value[13] != '-' || value[18] != '-' || value[23] != '-' {
-		return fmt.Errorf("invalid UUID format")
-	}
-
-	return nil
-}
-
-// validateEmailFormat validates email format (basic check)
-func validateEmailFormat(value string) error {
-	// Basic email format check
-	if !strings.Contains(value, "@") {
-		return fmt.Errorf("invalid email format: missing @")
-	}
-
-	parts := strings.Split(value, "@")
-	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		return fmt.Errorf("invalid email format")
-	}
-
-	return nil
-}
-
-// validateDateTimeFormat validates RFC3339 date-time format
-func validateDateTimeFormat(value string) error {
-	_, err := time.Parse(time.RFC3339, value)
-	if err != nil {
-		return fmt.Errorf("invalid date-time format, expected RFC3339: %w", err)
-	}
-	return nil
-}
-
-// validateDateFormat validates date format (YYYY-MM-DD)
-func validateDateFormat(value string) error {
-	_, err := time.Parse("2006-01-02", value)
-	if err != nil {
-		return fmt.Errorf("invalid date format, expected YYYY-MM-DD: %w", err)
-	}
-	return nil
-}
-
-// validateTimeFormat validates time format (HH:MM:SS)
-func validateTimeFormat(value string) error {
-	_, err := time.Parse("15:04:05", value)
-	if err != nil {
-		return fmt.Errorf("invalid time format, expected HH:MM:SS: %w", err)
-	}
-	return nil
 }
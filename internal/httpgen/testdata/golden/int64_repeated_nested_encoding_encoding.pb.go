@@ -8,6 +8,8 @@ import (
 	"strconv"
 
 	"google.golang.org/protobuf/encoding/protojson"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
 
 // MarshalJSONSebuf implements sebufMarshaler for Stock.
@@ -131,6 +133,12 @@ func (x *GetStocksResponse) MarshalJSON() ([]byte, error) {
 	return x.MarshalJSONSebuf(protojson.MarshalOptions{})
 }
 
+// getStocksResponseAlternateJSONNames maps the proto names and JSON names protojson
+// accepts for the fields of GetStocksResponse to the keys UnmarshalJSON reads.
+var getStocksResponseAlternateJSONNames = map[string]string{
+	"last_updated": "lastUpdated",
+}
+
 // UnmarshalJSONSebuf implements sebufUnmarshaler for GetStocksResponse.
 // This method handles nested messages that have int64_encoding=NUMBER fields: stocks
 func (x *GetStocksResponse) UnmarshalJSONSebuf(data []byte, opts protojson.UnmarshalOptions) error {
@@ -138,6 +146,9 @@ func (x *GetStocksResponse) UnmarshalJSONSebuf(data []byte, opts protojson.Unmar
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	if err := sebufhttp.ResolveAlternateJSONNames(raw, getStocksResponseAlternateJSONNames); err != nil {
+		return err
+	}
 
 	// Handle repeated "stocks" using its custom unmarshaler
 	if rawVal, ok := raw["stocks"]; ok {
@@ -5,6 +5,7 @@ package int64repeatednested
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -17,21 +18,108 @@ type StockServiceServer interface {
 // RegisterStockServiceServer registers the HTTP handlers for service StockService to the given mux.
 func RegisterStockServiceServer(server StockServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingStockServiceServer{slot: registeredStockServiceServers.Add(server)}
 
 	serviceHeaders := getStockServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getGetStocksHeaders()
 	getStocksHandler := BindingMiddleware[GetStocksRequest](
-		genericHandler(server.GetStocks, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getStocksPathParams, getStocksQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetStocks, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getStocksPathParams, getStocksQueryParams, getStocksHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getStocksHandler = sebufhttp.MetricsMiddleware(getStocksHandler, config.metrics, "testdata.int64repeatednested.StockService.GetStocks")
+	getStocksHandler = sebufhttp.ResponseHeadersMiddleware(getStocksHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/stocks/{market}", getStocksHandler)
+	config.mux.Handle("HEAD /api/v1/stocks/{market}", sebufhttp.HeadHandler(getStocksHandler))
+
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, stockServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
 
 	return nil
 }
 
+// Paths of the routes RegisterStockServiceServer registers.
+const (
+	StockServicePathGetStocks = "/api/v1/stocks/{market}"
+)
+
+// StockServicePathGetStocksFor returns StockServicePathGetStocks with its wildcards replaced by
+// the URL-escaped values of market.
+func StockServicePathGetStocksFor(market string) string {
+	return sebufhttp.BuildPath(StockServicePathGetStocks, market)
+}
+
+// StockServiceServerRoutes returns the routes RegisterStockServiceServer registers.
+func StockServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), stockServiceRouteInfos...)
+}
+
+// stockServiceRouteInfos lists the routes RegisterStockServiceServer registers.
+var stockServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "GET", Path: StockServicePathGetStocks, Service: "testdata.int64repeatednested.StockService", RPC: "GetStocks"},
+}
+
+// registeredStockServiceServers holds the implementation of every StockService registration.
+var registeredStockServiceServers sebufhttp.ServerSlots[StockServiceServer]
+
+// UpdateStockServiceServer makes every handler registered by RegisterStockServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateStockServiceServer(server StockServiceServer) {
+	registeredStockServiceServers.Store(server)
+}
+
+// UnregisterStockServiceServer detaches the implementation from every handler
+// registered by RegisterStockServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateStockServiceServer installs a new implementation.
+func UnregisterStockServiceServer() {
+	registeredStockServiceServers.Clear()
+}
+
+// dispatchingStockServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingStockServiceServer struct {
+	slot *sebufhttp.ServerSlot[StockServiceServer]
+}
+
+func (d dispatchingStockServiceServer) GetStocks(ctx context.Context, req *GetStocksRequest) (*GetStocksResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service StockService is not registered"}
+	}
+	return server.GetStocks(ctx, req)
+}
+
+// UnimplementedStockServiceServer can be embedded in StockServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedStockServiceServer struct{}
+
+func (UnimplementedStockServiceServer) GetStocks(context.Context, *GetStocksRequest) (*GetStocksResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetStocks not implemented"}
+}
+
+// DecodeGetStocksRequest binds r to a GetStocksRequest as the GetStocks handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterStockServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetStocksRequest(r *http.Request) (*GetStocksRequest, error) {
+	req := new(GetStocksRequest)
+	err := bindRequest(nil, r, req, getStocksPathParams, getStocksQueryParams, getStocksHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getStockServiceHeaders returns the service-level required headers for StockService
 func getStockServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -49,3 +137,6 @@ var getStocksPathParams = []PathParamConfig{
 
 // getStocksQueryParams contains query parameter configuration for GetStocks
 var getStocksQueryParams = []QueryParamConfig{}
+
+// getStocksHeaderFieldParams contains header-sourced field configuration for GetStocks
+var getStocksHeaderFieldParams = []HeaderParamConfig{}
@@ -5,6 +5,7 @@ package nullable
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -18,30 +19,156 @@ type NullableServiceServer interface {
 // RegisterNullableServiceServer registers the HTTP handlers for service NullableService to the given mux.
 func RegisterNullableServiceServer(server NullableServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingNullableServiceServer{slot: registeredNullableServiceServers.Add(server)}
 
 	serviceHeaders := getNullableServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getGetUserHeaders()
 	getUserHandler := BindingMiddleware[GetUserRequest](
-		genericHandler(server.GetUser, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getUserPathParams, getUserQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetUser, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getUserPathParams, getUserQueryParams, getUserHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getUserHandler = sebufhttp.MetricsMiddleware(getUserHandler, config.metrics, "testdata.nullable.NullableService.GetUser")
+	getUserHandler = sebufhttp.ResponseHeadersMiddleware(getUserHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/users/{id}", getUserHandler)
+	config.mux.Handle("HEAD /api/v1/users/{id}", sebufhttp.HeadHandler(getUserHandler))
 
 	methodHeaders = getUpdateUserHeaders()
 	updateUserHandler := BindingMiddleware[UpdateUserRequest](
-		genericHandler(server.UpdateUser, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		updateUserPathParams, updateUserQueryParams,
-		"PUT", config.errorHandler, config.marshalOpts,
+		genericHandler(server.UpdateUser, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		updateUserPathParams, updateUserQueryParams, updateUserHeaderFieldParams,
+		"PUT", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	updateUserHandler = sebufhttp.MetricsMiddleware(updateUserHandler, config.metrics, "testdata.nullable.NullableService.UpdateUser")
+	updateUserHandler = sebufhttp.ResponseHeadersMiddleware(updateUserHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("PUT /api/v1/users/{id}", updateUserHandler)
 
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, nullableServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
+
 	return nil
 }
 
+// Paths of the routes RegisterNullableServiceServer registers.
+const (
+	NullableServicePathGetUser    = "/api/v1/users/{id}"
+	NullableServicePathUpdateUser = "/api/v1/users/{id}"
+)
+
+// NullableServicePathGetUserFor returns NullableServicePathGetUser with its wildcards replaced by
+// the URL-escaped values of id.
+func NullableServicePathGetUserFor(id string) string {
+	return sebufhttp.BuildPath(NullableServicePathGetUser, id)
+}
+
+// NullableServicePathUpdateUserFor returns NullableServicePathUpdateUser with its wildcards replaced by
+// the URL-escaped values of id.
+func NullableServicePathUpdateUserFor(id string) string {
+	return sebufhttp.BuildPath(NullableServicePathUpdateUser, id)
+}
+
+// NullableServiceServerRoutes returns the routes RegisterNullableServiceServer registers.
+func NullableServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), nullableServiceRouteInfos...)
+}
+
+// nullableServiceRouteInfos lists the routes RegisterNullableServiceServer registers.
+var nullableServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "GET", Path: NullableServicePathGetUser, Service: "testdata.nullable.NullableService", RPC: "GetUser"},
+	{Method: "PUT", Path: NullableServicePathUpdateUser, Service: "testdata.nullable.NullableService", RPC: "UpdateUser"},
+}
+
+// registeredNullableServiceServers holds the implementation of every NullableService registration.
+var registeredNullableServiceServers sebufhttp.ServerSlots[NullableServiceServer]
+
+// UpdateNullableServiceServer makes every handler registered by RegisterNullableServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateNullableServiceServer(server NullableServiceServer) {
+	registeredNullableServiceServers.Store(server)
+}
+
+// UnregisterNullableServiceServer detaches the implementation from every handler
+// registered by RegisterNullableServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateNullableServiceServer installs a new implementation.
+func UnregisterNullableServiceServer() {
+	registeredNullableServiceServers.Clear()
+}
+
+// dispatchingNullableServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingNullableServiceServer struct {
+	slot *sebufhttp.ServerSlot[NullableServiceServer]
+}
+
+func (d dispatchingNullableServiceServer) GetUser(ctx context.Context, req *GetUserRequest) (*User, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service NullableService is not registered"}
+	}
+	return server.GetUser(ctx, req)
+}
+
+func (d dispatchingNullableServiceServer) UpdateUser(ctx context.Context, req *UpdateUserRequest) (*User, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service NullableService is not registered"}
+	}
+	return server.UpdateUser(ctx, req)
+}
+
+// UnimplementedNullableServiceServer can be embedded in NullableServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedNullableServiceServer struct{}
+
+func (UnimplementedNullableServiceServer) GetUser(context.Context, *GetUserRequest) (*User, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetUser not implemented"}
+}
+
+func (UnimplementedNullableServiceServer) UpdateUser(context.Context, *UpdateUserRequest) (*User, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method UpdateUser not implemented"}
+}
+
+// DecodeGetUserRequest binds r to a GetUserRequest as the GetUser handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterNullableServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetUserRequest(r *http.Request) (*GetUserRequest, error) {
+	req := new(GetUserRequest)
+	err := bindRequest(nil, r, req, getUserPathParams, getUserQueryParams, getUserHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeUpdateUserRequest binds r to a UpdateUserRequest as the UpdateUser handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterNullableServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeUpdateUserRequest(r *http.Request) (*UpdateUserRequest, error) {
+	req := new(UpdateUserRequest)
+	err := bindRequest(nil, r, req, updateUserPathParams, updateUserQueryParams, updateUserHeaderFieldParams,
+		"PUT", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getNullableServiceHeaders returns the service-level required headers for NullableService
 func getNullableServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -65,6 +192,9 @@ var getUserPathParams = []PathParamConfig{
 // getUserQueryParams contains query parameter configuration for GetUser
 var getUserQueryParams = []QueryParamConfig{}
 
+// getUserHeaderFieldParams contains header-sourced field configuration for GetUser
+var getUserHeaderFieldParams = []HeaderParamConfig{}
+
 // updateUserPathParams contains path parameter configuration for UpdateUser
 var updateUserPathParams = []PathParamConfig{
 	{URLParam: "id", FieldName: "id"},
@@ -72,3 +202,6 @@ var updateUserPathParams = []PathParamConfig{
 
 // updateUserQueryParams contains query parameter configuration for UpdateUser
 var updateUserQueryParams = []QueryParamConfig{}
+
+// updateUserHeaderFieldParams contains header-sourced field configuration for UpdateUser
+var updateUserHeaderFieldParams = []HeaderParamConfig{}
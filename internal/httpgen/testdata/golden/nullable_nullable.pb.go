@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 
 	"google.golang.org/protobuf/encoding/protojson"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
 
 // MarshalJSONSebuf implements sebufMarshaler for User.
@@ -54,6 +56,13 @@ func (x *User) MarshalJSON() ([]byte, error) {
 	return x.MarshalJSONSebuf(protojson.MarshalOptions{})
 }
 
+// userAlternateJSONNames maps the proto names and JSON names protojson
+// accepts for the fields of User to the keys UnmarshalJSON reads.
+var userAlternateJSONNames = map[string]string{
+	"is_verified": "isVerified",
+	"middle_name": "middleName",
+}
+
 // UnmarshalJSON implements json.Unmarshaler for User.
 // This method handles nullable fields: middle_name, age, is_verified
 func (x *User) UnmarshalJSON(data []byte) error {
@@ -62,6 +71,9 @@ func (x *User) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	if err := sebufhttp.ResolveAlternateJSONNames(raw, userAlternateJSONNames); err != nil {
+		return err
+	}
 
 	// Handle nullable field: middle_name
 	// Remove explicit null so protojson leaves field unset
@@ -5,6 +5,7 @@ package oneofdiscriminator
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -19,39 +20,185 @@ type OneofDiscriminatorServiceServer interface {
 // RegisterOneofDiscriminatorServiceServer registers the HTTP handlers for service OneofDiscriminatorService to the given mux.
 func RegisterOneofDiscriminatorServiceServer(server OneofDiscriminatorServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingOneofDiscriminatorServiceServer{slot: registeredOneofDiscriminatorServiceServers.Add(server)}
 
 	serviceHeaders := getOneofDiscriminatorServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getTestFlattenedEventHeaders()
 	testFlattenedEventHandler := BindingMiddleware[FlattenedEvent](
-		genericHandler(server.TestFlattenedEvent, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		testFlattenedEventPathParams, testFlattenedEventQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.TestFlattenedEvent, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		testFlattenedEventPathParams, testFlattenedEventQueryParams, testFlattenedEventHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	testFlattenedEventHandler = sebufhttp.MetricsMiddleware(testFlattenedEventHandler, config.metrics, "testdata.oneof_discriminator.OneofDiscriminatorService.TestFlattenedEvent")
+	testFlattenedEventHandler = sebufhttp.ResponseHeadersMiddleware(testFlattenedEventHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v1/events/flattened", testFlattenedEventHandler)
 
 	methodHeaders = getTestNestedEventHeaders()
 	testNestedEventHandler := BindingMiddleware[NestedEvent](
-		genericHandler(server.TestNestedEvent, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		testNestedEventPathParams, testNestedEventQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.TestNestedEvent, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		testNestedEventPathParams, testNestedEventQueryParams, testNestedEventHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	testNestedEventHandler = sebufhttp.MetricsMiddleware(testNestedEventHandler, config.metrics, "testdata.oneof_discriminator.OneofDiscriminatorService.TestNestedEvent")
+	testNestedEventHandler = sebufhttp.ResponseHeadersMiddleware(testNestedEventHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v1/events/nested", testNestedEventHandler)
 
 	methodHeaders = getTestPlainEventHeaders()
 	testPlainEventHandler := BindingMiddleware[PlainEvent](
-		genericHandler(server.TestPlainEvent, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		testPlainEventPathParams, testPlainEventQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.TestPlainEvent, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		testPlainEventPathParams, testPlainEventQueryParams, testPlainEventHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	testPlainEventHandler = sebufhttp.MetricsMiddleware(testPlainEventHandler, config.metrics, "testdata.oneof_discriminator.OneofDiscriminatorService.TestPlainEvent")
+	testPlainEventHandler = sebufhttp.ResponseHeadersMiddleware(testPlainEventHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v1/events/plain", testPlainEventHandler)
 
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, oneofDiscriminatorServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
+
 	return nil
 }
 
+// Paths of the routes RegisterOneofDiscriminatorServiceServer registers.
+const (
+	OneofDiscriminatorServicePathTestFlattenedEvent = "/api/v1/events/flattened"
+	OneofDiscriminatorServicePathTestNestedEvent    = "/api/v1/events/nested"
+	OneofDiscriminatorServicePathTestPlainEvent     = "/api/v1/events/plain"
+)
+
+// OneofDiscriminatorServiceServerRoutes returns the routes RegisterOneofDiscriminatorServiceServer registers.
+func OneofDiscriminatorServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), oneofDiscriminatorServiceRouteInfos...)
+}
+
+// oneofDiscriminatorServiceRouteInfos lists the routes RegisterOneofDiscriminatorServiceServer registers.
+var oneofDiscriminatorServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "POST", Path: OneofDiscriminatorServicePathTestFlattenedEvent, Service: "testdata.oneof_discriminator.OneofDiscriminatorService", RPC: "TestFlattenedEvent"},
+	{Method: "POST", Path: OneofDiscriminatorServicePathTestNestedEvent, Service: "testdata.oneof_discriminator.OneofDiscriminatorService", RPC: "TestNestedEvent"},
+	{Method: "POST", Path: OneofDiscriminatorServicePathTestPlainEvent, Service: "testdata.oneof_discriminator.OneofDiscriminatorService", RPC: "TestPlainEvent"},
+}
+
+// registeredOneofDiscriminatorServiceServers holds the implementation of every OneofDiscriminatorService registration.
+var registeredOneofDiscriminatorServiceServers sebufhttp.ServerSlots[OneofDiscriminatorServiceServer]
+
+// UpdateOneofDiscriminatorServiceServer makes every handler registered by RegisterOneofDiscriminatorServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateOneofDiscriminatorServiceServer(server OneofDiscriminatorServiceServer) {
+	registeredOneofDiscriminatorServiceServers.Store(server)
+}
+
+// UnregisterOneofDiscriminatorServiceServer detaches the implementation from every handler
+// registered by RegisterOneofDiscriminatorServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateOneofDiscriminatorServiceServer installs a new implementation.
+func UnregisterOneofDiscriminatorServiceServer() {
+	registeredOneofDiscriminatorServiceServers.Clear()
+}
+
+// dispatchingOneofDiscriminatorServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingOneofDiscriminatorServiceServer struct {
+	slot *sebufhttp.ServerSlot[OneofDiscriminatorServiceServer]
+}
+
+func (d dispatchingOneofDiscriminatorServiceServer) TestFlattenedEvent(ctx context.Context, req *FlattenedEvent) (*FlattenedEvent, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service OneofDiscriminatorService is not registered"}
+	}
+	return server.TestFlattenedEvent(ctx, req)
+}
+
+func (d dispatchingOneofDiscriminatorServiceServer) TestNestedEvent(ctx context.Context, req *NestedEvent) (*NestedEvent, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service OneofDiscriminatorService is not registered"}
+	}
+	return server.TestNestedEvent(ctx, req)
+}
+
+func (d dispatchingOneofDiscriminatorServiceServer) TestPlainEvent(ctx context.Context, req *PlainEvent) (*PlainEvent, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service OneofDiscriminatorService is not registered"}
+	}
+	return server.TestPlainEvent(ctx, req)
+}
+
+// UnimplementedOneofDiscriminatorServiceServer can be embedded in OneofDiscriminatorServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedOneofDiscriminatorServiceServer struct{}
+
+func (UnimplementedOneofDiscriminatorServiceServer) TestFlattenedEvent(context.Context, *FlattenedEvent) (*FlattenedEvent, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method TestFlattenedEvent not implemented"}
+}
+
+func (UnimplementedOneofDiscriminatorServiceServer) TestNestedEvent(context.Context, *NestedEvent) (*NestedEvent, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method TestNestedEvent not implemented"}
+}
+
+func (UnimplementedOneofDiscriminatorServiceServer) TestPlainEvent(context.Context, *PlainEvent) (*PlainEvent, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method TestPlainEvent not implemented"}
+}
+
+// DecodeTestFlattenedEventRequest binds r to a FlattenedEvent as the TestFlattenedEvent handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterOneofDiscriminatorServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeTestFlattenedEventRequest(r *http.Request) (*FlattenedEvent, error) {
+	req := new(FlattenedEvent)
+	err := bindRequest(nil, r, req, testFlattenedEventPathParams, testFlattenedEventQueryParams, testFlattenedEventHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeTestNestedEventRequest binds r to a NestedEvent as the TestNestedEvent handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterOneofDiscriminatorServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeTestNestedEventRequest(r *http.Request) (*NestedEvent, error) {
+	req := new(NestedEvent)
+	err := bindRequest(nil, r, req, testNestedEventPathParams, testNestedEventQueryParams, testNestedEventHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeTestPlainEventRequest binds r to a PlainEvent as the TestPlainEvent handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterOneofDiscriminatorServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeTestPlainEventRequest(r *http.Request) (*PlainEvent, error) {
+	req := new(PlainEvent)
+	err := bindRequest(nil, r, req, testPlainEventPathParams, testPlainEventQueryParams, testPlainEventHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getOneofDiscriminatorServiceHeaders returns the service-level required headers for OneofDiscriminatorService
 func getOneofDiscriminatorServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -78,14 +225,23 @@ var testFlattenedEventPathParams = []PathParamConfig{}
 // testFlattenedEventQueryParams contains query parameter configuration for TestFlattenedEvent
 var testFlattenedEventQueryParams = []QueryParamConfig{}
 
+// testFlattenedEventHeaderFieldParams contains header-sourced field configuration for TestFlattenedEvent
+var testFlattenedEventHeaderFieldParams = []HeaderParamConfig{}
+
 // testNestedEventPathParams contains path parameter configuration for TestNestedEvent
 var testNestedEventPathParams = []PathParamConfig{}
 
 // testNestedEventQueryParams contains query parameter configuration for TestNestedEvent
 var testNestedEventQueryParams = []QueryParamConfig{}
 
+// testNestedEventHeaderFieldParams contains header-sourced field configuration for TestNestedEvent
+var testNestedEventHeaderFieldParams = []HeaderParamConfig{}
+
 // testPlainEventPathParams contains path parameter configuration for TestPlainEvent
 var testPlainEventPathParams = []PathParamConfig{}
 
 // testPlainEventQueryParams contains query parameter configuration for TestPlainEvent
 var testPlainEventQueryParams = []QueryParamConfig{}
+
+// testPlainEventHeaderFieldParams contains header-sourced field configuration for TestPlainEvent
+var testPlainEventHeaderFieldParams = []HeaderParamConfig{}
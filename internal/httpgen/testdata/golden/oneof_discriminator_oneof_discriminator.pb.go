@@ -117,11 +117,17 @@ func (x *FlattenedEvent) UnmarshalJSON(data []byte) error {
 			}
 			variantData, _ := json.Marshal(variantMap)
 			variant := &TextContent{}
-			if err := json.Unmarshal(variantData, variant); err != nil {
-				return fmt.Errorf("failed to unmarshal variant %s: %%w", "Text", err)
+			var variantErr error
+			if u, ok := any(variant).(json.Unmarshaler); ok {
+				variantErr = u.UnmarshalJSON(variantData)
+			} else {
+				variantErr = protojson.Unmarshal(variantData, variant)
+			}
+			if variantErr != nil {
+				return fmt.Errorf("failed to unmarshal variant %s: %%w", "Text", variantErr)
 			}
 			x.Content = &FlattenedEvent_Text{Text: variant}
-			raw["text"], _ = json.Marshal(variant)
+			raw["text"], _ = protojson.Marshal(variant)
 		case "img":
 			// Flatten unmarshal: extract Image fields from flat map
 			variantMap := make(map[string]json.RawMessage)
@@ -139,11 +145,17 @@ func (x *FlattenedEvent) UnmarshalJSON(data []byte) error {
 			}
 			variantData, _ := json.Marshal(variantMap)
 			variant := &ImageContent{}
-			if err := json.Unmarshal(variantData, variant); err != nil {
-				return fmt.Errorf("failed to unmarshal variant %s: %%w", "Image", err)
+			var variantErr error
+			if u, ok := any(variant).(json.Unmarshaler); ok {
+				variantErr = u.UnmarshalJSON(variantData)
+			} else {
+				variantErr = protojson.Unmarshal(variantData, variant)
+			}
+			if variantErr != nil {
+				return fmt.Errorf("failed to unmarshal variant %s: %%w", "Image", variantErr)
 			}
 			x.Content = &FlattenedEvent_Image{Image: variant}
-			raw["image"], _ = json.Marshal(variant)
+			raw["image"], _ = protojson.Marshal(variant)
 		}
 	}
 
@@ -216,29 +228,47 @@ func (x *NestedEvent) UnmarshalJSON(data []byte) error {
 
 		switch disc {
 		case "text":
-			// Non-flattened unmarshal: use json.Unmarshal for child UnmarshalJSON support
+			// Non-flattened unmarshal: use the child's UnmarshalJSON when it has one
 			if variantRaw, exists := raw["text"]; exists {
 				variant := &TextContent{}
-				if err := json.Unmarshal(variantRaw, variant); err != nil {
-					return fmt.Errorf("failed to unmarshal variant %s: %%w", "Text", err)
+				var variantErr error
+				if u, ok := any(variant).(json.Unmarshaler); ok {
+					variantErr = u.UnmarshalJSON(variantRaw)
+				} else {
+					variantErr = protojson.Unmarshal(variantRaw, variant)
+				}
+				if variantErr != nil {
+					return fmt.Errorf("failed to unmarshal variant %s: %%w", "Text", variantErr)
 				}
 				x.Content = &NestedEvent_Text{Text: variant}
 			}
 		case "image":
-			// Non-flattened unmarshal: use json.Unmarshal for child UnmarshalJSON support
+			// Non-flattened unmarshal: use the child's UnmarshalJSON when it has one
 			if variantRaw, exists := raw["image"]; exists {
 				variant := &ImageContent{}
-				if err := json.Unmarshal(variantRaw, variant); err != nil {
-					return fmt.Errorf("failed to unmarshal variant %s: %%w", "Image", err)
+				var variantErr error
+				if u, ok := any(variant).(json.Unmarshaler); ok {
+					variantErr = u.UnmarshalJSON(variantRaw)
+				} else {
+					variantErr = protojson.Unmarshal(variantRaw, variant)
+				}
+				if variantErr != nil {
+					return fmt.Errorf("failed to unmarshal variant %s: %%w", "Image", variantErr)
 				}
 				x.Content = &NestedEvent_Image{Image: variant}
 			}
 		case "vid":
-			// Non-flattened unmarshal: use json.Unmarshal for child UnmarshalJSON support
+			// Non-flattened unmarshal: use the child's UnmarshalJSON when it has one
 			if variantRaw, exists := raw["video"]; exists {
 				variant := &VideoContent{}
-				if err := json.Unmarshal(variantRaw, variant); err != nil {
-					return fmt.Errorf("failed to unmarshal variant %s: %%w", "Video", err)
+				var variantErr error
+				if u, ok := any(variant).(json.Unmarshaler); ok {
+					variantErr = u.UnmarshalJSON(variantRaw)
+				} else {
+					variantErr = protojson.Unmarshal(variantRaw, variant)
+				}
+				if variantErr != nil {
+					return fmt.Errorf("failed to unmarshal variant %s: %%w", "Video", variantErr)
 				}
 				x.Content = &NestedEvent_Video{Video: variant}
 			}
@@ -5,6 +5,7 @@ package generated
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -23,75 +24,372 @@ type QueryParamServiceServer interface {
 // RegisterQueryParamServiceServer registers the HTTP handlers for service QueryParamService to the given mux.
 func RegisterQueryParamServiceServer(server QueryParamServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingQueryParamServiceServer{slot: registeredQueryParamServiceServers.Add(server)}
 
 	serviceHeaders := getQueryParamServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getSearchWithTypesHeaders()
 	searchWithTypesHandler := BindingMiddleware[SearchWithTypesRequest](
-		genericHandler(server.SearchWithTypes, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		searchWithTypesPathParams, searchWithTypesQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.SearchWithTypes, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		searchWithTypesPathParams, searchWithTypesQueryParams, searchWithTypesHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	searchWithTypesHandler = sebufhttp.MetricsMiddleware(searchWithTypesHandler, config.metrics, "test.httpgen.query.QueryParamService.SearchWithTypes")
+	searchWithTypesHandler = sebufhttp.ResponseHeadersMiddleware(searchWithTypesHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/search/typed", searchWithTypesHandler)
+	config.mux.Handle("HEAD /api/search/typed", sebufhttp.HeadHandler(searchWithTypesHandler))
 
 	methodHeaders = getSearchRequiredHeaders()
 	searchRequiredHandler := BindingMiddleware[SearchRequiredRequest](
-		genericHandler(server.SearchRequired, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		searchRequiredPathParams, searchRequiredQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.SearchRequired, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		searchRequiredPathParams, searchRequiredQueryParams, searchRequiredHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	searchRequiredHandler = sebufhttp.MetricsMiddleware(searchRequiredHandler, config.metrics, "test.httpgen.query.QueryParamService.SearchRequired")
+	searchRequiredHandler = sebufhttp.ResponseHeadersMiddleware(searchRequiredHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/search/required", searchRequiredHandler)
+	config.mux.Handle("HEAD /api/search/required", sebufhttp.HeadHandler(searchRequiredHandler))
 
 	methodHeaders = getSearchCustomNamesHeaders()
 	searchCustomNamesHandler := BindingMiddleware[SearchCustomNamesRequest](
-		genericHandler(server.SearchCustomNames, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		searchCustomNamesPathParams, searchCustomNamesQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.SearchCustomNames, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		searchCustomNamesPathParams, searchCustomNamesQueryParams, searchCustomNamesHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	searchCustomNamesHandler = sebufhttp.MetricsMiddleware(searchCustomNamesHandler, config.metrics, "test.httpgen.query.QueryParamService.SearchCustomNames")
+	searchCustomNamesHandler = sebufhttp.ResponseHeadersMiddleware(searchCustomNamesHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/search/custom", searchCustomNamesHandler)
+	config.mux.Handle("HEAD /api/search/custom", sebufhttp.HeadHandler(searchCustomNamesHandler))
 
 	methodHeaders = getGetWithFiltersHeaders()
 	getWithFiltersHandler := BindingMiddleware[GetWithFiltersRequest](
-		genericHandler(server.GetWithFilters, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getWithFiltersPathParams, getWithFiltersQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetWithFilters, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getWithFiltersPathParams, getWithFiltersQueryParams, getWithFiltersHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getWithFiltersHandler = sebufhttp.MetricsMiddleware(getWithFiltersHandler, config.metrics, "test.httpgen.query.QueryParamService.GetWithFilters")
+	getWithFiltersHandler = sebufhttp.ResponseHeadersMiddleware(getWithFiltersHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/resources/{resource_id}/items", getWithFiltersHandler)
+	config.mux.Handle("HEAD /api/resources/{resource_id}/items", sebufhttp.HeadHandler(getWithFiltersHandler))
 
 	methodHeaders = getSearchAdvancedHeaders()
 	searchAdvancedHandler := BindingMiddleware[SearchAdvancedRequest](
-		genericHandler(server.SearchAdvanced, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		searchAdvancedPathParams, searchAdvancedQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.SearchAdvanced, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		searchAdvancedPathParams, searchAdvancedQueryParams, searchAdvancedHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	searchAdvancedHandler = sebufhttp.MetricsMiddleware(searchAdvancedHandler, config.metrics, "test.httpgen.query.QueryParamService.SearchAdvanced")
+	searchAdvancedHandler = sebufhttp.ResponseHeadersMiddleware(searchAdvancedHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/search/advanced", searchAdvancedHandler)
+	config.mux.Handle("HEAD /api/search/advanced", sebufhttp.HeadHandler(searchAdvancedHandler))
 
 	methodHeaders = getGetByRegionHeaders()
 	getByRegionHandler := BindingMiddleware[GetByRegionRequest](
-		genericHandler(server.GetByRegion, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getByRegionPathParams, getByRegionQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetByRegion, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getByRegionPathParams, getByRegionQueryParams, getByRegionHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getByRegionHandler = sebufhttp.MetricsMiddleware(getByRegionHandler, config.metrics, "test.httpgen.query.QueryParamService.GetByRegion")
+	getByRegionHandler = sebufhttp.ResponseHeadersMiddleware(getByRegionHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/regions/{region}", getByRegionHandler)
+	config.mux.Handle("HEAD /api/regions/{region}", sebufhttp.HeadHandler(getByRegionHandler))
 
 	methodHeaders = getGetDefaultsHeaders()
 	getDefaultsHandler := BindingMiddleware[EmptyRequest](
-		genericHandler(server.GetDefaults, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getDefaultsPathParams, getDefaultsQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetDefaults, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getDefaultsPathParams, getDefaultsQueryParams, getDefaultsHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getDefaultsHandler = sebufhttp.MetricsMiddleware(getDefaultsHandler, config.metrics, "test.httpgen.query.QueryParamService.GetDefaults")
+	getDefaultsHandler = sebufhttp.ResponseHeadersMiddleware(getDefaultsHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/defaults", getDefaultsHandler)
+	config.mux.Handle("HEAD /api/defaults", sebufhttp.HeadHandler(getDefaultsHandler))
+
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, queryParamServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
 
 	return nil
 }
 
+// Paths of the routes RegisterQueryParamServiceServer registers.
+const (
+	QueryParamServicePathSearchWithTypes   = "/api/search/typed"
+	QueryParamServicePathSearchRequired    = "/api/search/required"
+	QueryParamServicePathSearchCustomNames = "/api/search/custom"
+	QueryParamServicePathGetWithFilters    = "/api/resources/{resource_id}/items"
+	QueryParamServicePathSearchAdvanced    = "/api/search/advanced"
+	QueryParamServicePathGetByRegion       = "/api/regions/{region}"
+	QueryParamServicePathGetDefaults       = "/api/defaults"
+)
+
+// QueryParamServicePathGetWithFiltersFor returns QueryParamServicePathGetWithFilters with its wildcards replaced by
+// the URL-escaped values of resourceID.
+func QueryParamServicePathGetWithFiltersFor(resourceID string) string {
+	return sebufhttp.BuildPath(QueryParamServicePathGetWithFilters, resourceID)
+}
+
+// QueryParamServicePathGetByRegionFor returns QueryParamServicePathGetByRegion with its wildcards replaced by
+// the URL-escaped values of region.
+func QueryParamServicePathGetByRegionFor(region string) string {
+	return sebufhttp.BuildPath(QueryParamServicePathGetByRegion, region)
+}
+
+// QueryParamServiceServerRoutes returns the routes RegisterQueryParamServiceServer registers.
+func QueryParamServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), queryParamServiceRouteInfos...)
+}
+
+// queryParamServiceRouteInfos lists the routes RegisterQueryParamServiceServer registers.
+var queryParamServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "GET", Path: QueryParamServicePathSearchWithTypes, Service: "test.httpgen.query.QueryParamService", RPC: "SearchWithTypes"},
+	{Method: "GET", Path: QueryParamServicePathSearchRequired, Service: "test.httpgen.query.QueryParamService", RPC: "SearchRequired"},
+	{Method: "GET", Path: QueryParamServicePathSearchCustomNames, Service: "test.httpgen.query.QueryParamService", RPC: "SearchCustomNames"},
+	{Method: "GET", Path: QueryParamServicePathGetWithFilters, Service: "test.httpgen.query.QueryParamService", RPC: "GetWithFilters"},
+	{Method: "GET", Path: QueryParamServicePathSearchAdvanced, Service: "test.httpgen.query.QueryParamService", RPC: "SearchAdvanced"},
+	{Method: "GET", Path: QueryParamServicePathGetByRegion, Service: "test.httpgen.query.QueryParamService", RPC: "GetByRegion"},
+	{Method: "GET", Path: QueryParamServicePathGetDefaults, Service: "test.httpgen.query.QueryParamService", RPC: "GetDefaults"},
+}
+
+// registeredQueryParamServiceServers holds the implementation of every QueryParamService registration.
+var registeredQueryParamServiceServers sebufhttp.ServerSlots[QueryParamServiceServer]
+
+// UpdateQueryParamServiceServer makes every handler registered by RegisterQueryParamServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateQueryParamServiceServer(server QueryParamServiceServer) {
+	registeredQueryParamServiceServers.Store(server)
+}
+
+// UnregisterQueryParamServiceServer detaches the implementation from every handler
+// registered by RegisterQueryParamServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateQueryParamServiceServer installs a new implementation.
+func UnregisterQueryParamServiceServer() {
+	registeredQueryParamServiceServers.Clear()
+}
+
+// dispatchingQueryParamServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingQueryParamServiceServer struct {
+	slot *sebufhttp.ServerSlot[QueryParamServiceServer]
+}
+
+func (d dispatchingQueryParamServiceServer) SearchWithTypes(ctx context.Context, req *SearchWithTypesRequest) (*SearchResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service QueryParamService is not registered"}
+	}
+	return server.SearchWithTypes(ctx, req)
+}
+
+func (d dispatchingQueryParamServiceServer) SearchRequired(ctx context.Context, req *SearchRequiredRequest) (*SearchResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service QueryParamService is not registered"}
+	}
+	return server.SearchRequired(ctx, req)
+}
+
+func (d dispatchingQueryParamServiceServer) SearchCustomNames(ctx context.Context, req *SearchCustomNamesRequest) (*SearchResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service QueryParamService is not registered"}
+	}
+	return server.SearchCustomNames(ctx, req)
+}
+
+func (d dispatchingQueryParamServiceServer) GetWithFilters(ctx context.Context, req *GetWithFiltersRequest) (*SearchResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service QueryParamService is not registered"}
+	}
+	return server.GetWithFilters(ctx, req)
+}
+
+func (d dispatchingQueryParamServiceServer) SearchAdvanced(ctx context.Context, req *SearchAdvancedRequest) (*SearchResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service QueryParamService is not registered"}
+	}
+	return server.SearchAdvanced(ctx, req)
+}
+
+func (d dispatchingQueryParamServiceServer) GetByRegion(ctx context.Context, req *GetByRegionRequest) (*SearchResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service QueryParamService is not registered"}
+	}
+	return server.GetByRegion(ctx, req)
+}
+
+func (d dispatchingQueryParamServiceServer) GetDefaults(ctx context.Context, req *EmptyRequest) (*SearchResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service QueryParamService is not registered"}
+	}
+	return server.GetDefaults(ctx, req)
+}
+
+// UnimplementedQueryParamServiceServer can be embedded in QueryParamServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedQueryParamServiceServer struct{}
+
+func (UnimplementedQueryParamServiceServer) SearchWithTypes(context.Context, *SearchWithTypesRequest) (*SearchResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method SearchWithTypes not implemented"}
+}
+
+func (UnimplementedQueryParamServiceServer) SearchRequired(context.Context, *SearchRequiredRequest) (*SearchResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method SearchRequired not implemented"}
+}
+
+func (UnimplementedQueryParamServiceServer) SearchCustomNames(context.Context, *SearchCustomNamesRequest) (*SearchResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method SearchCustomNames not implemented"}
+}
+
+func (UnimplementedQueryParamServiceServer) GetWithFilters(context.Context, *GetWithFiltersRequest) (*SearchResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetWithFilters not implemented"}
+}
+
+func (UnimplementedQueryParamServiceServer) SearchAdvanced(context.Context, *SearchAdvancedRequest) (*SearchResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method SearchAdvanced not implemented"}
+}
+
+func (UnimplementedQueryParamServiceServer) GetByRegion(context.Context, *GetByRegionRequest) (*SearchResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetByRegion not implemented"}
+}
+
+func (UnimplementedQueryParamServiceServer) GetDefaults(context.Context, *EmptyRequest) (*SearchResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetDefaults not implemented"}
+}
+
+// DecodeSearchWithTypesRequest binds r to a SearchWithTypesRequest as the SearchWithTypes handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterQueryParamServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeSearchWithTypesRequest(r *http.Request) (*SearchWithTypesRequest, error) {
+	req := new(SearchWithTypesRequest)
+	err := bindRequest(nil, r, req, searchWithTypesPathParams, searchWithTypesQueryParams, searchWithTypesHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeSearchRequiredRequest binds r to a SearchRequiredRequest as the SearchRequired handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterQueryParamServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeSearchRequiredRequest(r *http.Request) (*SearchRequiredRequest, error) {
+	req := new(SearchRequiredRequest)
+	err := bindRequest(nil, r, req, searchRequiredPathParams, searchRequiredQueryParams, searchRequiredHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeSearchCustomNamesRequest binds r to a SearchCustomNamesRequest as the SearchCustomNames handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterQueryParamServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeSearchCustomNamesRequest(r *http.Request) (*SearchCustomNamesRequest, error) {
+	req := new(SearchCustomNamesRequest)
+	err := bindRequest(nil, r, req, searchCustomNamesPathParams, searchCustomNamesQueryParams, searchCustomNamesHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeGetWithFiltersRequest binds r to a GetWithFiltersRequest as the GetWithFilters handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterQueryParamServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetWithFiltersRequest(r *http.Request) (*GetWithFiltersRequest, error) {
+	req := new(GetWithFiltersRequest)
+	err := bindRequest(nil, r, req, getWithFiltersPathParams, getWithFiltersQueryParams, getWithFiltersHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeSearchAdvancedRequest binds r to a SearchAdvancedRequest as the SearchAdvanced handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterQueryParamServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeSearchAdvancedRequest(r *http.Request) (*SearchAdvancedRequest, error) {
+	req := new(SearchAdvancedRequest)
+	err := bindRequest(nil, r, req, searchAdvancedPathParams, searchAdvancedQueryParams, searchAdvancedHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeGetByRegionRequest binds r to a GetByRegionRequest as the GetByRegion handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterQueryParamServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetByRegionRequest(r *http.Request) (*GetByRegionRequest, error) {
+	req := new(GetByRegionRequest)
+	err := bindRequest(nil, r, req, getByRegionPathParams, getByRegionQueryParams, getByRegionHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeGetDefaultsRequest binds r to a EmptyRequest as the GetDefaults handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterQueryParamServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetDefaultsRequest(r *http.Request) (*EmptyRequest, error) {
+	req := new(EmptyRequest)
+	err := bindRequest(nil, r, req, getDefaultsPathParams, getDefaultsQueryParams, getDefaultsHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getQueryParamServiceHeaders returns the service-level required headers for QueryParamService
 func getQueryParamServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -147,6 +445,9 @@ var searchWithTypesQueryParams = []QueryParamConfig{
 	{QueryName: "ts", FieldName: "timestamp", Required: false},
 }
 
+// searchWithTypesHeaderFieldParams contains header-sourced field configuration for SearchWithTypes
+var searchWithTypesHeaderFieldParams = []HeaderParamConfig{}
+
 // searchRequiredPathParams contains path parameter configuration for SearchRequired
 var searchRequiredPathParams = []PathParamConfig{}
 
@@ -157,6 +458,9 @@ var searchRequiredQueryParams = []QueryParamConfig{
 	{QueryName: "page_size", FieldName: "page_size", Required: false},
 }
 
+// searchRequiredHeaderFieldParams contains header-sourced field configuration for SearchRequired
+var searchRequiredHeaderFieldParams = []HeaderParamConfig{}
+
 // searchCustomNamesPathParams contains path parameter configuration for SearchCustomNames
 var searchCustomNamesPathParams = []PathParamConfig{}
 
@@ -169,6 +473,9 @@ var searchCustomNamesQueryParams = []QueryParamConfig{
 	{QueryName: "desc", FieldName: "descending_order", Required: false},
 }
 
+// searchCustomNamesHeaderFieldParams contains header-sourced field configuration for SearchCustomNames
+var searchCustomNamesHeaderFieldParams = []HeaderParamConfig{}
+
 // getWithFiltersPathParams contains path parameter configuration for GetWithFilters
 var getWithFiltersPathParams = []PathParamConfig{
 	{URLParam: "resource_id", FieldName: "resource_id"},
@@ -180,6 +487,9 @@ var getWithFiltersQueryParams = []QueryParamConfig{
 	{QueryName: "limit", FieldName: "limit", Required: false},
 }
 
+// getWithFiltersHeaderFieldParams contains header-sourced field configuration for GetWithFilters
+var getWithFiltersHeaderFieldParams = []HeaderParamConfig{}
+
 // searchAdvancedPathParams contains path parameter configuration for SearchAdvanced
 var searchAdvancedPathParams = []PathParamConfig{}
 
@@ -193,6 +503,9 @@ var searchAdvancedQueryParams = []QueryParamConfig{
 	{QueryName: "regions", FieldName: "regions", Required: false},
 }
 
+// searchAdvancedHeaderFieldParams contains header-sourced field configuration for SearchAdvanced
+var searchAdvancedHeaderFieldParams = []HeaderParamConfig{}
+
 // getByRegionPathParams contains path parameter configuration for GetByRegion
 var getByRegionPathParams = []PathParamConfig{
 	{URLParam: "region", FieldName: "region"},
@@ -203,8 +516,14 @@ var getByRegionQueryParams = []QueryParamConfig{
 	{QueryName: "keyword", FieldName: "keyword", Required: false},
 }
 
+// getByRegionHeaderFieldParams contains header-sourced field configuration for GetByRegion
+var getByRegionHeaderFieldParams = []HeaderParamConfig{}
+
 // getDefaultsPathParams contains path parameter configuration for GetDefaults
 var getDefaultsPathParams = []PathParamConfig{}
 
 // getDefaultsQueryParams contains query parameter configuration for GetDefaults
 var getDefaultsQueryParams = []QueryParamConfig{}
+
+// getDefaultsHeaderFieldParams contains header-sourced field configuration for GetDefaults
+var getDefaultsHeaderFieldParams = []HeaderParamConfig{}
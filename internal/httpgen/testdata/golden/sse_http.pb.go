@@ -5,6 +5,7 @@ package generated
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -20,48 +21,237 @@ type SSEServiceServer interface {
 // RegisterSSEServiceServer registers the HTTP handlers for service SSEService to the given mux.
 func RegisterSSEServiceServer(server SSEServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingSSEServiceServer{slot: registeredSSEServiceServers.Add(server)}
 
 	serviceHeaders := getSSEServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getGetStatusHeaders()
 	getStatusHandler := BindingMiddleware[GetStatusRequest](
-		genericHandler(server.GetStatus, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getStatusPathParams, getStatusQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetStatus, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getStatusPathParams, getStatusQueryParams, getStatusHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getStatusHandler = sebufhttp.MetricsMiddleware(getStatusHandler, config.metrics, "test.sse.SSEService.GetStatus")
+	getStatusHandler = sebufhttp.ResponseHeadersMiddleware(getStatusHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/status", getStatusHandler)
+	config.mux.Handle("HEAD /api/v1/status", sebufhttp.HeadHandler(getStatusHandler))
 
 	methodHeaders = getStreamEventsHeaders()
 	streamEventsHandler := SSEHandler[StreamEventsRequest](
 		server.StreamEvents, config.errorHandler, serviceHeaders, methodHeaders,
-		streamEventsPathParams, streamEventsQueryParams,
-		"GET", config.marshalOpts,
+		streamEventsPathParams, streamEventsQueryParams, streamEventsHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators,
 	)
+	streamEventsHandler = sebufhttp.MetricsMiddleware(streamEventsHandler, config.metrics, "test.sse.SSEService.StreamEvents")
+	streamEventsHandler = sebufhttp.ResponseHeadersMiddleware(streamEventsHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/events", streamEventsHandler)
+	config.mux.Handle("HEAD /api/v1/events", streamEventsHandler)
 
 	methodHeaders = getStreamResourceEventsHeaders()
 	streamResourceEventsHandler := SSEHandler[StreamResourceEventsRequest](
 		server.StreamResourceEvents, config.errorHandler, serviceHeaders, methodHeaders,
-		streamResourceEventsPathParams, streamResourceEventsQueryParams,
-		"GET", config.marshalOpts,
+		streamResourceEventsPathParams, streamResourceEventsQueryParams, streamResourceEventsHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators,
 	)
+	streamResourceEventsHandler = sebufhttp.MetricsMiddleware(streamResourceEventsHandler, config.metrics, "test.sse.SSEService.StreamResourceEvents")
+	streamResourceEventsHandler = sebufhttp.ResponseHeadersMiddleware(streamResourceEventsHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/resources/{resource_id}/events", streamResourceEventsHandler)
+	config.mux.Handle("HEAD /api/v1/resources/{resource_id}/events", streamResourceEventsHandler)
 
 	methodHeaders = getStreamFilteredEventsHeaders()
 	streamFilteredEventsHandler := SSEHandler[StreamFilteredEventsRequest](
 		server.StreamFilteredEvents, config.errorHandler, serviceHeaders, methodHeaders,
-		streamFilteredEventsPathParams, streamFilteredEventsQueryParams,
-		"GET", config.marshalOpts,
+		streamFilteredEventsPathParams, streamFilteredEventsQueryParams, streamFilteredEventsHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators,
 	)
+	streamFilteredEventsHandler = sebufhttp.MetricsMiddleware(streamFilteredEventsHandler, config.metrics, "test.sse.SSEService.StreamFilteredEvents")
+	streamFilteredEventsHandler = sebufhttp.ResponseHeadersMiddleware(streamFilteredEventsHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/events/filtered", streamFilteredEventsHandler)
+	config.mux.Handle("HEAD /api/v1/events/filtered", streamFilteredEventsHandler)
+
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, sSEServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
 
 	return nil
 }
 
+// Paths of the routes RegisterSSEServiceServer registers.
+const (
+	SSEServicePathGetStatus            = "/api/v1/status"
+	SSEServicePathStreamEvents         = "/api/v1/events"
+	SSEServicePathStreamResourceEvents = "/api/v1/resources/{resource_id}/events"
+	SSEServicePathStreamFilteredEvents = "/api/v1/events/filtered"
+)
+
+// SSEServicePathStreamResourceEventsFor returns SSEServicePathStreamResourceEvents with its wildcards replaced by
+// the URL-escaped values of resourceID.
+func SSEServicePathStreamResourceEventsFor(resourceID string) string {
+	return sebufhttp.BuildPath(SSEServicePathStreamResourceEvents, resourceID)
+}
+
+// SSEServiceServerRoutes returns the routes RegisterSSEServiceServer registers.
+func SSEServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), sSEServiceRouteInfos...)
+}
+
+// sSEServiceRouteInfos lists the routes RegisterSSEServiceServer registers.
+var sSEServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "GET", Path: SSEServicePathGetStatus, Service: "test.sse.SSEService", RPC: "GetStatus"},
+	{Method: "GET", Path: SSEServicePathStreamEvents, Service: "test.sse.SSEService", RPC: "StreamEvents"},
+	{Method: "GET", Path: SSEServicePathStreamResourceEvents, Service: "test.sse.SSEService", RPC: "StreamResourceEvents"},
+	{Method: "GET", Path: SSEServicePathStreamFilteredEvents, Service: "test.sse.SSEService", RPC: "StreamFilteredEvents"},
+}
+
+// registeredSSEServiceServers holds the implementation of every SSEService registration.
+var registeredSSEServiceServers sebufhttp.ServerSlots[SSEServiceServer]
+
+// UpdateSSEServiceServer makes every handler registered by RegisterSSEServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateSSEServiceServer(server SSEServiceServer) {
+	registeredSSEServiceServers.Store(server)
+}
+
+// UnregisterSSEServiceServer detaches the implementation from every handler
+// registered by RegisterSSEServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateSSEServiceServer installs a new implementation.
+func UnregisterSSEServiceServer() {
+	registeredSSEServiceServers.Clear()
+}
+
+// dispatchingSSEServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingSSEServiceServer struct {
+	slot *sebufhttp.ServerSlot[SSEServiceServer]
+}
+
+func (d dispatchingSSEServiceServer) GetStatus(ctx context.Context, req *GetStatusRequest) (*StatusResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service SSEService is not registered"}
+	}
+	return server.GetStatus(ctx, req)
+}
+
+func (d dispatchingSSEServiceServer) StreamEvents(ctx context.Context, req *StreamEventsRequest, sender SSESender) error {
+	server, ok := d.slot.Load()
+	if !ok {
+		return &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service SSEService is not registered"}
+	}
+	return server.StreamEvents(ctx, req, sender)
+}
+
+func (d dispatchingSSEServiceServer) StreamResourceEvents(ctx context.Context, req *StreamResourceEventsRequest, sender SSESender) error {
+	server, ok := d.slot.Load()
+	if !ok {
+		return &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service SSEService is not registered"}
+	}
+	return server.StreamResourceEvents(ctx, req, sender)
+}
+
+func (d dispatchingSSEServiceServer) StreamFilteredEvents(ctx context.Context, req *StreamFilteredEventsRequest, sender SSESender) error {
+	server, ok := d.slot.Load()
+	if !ok {
+		return &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service SSEService is not registered"}
+	}
+	return server.StreamFilteredEvents(ctx, req, sender)
+}
+
+// UnimplementedSSEServiceServer can be embedded in SSEServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedSSEServiceServer struct{}
+
+func (UnimplementedSSEServiceServer) GetStatus(context.Context, *GetStatusRequest) (*StatusResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetStatus not implemented"}
+}
+
+func (UnimplementedSSEServiceServer) StreamEvents(context.Context, *StreamEventsRequest, SSESender) error {
+	return &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method StreamEvents not implemented"}
+}
+
+func (UnimplementedSSEServiceServer) StreamResourceEvents(context.Context, *StreamResourceEventsRequest, SSESender) error {
+	return &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method StreamResourceEvents not implemented"}
+}
+
+func (UnimplementedSSEServiceServer) StreamFilteredEvents(context.Context, *StreamFilteredEventsRequest, SSESender) error {
+	return &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method StreamFilteredEvents not implemented"}
+}
+
+// DecodeGetStatusRequest binds r to a GetStatusRequest as the GetStatus handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterSSEServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetStatusRequest(r *http.Request) (*GetStatusRequest, error) {
+	req := new(GetStatusRequest)
+	err := bindRequest(nil, r, req, getStatusPathParams, getStatusQueryParams, getStatusHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeStreamEventsRequest binds r to a StreamEventsRequest as the StreamEvents handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterSSEServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeStreamEventsRequest(r *http.Request) (*StreamEventsRequest, error) {
+	req := new(StreamEventsRequest)
+	err := bindRequest(nil, r, req, streamEventsPathParams, streamEventsQueryParams, streamEventsHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeStreamResourceEventsRequest binds r to a StreamResourceEventsRequest as the StreamResourceEvents handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterSSEServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeStreamResourceEventsRequest(r *http.Request) (*StreamResourceEventsRequest, error) {
+	req := new(StreamResourceEventsRequest)
+	err := bindRequest(nil, r, req, streamResourceEventsPathParams, streamResourceEventsQueryParams, streamResourceEventsHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeStreamFilteredEventsRequest binds r to a StreamFilteredEventsRequest as the StreamFilteredEvents handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterSSEServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeStreamFilteredEventsRequest(r *http.Request) (*StreamFilteredEventsRequest, error) {
+	req := new(StreamFilteredEventsRequest)
+	err := bindRequest(nil, r, req, streamFilteredEventsPathParams, streamFilteredEventsQueryParams, streamFilteredEventsHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getSSEServiceHeaders returns the service-level required headers for SSEService
 func getSSEServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -93,12 +283,18 @@ var getStatusPathParams = []PathParamConfig{}
 // getStatusQueryParams contains query parameter configuration for GetStatus
 var getStatusQueryParams = []QueryParamConfig{}
 
+// getStatusHeaderFieldParams contains header-sourced field configuration for GetStatus
+var getStatusHeaderFieldParams = []HeaderParamConfig{}
+
 // streamEventsPathParams contains path parameter configuration for StreamEvents
 var streamEventsPathParams = []PathParamConfig{}
 
 // streamEventsQueryParams contains query parameter configuration for StreamEvents
 var streamEventsQueryParams = []QueryParamConfig{}
 
+// streamEventsHeaderFieldParams contains header-sourced field configuration for StreamEvents
+var streamEventsHeaderFieldParams = []HeaderParamConfig{}
+
 // streamResourceEventsPathParams contains path parameter configuration for StreamResourceEvents
 var streamResourceEventsPathParams = []PathParamConfig{
 	{URLParam: "resource_id", FieldName: "resource_id"},
@@ -107,6 +303,9 @@ var streamResourceEventsPathParams = []PathParamConfig{
 // streamResourceEventsQueryParams contains query parameter configuration for StreamResourceEvents
 var streamResourceEventsQueryParams = []QueryParamConfig{}
 
+// streamResourceEventsHeaderFieldParams contains header-sourced field configuration for StreamResourceEvents
+var streamResourceEventsHeaderFieldParams = []HeaderParamConfig{}
+
 // streamFilteredEventsPathParams contains path parameter configuration for StreamFilteredEvents
 var streamFilteredEventsPathParams = []PathParamConfig{}
 
@@ -115,3 +314,6 @@ var streamFilteredEventsQueryParams = []QueryParamConfig{
 	{QueryName: "type", FieldName: "event_type", Required: false},
 	{QueryName: "limit", FieldName: "limit", Required: false},
 }
+
+// streamFilteredEventsHeaderFieldParams contains header-sourced field configuration for StreamFilteredEvents
+var streamFilteredEventsHeaderFieldParams = []HeaderParamConfig{}
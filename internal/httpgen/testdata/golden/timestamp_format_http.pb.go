@@ -5,6 +5,7 @@ package timestampformat
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -18,30 +19,150 @@ type TimestampFormatServiceServer interface {
 // RegisterTimestampFormatServiceServer registers the HTTP handlers for service TimestampFormatService to the given mux.
 func RegisterTimestampFormatServiceServer(server TimestampFormatServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingTimestampFormatServiceServer{slot: registeredTimestampFormatServiceServers.Add(server)}
 
 	serviceHeaders := getTimestampFormatServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getCreateTimestampFormatHeaders()
 	createTimestampFormatHandler := BindingMiddleware[TimestampFormatTest](
-		genericHandler(server.CreateTimestampFormat, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		createTimestampFormatPathParams, createTimestampFormatQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.CreateTimestampFormat, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		createTimestampFormatPathParams, createTimestampFormatQueryParams, createTimestampFormatHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	createTimestampFormatHandler = sebufhttp.MetricsMiddleware(createTimestampFormatHandler, config.metrics, "testdata.timestamp_format.TimestampFormatService.CreateTimestampFormat")
+	createTimestampFormatHandler = sebufhttp.ResponseHeadersMiddleware(createTimestampFormatHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v1/timestamp-format", createTimestampFormatHandler)
 
 	methodHeaders = getGetTimestampFormatHeaders()
 	getTimestampFormatHandler := BindingMiddleware[TimestampFormatRequest](
-		genericHandler(server.GetTimestampFormat, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getTimestampFormatPathParams, getTimestampFormatQueryParams,
-		"GET", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetTimestampFormat, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getTimestampFormatPathParams, getTimestampFormatQueryParams, getTimestampFormatHeaderFieldParams,
+		"GET", BodyConfig{StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getTimestampFormatHandler = sebufhttp.MetricsMiddleware(getTimestampFormatHandler, config.metrics, "testdata.timestamp_format.TimestampFormatService.GetTimestampFormat")
+	getTimestampFormatHandler = sebufhttp.ResponseHeadersMiddleware(getTimestampFormatHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("GET /api/v1/timestamp-format/{id}", getTimestampFormatHandler)
+	config.mux.Handle("HEAD /api/v1/timestamp-format/{id}", sebufhttp.HeadHandler(getTimestampFormatHandler))
+
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, timestampFormatServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
 
 	return nil
 }
 
+// Paths of the routes RegisterTimestampFormatServiceServer registers.
+const (
+	TimestampFormatServicePathCreateTimestampFormat = "/api/v1/timestamp-format"
+	TimestampFormatServicePathGetTimestampFormat    = "/api/v1/timestamp-format/{id}"
+)
+
+// TimestampFormatServicePathGetTimestampFormatFor returns TimestampFormatServicePathGetTimestampFormat with its wildcards replaced by
+// the URL-escaped values of id.
+func TimestampFormatServicePathGetTimestampFormatFor(id string) string {
+	return sebufhttp.BuildPath(TimestampFormatServicePathGetTimestampFormat, id)
+}
+
+// TimestampFormatServiceServerRoutes returns the routes RegisterTimestampFormatServiceServer registers.
+func TimestampFormatServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), timestampFormatServiceRouteInfos...)
+}
+
+// timestampFormatServiceRouteInfos lists the routes RegisterTimestampFormatServiceServer registers.
+var timestampFormatServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "POST", Path: TimestampFormatServicePathCreateTimestampFormat, Service: "testdata.timestamp_format.TimestampFormatService", RPC: "CreateTimestampFormat"},
+	{Method: "GET", Path: TimestampFormatServicePathGetTimestampFormat, Service: "testdata.timestamp_format.TimestampFormatService", RPC: "GetTimestampFormat"},
+}
+
+// registeredTimestampFormatServiceServers holds the implementation of every TimestampFormatService registration.
+var registeredTimestampFormatServiceServers sebufhttp.ServerSlots[TimestampFormatServiceServer]
+
+// UpdateTimestampFormatServiceServer makes every handler registered by RegisterTimestampFormatServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateTimestampFormatServiceServer(server TimestampFormatServiceServer) {
+	registeredTimestampFormatServiceServers.Store(server)
+}
+
+// UnregisterTimestampFormatServiceServer detaches the implementation from every handler
+// registered by RegisterTimestampFormatServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateTimestampFormatServiceServer installs a new implementation.
+func UnregisterTimestampFormatServiceServer() {
+	registeredTimestampFormatServiceServers.Clear()
+}
+
+// dispatchingTimestampFormatServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingTimestampFormatServiceServer struct {
+	slot *sebufhttp.ServerSlot[TimestampFormatServiceServer]
+}
+
+func (d dispatchingTimestampFormatServiceServer) CreateTimestampFormat(ctx context.Context, req *TimestampFormatTest) (*TimestampFormatTest, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service TimestampFormatService is not registered"}
+	}
+	return server.CreateTimestampFormat(ctx, req)
+}
+
+func (d dispatchingTimestampFormatServiceServer) GetTimestampFormat(ctx context.Context, req *TimestampFormatRequest) (*TimestampFormatTest, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service TimestampFormatService is not registered"}
+	}
+	return server.GetTimestampFormat(ctx, req)
+}
+
+// UnimplementedTimestampFormatServiceServer can be embedded in TimestampFormatServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedTimestampFormatServiceServer struct{}
+
+func (UnimplementedTimestampFormatServiceServer) CreateTimestampFormat(context.Context, *TimestampFormatTest) (*TimestampFormatTest, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method CreateTimestampFormat not implemented"}
+}
+
+func (UnimplementedTimestampFormatServiceServer) GetTimestampFormat(context.Context, *TimestampFormatRequest) (*TimestampFormatTest, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetTimestampFormat not implemented"}
+}
+
+// DecodeCreateTimestampFormatRequest binds r to a TimestampFormatTest as the CreateTimestampFormat handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterTimestampFormatServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeCreateTimestampFormatRequest(r *http.Request) (*TimestampFormatTest, error) {
+	req := new(TimestampFormatTest)
+	err := bindRequest(nil, r, req, createTimestampFormatPathParams, createTimestampFormatQueryParams, createTimestampFormatHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeGetTimestampFormatRequest binds r to a TimestampFormatRequest as the GetTimestampFormat handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterTimestampFormatServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetTimestampFormatRequest(r *http.Request) (*TimestampFormatRequest, error) {
+	req := new(TimestampFormatRequest)
+	err := bindRequest(nil, r, req, getTimestampFormatPathParams, getTimestampFormatQueryParams, getTimestampFormatHeaderFieldParams,
+		"GET", BodyConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getTimestampFormatServiceHeaders returns the service-level required headers for TimestampFormatService
 func getTimestampFormatServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -63,6 +184,9 @@ var createTimestampFormatPathParams = []PathParamConfig{}
 // createTimestampFormatQueryParams contains query parameter configuration for CreateTimestampFormat
 var createTimestampFormatQueryParams = []QueryParamConfig{}
 
+// createTimestampFormatHeaderFieldParams contains header-sourced field configuration for CreateTimestampFormat
+var createTimestampFormatHeaderFieldParams = []HeaderParamConfig{}
+
 // getTimestampFormatPathParams contains path parameter configuration for GetTimestampFormat
 var getTimestampFormatPathParams = []PathParamConfig{
 	{URLParam: "id", FieldName: "id"},
@@ -70,3 +194,6 @@ var getTimestampFormatPathParams = []PathParamConfig{
 
 // getTimestampFormatQueryParams contains query parameter configuration for GetTimestampFormat
 var getTimestampFormatQueryParams = []QueryParamConfig{}
+
+// getTimestampFormatHeaderFieldParams contains header-sourced field configuration for GetTimestampFormat
+var getTimestampFormatHeaderFieldParams = []HeaderParamConfig{}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
 
 // MarshalJSONSebuf implements sebufMarshaler for TimestampFormatTest.
@@ -55,6 +57,16 @@ func (x *TimestampFormatTest) MarshalJSON() ([]byte, error) {
 	return x.MarshalJSONSebuf(protojson.MarshalOptions{})
 }
 
+// timestampFormatTestAlternateJSONNames maps the proto names and JSON names protojson
+// accepts for the fields of TimestampFormatTest to the keys UnmarshalJSON reads.
+var timestampFormatTestAlternateJSONNames = map[string]string{
+	"date_ts":         "dateTs",
+	"default_ts":      "defaultTs",
+	"rfc3339_ts":      "rfc3339Ts",
+	"unix_millis_ts":  "unixMillisTs",
+	"unix_seconds_ts": "unixSecondsTs",
+}
+
 // UnmarshalJSON implements json.Unmarshaler for TimestampFormatTest.
 // This method handles timestamp_format fields: unix_seconds_ts, unix_millis_ts, date_ts
 func (x *TimestampFormatTest) UnmarshalJSON(data []byte) error {
@@ -63,6 +75,9 @@ func (x *TimestampFormatTest) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	if err := sebufhttp.ResolveAlternateJSONNames(raw, timestampFormatTestAlternateJSONNames); err != nil {
+		return err
+	}
 
 	// Convert unixSecondsTs from TIMESTAMP_FORMAT_UNIX_SECONDS to RFC 3339 for protojson
 	if v, ok := raw["unixSecondsTs"]; ok {
@@ -5,6 +5,7 @@ package generated
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -17,21 +18,101 @@ type OptionDataServiceServer interface {
 // RegisterOptionDataServiceServer registers the HTTP handlers for service OptionDataService to the given mux.
 func RegisterOptionDataServiceServer(server OptionDataServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingOptionDataServiceServer{slot: registeredOptionDataServiceServers.Add(server)}
 
 	serviceHeaders := getOptionDataServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getGetOptionBarsHeaders()
 	getOptionBarsHandler := BindingMiddleware[GetOptionBarsRequest](
-		genericHandler(server.GetOptionBars, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getOptionBarsPathParams, getOptionBarsQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetOptionBars, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getOptionBarsPathParams, getOptionBarsQueryParams, getOptionBarsHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getOptionBarsHandler = sebufhttp.MetricsMiddleware(getOptionBarsHandler, config.metrics, "test.httpgen.unwrap.OptionDataService.GetOptionBars")
+	getOptionBarsHandler = sebufhttp.ResponseHeadersMiddleware(getOptionBarsHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v1/options/bars", getOptionBarsHandler)
 
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, optionDataServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
+
 	return nil
 }
 
+// Paths of the routes RegisterOptionDataServiceServer registers.
+const (
+	OptionDataServicePathGetOptionBars = "/api/v1/options/bars"
+)
+
+// OptionDataServiceServerRoutes returns the routes RegisterOptionDataServiceServer registers.
+func OptionDataServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), optionDataServiceRouteInfos...)
+}
+
+// optionDataServiceRouteInfos lists the routes RegisterOptionDataServiceServer registers.
+var optionDataServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "POST", Path: OptionDataServicePathGetOptionBars, Service: "test.httpgen.unwrap.OptionDataService", RPC: "GetOptionBars"},
+}
+
+// registeredOptionDataServiceServers holds the implementation of every OptionDataService registration.
+var registeredOptionDataServiceServers sebufhttp.ServerSlots[OptionDataServiceServer]
+
+// UpdateOptionDataServiceServer makes every handler registered by RegisterOptionDataServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateOptionDataServiceServer(server OptionDataServiceServer) {
+	registeredOptionDataServiceServers.Store(server)
+}
+
+// UnregisterOptionDataServiceServer detaches the implementation from every handler
+// registered by RegisterOptionDataServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateOptionDataServiceServer installs a new implementation.
+func UnregisterOptionDataServiceServer() {
+	registeredOptionDataServiceServers.Clear()
+}
+
+// dispatchingOptionDataServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingOptionDataServiceServer struct {
+	slot *sebufhttp.ServerSlot[OptionDataServiceServer]
+}
+
+func (d dispatchingOptionDataServiceServer) GetOptionBars(ctx context.Context, req *GetOptionBarsRequest) (*GetOptionBarsResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service OptionDataService is not registered"}
+	}
+	return server.GetOptionBars(ctx, req)
+}
+
+// UnimplementedOptionDataServiceServer can be embedded in OptionDataServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedOptionDataServiceServer struct{}
+
+func (UnimplementedOptionDataServiceServer) GetOptionBars(context.Context, *GetOptionBarsRequest) (*GetOptionBarsResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetOptionBars not implemented"}
+}
+
+// DecodeGetOptionBarsRequest binds r to a GetOptionBarsRequest as the GetOptionBars handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterOptionDataServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetOptionBarsRequest(r *http.Request) (*GetOptionBarsRequest, error) {
+	req := new(GetOptionBarsRequest)
+	err := bindRequest(nil, r, req, getOptionBarsPathParams, getOptionBarsQueryParams, getOptionBarsHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getOptionDataServiceHeaders returns the service-level required headers for OptionDataService
 func getOptionDataServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -48,6 +129,9 @@ var getOptionBarsPathParams = []PathParamConfig{}
 // getOptionBarsQueryParams contains query parameter configuration for GetOptionBars
 var getOptionBarsQueryParams = []QueryParamConfig{}
 
+// getOptionBarsHeaderFieldParams contains header-sourced field configuration for GetOptionBars
+var getOptionBarsHeaderFieldParams = []HeaderParamConfig{}
+
 // UnwrapServiceServer is the server API for UnwrapService service.
 type UnwrapServiceServer interface {
 	GetOptionBars(context.Context, *GetOptionBarsRequest) (*GetOptionBarsResponse, error)
@@ -59,48 +143,227 @@ type UnwrapServiceServer interface {
 // RegisterUnwrapServiceServer registers the HTTP handlers for service UnwrapService to the given mux.
 func RegisterUnwrapServiceServer(server UnwrapServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingUnwrapServiceServer{slot: registeredUnwrapServiceServers.Add(server)}
 
 	serviceHeaders := getUnwrapServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getGetOptionBarsHeaders()
 	getOptionBarsHandler := BindingMiddleware[GetOptionBarsRequest](
-		genericHandler(server.GetOptionBars, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getOptionBarsPathParams, getOptionBarsQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetOptionBars, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getOptionBarsPathParams, getOptionBarsQueryParams, getOptionBarsHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getOptionBarsHandler = sebufhttp.MetricsMiddleware(getOptionBarsHandler, config.metrics, "test.httpgen.unwrap.UnwrapService.GetOptionBars")
+	getOptionBarsHandler = sebufhttp.ResponseHeadersMiddleware(getOptionBarsHandler, nil, config.responseHeaderOverrides)
 
-	config.mux.Handle("POST /api/v1/options/bars", getOptionBarsHandler)
+	config.mux.Handle("POST /api/v1/unwrap/options/bars", getOptionBarsHandler)
 
 	methodHeaders = getGetRootMapHeaders()
 	getRootMapHandler := BindingMiddleware[GetOptionBarsRequest](
-		genericHandler(server.GetRootMap, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getRootMapPathParams, getRootMapQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetRootMap, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, false), serviceHeaders, methodHeaders,
+		getRootMapPathParams, getRootMapQueryParams, getRootMapHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getRootMapHandler = sebufhttp.MetricsMiddleware(getRootMapHandler, config.metrics, "test.httpgen.unwrap.UnwrapService.GetRootMap")
+	getRootMapHandler = sebufhttp.ResponseHeadersMiddleware(getRootMapHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v1/root/map", getRootMapHandler)
 
 	methodHeaders = getGetRootRepeatedHeaders()
 	getRootRepeatedHandler := BindingMiddleware[GetOptionBarsRequest](
-		genericHandler(server.GetRootRepeated, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getRootRepeatedPathParams, getRootRepeatedQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetRootRepeated, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, false), serviceHeaders, methodHeaders,
+		getRootRepeatedPathParams, getRootRepeatedQueryParams, getRootRepeatedHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getRootRepeatedHandler = sebufhttp.MetricsMiddleware(getRootRepeatedHandler, config.metrics, "test.httpgen.unwrap.UnwrapService.GetRootRepeated")
+	getRootRepeatedHandler = sebufhttp.ResponseHeadersMiddleware(getRootRepeatedHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v1/root/repeated", getRootRepeatedHandler)
 
 	methodHeaders = getGetRootMapWithValueUnwrapHeaders()
 	getRootMapWithValueUnwrapHandler := BindingMiddleware[GetOptionBarsRequest](
-		genericHandler(server.GetRootMapWithValueUnwrap, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getRootMapWithValueUnwrapPathParams, getRootMapWithValueUnwrapQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetRootMapWithValueUnwrap, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, false), serviceHeaders, methodHeaders,
+		getRootMapWithValueUnwrapPathParams, getRootMapWithValueUnwrapQueryParams, getRootMapWithValueUnwrapHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getRootMapWithValueUnwrapHandler = sebufhttp.MetricsMiddleware(getRootMapWithValueUnwrapHandler, config.metrics, "test.httpgen.unwrap.UnwrapService.GetRootMapWithValueUnwrap")
+	getRootMapWithValueUnwrapHandler = sebufhttp.ResponseHeadersMiddleware(getRootMapWithValueUnwrapHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v1/root/map-value-unwrap", getRootMapWithValueUnwrapHandler)
 
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, unwrapServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
+
 	return nil
 }
 
+// Paths of the routes RegisterUnwrapServiceServer registers.
+const (
+	UnwrapServicePathGetOptionBars             = "/api/v1/unwrap/options/bars"
+	UnwrapServicePathGetRootMap                = "/api/v1/root/map"
+	UnwrapServicePathGetRootRepeated           = "/api/v1/root/repeated"
+	UnwrapServicePathGetRootMapWithValueUnwrap = "/api/v1/root/map-value-unwrap"
+)
+
+// UnwrapServiceServerRoutes returns the routes RegisterUnwrapServiceServer registers.
+func UnwrapServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), unwrapServiceRouteInfos...)
+}
+
+// unwrapServiceRouteInfos lists the routes RegisterUnwrapServiceServer registers.
+var unwrapServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "POST", Path: UnwrapServicePathGetOptionBars, Service: "test.httpgen.unwrap.UnwrapService", RPC: "GetOptionBars"},
+	{Method: "POST", Path: UnwrapServicePathGetRootMap, Service: "test.httpgen.unwrap.UnwrapService", RPC: "GetRootMap"},
+	{Method: "POST", Path: UnwrapServicePathGetRootRepeated, Service: "test.httpgen.unwrap.UnwrapService", RPC: "GetRootRepeated"},
+	{Method: "POST", Path: UnwrapServicePathGetRootMapWithValueUnwrap, Service: "test.httpgen.unwrap.UnwrapService", RPC: "GetRootMapWithValueUnwrap"},
+}
+
+// registeredUnwrapServiceServers holds the implementation of every UnwrapService registration.
+var registeredUnwrapServiceServers sebufhttp.ServerSlots[UnwrapServiceServer]
+
+// UpdateUnwrapServiceServer makes every handler registered by RegisterUnwrapServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateUnwrapServiceServer(server UnwrapServiceServer) {
+	registeredUnwrapServiceServers.Store(server)
+}
+
+// UnregisterUnwrapServiceServer detaches the implementation from every handler
+// registered by RegisterUnwrapServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateUnwrapServiceServer installs a new implementation.
+func UnregisterUnwrapServiceServer() {
+	registeredUnwrapServiceServers.Clear()
+}
+
+// dispatchingUnwrapServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingUnwrapServiceServer struct {
+	slot *sebufhttp.ServerSlot[UnwrapServiceServer]
+}
+
+func (d dispatchingUnwrapServiceServer) GetOptionBars(ctx context.Context, req *GetOptionBarsRequest) (*GetOptionBarsResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service UnwrapService is not registered"}
+	}
+	return server.GetOptionBars(ctx, req)
+}
+
+func (d dispatchingUnwrapServiceServer) GetRootMap(ctx context.Context, req *GetOptionBarsRequest) (*RootMapResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service UnwrapService is not registered"}
+	}
+	return server.GetRootMap(ctx, req)
+}
+
+func (d dispatchingUnwrapServiceServer) GetRootRepeated(ctx context.Context, req *GetOptionBarsRequest) (*RootRepeatedResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service UnwrapService is not registered"}
+	}
+	return server.GetRootRepeated(ctx, req)
+}
+
+func (d dispatchingUnwrapServiceServer) GetRootMapWithValueUnwrap(ctx context.Context, req *GetOptionBarsRequest) (*RootMapWithValueUnwrapResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service UnwrapService is not registered"}
+	}
+	return server.GetRootMapWithValueUnwrap(ctx, req)
+}
+
+// UnimplementedUnwrapServiceServer can be embedded in UnwrapServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedUnwrapServiceServer struct{}
+
+func (UnimplementedUnwrapServiceServer) GetOptionBars(context.Context, *GetOptionBarsRequest) (*GetOptionBarsResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetOptionBars not implemented"}
+}
+
+func (UnimplementedUnwrapServiceServer) GetRootMap(context.Context, *GetOptionBarsRequest) (*RootMapResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetRootMap not implemented"}
+}
+
+func (UnimplementedUnwrapServiceServer) GetRootRepeated(context.Context, *GetOptionBarsRequest) (*RootRepeatedResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetRootRepeated not implemented"}
+}
+
+func (UnimplementedUnwrapServiceServer) GetRootMapWithValueUnwrap(context.Context, *GetOptionBarsRequest) (*RootMapWithValueUnwrapResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetRootMapWithValueUnwrap not implemented"}
+}
+
+// DecodeGetOptionBarsRequest binds r to a GetOptionBarsRequest as the GetOptionBars handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterUnwrapServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetOptionBarsRequest(r *http.Request) (*GetOptionBarsRequest, error) {
+	req := new(GetOptionBarsRequest)
+	err := bindRequest(nil, r, req, getOptionBarsPathParams, getOptionBarsQueryParams, getOptionBarsHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeGetRootMapRequest binds r to a GetOptionBarsRequest as the GetRootMap handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterUnwrapServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetRootMapRequest(r *http.Request) (*GetOptionBarsRequest, error) {
+	req := new(GetOptionBarsRequest)
+	err := bindRequest(nil, r, req, getRootMapPathParams, getRootMapQueryParams, getRootMapHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeGetRootRepeatedRequest binds r to a GetOptionBarsRequest as the GetRootRepeated handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterUnwrapServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetRootRepeatedRequest(r *http.Request) (*GetOptionBarsRequest, error) {
+	req := new(GetOptionBarsRequest)
+	err := bindRequest(nil, r, req, getRootRepeatedPathParams, getRootRepeatedQueryParams, getRootRepeatedHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeGetRootMapWithValueUnwrapRequest binds r to a GetOptionBarsRequest as the GetRootMapWithValueUnwrap handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterUnwrapServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetRootMapWithValueUnwrapRequest(r *http.Request) (*GetOptionBarsRequest, error) {
+	req := new(GetOptionBarsRequest)
+	err := bindRequest(nil, r, req, getRootMapWithValueUnwrapPathParams, getRootMapWithValueUnwrapQueryParams, getRootMapWithValueUnwrapHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getUnwrapServiceHeaders returns the service-level required headers for UnwrapService
 func getUnwrapServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -132,20 +395,32 @@ var getOptionBarsPathParams = []PathParamConfig{}
 // getOptionBarsQueryParams contains query parameter configuration for GetOptionBars
 var getOptionBarsQueryParams = []QueryParamConfig{}
 
+// getOptionBarsHeaderFieldParams contains header-sourced field configuration for GetOptionBars
+var getOptionBarsHeaderFieldParams = []HeaderParamConfig{}
+
 // getRootMapPathParams contains path parameter configuration for GetRootMap
 var getRootMapPathParams = []PathParamConfig{}
 
 // getRootMapQueryParams contains query parameter configuration for GetRootMap
 var getRootMapQueryParams = []QueryParamConfig{}
 
+// getRootMapHeaderFieldParams contains header-sourced field configuration for GetRootMap
+var getRootMapHeaderFieldParams = []HeaderParamConfig{}
+
 // getRootRepeatedPathParams contains path parameter configuration for GetRootRepeated
 var getRootRepeatedPathParams = []PathParamConfig{}
 
 // getRootRepeatedQueryParams contains query parameter configuration for GetRootRepeated
 var getRootRepeatedQueryParams = []QueryParamConfig{}
 
+// getRootRepeatedHeaderFieldParams contains header-sourced field configuration for GetRootRepeated
+var getRootRepeatedHeaderFieldParams = []HeaderParamConfig{}
+
 // getRootMapWithValueUnwrapPathParams contains path parameter configuration for GetRootMapWithValueUnwrap
 var getRootMapWithValueUnwrapPathParams = []PathParamConfig{}
 
 // getRootMapWithValueUnwrapQueryParams contains query parameter configuration for GetRootMapWithValueUnwrap
 var getRootMapWithValueUnwrapQueryParams = []QueryParamConfig{}
+
+// getRootMapWithValueUnwrapHeaderFieldParams contains header-sourced field configuration for GetRootMapWithValueUnwrap
+var getRootMapWithValueUnwrapHeaderFieldParams = []HeaderParamConfig{}
@@ -5,6 +5,7 @@ package unwrapint64encoding
 
 import (
 	"context"
+	"net/http"
 
 	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
@@ -17,21 +18,101 @@ type TestServiceServer interface {
 // RegisterTestServiceServer registers the HTTP handlers for service TestService to the given mux.
 func RegisterTestServiceServer(server TestServiceServer, opts ...ServerOption) error {
 	config := getConfiguration(opts...)
+	server = dispatchingTestServiceServer{slot: registeredTestServiceServers.Add(server)}
 
 	serviceHeaders := getTestServiceHeaders()
 
+	limiter := sebufhttp.NewConcurrencyLimiter(config.concurrencyLimit)
+
 	methodHeaders := getGetCombinedHeaders()
 	getCombinedHandler := BindingMiddleware[Request](
-		genericHandler(server.GetCombined, config.errorHandler, config.marshalOpts), serviceHeaders, methodHeaders,
-		getCombinedPathParams, getCombinedQueryParams,
-		"POST", config.errorHandler, config.marshalOpts,
+		genericHandler(server.GetCombined, config.errorHandler, config.marshalOpts, limiter, config.defaultTimeout, config.warningsInBody), serviceHeaders, methodHeaders,
+		getCombinedPathParams, getCombinedQueryParams, getCombinedHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true, StrictJSON: config.strictJSON, MaxSize: config.maxBodySize, TypeResolver: config.typeResolver, NoContentSniffing: config.noContentSniffing}, config.errorHandler, config.marshalOpts,
+		config.validationPolicy, config.violationFormatter, config.validationFailureMode,
+		config.logger, config.headerAuthenticators, config.timeoutHeader,
 	)
+	getCombinedHandler = sebufhttp.MetricsMiddleware(getCombinedHandler, config.metrics, "testdata.unwrapint64encoding.TestService.GetCombined")
+	getCombinedHandler = sebufhttp.ResponseHeadersMiddleware(getCombinedHandler, nil, config.responseHeaderOverrides)
 
 	config.mux.Handle("POST /api/v1/combined", getCombinedHandler)
 
+	if config.routeDebug {
+		sebufhttp.RegisterRouteDebug(config.mux, testServiceRouteInfos, config.logger, config.routeDebugAuth)
+	}
+
 	return nil
 }
 
+// Paths of the routes RegisterTestServiceServer registers.
+const (
+	TestServicePathGetCombined = "/api/v1/combined"
+)
+
+// TestServiceServerRoutes returns the routes RegisterTestServiceServer registers.
+func TestServiceServerRoutes() []sebufhttp.RouteInfo {
+	return append([]sebufhttp.RouteInfo(nil), testServiceRouteInfos...)
+}
+
+// testServiceRouteInfos lists the routes RegisterTestServiceServer registers.
+var testServiceRouteInfos = []sebufhttp.RouteInfo{
+	{Method: "POST", Path: TestServicePathGetCombined, Service: "testdata.unwrapint64encoding.TestService", RPC: "GetCombined"},
+}
+
+// registeredTestServiceServers holds the implementation of every TestService registration.
+var registeredTestServiceServers sebufhttp.ServerSlots[TestServiceServer]
+
+// UpdateTestServiceServer makes every handler registered by RegisterTestServiceServer
+// call server from now on. Requests already being handled finish on the
+// implementation they started with.
+func UpdateTestServiceServer(server TestServiceServer) {
+	registeredTestServiceServers.Store(server)
+}
+
+// UnregisterTestServiceServer detaches the implementation from every handler
+// registered by RegisterTestServiceServer. The routes stay on their mux and answer
+// HTTP 503 until UpdateTestServiceServer installs a new implementation.
+func UnregisterTestServiceServer() {
+	registeredTestServiceServers.Clear()
+}
+
+// dispatchingTestServiceServer forwards each call to the implementation installed in its slot.
+type dispatchingTestServiceServer struct {
+	slot *sebufhttp.ServerSlot[TestServiceServer]
+}
+
+func (d dispatchingTestServiceServer) GetCombined(ctx context.Context, req *Request) (*CombinedResponse, error) {
+	server, ok := d.slot.Load()
+	if !ok {
+		return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnavailable, Message: "service TestService is not registered"}
+	}
+	return server.GetCombined(ctx, req)
+}
+
+// UnimplementedTestServiceServer can be embedded in TestServiceServer implementations for
+// forward compatibility. Methods that are not overridden return a *sebufhttp.Error
+// with Code sebufhttp.ErrorCodeUnimplemented, which is written as HTTP 501.
+type UnimplementedTestServiceServer struct{}
+
+func (UnimplementedTestServiceServer) GetCombined(context.Context, *Request) (*CombinedResponse, error) {
+	return nil, &sebufhttp.Error{Code: sebufhttp.ErrorCodeUnimplemented, Message: "method GetCombined not implemented"}
+}
+
+// DecodeGetCombinedRequest binds r to a Request as the GetCombined handler does
+// without server options: its body, path, query and header-sourced fields, so
+// routers other than the one RegisterTestServiceServer configures reuse the
+// generated binding. Path values are read with r.PathValue.
+// Declared headers are not checked.
+func DecodeGetCombinedRequest(r *http.Request) (*Request, error) {
+	req := new(Request)
+	err := bindRequest(nil, r, req, getCombinedPathParams, getCombinedQueryParams, getCombinedHeaderFieldParams,
+		"POST", BodyConfig{OptionalBody: true})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // getTestServiceHeaders returns the service-level required headers for TestService
 func getTestServiceHeaders() []*sebufhttp.Header {
 	return nil
@@ -47,3 +128,6 @@ var getCombinedPathParams = []PathParamConfig{}
 
 // getCombinedQueryParams contains query parameter configuration for GetCombined
 var getCombinedQueryParams = []QueryParamConfig{}
+
+// getCombinedHeaderFieldParams contains header-sourced field configuration for GetCombined
+var getCombinedHeaderFieldParams = []HeaderParamConfig{}
@@ -171,3 +171,21 @@ func (x *CombinedResponse) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// UnknownJSONFields returns the keys of the JSON object data that UnmarshalJSON
+// does not bind to a field of CombinedResponse, in no particular order.
+func (x *CombinedResponse) UnknownJSONFields(data []byte) []string {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil // UnmarshalJSON reports malformed bodies
+	}
+	var unknown []string
+	for key := range raw {
+		switch key {
+		case "data", "meta":
+		default:
+			unknown = append(unknown, key)
+		}
+	}
+	return unknown
+}
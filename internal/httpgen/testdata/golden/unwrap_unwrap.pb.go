@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 
 	"google.golang.org/protobuf/encoding/protojson"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
 )
 
 // MarshalJSONSebuf implements sebufMarshaler for OptionBarsList.
@@ -66,6 +68,9 @@ func (x *IntList) MarshalJSONSebuf(opts protojson.MarshalOptions) ([]byte, error
 		return []byte("null"), nil
 	}
 
+	if x.Values == nil {
+		return []byte("[]"), nil
+	}
 	return json.Marshal(x.Values)
 }
 
@@ -276,6 +281,9 @@ func (x *ScalarRootRepeatedResponse) MarshalJSONSebuf(opts protojson.MarshalOpti
 		return []byte("null"), nil
 	}
 
+	if x.Names == nil {
+		return []byte("[]"), nil
+	}
 	return json.Marshal(x.Names)
 }
 
@@ -395,6 +403,12 @@ func (x *GetOptionBarsResponse) MarshalJSON() ([]byte, error) {
 	return x.MarshalJSONSebuf(protojson.MarshalOptions{})
 }
 
+// getOptionBarsResponseAlternateJSONNames maps the proto names and JSON names protojson
+// accepts for the fields of GetOptionBarsResponse to the keys UnmarshalJSON reads.
+var getOptionBarsResponseAlternateJSONNames = map[string]string{
+	"next_page_token": "nextPageToken",
+}
+
 // UnmarshalJSON implements json.Unmarshaler for GetOptionBarsResponse.
 // This method handles unwrap field deserialization for map values.
 func (x *GetOptionBarsResponse) UnmarshalJSON(data []byte) error {
@@ -402,6 +416,9 @@ func (x *GetOptionBarsResponse) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	if err := sebufhttp.ResolveAlternateJSONNames(raw, getOptionBarsResponseAlternateJSONNames); err != nil {
+		return err
+	}
 
 	// Handle unwrap map field: Bars
 	if rawField, ok := raw["bars"]; ok {
@@ -437,6 +454,24 @@ func (x *GetOptionBarsResponse) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// UnknownJSONFields returns the keys of the JSON object data that UnmarshalJSON
+// does not bind to a field of GetOptionBarsResponse, in no particular order.
+func (x *GetOptionBarsResponse) UnknownJSONFields(data []byte) []string {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil // UnmarshalJSON reports malformed bodies
+	}
+	var unknown []string
+	for key := range raw {
+		switch key {
+		case "bars", "nextPageToken", "next_page_token":
+		default:
+			unknown = append(unknown, key)
+		}
+	}
+	return unknown
+}
+
 // MarshalJSONSebuf implements sebufMarshaler for ScalarMapResponse.
 // This method handles unwrap field serialization for map values.
 func (x *ScalarMapResponse) MarshalJSONSebuf(opts protojson.MarshalOptions) ([]byte, error) {
@@ -505,6 +540,24 @@ func (x *ScalarMapResponse) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// UnknownJSONFields returns the keys of the JSON object data that UnmarshalJSON
+// does not bind to a field of ScalarMapResponse, in no particular order.
+func (x *ScalarMapResponse) UnknownJSONFields(data []byte) []string {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil // UnmarshalJSON reports malformed bodies
+	}
+	var unknown []string
+	for key := range raw {
+		switch key {
+		case "data":
+		default:
+			unknown = append(unknown, key)
+		}
+	}
+	return unknown
+}
+
 // MarshalJSONSebuf implements sebufMarshaler for MixedResponse.
 // This method handles unwrap field serialization for map values.
 func (x *MixedResponse) MarshalJSONSebuf(opts protojson.MarshalOptions) ([]byte, error) {
@@ -576,6 +629,13 @@ func (x *MixedResponse) MarshalJSON() ([]byte, error) {
 	return x.MarshalJSONSebuf(protojson.MarshalOptions{})
 }
 
+// mixedResponseAlternateJSONNames maps the proto names and JSON names protojson
+// accepts for the fields of MixedResponse to the keys UnmarshalJSON reads.
+var mixedResponseAlternateJSONNames = map[string]string{
+	"regular_bars":   "regularBars",
+	"unwrapped_bars": "unwrappedBars",
+}
+
 // UnmarshalJSON implements json.Unmarshaler for MixedResponse.
 // This method handles unwrap field deserialization for map values.
 func (x *MixedResponse) UnmarshalJSON(data []byte) error {
@@ -583,6 +643,9 @@ func (x *MixedResponse) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	if err := sebufhttp.ResolveAlternateJSONNames(raw, mixedResponseAlternateJSONNames); err != nil {
+		return err
+	}
 
 	// Handle unwrap map field: UnwrappedBars
 	if rawField, ok := raw["unwrappedBars"]; ok {
@@ -624,3 +687,21 @@ func (x *MixedResponse) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// UnknownJSONFields returns the keys of the JSON object data that UnmarshalJSON
+// does not bind to a field of MixedResponse, in no particular order.
+func (x *MixedResponse) UnknownJSONFields(data []byte) []string {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil // UnmarshalJSON reports malformed bodies
+	}
+	var unknown []string
+	for key := range raw {
+		switch key {
+		case "unwrappedBars", "regularBars", "status", "regular_bars", "unwrapped_bars":
+		default:
+			unknown = append(unknown, key)
+		}
+	}
+	return unknown
+}
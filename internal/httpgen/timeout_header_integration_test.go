@@ -0,0 +1,178 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestTimeoutHeaderIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server and Go client,
+//  2. writes a temporary Go module serving the generated handlers with
+//     WithTimeoutHeader, called by a client with WithDeadlinePropagation,
+//  3. verifies the deadline of the client's context bounds the handler's, up to
+//     the server's maximum, that a handler outliving it is answered with 504,
+//     and that a malformed header is rejected naming it.
+func TestTimeoutHeaderIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "timeout_header_test",
+		Protos:  map[string]string{"timeout_header.proto": timeoutHeaderProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}, {Name: "go-client"}},
+		Files:   map[string]string{"timeout_header_test.go": timeoutHeaderIntegrationTestCode},
+	})
+}
+
+const timeoutHeaderProto = `syntax = "proto3";
+package test.timeoutheader;
+option go_package = "timeout_header_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service JobService {
+  rpc RunJob(RunJobRequest) returns (RunJobResponse) {
+    option (sebuf.http.config) = { path: "/jobs" method: HTTP_METHOD_POST };
+  }
+}
+
+message RunJobRequest {
+  // Whether the handler keeps working past its deadline.
+  bool overrun = 1;
+}
+
+message RunJobResponse {
+  // Milliseconds left before the handler's deadline, or -1 without one.
+  int64 remaining_ms = 1;
+}
+`
+
+// timeoutHeaderIntegrationTestCode is the test source that runs inside the temp
+// module. The handler reports the time left before its context's deadline.
+const timeoutHeaderIntegrationTestCode = `package timeout_header_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "timeout_header_test/gen"
+)
+
+type jobServer struct{}
+
+func (jobServer) RunJob(ctx context.Context, req *gen.RunJobRequest) (*gen.RunJobResponse, error) {
+	if req.Overrun {
+		time.Sleep(2 * time.Second)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return &gen.RunJobResponse{RemainingMs: -1}, nil
+	}
+	return &gen.RunJobResponse{RemainingMs: time.Until(deadline).Milliseconds()}, nil
+}
+
+func newServer(t *testing.T, header string, max time.Duration) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	err := gen.RegisterJobServiceServer(jobServer{}, gen.WithMux(mux), gen.WithTimeoutHeader(header, max))
+	if err != nil {
+		t.Fatalf("RegisterJobServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// runJob calls RunJob with a context expiring after timeout, and returns the
+// time left to the handler and the X-Timeout-Applied-Ms header.
+func runJob(t *testing.T, client gen.JobServiceClient, timeout time.Duration) (time.Duration, string) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var metadata sebufhttp.ResponseMetadata
+	resp, err := client.RunJob(ctx, &gen.RunJobRequest{}, gen.WithJobServiceResponseMetadata(&metadata))
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	return time.Duration(resp.RemainingMs) * time.Millisecond, metadata.Header.Get(sebufhttp.TimeoutAppliedHeader)
+}
+
+func TestClientDeadlineBoundsHandler(t *testing.T) {
+	for _, header := range []string{"X-Request-Timeout-Ms", "grpc-timeout"} {
+		srv := newServer(t, header, time.Minute)
+		client := gen.NewJobServiceClient(srv.URL, gen.WithJobServiceDeadlinePropagation(header))
+		remaining, applied := runJob(t, client, 3*time.Second)
+		if remaining <= time.Second || remaining > 3*time.Second {
+			t.Errorf("%s: handler had %v left, want the client's 3s deadline", header, remaining)
+		}
+		if ms, err := strconv.Atoi(applied); err != nil || ms <= 1000 || ms > 3000 {
+			t.Errorf("%s: %s = %q, want the client's 3000", header, sebufhttp.TimeoutAppliedHeader, applied)
+		}
+	}
+}
+
+func TestServerClampsToMax(t *testing.T) {
+	srv := newServer(t, "X-Request-Timeout-Ms", 500*time.Millisecond)
+	client := gen.NewJobServiceClient(srv.URL, gen.WithJobServiceDeadlinePropagation("X-Request-Timeout-Ms"))
+	remaining, applied := runJob(t, client, time.Minute)
+	if remaining > 500*time.Millisecond {
+		t.Errorf("handler had %v left, want at most the 500ms maximum", remaining)
+	}
+	if applied != "500" {
+		t.Errorf("%s = %q, want 500", sebufhttp.TimeoutAppliedHeader, applied)
+	}
+}
+
+func TestWithoutPropagation(t *testing.T) {
+	srv := newServer(t, "X-Request-Timeout-Ms", time.Minute)
+	remaining, applied := runJob(t, gen.NewJobServiceClient(srv.URL), time.Minute)
+	if remaining != -time.Millisecond || applied != "" {
+		t.Errorf("handler had %v left and %q applied, want no deadline", remaining, applied)
+	}
+}
+
+func post(t *testing.T, srv *httptest.Server, timeout, body string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/jobs", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-Timeout-Ms", timeout)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST /jobs: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(data)
+}
+
+func TestOverrunAnswers504(t *testing.T) {
+	srv := newServer(t, "X-Request-Timeout-Ms", time.Minute)
+	start := time.Now()
+	resp, body := post(t, srv, "100", ` + "`" + `{"overrun": true}` + "`" + `)
+	if resp.StatusCode != http.StatusGatewayTimeout {
+		t.Errorf("status = %d, want 504: %s", resp.StatusCode, body)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("answered after %v, want soon after the 100ms deadline", elapsed)
+	}
+}
+
+func TestMalformedHeaderIsRejected(t *testing.T) {
+	srv := newServer(t, "X-Request-Timeout-Ms", time.Minute)
+	resp, body := post(t, srv, "soon", "{}")
+	if resp.StatusCode != http.StatusBadRequest || !strings.Contains(body, "X-Request-Timeout-Ms") {
+		t.Errorf("status = %d, body = %s; want 400 naming the header", resp.StatusCode, body)
+	}
+}
+`
@@ -0,0 +1,152 @@
+package httpgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestTrailingSlashIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server from the same proto once per trailing_slash
+//     mode,
+//  2. writes a temporary Go module with one package per mode that serves it
+//     with httptest,
+//  3. verifies the canonical form of every route is served in all modes, and
+//     that the trailing-slash form 404s under strict, redirects with 308 under
+//     redirect, and reaches the handler under ignore.
+func TestTrailingSlashIntegration(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{}
+	for _, mode := range []TrailingSlash{TrailingSlashStrict, TrailingSlashRedirect, TrailingSlashIgnore} {
+		plugintest.GenerateCode(t, dir, plugintest.Module{
+			Protos:  map[string]string{"users.proto": trailingSlashProto},
+			Plugins: []plugintest.Plugin{{Name: "go-http", Opt: "trailing_slash=" + string(mode)}},
+			Out:     string(mode),
+		})
+		testCode := strings.Replace(trailingSlashIntegrationTestCode, "modePlaceholder", string(mode), 1)
+		files[string(mode)+"/trailing_slash_test.go"] = testCode
+	}
+	plugintest.WriteModule(t, dir, plugintest.Module{Path: "trailing_slash_test", Files: files})
+	plugintest.GoTest(t, dir)
+}
+
+const trailingSlashProto = `syntax = "proto3";
+package test.trailingslash;
+option go_package = "trailing_slash_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service UserService {
+  option (sebuf.http.service_config) = { base_path: "/api/v1" };
+  rpc GetUser(GetUserRequest) returns (User) {
+    option (sebuf.http.config) = { path: "/users/{id}" method: HTTP_METHOD_GET };
+  }
+  rpc CreateUser(User) returns (User) {
+    option (sebuf.http.config) = { path: "/users/" method: HTTP_METHOD_POST };
+  }
+}
+
+message GetUserRequest {
+  string id = 1;
+}
+
+message User {
+  string id = 1;
+  string name = 2;
+}
+`
+
+// trailingSlashIntegrationTestCode is the test source that runs inside each
+// mode's generated package, so every package registers users.proto in its own
+// test binary. modePlaceholder is replaced with the package's trailing_slash mode.
+const trailingSlashIntegrationTestCode = `package gen
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const mode = "modePlaceholder"
+
+type userServer struct{}
+
+func (userServer) GetUser(_ context.Context, req *GetUserRequest) (*User, error) {
+	return &User{Id: req.GetId()}, nil
+}
+
+func (userServer) CreateUser(_ context.Context, req *User) (*User, error) {
+	return req, nil
+}
+
+// request sends a request without following redirects.
+func request(t *testing.T, method, target, body string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, target, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, target, err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(respBody)
+}
+
+func TestTrailingSlash(t *testing.T) {
+	mux := http.NewServeMux()
+	if err := RegisterUserServiceServer(userServer{}, WithMux(mux)); err != nil {
+		t.Fatalf("RegisterUserServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		method    string
+		canonical string
+		slashed   string
+		body      string
+		want      string
+	}{
+		{http.MethodGet, "/api/v1/users/42?fields=name", "/api/v1/users/42/?fields=name", "", "42"},
+		{http.MethodPost, "/api/v1/users", "/api/v1/users/", ` + "`" + `{"name":"Rima"}` + "`" + `, "Rima"},
+	}
+	for _, tt := range tests {
+		resp, body := request(t, tt.method, srv.URL+tt.canonical, tt.body)
+		if resp.StatusCode != http.StatusOK || !strings.Contains(body, tt.want) {
+			t.Errorf("%s %s = %d %s, want 200 with %q", tt.method, tt.canonical, resp.StatusCode, body, tt.want)
+		}
+
+		resp, body = request(t, tt.method, srv.URL+tt.slashed, tt.body)
+		switch mode {
+		case "strict":
+			if resp.StatusCode != http.StatusNotFound {
+				t.Errorf("%s %s status = %d, want 404", tt.method, tt.slashed, resp.StatusCode)
+			}
+		case "redirect":
+			if resp.StatusCode != http.StatusPermanentRedirect {
+				t.Errorf("%s %s status = %d, want 308", tt.method, tt.slashed, resp.StatusCode)
+			}
+			if got := resp.Header.Get("Location"); got != tt.canonical {
+				t.Errorf("%s %s Location = %q, want %q", tt.method, tt.slashed, got, tt.canonical)
+			}
+		case "ignore":
+			if resp.StatusCode != http.StatusOK || !strings.Contains(body, tt.want) {
+				t.Errorf("%s %s = %d %s, want 200 with %q", tt.method, tt.slashed, resp.StatusCode, body, tt.want)
+			}
+		}
+	}
+}
+`
@@ -2,10 +2,13 @@ package httpgen
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
 	"google.golang.org/protobuf/compiler/protogen"
 
 	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/encodinggen"
 )
 
 // Proto field kind constants for type checking.
@@ -336,7 +339,7 @@ func (g *Generator) hasEncodingMarshalJSON(msg *protogen.Message) bool {
 	if msg == nil {
 		return false
 	}
-	return hasInt64NumberFields(msg)
+	return encodinggen.HasInt64NumberFields(msg)
 }
 
 // generateUnwrapFile generates the *_unwrap.pb.go file if needed.
@@ -355,7 +358,10 @@ func (g *Generator) generateUnwrapFile(file *protogen.File) error {
 	gf := g.plugin.NewGeneratedFile(filename, file.GoImportPath)
 
 	g.writeHeader(gf, file)
-	g.writeUnwrapImports(gf)
+	alternateNames := slices.ContainsFunc(ctx.ContainingMessages, func(containing *UnwrapContainingMessage) bool {
+		return annotations.AlternateJSONNames(containing.Message) != nil
+	})
+	g.writeUnwrapImports(gf, alternateNames)
 
 	// Generate root unwrap methods first
 	for _, rootUnwrap := range ctx.RootUnwrapMessages {
@@ -372,16 +378,27 @@ func (g *Generator) generateUnwrapFile(file *protogen.File) error {
 	for _, containing := range ctx.ContainingMessages {
 		g.generateUnwrapMarshalJSON(gf, containing)
 		g.generateUnwrapUnmarshalJSON(gf, containing)
+		known := make([]string, 0, len(containing.Message.Fields))
+		for _, field := range containing.Message.Fields {
+			known = append(known, annotations.JSONFieldName(field))
+		}
+		if !annotations.RejectsAlternateJSONNames(containing.Message) {
+			known = append(known, slices.Sorted(maps.Keys(annotations.AlternateJSONNames(containing.Message)))...)
+		}
+		g.generateUnknownJSONFieldsMethod(gf, containing.Message.GoIdent.GoName, known)
 	}
 
 	return nil
 }
 
-func (g *Generator) writeUnwrapImports(gf *protogen.GeneratedFile) {
+// writeUnwrapImports writes the imports of the unwrap file, with sebufhttp when
+// an UnmarshalJSON resolves alternate field names.
+func (g *Generator) writeUnwrapImports(gf *protogen.GeneratedFile, alternateNames bool) {
 	gf.P("import (")
 	gf.P(`"encoding/json"`)
 	gf.P()
 	gf.P(`"google.golang.org/protobuf/encoding/protojson"`)
+	encodinggen.WriteJSONNamingImport(gf, alternateNames)
 	gf.P(")")
 	gf.P()
 }
@@ -425,7 +442,7 @@ func (g *Generator) generateUnwrapMarshalJSON(gf *protogen.GeneratedFile, contai
 	// Handle each field in the message
 	for _, field := range containing.Message.Fields {
 		fieldName := field.GoName
-		jsonName := getJSONFieldName(field)
+		jsonName := annotations.JSONFieldName(field)
 
 		// Check if this is one of our unwrap map fields
 		var unwrapMapField *UnwrapMapField
@@ -587,6 +604,7 @@ func (g *Generator) generateScalarFieldMarshal(
 func (g *Generator) generateUnwrapUnmarshalJSON(gf *protogen.GeneratedFile, containing *UnwrapContainingMessage) {
 	msgName := containing.Message.GoIdent.GoName
 
+	encodinggen.WriteAlternateJSONNames(gf, containing.Message)
 	gf.P("// UnmarshalJSON implements json.Unmarshaler for ", msgName, ".")
 	gf.P("// This method handles unwrap field deserialization for map values.")
 	gf.P("func (x *", msgName, ") UnmarshalJSON(data []byte) error {")
@@ -594,12 +612,13 @@ func (g *Generator) generateUnwrapUnmarshalJSON(gf *protogen.GeneratedFile, cont
 	gf.P("if err := json.Unmarshal(data, &raw); err != nil {")
 	gf.P("return err")
 	gf.P("}")
+	encodinggen.WriteResolveAlternateJSONNames(gf, containing.Message)
 	gf.P()
 
 	// Handle each field
 	for _, field := range containing.Message.Fields {
 		fieldName := field.GoName
-		jsonName := getJSONFieldName(field)
+		jsonName := annotations.JSONFieldName(field)
 
 		// Check if this is one of our unwrap map fields
 		var unwrapMapField *UnwrapMapField
@@ -749,12 +768,6 @@ func (g *Generator) generateScalarFieldUnmarshal(
 	gf.P()
 }
 
-// getJSONFieldName returns the JSON field name for a protobuf field.
-func getJSONFieldName(field *protogen.Field) string {
-	// Use the proto JSON name (which is camelCase version of the proto field name)
-	return field.Desc.JSONName()
-}
-
 // getZeroValueCheck returns a condition that checks if a field is non-zero.
 func getZeroValueCheck(field *protogen.Field, fieldExpr string) string {
 	switch field.Desc.Kind().String() {
@@ -1036,7 +1049,10 @@ func (g *Generator) generateRootRepeatedUnwrapMarshalJSON(gf *protogen.Generated
 		gf.P("return json.Marshal(items)")
 		gf.P()
 	} else {
-		// Scalar type - marshal directly
+		// Scalar type - marshal directly; an empty list is [] rather than null
+		gf.P("if x.", fieldName, " == nil {")
+		gf.P("return []byte(\"[]\"), nil")
+		gf.P("}")
 		gf.P("return json.Marshal(x.", fieldName, ")")
 	}
 
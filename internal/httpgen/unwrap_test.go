@@ -179,12 +179,10 @@ func TestUnwrapBindingIntegration(t *testing.T) {
 		}
 	})
 
-	t.Run("marshalJSONWithOpts checks sebufMarshaler before json.Marshaler", func(t *testing.T) {
-		if !strings.Contains(content, "if m, ok := msg.(sebufMarshaler); ok {") {
-			t.Error("marshalJSONWithOpts should check for sebufMarshaler")
-		}
-		if !strings.Contains(content, "if m, ok := msg.(json.Marshaler); ok {") {
-			t.Error("marshalJSONWithOpts should also check for json.Marshaler as a fallback")
+	t.Run("marshalJSONWithOpts dispatches through sebufhttp.MarshalMessageJSON", func(t *testing.T) {
+		// MarshalMessageJSON checks sebufMarshaler before json.Marshaler (see http/message_json_test.go)
+		if !strings.Contains(content, "return sebufhttp.MarshalMessageJSON(msg, marshalOpts)") {
+			t.Error("marshalJSONWithOpts should dispatch through sebufhttp.MarshalMessageJSON")
 		}
 	})
 }
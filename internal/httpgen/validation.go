@@ -2,10 +2,14 @@ package httpgen
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"slices"
 
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/reflect/protoreflect"
 
+	"github.com/SebastienMelki/sebuf/http"
 	"github.com/SebastienMelki/sebuf/internal/annotations"
 )
 
@@ -13,6 +17,9 @@ import (
 type ValidationError struct {
 	Service string
 	Method  string
+	// Rule identifies the check that failed, e.g. "path-param-field". sebuf-lint
+	// reports the error under this rule ID.
+	Rule    string
 	Message string
 }
 
@@ -30,13 +37,22 @@ func ValidateMethodConfig(service *protogen.Service, method *protogen.Method) []
 	methodName := string(method.Desc.Name())
 	inputMsgName := string(method.Input.Desc.Name())
 
-	// 1. Validate path variables have corresponding fields
+	// 1. Validate path variables have corresponding fields, and a wildcard ends the path
+	if err := annotations.ValidatePathWildcard(annotations.EnsureLeadingSlash(config.Path)); err != nil {
+		errors = append(errors, ValidationError{
+			Service: serviceName,
+			Method:  methodName,
+			Rule:    "path-wildcard",
+			Message: err.Error(),
+		})
+	}
 	for _, param := range config.PathParams {
-		field := findFieldByProtoName(method.Input, param)
+		field := annotations.FindFieldByProtoName(method.Input, param)
 		if field == nil {
 			errors = append(errors, ValidationError{
 				Service: serviceName,
 				Method:  methodName,
+				Rule:    "path-param-field",
 				Message: fmt.Sprintf(
 					"path variable '{%s}' in path '%s' has no matching field in message '%s'. "+
 						"Add a field named '%s' to the request message, or fix the path variable name.",
@@ -50,6 +66,7 @@ func ValidateMethodConfig(service *protogen.Service, method *protogen.Method) []
 			errors = append(errors, ValidationError{
 				Service: serviceName,
 				Method:  methodName,
+				Rule:    "path-param-type",
 				Message: fmt.Sprintf(
 					"path variable '{%s}' is bound to field '%s' of type '%s', but path parameters must be scalar types "+
 						"(string, int32, int64, uint32, uint64, bool, float, double, enum). "+
@@ -59,6 +76,20 @@ func ValidateMethodConfig(service *protogen.Service, method *protogen.Method) []
 					field.Desc.Kind(),
 				),
 			})
+			continue
+		}
+		if annotations.IsPathWildcard(config.Path, param) &&
+			(field.Desc.Kind() != protoreflect.StringKind || field.Desc.IsList()) {
+			errors = append(errors, ValidationError{
+				Service: serviceName,
+				Method:  methodName,
+				Rule:    "path-wildcard",
+				Message: fmt.Sprintf(
+					"wildcard '{%s...}' in path '%s' is bound to field '%s' of type '%s', but a wildcard matches "+
+						"the rest of the path and must be bound to a string field.",
+					param, config.Path, param, field.Desc.Kind(),
+				),
+			})
 		}
 	}
 
@@ -70,6 +101,7 @@ func ValidateMethodConfig(service *protogen.Service, method *protogen.Method) []
 				errors = append(errors, ValidationError{
 					Service: serviceName,
 					Method:  methodName,
+					Rule:    "query-path-conflict",
 					Message: fmt.Sprintf(
 						"field '%s' is used both as a path variable in '%s' and as a query parameter. "+
 							"A field can only be bound to one parameter type. "+
@@ -80,22 +112,38 @@ func ValidateMethodConfig(service *protogen.Service, method *protogen.Method) []
 		}
 	}
 
-	// 4. Error on GET/DELETE with unbound body fields
+	// 4. Validate declared field sources against the path and base path
+	pathParams := append(annotations.GetBoundBasePathParams(service), config.PathParams...)
+	if err := annotations.ValidateFieldSources(method, pathParams); err != nil {
+		errors = append(errors, ValidationError{
+			Service: serviceName,
+			Method:  methodName,
+			Rule:    "field-source",
+			Message: err.Error(),
+		})
+	}
+
+	// 5. Error on GET/DELETE with unbound body fields
 	httpMethod := config.Method
 	if httpMethod == "" {
 		httpMethod = "POST"
 	}
 
 	if httpMethod == "GET" || httpMethod == "DELETE" {
-		bodyFields := getBodyFields(method.Input, config.PathParams, queryParams)
-		if len(bodyFields) > 0 {
-			fieldNames := make([]string, 0, len(bodyFields))
-			for _, f := range bodyFields {
+		errors = append(errors, validateQueryFieldTypes(serviceName, methodName, httpMethod, method, pathParams)...)
+
+		var fieldNames []string
+		for _, f := range getBodyFields(method.Input, pathParams, queryParams) {
+			// Fields the query string cannot carry are reported by query-field-type
+			if annotations.QueryUnbindableReason(f) == "" {
 				fieldNames = append(fieldNames, string(f.Desc.Name()))
 			}
+		}
+		if len(fieldNames) > 0 {
 			errors = append(errors, ValidationError{
 				Service: serviceName,
 				Method:  methodName,
+				Rule:    "get-body-fields",
 				Message: fmt.Sprintf(
 					"%s request has fields that are not bound to path or query parameters: %v. "+
 						"%s requests cannot have a request body. "+
@@ -106,17 +154,229 @@ func ValidateMethodConfig(service *protogen.Service, method *protogen.Method) []
 		}
 	}
 
+	// 6. Idempotent replay stores a single response body, which a stream has not
+	if config.Idempotency && config.Stream {
+		errors = append(errors, ValidationError{
+			Service: serviceName,
+			Method:  methodName,
+			Rule:    "idempotency-stream",
+			Message: "idempotency is not supported on streaming methods. " +
+				"Remove either idempotency: true or stream: true.",
+		})
+	}
+
+	// 7. Only safe, single-response methods may be cached
+	if config.Cache != nil {
+		errors = append(errors, validateCacheConfig(serviceName, methodName, httpMethod, config)...)
+	}
+
+	// 8. A timeout bounds a single response, and must not be negative
+	if config.TimeoutMs < 0 {
+		errors = append(errors, ValidationError{
+			Service: serviceName,
+			Method:  methodName,
+			Rule:    "timeout",
+			Message: fmt.Sprintf("timeout_ms must not be negative, got %d.", config.TimeoutMs),
+		})
+	}
+	if config.TimeoutMs > 0 && config.Stream {
+		errors = append(errors, ValidationError{
+			Service: serviceName,
+			Method:  methodName,
+			Rule:    "timeout",
+			Message: "timeout_ms is not supported on streaming methods. Remove either timeout_ms or stream: true.",
+		})
+	}
+
+	// 9. A form is a request body, which GET and DELETE requests do not have
+	if config.AcceptForm && (httpMethod == "GET" || httpMethod == "DELETE") {
+		errors = append(errors, ValidationError{
+			Service: serviceName,
+			Method:  methodName,
+			Rule:    "form-body",
+			Message: fmt.Sprintf(
+				"accept_form is only supported on methods with a request body, but this method uses %s. "+
+					"Remove accept_form or change the method to POST/PUT/PATCH.",
+				httpMethod),
+		})
+	}
+
+	// 10. Likewise for multipart bodies, whose filename captures must name bytes fields
+	if config.AcceptMultipart && (httpMethod == "GET" || httpMethod == "DELETE") {
+		errors = append(errors, ValidationError{
+			Service: serviceName,
+			Method:  methodName,
+			Rule:    "multipart",
+			Message: fmt.Sprintf(
+				"accept_multipart is only supported on methods with a request body, but this method uses %s. "+
+					"Remove accept_multipart or change the method to POST/PUT/PATCH.",
+				httpMethod),
+		})
+	}
+	if config.AcceptMultipart {
+		if err := annotations.ValidateMultipartFilenames(method.Input); err != nil {
+			errors = append(errors, ValidationError{
+				Service: serviceName,
+				Method:  methodName,
+				Rule:    "multipart",
+				Message: err.Error(),
+			})
+		}
+	}
+
+	// 11. Streamed items need a list-shaped response and a handler that is not buffered
+	if config.StreamResponse {
+		errors = append(errors, validateStreamResponse(serviceName, methodName, method, config)...)
+	}
+
+	// 12. A merge patch is the body of a PATCH request, in JSON only
+	if config.MergePatch {
+		errors = append(errors, validateMergePatch(serviceName, methodName, httpMethod, config)...)
+	}
+
 	return errors
 }
 
-// findFieldByProtoName finds a field in a message by its proto name.
-func findFieldByProtoName(message *protogen.Message, fieldName string) *protogen.Field {
-	for _, field := range message.Fields {
-		if string(field.Desc.Name()) == fieldName {
-			return field
+// validateQueryFieldTypes reports the request fields of a GET or DELETE method
+// that are neither path variables nor headers and have a type the query string
+// cannot carry, so the server could never populate them.
+func validateQueryFieldTypes(
+	serviceName, methodName, httpMethod string,
+	method *protogen.Method,
+	pathParams []string,
+) []ValidationError {
+	var errors []ValidationError
+	for _, field := range method.Input.Fields {
+		name := string(field.Desc.Name())
+		if slices.Contains(pathParams, name) ||
+			annotations.GetFieldSource(field) == http.FieldSource_FIELD_SOURCE_HEADER {
+			continue
 		}
+		reason := annotations.QueryUnbindableReason(field)
+		if reason == "" {
+			continue
+		}
+		errors = append(errors, ValidationError{
+			Service: serviceName,
+			Method:  methodName,
+			Rule:    "query-field-type",
+			Message: fmt.Sprintf(
+				"field '%s' cannot be bound from the query string of a %s request because %s. "+
+					"Query parameters only carry scalars, enums and repeated scalars or enums. "+
+					"Change the HTTP method to POST/PUT/PATCH, or remove the field from the request.",
+				name, httpMethod, reason),
+		})
 	}
-	return nil
+	return errors
+}
+
+// validateStreamResponse validates the stream_response annotation of a method.
+func validateStreamResponse(
+	serviceName, methodName string,
+	method *protogen.Method,
+	config *annotations.HTTPConfig,
+) []ValidationError {
+	var errors []ValidationError
+	if _, err := annotations.StreamResponseField(method); err != nil {
+		errors = append(errors, ValidationError{
+			Service: serviceName,
+			Method:  methodName,
+			Rule:    "stream-response",
+			Message: err.Error() + ". Give the response a single repeated message field or remove stream_response.",
+		})
+	}
+	for _, conflict := range []struct {
+		set  bool
+		name string
+	}{
+		{config.Stream, "stream: true"},
+		{config.Idempotency, "idempotency: true"},
+		{config.Cache != nil, "cache"},
+		{config.TimeoutMs > 0, "timeout_ms"},
+	} {
+		if conflict.set {
+			errors = append(errors, ValidationError{
+				Service: serviceName,
+				Method:  methodName,
+				Rule:    "stream-response",
+				Message: fmt.Sprintf(
+					"stream_response is not supported together with %s. Remove either of them.", conflict.name),
+			})
+		}
+	}
+	return errors
+}
+
+// validateMergePatch validates the patch_format annotation of a method.
+func validateMergePatch(serviceName, methodName, httpMethod string, config *annotations.HTTPConfig) []ValidationError {
+	var errors []ValidationError
+	if httpMethod != "PATCH" {
+		errors = append(errors, ValidationError{
+			Service: serviceName,
+			Method:  methodName,
+			Rule:    "merge-patch",
+			Message: fmt.Sprintf(
+				"patch_format MERGE_PATCH is only supported on PATCH methods, but this method uses %s. "+
+					"Remove patch_format or change the method to PATCH.",
+				httpMethod),
+		})
+	}
+	for _, conflict := range []struct {
+		set  bool
+		name string
+	}{
+		{config.Stream, "stream: true"},
+		{config.AcceptForm, "accept_form: true"},
+		{config.AcceptMultipart, "accept_multipart: true"},
+	} {
+		if conflict.set {
+			errors = append(errors, ValidationError{
+				Service: serviceName,
+				Method:  methodName,
+				Rule:    "merge-patch",
+				Message: fmt.Sprintf(
+					"patch_format MERGE_PATCH is not supported together with %s. Remove either of them.",
+					conflict.name),
+			})
+		}
+	}
+	return errors
+}
+
+// validateCacheConfig validates the cache annotation of a method.
+func validateCacheConfig(serviceName, methodName, httpMethod string, config *annotations.HTTPConfig) []ValidationError {
+	var errors []ValidationError
+	if httpMethod != "GET" {
+		errors = append(errors, ValidationError{
+			Service: serviceName,
+			Method:  methodName,
+			Rule:    "cache",
+			Message: fmt.Sprintf(
+				"cache is only supported on GET methods, but this method uses %s. "+
+					"Responses of mutating methods must never be cached. "+
+					"Remove the cache option or change the method to GET.",
+				httpMethod),
+		})
+	}
+	if config.Stream {
+		errors = append(errors, ValidationError{
+			Service: serviceName,
+			Method:  methodName,
+			Rule:    "cache",
+			Message: "cache is not supported on streaming methods. Remove either cache or stream: true.",
+		})
+	}
+	if config.Cache.GetMaxAgeSeconds() <= 0 {
+		errors = append(errors, ValidationError{
+			Service: serviceName,
+			Method:  methodName,
+			Rule:    "cache",
+			Message: fmt.Sprintf(
+				"cache.max_age_seconds must be positive, got %d. Set how long responses may be reused.",
+				config.Cache.GetMaxAgeSeconds()),
+		})
+	}
+	return errors
 }
 
 // isPathParamCompatible checks if a field type can be used as a path parameter.
@@ -137,7 +397,8 @@ func isPathParamCompatible(field *protogen.Field) bool {
 	return false
 }
 
-// getBodyFields returns fields that are not bound to path or query parameters.
+// getBodyFields returns fields that are not bound to path or query parameters
+// or read from a request header.
 func getBodyFields(
 	message *protogen.Message,
 	pathParams []string,
@@ -156,7 +417,8 @@ func getBodyFields(
 	var bodyFields []*protogen.Field
 	for _, field := range message.Fields {
 		fieldName := string(field.Desc.Name())
-		if !pathParamSet[fieldName] && !queryParamSet[fieldName] {
+		if !pathParamSet[fieldName] && !queryParamSet[fieldName] &&
+			annotations.GetFieldSource(field) != http.FieldSource_FIELD_SOURCE_HEADER {
 			bodyFields = append(bodyFields, field)
 		}
 	}
@@ -164,16 +426,53 @@ func getBodyFields(
 	return bodyFields
 }
 
-// ValidateService validates all methods in a service.
+// ValidateService validates all methods in a service, except for the method
+// checks whose rule is listed in skipRules.
 // Returns an error if any validation issues are found, stopping code generation.
-func ValidateService(service *protogen.Service) error {
+func ValidateService(service *protogen.Service, skipRules ...string) error {
+	if err := annotations.ValidateServiceVersions(service); err != nil {
+		return fmt.Errorf("%s: %w", service.Desc.Name(), err)
+	}
+	if err := annotations.ValidateServiceHeaders(service); err != nil {
+		return err
+	}
+	if err := annotations.ValidateResponseHeaders(service); err != nil {
+		return err
+	}
+	if err := annotations.ValidateResponseStatuses(service); err != nil {
+		return err
+	}
+	if err := annotations.ValidateRawBodyResponses(service); err != nil {
+		return err
+	}
+	if err := annotations.ValidateQueryEncodings(service); err != nil {
+		return err
+	}
+	if err := annotations.ValidateQueryAliases(service); err != nil {
+		return err
+	}
+	if err := annotations.ValidateBasePathParams(service); err != nil {
+		return err
+	}
+	if err := annotations.ValidateMethodExamples(service); err != nil {
+		return err
+	}
 	for _, method := range service.Methods {
-		errors := ValidateMethodConfig(service, method)
-		if len(errors) > 0 {
+		for _, err := range ValidateMethodConfig(service, method) {
+			if slices.Contains(skipRules, err.Rule) {
+				continue
+			}
 			// Return the first error to fail fast
-			err := errors[0]
 			return fmt.Errorf("%s.%s: %s", err.Service, err.Method, err.Message)
 		}
 	}
 	return nil
 }
+
+// warningWriter returns the writer of generation-time warnings.
+func (g *Generator) warningWriter() io.Writer {
+	if g.warnings == nil {
+		return os.Stderr
+	}
+	return g.warnings
+}
@@ -0,0 +1,207 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestValidationPolicyIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server from a proto with a required header and a
+//     min_len constraint,
+//  2. writes a temporary Go module that serves it with httptest under a
+//     WithValidationPolicy selecting the mode from a request header,
+//  3. verifies Enforce rejects, Warn logs and exposes the violations through
+//     sebufhttp.ViolationsFromContext, and Skip lets the request through silently.
+func TestValidationPolicyIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "validation_policy_test",
+		Protos:  map[string]string{"validation_policy.proto": validationPolicyProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"validation_policy_test.go": validationPolicyIntegrationTestCode},
+	})
+}
+
+const validationPolicyProto = `syntax = "proto3";
+package test.validationpolicy;
+option go_package = "validation_policy_test/gen;gen";
+import "buf/validate/validate.proto";
+import "sebuf/http/annotations.proto";
+import "sebuf/http/headers.proto";
+
+service NoteService {
+  option (sebuf.http.service_headers) = {
+    required_headers: [
+      { name: "X-Tenant-ID" type: "string" required: true }
+    ]
+  };
+
+  rpc CreateNote(CreateNoteRequest) returns (Note) {
+    option (sebuf.http.config) = { path: "/notes" };
+  }
+}
+
+message CreateNoteRequest {
+  string title = 1 [(buf.validate.field).string.min_len = 3];
+}
+
+message Note {
+  string title = 1;
+  repeated string violations = 2;
+}
+`
+
+// validationPolicyIntegrationTestCode is the test source that runs inside the
+// temp module. The policy takes the mode from the X-Validation-Mode request
+// header, and the server echoes the violations it was handed.
+const validationPolicyIntegrationTestCode = `package validation_policy_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "validation_policy_test/gen"
+)
+
+type noteServer struct{}
+
+func (noteServer) CreateNote(ctx context.Context, req *gen.CreateNoteRequest) (*gen.Note, error) {
+	note := &gen.Note{Title: req.GetTitle()}
+	for _, v := range sebufhttp.ViolationsFromContext(ctx) {
+		note.Violations = append(note.Violations, v.GetField())
+	}
+	return note, nil
+}
+
+type harness struct {
+	url  string
+	logs *bytes.Buffer
+
+	mu   sync.Mutex
+	msgs []proto.Message // Messages the policy was consulted with
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+	h := &harness{logs: &bytes.Buffer{}}
+	modes := map[string]sebufhttp.ValidationMode{
+		"warn": sebufhttp.ValidationWarn,
+		"skip": sebufhttp.ValidationSkip,
+	}
+	policy := func(r *http.Request, msg proto.Message) sebufhttp.ValidationMode {
+		h.mu.Lock()
+		h.msgs = append(h.msgs, msg)
+		h.mu.Unlock()
+		return modes[r.Header.Get("X-Validation-Mode")]
+	}
+
+	mux := http.NewServeMux()
+	if err := gen.RegisterNoteServiceServer(noteServer{},
+		gen.WithMux(mux),
+		gen.WithValidationPolicy(policy),
+		gen.WithLogger(slog.New(slog.NewTextHandler(h.logs, nil))),
+	); err != nil {
+		t.Fatalf("RegisterNoteServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	h.url = srv.URL
+	return h
+}
+
+// post sends a note titled title under mode, with the tenant header unless
+// omitTenant is set, and returns the status and decoded note.
+func (h *harness) post(t *testing.T, mode, title string, omitTenant bool) (int, *gen.Note) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, h.url+"/notes", strings.NewReader(` + "`" + `{"title":"` + "`" + `+title+` + "`" + `"}` + "`" + `))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Validation-Mode", mode)
+	if !omitTenant {
+		req.Header.Set("X-Tenant-ID", "acme")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST /notes: %v", err)
+	}
+	defer resp.Body.Close()
+	note := &gen.Note{}
+	if resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(note); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+	}
+	return resp.StatusCode, note
+}
+
+func TestValidRequestPasses(t *testing.T) {
+	h := newHarness(t)
+	status, note := h.post(t, "", "groceries", false)
+	if status != http.StatusOK || len(note.Violations) != 0 {
+		t.Errorf("status %d, violations %v; want 200 without violations", status, note.Violations)
+	}
+}
+
+func TestEnforceRejects(t *testing.T) {
+	h := newHarness(t)
+	if status, _ := h.post(t, "", "ab", false); status != http.StatusBadRequest {
+		t.Errorf("min_len: status %d, want 400", status)
+	}
+	if status, _ := h.post(t, "", "groceries", true); status != http.StatusBadRequest {
+		t.Errorf("missing header: status %d, want 400", status)
+	}
+}
+
+func TestWarnProceedsWithViolations(t *testing.T) {
+	h := newHarness(t)
+	status, note := h.post(t, "warn", "ab", true)
+	if status != http.StatusOK {
+		t.Fatalf("status %d, want 200", status)
+	}
+	if strings.Join(note.Violations, ",") != "X-Tenant-ID,title" {
+		t.Errorf("violations = %v, want [X-Tenant-ID title]", note.Violations)
+	}
+	logs := h.logs.String()
+	if strings.Count(logs, "request validation failed") != 2 ||
+		!strings.Contains(logs, "field=title") || !strings.Contains(logs, "field=X-Tenant-ID") {
+		t.Errorf("expected both violations to be logged, got:\n%s", logs)
+	}
+}
+
+func TestSkipProceedsSilently(t *testing.T) {
+	h := newHarness(t)
+	status, note := h.post(t, "skip", "ab", true)
+	if status != http.StatusOK || note.Title != "ab" {
+		t.Fatalf("status %d, title %q; want 200 echoing ab", status, note.Title)
+	}
+	if len(note.Violations) != 0 || h.logs.Len() != 0 {
+		t.Errorf("skip should neither record nor log violations, got %v and %q", note.Violations, h.logs.String())
+	}
+}
+
+// TestPolicySeesBoundMessage checks the policy is consulted with nil for the
+// failing headers and with the bound request for the message.
+func TestPolicySeesBoundMessage(t *testing.T) {
+	h := newHarness(t)
+	h.post(t, "warn", "ab", true)
+	if len(h.msgs) != 2 || h.msgs[0] != nil {
+		t.Fatalf("policy consulted with %v, want [nil, request]", h.msgs)
+	}
+	if req, ok := h.msgs[1].(*gen.CreateNoteRequest); !ok || req.GetTitle() != "ab" {
+		t.Errorf("policy saw %v, want the bound CreateNoteRequest", h.msgs[1])
+	}
+}
+`
@@ -0,0 +1,52 @@
+package httpgen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatorWarmUpGeneration(t *testing.T) {
+	files := generateTestFiles(t, "request_validation.proto")
+
+	t.Run("registration warms up the validators", func(t *testing.T) {
+		if !strings.Contains(files.http, "\tif err := warmUpValidators(\n") {
+			t.Fatal("Register*Server should warm up the validators of its messages")
+		}
+		warmUp := strings.Index(files.http, "warmUpValidators(")
+		if slot := strings.Index(files.http, "server = dispatching"); slot < warmUp {
+			t.Error("validators should be warmed up before the server is registered")
+		}
+	})
+
+	t.Run("validators are cached per message type", func(t *testing.T) {
+		for _, want := range []string{
+			"var validators sync.Map",
+			"func getValidator(msg proto.Message) (protovalidate.Validator, error) {",
+			"protovalidate.New(protovalidate.WithMessages(msg))",
+		} {
+			if !strings.Contains(files.binding, want) {
+				t.Errorf("binding file should contain %q", want)
+			}
+		}
+		if strings.Contains(files.binding, "validatorOnce") {
+			t.Error("the global validator should be gone")
+		}
+	})
+
+	t.Run("validation failures honor the failure mode", func(t *testing.T) {
+		if !strings.Contains(files.binding, "sebufhttp.ApplyValidationFailure(r, validationFailureMode, err, logger)") {
+			t.Error("BindingMiddleware should apply the validation failure mode")
+		}
+		if !strings.Contains(files.config,
+			"func WithValidationFailureMode(mode sebufhttp.ValidationFailureMode) ServerOption") {
+			t.Error("WithValidationFailureMode option not found")
+		}
+	})
+}
+
+func TestValidatorWarmUpOmittedWithoutRules(t *testing.T) {
+	files := generateTestFiles(t, "http_verbs_comprehensive.proto")
+	if strings.Contains(files.http, "warmUpValidators(") || strings.Contains(files.binding, "validators sync.Map") {
+		t.Error("files without buf.validate rules should not build validators")
+	}
+}
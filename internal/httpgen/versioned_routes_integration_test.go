@@ -0,0 +1,168 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestVersionedRoutesIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server and Go client from a service with a deprecated
+//     and a current API version,
+//  2. writes a temporary Go module that serves the generated handlers with httptest,
+//  3. verifies both versions are served, that only the deprecated one sets the
+//     Deprecation and Sunset headers, and that the client calls the current
+//     version unless WithAPIVersion selects another.
+func TestVersionedRoutesIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "versioned_routes_test",
+		Protos:  map[string]string{"versioned_routes.proto": versionedRoutesProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}, {Name: "go-client"}},
+		Files:   map[string]string{"versioned_routes_test.go": versionedRoutesIntegrationTestCode},
+	})
+}
+
+const versionedRoutesProto = `syntax = "proto3";
+package test.versionedroutes;
+option go_package = "versioned_routes_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service CatalogService {
+  option (sebuf.http.service_config) = {
+    versions: [
+      { base_path: "/api/v1" deprecated: true sunset: "2026-01-01" },
+      { base_path: "/api/v2" }
+    ]
+  };
+
+  rpc GetProduct(GetProductRequest) returns (Product) {
+    option (sebuf.http.config) = { path: "/products/{product_id}" method: HTTP_METHOD_GET };
+  }
+}
+
+message GetProductRequest {
+  string product_id = 1;
+}
+
+message Product {
+  string product_id = 1;
+}
+`
+
+// versionedRoutesIntegrationTestCode is the test source that runs inside the
+// temp module.
+const versionedRoutesIntegrationTestCode = `package versioned_routes_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "versioned_routes_test/gen"
+)
+
+type catalogServer struct{}
+
+func (catalogServer) GetProduct(ctx context.Context, req *gen.GetProductRequest) (*gen.Product, error) {
+	if req.GetProductId() == "missing" {
+		return nil, errors.New("product not found")
+	}
+	return &gen.Product{ProductId: req.GetProductId()}, nil
+}
+
+// newServer serves CatalogService and records the path and response headers
+// of the last request.
+func newServer(t *testing.T) (string, *string, *http.Header) {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterCatalogServiceServer(catalogServer{}, gen.WithMux(mux)); err != nil {
+		t.Fatalf("RegisterCatalogServiceServer: %v", err)
+	}
+	var lastPath string
+	var lastHeader http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastPath = r.URL.Path
+		mux.ServeHTTP(w, r)
+		lastHeader = w.Header().Clone()
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL, &lastPath, &lastHeader
+}
+
+func TestBothVersionsServed(t *testing.T) {
+	url, _, _ := newServer(t)
+
+	for _, tc := range []struct {
+		path            string
+		wantDeprecation string
+		wantSunset      string
+	}{
+		{"/api/v1/products/p1", "true", "Thu, 01 Jan 2026 00:00:00 GMT"},
+		{"/api/v2/products/p1", "", ""},
+	} {
+		resp, err := http.Get(url + tc.path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", tc.path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GET %s: status = %d, want 200", tc.path, resp.StatusCode)
+		}
+		if got := resp.Header.Get(sebufhttp.DeprecationHeader); got != tc.wantDeprecation {
+			t.Errorf("GET %s: Deprecation = %q, want %q", tc.path, got, tc.wantDeprecation)
+		}
+		if got := resp.Header.Get(sebufhttp.SunsetHeader); got != tc.wantSunset {
+			t.Errorf("GET %s: Sunset = %q, want %q", tc.path, got, tc.wantSunset)
+		}
+	}
+}
+
+func TestDeprecationHeadersOnErrors(t *testing.T) {
+	url, _, _ := newServer(t)
+	resp, err := http.Get(url + "/api/v1/products/missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 400 {
+		t.Fatalf("status = %d, want an error", resp.StatusCode)
+	}
+	if resp.Header.Get(sebufhttp.DeprecationHeader) != "true" {
+		t.Error("error responses of deprecated routes should carry the Deprecation header")
+	}
+}
+
+func TestClientVersionSelection(t *testing.T) {
+	url, lastPath, lastHeader := newServer(t)
+	ctx := context.Background()
+	req := &gen.GetProductRequest{ProductId: "p1"}
+
+	if _, err := gen.NewCatalogServiceClient(url).GetProduct(ctx, req); err != nil {
+		t.Fatalf("default version: %v", err)
+	}
+	if *lastPath != "/api/v2/products/p1" {
+		t.Errorf("default client called %s, want the newest non-deprecated version", *lastPath)
+	}
+
+	v1 := gen.NewCatalogServiceClient(url, gen.WithCatalogServiceAPIVersion("v1"))
+	if _, err := v1.GetProduct(ctx, req); err != nil {
+		t.Fatalf("v1: %v", err)
+	}
+	if *lastPath != "/api/v1/products/p1" {
+		t.Errorf("v1 client called %s", *lastPath)
+	}
+	if lastHeader.Get(sebufhttp.DeprecationHeader) != "true" {
+		t.Error("v1 responses should carry the Deprecation header")
+	}
+
+	unknown := gen.NewCatalogServiceClient(url, gen.WithCatalogServiceAPIVersion("v9"))
+	if _, err := unknown.GetProduct(ctx, req); err == nil || !strings.Contains(err.Error(), "v9") {
+		t.Errorf("unknown version: err = %v, want an unknown version error", err)
+	}
+}
+`
@@ -0,0 +1,128 @@
+package httpgen
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestVersionedRoutesGeneration verifies that every method of a versioned service
+// is registered under each version's base path, and only deprecated versions are
+// wrapped in sebufhttp.DeprecatedVersionMiddleware.
+func TestVersionedRoutesGeneration(t *testing.T) {
+	files := generateTestFiles(t, "versioned_routes.proto")
+
+	for _, want := range []string{
+		`config.mux.Handle("GET /api/v1beta/products/{product_id}", ` +
+			`sebufhttp.DeprecatedVersionMiddleware(getProductHandler, ""))`,
+		`config.mux.Handle("GET /api/v1/products/{product_id}", ` +
+			`sebufhttp.DeprecatedVersionMiddleware(getProductHandler, "Thu, 01 Jan 2026 00:00:00 GMT"))`,
+		`config.mux.Handle("GET /api/v2/products/{product_id}", getProductHandler)`,
+		`config.mux.Handle("POST /api/v2/products", createProductHandler)`,
+	} {
+		if !strings.Contains(files.http, want) {
+			t.Errorf("missing route registration %q", want)
+		}
+	}
+	heads := strings.Count(files.http, `config.mux.Handle("HEAD `)
+	if n := strings.Count(files.http, "config.mux.Handle(") - heads; n != 6 {
+		t.Errorf("expected 6 route registrations (2 methods x 3 versions), got %d", n)
+	}
+}
+
+// TestVersionedRoutesRejected verifies generation fails on invalid versions annotations.
+func TestVersionedRoutesRejected(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping versions validation test")
+	}
+
+	baseDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	projectRoot := filepath.Join(baseDir, "..", "..")
+	pluginPath := filepath.Join(projectRoot, "bin", "protoc-gen-go-http")
+	if _, statErr := os.Stat(pluginPath); os.IsNotExist(statErr) {
+		t.Skip("protoc-gen-go-http not built, run make build")
+	}
+
+	tests := []struct {
+		name       string
+		service    string
+		methodPath string
+		wantErr    string
+	}{
+		{
+			name:       "absolute method path",
+			service:    `versions: [{ base_path: "/api/v1" }, { base_path: "/api/v2" }]`,
+			methodPath: "/api/v1/orders",
+			wantErr:    `path "/api/v1/orders" already includes the base path of version "v1"`,
+		},
+		{
+			name:       "base path and versions",
+			service:    `base_path: "/api" versions: [{ base_path: "/api/v1" }]`,
+			methodPath: "/orders",
+			wantErr:    "versions and base_path are mutually exclusive",
+		},
+		{
+			name:       "duplicate name",
+			service:    `versions: [{ base_path: "/v1" }, { base_path: "/api/v1" }]`,
+			methodPath: "/orders",
+			wantErr:    `version name "v1" is used by more than one version`,
+		},
+		{
+			name:       "invalid sunset",
+			service:    `versions: [{ base_path: "/api/v1" deprecated: true sunset: "next year" }]`,
+			methodPath: "/orders",
+			wantErr:    `version "v1" has an invalid sunset "next year"`,
+		},
+		{
+			name:       "sunset without deprecation",
+			service:    `versions: [{ base_path: "/api/v1" sunset: "2026-01-01" }]`,
+			methodPath: "/orders",
+			wantErr:    `version "v1" has a sunset but is not deprecated`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			protoDir := t.TempDir()
+			protoSrc := `syntax = "proto3";
+package test.versions;
+option go_package = "example.com/versions;versions";
+import "sebuf/http/annotations.proto";
+service Orders {
+  option (sebuf.http.service_config) = { ` + tt.service + ` };
+  rpc ListOrders(ListOrdersRequest) returns (ListOrdersResponse) {
+    option (sebuf.http.config) = { path: "` + tt.methodPath + `" method: HTTP_METHOD_GET };
+  }
+}
+message ListOrdersRequest {}
+message ListOrdersResponse {}
+`
+			if writeErr := os.WriteFile(filepath.Join(protoDir, "versions.proto"), []byte(protoSrc), 0o600); writeErr != nil {
+				t.Fatalf("Failed to write proto: %v", writeErr)
+			}
+
+			cmd := exec.Command("protoc",
+				"--plugin=protoc-gen-go-http="+pluginPath,
+				"--go-http_out="+t.TempDir(),
+				"--go-http_opt=paths=source_relative",
+				"--proto_path="+protoDir,
+				"--proto_path="+filepath.Join(projectRoot, "proto"),
+				"versions.proto",
+			)
+			var stderr bytes.Buffer
+			cmd.Stderr = &stderr
+			if runErr := cmd.Run(); runErr == nil {
+				t.Fatal("expected generation to fail")
+			}
+			if !strings.Contains(stderr.String(), tt.wantErr) {
+				t.Errorf("unexpected error output: %s", stderr.String())
+			}
+		})
+	}
+}
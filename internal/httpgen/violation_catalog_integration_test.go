@@ -0,0 +1,151 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestViolationCatalogIntegration is an end-to-end integration test that:
+//  1. generates a Go HTTP server from a proto declaring a required header and a
+//     uuid header,
+//  2. writes a temporary Go module that serves the generated handlers with httptest,
+//  3. verifies WithViolationCatalog and WithViolationFormatter describe the header
+//     violations, and that violations without a template keep their message.
+func TestViolationCatalogIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "violation_catalog_test",
+		Protos:  map[string]string{"greetings.proto": violationCatalogProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}},
+		Files:   map[string]string{"violation_catalog_test.go": violationCatalogIntegrationTestCode},
+	})
+}
+
+const violationCatalogProto = `syntax = "proto3";
+package test.violationcatalog;
+option go_package = "violation_catalog_test/gen;gen";
+import "sebuf/http/annotations.proto";
+import "sebuf/http/headers.proto";
+
+service GreetingService {
+  option (sebuf.http.service_headers) = {
+    required_headers: [
+      { name: "X-Api-Key" type: "string" required: true },
+      { name: "X-Request-Id" type: "string" format: "uuid" required: true }
+    ]
+  };
+
+  rpc Greet(GreetRequest) returns (GreetResponse) {
+    option (sebuf.http.config) = { path: "/greet" };
+  }
+}
+
+message GreetRequest {
+  string name = 1;
+}
+
+message GreetResponse {
+  string message = 1;
+}
+`
+
+// violationCatalogIntegrationTestCode is the test source that runs inside the
+// temp module.
+const violationCatalogIntegrationTestCode = `package violation_catalog_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "violation_catalog_test/gen"
+)
+
+type greetingServer struct{}
+
+func (greetingServer) Greet(_ context.Context, req *gen.GreetRequest) (*gen.GreetResponse, error) {
+	return &gen.GreetResponse{Message: "Hello, " + req.GetName()}, nil
+}
+
+var frenchCatalog = map[string]string{
+	"header.required":    "l'en-tête {{.Params.header}} est obligatoire",
+	"header.format.uuid": "l'en-tête {{.Field}} doit être un UUID",
+}
+
+// violations posts a greeting with headers and returns the descriptions of the
+// violations in the 400 response, by field.
+func violations(t *testing.T, headers map[string]string, opts ...gen.ServerOption) map[string]string {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterGreetingServiceServer(greetingServer{}, append(opts, gen.WithMux(mux))...); err != nil {
+		t.Fatalf("RegisterGreetingServiceServer: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/greet", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", resp.StatusCode)
+	}
+	var body struct {
+		Violations []struct{ Field, Description string }
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	got := make(map[string]string, len(body.Violations))
+	for _, v := range body.Violations {
+		got[v.Field] = v.Description
+	}
+	return got
+}
+
+func TestCatalogTranslatesHeaderViolations(t *testing.T) {
+	got := violations(t, map[string]string{"X-Request-Id": "not-a-uuid"}, gen.WithViolationCatalog(frenchCatalog))
+	want := map[string]string{
+		"X-Api-Key":    "l'en-tête X-Api-Key est obligatoire",
+		"X-Request-Id": "l'en-tête X-Request-Id doit être un UUID",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("violations = %v, want %v", got, want)
+	}
+}
+
+func TestCatalogKeepsUntranslatedMessages(t *testing.T) {
+	catalog := map[string]string{"header.format.uuid": frenchCatalog["header.format.uuid"]}
+	got := violations(t, nil, gen.WithViolationCatalog(catalog))
+	if got["X-Api-Key"] != "required header 'X-Api-Key' is missing" {
+		t.Errorf("X-Api-Key violation = %q, want the default message", got["X-Api-Key"])
+	}
+}
+
+func TestViolationFormatter(t *testing.T) {
+	formatter := func(v sebufhttp.Violation) string {
+		return v.ConstraintID + ": " + v.Message
+	}
+	got := violations(t, map[string]string{"X-Api-Key": "k", "X-Request-Id": "nope"},
+		gen.WithViolationFormatter(formatter))
+	want := "header.format.uuid: header 'X-Request-Id' validation failed: UUID must be 36 characters long"
+	if len(got) != 1 || got["X-Request-Id"] != want {
+		t.Errorf("violations = %v, want X-Request-Id described as %q", got, want)
+	}
+}
+`
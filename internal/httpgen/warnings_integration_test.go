@@ -0,0 +1,202 @@
+package httpgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestWarningsIntegration generates a Go HTTP server and Go client for a
+// catalog whose handlers add warnings with sebufhttp.AddWarning, and checks
+// that they reach the client's ResponseMetadata through Warning headers, that
+// WithWarningsInBody adds them to JSON objects without breaking strict
+// decoding, and that root-unwrapped arrays are left as they are.
+func TestWarningsIntegration(t *testing.T) {
+	plugintest.RunModuleTest(t, plugintest.Module{
+		Path:    "warnings_test",
+		Protos:  map[string]string{"catalog.proto": warningsProto},
+		Plugins: []plugintest.Plugin{{Name: "go-http"}, {Name: "go-client"}},
+		Files:   map[string]string{"warnings_test.go": warningsIntegrationTestCode},
+	})
+}
+
+const warningsProto = `syntax = "proto3";
+package test.warnings;
+option go_package = "warnings_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+service CatalogService {
+  rpc ListItems(ListItemsRequest) returns (ListItemsResponse) {
+    option (sebuf.http.config) = { path: "/items" method: HTTP_METHOD_GET };
+  }
+  rpc ListNames(ListItemsRequest) returns (NameList) {
+    option (sebuf.http.config) = { path: "/names" method: HTTP_METHOD_GET };
+  }
+}
+
+message ListItemsRequest {}
+
+message ListItemsResponse {
+  repeated string items = 1;
+}
+
+// NameList is sent as a bare JSON array.
+message NameList {
+  repeated string names = 1 [(sebuf.http.unwrap) = true];
+}
+`
+
+// warningsIntegrationTestCode is the test source that runs inside the temp
+// module. Both handlers answer with what 3 of 5 backends returned.
+const warningsIntegrationTestCode = `package warnings_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "warnings_test/gen"
+)
+
+type catalogServer struct{}
+
+func (catalogServer) ListItems(ctx context.Context, _ *gen.ListItemsRequest) (*gen.ListItemsResponse, error) {
+	sebufhttp.AddWarning(ctx, "partial_results", "3 of 5 backends responded")
+	return &gen.ListItemsResponse{Items: []string{"a", "b"}}, nil
+}
+
+func (catalogServer) ListNames(ctx context.Context, _ *gen.ListItemsRequest) (*gen.NameList, error) {
+	sebufhttp.AddWarning(ctx, "partial_results", "3 of 5 backends responded")
+	return &gen.NameList{Names: []string{"a", "b"}}, nil
+}
+
+func newServer(t *testing.T, opts ...gen.ServerOption) string {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := gen.RegisterCatalogServiceServer(catalogServer{}, append(opts, gen.WithMux(mux))...); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func get(t *testing.T, url string) (http.Header, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d: %s", resp.StatusCode, body)
+	}
+	return resp.Header, string(body)
+}
+
+var want = sebufhttp.Warning{Code: "partial_results", Message: "3 of 5 backends responded"}
+
+func checkMetadata(t *testing.T, metadata sebufhttp.ResponseMetadata) {
+	t.Helper()
+	if metadata.StatusCode != http.StatusOK || len(metadata.Warnings) != 1 || metadata.Warnings[0] != want {
+		t.Errorf("metadata = %+v, want status 200 and the partial_results warning", metadata)
+	}
+}
+
+func TestWarningsInHeaderOnly(t *testing.T) {
+	url := newServer(t)
+	header, body := get(t, url+"/items")
+	if got := header.Get("Warning"); got != ` + "`" + `299 - "partial_results: 3 of 5 backends responded"` + "`" + ` {
+		t.Errorf("Warning = %q", got)
+	}
+	if strings.Contains(body, "_warnings") {
+		t.Errorf("body %s has _warnings without WithWarningsInBody", body)
+	}
+
+	var metadata sebufhttp.ResponseMetadata
+	client := gen.NewCatalogServiceClient(url)
+	resp, err := client.ListItems(context.Background(), &gen.ListItemsRequest{},
+		gen.WithCatalogServiceResponseMetadata(&metadata))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetItems()) != 2 {
+		t.Errorf("ListItems = %v", resp)
+	}
+	checkMetadata(t, metadata)
+}
+
+// stripWarningHeaders drops the Warning headers of responses, as some proxies do.
+type stripWarningHeaders struct{}
+
+func (stripWarningHeaders) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err == nil {
+		resp.Header.Del("Warning")
+	}
+	return resp, err
+}
+
+func TestWarningsInBody(t *testing.T) {
+	url := newServer(t, gen.WithWarningsInBody())
+	header, body := get(t, url+"/items")
+	if header.Get("Warning") == "" {
+		t.Error("Warning header missing in body mode")
+	}
+	var decoded struct {
+		Items    []string            ` + "`" + `json:"items"` + "`" + `
+		Warnings []sebufhttp.Warning ` + "`" + `json:"_warnings"` + "`" + `
+	}
+	if err := json.Unmarshal([]byte(body), &decoded); err != nil {
+		t.Fatalf("body %s: %v", body, err)
+	}
+	if len(decoded.Items) != 2 || len(decoded.Warnings) != 1 || decoded.Warnings[0] != want {
+		t.Errorf("body = %s, want the items and the warning", body)
+	}
+
+	// The client decodes strictly, so _warnings must be dropped before decoding;
+	// without the header it still finds the warnings in the body.
+	var metadata sebufhttp.ResponseMetadata
+	client := gen.NewCatalogServiceClient(url,
+		gen.WithCatalogServiceHTTPClient(&http.Client{Transport: stripWarningHeaders{}}))
+	resp, err := client.ListItems(context.Background(), &gen.ListItemsRequest{},
+		gen.WithCatalogServiceResponseMetadata(&metadata))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetItems()) != 2 {
+		t.Errorf("ListItems = %v", resp)
+	}
+	checkMetadata(t, metadata)
+}
+
+func TestWarningsSkipRootUnwrappedBodies(t *testing.T) {
+	url := newServer(t, gen.WithWarningsInBody())
+	header, body := get(t, url+"/names")
+	if strings.TrimSpace(body) != ` + "`" + `["a","b"]` + "`" + ` {
+		t.Errorf("body = %s, want the bare array", body)
+	}
+	if header.Get("Warning") == "" {
+		t.Error("Warning header missing for a root-unwrapped response")
+	}
+
+	var metadata sebufhttp.ResponseMetadata
+	client := gen.NewCatalogServiceClient(url)
+	resp, err := client.ListNames(context.Background(), &gen.ListItemsRequest{},
+		gen.WithCatalogServiceResponseMetadata(&metadata))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetNames()) != 2 {
+		t.Errorf("ListNames = %v", resp)
+	}
+	checkMetadata(t, metadata)
+}
+`
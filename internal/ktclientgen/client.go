@@ -0,0 +1,395 @@
+package ktclientgen
+
+import (
+	"net/http"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// writeServiceClient emits a single client class for a proto service, including
+// the typed *ClientOptions and *CallOptions data classes, header surface, and
+// per-RPC suspend functions. SSE methods (stream=true) throw NotImplementedError.
+func writeServiceClient(p printer, file *protogen.File, service *protogen.Service) {
+	serviceName := string(service.Desc.Name())
+
+	writeClientOptionsClass(p, service, serviceName)
+	writeCallOptionsClass(p, service, serviceName)
+	writeClientClass(p, file, service, serviceName)
+}
+
+func writeClientOptionsClass(p printer, service *protogen.Service, serviceName string) {
+	p("/** Construct-time options for [%sClient]. */", serviceName)
+	p("data class %sClientOptions(", serviceName)
+	p("    val httpClient: OkHttpClient = OkHttpClient(),")
+	p("    val defaultHeaders: Map<String, String> = emptyMap(),")
+	p("    val json: Json = Json {")
+	p("        ignoreUnknownKeys = true")
+	p("        coerceInputValues = true")
+	p("    },")
+
+	// Typed properties for every service-level header annotation.
+	for _, header := range annotations.GetServiceHeaders(service) {
+		p("    val %s: %s = null,", headerOptionName(header.GetName()), headerOptionType(header))
+	}
+
+	// Base path parameters: the constructor throws when one is unset.
+	for _, param := range annotations.GetBasePathParams(service) {
+		p("    val %s: String? = null,", escapeKtKeyword(lowerCamel(param.Name)))
+	}
+	p(")")
+	p("")
+}
+
+func writeCallOptionsClass(p printer, service *protogen.Service, serviceName string) {
+	p("/** Per-call options for [%sClient] methods. */", serviceName)
+	p("data class %sCallOptions(", serviceName)
+	p("    val headers: Map<String, String> = emptyMap(),")
+	p("    val timeoutMillis: Long? = null,")
+
+	// Method-level headers and service-level headers are both available per-call;
+	// service headers are also on the client options. Dedup against service set.
+	seen := make(map[string]bool)
+	for _, header := range annotations.GetServiceHeaders(service) {
+		seen[header.GetName()] = true
+		p("    val %s: %s = null,", headerOptionName(header.GetName()), headerOptionType(header))
+	}
+	for _, method := range service.Methods {
+		for _, header := range annotations.GetMethodHeaders(method) {
+			if seen[header.GetName()] {
+				continue
+			}
+			seen[header.GetName()] = true
+			p("    val %s: %s = null,", headerOptionName(header.GetName()), headerOptionType(header))
+		}
+	}
+	p(")")
+	p("")
+}
+
+func writeClientClass(p printer, file *protogen.File, service *protogen.Service, serviceName string) {
+	p("/** Generated client for %s. */", service.Desc.FullName())
+	p("class %sClient(", serviceName)
+	p("    baseUrl: String,")
+	p("    options: %sClientOptions = %sClientOptions(),", serviceName, serviceName)
+	p(") {")
+
+	writeClientConstructor(p, service, serviceName)
+
+	for _, method := range service.Methods {
+		writeRPCMethod(p, file, service, method, serviceName)
+	}
+
+	writeExecute(p, serviceName)
+	p("}")
+	p("")
+}
+
+func writeClientConstructor(p printer, service *protogen.Service, serviceName string) {
+	p("    private val httpClient: OkHttpClient = options.httpClient")
+	p("    private val json: Json = options.json")
+	p("    private val defaultHeaders: Map<String, String> = buildMap {")
+	p("        putAll(options.defaultHeaders)")
+	// Apply typed service-header options onto default headers.
+	for _, header := range annotations.GetServiceHeaders(service) {
+		propName := headerOptionName(header.GetName())
+		p("        options.%s?.let { put(%s, %s) }",
+			propName, kotlinStringLiteral(annotations.CanonicalHeaderName(header.GetName())), headerValueExpr(header))
+	}
+	p("    }")
+
+	params := annotations.GetBasePathParams(service)
+	if len(params) == 0 {
+		p("    private val baseUrl: String = baseUrl.trimEnd('/')")
+		p("")
+		return
+	}
+	// The base path parameters are substituted once, from the options.
+	p("    private val baseUrl: String")
+	p("")
+	p("    init {")
+	p("        var basePath = %s", kotlinStringLiteral(annotations.GetServiceBasePath(service)))
+	for _, param := range params {
+		propName := escapeKtKeyword(lowerCamel(param.Name))
+		p("        val %s = requireNotNull(options.%s) { %s }",
+			propName, propName, kotlinStringLiteral(serviceName+"ClientOptions."+propName+" is required"))
+		p("        basePath = basePath.replace(%s, encodePathSegment(%s))",
+			kotlinStringLiteral("{"+param.Name+"}"), propName)
+	}
+	p("        this.baseUrl = baseUrl.trimEnd('/') + basePath")
+	p("    }")
+	p("")
+}
+
+func writeRPCMethod(
+	p printer,
+	file *protogen.File,
+	service *protogen.Service,
+	method *protogen.Method,
+	serviceName string,
+) {
+	cfg := buildMethodConfig(service, method)
+	methodName := escapeKtKeyword(annotations.LowerFirst(string(method.Desc.Name())))
+	inputType := rpcMessageType(file, method.Input)
+	outputType := rpcMessageType(file, method.Output)
+
+	if cfg.isSSE {
+		p("    /** SSE streaming is not yet supported by protoc-gen-kt-client. */")
+		p("    @Suppress(\"UNUSED_PARAMETER\")")
+		p("    fun %s(", methodName)
+		p("        req: %s,", inputType)
+		p("        options: %sCallOptions = %sCallOptions(),", serviceName, serviceName)
+		p("    ): Flow<%s> = throw NotImplementedError(", outputType)
+		p(`        "SSE streaming is not yet supported in kt-client. " +`)
+		p(`            "Track support at https://github.com/SebastienMelki/sebuf/issues (label: kt-client).",`)
+		p("    )")
+		p("")
+		return
+	}
+
+	p("    /** Calls %s. */", method.Desc.FullName())
+	p("    suspend fun %s(", methodName)
+	p("        req: %s,", inputType)
+	p("        options: %sCallOptions = %sCallOptions(),", serviceName, serviceName)
+	p("    ): %s {", outputType)
+
+	writePathBuilding(p, file, method, cfg)
+	writeQueryBuilding(p, file, cfg)
+	writeHeaderBuilding(p, file, service, method, cfg)
+	writeBodyBuilding(p, cfg, inputType)
+	p(`        return execute(%s, url.build(), headers.build(), body, options, %s.serializer())`,
+		kotlinStringLiteral(cfg.httpMethod), outputType)
+	p("    }")
+	p("")
+}
+
+func writePathBuilding(p printer, file *protogen.File, method *protogen.Method, cfg *methodConfig) {
+	p("        val path = %s", kotlinStringLiteral(cfg.fullPath))
+	for _, param := range cfg.pathParams {
+		value := "req." + escapeKtKeyword(lowerCamel(param))
+		if field := annotations.FindFieldByProtoName(method.Input, param); field != nil {
+			value = wireString(file, field, "req."+kotlinPropertyName(field))
+		}
+		if annotations.IsPathWildcard(cfg.fullPath, param) {
+			// The wildcard matches the rest of the path, so its slashes are kept
+			p(`            .replace(%s, %s.split("/").joinToString("/") { encodePathSegment(it) })`,
+				kotlinStringLiteral("{"+param+"...}"), value)
+			continue
+		}
+		p("            .replace(%s, encodePathSegment(%s))", kotlinStringLiteral("{"+param+"}"), value)
+	}
+	p("        val url = (baseUrl + path).toHttpUrl().newBuilder()")
+}
+
+func writeQueryBuilding(p printer, file *protogen.File, cfg *methodConfig) {
+	for _, qp := range cfg.queryParams {
+		name := kotlinStringLiteral(qp.ParamName)
+		src := "req." + kotlinPropertyName(qp.Field)
+		switch {
+		case qp.Field.Desc.IsList():
+			p("        %s.forEach { url.addQueryParameter(%s, %s) }", src, name, wireString(file, qp.Field, "it"))
+		case isNullable(qp.Field):
+			p("        %s?.let { url.addQueryParameter(%s, %s) }", src, name, wireString(file, qp.Field, "it"))
+		default:
+			p("        if (%s) url.addQueryParameter(%s, %s)",
+				nonZeroCondition(file, qp.Field, src), name, wireString(file, qp.Field, src))
+		}
+	}
+}
+
+func writeHeaderBuilding(
+	p printer,
+	file *protogen.File,
+	service *protogen.Service,
+	method *protogen.Method,
+	cfg *methodConfig,
+) {
+	p("        val headers = Headers.Builder()")
+	p("        defaultHeaders.forEach { (name, value) -> headers.set(name, value) }")
+	p(`        headers.set("Accept", "application/json")`)
+	p("        options.headers.forEach { (name, value) -> headers.set(name, value) }")
+
+	for _, header := range annotations.GetServiceHeaders(service) {
+		p("        options.%s?.let { headers.set(%s, %s) }", headerOptionName(header.GetName()),
+			kotlinStringLiteral(annotations.CanonicalHeaderName(header.GetName())), headerValueExpr(header))
+	}
+	for _, header := range annotations.GetMethodHeaders(method) {
+		p("        options.%s?.let { headers.set(%s, %s) }", headerOptionName(header.GetName()),
+			kotlinStringLiteral(annotations.CanonicalHeaderName(header.GetName())), headerValueExpr(header))
+	}
+	// Header-sourced fields are set last, overriding default and per-call headers
+	for _, hp := range cfg.headerParams {
+		src := "req." + kotlinPropertyName(hp.Field)
+		name := kotlinStringLiteral(hp.HeaderName)
+		if isNullable(hp.Field) {
+			p("        %s?.let { headers.set(%s, %s) }", src, name, wireString(file, hp.Field, "it"))
+			continue
+		}
+		p("        if (%s) headers.set(%s, %s)", nonZeroCondition(file, hp.Field, src), name,
+			wireString(file, hp.Field, src))
+	}
+}
+
+func writeBodyBuilding(p printer, cfg *methodConfig, inputType string) {
+	if !cfg.hasBody {
+		p("        val body: RequestBody? = null")
+		return
+	}
+	if len(cfg.bodyExcluded) == 0 {
+		p("        val body = json.encodeToString(%s.serializer(), req).toRequestBody(jsonMediaType)", inputType)
+		return
+	}
+	// Fields declared with a query, path, or header source stay out of the body
+	keys := make([]string, 0, len(cfg.bodyExcluded))
+	for _, field := range cfg.bodyExcluded {
+		keys = append(keys, kotlinStringLiteral(annotations.JSONFieldName(field)))
+	}
+	p("        val excluded = setOf(%s)", strings.Join(keys, ", "))
+	p("        val fields = json.encodeToJsonElement(%s.serializer(), req).jsonObject - excluded", inputType)
+	p("        val body = JsonObject(fields).toString().toRequestBody(jsonMediaType)")
+}
+
+// writeExecute emits the private function every RPC method sends its request
+// with, mapping non-2xx responses to ApiException.
+func writeExecute(p printer, serviceName string) {
+	p("    private suspend fun <T> execute(")
+	p("        method: String,")
+	p("        url: HttpUrl,")
+	p("        headers: Headers,")
+	p("        body: RequestBody?,")
+	p("        options: %sCallOptions,", serviceName)
+	p("        deserializer: DeserializationStrategy<T>,")
+	p("    ): T {")
+	p("        val request = Request.Builder().url(url).headers(headers).method(method, body).build()")
+	p("        val client = options.timeoutMillis")
+	p("            ?.let { httpClient.newBuilder().callTimeout(it, TimeUnit.MILLISECONDS).build() }")
+	p("            ?: httpClient")
+	p("        return client.newCall(request).await().use { response ->")
+	p("            val text = response.body?.string().orEmpty()")
+	p("            if (!response.isSuccessful) {")
+	p("                throw apiException(response.code, text, response.headers.toMultimap(), json)")
+	p("            }")
+	p(`            json.decodeFromString(deserializer, text.ifEmpty { "{}" })`)
+	p("        }")
+	p("    }")
+}
+
+// wireString returns the Kotlin expression writing the value src of field as a
+// path, query or header string, in its JSON form.
+func wireString(file *protogen.File, field *protogen.Field, src string) string {
+	if field.Enum != nil {
+		if isEnumNumberEncoded(field) {
+			return src + ".number.toString()"
+		}
+		return "json.encodeToJsonElement(" + enumRef(file, field.Enum) + ".serializer(), " + src +
+			").jsonPrimitive.content"
+	}
+	if kotlinScalarType(file, field) == ktString {
+		return src
+	}
+	return src + ".toString()"
+}
+
+// nonZeroCondition returns the Kotlin condition holding when the singular
+// value src of field is not its proto3 zero value, which is not sent.
+func nonZeroCondition(file *protogen.File, field *protogen.Field, src string) string {
+	if field.Enum != nil {
+		return src + ".number != 0"
+	}
+	switch kotlinScalarType(file, field) {
+	case ktBoolean:
+		return src
+	case ktString:
+		if isInt64Kind(field) {
+			return src + `.isNotEmpty() && ` + src + ` != "0"`
+		}
+		return src + ".isNotEmpty()"
+	}
+	return src + " != " + kotlinFieldDefault(file, field)
+}
+
+// methodConfig captures every detail of an RPC method needed for generation.
+type methodConfig struct {
+	httpMethod  string
+	fullPath    string
+	pathParams  []string
+	queryParams []annotations.QueryParam // sent in the URL query string
+	hasBody     bool
+	isSSE       bool
+	// headerParams holds the request fields declared with source HEADER.
+	headerParams []annotations.HeaderFieldParam
+	// bodyExcluded holds the request fields declared with a non-body source.
+	bodyExcluded []*protogen.Field
+}
+
+func buildMethodConfig(service *protogen.Service, method *protogen.Method) *methodConfig {
+	methodName := string(method.Desc.Name())
+	httpConfig := annotations.GetMethodHTTPConfig(method)
+
+	httpMethod := http.MethodPost
+	httpPath := "/" + annotations.LowerFirst(methodName)
+	var pathParams []string
+
+	if httpConfig != nil {
+		if httpConfig.Method != "" {
+			httpMethod = httpConfig.Method
+		}
+		if httpConfig.Path != "" {
+			httpPath = httpConfig.Path
+		}
+		pathParams = httpConfig.PathParams
+	}
+
+	// The constructor appends a base path with parameters to the base URL.
+	basePath := annotations.GetServiceBasePath(service)
+	if len(annotations.GetBasePathParams(service)) > 0 {
+		basePath = ""
+	}
+	fullPath := annotations.BuildHTTPPath(basePath, httpPath)
+
+	isSSE := httpConfig != nil && httpConfig.Stream
+	hasBody := httpMethod == http.MethodPost || httpMethod == http.MethodPut || httpMethod == http.MethodPatch
+
+	return &methodConfig{
+		httpMethod:  httpMethod,
+		fullPath:    fullPath,
+		pathParams:  pathParams,
+		queryParams: annotations.GetURLQueryParams(method.Input, hasBody),
+		hasBody:     hasBody,
+		isSSE:       isSSE,
+
+		headerParams: annotations.GetHeaderFieldParams(method.Input),
+		bodyExcluded: annotations.GetBodyExcludedFields(method.Input),
+	}
+}
+
+// headerOptionName converts an HTTP header name to a Kotlin property name.
+// "X-API-Key" -> "apiKey", "X-Request-ID" -> "requestId". The request is
+// written with the canonical header name.
+func headerOptionName(headerName string) string {
+	name := strings.TrimPrefix(headerName, "X-")
+	name = strings.TrimPrefix(name, "x-")
+	name = strings.ToLower(strings.ReplaceAll(name, "-", "_"))
+	return escapeKtKeyword(lowerCamel(name))
+}
+
+// headerOptionType is the type of the property setting a header: a list of
+// values for a multiple header.
+func headerOptionType(header *sebufhttp.Header) string {
+	if header.GetMultiple() {
+		return "List<String>?"
+	}
+	return "String?"
+}
+
+// headerValueExpr is the expression sending the property value `it` as a
+// header value, joining the values of a multiple header into one line.
+func headerValueExpr(header *sebufhttp.Header) string {
+	if header.GetMultiple() {
+		return `it.joinToString(", ")`
+	}
+	return "it"
+}
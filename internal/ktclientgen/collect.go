@@ -0,0 +1,87 @@
+package ktclientgen
+
+import (
+	"sort"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// collectedTypes captures every message and enum declared in a file, nested
+// declarations included.
+//
+// Unlike the Python generator, types imported from other files are not copied
+// into the file: Kotlin resolves them by their package, and a second
+// declaration of the same class in one package would not compile.
+type collectedTypes struct {
+	messages map[string]*protogen.Message
+	enums    map[string]*protogen.Enum
+}
+
+func collectFileTypes(file *protogen.File) *collectedTypes {
+	c := &collectedTypes{
+		messages: make(map[string]*protogen.Message),
+		enums:    make(map[string]*protogen.Enum),
+	}
+	for _, msg := range file.Messages {
+		c.addMessage(msg)
+	}
+	for _, enum := range file.Enums {
+		c.addEnum(enum)
+	}
+	return c
+}
+
+func (c *collectedTypes) addMessage(msg *protogen.Message) {
+	if msg.Desc.IsMapEntry() {
+		// Map entries are synthetic: the parent field becomes a Map.
+		return
+	}
+	c.messages[kotlinTypeName(msg)] = msg
+	for _, nested := range msg.Messages {
+		c.addMessage(nested)
+	}
+	for _, nestedEnum := range msg.Enums {
+		c.addEnum(nestedEnum)
+	}
+}
+
+func (c *collectedTypes) addEnum(enum *protogen.Enum) {
+	c.enums[kotlinEnumName(enum)] = enum
+}
+
+// OrderedMessages returns messages sorted by their Kotlin class name for
+// deterministic output.
+func (c *collectedTypes) OrderedMessages() []*protogen.Message {
+	out := make([]*protogen.Message, 0, len(c.messages))
+	for _, msg := range c.messages {
+		out = append(out, msg)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return kotlinTypeName(out[i]) < kotlinTypeName(out[j])
+	})
+	return out
+}
+
+// OrderedEnums returns enums sorted by Kotlin class name.
+func (c *collectedTypes) OrderedEnums() []*protogen.Enum {
+	out := make([]*protogen.Enum, 0, len(c.enums))
+	for _, e := range c.enums {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return kotlinEnumName(out[i]) < kotlinEnumName(out[j])
+	})
+	return out
+}
+
+// kotlinTypeName returns the Kotlin class name for a proto message. Nested
+// messages are flattened with underscore separation (Outer.Inner -> Outer_Inner),
+// the Go and Python convention, so every class is top-level.
+func kotlinTypeName(msg *protogen.Message) string {
+	return msg.GoIdent.GoName
+}
+
+// kotlinEnumName returns the Kotlin class name for a proto enum.
+func kotlinEnumName(enum *protogen.Enum) string {
+	return enum.GoIdent.GoName
+}
@@ -0,0 +1,46 @@
+package ktclientgen
+
+// Kotlin type-name constants, centralised to satisfy goconst's complaint about
+// repeated string literals.
+const (
+	ktString  = "String"
+	ktInt     = "Int"
+	ktUInt    = "UInt"
+	ktLong    = "Long"
+	ktULong   = "ULong"
+	ktBoolean = "Boolean"
+	ktDouble  = "Double"
+	ktFloat   = "Float"
+
+	ktJSONObject  = "JsonObject"
+	ktJSONElement = "JsonElement"
+	ktJSONArray   = "JsonArray"
+
+	ktNull = "null"
+)
+
+// Well-known type proto names. These are the FullName() strings we match
+// against to apply the WKT-specific Kotlin representation.
+const (
+	wktTimestamp   = "google.protobuf.Timestamp"
+	wktDuration    = "google.protobuf.Duration"
+	wktAny         = "google.protobuf.Any"
+	wktFieldMask   = "google.protobuf.FieldMask"
+	wktEmpty       = "google.protobuf.Empty"
+	wktStruct      = "google.protobuf.Struct"
+	wktValue       = "google.protobuf.Value"
+	wktListValue   = "google.protobuf.ListValue"
+	wktStringValue = "google.protobuf.StringValue"
+	wktBoolValue   = "google.protobuf.BoolValue"
+	wktInt32Value  = "google.protobuf.Int32Value"
+	wktUInt32Value = "google.protobuf.UInt32Value"
+	wktInt64Value  = "google.protobuf.Int64Value"
+	wktUInt64Value = "google.protobuf.UInt64Value"
+	wktFloatValue  = "google.protobuf.FloatValue"
+	wktDoubleValue = "google.protobuf.DoubleValue"
+	wktBytesValue  = "google.protobuf.BytesValue"
+)
+
+// int64NumberWarning is the comment placed above int64 fields encoded as JSON
+// numbers.
+const int64NumberWarning = "// Warning: int64_encoding=NUMBER; values > 2^53 may lose precision in JavaScript."
@@ -0,0 +1,58 @@
+package ktclientgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// writeEnum emits a @Serializable Kotlin enum class for the given proto enum.
+// Each constant carries its proto number, and a `(sebuf.http.enum_value)`
+// mapping becomes the constant's @SerialName so the custom JSON string is what
+// goes on the wire. A sibling NumberSerializer encodes the enum as its number
+// for fields with enum_encoding=NUMBER; it is always emitted, so fields in other
+// files can use it too.
+func writeEnum(p printer, enum *protogen.Enum) {
+	name := kotlinEnumName(enum)
+
+	p("/** Generated from proto enum %s. */", enum.Desc.FullName())
+	p("@Serializable")
+	p("enum class %s(val number: Int) {", name)
+	for _, value := range enum.Values {
+		if override := annotations.GetEnumValueMapping(value); override != "" {
+			p("    @SerialName(%s)", kotlinStringLiteral(override))
+		}
+		p("    %s(%d),", enumConstantName(value), value.Desc.Number())
+	}
+	p("}")
+	p("")
+	writeEnumNumberSerializer(p, enum)
+}
+
+// writeEnumNumberSerializer emits the `<Enum>NumberSerializer` object. Unknown
+// numbers decode to the enum's first value, the proto3 default.
+func writeEnumNumberSerializer(p printer, enum *protogen.Enum) {
+	name := kotlinEnumName(enum)
+	p("/** Encodes [%s] as its proto number, for fields with enum_encoding=NUMBER. */", name)
+	p("object %sNumberSerializer : KSerializer<%s> {", name, name)
+	p("    override val descriptor: SerialDescriptor =")
+	p(`        PrimitiveSerialDescriptor("%s.Number", PrimitiveKind.INT)`, enum.Desc.FullName())
+	p("")
+	p("    override fun serialize(encoder: Encoder, value: %s) {", name)
+	p("        encoder.encodeInt(value.number)")
+	p("    }")
+	p("")
+	p("    override fun deserialize(decoder: Decoder): %s {", name)
+	p("        val number = decoder.decodeInt()")
+	p("        return %s.entries.firstOrNull { it.number == number } ?: %s.entries.first()", name, name)
+	p("    }")
+	p("}")
+	p("")
+}
+
+// enumConstantName returns the Kotlin constant name for an enum value. The
+// proto value name (e.g. PRIORITY_HIGH) is kept verbatim so that the default
+// JSON form matches protojson's.
+func enumConstantName(value *protogen.EnumValue) string {
+	return escapeKtKeyword(string(value.Desc.Name()))
+}
@@ -0,0 +1,120 @@
+// Package ktclientgen generates Kotlin/JVM HTTP clients from protobuf service definitions.
+//
+// The generated code depends on kotlinx.serialization, kotlinx.coroutines and
+// OkHttp, the libraries Android apps already ship. Users may pass their own
+// OkHttpClient (interceptors, timeouts, certificate pinning) via client options.
+//
+// The generator emits one Kotlin file per .proto source containing:
+//   - @Serializable data classes for each message
+//   - @Serializable enum classes for each enum
+//   - An ApiException hierarchy (ApiException, ValidationException), once per package
+//   - One client class per service with suspend functions and typed options
+//
+// Server-Sent Events methods are detected and emit stubs that throw NotImplementedError.
+package ktclientgen
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// Generator produces Kotlin HTTP client code for protobuf services.
+type Generator struct {
+	plugin *protogen.Plugin
+	// runtimePackages records the Kotlin packages whose shared runtime
+	// declarations were already emitted in this run.
+	runtimePackages map[string]bool
+}
+
+// New creates a Kotlin client generator.
+func New(plugin *protogen.Plugin) *Generator {
+	return &Generator{plugin: plugin, runtimePackages: make(map[string]bool)}
+}
+
+// Generate iterates all input files and emits a {file}_client.kt per source.
+func (g *Generator) Generate() error {
+	if err := annotations.ValidateSyntax(g.plugin); err != nil {
+		return err
+	}
+	if err := annotations.ApplyVisibility(g.plugin, annotations.GeneratorKtClient); err != nil {
+		return err
+	}
+	for _, file := range g.plugin.Files {
+		if !file.Generate {
+			continue
+		}
+		if err := g.generateFile(file); err != nil {
+			return fmt.Errorf("ktclientgen: %s: %w", file.Desc.Path(), err)
+		}
+	}
+	return nil
+}
+
+func (g *Generator) generateFile(file *protogen.File) error {
+	if len(file.Services) == 0 && !hasGeneratableTypes(file) {
+		return nil
+	}
+	return g.generateClientFile(file)
+}
+
+// hasGeneratableTypes returns true if the file has messages or enums worth emitting
+// even when no service is declared.
+func hasGeneratableTypes(file *protogen.File) bool {
+	return len(file.Messages) > 0 || len(file.Enums) > 0
+}
+
+func (g *Generator) generateClientFile(file *protogen.File) error {
+	for _, service := range file.Services {
+		if err := annotations.ValidateServiceHeaders(service); err != nil {
+			return err
+		}
+		if err := annotations.ValidateBasePathParams(service); err != nil {
+			return err
+		}
+		if err := annotations.ValidateScalarQueryParams(service, "protoc-gen-kt-client"); err != nil {
+			return err
+		}
+		if err := annotations.ValidateBodylessRequestFields(service); err != nil {
+			return err
+		}
+	}
+
+	filename := file.GeneratedFilenamePrefix + "_client.kt"
+	gf := g.plugin.NewGeneratedFile(filename, "")
+
+	p := newPrinter(gf)
+
+	collected := collectFileTypes(file)
+	pkg := kotlinPackage(file)
+
+	writeHeader(p, file)
+	writeImports(p, pkg)
+
+	// ApiException and friends are public top-level declarations, which Kotlin
+	// allows only once per package: the first file of the package with a
+	// service carries them.
+	if len(file.Services) > 0 && !g.runtimePackages[pkg] {
+		g.runtimePackages[pkg] = true
+		writeRuntime(p)
+	}
+
+	for _, enum := range collected.OrderedEnums() {
+		writeEnum(p, enum)
+	}
+	for _, msg := range collected.OrderedMessages() {
+		writeMessage(p, file, msg)
+	}
+
+	if len(file.Services) > 0 {
+		p("private val jsonMediaType = \"application/json\".toMediaType()")
+		p("")
+	}
+	for _, service := range file.Services {
+		writeServiceClient(p, file, service)
+	}
+
+	return nil
+}
@@ -0,0 +1,270 @@
+package ktclientgen
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestKtClientGenGoldenFiles tests Kotlin client generation against golden files.
+// This ensures any changes to code generation are intentional and reviewed.
+//
+// To update golden files after intentional changes:
+//
+//	UPDATE_GOLDEN=1 go test -run TestKtClientGenGoldenFiles
+func TestKtClientGenGoldenFiles(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping golden file tests")
+	}
+
+	testCases := []struct {
+		name          string
+		protoFile     string
+		expectedFiles []string
+	}{
+		{
+			name:      "restful CRUD",
+			protoFile: "restful_crud.proto",
+			expectedFiles: []string{
+				"restful_crud_client.kt",
+			},
+		},
+		{
+			name:      "int64 encoding",
+			protoFile: "int64_encoding.proto",
+			expectedFiles: []string{
+				"int64_encoding_client.kt",
+			},
+		},
+		{
+			name:      "enum encoding",
+			protoFile: "enum_encoding.proto",
+			expectedFiles: []string{
+				"enum_encoding_client.kt",
+			},
+		},
+		{
+			name:      "nullable fields",
+			protoFile: "nullable.proto",
+			expectedFiles: []string{
+				"nullable_client.kt",
+			},
+		},
+		{
+			name:      "path wildcards",
+			protoFile: "path_wildcard.proto",
+			expectedFiles: []string{
+				"path_wildcard_client.kt",
+			},
+		},
+		{
+			name:      "edition 2023",
+			protoFile: "editions.proto",
+			expectedFiles: []string{
+				"editions_client.kt",
+			},
+		},
+		{
+			name:      "proto2 syntax",
+			protoFile: "proto2_syntax.proto",
+			expectedFiles: []string{
+				"proto2_syntax_client.kt",
+			},
+		},
+	}
+
+	projectRoot, protoDir, goldenDir := testDirs(t)
+	pluginPath := buildPlugin(t, projectRoot)
+
+	tempDir := t.TempDir()
+
+	updateGolden := os.Getenv("UPDATE_GOLDEN") == "1"
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			protoPath := filepath.Join(protoDir, tc.protoFile)
+
+			_, statErr := os.Stat(protoPath)
+			if os.IsNotExist(statErr) {
+				t.Fatalf("Proto file not found: %s", protoPath)
+			}
+
+			cmd := exec.Command("protoc",
+				"--plugin=protoc-gen-kt-client="+pluginPath,
+				"--kt-client_out="+tempDir,
+				"--kt-client_opt=paths=source_relative",
+				"--proto_path="+protoDir,
+				"--proto_path="+filepath.Join(projectRoot, "proto"),
+				tc.protoFile,
+			)
+			cmd.Dir = protoDir
+
+			var stderr bytes.Buffer
+			cmd.Stderr = &stderr
+
+			runErr := cmd.Run()
+			if runErr != nil {
+				t.Fatalf("protoc failed: %v\nstderr: %s", runErr, stderr.String())
+			}
+
+			for _, expectedFile := range tc.expectedFiles {
+				generatedPath := filepath.Join(tempDir, expectedFile)
+				goldenPath := filepath.Join(goldenDir, expectedFile)
+
+				generatedContent, readErr := os.ReadFile(generatedPath)
+				if readErr != nil {
+					t.Fatalf("Failed to read generated file %s: %v", generatedPath, readErr)
+				}
+
+				if updateGolden {
+					updateGoldenFile(t, goldenPath, generatedContent)
+					continue
+				}
+				compareGoldenFile(t, expectedFile, goldenPath, generatedContent)
+			}
+		})
+	}
+}
+
+// TestKtClientGenGoldenFilesCompile compiles every golden file with kotlinc, so
+// a golden captured with invalid Kotlin cannot slip through review. It needs
+// kotlinc on PATH and KT_CLIENT_CLASSPATH listing the kotlinx-serialization-json,
+// kotlinx-coroutines-core, okhttp and okio jars; CI provides both, and the test
+// is skipped when either is missing.
+func TestKtClientGenGoldenFilesCompile(t *testing.T) {
+	kotlinc, err := exec.LookPath("kotlinc")
+	if err != nil {
+		t.Skip("kotlinc not found, skipping Kotlin compilation")
+	}
+	classpath := os.Getenv("KT_CLIENT_CLASSPATH")
+	if classpath == "" {
+		t.Skip("KT_CLIENT_CLASSPATH not set, skipping Kotlin compilation")
+	}
+	// The serialization compiler plugin ships with the compiler distribution.
+	home, err := filepath.EvalSymlinks(kotlinc)
+	if err != nil {
+		t.Fatalf("Failed to resolve kotlinc: %v", err)
+	}
+	plugin := filepath.Join(filepath.Dir(home), "..", "lib", "kotlinx-serialization-compiler-plugin.jar")
+	if _, statErr := os.Stat(plugin); statErr != nil {
+		t.Skipf("kotlinx-serialization compiler plugin not found at %s", plugin)
+	}
+
+	_, _, goldenDir := testDirs(t)
+	sources, err := filepath.Glob(filepath.Join(goldenDir, "*.kt"))
+	if err != nil || len(sources) == 0 {
+		t.Fatalf("No golden Kotlin files in %s: %v", goldenDir, err)
+	}
+
+	args := []string{"-Xplugin=" + plugin, "-classpath", classpath, "-d", t.TempDir()}
+	cmd := exec.Command(kotlinc, append(args, sources...)...)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if runErr := cmd.Run(); runErr != nil {
+		t.Fatalf("kotlinc failed: %v\n%s", runErr, output.String())
+	}
+}
+
+// testDirs returns the project root and the proto and golden directories of
+// the package.
+func testDirs(t *testing.T) (string, string, string) {
+	t.Helper()
+	baseDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	return filepath.Join(baseDir, "..", ".."),
+		filepath.Join(baseDir, "testdata", "proto"),
+		filepath.Join(baseDir, "testdata", "golden")
+}
+
+// buildPlugin returns the path of the plugin binary, building it with make
+// when missing.
+func buildPlugin(t *testing.T, projectRoot string) string {
+	t.Helper()
+	pluginPath := filepath.Join(projectRoot, "bin", "protoc-gen-kt-client")
+	if _, statErr := os.Stat(pluginPath); os.IsNotExist(statErr) {
+		buildCmd := exec.Command("make", "build")
+		buildCmd.Dir = projectRoot
+		if buildErr := buildCmd.Run(); buildErr != nil {
+			t.Fatalf("Failed to build plugin: %v", buildErr)
+		}
+	}
+	return pluginPath
+}
+
+func updateGoldenFile(t *testing.T, goldenPath string, content []byte) {
+	t.Helper()
+	writeErr := os.WriteFile(goldenPath, content, 0o644)
+	if writeErr != nil {
+		t.Fatalf("Failed to write golden file %s: %v", goldenPath, writeErr)
+	}
+	t.Logf("Updated golden file: %s", goldenPath)
+}
+
+func compareGoldenFile(t *testing.T, expectedFile, goldenPath string, generatedContent []byte) {
+	t.Helper()
+	goldenContent, goldenReadErr := os.ReadFile(goldenPath)
+	if goldenReadErr != nil {
+		if os.IsNotExist(goldenReadErr) {
+			t.Fatalf("Golden file not found: %s\nRun with UPDATE_GOLDEN=1 to create it", goldenPath)
+		}
+		t.Fatalf("Failed to read golden file %s: %v", goldenPath, goldenReadErr)
+	}
+
+	if !bytes.Equal(generatedContent, goldenContent) {
+		t.Errorf("Generated file %s does not match golden file.\n"+
+			"Run with UPDATE_GOLDEN=1 to update golden files after reviewing changes.\n"+
+			"Diff:\n%s",
+			expectedFile,
+			diffStrings(string(goldenContent), string(generatedContent)))
+	}
+}
+
+func diffStrings(expected, actual string) string {
+	expectedLines := strings.Split(expected, "\n")
+	actualLines := strings.Split(actual, "\n")
+
+	var diff strings.Builder
+	maxLines := len(expectedLines)
+	if len(actualLines) > maxLines {
+		maxLines = len(actualLines)
+	}
+
+	diffCount := 0
+	const maxDiffs = 20
+
+	for i := 0; i < maxLines && diffCount < maxDiffs; i++ {
+		var expLine, actLine string
+		if i < len(expectedLines) {
+			expLine = expectedLines[i]
+		}
+		if i < len(actualLines) {
+			actLine = actualLines[i]
+		}
+
+		if expLine != actLine {
+			diff.WriteString("Line ")
+			diff.WriteRune(rune('0' + i/100))
+			diff.WriteRune(rune('0' + (i/10)%10))
+			diff.WriteRune(rune('0' + i%10))
+			diff.WriteString(":\n")
+			diff.WriteString("  expected: ")
+			diff.WriteString(expLine)
+			diff.WriteString("\n  actual:   ")
+			diff.WriteString(actLine)
+			diff.WriteString("\n")
+			diffCount++
+		}
+	}
+
+	if diffCount >= maxDiffs {
+		diff.WriteString("... (more differences truncated)\n")
+	}
+
+	return diff.String()
+}
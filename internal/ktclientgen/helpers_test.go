@@ -0,0 +1,96 @@
+package ktclientgen
+
+import "testing"
+
+func TestLowerCamel(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"product_id", "productId"},
+		{"stock_quantity", "stockQuantity"},
+		{"id", "id"},
+		{"created_at_unix_ms", "createdAtUnixMs"},
+		// Doubled and trailing underscores collapse.
+		{"user__name_", "userName"},
+		// Names already in camelCase keep their humps.
+		{"userId", "userId"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := lowerCamel(tt.input); got != tt.want {
+				t.Errorf("lowerCamel(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeaderOptionName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"X-API-Key", "apiKey"},
+		{"X-Request-ID", "requestId"},
+		{"X-Confirm-Delete", "confirmDelete"},
+		{"Authorization", "authorization"},
+		// Lowercase x- prefix should also be stripped.
+		{"x-tenant-id", "tenantId"},
+		// A header name that camel-cases into a Kotlin keyword must escape.
+		{"X-Object", "`object`"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := headerOptionName(tt.input); got != tt.want {
+				t.Errorf("headerOptionName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeKtKeyword(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"object", "`object`"},
+		{"in", "`in`"},
+		{"val", "`val`"},
+		// Soft keywords are valid identifiers and pass through.
+		{"data", "data"},
+		{"value", "value"},
+		{"name", "name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := escapeKtKeyword(tt.input); got != tt.want {
+				t.Errorf("escapeKtKeyword(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKotlinStringLiteral(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"/api/v1/products/{product_id}", `"/api/v1/products/{product_id}"`},
+		// A $ would start a string template.
+		{"$ref", `"\$ref"`},
+		{`say "hi"\n`, `"say \"hi\"\\n"`},
+		{"line\nbreak", `"line\nbreak"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := kotlinStringLiteral(tt.input); got != tt.want {
+				t.Errorf("kotlinStringLiteral(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package ktclientgen
+
+// writeImports emits the package clause and the import block. Imports are
+// stable regardless of file content to keep golden tests simple; unused imports
+// are only a warning in Kotlin.
+func writeImports(p printer, pkg string) {
+	p("@file:OptIn(ExperimentalSerializationApi::class)")
+	p("")
+	if pkg != "" {
+		p("package %s", pkg)
+		p("")
+	}
+	p("import java.io.IOException")
+	p("import java.net.URLEncoder")
+	p("import java.util.concurrent.TimeUnit")
+	p("import kotlin.coroutines.resume")
+	p("import kotlin.coroutines.resumeWithException")
+	p("import kotlinx.coroutines.flow.Flow")
+	p("import kotlinx.coroutines.suspendCancellableCoroutine")
+	p("import kotlinx.serialization.DeserializationStrategy")
+	p("import kotlinx.serialization.EncodeDefault")
+	p("import kotlinx.serialization.ExperimentalSerializationApi")
+	p("import kotlinx.serialization.KSerializer")
+	p("import kotlinx.serialization.SerialName")
+	p("import kotlinx.serialization.Serializable")
+	p("import kotlinx.serialization.descriptors.PrimitiveKind")
+	p("import kotlinx.serialization.descriptors.PrimitiveSerialDescriptor")
+	p("import kotlinx.serialization.descriptors.SerialDescriptor")
+	p("import kotlinx.serialization.encoding.Decoder")
+	p("import kotlinx.serialization.encoding.Encoder")
+	p("import kotlinx.serialization.json.Json")
+	p("import kotlinx.serialization.json.JsonArray")
+	p("import kotlinx.serialization.json.JsonElement")
+	p("import kotlinx.serialization.json.JsonObject")
+	p("import kotlinx.serialization.json.jsonObject")
+	p("import kotlinx.serialization.json.jsonPrimitive")
+	p("import okhttp3.Call")
+	p("import okhttp3.Callback")
+	p("import okhttp3.Headers")
+	p("import okhttp3.HttpUrl")
+	p("import okhttp3.HttpUrl.Companion.toHttpUrl")
+	p("import okhttp3.MediaType.Companion.toMediaType")
+	p("import okhttp3.OkHttpClient")
+	p("import okhttp3.Request")
+	p("import okhttp3.RequestBody")
+	p("import okhttp3.RequestBody.Companion.toRequestBody")
+	p("import okhttp3.Response")
+	p("")
+}
@@ -0,0 +1,48 @@
+package ktclientgen
+
+// ktKeywords lists every Kotlin hard keyword. Identifiers colliding with any
+// entry are escaped with backticks, which keeps the name unchanged on the JVM.
+//
+// Source: https://kotlinlang.org/docs/keyword-reference.html#hard-keywords
+//
+//nolint:gochecknoglobals // intentional constant lookup table
+var ktKeywords = map[string]bool{
+	"as":        true,
+	"break":     true,
+	"class":     true,
+	"continue":  true,
+	"do":        true,
+	"else":      true,
+	"false":     true,
+	"for":       true,
+	"fun":       true,
+	"if":        true,
+	"in":        true,
+	"interface": true,
+	"is":        true,
+	"null":      true,
+	"object":    true,
+	"package":   true,
+	"return":    true,
+	"super":     true,
+	"this":      true,
+	"throw":     true,
+	"true":      true,
+	"try":       true,
+	"typealias": true,
+	"typeof":    true,
+	"val":       true,
+	"var":       true,
+	"when":      true,
+	"while":     true,
+}
+
+// escapeKtKeyword returns the identifier, or the identifier in backticks when
+// it collides with a Kotlin hard keyword. The JSON name is carried separately
+// by @SerialName, so escaping never changes the wire format.
+func escapeKtKeyword(name string) string {
+	if ktKeywords[name] {
+		return "`" + name + "`"
+	}
+	return name
+}
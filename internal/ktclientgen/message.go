@@ -0,0 +1,51 @@
+package ktclientgen
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// writeMessage emits a @Serializable Kotlin data class for a single proto
+// message. Every property carries its JSON name in @SerialName and defaults to
+// the proto3 zero value, so kotlinx.serialization reads and writes the same
+// JSON as protojson.
+func writeMessage(p printer, file *protogen.File, msg *protogen.Message) {
+	className := kotlinTypeName(msg)
+
+	p("/** Generated from proto message %s. */", msg.Desc.FullName())
+	p("@Serializable")
+	if len(msg.Fields) == 0 {
+		// A data class needs at least one property.
+		p("class %s", className)
+		p("")
+		return
+	}
+	p("data class %s(", className)
+	for _, field := range msg.Fields {
+		writeProperty(p, file, field)
+	}
+	p(")")
+	p("")
+}
+
+func writeProperty(p printer, file *protogen.File, field *protogen.Field) {
+	if isInt64Kind(field) && annotations.IsInt64NumberEncoding(field) {
+		p("    %s", int64NumberWarning)
+	}
+	modifiers := []string{"@SerialName(" + kotlinStringLiteral(annotations.JSONFieldName(field)) + ")"}
+	// A nullable field is sent as an explicit null when unset.
+	if annotations.IsNullableField(field) {
+		modifiers = append(modifiers, "@EncodeDefault")
+	}
+	if isEnumNumberEncoded(field) && !field.Desc.IsList() {
+		modifiers = append(modifiers, "@Serializable(with = "+enumSerializerRef(file, field.Enum)+"::class)")
+	}
+	p("    %s val %s: %s = %s,",
+		strings.Join(modifiers, " "),
+		kotlinPropertyName(field),
+		kotlinFieldType(file, field),
+		kotlinFieldDefault(file, field))
+}
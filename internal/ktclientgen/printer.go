@@ -0,0 +1,27 @@
+package ktclientgen
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// printer is a thin wrapper around protogen.GeneratedFile.P with fmt.Sprintf semantics.
+// Mirrors the helper used by pyclientgen so generator code stays compact.
+type printer func(format string, args ...interface{})
+
+func newPrinter(gf *protogen.GeneratedFile) printer {
+	return func(format string, args ...interface{}) {
+		if len(args) == 0 {
+			gf.P(format)
+			return
+		}
+		gf.P(fmt.Sprintf(format, args...))
+	}
+}
+
+func writeHeader(p printer, file *protogen.File) {
+	p("// Code generated by protoc-gen-kt-client. DO NOT EDIT.")
+	p("// source: %s", file.Desc.Path())
+	p("")
+}
@@ -0,0 +1,68 @@
+package ktclientgen
+
+// writeRuntime emits the declarations every generated client of a package
+// shares: the exception hierarchy thrown for non-2xx responses and the internal
+// helpers bridging OkHttp callbacks to coroutines.
+func writeRuntime(p printer) {
+	p("/** Thrown by generated clients for every non-2xx response. */")
+	p("open class ApiException(")
+	p("    val status: Int,")
+	p("    val body: String,")
+	p("    val headers: Map<String, List<String>>,")
+	p(`) : IOException("HTTP $status: ${body.take(200)}")`)
+	p("")
+	p("/** Thrown for 400 responses carrying the field violations of a rejected request. */")
+	p("class ValidationException(")
+	p("    status: Int,")
+	p("    body: String,")
+	p("    headers: Map<String, List<String>>,")
+	p("    val violations: List<FieldViolation>,")
+	p(") : ApiException(status, body, headers)")
+	p("")
+	p("/** One invalid field of a request rejected with a ValidationException. */")
+	p("@Serializable")
+	p("data class FieldViolation(")
+	p(`    val field: String = "",`)
+	p(`    val description: String = "",`)
+	p(")")
+	p("")
+	p("@Serializable")
+	p("private class ValidationErrorBody(")
+	p("    val violations: List<FieldViolation> = emptyList(),")
+	p(")")
+	p("")
+	p("/** Returns the most specific exception describing a non-2xx response. */")
+	p("internal fun apiException(")
+	p("    status: Int,")
+	p("    body: String,")
+	p("    headers: Map<String, List<String>>,")
+	p("    json: Json,")
+	p("): ApiException {")
+	p(`    if (status == 400 && body.contains("\"violations\"")) {`)
+	p("        val parsed = runCatching { json.decodeFromString(ValidationErrorBody.serializer(), body) }.getOrNull()")
+	p("        if (parsed != null) {")
+	p("            return ValidationException(status, body, headers, parsed.violations)")
+	p("        }")
+	p("    }")
+	p("    return ApiException(status, body, headers)")
+	p("}")
+	p("")
+	p("/** Sends the call on OkHttp's dispatcher, cancelling it with the coroutine. */")
+	p("internal suspend fun Call.await(): Response = suspendCancellableCoroutine { continuation ->")
+	p("    continuation.invokeOnCancellation { cancel() }")
+	p("    enqueue(object : Callback {")
+	p("        override fun onResponse(call: Call, response: Response) {")
+	p("            continuation.resume(response)")
+	p("        }")
+	p("")
+	p("        override fun onFailure(call: Call, e: IOException) {")
+	p("            continuation.resumeWithException(e)")
+	p("        }")
+	p("    })")
+	p("}")
+	p("")
+	p("/** Percent-encodes a path parameter value. */")
+	p("internal fun encodePathSegment(value: String): String =")
+	p(`    URLEncoder.encode(value, "UTF-8").replace("+", "%20")`)
+	p("")
+}
@@ -0,0 +1,297 @@
+package ktclientgen
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// kotlinPackage returns the Kotlin package of a generated file: its
+// java_package option when set, the proto package otherwise.
+func kotlinPackage(file *protogen.File) string {
+	return kotlinPackageOf(file.Desc)
+}
+
+func kotlinPackageOf(file protoreflect.FileDescriptor) string {
+	pkg := string(file.Package())
+	if opts, ok := file.Options().(*descriptorpb.FileOptions); ok && opts.GetJavaPackage() != "" {
+		pkg = opts.GetJavaPackage()
+	}
+	if pkg == "" {
+		return ""
+	}
+	segments := strings.Split(pkg, ".")
+	for i, segment := range segments {
+		segments[i] = escapeKtKeyword(segment)
+	}
+	return strings.Join(segments, ".")
+}
+
+// qualify returns name as referenced from file: bare in its own package,
+// fully qualified otherwise.
+func qualify(file *protogen.File, decl protoreflect.FileDescriptor, name string) string {
+	pkg := kotlinPackageOf(decl)
+	if pkg == "" || pkg == kotlinPackage(file) {
+		return name
+	}
+	return pkg + "." + name
+}
+
+// messageRef returns the Kotlin type of a message as referenced from file.
+func messageRef(file *protogen.File, msg *protogen.Message) string {
+	return qualify(file, msg.Desc.ParentFile(), kotlinTypeName(msg))
+}
+
+// enumRef returns the Kotlin type of an enum as referenced from file.
+func enumRef(file *protogen.File, enum *protogen.Enum) string {
+	return qualify(file, enum.Desc.ParentFile(), kotlinEnumName(enum))
+}
+
+// rpcMessageType returns the Kotlin type of a method's request or response.
+// Well-known types have no generated class and decode as plain JSON.
+func rpcMessageType(file *protogen.File, msg *protogen.Message) string {
+	switch msg.Desc.FullName() {
+	case wktEmpty, wktStruct, wktAny:
+		return ktJSONObject
+	}
+	if isWellKnownMessage(msg) {
+		return ktJSONElement
+	}
+	return messageRef(file, msg)
+}
+
+// kotlinScalarType maps a field's kind to its Kotlin type, without the
+// List/Map/nullable modifiers. int64 kinds are Strings, matching protojson's
+// JavaScript-safe encoding, unless int64_encoding=NUMBER makes them Longs.
+func kotlinScalarType(file *protogen.File, field *protogen.Field) string {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return ktBoolean
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return ktString
+	case protoreflect.DoubleKind:
+		return ktDouble
+	case protoreflect.FloatKind:
+		return ktFloat
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return ktInt
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return ktUInt
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if annotations.IsInt64NumberEncoding(field) {
+			return ktLong
+		}
+		return ktString
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if annotations.IsInt64NumberEncoding(field) {
+			return ktULong
+		}
+		return ktString
+	case protoreflect.EnumKind:
+		return enumRef(file, field.Enum)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if isWellKnown(field) {
+			return wellKnownKotlinType(field)
+		}
+		return messageRef(file, field.Message)
+	default:
+		return ktJSONElement
+	}
+}
+
+// kotlinFieldType returns the Kotlin type of a data class property, accounting
+// for repeated/map/nullable modifiers.
+func kotlinFieldType(file *protogen.File, field *protogen.Field) string {
+	if field.Desc.IsMap() {
+		key := kotlinScalarType(file, field.Message.Fields[0])
+		value := kotlinScalarType(file, field.Message.Fields[1])
+		return fmt.Sprintf("Map<%s, %s>", key, value)
+	}
+	base := kotlinScalarType(file, field)
+	if field.Desc.IsList() {
+		if isEnumNumberEncoded(field) {
+			return fmt.Sprintf("List<@Serializable(with = %s::class) %s>", enumSerializerRef(file, field.Enum), base)
+		}
+		return fmt.Sprintf("List<%s>", base)
+	}
+	if isNullable(field) {
+		return base + "?"
+	}
+	return base
+}
+
+// isNullable reports whether a singular field is a nullable property: proto3
+// optional and oneof members, nullable-annotated fields, and message fields,
+// which are unset rather than empty by default.
+func isNullable(field *protogen.Field) bool {
+	if field.Desc.IsList() || field.Desc.IsMap() {
+		return false
+	}
+	if annotations.IsOptionalScalarField(field) || field.Oneof != nil || annotations.IsNullableField(field) {
+		return true
+	}
+	return field.Desc.Kind() == protoreflect.MessageKind
+}
+
+// kotlinFieldDefault returns the default value of a data class property. The
+// defaults are the proto3 zero values, which kotlinx.serialization leaves out
+// of the encoded JSON, as protojson does.
+func kotlinFieldDefault(file *protogen.File, field *protogen.Field) string {
+	if field.Desc.IsMap() {
+		return "emptyMap()"
+	}
+	if field.Desc.IsList() {
+		return "emptyList()"
+	}
+	if isNullable(field) {
+		return ktNull
+	}
+	switch kotlinScalarType(file, field) {
+	case ktBoolean:
+		return "false"
+	case ktString:
+		if isInt64Kind(field) {
+			return `"0"`
+		}
+		return `""`
+	case ktDouble:
+		return "0.0"
+	case ktFloat:
+		return "0.0f"
+	case ktInt:
+		return "0"
+	case ktUInt:
+		return "0u"
+	case ktLong:
+		return "0L"
+	case ktULong:
+		return "0uL"
+	}
+	if field.Enum != nil && len(field.Enum.Values) > 0 {
+		return enumRef(file, field.Enum) + "." + enumConstantName(field.Enum.Values[0])
+	}
+	return ktNull
+}
+
+// kotlinPropertyName returns the Kotlin property name for a field: its proto
+// name in lowerCamelCase. The JSON name is carried by @SerialName.
+func kotlinPropertyName(field *protogen.Field) string {
+	return escapeKtKeyword(lowerCamel(string(field.Desc.Name())))
+}
+
+// lowerCamel converts snake_case to lowerCamelCase.
+func lowerCamel(name string) string {
+	parts := strings.Split(name, "_")
+	var b strings.Builder
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+		if b.Len() == 0 {
+			b.WriteString(strings.ToLower(part[:1]) + part[1:])
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// isInt64Kind reports whether a field is a 64-bit integer.
+func isInt64Kind(field *protogen.Field) bool {
+	//nolint:exhaustive // only the 64-bit integer kinds matter
+	switch field.Desc.Kind() {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return true
+	default:
+		return false
+	}
+}
+
+// isEnumNumberEncoded reports whether an enum field is encoded as its number.
+func isEnumNumberEncoded(field *protogen.Field) bool {
+	return field.Enum != nil &&
+		annotations.ResolveEnumEncoding(field) == sebufhttp.EnumEncoding_ENUM_ENCODING_NUMBER
+}
+
+// enumSerializerRef returns the serializer encoding an enum as its number.
+func enumSerializerRef(file *protogen.File, enum *protogen.Enum) string {
+	return enumRef(file, enum) + "NumberSerializer"
+}
+
+// isWellKnown reports whether a message field references a google.protobuf WKT.
+func isWellKnown(field *protogen.Field) bool {
+	return field.Message != nil && isWellKnownMessage(field.Message)
+}
+
+func isWellKnownMessage(msg *protogen.Message) bool {
+	return strings.HasPrefix(string(msg.Desc.FullName()), "google.protobuf.")
+}
+
+// wellKnownKotlinType maps WKT proto names to the Kotlin type of their
+// protojson representation.
+func wellKnownKotlinType(field *protogen.Field) string {
+	switch field.Message.Desc.FullName() {
+	case wktTimestamp:
+		switch annotations.ResolveTimestampFormat(field) {
+		case sebufhttp.TimestampFormat_TIMESTAMP_FORMAT_UNIX_SECONDS,
+			sebufhttp.TimestampFormat_TIMESTAMP_FORMAT_UNIX_MILLIS:
+			return ktLong
+		default:
+			return ktString
+		}
+	case wktDuration, wktFieldMask, wktStringValue, wktBytesValue:
+		return ktString
+	case wktAny, wktEmpty, wktStruct:
+		return ktJSONObject
+	case wktListValue:
+		return ktJSONArray
+	case wktBoolValue:
+		return ktBoolean
+	case wktInt32Value:
+		return ktInt
+	case wktUInt32Value:
+		return ktUInt
+	case wktInt64Value:
+		if annotations.IsInt64NumberEncoding(field) {
+			return ktLong
+		}
+		return ktString
+	case wktUInt64Value:
+		if annotations.IsInt64NumberEncoding(field) {
+			return ktULong
+		}
+		return ktString
+	case wktFloatValue:
+		return ktFloat
+	case wktDoubleValue:
+		return ktDouble
+	}
+	return ktJSONElement
+}
+
+// kotlinStringLiteral returns s as a Kotlin string literal, escaping the
+// template character.
+func kotlinStringLiteral(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\', '$':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
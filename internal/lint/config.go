@@ -0,0 +1,75 @@
+package lint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	yaml "go.yaml.in/yaml/v4"
+)
+
+// ConfigFileName is the config file sebuf-lint reads from the working
+// directory when no other is given.
+const ConfigFileName = ".sebuf-lint.yaml"
+
+// Config overrides the severity of rules:
+//
+//	rules:
+//	  header-case: error
+//	  path-case: off
+type Config struct {
+	Rules map[string]Severity `yaml:"rules"`
+}
+
+// ParseConfig parses a config file. Unknown keys and rule IDs are errors, so
+// typos do not silently leave a rule at its default.
+func ParseConfig(data []byte) (*Config, error) {
+	var config Config
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	for id := range config.Rules {
+		if ruleByID(id) == nil {
+			return nil, fmt.Errorf("unknown rule %q", id)
+		}
+	}
+	return &config, nil
+}
+
+// LoadConfig reads and parses the config file at path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config, err := ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+// LoadDefaultConfig reads ConfigFileName from the working directory, returning
+// a nil config when there is none.
+func LoadDefaultConfig() (*Config, error) {
+	config, err := LoadConfig(ConfigFileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil //nolint:nilnil // a nil config keeps the default severities
+	}
+	return config, err
+}
+
+// severity returns the severity of rule under c.
+func (c *Config) severity(rule *Rule) Severity {
+	if c != nil {
+		if severity, ok := c.Rules[rule.ID]; ok {
+			return severity
+		}
+	}
+	return rule.Severity
+}
@@ -0,0 +1,133 @@
+// Package lint checks sebuf annotations without generating code. It runs the
+// validations the generators fail on, each under its own rule ID, alongside
+// style rules that keep an API consistent. cmd/sebuf-lint and
+// protoc-gen-sebuf-lint are thin wrappers around it.
+package lint
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Severity is how a rule's findings are reported.
+type Severity int
+
+const (
+	// SeverityOff disables a rule.
+	SeverityOff Severity = iota
+	// SeverityWarning reports findings without failing the run.
+	SeverityWarning
+	// SeverityError reports findings and fails the run.
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityOff:
+		return "off"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Severity) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "off":
+		*s = SeverityOff
+	case "warning":
+		*s = SeverityWarning
+	case "error":
+		*s = SeverityError
+	default:
+		return fmt.Errorf("unknown severity %q, want off, warning or error", text)
+	}
+	return nil
+}
+
+// Rule is a single lint check.
+type Rule struct {
+	// ID names the rule in findings and in the config file, e.g. "header-case".
+	ID string
+	// Doc is a one-line description of what the rule requires.
+	Doc string
+	// Severity is the severity of the rule's findings unless the config overrides it.
+	Severity Severity
+	// Check returns the violations of the rule in file.
+	Check func(file *protogen.File) []Violation
+}
+
+// Violation is a problem a rule found on an element of a file.
+type Violation struct {
+	Element protoreflect.Descriptor
+	Message string
+}
+
+// Finding is a reported violation.
+type Finding struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line"`   // 1-based, 0 when the descriptors carry no source info
+	Column   int      `json:"column"` // 1-based, 0 when the descriptors carry no source info
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (f Finding) String() string {
+	pos := f.File
+	if f.Line > 0 {
+		pos = fmt.Sprintf("%s:%d:%d", f.File, f.Line, f.Column)
+	}
+	return fmt.Sprintf("%s: %s [%s] %s", pos, f.Severity, f.Rule, f.Message)
+}
+
+// Lint runs every rule config leaves enabled over files and returns the
+// findings sorted by position. A nil config keeps the default severities.
+func Lint(files []*protogen.File, config *Config) []Finding {
+	var findings []Finding
+	for _, rule := range Rules() {
+		severity := config.severity(rule)
+		if severity == SeverityOff {
+			continue
+		}
+		for _, file := range files {
+			for _, v := range rule.Check(file) {
+				f := Finding{Rule: rule.ID, Severity: severity, Message: v.Message}
+				f.File, f.Line, f.Column = position(v.Element)
+				findings = append(findings, f)
+			}
+		}
+	}
+	// Findings at the same position keep the order of the rules
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		return cmp.Or(cmp.Compare(a.File, b.File), cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column))
+	})
+	return findings
+}
+
+// HasErrors reports whether any finding has error severity.
+func HasErrors(findings []Finding) bool {
+	return slices.ContainsFunc(findings, func(f Finding) bool { return f.Severity == SeverityError })
+}
+
+// position returns the file and 1-based line and column of a descriptor.
+func position(d protoreflect.Descriptor) (string, int, int) {
+	file := d.ParentFile()
+	loc := file.SourceLocations().ByDescriptor(d)
+	if len(loc.Path) == 0 {
+		return file.Path(), 0, 0
+	}
+	return file.Path(), loc.StartLine + 1, loc.StartColumn + 1
+}
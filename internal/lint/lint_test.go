@@ -0,0 +1,577 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// testFileHeader starts the test.proto descriptor every case is built from.
+const testFileHeader = `
+name: "test.proto"
+package: "test"
+syntax: "proto3"
+options { go_package: "example.com/test;test" }
+`
+
+// dependencies returns the descriptors test.proto imports, dependencies first.
+func dependencies() []*descriptorpb.FileDescriptorProto {
+	var deps []*descriptorpb.FileDescriptorProto
+	seen := map[string]bool{}
+	var add func(protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		for i := range fd.Imports().Len() {
+			add(fd.Imports().Get(i).FileDescriptor)
+		}
+		dep := protodesc.ToFileDescriptorProto(fd)
+		// headers.proto is registered under the path it was compiled from
+		dep.Name = proto.String(strings.TrimPrefix(dep.GetName(), "proto/"))
+		deps = append(deps, dep)
+	}
+	add(http.E_Config.TypeDescriptor().ParentFile())
+	add(http.E_ServiceHeaders.TypeDescriptor().ParentFile())
+	return deps
+}
+
+// descriptorSet builds the descriptors of test.proto from the text format of
+// the rest of its FileDescriptorProto.
+func descriptorSet(t *testing.T, body string) *descriptorpb.FileDescriptorSet {
+	t.Helper()
+	deps := dependencies()
+	var fdp descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(testFileHeader+body), &fdp); err != nil {
+		t.Fatalf("parse test.proto: %v", err)
+	}
+	for _, dep := range deps {
+		fdp.Dependency = append(fdp.Dependency, dep.GetName())
+	}
+	return &descriptorpb.FileDescriptorSet{File: append(deps, &fdp)}
+}
+
+// testFile builds test.proto as a protogen file.
+func testFile(t *testing.T, body string) *protogen.File {
+	t.Helper()
+	set := descriptorSet(t, body)
+	plugin, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"test.proto"},
+		ProtoFile:      set.GetFile(),
+	})
+	if err != nil {
+		t.Fatalf("build test.proto: %v", err)
+	}
+	return plugin.FilesByPath["test.proto"]
+}
+
+// service returns a service of methods built with method.
+func service(options string, methods ...string) string {
+	return `service { name: "Svc" options { ` + options + ` } ` + strings.Join(methods, " ") + ` }`
+}
+
+// method returns a method taking and returning input with the given HTTP config.
+func method(name, input, config string) string {
+	return `method { name: "` + name + `" input_type: ".test.` + input + `" output_type: ".test.` + input + `"` +
+		` options { [sebuf.http.config] { ` + config + ` } } }`
+}
+
+const (
+	emptyReq  = `message_type { name: "Empty" }`
+	stringReq = `message_type { name: "Req" field { name: "id" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING } }`
+)
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		rule string
+		name string
+		file string
+		want []string // a substring of each expected violation, in order
+	}{
+		{
+			rule: "path-param-field",
+			name: "unknown variable",
+			file: stringReq + service("", method("Get", "Req", `path: "/items/{item_id}" method: HTTP_METHOD_GET`)),
+			want: []string{"path variable '{item_id}'"},
+		},
+		{
+			rule: "path-param-field",
+			name: "matching field",
+			file: stringReq + service("", method("Get", "Req", `path: "/items/{id}" method: HTTP_METHOD_GET`)),
+		},
+		{
+			rule: "path-param-type",
+			name: "bytes field",
+			file: `message_type { name: "Req" field { name: "id" number: 1 label: LABEL_OPTIONAL type: TYPE_BYTES } }` +
+				service("", method("Get", "Req", `path: "/items/{id}" method: HTTP_METHOD_GET`)),
+			want: []string{"must be scalar types"},
+		},
+		{
+			rule: "path-wildcard",
+			name: "wildcard before the end",
+			file: stringReq + service("", method("Get", "Req", `path: "/items/{id...}/raw" method: HTTP_METHOD_GET`)),
+			want: []string{"must be the final segment"},
+		},
+		{
+			rule: "path-wildcard",
+			name: "wildcard bound to an integer",
+			file: `message_type { name: "Req" field { name: "id" number: 1 label: LABEL_OPTIONAL type: TYPE_INT64 } }` +
+				service("", method("Get", "Req", `path: "/items/{id...}" method: HTTP_METHOD_GET`)),
+			want: []string{"must be bound to a string field"},
+		},
+		{
+			rule: "path-wildcard",
+			name: "wildcard bound to a string",
+			file: stringReq + service("", method("Get", "Req", `path: "/items/{id...}" method: HTTP_METHOD_GET`)),
+		},
+		{
+			rule: "query-path-conflict",
+			name: "query annotation on a path variable",
+			file: `message_type { name: "Req" field { name: "id" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING` +
+				` options { [sebuf.http.query] { name: "id" } } } }` +
+				service("", method("Get", "Req", `path: "/items/{id}" method: HTTP_METHOD_GET`)),
+			want: []string{"both as a path variable"},
+		},
+		{
+			rule: "field-source",
+			name: "PATH source without a variable",
+			file: `message_type { name: "Req" field { name: "id" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING` +
+				` options { [sebuf.http.source]: FIELD_SOURCE_PATH } } }` +
+				service("", method("Create", "Req", `path: "/items" method: HTTP_METHOD_POST`)),
+			want: []string{"source is PATH"},
+		},
+		{
+			rule: "get-body-fields",
+			name: "unbound GET field",
+			file: stringReq + service("", method("List", "Req", `path: "/items" method: HTTP_METHOD_GET`)),
+			want: []string{"not bound to path or query parameters: [id]"},
+		},
+		{
+			rule: "get-body-fields",
+			name: "query field",
+			file: `message_type { name: "Req" field { name: "id" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING` +
+				` options { [sebuf.http.query] { name: "id" } } } }` +
+				service("", method("List", "Req", `path: "/items" method: HTTP_METHOD_GET`)),
+		},
+		{
+			rule: "query-field-type",
+			name: "bytes query field",
+			file: `message_type { name: "Req" field { name: "token" number: 1 label: LABEL_OPTIONAL type: TYPE_BYTES` +
+				` options { [sebuf.http.query] { name: "token" } } } }` +
+				service("", method("List", "Req", `path: "/items" method: HTTP_METHOD_DELETE`)),
+			want: []string{"field 'token' cannot be bound from the query string of a DELETE request"},
+		},
+		{
+			rule: "query-field-type",
+			name: "bytes body field",
+			file: `message_type { name: "Req" field { name: "token" number: 1 label: LABEL_OPTIONAL` +
+				` type: TYPE_BYTES } }` +
+				service("", method("Create", "Req", `path: "/items" method: HTTP_METHOD_POST`)),
+		},
+		{
+			rule: "idempotency-stream",
+			name: "idempotent stream",
+			file: emptyReq + service("", method("Watch", "Empty", `path: "/watch" stream: true idempotency: true`)),
+			want: []string{"idempotency is not supported on streaming methods"},
+		},
+		{
+			rule: "cache",
+			name: "cached POST",
+			file: emptyReq + service("", method("Create", "Empty",
+				`path: "/items" method: HTTP_METHOD_POST cache { max_age_seconds: 60 }`)),
+			want: []string{"cache is only supported on GET methods"},
+		},
+		{
+			rule: "cache",
+			name: "cached GET",
+			file: emptyReq + service("", method("List", "Empty",
+				`path: "/items" method: HTTP_METHOD_GET cache { max_age_seconds: 60 }`)),
+		},
+		{
+			rule: "timeout",
+			name: "negative timeout",
+			file: emptyReq + service("", method("List", "Empty", `path: "/items" timeout_ms: -1`)),
+			want: []string{"timeout_ms must not be negative"},
+		},
+		{
+			rule: "form-body",
+			name: "form on GET",
+			file: emptyReq + service("",
+				method("List", "Empty", `path: "/items" method: HTTP_METHOD_GET accept_form: true`)),
+			want: []string{"accept_form is only supported"},
+		},
+		{
+			rule: "multipart",
+			name: "multipart on DELETE",
+			file: emptyReq + service("", method("Drop", "Empty",
+				`path: "/items" method: HTTP_METHOD_DELETE accept_multipart: true`)),
+			want: []string{"accept_multipart is only supported"},
+		},
+		{
+			rule: "stream-response",
+			name: "response without a repeated field",
+			file: emptyReq + service("", method("List", "Empty", `path: "/items" stream_response: true`)),
+			want: []string{"stream_response requires the response message Empty to have a single repeated message field"},
+		},
+		{
+			rule: "merge-patch",
+			name: "merge patch on PUT",
+			file: emptyReq + service("", method("Replace", "Empty",
+				`path: "/items" method: HTTP_METHOD_PUT patch_format: PATCH_FORMAT_MERGE_PATCH`)),
+			want: []string{"patch_format MERGE_PATCH is only supported on PATCH methods"},
+		},
+		{
+			rule: "service-versions",
+			name: "duplicate base path",
+			file: emptyReq + service(`[sebuf.http.service_config] { versions { base_path: "/v1" name: "a" }`+
+				` versions { base_path: "/v1" name: "b" } }`, method("List", "Empty", `path: "/items"`)),
+			want: []string{`base_path "/v1" is used by more than one version`},
+		},
+		{
+			rule: "base-path-params",
+			name: "unbound base path parameter",
+			file: emptyReq + service(`[sebuf.http.service_config] { base_path: "/t/{tenant_id}" }`,
+				method("List", "Empty", `path: "/items"`)),
+			want: []string{"base path parameter {tenant_id} is not a field of Empty"},
+		},
+		{
+			rule: "route-conflict",
+			name: "same verb and path",
+			file: emptyReq + service("",
+				method("List", "Empty", `path: "/items/{id}" method: HTTP_METHOD_GET`),
+				method("Get", "Empty", `path: "/items/{item_id}/" method: HTTP_METHOD_GET`)),
+			want: []string{"test.Svc.List (GET /items/{id}) and test.Svc.Get (GET /items/{item_id}/)"},
+		},
+		{
+			rule: "route-conflict",
+			name: "different verbs",
+			file: emptyReq + service("",
+				method("List", "Empty", `path: "/items" method: HTTP_METHOD_GET`),
+				method("Create", "Empty", `path: "/items" method: HTTP_METHOD_POST`)),
+		},
+		{
+			rule: "field-annotation",
+			name: "timestamp_format on a string",
+			file: `message_type { name: "Event" field { name: "at" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING` +
+				` options { [sebuf.http.timestamp_format]: TIMESTAMP_FORMAT_UNIX_SECONDS } } }`,
+			want: []string{"only valid on google.protobuf.Timestamp fields"},
+		},
+		{
+			rule: "flatten-collision",
+			name: "flattened field shadows a parent field",
+			file: `message_type { name: "Inner" field { name: "name" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING } }` +
+				`message_type { name: "Outer"` +
+				` field { name: "name" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }` +
+				` field { name: "inner" number: 2 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".test.Inner"` +
+				` options { [sebuf.http.flatten]: true } } }`,
+			want: []string{"name"},
+		},
+		{
+			rule: "oneof-discriminator",
+			name: "discriminator shadows a field",
+			file: `message_type { name: "Shape"` +
+				` field { name: "kind" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }` +
+				` field { name: "circle" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING oneof_index: 0 }` +
+				` oneof_decl { name: "shape" options { [sebuf.http.oneof_config] { discriminator: "kind" } } } }`,
+			want: []string{"kind"},
+		},
+		{
+			rule: "unwrap",
+			name: "unwrap on a singular field",
+			file: `message_type { name: "List" field { name: "items" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING` +
+				` options { [sebuf.http.unwrap]: true } } }`,
+			want: []string{"can only be used on repeated or map fields"},
+		},
+		{
+			rule: "go-encoding",
+			name: "flatten with bytes_encoding",
+			file: `message_type { name: "Inner" field { name: "name" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING } }` +
+				`message_type { name: "Outer"` +
+				` field { name: "blob" number: 1 label: LABEL_OPTIONAL type: TYPE_BYTES` +
+				` options { [sebuf.http.bytes_encoding]: BYTES_ENCODING_HEX } }` +
+				` field { name: "inner" number: 2 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".test.Inner"` +
+				` options { [sebuf.http.flatten]: true } } }`,
+			want: []string{"has both flatten and bytes_encoding"},
+		},
+		{
+			rule: "webhook",
+			name: "path variable",
+			file: `message_type { name: "OrderShipped" field { name: "id" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }` +
+				` options { [sebuf.http.webhook] { path: "/hooks/{id}" } } }`,
+			want: []string{"/hooks/{id}"},
+		},
+		{
+			rule: "path-case",
+			name: "uppercase segment",
+			file: emptyReq + service("", method("List", "Empty", `path: "/Items"`)),
+			want: []string{`path segment "Items" of "/Items" is not lowercase`},
+		},
+		{
+			rule: "path-case",
+			name: "mixed separators",
+			file: emptyReq + service(`[sebuf.http.service_config] { base_path: "/api/order-service" }`,
+				method("List", "Empty", `path: "/line_items"`),
+				method("Get", "Empty", `path: "/line-items/{id}:cancel"`)),
+			want: []string{`path segment "line_items" of "/line_items" is snake_case, but "order-service"`},
+		},
+		{
+			rule: "path-case",
+			name: "consistent kebab-case",
+			file: emptyReq + service(`[sebuf.http.service_config] { base_path: "/api/v1" }`,
+				method("List", "Empty", `path: "/line-items"`),
+				method("Get", "Empty", `path: "/line-items/{item_id}:batchGet"`)),
+		},
+		{
+			rule: "header-case",
+			name: "lowercase header",
+			file: emptyReq + service(`[sebuf.http.service_headers] { required_headers { name: "x-api-key" } }`,
+				method("List", "Empty", `path: "/items"`)),
+			want: []string{`header "x-api-key" should be written "X-Api-Key"`},
+		},
+		{
+			rule: "header-case",
+			name: "canonical header",
+			file: emptyReq + service(`[sebuf.http.service_headers] { required_headers { name: "X-Tenant-Id" } }`,
+				method("List", "Empty", `path: "/items"`)),
+		},
+		{
+			rule: "header-declaration",
+			name: "header declared twice",
+			file: emptyReq + service(`[sebuf.http.service_headers] { required_headers { name: "X-Tenant-Id" }`+
+				` required_headers { name: "X-Tenant-Id" } }`, method("List", "Empty", `path: "/items"`)),
+			want: []string{`header "X-Tenant-Id" is declared twice`},
+		},
+		{
+			rule: "header-declaration",
+			name: "invalid header name",
+			file: emptyReq + service(`[sebuf.http.service_headers] { required_headers { name: "X Tenant" } }`,
+				method("List", "Empty", `path: "/items"`)),
+			want: []string{`header name "X Tenant" is not a valid HTTP field name`},
+		},
+		{
+			rule: "header-override",
+			name: "method header changing the type",
+			file: emptyReq + service(
+				`[sebuf.http.service_headers] { required_headers { name: "X-Limit" type: "integer" } }`,
+				method("List", "Empty", `path: "/items" } [sebuf.http.method_headers] {`+
+					` required_headers { name: "X-Limit" type: "string" format: "uuid" }`)),
+			want: []string{`header "X-Limit" overrides the service header and changes its type from integer to string` +
+				` and its format from "" to "uuid"`},
+		},
+		{
+			rule: "header-override",
+			name: "method header changing the description",
+			file: emptyReq + service(
+				`[sebuf.http.service_headers] { required_headers { name: "X-Limit" type: "integer" } }`,
+				method("List", "Empty", `path: "/items" } [sebuf.http.method_headers] {`+
+					` required_headers { name: "X-Limit" type: "integer" description: "Page size" example: "20" }`)),
+		},
+		{
+			rule: "base-path-slash",
+			name: "trailing slash",
+			file: emptyReq + service(`[sebuf.http.service_config] { base_path: "/api/" }`,
+				method("List", "Empty", `path: "/items"`)),
+			want: []string{`base path "/api/" ends in '/'`},
+		},
+		{
+			rule: "base-path-slash",
+			name: "no trailing slash",
+			file: emptyReq + service(`[sebuf.http.service_config] { base_path: "/api" }`,
+				method("List", "Empty", `path: "/items"`)),
+		},
+		{
+			rule: "unwrap-single-field",
+			name: "unwrap beside another field",
+			file: `message_type { name: "Page"` +
+				` field { name: "items" number: 1 label: LABEL_REPEATED type: TYPE_STRING` +
+				` options { [sebuf.http.unwrap]: true } }` +
+				` field { name: "next" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING } }`,
+			want: []string{"Page has 2 fields"},
+		},
+		{
+			rule: "unwrap-single-field",
+			name: "single field",
+			file: `message_type { name: "Page"` +
+				` field { name: "items" number: 1 label: LABEL_REPEATED type: TYPE_STRING` +
+				` options { [sebuf.http.unwrap]: true } } }`,
+		},
+	}
+
+	tested := map[string]bool{}
+	for _, tt := range tests {
+		t.Run(tt.rule+"/"+tt.name, func(t *testing.T) {
+			rule := ruleByID(tt.rule)
+			if rule == nil {
+				t.Fatalf("no rule %q", tt.rule)
+			}
+			tested[tt.rule] = true
+			violations := rule.Check(testFile(t, tt.file))
+			if len(violations) != len(tt.want) {
+				t.Fatalf("got %d violations %v, want %d", len(violations), violations, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(violations[i].Message, want) {
+					t.Errorf("violation %d = %q, want it to contain %q", i, violations[i].Message, want)
+				}
+			}
+		})
+	}
+	for _, rule := range Rules() {
+		if !tested[rule.ID] {
+			t.Errorf("rule %q has no test case", rule.ID)
+		}
+	}
+}
+
+func TestLint(t *testing.T) {
+	file := testFile(t, stringReq+service(`[sebuf.http.service_config] { base_path: "/api/" }`,
+		method("List", "Req", `path: "/items" method: HTTP_METHOD_GET`)))
+
+	findings := Lint([]*protogen.File{file}, nil)
+	if len(findings) != 2 {
+		t.Fatalf("findings = %v, want 2", findings)
+	}
+	if f := findings[0]; f.Rule != "get-body-fields" || f.Severity != SeverityError || f.File != "test.proto" {
+		t.Errorf("first finding = %+v", f)
+	}
+	if f := findings[1]; f.Rule != "base-path-slash" || f.Severity != SeverityWarning {
+		t.Errorf("second finding = %+v", f)
+	}
+	if !HasErrors(findings) {
+		t.Error("HasErrors = false, want true")
+	}
+
+	config, err := ParseConfig([]byte("rules:\n  get-body-fields: off\n  base-path-slash: error\n"))
+	if err != nil {
+		t.Fatalf("ParseConfig: %v", err)
+	}
+	findings = Lint([]*protogen.File{file}, config)
+	if len(findings) != 1 || findings[0].Rule != "base-path-slash" || findings[0].Severity != SeverityError {
+		t.Errorf("findings with config = %v, want base-path-slash as an error", findings)
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{name: "empty", config: ""},
+		{name: "severities", config: "rules:\n  path-case: off\n  header-case: warning\n  unwrap: error\n"},
+		{name: "unknown rule", config: "rules:\n  path-casing: off\n", wantErr: `unknown rule "path-casing"`},
+		{name: "unknown severity", config: "rules:\n  path-case: fatal\n", wantErr: `unknown severity "fatal"`},
+		{name: "unknown key", config: "rule:\n  path-case: off\n", wantErr: "rule"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(tt.config))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ParseConfig: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseConfig error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	set := descriptorSet(t, emptyReq)
+	set.File[len(set.File)-1].Options = nil // no go_package
+
+	data, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := Load(data, nil)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(files) != 1 || files[0].Desc.Path() != "test.proto" {
+		t.Errorf("Load linted %v, want only test.proto", files)
+	}
+
+	// buf images mark their dependencies with is_import
+	var bufExtension []byte
+	bufExtension = protowire.AppendTag(bufExtension, 1, protowire.VarintType)
+	bufExtension = protowire.AppendVarint(bufExtension, 1)
+	var unknown []byte
+	unknown = protowire.AppendTag(unknown, bufImageExtensionField, protowire.BytesType)
+	unknown = protowire.AppendBytes(unknown, bufExtension)
+	set.File[len(set.File)-1].ProtoReflect().SetUnknown(unknown)
+	if data, err = proto.Marshal(set); err != nil {
+		t.Fatal(err)
+	}
+	if files, err = Load(data, nil); err != nil || len(files) != 0 {
+		t.Errorf("Load of a buf import = %v, %v; want no files", files, err)
+	}
+	if files, err = Load(data, []string{"test.proto"}); err != nil || len(files) != 1 {
+		t.Errorf("Load of a named buf import = %v, %v; want test.proto", files, err)
+	}
+	if _, err = Load(data, []string{"missing.proto"}); err == nil {
+		t.Error("Load of a missing file succeeded")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	findings := []Finding{
+		{File: "a.proto", Line: 3, Column: 5, Rule: "header-case", Severity: SeverityWarning, Message: "bad header"},
+		{File: "b.proto", Rule: "unwrap", Severity: SeverityError, Message: "bad unwrap"},
+	}
+
+	var text bytes.Buffer
+	if err := Write(&text, FormatText, findings); err != nil {
+		t.Fatal(err)
+	}
+	wantText := "a.proto:3:5: warning [header-case] bad header\nb.proto: error [unwrap] bad unwrap\n"
+	if text.String() != wantText {
+		t.Errorf("text output = %q, want %q", text.String(), wantText)
+	}
+
+	var out bytes.Buffer
+	if err := Write(&out, FormatJSON, findings); err != nil {
+		t.Fatal(err)
+	}
+	var got []map[string]any
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("json output %s: %v", out.String(), err)
+	}
+	want := map[string]any{
+		"file": "a.proto", "line": float64(3), "column": float64(5),
+		"rule": "header-case", "severity": "warning", "message": "bad header",
+	}
+	if len(got) != 2 || !equalJSON(got[0], want) {
+		t.Errorf("json output = %v, want first finding %v", got, want)
+	}
+
+	out.Reset()
+	if err := Write(&out, FormatJSON, nil); err != nil || strings.TrimSpace(out.String()) != "[]" {
+		t.Errorf("json output without findings = %q, %v; want []", out.String(), err)
+	}
+	if err := Write(&out, "xml", findings); err == nil {
+		t.Error("Write in an unknown format succeeded")
+	}
+}
+
+func equalJSON(a, b map[string]any) bool {
+	aJSON, _ := json.Marshal(a)
+	bJSON, _ := json.Marshal(b)
+	return string(aJSON) == string(bJSON)
+}
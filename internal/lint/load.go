@@ -0,0 +1,124 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// dependencyPrefixes are the paths of the protos sebuf users import rather
+// than write, which are not linted unless asked for.
+var dependencyPrefixes = []string{"google/", "buf/validate/", "sebuf/"}
+
+// bufImageExtensionField is the number of buf's ImageFile.buf_extension field,
+// whose is_import field (1) marks the files a buf image only holds as
+// dependencies.
+const bufImageExtensionField = 8042
+
+// Load reads the files to lint from a serialized FileDescriptorSet, as written
+// by protoc --descriptor_set_out=... --include_imports --include_source_info or
+// by buf build -o image.binpb. The files named in paths are linted; when paths
+// is empty, every file is except the imports of a buf image and the
+// google/, buf/validate/ and sebuf/ protos.
+func Load(data []byte, paths []string) ([]*protogen.File, error) {
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("parsing descriptor set: %w", err)
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{ProtoFile: set.GetFile()}
+	for _, file := range set.GetFile() {
+		name := file.GetName()
+		if (len(paths) > 0 && slices.Contains(paths, name)) || (len(paths) == 0 && !isDependency(file)) {
+			req.FileToGenerate = append(req.FileToGenerate, name)
+		}
+	}
+	for _, path := range paths {
+		if !slices.Contains(req.FileToGenerate, path) {
+			return nil, fmt.Errorf("%s is not in the descriptor set", path)
+		}
+	}
+	addGoPackages(req)
+
+	plugin, err := protogen.Options{}.New(req)
+	if err != nil {
+		return nil, err
+	}
+	var files []*protogen.File
+	for _, file := range plugin.Files {
+		if file.Generate {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// addGoPackages maps the files of req without a go_package option to a made-up
+// Go package, since protogen needs one for every file and linting never uses it.
+func addGoPackages(req *pluginpb.CodeGeneratorRequest) {
+	var params []string
+	if req.GetParameter() != "" {
+		params = append(params, req.GetParameter())
+	}
+	for _, file := range req.GetProtoFile() {
+		if file.GetOptions().GetGoPackage() == "" {
+			params = append(params, "M"+file.GetName()+"=sebuf-lint/"+strings.TrimSuffix(file.GetName(), ".proto"))
+		}
+	}
+	req.Parameter = proto.String(strings.Join(params, ","))
+}
+
+// isDependency reports whether file is only in the set as a dependency.
+func isDependency(file *descriptorpb.FileDescriptorProto) bool {
+	for _, prefix := range dependencyPrefixes {
+		if strings.HasPrefix(file.GetName(), prefix) {
+			return true
+		}
+	}
+	return isBufImport(file.ProtoReflect().GetUnknown())
+}
+
+// isBufImport reports whether the unknown fields of a file descriptor hold a
+// buf image extension marking the file as an import.
+func isBufImport(b []byte) bool {
+	ext, ok := consumeField(b, bufImageExtensionField, protowire.BytesType)
+	if !ok {
+		return false
+	}
+	v, n := protowire.ConsumeBytes(ext)
+	if n < 0 {
+		return false
+	}
+	isImport, ok := consumeField(v, 1, protowire.VarintType)
+	if !ok {
+		return false
+	}
+	flag, n := protowire.ConsumeVarint(isImport)
+	return n > 0 && flag != 0
+}
+
+// consumeField returns the encoded value of the first field numbered num in b.
+func consumeField(b []byte, num protowire.Number, typ protowire.Type) ([]byte, bool) {
+	for len(b) > 0 {
+		fieldNum, fieldType, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, false
+		}
+		b = b[n:]
+		if fieldNum == num && fieldType == typ {
+			return b, true
+		}
+		n = protowire.ConsumeFieldValue(fieldNum, fieldType, b)
+		if n < 0 {
+			return nil, false
+		}
+		b = b[n:]
+	}
+	return nil, false
+}
@@ -0,0 +1,76 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/internal/pluginrun"
+)
+
+// RunPlugin lints the files of a protoc plugin request and writes the findings
+// to w. The format parameter selects the output format (text by default) and
+// the config parameter a config file (ConfigFileName when present by default).
+// When a finding has error severity, the response carries an error, which
+// fails the protoc run. No files are generated.
+func RunPlugin(req *pluginpb.CodeGeneratorRequest, w io.Writer) (*pluginpb.CodeGeneratorResponse, error) {
+	format := FormatText
+	configPath := ""
+	if req != nil {
+		addGoPackages(req)
+	}
+	paramFunc := func(name, value string) error {
+		switch name {
+		case "format":
+			if value != FormatText && value != FormatJSON {
+				return fmt.Errorf("want %s or %s", FormatText, FormatJSON)
+			}
+			format = value
+		case "config":
+			configPath = value
+		default:
+			return fmt.Errorf("unknown parameter %q", name)
+		}
+		return nil
+	}
+	return pluginrun.Run(req, paramFunc, func(plugin *protogen.Plugin) error {
+		var config *Config
+		var err error
+		if configPath != "" {
+			config, err = LoadConfig(configPath)
+		} else {
+			config, err = LoadDefaultConfig()
+		}
+		if err != nil {
+			return err
+		}
+
+		var files []*protogen.File
+		for _, file := range plugin.Files {
+			if file.Generate {
+				files = append(files, file)
+			}
+		}
+		findings := Lint(files, config)
+		if err = Write(w, format, findings); err != nil {
+			return err
+		}
+		if errorCount := countErrors(findings); errorCount > 0 {
+			return fmt.Errorf("sebuf-lint found %d error(s)", errorCount)
+		}
+		return nil
+	})
+}
+
+// countErrors returns the number of findings with error severity.
+func countErrors(findings []Finding) int {
+	n := 0
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,35 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Output formats of Write.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Write writes findings to w in format: one finding per line for text, a JSON
+// array for json.
+func Write(w io.Writer, format string, findings []Finding) error {
+	switch format {
+	case FormatText:
+		for _, f := range findings {
+			if _, err := fmt.Fprintln(w, f); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatJSON:
+		if findings == nil {
+			findings = []Finding{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(findings)
+	}
+	return fmt.Errorf("unknown format %q, want %s or %s", format, FormatText, FormatJSON)
+}
@@ -0,0 +1,461 @@
+package lint
+
+import (
+	"fmt"
+	"net/textproto"
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/httpgen"
+)
+
+// rules holds every rule, in the order findings on the same position are
+// reported: the checks the generators fail on first, then the style rules.
+var rules = []*Rule{
+	methodConfigRule("path-param-field", "Every path variable names a field of the request message."),
+	methodConfigRule("path-param-type", "Path variables are bound to scalar fields."),
+	methodConfigRule("path-wildcard", "A {name...} wildcard is the final segment of its path and is bound "+
+		"to a string field."),
+	methodConfigRule("query-path-conflict", "A field is not both a path variable and a query parameter."),
+	methodConfigRule("field-source", "Field source annotations agree with the method path."),
+	methodConfigRule("get-body-fields", "GET and DELETE requests bind every field to the path, query or a header."),
+	methodConfigRule("query-field-type", "GET and DELETE requests have no map, message or bytes fields "+
+		"outside the path and headers."),
+	methodConfigRule("idempotency-stream", "Streaming methods do not declare idempotency."),
+	methodConfigRule("cache", "Only GET methods with a single response are cached, for a positive max age."),
+	methodConfigRule("timeout", "timeout_ms is not negative and not set on streaming methods."),
+	methodConfigRule("form-body", "accept_form is only set on methods with a request body."),
+	methodConfigRule("multipart", "accept_multipart is only set on methods with a request body, "+
+		"and its filename captures name bytes fields."),
+	methodConfigRule("stream-response", "stream_response methods return a single repeated message field "+
+		"and are not streamed, idempotent, cached or timed out."),
+	methodConfigRule("merge-patch", "patch_format MERGE_PATCH is only set on PATCH methods with JSON bodies "+
+		"that are not streamed."),
+	{
+		ID:       "service-versions",
+		Doc:      "API versions have distinct base paths and names, and valid sunsets.",
+		Severity: SeverityError,
+		Check:    checkServiceVersions,
+	},
+	{
+		ID:       "base-path-params",
+		Doc:      "Base path parameters are request fields of every method, or declared context_only.",
+		Severity: SeverityError,
+		Check:    checkBasePathParams,
+	},
+	{
+		ID:       "route-conflict",
+		Doc:      "No two methods of a file are served on the same verb and path.",
+		Severity: SeverityError,
+		Check:    checkRouteConflicts,
+	},
+	{
+		ID:       "field-annotation",
+		Doc:      "Field encoding annotations are set on fields of a type they apply to.",
+		Severity: SeverityError,
+		Check:    checkFieldAnnotations,
+	},
+	{
+		ID:       "flatten-collision",
+		Doc:      "Flattened fields do not collide with the fields of their parent.",
+		Severity: SeverityError,
+		Check:    checkFlattenCollisions,
+	},
+	{
+		ID:       "oneof-discriminator",
+		Doc:      "Oneof discriminators and flattened variants do not collide with other fields.",
+		Severity: SeverityError,
+		Check:    checkOneofDiscriminators,
+	},
+	{
+		ID:       "unwrap",
+		Doc:      "unwrap is set on at most one repeated or map field per message.",
+		Severity: SeverityError,
+		Check:    checkUnwrap,
+	},
+	{
+		ID:       "go-encoding",
+		Doc:      "A message uses at most one annotation that needs a generated Go MarshalJSON.",
+		Severity: SeverityError,
+		Check:    checkGoEncoding,
+	},
+	{
+		ID:       "webhook",
+		Doc:      "Webhook signature headers are valid header names and webhook paths have no variables.",
+		Severity: SeverityError,
+		Check:    checkWebhooks,
+	},
+	{
+		ID:       "header-declaration",
+		Doc:      "Header names are valid HTTP field names, declared once per service or method.",
+		Severity: SeverityError,
+		Check:    checkHeaderDeclarations,
+	},
+	{
+		ID:       "path-case",
+		Doc:      "Path segments are lowercase and a file sticks to either kebab-case or snake_case.",
+		Severity: SeverityWarning,
+		Check:    checkPathCase,
+	},
+	{
+		ID:       "header-case",
+		Doc:      "Header names are written in Canonical-Case.",
+		Severity: SeverityWarning,
+		Check:    checkHeaderCase,
+	},
+	{
+		ID:       "header-override",
+		Doc:      "Method headers keep the type and format of the service headers they override.",
+		Severity: SeverityWarning,
+		Check:    checkHeaderOverrides,
+	},
+	{
+		ID:       "base-path-slash",
+		Doc:      "Base paths do not end in '/'.",
+		Severity: SeverityWarning,
+		Check:    checkBasePathSlash,
+	},
+	{
+		ID:       "unwrap-single-field",
+		Doc:      "unwrap is only set in messages with a single field.",
+		Severity: SeverityWarning,
+		Check:    checkUnwrapSingleField,
+	},
+}
+
+// Rules returns every rule.
+func Rules() []*Rule {
+	return rules
+}
+
+// ruleByID returns the rule with the given ID, or nil.
+func ruleByID(id string) *Rule {
+	for _, rule := range rules {
+		if rule.ID == id {
+			return rule
+		}
+	}
+	return nil
+}
+
+// methodConfigRule returns the rule reporting the method config errors the
+// HTTP generator tags with id.
+func methodConfigRule(id, doc string) *Rule {
+	return &Rule{
+		ID:       id,
+		Doc:      doc,
+		Severity: SeverityError,
+		Check: func(file *protogen.File) []Violation {
+			var violations []Violation
+			for _, service := range file.Services {
+				for _, method := range service.Methods {
+					for _, err := range httpgen.ValidateMethodConfig(service, method) {
+						if err.Rule == id {
+							violations = append(violations, Violation{method.Desc, err.Message})
+						}
+					}
+				}
+			}
+			return violations
+		},
+	}
+}
+
+func checkServiceVersions(file *protogen.File) []Violation {
+	var violations []Violation
+	for _, service := range file.Services {
+		if err := annotations.ValidateServiceVersions(service); err != nil {
+			violations = append(violations, Violation{service.Desc, err.Error()})
+		}
+	}
+	return violations
+}
+
+func checkBasePathParams(file *protogen.File) []Violation {
+	var violations []Violation
+	for _, service := range file.Services {
+		if err := annotations.ValidateBasePathParams(service); err != nil {
+			violations = append(violations, Violation{service.Desc, err.Error()})
+		}
+	}
+	return violations
+}
+
+func checkRouteConflicts(file *protogen.File) []Violation {
+	var violations []Violation
+	for _, conflict := range httpgen.RouteConflicts(file) {
+		violations = append(violations, Violation{conflict.Method.Desc, conflict.Error()})
+	}
+	return violations
+}
+
+// fieldValidators are the checks of single field annotations, given the field
+// and the Go name of its message.
+var fieldValidators = []func(*protogen.Field, string) error{
+	validateEnumAnnotations,
+	annotations.ValidateTimestampFormatAnnotation,
+	annotations.ValidateBytesEncodingAnnotation,
+	annotations.ValidateNullableAnnotation,
+	annotations.ValidateEmptyBehaviorAnnotation,
+	annotations.ValidateSensitiveAnnotation,
+	annotations.ValidateFlattenField,
+}
+
+func validateEnumAnnotations(field *protogen.Field, _ string) error {
+	if field.Desc.Kind() == protoreflect.EnumKind && annotations.HasConflictingEnumAnnotations(field) {
+		return fmt.Errorf(
+			"field %s has both enum_encoding=NUMBER and enum_value annotations - this is not allowed",
+			field.Desc.Name(),
+		)
+	}
+	return nil
+}
+
+func checkFieldAnnotations(file *protogen.File) []Violation {
+	var violations []Violation
+	forEachMessage(file, func(msg *protogen.Message) {
+		for _, field := range msg.Fields {
+			for _, validate := range fieldValidators {
+				if err := validate(field, msg.GoIdent.GoName); err != nil {
+					violations = append(violations, Violation{field.Desc, err.Error()})
+				}
+			}
+		}
+	})
+	return violations
+}
+
+func checkFlattenCollisions(file *protogen.File) []Violation {
+	var violations []Violation
+	forEachMessage(file, func(msg *protogen.Message) {
+		if !annotations.HasFlattenFields(msg) {
+			return
+		}
+		if err := annotations.ValidateFlattenCollisions(msg); err != nil {
+			violations = append(violations, Violation{msg.Desc, err.Error()})
+		}
+	})
+	return violations
+}
+
+func checkOneofDiscriminators(file *protogen.File) []Violation {
+	var violations []Violation
+	forEachMessage(file, func(msg *protogen.Message) {
+		for _, oneof := range msg.Oneofs {
+			config := annotations.GetOneofConfig(oneof)
+			if config == nil {
+				continue
+			}
+			if err := annotations.ValidateOneofDiscriminator(msg, oneof, config); err != nil {
+				violations = append(violations, Violation{oneof.Desc, err.Error()})
+			}
+		}
+	})
+	return violations
+}
+
+func checkUnwrap(file *protogen.File) []Violation {
+	var violations []Violation
+	forEachMessage(file, func(msg *protogen.Message) {
+		if _, err := annotations.GetUnwrapField(msg); err != nil {
+			violations = append(violations, Violation{msg.Desc, err.Error()})
+		}
+	})
+	return violations
+}
+
+func checkGoEncoding(file *protogen.File) []Violation {
+	var violations []Violation
+	forEachMessage(file, func(msg *protogen.Message) {
+		if err := httpgen.ValidateMessageEncoding(msg); err != nil {
+			violations = append(violations, Violation{msg.Desc, err.Error()})
+		}
+	})
+	return violations
+}
+
+func checkWebhooks(file *protogen.File) []Violation {
+	var violations []Violation
+	for _, webhook := range annotations.GetFileWebhooks(file) {
+		if err := annotations.ValidateWebhook(webhook); err != nil {
+			violations = append(violations, Violation{webhook.Message.Desc, err.Error()})
+		}
+	}
+	return violations
+}
+
+func checkHeaderDeclarations(file *protogen.File) []Violation {
+	return headerProblems(file, false)
+}
+
+func checkHeaderOverrides(file *protogen.File) []Violation {
+	return headerProblems(file, true)
+}
+
+// headerProblems returns the header overrides of the services of file, or
+// their other header problems.
+func headerProblems(file *protogen.File, overrides bool) []Violation {
+	var violations []Violation
+	for _, service := range file.Services {
+		for _, problem := range annotations.CheckServiceHeaders(service) {
+			if problem.Override == overrides {
+				violations = append(violations, Violation{problem.Element, problem.Message})
+			}
+		}
+	}
+	return violations
+}
+
+// pathSegmentPattern matches a lowercase path segment whose words are
+// separated by '-', '_' or '.', optionally followed by a ":verb" custom method.
+var pathSegmentPattern = regexp.MustCompile(`^[a-z0-9]+([-_.][a-z0-9]+)*(:[a-zA-Z][a-zA-Z0-9]*)?$`)
+
+// pathStyle is the word separator of the path segments of a file.
+type pathStyle struct {
+	separator string
+	name      string
+	segment   string // the first segment written in the style
+}
+
+func checkPathCase(file *protogen.File) []Violation {
+	var violations []Violation
+	var style *pathStyle
+	check := func(element protoreflect.Descriptor, path string) {
+		for segment := range strings.SplitSeq(path, "/") {
+			if segment == "" || strings.HasPrefix(segment, "{") {
+				continue
+			}
+			if !pathSegmentPattern.MatchString(segment) {
+				violations = append(violations, Violation{element, fmt.Sprintf(
+					"path segment %q of %q is not lowercase kebab-case or snake_case", segment, path)})
+				continue
+			}
+			segment, _, _ = strings.Cut(segment, ":")
+			segmentStyle := segmentPathStyle(segment)
+			switch {
+			case segmentStyle == nil:
+			case style == nil:
+				style = segmentStyle
+			case segmentStyle.separator != style.separator:
+				violations = append(violations, Violation{element, fmt.Sprintf(
+					"path segment %q of %q is %s, but %q made this file's paths %s",
+					segment, path, segmentStyle.name, style.segment, style.name)})
+			}
+		}
+	}
+
+	for _, service := range file.Services {
+		config := serviceConfig(service)
+		check(service.Desc, config.GetBasePath())
+		for _, version := range config.GetVersions() {
+			check(service.Desc, version.GetBasePath())
+		}
+		for _, method := range service.Methods {
+			if config := annotations.GetMethodHTTPConfig(method); config != nil {
+				check(method.Desc, config.Path)
+			}
+		}
+	}
+	return violations
+}
+
+// segmentPathStyle returns the style of a path segment, or nil for a single word.
+func segmentPathStyle(segment string) *pathStyle {
+	switch {
+	case strings.Contains(segment, "-"):
+		return &pathStyle{separator: "-", name: "kebab-case", segment: segment}
+	case strings.Contains(segment, "_"):
+		return &pathStyle{separator: "_", name: "snake_case", segment: segment}
+	}
+	return nil
+}
+
+func checkHeaderCase(file *protogen.File) []Violation {
+	var violations []Violation
+	check := func(element protoreflect.Descriptor, headers []*http.Header) {
+		for _, header := range headers {
+			name := header.GetName()
+			if canonical := textproto.CanonicalMIMEHeaderKey(name); canonical != name {
+				violations = append(violations, Violation{element, fmt.Sprintf(
+					"header %q should be written %q", name, canonical)})
+			}
+		}
+	}
+	for _, service := range file.Services {
+		check(service.Desc, annotations.GetServiceHeaders(service))
+		for _, method := range service.Methods {
+			check(method.Desc, annotations.GetMethodHeaders(method))
+		}
+	}
+	return violations
+}
+
+func checkBasePathSlash(file *protogen.File) []Violation {
+	var violations []Violation
+	check := func(element protoreflect.Descriptor, basePath string) {
+		if strings.HasSuffix(basePath, "/") {
+			violations = append(violations, Violation{element, fmt.Sprintf(
+				"base path %q ends in '/'. Method paths are joined to it with their own leading '/'", basePath)})
+		}
+	}
+	for _, service := range file.Services {
+		config := serviceConfig(service)
+		check(service.Desc, config.GetBasePath())
+		for _, version := range config.GetVersions() {
+			check(service.Desc, version.GetBasePath())
+		}
+	}
+	return violations
+}
+
+func checkUnwrapSingleField(file *protogen.File) []Violation {
+	var violations []Violation
+	forEachMessage(file, func(msg *protogen.Message) {
+		if len(msg.Fields) < 2 {
+			return
+		}
+		for _, field := range msg.Fields {
+			if annotations.HasUnwrapAnnotation(field) {
+				violations = append(violations, Violation{field.Desc, fmt.Sprintf(
+					"unwrap on %s.%s: %s has %d fields, so it is only unwrapped as a map value. "+
+						"Move %s into a message of its own",
+					msg.Desc.Name(), field.Desc.Name(), msg.Desc.Name(), len(msg.Fields), field.Desc.Name())})
+			}
+		}
+	})
+	return violations
+}
+
+// serviceConfig returns the sebuf.http.service_config annotation of a service
+// as written, or nil.
+func serviceConfig(service *protogen.Service) *http.ServiceConfig {
+	options, ok := service.Desc.Options().(*descriptorpb.ServiceOptions)
+	if !ok || options == nil {
+		return nil
+	}
+	config, _ := proto.GetExtension(options, http.E_ServiceConfig).(*http.ServiceConfig)
+	return config
+}
+
+// forEachMessage calls fn for the messages of file, nested messages included
+// and map entries excluded.
+func forEachMessage(file *protogen.File, fn func(*protogen.Message)) {
+	var walk func([]*protogen.Message)
+	walk = func(messages []*protogen.Message) {
+		for _, msg := range messages {
+			if msg.Desc.IsMapEntry() {
+				continue
+			}
+			fn(msg)
+			walk(msg.Messages)
+		}
+	}
+	walk(file.Messages)
+}
@@ -0,0 +1,220 @@
+// Package manifest describes what a sebuf plugin generated in a
+// machine-readable sebuf.manifest.json, for tooling such as API catalogs and
+// SDK portals.
+//
+// A generator run with manifest=true records the route of every method it
+// serves or calls on a Builder while generating, then Write appends the
+// manifest to the plugin response, listing those routes and every file of the
+// response. Each entry names the plugin and version that produced it, so the
+// manifests of several plugins can be merged downstream.
+package manifest
+
+import (
+	"cmp"
+	"encoding/json"
+	"maps"
+	"runtime/debug"
+	"slices"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// FileName is the name of the manifest file, one per plugin invocation.
+const FileName = "sebuf.manifest.json"
+
+// Version is the manifestVersion of the schema described by Manifest. It is
+// incremented on changes existing readers would misinterpret.
+const Version = 1
+
+// modulePath is the module whose version identifies the plugins.
+const modulePath = "github.com/SebastienMelki/sebuf"
+
+// Manifest is the content of sebuf.manifest.json.
+type Manifest struct {
+	ManifestVersion int       `json:"manifestVersion"`
+	Outputs         []Output  `json:"outputs"`
+	Services        []Service `json:"services"`
+}
+
+// Output is a file written by the invocation, sorted by name.
+type Output struct {
+	Name          string `json:"name"`
+	Plugin        string `json:"plugin"`
+	PluginVersion string `json:"pluginVersion"`
+}
+
+// Service is a service the invocation generated code for, sorted by proto
+// file and then by name.
+type Service struct {
+	Name          string   `json:"name"`
+	ProtoFile     string   `json:"protoFile"`
+	Plugin        string   `json:"plugin"`
+	PluginVersion string   `json:"pluginVersion"`
+	Methods       []Method `json:"methods"`
+}
+
+// Method is a method of a Service, in declaration order.
+type Method struct {
+	Name            string   `json:"name"`
+	RequestType     string   `json:"requestType"`
+	ResponseType    string   `json:"responseType"`
+	RequiredHeaders []string `json:"requiredHeaders,omitempty"`
+	// Routes holds one route per API version the method is served under, in
+	// version order, or a single route for unversioned services.
+	Routes []Route `json:"routes"`
+}
+
+// Route is an HTTP verb and path pattern ("/users/{id}").
+type Route struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+// Builder collects the routes of a plugin invocation. All methods do nothing
+// on a nil Builder, so generators can record routes unconditionally.
+type Builder struct {
+	plugin   string
+	version  string
+	services map[protoreflect.FullName]*serviceEntry
+	methods  map[protoreflect.FullName]*Method
+}
+
+// serviceEntry is a Service being built, with its methods by declaration index.
+type serviceEntry struct {
+	Service
+	methods map[int]*Method
+}
+
+// NewBuilder returns a Builder for the named plugin ("protoc-gen-go-http").
+func NewBuilder(plugin string) *Builder {
+	return &Builder{
+		plugin:   plugin,
+		version:  pluginVersion(),
+		services: make(map[protoreflect.FullName]*serviceEntry),
+		methods:  make(map[protoreflect.FullName]*Method),
+	}
+}
+
+// AddRoute records that method is served or called on httpMethod and path.
+// Recording the same route twice has no effect.
+func (b *Builder) AddRoute(method *protogen.Method, httpMethod, path string) {
+	if b == nil {
+		return
+	}
+	entry := b.method(method)
+	route := Route{Method: httpMethod, Path: path}
+	if !slices.Contains(entry.Routes, route) {
+		entry.Routes = append(entry.Routes, route)
+	}
+}
+
+// method returns the entry of method, creating it and its service's entry on
+// first use.
+func (b *Builder) method(method *protogen.Method) *Method {
+	if entry, ok := b.methods[method.Desc.FullName()]; ok {
+		return entry
+	}
+	service := b.services[method.Parent.Desc.FullName()]
+	if service == nil {
+		service = &serviceEntry{
+			Service: Service{
+				Name:          string(method.Parent.Desc.FullName()),
+				ProtoFile:     method.Parent.Desc.ParentFile().Path(),
+				Plugin:        b.plugin,
+				PluginVersion: b.version,
+			},
+			methods: make(map[int]*Method),
+		}
+		b.services[method.Parent.Desc.FullName()] = service
+	}
+
+	entry := &Method{
+		Name:         string(method.Desc.Name()),
+		RequestType:  string(method.Input.Desc.FullName()),
+		ResponseType: string(method.Output.Desc.FullName()),
+	}
+	headers := annotations.CombineHeaders(
+		annotations.GetServiceHeaders(method.Parent),
+		annotations.GetMethodHeaders(method),
+	)
+	for _, header := range headers {
+		if header.GetRequired() {
+			entry.RequiredHeaders = append(entry.RequiredHeaders, annotations.CanonicalHeaderName(header.GetName()))
+		}
+	}
+	b.methods[method.Desc.FullName()] = entry
+	service.methods[method.Desc.Index()] = entry
+	return entry
+}
+
+// Manifest returns the manifest of the recorded routes and the files of resp.
+func (b *Builder) Manifest(resp *pluginpb.CodeGeneratorResponse) *Manifest {
+	m := &Manifest{ManifestVersion: Version, Outputs: []Output{}, Services: []Service{}}
+	if b == nil {
+		return m
+	}
+	for _, file := range resp.GetFile() {
+		if file.GetInsertionPoint() != "" {
+			continue
+		}
+		m.Outputs = append(m.Outputs, Output{Name: file.GetName(), Plugin: b.plugin, PluginVersion: b.version})
+	}
+	slices.SortFunc(m.Outputs, func(x, y Output) int { return cmp.Compare(x.Name, y.Name) })
+
+	for _, entry := range b.services {
+		service := entry.Service
+		for _, index := range slices.Sorted(maps.Keys(entry.methods)) {
+			service.Methods = append(service.Methods, *entry.methods[index])
+		}
+		m.Services = append(m.Services, service)
+	}
+	slices.SortFunc(m.Services, func(x, y Service) int {
+		return cmp.Or(cmp.Compare(x.ProtoFile, y.ProtoFile), cmp.Compare(x.Name, y.Name))
+	})
+	return m
+}
+
+// Write appends the manifest to resp. It does nothing on a nil Builder or a
+// response reporting an error.
+func (b *Builder) Write(resp *pluginpb.CodeGeneratorResponse) {
+	if b == nil || resp == nil || resp.GetError() != "" {
+		return
+	}
+	content, err := json.MarshalIndent(b.Manifest(resp), "", "  ")
+	if err != nil {
+		resp.Error = proto.String("writing " + FileName + ": " + err.Error())
+		return
+	}
+	resp.File = append(resp.File, &pluginpb.CodeGeneratorResponse_File{
+		Name:    proto.String(FileName),
+		Content: proto.String(string(content) + "\n"),
+	})
+}
+
+// pluginVersion returns the version of the sebuf module the running binary was
+// built from, or "(devel)" for builds without one (such as tests).
+func pluginVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "(devel)"
+	}
+	version := info.Main.Version
+	if info.Main.Path != modulePath {
+		// sebuf is embedded as a library, through the generators' Run functions
+		version = ""
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				version = dep.Version
+			}
+		}
+	}
+	if version == "" {
+		return "(devel)"
+	}
+	return version
+}
@@ -0,0 +1,100 @@
+package manifest
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/internal/pluginrun/pluginruntest"
+)
+
+func noteMethods(t *testing.T) (getNote, createNote *protogen.Method) {
+	t.Helper()
+	plugin, err := protogen.Options{}.New(pluginruntest.Request(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	methods := plugin.FilesByPath[pluginruntest.FileName].Services[0].Methods
+	return methods[0], methods[1]
+}
+
+func TestBuilderStableOrder(t *testing.T) {
+	getNote, createNote := noteMethods(t)
+	b := NewBuilder("protoc-gen-test")
+	b.AddRoute(createNote, "POST", "/api/v1/notes")
+	b.AddRoute(getNote, "GET", "/api/v1/notes/{id}")
+	b.AddRoute(getNote, "GET", "/api/v2/notes/{id}")
+	b.AddRoute(getNote, "GET", "/api/v1/notes/{id}")
+
+	resp := &pluginpb.CodeGeneratorResponse{File: []*pluginpb.CodeGeneratorResponse_File{
+		{Name: proto.String("notes_b.txt")},
+		{Name: proto.String("notes_a.txt")},
+		{Name: proto.String("notes_a.txt"), InsertionPoint: proto.String("imports")},
+	}}
+	b.Write(resp)
+	if len(resp.GetFile()) != 4 || resp.GetFile()[3].GetName() != FileName {
+		t.Fatalf("Write should append %s, got %v", FileName, resp.GetFile())
+	}
+	var got Manifest
+	if err := json.Unmarshal([]byte(resp.GetFile()[3].GetContent()), &got); err != nil {
+		t.Fatalf("decode manifest: %v", err)
+	}
+
+	version := b.version
+	want := Manifest{
+		ManifestVersion: Version,
+		Outputs: []Output{
+			{Name: "notes_a.txt", Plugin: "protoc-gen-test", PluginVersion: version},
+			{Name: "notes_b.txt", Plugin: "protoc-gen-test", PluginVersion: version},
+		},
+		Services: []Service{{
+			Name:          "test.notes.v1.NoteService",
+			ProtoFile:     pluginruntest.FileName,
+			Plugin:        "protoc-gen-test",
+			PluginVersion: version,
+			Methods: []Method{
+				{
+					Name:         "GetNote",
+					RequestType:  "test.notes.v1.GetNoteRequest",
+					ResponseType: "test.notes.v1.Note",
+					Routes: []Route{
+						{Method: "GET", Path: "/api/v1/notes/{id}"},
+						{Method: "GET", Path: "/api/v2/notes/{id}"},
+					},
+				},
+				{
+					Name:         "CreateNote",
+					RequestType:  "test.notes.v1.CreateNoteRequest",
+					ResponseType: "test.notes.v1.Note",
+					Routes:       []Route{{Method: "POST", Path: "/api/v1/notes"}},
+				},
+			},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("manifest = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestWriteSkipped(t *testing.T) {
+	getNote, _ := noteMethods(t)
+
+	var nilBuilder *Builder
+	nilBuilder.AddRoute(getNote, "GET", "/notes/{id}")
+	resp := &pluginpb.CodeGeneratorResponse{}
+	nilBuilder.Write(resp)
+	if len(resp.GetFile()) != 0 {
+		t.Errorf("a nil Builder should not write a manifest")
+	}
+
+	resp = &pluginpb.CodeGeneratorResponse{Error: proto.String("bad input")}
+	NewBuilder("protoc-gen-test").Write(resp)
+	if len(resp.GetFile()) != 0 {
+		t.Errorf("no manifest should be written for a failed generation")
+	}
+	NewBuilder("protoc-gen-test").Write(nil)
+}
@@ -0,0 +1,107 @@
+package openapiv3
+
+import (
+	"encoding/base64"
+	"math"
+	"strconv"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	yaml "go.yaml.in/yaml/v4"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// documentProtoDefault returns schemaProxy with the default value the field
+// declares, as in proto2 [default = ...], in the form its JSON takes. A field
+// without one, or whose default has no plain JSON form, is returned as is.
+func documentProtoDefault(schemaProxy *base.SchemaProxy, field *protogen.Field) *base.SchemaProxy {
+	if !field.Desc.HasDefault() || schemaProxy.IsReference() {
+		return schemaProxy
+	}
+	value := protoDefaultNode(field)
+	if value == nil {
+		return schemaProxy
+	}
+	builtSchema, err := schemaProxy.BuildSchema()
+	if err != nil || builtSchema == nil {
+		return schemaProxy
+	}
+	builtSchema.Default = value
+	return base.CreateSchemaProxy(builtSchema)
+}
+
+// protoDefaultNode returns the JSON of the field's default value, or nil when
+// the field's encoding annotations give it no plain JSON form.
+func protoDefaultNode(field *protogen.Field) *yaml.Node {
+	value := field.Desc.Default()
+	scalar := func(tag, v string) *yaml.Node {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v}
+	}
+	//exhaustive:ignore -- message and group fields have no default
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return scalar("!!bool", strconv.FormatBool(value.Bool()))
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return scalar("!!int", strconv.FormatInt(value.Int(), 10))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return scalar("!!int", strconv.FormatUint(value.Uint(), 10))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if annotations.IsInt64NumberEncoding(field) {
+			return scalar("!!int", strconv.FormatInt(value.Int(), 10))
+		}
+		return scalar("!!str", strconv.FormatInt(value.Int(), 10))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if annotations.IsInt64NumberEncoding(field) {
+			return scalar("!!int", strconv.FormatUint(value.Uint(), 10))
+		}
+		return scalar("!!str", strconv.FormatUint(value.Uint(), 10))
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		f := value.Float()
+		switch {
+		case math.IsNaN(f):
+			return scalar("!!str", "NaN")
+		case math.IsInf(f, 1):
+			return scalar("!!str", "Infinity")
+		case math.IsInf(f, -1):
+			return scalar("!!str", "-Infinity")
+		}
+		return scalar("!!float", strconv.FormatFloat(f, 'g', -1, 64))
+	case protoreflect.StringKind:
+		return scalar("!!str", value.String())
+	case protoreflect.BytesKind:
+		//exhaustive:ignore -- only the default encoding is documented
+		switch annotations.ResolveBytesEncoding(field) {
+		case http.BytesEncoding_BYTES_ENCODING_UNSPECIFIED, http.BytesEncoding_BYTES_ENCODING_BASE64:
+			return scalar("!!str", base64.StdEncoding.EncodeToString(value.Bytes()))
+		}
+	case protoreflect.EnumKind:
+		return enumDefaultNode(field, value.Enum())
+	}
+	return nil
+}
+
+// enumDefaultNode returns the JSON of the enum value number, the default of
+// field: its number under enum_encoding=NUMBER, else its custom enum_value or
+// proto name.
+func enumDefaultNode(field *protogen.Field, number protoreflect.EnumNumber) *yaml.Node {
+	if annotations.ResolveEnumEncoding(field) == http.EnumEncoding_ENUM_ENCODING_NUMBER {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(int(number))}
+	}
+	if field.Enum == nil {
+		return nil
+	}
+	for _, value := range field.Enum.Values {
+		if value.Desc.Number() != number {
+			continue
+		}
+		name := annotations.GetEnumValueMapping(value)
+		if name == "" {
+			name = string(value.Desc.Name())
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	yaml "go.yaml.in/yaml/v4"
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// generatedExampleName names the example synthesized from field_examples for
+// methods without examples annotations.
+const generatedExampleName = "generated"
+
+// operationExamples returns the examples of a method's operation: its examples
+// annotations, or else one named "generated" holding the field_examples of its
+// request body and response, when they have any. The response of a root unwrap
+// message is not synthesized, its JSON not being an object.
+func operationExamples(method *protogen.Method) []*http.MethodExample {
+	if examples := annotations.GetMethodExamples(method); len(examples) > 0 {
+		return examples
+	}
+	request, _ := messageExampleJSON(method.Input, nil, annotations.IsBodyExcluded)
+	var response string
+	if !annotations.IsRootUnwrap(method.Output) {
+		response, _ = messageExampleJSON(method.Output, nil, nil)
+	}
+	if request == "" && response == "" {
+		return nil
+	}
+	return []*http.MethodExample{{
+		Name:     generatedExampleName,
+		Summary:  "Built from the field examples",
+		Request:  request,
+		Response: response,
+	}}
+}
+
+// documentExamples sets the examples of a method on the JSON content of its
+// operation's request body and successful response. The response of a result
+// message is its set variant's message, documented under the variant's status.
+// Streamed and raw body responses carry none.
+func documentExamples(operation *v3.Operation, method *protogen.Method) {
+	variants, _ := annotations.GetResponseVariants(method.Output)
+	for _, example := range operationExamples(method) {
+		if example.GetRequest() != "" && operation.RequestBody != nil {
+			addExample(operation.RequestBody.Content, example, example.GetRequest())
+		}
+		if example.GetResponse() == "" || annotations.IsRawBodyResponse(method) {
+			continue
+		}
+		if config := annotations.GetMethodHTTPConfig(method); config != nil && config.Stream {
+			continue
+		}
+		if variants == nil {
+			if response, ok := operation.Responses.Codes.Get("200"); ok {
+				addExample(response.Content, example, example.GetResponse())
+			}
+			continue
+		}
+		var members map[string]json.RawMessage
+		if json.Unmarshal([]byte(example.GetResponse()), &members) != nil {
+			continue
+		}
+		for _, variant := range variants {
+			value, set := members[annotations.JSONFieldName(variant.Field)]
+			if !set {
+				value, set = members[string(variant.Field.Desc.Name())]
+			}
+			if response, ok := operation.Responses.Codes.Get(strconv.Itoa(variant.Status)); set && ok {
+				addExample(response.Content, example, string(value))
+			}
+		}
+	}
+}
+
+// addExample adds example, valued by the JSON data, to the application/json
+// media type of content, if it has one.
+func addExample(content *orderedmap.Map[string, *v3.MediaType], example *http.MethodExample, data string) {
+	media, ok := content.Get("application/json")
+	if !ok {
+		return
+	}
+	var document yaml.Node
+	if yaml.Unmarshal([]byte(data), &document) != nil || len(document.Content) == 0 {
+		return
+	}
+	value := document.Content[0]
+	clearStyle(value)
+	if media.Examples == nil {
+		media.Examples = orderedmap.New[string, *base.Example]()
+	}
+	media.Examples.Set(example.GetName(), &base.Example{Summary: example.GetSummary(), Value: value})
+}
+
+// clearStyle resets the style of node and its descendants, so that YAML specs
+// render examples in block style rather than as the JSON they were written in.
+// Strings that would read as another type stay quoted.
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/pb33f/libopenapi"
 )
 
 func reportGoldenFileMismatch(t *testing.T, testName, goldenFile string, generatedContent, goldenContent []byte) {
@@ -309,6 +311,51 @@ func TestExhaustiveGoldenFiles(t *testing.T) {
 			goldenFile:  "testdata/golden/json/NotificationService.openapi.json",
 			format:      "json",
 		},
+		// auth_headers.proto -> AccountService (bearer token next to a plain header)
+		{
+			name:        "account_service_yaml",
+			protoFile:   "testdata/proto/auth_headers.proto",
+			serviceName: "AccountService",
+			goldenFile:  "testdata/golden/yaml/AccountService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "account_service_json",
+			protoFile:   "testdata/proto/auth_headers.proto",
+			serviceName: "AccountService",
+			goldenFile:  "testdata/golden/json/AccountService.openapi.json",
+			format:      "json",
+		},
+		// auth_headers.proto -> BackofficeService (bearer token AND API key)
+		{
+			name:        "backoffice_service_yaml",
+			protoFile:   "testdata/proto/auth_headers.proto",
+			serviceName: "BackofficeService",
+			goldenFile:  "testdata/golden/yaml/BackofficeService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "backoffice_service_json",
+			protoFile:   "testdata/proto/auth_headers.proto",
+			serviceName: "BackofficeService",
+			goldenFile:  "testdata/golden/json/BackofficeService.openapi.json",
+			format:      "json",
+		},
+		// auth_headers.proto -> PartnerService (alternative credentials)
+		{
+			name:        "partner_service_yaml",
+			protoFile:   "testdata/proto/auth_headers.proto",
+			serviceName: "PartnerService",
+			goldenFile:  "testdata/golden/yaml/PartnerService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "partner_service_json",
+			protoFile:   "testdata/proto/auth_headers.proto",
+			serviceName: "PartnerService",
+			goldenFile:  "testdata/golden/json/PartnerService.openapi.json",
+			format:      "json",
+		},
 		// http_annotations.proto -> BasicService
 		{
 			name:        "basic_service_yaml",
@@ -415,6 +462,271 @@ func TestExhaustiveGoldenFiles(t *testing.T) {
 			goldenFile:  "testdata/golden/json/QueryParamService.openapi.json",
 			format:      "json",
 		},
+		// field_sources.proto -> FieldSourceService
+		{
+			name:        "shared_field_source_service_yaml",
+			protoFile:   "testdata/proto/field_sources.proto",
+			serviceName: "FieldSourceService",
+			goldenFile:  "testdata/golden/yaml/FieldSourceService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "shared_field_source_service_json",
+			protoFile:   "testdata/proto/field_sources.proto",
+			serviceName: "FieldSourceService",
+			goldenFile:  "testdata/golden/json/FieldSourceService.openapi.json",
+			format:      "json",
+		},
+		// sensitive.proto -> AuthService (sensitive fields documented as passwords)
+		{
+			name:        "auth_service_yaml",
+			protoFile:   "testdata/proto/sensitive.proto",
+			serviceName: "AuthService",
+			goldenFile:  "testdata/golden/yaml/AuthService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "auth_service_json",
+			protoFile:   "testdata/proto/sensitive.proto",
+			serviceName: "AuthService",
+			goldenFile:  "testdata/golden/json/AuthService.openapi.json",
+			format:      "json",
+		},
+		// json_naming.proto -> OrderService (snake_case file with a camelCase message)
+		{
+			name:        "order_service_yaml",
+			protoFile:   "testdata/proto/json_naming.proto",
+			serviceName: "OrderService",
+			goldenFile:  "testdata/golden/yaml/OrderService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "order_service_json",
+			protoFile:   "testdata/proto/json_naming.proto",
+			serviceName: "OrderService",
+			goldenFile:  "testdata/golden/json/OrderService.openapi.json",
+			format:      "json",
+		},
+		// dynamic_json.proto -> DocumentService (Any, Struct, Value and ListValue fields)
+		{
+			name:        "document_service_yaml",
+			protoFile:   "testdata/proto/dynamic_json.proto",
+			serviceName: "DocumentService",
+			goldenFile:  "testdata/golden/yaml/DocumentService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "document_service_json",
+			protoFile:   "testdata/proto/dynamic_json.proto",
+			serviceName: "DocumentService",
+			goldenFile:  "testdata/golden/json/DocumentService.openapi.json",
+			format:      "json",
+		},
+		// json_names.proto -> JSONNameService (custom json_name on every field)
+		{
+			name:        "json_name_service_yaml",
+			protoFile:   "testdata/proto/json_names.proto",
+			serviceName: "JSONNameService",
+			goldenFile:  "testdata/golden/yaml/JSONNameService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "json_name_service_json",
+			protoFile:   "testdata/proto/json_names.proto",
+			serviceName: "JSONNameService",
+			goldenFile:  "testdata/golden/json/JSONNameService.openapi.json",
+			format:      "json",
+		},
+		// form_body.proto -> FormService (form-encoded request bodies via accept_form)
+		{
+			name:        "form_service_yaml",
+			protoFile:   "testdata/proto/form_body.proto",
+			serviceName: "FormService",
+			goldenFile:  "testdata/golden/yaml/FormService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "form_service_json",
+			protoFile:   "testdata/proto/form_body.proto",
+			serviceName: "FormService",
+			goldenFile:  "testdata/golden/json/FormService.openapi.json",
+			format:      "json",
+		},
+		// multipart_upload.proto -> UploadService (multipart/form-data request bodies via accept_multipart)
+		{
+			name:        "upload_service_yaml",
+			protoFile:   "testdata/proto/multipart_upload.proto",
+			serviceName: "UploadService",
+			goldenFile:  "testdata/golden/yaml/UploadService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "upload_service_json",
+			protoFile:   "testdata/proto/multipart_upload.proto",
+			serviceName: "UploadService",
+			goldenFile:  "testdata/golden/json/UploadService.openapi.json",
+			format:      "json",
+		},
+		// raw_body.proto -> FileService (raw_body responses written as binary bodies)
+		{
+			name:        "file_service_yaml",
+			protoFile:   "testdata/proto/raw_body.proto",
+			serviceName: "FileService",
+			goldenFile:  "testdata/golden/yaml/FileService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "file_service_json",
+			protoFile:   "testdata/proto/raw_body.proto",
+			serviceName: "FileService",
+			goldenFile:  "testdata/golden/json/FileService.openapi.json",
+			format:      "json",
+		},
+		// json_query.proto -> OrderSearchService (message query parameters encoded as JSON_BASE64URL)
+		{
+			name:        "order_search_service_yaml",
+			protoFile:   "testdata/proto/json_query.proto",
+			serviceName: "OrderSearchService",
+			goldenFile:  "testdata/golden/yaml/OrderSearchService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "order_search_service_json",
+			protoFile:   "testdata/proto/json_query.proto",
+			serviceName: "OrderSearchService",
+			goldenFile:  "testdata/golden/json/OrderSearchService.openapi.json",
+			format:      "json",
+		},
+		// merge_patch.proto -> ListingService (PATCH bodies read as JSON Merge Patch)
+		{
+			name:        "listing_service_yaml",
+			protoFile:   "testdata/proto/merge_patch.proto",
+			serviceName: "ListingService",
+			goldenFile:  "testdata/golden/yaml/ListingService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "listing_service_json",
+			protoFile:   "testdata/proto/merge_patch.proto",
+			serviceName: "ListingService",
+			goldenFile:  "testdata/golden/json/ListingService.openapi.json",
+			format:      "json",
+		},
+		// deprecated_fields.proto -> CustomerService, LegacyCustomerService (deprecated fields, methods and services)
+		{
+			name:        "customer_service_yaml",
+			protoFile:   "testdata/proto/deprecated_fields.proto",
+			serviceName: "CustomerService",
+			goldenFile:  "testdata/golden/yaml/CustomerService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "legacy_customer_service_yaml",
+			protoFile:   "testdata/proto/deprecated_fields.proto",
+			serviceName: "LegacyCustomerService",
+			goldenFile:  "testdata/golden/yaml/LegacyCustomerService.openapi.yaml",
+			format:      "yaml",
+		},
+		// query_aliases.proto -> ArticleService (query parameters accepted under deprecated aliases)
+		{
+			name:        "article_service_yaml",
+			protoFile:   "testdata/proto/query_aliases.proto",
+			serviceName: "ArticleService",
+			goldenFile:  "testdata/golden/yaml/ArticleService.openapi.yaml",
+			format:      "yaml",
+		},
+		// editions.proto -> NoteService (edition 2023 field presence)
+		{
+			name:        "note_service_yaml",
+			protoFile:   "testdata/proto/editions.proto",
+			serviceName: "NoteService",
+			goldenFile:  "testdata/golden/yaml/NoteService.openapi.yaml",
+			format:      "yaml",
+		},
+		// proto2_syntax.proto -> ItemService (proto2 required fields and defaults)
+		{
+			name:        "item_service_yaml",
+			protoFile:   "testdata/proto/proto2_syntax.proto",
+			serviceName: "ItemService",
+			goldenFile:  "testdata/golden/yaml/ItemService.openapi.yaml",
+			format:      "yaml",
+		},
+		// response_headers.proto -> ProfileService (static response headers)
+		{
+			name:        "profile_service_yaml",
+			protoFile:   "testdata/proto/response_headers.proto",
+			serviceName: "ProfileService",
+			goldenFile:  "testdata/golden/yaml/ProfileService.openapi.yaml",
+			format:      "yaml",
+		},
+		// path_wildcard.proto -> StorageService ({name...} wildcard suffix paths)
+		{
+			name:        "storage_service_yaml",
+			protoFile:   "testdata/proto/path_wildcard.proto",
+			serviceName: "StorageService",
+			goldenFile:  "testdata/golden/yaml/StorageService.openapi.yaml",
+			format:      "yaml",
+		},
+		// versioned_routes.proto -> CatalogService (served under several API versions)
+		{
+			name:        "catalog_service_yaml",
+			protoFile:   "testdata/proto/versioned_routes.proto",
+			serviceName: "CatalogService",
+			goldenFile:  "testdata/golden/yaml/CatalogService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "catalog_service_json",
+			protoFile:   "testdata/proto/versioned_routes.proto",
+			serviceName: "CatalogService",
+			goldenFile:  "testdata/golden/json/CatalogService.openapi.json",
+			format:      "json",
+		},
+		// response_statuses.proto -> CheckoutService (result messages answered per variant status)
+		{
+			name:        "checkout_service_yaml",
+			protoFile:   "testdata/proto/response_statuses.proto",
+			serviceName: "CheckoutService",
+			goldenFile:  "testdata/golden/yaml/CheckoutService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "checkout_service_json",
+			protoFile:   "testdata/proto/response_statuses.proto",
+			serviceName: "CheckoutService",
+			goldenFile:  "testdata/golden/json/CheckoutService.openapi.json",
+			format:      "json",
+		},
+		// method_examples.proto -> BookService (named examples, and one generated from field_examples)
+		{
+			name:        "book_service_yaml",
+			protoFile:   "testdata/proto/method_examples.proto",
+			serviceName: "BookService",
+			goldenFile:  "testdata/golden/yaml/BookService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "book_service_json",
+			protoFile:   "testdata/proto/method_examples.proto",
+			serviceName: "BookService",
+			goldenFile:  "testdata/golden/json/BookService.openapi.json",
+			format:      "json",
+		},
+		// cross_package.proto -> CrossPackageService (colliding imported names, recursive messages)
+		{
+			name:        "cross_package_service_yaml",
+			protoFile:   "testdata/proto/cross_package.proto",
+			serviceName: "CrossPackageService",
+			goldenFile:  "testdata/golden/yaml/CrossPackageService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "cross_package_service_json",
+			protoFile:   "testdata/proto/cross_package.proto",
+			serviceName: "CrossPackageService",
+			goldenFile:  "testdata/golden/json/CrossPackageService.openapi.json",
+			format:      "json",
+		},
 		// backward_compat.proto -> NoAnnotationsService
 		{
 			name:        "shared_no_annotations_service_yaml",
@@ -535,6 +847,21 @@ func TestExhaustiveGoldenFiles(t *testing.T) {
 			goldenFile:  "testdata/golden/json/BytesEncodingService.openapi.json",
 			format:      "json",
 		},
+		// scoped_encoding.proto -> ScopedEncodingService (file and message encoding defaults)
+		{
+			name:        "scoped_encoding_service_yaml",
+			protoFile:   "testdata/proto/scoped_encoding.proto",
+			serviceName: "ScopedEncodingService",
+			goldenFile:  "testdata/golden/yaml/ScopedEncodingService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "scoped_encoding_service_json",
+			protoFile:   "testdata/proto/scoped_encoding.proto",
+			serviceName: "ScopedEncodingService",
+			goldenFile:  "testdata/golden/json/ScopedEncodingService.openapi.json",
+			format:      "json",
+		},
 		// flatten.proto -> FlattenService (nested message flattening)
 		{
 			name:        "flatten_service_yaml",
@@ -595,6 +922,66 @@ func TestExhaustiveGoldenFiles(t *testing.T) {
 			goldenFile:  "testdata/golden/json/EmptyRequestBodyService.openapi.json",
 			format:      "json",
 		},
+		// validation_constraints.proto -> ValidationService (ignore_empty, CEL and exact length rules)
+		{
+			name:        "validation_service_yaml",
+			protoFile:   "testdata/proto/validation_constraints.proto",
+			serviceName: "ValidationService",
+			goldenFile:  "testdata/golden/yaml/ValidationService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "validation_service_json",
+			protoFile:   "testdata/proto/validation_constraints.proto",
+			serviceName: "ValidationService",
+			goldenFile:  "testdata/golden/json/ValidationService.openapi.json",
+			format:      "json",
+		},
+		// path_params.proto -> NestedResourceService (path parameter schemas from bound fields)
+		{
+			name:        "nested_resource_service_yaml",
+			protoFile:   "testdata/proto/path_params.proto",
+			serviceName: "NestedResourceService",
+			goldenFile:  "testdata/golden/yaml/NestedResourceService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "nested_resource_service_json",
+			protoFile:   "testdata/proto/path_params.proto",
+			serviceName: "NestedResourceService",
+			goldenFile:  "testdata/golden/json/NestedResourceService.openapi.json",
+			format:      "json",
+		},
+		// visibility.proto -> InventoryService (ReindexInventory excluded from openapiv3)
+		{
+			name:        "inventory_service_yaml",
+			protoFile:   "testdata/proto/visibility.proto",
+			serviceName: "InventoryService",
+			goldenFile:  "testdata/golden/yaml/InventoryService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "inventory_service_json",
+			protoFile:   "testdata/proto/visibility.proto",
+			serviceName: "InventoryService",
+			goldenFile:  "testdata/golden/json/InventoryService.openapi.json",
+			format:      "json",
+		},
+		// base_path_params.proto -> ProjectService (tenant_id documented once per path)
+		{
+			name:        "project_service_yaml",
+			protoFile:   "testdata/proto/base_path_params.proto",
+			serviceName: "ProjectService",
+			goldenFile:  "testdata/golden/yaml/ProjectService.openapi.yaml",
+			format:      "yaml",
+		},
+		{
+			name:        "project_service_json",
+			protoFile:   "testdata/proto/base_path_params.proto",
+			serviceName: "ProjectService",
+			goldenFile:  "testdata/golden/json/ProjectService.openapi.json",
+			format:      "json",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -691,6 +1078,19 @@ func TestExhaustiveRegression(t *testing.T) {
 		"testdata/proto/unwrap.proto":                   {"OptionDataService", "UnwrapService"},
 		"testdata/proto/http_verbs_comprehensive.proto": {"RESTfulAPIService", "BackwardCompatService"},
 		"testdata/proto/query_params.proto":             {"QueryParamService"},
+		"testdata/proto/field_sources.proto":            {"FieldSourceService"},
+		"testdata/proto/cross_package.proto":            {"CrossPackageService"},
+		"testdata/proto/sensitive.proto":                {"AuthService"},
+		"testdata/proto/json_names.proto":               {"JSONNameService"},
+		"testdata/proto/form_body.proto":                {"FormService"},
+		"testdata/proto/merge_patch.proto":              {"ListingService"},
+		"testdata/proto/deprecated_fields.proto":        {"CustomerService", "LegacyCustomerService"},
+		"testdata/proto/path_wildcard.proto":            {"StorageService"},
+		"testdata/proto/query_aliases.proto":            {"ArticleService"},
+		"testdata/proto/editions.proto":                 {"NoteService"},
+		"testdata/proto/proto2_syntax.proto":            {"ItemService"},
+		"testdata/proto/response_headers.proto":         {"ProfileService"},
+		"testdata/proto/versioned_routes.proto":         {"CatalogService"},
 		"testdata/proto/backward_compat.proto":          {"NoAnnotationsService", "BasePathOnlyService"},
 		"testdata/proto/int64_encoding.proto":           {"Int64EncodingService"},
 		"testdata/proto/enum_encoding.proto":            {"EnumEncodingService"},
@@ -701,6 +1101,10 @@ func TestExhaustiveRegression(t *testing.T) {
 		"testdata/proto/flatten.proto":                  {"FlattenService"},
 		"testdata/proto/oneof_discriminator.proto":      {"OneofDiscriminatorService"},
 		"testdata/proto/sse.proto":                      {"SSEService"},
+		"testdata/proto/validation_constraints.proto":   {"ValidationService"},
+		"testdata/proto/visibility.proto":               {"InventoryService"},
+		"testdata/proto/path_params.proto":              {"NestedResourceService"},
+		"testdata/proto/method_examples.proto":          {"BookService"},
 	}
 
 	formats := []string{"yaml", "json"}
@@ -840,6 +1244,53 @@ func TestGoldenFileValidity(t *testing.T) {
 	}
 }
 
+// TestSecuritySchemeGoldenFiles ensures the golden specs of services with
+// authentication headers load under libopenapi, and that their operations
+// only require declared security schemes and no longer list those headers as
+// parameters.
+func TestSecuritySchemeGoldenFiles(t *testing.T) {
+	for _, service := range []string{"AccountService", "BackofficeService", "PartnerService"} {
+		for _, format := range []string{"yaml", "json"} {
+			goldenFile := fmt.Sprintf("testdata/golden/%s/%s.openapi.%s", format, service, format)
+			t.Run(filepath.Base(goldenFile), func(t *testing.T) {
+				content, err := os.ReadFile(goldenFile)
+				if err != nil {
+					t.Fatalf("Failed to read golden file: %v", err)
+				}
+				document, err := libopenapi.NewDocument(content)
+				if err != nil {
+					t.Fatalf("libopenapi rejected the document: %v", err)
+				}
+				model, err := document.BuildV3Model()
+				if err != nil {
+					t.Fatalf("libopenapi could not build the model: %v", err)
+				}
+
+				schemes := model.Model.Components.SecuritySchemes
+				for path, item := range model.Model.Paths.PathItems.FromOldest() {
+					for verb, operation := range item.GetOperations().FromOldest() {
+						if len(operation.Security) == 0 {
+							t.Errorf("%s %s has no security requirement", verb, path)
+						}
+						for _, requirement := range operation.Security {
+							for name := range requirement.Requirements.KeysFromOldest() {
+								if _, ok := schemes.Get(name); !ok {
+									t.Errorf("%s %s requires undeclared scheme %q", verb, path, name)
+								}
+							}
+						}
+						for _, parameter := range operation.Parameters {
+							if _, ok := schemes.Get(parameter.Name); ok || parameter.Name == "Authorization" {
+								t.Errorf("%s %s lists auth header %q as a parameter", verb, path, parameter.Name)
+							}
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
 // BenchmarkExhaustiveComparison benchmarks the golden file comparison process.
 func BenchmarkExhaustiveComparison(b *testing.B) {
 	// Read a sample golden file (YAML format)
@@ -2,6 +2,10 @@ package openapiv3
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
 
 	"google.golang.org/protobuf/proto"
@@ -11,9 +15,12 @@ import (
 	"github.com/pb33f/libopenapi/orderedmap"
 	yaml "go.yaml.in/yaml/v4"
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	k8syaml "sigs.k8s.io/yaml"
 
+	"github.com/SebastienMelki/sebuf/http"
 	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/manifest"
 )
 
 // OutputFormat represents the output format for the OpenAPI document.
@@ -22,6 +29,9 @@ type OutputFormat string
 const (
 	FormatYAML OutputFormat = "yaml"
 	FormatJSON OutputFormat = "json"
+	// FormatToolsJSON writes function-calling tool definitions instead of
+	// OpenAPI documents; see Tool.
+	FormatToolsJSON OutputFormat = "tools-json"
 )
 
 // HTTP method constants (lowercase for OpenAPI).
@@ -39,6 +49,13 @@ type Generator struct {
 	schemas    *orderedmap.Map[string, *base.SchemaProxy]
 	format     OutputFormat
 	bundleMode bool
+	// schemaNames holds the component name of every message collected by
+	// CollectReferencedMessages, resolved before any schema references them.
+	schemaNames map[protoreflect.FullName]string
+	// manifest records the documented routes when Run writes a manifest.
+	manifest *manifest.Builder
+	// warnings receives generation-time warnings. Defaults to os.Stderr.
+	warnings io.Writer
 }
 
 // NewGenerator creates a new OpenAPI generator with the specified output format.
@@ -140,45 +157,92 @@ func (g *Generator) ProcessService(service *protogen.Service) {
 	// In per-service mode we derive the title from the service name.
 	if !g.bundleMode {
 		g.doc.Info.Title = fmt.Sprintf("%s API", service.Desc.Name())
+		if description := versionsDescription(annotations.GetServiceVersions(service)); description != "" {
+			g.doc.Info.Description = description
+		}
 	}
 
 	g.processService(service)
 }
 
+// versionsDescription describes the API versions of a versioned service: the paths
+// document the default version, and the other versions are listed with their base
+// paths. It returns "" for services without versions.
+func versionsDescription(versions []annotations.APIVersion) string {
+	defaultVersion := annotations.DefaultAPIVersion(versions)
+	if defaultVersion == nil {
+		return ""
+	}
+	var others []string
+	for _, version := range versions {
+		if version.Name == defaultVersion.Name {
+			continue
+		}
+		details := []string{version.Name}
+		if version.Deprecated {
+			details = append(details, "deprecated")
+		}
+		if version.Sunset != "" {
+			details = append(details, "sunset "+version.Sunset)
+		}
+		others = append(others, fmt.Sprintf("%s (%s)", version.BasePath, strings.Join(details, ", ")))
+	}
+	description := fmt.Sprintf(
+		"Paths are documented for API version %s (%s).", defaultVersion.Name, defaultVersion.BasePath,
+	)
+	if len(others) > 0 {
+		description += " The same operations are also served under: " + strings.Join(others, "; ") + "."
+	}
+	return description
+}
+
 // CollectReferencedMessages recursively collects all messages referenced by a service.
 // This includes input/output messages and all their nested field types.
+// Messages are gathered first so that every component name is known before
+// schemas reference each other, which also lets recursive messages refer to
+// themselves.
 func (g *Generator) CollectReferencedMessages(service *protogen.Service) {
 	// Track processed messages to avoid infinite recursion
 	processed := make(map[string]bool)
 
 	// Collect messages from all methods
+	var messages []*protogen.Message
 	for _, method := range service.Methods {
-		g.collectMessageRecursive(method.Input, processed)
-		g.collectMessageRecursive(method.Output, processed)
+		messages = collectMessageRecursive(method.Input, processed, messages)
+		messages = collectMessageRecursive(method.Output, processed, messages)
+	}
+
+	g.assignSchemaNames(messages)
+	for _, message := range messages {
+		g.processMessage(message)
 	}
 }
 
-// collectMessageRecursive recursively processes a message and all its dependencies.
-func (g *Generator) collectMessageRecursive(message *protogen.Message, processed map[string]bool) {
-	if message == nil {
-		return
+// collectMessageRecursive appends a message and all its dependencies to messages.
+func collectMessageRecursive(
+	message *protogen.Message,
+	processed map[string]bool,
+	messages []*protogen.Message,
+) []*protogen.Message {
+	// Any, Struct, Value and ListValue are inlined as free-form schemas
+	if message == nil || annotations.IsDynamicJSONMessage(message) {
+		return messages
 	}
 
 	// Use the fully qualified name as the key to avoid duplicates
 	key := string(message.Desc.FullName())
 	if processed[key] {
-		return
+		return messages
 	}
 	processed[key] = true
 
-	// Process this message
-	g.processMessage(message)
+	messages = append(messages, message)
 
-	// Process all field types
+	// Collect all field types
 	for _, field := range message.Fields {
 		if field.Message != nil {
-			// Recursively process message fields
-			g.collectMessageRecursive(field.Message, processed)
+			// Recursively collect message fields
+			messages = collectMessageRecursive(field.Message, processed, messages)
 		}
 
 		// For maps, the value type might be a message
@@ -186,22 +250,58 @@ func (g *Generator) collectMessageRecursive(message *protogen.Message, processed
 			// Map entry messages have a value field (field 2)
 			for _, mapField := range field.Message.Fields {
 				if mapField.Desc.Number() == 2 && mapField.Message != nil {
-					g.collectMessageRecursive(mapField.Message, processed)
+					messages = collectMessageRecursive(mapField.Message, processed, messages)
 				}
 			}
 		}
 	}
 
-	// Process nested messages
+	// Collect nested messages
 	for _, nested := range message.Messages {
-		g.collectMessageRecursive(nested, processed)
+		messages = collectMessageRecursive(nested, processed, messages)
 	}
+	return messages
 }
 
-// getSchemaName generates a schema name for a protobuf message.
-// Since each service generates its own OpenAPI file, we can use simple message names
-// without package prefixes to avoid collisions.
+// assignSchemaNames names the components of the collected messages. A message
+// keeps its short name when no other collected message shares it; otherwise
+// every message sharing it is named after its fully-qualified name
+// (models.Timestamp -> models_Timestamp), so neither overwrites the other.
+func (g *Generator) assignSchemaNames(messages []*protogen.Message) {
+	if g.bundleMode {
+		return // Bundle names are always fully qualified
+	}
+
+	byShortName := make(map[protoreflect.Name][]protoreflect.FullName)
+	for _, message := range messages {
+		if message.Desc.IsMapEntry() {
+			continue
+		}
+		name := message.Desc.Name()
+		byShortName[name] = append(byShortName[name], message.Desc.FullName())
+	}
+
+	if g.schemaNames == nil {
+		g.schemaNames = make(map[protoreflect.FullName]string)
+	}
+	for name, fullNames := range byShortName {
+		for _, fullName := range fullNames {
+			if len(fullNames) == 1 {
+				g.schemaNames[fullName] = string(name)
+			} else {
+				g.schemaNames[fullName] = strings.ReplaceAll(string(fullName), ".", "_")
+			}
+		}
+	}
+}
+
+// getSchemaName returns the component name of a protobuf message. Each service
+// generates its own OpenAPI file, so messages use their short name unless
+// CollectReferencedMessages found another message with the same one.
 func (g *Generator) getSchemaName(message *protogen.Message) string {
+	if name, ok := g.schemaNames[message.Desc.FullName()]; ok {
+		return name
+	}
 	if g.bundleMode {
 		// Proto-package-qualified name keeps schema slots unique across services.
 		// e.g. sebuf.test.User -> sebuf_test_User. Built-in error schemas are added
@@ -212,7 +312,12 @@ func (g *Generator) getSchemaName(message *protogen.Message) string {
 }
 
 // processMessage converts a protobuf message to an OpenAPI schema.
+// Map entry messages are skipped: maps are inlined as additionalProperties.
 func (g *Generator) processMessage(message *protogen.Message) {
+	if message.Desc.IsMapEntry() {
+		return
+	}
+
 	schema := g.buildObjectSchema(message)
 	schemaName := g.getSchemaName(message)
 	g.schemas.Set(schemaName, schema)
@@ -232,7 +337,7 @@ func (g *Generator) buildObjectSchema(message *protogen.Message) *base.SchemaPro
 		return g.buildRootUnwrapSchema(message, rootUnwrap)
 	}
 
-	// Check if message has flatten fields -- use allOf for clean representation
+	// Check if message has flatten fields -- inline the prefixed child properties
 	if annotations.HasFlattenFields(message) {
 		return g.buildFlattenedObjectSchema(message)
 	}
@@ -247,7 +352,7 @@ func (g *Generator) buildObjectSchema(message *protogen.Message) *base.SchemaPro
 
 	for _, field := range message.Fields {
 		fieldSchema := g.convertField(field)
-		fieldName := field.Desc.JSONName()
+		fieldName := annotations.JSONFieldName(field)
 		properties.Set(fieldName, fieldSchema)
 
 		// Check if field has the required constraint from buf.validate
@@ -274,74 +379,24 @@ func (g *Generator) buildObjectSchema(message *protogen.Message) *base.SchemaPro
 }
 
 // buildOneofDiscriminatorSchema creates an OpenAPI schema for messages with discriminated oneofs.
-// For flattened mode: generates per-variant schemas with oneOf + discriminator.
-// For non-flattened mode: object schema with discriminator property and oneOf for variant routing.
-//
-
+// The message becomes a oneOf over one registered schema per variant (named Message_value), with a
+// discriminator mapping each discriminator value to its variant schema. Every variant schema holds
+// the message's other fields, the discriminator property fixed to the variant's value, and the
+// variant itself: its message fields inlined when the oneof is flattened, or the variant field
+// when it is not. This matches the JSON the generated marshalers emit when the oneof is set.
 func (g *Generator) buildOneofDiscriminatorSchema(message *protogen.Message) *base.SchemaProxy {
-	// Collect discriminated oneofs
-	var discriminatedOneofs []*annotations.OneofDiscriminatorInfo
+	// The first discriminated oneof selects the variant; the JSON carries one discriminator per message
+	var info *annotations.OneofDiscriminatorInfo
 	for _, oneof := range message.Oneofs {
-		info := annotations.GetOneofDiscriminatorInfo(oneof)
-		if info != nil {
-			discriminatedOneofs = append(discriminatedOneofs, info)
-		}
-	}
-
-	// Build set of fields that belong to discriminated oneofs
-	oneofFields := make(map[string]bool)
-	for _, info := range discriminatedOneofs {
-		for _, variant := range info.Variants {
-			oneofFields[string(variant.Field.Desc.Name())] = true
-		}
-	}
-
-	// Check if any discriminated oneofs are flattened
-	hasFlattenedOneof := false
-	for _, info := range discriminatedOneofs {
-		if info.Flatten {
-			hasFlattenedOneof = true
+		if info = annotations.GetOneofDiscriminatorInfo(oneof); info != nil {
 			break
 		}
 	}
 
-	if hasFlattenedOneof {
-		return g.buildFlattenedOneofSchema(message, discriminatedOneofs, oneofFields)
-	}
-	return g.buildNestedOneofSchema(message, discriminatedOneofs, oneofFields)
-}
-
-// buildFlattenedOneofSchema generates per-variant schemas with oneOf + discriminator.
-// Each variant schema includes common fields + discriminator field + variant's own fields.
-func (g *Generator) buildFlattenedOneofSchema(
-	message *protogen.Message,
-	discriminatedOneofs []*annotations.OneofDiscriminatorInfo,
-	oneofFields map[string]bool,
-) *base.SchemaProxy {
 	msgName := g.getSchemaName(message)
-
-	// For each flattened oneof, generate per-variant schemas
-	var allVariantRefs []*base.SchemaProxy
-	var allMappings []*annotations.OneofDiscriminatorInfo
-
-	for _, info := range discriminatedOneofs {
-		if !info.Flatten {
-			continue
-		}
-
-		refs := g.buildFlattenedVariantSchemas(message, info, msgName, oneofFields)
-		allVariantRefs = append(allVariantRefs, refs...)
-		allMappings = append(allMappings, info)
-	}
-
-	// Build main schema with oneOf + discriminator
 	schema := &base.Schema{
-		OneOf: allVariantRefs,
-	}
-
-	// Build discriminator with mapping (use first flattened oneof's discriminator)
-	if len(allMappings) > 0 {
-		schema.Discriminator = g.buildFlattenedDiscriminator(allMappings[0], msgName)
+		OneOf:         g.buildOneofVariantSchemas(message, info, msgName),
+		Discriminator: buildOneofDiscriminator(info, msgName),
 	}
 
 	// Add description from message comments
@@ -352,68 +407,70 @@ func (g *Generator) buildFlattenedOneofSchema(
 	return base.CreateSchemaProxy(schema)
 }
 
-// buildFlattenedVariantSchemas creates and registers per-variant schemas for a flattened oneof.
-func (g *Generator) buildFlattenedVariantSchemas(
+// oneofVariantSchemaName returns the component name of a discriminated oneof variant schema.
+func oneofVariantSchemaName(msgName string, variant annotations.OneofVariant) string {
+	return fmt.Sprintf("%s_%s", msgName, variant.DiscriminatorVal)
+}
+
+// buildOneofVariantSchemas creates and registers the per-variant schemas of a discriminated oneof.
+func (g *Generator) buildOneofVariantSchemas(
 	message *protogen.Message,
 	info *annotations.OneofDiscriminatorInfo,
 	msgName string,
-	oneofFields map[string]bool,
 ) []*base.SchemaProxy {
 	var refs []*base.SchemaProxy
 
 	for _, variant := range info.Variants {
-		variantSchemaName := fmt.Sprintf("%s_%s", msgName, variant.DiscriminatorVal)
-
-		// Build variant schema: common fields + discriminator + variant fields
 		variantProps := orderedmap.New[string, *base.SchemaProxy]()
+		required := []string{info.Discriminator}
 
-		// Add common (non-oneof) fields
+		// Add common fields: everything outside the discriminated oneof
 		for _, field := range message.Fields {
-			if oneofFields[string(field.Desc.Name())] {
+			if field.Oneof == info.Oneof {
 				continue
 			}
-			variantProps.Set(field.Desc.JSONName(), g.convertField(field))
+			fieldName := annotations.JSONFieldName(field)
+			variantProps.Set(fieldName, g.convertField(field))
+			if checkIfFieldRequired(field) {
+				required = append(required, fieldName)
+			}
 		}
 
 		// Add discriminator field
-		discSchema := &base.Schema{
+		variantProps.Set(info.Discriminator, base.CreateSchemaProxy(&base.Schema{
 			Type: []string{"string"},
 			Enum: []*yaml.Node{{Kind: yaml.ScalarNode, Value: variant.DiscriminatorVal}},
-		}
-		variantProps.Set(info.Discriminator, base.CreateSchemaProxy(discSchema))
+		}))
 
-		// Add variant's message fields (flattened to parent level)
-		if variant.IsMessage {
+		if info.Flatten && variant.IsMessage {
+			// Flattened: the variant's message fields sit at the parent level
 			for _, childField := range variant.Field.Message.Fields {
-				variantProps.Set(childField.Desc.JSONName(), g.convertField(childField))
+				variantProps.Set(annotations.JSONFieldName(childField), g.convertField(childField))
 			}
+		} else {
+			// Nested: the variant field is always present once the oneof is set
+			fieldName := annotations.JSONFieldName(variant.Field)
+			variantProps.Set(fieldName, g.convertField(variant.Field))
+			required = append(required, fieldName)
 		}
 
-		variantSchema := &base.Schema{
+		variantSchemaName := oneofVariantSchemaName(msgName, variant)
+		g.schemas.Set(variantSchemaName, base.CreateSchemaProxy(&base.Schema{
 			Type:       []string{"object"},
 			Properties: variantProps,
-			Required:   []string{info.Discriminator},
-		}
-
-		// Register and reference the variant schema
-		g.schemas.Set(variantSchemaName, base.CreateSchemaProxy(variantSchema))
-		refs = append(refs, base.CreateSchemaProxyRef(
-			fmt.Sprintf("#/components/schemas/%s", variantSchemaName),
-		))
+			Required:   required,
+		}))
+		refs = append(refs, base.CreateSchemaProxyRef("#/components/schemas/"+variantSchemaName))
 	}
 
 	return refs
 }
 
-// buildFlattenedDiscriminator creates the discriminator object with mapping for a flattened oneof.
-func (g *Generator) buildFlattenedDiscriminator(
-	info *annotations.OneofDiscriminatorInfo,
-	msgName string,
-) *base.Discriminator {
+// buildOneofDiscriminator creates the discriminator object mapping each value to its variant schema.
+func buildOneofDiscriminator(info *annotations.OneofDiscriminatorInfo, msgName string) *base.Discriminator {
 	mapping := orderedmap.New[string, string]()
 	for _, variant := range info.Variants {
-		variantSchemaName := fmt.Sprintf("%s_%s", msgName, variant.DiscriminatorVal)
-		mapping.Set(variant.DiscriminatorVal, fmt.Sprintf("#/components/schemas/%s", variantSchemaName))
+		mapping.Set(variant.DiscriminatorVal, "#/components/schemas/"+oneofVariantSchemaName(msgName, variant))
 	}
 	return &base.Discriminator{
 		PropertyName: info.Discriminator,
@@ -421,31 +478,29 @@ func (g *Generator) buildFlattenedDiscriminator(
 	}
 }
 
-// buildNestedOneofSchema generates an object schema with discriminator property
-// and oneOf for non-flattened variant routing.
-func (g *Generator) buildNestedOneofSchema(
-	message *protogen.Message,
-	discriminatedOneofs []*annotations.OneofDiscriminatorInfo,
-	oneofFields map[string]bool,
-) *base.SchemaProxy {
+// buildFlattenedObjectSchema creates an object schema for messages with flatten fields.
+// Each flattened field is replaced by its child message's fields, with the flatten prefix
+// applied to their names, at the position of the flattened field.
+func (g *Generator) buildFlattenedObjectSchema(message *protogen.Message) *base.SchemaProxy {
 	properties := orderedmap.New[string, *base.SchemaProxy]()
 	var required []string
 
-	// Add non-oneof fields
 	for _, field := range message.Fields {
-		if oneofFields[string(field.Desc.Name())] {
+		if annotations.IsFlattenField(field) && field.Message != nil {
+			prefix := annotations.GetFlattenPrefix(field)
+			for _, childField := range field.Message.Fields {
+				properties.Set(prefix+annotations.JSONFieldName(childField), g.convertField(childField))
+			}
 			continue
 		}
-		fieldName := field.Desc.JSONName()
+
+		fieldName := annotations.JSONFieldName(field)
 		properties.Set(fieldName, g.convertField(field))
 		if checkIfFieldRequired(field) {
 			required = append(required, fieldName)
 		}
 	}
 
-	// For each non-flattened discriminated oneof, add discriminator property and oneOf
-	oneOfSchemas, discInfo := g.buildNestedOneofVariants(discriminatedOneofs, properties)
-
 	schema := &base.Schema{
 		Type:       []string{"object"},
 		Properties: properties,
@@ -455,147 +510,6 @@ func (g *Generator) buildNestedOneofSchema(
 		schema.Required = required
 	}
 
-	if len(oneOfSchemas) > 0 {
-		schema.OneOf = oneOfSchemas
-	}
-
-	if discInfo != nil {
-		schema.Discriminator = g.buildNestedDiscriminator(discInfo)
-	}
-
-	// Add description from comments
-	if message.Comments.Leading != "" {
-		schema.Description = strings.TrimSpace(string(message.Comments.Leading))
-	}
-
-	return base.CreateSchemaProxy(schema)
-}
-
-// buildNestedOneofVariants builds oneOf variant schemas and discriminator enum property
-// for nested (non-flattened) discriminated oneofs.
-func (g *Generator) buildNestedOneofVariants(
-	discriminatedOneofs []*annotations.OneofDiscriminatorInfo,
-	properties *orderedmap.Map[string, *base.SchemaProxy],
-) ([]*base.SchemaProxy, *annotations.OneofDiscriminatorInfo) {
-	var oneOfSchemas []*base.SchemaProxy
-	var discInfo *annotations.OneofDiscriminatorInfo
-
-	for _, info := range discriminatedOneofs {
-		discInfo = info
-
-		// Add discriminator property with enum of all possible values
-		var enumValues []*yaml.Node
-		for _, variant := range info.Variants {
-			enumValues = append(enumValues, &yaml.Node{Kind: yaml.ScalarNode, Value: variant.DiscriminatorVal})
-		}
-		properties.Set(info.Discriminator, base.CreateSchemaProxy(&base.Schema{
-			Type: []string{"string"},
-			Enum: enumValues,
-		}))
-
-		// Build oneOf with per-variant schemas
-		for _, variant := range info.Variants {
-			variantProps := orderedmap.New[string, *base.SchemaProxy]()
-			fieldJSONName := variant.Field.Desc.JSONName()
-			if variant.IsMessage {
-				ref := fmt.Sprintf("#/components/schemas/%s", g.getSchemaName(variant.Field.Message))
-				variantProps.Set(fieldJSONName, base.CreateSchemaProxyRef(ref))
-			} else {
-				variantProps.Set(fieldJSONName, g.convertScalarField(variant.Field))
-			}
-			oneOfSchemas = append(oneOfSchemas, base.CreateSchemaProxy(&base.Schema{
-				Type:       []string{"object"},
-				Properties: variantProps,
-			}))
-		}
-	}
-
-	return oneOfSchemas, discInfo
-}
-
-// buildNestedDiscriminator creates the discriminator object with mapping for a nested oneof.
-func (g *Generator) buildNestedDiscriminator(info *annotations.OneofDiscriminatorInfo) *base.Discriminator {
-	mapping := orderedmap.New[string, string]()
-	for _, variant := range info.Variants {
-		if variant.IsMessage {
-			mapping.Set(
-				variant.DiscriminatorVal,
-				fmt.Sprintf("#/components/schemas/%s", g.getSchemaName(variant.Field.Message)),
-			)
-		}
-	}
-	return &base.Discriminator{
-		PropertyName: info.Discriminator,
-		Mapping:      mapping,
-	}
-}
-
-// buildFlattenedObjectSchema creates an OpenAPI schema using allOf for messages with flatten fields.
-// Non-flattened fields go into one object schema, each flattened field's children go into
-// separate object schemas with prefixed property names.
-func (g *Generator) buildFlattenedObjectSchema(message *protogen.Message) *base.SchemaProxy {
-	var allOfSchemas []*base.SchemaProxy
-
-	// First: collect non-flattened fields into a base object schema
-	baseProps := orderedmap.New[string, *base.SchemaProxy]()
-	var baseRequired []string
-
-	for _, field := range message.Fields {
-		if annotations.IsFlattenField(field) {
-			continue
-		}
-
-		fieldSchema := g.convertField(field)
-		fieldName := field.Desc.JSONName()
-		baseProps.Set(fieldName, fieldSchema)
-
-		if checkIfFieldRequired(field) {
-			baseRequired = append(baseRequired, fieldName)
-		}
-	}
-
-	if baseProps.Len() > 0 {
-		baseSchema := &base.Schema{
-			Type:       []string{"object"},
-			Properties: baseProps,
-		}
-		if len(baseRequired) > 0 {
-			baseSchema.Required = baseRequired
-		}
-		allOfSchemas = append(allOfSchemas, base.CreateSchemaProxy(baseSchema))
-	}
-
-	// Second: for each flattened field, create an object schema with prefixed properties
-	for _, field := range message.Fields {
-		if !annotations.IsFlattenField(field) || field.Message == nil {
-			continue
-		}
-
-		prefix := annotations.GetFlattenPrefix(field)
-		flatProps := orderedmap.New[string, *base.SchemaProxy]()
-
-		for _, childField := range field.Message.Fields {
-			childSchema := g.convertField(childField)
-			flattenedName := prefix + childField.Desc.JSONName()
-			flatProps.Set(flattenedName, childSchema)
-		}
-
-		flatSchema := &base.Schema{
-			Type:       []string{"object"},
-			Properties: flatProps,
-		}
-		if prefix != "" {
-			flatSchema.Description = fmt.Sprintf("Flattened from %s with prefix %q", field.Desc.Name(), prefix)
-		} else {
-			flatSchema.Description = fmt.Sprintf("Flattened from %s", field.Desc.Name())
-		}
-		allOfSchemas = append(allOfSchemas, base.CreateSchemaProxy(flatSchema))
-	}
-
-	schema := &base.Schema{
-		AllOf: allOfSchemas,
-	}
-
 	// Add description from message comments
 	if message.Comments.Leading != "" {
 		schema.Description = strings.TrimSpace(string(message.Comments.Leading))
@@ -761,19 +675,30 @@ func extractMethodHTTPInfo(service *protogen.Service, method *protogen.Method) m
 }
 
 // buildPathParameters creates OpenAPI path parameters from path variable names.
-func (g *Generator) buildPathParameters(method *protogen.Method, pathParams []string) []*v3.Parameter {
+// A parameter takes its schema and description from the request field it binds
+// to. Parameters bound to no field are documented as strings, with a warning
+// unless contextOnly lists them.
+func (g *Generator) buildPathParameters(
+	method *protogen.Method,
+	pathParams []string,
+	contextOnly map[string]bool,
+) []*v3.Parameter {
 	var parameters []*v3.Parameter
 	for _, paramName := range pathParams {
-		field := findFieldByName(method.Input, paramName)
+		field := annotations.FindFieldByProtoName(method.Input, paramName)
 		pathParam := &v3.Parameter{
 			Name:     paramName,
 			In:       "path",
 			Required: proto.Bool(true),
 		}
 		if field != nil {
-			pathParam.Schema = g.createFieldSchema(field)
+			pathParam.Schema = g.createPathParamSchema(field)
 			pathParam.Description = strings.TrimSpace(string(field.Comments.Leading))
 		} else {
+			if !contextOnly[paramName] {
+				g.warnf("Warning: path parameter {%s} of %s matches no field of %s; documenting it as a string.\n",
+					paramName, method.Desc.FullName(), method.Input.Desc.FullName())
+			}
 			pathParam.Schema = base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}})
 		}
 		parameters = append(parameters, pathParam)
@@ -781,6 +706,28 @@ func (g *Generator) buildPathParameters(method *protogen.Method, pathParams []st
 	return parameters
 }
 
+// buildBasePathParameters creates the OpenAPI path parameters of the service
+// base path, documented once on the path item rather than on each operation.
+func (g *Generator) buildBasePathParameters(service *protogen.Service, method *protogen.Method) []*v3.Parameter {
+	params := annotations.GetBasePathParams(service)
+	names := make([]string, 0, len(params))
+	contextOnly := make(map[string]bool)
+	for _, param := range params {
+		names = append(names, param.Name)
+		contextOnly[param.Name] = param.ContextOnly
+	}
+	return g.buildPathParameters(method, names, contextOnly)
+}
+
+// warnf prints a generation-time warning.
+func (g *Generator) warnf(format string, args ...any) {
+	w := g.warnings
+	if w == nil {
+		w = os.Stderr
+	}
+	_, _ = fmt.Fprintf(w, format, args...)
+}
+
 // buildQueryParameters creates OpenAPI query parameters from method input.
 func (g *Generator) buildQueryParameters(method *protogen.Method) []*v3.Parameter {
 	var parameters []*v3.Parameter
@@ -794,32 +741,195 @@ func (g *Generator) buildQueryParameters(method *protogen.Method) []*v3.Paramete
 		if qp.Field != nil {
 			queryParam.Schema = g.createFieldSchema(qp.Field)
 			queryParam.Description = strings.TrimSpace(string(qp.Field.Comments.Leading))
-			if qp.Field.Desc.IsList() {
+			queryParam.Deprecated = annotations.IsFieldDeprecated(qp.Field)
+			if qp.JSON {
+				g.documentJSONQueryParam(queryParam, qp)
+			} else if qp.Field.Desc.IsList() {
 				queryParam.Style = "form"
 				queryParam.Explode = proto.Bool(true)
 			}
 		} else {
 			queryParam.Schema = base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}})
 		}
+		documentQueryPresence(queryParam, qp)
+		documentQueryAliases(queryParam, qp)
 		parameters = append(parameters, queryParam)
 	}
 	return parameters
 }
 
+// documentQueryPresence notes on the parameter of qp when generated clients
+// send it, as annotations.GetQueryPresence decides.
+func documentQueryPresence(param *v3.Parameter, qp annotations.QueryParam) {
+	if qp.Field == nil {
+		return
+	}
+	var note string
+	switch annotations.GetQueryPresence(qp) {
+	case annotations.QueryPresenceNonEmpty:
+		note = "Generated clients leave it out when the list is empty."
+	case annotations.QueryPresenceWhenSet:
+		note = "Generated clients send it whenever it is set, even to its zero value."
+		if qp.Field.Message != nil {
+			note = "Generated clients send it whenever it is set."
+		}
+	case annotations.QueryPresenceNonZero:
+		switch zero := annotations.QueryZeroValue(qp); zero {
+		case "":
+			note = "Generated clients leave it out when it is empty."
+		case "false":
+			note = "Generated clients send it only when it is true."
+		default:
+			note = "Generated clients leave it out when it is " + zero + "."
+		}
+	}
+	if param.Description == "" {
+		param.Description = note
+	} else {
+		param.Description += "\n\n" + note
+	}
+}
+
+// documentQueryAliases notes on the parameter of qp the aliases servers also
+// accept it under. Clients send only its name, so the aliases are not
+// documented as parameters of their own.
+func documentQueryAliases(param *v3.Parameter, qp annotations.QueryParam) {
+	if len(qp.Aliases) == 0 {
+		return
+	}
+	note := "Also accepted as " + strings.Join(qp.Aliases, ", ") + ", deprecated; this name wins when both are sent."
+	if param.Description == "" {
+		param.Description = note
+	} else {
+		param.Description += "\n\n" + note
+	}
+}
+
+// buildHeaderFieldParameters creates OpenAPI header parameters from the request
+// fields declared with source HEADER.
+func (g *Generator) buildHeaderFieldParameters(method *protogen.Method) []*v3.Parameter {
+	var parameters []*v3.Parameter
+	for _, hp := range annotations.GetHeaderFieldParams(method.Input) {
+		parameters = append(parameters, &v3.Parameter{
+			Name:        hp.HeaderName,
+			In:          "header",
+			Required:    proto.Bool(checkIfFieldRequired(hp.Field)),
+			Schema:      g.createFieldSchema(hp.Field),
+			Description: strings.TrimSpace(string(hp.Field.Comments.Leading)),
+			Deprecated:  annotations.IsFieldDeprecated(hp.Field),
+		})
+	}
+	return parameters
+}
+
+// requestBodySchemaName returns the schema name of a request body. A request
+// message with fields declared with a non-body source gets a <Message>Body
+// schema without them, so those fields are documented only as parameters.
+func (g *Generator) requestBodySchemaName(message *protogen.Message) string {
+	name := g.getSchemaName(message)
+	excluded := annotations.GetBodyExcludedFields(message)
+	if len(excluded) == 0 {
+		return name
+	}
+	name += "Body"
+	if _, exists := g.schemas.Get(name); exists {
+		return name
+	}
+
+	// buildObjectSchema returns a fresh schema, so it can be trimmed in place
+	schema := g.buildObjectSchema(message).Schema()
+	for _, field := range excluded {
+		jsonName := annotations.JSONFieldName(field)
+		if schema.Properties != nil {
+			schema.Properties.Delete(jsonName)
+		}
+		schema.Required = slices.DeleteFunc(schema.Required, func(r string) bool { return r == jsonName })
+	}
+	if len(schema.Required) == 0 {
+		schema.Required = nil
+	}
+	g.schemas.Set(name, base.CreateSchemaProxy(schema))
+	return name
+}
+
+// requestMultipartSchemaName returns the schema name of a multipart request
+// body: a <Message>Multipart schema holding the request body's properties with
+// its bytes fields as binary file parts instead of base64 strings.
+func (g *Generator) requestMultipartSchemaName(message *protogen.Message) string {
+	bodyName := g.requestBodySchemaName(message)
+	name := g.getSchemaName(message) + "Multipart"
+	if _, exists := g.schemas.Get(name); exists {
+		return name
+	}
+
+	bodySchema, _ := g.schemas.Get(bodyName)
+	if bodySchema == nil {
+		bodySchema = g.buildObjectSchema(message)
+	}
+	source := bodySchema.Schema()
+	schema := &base.Schema{
+		Type:        source.Type,
+		Description: source.Description,
+		Required:    source.Required,
+		Properties:  orderedmap.New[string, *base.SchemaProxy](),
+	}
+	if source.Properties != nil {
+		for pair := source.Properties.First(); pair != nil; pair = pair.Next() {
+			schema.Properties.Set(pair.Key(), pair.Value())
+		}
+	}
+	for _, field := range message.Fields {
+		if !annotations.IsMultipartFileField(field) {
+			continue
+		}
+		jsonName := annotations.JSONFieldName(field)
+		if _, present := schema.Properties.Get(jsonName); !present {
+			continue
+		}
+		binary := &base.Schema{Type: []string{"string"}, Format: "binary"}
+		if field.Desc.IsList() {
+			binary = &base.Schema{
+				Type:  []string{"array"},
+				Items: &base.DynamicValue[*base.SchemaProxy, bool]{A: base.CreateSchemaProxy(binary)},
+			}
+		}
+		if field.Comments.Leading != "" {
+			binary.Description = strings.TrimSpace(string(field.Comments.Leading))
+		}
+		schema.Properties.Set(jsonName, base.CreateSchemaProxy(binary))
+	}
+	g.schemas.Set(name, base.CreateSchemaProxy(schema))
+	return name
+}
+
 // buildResponses creates the standard response map for an operation.
 func (g *Generator) buildResponses(method *protogen.Method) *orderedmap.Map[string, *v3.Response] {
 	responses := orderedmap.New[string, *v3.Response]()
 
-	// Success response
-	outputSchemaRef := fmt.Sprintf("#/components/schemas/%s", g.getSchemaName(method.Output))
-	successResponse := &v3.Response{
-		Description: "Successful response",
-		Content:     orderedmap.New[string, *v3.MediaType](),
+	// Success response, or one response per variant of a result message
+	if variants, _ := annotations.GetResponseVariants(method.Output); variants != nil {
+		g.addVariantResponses(responses, variants)
+	} else if annotations.IsRawBodyResponse(method) {
+		// The body is the raw bytes of the response, with the Content-Type it sets
+		successResponse := &v3.Response{
+			Description: "Successful response",
+			Content:     orderedmap.New[string, *v3.MediaType](),
+		}
+		successResponse.Content.Set(annotations.DefaultRawBodyContentType, &v3.MediaType{
+			Schema: base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}, Format: "binary"}),
+		})
+		responses.Set("200", successResponse)
+	} else {
+		outputSchemaRef := fmt.Sprintf("#/components/schemas/%s", g.getSchemaName(method.Output))
+		successResponse := &v3.Response{
+			Description: "Successful response",
+			Content:     orderedmap.New[string, *v3.MediaType](),
+		}
+		successResponse.Content.Set("application/json", &v3.MediaType{
+			Schema: base.CreateSchemaProxyRef(outputSchemaRef),
+		})
+		responses.Set("200", successResponse)
 	}
-	successResponse.Content.Set("application/json", &v3.MediaType{
-		Schema: base.CreateSchemaProxyRef(outputSchemaRef),
-	})
-	responses.Set("200", successResponse)
 
 	// Validation error response
 	validationErrorResponse := &v3.Response{
@@ -829,7 +939,31 @@ func (g *Generator) buildResponses(method *protogen.Method) *orderedmap.Map[stri
 	validationErrorResponse.Content.Set("application/json", &v3.MediaType{
 		Schema: base.CreateSchemaProxyRef("#/components/schemas/ValidationError"),
 	})
-	responses.Set("400", validationErrorResponse)
+	setResponse(responses, "400", validationErrorResponse)
+
+	// Idempotent methods reject a reused key with a different body, or one still in flight
+	if methodConfig := annotations.GetMethodHTTPConfig(method); methodConfig != nil && methodConfig.Idempotency {
+		conflictResponse := &v3.Response{
+			Description: "Idempotency-Key reused with a different request body, or still being processed",
+			Content:     orderedmap.New[string, *v3.MediaType](),
+		}
+		conflictResponse.Content.Set("application/json", &v3.MediaType{
+			Schema: base.CreateSchemaProxyRef("#/components/schemas/Error"),
+		})
+		setResponse(responses, "409", conflictResponse)
+	}
+
+	// Multipart uploads over the server's maximum body size are rejected
+	if methodConfig := annotations.GetMethodHTTPConfig(method); methodConfig != nil && methodConfig.AcceptMultipart {
+		tooLargeResponse := &v3.Response{
+			Description: "Request body exceeds the server's maximum body size",
+			Content:     orderedmap.New[string, *v3.MediaType](),
+		}
+		tooLargeResponse.Content.Set("application/json", &v3.MediaType{
+			Schema: base.CreateSchemaProxyRef("#/components/schemas/Error"),
+		})
+		setResponse(responses, "413", tooLargeResponse)
+	}
 
 	// Default error response - references the Error component schema
 	// which matches the sebuf.http.Error proto message (single "message" field)
@@ -845,6 +979,36 @@ func (g *Generator) buildResponses(method *protogen.Method) *orderedmap.Map[stri
 	return responses
 }
 
+// addVariantResponses adds the response of each variant of a result message:
+// the variant's message answered with the variant's status.
+func (g *Generator) addVariantResponses(
+	responses *orderedmap.Map[string, *v3.Response],
+	variants []annotations.ResponseVariant,
+) {
+	for _, variant := range variants {
+		description := strings.TrimSpace(string(variant.Field.Comments.Leading))
+		if description == "" {
+			description = fmt.Sprintf("The %s variant", variant.Field.Desc.Name())
+		}
+		response := &v3.Response{
+			Description: description,
+			Content:     orderedmap.New[string, *v3.MediaType](),
+		}
+		response.Content.Set("application/json", &v3.MediaType{
+			Schema: base.CreateSchemaProxyRef("#/components/schemas/" + g.getSchemaName(variant.Field.Message)),
+		})
+		responses.Set(strconv.Itoa(variant.Status), response)
+	}
+}
+
+// setResponse sets the response of a status unless a result message variant
+// already documents it.
+func setResponse(responses *orderedmap.Map[string, *v3.Response], status string, response *v3.Response) {
+	if _, exists := responses.Get(status); !exists {
+		responses.Set(status, response)
+	}
+}
+
 // assignOperationToPathItem assigns an operation to the correct HTTP method on a path item.
 func assignOperationToPathItem(pathItem *v3.PathItem, httpMethod string, operation *v3.Operation) {
 	switch httpMethod {
@@ -866,6 +1030,7 @@ func assignOperationToPathItem(pathItem *v3.PathItem, httpMethod string, operati
 // processMethod converts a protobuf RPC method to an OpenAPI operation.
 func (g *Generator) processMethod(service *protogen.Service, method *protogen.Method) {
 	info := extractMethodHTTPInfo(service, method)
+	g.manifest.AddRoute(method, strings.ToUpper(info.httpMethod), info.path)
 
 	// Check if this is an SSE streaming method
 	methodConfig := annotations.GetMethodHTTPConfig(method)
@@ -881,17 +1046,30 @@ func (g *Generator) processMethod(service *protogen.Service, method *protogen.Me
 		operation.Description = strings.TrimSpace(string(method.Comments.Leading))
 	}
 
+	if annotations.IsMethodDeprecated(method) || annotations.IsServiceDeprecated(service) {
+		operation.Deprecated = proto.Bool(true)
+	}
+
 	// Build parameters
 	var parameters []*v3.Parameter
 	allHeaders := annotations.CombineHeaders(
 		annotations.GetServiceHeaders(service),
 		annotations.GetMethodHeaders(method),
 	)
+	if methodConfig != nil && methodConfig.Idempotency {
+		// Declared headers take precedence over the implied Idempotency-Key
+		allHeaders = annotations.CombineHeaders([]*http.Header{idempotencyKeyHeader()}, allHeaders)
+	}
 	if len(allHeaders) > 0 {
-		parameters = convertHeadersToParameters(allHeaders)
+		// Authentication headers are documented as security requirements only
+		operation.Security = g.addSecuritySchemes(allHeaders)
+		parameters = convertHeadersToParameters(slices.DeleteFunc(slices.Clone(allHeaders), isAuthHeader))
 	}
-	parameters = append(parameters, g.buildPathParameters(method, info.pathParams)...)
+	pathParameters := g.buildPathParameters(method, info.pathParams, nil)
+	documentPathWildcard(pathParameters, annotations.PathWildcard(info.path))
+	parameters = append(parameters, pathParameters...)
 	parameters = append(parameters, g.buildQueryParameters(method)...)
+	parameters = append(parameters, g.buildHeaderFieldParameters(method)...)
 
 	if len(parameters) > 0 {
 		operation.Parameters = parameters
@@ -899,7 +1077,7 @@ func (g *Generator) processMethod(service *protogen.Service, method *protogen.Me
 
 	// Add request body for POST, PUT, PATCH
 	if info.httpMethod == httpMethodPost || info.httpMethod == httpMethodPut || info.httpMethod == httpMethodPatch {
-		inputSchemaRef := fmt.Sprintf("#/components/schemas/%s", g.getSchemaName(method.Input))
+		inputSchemaRef := fmt.Sprintf("#/components/schemas/%s", g.requestBodySchemaName(method.Input))
 		operation.RequestBody = &v3.RequestBody{
 			Required: proto.Bool(true),
 			Content:  orderedmap.New[string, *v3.MediaType](),
@@ -907,6 +1085,24 @@ func (g *Generator) processMethod(service *protogen.Service, method *protogen.Me
 		operation.RequestBody.Content.Set("application/json", &v3.MediaType{
 			Schema: base.CreateSchemaProxyRef(inputSchemaRef),
 		})
+		if methodConfig != nil && methodConfig.MergePatch {
+			// The body of a merge patch is the request message, with null clearing optional fields
+			operation.RequestBody.Content.Set(http.MergePatchContentType, &v3.MediaType{
+				Schema: base.CreateSchemaProxyRef(inputSchemaRef),
+			})
+		}
+		if methodConfig != nil && methodConfig.AcceptForm {
+			// Form keys bind to the same fields, with dots addressing nested messages
+			operation.RequestBody.Content.Set("application/x-www-form-urlencoded", &v3.MediaType{
+				Schema: base.CreateSchemaProxyRef(inputSchemaRef),
+			})
+		}
+		if methodConfig != nil && methodConfig.AcceptMultipart {
+			multipartSchemaRef := fmt.Sprintf("#/components/schemas/%s", g.requestMultipartSchemaName(method.Input))
+			operation.RequestBody.Content.Set("multipart/form-data", &v3.MediaType{
+				Schema: base.CreateSchemaProxyRef(multipartSchemaRef),
+			})
+		}
 	}
 
 	if isSSE {
@@ -914,14 +1110,68 @@ func (g *Generator) processMethod(service *protogen.Service, method *protogen.Me
 	} else {
 		operation.Responses = &v3.Responses{Codes: g.buildResponses(method)}
 	}
+	documentResponseHeaders(operation.Responses.Codes, annotations.GetResponseHeaders(method))
+	documentExamples(operation, method)
 
-	// Add to path items
-	existingPathItem, exists := g.doc.Paths.PathItems.Get(info.path)
+	// Add to path items, under a template without the wildcard syntax OpenAPI lacks
+	path := annotations.TemplatePath(info.path)
+	existingPathItem, exists := g.doc.Paths.PathItems.Get(path)
 	if !exists {
-		existingPathItem = &v3.PathItem{}
+		// Base path parameters are shared by every operation of the path
+		existingPathItem = &v3.PathItem{Parameters: g.buildBasePathParameters(service, method)}
 	}
 	assignOperationToPathItem(existingPathItem, info.httpMethod, operation)
-	g.doc.Paths.PathItems.Set(info.path, existingPathItem)
+	g.doc.Paths.PathItems.Set(path, existingPathItem)
+}
+
+// pathWildcardNote describes the path parameter of a {name...} wildcard, which
+// OpenAPI path templates cannot express.
+const pathWildcardNote = "Matches the rest of the path, slashes included: each segment is " +
+	"percent-encoded, but the slashes between segments are not."
+
+// documentPathWildcard notes on the parameter of the wildcard ending a path,
+// named wildcard, that its value spans several segments.
+func documentPathWildcard(parameters []*v3.Parameter, wildcard string) {
+	for _, param := range parameters {
+		if wildcard == "" || param.Name != wildcard {
+			continue
+		}
+		if param.Description == "" {
+			param.Description = pathWildcardNote
+		} else {
+			param.Description += "\n\n" + pathWildcardNote
+		}
+	}
+}
+
+// documentResponseHeaders lists the response_headers of a method in the
+// headers of each of its responses, errors included, as the server sets them
+// on every response.
+func documentResponseHeaders(responses *orderedmap.Map[string, *v3.Response], headers []annotations.ResponseHeader) {
+	if len(headers) == 0 {
+		return
+	}
+	for _, response := range responses.FromOldest() {
+		if response.Headers == nil {
+			response.Headers = orderedmap.New[string, *v3.Header]()
+		}
+		for _, header := range headers {
+			response.Headers.Set(header.Name, &v3.Header{
+				Schema:  base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+				Example: &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: header.Value},
+			})
+		}
+	}
+}
+
+// idempotencyKeyHeader is the header implied by idempotency: true.
+func idempotencyKeyHeader() *http.Header {
+	return &http.Header{
+		Name:        http.IdempotencyKeyHeader,
+		Description: "Unique key for this operation; retries with the same key and body replay the first response",
+		Type:        "string",
+		Required:    true,
+	}
 }
 
 // buildSSEResponses creates the SSE-specific response map for a streaming operation.
@@ -962,7 +1212,7 @@ func (g *Generator) buildSSEResponses(method *protogen.Method) *orderedmap.Map[s
 	validationErrorResponse.Content.Set("application/json", &v3.MediaType{
 		Schema: base.CreateSchemaProxyRef("#/components/schemas/ValidationError"),
 	})
-	responses.Set("400", validationErrorResponse)
+	setResponse(responses, "400", validationErrorResponse)
 
 	// Default error response
 	errorResponse := &v3.Response{
@@ -977,16 +1227,6 @@ func (g *Generator) buildSSEResponses(method *protogen.Method) *orderedmap.Map[s
 	return responses
 }
 
-// findFieldByName finds a field in a message by its proto name.
-func findFieldByName(message *protogen.Message, fieldName string) *protogen.Field {
-	for _, field := range message.Fields {
-		if string(field.Desc.Name()) == fieldName {
-			return field
-		}
-	}
-	return nil
-}
-
 // createFieldSchema creates an OpenAPI schema for a protobuf field.
 func (g *Generator) createFieldSchema(field *protogen.Field) *base.SchemaProxy {
 	schema := g.createScalarFieldSchema(field)
@@ -1002,6 +1242,24 @@ func (g *Generator) createFieldSchema(field *protogen.Field) *base.SchemaProxy {
 // createScalarFieldSchema creates the OpenAPI schema for the scalar kind of a field,
 // ignoring repeated/map modifiers. Used as the item schema for repeated fields.
 func (g *Generator) createScalarFieldSchema(field *protogen.Field) *base.SchemaProxy {
+	return base.CreateSchemaProxy(scalarParamSchema(field))
+}
+
+// createPathParamSchema creates the schema of a path parameter bound to field:
+// the parameter schema of its kind, an integer for int64 fields with
+// int64_encoding=NUMBER, and the field's buf.validate constraints.
+func (g *Generator) createPathParamSchema(field *protogen.Field) *base.SchemaProxy {
+	schema := scalarParamSchema(field)
+	if annotations.IsInt64NumberEncoding(field) {
+		schema.Type = []string{headerTypeInteger}
+	}
+	extractValidationConstraints(field, schema)
+	return base.CreateSchemaProxy(schema)
+}
+
+// scalarParamSchema returns the schema of a parameter value bound to the scalar
+// kind of field.
+func scalarParamSchema(field *protogen.Field) *base.Schema {
 	schema := &base.Schema{}
 
 	switch field.Desc.Kind().String() {
@@ -1029,27 +1287,44 @@ func (g *Generator) createScalarFieldSchema(field *protogen.Field) *base.SchemaP
 	case headerTypeDouble:
 		schema.Type = []string{headerTypeNumber}
 		schema.Format = headerTypeDouble
+	case "enum":
+		// Parameters are bound from strings: list every string the server accepts
+		schema.Type = []string{headerTypeString}
+		if field.Enum != nil {
+			schema.Enum = enumParamValues(field.Enum)
+		}
 	default:
 		schema.Type = []string{headerTypeString}
 	}
 
-	return base.CreateSchemaProxy(schema)
+	return schema
 }
 
 // addBuiltinErrorSchemas adds the Error, ValidationError, and FieldViolation schemas to the components.
 // These schemas match the proto definitions in proto/sebuf/http/errors.proto.
 func addBuiltinErrorSchemas(schemas *orderedmap.Map[string, *base.SchemaProxy]) {
 	// Add Error schema - matches sebuf.http.Error proto message
-	// Error has a single "message" field (string)
+	// Error has a "message" field, an optional machine-readable "code" and a "details" string map
 	errorProps := orderedmap.New[string, *base.SchemaProxy]()
 	errorProps.Set("message", base.CreateSchemaProxy(&base.Schema{
 		Type:        []string{"string"},
 		Description: "Error message (e.g., 'user not found', 'database connection failed')",
 	}))
+	errorProps.Set("code", base.CreateSchemaProxy(&base.Schema{
+		Type:        []string{"string"},
+		Description: "Machine-readable error code (e.g., 'NOT_FOUND', 'UNIMPLEMENTED'). Empty unless the error was raised with a code.",
+	}))
+	errorProps.Set("details", base.CreateSchemaProxy(&base.Schema{
+		Type:        []string{"object"},
+		Description: "Additional machine-readable context (e.g., {'resource_id': 'user-42'})",
+		AdditionalProperties: &base.DynamicValue[*base.SchemaProxy, bool]{
+			A: base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+		},
+	}))
 
 	errorSchema := base.CreateSchemaProxy(&base.Schema{
 		Type:        []string{"object"},
-		Description: "Error is returned when a handler encounters an error. It contains a simple error message that the developer can customize.",
+		Description: "Error is returned when a handler encounters an error. It contains an error message that the developer can customize, and an optional machine-readable code and details clients can branch on.",
 		Properties:  errorProps,
 	})
 	schemas.Set("Error", errorSchema)
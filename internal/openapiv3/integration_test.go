@@ -94,6 +94,72 @@ func TestPluginIntegration(t *testing.T) {
 	}
 }
 
+// TestPluginWarnsOnUnmatchedPathParameter checks that a path parameter no
+// request field binds to is reported on stderr, and only that one.
+func TestPluginWarnsOnUnmatchedPathParameter(t *testing.T) {
+	pluginPath := "./protoc-gen-openapiv3-path-params-test"
+	buildCmd := exec.Command("go", "build", "-o", pluginPath, "../../cmd/protoc-gen-openapiv3")
+	if err := buildCmd.Run(); err != nil {
+		t.Fatalf("Failed to build plugin: %v", err)
+	}
+	defer os.Remove(pluginPath)
+
+	cmd := exec.Command("protoc",
+		"--plugin=protoc-gen-openapiv3="+pluginPath,
+		"--openapiv3_out="+t.TempDir(),
+		"--proto_path=testdata/proto",
+		"--proto_path=../../proto",
+		"testdata/proto/path_params.proto",
+	)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("protoc failed: %v\nOutput: %s", err, string(output))
+	}
+
+	const want = "Warning: path parameter {version} of testdata.pathparams.NestedResourceService.ListResources " +
+		"matches no field of testdata.pathparams.ListResourcesRequest; documenting it as a string."
+	if !strings.Contains(string(output), want) {
+		t.Errorf("output does not contain %q:\n%s", want, output)
+	}
+	if n := strings.Count(string(output), "Warning: path parameter"); n != 1 {
+		t.Errorf("got %d path parameter warnings, want 1:\n%s", n, output)
+	}
+}
+
+// TestPluginRejectsInvalidMethodExample checks that generation fails on an
+// example whose JSON is not the method's response message, naming the method,
+// the example and the parse error.
+func TestPluginRejectsInvalidMethodExample(t *testing.T) {
+	pluginPath := "./protoc-gen-openapiv3-examples-test"
+	buildCmd := exec.Command("go", "build", "-o", pluginPath, "../../cmd/protoc-gen-openapiv3")
+	if err := buildCmd.Run(); err != nil {
+		t.Fatalf("Failed to build plugin: %v", err)
+	}
+	defer os.Remove(pluginPath)
+
+	cmd := exec.Command("protoc",
+		"--plugin=protoc-gen-openapiv3="+pluginPath,
+		"--openapiv3_out="+t.TempDir(),
+		"--proto_path=testdata/proto",
+		"--proto_path=../../proto",
+		"testdata/proto/invalid_method_example.proto",
+	)
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("protoc succeeded, want the invalid example rejected:\n%s", output)
+	}
+
+	for _, want := range []string{
+		`testdata.invalidmethodexample.BookService.GetBook: response of example "default" is not a ` +
+			"testdata.invalidmethodexample.Book",
+		"invalid value for int32 field pages",
+	} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("output does not contain %q:\n%s", want, output)
+		}
+	}
+}
+
 // TestPluginErrorHandling tests how the plugin handles various error conditions.
 func TestPluginErrorHandling(t *testing.T) {
 	// Build the plugin binary for testing
@@ -301,6 +367,11 @@ func TestPluginServiceGeneration(t *testing.T) {
 				"DeprecatedHeaderService",
 			},
 		},
+		{
+			name:             "service_visibility",
+			protoFile:        "visibility.proto",
+			expectedServices: []string{"InventoryService"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -346,11 +417,9 @@ func TestPluginServiceGeneration(t *testing.T) {
 				}
 			}
 
-			// Verify no unexpected services were generated (basic check)
+			// Verify no unexpected services were generated
 			if len(generatedServices) != len(tc.expectedServices) {
-				t.Logf("Generated %d services, expected %d", len(generatedServices), len(tc.expectedServices))
-				t.Logf("Generated services: %v", getMapKeys(generatedServices))
-				t.Logf("Expected services: %v", tc.expectedServices)
+				t.Errorf("Generated services %v, expected %v", getMapKeys(generatedServices), tc.expectedServices)
 			}
 		})
 	}
@@ -0,0 +1,152 @@
+package openapiv3
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	yaml "go.yaml.in/yaml/v4"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// documentJSONQueryParam documents a query parameter encoded as JSON_BASE64URL:
+// a string holding the base64url-encoded JSON of the field, whose schema is the
+// contentSchema, no longer than the encoding of max_bytes. Its example encodes
+// the field_examples of the message's fields, when there are any.
+func (g *Generator) documentJSONQueryParam(param *v3.Parameter, qp annotations.QueryParam) {
+	maxLength := int64(base64.RawURLEncoding.EncodedLen(qp.MaxJSONBytes))
+	content := base.CreateSchemaProxyRef("#/components/schemas/" + g.getSchemaName(qp.Field.Message))
+	if qp.Field.Desc.IsList() {
+		content = base.CreateSchemaProxy(&base.Schema{
+			Type:  []string{"array"},
+			Items: &base.DynamicValue[*base.SchemaProxy, bool]{A: content},
+		})
+	}
+	param.Schema = base.CreateSchemaProxy(&base.Schema{
+		Type:             []string{"string"},
+		ContentEncoding:  "base64url",
+		ContentMediaType: "application/json",
+		ContentSchema:    content,
+		MaxLength:        &maxLength,
+	})
+
+	what := "a " + string(qp.Field.Message.Desc.Name())
+	if qp.Field.Desc.IsList() {
+		what = "an array of " + string(qp.Field.Message.Desc.Name())
+	}
+	encoding := fmt.Sprintf("The JSON of %s, as in a request body, in unpadded base64url (RFC 4648 section 5). "+
+		"Values decoding to more than %d bytes are rejected.", what, qp.MaxJSONBytes)
+	if param.Description == "" {
+		param.Description = encoding
+	} else {
+		param.Description += "\n\n" + encoding
+	}
+
+	example, ok := messageExampleJSON(qp.Field.Message, nil, nil)
+	if !ok {
+		return
+	}
+	if qp.Field.Desc.IsList() {
+		example = "[" + example + "]"
+	}
+	param.Example = &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Value: base64.RawURLEncoding.EncodeToString([]byte(example)),
+	}
+}
+
+// messageExampleJSON returns the JSON object holding the fields of msg that
+// have field_examples, valued by annotations.ResolveExampleValue, and its
+// message fields holding any. It reports false when there are none. path holds
+// the enclosing messages, as for annotations.PopulatesExample; omit, which may
+// be nil, leaves out fields of msg itself.
+func messageExampleJSON(
+	msg *protogen.Message,
+	path []*protogen.Message,
+	omit func(*protogen.Field) bool,
+) (string, bool) {
+	members := messageExampleMembers(msg, path, "", omit)
+	if len(members) == 0 {
+		return "", false
+	}
+	return "{" + strings.Join(members, ",") + "}", true
+}
+
+// messageExampleMembers returns the members msg contributes to the JSON object
+// of messageExampleJSON. Flattened fields contribute their child's members
+// under the flatten prefix, which prefixes every key.
+func messageExampleMembers(
+	msg *protogen.Message,
+	path []*protogen.Message,
+	prefix string,
+	omit func(*protogen.Field) bool,
+) []string {
+	path = append(path[:len(path):len(path)], msg)
+	var members []string
+	for _, field := range msg.Fields {
+		if (omit != nil && omit(field)) || field.Desc.IsMap() || !annotations.PopulatesExample(field, path) {
+			continue
+		}
+		if annotations.IsFlattenField(field) && field.Message != nil {
+			childPrefix := prefix + annotations.GetFlattenPrefix(field)
+			members = append(members, messageExampleMembers(field.Message, path, childPrefix, nil)...)
+			continue
+		}
+		var value string
+		if field.Message != nil {
+			var ok bool
+			if value, ok = messageExampleJSON(field.Message, path, nil); !ok {
+				continue
+			}
+		} else {
+			if len(annotations.GetFieldExamples(field)) == 0 {
+				continue
+			}
+			value = scalarExampleJSON(field, annotations.ResolveExampleValue(field))
+		}
+		if field.Desc.IsList() {
+			value = "[" + value + "]"
+		}
+		members = append(members, strconv.Quote(prefix+annotations.JSONFieldName(field))+":"+value)
+	}
+	return members
+}
+
+// scalarExampleJSON returns the JSON of the example v of a scalar or enum
+// field, honoring its int64 and enum encodings.
+func scalarExampleJSON(field *protogen.Field, v protoreflect.Value) string {
+	var value any
+	switch field.Desc.Kind() {
+	case protoreflect.EnumKind:
+		enumValue := annotations.ExampleEnumValue(field, v)
+		switch {
+		case enumValue == nil || annotations.ResolveEnumEncoding(field) == http.EnumEncoding_ENUM_ENCODING_NUMBER:
+			value = v.Enum()
+		case annotations.GetEnumValueMapping(enumValue) != "":
+			value = annotations.GetEnumValueMapping(enumValue)
+		default:
+			value = string(enumValue.Desc.Name())
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		value = v.String()
+		if annotations.IsInt64NumberEncoding(field) {
+			value = json.Number(v.String())
+		}
+	default:
+		value = v.Interface()
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "null"
+	}
+	return string(data)
+}
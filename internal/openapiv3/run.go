@@ -0,0 +1,316 @@
+package openapiv3
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/manifest"
+	"github.com/SebastienMelki/sebuf/internal/pluginrun"
+)
+
+// Options configures a Run.
+type Options struct {
+	// Format selects the output format. Defaults to FormatYAML.
+	Format OutputFormat
+	// Bundle configures the single document collecting every service.
+	Bundle BundleOptions
+	// Manifest makes Run append sebuf.manifest.json, describing the documented
+	// routes and the generated files, to its response.
+	Manifest bool
+}
+
+// BundleOptions holds origin-level metadata for the bundled OpenAPI document.
+// All fields are optional; unset fields fall through to library defaults
+// (e.g. the bundled doc gets Title "API" and Version "1.0.0" if unspecified).
+type BundleOptions struct {
+	Enabled      bool     // Write the bundled document
+	Only         bool     // Skip the per-service documents
+	Output       string   // File name, defaulting to openapi.yaml or openapi.json
+	Title        string   // info.title
+	Version      string   // info.version
+	Description  string   // info.description
+	Servers      []string // servers[].url, in order
+	ContactName  string   // info.contact.name
+	ContactURL   string   // info.contact.url
+	ContactEmail string   // info.contact.email
+	LicenseName  string   // info.license.name
+	LicenseURL   string   // info.license.url
+}
+
+// Run generates the OpenAPI documents of req in memory, without reading stdin
+// or writing stdout: one per service, and/or a bundle of all of them. opts
+// supplies the defaults for plugin parameters; format, manifest and bundle_*
+// parameters in req override them. Invalid input is reported in the response's Error
+// field; the error is only set if generation panicked.
+func Run(req *pluginpb.CodeGeneratorRequest, opts Options) (*pluginpb.CodeGeneratorResponse, error) {
+	if req != nil {
+		opts = applyParameters(opts, parseParameters(req.GetParameter()))
+	}
+	var routes *manifest.Builder
+	if opts.Manifest {
+		routes = manifest.NewBuilder("protoc-gen-openapiv3")
+	}
+	resp, err := pluginrun.Run(req, nil, func(plugin *protogen.Plugin) error {
+		return generateFiles(plugin, opts, routes)
+	})
+	routes.Write(resp)
+	return resp, err
+}
+
+// applyParameters overrides opts with the format, manifest and bundle_* plugin
+// parameters.
+func applyParameters(opts Options, params map[string][]string) Options {
+	first := func(key string, target *string) {
+		if vs, ok := params[key]; ok && len(vs) > 0 {
+			*target = vs[0]
+		}
+	}
+	boolean := func(key string, target *bool) {
+		if vs, ok := params[key]; ok && len(vs) > 0 {
+			*target = vs[0] == "true" || vs[0] == "1"
+		}
+	}
+
+	if vs, ok := params["format"]; ok && len(vs) > 0 {
+		switch vs[0] {
+		case "json":
+			opts.Format = FormatJSON
+		case "yaml", "yml":
+			opts.Format = FormatYAML
+		case "tools-json":
+			opts.Format = FormatToolsJSON
+		}
+	}
+
+	boolean("manifest", &opts.Manifest)
+
+	bundle := &opts.Bundle
+	boolean("bundle", &bundle.Enabled)
+	boolean("bundle_only", &bundle.Only)
+	first("bundle_output", &bundle.Output)
+	first("bundle_title", &bundle.Title)
+	first("bundle_version", &bundle.Version)
+	first("bundle_description", &bundle.Description)
+	if servers, ok := params["bundle_server"]; ok {
+		bundle.Servers = servers
+	}
+	first("bundle_contact_name", &bundle.ContactName)
+	first("bundle_contact_url", &bundle.ContactURL)
+	first("bundle_contact_email", &bundle.ContactEmail)
+	first("bundle_license_name", &bundle.LicenseName)
+	first("bundle_license_url", &bundle.LicenseURL)
+	return opts
+}
+
+func generateFiles(plugin *protogen.Plugin, opts Options, routes *manifest.Builder) error {
+	format := opts.Format
+	if format == "" {
+		format = FormatYAML
+	}
+	if err := annotations.ValidateSyntax(plugin); err != nil {
+		return err
+	}
+	if err := annotations.ApplyVisibility(plugin, annotations.GeneratorOpenAPIv3); err != nil {
+		return err
+	}
+	for _, file := range plugin.Files {
+		if !file.Generate {
+			continue
+		}
+		for _, service := range file.Services {
+			if err := annotations.ValidateServiceHeaders(service); err != nil {
+				return err
+			}
+			if err := annotations.ValidateResponseHeaders(service); err != nil {
+				return err
+			}
+			if err := annotations.ValidateResponseStatuses(service); err != nil {
+				return err
+			}
+			if err := annotations.ValidateRawBodyResponses(service); err != nil {
+				return err
+			}
+			if err := annotations.ValidateQueryEncodings(service); err != nil {
+				return err
+			}
+			if err := annotations.ValidateQueryAliases(service); err != nil {
+				return err
+			}
+			if err := annotations.ValidateBasePathParams(service); err != nil {
+				return err
+			}
+			if err := annotations.ValidateMethodExamples(service); err != nil {
+				return err
+			}
+		}
+	}
+	if format == FormatToolsJSON {
+		if opts.Bundle.Enabled {
+			return errors.New("bundle is not supported with format=tools-json")
+		}
+		return generateToolsFiles(plugin, routes)
+	}
+
+	// Per-service output (default behaviour; suppressed when bundle_only=true).
+	if !opts.Bundle.Enabled || !opts.Bundle.Only {
+		for _, file := range plugin.Files {
+			if !file.Generate {
+				continue
+			}
+			if err := processFileServices(plugin, file, format, routes); err != nil {
+				return err
+			}
+		}
+	}
+
+	if opts.Bundle.Enabled {
+		return generateBundleFile(plugin, format, opts.Bundle, routes)
+	}
+	return nil
+}
+
+func processFileServices(
+	plugin *protogen.Plugin,
+	file *protogen.File,
+	format OutputFormat,
+	routes *manifest.Builder,
+) error {
+	for _, service := range file.Services {
+		generator := NewGenerator(format)
+		generator.manifest = routes
+
+		// Collect all messages referenced by this service, including those from other files
+		generator.CollectReferencedMessages(service)
+		generator.ProcessService(service)
+
+		output, err := generator.Render()
+		if err != nil {
+			return fmt.Errorf("rendering %s: %w", service.Desc.Name(), err)
+		}
+		filename := fmt.Sprintf("%s.openapi.%s", service.Desc.Name(), fileExtension(format))
+		if _, err = plugin.NewGeneratedFile(filename, "").Write(output); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateBundleFile collects every service across every generated proto file into a
+// single OpenAPI document with proto-package-qualified schema names.
+func generateBundleFile(
+	plugin *protogen.Plugin,
+	format OutputFormat,
+	bundle BundleOptions,
+	routes *manifest.Builder,
+) error {
+	generator := NewBundleGenerator(format)
+	generator.manifest = routes
+	applyBundleMetadata(generator, bundle)
+
+	serviceCount := 0
+	for _, file := range annotations.GeneratedFiles(plugin) {
+		for _, service := range file.Services {
+			generator.CollectReferencedMessages(service)
+			generator.ProcessService(service)
+			serviceCount++
+		}
+	}
+
+	// No services in the protoc invocation — skip writing an empty bundle.
+	if serviceCount == 0 {
+		return nil
+	}
+
+	output, err := generator.Render()
+	if err != nil {
+		return fmt.Errorf("rendering bundle: %w", err)
+	}
+	filename := bundle.Output
+	if filename == "" {
+		filename = "openapi." + fileExtension(format)
+	}
+	_, err = plugin.NewGeneratedFile(filename, "").Write(output)
+	return err
+}
+
+func applyBundleMetadata(g *Generator, bundle BundleOptions) {
+	var contact *base.Contact
+	if bundle.ContactName != "" || bundle.ContactURL != "" || bundle.ContactEmail != "" {
+		contact = &base.Contact{
+			Name:  bundle.ContactName,
+			URL:   bundle.ContactURL,
+			Email: bundle.ContactEmail,
+		}
+	}
+	var license *base.License
+	if bundle.LicenseName != "" || bundle.LicenseURL != "" {
+		license = &base.License{
+			Name: bundle.LicenseName,
+			URL:  bundle.LicenseURL,
+		}
+	}
+	g.SetInfo(bundle.Title, bundle.Version, bundle.Description, contact, license)
+	g.SetServers(bundle.Servers)
+}
+
+// fileExtension returns the file extension of a format.
+func fileExtension(format OutputFormat) string {
+	if format == FormatJSON {
+		return "json"
+	}
+	return "yaml"
+}
+
+// parseParameters parses protoc plugin parameters in the format
+// "key=value,key2=value2". Repeated keys (e.g. bundle_server) collect into a slice
+// in insertion order; the first value is used for scalar options.
+//
+// Commas inside values can be escaped with a backslash ("\,") — required for
+// bundle_description and similar prose fields. The escape sequence is unescaped
+// after the split.
+func parseParameters(parameter string) map[string][]string {
+	params := make(map[string][]string)
+	if parameter == "" {
+		return params
+	}
+
+	for _, pair := range splitUnescapedComma(parameter) {
+		const splitLimit = 2
+		kv := strings.SplitN(pair, "=", splitLimit)
+		if len(kv) != splitLimit {
+			continue
+		}
+		key := strings.TrimSpace(kv[0])
+		value := strings.ReplaceAll(strings.TrimSpace(kv[1]), `\,`, ",")
+		params[key] = append(params[key], value)
+	}
+	return params
+}
+
+// splitUnescapedComma splits on commas but treats "\," as a literal comma.
+func splitUnescapedComma(s string) []string {
+	var out []string
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && s[i+1] == ',' {
+			buf.WriteByte('\\')
+			buf.WriteByte(',')
+			i++
+			continue
+		}
+		if s[i] == ',' {
+			out = append(out, buf.String())
+			buf.Reset()
+			continue
+		}
+		buf.WriteByte(s[i])
+	}
+	out = append(out, buf.String())
+	return out
+}
@@ -0,0 +1,117 @@
+package openapiv3
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/manifest"
+	"github.com/SebastienMelki/sebuf/internal/pluginrun/pluginruntest"
+)
+
+func TestRun(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		param string
+		opts  Options
+		want  []string
+	}{
+		{name: "default", want: []string{"NoteService.openapi.yaml"}},
+		{name: "format option", opts: Options{Format: FormatJSON}, want: []string{"NoteService.openapi.json"}},
+		{
+			name:  "parameters override options",
+			param: "format=json,bundle=true,bundle_only=true,bundle_output=notes.json",
+			opts:  Options{Format: FormatYAML},
+			want:  []string{"notes.json"},
+		},
+		{name: "tools format", param: "format=tools-json", want: []string{"NoteService.tools.json"}},
+		{
+			name: "bundle option",
+			opts: Options{Bundle: BundleOptions{Enabled: true, Title: "Notes"}},
+			want: []string{"NoteService.openapi.yaml", "openapi.yaml"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := Run(pluginruntest.Request(tt.param), tt.opts)
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if resp.GetError() != "" {
+				t.Fatalf("response error: %s", resp.GetError())
+			}
+			if got := pluginruntest.FileNames(resp); !slices.Equal(got, tt.want) {
+				t.Errorf("files = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunBundleMetadata(t *testing.T) {
+	resp, err := Run(pluginruntest.Request(`bundle=true,bundle_only=true,bundle_description=Notes\, and more`), Options{
+		Bundle: BundleOptions{Title: "Notes API", Servers: []string{"https://notes.example.com"}},
+	})
+	if err != nil || resp.GetError() != "" {
+		t.Fatalf("Run: %v %s", err, resp.GetError())
+	}
+	doc := pluginruntest.Content(resp, "openapi.yaml")
+	for _, want := range []string{"title: Notes API", "description: Notes, and more", "https://notes.example.com"} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("bundle should contain %q:\n%s", want, doc)
+		}
+	}
+}
+
+func TestRunRejectsBundledTools(t *testing.T) {
+	resp, err := Run(pluginruntest.Request("format=tools-json,bundle=true"), Options{})
+	if err != nil {
+		t.Fatalf("Run returned error %v, want it in the response", err)
+	}
+	if !strings.Contains(resp.GetError(), "format=tools-json") {
+		t.Errorf("response error = %q, want bundle rejected for tools", resp.GetError())
+	}
+}
+
+func TestRunReportsInvalidInput(t *testing.T) {
+	req := pluginruntest.Request("")
+	req.FileToGenerate = []string{"missing.proto"}
+	resp, err := Run(req, Options{})
+	if err != nil {
+		t.Fatalf("Run returned error %v, want it in the response", err)
+	}
+	if !strings.Contains(resp.GetError(), "missing.proto") {
+		t.Errorf("response error = %q, want the missing file reported", resp.GetError())
+	}
+}
+
+func TestRunManifestOption(t *testing.T) {
+	resp, err := Run(pluginruntest.Request("bundle=true,manifest=true"), Options{})
+	if err != nil || resp.GetError() != "" {
+		t.Fatalf("Run: %v %s", err, resp.GetError())
+	}
+	var got manifest.Manifest
+	if err = json.Unmarshal([]byte(pluginruntest.Content(resp, manifest.FileName)), &got); err != nil {
+		t.Fatalf("decode manifest: %v", err)
+	}
+	var outputs []string
+	for _, output := range got.Outputs {
+		outputs = append(outputs, output.Plugin+" "+output.Name)
+	}
+	want := []string{"protoc-gen-openapiv3 NoteService.openapi.yaml", "protoc-gen-openapiv3 openapi.yaml"}
+	if !slices.Equal(outputs, want) {
+		t.Errorf("outputs = %v, want %v", outputs, want)
+	}
+
+	// The bundle documents the same routes as the per-service document
+	var routes []string
+	for _, service := range got.Services {
+		for _, method := range service.Methods {
+			for _, route := range method.Routes {
+				routes = append(routes, method.Name+" "+route.Method+" "+route.Path)
+			}
+		}
+	}
+	if want := []string{"GetNote GET /api/v1/notes/{id}", "CreateNote POST /api/v1/notes"}; !slices.Equal(routes, want) {
+		t.Errorf("routes = %v, want %v", routes, want)
+	}
+}
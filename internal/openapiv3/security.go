@@ -0,0 +1,141 @@
+package openapiv3
+
+import (
+	"slices"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+
+	"github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// Component names of the HTTP authentication schemes. API key schemes are
+// named after their header.
+const (
+	bearerSchemeName = "bearerAuth"
+	basicSchemeName  = "basicAuth"
+)
+
+// isAuthHeader reports whether a header is documented as a security scheme.
+func isAuthHeader(header *http.Header) bool {
+	return header.GetName() != "" && header.GetAuthType() != http.AuthType_AUTH_TYPE_UNSPECIFIED
+}
+
+// securitySchemeName returns the component name of the security scheme of an
+// authentication header.
+func securitySchemeName(header *http.Header) string {
+	switch header.GetAuthType() {
+	case http.AuthType_AUTH_TYPE_BEARER:
+		return bearerSchemeName
+	case http.AuthType_AUTH_TYPE_BASIC:
+		return basicSchemeName
+	case http.AuthType_AUTH_TYPE_API_KEY, http.AuthType_AUTH_TYPE_UNSPECIFIED:
+		// API keys are named after their header
+	}
+	return header.GetName()
+}
+
+// buildSecurityScheme creates the security scheme of an authentication header.
+// Bearer and Basic credentials travel in the Authorization header whatever the
+// header is named.
+func buildSecurityScheme(header *http.Header) *v3.SecurityScheme {
+	switch header.GetAuthType() {
+	case http.AuthType_AUTH_TYPE_BEARER:
+		return &v3.SecurityScheme{
+			Type:         "http",
+			Scheme:       "bearer",
+			BearerFormat: header.GetFormat(),
+			Description:  header.GetDescription(),
+		}
+	case http.AuthType_AUTH_TYPE_BASIC:
+		return &v3.SecurityScheme{Type: "http", Scheme: "basic", Description: header.GetDescription()}
+	case http.AuthType_AUTH_TYPE_API_KEY, http.AuthType_AUTH_TYPE_UNSPECIFIED:
+		// API keys are sent as the header value
+	}
+	return &v3.SecurityScheme{
+		Type:        "apiKey",
+		In:          "header",
+		Name:        annotations.CanonicalHeaderName(header.GetName()),
+		Description: header.GetDescription(),
+	}
+}
+
+// addSecuritySchemes registers the security schemes of the authentication
+// headers in the document's components and returns the operation's security
+// requirements, or nil when none of the headers authenticates.
+//
+// Every authentication header without an auth_group must be sent, and one
+// header of each group: the requirements are every such combination. An
+// ungrouped header that is not required may also be omitted, which adds the
+// combinations without it (an empty requirement makes authentication optional).
+func (g *Generator) addSecuritySchemes(headers []*http.Header) []*base.SecurityRequirement {
+	// Each clause lists the alternative schemes of a group or ungrouped header,
+	// with "" standing for sending none of them
+	var clauses [][]string
+	groups := make(map[string]int)
+	for _, header := range headers {
+		if !isAuthHeader(header) {
+			continue
+		}
+		name := securitySchemeName(header)
+		if g.doc.Components.SecuritySchemes == nil {
+			g.doc.Components.SecuritySchemes = orderedmap.New[string, *v3.SecurityScheme]()
+		}
+		if _, exists := g.doc.Components.SecuritySchemes.Get(name); !exists {
+			g.doc.Components.SecuritySchemes.Set(name, buildSecurityScheme(header))
+		}
+
+		group := header.GetAuthGroup()
+		if index, exists := groups[group]; exists && group != "" {
+			clauses[index] = append(clauses[index], name)
+			continue
+		}
+		clause := []string{name}
+		if group == "" && !header.GetRequired() {
+			clause = append(clause, "")
+		}
+		if group != "" {
+			groups[group] = len(clauses)
+		}
+		clauses = append(clauses, clause)
+	}
+	if len(clauses) == 0 {
+		return nil
+	}
+
+	combinations := securityCombinations(clauses)
+	requirements := make([]*base.SecurityRequirement, 0, len(combinations))
+	for _, combination := range combinations {
+		schemes := orderedmap.New[string, []string]()
+		for _, name := range combination {
+			schemes.Set(name, []string{})
+		}
+		requirements = append(requirements, &base.SecurityRequirement{
+			Requirements:             schemes,
+			ContainsEmptyRequirement: len(combination) == 0,
+		})
+	}
+	return requirements
+}
+
+// securityCombinations returns every combination of one scheme per clause, in
+// clause order, leaving out the "" alternatives.
+func securityCombinations(clauses [][]string) [][]string {
+	combinations := [][]string{nil}
+	for _, clause := range clauses {
+		var next [][]string
+		for _, combination := range combinations {
+			for _, name := range clause {
+				extended := slices.Clone(combination)
+				if name != "" {
+					extended = append(extended, name)
+				}
+				next = append(next, extended)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
@@ -0,0 +1,60 @@
+package openapiv3
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+func TestAddSecuritySchemes(t *testing.T) {
+	bearer := &http.Header{Name: "Authorization", Required: true, AuthType: http.AuthType_AUTH_TYPE_BEARER}
+	optionalKey := &http.Header{Name: "X-API-Key", AuthType: http.AuthType_AUTH_TYPE_API_KEY}
+	basic := &http.Header{Name: "Authorization", AuthType: http.AuthType_AUTH_TYPE_BASIC, AuthGroup: "partner"}
+	partnerKey := &http.Header{Name: "X-Partner-Key", AuthType: http.AuthType_AUTH_TYPE_API_KEY, AuthGroup: "partner"}
+	tenant := &http.Header{Name: "X-Tenant-ID", Required: true}
+
+	tests := []struct {
+		name    string
+		headers []*http.Header
+		want    [][]string
+	}{
+		{"no auth headers", []*http.Header{tenant}, nil},
+		{"required headers are combined", []*http.Header{bearer, tenant, partnerKey}, [][]string{
+			{"bearerAuth", "X-Partner-Key"},
+		}},
+		{"optional header may be omitted", []*http.Header{optionalKey}, [][]string{{"X-API-Key"}, {}}},
+		{"group members are alternatives", []*http.Header{basic, partnerKey, bearer}, [][]string{
+			{"basicAuth", "bearerAuth"},
+			{"X-Partner-Key", "bearerAuth"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGenerator(FormatYAML)
+			requirements := g.addSecuritySchemes(tt.headers)
+
+			var got [][]string
+			for _, requirement := range requirements {
+				names := slices.Collect(requirement.Requirements.KeysFromOldest())
+				if names == nil {
+					names = []string{}
+				}
+				if requirement.ContainsEmptyRequirement != (len(names) == 0) {
+					t.Errorf("requirement %v: ContainsEmptyRequirement = %v", names, requirement.ContainsEmptyRequirement)
+				}
+				got = append(got, names)
+			}
+			if !slices.EqualFunc(got, tt.want, slices.Equal[[]string]) {
+				t.Errorf("requirements = %v, want %v", got, tt.want)
+			}
+			for _, want := range tt.want {
+				for _, name := range want {
+					if _, ok := g.doc.Components.SecuritySchemes.Get(name); !ok {
+						t.Errorf("scheme %q is not declared", name)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package openapiv3
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/manifest"
+)
+
+// componentRefPrefix starts every reference to a component schema in the
+// rendered OpenAPI document.
+const componentRefPrefix = "#/components/schemas/"
+
+// Tool is a function-calling tool definition: one RPC method, described so a
+// language model can call it. Its schemas are the OpenAPI schemas of the
+// method's messages, which are JSON Schema 2020-12, made self-contained with
+// $defs.
+type Tool struct {
+	// Name is Service_Method.
+	Name string `json:"name"`
+	// Description is the method's leading comment, or its route when it has none.
+	Description string `json:"description"`
+	// Parameters is the object schema of the call's arguments: the request
+	// message in its JSON encoding, holding the path, query, header and body
+	// fields alike. Fields bound from anywhere but the body carry x-in, and
+	// x-name when their name on the wire differs from the JSON field name.
+	Parameters map[string]any `json:"parameters"`
+	// Response is the schema of the response message, or of one event for SSE
+	// methods.
+	Response map[string]any `json:"x-response"`
+	// HTTP is the route the method is served on.
+	HTTP ToolRoute `json:"x-http"`
+	// Deprecated is set on methods with the deprecated option, or of a service with it.
+	Deprecated bool `json:"x-deprecated,omitempty"`
+}
+
+// ToolRoute is the HTTP method and path template of a Tool.
+type ToolRoute struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+// generateToolsFiles writes a <Service>.tools.json array of Tool definitions
+// for every service of the files to generate.
+func generateToolsFiles(plugin *protogen.Plugin, routes *manifest.Builder) error {
+	for _, file := range plugin.Files {
+		if !file.Generate {
+			continue
+		}
+		for _, service := range file.Services {
+			generator := NewGenerator(FormatJSON)
+			generator.manifest = routes
+			generator.CollectReferencedMessages(service)
+			generator.ProcessService(service)
+
+			tools, err := generator.BuildTools(service)
+			if err != nil {
+				return fmt.Errorf("building tools for %s: %w", service.Desc.Name(), err)
+			}
+			var output bytes.Buffer
+			encoder := json.NewEncoder(&output)
+			encoder.SetEscapeHTML(false)
+			encoder.SetIndent("", "  ")
+			if err = encoder.Encode(tools); err != nil {
+				return fmt.Errorf("rendering tools for %s: %w", service.Desc.Name(), err)
+			}
+			filename := fmt.Sprintf("%s.tools.json", service.Desc.Name())
+			if _, err = plugin.NewGeneratedFile(filename, "").Write(output.Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// BuildTools returns a Tool for every method of service, in declaration order.
+// The service must have been processed by ProcessService, whose schemas the
+// tools are built from.
+func (g *Generator) BuildTools(service *protogen.Service) ([]Tool, error) {
+	rendered, err := g.Render()
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(rendered))
+	decoder.UseNumber() // int64 bounds must survive the round trip
+	if err = decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decoding rendered document: %w", err)
+	}
+	components, _ := lookupObject(doc, "components", "schemas")
+
+	tools := make([]Tool, 0, len(service.Methods))
+	for _, method := range service.Methods {
+		info := extractMethodHTTPInfo(service, method)
+		parameters, err := g.toolParameters(method, info, components)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", method.Desc.Name(), err)
+		}
+		route := ToolRoute{Method: strings.ToUpper(info.httpMethod), Path: info.path}
+		description := strings.TrimSpace(string(method.Comments.Leading))
+		if description == "" {
+			description = route.Method + " " + route.Path
+		}
+		tools = append(tools, Tool{
+			Name:        fmt.Sprintf("%s_%s", service.Desc.Name(), method.Desc.Name()),
+			Description: description,
+			Parameters:  parameters,
+			Response:    selfContainedSchema(components, g.toolResponse(method, components)),
+			HTTP:        route,
+			Deprecated:  annotations.IsMethodDeprecated(method) || annotations.IsServiceDeprecated(service),
+		})
+	}
+	return tools, nil
+}
+
+// toolResponse returns the response schema of a method: its response
+// message's schema, or one of the variant messages of a result message.
+func (g *Generator) toolResponse(method *protogen.Method, components map[string]any) any {
+	variants, _ := annotations.GetResponseVariants(method.Output)
+	if variants == nil {
+		return components[g.getSchemaName(method.Output)]
+	}
+	oneOf := make([]any, 0, len(variants))
+	for _, variant := range variants {
+		oneOf = append(oneOf, map[string]any{"$ref": componentRefPrefix + g.getSchemaName(variant.Field.Message)})
+	}
+	return map[string]any{"oneOf": oneOf}
+}
+
+// toolParameters returns the parameters schema of a method: its request
+// message's schema, with the fields bound from the path, query string or
+// headers marked, and without the body fields of a method that has no body.
+func (g *Generator) toolParameters(
+	method *protogen.Method,
+	info methodHTTPInfo,
+	components map[string]any,
+) (map[string]any, error) {
+	schema, _ := rewriteRefs(components[g.getSchemaName(method.Input)], nil).(map[string]any)
+	if !slices.Contains(schemaTypes(schema), "object") {
+		return nil, fmt.Errorf("request message %s does not encode as a JSON object", method.Input.Desc.Name())
+	}
+	properties, _ := schema["properties"].(map[string]any)
+	if properties == nil {
+		properties = map[string]any{}
+	}
+	required, _ := schema["required"].([]any)
+
+	bound := map[string]bool{}
+	mark := func(field *protogen.Field, in, wireName string, isRequired bool) {
+		name := annotations.JSONFieldName(field)
+		property, ok := properties[name].(map[string]any)
+		if !ok {
+			return
+		}
+		bound[name] = true
+		property["x-in"] = in
+		if wireName != name {
+			property["x-name"] = wireName
+		}
+		if isRequired && !slices.Contains(required, any(name)) {
+			required = append(required, name)
+		}
+	}
+	for _, param := range info.pathParams {
+		if field := annotations.FindFieldByProtoName(method.Input, param); field != nil {
+			mark(field, "path", param, true)
+		}
+	}
+	for _, param := range annotations.GetQueryParams(method.Input) {
+		if param.Field == nil {
+			continue
+		}
+		mark(param.Field, "query", param.ParamName, param.Required)
+		if property, ok := properties[annotations.JSONFieldName(param.Field)].(map[string]any); ok && param.JSON {
+			property["x-encoding"] = "json-base64url"
+		}
+	}
+	for _, param := range annotations.GetHeaderFieldParams(method.Input) {
+		mark(param.Field, "header", param.HeaderName, checkIfFieldRequired(param.Field))
+	}
+
+	// Without a body, only the fields bound from elsewhere reach the server
+	if info.httpMethod != httpMethodPost && info.httpMethod != httpMethodPut && info.httpMethod != httpMethodPatch {
+		for name := range properties {
+			if !bound[name] {
+				delete(properties, name)
+			}
+		}
+		required = slices.DeleteFunc(required, func(name any) bool { return !bound[fmt.Sprint(name)] })
+	}
+
+	schema["properties"] = properties
+	if len(required) > 0 {
+		schema["required"] = required
+	} else {
+		delete(schema, "required")
+	}
+	return selfContainedSchema(components, schema), nil
+}
+
+// selfContainedSchema returns a copy of schema, with every component it
+// references, directly or not, copied under $defs and its references pointed
+// there.
+func selfContainedSchema(components map[string]any, schema any) map[string]any {
+	defs := map[string]any{}
+	var pending []string
+	rewrite := func(node any) any {
+		return rewriteRefs(node, func(ref string) string {
+			target := strings.TrimPrefix(ref, componentRefPrefix)
+			if _, seen := defs[target]; !seen {
+				defs[target] = nil // claimed, so each component is copied once
+				pending = append(pending, target)
+			}
+			return "#/$defs/" + target
+		})
+	}
+
+	root, _ := rewrite(schema).(map[string]any)
+	if root == nil {
+		root = map[string]any{}
+	}
+	for len(pending) > 0 {
+		target := pending[0]
+		pending = pending[1:]
+		defs[target] = rewrite(components[target])
+	}
+	if len(defs) > 0 {
+		root["$defs"] = defs
+	}
+	return root
+}
+
+// rewriteRefs returns a deep copy of node with every component reference
+// replaced by the result of target. A nil target copies references unchanged.
+func rewriteRefs(node any, target func(ref string) string) any {
+	switch value := node.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(value))
+		for key, child := range value {
+			ref, ok := child.(string)
+			if ok && target != nil && key == "$ref" && strings.HasPrefix(ref, componentRefPrefix) {
+				copied[key] = target(ref)
+				continue
+			}
+			copied[key] = rewriteRefs(child, target)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(value))
+		for i, child := range value {
+			copied[i] = rewriteRefs(child, target)
+		}
+		return copied
+	default:
+		return node
+	}
+}
+
+// schemaTypes returns the type keyword of a schema as a list.
+func schemaTypes(schema map[string]any) []string {
+	var types []string
+	switch value := schema["type"].(type) {
+	case string:
+		types = append(types, value)
+	case []any:
+		for _, t := range value {
+			if s, ok := t.(string); ok {
+				types = append(types, s)
+			}
+		}
+	}
+	return types
+}
+
+// lookupObject returns the object at the path of keys below node.
+func lookupObject(node map[string]any, keys ...string) (map[string]any, bool) {
+	for _, key := range keys {
+		child, ok := node[key].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		node = child
+	}
+	return node, true
+}
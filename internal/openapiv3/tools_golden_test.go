@@ -0,0 +1,332 @@
+package openapiv3_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// toolsGoldenCases are the fixtures of TestToolsGoldenFiles and the services
+// each one defines.
+var toolsGoldenCases = []struct {
+	protoFile string
+	services  []string
+}{
+	{protoFile: "field_sources.proto", services: []string{"FieldSourceService"}},
+	{protoFile: "validation_constraints.proto", services: []string{"ValidationService"}},
+	{protoFile: "enum_encoding.proto", services: []string{"EnumEncodingService"}},
+	{protoFile: "nested_messages.proto", services: []string{"NestedService"}},
+	{protoFile: "sse.proto", services: []string{"SSEService"}},
+}
+
+// TestToolsGoldenFiles compares format=tools-json output with golden files,
+// checks that generating twice gives the same bytes, and validates every tool
+// against the JSON Schema 2020-12 meta-schema.
+func TestToolsGoldenFiles(t *testing.T) {
+	pluginPath := "./protoc-gen-openapiv3-tools-test"
+	buildCmd := exec.Command("go", "build", "-o", pluginPath, "../../cmd/protoc-gen-openapiv3")
+	if err := buildCmd.Run(); err != nil {
+		t.Fatalf("Failed to build plugin: %v", err)
+	}
+	defer os.Remove(pluginPath)
+
+	generate := func(t *testing.T, protoFile string) string {
+		t.Helper()
+		tempDir := t.TempDir()
+		cmd := exec.Command("protoc",
+			"--plugin=protoc-gen-openapiv3="+pluginPath,
+			"--openapiv3_out="+tempDir,
+			"--openapiv3_opt=format=tools-json",
+			"--proto_path=testdata/proto",
+			"--proto_path=../../proto",
+			filepath.Join("testdata/proto", protoFile),
+		)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			t.Fatalf("protoc failed for %s: %v\nStderr: %s", protoFile, err, stderr.String())
+		}
+		return tempDir
+	}
+
+	for _, tc := range toolsGoldenCases {
+		t.Run(strings.TrimSuffix(tc.protoFile, ".proto"), func(t *testing.T) {
+			first, second := generate(t, tc.protoFile), generate(t, tc.protoFile)
+			for _, service := range tc.services {
+				name := service + ".tools.json"
+				generatedContent, err := os.ReadFile(filepath.Join(first, name))
+				if err != nil {
+					t.Fatalf("Failed to read generated file: %v", err)
+				}
+				again, err := os.ReadFile(filepath.Join(second, name))
+				if err != nil {
+					t.Fatalf("Failed to read generated file: %v", err)
+				}
+				if !bytes.Equal(generatedContent, again) {
+					t.Errorf("%s differs between two runs", name)
+				}
+
+				for _, problem := range validateTools(generatedContent) {
+					t.Errorf("%s: %s", name, problem)
+				}
+
+				goldenFile := filepath.Join("testdata/golden/tools", name)
+				goldenContent, err := os.ReadFile(goldenFile)
+				if err != nil {
+					if created := tryCreateGoldenFile(t, goldenFile, generatedContent, err); created {
+						continue
+					}
+					t.Fatalf("Failed to read golden file %s: %v", goldenFile, err)
+				}
+				if !bytes.Equal(generatedContent, goldenContent) {
+					reportGoldenFileMismatch(t, name, goldenFile, generatedContent, goldenContent)
+				}
+			}
+		})
+	}
+}
+
+// toolNamePattern is the tool name syntax function-calling APIs accept.
+var toolNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// validateTools returns the problems of a tools-json document: tools that are
+// malformed, and schemas that do not conform to the JSON Schema 2020-12
+// meta-schema or hold references that do not resolve.
+func validateTools(content []byte) []string {
+	var tools []map[string]any
+	if err := json.Unmarshal(content, &tools); err != nil {
+		return []string{fmt.Sprintf("not a JSON array of objects: %v", err)}
+	}
+	var problems []string
+	names := map[string]bool{}
+	for i, tool := range tools {
+		name, _ := tool["name"].(string)
+		if !toolNamePattern.MatchString(name) || names[name] {
+			problems = append(problems, fmt.Sprintf("tool %d: invalid or duplicate name %q", i, name))
+		}
+		names[name] = true
+		if description, _ := tool["description"].(string); description == "" {
+			problems = append(problems, fmt.Sprintf("%s: empty description", name))
+		}
+		parameters, _ := tool["parameters"].(map[string]any)
+		if parameters["type"] != "object" {
+			problems = append(problems, fmt.Sprintf("%s: parameters is not an object schema", name))
+		}
+		for _, key := range []string{"parameters", "x-response"} {
+			v := metaSchemaValidator{root: tool[key]}
+			v.validate(tool[key], name+"."+key)
+			problems = append(problems, v.problems...)
+		}
+	}
+	return problems
+}
+
+// metaSchemaValidator checks a schema against the JSON Schema 2020-12
+// meta-schema: every keyword it defines must hold a value of the right type.
+// Unknown keywords, such as the x- extensions, are allowed, as they are by the
+// meta-schema.
+type metaSchemaValidator struct {
+	root     any
+	problems []string
+}
+
+var (
+	schemaKeywords = []string{
+		"items", "additionalProperties", "not", "if", "then", "else", "contains",
+		"propertyNames", "unevaluatedItems", "unevaluatedProperties", "contentSchema",
+	}
+	schemaMapKeywords   = []string{"properties", "patternProperties", "$defs", "dependentSchemas"}
+	schemaArrayKeywords = []string{"allOf", "anyOf", "oneOf", "prefixItems"}
+	countKeywords       = []string{
+		"maxLength", "minLength", "maxItems", "minItems",
+		"maxProperties", "minProperties", "maxContains", "minContains",
+	}
+	numberKeywords  = []string{"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf"}
+	booleanKeywords = []string{"uniqueItems", "deprecated", "readOnly", "writeOnly"}
+	stringKeywords  = []string{
+		"format", "title", "description", "$comment", "contentEncoding", "contentMediaType",
+		"$anchor", "$id", "$schema",
+	}
+	simpleTypes = []string{"array", "boolean", "integer", "null", "number", "object", "string"}
+)
+
+func (v *metaSchemaValidator) fail(path, format string, args ...any) {
+	v.problems = append(v.problems, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (v *metaSchemaValidator) validate(node any, path string) {
+	if _, ok := node.(bool); ok {
+		return
+	}
+	schema, ok := node.(map[string]any)
+	if !ok {
+		v.fail(path, "a schema must be an object or a boolean, got %T", node)
+		return
+	}
+
+	for _, key := range schemaKeywords {
+		if child, present := schema[key]; present {
+			v.validate(child, path+"."+key)
+		}
+	}
+	for _, key := range schemaMapKeywords {
+		if child, present := schema[key]; present {
+			children, isMap := child.(map[string]any)
+			if !isMap {
+				v.fail(path+"."+key, "must be an object")
+			}
+			for name, grandchild := range children {
+				v.validate(grandchild, path+"."+key+"."+name)
+			}
+		}
+	}
+	for _, key := range schemaArrayKeywords {
+		if child, present := schema[key]; present {
+			children, isList := child.([]any)
+			if !isList || len(children) == 0 {
+				v.fail(path+"."+key, "must be a non-empty array")
+			}
+			for i, grandchild := range children {
+				v.validate(grandchild, fmt.Sprintf("%s.%s[%d]", path, key, i))
+			}
+		}
+	}
+	for _, key := range countKeywords {
+		if value, present := schema[key]; present {
+			if n, isNumber := value.(float64); !isNumber || n < 0 || n != float64(int64(n)) {
+				v.fail(path+"."+key, "must be a non-negative integer, got %v", value)
+			}
+		}
+	}
+	for _, key := range numberKeywords {
+		if value, present := schema[key]; present {
+			if n, isNumber := value.(float64); !isNumber || (key == "multipleOf" && n <= 0) {
+				v.fail(path+"."+key, "must be a number, got %v", value)
+			}
+		}
+	}
+	for _, key := range booleanKeywords {
+		if value, present := schema[key]; present {
+			if _, isBool := value.(bool); !isBool {
+				v.fail(path+"."+key, "must be a boolean, got %v", value)
+			}
+		}
+	}
+	for _, key := range stringKeywords {
+		if value, present := schema[key]; present {
+			if _, isString := value.(string); !isString {
+				v.fail(path+"."+key, "must be a string, got %v", value)
+			}
+		}
+	}
+	v.validateAssertions(schema, path)
+}
+
+// validateAssertions checks the keywords with a value syntax of their own.
+func (v *metaSchemaValidator) validateAssertions(schema map[string]any, path string) {
+	if value, present := schema["type"]; present {
+		types, isList := value.([]any)
+		if !isList {
+			types = []any{value}
+		}
+		seen := map[any]bool{}
+		for _, t := range types {
+			name, _ := t.(string)
+			if !slices.Contains(simpleTypes, name) || seen[t] {
+				v.fail(path+".type", "invalid or repeated type %v", t)
+			}
+			seen[t] = true
+		}
+		if len(types) == 0 {
+			v.fail(path+".type", "must not be empty")
+		}
+	}
+	if value, present := schema["enum"]; present {
+		if _, isList := value.([]any); !isList {
+			v.fail(path+".enum", "must be an array")
+		}
+	}
+	if value, present := schema["required"]; present {
+		names, isList := value.([]any)
+		seen := map[any]bool{}
+		for _, name := range names {
+			if _, isString := name.(string); !isString || seen[name] {
+				v.fail(path+".required", "invalid or repeated name %v", name)
+			}
+			seen[name] = true
+		}
+		if !isList {
+			v.fail(path+".required", "must be an array")
+		}
+	}
+	if value, present := schema["pattern"]; present {
+		pattern, _ := value.(string)
+		if _, err := regexp.Compile(pattern); err != nil {
+			v.fail(path+".pattern", "invalid regular expression: %v", err)
+		}
+	}
+	if value, present := schema["$ref"]; present {
+		ref, _ := value.(string)
+		if !v.resolves(ref) {
+			v.fail(path+".$ref", "%q does not resolve within the schema", ref)
+		}
+	}
+}
+
+// resolves reports whether ref points at the root schema or one of its $defs.
+func (v *metaSchemaValidator) resolves(ref string) bool {
+	if ref == "#" {
+		return true
+	}
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return false
+	}
+	root, _ := v.root.(map[string]any)
+	defs, _ := root["$defs"].(map[string]any)
+	_, ok = defs[name]
+	return ok
+}
+
+func TestValidateToolsRejectsInvalidSchemas(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		tools string
+		want  string
+	}{
+		{
+			name:  "unknown type",
+			tools: `[{"name":"S_M","description":"d","parameters":{"type":"object","properties":{"a":{"type":"int"}}}}]`,
+			want:  "invalid or repeated type int",
+		},
+		{
+			name:  "dangling reference",
+			tools: `[{"name":"S_M","description":"d","parameters":{"type":"object","properties":{"a":{"$ref":"#/$defs/A"}}}}]`,
+			want:  "does not resolve",
+		},
+		{
+			name:  "negative count",
+			tools: `[{"name":"S_M","description":"d","parameters":{"type":"object","minProperties":-1}}]`,
+			want:  "non-negative integer",
+		},
+		{
+			name:  "invalid name",
+			tools: `[{"name":"S.M","description":"d","parameters":{"type":"object"}}]`,
+			want:  "invalid or duplicate name",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			problems := validateTools([]byte(tc.tools))
+			if !slices.ContainsFunc(problems, func(p string) bool { return strings.Contains(p, tc.want) }) {
+				t.Errorf("problems = %v, want one containing %q", problems, tc.want)
+			}
+		})
+	}
+}
@@ -6,8 +6,10 @@ import (
 
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
 	yaml "go.yaml.in/yaml/v4"
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"github.com/SebastienMelki/sebuf/http"
@@ -26,8 +28,35 @@ const (
 	headerTypeDouble  = "double"
 )
 
-// convertField converts a protobuf field to an OpenAPI schema.
+// convertField converts a protobuf field to an OpenAPI schema, marked
+// deprecated when the field sets the deprecated option.
 func (g *Generator) convertField(field *protogen.Field) *base.SchemaProxy {
+	schema := g.convertFieldValue(field)
+	if annotations.IsFieldDeprecated(field) {
+		return markDeprecated(schema)
+	}
+	return schema
+}
+
+// markDeprecated returns schemaProxy with deprecated set. A reference, which
+// takes no sibling keywords here, is wrapped in an allOf.
+func markDeprecated(schemaProxy *base.SchemaProxy) *base.SchemaProxy {
+	if schemaProxy.IsReference() {
+		return base.CreateSchemaProxy(&base.Schema{
+			AllOf:      []*base.SchemaProxy{schemaProxy},
+			Deprecated: proto.Bool(true),
+		})
+	}
+	builtSchema, err := schemaProxy.BuildSchema()
+	if err != nil || builtSchema == nil {
+		return schemaProxy
+	}
+	builtSchema.Deprecated = proto.Bool(true)
+	return base.CreateSchemaProxy(builtSchema)
+}
+
+// convertFieldValue converts a protobuf field to the OpenAPI schema of its values.
+func (g *Generator) convertFieldValue(field *protogen.Field) *base.SchemaProxy {
 	// Handle repeated fields (arrays)
 	if field.Desc.IsList() {
 		itemSchema := g.convertScalarField(field)
@@ -49,8 +78,8 @@ func (g *Generator) convertField(field *protogen.Field) *base.SchemaProxy {
 		return g.convertMapField(field)
 	}
 
-	// Handle optional fields (proto3 optional)
-	schema := g.convertScalarField(field)
+	// Handle optional fields (proto3 optional), with their proto2 default
+	schema := documentProtoDefault(g.convertScalarField(field), field)
 
 	// Handle nullable fields: use type array syntax per OpenAPI 3.1
 	if annotations.IsNullableField(field) {
@@ -156,10 +185,13 @@ func (g *Generator) convertScalarField(field *protogen.Field) *base.SchemaProxy
 
 	case protoreflect.StringKind:
 		schema.Type = []string{"string"}
+		if annotations.IsSensitiveField(field) {
+			schema.Format = "password"
+		}
 
 	case protoreflect.BytesKind:
 		schema.Type = []string{"string"}
-		encoding := annotations.GetBytesEncoding(field)
+		encoding := annotations.ResolveBytesEncoding(field)
 		//exhaustive:ignore -- UNSPECIFIED and BASE64 both use default byte format
 		switch encoding {
 		case http.BytesEncoding_BYTES_ENCODING_HEX:
@@ -186,6 +218,10 @@ func (g *Generator) convertScalarField(field *protogen.Field) *base.SchemaProxy
 		if annotations.IsTimestampField(field) {
 			return g.convertTimestampField(field, schema)
 		}
+		// Any, Struct, Value and ListValue hold arbitrary JSON
+		if wkt := annotations.DynamicJSONType(field); wkt != "" {
+			return g.convertDynamicJSONField(field, wkt)
+		}
 		// Reference to another message
 		return base.CreateSchemaProxyRef(fmt.Sprintf("#/components/schemas/%s", g.getSchemaName(field.Message)))
 
@@ -247,7 +283,7 @@ func (g *Generator) convertEnumField(field *protogen.Field) *base.SchemaProxy {
 	}
 
 	// Check for NUMBER encoding
-	encoding := annotations.GetEnumEncoding(field)
+	encoding := annotations.ResolveEnumEncoding(field)
 	if encoding == http.EnumEncoding_ENUM_ENCODING_NUMBER {
 		// Generate integer enum with numeric values
 		schema := &base.Schema{
@@ -295,6 +331,24 @@ func (g *Generator) convertEnumField(field *protogen.Field) *base.SchemaProxy {
 	return base.CreateSchemaProxy(schema)
 }
 
+// enumParamValues returns the strings accepted for an enum path or query parameter:
+// the preferred value of each enum value (custom enum_value, else proto name), followed
+// by the proto names that remain accepted as aliases for annotated values.
+func enumParamValues(enum *protogen.Enum) []*yaml.Node {
+	nodes := make([]*yaml.Node, 0, len(enum.Values))
+	var aliases []*yaml.Node
+	for _, value := range enum.Values {
+		protoName := string(value.Desc.Name())
+		if customValue := annotations.GetEnumValueMapping(value); customValue != "" {
+			nodes = append(nodes, &yaml.Node{Kind: yaml.ScalarNode, Value: customValue})
+			aliases = append(aliases, &yaml.Node{Kind: yaml.ScalarNode, Value: protoName})
+			continue
+		}
+		nodes = append(nodes, &yaml.Node{Kind: yaml.ScalarNode, Value: protoName})
+	}
+	return append(nodes, aliases...)
+}
+
 // convertMapField converts a protobuf map field to an OpenAPI schema.
 func (g *Generator) convertMapField(field *protogen.Field) *base.SchemaProxy {
 	schema := &base.Schema{
@@ -330,6 +384,13 @@ func (g *Generator) getMapValueSchema(field *protogen.Field) *base.DynamicValue[
 		}
 	}
 
+	// Sensitive map values carry the annotation on the map field itself
+	if annotations.IsSensitiveField(field) && valueField.Desc.Kind() == protoreflect.StringKind {
+		return &base.DynamicValue[*base.SchemaProxy, bool]{
+			A: base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}, Format: "password"}),
+		}
+	}
+
 	// Normal scalar or message type
 	valueSchema := g.convertScalarField(valueField)
 	return &base.DynamicValue[*base.SchemaProxy, bool]{A: valueSchema}
@@ -365,6 +426,24 @@ func (g *Generator) createUnwrapArraySchema(unwrapField *protogen.Field) *base.D
 	}
 }
 
+// multipleHeaderSchema returns the array schema of a multiple header whose
+// values each match item. The default of item, a comma-separated line, becomes
+// the default list.
+func multipleHeaderSchema(item *base.Schema) *base.Schema {
+	schema := &base.Schema{
+		Type:  []string{"array"},
+		Items: &base.DynamicValue[*base.SchemaProxy, bool]{A: base.CreateSchemaProxy(item)},
+	}
+	if item.Default != nil {
+		schema.Default = &yaml.Node{Kind: yaml.SequenceNode}
+		for _, value := range http.SplitHeaderValues([]string{item.Default.Value}) {
+			schema.Default.Content = append(schema.Default.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: value})
+		}
+		item.Default = nil
+	}
+	return schema
+}
+
 // convertHeadersToParameters converts proto headers to OpenAPI parameters.
 func convertHeadersToParameters(headers []*http.Header) []*v3.Parameter {
 	if len(headers) == 0 {
@@ -396,11 +475,28 @@ func convertHeadersToParameters(headers []*http.Header) []*v3.Parameter {
 			}
 		}
 
+		// Add default if specified. Servers fill in an omitted header with its
+		// default, so such a header is never required on the wire.
+		required := header.GetRequired()
+		if header.GetDefaultValue() != "" {
+			schema.Default = &yaml.Node{
+				Kind:  yaml.ScalarNode,
+				Value: header.GetDefaultValue(),
+			}
+			required = false
+		}
+
+		// A multiple header is a list of values of its type, sent comma-separated
+		// as the simple style of header parameters encodes arrays
+		if header.GetMultiple() {
+			schema = multipleHeaderSchema(schema)
+		}
+
 		// Create the parameter
 		parameter := &v3.Parameter{
-			Name:        header.GetName(),
+			Name:        annotations.CanonicalHeaderName(header.GetName()),
 			In:          "header",
-			Required:    &header.Required,
+			Required:    &required,
 			Schema:      base.CreateSchemaProxy(schema),
 			Description: header.GetDescription(),
 		}
@@ -440,7 +536,7 @@ func mapHeaderTypeToOpenAPI(headerType string) string {
 //
 //nolint:exhaustive // Only non-default formats have special schemas; default falls through to date-time
 func (g *Generator) convertTimestampField(field *protogen.Field, schema *base.Schema) *base.SchemaProxy {
-	format := annotations.GetTimestampFormat(field)
+	format := annotations.ResolveTimestampFormat(field)
 	switch format {
 	case http.TimestampFormat_TIMESTAMP_FORMAT_UNIX_SECONDS:
 		schema.Type = []string{headerTypeInteger}
@@ -467,6 +563,44 @@ func (g *Generator) convertTimestampField(field *protogen.Field, schema *base.Sc
 	return base.CreateSchemaProxy(schema)
 }
 
+// convertDynamicJSONField creates the free-form OpenAPI schema of a field
+// holding a google.protobuf.Any, Struct, Value or ListValue, whose JSON shape
+// depends on the data rather than the descriptor.
+func (g *Generator) convertDynamicJSONField(field *protogen.Field, wkt protoreflect.FullName) *base.SchemaProxy {
+	anyJSON := &base.DynamicValue[*base.SchemaProxy, bool]{N: 1, B: true}
+	schema := &base.Schema{}
+	var note string
+	switch wkt {
+	case annotations.AnyFullName:
+		properties := orderedmap.New[string, *base.SchemaProxy]()
+		properties.Set("@type", base.CreateSchemaProxy(&base.Schema{
+			Type:        []string{headerTypeString},
+			Description: "Type URL of the embedded message, e.g. type.googleapis.com/acme.v1.Note",
+		}))
+		schema.Type = []string{"object"}
+		schema.Properties = properties
+		schema.Required = []string{"@type"}
+		schema.AdditionalProperties = anyJSON
+		note = "google.protobuf.Any: the JSON of the message named by @type, with its fields next to @type"
+	case annotations.StructFullName:
+		schema.Type = []string{"object"}
+		schema.AdditionalProperties = anyJSON
+		note = "google.protobuf.Struct: an arbitrary JSON object"
+	case annotations.ListValueFullName:
+		schema.Type = []string{"array"}
+		schema.Items = anyJSON
+		note = "google.protobuf.ListValue: an arbitrary JSON array"
+	default:
+		note = "google.protobuf.Value: any JSON value"
+	}
+
+	schema.Description = note
+	if field.Comments.Leading != "" {
+		schema.Description = strings.TrimSpace(string(field.Comments.Leading)) + "\n\n" + note
+	}
+	return base.CreateSchemaProxy(schema)
+}
+
 // int64PrecisionWarning is the warning message for NUMBER-encoded int64/uint64 fields.
 const int64PrecisionWarning = "Warning: Values > 2^53 may lose precision in JavaScript"
 
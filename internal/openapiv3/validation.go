@@ -2,15 +2,19 @@ package openapiv3
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 
 	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
 	yaml "go.yaml.in/yaml/v4"
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 
 	validate "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
 )
 
 // extractValidationConstraints extracts buf.validate field options and applies them to the schema.
@@ -33,6 +37,11 @@ func extractValidationConstraints(field *protogen.Field, schema *base.Schema) {
 		return
 	}
 
+	// Rules that are never applied describe nothing
+	if fieldConstraints.GetIgnore() == validate.Ignore_IGNORE_ALWAYS {
+		return
+	}
+
 	// Apply constraints based on field type
 	switch field.Desc.Kind() {
 	case protoreflect.StringKind:
@@ -69,6 +78,71 @@ func extractValidationConstraints(field *protogen.Field, schema *base.Schema) {
 	// Note: Required is handled at the message level, not here
 	// This is a marker for the parent message to add this field to required[]
 	_ = fieldConstraints.GetRequired()
+
+	// The rules of a repeated field describe the array, not each item
+	if field.Desc.IsList() && !slices.Contains(schema.Type, "array") {
+		return
+	}
+	if fieldConstraints.GetIgnore() == validate.Ignore_IGNORE_IF_ZERO_VALUE {
+		appendValidationNote(schema, ignoreEmptyNote)
+	}
+	applyCELConstraints(fieldConstraints, schema)
+}
+
+// ignoreEmptyNote documents the rules of a field with ignore = IGNORE_IF_ZERO_VALUE.
+const ignoreEmptyNote = "Optional: the constraints apply only when the value is set (not empty)."
+
+// appendValidationNote appends a line about the field's validation to the
+// schema's description.
+func appendValidationNote(schema *base.Schema, note string) {
+	if schema.Description != "" {
+		schema.Description = schema.Description + "\n\n" + note
+	} else {
+		schema.Description = note
+	}
+}
+
+// applyCELConstraints documents the custom CEL rules of a field: each one is
+// listed in the x-validation-cel extension, with its id, message and
+// expression, and described in a line of the schema's description.
+func applyCELConstraints(constraints *validate.FieldRules, schema *base.Schema) {
+	rules := constraints.GetCel()
+	for _, expression := range constraints.GetCelExpression() {
+		rules = append(rules, validate.Rule_builder{Expression: proto.String(expression)}.Build())
+	}
+	if len(rules) == 0 {
+		return
+	}
+
+	list := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, rule := range rules {
+		entry := &yaml.Node{Kind: yaml.MappingNode}
+		for _, kv := range [][2]string{
+			{"id", rule.GetId()},
+			{"message", rule.GetMessage()},
+			{"expression", rule.GetExpression()},
+		} {
+			if kv[1] == "" {
+				continue
+			}
+			entry.Content = append(entry.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: kv[0]},
+				&yaml.Node{Kind: yaml.ScalarNode, Value: kv[1]},
+			)
+		}
+		list.Content = append(list.Content, entry)
+
+		line := "Must satisfy `" + rule.GetExpression() + "`"
+		if rule.GetMessage() != "" {
+			line += ": " + rule.GetMessage()
+		}
+		appendValidationNote(schema, line+".")
+	}
+
+	if schema.Extensions == nil {
+		schema.Extensions = orderedmap.New[string, *yaml.Node]()
+	}
+	schema.Extensions.Set("x-validation-cel", list)
 }
 
 // applyStringConstraints applies string validation constraints to the schema.
@@ -78,6 +152,13 @@ func applyStringConstraints(constraints *validate.FieldRules, schema *base.Schem
 		return
 	}
 
+	// Exact length
+	if stringConstraints.HasLen() {
+		length := int64(stringConstraints.GetLen()) // #nosec G115
+		schema.MinLength = &length
+		schema.MaxLength = &length
+	}
+
 	// Min and max length
 	if stringConstraints.HasMinLen() {
 		minLen := int64(stringConstraints.GetMinLen()) // #nosec G115
@@ -153,7 +234,7 @@ func applyInt32Constraints(constraints *validate.FieldRules, schema *base.Schema
 	// Greater than (exclusive minimum)
 	if int32Constraints.HasGt() {
 		minValue := float64(int32Constraints.GetGt())
-		schema.ExclusiveMinimum = &base.DynamicValue[bool, float64]{B: minValue}
+		schema.ExclusiveMinimum = &base.DynamicValue[bool, float64]{N: 1, B: minValue}
 	}
 
 	// Less than or equal (maximum)
@@ -165,7 +246,7 @@ func applyInt32Constraints(constraints *validate.FieldRules, schema *base.Schema
 	// Less than (exclusive maximum)
 	if int32Constraints.HasLt() {
 		maxValue := float64(int32Constraints.GetLt())
-		schema.ExclusiveMaximum = &base.DynamicValue[bool, float64]{B: maxValue}
+		schema.ExclusiveMaximum = &base.DynamicValue[bool, float64]{N: 1, B: maxValue}
 	}
 
 	// Const value
@@ -204,7 +285,7 @@ func applyInt64Constraints(constraints *validate.FieldRules, schema *base.Schema
 	// Greater than (exclusive minimum)
 	if int64Constraints.HasGt() {
 		minValue := float64(int64Constraints.GetGt())
-		schema.ExclusiveMinimum = &base.DynamicValue[bool, float64]{B: minValue}
+		schema.ExclusiveMinimum = &base.DynamicValue[bool, float64]{N: 1, B: minValue}
 	}
 
 	// Less than or equal (maximum)
@@ -216,7 +297,7 @@ func applyInt64Constraints(constraints *validate.FieldRules, schema *base.Schema
 	// Less than (exclusive maximum)
 	if int64Constraints.HasLt() {
 		maxValue := float64(int64Constraints.GetLt())
-		schema.ExclusiveMaximum = &base.DynamicValue[bool, float64]{B: maxValue}
+		schema.ExclusiveMaximum = &base.DynamicValue[bool, float64]{N: 1, B: maxValue}
 	}
 
 	// Const value
@@ -255,7 +336,7 @@ func applyFloatConstraints(constraints *validate.FieldRules, schema *base.Schema
 	// Greater than (exclusive minimum)
 	if floatConstraints.HasGt() {
 		minValue := float64(floatConstraints.GetGt())
-		schema.ExclusiveMinimum = &base.DynamicValue[bool, float64]{B: minValue}
+		schema.ExclusiveMinimum = &base.DynamicValue[bool, float64]{N: 1, B: minValue}
 	}
 
 	// Less than or equal (maximum)
@@ -267,7 +348,7 @@ func applyFloatConstraints(constraints *validate.FieldRules, schema *base.Schema
 	// Less than (exclusive maximum)
 	if floatConstraints.HasLt() {
 		maxValue := float64(floatConstraints.GetLt())
-		schema.ExclusiveMaximum = &base.DynamicValue[bool, float64]{B: maxValue}
+		schema.ExclusiveMaximum = &base.DynamicValue[bool, float64]{N: 1, B: maxValue}
 	}
 
 	// Const value
@@ -306,7 +387,7 @@ func applyDoubleConstraints(constraints *validate.FieldRules, schema *base.Schem
 	// Greater than (exclusive minimum)
 	if doubleConstraints.HasGt() {
 		minValue := doubleConstraints.GetGt()
-		schema.ExclusiveMinimum = &base.DynamicValue[bool, float64]{B: minValue}
+		schema.ExclusiveMinimum = &base.DynamicValue[bool, float64]{N: 1, B: minValue}
 	}
 
 	// Less than or equal (maximum)
@@ -318,7 +399,7 @@ func applyDoubleConstraints(constraints *validate.FieldRules, schema *base.Schem
 	// Less than (exclusive maximum)
 	if doubleConstraints.HasLt() {
 		maxValue := doubleConstraints.GetLt()
-		schema.ExclusiveMaximum = &base.DynamicValue[bool, float64]{B: maxValue}
+		schema.ExclusiveMaximum = &base.DynamicValue[bool, float64]{N: 1, B: maxValue}
 	}
 
 	// Const value
@@ -387,8 +468,13 @@ func applyMapConstraints(constraints *validate.FieldRules, schema *base.Schema)
 	}
 }
 
-// checkIfFieldRequired checks if a field has the required constraint.
+// checkIfFieldRequired checks if a field is a proto2 required field or has
+// the required constraint.
 func checkIfFieldRequired(field *protogen.Field) bool {
+	if annotations.IsRequiredField(field) {
+		return true
+	}
+
 	// Get the field descriptor options
 	fieldOptions := field.Desc.Options()
 	if fieldOptions == nil {
@@ -407,5 +493,10 @@ func checkIfFieldRequired(field *protogen.Field) bool {
 		return false
 	}
 
+	// A field whose rules are skipped when it is empty may be omitted
+	if ignore := fieldConstraints.GetIgnore(); ignore == validate.Ignore_IGNORE_IF_ZERO_VALUE ||
+		ignore == validate.Ignore_IGNORE_ALWAYS {
+		return false
+	}
 	return fieldConstraints.GetRequired()
 }
@@ -0,0 +1,466 @@
+package openapiv3_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v4"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestWireSchemaIntegration verifies the golden OpenAPI documents describe the
+// JSON that generated servers actually put on the wire for unwrap, flatten and
+// discriminated oneof messages. It:
+//  1. generates Go messages and HTTP handlers for the fixtures into a temp module,
+//  2. runs a program there that posts sample requests to echo/fixed servers and
+//     prints every response body,
+//  3. validates each body against the response schema of its operation in the
+//     golden YAML.
+func TestWireSchemaIntegration(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, pkg := range []struct{ proto, dir string }{
+		{"unwrap.proto", "unwrap"},
+		{"flatten.proto", "flatten"},
+		{"oneof_discriminator.proto", "oneofdiscriminator"},
+	} {
+		// unwrap.proto declares GetOptionBars on two services, whose generated
+		// Go helpers collide in one package; only UnwrapService is served here.
+		content, readErr := os.ReadFile(filepath.Join("testdata", "proto", pkg.proto))
+		if readErr != nil {
+			t.Fatal(readErr)
+		}
+		src := string(content)
+		if start := strings.Index(src, "service OptionDataService {"); start >= 0 {
+			end := start + strings.Index(src[start:], "\n}\n") + len("\n}\n")
+			src = src[:start] + src[end:]
+		}
+		plugintest.GenerateCode(t, tempDir, plugintest.Module{
+			Protos:   map[string]string{pkg.proto: src},
+			Mappings: []string{"M" + pkg.proto + "=wire_schema_test/" + pkg.dir + ";" + pkg.dir},
+			Plugins:  []plugintest.Plugin{{Name: "go-http"}},
+			Out:      pkg.dir,
+		})
+	}
+	plugintest.WriteModule(t, tempDir, plugintest.Module{
+		Path:  "wire_schema_test",
+		Files: map[string]string{"main.go": wireSchemaProgram},
+	})
+
+	runCmd := exec.Command("go", "run", ".")
+	runCmd.Dir = tempDir
+	runCmd.Stderr = os.Stderr
+	out, runErr := runCmd.Output()
+	if runErr != nil {
+		t.Fatalf("sample program failed: %v", runErr)
+	}
+
+	var samples []struct {
+		Service string          `json:"service"`
+		Path    string          `json:"path"`
+		Body    json.RawMessage `json:"body"`
+	}
+	if unmarshalErr := json.Unmarshal(out, &samples); unmarshalErr != nil {
+		t.Fatalf("Failed to parse sample output: %v\n%s", unmarshalErr, string(out))
+	}
+
+	docs := map[string]map[string]any{}
+	for _, sample := range samples {
+		t.Run(sample.Service+sample.Path, func(t *testing.T) {
+			doc, ok := docs[sample.Service]
+			if !ok {
+				doc = loadGoldenDocument(t, sample.Service)
+				docs[sample.Service] = doc
+			}
+			schema := lookup(doc, "paths", sample.Path, "post", "responses", "200",
+				"content", "application/json", "schema")
+			if schema == nil {
+				t.Fatalf("no 200 response schema for POST %s", sample.Path)
+			}
+
+			var body any
+			if unmarshalErr := json.Unmarshal(sample.Body, &body); unmarshalErr != nil {
+				t.Fatalf("response is not JSON: %v", unmarshalErr)
+			}
+			if validateErr := (&wireValidator{doc: doc}).validate(schema, body, "$"); validateErr != nil {
+				t.Errorf("response does not match schema: %v\nbody: %s", validateErr, string(sample.Body))
+			}
+		})
+	}
+}
+
+func loadGoldenDocument(t *testing.T, service string) map[string]any {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", "golden", "yaml", service+".openapi.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	var doc map[string]any
+	if err = yaml.Unmarshal(content, &doc); err != nil {
+		t.Fatalf("Failed to parse golden file: %v", err)
+	}
+	return doc
+}
+
+// lookup walks nested YAML mappings, returning nil when a key is missing.
+func lookup(node any, keys ...string) any {
+	for _, key := range keys {
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil
+		}
+		node = m[key]
+	}
+	return node
+}
+
+// wireValidator checks a decoded JSON value against an OpenAPI 3.1 schema. It
+// supports the keywords sebuf emits for messages ($ref, type, enum, properties,
+// required, additionalProperties, items, oneOf, allOf). Validation is strict:
+// an object schema with properties and no additionalProperties rejects
+// undeclared keys, since the schemas are meant to describe the wire exactly.
+type wireValidator struct {
+	doc map[string]any
+}
+
+func (v *wireValidator) validate(schemaNode, value any, path string) error {
+	schema, ok := schemaNode.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s: schema is not an object", path)
+	}
+
+	if ref, isRef := schema["$ref"].(string); isRef {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		target := lookup(v.doc, "components", "schemas", name)
+		if target == nil {
+			return fmt.Errorf("%s: unresolved $ref %s", path, ref)
+		}
+		return v.validate(target, value, path)
+	}
+
+	if err := v.validateType(schema, value, path); err != nil {
+		return err
+	}
+	if enum, hasEnum := schema["enum"].([]any); hasEnum && !slices.Contains(enum, value) {
+		return fmt.Errorf("%s: %v is not one of %v", path, value, enum)
+	}
+
+	for _, sub := range asList(schema["allOf"]) {
+		if err := v.validate(sub, value, path); err != nil {
+			return err
+		}
+	}
+	if oneOf := asList(schema["oneOf"]); len(oneOf) > 0 {
+		var matches int
+		var errs []string
+		for _, sub := range oneOf {
+			if err := v.validate(sub, value, path); err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			matches++
+		}
+		if matches != 1 {
+			return fmt.Errorf("%s: matches %d oneOf schemas, want 1 (%s)", path, matches, strings.Join(errs, "; "))
+		}
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		return v.validateObject(schema, typed, path)
+	case []any:
+		if items, hasItems := schema["items"]; hasItems {
+			for i, item := range typed {
+				if err := v.validate(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *wireValidator) validateType(schema map[string]any, value any, path string) error {
+	var types []any
+	switch typ := schema["type"].(type) {
+	case string:
+		types = []any{typ}
+	case []any:
+		types = typ
+	default:
+		return nil
+	}
+	for _, typ := range types {
+		if jsonTypeMatches(typ, value) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: %T value %v is not of type %v", path, value, value, types)
+}
+
+func jsonTypeMatches(typ, value any) bool {
+	switch typ {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	}
+	return false
+}
+
+func (v *wireValidator) validateObject(schema, value map[string]any, path string) error {
+	properties, _ := schema["properties"].(map[string]any)
+	for _, name := range asList(schema["required"]) {
+		if key, _ := name.(string); value[key] == nil {
+			return fmt.Errorf("%s: missing required property %q", path, key)
+		}
+	}
+
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	additional, hasAdditional := schema["additionalProperties"]
+	for _, key := range keys {
+		propPath := path + "." + key
+		if prop, declared := properties[key]; declared {
+			if err := v.validate(prop, value[key], propPath); err != nil {
+				return err
+			}
+			continue
+		}
+		switch {
+		case hasAdditional:
+			if err := v.validate(additional, value[key], propPath); err != nil {
+				return err
+			}
+		case properties != nil:
+			return fmt.Errorf("%s: undeclared property", propPath)
+		}
+	}
+	return nil
+}
+
+func asList(node any) []any {
+	list, _ := node.([]any)
+	return list
+}
+
+// wireSchemaProgram runs inside the temp module. Services whose methods return
+// their request type echo it back, so requests round-trip through the generated
+// UnmarshalJSON and MarshalJSON and must come back unchanged; the unwrap service
+// answers with fixed data.
+const wireSchemaProgram = `package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"wire_schema_test/flatten"
+	"wire_schema_test/oneofdiscriminator"
+	"wire_schema_test/unwrap"
+)
+
+var bars = []*unwrap.OptionBar{
+	{Symbol: "AAPL240119C00190000", Price: 4.25, Volume: 1200, Timestamp: "2024-01-02T14:30:00Z"},
+	{Symbol: "AAPL240119C00195000", Price: 2.1, Volume: 800, Timestamp: "2024-01-02T14:31:00Z"},
+}
+
+type unwrapServer struct{}
+
+func (unwrapServer) GetOptionBars(
+	context.Context, *unwrap.GetOptionBarsRequest,
+) (*unwrap.GetOptionBarsResponse, error) {
+	return &unwrap.GetOptionBarsResponse{
+		Bars:          map[string]*unwrap.OptionBarsList{"AAPL": {Bars: bars}, "GOOG": {}},
+		NextPageToken: "page-2",
+	}, nil
+}
+
+func (unwrapServer) GetRootMap(context.Context, *unwrap.GetOptionBarsRequest) (*unwrap.RootMapResponse, error) {
+	return &unwrap.RootMapResponse{People: map[string]*unwrap.OptionBar{"AAPL": bars[0], "MSFT": bars[1]}}, nil
+}
+
+func (unwrapServer) GetRootRepeated(
+	context.Context, *unwrap.GetOptionBarsRequest,
+) (*unwrap.RootRepeatedResponse, error) {
+	return &unwrap.RootRepeatedResponse{Items: bars}, nil
+}
+
+func (unwrapServer) GetRootMapWithValueUnwrap(
+	context.Context, *unwrap.GetOptionBarsRequest,
+) (*unwrap.RootMapWithValueUnwrapResponse, error) {
+	return &unwrap.RootMapWithValueUnwrapResponse{
+		Data: map[string]*unwrap.OptionBarsList{"AAPL": {Bars: bars}, "GOOG": {Bars: bars[1:]}},
+	}, nil
+}
+
+type flattenServer struct{}
+
+func (flattenServer) TestSimpleFlatten(_ context.Context, req *flatten.SimpleFlatten) (*flatten.SimpleFlatten, error) {
+	return req, nil
+}
+
+func (flattenServer) TestDualFlatten(_ context.Context, req *flatten.DualFlatten) (*flatten.DualFlatten, error) {
+	return req, nil
+}
+
+func (flattenServer) TestMixedFlatten(_ context.Context, req *flatten.MixedFlatten) (*flatten.MixedFlatten, error) {
+	return req, nil
+}
+
+func (flattenServer) TestPlainNested(_ context.Context, req *flatten.PlainNested) (*flatten.PlainNested, error) {
+	return req, nil
+}
+
+type oneofServer struct{}
+
+func (oneofServer) TestFlattenedEvent(
+	_ context.Context, req *oneofdiscriminator.FlattenedEvent,
+) (*oneofdiscriminator.FlattenedEvent, error) {
+	return req, nil
+}
+
+func (oneofServer) TestNestedEvent(
+	_ context.Context, req *oneofdiscriminator.NestedEvent,
+) (*oneofdiscriminator.NestedEvent, error) {
+	return req, nil
+}
+
+func (oneofServer) TestPlainEvent(
+	_ context.Context, req *oneofdiscriminator.PlainEvent,
+) (*oneofdiscriminator.PlainEvent, error) {
+	return req, nil
+}
+
+type sample struct {
+	Service string          ` + "`json:\"service\"`" + `
+	Path    string          ` + "`json:\"path\"`" + `
+	Body    json.RawMessage ` + "`json:\"body\"`" + `
+}
+
+func marshal(msg proto.Message) ([]byte, error) {
+	if m, ok := msg.(json.Marshaler); ok {
+		return m.MarshalJSON()
+	}
+	return protojson.Marshal(msg)
+}
+
+func main() {
+	mux := http.NewServeMux()
+	for _, err := range []error{
+		unwrap.RegisterUnwrapServiceServer(unwrapServer{}, unwrap.WithMux(mux)),
+		flatten.RegisterFlattenServiceServer(flattenServer{}, flatten.WithMux(mux)),
+		oneofdiscriminator.RegisterOneofDiscriminatorServiceServer(oneofServer{}, oneofdiscriminator.WithMux(mux)),
+	} {
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	address := &flatten.Address{Street: "1 Main St", City: "Springfield", Zip: "12345"}
+	text := &oneofdiscriminator.TextContent{Body: "hello"}
+	image := &oneofdiscriminator.ImageContent{Url: "https://example.com/a.png", Width: 640, Height: 480}
+	video := &oneofdiscriminator.VideoContent{Url: "https://example.com/a.mp4", Duration: 30}
+	optionBars := &unwrap.GetOptionBarsRequest{Symbols: []string{"AAPL"}}
+
+	calls := []struct {
+		service, path string
+		req           proto.Message
+	}{
+		{"UnwrapService", "/api/v1/unwrap/options/bars", optionBars},
+		{"UnwrapService", "/api/v1/root/map", optionBars},
+		{"UnwrapService", "/api/v1/root/repeated", optionBars},
+		{"UnwrapService", "/api/v1/root/map-value-unwrap", optionBars},
+		{"FlattenService", "/api/v1/flatten/simple", &flatten.SimpleFlatten{Id: "1", Address: address}},
+		{"FlattenService", "/api/v1/flatten/dual", &flatten.DualFlatten{Id: "2", Billing: address, Shipping: address}},
+		{"FlattenService", "/api/v1/flatten/mixed", &flatten.MixedFlatten{
+			Id: "3", Address: address, Contact: &flatten.ContactInfo{Email: "a@example.com"}, Notes: "n",
+		}},
+		{"FlattenService", "/api/v1/flatten/plain", &flatten.PlainNested{Id: "4", Address: address}},
+		{"OneofDiscriminatorService", "/api/v1/events/flattened", &oneofdiscriminator.FlattenedEvent{
+			Id: "e1", Content: &oneofdiscriminator.FlattenedEvent_Text{Text: text},
+		}},
+		{"OneofDiscriminatorService", "/api/v1/events/flattened", &oneofdiscriminator.FlattenedEvent{
+			Id: "e2", Content: &oneofdiscriminator.FlattenedEvent_Image{Image: image},
+		}},
+		{"OneofDiscriminatorService", "/api/v1/events/nested", &oneofdiscriminator.NestedEvent{
+			Id: "e3", Content: &oneofdiscriminator.NestedEvent_Text{Text: text},
+		}},
+		{"OneofDiscriminatorService", "/api/v1/events/nested", &oneofdiscriminator.NestedEvent{
+			Id: "e4", Content: &oneofdiscriminator.NestedEvent_Image{Image: image},
+		}},
+		{"OneofDiscriminatorService", "/api/v1/events/nested", &oneofdiscriminator.NestedEvent{
+			Id: "e5", Content: &oneofdiscriminator.NestedEvent_Video{Video: video},
+		}},
+		{"OneofDiscriminatorService", "/api/v1/events/plain", &oneofdiscriminator.PlainEvent{
+			Id: "e6", Content: &oneofdiscriminator.PlainEvent_Image{Image: image},
+		}},
+	}
+
+	var samples []sample
+	for _, call := range calls {
+		reqBody, err := marshal(call.req)
+		if err != nil {
+			log.Fatal(err)
+		}
+		resp, err := http.Post(srv.URL+call.path, "application/json", bytes.NewReader(reqBody))
+		if err != nil {
+			log.Fatal(err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			log.Fatalf("POST %s: status %d: %s", call.path, resp.StatusCode, body)
+		}
+		if call.service != "UnwrapService" && !bytes.Equal(bytes.TrimSpace(body), reqBody) {
+			log.Fatalf("POST %s: echo lost data:\nsent     %s\nreceived %s", call.path, reqBody, body)
+		}
+		samples = append(samples, sample{Service: call.service, Path: call.path, Body: body})
+	}
+
+	if err := json.NewEncoder(os.Stdout).Encode(samples); err != nil {
+		log.Fatal(err)
+	}
+}
+`
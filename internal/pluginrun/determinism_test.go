@@ -0,0 +1,255 @@
+package pluginrun_test
+
+import (
+	"math/rand/v2"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/internal/clientgen"
+	"github.com/SebastienMelki/sebuf/internal/httpgen"
+	"github.com/SebastienMelki/sebuf/internal/ktclientgen"
+	"github.com/SebastienMelki/sebuf/internal/openapiv3"
+	"github.com/SebastienMelki/sebuf/internal/pyclientgen"
+	"github.com/SebastienMelki/sebuf/internal/tsclientgen"
+	"github.com/SebastienMelki/sebuf/internal/tsservergen"
+)
+
+// determinismShuffles is the number of shuffled requests each generator is run
+// on per example.
+const determinismShuffles = 4
+
+// determinismGenerators are the generators whose output must not depend on the
+// order of the files in the request, with parameters turning on their optional
+// files.
+var determinismGenerators = []struct {
+	name      string
+	parameter string
+	run       func(*pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error)
+}{
+	{"go-http", "paths=source_relative,generate_mock=true,generate_tests=true,manifest=true",
+		func(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+			return httpgen.Run(req, httpgen.Options{})
+		}},
+	{"go-client", "paths=source_relative,manifest=true",
+		func(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+			return clientgen.Run(req, clientgen.Options{})
+		}},
+	{"ts-client", "paths=source_relative,manifest=true",
+		func(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+			return tsclientgen.Run(req, tsclientgen.Options{})
+		}},
+	{"ts-server", "paths=source_relative",
+		func(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+			return tsservergen.Run(req, tsservergen.Options{})
+		}},
+	{"openapiv3", "bundle=true,manifest=true",
+		func(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+			return openapiv3.Run(req, openapiv3.Options{})
+		}},
+	{"py-client", "",
+		func(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+			return pyclientgen.Run(req, pyclientgen.Options{})
+		}},
+	{"kt-client", "",
+		func(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+			return ktclientgen.Run(req, ktclientgen.Options{})
+		}},
+}
+
+// TestGeneratedOutputIgnoresFileOrder runs every generator on each example's
+// protos in protoc's order and in shuffled orders, a valid dependency order of
+// the descriptors and any order of the files to generate, and checks that every
+// run generates the same files with byte-identical contents. Upgrading protoc
+// or protogen then cannot reorder vendored generated code, and the generators
+// collecting across files, like unwrap, cannot depend on which file comes first.
+func TestGeneratedOutputIgnoresFileOrder(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping determinism test")
+	}
+
+	projectRoot, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exampleDirs, err := filepath.Glob(filepath.Join(projectRoot, "examples", "*", "buf.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exampleDirs) == 0 {
+		t.Fatal("no examples found")
+	}
+
+	for _, bufYAML := range exampleDirs {
+		exampleDir := filepath.Dir(bufYAML)
+		t.Run(filepath.Base(exampleDir), func(t *testing.T) {
+			files, descriptors := compileExample(t, projectRoot, exampleDir)
+			for _, gen := range determinismGenerators {
+				t.Run(gen.name, func(t *testing.T) {
+					want := generateFiles(t, gen.run, &pluginpb.CodeGeneratorRequest{
+						FileToGenerate: files,
+						Parameter:      proto.String(gen.parameter),
+						ProtoFile:      descriptors,
+					})
+					rng := rand.New(rand.NewPCG(1, uint64(len(files))))
+					for range determinismShuffles {
+						shuffled := slices.Clone(files)
+						rng.Shuffle(len(shuffled), func(i, j int) {
+							shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+						})
+						got := generateFiles(t, gen.run, &pluginpb.CodeGeneratorRequest{
+							FileToGenerate: shuffled,
+							Parameter:      proto.String(gen.parameter),
+							ProtoFile:      shuffleDependencyOrder(rng, descriptors),
+						})
+						assertSameFiles(t, shuffled, want, got)
+					}
+				})
+			}
+		})
+	}
+}
+
+// compileExample compiles the protos of the buf module in exampleDir and returns
+// their names, relative to exampleDir and sorted, and the descriptors of them
+// and their imports in protoc's dependency order. Like the go_package_prefix
+// the examples set in buf's managed mode, the files without a go_package get
+// one under the example's api package.
+func compileExample(t *testing.T, projectRoot, exampleDir string) ([]string, []*descriptorpb.FileDescriptorProto) {
+	t.Helper()
+
+	var files []string
+	protoDir := filepath.Join(exampleDir, "proto")
+	walkErr := filepath.WalkDir(protoDir, func(file string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || filepath.Ext(file) != ".proto" {
+			return err
+		}
+		rel, relErr := filepath.Rel(exampleDir, file)
+		files = append(files, filepath.ToSlash(rel))
+		return relErr
+	})
+	if walkErr != nil {
+		t.Fatal(walkErr)
+	}
+	slices.Sort(files)
+
+	descPath := filepath.Join(t.TempDir(), "descriptors.pb")
+	args := []string{
+		"--descriptor_set_out=" + descPath,
+		"--include_imports",
+		"--include_source_info",
+		"--proto_path=" + exampleDir,
+		"--proto_path=" + filepath.Join(projectRoot, "proto"),
+	}
+	cmd := exec.Command("protoc", append(args, files...)...)
+	cmd.Dir = exampleDir
+	if out, runErr := cmd.CombinedOutput(); runErr != nil {
+		t.Fatalf("protoc descriptor_set_out failed: %v\noutput: %s", runErr, out)
+	}
+	raw, err := os.ReadFile(descPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fds descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(raw, &fds); err != nil {
+		t.Fatal(err)
+	}
+	goPackagePrefix := "github.com/SebastienMelki/sebuf/examples/" + filepath.Base(exampleDir) + "/api/"
+	for _, fd := range fds.GetFile() {
+		if !slices.Contains(files, fd.GetName()) || fd.GetOptions().GetGoPackage() != "" {
+			continue
+		}
+		if fd.Options == nil {
+			fd.Options = &descriptorpb.FileOptions{}
+		}
+		fd.Options.GoPackage = proto.String(goPackagePrefix + path.Dir(fd.GetName()))
+	}
+	return files, fds.GetFile()
+}
+
+// shuffleDependencyOrder returns the descriptors in a random order that still
+// lists every file after its dependencies, as protogen requires.
+func shuffleDependencyOrder(
+	rng *rand.Rand,
+	descriptors []*descriptorpb.FileDescriptorProto,
+) []*descriptorpb.FileDescriptorProto {
+	pending := slices.Clone(descriptors)
+	placed := make(map[string]bool, len(descriptors))
+	ordered := make([]*descriptorpb.FileDescriptorProto, 0, len(descriptors))
+	for len(pending) > 0 {
+		var ready []int
+		for i, fd := range pending {
+			if !slices.ContainsFunc(fd.GetDependency(), func(dep string) bool { return !placed[dep] }) {
+				ready = append(ready, i)
+			}
+		}
+		next := ready[rng.IntN(len(ready))]
+		placed[pending[next].GetName()] = true
+		ordered = append(ordered, pending[next])
+		pending = slices.Delete(pending, next, next+1)
+	}
+	return ordered
+}
+
+// generateFiles runs a generator on req and returns the contents of the files
+// it generated by name.
+func generateFiles(
+	t *testing.T,
+	run func(*pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error),
+	req *pluginpb.CodeGeneratorRequest,
+) map[string]string {
+	t.Helper()
+	resp, err := run(req)
+	if err != nil {
+		t.Fatalf("generator failed: %v", err)
+	}
+	if resp.GetError() != "" {
+		t.Fatalf("generator reported an error: %s", resp.GetError())
+	}
+	files := make(map[string]string, len(resp.GetFile()))
+	for _, file := range resp.GetFile() {
+		if file.GetInsertionPoint() != "" {
+			t.Fatalf("unexpected insertion point in %s", file.GetName())
+		}
+		files[file.GetName()] = file.GetContent()
+	}
+	return files
+}
+
+// assertSameFiles checks that a run on the files to generate in order produced
+// the files of the run in protoc's order, with the same contents.
+func assertSameFiles(t *testing.T, order []string, want, got map[string]string) {
+	t.Helper()
+	for name, content := range want {
+		gotContent, ok := got[name]
+		switch {
+		case !ok:
+			t.Errorf("generating %v: %s is missing", order, name)
+		case gotContent != content:
+			t.Errorf("generating %v: %s differs:\n%s", order, name, firstDifference(content, gotContent))
+		}
+	}
+	for name := range got {
+		if _, ok := want[name]; !ok {
+			t.Errorf("generating %v: unexpected file %s", order, name)
+		}
+	}
+}
+
+// firstDifference returns the lines around the first difference between want
+// and got.
+func firstDifference(want, got string) string {
+	i := 0
+	for i < len(want) && i < len(got) && want[i] == got[i] {
+		i++
+	}
+	start := max(i-200, 0)
+	return "want ..." + want[start:min(i+200, len(want))] + "\ngot  ..." + got[start:min(i+200, len(got))]
+}
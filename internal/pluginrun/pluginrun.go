@@ -0,0 +1,153 @@
+// Package pluginrun runs sebuf generators on a CodeGeneratorRequest held in
+// memory, so they can be embedded in other tools without shelling out to
+// protoc. The protoc-gen-* binaries are thin wrappers around Main.
+package pluginrun
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// supportedFeatures are the features every sebuf generator declares: proto3
+// optional fields, and editions from proto2 up to annotations.MaxSupportedEdition,
+// so that protoc hands them editions files instead of rejecting them.
+const supportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL |
+	pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS)
+
+// Run builds a protogen.Plugin from req and calls generate on it. Problems
+// with the request (malformed descriptors, invalid parameters) and errors
+// returned by generate are reported in the response's Error field, as the
+// plugin protocol expects. A panic is recovered and returned as the error,
+// since it signals a generator bug rather than bad input.
+//
+// paramFunc receives the parameters protogen does not handle itself and may
+// be nil to ignore them.
+func Run(
+	req *pluginpb.CodeGeneratorRequest,
+	paramFunc func(name, value string) error,
+	generate func(*protogen.Plugin) error,
+) (resp *pluginpb.CodeGeneratorResponse, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			resp, err = nil, fmt.Errorf("generator panic: %v", r)
+		}
+	}()
+
+	if req == nil {
+		return errorResponse(errors.New("nil CodeGeneratorRequest")), nil
+	}
+	options := protogen.Options{}
+	if paramFunc != nil {
+		options.ParamFunc = func(name, value string) error {
+			if paramErr := paramFunc(name, value); paramErr != nil {
+				return fmt.Errorf("invalid plugin parameter %s=%s: %w", name, value, paramErr)
+			}
+			return nil
+		}
+	}
+	plugin, err := options.New(req)
+	if err != nil {
+		return errorResponse(err), nil
+	}
+	plugin.SupportedFeatures = supportedFeatures
+	plugin.SupportedEditionsMinimum = descriptorpb.Edition_EDITION_PROTO2
+	plugin.SupportedEditionsMaximum = annotations.MaxSupportedEdition
+	if genErr := generate(plugin); genErr != nil {
+		plugin.Error(genErr)
+	}
+	return plugin.Response(), nil
+}
+
+// errorResponse returns a response carrying only err.
+func errorResponse(err error) *pluginpb.CodeGeneratorResponse {
+	return &pluginpb.CodeGeneratorResponse{
+		Error:             proto.String(err.Error()),
+		SupportedFeatures: proto.Uint64(supportedFeatures),
+		MinimumEdition:    proto.Int32(int32(descriptorpb.Edition_EDITION_PROTO2)),
+		MaximumEdition:    proto.Int32(int32(annotations.MaxSupportedEdition)),
+	}
+}
+
+// Main runs a generator as a protoc plugin: it reads the request from stdin,
+// calls run and writes the response to stdout. Failures outside the response
+// (unreadable input, a generator panic) are printed to stderr with exit code 1.
+func Main(run func(*pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error)) {
+	if err := serve(os.Stdin, os.Stdout, run); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", filepath.Base(os.Args[0]), err)
+		os.Exit(1)
+	}
+}
+
+// serve implements Main over explicit streams.
+func serve(
+	in io.Reader,
+	out io.Writer,
+	run func(*pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error),
+) error {
+	input, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	req := &pluginpb.CodeGeneratorRequest{}
+	if err = proto.Unmarshal(input, req); err != nil {
+		return err
+	}
+	resp, err := run(req)
+	if err != nil {
+		return err
+	}
+	output, err := proto.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(output)
+	return err
+}
+
+// TakeParam removes every name=value entry for name from a comma-separated
+// plugin parameter string, returning the last value and the remaining string.
+func TakeParam(param, name string) (string, string) {
+	var value string
+	var rest []string
+	for _, kv := range strings.Split(param, ",") {
+		if k, v, _ := strings.Cut(kv, "="); k == name {
+			value = v
+			continue
+		}
+		rest = append(rest, kv)
+	}
+	return value, strings.Join(rest, ",")
+}
+
+// TakeListParam removes a list parameter from a comma-separated plugin
+// parameter string, returning its values and the remaining string. protoc
+// splits the parameter on commas, so name=a,b arrives as name=a and a bare b:
+// the entries without "=" following a name entry are taken as more values.
+// Repeating name=value also adds values. The values are nil when name is absent.
+func TakeListParam(param, name string) ([]string, string) {
+	var values, rest []string
+	inList := false
+	for _, kv := range strings.Split(param, ",") {
+		k, v, hasValue := strings.Cut(kv, "=")
+		switch {
+		case hasValue && k == name:
+			values, inList = append(values, v), true
+		case !hasValue && inList:
+			values = append(values, kv)
+		default:
+			rest, inList = append(rest, kv), false
+		}
+	}
+	return values, strings.Join(rest, ",")
+}
@@ -0,0 +1,157 @@
+package pluginrun
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/internal/pluginrun/pluginruntest"
+)
+
+func TestRunGenerates(t *testing.T) {
+	var gotParam string
+	resp, err := Run(pluginruntest.Request("flavor=plain"), func(name, value string) error {
+		gotParam = name + "=" + value
+		return nil
+	}, func(plugin *protogen.Plugin) error {
+		plugin.NewGeneratedFile("out.txt", "").P(len(plugin.Files[len(plugin.Files)-1].Services))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if resp.GetError() != "" {
+		t.Fatalf("response error: %s", resp.GetError())
+	}
+	if gotParam != "flavor=plain" {
+		t.Errorf("paramFunc got %q, want flavor=plain", gotParam)
+	}
+	if got := pluginruntest.Content(resp, "out.txt"); got != "1\n" {
+		t.Errorf("out.txt = %q, want 1", got)
+	}
+	if resp.GetSupportedFeatures()&uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL) == 0 {
+		t.Error("response should declare proto3 optional support")
+	}
+	if resp.GetSupportedFeatures()&uint64(pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS) == 0 ||
+		resp.GetMinimumEdition() != int32(descriptorpb.Edition_EDITION_PROTO2) ||
+		resp.GetMaximumEdition() != int32(descriptorpb.Edition_EDITION_2023) {
+		t.Errorf("response should declare editions proto2 to 2023, got features %d, editions %d to %d",
+			resp.GetSupportedFeatures(), resp.GetMinimumEdition(), resp.GetMaximumEdition())
+	}
+}
+
+func TestRunReportsErrorsInResponse(t *testing.T) {
+	missingDependency := pluginruntest.Request("")
+	missingDependency.ProtoFile = missingDependency.GetProtoFile()[1:]
+
+	tests := []struct {
+		name      string
+		req       *pluginpb.CodeGeneratorRequest
+		paramFunc func(name, value string) error
+		generate  func(*protogen.Plugin) error
+		want      string
+	}{
+		{
+			name: "nil request",
+			want: "nil CodeGeneratorRequest",
+		},
+		{
+			name: "missing dependency",
+			req:  missingDependency,
+			want: "sebuf/http/annotations.proto",
+		},
+		{
+			name:      "invalid parameter",
+			req:       pluginruntest.Request("flavor=spicy"),
+			paramFunc: func(string, string) error { return errors.New("unsupported flavor") },
+			want:      "unsupported flavor",
+		},
+		{
+			name:     "generator error",
+			req:      pluginruntest.Request(""),
+			generate: func(*protogen.Plugin) error { return errors.New("bad annotation") },
+			want:     "bad annotation",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generate := tt.generate
+			if generate == nil {
+				generate = func(*protogen.Plugin) error { return nil }
+			}
+			resp, err := Run(tt.req, tt.paramFunc, generate)
+			if err != nil {
+				t.Fatalf("Run returned error %v, want it in the response", err)
+			}
+			if !strings.Contains(resp.GetError(), tt.want) {
+				t.Errorf("response error = %q, want it to contain %q", resp.GetError(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRunRecoversPanics(t *testing.T) {
+	resp, err := Run(pluginruntest.Request(""), nil, func(*protogen.Plugin) error {
+		panic("index out of range")
+	})
+	if err == nil || !strings.Contains(err.Error(), "index out of range") {
+		t.Fatalf("Run error = %v, want the recovered panic", err)
+	}
+	if resp != nil {
+		t.Errorf("response = %v, want nil", resp)
+	}
+}
+
+func TestServe(t *testing.T) {
+	in, err := proto.Marshal(pluginruntest.Request(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	err = serve(bytes.NewReader(in), &out, func(req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+		return &pluginpb.CodeGeneratorResponse{Error: proto.String(req.GetFileToGenerate()[0])}, nil
+	})
+	if err != nil {
+		t.Fatalf("serve: %v", err)
+	}
+	resp := &pluginpb.CodeGeneratorResponse{}
+	if err = proto.Unmarshal(out.Bytes(), resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetError() != pluginruntest.FileName {
+		t.Errorf("response = %v, want the request's file name echoed", resp)
+	}
+
+	if err = serve(strings.NewReader("not a request"), &out, nil); err == nil {
+		t.Error("serve should fail on an unparsable request")
+	}
+}
+
+func TestTakeParam(t *testing.T) {
+	value, rest := TakeParam("paths=source_relative,module=cjs,target=node,module=esm", "module")
+	if value != "esm" || rest != "paths=source_relative,target=node" {
+		t.Errorf("TakeParam = %q, %q", value, rest)
+	}
+	value, rest = TakeParam("target=node", "module")
+	if value != "" || rest != "target=node" {
+		t.Errorf("TakeParam without the parameter = %q, %q", value, rest)
+	}
+}
+
+func TestTakeListParam(t *testing.T) {
+	values, rest := TakeListParam("paths=source_relative,list=a,b,target=node,list=c", "list")
+	if !slices.Equal(values, []string{"a", "b", "c"}) || rest != "paths=source_relative,target=node" {
+		t.Errorf("TakeListParam = %q, %q", values, rest)
+	}
+	values, rest = TakeListParam("target=node", "list")
+	if values != nil || rest != "target=node" {
+		t.Errorf("TakeListParam without the parameter = %q, %q", values, rest)
+	}
+}
@@ -0,0 +1,114 @@
+// Package pluginruntest builds CodeGeneratorRequests in memory for tests that
+// drive the generators' Run functions without protoc.
+package pluginruntest
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/http"
+)
+
+// FileName is the name of the proto file Request asks to generate.
+const FileName = "notes.proto"
+
+// Request returns a request generating notes.proto with the given plugin
+// parameter. The file declares a NoteService under base path /api/v1 with
+// GetNote (GET /notes/{id}) and CreateNote (POST /notes). Its dependencies
+// come from the descriptors compiled into the binary, converted with protodesc.
+func Request(parameter string) *pluginpb.CodeGeneratorRequest {
+	var files []*descriptorpb.FileDescriptorProto
+	seen := make(map[string]bool)
+	var addFile func(fd protoreflect.FileDescriptor)
+	addFile = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		imports := fd.Imports()
+		for i := range imports.Len() {
+			addFile(imports.Get(i).FileDescriptor)
+		}
+		files = append(files, protodesc.ToFileDescriptorProto(fd))
+	}
+	addFile(http.File_sebuf_http_annotations_proto)
+
+	return &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{FileName},
+		Parameter:      proto.String(parameter),
+		ProtoFile:      append(files, notesFile()),
+	}
+}
+
+// notesFile builds the descriptor of notes.proto.
+func notesFile() *descriptorpb.FileDescriptorProto {
+	stringField := func(name string, number int32) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			JsonName: proto.String(name),
+		}
+	}
+	method := func(name, input, path string, httpMethod http.HttpMethod) *descriptorpb.MethodDescriptorProto {
+		options := &descriptorpb.MethodOptions{}
+		proto.SetExtension(options, http.E_Config, &http.HttpConfig{Path: path, Method: httpMethod})
+		return &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(name),
+			InputType:  proto.String(".test.notes.v1." + input),
+			OutputType: proto.String(".test.notes.v1.Note"),
+			Options:    options,
+		}
+	}
+	serviceOptions := &descriptorpb.ServiceOptions{}
+	proto.SetExtension(serviceOptions, http.E_ServiceConfig, &http.ServiceConfig{BasePath: "/api/v1"})
+
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String(FileName),
+		Package:    proto.String("test.notes.v1"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{http.File_sebuf_http_annotations_proto.Path()},
+		Options: &descriptorpb.FileOptions{
+			GoPackage: proto.String("github.com/SebastienMelki/sebuf/internal/pluginrun/notesv1;notesv1"),
+		},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("GetNoteRequest"), Field: []*descriptorpb.FieldDescriptorProto{stringField("id", 1)}},
+			{Name: proto.String("CreateNoteRequest"), Field: []*descriptorpb.FieldDescriptorProto{stringField("body", 1)}},
+			{
+				Name:  proto.String("Note"),
+				Field: []*descriptorpb.FieldDescriptorProto{stringField("id", 1), stringField("body", 2)},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("NoteService"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				method("GetNote", "GetNoteRequest", "/notes/{id}", http.HttpMethod_HTTP_METHOD_GET),
+				method("CreateNote", "CreateNoteRequest", "/notes", http.HttpMethod_HTTP_METHOD_POST),
+			},
+			Options: serviceOptions,
+		}},
+	}
+}
+
+// FileNames returns the names of the files in resp.
+func FileNames(resp *pluginpb.CodeGeneratorResponse) []string {
+	names := make([]string, 0, len(resp.GetFile()))
+	for _, file := range resp.GetFile() {
+		names = append(names, file.GetName())
+	}
+	return names
+}
+
+// Content returns the content of the file named name in resp, or "".
+func Content(resp *pluginpb.CodeGeneratorResponse, name string) string {
+	for _, file := range resp.GetFile() {
+		if file.GetName() == name {
+			return file.GetContent()
+		}
+	}
+	return ""
+}
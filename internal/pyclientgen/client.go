@@ -7,6 +7,7 @@ import (
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/reflect/protoreflect"
 
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
 	"github.com/SebastienMelki/sebuf/internal/annotations"
 )
 
@@ -33,7 +34,12 @@ func writeClientOptionsClass(p printer, service *protogen.Service, serviceName s
 	// Typed kwargs for every service-level header annotation.
 	serviceHeaders := annotations.GetServiceHeaders(service)
 	for _, header := range serviceHeaders {
-		p("    %s: Optional[str] = None", headerOptionName(header.GetName()))
+		p("    %s: %s = None", headerOptionName(header.GetName()), headerOptionType(header))
+	}
+
+	// Base path parameters: the constructor raises ValueError when one is unset.
+	for _, param := range annotations.GetBasePathParams(service) {
+		p("    %s: Optional[str] = None", param.Name)
 	}
 	p("")
 	p("")
@@ -52,7 +58,7 @@ func writeCallOptionsClass(p printer, service *protogen.Service, serviceName str
 	seen := make(map[string]bool)
 	for _, header := range annotations.GetServiceHeaders(service) {
 		seen[header.GetName()] = true
-		p("    %s: Optional[str] = None", headerOptionName(header.GetName()))
+		p("    %s: %s = None", headerOptionName(header.GetName()), headerOptionType(header))
 	}
 	for _, method := range service.Methods {
 		for _, header := range annotations.GetMethodHeaders(method) {
@@ -60,7 +66,7 @@ func writeCallOptionsClass(p printer, service *protogen.Service, serviceName str
 				continue
 			}
 			seen[header.GetName()] = true
-			p("    %s: Optional[str] = None", headerOptionName(header.GetName()))
+			p("    %s: %s = None", headerOptionName(header.GetName()), headerOptionType(header))
 		}
 	}
 	p("")
@@ -87,8 +93,22 @@ func writeClientConstructor(p printer, service *protogen.Service, serviceName st
 	p("        base_url: str,")
 	p("        options: Optional[%sClientOptions] = None,", serviceName)
 	p("    ) -> None:")
-	p(`        self._base_url = base_url.rstrip("/")`)
+	params := annotations.GetBasePathParams(service)
+	if len(params) == 0 {
+		p(`        self._base_url = base_url.rstrip("/")`)
+	}
 	p("        opts = options or %sClientOptions()", serviceName)
+	if len(params) > 0 {
+		// The base path parameters are substituted once, from the options
+		p(`        base_path = "%s"`, annotations.GetServiceBasePath(service))
+		for _, param := range params {
+			p("        if opts.%s is None:", param.Name)
+			p(`            raise ValueError("%sClientOptions.%s is required")`, serviceName, param.Name)
+			p(`        base_path = base_path.replace("{%s}", urllib.parse.quote(opts.%s, safe=""))`,
+				param.Name, param.Name)
+		}
+		p(`        self._base_url = base_url.rstrip("/") + base_path`)
+	}
 	p("        self._transport: HttpTransport = opts.transport or UrllibTransport()")
 	p("        self._default_headers: dict[str, str] = dict(opts.default_headers or {})")
 	p("        self._timeout = opts.timeout")
@@ -98,7 +118,8 @@ func writeClientConstructor(p printer, service *protogen.Service, serviceName st
 	for _, header := range annotations.GetServiceHeaders(service) {
 		propName := headerOptionName(header.GetName())
 		p("        if opts.%s is not None:", propName)
-		p(`            self._default_headers["%s"] = opts.%s`, header.GetName(), propName)
+		p(`            self._default_headers["%s"] = %s`,
+			annotations.CanonicalHeaderName(header.GetName()), headerValueExpr(header, "opts."+propName))
 	}
 
 	p("")
@@ -158,14 +179,16 @@ func writePathBuilding(p printer, cfg *methodConfig) {
 	p(`        path = "%s"`, cfg.fullPath)
 	for _, param := range cfg.pathParams {
 		pyName := snakeCase(param)
+		if annotations.IsPathWildcard(cfg.fullPath, param) {
+			// The wildcard matches the rest of the path, so its slashes are kept
+			p(`        path = path.replace("{%s...}", urllib.parse.quote(str(req.%s), safe="/"))`, param, pyName)
+			continue
+		}
 		p(`        path = path.replace("{%s}", urllib.parse.quote(str(req.%s), safe=""))`, param, pyName)
 	}
 }
 
 func writeQueryBuilding(p printer, cfg *methodConfig) {
-	if cfg.httpMethod != http.MethodGet && cfg.httpMethod != http.MethodDelete {
-		return
-	}
 	if len(cfg.queryParams) == 0 {
 		return
 	}
@@ -210,14 +233,21 @@ func writeHeaderBuilding(p printer, service *protogen.Service, method *protogen.
 	for _, header := range annotations.GetServiceHeaders(service) {
 		propName := headerOptionName(header.GetName())
 		p("        if opts.%s is not None:", propName)
-		p(`            headers["%s"] = opts.%s`, header.GetName(), propName)
+		p(`            headers["%s"] = %s`,
+			annotations.CanonicalHeaderName(header.GetName()), headerValueExpr(header, "opts."+propName))
 	}
 	for _, header := range annotations.GetMethodHeaders(method) {
 		propName := headerOptionName(header.GetName())
 		p("        if opts.%s is not None:", propName)
-		p(`            headers["%s"] = opts.%s`, header.GetName(), propName)
+		p(`            headers["%s"] = %s`,
+			annotations.CanonicalHeaderName(header.GetName()), headerValueExpr(header, "opts."+propName))
+	}
+	// Header-sourced fields are set last, overriding default and per-call headers
+	for _, hp := range cfg.headerParams {
+		src := "req." + escapePyKeyword(hp.FieldName)
+		p("        if %s:", src)
+		p(`            headers["%s"] = %s`, hp.HeaderName, src)
 	}
-	_ = cfg
 }
 
 func writeBodyBuilding(p printer, cfg *methodConfig) {
@@ -225,7 +255,16 @@ func writeBodyBuilding(p printer, cfg *methodConfig) {
 		p("        body: Optional[bytes] = None")
 		return
 	}
-	p(`        body = json.dumps(req.to_dict()).encode("utf-8")`)
+	if len(cfg.bodyExcluded) == 0 {
+		p(`        body = json.dumps(req.to_dict()).encode("utf-8")`)
+		return
+	}
+	// Fields declared with a query, path, or header source stay out of the body
+	p("        body_dict = req.to_dict()")
+	for _, field := range cfg.bodyExcluded {
+		p(`        body_dict.pop("%s", None)`, annotations.JSONFieldName(field))
+	}
+	p(`        body = json.dumps(body_dict).encode("utf-8")`)
 }
 
 func writeTransportCall(p printer, cfg *methodConfig) {
@@ -289,9 +328,13 @@ type methodConfig struct {
 	httpMethod  string
 	fullPath    string
 	pathParams  []string
-	queryParams []annotations.QueryParam
+	queryParams []annotations.QueryParam // sent in the URL query string
 	hasBody     bool
 	isSSE       bool
+	// headerParams holds the request fields declared with source HEADER.
+	headerParams []annotations.HeaderFieldParam
+	// bodyExcluded holds the request fields declared with a non-body source.
+	bodyExcluded []*protogen.Field
 }
 
 func buildMethodConfig(service *protogen.Service, method *protogen.Method) *methodConfig {
@@ -312,7 +355,11 @@ func buildMethodConfig(service *protogen.Service, method *protogen.Method) *meth
 		pathParams = httpConfig.PathParams
 	}
 
+	// The constructor appends a base path with parameters to the base URL.
 	basePath := annotations.GetServiceBasePath(service)
+	if len(annotations.GetBasePathParams(service)) > 0 {
+		basePath = ""
+	}
 	fullPath := annotations.BuildHTTPPath(basePath, httpPath)
 
 	isSSE := httpConfig != nil && httpConfig.Stream
@@ -323,9 +370,12 @@ func buildMethodConfig(service *protogen.Service, method *protogen.Method) *meth
 		httpMethod:  httpMethod,
 		fullPath:    fullPath,
 		pathParams:  pathParams,
-		queryParams: annotations.GetQueryParams(method.Input),
+		queryParams: annotations.GetURLQueryParams(method.Input, hasBody),
 		hasBody:     hasBody,
 		isSSE:       isSSE,
+
+		headerParams: annotations.GetHeaderFieldParams(method.Input),
+		bodyExcluded: annotations.GetBodyExcludedFields(method.Input),
 	}
 }
 
@@ -354,8 +404,8 @@ func snakeCase(s string) string {
 }
 
 // headerOptionName converts an HTTP header name to a Python keyword argument.
-// "X-API-Key" -> "api_key", "X-Request-ID" -> "request_id". The original header
-// name is always preserved when writing the request.
+// "X-API-Key" -> "api_key", "X-Request-ID" -> "request_id". The request is
+// written with the canonical header name.
 func headerOptionName(headerName string) string {
 	name := strings.TrimPrefix(headerName, "X-")
 	name = strings.TrimPrefix(name, "x-")
@@ -363,3 +413,21 @@ func headerOptionName(headerName string) string {
 	name = strings.ToLower(name)
 	return escapePyKeyword(name)
 }
+
+// headerOptionType is the type of the keyword argument setting a header: a
+// list of values for a multiple header.
+func headerOptionType(header *sebufhttp.Header) string {
+	if header.GetMultiple() {
+		return "Optional[list[str]]"
+	}
+	return "Optional[str]"
+}
+
+// headerValueExpr is the expression sending the keyword argument source as a
+// header value, joining the values of a multiple header into one line.
+func headerValueExpr(header *sebufhttp.Header, source string) string {
+	if header.GetMultiple() {
+		return `", ".join(` + source + ")"
+	}
+	return source
+}
@@ -80,7 +80,7 @@ func decodeScalarExpr(field *protogen.Field, src string) string {
 //
 //nolint:exhaustive // default covers BASE64 and UNSPECIFIED with the same expression
 func encodeBytesExpr(field *protogen.Field, src string) string {
-	switch annotations.GetBytesEncoding(field) {
+	switch annotations.ResolveBytesEncoding(field) {
 	case sebufhttp.BytesEncoding_BYTES_ENCODING_HEX:
 		return fmt.Sprintf("%s.hex()", src)
 	case sebufhttp.BytesEncoding_BYTES_ENCODING_BASE64URL:
@@ -98,7 +98,7 @@ func encodeBytesExpr(field *protogen.Field, src string) string {
 //
 //nolint:exhaustive // default covers BASE64 and UNSPECIFIED with the same expression
 func decodeBytesExpr(field *protogen.Field, src string) string {
-	switch annotations.GetBytesEncoding(field) {
+	switch annotations.ResolveBytesEncoding(field) {
 	case sebufhttp.BytesEncoding_BYTES_ENCODING_HEX:
 		return fmt.Sprintf("bytes.fromhex(%s)", src)
 	case sebufhttp.BytesEncoding_BYTES_ENCODING_BASE64URL,
@@ -115,7 +115,7 @@ func decodeBytesExpr(field *protogen.Field, src string) string {
 // enums serialize as the proto enum name (STRING) or the integer (NUMBER); if
 // any variant declares (sebuf.http.enum_value), the JSON_VALUES table overrides.
 func encodeEnumExpr(field *protogen.Field, src string) string {
-	if annotations.GetEnumEncoding(field) == sebufhttp.EnumEncoding_ENUM_ENCODING_NUMBER {
+	if annotations.ResolveEnumEncoding(field) == sebufhttp.EnumEncoding_ENUM_ENCODING_NUMBER {
 		return fmt.Sprintf("int(%s)", src)
 	}
 	enumName := pythonEnumName(field.Enum)
@@ -125,7 +125,7 @@ func encodeEnumExpr(field *protogen.Field, src string) string {
 // decodeEnumExpr returns the Python-side decoding for an enum field.
 func decodeEnumExpr(field *protogen.Field, src string) string {
 	enumName := pythonEnumName(field.Enum)
-	if annotations.GetEnumEncoding(field) == sebufhttp.EnumEncoding_ENUM_ENCODING_NUMBER {
+	if annotations.ResolveEnumEncoding(field) == sebufhttp.EnumEncoding_ENUM_ENCODING_NUMBER {
 		return fmt.Sprintf("%s(int(%s))", enumName, src)
 	}
 	return fmt.Sprintf("_decode_enum_%s(%s)", enumName, src)
@@ -157,7 +157,7 @@ func encodeWKTExpr(field *protogen.Field, src string) string {
 //
 //nolint:exhaustive // UNSPECIFIED/RFC3339 fall through to the default
 func encodeTimestampExpr(field *protogen.Field, src string) string {
-	switch annotations.GetTimestampFormat(field) {
+	switch annotations.ResolveTimestampFormat(field) {
 	case sebufhttp.TimestampFormat_TIMESTAMP_FORMAT_UNIX_SECONDS:
 		return fmt.Sprintf("int(%s.timestamp())", src)
 	case sebufhttp.TimestampFormat_TIMESTAMP_FORMAT_UNIX_MILLIS:
@@ -193,7 +193,7 @@ func decodeWKTExpr(field *protogen.Field, src string) string {
 //
 //nolint:exhaustive // UNSPECIFIED/RFC3339 fall through to the default
 func decodeTimestampExpr(field *protogen.Field, src string) string {
-	switch annotations.GetTimestampFormat(field) {
+	switch annotations.ResolveTimestampFormat(field) {
 	case sebufhttp.TimestampFormat_TIMESTAMP_FORMAT_UNIX_SECONDS:
 		return fmt.Sprintf("datetime.fromtimestamp(int(%s), tz=timezone.utc)", src)
 	case sebufhttp.TimestampFormat_TIMESTAMP_FORMAT_UNIX_MILLIS:
@@ -18,6 +18,8 @@ import (
 	"fmt"
 
 	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
 )
 
 // Generator produces Python HTTP client code for protobuf services.
@@ -32,6 +34,12 @@ func New(plugin *protogen.Plugin) *Generator {
 
 // Generate iterates all input files and emits a {file}_client.py per source.
 func (g *Generator) Generate() error {
+	if err := annotations.ValidateSyntax(g.plugin); err != nil {
+		return err
+	}
+	if err := annotations.ApplyVisibility(g.plugin, annotations.GeneratorPyClient); err != nil {
+		return err
+	}
 	for _, file := range g.plugin.Files {
 		if !file.Generate {
 			continue
@@ -58,6 +66,21 @@ func hasGeneratableTypes(file *protogen.File) bool {
 }
 
 func (g *Generator) generateClientFile(file *protogen.File) error {
+	for _, service := range file.Services {
+		if err := annotations.ValidateServiceHeaders(service); err != nil {
+			return err
+		}
+		if err := annotations.ValidateBasePathParams(service); err != nil {
+			return err
+		}
+		if err := annotations.ValidateScalarQueryParams(service, "protoc-gen-py-client"); err != nil {
+			return err
+		}
+		if err := annotations.ValidateBodylessRequestFields(service); err != nil {
+			return err
+		}
+	}
+
 	filename := file.GeneratedFilenamePrefix + "_client.py"
 	gf := g.plugin.NewGeneratedFile(filename, "")
 
@@ -39,6 +39,13 @@ func TestPyClientGenGoldenFiles(t *testing.T) {
 				"query_params_client.py",
 			},
 		},
+		{
+			name:      "field sources",
+			protoFile: "field_sources.proto",
+			expectedFiles: []string{
+				"field_sources_client.py",
+			},
+		},
 		{
 			name:      "backward compatibility",
 			protoFile: "backward_compat.proto",
@@ -81,6 +88,13 @@ func TestPyClientGenGoldenFiles(t *testing.T) {
 				"nullable_client.py",
 			},
 		},
+		{
+			name:      "path wildcards",
+			protoFile: "path_wildcard.proto",
+			expectedFiles: []string{
+				"path_wildcard_client.py",
+			},
+		},
 		{
 			name:      "empty behavior",
 			protoFile: "empty_behavior.proto",
@@ -109,6 +123,13 @@ func TestPyClientGenGoldenFiles(t *testing.T) {
 				"bytes_encoding_client.py",
 			},
 		},
+		{
+			name:      "scoped encoding defaults",
+			protoFile: "scoped_encoding.proto",
+			expectedFiles: []string{
+				"scoped_encoding_client.py",
+			},
+		},
 		{
 			name:      "flatten",
 			protoFile: "flatten.proto",
@@ -137,6 +158,20 @@ func TestPyClientGenGoldenFiles(t *testing.T) {
 				"errors_client.py",
 			},
 		},
+		{
+			name:      "edition 2023",
+			protoFile: "editions.proto",
+			expectedFiles: []string{
+				"editions_client.py",
+			},
+		},
+		{
+			name:      "proto2 syntax",
+			protoFile: "proto2_syntax.proto",
+			expectedFiles: []string{
+				"proto2_syntax_client.py",
+			},
+		},
 	}
 
 	baseDir, err := os.Getwd()
@@ -161,7 +161,7 @@ func writeFieldToDict(p printer, f *protogen.Field) {
 	// scalar wrappers, ...) are always nullable in proto3, so we guard them the
 	// same way as proto3 `optional` scalars to avoid AttributeError when the
 	// encoder calls methods like .timestamp() or .strftime() on a None default.
-	if f.Desc.HasOptionalKeyword() || annotations.IsNullableField(f) ||
+	if annotations.IsOptionalScalarField(f) || annotations.IsNullableField(f) ||
 		f.Desc.Kind() == protoreflect.MessageKind {
 		p("        if %s is not None:", src)
 		p(`            d["%s"] = %s`, jsonName, encodeScalarExpr(f, src))
@@ -0,0 +1,21 @@
+package pyclientgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/internal/pluginrun"
+)
+
+// Options configures the generator. The Python client generator takes no plugin
+// parameters yet; Run accepts Options so every generator shares one signature.
+type Options struct{}
+
+// Run generates the Python client of req in memory, without reading stdin or
+// writing stdout. Invalid input is reported in the response's Error field; the
+// error is only set if generation panicked.
+func Run(req *pluginpb.CodeGeneratorRequest, _ Options) (*pluginpb.CodeGeneratorResponse, error) {
+	return pluginrun.Run(req, nil, func(plugin *protogen.Plugin) error {
+		return New(plugin).Generate()
+	})
+}
@@ -645,6 +645,7 @@ class FeatureServiceCallOptions:
     content_type: Optional[str] = None
     api_key: Optional[str] = None
     tenant_id: Optional[str] = None
+    note_tag: Optional[list[str]] = None
     request_id: Optional[str] = None
     idempotency_key: Optional[str] = None
 
@@ -663,9 +664,9 @@ class FeatureServiceClient:
         self._timeout = opts.timeout
         self._content_type = opts.content_type
         if opts.api_key is not None:
-            self._default_headers["X-API-Key"] = opts.api_key
+            self._default_headers["X-Api-Key"] = opts.api_key
         if opts.tenant_id is not None:
-            self._default_headers["X-Tenant-ID"] = opts.tenant_id
+            self._default_headers["X-Tenant-Id"] = opts.tenant_id
 
     def list_notes(
         self,
@@ -693,9 +694,11 @@ class FeatureServiceClient:
         if opts.headers:
             headers.update(opts.headers)
         if opts.api_key is not None:
-            headers["X-API-Key"] = opts.api_key
+            headers["X-Api-Key"] = opts.api_key
         if opts.tenant_id is not None:
-            headers["X-Tenant-ID"] = opts.tenant_id
+            headers["X-Tenant-Id"] = opts.tenant_id
+        if opts.note_tag is not None:
+            headers["X-Note-Tag"] = ", ".join(opts.note_tag)
         body: Optional[bytes] = None
         resp = self._transport.request(
             method="GET",
@@ -728,9 +731,9 @@ class FeatureServiceClient:
         if opts.headers:
             headers.update(opts.headers)
         if opts.api_key is not None:
-            headers["X-API-Key"] = opts.api_key
+            headers["X-Api-Key"] = opts.api_key
         if opts.tenant_id is not None:
-            headers["X-Tenant-ID"] = opts.tenant_id
+            headers["X-Tenant-Id"] = opts.tenant_id
         body: Optional[bytes] = None
         resp = self._transport.request(
             method="GET",
@@ -762,11 +765,11 @@ class FeatureServiceClient:
         if opts.headers:
             headers.update(opts.headers)
         if opts.api_key is not None:
-            headers["X-API-Key"] = opts.api_key
+            headers["X-Api-Key"] = opts.api_key
         if opts.tenant_id is not None:
-            headers["X-Tenant-ID"] = opts.tenant_id
+            headers["X-Tenant-Id"] = opts.tenant_id
         if opts.request_id is not None:
-            headers["X-Request-ID"] = opts.request_id
+            headers["X-Request-Id"] = opts.request_id
         body = json.dumps(req.to_dict()).encode("utf-8")
         resp = self._transport.request(
             method="POST",
@@ -799,9 +802,9 @@ class FeatureServiceClient:
         if opts.headers:
             headers.update(opts.headers)
         if opts.api_key is not None:
-            headers["X-API-Key"] = opts.api_key
+            headers["X-Api-Key"] = opts.api_key
         if opts.tenant_id is not None:
-            headers["X-Tenant-ID"] = opts.tenant_id
+            headers["X-Tenant-Id"] = opts.tenant_id
         if opts.idempotency_key is not None:
             headers["X-Idempotency-Key"] = opts.idempotency_key
         body = json.dumps(req.to_dict()).encode("utf-8")
@@ -835,9 +838,9 @@ class FeatureServiceClient:
         if opts.headers:
             headers.update(opts.headers)
         if opts.api_key is not None:
-            headers["X-API-Key"] = opts.api_key
+            headers["X-Api-Key"] = opts.api_key
         if opts.tenant_id is not None:
-            headers["X-Tenant-ID"] = opts.tenant_id
+            headers["X-Tenant-Id"] = opts.tenant_id
         body = json.dumps(req.to_dict()).encode("utf-8")
         resp = self._transport.request(
             method="POST",
@@ -869,9 +872,9 @@ class FeatureServiceClient:
         if opts.headers:
             headers.update(opts.headers)
         if opts.api_key is not None:
-            headers["X-API-Key"] = opts.api_key
+            headers["X-Api-Key"] = opts.api_key
         if opts.tenant_id is not None:
-            headers["X-Tenant-ID"] = opts.tenant_id
+            headers["X-Tenant-Id"] = opts.tenant_id
         body = json.dumps(req.to_dict()).encode("utf-8")
         resp = self._transport.request(
             method="POST",
@@ -903,9 +906,9 @@ class FeatureServiceClient:
         if opts.headers:
             headers.update(opts.headers)
         if opts.api_key is not None:
-            headers["X-API-Key"] = opts.api_key
+            headers["X-Api-Key"] = opts.api_key
         if opts.tenant_id is not None:
-            headers["X-Tenant-ID"] = opts.tenant_id
+            headers["X-Tenant-Id"] = opts.tenant_id
         body = json.dumps(req.to_dict()).encode("utf-8")
         resp = self._transport.request(
             method="POST",
@@ -937,9 +940,9 @@ class FeatureServiceClient:
         if opts.headers:
             headers.update(opts.headers)
         if opts.api_key is not None:
-            headers["X-API-Key"] = opts.api_key
+            headers["X-Api-Key"] = opts.api_key
         if opts.tenant_id is not None:
-            headers["X-Tenant-ID"] = opts.tenant_id
+            headers["X-Tenant-Id"] = opts.tenant_id
         body = json.dumps(req.to_dict()).encode("utf-8")
         resp = self._transport.request(
             method="POST",
@@ -0,0 +1,397 @@
+# Code generated by protoc-gen-py-client. DO NOT EDIT.
+# source: editions.proto
+
+from __future__ import annotations
+
+import base64
+import binascii
+import json
+import urllib.error
+import urllib.parse
+import urllib.request
+from dataclasses import dataclass, field
+from datetime import datetime, timezone
+from enum import IntEnum
+from typing import Any, AsyncIterator, Iterator, Mapping, Optional, Protocol, Sequence, Union
+
+@dataclass
+class HttpResponse:
+    """Minimal HTTP response shape returned by every HttpTransport."""
+    status: int
+    headers: Mapping[str, str]
+    body: bytes
+
+
+class HttpTransport(Protocol):
+    """Duck-typed HTTP transport. Implement this to plug in requests/httpx/aiohttp."""
+    def request(
+        self,
+        method: str,
+        url: str,
+        headers: Mapping[str, str],
+        body: Optional[bytes],
+        timeout: Optional[float],
+    ) -> HttpResponse: ...
+
+
+class UrllibTransport:
+    """Default transport built on the Python standard library."""
+    def request(
+        self,
+        method: str,
+        url: str,
+        headers: Mapping[str, str],
+        body: Optional[bytes],
+        timeout: Optional[float],
+    ) -> HttpResponse:
+        req = urllib.request.Request(url=url, method=method, data=body)
+        for key, value in headers.items():
+            req.add_header(key, value)
+        try:
+            with urllib.request.urlopen(req, timeout=timeout) as resp:
+                return HttpResponse(
+                    status=resp.status,
+                    headers={k: v for k, v in resp.headers.items()},
+                    body=resp.read(),
+                )
+        except urllib.error.HTTPError as exc:
+            return HttpResponse(
+                status=exc.code,
+                headers={k: v for k, v in exc.headers.items()} if exc.headers else {},
+                body=exc.read() if hasattr(exc, "read") else b"",
+            )
+
+
+class NoteKind(IntEnum):
+    """Generated from proto enum test.httpgen.editions.NoteKind."""
+    NOTE_KIND_UNSPECIFIED = 0
+    NOTE_KIND_TEXT = 1
+    NOTE_KIND_CHECKLIST = 2
+
+
+NoteKind_JSON_VALUES: Mapping[NoteKind, str] = {}
+
+def _decode_enum_NoteKind(value: Any) -> NoteKind:
+    if isinstance(value, int):
+        return NoteKind(value)
+    if isinstance(value, str):
+        for member, json_value in NoteKind_JSON_VALUES.items():
+            if json_value == value:
+                return member
+        try:
+            return NoteKind[value]
+        except KeyError:
+            raise ValueError(f"unknown NoteKind value: {value!r}")
+    raise TypeError(f"cannot decode NoteKind from {type(value).__name__}")
+
+
+@dataclass
+class FieldViolation:
+    """Single validation violation, matching sebuf.http.FieldViolation."""
+    field: str
+    description: str = ""
+
+
+class ApiError(Exception):
+    """Base exception for any non-2xx HTTP response."""
+    def __init__(
+        self,
+        status: int,
+        body: bytes,
+        headers: Optional[Mapping[str, str]] = None,
+    ) -> None:
+        self.status = status
+        self.body = body
+        self.headers = headers or {}
+        super().__init__(f"HTTP {status}")
+
+
+class ValidationError(ApiError):
+    """Raised on HTTP 400 when the server returns sebuf.http.ValidationError JSON."""
+    def __init__(
+        self,
+        status: int,
+        body: bytes,
+        headers: Optional[Mapping[str, str]] = None,
+        violations: Optional[Sequence[FieldViolation]] = None,
+    ) -> None:
+        super().__init__(status, body, headers)
+        self.violations: list[FieldViolation] = list(violations or [])
+
+
+_ERROR_CLASSES: list[tuple[type[ApiError], set[str]]] = [
+]
+
+
+@dataclass
+class Author:
+    """Generated from proto message test.httpgen.editions.Author."""
+    name: Optional[str] = None
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        if self.name is not None:
+            d["name"] = self.name
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "Author":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "name" in data and data["name"] is not None:
+            kwargs["name"] = str(data["name"])
+        return cls(**kwargs)
+
+@dataclass
+class CreateNoteRequest:
+    """Generated from proto message test.httpgen.editions.CreateNoteRequest."""
+    title: Optional[str] = None
+    body: str = ""
+    priority: Optional[int] = None
+    owner: str = ""
+    kind: Optional[NoteKind] = None
+    tags: list[str] = field(default_factory=list)
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        if self.title is not None:
+            d["title"] = self.title
+        d["body"] = self.body
+        if self.priority is not None:
+            d["priority"] = self.priority
+        d["owner"] = self.owner
+        if self.kind is not None:
+            d["kind"] = NoteKind_JSON_VALUES.get(self.kind, self.kind.name)
+        if self.tags:
+            d["tags"] = [v for v in self.tags]
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "CreateNoteRequest":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "title" in data and data["title"] is not None:
+            kwargs["title"] = str(data["title"])
+        if "body" in data and data["body"] is not None:
+            kwargs["body"] = str(data["body"])
+        if "priority" in data and data["priority"] is not None:
+            kwargs["priority"] = int(data["priority"])
+        if "owner" in data and data["owner"] is not None:
+            kwargs["owner"] = str(data["owner"])
+        if "kind" in data and data["kind"] is not None:
+            kwargs["kind"] = _decode_enum_NoteKind(data["kind"])
+        if "tags" in data and data["tags"] is not None:
+            kwargs["tags"] = [str(v) for v in data["tags"]]
+        return cls(**kwargs)
+
+@dataclass
+class GetNoteRequest:
+    """Generated from proto message test.httpgen.editions.GetNoteRequest."""
+    id: Optional[str] = None
+    version: Optional[int] = None
+    kind: Optional[NoteKind] = None
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        if self.id is not None:
+            d["id"] = self.id
+        if self.version is not None:
+            d["version"] = self.version
+        if self.kind is not None:
+            d["kind"] = NoteKind_JSON_VALUES.get(self.kind, self.kind.name)
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "GetNoteRequest":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "id" in data and data["id"] is not None:
+            kwargs["id"] = str(data["id"])
+        if "version" in data and data["version"] is not None:
+            kwargs["version"] = int(data["version"])
+        if "kind" in data and data["kind"] is not None:
+            kwargs["kind"] = _decode_enum_NoteKind(data["kind"])
+        return cls(**kwargs)
+
+@dataclass
+class Note:
+    """Generated from proto message test.httpgen.editions.Note."""
+    id: Optional[str] = None
+    title: Optional[str] = None
+    body: str = ""
+    priority: Optional[int] = None
+    kind: Optional[NoteKind] = None
+    tags: list[str] = field(default_factory=list)
+    author: Optional[Author] = None
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        if self.id is not None:
+            d["id"] = self.id
+        if self.title is not None:
+            d["title"] = self.title
+        d["body"] = self.body
+        if self.priority is not None:
+            d["priority"] = self.priority
+        if self.kind is not None:
+            d["kind"] = NoteKind_JSON_VALUES.get(self.kind, self.kind.name)
+        if self.tags:
+            d["tags"] = [v for v in self.tags]
+        if self.author is not None:
+            d["author"] = self.author.to_dict()
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "Note":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "id" in data and data["id"] is not None:
+            kwargs["id"] = str(data["id"])
+        if "title" in data and data["title"] is not None:
+            kwargs["title"] = str(data["title"])
+        if "body" in data and data["body"] is not None:
+            kwargs["body"] = str(data["body"])
+        if "priority" in data and data["priority"] is not None:
+            kwargs["priority"] = int(data["priority"])
+        if "kind" in data and data["kind"] is not None:
+            kwargs["kind"] = _decode_enum_NoteKind(data["kind"])
+        if "tags" in data and data["tags"] is not None:
+            kwargs["tags"] = [str(v) for v in data["tags"]]
+        if "author" in data and data["author"] is not None:
+            kwargs["author"] = Author.from_dict(data["author"])
+        return cls(**kwargs)
+
+@dataclass
+class NoteServiceClientOptions:
+    """Construct-time options for NoteServiceClient."""
+    transport: Optional[HttpTransport] = None
+    default_headers: Optional[Mapping[str, str]] = None
+    timeout: Optional[float] = None
+    content_type: str = "application/json"
+
+
+@dataclass
+class NoteServiceCallOptions:
+    """Per-call options for NoteServiceClient methods."""
+    headers: Optional[Mapping[str, str]] = None
+    timeout: Optional[float] = None
+    content_type: Optional[str] = None
+
+
+class NoteServiceClient:
+    """Generated client for test.httpgen.editions.NoteService."""
+    def __init__(
+        self,
+        base_url: str,
+        options: Optional[NoteServiceClientOptions] = None,
+    ) -> None:
+        self._base_url = base_url.rstrip("/")
+        opts = options or NoteServiceClientOptions()
+        self._transport: HttpTransport = opts.transport or UrllibTransport()
+        self._default_headers: dict[str, str] = dict(opts.default_headers or {})
+        self._timeout = opts.timeout
+        self._content_type = opts.content_type
+
+    def create_note(
+        self,
+        req: CreateNoteRequest,
+        options: Optional[NoteServiceCallOptions] = None,
+    ) -> Note:
+        """Calls test.httpgen.editions.NoteService.CreateNote."""
+        opts = options or NoteServiceCallOptions()
+        content_type = opts.content_type or self._content_type
+        if content_type != "application/json":
+            raise NotImplementedError("only application/json is implemented; see docs/python-generation.md")
+        path = "/api/v1/notes"
+        headers: dict[str, str] = dict(self._default_headers)
+        headers["Content-Type"] = content_type
+        headers["Accept"] = "application/json"
+        if opts.headers:
+            headers.update(opts.headers)
+        body = json.dumps(req.to_dict()).encode("utf-8")
+        resp = self._transport.request(
+            method="POST",
+            url=self._base_url + path,
+            headers=headers,
+            body=body,
+            timeout=opts.timeout if opts.timeout is not None else self._timeout,
+        )
+        if resp.status >= 400:
+            self._raise_for_status(resp)
+        if not resp.body:
+            return Note()
+        return Note.from_dict(json.loads(resp.body))
+
+    def get_note(
+        self,
+        req: GetNoteRequest,
+        options: Optional[NoteServiceCallOptions] = None,
+    ) -> Note:
+        """Calls test.httpgen.editions.NoteService.GetNote."""
+        opts = options or NoteServiceCallOptions()
+        content_type = opts.content_type or self._content_type
+        if content_type != "application/json":
+            raise NotImplementedError("only application/json is implemented; see docs/python-generation.md")
+        path = "/api/v1/notes/{id}"
+        path = path.replace("{id}", urllib.parse.quote(str(req.id), safe=""))
+        query_pairs: list[tuple[str, str]] = []
+        if req.version is not None and req.version != 0:
+            query_pairs.append(("version", str(req.version)))
+        if req.kind is not None and req.kind != 0:
+            query_pairs.append(("kind", str(req.kind)))
+        if query_pairs:
+            path = path + "?" + urllib.parse.urlencode(query_pairs, doseq=True)
+        headers: dict[str, str] = dict(self._default_headers)
+        headers["Content-Type"] = content_type
+        headers["Accept"] = "application/json"
+        if opts.headers:
+            headers.update(opts.headers)
+        body: Optional[bytes] = None
+        resp = self._transport.request(
+            method="GET",
+            url=self._base_url + path,
+            headers=headers,
+            body=body,
+            timeout=opts.timeout if opts.timeout is not None else self._timeout,
+        )
+        if resp.status >= 400:
+            self._raise_for_status(resp)
+        if not resp.body:
+            return Note()
+        return Note.from_dict(json.loads(resp.body))
+
+    def _raise_for_status(self, resp: HttpResponse) -> None:
+        """Map a non-2xx response to the most specific exception available."""
+        body = resp.body or b""
+        parsed: Any = None
+        ctype = (resp.headers or {}).get("Content-Type", "")
+        looks_jsonish = "json" in ctype.lower() or body[:1] in (b"{", b"[")
+        if looks_jsonish:
+            try:
+                parsed = json.loads(body.decode("utf-8"))
+            except (ValueError, UnicodeDecodeError):
+                parsed = None
+        if resp.status == 400 and isinstance(parsed, dict) and "violations" in parsed:
+            violations = [
+                FieldViolation(field=v.get("field", ""), description=v.get("description", ""))
+                for v in parsed.get("violations", [])
+            ]
+            raise ValidationError(resp.status, body, resp.headers, violations)
+        if isinstance(parsed, dict):
+            for err_cls, required_keys in _ERROR_CLASSES:
+                if required_keys and required_keys.issubset(parsed.keys()):
+                    raise err_cls.populate(resp.status, body, resp.headers, parsed)
+        raise ApiError(resp.status, body, resp.headers)
+
@@ -0,0 +1,353 @@
+# Code generated by protoc-gen-py-client. DO NOT EDIT.
+# source: field_sources.proto
+
+from __future__ import annotations
+
+import base64
+import binascii
+import json
+import urllib.error
+import urllib.parse
+import urllib.request
+from dataclasses import dataclass, field
+from datetime import datetime, timezone
+from enum import IntEnum
+from typing import Any, AsyncIterator, Iterator, Mapping, Optional, Protocol, Sequence, Union
+
+@dataclass
+class HttpResponse:
+    """Minimal HTTP response shape returned by every HttpTransport."""
+    status: int
+    headers: Mapping[str, str]
+    body: bytes
+
+
+class HttpTransport(Protocol):
+    """Duck-typed HTTP transport. Implement this to plug in requests/httpx/aiohttp."""
+    def request(
+        self,
+        method: str,
+        url: str,
+        headers: Mapping[str, str],
+        body: Optional[bytes],
+        timeout: Optional[float],
+    ) -> HttpResponse: ...
+
+
+class UrllibTransport:
+    """Default transport built on the Python standard library."""
+    def request(
+        self,
+        method: str,
+        url: str,
+        headers: Mapping[str, str],
+        body: Optional[bytes],
+        timeout: Optional[float],
+    ) -> HttpResponse:
+        req = urllib.request.Request(url=url, method=method, data=body)
+        for key, value in headers.items():
+            req.add_header(key, value)
+        try:
+            with urllib.request.urlopen(req, timeout=timeout) as resp:
+                return HttpResponse(
+                    status=resp.status,
+                    headers={k: v for k, v in resp.headers.items()},
+                    body=resp.read(),
+                )
+        except urllib.error.HTTPError as exc:
+            return HttpResponse(
+                status=exc.code,
+                headers={k: v for k, v in exc.headers.items()} if exc.headers else {},
+                body=exc.read() if hasattr(exc, "read") else b"",
+            )
+
+
+@dataclass
+class FieldViolation:
+    """Single validation violation, matching sebuf.http.FieldViolation."""
+    field: str
+    description: str = ""
+
+
+class ApiError(Exception):
+    """Base exception for any non-2xx HTTP response."""
+    def __init__(
+        self,
+        status: int,
+        body: bytes,
+        headers: Optional[Mapping[str, str]] = None,
+    ) -> None:
+        self.status = status
+        self.body = body
+        self.headers = headers or {}
+        super().__init__(f"HTTP {status}")
+
+
+class ValidationError(ApiError):
+    """Raised on HTTP 400 when the server returns sebuf.http.ValidationError JSON."""
+    def __init__(
+        self,
+        status: int,
+        body: bytes,
+        headers: Optional[Mapping[str, str]] = None,
+        violations: Optional[Sequence[FieldViolation]] = None,
+    ) -> None:
+        super().__init__(status, body, headers)
+        self.violations: list[FieldViolation] = list(violations or [])
+
+
+_ERROR_CLASSES: list[tuple[type[ApiError], set[str]]] = [
+]
+
+
+@dataclass
+class Document:
+    """Generated from proto message test.httpgen.sources.Document."""
+    document_id: str = ""
+    title: str = ""
+    content: str = ""
+    labels: list[str] = field(default_factory=list)
+    revision: str = "0"
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        d["documentId"] = self.document_id
+        d["title"] = self.title
+        d["content"] = self.content
+        if self.labels:
+            d["labels"] = [v for v in self.labels]
+        d["revision"] = str(self.revision)
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "Document":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "documentId" in data and data["documentId"] is not None:
+            kwargs["document_id"] = str(data["documentId"])
+        if "title" in data and data["title"] is not None:
+            kwargs["title"] = str(data["title"])
+        if "content" in data and data["content"] is not None:
+            kwargs["content"] = str(data["content"])
+        if "labels" in data and data["labels"] is not None:
+            kwargs["labels"] = [str(v) for v in data["labels"]]
+        if "revision" in data and data["revision"] is not None:
+            kwargs["revision"] = str(data["revision"])
+        return cls(**kwargs)
+
+@dataclass
+class GetDocumentRequest:
+    """Generated from proto message test.httpgen.sources.GetDocumentRequest."""
+    document_id: str = ""
+    x_tenant_id: Optional[str] = None
+    include_history: bool = False
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        d["documentId"] = self.document_id
+        if self.x_tenant_id is not None:
+            d["xTenantId"] = self.x_tenant_id
+        d["includeHistory"] = self.include_history
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "GetDocumentRequest":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "documentId" in data and data["documentId"] is not None:
+            kwargs["document_id"] = str(data["documentId"])
+        if "xTenantId" in data and data["xTenantId"] is not None:
+            kwargs["x_tenant_id"] = str(data["xTenantId"])
+        if "includeHistory" in data and data["includeHistory"] is not None:
+            kwargs["include_history"] = bool(data["includeHistory"])
+        return cls(**kwargs)
+
+@dataclass
+class UpdateDocumentRequest:
+    """Generated from proto message test.httpgen.sources.UpdateDocumentRequest."""
+    document_id: str = ""
+    x_tenant_id: str = ""
+    expected_revision: str = "0"
+    notify: bool = False
+    title: str = ""
+    content: str = ""
+    labels: list[str] = field(default_factory=list)
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        d["documentId"] = self.document_id
+        d["xTenantId"] = self.x_tenant_id
+        d["expectedRevision"] = str(self.expected_revision)
+        d["notify"] = self.notify
+        d["title"] = self.title
+        d["content"] = self.content
+        if self.labels:
+            d["labels"] = [v for v in self.labels]
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "UpdateDocumentRequest":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "documentId" in data and data["documentId"] is not None:
+            kwargs["document_id"] = str(data["documentId"])
+        if "xTenantId" in data and data["xTenantId"] is not None:
+            kwargs["x_tenant_id"] = str(data["xTenantId"])
+        if "expectedRevision" in data and data["expectedRevision"] is not None:
+            kwargs["expected_revision"] = str(data["expectedRevision"])
+        if "notify" in data and data["notify"] is not None:
+            kwargs["notify"] = bool(data["notify"])
+        if "title" in data and data["title"] is not None:
+            kwargs["title"] = str(data["title"])
+        if "content" in data and data["content"] is not None:
+            kwargs["content"] = str(data["content"])
+        if "labels" in data and data["labels"] is not None:
+            kwargs["labels"] = [str(v) for v in data["labels"]]
+        return cls(**kwargs)
+
+@dataclass
+class FieldSourceServiceClientOptions:
+    """Construct-time options for FieldSourceServiceClient."""
+    transport: Optional[HttpTransport] = None
+    default_headers: Optional[Mapping[str, str]] = None
+    timeout: Optional[float] = None
+    content_type: str = "application/json"
+
+
+@dataclass
+class FieldSourceServiceCallOptions:
+    """Per-call options for FieldSourceServiceClient methods."""
+    headers: Optional[Mapping[str, str]] = None
+    timeout: Optional[float] = None
+    content_type: Optional[str] = None
+
+
+class FieldSourceServiceClient:
+    """Generated client for test.httpgen.sources.FieldSourceService."""
+    def __init__(
+        self,
+        base_url: str,
+        options: Optional[FieldSourceServiceClientOptions] = None,
+    ) -> None:
+        self._base_url = base_url.rstrip("/")
+        opts = options or FieldSourceServiceClientOptions()
+        self._transport: HttpTransport = opts.transport or UrllibTransport()
+        self._default_headers: dict[str, str] = dict(opts.default_headers or {})
+        self._timeout = opts.timeout
+        self._content_type = opts.content_type
+
+    def update_document(
+        self,
+        req: UpdateDocumentRequest,
+        options: Optional[FieldSourceServiceCallOptions] = None,
+    ) -> Document:
+        """Calls test.httpgen.sources.FieldSourceService.UpdateDocument."""
+        opts = options or FieldSourceServiceCallOptions()
+        content_type = opts.content_type or self._content_type
+        if content_type != "application/json":
+            raise NotImplementedError("only application/json is implemented; see docs/python-generation.md")
+        path = "/api/v1/documents/{document_id}"
+        path = path.replace("{document_id}", urllib.parse.quote(str(req.document_id), safe=""))
+        query_pairs: list[tuple[str, str]] = []
+        if req.expected_revision is not None and req.expected_revision != 0:
+            query_pairs.append(("revision", str(req.expected_revision)))
+        if req.notify:
+            query_pairs.append(("notify", "true" if req.notify else "false"))
+        if query_pairs:
+            path = path + "?" + urllib.parse.urlencode(query_pairs, doseq=True)
+        headers: dict[str, str] = dict(self._default_headers)
+        headers["Content-Type"] = content_type
+        headers["Accept"] = "application/json"
+        if opts.headers:
+            headers.update(opts.headers)
+        if req.x_tenant_id:
+            headers["X-Tenant-Id"] = req.x_tenant_id
+        body_dict = req.to_dict()
+        body_dict.pop("documentId", None)
+        body_dict.pop("xTenantId", None)
+        body_dict.pop("expectedRevision", None)
+        body_dict.pop("notify", None)
+        body = json.dumps(body_dict).encode("utf-8")
+        resp = self._transport.request(
+            method="PATCH",
+            url=self._base_url + path,
+            headers=headers,
+            body=body,
+            timeout=opts.timeout if opts.timeout is not None else self._timeout,
+        )
+        if resp.status >= 400:
+            self._raise_for_status(resp)
+        if not resp.body:
+            return Document()
+        return Document.from_dict(json.loads(resp.body))
+
+    def get_document(
+        self,
+        req: GetDocumentRequest,
+        options: Optional[FieldSourceServiceCallOptions] = None,
+    ) -> Document:
+        """Calls test.httpgen.sources.FieldSourceService.GetDocument."""
+        opts = options or FieldSourceServiceCallOptions()
+        content_type = opts.content_type or self._content_type
+        if content_type != "application/json":
+            raise NotImplementedError("only application/json is implemented; see docs/python-generation.md")
+        path = "/api/v1/documents/{document_id}"
+        path = path.replace("{document_id}", urllib.parse.quote(str(req.document_id), safe=""))
+        query_pairs: list[tuple[str, str]] = []
+        if req.include_history:
+            query_pairs.append(("history", "true" if req.include_history else "false"))
+        if query_pairs:
+            path = path + "?" + urllib.parse.urlencode(query_pairs, doseq=True)
+        headers: dict[str, str] = dict(self._default_headers)
+        headers["Content-Type"] = content_type
+        headers["Accept"] = "application/json"
+        if opts.headers:
+            headers.update(opts.headers)
+        if req.x_tenant_id:
+            headers["X-Tenant-Id"] = req.x_tenant_id
+        body: Optional[bytes] = None
+        resp = self._transport.request(
+            method="GET",
+            url=self._base_url + path,
+            headers=headers,
+            body=body,
+            timeout=opts.timeout if opts.timeout is not None else self._timeout,
+        )
+        if resp.status >= 400:
+            self._raise_for_status(resp)
+        if not resp.body:
+            return Document()
+        return Document.from_dict(json.loads(resp.body))
+
+    def _raise_for_status(self, resp: HttpResponse) -> None:
+        """Map a non-2xx response to the most specific exception available."""
+        body = resp.body or b""
+        parsed: Any = None
+        ctype = (resp.headers or {}).get("Content-Type", "")
+        looks_jsonish = "json" in ctype.lower() or body[:1] in (b"{", b"[")
+        if looks_jsonish:
+            try:
+                parsed = json.loads(body.decode("utf-8"))
+            except (ValueError, UnicodeDecodeError):
+                parsed = None
+        if resp.status == 400 and isinstance(parsed, dict) and "violations" in parsed:
+            violations = [
+                FieldViolation(field=v.get("field", ""), description=v.get("description", ""))
+                for v in parsed.get("violations", [])
+            ]
+            raise ValidationError(resp.status, body, resp.headers, violations)
+        if isinstance(parsed, dict):
+            for err_cls, required_keys in _ERROR_CLASSES:
+                if required_keys and required_keys.issubset(parsed.keys()):
+                    raise err_cls.populate(resp.status, body, resp.headers, parsed)
+        raise ApiError(resp.status, body, resp.headers)
+
@@ -616,7 +616,7 @@ class RESTfulAPIServiceClient:
         self._timeout = opts.timeout
         self._content_type = opts.content_type
         if opts.api_key is not None:
-            self._default_headers["X-API-Key"] = opts.api_key
+            self._default_headers["X-Api-Key"] = opts.api_key
 
     def list_resources(
         self,
@@ -654,7 +654,7 @@ class RESTfulAPIServiceClient:
         if opts.headers:
             headers.update(opts.headers)
         if opts.api_key is not None:
-            headers["X-API-Key"] = opts.api_key
+            headers["X-Api-Key"] = opts.api_key
         body: Optional[bytes] = None
         resp = self._transport.request(
             method="GET",
@@ -687,7 +687,7 @@ class RESTfulAPIServiceClient:
         if opts.headers:
             headers.update(opts.headers)
         if opts.api_key is not None:
-            headers["X-API-Key"] = opts.api_key
+            headers["X-Api-Key"] = opts.api_key
         body: Optional[bytes] = None
         resp = self._transport.request(
             method="GET",
@@ -722,7 +722,7 @@ class RESTfulAPIServiceClient:
         if opts.headers:
             headers.update(opts.headers)
         if opts.api_key is not None:
-            headers["X-API-Key"] = opts.api_key
+            headers["X-Api-Key"] = opts.api_key
         body: Optional[bytes] = None
         resp = self._transport.request(
             method="GET",
@@ -754,9 +754,9 @@ class RESTfulAPIServiceClient:
         if opts.headers:
             headers.update(opts.headers)
         if opts.api_key is not None:
-            headers["X-API-Key"] = opts.api_key
+            headers["X-Api-Key"] = opts.api_key
         if opts.request_id is not None:
-            headers["X-Request-ID"] = opts.request_id
+            headers["X-Request-Id"] = opts.request_id
         body = json.dumps(req.to_dict()).encode("utf-8")
         resp = self._transport.request(
             method="POST",
@@ -789,7 +789,7 @@ class RESTfulAPIServiceClient:
         if opts.headers:
             headers.update(opts.headers)
         if opts.api_key is not None:
-            headers["X-API-Key"] = opts.api_key
+            headers["X-Api-Key"] = opts.api_key
         body = json.dumps(req.to_dict()).encode("utf-8")
         resp = self._transport.request(
             method="PUT",
@@ -822,7 +822,7 @@ class RESTfulAPIServiceClient:
         if opts.headers:
             headers.update(opts.headers)
         if opts.api_key is not None:
-            headers["X-API-Key"] = opts.api_key
+            headers["X-Api-Key"] = opts.api_key
         body = json.dumps(req.to_dict()).encode("utf-8")
         resp = self._transport.request(
             method="PATCH",
@@ -855,7 +855,7 @@ class RESTfulAPIServiceClient:
         if opts.headers:
             headers.update(opts.headers)
         if opts.api_key is not None:
-            headers["X-API-Key"] = opts.api_key
+            headers["X-Api-Key"] = opts.api_key
         body: Optional[bytes] = None
         resp = self._transport.request(
             method="DELETE",
@@ -887,7 +887,7 @@ class RESTfulAPIServiceClient:
         if opts.headers:
             headers.update(opts.headers)
         if opts.api_key is not None:
-            headers["X-API-Key"] = opts.api_key
+            headers["X-Api-Key"] = opts.api_key
         body = json.dumps(req.to_dict()).encode("utf-8")
         resp = self._transport.request(
             method="POST",
@@ -926,7 +926,7 @@ class RESTfulAPIServiceClient:
         if opts.headers:
             headers.update(opts.headers)
         if opts.api_key is not None:
-            headers["X-API-Key"] = opts.api_key
+            headers["X-Api-Key"] = opts.api_key
         body: Optional[bytes] = None
         resp = self._transport.request(
             method="GET",
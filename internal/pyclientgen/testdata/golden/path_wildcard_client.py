@@ -0,0 +1,294 @@
+# Code generated by protoc-gen-py-client. DO NOT EDIT.
+# source: path_wildcard.proto
+
+from __future__ import annotations
+
+import base64
+import binascii
+import json
+import urllib.error
+import urllib.parse
+import urllib.request
+from dataclasses import dataclass, field
+from datetime import datetime, timezone
+from enum import IntEnum
+from typing import Any, AsyncIterator, Iterator, Mapping, Optional, Protocol, Sequence, Union
+
+@dataclass
+class HttpResponse:
+    """Minimal HTTP response shape returned by every HttpTransport."""
+    status: int
+    headers: Mapping[str, str]
+    body: bytes
+
+
+class HttpTransport(Protocol):
+    """Duck-typed HTTP transport. Implement this to plug in requests/httpx/aiohttp."""
+    def request(
+        self,
+        method: str,
+        url: str,
+        headers: Mapping[str, str],
+        body: Optional[bytes],
+        timeout: Optional[float],
+    ) -> HttpResponse: ...
+
+
+class UrllibTransport:
+    """Default transport built on the Python standard library."""
+    def request(
+        self,
+        method: str,
+        url: str,
+        headers: Mapping[str, str],
+        body: Optional[bytes],
+        timeout: Optional[float],
+    ) -> HttpResponse:
+        req = urllib.request.Request(url=url, method=method, data=body)
+        for key, value in headers.items():
+            req.add_header(key, value)
+        try:
+            with urllib.request.urlopen(req, timeout=timeout) as resp:
+                return HttpResponse(
+                    status=resp.status,
+                    headers={k: v for k, v in resp.headers.items()},
+                    body=resp.read(),
+                )
+        except urllib.error.HTTPError as exc:
+            return HttpResponse(
+                status=exc.code,
+                headers={k: v for k, v in exc.headers.items()} if exc.headers else {},
+                body=exc.read() if hasattr(exc, "read") else b"",
+            )
+
+
+@dataclass
+class FieldViolation:
+    """Single validation violation, matching sebuf.http.FieldViolation."""
+    field: str
+    description: str = ""
+
+
+class ApiError(Exception):
+    """Base exception for any non-2xx HTTP response."""
+    def __init__(
+        self,
+        status: int,
+        body: bytes,
+        headers: Optional[Mapping[str, str]] = None,
+    ) -> None:
+        self.status = status
+        self.body = body
+        self.headers = headers or {}
+        super().__init__(f"HTTP {status}")
+
+
+class ValidationError(ApiError):
+    """Raised on HTTP 400 when the server returns sebuf.http.ValidationError JSON."""
+    def __init__(
+        self,
+        status: int,
+        body: bytes,
+        headers: Optional[Mapping[str, str]] = None,
+        violations: Optional[Sequence[FieldViolation]] = None,
+    ) -> None:
+        super().__init__(status, body, headers)
+        self.violations: list[FieldViolation] = list(violations or [])
+
+
+_ERROR_CLASSES: list[tuple[type[ApiError], set[str]]] = [
+]
+
+
+@dataclass
+class DownloadFileRequest:
+    """Generated from proto message test.httpgen.pathwildcard.DownloadFileRequest."""
+    path: str = ""
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        d["path"] = self.path
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "DownloadFileRequest":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "path" in data and data["path"] is not None:
+            kwargs["path"] = str(data["path"])
+        return cls(**kwargs)
+
+@dataclass
+class File:
+    """Generated from proto message test.httpgen.pathwildcard.File."""
+    path: str = ""
+    size: str = "0"
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        d["path"] = self.path
+        d["size"] = str(self.size)
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "File":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "path" in data and data["path"] is not None:
+            kwargs["path"] = str(data["path"])
+        if "size" in data and data["size"] is not None:
+            kwargs["size"] = str(data["size"])
+        return cls(**kwargs)
+
+@dataclass
+class PutObjectRequest:
+    """Generated from proto message test.httpgen.pathwildcard.PutObjectRequest."""
+    bucket: str = ""
+    key: str = ""
+    content: bytes = b""
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        d["bucket"] = self.bucket
+        d["key"] = self.key
+        d["content"] = base64.b64encode(self.content).decode("ascii")
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "PutObjectRequest":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "bucket" in data and data["bucket"] is not None:
+            kwargs["bucket"] = str(data["bucket"])
+        if "key" in data and data["key"] is not None:
+            kwargs["key"] = str(data["key"])
+        if "content" in data and data["content"] is not None:
+            kwargs["content"] = base64.b64decode(data["content"])
+        return cls(**kwargs)
+
+@dataclass
+class StorageServiceClientOptions:
+    """Construct-time options for StorageServiceClient."""
+    transport: Optional[HttpTransport] = None
+    default_headers: Optional[Mapping[str, str]] = None
+    timeout: Optional[float] = None
+    content_type: str = "application/json"
+
+
+@dataclass
+class StorageServiceCallOptions:
+    """Per-call options for StorageServiceClient methods."""
+    headers: Optional[Mapping[str, str]] = None
+    timeout: Optional[float] = None
+    content_type: Optional[str] = None
+
+
+class StorageServiceClient:
+    """Generated client for test.httpgen.pathwildcard.StorageService."""
+    def __init__(
+        self,
+        base_url: str,
+        options: Optional[StorageServiceClientOptions] = None,
+    ) -> None:
+        self._base_url = base_url.rstrip("/")
+        opts = options or StorageServiceClientOptions()
+        self._transport: HttpTransport = opts.transport or UrllibTransport()
+        self._default_headers: dict[str, str] = dict(opts.default_headers or {})
+        self._timeout = opts.timeout
+        self._content_type = opts.content_type
+
+    def download_file(
+        self,
+        req: DownloadFileRequest,
+        options: Optional[StorageServiceCallOptions] = None,
+    ) -> File:
+        """Calls test.httpgen.pathwildcard.StorageService.DownloadFile."""
+        opts = options or StorageServiceCallOptions()
+        content_type = opts.content_type or self._content_type
+        if content_type != "application/json":
+            raise NotImplementedError("only application/json is implemented; see docs/python-generation.md")
+        path = "/api/v1/files/{path...}"
+        path = path.replace("{path...}", urllib.parse.quote(str(req.path), safe="/"))
+        headers: dict[str, str] = dict(self._default_headers)
+        headers["Content-Type"] = content_type
+        headers["Accept"] = "application/json"
+        if opts.headers:
+            headers.update(opts.headers)
+        body: Optional[bytes] = None
+        resp = self._transport.request(
+            method="GET",
+            url=self._base_url + path,
+            headers=headers,
+            body=body,
+            timeout=opts.timeout if opts.timeout is not None else self._timeout,
+        )
+        if resp.status >= 400:
+            self._raise_for_status(resp)
+        if not resp.body:
+            return File()
+        return File.from_dict(json.loads(resp.body))
+
+    def put_object(
+        self,
+        req: PutObjectRequest,
+        options: Optional[StorageServiceCallOptions] = None,
+    ) -> File:
+        """Calls test.httpgen.pathwildcard.StorageService.PutObject."""
+        opts = options or StorageServiceCallOptions()
+        content_type = opts.content_type or self._content_type
+        if content_type != "application/json":
+            raise NotImplementedError("only application/json is implemented; see docs/python-generation.md")
+        path = "/api/v1/buckets/{bucket}/objects/{key...}"
+        path = path.replace("{bucket}", urllib.parse.quote(str(req.bucket), safe=""))
+        path = path.replace("{key...}", urllib.parse.quote(str(req.key), safe="/"))
+        headers: dict[str, str] = dict(self._default_headers)
+        headers["Content-Type"] = content_type
+        headers["Accept"] = "application/json"
+        if opts.headers:
+            headers.update(opts.headers)
+        body = json.dumps(req.to_dict()).encode("utf-8")
+        resp = self._transport.request(
+            method="PUT",
+            url=self._base_url + path,
+            headers=headers,
+            body=body,
+            timeout=opts.timeout if opts.timeout is not None else self._timeout,
+        )
+        if resp.status >= 400:
+            self._raise_for_status(resp)
+        if not resp.body:
+            return File()
+        return File.from_dict(json.loads(resp.body))
+
+    def _raise_for_status(self, resp: HttpResponse) -> None:
+        """Map a non-2xx response to the most specific exception available."""
+        body = resp.body or b""
+        parsed: Any = None
+        ctype = (resp.headers or {}).get("Content-Type", "")
+        looks_jsonish = "json" in ctype.lower() or body[:1] in (b"{", b"[")
+        if looks_jsonish:
+            try:
+                parsed = json.loads(body.decode("utf-8"))
+            except (ValueError, UnicodeDecodeError):
+                parsed = None
+        if resp.status == 400 and isinstance(parsed, dict) and "violations" in parsed:
+            violations = [
+                FieldViolation(field=v.get("field", ""), description=v.get("description", ""))
+                for v in parsed.get("violations", [])
+            ]
+            raise ValidationError(resp.status, body, resp.headers, violations)
+        if isinstance(parsed, dict):
+            for err_cls, required_keys in _ERROR_CLASSES:
+                if required_keys and required_keys.issubset(parsed.keys()):
+                    raise err_cls.populate(resp.status, body, resp.headers, parsed)
+        raise ApiError(resp.status, body, resp.headers)
+
@@ -0,0 +1,418 @@
+# Code generated by protoc-gen-py-client. DO NOT EDIT.
+# source: proto2_syntax.proto
+
+from __future__ import annotations
+
+import base64
+import binascii
+import json
+import urllib.error
+import urllib.parse
+import urllib.request
+from dataclasses import dataclass, field
+from datetime import datetime, timezone
+from enum import IntEnum
+from typing import Any, AsyncIterator, Iterator, Mapping, Optional, Protocol, Sequence, Union
+
+@dataclass
+class HttpResponse:
+    """Minimal HTTP response shape returned by every HttpTransport."""
+    status: int
+    headers: Mapping[str, str]
+    body: bytes
+
+
+class HttpTransport(Protocol):
+    """Duck-typed HTTP transport. Implement this to plug in requests/httpx/aiohttp."""
+    def request(
+        self,
+        method: str,
+        url: str,
+        headers: Mapping[str, str],
+        body: Optional[bytes],
+        timeout: Optional[float],
+    ) -> HttpResponse: ...
+
+
+class UrllibTransport:
+    """Default transport built on the Python standard library."""
+    def request(
+        self,
+        method: str,
+        url: str,
+        headers: Mapping[str, str],
+        body: Optional[bytes],
+        timeout: Optional[float],
+    ) -> HttpResponse:
+        req = urllib.request.Request(url=url, method=method, data=body)
+        for key, value in headers.items():
+            req.add_header(key, value)
+        try:
+            with urllib.request.urlopen(req, timeout=timeout) as resp:
+                return HttpResponse(
+                    status=resp.status,
+                    headers={k: v for k, v in resp.headers.items()},
+                    body=resp.read(),
+                )
+        except urllib.error.HTTPError as exc:
+            return HttpResponse(
+                status=exc.code,
+                headers={k: v for k, v in exc.headers.items()} if exc.headers else {},
+                body=exc.read() if hasattr(exc, "read") else b"",
+            )
+
+
+class Availability(IntEnum):
+    """Generated from proto enum test.httpgen.proto2syntax.Availability."""
+    AVAILABILITY_UNSPECIFIED = 0
+    AVAILABILITY_IN_STOCK = 1
+    AVAILABILITY_BACKORDER = 2
+
+
+Availability_JSON_VALUES: Mapping[Availability, str] = {}
+
+def _decode_enum_Availability(value: Any) -> Availability:
+    if isinstance(value, int):
+        return Availability(value)
+    if isinstance(value, str):
+        for member, json_value in Availability_JSON_VALUES.items():
+            if json_value == value:
+                return member
+        try:
+            return Availability[value]
+        except KeyError:
+            raise ValueError(f"unknown Availability value: {value!r}")
+    raise TypeError(f"cannot decode Availability from {type(value).__name__}")
+
+
+@dataclass
+class FieldViolation:
+    """Single validation violation, matching sebuf.http.FieldViolation."""
+    field: str
+    description: str = ""
+
+
+class ApiError(Exception):
+    """Base exception for any non-2xx HTTP response."""
+    def __init__(
+        self,
+        status: int,
+        body: bytes,
+        headers: Optional[Mapping[str, str]] = None,
+    ) -> None:
+        self.status = status
+        self.body = body
+        self.headers = headers or {}
+        super().__init__(f"HTTP {status}")
+
+
+class ValidationError(ApiError):
+    """Raised on HTTP 400 when the server returns sebuf.http.ValidationError JSON."""
+    def __init__(
+        self,
+        status: int,
+        body: bytes,
+        headers: Optional[Mapping[str, str]] = None,
+        violations: Optional[Sequence[FieldViolation]] = None,
+    ) -> None:
+        super().__init__(status, body, headers)
+        self.violations: list[FieldViolation] = list(violations or [])
+
+
+_ERROR_CLASSES: list[tuple[type[ApiError], set[str]]] = [
+]
+
+
+@dataclass
+class CreateItemRequest:
+    """Generated from proto message test.httpgen.proto2syntax.CreateItemRequest."""
+    name: str = ""
+    count: Optional[int] = None
+    note: Optional[str] = None
+    availability: Optional[Availability] = None
+    featured: Optional[bool] = None
+    price: Optional[float] = None
+    labels: list[str] = field(default_factory=list)
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        d["name"] = self.name
+        if self.count is not None:
+            d["count"] = self.count
+        if self.note is not None:
+            d["note"] = self.note
+        if self.availability is not None:
+            d["availability"] = Availability_JSON_VALUES.get(self.availability, self.availability.name)
+        if self.featured is not None:
+            d["featured"] = self.featured
+        if self.price is not None:
+            d["price"] = self.price
+        if self.labels:
+            d["labels"] = [v for v in self.labels]
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "CreateItemRequest":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "name" in data and data["name"] is not None:
+            kwargs["name"] = str(data["name"])
+        if "count" in data and data["count"] is not None:
+            kwargs["count"] = int(data["count"])
+        if "note" in data and data["note"] is not None:
+            kwargs["note"] = str(data["note"])
+        if "availability" in data and data["availability"] is not None:
+            kwargs["availability"] = _decode_enum_Availability(data["availability"])
+        if "featured" in data and data["featured"] is not None:
+            kwargs["featured"] = bool(data["featured"])
+        if "price" in data and data["price"] is not None:
+            kwargs["price"] = float(data["price"])
+        if "labels" in data and data["labels"] is not None:
+            kwargs["labels"] = [str(v) for v in data["labels"]]
+        return cls(**kwargs)
+
+@dataclass
+class Dimensions:
+    """Generated from proto message test.httpgen.proto2syntax.Dimensions."""
+    width: Optional[float] = None
+    height: Optional[float] = None
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        if self.width is not None:
+            d["width"] = self.width
+        if self.height is not None:
+            d["height"] = self.height
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "Dimensions":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "width" in data and data["width"] is not None:
+            kwargs["width"] = float(data["width"])
+        if "height" in data and data["height"] is not None:
+            kwargs["height"] = float(data["height"])
+        return cls(**kwargs)
+
+@dataclass
+class Item:
+    """Generated from proto message test.httpgen.proto2syntax.Item."""
+    id: str = ""
+    name: str = ""
+    count: Optional[int] = None
+    availability: Optional[Availability] = None
+    dimensions: Optional[Dimensions] = None
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        d["id"] = self.id
+        d["name"] = self.name
+        if self.count is not None:
+            d["count"] = self.count
+        if self.availability is not None:
+            d["availability"] = Availability_JSON_VALUES.get(self.availability, self.availability.name)
+        if self.dimensions is not None:
+            d["dimensions"] = self.dimensions.to_dict()
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "Item":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "id" in data and data["id"] is not None:
+            kwargs["id"] = str(data["id"])
+        if "name" in data and data["name"] is not None:
+            kwargs["name"] = str(data["name"])
+        if "count" in data and data["count"] is not None:
+            kwargs["count"] = int(data["count"])
+        if "availability" in data and data["availability"] is not None:
+            kwargs["availability"] = _decode_enum_Availability(data["availability"])
+        if "dimensions" in data and data["dimensions"] is not None:
+            kwargs["dimensions"] = Dimensions.from_dict(data["dimensions"])
+        return cls(**kwargs)
+
+@dataclass
+class ListItemsRequest:
+    """Generated from proto message test.httpgen.proto2syntax.ListItemsRequest."""
+    page_size: Optional[int] = None
+    cursor: Optional[str] = None
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        if self.page_size is not None:
+            d["pageSize"] = self.page_size
+        if self.cursor is not None:
+            d["cursor"] = self.cursor
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "ListItemsRequest":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "pageSize" in data and data["pageSize"] is not None:
+            kwargs["page_size"] = int(data["pageSize"])
+        if "cursor" in data and data["cursor"] is not None:
+            kwargs["cursor"] = str(data["cursor"])
+        return cls(**kwargs)
+
+@dataclass
+class ListItemsResponse:
+    """Generated from proto message test.httpgen.proto2syntax.ListItemsResponse."""
+    items: list[Item] = field(default_factory=list)
+    next_cursor: Optional[str] = None
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        if self.items:
+            d["items"] = [v.to_dict() for v in self.items]
+        if self.next_cursor is not None:
+            d["nextCursor"] = self.next_cursor
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "ListItemsResponse":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "items" in data and data["items"] is not None:
+            kwargs["items"] = [Item.from_dict(v) for v in data["items"]]
+        if "nextCursor" in data and data["nextCursor"] is not None:
+            kwargs["next_cursor"] = str(data["nextCursor"])
+        return cls(**kwargs)
+
+@dataclass
+class ItemServiceClientOptions:
+    """Construct-time options for ItemServiceClient."""
+    transport: Optional[HttpTransport] = None
+    default_headers: Optional[Mapping[str, str]] = None
+    timeout: Optional[float] = None
+    content_type: str = "application/json"
+
+
+@dataclass
+class ItemServiceCallOptions:
+    """Per-call options for ItemServiceClient methods."""
+    headers: Optional[Mapping[str, str]] = None
+    timeout: Optional[float] = None
+    content_type: Optional[str] = None
+
+
+class ItemServiceClient:
+    """Generated client for test.httpgen.proto2syntax.ItemService."""
+    def __init__(
+        self,
+        base_url: str,
+        options: Optional[ItemServiceClientOptions] = None,
+    ) -> None:
+        self._base_url = base_url.rstrip("/")
+        opts = options or ItemServiceClientOptions()
+        self._transport: HttpTransport = opts.transport or UrllibTransport()
+        self._default_headers: dict[str, str] = dict(opts.default_headers or {})
+        self._timeout = opts.timeout
+        self._content_type = opts.content_type
+
+    def create_item(
+        self,
+        req: CreateItemRequest,
+        options: Optional[ItemServiceCallOptions] = None,
+    ) -> Item:
+        """Calls test.httpgen.proto2syntax.ItemService.CreateItem."""
+        opts = options or ItemServiceCallOptions()
+        content_type = opts.content_type or self._content_type
+        if content_type != "application/json":
+            raise NotImplementedError("only application/json is implemented; see docs/python-generation.md")
+        path = "/api/v1/items"
+        headers: dict[str, str] = dict(self._default_headers)
+        headers["Content-Type"] = content_type
+        headers["Accept"] = "application/json"
+        if opts.headers:
+            headers.update(opts.headers)
+        body = json.dumps(req.to_dict()).encode("utf-8")
+        resp = self._transport.request(
+            method="POST",
+            url=self._base_url + path,
+            headers=headers,
+            body=body,
+            timeout=opts.timeout if opts.timeout is not None else self._timeout,
+        )
+        if resp.status >= 400:
+            self._raise_for_status(resp)
+        if not resp.body:
+            return Item()
+        return Item.from_dict(json.loads(resp.body))
+
+    def list_items(
+        self,
+        req: ListItemsRequest,
+        options: Optional[ItemServiceCallOptions] = None,
+    ) -> ListItemsResponse:
+        """Calls test.httpgen.proto2syntax.ItemService.ListItems."""
+        opts = options or ItemServiceCallOptions()
+        content_type = opts.content_type or self._content_type
+        if content_type != "application/json":
+            raise NotImplementedError("only application/json is implemented; see docs/python-generation.md")
+        path = "/api/v1/items"
+        query_pairs: list[tuple[str, str]] = []
+        if req.page_size is not None and req.page_size != 0:
+            query_pairs.append(("page_size", str(req.page_size)))
+        if req.cursor:
+            query_pairs.append(("cursor", str(req.cursor)))
+        if query_pairs:
+            path = path + "?" + urllib.parse.urlencode(query_pairs, doseq=True)
+        headers: dict[str, str] = dict(self._default_headers)
+        headers["Content-Type"] = content_type
+        headers["Accept"] = "application/json"
+        if opts.headers:
+            headers.update(opts.headers)
+        body: Optional[bytes] = None
+        resp = self._transport.request(
+            method="GET",
+            url=self._base_url + path,
+            headers=headers,
+            body=body,
+            timeout=opts.timeout if opts.timeout is not None else self._timeout,
+        )
+        if resp.status >= 400:
+            self._raise_for_status(resp)
+        if not resp.body:
+            return ListItemsResponse()
+        return ListItemsResponse.from_dict(json.loads(resp.body))
+
+    def _raise_for_status(self, resp: HttpResponse) -> None:
+        """Map a non-2xx response to the most specific exception available."""
+        body = resp.body or b""
+        parsed: Any = None
+        ctype = (resp.headers or {}).get("Content-Type", "")
+        looks_jsonish = "json" in ctype.lower() or body[:1] in (b"{", b"[")
+        if looks_jsonish:
+            try:
+                parsed = json.loads(body.decode("utf-8"))
+            except (ValueError, UnicodeDecodeError):
+                parsed = None
+        if resp.status == 400 and isinstance(parsed, dict) and "violations" in parsed:
+            violations = [
+                FieldViolation(field=v.get("field", ""), description=v.get("description", ""))
+                for v in parsed.get("violations", [])
+            ]
+            raise ValidationError(resp.status, body, resp.headers, violations)
+        if isinstance(parsed, dict):
+            for err_cls, required_keys in _ERROR_CLASSES:
+                if required_keys and required_keys.issubset(parsed.keys()):
+                    raise err_cls.populate(resp.status, body, resp.headers, parsed)
+        raise ApiError(resp.status, body, resp.headers)
+
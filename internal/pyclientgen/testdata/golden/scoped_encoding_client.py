@@ -0,0 +1,380 @@
+# Code generated by protoc-gen-py-client. DO NOT EDIT.
+# source: scoped_encoding.proto
+
+from __future__ import annotations
+
+import base64
+import binascii
+import json
+import urllib.error
+import urllib.parse
+import urllib.request
+from dataclasses import dataclass, field
+from datetime import datetime, timezone
+from enum import IntEnum
+from typing import Any, AsyncIterator, Iterator, Mapping, Optional, Protocol, Sequence, Union
+
+@dataclass
+class HttpResponse:
+    """Minimal HTTP response shape returned by every HttpTransport."""
+    status: int
+    headers: Mapping[str, str]
+    body: bytes
+
+
+class HttpTransport(Protocol):
+    """Duck-typed HTTP transport. Implement this to plug in requests/httpx/aiohttp."""
+    def request(
+        self,
+        method: str,
+        url: str,
+        headers: Mapping[str, str],
+        body: Optional[bytes],
+        timeout: Optional[float],
+    ) -> HttpResponse: ...
+
+
+class UrllibTransport:
+    """Default transport built on the Python standard library."""
+    def request(
+        self,
+        method: str,
+        url: str,
+        headers: Mapping[str, str],
+        body: Optional[bytes],
+        timeout: Optional[float],
+    ) -> HttpResponse:
+        req = urllib.request.Request(url=url, method=method, data=body)
+        for key, value in headers.items():
+            req.add_header(key, value)
+        try:
+            with urllib.request.urlopen(req, timeout=timeout) as resp:
+                return HttpResponse(
+                    status=resp.status,
+                    headers={k: v for k, v in resp.headers.items()},
+                    body=resp.read(),
+                )
+        except urllib.error.HTTPError as exc:
+            return HttpResponse(
+                status=exc.code,
+                headers={k: v for k, v in exc.headers.items()} if exc.headers else {},
+                body=exc.read() if hasattr(exc, "read") else b"",
+            )
+
+
+class Level(IntEnum):
+    """Generated from proto enum testdata.scopedencoding.Level."""
+    LEVEL_UNSPECIFIED = 0
+    LEVEL_LOW = 1
+    LEVEL_HIGH = 2
+
+
+Level_JSON_VALUES: Mapping[Level, str] = {}
+
+def _decode_enum_Level(value: Any) -> Level:
+    if isinstance(value, int):
+        return Level(value)
+    if isinstance(value, str):
+        for member, json_value in Level_JSON_VALUES.items():
+            if json_value == value:
+                return member
+        try:
+            return Level[value]
+        except KeyError:
+            raise ValueError(f"unknown Level value: {value!r}")
+    raise TypeError(f"cannot decode Level from {type(value).__name__}")
+
+
+@dataclass
+class FieldViolation:
+    """Single validation violation, matching sebuf.http.FieldViolation."""
+    field: str
+    description: str = ""
+
+
+class ApiError(Exception):
+    """Base exception for any non-2xx HTTP response."""
+    def __init__(
+        self,
+        status: int,
+        body: bytes,
+        headers: Optional[Mapping[str, str]] = None,
+    ) -> None:
+        self.status = status
+        self.body = body
+        self.headers = headers or {}
+        super().__init__(f"HTTP {status}")
+
+
+class ValidationError(ApiError):
+    """Raised on HTTP 400 when the server returns sebuf.http.ValidationError JSON."""
+    def __init__(
+        self,
+        status: int,
+        body: bytes,
+        headers: Optional[Mapping[str, str]] = None,
+        violations: Optional[Sequence[FieldViolation]] = None,
+    ) -> None:
+        super().__init__(status, body, headers)
+        self.violations: list[FieldViolation] = list(violations or [])
+
+
+_ERROR_CLASSES: list[tuple[type[ApiError], set[str]]] = [
+]
+
+
+@dataclass
+class FileScopedTest:
+    """Generated from proto message testdata.scopedencoding.FileScopedTest."""
+    file_int64: int = 0
+    file_level: Level = Level.LEVEL_UNSPECIFIED
+    file_time: Optional[datetime] = None
+    file_data: bytes = b""
+    field_int64: str = "0"
+    field_data: bytes = b""
+    name: str = ""
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        d["fileInt64"] = self.file_int64
+        d["fileLevel"] = int(self.file_level)
+        if self.file_time is not None:
+            d["fileTime"] = int(self.file_time.timestamp())
+        d["fileData"] = self.file_data.hex()
+        d["fieldInt64"] = str(self.field_int64)
+        d["fieldData"] = base64.b64encode(self.field_data).decode("ascii")
+        d["name"] = self.name
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "FileScopedTest":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "fileInt64" in data and data["fileInt64"] is not None:
+            kwargs["file_int64"] = int(data["fileInt64"])
+        if "fileLevel" in data and data["fileLevel"] is not None:
+            kwargs["file_level"] = Level(int(data["fileLevel"]))
+        if "fileTime" in data and data["fileTime"] is not None:
+            kwargs["file_time"] = datetime.fromtimestamp(int(data["fileTime"]), tz=timezone.utc)
+        if "fileData" in data and data["fileData"] is not None:
+            kwargs["file_data"] = bytes.fromhex(data["fileData"])
+        if "fieldInt64" in data and data["fieldInt64"] is not None:
+            kwargs["field_int64"] = str(data["fieldInt64"])
+        if "fieldData" in data and data["fieldData"] is not None:
+            kwargs["field_data"] = base64.b64decode(data["fieldData"])
+        if "name" in data and data["name"] is not None:
+            kwargs["name"] = str(data["name"])
+        return cls(**kwargs)
+
+@dataclass
+class GetScopedRequest:
+    """Generated from proto message testdata.scopedencoding.GetScopedRequest."""
+    id: str = ""
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        d["id"] = self.id
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "GetScopedRequest":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "id" in data and data["id"] is not None:
+            kwargs["id"] = str(data["id"])
+        return cls(**kwargs)
+
+@dataclass
+class GetScopedResponse:
+    """Generated from proto message testdata.scopedencoding.GetScopedResponse."""
+    file_scoped: Optional[FileScopedTest] = None
+    message_scoped: Optional[MessageScopedTest] = None
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        if self.file_scoped is not None:
+            d["fileScoped"] = self.file_scoped.to_dict()
+        if self.message_scoped is not None:
+            d["messageScoped"] = self.message_scoped.to_dict()
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "GetScopedResponse":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "fileScoped" in data and data["fileScoped"] is not None:
+            kwargs["file_scoped"] = FileScopedTest.from_dict(data["fileScoped"])
+        if "messageScoped" in data and data["messageScoped"] is not None:
+            kwargs["message_scoped"] = MessageScopedTest.from_dict(data["messageScoped"])
+        return cls(**kwargs)
+
+@dataclass
+class MessageScopedTest:
+    """Generated from proto message testdata.scopedencoding.MessageScopedTest."""
+    message_int64: str = "0"
+    message_level: Level = Level.LEVEL_UNSPECIFIED
+    message_time: Optional[datetime] = None
+    message_data: bytes = b""
+    field_int64: int = 0
+    field_level: Level = Level.LEVEL_UNSPECIFIED
+    field_time: Optional[datetime] = None
+    field_data: bytes = b""
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        d["messageInt64"] = str(self.message_int64)
+        d["messageLevel"] = Level_JSON_VALUES.get(self.message_level, self.message_level.name)
+        if self.message_time is not None:
+            d["messageTime"] = int(self.message_time.timestamp() * 1000)
+        d["messageData"] = base64.urlsafe_b64encode(self.message_data).decode("ascii")
+        d["fieldInt64"] = self.field_int64
+        d["fieldLevel"] = int(self.field_level)
+        if self.field_time is not None:
+            d["fieldTime"] = self.field_time.strftime("%Y-%m-%d")
+        d["fieldData"] = base64.b64encode(self.field_data).decode("ascii").rstrip("=")
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "MessageScopedTest":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "messageInt64" in data and data["messageInt64"] is not None:
+            kwargs["message_int64"] = str(data["messageInt64"])
+        if "messageLevel" in data and data["messageLevel"] is not None:
+            kwargs["message_level"] = _decode_enum_Level(data["messageLevel"])
+        if "messageTime" in data and data["messageTime"] is not None:
+            kwargs["message_time"] = datetime.fromtimestamp(int(data["messageTime"]) / 1000, tz=timezone.utc)
+        if "messageData" in data and data["messageData"] is not None:
+            kwargs["message_data"] = base64.urlsafe_b64decode(data["messageData"] + "=" * (-len(data["messageData"]) % 4))
+        if "fieldInt64" in data and data["fieldInt64"] is not None:
+            kwargs["field_int64"] = int(data["fieldInt64"])
+        if "fieldLevel" in data and data["fieldLevel"] is not None:
+            kwargs["field_level"] = Level(int(data["fieldLevel"]))
+        if "fieldTime" in data and data["fieldTime"] is not None:
+            kwargs["field_time"] = datetime.strptime(data["fieldTime"], "%Y-%m-%d").replace(tzinfo=timezone.utc)
+        if "fieldData" in data and data["fieldData"] is not None:
+            kwargs["field_data"] = base64.b64decode(data["fieldData"] + "=" * (-len(data["fieldData"]) % 4))
+        return cls(**kwargs)
+
+@dataclass
+class Timestamp:
+    """Generated from proto message google.protobuf.Timestamp."""
+    seconds: str = "0"
+    nanos: int = 0
+
+    def to_dict(self) -> Any:
+        """Serialize to a JSON-ready dict respecting sebuf JSON mapping annotations."""
+        d: dict[str, Any] = {}
+        d["seconds"] = str(self.seconds)
+        d["nanos"] = self.nanos
+        return d
+
+    @classmethod
+    def from_dict(cls, data: Any) -> "Timestamp":
+        """Deserialize from a JSON-decoded dict (or value, for root-unwrapped messages)."""
+        if data is None:
+            return cls()
+        kwargs: dict[str, Any] = {}
+        if "seconds" in data and data["seconds"] is not None:
+            kwargs["seconds"] = str(data["seconds"])
+        if "nanos" in data and data["nanos"] is not None:
+            kwargs["nanos"] = int(data["nanos"])
+        return cls(**kwargs)
+
+@dataclass
+class ScopedEncodingServiceClientOptions:
+    """Construct-time options for ScopedEncodingServiceClient."""
+    transport: Optional[HttpTransport] = None
+    default_headers: Optional[Mapping[str, str]] = None
+    timeout: Optional[float] = None
+    content_type: str = "application/json"
+
+
+@dataclass
+class ScopedEncodingServiceCallOptions:
+    """Per-call options for ScopedEncodingServiceClient methods."""
+    headers: Optional[Mapping[str, str]] = None
+    timeout: Optional[float] = None
+    content_type: Optional[str] = None
+
+
+class ScopedEncodingServiceClient:
+    """Generated client for testdata.scopedencoding.ScopedEncodingService."""
+    def __init__(
+        self,
+        base_url: str,
+        options: Optional[ScopedEncodingServiceClientOptions] = None,
+    ) -> None:
+        self._base_url = base_url.rstrip("/")
+        opts = options or ScopedEncodingServiceClientOptions()
+        self._transport: HttpTransport = opts.transport or UrllibTransport()
+        self._default_headers: dict[str, str] = dict(opts.default_headers or {})
+        self._timeout = opts.timeout
+        self._content_type = opts.content_type
+
+    def get_scoped(
+        self,
+        req: GetScopedRequest,
+        options: Optional[ScopedEncodingServiceCallOptions] = None,
+    ) -> GetScopedResponse:
+        """Calls testdata.scopedencoding.ScopedEncodingService.GetScoped."""
+        opts = options or ScopedEncodingServiceCallOptions()
+        content_type = opts.content_type or self._content_type
+        if content_type != "application/json":
+            raise NotImplementedError("only application/json is implemented; see docs/python-generation.md")
+        path = "/api/v1/scoped/{id}"
+        path = path.replace("{id}", urllib.parse.quote(str(req.id), safe=""))
+        headers: dict[str, str] = dict(self._default_headers)
+        headers["Content-Type"] = content_type
+        headers["Accept"] = "application/json"
+        if opts.headers:
+            headers.update(opts.headers)
+        body: Optional[bytes] = None
+        resp = self._transport.request(
+            method="GET",
+            url=self._base_url + path,
+            headers=headers,
+            body=body,
+            timeout=opts.timeout if opts.timeout is not None else self._timeout,
+        )
+        if resp.status >= 400:
+            self._raise_for_status(resp)
+        if not resp.body:
+            return GetScopedResponse()
+        return GetScopedResponse.from_dict(json.loads(resp.body))
+
+    def _raise_for_status(self, resp: HttpResponse) -> None:
+        """Map a non-2xx response to the most specific exception available."""
+        body = resp.body or b""
+        parsed: Any = None
+        ctype = (resp.headers or {}).get("Content-Type", "")
+        looks_jsonish = "json" in ctype.lower() or body[:1] in (b"{", b"[")
+        if looks_jsonish:
+            try:
+                parsed = json.loads(body.decode("utf-8"))
+            except (ValueError, UnicodeDecodeError):
+                parsed = None
+        if resp.status == 400 and isinstance(parsed, dict) and "violations" in parsed:
+            violations = [
+                FieldViolation(field=v.get("field", ""), description=v.get("description", ""))
+                for v in parsed.get("violations", [])
+            ]
+            raise ValidationError(resp.status, body, resp.headers, violations)
+        if isinstance(parsed, dict):
+            for err_cls, required_keys in _ERROR_CLASSES:
+                if required_keys and required_keys.issubset(parsed.keys()):
+                    raise err_cls.populate(resp.status, body, resp.headers, parsed)
+        raise ApiError(resp.status, body, resp.headers)
+
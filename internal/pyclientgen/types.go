@@ -72,7 +72,7 @@ func wrapModifiers(field *protogen.Field, base string) string {
 	if field.Desc.IsList() {
 		return fmt.Sprintf("list[%s]", base)
 	}
-	if field.Desc.HasOptionalKeyword() || annotations.IsNullableField(field) {
+	if annotations.IsOptionalScalarField(field) || annotations.IsNullableField(field) {
 		return fmt.Sprintf("Optional[%s]", base)
 	}
 	// Message fields are inherently nullable in proto3 (unset == None).
@@ -99,7 +99,7 @@ func pythonFieldDefault(field *protogen.Field) string {
 	if field.Desc.IsList() {
 		return "field(default_factory=list)"
 	}
-	if field.Desc.HasOptionalKeyword() || annotations.IsNullableField(field) {
+	if annotations.IsOptionalScalarField(field) || annotations.IsNullableField(field) {
 		return pyNone
 	}
 	switch field.Desc.Kind() {
@@ -144,7 +144,7 @@ func pythonFieldName(field *protogen.Field) string {
 // proto-declared json_name (which protoc populates with lowerCamelCase unless
 // the user overrides). This matches protojson on the server side.
 func jsonFieldName(field *protogen.Field) string {
-	return field.Desc.JSONName()
+	return annotations.JSONFieldName(field)
 }
 
 // fieldIsMessage reports whether a singular field is a non-WKT message field
@@ -0,0 +1,120 @@
+// Package schemahash computes the schema fingerprint of a service, which the
+// generated servers and clients opting in with schema_fingerprint=true embed to
+// tell a client built from an older or newer proto apart from a current one.
+//
+// Every generator takes the fingerprint from Fingerprint, so a Go server, a Go
+// client and a TypeScript client generated from the same proto always agree.
+package schemahash
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Length is the number of hexadecimal digits of a fingerprint.
+const Length = 16
+
+// Fingerprint returns the schema fingerprint of service: the first Length hex
+// digits of the SHA-256 of a canonical description of its methods and of the
+// messages and enums they reach. The description holds the name, request and
+// response types and streaming of each method, the number, cardinality and
+// type of each field, and the numbers of enum values. Names of fields and enum
+// values are left out, so renaming them keeps the fingerprint; changing a
+// field's number or type, adding or removing a field or method, or renaming a
+// message changes it. The order of declarations does not matter.
+func Fingerprint(service protoreflect.ServiceDescriptor) string {
+	var d description
+	methods := service.Methods()
+	lines := make([]string, 0, methods.Len())
+	for i := range methods.Len() {
+		method := methods.Get(i)
+		lines = append(lines, fmt.Sprintf("rpc %s(%s) returns (%s) client_streaming=%t server_streaming=%t",
+			method.Name(), method.Input().FullName(), method.Output().FullName(),
+			method.IsStreamingClient(), method.IsStreamingServer()))
+		d.addMessage(method.Input())
+		d.addMessage(method.Output())
+	}
+	slices.Sort(lines)
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+	for _, name := range slices.Sorted(maps.Keys(d.types)) {
+		b.WriteString(d.types[name])
+	}
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])[:Length]
+}
+
+// description collects the canonical description of every message and enum
+// reached from the methods, keyed by full name.
+type description struct {
+	types map[protoreflect.FullName]string
+}
+
+func (d *description) addMessage(msg protoreflect.MessageDescriptor) {
+	if _, ok := d.types[msg.FullName()]; ok {
+		return
+	}
+	if d.types == nil {
+		d.types = make(map[protoreflect.FullName]string)
+	}
+	// Recorded before the fields are walked, so recursive messages terminate
+	d.types[msg.FullName()] = ""
+
+	fields := msg.Fields()
+	sorted := make([]protoreflect.FieldDescriptor, 0, fields.Len())
+	for i := range fields.Len() {
+		sorted = append(sorted, fields.Get(i))
+	}
+	slices.SortFunc(sorted, func(a, b protoreflect.FieldDescriptor) int {
+		return cmp.Compare(a.Number(), b.Number())
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "message %s\n", msg.FullName())
+	for _, field := range sorted {
+		fmt.Fprintf(&b, "  field %d %s %s", field.Number(), field.Cardinality(), field.Kind())
+		switch {
+		case field.Message() != nil:
+			fmt.Fprintf(&b, " %s", field.Message().FullName())
+			d.addMessage(field.Message())
+		case field.Enum() != nil:
+			fmt.Fprintf(&b, " %s", field.Enum().FullName())
+			d.addEnum(field.Enum())
+		}
+		if field.HasPresence() {
+			b.WriteString(" presence")
+		}
+		b.WriteString("\n")
+	}
+	d.types[msg.FullName()] = b.String()
+}
+
+func (d *description) addEnum(enum protoreflect.EnumDescriptor) {
+	if _, ok := d.types[enum.FullName()]; ok {
+		return
+	}
+	values := enum.Values()
+	numbers := make([]protoreflect.EnumNumber, 0, values.Len())
+	for i := range values.Len() {
+		numbers = append(numbers, values.Get(i).Number())
+	}
+	slices.Sort(numbers)
+	numbers = slices.Compact(numbers)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "enum %s\n", enum.FullName())
+	for _, number := range numbers {
+		fmt.Fprintf(&b, "  value %d\n", number)
+	}
+	d.types[enum.FullName()] = b.String()
+}
@@ -0,0 +1,112 @@
+package schemahash
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/SebastienMelki/sebuf/internal/pluginrun/pluginruntest"
+)
+
+// notesFingerprint returns the fingerprint of NoteService after edit changed
+// the descriptor of notes.proto.
+func notesFingerprint(t *testing.T, edit func(file *descriptorpb.FileDescriptorProto)) string {
+	t.Helper()
+	files := pluginruntest.Request("").GetProtoFile()
+	file := proto.Clone(files[len(files)-1]).(*descriptorpb.FileDescriptorProto)
+	edit(file)
+	fd, err := protodesc.NewFile(file, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("build notes.proto: %v", err)
+	}
+	return Fingerprint(fd.Services().ByName("NoteService"))
+}
+
+// note returns the Note message of file.
+func note(file *descriptorpb.FileDescriptorProto) *descriptorpb.DescriptorProto {
+	for _, msg := range file.GetMessageType() {
+		if msg.GetName() == "Note" {
+			return msg
+		}
+	}
+	panic("notes.proto has no Note message")
+}
+
+func TestFingerprint(t *testing.T) {
+	base := notesFingerprint(t, func(*descriptorpb.FileDescriptorProto) {})
+	if len(base) != Length {
+		t.Fatalf("Fingerprint = %q, want %d hex digits", base, Length)
+	}
+
+	tests := []struct {
+		name    string
+		edit    func(file *descriptorpb.FileDescriptorProto)
+		changes bool
+	}{
+		{"field renamed", func(file *descriptorpb.FileDescriptorProto) {
+			field := note(file).GetField()[1]
+			field.Name = proto.String("text")
+			field.JsonName = proto.String("text")
+		}, false},
+		{"fields reordered", func(file *descriptorpb.FileDescriptorProto) {
+			fields := note(file).GetField()
+			fields[0], fields[1] = fields[1], fields[0]
+		}, false},
+		{"methods reordered", func(file *descriptorpb.FileDescriptorProto) {
+			methods := file.GetService()[0].GetMethod()
+			methods[0], methods[1] = methods[1], methods[0]
+		}, false},
+		{"field type changed", func(file *descriptorpb.FileDescriptorProto) {
+			note(file).GetField()[1].Type = descriptorpb.FieldDescriptorProto_TYPE_BYTES.Enum()
+		}, true},
+		{"field number changed", func(file *descriptorpb.FileDescriptorProto) {
+			note(file).GetField()[1].Number = proto.Int32(3)
+		}, true},
+		{"field made repeated", func(file *descriptorpb.FileDescriptorProto) {
+			note(file).GetField()[1].Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		}, true},
+		{"field added", func(file *descriptorpb.FileDescriptorProto) {
+			msg := note(file)
+			field := proto.Clone(msg.GetField()[1]).(*descriptorpb.FieldDescriptorProto)
+			field.Name = proto.String("title")
+			field.JsonName = proto.String("title")
+			field.Number = proto.Int32(3)
+			msg.Field = append(msg.Field, field)
+		}, true},
+		{"method removed", func(file *descriptorpb.FileDescriptorProto) {
+			service := file.GetService()[0]
+			service.Method = service.GetMethod()[:1]
+		}, true},
+		{"request type changed", func(file *descriptorpb.FileDescriptorProto) {
+			file.GetService()[0].GetMethod()[1].InputType = proto.String(".test.notes.v1.GetNoteRequest")
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := notesFingerprint(t, tt.edit)
+			if changed := got != base; changed != tt.changes {
+				t.Errorf("Fingerprint = %s, base %s: changed = %t, want %t", got, base, changed, tt.changes)
+			}
+		})
+	}
+}
+
+func TestFingerprintRecursiveMessage(t *testing.T) {
+	got := notesFingerprint(t, func(file *descriptorpb.FileDescriptorProto) {
+		msg := note(file)
+		msg.Field = append(msg.Field, &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String("replies"),
+			Number:   proto.Int32(3),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+			TypeName: proto.String(".test.notes.v1.Note"),
+			JsonName: proto.String("replies"),
+		})
+	})
+	if len(got) != Length {
+		t.Errorf("Fingerprint = %q, want %d hex digits", got, Length)
+	}
+}
@@ -0,0 +1,231 @@
+package tsclientgen
+
+import (
+	"net/http"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/tscommon"
+)
+
+// responseCacheModule is the extensionless path of the shared module, emitted
+// at the output root, holding the response cache of clients with GET methods.
+const responseCacheModule = "response_cache"
+
+// Exports of the response cache module referenced by the client modules.
+const (
+	responseCacheName        = "ResponseCache"
+	responseCacheOptionsName = "ResponseCacheOptions"
+)
+
+// cachesResponses reports whether a service's client takes the cache option:
+// it has a GET method whose response can be memoized.
+func cachesResponses(service *protogen.Service) bool {
+	for _, method := range service.Methods {
+		config := annotations.GetMethodHTTPConfig(method)
+		if config != nil && config.Method == http.MethodGet && !config.Stream {
+			return true
+		}
+	}
+	return false
+}
+
+// fileCachesResponses reports whether any service of file caches responses.
+func fileCachesResponses(file *protogen.File) bool {
+	for _, service := range file.Services {
+		if cachesResponses(service) {
+			return true
+		}
+	}
+	return false
+}
+
+// invalidationPrefix returns the path prefix of the cache entries a mutation
+// of fullPath invalidates: its path up to the segment holding the first
+// variable, without a custom verb, so that PUT /users/{id} drops both
+// /users/42 and the /users listing.
+func invalidationPrefix(fullPath string) string {
+	prefix := fullPath
+	if i := strings.Index(prefix, "{"); i >= 0 {
+		prefix = prefix[:strings.LastIndex(prefix[:i], "/")]
+	}
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		if verb := strings.Index(prefix[i:], ":"); verb >= 0 {
+			prefix = prefix[:i+verb]
+		}
+	}
+	return strings.TrimSuffix(prefix, "/")
+}
+
+// anyClientCachesResponses reports whether any generated client caches responses, and
+// so imports the response cache module.
+func (g *Generator) anyClientCachesResponses() bool {
+	for _, file := range g.plugin.Files {
+		if file.Generate && fileCachesResponses(file) {
+			return true
+		}
+	}
+	return false
+}
+
+// emitResponseCacheModule writes the shared response cache module for one
+// format variant.
+func (g *Generator) emitResponseCacheModule(variant tscommon.ModuleVariant) {
+	gf := g.plugin.NewGeneratedFile(responseCacheModule+variant.SourceExt, "")
+	writeResponseCacheModule(tscommon.DirectPrinter(gf))
+}
+
+func writeResponseCacheModule(p tscommon.Printer) {
+	p("// Code generated by protoc-gen-ts-client. DO NOT EDIT.")
+	p("")
+	p("/** Configures the response cache of a generated client. */")
+	p("export interface ResponseCacheOptions {")
+	p("  /** How long a GET response is served from the cache, in milliseconds. */")
+	p("  ttlMs: number;")
+	p("  /** How many responses are kept before the least recently used is evicted. Defaults to 100. */")
+	p("  maxEntries?: number;")
+	p("  /** Serve an expired response immediately while it is refreshed in the background. */")
+	p("  staleWhileRevalidate?: boolean;")
+	p("}")
+	p("")
+	p("interface CacheEntry {")
+	p("  value: unknown;")
+	p("  expiresAt: number;")
+	p("  refreshing: boolean;")
+	p("}")
+	p("")
+	p("/**")
+	p(" * Memoizes the GET responses of one client instance, keyed by URL and request")
+	p(" * headers. Entries live in a Map in least recently used order, so the first key")
+	p(" * is the one evicted when the cache is full. The cache keeps its own copy of")
+	p(" * every response and hands out copies, so callers may mutate what they get.")
+	p(" */")
+	p("export class ResponseCache {")
+	p("  private readonly entries = new Map<string, CacheEntry>();")
+	p("  // The loads of the misses in flight, shared by the concurrent misses of a key")
+	p("  private readonly loading = new Map<string, Promise<unknown>>();")
+	p("  private readonly ttlMs: number;")
+	p("  private readonly maxEntries: number;")
+	p("  private readonly staleWhileRevalidate: boolean;")
+	p("  // Bumped by invalidate, so loads started before it do not store their now stale result")
+	p("  private generation = 0;")
+	p("")
+	p("  constructor(options: ResponseCacheOptions) {")
+	p("    this.ttlMs = options.ttlMs;")
+	p("    this.maxEntries = Math.max(1, options.maxEntries ?? 100);")
+	p("    this.staleWhileRevalidate = options.staleWhileRevalidate ?? false;")
+	p("  }")
+	p("")
+	p("  /**")
+	p("   * Returns a copy of the cached response of a GET to url with headers, calling")
+	p("   * load on a miss. Concurrent misses of a key share one call of load and its")
+	p("   * outcome: each gets its own copy of the response, or the same error. With")
+	p("   * staleWhileRevalidate an expired response is returned as is while load")
+	p("   * refreshes it; a failed refresh keeps the stale response.")
+	p("   */")
+	p("  async get<T>(url: string, headers: Record<string, string>, load: () => Promise<T>): Promise<T> {")
+	p("    const key = cacheKey(url, headers);")
+	p("    const entry = this.entries.get(key);")
+	p("    if (entry) {")
+	p("      this.entries.delete(key);")
+	p("      this.entries.set(key, entry);")
+	p("      if (Date.now() < entry.expiresAt) {")
+	p("        return structuredClone(entry.value) as T;")
+	p("      }")
+	p("      if (this.staleWhileRevalidate) {")
+	p("        if (!entry.refreshing) {")
+	p("          entry.refreshing = true;")
+	p("          const generation = this.generation;")
+	p("          load().then(")
+	p("            (value) => this.store(key, value, generation),")
+	p("            () => {")
+	p("              entry.refreshing = false;")
+	p("            },")
+	p("          );")
+	p("        }")
+	p("        return structuredClone(entry.value) as T;")
+	p("      }")
+	p("    }")
+	p("    let loading = this.loading.get(key);")
+	p("    if (!loading) {")
+	p("      const generation = this.generation;")
+	p("      const started = load().then((value) => {")
+	p("        this.store(key, value, generation);")
+	p("        return value;")
+	p("      });")
+	p("      const settled = () => {")
+	p("        if (this.loading.get(key) === started) {")
+	p("          this.loading.delete(key);")
+	p("        }")
+	p("      };")
+	p("      started.then(settled, settled);")
+	p("      this.loading.set(key, started);")
+	p("      loading = started;")
+	p("    }")
+	p("    return structuredClone(await loading) as T;")
+	p("  }")
+	p("")
+	p("  /**")
+	p("   * Drops the responses of the URLs under urlPrefix: the URL itself, the paths")
+	p("   * below it and its custom verbs, with any query string. Misses of those URLs")
+	p("   * after it call load again instead of sharing a load started before it.")
+	p("   */")
+	p("  invalidate(urlPrefix: string): void {")
+	p("    this.generation++;")
+	p("    for (const cached of [this.entries, this.loading]) {")
+	p("      for (const key of [...cached.keys()]) {")
+	p("        const url = key.slice(0, key.indexOf(\"\\n\")).split(\"?\")[0];")
+	p("        if (url === urlPrefix || url.startsWith(urlPrefix + \"/\") || url.startsWith(urlPrefix + \":\")) {")
+	p("          cached.delete(key);")
+	p("        }")
+	p("      }")
+	p("    }")
+	p("  }")
+	p("")
+	p("  private store(key: string, value: unknown, generation: number): void {")
+	p("    if (generation !== this.generation) {")
+	p("      return;")
+	p("    }")
+	p("    this.entries.delete(key);")
+	p("    this.entries.set(key, { value, expiresAt: Date.now() + this.ttlMs, refreshing: false });")
+	p("    while (this.entries.size > this.maxEntries) {")
+	p("      this.entries.delete(this.entries.keys().next().value as string);")
+	p("    }")
+	p("  }")
+	p("}")
+	p("")
+	p("/** Keys a request by its URL and its headers, in name order. */")
+	p("function cacheKey(url: string, headers: Record<string, string>): string {")
+	p("  const names = Object.keys(headers).sort();")
+	p("  return url + \"\\n\" + JSON.stringify(names.map((name) => [name.toLowerCase(), headers[name]]));")
+	p("}")
+}
+
+// generateCachedFetch generates the fetch and response handling of a GET
+// method as a load function, called through the client's response cache when
+// it has one.
+func (g *Generator) generateCachedFetch(p printer, cfg *rpcMethodConfig, method *protogen.Method) {
+	p("    const load = async (): Promise<%s> => {", g.resolveOutputType(method))
+	body := printer(func(format string, args ...interface{}) {
+		if format == "" {
+			p("")
+			return
+		}
+		p("  "+format, args...)
+	})
+	g.generateFetchCall(body, cfg)
+	g.generateResponseHandling(body, cfg, method)
+	p("    };")
+	p("    return this.cache ? this.cache.get(url, headers, load) : load();")
+}
+
+// needResponseCacheModule records the response cache module imports of a
+// client module with a caching service.
+func (g *Generator) needResponseCacheModule(file *protogen.File) {
+	if !fileCachesResponses(file) {
+		return
+	}
+	g.ctx.NeedRuntime(responseCacheModule, responseCacheName, "type "+responseCacheOptionsName)
+}
@@ -0,0 +1,28 @@
+package tsclientgen
+
+import "testing"
+
+func TestInvalidationPrefix(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"/api/v1/resources", "/api/v1/resources"},
+		{"/api/v1/resources/{resource_id}", "/api/v1/resources"},
+		{"/orgs/{org_id}/teams/{team_id}", "/orgs"},
+		{"/resources/{name=shelves/*}", "/resources"},
+		{"/resources:batchDelete", "/resources"},
+		{"/resources/{id}:archive", "/resources"},
+		{"/{id}", ""},
+		{"/", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := invalidationPrefix(tt.input)
+			if got != tt.want {
+				t.Errorf("invalidationPrefix(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
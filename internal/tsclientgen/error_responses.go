@@ -0,0 +1,77 @@
+package tsclientgen
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/tscommon"
+)
+
+// errorResponseClassName returns the name of the ApiError subclass thrown for
+// a documented error status whose body is msg.
+func errorResponseClassName(msg *protogen.Message) string {
+	return tscommon.QualifiedTSName(msg.Desc) + "Response"
+}
+
+// fileErrorResponseMessages returns the messages the methods of file document
+// as error bodies, once each, in the order they are first documented.
+func (g *Generator) fileErrorResponseMessages(file *protogen.File) []*protogen.Message {
+	var messages []*protogen.Message
+	seen := make(map[*protogen.Message]bool)
+	for _, service := range file.Services {
+		for _, method := range service.Methods {
+			responses, _ := annotations.GetErrorResponses(g.plugin, method)
+			for _, response := range responses {
+				if !seen[response.Message] {
+					seen[response.Message] = true
+					messages = append(messages, response.Message)
+				}
+			}
+		}
+	}
+	return messages
+}
+
+// serviceDocumentsErrors reports whether a method of service documents error
+// responses, which its handleError then takes.
+func (g *Generator) serviceDocumentsErrors(service *protogen.Service) bool {
+	for _, method := range service.Methods {
+		if responses, _ := annotations.GetErrorResponses(g.plugin, method); len(responses) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// generateErrorResponseClass generates the ApiError subclass thrown for the
+// documented error statuses whose body is msg, carrying the parsed body.
+func (g *Generator) generateErrorResponseClass(p printer, msg *protogen.Message) {
+	name := errorResponseClassName(msg)
+	p("/** Thrown when a method answers a status it documents with a %s body. */", msg.Desc.Name())
+	p("export class %s extends ApiError {", name)
+	p("  parsedBody: %s;", g.ctx.RefMessage(msg))
+	p("")
+	p("  constructor(statusCode: number, body: string, parsedBody: %s) {", g.ctx.RefMessage(msg))
+	p("    super(statusCode, `Request failed with status ${statusCode}`, body);")
+	p("    this.name = %q;", name)
+	p("    this.parsedBody = parsedBody;")
+	p("  }")
+	p("}")
+	p("")
+}
+
+// handleErrorCall returns the call of handleError answering an error status,
+// passing the classes of the method's documented error statuses.
+func (cfg *rpcMethodConfig) handleErrorCall() string {
+	if len(cfg.errorResponses) == 0 {
+		return "this.handleError(resp)"
+	}
+	classes := make([]string, 0, len(cfg.errorResponses))
+	for _, response := range cfg.errorResponses {
+		classes = append(classes, fmt.Sprintf("%d: %s", response.Status, errorResponseClassName(response.Message)))
+	}
+	return "this.handleError(resp, { " + strings.Join(classes, ", ") + " })"
+}
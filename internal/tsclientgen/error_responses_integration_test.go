@@ -0,0 +1,95 @@
+package tsclientgen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestErrorResponsesIntegration generates the TypeScript client for
+// error_responses.proto and runs errorResponsesTSProgram against it with a
+// fetch answering with error statuses: documented statuses throw the class of
+// their body, which is an ApiError, and other statuses keep throwing ApiError.
+func TestErrorResponsesIntegration(t *testing.T) {
+	plugintest.RunTS(t, plugintest.Module{
+		Protos:   map[string]string{"error_responses.proto": ""},
+		ProtoDir: filepath.Join("testdata", "proto"),
+		Plugins:  []plugintest.Plugin{{Name: "ts-client"}},
+		Files:    map[string]string{"main.ts": errorResponsesTSProgram},
+	})
+}
+
+// errorResponsesTSProgram tests the error classes of a generated client. Its
+// fetch answers with the status and body the test sets.
+const errorResponsesTSProgram = `import assert from "node:assert/strict";
+import { test } from "node:test";
+import {
+  BorrowBookRequestRejectionResponse,
+  LibraryServiceClient,
+  NotFoundErrorResponse,
+  RateLimitedResponse,
+} from "./error_responses_client.ts";
+import { ApiError, ValidationError } from "./errors.ts";
+
+let status = 200;
+let body: unknown = {};
+
+function client(): LibraryServiceClient {
+  const fetchFn = async () =>
+    new Response(JSON.stringify(body), { status, headers: { "Content-Type": "application/json" } });
+  return new LibraryServiceClient("http://api.test", { fetch: fetchFn as typeof fetch });
+}
+
+test("a documented status throws the class of its body", async () => {
+  status = 404;
+  body = { resource: "book", id: "b-1" };
+  const err = await client().getBook({ id: "b-1" }).catch((e: unknown) => e);
+  assert.ok(err instanceof NotFoundErrorResponse);
+  assert.ok(err instanceof ApiError);
+  assert.equal(err.name, "NotFoundErrorResponse");
+  assert.equal(err.statusCode, 404);
+  assert.deepEqual(err.parsedBody, { resource: "book", id: "b-1" });
+  assert.equal(err.body, JSON.stringify(body));
+
+  status = 429;
+  body = { retryAfterSeconds: 30 };
+  const limited = await client().getBook({ id: "b-1" }).catch((e: unknown) => e);
+  assert.ok(limited instanceof RateLimitedResponse);
+  assert.equal(limited.parsedBody.retryAfterSeconds, 30);
+});
+
+test("result messages throw the classes of their documented statuses", async () => {
+  status = 422;
+  body = { reason: "membership expired" };
+  const err = await client().borrowBook({ id: "b-1", memberId: "m-1" }).catch((e: unknown) => e);
+  assert.ok(err instanceof BorrowBookRequestRejectionResponse);
+  assert.equal(err.parsedBody.reason, "membership expired");
+
+  status = 202;
+  body = { position: 3 };
+  assert.deepEqual(await client().borrowBook({ id: "b-1", memberId: "m-1" }), {
+    status: 202,
+    waitlist: { position: 3 },
+  });
+});
+
+test("other statuses and methods keep throwing ApiError", async () => {
+  status = 500;
+  body = { message: "boom", code: "INTERNAL" };
+  const err = await client().getBook({ id: "b-1" }).catch((e: unknown) => e);
+  assert.ok(err instanceof ApiError);
+  assert.ok(!(err instanceof NotFoundErrorResponse));
+  assert.equal(err.code, "INTERNAL");
+
+  status = 404;
+  body = { resource: "book", id: "b-1" };
+  const listErr = await client().listBooks({}).catch((e: unknown) => e);
+  assert.ok(listErr instanceof ApiError);
+  assert.ok(!(listErr instanceof NotFoundErrorResponse));
+
+  status = 400;
+  body = { violations: [{ field: "id", description: "required" }] };
+  assert.ok(await client().getBook({ id: "" }).catch((e: unknown) => e) instanceof ValidationError);
+});
+`
@@ -0,0 +1,128 @@
+package tsclientgen
+
+import "github.com/SebastienMelki/sebuf/internal/tscommon"
+
+// Target selects the runtime environment the generated client is written for.
+type Target string
+
+const (
+	// TargetBrowser types the client against the DOM lib (typeof fetch,
+	// Response) and uses the global fetch.
+	TargetBrowser Target = "browser"
+	// TargetNode avoids DOM types and resolves fetch through the shared fetch
+	// module, so runtimes without a global fetch (Node 16) can install one.
+	TargetNode Target = "node"
+	// TargetIsomorphic emits the same DOM-free code as TargetNode for clients
+	// shared between browser and server bundles.
+	TargetIsomorphic Target = "isomorphic"
+)
+
+func (t Target) valid() bool {
+	return t == TargetBrowser || t == TargetNode || t == TargetIsomorphic
+}
+
+// usesFetchModule reports whether the client imports the shared fetch module
+// instead of relying on the DOM fetch types and global.
+func (t Target) usesFetchModule() bool {
+	return t == TargetNode || t == TargetIsomorphic
+}
+
+// fetchModule is the extensionless path of the shared fetch module emitted at
+// the output root for the node and isomorphic targets.
+const fetchModule = "fetch"
+
+// Exports of the fetch module referenced by the client modules.
+const (
+	fetchLikeName     = "FetchLike"
+	fetchResponseName = "FetchResponse"
+	resolveFetchName  = "resolveFetch"
+)
+
+// fetchHelperNames returns the fetch module exports client modules reference,
+// reserved in their import trackers so proto types are aliased around them.
+func fetchHelperNames() []string {
+	return []string{fetchLikeName, fetchResponseName, resolveFetchName}
+}
+
+// emitFetchModule writes the shared fetch module for one format variant. It
+// declares the minimal structural fetch types the client needs (so no DOM lib
+// is required) and the setFetchImplementation hook.
+func (g *Generator) emitFetchModule(variant tscommon.ModuleVariant) {
+	gf := g.plugin.NewGeneratedFile(fetchModule+variant.SourceExt, "")
+	writeFetchModule(tscommon.DirectPrinter(gf))
+}
+
+func writeFetchModule(p tscommon.Printer) {
+	p("// Code generated by protoc-gen-ts-client. DO NOT EDIT.")
+	p("")
+	p("export interface FetchInit {")
+	p("  method?: string;")
+	p("  headers?: Record<string, string>;")
+	p("  body?: string;")
+	p("  signal?: AbortSignal;")
+	p("}")
+	p("")
+	p("export interface FetchStreamReader {")
+	p("  read(): Promise<{ done: boolean; value?: Uint8Array }>;")
+	p("  releaseLock(): void;")
+	p("}")
+	p("")
+	p("export interface FetchResponse {")
+	p("  readonly ok: boolean;")
+	p("  readonly status: number;")
+	p("  readonly headers: { get(name: string): string | null };")
+	p("  readonly body: { getReader(): FetchStreamReader } | null;")
+	p("  json(): Promise<unknown>;")
+	p("  text(): Promise<string>;")
+	p("}")
+	p("")
+	p("/** The subset of the fetch API the generated clients use. The global fetch satisfies it. */")
+	p("export type FetchLike = (url: string, init?: FetchInit) => Promise<FetchResponse>;")
+	p("")
+	p("let fetchImplementation: FetchLike | undefined;")
+	p("")
+	p("/**")
+	p(" * Sets the fetch used by clients constructed without a fetch option. Call it")
+	p(" * once at startup on runtimes without a global fetch (e.g. Node 16 with")
+	p(" * node-fetch or undici), before constructing any client.")
+	p(" */")
+	p("export function setFetchImplementation(fn: FetchLike): void {")
+	p("  fetchImplementation = fn;")
+	p("}")
+	p("")
+	p("/** Returns the fetch set by setFetchImplementation, falling back to the global fetch. */")
+	p("export function resolveFetch(): FetchLike {")
+	p("  if (fetchImplementation) return fetchImplementation;")
+	p("  const globalFetch = (globalThis as { fetch?: FetchLike }).fetch;")
+	p("  if (!globalFetch) {")
+	p(`    throw new Error("no fetch available: call setFetchImplementation() or pass the fetch option");`)
+	p("  }")
+	p("  return globalFetch.bind(globalThis);")
+	p("}")
+}
+
+// fetchTypes returns the TypeScript types the client uses for its fetch
+// function and responses.
+func (g *Generator) fetchTypes() (fetchType, responseType string) {
+	if g.target.usesFetchModule() {
+		return fetchLikeName, fetchResponseName
+	}
+	return "typeof fetch", "Response"
+}
+
+// defaultFetchExpr returns the expression a client falls back to when no
+// fetch option is given.
+func (g *Generator) defaultFetchExpr() string {
+	if g.target.usesFetchModule() {
+		return resolveFetchName + "()"
+	}
+	return "globalThis.fetch"
+}
+
+// needFetchModule records the fetch module imports used by the client body.
+func (g *Generator) needFetchModule() {
+	if !g.target.usesFetchModule() {
+		return
+	}
+	g.ctx.NeedRuntime(fetchModule, "type "+fetchLikeName, "type "+fetchResponseName, resolveFetchName)
+}
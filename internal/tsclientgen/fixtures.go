@@ -0,0 +1,209 @@
+package tsclientgen
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/tscommon"
+)
+
+// emitFixturesModule writes a service file's test fixtures in one module
+// format variant: a mock<Message>() factory for every message its services
+// reference, returning the value the Go mock server (generate_mock=true)
+// serializes for that message. Values and recursion follow the shared rules in
+// annotations (ResolveExampleValue, PopulatesExample), so the two cannot drift.
+// Fixtures are test-only and are left out of the package barrels.
+func (g *Generator) emitFixturesModule(file *protogen.File, variant tscommon.ModuleVariant) {
+	module := file.GeneratedFilenamePrefix + "_fixtures"
+	gf := g.plugin.NewGeneratedFile(module+variant.SourceExt, "")
+	messages := tscommon.CollectServiceMessages(file).OrderedMessages()
+	tracker := tscommon.NewImportTracker()
+	for _, msg := range messages {
+		tracker.Reserve(fixtureFuncName(msg))
+	}
+	ctx := &tscommon.EmitContext{SelfModule: module, Imports: tracker, ImportExt: variant.ImportExt}
+
+	var body []string
+	bp := tscommon.BufferedPrinter(&body)
+	for _, msg := range messages {
+		bp("export function %s(): %s {", fixtureFuncName(msg), ctx.RefMessage(msg))
+		bp("  return %s;", fixtureObject(msg, nil, "  "))
+		bp("}")
+		bp("")
+	}
+
+	dp := tscommon.DirectPrinter(gf)
+	dp("// Code generated by protoc-gen-ts-client. DO NOT EDIT.")
+	dp("// source: %s", file.Desc.Path())
+	dp("")
+	tracker.Render(dp)
+	for _, line := range body {
+		gf.P(line)
+	}
+}
+
+// fixtureFuncName returns the name of a message's fixture factory.
+func fixtureFuncName(msg *protogen.Message) string {
+	return "mock" + tscommon.QualifiedTSName(msg.Desc)
+}
+
+// fixtureObject renders the object literal for msg, whose closing brace is
+// indented by indent. path holds the enclosing messages being populated.
+func fixtureObject(msg *protogen.Message, path []*protogen.Message, indent string) string {
+	entries := fixtureEntries(msg, path, "", indent+"  ")
+	if len(entries) == 0 {
+		return "{}"
+	}
+	return "{\n" + strings.Join(entries, "\n") + "\n" + indent + "}"
+}
+
+// fixtureEntries renders one "key: value," line per property msg contributes to
+// its JSON object. Flattened fields contribute their child's properties under
+// the flatten prefix, which prefixes every key.
+func fixtureEntries(msg *protogen.Message, path []*protogen.Message, prefix, indent string) []string {
+	path = append(path[:len(path):len(path)], msg)
+
+	var entries []string
+	for _, field := range msg.Fields {
+		key := tscommon.PropertyKey(prefix + annotations.JSONFieldName(field))
+		if !annotations.PopulatesExample(field, path) {
+			// Repeated and map properties are required in the interfaces.
+			switch {
+			case field.Oneof != nil && !field.Oneof.Desc.IsSynthetic():
+			case field.Desc.IsMap():
+				entries = append(entries, indent+key+": {},")
+			case field.Desc.IsList():
+				entries = append(entries, indent+key+": [],")
+			}
+			continue
+		}
+		if annotations.IsFlattenField(field) && field.Message != nil {
+			childPrefix := prefix + annotations.GetFlattenPrefix(field)
+			entries = append(entries, fixtureEntries(field.Message, path, childPrefix, indent)...)
+			continue
+		}
+		entries = append(entries, indent+key+": "+fixtureFieldValue(field, path, indent)+",")
+	}
+	return entries
+}
+
+// fixtureFieldValue renders a populated field's value: one element for
+// repeated fields and one entry for maps.
+func fixtureFieldValue(field *protogen.Field, path []*protogen.Message, indent string) string {
+	switch {
+	case field.Desc.IsMap():
+		keyField, valueField := field.Message.Fields[0], field.Message.Fields[1]
+		key := annotations.ResolveExampleMapKey(keyField).String()
+		value := fixtureMapValue(valueField, path, indent+"  ")
+		return fmt.Sprintf("{\n%s  %q: %s,\n%s}", indent, key, value, indent)
+	case field.Desc.IsList():
+		return "[" + fixtureElement(field, path, indent) + "]"
+	default:
+		return fixtureElement(field, path, indent)
+	}
+}
+
+// fixtureMapValue renders a map entry's value. A message value carrying an
+// unwrap annotation collapses to its unwrapped repeated field, as it does on
+// the wire.
+func fixtureMapValue(valueField *protogen.Field, path []*protogen.Message, indent string) string {
+	if valueField.Message == nil {
+		return fixtureScalar(valueField)
+	}
+	unwrapField := annotations.FindUnwrapField(valueField.Message)
+	if unwrapField == nil || unwrapField.Desc.IsMap() {
+		return fixtureObject(valueField.Message, path, indent)
+	}
+	wrapperPath := append(path[:len(path):len(path)], valueField.Message)
+	if !annotations.PopulatesExample(unwrapField, wrapperPath) {
+		return "[]"
+	}
+	return "[" + fixtureElement(unwrapField, wrapperPath, indent) + "]"
+}
+
+// fixtureElement renders a single (element) value of a message or scalar field.
+func fixtureElement(field *protogen.Field, path []*protogen.Message, indent string) string {
+	if field.Message != nil {
+		return fixtureObject(field.Message, path, indent)
+	}
+	return fixtureScalar(field)
+}
+
+// fixtureScalar renders the JSON form of a scalar or enum field's resolved
+// example value, honoring the field's encoding annotations.
+func fixtureScalar(field *protogen.Field) string {
+	v := annotations.ResolveExampleValue(field)
+	switch field.Desc.Kind() {
+	case protoreflect.StringKind:
+		return strconv.Quote(v.String())
+	case protoreflect.BytesKind:
+		return strconv.Quote(fixtureBytes(field, v.Bytes()))
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(v.Bool())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return strconv.FormatInt(v.Int(), 10)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return strconv.FormatUint(v.Uint(), 10)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return fixtureInt64(field, strconv.FormatInt(v.Int(), 10))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return fixtureInt64(field, strconv.FormatUint(v.Uint(), 10))
+	case protoreflect.FloatKind:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32)
+	case protoreflect.DoubleKind:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case protoreflect.EnumKind:
+		return fixtureEnum(field, v)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return "undefined"
+	default:
+		return "undefined"
+	}
+}
+
+// fixtureInt64 quotes a 64-bit integer unless the field uses NUMBER encoding.
+func fixtureInt64(field *protogen.Field, digits string) string {
+	if annotations.IsInt64NumberEncoding(field) {
+		return digits
+	}
+	return strconv.Quote(digits)
+}
+
+// fixtureEnum renders an enum value by its JSON name (honoring enum_value
+// mappings), or by number under NUMBER encoding.
+func fixtureEnum(field *protogen.Field, v protoreflect.Value) string {
+	value := annotations.ExampleEnumValue(field, v)
+	if value == nil || annotations.ResolveEnumEncoding(field) == http.EnumEncoding_ENUM_ENCODING_NUMBER {
+		return strconv.Itoa(int(v.Enum()))
+	}
+	if custom := annotations.GetEnumValueMapping(value); custom != "" {
+		return strconv.Quote(custom)
+	}
+	return strconv.Quote(string(value.Desc.Name()))
+}
+
+// fixtureBytes encodes b as the field's bytes_encoding serializes it.
+//
+//nolint:exhaustive // UNSPECIFIED and BASE64 both use the protojson default
+func fixtureBytes(field *protogen.Field, b []byte) string {
+	switch annotations.ResolveBytesEncoding(field) {
+	case http.BytesEncoding_BYTES_ENCODING_BASE64_RAW:
+		return base64.RawStdEncoding.EncodeToString(b)
+	case http.BytesEncoding_BYTES_ENCODING_BASE64URL:
+		return base64.URLEncoding.EncodeToString(b)
+	case http.BytesEncoding_BYTES_ENCODING_BASE64URL_RAW:
+		return base64.RawURLEncoding.EncodeToString(b)
+	case http.BytesEncoding_BYTES_ENCODING_HEX:
+		return hex.EncodeToString(b)
+	default:
+		return base64.StdEncoding.EncodeToString(b)
+	}
+}
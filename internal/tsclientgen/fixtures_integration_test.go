@@ -0,0 +1,197 @@
+package tsclientgen
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// fixtureMessages lists the CatalogService responses the cross-generator test
+// compares; goMockProgram and tsFixtureProgram print each under its name.
+var fixtureMessages = []string{
+	"Product", "CategoryTree", "Inventory", "Ledger", "Media", "Shipment", "ReviewIndex",
+}
+
+// TestFixturesGoMockIntegration generates the Go mock server (generate_mock=true) and
+// the TypeScript fixtures (fixtures=true) for fixtures.proto, then checks that
+// the JSON the Go mock returns for every message equals the JSON of the
+// matching mock<Message>() fixture. Zero values are dropped from both sides
+// first: protojson omits them while the TypeScript interfaces require them.
+func TestFixturesGoMockIntegration(t *testing.T) {
+	node := plugintest.TypeStrippingNode()
+	if node == "" {
+		t.Skip("node with --experimental-strip-types not found, skipping integration test")
+	}
+
+	tsDir := t.TempDir()
+	tempDir := plugintest.Generate(t, plugintest.Module{
+		Path:     "fixtures_test",
+		Protos:   map[string]string{"fixtures.proto": ""},
+		ProtoDir: filepath.Join("testdata", "proto"),
+		Mappings: []string{"Mfixtures.proto=fixtures_test/gen;gen"},
+		Plugins: []plugintest.Plugin{
+			{Name: "go-http", Opt: "generate_mock=true"},
+			{Name: "ts-client", Opt: "fixtures=true", Out: tsDir},
+		},
+		Files: map[string]string{"main.go": goMockProgram},
+	})
+
+	goCmd := exec.Command("go", "run", ".")
+	goCmd.Dir = tempDir
+	goCmd.Stderr = os.Stderr
+	goOut, goErr := goCmd.Output()
+	if goErr != nil {
+		t.Fatalf("Go mock program failed: %v", goErr)
+	}
+
+	plugintest.WriteFiles(t, tsDir, map[string]string{"package.json": `{"type": "module"}`, "main.ts": tsFixtureProgram})
+	tsCmd := exec.Command(node, "--experimental-strip-types", "--no-warnings", "main.ts")
+	tsCmd.Dir = tsDir
+	tsCmd.Stderr = os.Stderr
+	tsOut, tsErr := tsCmd.Output()
+	if tsErr != nil {
+		t.Fatalf("TypeScript fixture program failed: %v", tsErr)
+	}
+
+	goJSON := decodeFixtureOutput(t, "Go mock", goOut)
+	tsJSON := decodeFixtureOutput(t, "TypeScript fixture", tsOut)
+	for _, msg := range fixtureMessages {
+		t.Run(msg, func(t *testing.T) {
+			want := dropZeroValues(goJSON[msg])
+			got := dropZeroValues(tsJSON[msg])
+			if want == nil {
+				t.Fatalf("Go mock produced no JSON for %s", msg)
+			}
+			if !reflect.DeepEqual(got, want) {
+				gotJSON, _ := json.MarshalIndent(got, "", "  ")
+				wantJSON, _ := json.MarshalIndent(want, "", "  ")
+				t.Errorf("mock%s() does not match the Go mock\nTypeScript: %s\nGo:         %s", msg, gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func decodeFixtureOutput(t *testing.T, source string, out []byte) map[string]any {
+	t.Helper()
+	var decoded map[string]any
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("Failed to parse %s output: %v\n%s", source, err, string(out))
+	}
+	return decoded
+}
+
+// dropZeroValues removes the JSON zero values protojson leaves out (false, 0,
+// "", "0", null, and empty arrays and objects), recursively.
+func dropZeroValues(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := map[string]any{}
+		for key, value := range v {
+			if value = dropZeroValues(value); value != nil {
+				out[key] = value
+			}
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		return out
+	case []any:
+		var out []any
+		for _, value := range v {
+			out = append(out, dropZeroValues(value))
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		return out
+	case bool, float64, string:
+		if v == false || v == float64(0) || v == "" || v == "0" {
+			return nil
+		}
+		return v
+	default:
+		return nil
+	}
+}
+
+// goMockProgram prints the JSON the Go mock server returns for each compared
+// message, using the generated MarshalJSON where one exists.
+const goMockProgram = `package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	gen "fixtures_test/gen"
+)
+
+func marshal(msg proto.Message) json.RawMessage {
+	var data []byte
+	var err error
+	if m, ok := msg.(json.Marshaler); ok {
+		data, err = m.MarshalJSON()
+	} else {
+		data, err = protojson.Marshal(msg)
+	}
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func must[T proto.Message](msg T, err error) proto.Message {
+	if err != nil {
+		panic(err)
+	}
+	return msg
+}
+
+func main() {
+	ctx := context.Background()
+	req := &gen.GetRequest{}
+	mock := gen.NewMockCatalogServiceServer()
+	out := map[string]json.RawMessage{
+		"Product":      marshal(must(mock.GetProduct(ctx, req))),
+		"CategoryTree": marshal(must(mock.GetCategories(ctx, req))),
+		"Inventory":    marshal(must(mock.GetInventory(ctx, req))),
+		"Ledger":       marshal(must(mock.GetLedger(ctx, req))),
+		"Media":        marshal(must(mock.GetMedia(ctx, req))),
+		"Shipment":     marshal(must(mock.GetShipment(ctx, req))),
+		"ReviewIndex":  marshal(must(mock.GetReviewIndex(ctx, req))),
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(out); err != nil {
+		panic(err)
+	}
+}
+`
+
+// tsFixtureProgram prints the JSON of each compared fixture.
+const tsFixtureProgram = `import {
+  mockCategoryTree,
+  mockInventory,
+  mockLedger,
+  mockMedia,
+  mockProduct,
+  mockReviewIndex,
+  mockShipment,
+} from "./fixtures_fixtures.ts";
+
+console.log(JSON.stringify({
+  Product: mockProduct(),
+  CategoryTree: mockCategoryTree(),
+  Inventory: mockInventory(),
+  Ledger: mockLedger(),
+  Media: mockMedia(),
+  Shipment: mockShipment(),
+  ReviewIndex: mockReviewIndex(),
+}));
+`
@@ -1,30 +1,130 @@
 package tsclientgen
 
 import (
+	"fmt"
 	"net/http"
+	"slices"
 
 	"google.golang.org/protobuf/compiler/protogen"
 
 	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/manifest"
 	"github.com/SebastienMelki/sebuf/internal/tscommon"
 )
 
 // Generator handles TypeScript HTTP client code generation for protobuf services.
 type Generator struct {
 	plugin *protogen.Plugin
+	module tscommon.ModuleFormat
+	target Target
+	// fixtures enables the per-service-file *_fixtures test modules.
+	fixtures bool
+	// validateRequests checks requests against their buf.validate rules before fetch.
+	validateRequests bool
+	// schemaFingerprint sends a fingerprint of the schema with every request.
+	schemaFingerprint bool
 	// ctx carries the emission state (self module + import tracker) for the
 	// service file currently being written.
 	ctx *tscommon.EmitContext
+	// manifest records the called routes when Run writes a manifest.
+	manifest *manifest.Builder
+}
+
+// Options configures the TypeScript client generator.
+type Options struct {
+	// Module selects the module format of the emitted runtime modules.
+	// Defaults to tscommon.ModuleFormatESM.
+	Module tscommon.ModuleFormat
+	// Target selects the runtime the client is typed for. Defaults to
+	// TargetBrowser.
+	Target Target
+	// Fixtures emits a <proto>_fixtures module per service file exporting a
+	// mock<Message>() factory for each message its services reference.
+	Fixtures bool
+	// ValidateRequests checks each request against its buf.validate rules
+	// before fetch, throwing the ValidationError the server would answer with.
+	ValidateRequests bool
+	// SchemaFingerprint embeds a fingerprint of each service's schema, which
+	// the client sends so servers can tell it was generated from another one.
+	SchemaFingerprint bool
+	// Manifest makes Run append sebuf.manifest.json, describing the called
+	// routes and the generated files, to its response.
+	Manifest bool
 }
 
 // New creates a new TypeScript client generator.
 func New(plugin *protogen.Plugin) *Generator {
-	return &Generator{plugin: plugin}
+	return NewWithOptions(plugin, Options{})
+}
+
+// NewWithOptions creates a new TypeScript client generator with the given options.
+func NewWithOptions(plugin *protogen.Plugin, opts Options) *Generator {
+	target := opts.Target
+	if target == "" {
+		target = TargetBrowser
+	}
+	return &Generator{
+		plugin:            plugin,
+		module:            opts.Module,
+		target:            target,
+		fixtures:          opts.Fixtures,
+		validateRequests:  opts.ValidateRequests,
+		schemaFingerprint: opts.SchemaFingerprint,
+	}
 }
 
 // Generate emits one canonical type module per proto file, a shared errors
 // module, and one slimmed client module per service file.
 func (g *Generator) Generate() error {
+	module, err := tscommon.ParseModuleFormat(string(g.module))
+	if err != nil {
+		return err
+	}
+	g.module = module
+	if !g.target.valid() {
+		return fmt.Errorf("unsupported target %q: expected %q, %q, or %q",
+			g.target, TargetNode, TargetBrowser, TargetIsomorphic)
+	}
+	if err := annotations.ValidateSyntax(g.plugin); err != nil {
+		return err
+	}
+	if err := annotations.ApplyVisibility(g.plugin, annotations.GeneratorTSClient); err != nil {
+		return err
+	}
+	for _, file := range g.plugin.Files {
+		if !file.Generate {
+			continue
+		}
+		for _, service := range file.Services {
+			if err := annotations.ValidateStreamResponses(service); err != nil {
+				return err
+			}
+			if err := annotations.ValidateServiceHeaders(service); err != nil {
+				return err
+			}
+			if err := annotations.ValidateResponseStatuses(service); err != nil {
+				return err
+			}
+			if err := annotations.ValidateErrorResponses(g.plugin, service); err != nil {
+				return err
+			}
+			if err := annotations.ValidateRawBodyResponses(service); err != nil {
+				return err
+			}
+			if err := annotations.ValidateQueryEncodings(service); err != nil {
+				return err
+			}
+			if err := annotations.ValidateQueryAliases(service); err != nil {
+				return err
+			}
+			if err := annotations.ValidateBasePathParams(service); err != nil {
+				return err
+			}
+			if err := annotations.ValidateBodylessRequestFields(service); err != nil {
+				return err
+			}
+		}
+	}
 	return g.generateModules()
 }
 
@@ -48,15 +148,25 @@ func (g *Generator) generateServiceClient(p printer, service *protogen.Service)
 func (g *Generator) generateClientOptionsInterface(p printer, service *protogen.Service) {
 	serviceName := service.GoName
 
+	fetchType, _ := g.fetchTypes()
 	p("export interface %sClientOptions {", serviceName)
-	p("  fetch?: typeof fetch;")
+	p("  fetch?: %s;", fetchType)
 	p("  defaultHeaders?: Record<string, string>;")
+	if cachesResponses(service) {
+		p("  cache?: %s;", responseCacheOptionsName)
+	}
 
 	// Add typed properties for service-level headers
 	serviceHeaders := annotations.GetServiceHeaders(service)
 	for _, header := range serviceHeaders {
 		propName := headerNameToPropertyName(header.GetName())
-		p("  %s?: string;", propName)
+		p("  %s?: %s;", propName, headerPropertyType(header))
+	}
+
+	// Add the required base path parameters
+	for _, param := range annotations.GetBasePathParams(service) {
+		p("  /** The {%s} parameter of the base path. */", param.Name)
+		p("  %s: string;", tscommon.SnakeToLowerCamel(param.Name))
 	}
 
 	p("}")
@@ -70,12 +180,24 @@ func (g *Generator) generateCallOptionsInterface(p printer, service *protogen.Se
 	p("export interface %sCallOptions {", serviceName)
 	p("  headers?: Record<string, string>;")
 	p("  signal?: AbortSignal;")
+	if serviceReturnsRawBodies(service) {
+		p("  /** Reports the bytes of a raw body response read so far, and its Content-Length if known. */")
+		p("  onDownloadProgress?: (loaded: number, total: number | undefined) => void;")
+	}
+	if g.serviceEmitsMultipart(service) {
+		p("  /** Reports the bytes of a multipart request sent so far, and its size. */")
+		p("  onUploadProgress?: (loaded: number, total: number) => void;")
+	}
+	if reportsResponses(service) {
+		p("  /** Receives the status, headers and warnings of the response to a unary call. */")
+		p("  onResponse?: (metadata: %s) => void;", responseMetadataName)
+	}
 
 	// Add typed properties for service-level headers (also available per-call)
 	serviceHeaders := annotations.GetServiceHeaders(service)
 	for _, header := range serviceHeaders {
 		propName := headerNameToPropertyName(header.GetName())
-		p("  %s?: string;", propName)
+		p("  %s?: %s;", propName, headerPropertyType(header))
 	}
 
 	// Add typed properties for method-level headers
@@ -88,7 +210,7 @@ func (g *Generator) generateCallOptionsInterface(p printer, service *protogen.Se
 				continue
 			}
 			seen[propName] = true
-			p("  %s?: string;", propName)
+			p("  %s?: %s;", propName, headerPropertyType(header))
 		}
 	}
 
@@ -100,24 +222,43 @@ func (g *Generator) generateCallOptionsInterface(p printer, service *protogen.Se
 func (g *Generator) generateClientClass(p printer, service *protogen.Service) {
 	serviceName := service.GoName
 
+	tscommon.WriteJSDoc(tscommon.Printer(p), "", string(service.Comments.Leading), annotations.IsServiceDeprecated(service))
 	p("export class %sClient {", serviceName)
+	g.generateRoutes(p, service)
+	if g.schemaFingerprint {
+		generateSchemaFingerprint(p, service)
+	}
 
 	// Private fields
+	fetchType, _ := g.fetchTypes()
 	p("  private baseURL: string;")
-	p("  private fetchFn: typeof fetch;")
+	p("  private fetchFn: %s;", fetchType)
 	p("  private defaultHeaders: Record<string, string>;")
+	if cachesResponses(service) {
+		p("  private cache?: %s;", responseCacheName)
+	}
 	p("")
 
 	// Constructor
 	g.generateConstructor(p, service)
 
-	// RPC methods
+	// RPC methods, each after its URL builder
 	for _, method := range service.Methods {
+		g.generateURLBuilder(p, method, g.buildRPCMethodConfig(service, method))
 		g.generateRPCMethod(p, service, method)
+		if g.emitsMultipart(method) {
+			g.generateMultipartRPCMethod(p, service, method)
+		}
+	}
+
+	// Multipart form helpers
+	if g.serviceEmitsMultipart(service) {
+		g.generateAppendFormValue(p)
+		g.generateSendForm(p)
 	}
 
 	// Error handler
-	g.generateHandleError(p)
+	g.generateHandleError(p, service)
 
 	p("}")
 	p("")
@@ -127,18 +268,37 @@ func (g *Generator) generateClientClass(p printer, service *protogen.Service) {
 func (g *Generator) generateConstructor(p printer, service *protogen.Service) {
 	serviceName := service.GoName
 
-	p("  constructor(baseURL: string, options?: %sClientOptions) {", serviceName)
-	p(`    this.baseURL = baseURL.replace(/\/+$/, "");`)
-	p("    this.fetchFn = options?.fetch ?? globalThis.fetch;")
-	p("    this.defaultHeaders = { ...options?.defaultHeaders };")
+	basePathParams := annotations.GetBasePathParams(service)
+	if len(basePathParams) == 0 {
+		p("  constructor(baseURL: string, options?: %sClientOptions) {", serviceName)
+		p(`    this.baseURL = baseURL.replace(/\/+$/, "");`)
+	} else {
+		// The base path parameters are required, and so are the options
+		p("  constructor(baseURL: string, options: %sClientOptions) {", serviceName)
+		p(`    let basePath = "%s";`, annotations.GetServiceBasePath(service))
+		for _, param := range basePathParams {
+			value := "options." + tscommon.SnakeToLowerCamel(param.Name)
+			p(`    basePath = basePath.replace("{%s}", encodeURIComponent(%s));`, param.Name, value)
+		}
+		p(`    this.baseURL = baseURL.replace(/\/+$/, "") + basePath;`)
+	}
+	p("    this.fetchFn = options?.fetch ?? %s;", g.defaultFetchExpr())
+	if g.schemaFingerprint {
+		generateSchemaDefaultHeaders(p, serviceName)
+	} else {
+		p("    this.defaultHeaders = { ...options?.defaultHeaders };")
+	}
+	if cachesResponses(service) {
+		p("    this.cache = options?.cache ? new %s(options.cache) : undefined;", responseCacheName)
+	}
 
 	// Apply service-level headers from options
 	serviceHeaders := annotations.GetServiceHeaders(service)
 	for _, header := range serviceHeaders {
 		propName := headerNameToPropertyName(header.GetName())
-		headerName := header.GetName()
+		headerName := annotations.CanonicalHeaderName(header.GetName())
 		p("    if (options?.%s) {", propName)
-		p(`      this.defaultHeaders["%s"] = options.%s;`, headerName, propName)
+		p(`      this.defaultHeaders["%s"] = %s;`, headerName, headerValueExpr(header, "options."+propName))
 		p("    }")
 	}
 
@@ -153,22 +313,59 @@ type rpcMethodConfig struct {
 	httpMethod  string
 	fullPath    string
 	pathParams  []string
-	queryParams []annotations.QueryParam
-	hasBody     bool
-	isSSE       bool
+	// pathJSONNames maps each path parameter to the request property it reads.
+	pathJSONNames map[string]string
+	queryParams   []annotations.QueryParam // sent in the URL query string
+	hasBody       bool
+	isSSE         bool
+	// streamResponse is true for stream_response methods, whose items are read as they arrive.
+	streamResponse bool
+	// headerParams holds the request fields declared with source HEADER.
+	headerParams []annotations.HeaderFieldParam
+	// bodyExcluded holds the request fields declared with a non-body source.
+	bodyExcluded []*protogen.Field
+	// validator names the function checking the request before fetch, if any.
+	validator string
+	// cached memoizes the response in the client's response cache (GET).
+	cached bool
+	// invalidates drops the cached responses under invalidatePrefix once the
+	// mutation succeeds.
+	invalidates      bool
+	invalidatePrefix string
+	// variants holds the variants of a result message response, selected by status.
+	variants []annotations.ResponseVariant
+	// rawBody is true when the response is a raw_body message, read as is.
+	rawBody bool
+	// errorResponses holds the error statuses the method documents with a typed body.
+	errorResponses []annotations.ErrorResponse
 }
 
 // Empty protobuf messages can still be meaningful request values, such as
 // JSON bodies for POST/PUT/PATCH, so don't infer usage from field count alone.
 func (cfg *rpcMethodConfig) requestParamName() string {
-	usesQueryParams := (cfg.httpMethod == http.MethodGet || cfg.httpMethod == http.MethodDelete) &&
-		len(cfg.queryParams) > 0
-	if cfg.hasBody || len(cfg.pathParams) > 0 || usesQueryParams {
+	if cfg.hasBody || len(cfg.pathParams) > 0 || cfg.sendsQueryParams() || len(cfg.headerParams) > 0 ||
+		cfg.validator != "" {
 		return "req"
 	}
 	return "_req"
 }
 
+// sendsQueryParams reports whether the method writes query parameters: all of
+// them for GET/DELETE, and only the explicit QUERY-sourced ones for body methods.
+func (cfg *rpcMethodConfig) sendsQueryParams() bool {
+	usesQuery := cfg.httpMethod == http.MethodGet || cfg.httpMethod == http.MethodDelete || cfg.hasBody
+	return usesQuery && len(cfg.queryParams) > 0
+}
+
+// bodyExpr returns the expression serialized as the request body: the request,
+// or the body built by generateRequestBody when fields are excluded from it.
+func (cfg *rpcMethodConfig) bodyExpr() string {
+	if len(cfg.bodyExcluded) > 0 {
+		return "body"
+	}
+	return "req"
+}
+
 func (g *Generator) buildRPCMethodConfig(service *protogen.Service, method *protogen.Method) *rpcMethodConfig {
 	serviceName := service.GoName
 	methodName := method.GoName
@@ -188,34 +385,70 @@ func (g *Generator) buildRPCMethodConfig(service *protogen.Service, method *prot
 		pathParams = httpConfig.PathParams
 	}
 
-	// Get base path from service config
+	// Get base path from service config. The constructor appends a base path
+	// with parameters to the base URL, so the method paths are relative to it.
 	basePath := annotations.GetServiceBasePath(service)
+	if len(annotations.GetBasePathParams(service)) > 0 {
+		basePath = ""
+	}
 
 	// Combine base path and method path
 	fullPath := annotations.BuildHTTPPath(basePath, httpPath)
 
 	isSSE := httpConfig != nil && httpConfig.Stream
+	streamResponse := httpConfig != nil && httpConfig.StreamResponse
+	hasBody := httpMethod == "POST" || httpMethod == "PUT" || httpMethod == "PATCH"
+	var validator string
+	if g.validatesRequest(method) {
+		validator = validatorFuncName(method.Input)
+	}
+	caches := cachesResponses(service)
+	variants, _ := annotations.GetResponseVariants(method.Output)
+	rawBody := annotations.IsRawBodyResponse(method)
+	errorResponses, _ := annotations.GetErrorResponses(g.plugin, method)
+	// Raw bodies are read as streams, which a cached response could not replay
+	cached := caches && httpMethod == http.MethodGet && !isSSE && !streamResponse && variants == nil && !rawBody
 
 	return &rpcMethodConfig{
-		serviceName: serviceName,
-		methodName:  methodName,
-		httpMethod:  httpMethod,
-		fullPath:    fullPath,
-		pathParams:  pathParams,
-		queryParams: annotations.GetQueryParams(method.Input),
-		hasBody:     httpMethod == "POST" || httpMethod == "PUT" || httpMethod == "PATCH",
-		isSSE:       isSSE,
+		serviceName:      serviceName,
+		methodName:       methodName,
+		httpMethod:       httpMethod,
+		fullPath:         fullPath,
+		pathParams:       pathParams,
+		pathJSONNames:    pathParamJSONNames(method.Input, pathParams),
+		queryParams:      annotations.GetURLQueryParams(method.Input, hasBody),
+		hasBody:          hasBody,
+		isSSE:            isSSE,
+		streamResponse:   streamResponse,
+		headerParams:     annotations.GetHeaderFieldParams(method.Input),
+		bodyExcluded:     annotations.GetBodyExcludedFields(method.Input),
+		validator:        validator,
+		cached:           cached,
+		invalidates:      caches && httpMethod != http.MethodGet && !isSSE && !streamResponse,
+		invalidatePrefix: invalidationPrefix(fullPath),
+		variants:         variants,
+		rawBody:          rawBody,
+		errorResponses:   errorResponses,
 	}
 }
 
 // generateRPCMethod generates a single async RPC method.
 func (g *Generator) generateRPCMethod(p printer, service *protogen.Service, method *protogen.Method) {
 	cfg := g.buildRPCMethodConfig(service, method)
+	if len(annotations.GetBasePathParams(service)) > 0 {
+		g.manifest.AddRoute(method, cfg.httpMethod, annotations.GetServiceBasePath(service)+cfg.fullPath)
+	} else {
+		g.manifest.AddRoute(method, cfg.httpMethod, cfg.fullPath)
+	}
 
 	if cfg.isSSE {
 		g.generateSSERPCMethod(p, service, method, cfg)
 		return
 	}
+	if cfg.streamResponse {
+		g.generateStreamResponseRPCMethod(p, service, method, cfg)
+		return
+	}
 
 	inputType := g.ctx.RefMessage(method.Input)
 	outputType := g.resolveOutputType(method)
@@ -223,20 +456,34 @@ func (g *Generator) generateRPCMethod(p printer, service *protogen.Service, meth
 	tsMethodName := annotations.LowerFirst(cfg.methodName)
 
 	reqParam := cfg.requestParamName()
+	tscommon.WriteJSDoc(tscommon.Printer(p), "  ", string(method.Comments.Leading), annotations.IsMethodDeprecated(method))
 	p("  async %s(%s: %s, options?: %sCallOptions): Promise<%s> {",
 		tsMethodName, reqParam, inputType, cfg.serviceName, outputType)
+	g.generateRequestValidation(p, cfg)
 
 	// Build URL with path params
 	g.generateURLBuilding(p, cfg)
 
 	// Build headers
-	g.generateHeaderMerging(p, service, method)
+	g.generateHeaderMerging(p, service, method, "application/json")
+	g.generateHeaderFieldParams(p, cfg)
+
+	// Build the body without the fields sent elsewhere
+	g.generateRequestBody(p, cfg, inputType)
+
+	// Serve GET responses from the response cache when the client has one
+	if cfg.cached {
+		g.generateCachedFetch(p, cfg, method)
+		p("  }")
+		p("")
+		return
+	}
 
 	// Build fetch options
 	g.generateFetchCall(p, cfg)
 
 	// Handle response
-	g.generateResponseHandling(p, method)
+	g.generateResponseHandling(p, cfg, method)
 
 	p("  }")
 	p("")
@@ -254,14 +501,20 @@ func (g *Generator) generateSSERPCMethod(
 	tsMethodName := annotations.LowerFirst(cfg.methodName)
 
 	reqParam := cfg.requestParamName()
+	tscommon.WriteJSDoc(tscommon.Printer(p), "  ", string(method.Comments.Leading), annotations.IsMethodDeprecated(method))
 	p("  async *%s(%s: %s, options?: %sCallOptions): AsyncGenerator<%s> {",
 		tsMethodName, reqParam, inputType, cfg.serviceName, outputType)
+	g.generateRequestValidation(p, cfg)
 
 	// Build URL with path params
 	g.generateURLBuilding(p, cfg)
 
 	// Build headers (use Accept instead of Content-Type for SSE)
 	g.generateSSEHeaderMerging(p, service, method)
+	g.generateHeaderFieldParams(p, cfg)
+
+	// Build the body without the fields sent elsewhere
+	g.generateRequestBody(p, cfg, inputType)
 
 	// Fetch call
 	g.generateSSEFetchCall(p, cfg)
@@ -273,10 +526,63 @@ func (g *Generator) generateSSERPCMethod(
 	p("")
 }
 
+// generateStreamResponseRPCMethod generates an async generator method for a
+// stream_response method, yielding the items of the list as they arrive.
+func (g *Generator) generateStreamResponseRPCMethod(
+	p printer,
+	service *protogen.Service,
+	method *protogen.Method,
+	cfg *rpcMethodConfig,
+) {
+	inputType := g.ctx.RefMessage(method.Input)
+	field, _ := annotations.StreamResponseField(method)
+	itemType := g.ctx.RefMessage(field.Message)
+	tsMethodName := annotations.LowerFirst(cfg.methodName)
+
+	reqParam := cfg.requestParamName()
+	tscommon.WriteJSDoc(tscommon.Printer(p), "  ", string(method.Comments.Leading), annotations.IsMethodDeprecated(method))
+	p("  async *%s(%s: %s, options?: %sCallOptions): AsyncGenerator<%s> {",
+		tsMethodName, reqParam, inputType, cfg.serviceName, itemType)
+	g.generateRequestValidation(p, cfg)
+
+	// Build URL with path params
+	g.generateURLBuilding(p, cfg)
+
+	// Build headers
+	g.generateHeaderMerging(p, service, method, "application/json")
+	g.generateHeaderFieldParams(p, cfg)
+
+	// Build the body without the fields sent elsewhere
+	g.generateRequestBody(p, cfg, inputType)
+
+	// Fetch call and error check, shared with SSE
+	g.generateSSEFetchCall(p, cfg)
+
+	p("    for await (const item of readJSONArrayItems(resp.body!)) {")
+	p("      yield item as %s;", itemType)
+	p("    }")
+	p("  }")
+	p("")
+}
+
+// generateRequestValidation throws the ValidationError of an invalid request
+// before anything is sent.
+func (g *Generator) generateRequestValidation(p printer, cfg *rpcMethodConfig) {
+	if cfg.validator == "" {
+		return
+	}
+	p("    const violations = %s(req);", cfg.validator)
+	p("    if (violations.length > 0) {")
+	p("      throw new ValidationError(violations);")
+	p("    }")
+	p("")
+}
+
 // generateSSEHeaderMerging generates header construction for SSE requests.
 func (g *Generator) generateSSEHeaderMerging(p printer, service *protogen.Service, method *protogen.Method) {
 	p("    const headers: Record<string, string> = {")
 	p(`      "Accept": "text/event-stream",`)
+	g.generateHeaderDefaults(p, service, method)
 	p("      ...this.defaultHeaders,")
 	p("      ...options?.headers,")
 	p("    };")
@@ -285,16 +591,18 @@ func (g *Generator) generateSSEHeaderMerging(p printer, service *protogen.Servic
 	serviceHeaders := annotations.GetServiceHeaders(service)
 	for _, header := range serviceHeaders {
 		propName := headerNameToPropertyName(header.GetName())
-		headerName := header.GetName()
-		p("    if (options?.%s) headers[\"%s\"] = options.%s;", propName, headerName, propName)
+		headerName := annotations.CanonicalHeaderName(header.GetName())
+		value := headerValueExpr(header, "options."+propName)
+		p("    if (options?.%s) headers[\"%s\"] = %s;", propName, headerName, value)
 	}
 
 	// Apply method-level headers from call options
 	methodHeaders := annotations.GetMethodHeaders(method)
 	for _, header := range methodHeaders {
 		propName := headerNameToPropertyName(header.GetName())
-		headerName := header.GetName()
-		p("    if (options?.%s) headers[\"%s\"] = options.%s;", propName, headerName, propName)
+		headerName := annotations.CanonicalHeaderName(header.GetName())
+		value := headerValueExpr(header, "options."+propName)
+		p("    if (options?.%s) headers[\"%s\"] = %s;", propName, headerName, value)
 	}
 
 	p("")
@@ -306,7 +614,7 @@ func (g *Generator) generateSSEFetchCall(p printer, cfg *rpcMethodConfig) {
 		p("    const resp = await this.fetchFn(url, {")
 		p(`      method: "%s",`, cfg.httpMethod)
 		p("      headers,")
-		p("      body: JSON.stringify(req),")
+		p("      body: JSON.stringify(%s),", cfg.bodyExpr())
 		p("      signal: options?.signal,")
 		p("    });")
 	} else {
@@ -319,7 +627,7 @@ func (g *Generator) generateSSEFetchCall(p printer, cfg *rpcMethodConfig) {
 	p("")
 
 	p("    if (!resp.ok) {")
-	p("      return this.handleError(resp);")
+	p("      return %s;", cfg.handleErrorCall())
 	p("    }")
 	p("")
 }
@@ -349,66 +657,148 @@ func (g *Generator) generateSSEStreamParsing(p printer, outputType string) {
 	p("    }")
 }
 
-// resolveOutputType returns the TypeScript return type, handling root unwrap.
+// fileStreamsResponses reports whether a file has stream_response methods,
+// whose client module then carries readJSONArrayItems.
+func fileStreamsResponses(file *protogen.File) bool {
+	for _, service := range file.Services {
+		if slices.ContainsFunc(service.Methods, annotations.IsStreamResponse) {
+			return true
+		}
+	}
+	return false
+}
+
+// readJSONArrayItemsName is the module-level function reading streamed list responses.
+const readJSONArrayItemsName = "readJSONArrayItems"
+
+// generateReadJSONArrayItems generates the module-level reader of streamed list
+// responses. It scans the body as it arrives, tracking nesting and strings, and
+// parses each item once its separator is read, so only the item being read is
+// held in memory. It accepts a bare array and an object whose only key holds
+// the array, and throws when the response is cut short.
+func generateReadJSONArrayItems(p printer) {
+	p("async function* %s(body: ReadableStream<Uint8Array>): AsyncGenerator<unknown> {", readJSONArrayItemsName)
+	p("  const reader = body.getReader();")
+	p("  const decoder = new TextDecoder();")
+	p(`  let buffer = "";`)
+	p("  let pos = 0;")
+	p("  let start = -1;")
+	p("  let depth = 0;")
+	p("  let arrayDepth = 0;")
+	p("  let inString = false;")
+	p("  let escaped = false;")
+	p("  let ended = false;")
+	p("  try {")
+	p("    while (!ended) {")
+	p("      const { done, value } = await reader.read();")
+	p("      if (done) break;")
+	p("      buffer += decoder.decode(value, { stream: true });")
+	p("      for (; pos < buffer.length && !ended; pos++) {")
+	p("        const c = buffer[pos];")
+	p("        if (inString) {")
+	p("          if (escaped) escaped = false;")
+	p(`          else if (c === "\\") escaped = true;`)
+	p(`          else if (c === '"') inString = false;`)
+	p("          continue;")
+	p("        }")
+	p(`        const atItems = arrayDepth > 0 && depth === arrayDepth;`)
+	p(`        if (atItems && (c === "," || c === "]")) {`)
+	p("          if (start >= 0) yield JSON.parse(buffer.slice(start, pos));")
+	p("          start = -1;")
+	p(`          if (c === ",") continue;`)
+	p("          arrayDepth = -1;")
+	p(`        } else if (atItems && start < 0 && c.trim() !== "") {`)
+	p("          start = pos;")
+	p("        }")
+	p(`        if (c === '"') {`)
+	p("          inString = true;")
+	p(`        } else if (c === "{" || c === "[") {`)
+	p("          depth++;")
+	p(`          if (c === "[" && arrayDepth === 0) arrayDepth = depth;`)
+	p(`        } else if (c === "}" || c === "]") {`)
+	p("          depth--;")
+	p("          ended = depth === 0;")
+	p("        }")
+	p("      }")
+	p("      // Keep only the item being read.")
+	p("      const keep = start >= 0 ? start : pos;")
+	p("      buffer = buffer.slice(keep);")
+	p("      pos -= keep;")
+	p("      if (start >= 0) start = 0;")
+	p("    }")
+	p("  } finally {")
+	p("    // Stop the request when the caller leaves the loop early.")
+	p("    if (!ended) reader.cancel().catch(() => {});")
+	p("    reader.releaseLock();")
+	p("  }")
+	p("  if (!ended) {")
+	p(`    throw new Error("list response ended before it was complete");`)
+	p("  }")
+	p("}")
+	p("")
+}
+
+// resolveOutputType returns the TypeScript return type, handling root unwrap,
+// result messages and raw bodies.
 func (g *Generator) resolveOutputType(method *protogen.Method) string {
 	msg := method.Output
+	if annotations.IsResultMessage(msg) {
+		return resultTypeName(msg)
+	}
+	if annotations.IsRawBodyResponse(method) {
+		return rawBodyTypeName
+	}
 	if annotations.IsRootUnwrap(msg) {
 		return tscommon.RootUnwrapTSTypeCtx(g.ctx, msg)
 	}
 	return g.ctx.RefMessage(msg)
 }
 
-// generateURLBuilding generates URL construction with path and query params.
-func (g *Generator) generateURLBuilding(p printer, cfg *rpcMethodConfig) {
-	p(`    let path = "%s";`, cfg.fullPath)
-
-	// Path parameter substitution
-	for _, param := range cfg.pathParams {
-		jsonName := snakeToLowerCamel(param)
-		p(`    path = path.replace("{%s}", encodeURIComponent(String(req.%s)));`, param, jsonName)
-	}
-
-	// Query parameters
-	//nolint:nestif // Query param generation requires multiple nested conditions
-	if (cfg.httpMethod == "GET" || cfg.httpMethod == "DELETE") && len(cfg.queryParams) > 0 {
-		p("    const params = new URLSearchParams();")
-		for _, qp := range cfg.queryParams {
-			// Handle repeated fields: use forEach + append for multi-value params
-			if qp.Field != nil && qp.Field.Desc.IsList() {
-				p("    if (req.%s && req.%s.length > 0) req.%s.forEach(v => params.append(\"%s\", String(v)));",
-					qp.FieldJSONName, qp.FieldJSONName, qp.FieldJSONName, qp.ParamName)
-				continue
-			}
-
-			// Use field-aware zero check when field reference is available
-			var check string
-			if qp.Field != nil {
-				check = tsZeroCheckForField(qp.Field)
-			} else {
-				check = tsZeroCheck(qp.FieldKind)
-			}
-			if check == "" {
-				// bool: only add if true (undefined is already falsy)
-				p("    if (req.%s) params.set(\"%s\", String(req.%s));",
-					qp.FieldJSONName, qp.ParamName, qp.FieldJSONName)
-			} else {
-				// Guard against undefined/null before zero-value check
-				p("    if (req.%s != null && req.%s%s) params.set(\"%s\", String(req.%s));",
-					qp.FieldJSONName, qp.FieldJSONName, check, qp.ParamName, qp.FieldJSONName)
-			}
+// pathParamJSONNames maps path parameters to the JSON name of their request
+// field, honoring json_name overrides. Parameters without a matching field fall
+// back to the lowerCamelCase form of their name.
+func pathParamJSONNames(input *protogen.Message, pathParams []string) map[string]string {
+	names := make(map[string]string, len(pathParams))
+	for _, param := range pathParams {
+		if field := annotations.FindFieldByProtoName(input, param); field != nil {
+			names[param] = annotations.JSONFieldName(field)
+		} else {
+			names[param] = snakeToLowerCamel(param)
 		}
-		p(`    const url = this.baseURL + path + (params.toString() ? "?" + params.toString() : "");`)
-	} else {
-		p("    const url = this.baseURL + path;")
 	}
+	return names
+}
 
+// generateURLBuilding generates the request URL, built by the method's URL builder.
+func (g *Generator) generateURLBuilding(p printer, cfg *rpcMethodConfig) {
+	p("    const url = this.baseURL + %s;", urlBuilderCall(cfg))
 	p("")
 }
 
+// generateHeaderDefaults generates the declared header defaults as the first
+// entries of the headers literal, so client and call headers override them.
+func (g *Generator) generateHeaderDefaults(p printer, service *protogen.Service, method *protogen.Method) {
+	headers := annotations.HeadersWithDefaults(annotations.CombineHeaders(
+		annotations.GetServiceHeaders(service), annotations.GetMethodHeaders(method),
+	))
+	for _, header := range headers {
+		p("      %q: %q,", annotations.CanonicalHeaderName(header.GetName()), header.GetDefaultValue())
+	}
+}
+
 // generateHeaderMerging generates header construction from defaults + options.
-func (g *Generator) generateHeaderMerging(p printer, service *protogen.Service, method *protogen.Method) {
+// An empty contentType leaves the Content-Type to fetch.
+func (g *Generator) generateHeaderMerging(
+	p printer,
+	service *protogen.Service,
+	method *protogen.Method,
+	contentType string,
+) {
 	p("    const headers: Record<string, string> = {")
-	p(`      "Content-Type": "application/json",`)
+	if contentType != "" {
+		p(`      "Content-Type": "%s",`, contentType)
+	}
+	g.generateHeaderDefaults(p, service, method)
 	p("      ...this.defaultHeaders,")
 	p("      ...options?.headers,")
 	p("    };")
@@ -417,28 +807,55 @@ func (g *Generator) generateHeaderMerging(p printer, service *protogen.Service,
 	serviceHeaders := annotations.GetServiceHeaders(service)
 	for _, header := range serviceHeaders {
 		propName := headerNameToPropertyName(header.GetName())
-		headerName := header.GetName()
-		p("    if (options?.%s) headers[\"%s\"] = options.%s;", propName, headerName, propName)
+		headerName := annotations.CanonicalHeaderName(header.GetName())
+		value := headerValueExpr(header, "options."+propName)
+		p("    if (options?.%s) headers[\"%s\"] = %s;", propName, headerName, value)
 	}
 
 	// Apply method-level headers from call options
 	methodHeaders := annotations.GetMethodHeaders(method)
 	for _, header := range methodHeaders {
 		propName := headerNameToPropertyName(header.GetName())
-		headerName := header.GetName()
-		p("    if (options?.%s) headers[\"%s\"] = options.%s;", propName, headerName, propName)
+		headerName := annotations.CanonicalHeaderName(header.GetName())
+		value := headerValueExpr(header, "options."+propName)
+		p("    if (options?.%s) headers[\"%s\"] = %s;", propName, headerName, value)
 	}
 
 	p("")
 }
 
+// generateHeaderFieldParams sets the request fields declared with source
+// HEADER. They are set last, so they override default and per-call headers.
+func (g *Generator) generateHeaderFieldParams(p printer, cfg *rpcMethodConfig) {
+	for _, hp := range cfg.headerParams {
+		value := tscommon.PropertyAccess("req", hp.FieldJSONName)
+		p("    if (%s) headers[%q] = %s;", value, hp.HeaderName, value)
+	}
+	if len(cfg.headerParams) > 0 {
+		p("")
+	}
+}
+
+// generateRequestBody copies the request without the fields declared with a
+// query, path, or header source, which are sent only in their own location.
+func (g *Generator) generateRequestBody(p printer, cfg *rpcMethodConfig, inputType string) {
+	if !cfg.hasBody || len(cfg.bodyExcluded) == 0 {
+		return
+	}
+	p("    const body: Partial<%s> = { ...req };", inputType)
+	for _, field := range cfg.bodyExcluded {
+		p("    delete %s;", tscommon.PropertyAccess("body", annotations.JSONFieldName(field)))
+	}
+	p("")
+}
+
 // generateFetchCall generates the fetch invocation.
 func (g *Generator) generateFetchCall(p printer, cfg *rpcMethodConfig) {
 	if cfg.hasBody {
 		p("    const resp = await this.fetchFn(url, {")
 		p(`      method: "%s",`, cfg.httpMethod)
 		p("      headers,")
-		p("      body: JSON.stringify(req),")
+		p("      body: JSON.stringify(%s),", cfg.bodyExpr())
 		p("      signal: options?.signal,")
 		p("    });")
 	} else {
@@ -451,31 +868,88 @@ func (g *Generator) generateFetchCall(p printer, cfg *rpcMethodConfig) {
 	p("")
 }
 
-// generateResponseHandling generates response parsing and error handling.
-func (g *Generator) generateResponseHandling(p printer, method *protogen.Method) {
+// generateResponseHandling generates response parsing and error handling. A
+// successful mutation first drops the cached responses it may have changed.
+func (g *Generator) generateResponseHandling(p printer, cfg *rpcMethodConfig, method *protogen.Method) {
 	outputType := g.resolveOutputType(method)
 
+	if cfg.variants != nil {
+		g.generateResultResponseHandling(p, cfg)
+		return
+	}
 	p("    if (!resp.ok) {")
-	p("      return this.handleError(resp);")
+	p("      return %s;", cfg.handleErrorCall())
 	p("    }")
 	p("")
-	p("    return await resp.json() as %s;", outputType)
+	if cfg.invalidates {
+		p("    this.cache?.invalidate(this.baseURL + %q);", cfg.invalidatePrefix)
+		p("")
+	}
+	if cfg.rawBody {
+		generateResponseMetadataReport(p, "    ", "")
+		p("    return %s(resp, options?.onDownloadProgress);", readRawBodyFuncName)
+		return
+	}
+	p("    const result = await resp.json() as %s;", outputType)
+	if annotations.IsRootUnwrap(method.Output) {
+		generateResponseMetadataReport(p, "    ", "")
+	} else {
+		generateResponseMetadataReport(p, "    ", "result")
+	}
+	p("    return result;")
 }
 
 // generateHandleError generates the private error handler method.
-func (g *Generator) generateHandleError(p printer) {
-	p("  private async handleError(resp: Response): Promise<never> {")
+func (g *Generator) generateHandleError(p printer, service *protogen.Service) {
+	_, responseType := g.fetchTypes()
+	documentsErrors := g.serviceDocumentsErrors(service)
+	if documentsErrors {
+		p("  private async handleError(")
+		p("    resp: %s,", responseType)
+		p("    errorResponses?: Record<number, new (statusCode: number, body: string, parsedBody: never) => ApiError>,")
+		p("  ): Promise<never> {")
+	} else {
+		p("  private async handleError(resp: %s): Promise<never> {", responseType)
+	}
 	p("    const body = await resp.text();")
-	p("    if (resp.status === 400) {")
-	p("      try {")
-	p("        const parsed = JSON.parse(body);")
-	p("        if (parsed.violations) {")
-	p("          throw new ValidationError(parsed.violations);")
-	p("        }")
-	p("      } catch (e) {")
-	p("        if (e instanceof ValidationError) throw e;")
+	p("    let parsed: Record<string, unknown> | undefined;")
+	p("    try {")
+	p("      parsed = JSON.parse(body);")
+	p("    } catch {")
+	p("      parsed = undefined;")
+	p("    }")
+	p("    // Problem Details (RFC 9457) carry the error as application/problem+json")
+	p(`    const contentType = resp.headers.get("Content-Type") ?? "";`)
+	p(`    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {`)
+	p("      if (Array.isArray(parsed.errors)) {")
+	p("        const errors = parsed.errors as { pointer?: string; detail?: string }[];")
+	p("        throw new ValidationError(errors.map((e) => ({")
+	p(`          field: (e.pointer ?? "")`)
+	p(`            .split("/")`)
+	p("            .slice(1)")
+	p(`            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))`)
+	p(`            .join("."),`)
+	p(`          description: e.detail ?? "",`)
+	p("        })));")
 	p("      }")
+	p(`      const detail = typeof parsed.detail === "string" ? parsed.detail : "";`)
+	p(`      const code = typeof parsed.code === "string" ? parsed.code : "";`)
+	p("      const details = (parsed.details ?? {}) as Record<string, string>;")
+	p("      const message = detail || `Request failed with status ${resp.status}`;")
+	p("      throw new ApiError(resp.status, message, body, code, details);")
+	p("    }")
+	if documentsErrors {
+		p("    // Documented error statuses throw the class of their body")
+		p("    const errorResponse = errorResponses?.[resp.status];")
+		p("    if (errorResponse && parsed) {")
+		p("      throw new errorResponse(resp.status, body, parsed as never);")
+		p("    }")
+	}
+	p("    if (resp.status === 400 && Array.isArray(parsed?.violations)) {")
+	p("      throw new ValidationError(parsed.violations);")
 	p("    }")
-	p("    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);")
+	p(`    const code = typeof parsed?.code === "string" ? parsed.code : "";`)
+	p("    const details = (parsed?.details ?? {}) as Record<string, string>;")
+	p("    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);")
 	p("  }")
 }
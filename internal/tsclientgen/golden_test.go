@@ -29,6 +29,8 @@ func TestTSClientGenGoldenFiles(t *testing.T) {
 	testCases := []struct {
 		name       string
 		protoFiles []string
+		// opts, when set, is appended to the plugin options.
+		opts string
 		// assertImportFile/assertImport, when set, require the generated file at
 		// assertImportFile (relative to the output dir) to contain assertImport.
 		// Used to lock in cross-package relative imports in the modules layout.
@@ -43,6 +45,7 @@ func TestTSClientGenGoldenFiles(t *testing.T) {
 	}{
 		{name: "comprehensive HTTP verbs", protoFiles: []string{"http_verbs_comprehensive.proto"}},
 		{name: "query parameters", protoFiles: []string{"query_params.proto"}},
+		{name: "field sources", protoFiles: []string{"field_sources.proto"}},
 		{name: "backward compatibility", protoFiles: []string{"backward_compat.proto"}},
 		{name: "complex features", protoFiles: []string{"complex_features.proto"}},
 		{name: "unwrap variants", protoFiles: []string{"unwrap.proto"}},
@@ -52,15 +55,35 @@ func TestTSClientGenGoldenFiles(t *testing.T) {
 		{name: "empty behavior", protoFiles: []string{"empty_behavior.proto"}},
 		{name: "timestamp format", protoFiles: []string{"timestamp_format.proto"}},
 		{name: "bytes encoding", protoFiles: []string{"bytes_encoding.proto"}},
+		{name: "scoped encoding defaults", protoFiles: []string{"scoped_encoding.proto"}},
 		{name: "flatten", protoFiles: []string{"flatten.proto"}},
 		{name: "oneof discriminator", protoFiles: []string{"oneof_discriminator.proto"}},
+		{name: "custom json names", protoFiles: []string{"json_names.proto"}},
+		{name: "json naming policies", protoFiles: []string{"json_naming.proto"}},
+		{name: "free-form well-known types", protoFiles: []string{"dynamic_json.proto"}},
 		{name: "multi-word oneof name", protoFiles: []string{"multi_word_oneof.proto"}},
 		{name: "two un-annotated oneofs in one message", protoFiles: []string{"two_oneofs.proto"}},
 		{name: "un-annotated oneof with enum and timestamp variants", protoFiles: []string{"oneof_field_typing.proto"}},
 		{name: "flatten oneof unset arm guards child keys", protoFiles: []string{"flatten_oneof_unset.proto"}},
 		{name: "SSE streaming", protoFiles: []string{"sse.proto"}},
+		{name: "streamed responses", protoFiles: []string{"stream_response.proto"}},
+		{name: "result messages", protoFiles: []string{"response_statuses.proto"}},
 		{name: "empty request body", protoFiles: []string{"empty_request_body.proto"}},
 		{name: "record map collision", protoFiles: []string{"record_map_collision.proto"}},
+		{name: "multipart uploads", protoFiles: []string{"multipart_upload.proto"}},
+		{name: "raw body responses", protoFiles: []string{"raw_body.proto"}},
+		{name: "JSON query parameters", protoFiles: []string{"json_query.proto"}},
+		{name: "test fixtures", protoFiles: []string{"fixtures.proto"}, opts: "fixtures=true"},
+		{name: "request validation", protoFiles: []string{"request_validation.proto"}, opts: "validate_requests=true"},
+		{name: "visibility", protoFiles: []string{"visibility.proto"}},
+		{name: "base path parameters", protoFiles: []string{"base_path_params.proto"}},
+		{name: "schema fingerprint", protoFiles: []string{"schema_fingerprint.proto"}, opts: "schema_fingerprint=true"},
+		{name: "problem json", protoFiles: []string{"problem_json.proto"}},
+		{name: "deprecated fields", protoFiles: []string{"deprecated_fields.proto"}},
+		{name: "path wildcards", protoFiles: []string{"path_wildcard.proto"}},
+		{name: "error responses", protoFiles: []string{"error_responses.proto"}},
+		{name: "edition 2023", protoFiles: []string{"editions.proto"}},
+		{name: "proto2 syntax", protoFiles: []string{"proto2_syntax.proto"}},
 		{
 			name:             "reserved error-helper names",
 			protoFiles:       []string{"reserved_name.proto"},
@@ -121,10 +144,14 @@ func TestTSClientGenGoldenFiles(t *testing.T) {
 			}
 
 			outDir := t.TempDir()
+			pluginOpts := "paths=source_relative"
+			if tc.opts != "" {
+				pluginOpts += "," + tc.opts
+			}
 			args := []string{
 				"--plugin=protoc-gen-ts-client=" + pluginPath,
 				"--ts-client_out=" + outDir,
-				"--ts-client_opt=paths=source_relative",
+				"--ts-client_opt=" + pluginOpts,
 				"--proto_path=" + protoDir,
 				"--proto_path=" + filepath.Join(projectRoot, "proto"),
 			}
@@ -177,7 +204,121 @@ func TestTSClientGenGoldenFiles(t *testing.T) {
 	}
 }
 
-// generatedTSFiles returns the relative paths of every .ts file under dir, sorted.
+// formatMatrix lists every module= × target= combination the client generator
+// supports. Each combination's output lives under
+// testdata/golden_formats/<module>_<target>/, outside testdata/golden so the
+// browser/nodenext typecheck of the main tree does not pick it up.
+var formatMatrix = func() []struct{ module, target string } {
+	var combos []struct{ module, target string }
+	for _, module := range []string{"esm", "cjs", "both"} {
+		for _, target := range []string{"browser", "node", "isomorphic"} {
+			combos = append(combos, struct{ module, target string }{module, target})
+		}
+	}
+	return combos
+}()
+
+// TestTSClientGenFormatGoldenFiles generates the SSE fixture (unary and
+// streaming methods) under every module/target combination and compares the
+// emitted .ts/.mts/.cts/.d.ts files against testdata/golden_formats.
+//
+// To update golden files after intentional changes:
+//
+//	UPDATE_GOLDEN=1 go test -run TestTSClientGenFormatGoldenFiles
+func TestTSClientGenFormatGoldenFiles(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping golden file tests")
+	}
+
+	baseDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	projectRoot := filepath.Join(baseDir, "..", "..")
+	protoDir := filepath.Join(baseDir, "testdata", "proto")
+	goldenDir := filepath.Join(baseDir, "testdata", "golden_formats")
+
+	pluginPath := plugintest.Build(t, projectRoot, "protoc-gen-ts-client")
+	updateGolden := os.Getenv("UPDATE_GOLDEN") == "1"
+
+	for _, combo := range formatMatrix {
+		name := combo.module + "_" + combo.target
+		t.Run(name, func(t *testing.T) {
+			outDir := t.TempDir()
+			cmd := exec.Command("protoc",
+				"--plugin=protoc-gen-ts-client="+pluginPath,
+				"--ts-client_out="+outDir,
+				"--ts-client_opt=paths=source_relative,module="+combo.module+",target="+combo.target,
+				"--proto_path="+protoDir,
+				"--proto_path="+filepath.Join(projectRoot, "proto"),
+				"sse.proto",
+			)
+			cmd.Dir = protoDir
+			var stderr bytes.Buffer
+			cmd.Stderr = &stderr
+			if runErr := cmd.Run(); runErr != nil {
+				t.Fatalf("protoc failed: %v\nstderr: %s", runErr, stderr.String())
+			}
+
+			for _, rel := range generatedTSFiles(t, outDir) {
+				generatedContent, readErr := os.ReadFile(filepath.Join(outDir, rel))
+				if readErr != nil {
+					t.Fatalf("Failed to read generated file %s: %v", rel, readErr)
+				}
+				goldenPath := filepath.Join(goldenDir, name, rel)
+				if updateGolden {
+					updateGoldenFile(t, goldenPath, generatedContent)
+					continue
+				}
+				compareGoldenFile(t, rel, goldenPath, generatedContent)
+			}
+		})
+	}
+}
+
+// TestTSClientGenRejectsUnknownFormat asserts invalid module= and target=
+// values fail generation instead of silently falling back to the defaults.
+func TestTSClientGenRejectsUnknownFormat(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping plugin parameter tests")
+	}
+
+	baseDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	projectRoot := filepath.Join(baseDir, "..", "..")
+	protoDir := filepath.Join(baseDir, "testdata", "proto")
+	pluginPath := plugintest.Build(t, projectRoot, "protoc-gen-ts-client")
+
+	for opt, want := range map[string]string{
+		"module=umd":  `unsupported module format "umd"`,
+		"target=deno": `unsupported target "deno"`,
+	} {
+		t.Run(opt, func(t *testing.T) {
+			cmd := exec.Command("protoc",
+				"--plugin=protoc-gen-ts-client="+pluginPath,
+				"--ts-client_out="+t.TempDir(),
+				"--ts-client_opt=paths=source_relative,"+opt,
+				"--proto_path="+protoDir,
+				"--proto_path="+filepath.Join(projectRoot, "proto"),
+				"sse.proto",
+			)
+			cmd.Dir = protoDir
+			var stderr bytes.Buffer
+			cmd.Stderr = &stderr
+			if runErr := cmd.Run(); runErr == nil {
+				t.Fatalf("protoc succeeded with %s, want failure", opt)
+			}
+			if !strings.Contains(stderr.String(), want) {
+				t.Errorf("stderr %q does not contain %q", stderr.String(), want)
+			}
+		})
+	}
+}
+
+// generatedTSFiles returns the relative paths of every .ts, .mts, and .cts
+// file under dir, sorted.
 func generatedTSFiles(t *testing.T, dir string) []string {
 	t.Helper()
 	var files []string
@@ -185,7 +326,7 @@ func generatedTSFiles(t *testing.T, dir string) []string {
 		if err != nil {
 			return err
 		}
-		if !d.IsDir() && strings.HasSuffix(path, ".ts") {
+		if !d.IsDir() && isTSSource(path) {
 			rel, relErr := filepath.Rel(dir, path)
 			if relErr != nil {
 				return relErr
@@ -201,6 +342,10 @@ func generatedTSFiles(t *testing.T, dir string) []string {
 	return files
 }
 
+func isTSSource(path string) bool {
+	return strings.HasSuffix(path, ".ts") || strings.HasSuffix(path, ".mts") || strings.HasSuffix(path, ".cts")
+}
+
 // readGoldenConcat reads and concatenates the named golden files. In the modules
 // layout a message's interfaces and oneof union types live in the per-proto type
 // module while the client module imports them, so oneof-shape assertions read both.
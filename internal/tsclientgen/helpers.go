@@ -1,6 +1,9 @@
 package tsclientgen
 
-import "github.com/SebastienMelki/sebuf/internal/tscommon"
+import (
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/internal/tscommon"
+)
 
 // snakeToLowerCamel converts "user_id" to "userId".
 func snakeToLowerCamel(s string) string {
@@ -11,3 +14,21 @@ func snakeToLowerCamel(s string) string {
 func headerNameToPropertyName(headerName string) string {
 	return tscommon.HeaderNameToPropertyName(headerName)
 }
+
+// headerPropertyType is the type of the option property setting a header: a
+// list of values for a multiple header.
+func headerPropertyType(header *sebufhttp.Header) string {
+	if header.GetMultiple() {
+		return "string[]"
+	}
+	return "string"
+}
+
+// headerValueExpr is the expression sending the option property source as a
+// header value, joining the values of a multiple header into one line.
+func headerValueExpr(header *sebufhttp.Header, source string) string {
+	if header.GetMultiple() {
+		return source + `.join(", ")`
+	}
+	return source
+}
@@ -120,7 +120,9 @@ func inProcessFixtures() []inProcessFixture {
 		{name: "bytes encoding", protoFiles: []string{"bytes_encoding.proto"}},
 		{name: "flatten", protoFiles: []string{"flatten.proto"}},
 		{name: "oneof discriminator", protoFiles: []string{"oneof_discriminator.proto"}},
+		{name: "custom json names", protoFiles: []string{"json_names.proto"}},
 		{name: "SSE streaming", protoFiles: []string{"sse.proto"}},
+		{name: "multipart uploads", protoFiles: []string{"multipart_upload.proto"}},
 		{name: "empty request body", protoFiles: []string{"empty_request_body.proto"}},
 		{name: "reserved error-helper names", protoFiles: []string{"reserved_name.proto"}},
 		{
@@ -182,6 +184,7 @@ func buildInProcessPluginParams(
 	args := []string{
 		"--descriptor_set_out=" + descPath,
 		"--include_imports",
+		"--include_source_info",
 		"--proto_path=" + protoDir,
 		"--proto_path=" + filepath.Join(projectRoot, "proto"),
 	}
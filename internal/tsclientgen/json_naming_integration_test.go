@@ -0,0 +1,174 @@
+package tsclientgen
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestJSONNamingRoundTripIntegration serves json_naming.proto with the
+// generated Go server and calls it with the generated TypeScript client. The
+// client sends snake_case bodies typed by its interfaces, the server echoes
+// them back through its json_naming MarshalJSON, and the JSON the client
+// receives must use the keys its interfaces declare.
+func TestJSONNamingRoundTripIntegration(t *testing.T) {
+	node := plugintest.TypeStrippingNode()
+	if node == "" {
+		t.Skip("node with --experimental-strip-types not found, skipping integration test")
+	}
+
+	tsDir := t.TempDir()
+	tempDir := plugintest.Generate(t, plugintest.Module{
+		Path:     "json_naming_test",
+		Protos:   map[string]string{"json_naming.proto": ""},
+		ProtoDir: filepath.Join("testdata", "proto"),
+		Mappings: []string{"Mjson_naming.proto=json_naming_test/gen;gen"},
+		Plugins:  []plugintest.Plugin{{Name: "go-http"}, {Name: "ts-client", Out: tsDir}},
+		Files:    map[string]string{"main.go": jsonNamingServerProgram},
+	})
+
+	plugintest.GoCommand(t, tempDir, "build", "-o", "server", ".")
+
+	serverCmd := exec.Command(filepath.Join(tempDir, "server"))
+	serverCmd.Stderr = os.Stderr
+	serverOut, err := serverCmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = serverCmd.Start(); err != nil {
+		t.Fatalf("Go server failed to start: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = serverCmd.Process.Kill()
+		_ = serverCmd.Wait()
+	})
+	baseURL, err := bufio.NewReader(serverOut).ReadString('\n')
+	if err != nil {
+		t.Fatalf("Go server did not report its address: %v", err)
+	}
+
+	plugintest.WriteFiles(t, tsDir, map[string]string{"package.json": `{"type": "module"}`, "main.ts": jsonNamingClientProgram})
+	tsCmd := exec.Command(node, "--experimental-strip-types", "--no-warnings", "main.ts", strings.TrimSpace(baseURL))
+	tsCmd.Dir = tsDir
+	tsCmd.Stderr = os.Stderr
+	tsOut, err := tsCmd.Output()
+	if err != nil {
+		t.Fatalf("TypeScript client program failed: %v", err)
+	}
+
+	var got map[string]any
+	if err = json.Unmarshal(tsOut, &got); err != nil {
+		t.Fatalf("Failed to parse TypeScript output: %v\n%s", err, string(tsOut))
+	}
+	var want map[string]any
+	if err = json.Unmarshal([]byte(jsonNamingWant), &want); err != nil {
+		t.Fatal(err)
+	}
+	for _, call := range []string{"createOrder", "getCatalog"} {
+		if !reflect.DeepEqual(got[call], want[call]) {
+			gotJSON, _ := json.MarshalIndent(got[call], "", "  ")
+			wantJSON, _ := json.MarshalIndent(want[call], "", "  ")
+			t.Errorf("%s returned\n%s\nwant\n%s", call, gotJSON, wantJSON)
+		}
+	}
+}
+
+// jsonNamingServerProgram serves OrderService on a free port and prints its
+// base URL. CreateOrder echoes the request into the order it returns.
+const jsonNamingServerProgram = `package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	gen "json_naming_test/gen"
+)
+
+type orderServer struct{}
+
+func (orderServer) CreateOrder(_ context.Context, req *gen.CreateOrderRequest) (*gen.Order, error) {
+	order := &gen.Order{
+		OrderId:         "order-" + req.GetCustomerId(),
+		CustomerId:      req.GetCustomerId(),
+		LineItems:       req.GetLineItems(),
+		ItemsBySku:      map[string]*gen.LineItem{},
+		ShippingAddress: req.GetShippingAddress(),
+		GiftNote:        req.GetGiftNote(),
+		Totals:          &gen.OrderTotals{TaxCents: 7},
+	}
+	for _, item := range req.GetLineItems() {
+		order.ItemsBySku[item.GetProductSku()] = item
+		order.Totals.GrandTotalCents += 100 * item.GetUnitCount()
+	}
+	return order, nil
+}
+
+func (orderServer) GetOrder(_ context.Context, req *gen.GetOrderRequest) (*gen.Order, error) {
+	return &gen.Order{OrderId: req.GetOrderId()}, nil
+}
+
+func (orderServer) GetCatalog(context.Context, *gen.GetCatalogRequest) (*gen.Catalog, error) {
+	return &gen.Catalog{
+		CatalogName: "spring",
+		SkusByCategory: map[string]*gen.SkuList{
+			"tools": {ProductSkus: []string{"hammer", "saw"}},
+		},
+	}, nil
+}
+
+func main() {
+	mux := http.NewServeMux()
+	if err := gen.RegisterOrderServiceServer(orderServer{}, gen.WithMux(mux)); err != nil {
+		panic(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("http://" + listener.Addr().String())
+	panic(http.Serve(listener, mux))
+}
+`
+
+// jsonNamingClientProgram calls the server at the URL given as its argument and
+// prints the responses.
+const jsonNamingClientProgram = `import { OrderServiceClient } from "./json_naming_client.ts";
+
+const client = new OrderServiceClient(process.argv[2]);
+const createOrder = await client.createOrder({
+  customer_id: "c-1",
+  line_items: [{ product_sku: "hammer", unit_count: 2 }],
+  shipping_address: { streetLine: "1 Main St", postalCode: "12345", country: "NL" },
+  giftMessage: "enjoy",
+});
+const getCatalog = await client.getCatalog({});
+console.log(JSON.stringify({ createOrder, getCatalog }));
+`
+
+// jsonNamingWant is the JSON the client must receive: snake_case keys, the
+// explicit json_name, a camelCase shipping address and prefixed flattened totals.
+const jsonNamingWant = `{
+  "createOrder": {
+    "order_id": "order-c-1",
+    "customer_id": "c-1",
+    "line_items": [{"product_sku": "hammer", "unit_count": 2}],
+    "items_by_sku": {"hammer": {"product_sku": "hammer", "unit_count": 2}},
+    "shipping_address": {"streetLine": "1 Main St", "postalCode": "12345", "country": "NL"},
+    "giftMessage": "enjoy",
+    "total_tax_cents": 7,
+    "total_grand_total_cents": 200
+  },
+  "getCatalog": {
+    "catalog_name": "spring",
+    "skus_by_category": {"tools": ["hammer", "saw"]}
+  }
+}`
@@ -2,55 +2,146 @@ package tsclientgen
 
 import (
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"github.com/SebastienMelki/sebuf/internal/tscommon"
 )
 
 // generateModules emits shared canonical type modules and an errors module
-// (via tscommon), plus one slimmed client module per service file that imports
-// its request/response types and the error helpers, then a per-package barrel
-// (index.ts) re-exporting each package directory's modules.
+// (via tscommon), the shared fetch module for the node and isomorphic targets,
+// the shared response cache module when a client has GET methods, the shared
+// response metadata module when a client has unary methods,
+// plus one slimmed client module per service file that imports its
+// request/response types and the error helpers (and, with fixtures enabled,
+// its test fixtures module), then a per-package barrel
+// (index.ts) re-exporting each package directory's modules. Runtime modules
+// are emitted once per variant of the configured module format.
 func (g *Generator) generateModules() error {
-	moduleFiles, err := tscommon.EmitSharedModules(g.plugin)
+	modules, err := tscommon.EmitSharedModules(g.plugin, g.module)
 	if err != nil {
 		return err
 	}
+	if g.target.usesFetchModule() {
+		for _, variant := range g.module.Variants() {
+			g.emitFetchModule(variant)
+		}
+	}
+	if g.anyClientCachesResponses() {
+		for _, variant := range g.module.Variants() {
+			g.emitResponseCacheModule(variant)
+		}
+	}
+	if g.anyClientReportsResponses() {
+		for _, variant := range g.module.Variants() {
+			g.emitResponseMetadataModule(variant)
+		}
+	}
 	for _, file := range g.plugin.Files {
 		if !file.Generate || len(file.Services) == 0 {
 			continue
 		}
-		moduleFiles = append(moduleFiles, g.emitClientModule(file))
+		var module tscommon.EmittedModule
+		for _, variant := range g.module.Variants() {
+			module = g.emitClientModule(file, variant)
+			if g.fixtures {
+				g.emitFixturesModule(file, variant)
+			}
+		}
+		modules = append(modules, module)
 	}
-	tscommon.EmitPackageBarrels(g.plugin, moduleFiles)
+	tscommon.EmitPackageBarrels(g.plugin, g.module, modules)
 	return nil
 }
 
-// emitClientModule writes a service file's client class(es), importing the
-// request/response types from their canonical modules and the shared error
-// helpers. It returns the output-relative filename it emitted (ending in
-// ".ts"), so the caller can fold it into the per-package barrel.
-func (g *Generator) emitClientModule(file *protogen.File) string {
+// emitClientModule writes a service file's client class(es) in one module
+// format variant, followed by the validators of the requests checked before
+// fetch (validate_requests=true) and the readers of streamed list and raw body
+// responses, importing the request/response types from their canonical
+// modules and the shared error helpers. The module exports its runtime values
+// as a default (ESM) or assigns its namespace with `export =` (CommonJS). It
+// returns the module it emitted, so the caller can fold it into the
+// per-package barrel.
+func (g *Generator) emitClientModule(file *protogen.File, variant tscommon.ModuleVariant) tscommon.EmittedModule {
 	module := file.GeneratedFilenamePrefix + "_client"
-	gf := g.plugin.NewGeneratedFile(module+".ts", "")
+	gf := g.plugin.NewGeneratedFile(module+variant.SourceExt, "")
 	tracker := tscommon.NewImportTracker()
-	g.ctx = &tscommon.EmitContext{SelfModule: module, Imports: tracker}
+	tracker.Reserve(tscommon.ModuleNamespace(module))
+	if g.target.usesFetchModule() {
+		tracker.Reserve(fetchHelperNames()...)
+	}
+	if fileCachesResponses(file) {
+		tracker.Reserve(responseCacheName, responseCacheOptionsName)
+	}
+	if fileReportsResponses(file) {
+		tracker.Reserve(responseMetadataName, reportResponseMetadataName)
+	}
+	streams := fileStreamsResponses(file)
+	if streams {
+		tracker.Reserve(readJSONArrayItemsName)
+	}
+	results := fileResultMessages(file)
+	for _, msg := range results {
+		tracker.Reserve(resultTypeName(msg))
+	}
+	errorBodies := g.fileErrorResponseMessages(file)
+	for _, msg := range errorBodies {
+		tracker.Reserve(errorResponseClassName(msg))
+	}
+	rawBodies := fileReturnsRawBodies(file)
+	if rawBodies {
+		tracker.Reserve(rawBodyTypeName, readRawBodyFuncName)
+	}
+	jsonQueryParams := g.fileSendsJSONQueryParams(file)
+	if jsonQueryParams {
+		tracker.Reserve(encodeJSONQueryParamFuncName)
+	}
+	validated := g.collectValidatedMessages(file)
+	validatedNames := make(map[protoreflect.FullName]bool, len(validated))
+	for _, msg := range validated {
+		tracker.Reserve(validatorFuncName(msg))
+		validatedNames[msg.Desc.FullName()] = true
+	}
+	g.ctx = &tscommon.EmitContext{SelfModule: module, Imports: tracker, ImportExt: variant.ImportExt}
 	defer func() { g.ctx = nil }()
 
 	var body []string
 	bp := printer(tscommon.BufferedPrinter(&body))
+	for _, msg := range results {
+		g.generateResultType(bp, msg)
+	}
+	for _, msg := range errorBodies {
+		g.generateErrorResponseClass(bp, msg)
+	}
+	if rawBodies {
+		generateRawBodyType(bp)
+	}
 	for _, service := range file.Services {
 		_ = g.generateServiceClient(bp, service)
 	}
+	for _, msg := range validated {
+		g.generateRequestValidator(bp, msg, validatedNames)
+	}
+	if streams {
+		generateReadJSONArrayItems(bp)
+	}
+	if rawBodies {
+		g.generateReadRawBody(bp)
+	}
+	if jsonQueryParams {
+		generateEncodeJSONQueryParam(bp)
+	}
 	// Import only the error helpers actually referenced in the body.
 	g.ctx.NeedErrors(tscommon.UsedErrorSymbols(body)...)
+	g.needFetchModule()
+	g.needResponseCacheModule(file)
+	g.needResponseMetadataModule(file)
 
 	dp := tscommon.DirectPrinter(gf)
 	dp("// Code generated by protoc-gen-ts-client. DO NOT EDIT.")
 	dp("// source: %s", file.Desc.Path())
 	dp("")
 	tracker.Render(dp)
-	for _, line := range body {
-		gf.P(line)
-	}
-	return module + ".ts"
+	tscommon.EmitModuleBody(gf, variant, module, body)
+	exports := tscommon.ScanExports(body)
+	return tscommon.EmittedModule{Path: module, Exports: &exports}
 }
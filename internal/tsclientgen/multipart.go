@@ -0,0 +1,191 @@
+package tsclientgen
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/tscommon"
+)
+
+// multipartMethodSuffix names the multipart/form-data variant of a method
+// annotated with accept_multipart (uploadDocument -> uploadDocumentMultipart).
+const multipartMethodSuffix = "Multipart"
+
+// uploadChunkSize is the size of the chunks a multipart upload with progress
+// reporting is streamed in.
+const uploadChunkSize = 64 << 10
+
+// emitsMultipart reports whether a method gets a multipart/form-data variant.
+// The variant takes DOM File and Blob values and builds a DOM FormData, so it is
+// only emitted for the browser target, whose output may depend on lib.dom.
+func (g *Generator) emitsMultipart(method *protogen.Method) bool {
+	if g.target != TargetBrowser {
+		return false
+	}
+	config := annotations.GetMethodHTTPConfig(method)
+	return config != nil && config.AcceptMultipart && !config.Stream
+}
+
+// serviceEmitsMultipart reports whether any method of the service gets a
+// multipart/form-data variant, and so needs the appendFormValue helper.
+func (g *Generator) serviceEmitsMultipart(service *protogen.Service) bool {
+	for _, method := range service.Methods {
+		if g.emitsMultipart(method) {
+			return true
+		}
+	}
+	return false
+}
+
+// multipartInputType returns the request type of a multipart variant: the
+// request with its bytes fields taking File or Blob values instead of base64
+// strings.
+func (g *Generator) multipartInputType(method *protogen.Method, inputType string) string {
+	var names, overrides []string
+	for _, field := range method.Input.Fields {
+		if !annotations.IsMultipartFileField(field) {
+			continue
+		}
+		jsonName := annotations.JSONFieldName(field)
+		names = append(names, `"`+jsonName+`"`)
+		fileType := "File | Blob"
+		if field.Desc.IsList() {
+			fileType = "(File | Blob)[]"
+		}
+		overrides = append(overrides, tscommon.PropertyKey(jsonName)+": "+fileType)
+	}
+	if len(names) == 0 {
+		return inputType
+	}
+	return "Omit<" + inputType + ", " + strings.Join(names, " | ") + "> & { " + strings.Join(overrides, "; ") + " }"
+}
+
+// generateMultipartRPCMethod generates the multipart/form-data variant of a
+// method annotated with accept_multipart. Each body field becomes a form part
+// named by its JSON name: files as file parts, nested messages with dotted keys.
+func (g *Generator) generateMultipartRPCMethod(p printer, service *protogen.Service, method *protogen.Method) {
+	cfg := g.buildRPCMethodConfig(service, method)
+	inputType := g.multipartInputType(method, g.ctx.RefMessage(method.Input))
+	outputType := g.resolveOutputType(method)
+	tsMethodName := annotations.LowerFirst(cfg.methodName) + multipartMethodSuffix
+
+	p("  /**")
+	p("   * Like %s, but sends the request as multipart/form-data with its", annotations.LowerFirst(cfg.methodName))
+	p("   * bytes fields uploaded as files.")
+	if annotations.IsMethodDeprecated(method) {
+		p("   * @deprecated")
+	}
+	p("   */")
+	p("  async %s(req: %s, options?: %sCallOptions): Promise<%s> {",
+		tsMethodName, inputType, cfg.serviceName, outputType)
+
+	// Build URL with path params
+	g.generateURLBuilding(p, cfg)
+
+	// Build headers; fetch sets the multipart Content-Type with its boundary
+	g.generateHeaderMerging(p, service, method, "")
+	g.generateHeaderFieldParams(p, cfg)
+
+	// Build the form without the fields sent elsewhere
+	source := "req"
+	if len(cfg.bodyExcluded) > 0 {
+		source = "body"
+		p("    const body: Record<string, unknown> = { ...req };")
+		for _, field := range cfg.bodyExcluded {
+			p("    delete %s;", tscommon.PropertyAccess("body", annotations.JSONFieldName(field)))
+		}
+	}
+	p("    const form = new FormData();")
+	p("    for (const [key, value] of Object.entries(%s)) {", source)
+	p("      this.appendFormValue(form, key, value);")
+	p("    }")
+	p("")
+
+	p("    const resp = await this.sendForm(url, {")
+	p(`      method: "%s",`, cfg.httpMethod)
+	p("      headers,")
+	p("      signal: options?.signal,")
+	p("    }, form, options?.onUploadProgress);")
+	p("")
+
+	g.generateResponseHandling(p, cfg, method)
+
+	p("  }")
+	p("")
+}
+
+// generateAppendFormValue generates the private helper adding a value to a
+// multipart form: blobs as file parts, arrays as repeated parts, and nested
+// objects under dotted keys, matching the server's form binding.
+func (g *Generator) generateAppendFormValue(p printer) {
+	p("  private appendFormValue(form: FormData, key: string, value: unknown): void {")
+	p("    if (value === undefined || value === null) return;")
+	p("    if (value instanceof Blob) {")
+	p("      form.append(key, value);")
+	p("    } else if (Array.isArray(value)) {")
+	p("      value.forEach(v => this.appendFormValue(form, key, v));")
+	p(`    } else if (typeof value === "object") {`)
+	p("      for (const [k, v] of Object.entries(value)) {")
+	p("        this.appendFormValue(form, `${key}.${k}`, v);")
+	p("      }")
+	p("    } else {")
+	p("      form.append(key, String(value));")
+	p("    }")
+	p("  }")
+	p("")
+}
+
+// generateSendForm generates the private helper sending a multipart form. With
+// an onUploadProgress callback, the form is encoded up front and, on runtimes
+// that stream request bodies, sent as a stream whose chunks are reported as
+// fetch reads them. Other runtimes send it whole and report it once sent.
+func (g *Generator) generateSendForm(p printer) {
+	p("  private async sendForm(")
+	p("    url: string,")
+	p("    init: RequestInit & { headers: Record<string, string> },")
+	p("    form: FormData,")
+	p("    onUploadProgress?: (loaded: number, total: number) => void,")
+	p("  ): Promise<Response> {")
+	p("    if (!onUploadProgress) {")
+	p("      return this.fetchFn(url, { ...init, body: form });")
+	p("    }")
+	p("    const encoded = new Response(form);")
+	p(`    const headers = { ...init.headers, "Content-Type": encoded.headers.get("Content-Type") ?? "" };`)
+	p("    const bytes = new Uint8Array(await encoded.arrayBuffer());")
+	p("    const total = bytes.length;")
+	p("")
+	p("    // Runtimes streaming request bodies read duplex and give a stream no Content-Type")
+	p("    let duplexRead = false;")
+	p(`    const probe = new Request("http://localhost/", {`)
+	p(`      method: "POST",`)
+	p("      body: new ReadableStream(),")
+	p("      get duplex() {")
+	p("        duplexRead = true;")
+	p(`        return "half";`)
+	p("      },")
+	p("    } as RequestInit);")
+	p(`    if (!duplexRead || probe.headers.has("Content-Type")) {`)
+	p("      const resp = await this.fetchFn(url, { ...init, headers, body: bytes });")
+	p("      onUploadProgress(total, total);")
+	p("      return resp;")
+	p("    }")
+	p("")
+	p("    let loaded = 0;")
+	p("    const body = new ReadableStream<Uint8Array>({")
+	p("      pull(controller) {")
+	p("        if (loaded === total) {")
+	p("          controller.close();")
+	p("          return;")
+	p("        }")
+	p("        const chunk = bytes.subarray(loaded, loaded + %d);", uploadChunkSize)
+	p("        controller.enqueue(chunk);")
+	p("        loaded += chunk.length;")
+	p("        onUploadProgress(loaded, total);")
+	p("      },")
+	p("    });")
+	p(`    return this.fetchFn(url, { ...init, headers, body, duplex: "half" } as RequestInit);`)
+	p("  }")
+	p("")
+}
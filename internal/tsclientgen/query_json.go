@@ -0,0 +1,53 @@
+package tsclientgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// encodeJSONQueryParamFuncName is the module-level encoder of JSON_BASE64URL
+// query parameters.
+const encodeJSONQueryParamFuncName = "encodeJSONQueryParam"
+
+// fileSendsJSONQueryParams reports whether a method of file sends a query
+// parameter encoded as JSON_BASE64URL, whose client module then carries
+// encodeJSONQueryParam.
+func (g *Generator) fileSendsJSONQueryParams(file *protogen.File) bool {
+	for _, service := range file.Services {
+		for _, method := range service.Methods {
+			for _, qp := range g.buildRPCMethodConfig(service, method).queryParams {
+				if qp.JSON {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// generateJSONQueryParam generates the line of a URL builder setting the
+// JSON_BASE64URL query parameter qp from value, when it holds a message or a
+// non-empty list of them.
+func generateJSONQueryParam(p printer, qp annotations.QueryParam, value string) {
+	if qp.Field.Desc.IsList() {
+		p("    if (%s && %s.length > 0) search.set(%q, %s(%s));",
+			value, value, qp.ParamName, encodeJSONQueryParamFuncName, value)
+		return
+	}
+	p("    if (%s != null) search.set(%q, %s(%s));", value, qp.ParamName, encodeJSONQueryParamFuncName, value)
+}
+
+// generateEncodeJSONQueryParam generates the module-level encoder of
+// JSON_BASE64URL query parameters: the JSON of the value, as sent in a body,
+// in unpadded base64url.
+func generateEncodeJSONQueryParam(p printer) {
+	p("/** Encodes a JSON_BASE64URL query parameter: its JSON in unpadded base64url. */")
+	p("function %s(value: unknown): string {", encodeJSONQueryParamFuncName)
+	p("  const bytes = new TextEncoder().encode(JSON.stringify(value));")
+	p(`  let binary = "";`)
+	p("  for (const byte of bytes) binary += String.fromCharCode(byte);")
+	p(`  return btoa(binary).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "");`)
+	p("}")
+	p("")
+}
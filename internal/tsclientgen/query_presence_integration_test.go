@@ -0,0 +1,220 @@
+package tsclientgen
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// queryPresenceValues lists, for each field of ListProductsRequest, the JSON
+// values the differential test sends it with, its zero value first.
+var queryPresenceValues = []struct {
+	field  string
+	values []string
+}{
+	{"q", []string{`""`, `"shoes"`, `"a b&c=d/é"`}},
+	{"inStock", []string{`false`, `true`}},
+	{"page", []string{`0`, `3`, `-1`}},
+	{"sinceId", []string{`"0"`, `"9007199254740993"`, `"-5"`}},
+	{"maxId", []string{`0`, `42`}},
+	{"minRating", []string{`0`, `4.5`, `-0.25`}},
+	{"weight", []string{`0`, `1.5`}},
+	{"region", []string{`"REGION_UNSPECIFIED"`, `"REGION_EU"`}},
+	{"minPrice", []string{`0`, `10`}},
+	{"cursor", []string{`""`, `"abc"`}},
+	{"featured", []string{`false`, `true`}},
+	{"origin", []string{`"REGION_UNSPECIFIED"`, `"REGION_US"`}},
+	{"after", []string{`"0"`, `"7"`}},
+	{"tags", []string{`[]`, `["x", "y z"]`}},
+	{"sizes", []string{`[]`, `[0, 2]`}},
+	{"regions", []string{`[]`, `["REGION_UNSPECIFIED", "REGION_EU"]`}},
+}
+
+// queryPresenceWant holds the query string expected of some of the cases,
+// named <field>/<value index>, pinning the rule both clients follow.
+var queryPresenceWant = map[string]string{
+	"empty":      "",
+	"q/0":        "",
+	"inStock/0":  "",
+	"page/0":     "",
+	"sinceId/0":  "",
+	"region/0":   "",
+	"tags/0":     "",
+	"q/2":        "q=a+b%26c%3Dd%2F%C3%A9",
+	"minPrice/0": "min_price=0",
+	"cursor/0":   "cursor=",
+	"featured/0": "featured=false",
+	"origin/0":   "origin=REGION_UNSPECIFIED",
+	"after/0":    "after=0",
+	"sizes/1":    "size=0&size=2",
+}
+
+// TestQueryPresenceParityIntegration generates the Go and TypeScript clients
+// of queryPresenceProto and builds the URL of ListProducts with both for every
+// value of queryPresenceValues, each sent alone, and for all of them at once.
+// The query strings must be byte-identical, and match queryPresenceWant. The
+// values avoid '*' and '~', which URLSearchParams and url.Values escape
+// differently, to the same effect. The TypeScript side needs node with
+// --experimental-strip-types and is skipped without it.
+func TestQueryPresenceParityIntegration(t *testing.T) {
+	if plugintest.TypeStrippingNode() == "" {
+		t.Skip("node with --experimental-strip-types not found, skipping integration test")
+	}
+
+	cases := map[string]json.RawMessage{"empty": json.RawMessage("{}")}
+	var all []string
+	for _, field := range queryPresenceValues {
+		for i, value := range field.values {
+			cases[field.field+"/"+strconv.Itoa(i)] = json.RawMessage(`{"` + field.field + `": ` + value + `}`)
+		}
+		all = append(all, `"`+field.field+`": `+field.values[len(field.values)-1])
+	}
+	cases["all"] = json.RawMessage("{" + strings.Join(all, ", ") + "}")
+	casesJSON, err := json.Marshal(cases)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tsDir := t.TempDir()
+	tempDir := plugintest.Generate(t, plugintest.Module{
+		Path:    "query_presence_test",
+		Protos:  map[string]string{"catalog.proto": queryPresenceProto},
+		Plugins: []plugintest.Plugin{{Name: "go-client"}, {Name: "ts-client", Out: tsDir}},
+		Files: map[string]string{
+			"main.go":    queryPresenceGoProgram,
+			"cases.json": string(casesJSON),
+		},
+	})
+
+	goCmd := exec.Command("go", "run", ".")
+	goCmd.Dir = tempDir
+	goCmd.Stderr = os.Stderr
+	goOut, goErr := goCmd.Output()
+	if goErr != nil {
+		t.Fatalf("Go URL program failed: %v", goErr)
+	}
+
+	tsOut := plugintest.RunNode(t, tsDir, map[string]string{
+		"main.ts":    queryPresenceTSProgram,
+		"cases.json": string(casesJSON),
+	})
+
+	var goURLs, tsURLs map[string]string
+	if err = json.Unmarshal(goOut, &goURLs); err != nil {
+		t.Fatalf("Failed to parse Go output: %v\n%s", err, string(goOut))
+	}
+	if err = json.Unmarshal(tsOut, &tsURLs); err != nil {
+		t.Fatalf("Failed to parse TypeScript output: %v\n%s", err, string(tsOut))
+	}
+	for name := range cases {
+		goURL, tsURL := goURLs[name], tsURLs[name]
+		if goURL != tsURL {
+			t.Errorf("%s: Go client builds %q, TypeScript client %q", name, goURL, tsURL)
+		}
+		want, ok := queryPresenceWant[name]
+		if !ok {
+			continue
+		}
+		if want != "" {
+			want = "?" + want
+		}
+		if goURL != "/products"+want {
+			t.Errorf("%s: clients build %q, want %q", name, goURL, "/products"+want)
+		}
+	}
+}
+
+const queryPresenceProto = `syntax = "proto3";
+package test.querypresence;
+option go_package = "query_presence_test/gen;gen";
+import "sebuf/http/annotations.proto";
+
+enum Region {
+  REGION_UNSPECIFIED = 0;
+  REGION_EU = 1;
+  REGION_US = 2;
+}
+
+service CatalogService {
+  rpc ListProducts(ListProductsRequest) returns (ListProductsResponse) {
+    option (sebuf.http.config) = { path: "/products" method: HTTP_METHOD_GET };
+  }
+}
+
+message ListProductsRequest {
+  string q = 1 [(sebuf.http.query) = { name: "q" }];
+  bool in_stock = 2 [(sebuf.http.query) = { name: "in_stock" }];
+  int32 page = 3 [(sebuf.http.query) = { name: "page" }];
+  int64 since_id = 4 [(sebuf.http.query) = { name: "since_id" }];
+  uint64 max_id = 5 [(sebuf.http.query) = { name: "max_id" }, (sebuf.http.int64_encoding) = INT64_ENCODING_NUMBER];
+  double min_rating = 6 [(sebuf.http.query) = { name: "min_rating" }];
+  float weight = 7 [(sebuf.http.query) = { name: "weight" }];
+  Region region = 8 [(sebuf.http.query) = { name: "region" }];
+  optional int32 min_price = 9 [(sebuf.http.query) = { name: "min_price" }];
+  optional string cursor = 10 [(sebuf.http.query) = { name: "cursor" }];
+  optional bool featured = 11 [(sebuf.http.query) = { name: "featured" }];
+  optional Region origin = 12 [(sebuf.http.query) = { name: "origin" }];
+  optional int64 after = 13 [(sebuf.http.query) = { name: "after" }];
+  repeated string tags = 14 [(sebuf.http.query) = { name: "tag" }];
+  repeated int32 sizes = 15 [(sebuf.http.query) = { name: "size" }];
+  repeated Region regions = 16 [(sebuf.http.query) = { name: "regions" }];
+}
+
+message ListProductsResponse {
+  repeated string ids = 1;
+}
+`
+
+// queryPresenceGoProgram prints the URL the Go client builds for every case.
+const queryPresenceGoProgram = `package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	gen "query_presence_test/gen"
+)
+
+func main() {
+	var cases map[string]json.RawMessage
+	data, err := os.ReadFile("cases.json")
+	if err != nil {
+		panic(err)
+	}
+	if err = json.Unmarshal(data, &cases); err != nil {
+		panic(err)
+	}
+	urls := make(map[string]string, len(cases))
+	for name, body := range cases {
+		req := &gen.ListProductsRequest{}
+		if err = protojson.Unmarshal(body, req); err != nil {
+			panic(name + ": " + err.Error())
+		}
+		urls[name] = gen.CatalogServiceListProductsURL(req)
+	}
+	if err = json.NewEncoder(os.Stdout).Encode(urls); err != nil {
+		panic(err)
+	}
+}
+`
+
+// queryPresenceTSProgram prints the URL the TypeScript client builds for
+// every case.
+const queryPresenceTSProgram = `import { readFileSync } from "node:fs";
+import { CatalogServiceClient } from "./catalog_client.ts";
+import type { ListProductsRequest } from "./catalog.ts";
+
+const cases: Record<string, ListProductsRequest> = JSON.parse(readFileSync("cases.json", "utf8"));
+const urls: Record<string, string> = {};
+for (const [name, req] of Object.entries(cases)) {
+  urls[name] = CatalogServiceClient.listProductsUrl(req);
+}
+process.stdout.write(JSON.stringify(urls));
+`
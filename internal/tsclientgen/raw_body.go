@@ -0,0 +1,83 @@
+package tsclientgen
+
+import (
+	"slices"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+)
+
+// Module-level names of a client module whose methods return raw bodies.
+const (
+	rawBodyTypeName     = "RawBody"
+	readRawBodyFuncName = "readRawBody"
+)
+
+// fileReturnsRawBodies reports whether a method of file returns a raw_body
+// message, whose client module then carries RawBody and readRawBody.
+func fileReturnsRawBodies(file *protogen.File) bool {
+	for _, service := range file.Services {
+		if serviceReturnsRawBodies(service) {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceReturnsRawBodies reports whether a method of service returns a
+// raw_body message, so its call options take onDownloadProgress.
+func serviceReturnsRawBodies(service *protogen.Service) bool {
+	return slices.ContainsFunc(service.Methods, annotations.IsRawBodyResponse)
+}
+
+// generateRawBodyType generates the type raw_body methods resolve to.
+func generateRawBodyType(p printer) {
+	p("/** The body of a raw_body response, as sent, with its Content-Type. */")
+	p("export interface %s {", rawBodyTypeName)
+	p("  data: ArrayBuffer;")
+	p("  contentType: string;")
+	p("}")
+	p("")
+}
+
+// generateReadRawBody generates the module-level reader of raw bodies. It reads
+// the body chunk by chunk, reporting the bytes read so far and the
+// Content-Length, when the server sent one, to onDownloadProgress.
+func (g *Generator) generateReadRawBody(p printer) {
+	_, responseType := g.fetchTypes()
+	p("async function %s(", readRawBodyFuncName)
+	p("  resp: %s,", responseType)
+	p("  onDownloadProgress?: (loaded: number, total: number | undefined) => void,")
+	p("): Promise<%s> {", rawBodyTypeName)
+	p("  if (!resp.body) {")
+	p(`    throw new Error("the response body cannot be read as a stream");`)
+	p("  }")
+	p(`  const length = resp.headers.get("Content-Length");`)
+	p("  const total = length === null ? undefined : Number(length);")
+	p("  const chunks: Uint8Array[] = [];")
+	p("  let loaded = 0;")
+	p("  const reader = resp.body.getReader();")
+	p("  try {")
+	p("    for (;;) {")
+	p("      const { done, value } = await reader.read();")
+	p("      if (done) break;")
+	p("      if (!value) continue;")
+	p("      chunks.push(value);")
+	p("      loaded += value.length;")
+	p("      onDownloadProgress?.(loaded, total);")
+	p("    }")
+	p("  } finally {")
+	p("    reader.releaseLock();")
+	p("  }")
+	p("  const data = new Uint8Array(loaded);")
+	p("  let offset = 0;")
+	p("  for (const chunk of chunks) {")
+	p("    data.set(chunk, offset);")
+	p("    offset += chunk.length;")
+	p("  }")
+	p(`  const contentType = resp.headers.get("Content-Type") ?? %q;`, annotations.DefaultRawBodyContentType)
+	p("  return { data: data.buffer, contentType };")
+	p("}")
+	p("")
+}
@@ -0,0 +1,222 @@
+package tsclientgen
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// requestValidationPayloads are the CreateAccount bodies every side validates.
+// All but "valid" break at least one rule of request_validation.proto.
+var requestValidationPayloads = map[string]string{
+	"valid": `{"username": "ann_01", "displayName": "Ann", "age": 30, "creditLimit": "100", "score": 0.5,
+		"tags": ["a"], "address": {"city": "Paris", "postalCode": "75001"}}`,
+	"short_username": `{"username": "an", "displayName": "Ann", "age": 30, "creditLimit": "100", "score": 0.5,
+		"tags": ["a"], "address": {"city": "Paris", "postalCode": "75001"}}`,
+	"nested_address": `{"username": "ann_01", "displayName": "Ann", "age": 30, "creditLimit": "100", "score": 0.5,
+		"tags": ["a"], "address": {"city": "P", "postalCode": "750"}}`,
+	"everything_wrong": `{"username": "Not Valid!", "age": 7, "creditLimit": "0", "score": 1.5,
+		"tags": ["a", "b", "c", "d"], "contacts": [{"email": "x"}, {"email": "ann@example.com"}],
+		"referralCode": "abc"}`,
+}
+
+// TestRequestValidationParityIntegration checks that client-side request
+// validation reports the same field paths as the server. It generates the Go
+// server, the Go client and the TypeScript client with validate_requests=true
+// for request_validation.proto, sends every payload to the server, and passes
+// the same payload to both clients, which must reject it before sending. The
+// TypeScript side needs node with --experimental-strip-types and is skipped
+// without it.
+func TestRequestValidationParityIntegration(t *testing.T) {
+	payloads, err := json.Marshal(requestValidationPayloads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tsDir := t.TempDir()
+	tempDir := plugintest.Generate(t, plugintest.Module{
+		Path:     "request_validation_test",
+		Protos:   map[string]string{"request_validation.proto": ""},
+		ProtoDir: filepath.Join("testdata", "proto"),
+		Mappings: []string{"Mrequest_validation.proto=request_validation_test/gen;gen"},
+		Plugins: []plugintest.Plugin{
+			{Name: "go-http"},
+			{Name: "go-client", Opt: "validate_requests=true"},
+			{Name: "ts-client", Opt: "validate_requests=true", Out: tsDir},
+		},
+		Files: map[string]string{
+			"main.go":       requestValidationGoProgram,
+			"payloads.json": string(payloads),
+		},
+	})
+
+	goCmd := exec.Command("go", "run", ".")
+	goCmd.Dir = tempDir
+	goCmd.Stderr = os.Stderr
+	goOut, goErr := goCmd.Output()
+	if goErr != nil {
+		t.Fatalf("Go validation program failed: %v", goErr)
+	}
+	var goResults map[string]struct{ Server, Client []string }
+	if err = json.Unmarshal(goOut, &goResults); err != nil {
+		t.Fatalf("Failed to parse Go output: %v\n%s", err, string(goOut))
+	}
+
+	server := make(map[string][]string)
+	for name := range requestValidationPayloads {
+		server[name] = sortedFields(goResults[name].Server)
+		if got := sortedFields(goResults[name].Client); !reflect.DeepEqual(got, server[name]) {
+			t.Errorf("%s: Go client violations %v, server violations %v", name, got, server[name])
+		}
+		if (name == "valid") != (len(server[name]) == 0) {
+			t.Errorf("%s: server violations %v", name, server[name])
+		}
+	}
+
+	tsOut := plugintest.RunNode(t, tsDir, map[string]string{
+		"main.ts":       requestValidationTSProgram,
+		"payloads.json": string(payloads),
+	})
+	var tsResults map[string][]string
+	if err = json.Unmarshal(tsOut, &tsResults); err != nil {
+		t.Fatalf("Failed to parse TypeScript output: %v\n%s", err, string(tsOut))
+	}
+	for name := range requestValidationPayloads {
+		if got := sortedFields(tsResults[name]); !reflect.DeepEqual(got, server[name]) {
+			t.Errorf("%s: TypeScript client violations %v, server violations %v", name, got, server[name])
+		}
+	}
+}
+
+// sortedFields returns fields sorted, with nil for no fields.
+func sortedFields(fields []string) []string {
+	if len(fields) == 0 {
+		return nil
+	}
+	sorted := append([]string(nil), fields...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// requestValidationGoProgram prints, for every payload, the violated fields
+// the server answers with and the ones the Go client rejects the request for.
+// The client points at an unreachable address, so a request it fails to reject
+// yields a transport error rather than the server's violations.
+const requestValidationGoProgram = `package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+
+	gen "request_validation_test/gen"
+)
+
+type accountServer struct{}
+
+func (accountServer) CreateAccount(context.Context, *gen.CreateAccountRequest) (*gen.Account, error) {
+	return &gen.Account{}, nil
+}
+
+func (accountServer) GetAccount(context.Context, *gen.GetAccountRequest) (*gen.Account, error) {
+	return &gen.Account{}, nil
+}
+
+func fields(validationErr *sebufhttp.ValidationError) []string {
+	var out []string
+	for _, violation := range validationErr.GetViolations() {
+		out = append(out, violation.GetField())
+	}
+	return out
+}
+
+func main() {
+	var payloads map[string]string
+	data, err := os.ReadFile("payloads.json")
+	if err != nil {
+		panic(err)
+	}
+	if err = json.Unmarshal(data, &payloads); err != nil {
+		panic(err)
+	}
+
+	mux := http.NewServeMux()
+	if err = gen.RegisterAccountServiceServer(accountServer{}, gen.WithMux(mux)); err != nil {
+		panic(err)
+	}
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := gen.NewAccountServiceClient("http://127.0.0.1:0")
+
+	type result struct{ Server, Client []string }
+	out := make(map[string]result)
+	for name, payload := range payloads {
+		var r result
+		resp, postErr := http.Post(srv.URL+"/api/v1/accounts", "application/json", strings.NewReader(payload))
+		if postErr != nil {
+			panic(postErr)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusBadRequest {
+			serverErr := &sebufhttp.ValidationError{}
+			if err = protojson.Unmarshal(body, serverErr); err != nil {
+				panic(err)
+			}
+			r.Server = fields(serverErr)
+		}
+
+		req := &gen.CreateAccountRequest{}
+		if err = protojson.Unmarshal([]byte(payload), req); err != nil {
+			panic(err)
+		}
+		var clientErr *sebufhttp.ValidationError
+		if _, err = client.CreateAccount(context.Background(), req); errors.As(err, &clientErr) {
+			r.Client = fields(clientErr)
+		}
+		out[name] = r
+	}
+	if err = json.NewEncoder(os.Stdout).Encode(out); err != nil {
+		panic(err)
+	}
+}
+`
+
+// requestValidationTSProgram prints the violated fields the TypeScript client
+// rejects each payload for. Its fetch succeeds, so a payload it lets through
+// reports no violations.
+const requestValidationTSProgram = `import { readFileSync } from "node:fs";
+import { ValidationError } from "./errors.ts";
+import { AccountServiceClient } from "./request_validation_client.ts";
+
+const payloads: Record<string, string> = JSON.parse(readFileSync("payloads.json", "utf8"));
+const client = new AccountServiceClient("http://localhost", {
+  fetch: async () => new Response("{}", { status: 200 }),
+});
+
+const out: Record<string, string[]> = {};
+for (const [name, payload] of Object.entries(payloads)) {
+  try {
+    await client.createAccount(JSON.parse(payload));
+    out[name] = [];
+  } catch (e) {
+    if (!(e instanceof ValidationError)) throw e;
+    out[name] = e.violations.map((violation) => violation.field);
+  }
+}
+console.log(JSON.stringify(out));
+`
@@ -0,0 +1,179 @@
+package tsclientgen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestResponseCacheIntegration generates the TypeScript client for
+// http_verbs_comprehensive.proto and runs responseCacheTSProgram against it
+// with a counting fetch and node:test mock timers: GET responses are memoized
+// per URL and headers until their TTL, handed out as copies, loaded once for
+// concurrent misses, served stale while revalidating, evicted least recently
+// used first, and dropped by successful mutations of their path.
+func TestResponseCacheIntegration(t *testing.T) {
+	plugintest.RunTS(t, plugintest.Module{
+		Protos:   map[string]string{"http_verbs_comprehensive.proto": ""},
+		ProtoDir: filepath.Join("testdata", "proto"),
+		Plugins:  []plugintest.Plugin{{Name: "ts-client"}},
+		Files:    map[string]string{"main.ts": responseCacheTSProgram},
+	})
+}
+
+// responseCacheTSProgram tests the response cache of a generated client. Its
+// fetch answers every request with the number of requests made so far, so a
+// response served from the cache carries an older count.
+const responseCacheTSProgram = `import assert from "node:assert/strict";
+import { afterEach, beforeEach, mock, test } from "node:test";
+import { RESTfulAPIServiceClient } from "./http_verbs_comprehensive_client.ts";
+
+let calls = 0;
+let failing = false;
+
+async function fetchFn(url: string, init?: { method?: string }): Promise<Response> {
+  calls++;
+  if (failing) {
+    return new Response("unavailable", { status: 503 });
+  }
+  return new Response(JSON.stringify({ resourceId: String(calls), name: init?.method + " " + url }));
+}
+
+function newClient(cache?: { ttlMs: number; maxEntries?: number; staleWhileRevalidate?: boolean }) {
+  return new RESTfulAPIServiceClient("http://api.test", { fetch: fetchFn as typeof fetch, cache });
+}
+
+const get = (client: RESTfulAPIServiceClient, id: string, headers?: Record<string, string>) =>
+  client.getResource({ resourceId: id } as any, { headers }).then((r) => r.resourceId);
+
+// settle lets background refreshes finish.
+async function settle(): Promise<void> {
+  for (let i = 0; i < 10; i++) {
+    await new Promise((resolve) => setImmediate(resolve));
+  }
+}
+
+beforeEach(() => {
+  calls = 0;
+  failing = false;
+  mock.timers.enable({ apis: ["Date"], now: 0 });
+});
+
+afterEach(() => {
+  mock.timers.reset();
+});
+
+test("fetches every call without the cache option", async () => {
+  const client = newClient();
+  assert.equal(await get(client, "a"), "1");
+  assert.equal(await get(client, "a"), "2");
+});
+
+test("memoizes GET responses by URL and headers until their TTL", async () => {
+  const client = newClient({ ttlMs: 1000 });
+  assert.equal(await get(client, "a"), "1");
+  assert.equal(await get(client, "a"), "1");
+  assert.equal(await get(client, "b"), "2");
+  assert.equal(await get(client, "a", { "X-Tenant": "t1" }), "3");
+  assert.equal(await get(client, "a", { "X-Tenant": "t1" }), "3");
+
+  mock.timers.tick(999);
+  assert.equal(await get(client, "a"), "1");
+  mock.timers.tick(1);
+  assert.equal(await get(client, "a"), "4");
+  assert.equal(await get(client, "a"), "4");
+});
+
+test("hands out copies of cached responses", async () => {
+  const client = newClient({ ttlMs: 1000 });
+  const first = await client.getResource({ resourceId: "a" } as any);
+  first.name = "changed";
+  const second = await client.getResource({ resourceId: "a" } as any);
+  assert.equal(calls, 1);
+  assert.notEqual(second.name, "changed");
+  assert.notStrictEqual(second, first);
+});
+
+test("shares one load between concurrent misses", async () => {
+  const client = newClient({ ttlMs: 1000 });
+  const [first, second] = await Promise.all([
+    client.getResource({ resourceId: "a" } as any),
+    client.getResource({ resourceId: "a" } as any),
+  ]);
+  assert.equal(calls, 1);
+  assert.deepEqual(second, first);
+  assert.notStrictEqual(second, first, "each miss gets its own copy");
+
+  failing = true;
+  mock.timers.tick(1000);
+  const misses = [get(client, "a"), get(client, "a")];
+  for (const miss of misses) {
+    await assert.rejects(miss);
+  }
+  assert.equal(calls, 2, "a failed load is shared too");
+});
+
+test("keeps one cache per client instance", async () => {
+  const first = newClient({ ttlMs: 1000 });
+  const second = newClient({ ttlMs: 1000 });
+  assert.equal(await get(first, "a"), "1");
+  assert.equal(await get(second, "a"), "2");
+  assert.equal(await get(first, "a"), "1");
+});
+
+test("serves stale responses while revalidating", async () => {
+  const client = newClient({ ttlMs: 1000, staleWhileRevalidate: true });
+  assert.equal(await get(client, "a"), "1");
+
+  mock.timers.tick(1000);
+  assert.equal(await get(client, "a"), "1");
+  assert.equal(await get(client, "a"), "1");
+  await settle();
+  assert.equal(calls, 2, "one background refresh");
+  assert.equal(await get(client, "a"), "2");
+
+  mock.timers.tick(1000);
+  failing = true;
+  assert.equal(await get(client, "a"), "2");
+  await settle();
+  assert.equal(await get(client, "a"), "2", "a failed refresh keeps the stale response");
+  failing = false;
+  await settle();
+  assert.equal(await get(client, "a"), "2");
+  await settle();
+  assert.equal(await get(client, "a"), "5");
+});
+
+test("evicts the least recently used response", async () => {
+  const client = newClient({ ttlMs: 1000, maxEntries: 2 });
+  assert.equal(await get(client, "a"), "1");
+  assert.equal(await get(client, "b"), "2");
+  assert.equal(await get(client, "a"), "1");
+  assert.equal(await get(client, "c"), "3");
+  assert.equal(await get(client, "a"), "1");
+  assert.equal(await get(client, "b"), "4");
+});
+
+test("mutations invalidate the responses under their path", async () => {
+  const client = newClient({ ttlMs: 1000 });
+  const list = () => client.listResources({} as any).then((r) => r.resources);
+  const nested = () =>
+    client.getNestedResource({ orgId: "o", teamId: "t", resourceId: "a" } as any).then((r) => r.resourceId);
+  assert.equal(await get(client, "a"), "1");
+  await list();
+  assert.equal(await nested(), "3");
+  assert.equal(calls, 3);
+
+  await client.updateResource({ resourceId: "a" } as any);
+  assert.equal(await get(client, "a"), "5");
+  await list();
+  assert.equal(calls, 6, "the listing is refetched");
+  assert.equal(await nested(), "3", "other paths are kept");
+
+  failing = true;
+  await assert.rejects(client.deleteResource({ resourceId: "a" } as any));
+  failing = false;
+  assert.equal(await get(client, "a"), "5", "failed mutations keep the cache");
+});
+`
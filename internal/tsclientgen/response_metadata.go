@@ -0,0 +1,135 @@
+package tsclientgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/tscommon"
+)
+
+// responseMetadataModule is the extensionless path of the shared module,
+// emitted at the output root, reading the status, headers and warnings of the
+// responses to unary calls.
+const responseMetadataModule = "response_metadata"
+
+// Exports of the response metadata module referenced by the client modules.
+const (
+	responseMetadataName       = "ResponseMetadata"
+	reportResponseMetadataName = "reportResponseMetadata"
+)
+
+// reportsResponses reports whether a service's calls take the onResponse
+// option: it has a unary method, neither SSE nor stream_response.
+func reportsResponses(service *protogen.Service) bool {
+	for _, method := range service.Methods {
+		config := annotations.GetMethodHTTPConfig(method)
+		if config == nil || (!config.Stream && !config.StreamResponse) {
+			return true
+		}
+	}
+	return false
+}
+
+// fileReportsResponses reports whether any service of file reports responses.
+func fileReportsResponses(file *protogen.File) bool {
+	for _, service := range file.Services {
+		if reportsResponses(service) {
+			return true
+		}
+	}
+	return false
+}
+
+// anyClientReportsResponses reports whether any generated client reports
+// responses, and so imports the response metadata module.
+func (g *Generator) anyClientReportsResponses() bool {
+	for _, file := range g.plugin.Files {
+		if file.Generate && fileReportsResponses(file) {
+			return true
+		}
+	}
+	return false
+}
+
+// emitResponseMetadataModule writes the shared response metadata module for
+// one format variant.
+func (g *Generator) emitResponseMetadataModule(variant tscommon.ModuleVariant) {
+	gf := g.plugin.NewGeneratedFile(responseMetadataModule+variant.SourceExt, "")
+	writeResponseMetadataModule(tscommon.DirectPrinter(gf))
+}
+
+func writeResponseMetadataModule(p tscommon.Printer) {
+	p("// Code generated by protoc-gen-ts-client. DO NOT EDIT.")
+	p("")
+	p("/** A warning of a partial response, such as a listing only some backends answered. */")
+	p("export interface Warning {")
+	p("  code: string;")
+	p("  message: string;")
+	p("}")
+	p("")
+	p("/** The status, headers and warnings of the response to a unary call. */")
+	p("export interface ResponseMetadata {")
+	p("  status: number;")
+	p("  headers: { get(name: string): string | null };")
+	p("  /** From the Warning headers or, without any, the _warnings of the JSON body. */")
+	p("  warnings: Warning[];")
+	p("}")
+	p("")
+	p("/**")
+	p(" * Reports the metadata of resp to onResponse. body, the decoded JSON object of")
+	p(" * the response if it can hold warnings, loses its _warnings key either way.")
+	p(" */")
+	p("export function reportResponseMetadata(")
+	p("  resp: { status: number; headers: { get(name: string): string | null } },")
+	p("  onResponse: ((metadata: ResponseMetadata) => void) | undefined,")
+	p("  body?: unknown,")
+	p("): void {")
+	p(`  let warnings = parseWarnings(resp.headers.get("Warning"));`)
+	p(`  if (body !== null && typeof body === "object" && !Array.isArray(body) && "_warnings" in body) {`)
+	p("    const object = body as Record<string, unknown>;")
+	p("    if (warnings.length === 0 && Array.isArray(object._warnings)) {")
+	p("      warnings = object._warnings as Warning[];")
+	p("    }")
+	p("    delete object._warnings;")
+	p("  }")
+	p("  onResponse?.({ status: resp.status, headers: resp.headers, warnings });")
+	p("}")
+	p("")
+	p("/**")
+	p(` * Parses Warning header values, 299 - "code: message", which fetch joins with`)
+	p(` * commas. Text without ": " is a message without a code.`)
+	p(" */")
+	p("export function parseWarnings(header: string | null): Warning[] {")
+	p("  const warnings: Warning[] = [];")
+	p(`  const pattern = /\d{3} \S+ "((?:[^"\\]|\\.)*)"/g;`)
+	p("  let match: RegExpExecArray | null;")
+	p(`  while (header && (match = pattern.exec(header)) !== null) {`)
+	p(`    const text = match[1].replace(/\\(.)/g, "$1");`)
+	p(`    const sep = text.indexOf(": ");`)
+	p("    warnings.push(sep >= 0")
+	p("      ? { code: text.slice(0, sep), message: text.slice(sep + 2) }")
+	p(`      : { code: "", message: text });`)
+	p("  }")
+	p("  return warnings;")
+	p("}")
+}
+
+// generateResponseMetadataReport reports the metadata of the response to the
+// onResponse option, removing the warnings from body, the decoded response,
+// unless it is empty: raw bodies and root-unwrapped messages never hold them.
+func generateResponseMetadataReport(p printer, indent, body string) {
+	if body == "" {
+		p("%s%s(resp, options?.onResponse);", indent, reportResponseMetadataName)
+		return
+	}
+	p("%s%s(resp, options?.onResponse, %s);", indent, reportResponseMetadataName, body)
+}
+
+// needResponseMetadataModule records the response metadata module imports of
+// a client module with a service reporting responses.
+func (g *Generator) needResponseMetadataModule(file *protogen.File) {
+	if !fileReportsResponses(file) {
+		return
+	}
+	g.ctx.NeedRuntime(responseMetadataModule, "type "+responseMetadataName, reportResponseMetadataName)
+}
@@ -0,0 +1,75 @@
+package tsclientgen
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/tscommon"
+)
+
+// resultTypeName returns the name of the union a method returning the result
+// message msg resolves to: one member per variant, told apart by status.
+func resultTypeName(msg *protogen.Message) string {
+	return msg.GoIdent.GoName + "Response"
+}
+
+// fileResultMessages returns the result messages returned by the methods of
+// file, once each, in the order they are first returned.
+func fileResultMessages(file *protogen.File) []*protogen.Message {
+	var results []*protogen.Message
+	seen := make(map[*protogen.Message]bool)
+	for _, service := range file.Services {
+		for _, method := range service.Methods {
+			msg := method.Output
+			if !seen[msg] && annotations.IsResultMessage(msg) {
+				seen[msg] = true
+				results = append(results, msg)
+			}
+		}
+	}
+	return results
+}
+
+// generateResultType generates the union of a result message: each member holds
+// the HTTP status and, under the variant's JSON name, the body sent with it.
+func (g *Generator) generateResultType(p printer, msg *protogen.Message) {
+	variants, _ := annotations.GetResponseVariants(msg)
+	p("/** The response of a method returning %s, discriminated by HTTP status. */", msg.Desc.Name())
+	p("export type %s =", resultTypeName(msg))
+	for i, variant := range variants {
+		end := ""
+		if i == len(variants)-1 {
+			end = ";"
+		}
+		key := tscommon.PropertyKey(annotations.JSONFieldName(variant.Field))
+		p("  | { status: %d; %s: %s }%s", variant.Status, key, g.ctx.RefMessage(variant.Field.Message), end)
+	}
+	p("")
+}
+
+// generateResultResponseHandling generates the response handling of a method
+// returning a result message: the status selects the variant the body holds,
+// whose metadata is reported, and other statuses are errors.
+func (g *Generator) generateResultResponseHandling(p printer, cfg *rpcMethodConfig) {
+	if cfg.invalidates {
+		p("    if (resp.ok) {")
+		p("      this.cache?.invalidate(this.baseURL + %q);", cfg.invalidatePrefix)
+		p("    }")
+		p("")
+	}
+	p("    switch (resp.status) {")
+	for _, variant := range cfg.variants {
+		p("      case %d: {", variant.Status)
+		p("        const value = await resp.json() as %s;", g.ctx.RefMessage(variant.Field.Message))
+		if annotations.IsRootUnwrap(variant.Field.Message) {
+			generateResponseMetadataReport(p, "        ", "")
+		} else {
+			generateResponseMetadataReport(p, "        ", "value")
+		}
+		p("        return { status: %d, %s: value };", variant.Status,
+			tscommon.PropertyKey(annotations.JSONFieldName(variant.Field)))
+		p("      }")
+	}
+	p("    }")
+	p("    return %s;", cfg.handleErrorCall())
+}
@@ -0,0 +1,168 @@
+package tsclientgen
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/tscommon"
+)
+
+// generateRoutes generates the static routes of a client class: the HTTP verb
+// and path template of every method, keyed by its name.
+func (g *Generator) generateRoutes(p printer, service *protogen.Service) {
+	if len(annotations.GetBasePathParams(service)) > 0 {
+		p("  /** The HTTP verb and path template of every method, relative to the base path %s. */",
+			annotations.GetServiceBasePath(service))
+	} else {
+		p("  /** The HTTP verb and path template of every method. */")
+	}
+	p("  static readonly routes = {")
+	for _, method := range service.Methods {
+		cfg := g.buildRPCMethodConfig(service, method)
+		p("    %s: { method: %q, path: %q },", annotations.LowerFirst(cfg.methodName), cfg.httpMethod, cfg.fullPath)
+	}
+	p("  } as const;")
+	p("")
+}
+
+// urlBuilderName returns the name of the static URL builder of a method.
+func urlBuilderName(cfg *rpcMethodConfig) string {
+	return annotations.LowerFirst(cfg.methodName) + "Url"
+}
+
+// generateURLBuilder generates the static URL builder of a method. It takes
+// the path parameters, then the query parameters, each only when the method
+// sends any, and returns the path and query string of the call relative to the
+// client's base URL. The client methods build their URLs with it, so the two
+// cannot disagree.
+func (g *Generator) generateURLBuilder(p printer, method *protogen.Method, cfg *rpcMethodConfig) {
+	var args []string
+	if len(cfg.pathParams) > 0 {
+		args = append(args, "params: "+g.pathParamsType(method, cfg))
+	}
+	if cfg.sendsQueryParams() {
+		args = append(args, "query: "+g.queryParamsType(cfg)+" = {}")
+	}
+
+	p("  /** Builds the URL of %s, relative to the client's base URL. */", annotations.LowerFirst(cfg.methodName))
+	p("  static %s(%s): string {", urlBuilderName(cfg), strings.Join(args, ", "))
+	if len(cfg.pathParams) == 0 {
+		p(`    const path = "%s";`, cfg.fullPath)
+	} else {
+		p(`    let path = "%s";`, cfg.fullPath)
+	}
+	for _, param := range cfg.pathParams {
+		value := tscommon.PropertyAccess("params", cfg.pathJSONNames[param])
+		if annotations.IsPathWildcard(cfg.fullPath, param) {
+			// The wildcard matches the rest of the path, so its slashes are kept
+			p(`    path = path.replace("{%s...}", String(%s).split("/").map(encodeURIComponent).join("/"));`,
+				param, value)
+			continue
+		}
+		p(`    path = path.replace("{%s}", encodeURIComponent(String(%s)));`, param, value)
+	}
+	if !cfg.sendsQueryParams() {
+		p("    return path;")
+		p("  }")
+		p("")
+		return
+	}
+
+	p("    const search = new URLSearchParams();")
+	for _, qp := range cfg.queryParams {
+		value := tscommon.PropertyAccess("query", qp.FieldJSONName)
+		if qp.JSON {
+			generateJSONQueryParam(p, qp, value)
+			continue
+		}
+		switch annotations.GetQueryPresence(qp) {
+		case annotations.QueryPresenceNonEmpty:
+			// Repeated fields: one value per element, none for an empty list
+			p("    if (%s && %s.length > 0) %s.forEach(v => search.append(\"%s\", String(v)));",
+				value, value, value, qp.ParamName)
+		case annotations.QueryPresenceWhenSet:
+			// Fields with explicit presence are sent when set, even to their zero value
+			p("    if (%s != null) search.set(\"%s\", String(%s));", value, qp.ParamName, value)
+		case annotations.QueryPresenceNonZero:
+			generateNonZeroQueryParam(p, qp, value)
+		}
+	}
+	// Sorted by name, the query string matches the one Go's url.Values encodes
+	p("    search.sort();")
+	p("    const queryString = search.toString();")
+	p(`    return queryString ? path + "?" + queryString : path;`)
+	p("  }")
+	p("")
+}
+
+// generateNonZeroQueryParam generates the setting of the query parameter of qp
+// from value when it does not hold its zero value.
+func generateNonZeroQueryParam(p printer, qp annotations.QueryParam, value string) {
+	// Use field-aware zero check when field reference is available
+	var check string
+	if qp.Field != nil {
+		check = tsZeroCheckForField(qp.Field)
+	} else {
+		check = tsZeroCheck(qp.FieldKind)
+	}
+	if check == "" {
+		// bool: only add if true (undefined is already falsy)
+		p("    if (%s) search.set(\"%s\", String(%s));", value, qp.ParamName, value)
+		return
+	}
+	// Guard against undefined/null before zero-value check
+	p("    if (%s != null && %s%s) search.set(\"%s\", String(%s));", value, value, check, qp.ParamName, value)
+}
+
+// pathParamsType returns the object type of a URL builder's path parameters,
+// declaring each like its request field so that the request is assignable to it.
+func (g *Generator) pathParamsType(method *protogen.Method, cfg *rpcMethodConfig) string {
+	props := make([]string, 0, len(cfg.pathParams))
+	for _, param := range cfg.pathParams {
+		key := tscommon.PropertyKey(cfg.pathJSONNames[param])
+		field := annotations.FindFieldByProtoName(method.Input, param)
+		switch {
+		case field == nil:
+			props = append(props, key+": string")
+		case annotations.IsNullableField(field):
+			props = append(props, key+": "+tscommon.TSFieldTypeCtx(g.ctx, field)+" | null")
+		case tscommon.IsOptionalField(field):
+			props = append(props, key+"?: "+tscommon.TSFieldTypeCtx(g.ctx, field))
+		default:
+			props = append(props, key+": "+tscommon.TSFieldTypeCtx(g.ctx, field))
+		}
+	}
+	return "{ " + strings.Join(props, "; ") + " }"
+}
+
+// queryParamsType returns the object type of a URL builder's query
+// parameters, all optional since unset parameters are left out of the URL.
+func (g *Generator) queryParamsType(cfg *rpcMethodConfig) string {
+	props := make([]string, 0, len(cfg.queryParams))
+	for _, qp := range cfg.queryParams {
+		key := tscommon.PropertyKey(qp.FieldJSONName)
+		tsType := "string"
+		if qp.Field != nil {
+			tsType = tscommon.TSFieldTypeCtx(g.ctx, qp.Field)
+			if annotations.IsNullableField(qp.Field) {
+				tsType += " | null"
+			}
+		}
+		props = append(props, key+"?: "+tsType)
+	}
+	return "{ " + strings.Join(props, "; ") + " }"
+}
+
+// urlBuilderCall returns the call of a method's URL builder with the request.
+func urlBuilderCall(cfg *rpcMethodConfig) string {
+	var args []string
+	if len(cfg.pathParams) > 0 {
+		args = append(args, "req")
+	}
+	if cfg.sendsQueryParams() {
+		args = append(args, "req")
+	}
+	return cfg.serviceName + "Client." + urlBuilderName(cfg) + "(" + strings.Join(args, ", ") + ")"
+}
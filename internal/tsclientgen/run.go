@@ -0,0 +1,61 @@
+package tsclientgen
+
+import (
+	"flag"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/internal/manifest"
+	"github.com/SebastienMelki/sebuf/internal/pluginrun"
+	"github.com/SebastienMelki/sebuf/internal/tscommon"
+)
+
+// Run generates the TypeScript client of req in memory, without reading stdin
+// or writing stdout. opts supplies the defaults for plugin parameters; module,
+// target, fixtures, validate_requests, schema_fingerprint and manifest
+// parameters in req override them.
+// Invalid input is reported in the response's Error field; the error is only
+// set if generation panicked.
+func Run(req *pluginpb.CodeGeneratorRequest, opts Options) (*pluginpb.CodeGeneratorResponse, error) {
+	var flags flag.FlagSet
+	target := flags.String("target", string(opts.Target), "runtime to target: browser, node, or isomorphic")
+	flags.BoolVar(&opts.Fixtures, "fixtures", opts.Fixtures, "generate mock<Message>() test fixtures per service file")
+	flags.BoolVar(&opts.ValidateRequests, "validate_requests", opts.ValidateRequests,
+		"check requests against their buf.validate rules before fetch")
+	flags.BoolVar(&opts.SchemaFingerprint, "schema_fingerprint", opts.SchemaFingerprint,
+		"embed a fingerprint of each service's schema and send it with every request")
+	flags.BoolVar(&opts.Manifest, "manifest", opts.Manifest,
+		"write "+manifest.FileName+" describing the called routes and generated files")
+
+	// protogen reserves module= for the Go import path prefix and rejects it
+	// alongside paths=source_relative, so it is taken out of a copy of the
+	// request (sharing its descriptors) before protogen sees it.
+	if req != nil {
+		module, param := pluginrun.TakeParam(req.GetParameter(), "module")
+		if module != "" {
+			opts.Module = tscommon.ModuleFormat(module)
+		}
+		req = &pluginpb.CodeGeneratorRequest{
+			FileToGenerate:        req.GetFileToGenerate(),
+			Parameter:             proto.String(param),
+			ProtoFile:             req.GetProtoFile(),
+			SourceFileDescriptors: req.GetSourceFileDescriptors(),
+			CompilerVersion:       req.GetCompilerVersion(),
+		}
+	}
+
+	var routes *manifest.Builder
+	resp, err := pluginrun.Run(req, flags.Set, func(plugin *protogen.Plugin) error {
+		opts.Target = Target(*target)
+		g := NewWithOptions(plugin, opts)
+		if opts.Manifest {
+			routes = manifest.NewBuilder("protoc-gen-ts-client")
+			g.manifest = routes
+		}
+		return g.Generate()
+	})
+	routes.Write(resp)
+	return resp, err
+}
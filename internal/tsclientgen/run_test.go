@@ -0,0 +1,79 @@
+package tsclientgen
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/manifest"
+	"github.com/SebastienMelki/sebuf/internal/pluginrun/pluginruntest"
+)
+
+func TestRun(t *testing.T) {
+	req := pluginruntest.Request("paths=source_relative,module=cjs,target=node")
+	resp, err := Run(req, Options{Target: TargetBrowser})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if resp.GetError() != "" {
+		t.Fatalf("response error: %s", resp.GetError())
+	}
+
+	names := pluginruntest.FileNames(resp)
+	if !slices.ContainsFunc(names, func(name string) bool { return strings.HasSuffix(name, ".cts") }) {
+		t.Errorf("module=cjs should emit .cts modules, got %v", names)
+	}
+	if content := pluginruntest.Content(resp, "notes_client.cts"); !strings.HasSuffix(content, "}\n\nexport = notes_client;\n") {
+		t.Errorf("notes_client.cts should assign its namespace with export =, got:\n%s", content)
+	}
+	if req.GetParameter() != "paths=source_relative,module=cjs,target=node" {
+		t.Errorf("Run must not modify the request, parameter is now %q", req.GetParameter())
+	}
+}
+
+func TestRunReportsInvalidInput(t *testing.T) {
+	resp, err := Run(pluginruntest.Request("paths=source_relative,target=deno"), Options{})
+	if err != nil {
+		t.Fatalf("Run returned error %v, want it in the response", err)
+	}
+	if !strings.Contains(resp.GetError(), `unsupported target "deno"`) {
+		t.Errorf("response error = %q, want the unsupported target reported", resp.GetError())
+	}
+}
+
+func TestRunManifestOption(t *testing.T) {
+	resp, err := Run(pluginruntest.Request("paths=source_relative,manifest=true"), Options{})
+	if err != nil || resp.GetError() != "" {
+		t.Fatalf("Run: %v %s", err, resp.GetError())
+	}
+	var got manifest.Manifest
+	if err = json.Unmarshal([]byte(pluginruntest.Content(resp, manifest.FileName)), &got); err != nil {
+		t.Fatalf("decode manifest: %v", err)
+	}
+
+	var outputs []string
+	for _, output := range got.Outputs {
+		outputs = append(outputs, output.Name)
+	}
+	want := slices.DeleteFunc(pluginruntest.FileNames(resp), func(name string) bool { return name == manifest.FileName })
+	slices.Sort(want)
+	if !slices.Equal(outputs, want) {
+		t.Errorf("outputs = %v, want every generated file %v", outputs, want)
+	}
+
+	var routes []string
+	for _, service := range got.Services {
+		if service.Plugin != "protoc-gen-ts-client" {
+			t.Errorf("service %s produced by %q, want protoc-gen-ts-client", service.Name, service.Plugin)
+		}
+		for _, method := range service.Methods {
+			for _, route := range method.Routes {
+				routes = append(routes, method.Name+" "+route.Method+" "+route.Path)
+			}
+		}
+	}
+	if want := []string{"GetNote GET /api/v1/notes/{id}", "CreateNote POST /api/v1/notes"}; !slices.Equal(routes, want) {
+		t.Errorf("routes = %v, want %v", routes, want)
+	}
+}
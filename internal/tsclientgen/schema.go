@@ -0,0 +1,30 @@
+package tsclientgen
+
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/internal/schemahash"
+)
+
+// generateSchemaFingerprint generates the static schemaFingerprint of a client
+// class, the fingerprint of the schema of its service.
+func generateSchemaFingerprint(p printer, service *protogen.Service) {
+	p("  /**")
+	p("   * The fingerprint of the methods and messages of the %s service this", service.GoName)
+	p("   * client was generated from, sent in the %s header of every request.", sebufhttp.SchemaHeader)
+	p("   */")
+	p("  static readonly schemaFingerprint = %s;", strconv.Quote(schemahash.Fingerprint(service.Desc)))
+	p("")
+}
+
+// generateSchemaDefaultHeaders initializes the default headers of a client
+// with its schema fingerprint, which the defaultHeaders option can override.
+func generateSchemaDefaultHeaders(p printer, serviceName string) {
+	p("    this.defaultHeaders = {")
+	p(`      %q: %sClient.schemaFingerprint,`, sebufhttp.SchemaHeader, serviceName)
+	p("      ...options?.defaultHeaders,")
+	p("    };")
+}
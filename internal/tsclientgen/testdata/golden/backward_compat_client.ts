@@ -2,6 +2,7 @@
 // source: backward_compat.proto
 
 import { ApiError, ValidationError } from "./errors.js";
+import { type ResponseMetadata, reportResponseMetadata } from "./response_metadata.js";
 import type { ActionRequest, ActionResponse, AnotherRequest, AnotherResponse, SimpleRequest, SimpleResponse } from "./backward_compat.js";
 
 export interface NoAnnotationsServiceClientOptions {
@@ -12,9 +13,18 @@ export interface NoAnnotationsServiceClientOptions {
 export interface NoAnnotationsServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
 }
 
+/** Service without any HTTP annotations - should use defaults */
 export class NoAnnotationsServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    simpleAction: { method: "POST", path: "/simpleAction" },
+    anotherAction: { method: "POST", path: "/anotherAction" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
@@ -25,9 +35,14 @@ export class NoAnnotationsServiceClient {
     this.defaultHeaders = { ...options?.defaultHeaders };
   }
 
+  /** Builds the URL of simpleAction, relative to the client's base URL. */
+  static simpleActionUrl(): string {
+    const path = "/simpleAction";
+    return path;
+  }
+
   async simpleAction(req: SimpleRequest, options?: NoAnnotationsServiceCallOptions): Promise<SimpleResponse> {
-    let path = "/simpleAction";
-    const url = this.baseURL + path;
+    const url = this.baseURL + NoAnnotationsServiceClient.simpleActionUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -46,12 +61,19 @@ export class NoAnnotationsServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as SimpleResponse;
+    const result = await resp.json() as SimpleResponse;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
+  }
+
+  /** Builds the URL of anotherAction, relative to the client's base URL. */
+  static anotherActionUrl(): string {
+    const path = "/anotherAction";
+    return path;
   }
 
   async anotherAction(req: AnotherRequest, options?: NoAnnotationsServiceCallOptions): Promise<AnotherResponse> {
-    let path = "/anotherAction";
-    const url = this.baseURL + path;
+    const url = this.baseURL + NoAnnotationsServiceClient.anotherActionUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -70,22 +92,45 @@ export class NoAnnotationsServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as AnotherResponse;
+    const result = await resp.json() as AnotherResponse;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
+    }
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
@@ -97,9 +142,18 @@ export interface BasePathOnlyServiceClientOptions {
 export interface BasePathOnlyServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
 }
 
+/** Service with only base_path but no method annotations */
 export class BasePathOnlyServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    actionOne: { method: "POST", path: "/api/v2/actionOne" },
+    actionTwo: { method: "POST", path: "/api/v2/actionTwo" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
@@ -110,9 +164,14 @@ export class BasePathOnlyServiceClient {
     this.defaultHeaders = { ...options?.defaultHeaders };
   }
 
+  /** Builds the URL of actionOne, relative to the client's base URL. */
+  static actionOneUrl(): string {
+    const path = "/api/v2/actionOne";
+    return path;
+  }
+
   async actionOne(req: ActionRequest, options?: BasePathOnlyServiceCallOptions): Promise<ActionResponse> {
-    let path = "/api/v2/actionOne";
-    const url = this.baseURL + path;
+    const url = this.baseURL + BasePathOnlyServiceClient.actionOneUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -131,12 +190,19 @@ export class BasePathOnlyServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as ActionResponse;
+    const result = await resp.json() as ActionResponse;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
+  }
+
+  /** Builds the URL of actionTwo, relative to the client's base URL. */
+  static actionTwoUrl(): string {
+    const path = "/api/v2/actionTwo";
+    return path;
   }
 
   async actionTwo(req: ActionRequest, options?: BasePathOnlyServiceCallOptions): Promise<ActionResponse> {
-    let path = "/api/v2/actionTwo";
-    const url = this.baseURL + path;
+    const url = this.baseURL + BasePathOnlyServiceClient.actionTwoUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -155,22 +221,46 @@ export class BasePathOnlyServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as ActionResponse;
+    const result = await resp.json() as ActionResponse;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
+    }
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
+export default { NoAnnotationsServiceClient, BasePathOnlyServiceClient };
@@ -2,11 +2,17 @@
 // source: bytes_encoding.proto
 
 export interface BytesEncodingTest {
+  /** Default (BASE64) - no annotation */
   defaultData: string;
+  /** Explicit BASE64 */
   base64Data: string;
+  /** BASE64_RAW (no padding) */
   base64RawData: string;
+  /** BASE64URL (URL-safe with padding) */
   base64urlData: string;
+  /** BASE64URL_RAW (URL-safe without padding) */
   base64urlRawData: string;
+  /** HEX (lowercase hexadecimal) */
   hexData: string;
 }
 
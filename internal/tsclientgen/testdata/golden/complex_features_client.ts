@@ -2,11 +2,14 @@
 // source: complex_features.proto
 
 import { ApiError, ValidationError } from "./errors.js";
+import { ResponseCache, type ResponseCacheOptions } from "./response_cache.js";
+import { type ResponseMetadata, reportResponseMetadata } from "./response_metadata.js";
 import type { Bar, BarsBySymbol, CreateNoteRequest, GetBarsBySymbolRequest, GetCombinedUnwrapRequest, GetNoteListRequest, GetNoteMapRequest, GetNoteRequest, ListNotesRequest, ListNotesResponse, Note, UpdateNoteRequest } from "./complex_features.js";
 
 export interface FeatureServiceClientOptions {
   fetch?: typeof fetch;
   defaultHeaders?: Record<string, string>;
+  cache?: ResponseCacheOptions;
   apiKey?: string;
   tenantId?: string;
 }
@@ -14,96 +17,144 @@ export interface FeatureServiceClientOptions {
 export interface FeatureServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
   apiKey?: string;
   tenantId?: string;
+  noteTag?: string[];
   requestId?: string;
   idempotencyKey?: string;
 }
 
 export class FeatureServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    listNotes: { method: "GET", path: "/api/v1/notes" },
+    getNote: { method: "GET", path: "/api/v1/notes/{note_id}" },
+    createNote: { method: "POST", path: "/api/v1/notes" },
+    updateNote: { method: "PUT", path: "/api/v1/notes/{note_id}" },
+    getNoteList: { method: "POST", path: "/api/v1/notes/list" },
+    getNoteMap: { method: "POST", path: "/api/v1/notes/map" },
+    getBarsBySymbol: { method: "POST", path: "/api/v1/bars" },
+    getCombinedUnwrap: { method: "POST", path: "/api/v1/bars/combined" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
+  private cache?: ResponseCache;
 
   constructor(baseURL: string, options?: FeatureServiceClientOptions) {
     this.baseURL = baseURL.replace(/\/+$/, "");
     this.fetchFn = options?.fetch ?? globalThis.fetch;
     this.defaultHeaders = { ...options?.defaultHeaders };
+    this.cache = options?.cache ? new ResponseCache(options.cache) : undefined;
     if (options?.apiKey) {
-      this.defaultHeaders["X-API-Key"] = options.apiKey;
+      this.defaultHeaders["X-Api-Key"] = options.apiKey;
     }
     if (options?.tenantId) {
-      this.defaultHeaders["X-Tenant-ID"] = options.tenantId;
+      this.defaultHeaders["X-Tenant-Id"] = options.tenantId;
     }
   }
 
+  /** Builds the URL of listNotes, relative to the client's base URL. */
+  static listNotesUrl(query: { page?: number; pageSize?: number; filter?: string } = {}): string {
+    const path = "/api/v1/notes";
+    const search = new URLSearchParams();
+    if (query.page != null && query.page !== 0) search.set("page", String(query.page));
+    if (query.pageSize != null && query.pageSize !== 0) search.set("page_size", String(query.pageSize));
+    if (query.filter != null && query.filter !== "") search.set("filter", String(query.filter));
+    search.sort();
+    const queryString = search.toString();
+    return queryString ? path + "?" + queryString : path;
+  }
+
+  /** GET with query params and a multiple method header (X-Note-Tag) */
   async listNotes(req: ListNotesRequest, options?: FeatureServiceCallOptions): Promise<ListNotesResponse> {
-    let path = "/api/v1/notes";
-    const params = new URLSearchParams();
-    if (req.page != null && req.page !== 0) params.set("page", String(req.page));
-    if (req.pageSize != null && req.pageSize !== 0) params.set("page_size", String(req.pageSize));
-    if (req.filter != null && req.filter !== "") params.set("filter", String(req.filter));
-    const url = this.baseURL + path + (params.toString() ? "?" + params.toString() : "");
+    const url = this.baseURL + FeatureServiceClient.listNotesUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
       ...this.defaultHeaders,
       ...options?.headers,
     };
-    if (options?.apiKey) headers["X-API-Key"] = options.apiKey;
-    if (options?.tenantId) headers["X-Tenant-ID"] = options.tenantId;
-
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
+    if (options?.apiKey) headers["X-Api-Key"] = options.apiKey;
+    if (options?.tenantId) headers["X-Tenant-Id"] = options.tenantId;
+    if (options?.noteTag) headers["X-Note-Tag"] = options.noteTag.join(", ");
+
+    const load = async (): Promise<ListNotesResponse> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
+
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
 
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+      const result = await resp.json() as ListNotesResponse;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
+  }
 
-    return await resp.json() as ListNotesResponse;
+  /** Builds the URL of getNote, relative to the client's base URL. */
+  static getNoteUrl(params: { noteId: string }): string {
+    let path = "/api/v1/notes/{note_id}";
+    path = path.replace("{note_id}", encodeURIComponent(String(params.noteId)));
+    return path;
   }
 
+  /** GET with path param */
   async getNote(req: GetNoteRequest, options?: FeatureServiceCallOptions): Promise<Note> {
-    let path = "/api/v1/notes/{note_id}";
-    path = path.replace("{note_id}", encodeURIComponent(String(req.noteId)));
-    const url = this.baseURL + path;
+    const url = this.baseURL + FeatureServiceClient.getNoteUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
       ...this.defaultHeaders,
       ...options?.headers,
     };
-    if (options?.apiKey) headers["X-API-Key"] = options.apiKey;
-    if (options?.tenantId) headers["X-Tenant-ID"] = options.tenantId;
-
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
+    if (options?.apiKey) headers["X-Api-Key"] = options.apiKey;
+    if (options?.tenantId) headers["X-Tenant-Id"] = options.tenantId;
+
+    const load = async (): Promise<Note> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
+
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
 
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+      const result = await resp.json() as Note;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
+  }
 
-    return await resp.json() as Note;
+  /** Builds the URL of createNote, relative to the client's base URL. */
+  static createNoteUrl(): string {
+    const path = "/api/v1/notes";
+    return path;
   }
 
+  /** POST with method header (X-Request-ID) - has enums, repeated, maps, optional */
   async createNote(req: CreateNoteRequest, options?: FeatureServiceCallOptions): Promise<Note> {
-    let path = "/api/v1/notes";
-    const url = this.baseURL + path;
+    const url = this.baseURL + FeatureServiceClient.createNoteUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
       ...this.defaultHeaders,
       ...options?.headers,
     };
-    if (options?.apiKey) headers["X-API-Key"] = options.apiKey;
-    if (options?.tenantId) headers["X-Tenant-ID"] = options.tenantId;
-    if (options?.requestId) headers["X-Request-ID"] = options.requestId;
+    if (options?.apiKey) headers["X-Api-Key"] = options.apiKey;
+    if (options?.tenantId) headers["X-Tenant-Id"] = options.tenantId;
+    if (options?.requestId) headers["X-Request-Id"] = options.requestId;
 
     const resp = await this.fetchFn(url, {
       method: "POST",
@@ -116,21 +167,31 @@ export class FeatureServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as Note;
+    this.cache?.invalidate(this.baseURL + "/api/v1/notes");
+
+    const result = await resp.json() as Note;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
-  async updateNote(req: UpdateNoteRequest, options?: FeatureServiceCallOptions): Promise<Note> {
+  /** Builds the URL of updateNote, relative to the client's base URL. */
+  static updateNoteUrl(params: { noteId: string }): string {
     let path = "/api/v1/notes/{note_id}";
-    path = path.replace("{note_id}", encodeURIComponent(String(req.noteId)));
-    const url = this.baseURL + path;
+    path = path.replace("{note_id}", encodeURIComponent(String(params.noteId)));
+    return path;
+  }
+
+  /** PUT with different method header (X-Idempotency-Key) - tests header dedup */
+  async updateNote(req: UpdateNoteRequest, options?: FeatureServiceCallOptions): Promise<Note> {
+    const url = this.baseURL + FeatureServiceClient.updateNoteUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
       ...this.defaultHeaders,
       ...options?.headers,
     };
-    if (options?.apiKey) headers["X-API-Key"] = options.apiKey;
-    if (options?.tenantId) headers["X-Tenant-ID"] = options.tenantId;
+    if (options?.apiKey) headers["X-Api-Key"] = options.apiKey;
+    if (options?.tenantId) headers["X-Tenant-Id"] = options.tenantId;
     if (options?.idempotencyKey) headers["X-Idempotency-Key"] = options.idempotencyKey;
 
     const resp = await this.fetchFn(url, {
@@ -144,20 +205,30 @@ export class FeatureServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as Note;
+    this.cache?.invalidate(this.baseURL + "/api/v1/notes");
+
+    const result = await resp.json() as Note;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
+  /** Builds the URL of getNoteList, relative to the client's base URL. */
+  static getNoteListUrl(): string {
+    const path = "/api/v1/notes/list";
+    return path;
+  }
+
+  /** Root repeated unwrap -> Note[] */
   async getNoteList(req: GetNoteListRequest, options?: FeatureServiceCallOptions): Promise<Note[]> {
-    let path = "/api/v1/notes/list";
-    const url = this.baseURL + path;
+    const url = this.baseURL + FeatureServiceClient.getNoteListUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
       ...this.defaultHeaders,
       ...options?.headers,
     };
-    if (options?.apiKey) headers["X-API-Key"] = options.apiKey;
-    if (options?.tenantId) headers["X-Tenant-ID"] = options.tenantId;
+    if (options?.apiKey) headers["X-Api-Key"] = options.apiKey;
+    if (options?.tenantId) headers["X-Tenant-Id"] = options.tenantId;
 
     const resp = await this.fetchFn(url, {
       method: "POST",
@@ -170,20 +241,30 @@ export class FeatureServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as Note[];
+    this.cache?.invalidate(this.baseURL + "/api/v1/notes/list");
+
+    const result = await resp.json() as Note[];
+    reportResponseMetadata(resp, options?.onResponse);
+    return result;
   }
 
+  /** Builds the URL of getNoteMap, relative to the client's base URL. */
+  static getNoteMapUrl(): string {
+    const path = "/api/v1/notes/map";
+    return path;
+  }
+
+  /** Root map unwrap -> Record<string, Note> */
   async getNoteMap(req: GetNoteMapRequest, options?: FeatureServiceCallOptions): Promise<{ [key: string]: Note }> {
-    let path = "/api/v1/notes/map";
-    const url = this.baseURL + path;
+    const url = this.baseURL + FeatureServiceClient.getNoteMapUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
       ...this.defaultHeaders,
       ...options?.headers,
     };
-    if (options?.apiKey) headers["X-API-Key"] = options.apiKey;
-    if (options?.tenantId) headers["X-Tenant-ID"] = options.tenantId;
+    if (options?.apiKey) headers["X-Api-Key"] = options.apiKey;
+    if (options?.tenantId) headers["X-Tenant-Id"] = options.tenantId;
 
     const resp = await this.fetchFn(url, {
       method: "POST",
@@ -196,20 +277,30 @@ export class FeatureServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as { [key: string]: Note };
+    this.cache?.invalidate(this.baseURL + "/api/v1/notes/map");
+
+    const result = await resp.json() as { [key: string]: Note };
+    reportResponseMetadata(resp, options?.onResponse);
+    return result;
   }
 
+  /** Builds the URL of getBarsBySymbol, relative to the client's base URL. */
+  static getBarsBySymbolUrl(): string {
+    const path = "/api/v1/bars";
+    return path;
+  }
+
+  /** Map-value unwrap -> data: Record<string, Bar[]> */
   async getBarsBySymbol(req: GetBarsBySymbolRequest, options?: FeatureServiceCallOptions): Promise<BarsBySymbol> {
-    let path = "/api/v1/bars";
-    const url = this.baseURL + path;
+    const url = this.baseURL + FeatureServiceClient.getBarsBySymbolUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
       ...this.defaultHeaders,
       ...options?.headers,
     };
-    if (options?.apiKey) headers["X-API-Key"] = options.apiKey;
-    if (options?.tenantId) headers["X-Tenant-ID"] = options.tenantId;
+    if (options?.apiKey) headers["X-Api-Key"] = options.apiKey;
+    if (options?.tenantId) headers["X-Tenant-Id"] = options.tenantId;
 
     const resp = await this.fetchFn(url, {
       method: "POST",
@@ -222,20 +313,30 @@ export class FeatureServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as BarsBySymbol;
+    this.cache?.invalidate(this.baseURL + "/api/v1/bars");
+
+    const result = await resp.json() as BarsBySymbol;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
+  /** Builds the URL of getCombinedUnwrap, relative to the client's base URL. */
+  static getCombinedUnwrapUrl(): string {
+    const path = "/api/v1/bars/combined";
+    return path;
+  }
+
+  /** Combined root + value unwrap -> Record<string, Bar[]> */
   async getCombinedUnwrap(req: GetCombinedUnwrapRequest, options?: FeatureServiceCallOptions): Promise<{ [key: string]: Bar[] }> {
-    let path = "/api/v1/bars/combined";
-    const url = this.baseURL + path;
+    const url = this.baseURL + FeatureServiceClient.getCombinedUnwrapUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
       ...this.defaultHeaders,
       ...options?.headers,
     };
-    if (options?.apiKey) headers["X-API-Key"] = options.apiKey;
-    if (options?.tenantId) headers["X-Tenant-ID"] = options.tenantId;
+    if (options?.apiKey) headers["X-Api-Key"] = options.apiKey;
+    if (options?.tenantId) headers["X-Tenant-Id"] = options.tenantId;
 
     const resp = await this.fetchFn(url, {
       method: "POST",
@@ -248,22 +349,48 @@ export class FeatureServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as { [key: string]: Bar[] };
+    this.cache?.invalidate(this.baseURL + "/api/v1/bars/combined");
+
+    const result = await resp.json() as { [key: string]: Bar[] };
+    reportResponseMetadata(resp, options?.onResponse);
+    return result;
   }
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
+    }
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
+export default { FeatureServiceClient };
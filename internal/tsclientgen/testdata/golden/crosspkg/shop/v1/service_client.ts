@@ -2,6 +2,7 @@
 // source: crosspkg/shop/v1/service.proto
 
 import { ApiError, ValidationError } from "../../../errors.js";
+import { type ResponseMetadata, reportResponseMetadata } from "../../../response_metadata.js";
 import type { GetItemRequest, GetItemResponse } from "./service.js";
 
 export interface ShopServiceClientOptions {
@@ -12,9 +13,20 @@ export interface ShopServiceClientOptions {
 export interface ShopServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
 }
 
+/**
+ * ShopService exercises generation across two proto packages: its request and
+ * response messages reference types from crosspkg.common.v1.
+ */
 export class ShopServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    getItem: { method: "POST", path: "/api/v1/get-item" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
@@ -25,9 +37,15 @@ export class ShopServiceClient {
     this.defaultHeaders = { ...options?.defaultHeaders };
   }
 
+  /** Builds the URL of getItem, relative to the client's base URL. */
+  static getItemUrl(): string {
+    const path = "/api/v1/get-item";
+    return path;
+  }
+
+  /** GetItem looks up a single item by its cross-package identifier. */
   async getItem(req: GetItemRequest, options?: ShopServiceCallOptions): Promise<GetItemResponse> {
-    let path = "/api/v1/get-item";
-    const url = this.baseURL + path;
+    const url = this.baseURL + ShopServiceClient.getItemUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -46,22 +64,46 @@ export class ShopServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as GetItemResponse;
+    const result = await resp.json() as GetItemResponse;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
+    }
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
+export default { ShopServiceClient };
@@ -7,10 +7,15 @@ export interface GetResponseRequest {
 
 export interface Response {
   id: string;
+  /** PRESERVE: empty message serializes as {} */
   metadataPreserve?: Metadata;
+  /** NULL: empty message serializes as null */
   metadataNull?: Metadata;
+  /** OMIT: empty message field is omitted */
   metadataOmit?: Metadata;
+  /** No annotation: follows default (PRESERVE) */
   metadataDefault?: Metadata;
+  /** Another message type to test */
   settings?: Settings;
 }
 
@@ -2,32 +2,55 @@
 // source: empty_request_body.proto
 
 import { ApiError, ValidationError } from "./errors.js";
+import { ResponseCache, type ResponseCacheOptions } from "./response_cache.js";
+import { type ResponseMetadata, reportResponseMetadata } from "./response_metadata.js";
 import type { NoArgsRequest, NoArgsResponse, PingRequest, PingResponse } from "./empty_request_body.js";
 
 export interface EmptyRequestBodyServiceClientOptions {
   fetch?: typeof fetch;
   defaultHeaders?: Record<string, string>;
+  cache?: ResponseCacheOptions;
 }
 
 export interface EmptyRequestBodyServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
 }
 
+/**
+ * EmptyRequestBodyService exercises requests whose message has no fields, across
+ * a verb that sends a body (POST) and one that does not (GET).
+ */
 export class EmptyRequestBodyServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    ping: { method: "POST", path: "/api/v1/ping" },
+    noArgs: { method: "GET", path: "/api/v1/no-args" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
+  private cache?: ResponseCache;
 
   constructor(baseURL: string, options?: EmptyRequestBodyServiceClientOptions) {
     this.baseURL = baseURL.replace(/\/+$/, "");
     this.fetchFn = options?.fetch ?? globalThis.fetch;
     this.defaultHeaders = { ...options?.defaultHeaders };
+    this.cache = options?.cache ? new ResponseCache(options.cache) : undefined;
+  }
+
+  /** Builds the URL of ping, relative to the client's base URL. */
+  static pingUrl(): string {
+    const path = "/api/v1/ping";
+    return path;
   }
 
+  /** Ping sends an empty JSON body over POST. */
   async ping(req: PingRequest, options?: EmptyRequestBodyServiceCallOptions): Promise<PingResponse> {
-    let path = "/api/v1/ping";
-    const url = this.baseURL + path;
+    const url = this.baseURL + EmptyRequestBodyServiceClient.pingUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -46,12 +69,22 @@ export class EmptyRequestBodyServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as PingResponse;
+    this.cache?.invalidate(this.baseURL + "/api/v1/ping");
+
+    const result = await resp.json() as PingResponse;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
+  /** Builds the URL of noArgs, relative to the client's base URL. */
+  static noArgsUrl(): string {
+    const path = "/api/v1/no-args";
+    return path;
+  }
+
+  /** NoArgs is a GET endpoint that takes no parameters. */
   async noArgs(_req: NoArgsRequest, options?: EmptyRequestBodyServiceCallOptions): Promise<NoArgsResponse> {
-    let path = "/api/v1/no-args";
-    const url = this.baseURL + path;
+    const url = this.baseURL + EmptyRequestBodyServiceClient.noArgsUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -59,32 +92,59 @@ export class EmptyRequestBodyServiceClient {
       ...options?.headers,
     };
 
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
+    const load = async (): Promise<NoArgsResponse> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
 
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
 
-    return await resp.json() as NoArgsResponse;
+      const result = await resp.json() as NoArgsResponse;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
   }
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
+    }
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
+export default { EmptyRequestBodyServiceClient };
@@ -6,13 +6,21 @@ export interface GetEnumTestRequest {
 }
 
 export interface EnumEncodingTest {
+  /** Default encoding with custom enum_value mappings */
   status: Status;
+  /** NUMBER encoding - should serialize as integer */
   priorityAsNumber: number;
+  /** STRING encoding (explicit, same as default) - should serialize as string */
   priorityAsString: Priority;
+  /** Default encoding (no annotation) - should serialize as string with proto names */
   defaultPriority: Priority;
+  /** Repeated enum with custom values */
   statusList: Status[];
+  /** Repeated enum with NUMBER encoding */
   numberPriorityList: number[];
+  /** Optional enum with custom values */
   optionalStatus?: Status;
+  /** Map with enum values carrying custom enum_value strings */
   statusMap: { [key: string]: Status };
 }
 
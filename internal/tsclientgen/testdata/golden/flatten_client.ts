@@ -2,6 +2,7 @@
 // source: flatten.proto
 
 import { ApiError, ValidationError } from "./errors.js";
+import { type ResponseMetadata, reportResponseMetadata } from "./response_metadata.js";
 import type { DualFlatten, MixedFlatten, PlainNested, SimpleFlatten } from "./flatten.js";
 
 export interface FlattenServiceClientOptions {
@@ -12,9 +13,20 @@ export interface FlattenServiceClientOptions {
 export interface FlattenServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
 }
 
+/** FlattenService tests flatten in service RPCs. */
 export class FlattenServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    testSimpleFlatten: { method: "POST", path: "/api/v1/flatten/simple" },
+    testDualFlatten: { method: "POST", path: "/api/v1/flatten/dual" },
+    testMixedFlatten: { method: "POST", path: "/api/v1/flatten/mixed" },
+    testPlainNested: { method: "POST", path: "/api/v1/flatten/plain" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
@@ -25,9 +37,14 @@ export class FlattenServiceClient {
     this.defaultHeaders = { ...options?.defaultHeaders };
   }
 
+  /** Builds the URL of testSimpleFlatten, relative to the client's base URL. */
+  static testSimpleFlattenUrl(): string {
+    const path = "/api/v1/flatten/simple";
+    return path;
+  }
+
   async testSimpleFlatten(req: SimpleFlatten, options?: FlattenServiceCallOptions): Promise<SimpleFlatten> {
-    let path = "/api/v1/flatten/simple";
-    const url = this.baseURL + path;
+    const url = this.baseURL + FlattenServiceClient.testSimpleFlattenUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -46,12 +63,19 @@ export class FlattenServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as SimpleFlatten;
+    const result = await resp.json() as SimpleFlatten;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
+  }
+
+  /** Builds the URL of testDualFlatten, relative to the client's base URL. */
+  static testDualFlattenUrl(): string {
+    const path = "/api/v1/flatten/dual";
+    return path;
   }
 
   async testDualFlatten(req: DualFlatten, options?: FlattenServiceCallOptions): Promise<DualFlatten> {
-    let path = "/api/v1/flatten/dual";
-    const url = this.baseURL + path;
+    const url = this.baseURL + FlattenServiceClient.testDualFlattenUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -70,12 +94,19 @@ export class FlattenServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as DualFlatten;
+    const result = await resp.json() as DualFlatten;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
+  }
+
+  /** Builds the URL of testMixedFlatten, relative to the client's base URL. */
+  static testMixedFlattenUrl(): string {
+    const path = "/api/v1/flatten/mixed";
+    return path;
   }
 
   async testMixedFlatten(req: MixedFlatten, options?: FlattenServiceCallOptions): Promise<MixedFlatten> {
-    let path = "/api/v1/flatten/mixed";
-    const url = this.baseURL + path;
+    const url = this.baseURL + FlattenServiceClient.testMixedFlattenUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -94,12 +125,19 @@ export class FlattenServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as MixedFlatten;
+    const result = await resp.json() as MixedFlatten;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
+  }
+
+  /** Builds the URL of testPlainNested, relative to the client's base URL. */
+  static testPlainNestedUrl(): string {
+    const path = "/api/v1/flatten/plain";
+    return path;
   }
 
   async testPlainNested(req: PlainNested, options?: FlattenServiceCallOptions): Promise<PlainNested> {
-    let path = "/api/v1/flatten/plain";
-    const url = this.baseURL + path;
+    const url = this.baseURL + FlattenServiceClient.testPlainNestedUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -118,22 +156,46 @@ export class FlattenServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as PlainNested;
+    const result = await resp.json() as PlainNested;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
+    }
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
+export default { FlattenServiceClient };
@@ -2,10 +2,12 @@
 // source: http_verbs_comprehensive.proto
 
 export interface ListResourcesRequest {
+  /** Query parameters */
   page: number;
   pageSize: number;
   filter: string;
   includeDeleted: boolean;
+  /** Extended scalar query params (int64, uint64, float, double) */
   sinceTimestamp: string;
   maxId: string;
   minScore: number;
@@ -54,6 +56,7 @@ export interface CreateResourceRequest {
 
 export interface UpdateResourceRequest {
   resourceId: string;
+  /** Body fields */
   name: string;
   description: string;
   metadata: { [key: string]: string };
@@ -61,6 +64,7 @@ export interface UpdateResourceRequest {
 
 export interface PatchResourceRequest {
   resourceId: string;
+  /** Fields for partial update (presence tracked via wrapper or empty check) */
   name: string;
   description: string;
 }
@@ -74,6 +78,11 @@ export interface DeleteResourceResponse {
 }
 
 export interface DefaultPostRequest {
+  /**
+   * Action to perform on the legacy endpoint
+   *
+   * @deprecated
+   */
   action: string;
 }
 
@@ -2,131 +2,211 @@
 // source: http_verbs_comprehensive.proto
 
 import { ApiError, ValidationError } from "./errors.js";
-import type { CreateResourceRequest, DefaultPostRequest, DefaultPostResponse, DeleteResourceRequest, DeleteResourceResponse, GetNestedResourceRequest, GetResourceRequest, LegacyRequest, LegacyResponse, ListResourcesRequest, ListResourcesResponse, PatchResourceRequest, Resource, SearchResourcesRequest, UpdateResourceRequest } from "./http_verbs_comprehensive.js";
+import { ResponseCache, type ResponseCacheOptions } from "./response_cache.js";
+import { type ResponseMetadata, reportResponseMetadata } from "./response_metadata.js";
+import type { CreateResourceRequest, DefaultPostRequest, DefaultPostResponse, DeleteResourceRequest, DeleteResourceResponse, GetNestedResourceRequest, GetResourceRequest, LegacyRequest, LegacyResponse, ListResourcesRequest, ListResourcesResponse, PatchResourceRequest, Resource, ResourceStatus, SearchResourcesRequest, UpdateResourceRequest } from "./http_verbs_comprehensive.js";
 
 export interface RESTfulAPIServiceClientOptions {
   fetch?: typeof fetch;
   defaultHeaders?: Record<string, string>;
+  cache?: ResponseCacheOptions;
   apiKey?: string;
+  clientVersion?: string;
 }
 
 export interface RESTfulAPIServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
   apiKey?: string;
+  clientVersion?: string;
+  acceptLanguage?: string;
+  resourceTag?: string[];
   requestId?: string;
 }
 
+/** RESTfulAPIService tests all HTTP verbs with various parameter combinations */
 export class RESTfulAPIServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    listResources: { method: "GET", path: "/api/v1/resources" },
+    getResource: { method: "GET", path: "/api/v1/resources/{resource_id}" },
+    getNestedResource: { method: "GET", path: "/api/v1/orgs/{org_id}/teams/{team_id}/resources/{resource_id}" },
+    createResource: { method: "POST", path: "/api/v1/resources" },
+    updateResource: { method: "PUT", path: "/api/v1/resources/{resource_id}" },
+    patchResource: { method: "PATCH", path: "/api/v1/resources/{resource_id}" },
+    deleteResource: { method: "DELETE", path: "/api/v1/resources/{resource_id}" },
+    defaultPostMethod: { method: "POST", path: "/api/v1/legacy/action" },
+    searchResources: { method: "GET", path: "/api/v1/resources/search" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
+  private cache?: ResponseCache;
 
   constructor(baseURL: string, options?: RESTfulAPIServiceClientOptions) {
     this.baseURL = baseURL.replace(/\/+$/, "");
     this.fetchFn = options?.fetch ?? globalThis.fetch;
     this.defaultHeaders = { ...options?.defaultHeaders };
+    this.cache = options?.cache ? new ResponseCache(options.cache) : undefined;
     if (options?.apiKey) {
-      this.defaultHeaders["X-API-Key"] = options.apiKey;
+      this.defaultHeaders["X-Api-Key"] = options.apiKey;
+    }
+    if (options?.clientVersion) {
+      this.defaultHeaders["X-Client-Version"] = options.clientVersion;
     }
   }
 
+  /** Builds the URL of listResources, relative to the client's base URL. */
+  static listResourcesUrl(query: { page?: number; pageSize?: number; filter?: string; includeDeleted?: boolean; sinceTimestamp?: string; maxId?: string; minScore?: number; maxScore?: number } = {}): string {
+    const path = "/api/v1/resources";
+    const search = new URLSearchParams();
+    if (query.page != null && query.page !== 0) search.set("page", String(query.page));
+    if (query.pageSize != null && query.pageSize !== 0) search.set("page_size", String(query.pageSize));
+    if (query.filter != null && query.filter !== "") search.set("filter", String(query.filter));
+    if (query.includeDeleted) search.set("include_deleted", String(query.includeDeleted));
+    if (query.sinceTimestamp != null && query.sinceTimestamp !== "0") search.set("since_timestamp", String(query.sinceTimestamp));
+    if (query.maxId != null && query.maxId !== "0") search.set("max_id", String(query.maxId));
+    if (query.minScore != null && query.minScore !== 0) search.set("min_score", String(query.minScore));
+    if (query.maxScore != null && query.maxScore !== 0) search.set("max_score", String(query.maxScore));
+    search.sort();
+    const queryString = search.toString();
+    return queryString ? path + "?" + queryString : path;
+  }
+
+  /** GET - List all resources with query parameters */
   async listResources(req: ListResourcesRequest, options?: RESTfulAPIServiceCallOptions): Promise<ListResourcesResponse> {
-    let path = "/api/v1/resources";
-    const params = new URLSearchParams();
-    if (req.page != null && req.page !== 0) params.set("page", String(req.page));
-    if (req.pageSize != null && req.pageSize !== 0) params.set("page_size", String(req.pageSize));
-    if (req.filter != null && req.filter !== "") params.set("filter", String(req.filter));
-    if (req.includeDeleted) params.set("include_deleted", String(req.includeDeleted));
-    if (req.sinceTimestamp != null && req.sinceTimestamp !== "0") params.set("since_timestamp", String(req.sinceTimestamp));
-    if (req.maxId != null && req.maxId !== "0") params.set("max_id", String(req.maxId));
-    if (req.minScore != null && req.minScore !== 0) params.set("min_score", String(req.minScore));
-    if (req.maxScore != null && req.maxScore !== 0) params.set("max_score", String(req.maxScore));
-    const url = this.baseURL + path + (params.toString() ? "?" + params.toString() : "");
+    const url = this.baseURL + RESTfulAPIServiceClient.listResourcesUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
+      "Accept-Language": "en-US",
+      "X-Client-Version": "1.0.0",
       ...this.defaultHeaders,
       ...options?.headers,
     };
-    if (options?.apiKey) headers["X-API-Key"] = options.apiKey;
-
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
+    if (options?.apiKey) headers["X-Api-Key"] = options.apiKey;
+    if (options?.clientVersion) headers["X-Client-Version"] = options.clientVersion;
+    if (options?.acceptLanguage) headers["Accept-Language"] = options.acceptLanguage;
+    if (options?.resourceTag) headers["X-Resource-Tag"] = options.resourceTag.join(", ");
+
+    const load = async (): Promise<ListResourcesResponse> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
+
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
 
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+      const result = await resp.json() as ListResourcesResponse;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
+  }
 
-    return await resp.json() as ListResourcesResponse;
+  /** Builds the URL of getResource, relative to the client's base URL. */
+  static getResourceUrl(params: { resourceId: string }): string {
+    let path = "/api/v1/resources/{resource_id}";
+    path = path.replace("{resource_id}", encodeURIComponent(String(params.resourceId)));
+    return path;
   }
 
+  /** GET - Get single resource with path parameter */
   async getResource(req: GetResourceRequest, options?: RESTfulAPIServiceCallOptions): Promise<Resource> {
-    let path = "/api/v1/resources/{resource_id}";
-    path = path.replace("{resource_id}", encodeURIComponent(String(req.resourceId)));
-    const url = this.baseURL + path;
+    const url = this.baseURL + RESTfulAPIServiceClient.getResourceUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
+      "X-Client-Version": "1.0.0",
       ...this.defaultHeaders,
       ...options?.headers,
     };
-    if (options?.apiKey) headers["X-API-Key"] = options.apiKey;
-
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
+    if (options?.apiKey) headers["X-Api-Key"] = options.apiKey;
+    if (options?.clientVersion) headers["X-Client-Version"] = options.clientVersion;
+
+    const load = async (): Promise<Resource> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
+
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
 
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+      const result = await resp.json() as Resource;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
+  }
 
-    return await resp.json() as Resource;
+  /** Builds the URL of getNestedResource, relative to the client's base URL. */
+  static getNestedResourceUrl(params: { orgId: string; teamId: string; resourceId: string }): string {
+    let path = "/api/v1/orgs/{org_id}/teams/{team_id}/resources/{resource_id}";
+    path = path.replace("{org_id}", encodeURIComponent(String(params.orgId)));
+    path = path.replace("{team_id}", encodeURIComponent(String(params.teamId)));
+    path = path.replace("{resource_id}", encodeURIComponent(String(params.resourceId)));
+    return path;
   }
 
+  /** GET - Nested resource with multiple path parameters */
   async getNestedResource(req: GetNestedResourceRequest, options?: RESTfulAPIServiceCallOptions): Promise<Resource> {
-    let path = "/api/v1/orgs/{org_id}/teams/{team_id}/resources/{resource_id}";
-    path = path.replace("{org_id}", encodeURIComponent(String(req.orgId)));
-    path = path.replace("{team_id}", encodeURIComponent(String(req.teamId)));
-    path = path.replace("{resource_id}", encodeURIComponent(String(req.resourceId)));
-    const url = this.baseURL + path;
+    const url = this.baseURL + RESTfulAPIServiceClient.getNestedResourceUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
+      "X-Client-Version": "1.0.0",
       ...this.defaultHeaders,
       ...options?.headers,
     };
-    if (options?.apiKey) headers["X-API-Key"] = options.apiKey;
-
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
+    if (options?.apiKey) headers["X-Api-Key"] = options.apiKey;
+    if (options?.clientVersion) headers["X-Client-Version"] = options.clientVersion;
+
+    const load = async (): Promise<Resource> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
+
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
 
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+      const result = await resp.json() as Resource;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
+  }
 
-    return await resp.json() as Resource;
+  /** Builds the URL of createResource, relative to the client's base URL. */
+  static createResourceUrl(): string {
+    const path = "/api/v1/resources";
+    return path;
   }
 
+  /** POST - Create new resource with request body */
   async createResource(req: CreateResourceRequest, options?: RESTfulAPIServiceCallOptions): Promise<Resource> {
-    let path = "/api/v1/resources";
-    const url = this.baseURL + path;
+    const url = this.baseURL + RESTfulAPIServiceClient.createResourceUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
+      "X-Client-Version": "1.0.0",
       ...this.defaultHeaders,
       ...options?.headers,
     };
-    if (options?.apiKey) headers["X-API-Key"] = options.apiKey;
-    if (options?.requestId) headers["X-Request-ID"] = options.requestId;
+    if (options?.apiKey) headers["X-Api-Key"] = options.apiKey;
+    if (options?.clientVersion) headers["X-Client-Version"] = options.clientVersion;
+    if (options?.requestId) headers["X-Request-Id"] = options.requestId;
 
     const resp = await this.fetchFn(url, {
       method: "POST",
@@ -139,20 +219,32 @@ export class RESTfulAPIServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as Resource;
+    this.cache?.invalidate(this.baseURL + "/api/v1/resources");
+
+    const result = await resp.json() as Resource;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
-  async updateResource(req: UpdateResourceRequest, options?: RESTfulAPIServiceCallOptions): Promise<Resource> {
+  /** Builds the URL of updateResource, relative to the client's base URL. */
+  static updateResourceUrl(params: { resourceId: string }): string {
     let path = "/api/v1/resources/{resource_id}";
-    path = path.replace("{resource_id}", encodeURIComponent(String(req.resourceId)));
-    const url = this.baseURL + path;
+    path = path.replace("{resource_id}", encodeURIComponent(String(params.resourceId)));
+    return path;
+  }
+
+  /** PUT - Full update with path param and body */
+  async updateResource(req: UpdateResourceRequest, options?: RESTfulAPIServiceCallOptions): Promise<Resource> {
+    const url = this.baseURL + RESTfulAPIServiceClient.updateResourceUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
+      "X-Client-Version": "1.0.0",
       ...this.defaultHeaders,
       ...options?.headers,
     };
-    if (options?.apiKey) headers["X-API-Key"] = options.apiKey;
+    if (options?.apiKey) headers["X-Api-Key"] = options.apiKey;
+    if (options?.clientVersion) headers["X-Client-Version"] = options.clientVersion;
 
     const resp = await this.fetchFn(url, {
       method: "PUT",
@@ -165,20 +257,32 @@ export class RESTfulAPIServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as Resource;
+    this.cache?.invalidate(this.baseURL + "/api/v1/resources");
+
+    const result = await resp.json() as Resource;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
-  async patchResource(req: PatchResourceRequest, options?: RESTfulAPIServiceCallOptions): Promise<Resource> {
+  /** Builds the URL of patchResource, relative to the client's base URL. */
+  static patchResourceUrl(params: { resourceId: string }): string {
     let path = "/api/v1/resources/{resource_id}";
-    path = path.replace("{resource_id}", encodeURIComponent(String(req.resourceId)));
-    const url = this.baseURL + path;
+    path = path.replace("{resource_id}", encodeURIComponent(String(params.resourceId)));
+    return path;
+  }
+
+  /** PATCH - Partial update with path param and body */
+  async patchResource(req: PatchResourceRequest, options?: RESTfulAPIServiceCallOptions): Promise<Resource> {
+    const url = this.baseURL + RESTfulAPIServiceClient.patchResourceUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
+      "X-Client-Version": "1.0.0",
       ...this.defaultHeaders,
       ...options?.headers,
     };
-    if (options?.apiKey) headers["X-API-Key"] = options.apiKey;
+    if (options?.apiKey) headers["X-Api-Key"] = options.apiKey;
+    if (options?.clientVersion) headers["X-Client-Version"] = options.clientVersion;
 
     const resp = await this.fetchFn(url, {
       method: "PATCH",
@@ -191,20 +295,32 @@ export class RESTfulAPIServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as Resource;
+    this.cache?.invalidate(this.baseURL + "/api/v1/resources");
+
+    const result = await resp.json() as Resource;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
-  async deleteResource(req: DeleteResourceRequest, options?: RESTfulAPIServiceCallOptions): Promise<DeleteResourceResponse> {
+  /** Builds the URL of deleteResource, relative to the client's base URL. */
+  static deleteResourceUrl(params: { resourceId: string }): string {
     let path = "/api/v1/resources/{resource_id}";
-    path = path.replace("{resource_id}", encodeURIComponent(String(req.resourceId)));
-    const url = this.baseURL + path;
+    path = path.replace("{resource_id}", encodeURIComponent(String(params.resourceId)));
+    return path;
+  }
+
+  /** DELETE - Delete resource with path parameter */
+  async deleteResource(req: DeleteResourceRequest, options?: RESTfulAPIServiceCallOptions): Promise<DeleteResourceResponse> {
+    const url = this.baseURL + RESTfulAPIServiceClient.deleteResourceUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
+      "X-Client-Version": "1.0.0",
       ...this.defaultHeaders,
       ...options?.headers,
     };
-    if (options?.apiKey) headers["X-API-Key"] = options.apiKey;
+    if (options?.apiKey) headers["X-Api-Key"] = options.apiKey;
+    if (options?.clientVersion) headers["X-Client-Version"] = options.clientVersion;
 
     const resp = await this.fetchFn(url, {
       method: "DELETE",
@@ -216,19 +332,35 @@ export class RESTfulAPIServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as DeleteResourceResponse;
+    this.cache?.invalidate(this.baseURL + "/api/v1/resources");
+
+    const result = await resp.json() as DeleteResourceResponse;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
+  /** Builds the URL of defaultPostMethod, relative to the client's base URL. */
+  static defaultPostMethodUrl(): string {
+    const path = "/api/v1/legacy/action";
+    return path;
+  }
+
+  /**
+   * Default POST - Method without explicit HTTP method should default to POST
+   *
+   * @deprecated
+   */
   async defaultPostMethod(req: DefaultPostRequest, options?: RESTfulAPIServiceCallOptions): Promise<DefaultPostResponse> {
-    let path = "/api/v1/legacy/action";
-    const url = this.baseURL + path;
+    const url = this.baseURL + RESTfulAPIServiceClient.defaultPostMethodUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
+      "X-Client-Version": "1.0.0",
       ...this.defaultHeaders,
       ...options?.headers,
     };
-    if (options?.apiKey) headers["X-API-Key"] = options.apiKey;
+    if (options?.apiKey) headers["X-Api-Key"] = options.apiKey;
+    if (options?.clientVersion) headers["X-Client-Version"] = options.clientVersion;
 
     const resp = await this.fetchFn(url, {
       method: "POST",
@@ -241,49 +373,89 @@ export class RESTfulAPIServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as DefaultPostResponse;
+    this.cache?.invalidate(this.baseURL + "/api/v1/legacy/action");
+
+    const result = await resp.json() as DefaultPostResponse;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
+  /** Builds the URL of searchResources, relative to the client's base URL. */
+  static searchResourcesUrl(query: { statusFilter?: ResourceStatus; query?: string } = {}): string {
+    const path = "/api/v1/resources/search";
+    const search = new URLSearchParams();
+    if (query.statusFilter != null && query.statusFilter !== "RESOURCE_STATUS_UNSPECIFIED") search.set("status", String(query.statusFilter));
+    if (query.query != null && query.query !== "") search.set("q", String(query.query));
+    search.sort();
+    const queryString = search.toString();
+    return queryString ? path + "?" + queryString : path;
+  }
+
+  /** GET - Search resources with enum and string query params */
   async searchResources(req: SearchResourcesRequest, options?: RESTfulAPIServiceCallOptions): Promise<ListResourcesResponse> {
-    let path = "/api/v1/resources/search";
-    const params = new URLSearchParams();
-    if (req.statusFilter != null && req.statusFilter !== "RESOURCE_STATUS_UNSPECIFIED") params.set("status", String(req.statusFilter));
-    if (req.query != null && req.query !== "") params.set("q", String(req.query));
-    const url = this.baseURL + path + (params.toString() ? "?" + params.toString() : "");
+    const url = this.baseURL + RESTfulAPIServiceClient.searchResourcesUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
+      "X-Client-Version": "1.0.0",
       ...this.defaultHeaders,
       ...options?.headers,
     };
-    if (options?.apiKey) headers["X-API-Key"] = options.apiKey;
-
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
-
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+    if (options?.apiKey) headers["X-Api-Key"] = options.apiKey;
+    if (options?.clientVersion) headers["X-Client-Version"] = options.clientVersion;
+
+    const load = async (): Promise<ListResourcesResponse> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
+
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
 
-    return await resp.json() as ListResourcesResponse;
+      const result = await resp.json() as ListResourcesResponse;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
   }
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
+    }
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
@@ -295,9 +467,17 @@ export interface BackwardCompatServiceClientOptions {
 export interface BackwardCompatServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
 }
 
+/** BackwardCompatService tests backward compatibility (no HTTP annotations) */
 export class BackwardCompatServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    legacyAction: { method: "POST", path: "/legacyAction" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
@@ -308,9 +488,15 @@ export class BackwardCompatServiceClient {
     this.defaultHeaders = { ...options?.defaultHeaders };
   }
 
+  /** Builds the URL of legacyAction, relative to the client's base URL. */
+  static legacyActionUrl(): string {
+    const path = "/legacyAction";
+    return path;
+  }
+
+  /** RPC without HTTP config - should default to POST */
   async legacyAction(req: LegacyRequest, options?: BackwardCompatServiceCallOptions): Promise<LegacyResponse> {
-    let path = "/legacyAction";
-    const url = this.baseURL + path;
+    const url = this.baseURL + BackwardCompatServiceClient.legacyActionUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -329,22 +515,46 @@ export class BackwardCompatServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as LegacyResponse;
+    const result = await resp.json() as LegacyResponse;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
+    }
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
+export default { RESTfulAPIServiceClient, BackwardCompatServiceClient };
@@ -6,17 +6,32 @@ export interface GetInt64TestRequest {
 }
 
 export interface Int64EncodingTest {
+  /** Default int64 (no annotation) - should be string in JSON */
   defaultInt64: string;
+  /** Explicit STRING encoding - should be string in JSON */
   stringInt64: string;
+  /** NUMBER encoding - should be number in JSON (precision risk for > 2^53) */
   numberInt64: number;
+  /** Default uint64 (no annotation) - should be string in JSON */
   defaultUint64: string;
+  /** NUMBER encoded uint64 - should be number in JSON */
   numberUint64: number;
+  /** sint64 with NUMBER encoding */
   numberSint64: number;
+  /** sfixed64 with NUMBER encoding */
   numberSfixed64: number;
+  /** fixed64 with NUMBER encoding */
   numberFixed64: number;
+  /** Repeated int64 with NUMBER encoding */
   repeatedNumberInt64: number[];
+  /** Repeated int64 with default STRING encoding */
   repeatedDefaultInt64: string[];
+  /** Optional int64 with NUMBER encoding */
   optionalNumberInt64?: number;
+  /**
+   * int64 field with leading comment (for description test)
+   * This is the user's unique identifier
+   */
   commentedNumberInt64: number;
 }
 
@@ -2,33 +2,51 @@
 // source: int64_encoding.proto
 
 import { ApiError, ValidationError } from "./errors.js";
+import { ResponseCache, type ResponseCacheOptions } from "./response_cache.js";
+import { type ResponseMetadata, reportResponseMetadata } from "./response_metadata.js";
 import type { GetInt64TestRequest, Int64EncodingTest } from "./int64_encoding.js";
 
 export interface Int64EncodingServiceClientOptions {
   fetch?: typeof fetch;
   defaultHeaders?: Record<string, string>;
+  cache?: ResponseCacheOptions;
 }
 
 export interface Int64EncodingServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
 }
 
+/** Service with int64 encoding test */
 export class Int64EncodingServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    getInt64Test: { method: "GET", path: "/api/v1/test/int64/{id}" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
+  private cache?: ResponseCache;
 
   constructor(baseURL: string, options?: Int64EncodingServiceClientOptions) {
     this.baseURL = baseURL.replace(/\/+$/, "");
     this.fetchFn = options?.fetch ?? globalThis.fetch;
     this.defaultHeaders = { ...options?.defaultHeaders };
+    this.cache = options?.cache ? new ResponseCache(options.cache) : undefined;
   }
 
-  async getInt64Test(req: GetInt64TestRequest, options?: Int64EncodingServiceCallOptions): Promise<Int64EncodingTest> {
+  /** Builds the URL of getInt64Test, relative to the client's base URL. */
+  static getInt64TestUrl(params: { id: string }): string {
     let path = "/api/v1/test/int64/{id}";
-    path = path.replace("{id}", encodeURIComponent(String(req.id)));
-    const url = this.baseURL + path;
+    path = path.replace("{id}", encodeURIComponent(String(params.id)));
+    return path;
+  }
+
+  async getInt64Test(req: GetInt64TestRequest, options?: Int64EncodingServiceCallOptions): Promise<Int64EncodingTest> {
+    const url = this.baseURL + Int64EncodingServiceClient.getInt64TestUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -36,32 +54,59 @@ export class Int64EncodingServiceClient {
       ...options?.headers,
     };
 
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
+    const load = async (): Promise<Int64EncodingTest> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
 
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
 
-    return await resp.json() as Int64EncodingTest;
+      const result = await resp.json() as Int64EncodingTest;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
   }
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
+    }
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
+export default { Int64EncodingServiceClient };
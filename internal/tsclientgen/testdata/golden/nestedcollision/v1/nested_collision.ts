@@ -8,8 +8,11 @@ export interface GetStatusRequest {
 }
 
 export interface GetStatusResponse {
+  /** Resolves to the top-level Status. */
   status?: Status;
+  /** Resolves to the top-level Kind. */
   kind: Kind;
+  /** Pulls in Wrapper (and its parent-qualified nested types) from wrapper.proto. */
   wrapper?: Wrapper;
 }
 
@@ -2,33 +2,52 @@
 // source: nullable.proto
 
 import { ApiError, ValidationError } from "./errors.js";
+import { ResponseCache, type ResponseCacheOptions } from "./response_cache.js";
+import { type ResponseMetadata, reportResponseMetadata } from "./response_metadata.js";
 import type { GetUserRequest, UpdateUserRequest, User } from "./nullable.js";
 
 export interface NullableServiceClientOptions {
   fetch?: typeof fetch;
   defaultHeaders?: Record<string, string>;
+  cache?: ResponseCacheOptions;
 }
 
 export interface NullableServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
 }
 
+/** NullableService tests nullable fields in requests and responses. */
 export class NullableServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    getUser: { method: "GET", path: "/api/v1/users/{id}" },
+    updateUser: { method: "PUT", path: "/api/v1/users/{id}" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
+  private cache?: ResponseCache;
 
   constructor(baseURL: string, options?: NullableServiceClientOptions) {
     this.baseURL = baseURL.replace(/\/+$/, "");
     this.fetchFn = options?.fetch ?? globalThis.fetch;
     this.defaultHeaders = { ...options?.defaultHeaders };
+    this.cache = options?.cache ? new ResponseCache(options.cache) : undefined;
   }
 
-  async getUser(req: GetUserRequest, options?: NullableServiceCallOptions): Promise<User> {
+  /** Builds the URL of getUser, relative to the client's base URL. */
+  static getUserUrl(params: { id: string }): string {
     let path = "/api/v1/users/{id}";
-    path = path.replace("{id}", encodeURIComponent(String(req.id)));
-    const url = this.baseURL + path;
+    path = path.replace("{id}", encodeURIComponent(String(params.id)));
+    return path;
+  }
+
+  async getUser(req: GetUserRequest, options?: NullableServiceCallOptions): Promise<User> {
+    const url = this.baseURL + NullableServiceClient.getUserUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -36,23 +55,33 @@ export class NullableServiceClient {
       ...options?.headers,
     };
 
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
+    const load = async (): Promise<User> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
 
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
 
-    return await resp.json() as User;
+      const result = await resp.json() as User;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
   }
 
-  async updateUser(req: UpdateUserRequest, options?: NullableServiceCallOptions): Promise<User> {
+  /** Builds the URL of updateUser, relative to the client's base URL. */
+  static updateUserUrl(params: { id: string }): string {
     let path = "/api/v1/users/{id}";
-    path = path.replace("{id}", encodeURIComponent(String(req.id)));
-    const url = this.baseURL + path;
+    path = path.replace("{id}", encodeURIComponent(String(params.id)));
+    return path;
+  }
+
+  async updateUser(req: UpdateUserRequest, options?: NullableServiceCallOptions): Promise<User> {
+    const url = this.baseURL + NullableServiceClient.updateUserUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -71,22 +100,48 @@ export class NullableServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as User;
+    this.cache?.invalidate(this.baseURL + "/api/v1/users");
+
+    const result = await resp.json() as User;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
+    }
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
+export default { NullableServiceClient };
@@ -2,6 +2,7 @@
 // source: oneof_discriminator.proto
 
 import { ApiError, ValidationError } from "./errors.js";
+import { type ResponseMetadata, reportResponseMetadata } from "./response_metadata.js";
 import type { FlattenedEvent, NestedEvent, PlainEvent } from "./oneof_discriminator.js";
 
 export interface OneofDiscriminatorServiceClientOptions {
@@ -12,9 +13,18 @@ export interface OneofDiscriminatorServiceClientOptions {
 export interface OneofDiscriminatorServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
 }
 
 export class OneofDiscriminatorServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    testFlattenedEvent: { method: "POST", path: "/api/v1/events/flattened" },
+    testNestedEvent: { method: "POST", path: "/api/v1/events/nested" },
+    testPlainEvent: { method: "POST", path: "/api/v1/events/plain" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
@@ -25,9 +35,14 @@ export class OneofDiscriminatorServiceClient {
     this.defaultHeaders = { ...options?.defaultHeaders };
   }
 
+  /** Builds the URL of testFlattenedEvent, relative to the client's base URL. */
+  static testFlattenedEventUrl(): string {
+    const path = "/api/v1/events/flattened";
+    return path;
+  }
+
   async testFlattenedEvent(req: FlattenedEvent, options?: OneofDiscriminatorServiceCallOptions): Promise<FlattenedEvent> {
-    let path = "/api/v1/events/flattened";
-    const url = this.baseURL + path;
+    const url = this.baseURL + OneofDiscriminatorServiceClient.testFlattenedEventUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -46,12 +61,19 @@ export class OneofDiscriminatorServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as FlattenedEvent;
+    const result = await resp.json() as FlattenedEvent;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
+  }
+
+  /** Builds the URL of testNestedEvent, relative to the client's base URL. */
+  static testNestedEventUrl(): string {
+    const path = "/api/v1/events/nested";
+    return path;
   }
 
   async testNestedEvent(req: NestedEvent, options?: OneofDiscriminatorServiceCallOptions): Promise<NestedEvent> {
-    let path = "/api/v1/events/nested";
-    const url = this.baseURL + path;
+    const url = this.baseURL + OneofDiscriminatorServiceClient.testNestedEventUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -70,12 +92,19 @@ export class OneofDiscriminatorServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as NestedEvent;
+    const result = await resp.json() as NestedEvent;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
+  }
+
+  /** Builds the URL of testPlainEvent, relative to the client's base URL. */
+  static testPlainEventUrl(): string {
+    const path = "/api/v1/events/plain";
+    return path;
   }
 
   async testPlainEvent(req: PlainEvent, options?: OneofDiscriminatorServiceCallOptions): Promise<PlainEvent> {
-    let path = "/api/v1/events/plain";
-    const url = this.baseURL + path;
+    const url = this.baseURL + OneofDiscriminatorServiceClient.testPlainEventUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -94,22 +123,46 @@ export class OneofDiscriminatorServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as PlainEvent;
+    const result = await resp.json() as PlainEvent;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
+    }
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
+export default { OneofDiscriminatorServiceClient };
@@ -2,6 +2,7 @@
 // source: oneof_field_typing.proto
 
 import { ApiError, ValidationError } from "./errors.js";
+import { type ResponseMetadata, reportResponseMetadata } from "./response_metadata.js";
 import type { OneofFieldTyping } from "./oneof_field_typing.js";
 
 export interface OneofFieldTypingServiceClientOptions {
@@ -12,9 +13,16 @@ export interface OneofFieldTypingServiceClientOptions {
 export interface OneofFieldTypingServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
 }
 
 export class OneofFieldTypingServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    testOneofFieldTyping: { method: "POST", path: "/api/v1/oneof-field-typing" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
@@ -25,9 +33,14 @@ export class OneofFieldTypingServiceClient {
     this.defaultHeaders = { ...options?.defaultHeaders };
   }
 
+  /** Builds the URL of testOneofFieldTyping, relative to the client's base URL. */
+  static testOneofFieldTypingUrl(): string {
+    const path = "/api/v1/oneof-field-typing";
+    return path;
+  }
+
   async testOneofFieldTyping(req: OneofFieldTyping, options?: OneofFieldTypingServiceCallOptions): Promise<OneofFieldTyping> {
-    let path = "/api/v1/oneof-field-typing";
-    const url = this.baseURL + path;
+    const url = this.baseURL + OneofFieldTypingServiceClient.testOneofFieldTypingUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -46,22 +59,46 @@ export class OneofFieldTypingServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as OneofFieldTyping;
+    const result = await resp.json() as OneofFieldTyping;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
+    }
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
+export default { OneofFieldTypingServiceClient };
@@ -2,41 +2,68 @@
 // source: query_params.proto
 
 import { ApiError, ValidationError } from "./errors.js";
-import type { EmptyRequest, GetByRegionRequest, GetWithFiltersRequest, SearchAdvancedRequest, SearchCustomNamesRequest, SearchRequiredRequest, SearchResponse, SearchWithTypesRequest } from "./query_params.js";
+import { ResponseCache, type ResponseCacheOptions } from "./response_cache.js";
+import { type ResponseMetadata, reportResponseMetadata } from "./response_metadata.js";
+import type { EmptyRequest, GetByRegionRequest, GetWithFiltersRequest, Region, SearchAdvancedRequest, SearchCustomNamesRequest, SearchRequiredRequest, SearchResponse, SearchWithTypesRequest } from "./query_params.js";
 
 export interface QueryParamServiceClientOptions {
   fetch?: typeof fetch;
   defaultHeaders?: Record<string, string>;
+  cache?: ResponseCacheOptions;
 }
 
 export interface QueryParamServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
 }
 
+/** QueryParamService tests various query parameter configurations */
 export class QueryParamServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    searchWithTypes: { method: "GET", path: "/api/search/typed" },
+    searchRequired: { method: "GET", path: "/api/search/required" },
+    searchCustomNames: { method: "GET", path: "/api/search/custom" },
+    getWithFilters: { method: "GET", path: "/api/resources/{resource_id}/items" },
+    searchAdvanced: { method: "GET", path: "/api/search/advanced" },
+    getByRegion: { method: "GET", path: "/api/regions/{region}" },
+    getDefaults: { method: "GET", path: "/api/defaults" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
+  private cache?: ResponseCache;
 
   constructor(baseURL: string, options?: QueryParamServiceClientOptions) {
     this.baseURL = baseURL.replace(/\/+$/, "");
     this.fetchFn = options?.fetch ?? globalThis.fetch;
     this.defaultHeaders = { ...options?.defaultHeaders };
+    this.cache = options?.cache ? new ResponseCache(options.cache) : undefined;
+  }
+
+  /** Builds the URL of searchWithTypes, relative to the client's base URL. */
+  static searchWithTypesUrl(query: { query?: string; limit?: number; offset?: string; active?: boolean; minScore?: number; maxScore?: number; page?: number; timestamp?: string } = {}): string {
+    const path = "/api/search/typed";
+    const search = new URLSearchParams();
+    if (query.query != null && query.query !== "") search.set("q", String(query.query));
+    if (query.limit != null && query.limit !== 0) search.set("limit", String(query.limit));
+    if (query.offset != null && query.offset !== "0") search.set("offset", String(query.offset));
+    if (query.active) search.set("active", String(query.active));
+    if (query.minScore != null && query.minScore !== 0) search.set("min_score", String(query.minScore));
+    if (query.maxScore != null && query.maxScore !== 0) search.set("max_score", String(query.maxScore));
+    if (query.page != null && query.page !== 0) search.set("page", String(query.page));
+    if (query.timestamp != null && query.timestamp !== "0") search.set("ts", String(query.timestamp));
+    search.sort();
+    const queryString = search.toString();
+    return queryString ? path + "?" + queryString : path;
   }
 
+  /** All scalar types as query params */
   async searchWithTypes(req: SearchWithTypesRequest, options?: QueryParamServiceCallOptions): Promise<SearchResponse> {
-    let path = "/api/search/typed";
-    const params = new URLSearchParams();
-    if (req.query != null && req.query !== "") params.set("q", String(req.query));
-    if (req.limit != null && req.limit !== 0) params.set("limit", String(req.limit));
-    if (req.offset != null && req.offset !== "0") params.set("offset", String(req.offset));
-    if (req.active) params.set("active", String(req.active));
-    if (req.minScore != null && req.minScore !== 0) params.set("min_score", String(req.minScore));
-    if (req.maxScore != null && req.maxScore !== 0) params.set("max_score", String(req.maxScore));
-    if (req.page != null && req.page !== 0) params.set("page", String(req.page));
-    if (req.timestamp != null && req.timestamp !== "0") params.set("ts", String(req.timestamp));
-    const url = this.baseURL + path + (params.toString() ? "?" + params.toString() : "");
+    const url = this.baseURL + QueryParamServiceClient.searchWithTypesUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -44,26 +71,39 @@ export class QueryParamServiceClient {
       ...options?.headers,
     };
 
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
+    const load = async (): Promise<SearchResponse> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
 
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
 
-    return await resp.json() as SearchResponse;
+      const result = await resp.json() as SearchResponse;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
   }
 
+  /** Builds the URL of searchRequired, relative to the client's base URL. */
+  static searchRequiredUrl(query: { query?: string; page?: number; pageSize?: number } = {}): string {
+    const path = "/api/search/required";
+    const search = new URLSearchParams();
+    if (query.query != null && query.query !== "") search.set("q", String(query.query));
+    if (query.page != null && query.page !== 0) search.set("page", String(query.page));
+    if (query.pageSize != null && query.pageSize !== 0) search.set("page_size", String(query.pageSize));
+    search.sort();
+    const queryString = search.toString();
+    return queryString ? path + "?" + queryString : path;
+  }
+
+  /** Required vs optional query params */
   async searchRequired(req: SearchRequiredRequest, options?: QueryParamServiceCallOptions): Promise<SearchResponse> {
-    let path = "/api/search/required";
-    const params = new URLSearchParams();
-    if (req.query != null && req.query !== "") params.set("q", String(req.query));
-    if (req.page != null && req.page !== 0) params.set("page", String(req.page));
-    if (req.pageSize != null && req.pageSize !== 0) params.set("page_size", String(req.pageSize));
-    const url = this.baseURL + path + (params.toString() ? "?" + params.toString() : "");
+    const url = this.baseURL + QueryParamServiceClient.searchRequiredUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -71,28 +111,41 @@ export class QueryParamServiceClient {
       ...options?.headers,
     };
 
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
+    const load = async (): Promise<SearchResponse> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
 
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
 
-    return await resp.json() as SearchResponse;
+      const result = await resp.json() as SearchResponse;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
   }
 
+  /** Builds the URL of searchCustomNames, relative to the client's base URL. */
+  static searchCustomNamesUrl(query: { searchTerm?: string; resultsPerPage?: number; pageNumber?: number; sortField?: string; descendingOrder?: boolean } = {}): string {
+    const path = "/api/search/custom";
+    const search = new URLSearchParams();
+    if (query.searchTerm != null && query.searchTerm !== "") search.set("q", String(query.searchTerm));
+    if (query.resultsPerPage != null && query.resultsPerPage !== 0) search.set("limit", String(query.resultsPerPage));
+    if (query.pageNumber != null && query.pageNumber !== 0) search.set("page", String(query.pageNumber));
+    if (query.sortField != null && query.sortField !== "") search.set("sort", String(query.sortField));
+    if (query.descendingOrder) search.set("desc", String(query.descendingOrder));
+    search.sort();
+    const queryString = search.toString();
+    return queryString ? path + "?" + queryString : path;
+  }
+
+  /** Custom query param names */
   async searchCustomNames(req: SearchCustomNamesRequest, options?: QueryParamServiceCallOptions): Promise<SearchResponse> {
-    let path = "/api/search/custom";
-    const params = new URLSearchParams();
-    if (req.searchTerm != null && req.searchTerm !== "") params.set("q", String(req.searchTerm));
-    if (req.resultsPerPage != null && req.resultsPerPage !== 0) params.set("limit", String(req.resultsPerPage));
-    if (req.pageNumber != null && req.pageNumber !== 0) params.set("page", String(req.pageNumber));
-    if (req.sortField != null && req.sortField !== "") params.set("sort", String(req.sortField));
-    if (req.descendingOrder) params.set("desc", String(req.descendingOrder));
-    const url = this.baseURL + path + (params.toString() ? "?" + params.toString() : "");
+    const url = this.baseURL + QueryParamServiceClient.searchCustomNamesUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -100,26 +153,39 @@ export class QueryParamServiceClient {
       ...options?.headers,
     };
 
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
+    const load = async (): Promise<SearchResponse> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
 
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
+
+      const result = await resp.json() as SearchResponse;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
+  }
 
-    return await resp.json() as SearchResponse;
+  /** Builds the URL of getWithFilters, relative to the client's base URL. */
+  static getWithFiltersUrl(params: { resourceId: string }, query: { filter?: string; limit?: number } = {}): string {
+    let path = "/api/resources/{resource_id}/items";
+    path = path.replace("{resource_id}", encodeURIComponent(String(params.resourceId)));
+    const search = new URLSearchParams();
+    if (query.filter != null && query.filter !== "") search.set("filter", String(query.filter));
+    if (query.limit != null && query.limit !== 0) search.set("limit", String(query.limit));
+    search.sort();
+    const queryString = search.toString();
+    return queryString ? path + "?" + queryString : path;
   }
 
+  /** Mixed path and query params */
   async getWithFilters(req: GetWithFiltersRequest, options?: QueryParamServiceCallOptions): Promise<SearchResponse> {
-    let path = "/api/resources/{resource_id}/items";
-    path = path.replace("{resource_id}", encodeURIComponent(String(req.resourceId)));
-    const params = new URLSearchParams();
-    if (req.filter != null && req.filter !== "") params.set("filter", String(req.filter));
-    if (req.limit != null && req.limit !== 0) params.set("limit", String(req.limit));
-    const url = this.baseURL + path + (params.toString() ? "?" + params.toString() : "");
+    const url = this.baseURL + QueryParamServiceClient.getWithFiltersUrl(req, req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -127,29 +193,42 @@ export class QueryParamServiceClient {
       ...options?.headers,
     };
 
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
+    const load = async (): Promise<SearchResponse> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
 
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
+
+      const result = await resp.json() as SearchResponse;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
+  }
 
-    return await resp.json() as SearchResponse;
+  /** Builds the URL of searchAdvanced, relative to the client's base URL. */
+  static searchAdvancedUrl(query: { region?: Region; countries?: string[]; keyword?: string; years?: number[]; flags?: boolean[]; regions?: Region[] } = {}): string {
+    const path = "/api/search/advanced";
+    const search = new URLSearchParams();
+    if (query.region != null && query.region !== "unspecified") search.set("region", String(query.region));
+    if (query.countries && query.countries.length > 0) query.countries.forEach(v => search.append("countries", String(v)));
+    if (query.keyword != null && query.keyword !== "") search.set("keyword", String(query.keyword));
+    if (query.years && query.years.length > 0) query.years.forEach(v => search.append("years", String(v)));
+    if (query.flags && query.flags.length > 0) query.flags.forEach(v => search.append("flags", String(v)));
+    if (query.regions && query.regions.length > 0) query.regions.forEach(v => search.append("regions", String(v)));
+    search.sort();
+    const queryString = search.toString();
+    return queryString ? path + "?" + queryString : path;
   }
 
+  /** Advanced search with enum + repeated params */
   async searchAdvanced(req: SearchAdvancedRequest, options?: QueryParamServiceCallOptions): Promise<SearchResponse> {
-    let path = "/api/search/advanced";
-    const params = new URLSearchParams();
-    if (req.region != null && req.region !== "unspecified") params.set("region", String(req.region));
-    if (req.countries && req.countries.length > 0) req.countries.forEach(v => params.append("countries", String(v)));
-    if (req.keyword != null && req.keyword !== "") params.set("keyword", String(req.keyword));
-    if (req.years && req.years.length > 0) req.years.forEach(v => params.append("years", String(v)));
-    if (req.flags && req.flags.length > 0) req.flags.forEach(v => params.append("flags", String(v)));
-    if (req.regions && req.regions.length > 0) req.regions.forEach(v => params.append("regions", String(v)));
-    const url = this.baseURL + path + (params.toString() ? "?" + params.toString() : "");
+    const url = this.baseURL + QueryParamServiceClient.searchAdvancedUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -157,25 +236,38 @@ export class QueryParamServiceClient {
       ...options?.headers,
     };
 
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
+    const load = async (): Promise<SearchResponse> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
 
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
 
-    return await resp.json() as SearchResponse;
+      const result = await resp.json() as SearchResponse;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
   }
 
-  async getByRegion(req: GetByRegionRequest, options?: QueryParamServiceCallOptions): Promise<SearchResponse> {
+  /** Builds the URL of getByRegion, relative to the client's base URL. */
+  static getByRegionUrl(params: { region: Region }, query: { keyword?: string } = {}): string {
     let path = "/api/regions/{region}";
-    path = path.replace("{region}", encodeURIComponent(String(req.region)));
-    const params = new URLSearchParams();
-    if (req.keyword != null && req.keyword !== "") params.set("keyword", String(req.keyword));
-    const url = this.baseURL + path + (params.toString() ? "?" + params.toString() : "");
+    path = path.replace("{region}", encodeURIComponent(String(params.region)));
+    const search = new URLSearchParams();
+    if (query.keyword != null && query.keyword !== "") search.set("keyword", String(query.keyword));
+    search.sort();
+    const queryString = search.toString();
+    return queryString ? path + "?" + queryString : path;
+  }
+
+  /** Enum as path parameter */
+  async getByRegion(req: GetByRegionRequest, options?: QueryParamServiceCallOptions): Promise<SearchResponse> {
+    const url = this.baseURL + QueryParamServiceClient.getByRegionUrl(req, req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -183,22 +275,33 @@ export class QueryParamServiceClient {
       ...options?.headers,
     };
 
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
+    const load = async (): Promise<SearchResponse> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
 
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
+
+      const result = await resp.json() as SearchResponse;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
+  }
 
-    return await resp.json() as SearchResponse;
+  /** Builds the URL of getDefaults, relative to the client's base URL. */
+  static getDefaultsUrl(): string {
+    const path = "/api/defaults";
+    return path;
   }
 
+  /** RPC with empty request message */
   async getDefaults(_req: EmptyRequest, options?: QueryParamServiceCallOptions): Promise<SearchResponse> {
-    let path = "/api/defaults";
-    const url = this.baseURL + path;
+    const url = this.baseURL + QueryParamServiceClient.getDefaultsUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -206,32 +309,59 @@ export class QueryParamServiceClient {
       ...options?.headers,
     };
 
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
+    const load = async (): Promise<SearchResponse> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
 
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
 
-    return await resp.json() as SearchResponse;
+      const result = await resp.json() as SearchResponse;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
   }
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
+    }
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
+export default { QueryParamServiceClient };
@@ -2,6 +2,7 @@
 // source: reserved_name.proto
 
 import { ApiError, ValidationError } from "./errors.js";
+import { type ResponseMetadata, reportResponseMetadata } from "./response_metadata.js";
 import type { ApiError as ApiError_1, GetThingRequest, ValidationError as ValidationError_1, Wrapper } from "./reserved_name.js";
 
 export interface ThingServiceClientOptions {
@@ -12,9 +13,18 @@ export interface ThingServiceClientOptions {
 export interface ThingServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
 }
 
 export class ThingServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    getThing: { method: "POST", path: "/things" },
+    listErrorCodes: { method: "POST", path: "/things/codes" },
+    getWrapper: { method: "POST", path: "/wrapper" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
@@ -25,9 +35,14 @@ export class ThingServiceClient {
     this.defaultHeaders = { ...options?.defaultHeaders };
   }
 
+  /** Builds the URL of getThing, relative to the client's base URL. */
+  static getThingUrl(): string {
+    const path = "/things";
+    return path;
+  }
+
   async getThing(req: GetThingRequest, options?: ThingServiceCallOptions): Promise<ValidationError_1> {
-    let path = "/things";
-    const url = this.baseURL + path;
+    const url = this.baseURL + ThingServiceClient.getThingUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -46,12 +61,19 @@ export class ThingServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as ValidationError_1;
+    const result = await resp.json() as ValidationError_1;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
+  }
+
+  /** Builds the URL of listErrorCodes, relative to the client's base URL. */
+  static listErrorCodesUrl(): string {
+    const path = "/things/codes";
+    return path;
   }
 
   async listErrorCodes(req: GetThingRequest, options?: ThingServiceCallOptions): Promise<ApiError_1[]> {
-    let path = "/things/codes";
-    const url = this.baseURL + path;
+    const url = this.baseURL + ThingServiceClient.listErrorCodesUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -70,12 +92,19 @@ export class ThingServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as ApiError_1[];
+    const result = await resp.json() as ApiError_1[];
+    reportResponseMetadata(resp, options?.onResponse);
+    return result;
+  }
+
+  /** Builds the URL of getWrapper, relative to the client's base URL. */
+  static getWrapperUrl(): string {
+    const path = "/wrapper";
+    return path;
   }
 
   async getWrapper(req: GetThingRequest, options?: ThingServiceCallOptions): Promise<Wrapper> {
-    let path = "/wrapper";
-    const url = this.baseURL + path;
+    const url = this.baseURL + ThingServiceClient.getWrapperUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -94,22 +123,46 @@ export class ThingServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as Wrapper;
+    const result = await resp.json() as Wrapper;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
+    }
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
+export default { ThingServiceClient };
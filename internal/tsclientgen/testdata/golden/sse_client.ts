@@ -2,32 +2,53 @@
 // source: sse.proto
 
 import { ApiError, ValidationError } from "./errors.js";
+import { ResponseCache, type ResponseCacheOptions } from "./response_cache.js";
+import { type ResponseMetadata, reportResponseMetadata } from "./response_metadata.js";
 import type { Event, GetStatusRequest, ResourceEvent, StatusResponse, StreamEventsRequest, StreamFilteredEventsRequest, StreamResourceEventsRequest } from "./sse.js";
 
 export interface SSEServiceClientOptions {
   fetch?: typeof fetch;
   defaultHeaders?: Record<string, string>;
+  cache?: ResponseCacheOptions;
 }
 
 export interface SSEServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
 }
 
 export class SSEServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    getStatus: { method: "GET", path: "/api/v1/status" },
+    streamEvents: { method: "GET", path: "/api/v1/events" },
+    streamResourceEvents: { method: "GET", path: "/api/v1/resources/{resource_id}/events" },
+    streamFilteredEvents: { method: "GET", path: "/api/v1/events/filtered" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
+  private cache?: ResponseCache;
 
   constructor(baseURL: string, options?: SSEServiceClientOptions) {
     this.baseURL = baseURL.replace(/\/+$/, "");
     this.fetchFn = options?.fetch ?? globalThis.fetch;
     this.defaultHeaders = { ...options?.defaultHeaders };
+    this.cache = options?.cache ? new ResponseCache(options.cache) : undefined;
+  }
+
+  /** Builds the URL of getStatus, relative to the client's base URL. */
+  static getStatusUrl(): string {
+    const path = "/api/v1/status";
+    return path;
   }
 
+  /** Standard unary RPC (should be unaffected) */
   async getStatus(_req: GetStatusRequest, options?: SSEServiceCallOptions): Promise<StatusResponse> {
-    let path = "/api/v1/status";
-    const url = this.baseURL + path;
+    const url = this.baseURL + SSEServiceClient.getStatusUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -35,22 +56,33 @@ export class SSEServiceClient {
       ...options?.headers,
     };
 
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
+    const load = async (): Promise<StatusResponse> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
 
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
 
-    return await resp.json() as StatusResponse;
+      const result = await resp.json() as StatusResponse;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
   }
 
+  /** Builds the URL of streamEvents, relative to the client's base URL. */
+  static streamEventsUrl(): string {
+    const path = "/api/v1/events";
+    return path;
+  }
+
+  /** SSE streaming RPC */
   async *streamEvents(_req: StreamEventsRequest, options?: SSEServiceCallOptions): AsyncGenerator<Event> {
-    let path = "/api/v1/events";
-    const url = this.baseURL + path;
+    const url = this.baseURL + SSEServiceClient.streamEventsUrl();
 
     const headers: Record<string, string> = {
       "Accept": "text/event-stream",
@@ -91,10 +123,16 @@ export class SSEServiceClient {
     }
   }
 
-  async *streamResourceEvents(req: StreamResourceEventsRequest, options?: SSEServiceCallOptions): AsyncGenerator<ResourceEvent> {
+  /** Builds the URL of streamResourceEvents, relative to the client's base URL. */
+  static streamResourceEventsUrl(params: { resourceId: string }): string {
     let path = "/api/v1/resources/{resource_id}/events";
-    path = path.replace("{resource_id}", encodeURIComponent(String(req.resourceId)));
-    const url = this.baseURL + path;
+    path = path.replace("{resource_id}", encodeURIComponent(String(params.resourceId)));
+    return path;
+  }
+
+  /** SSE with path params */
+  async *streamResourceEvents(req: StreamResourceEventsRequest, options?: SSEServiceCallOptions): AsyncGenerator<ResourceEvent> {
+    const url = this.baseURL + SSEServiceClient.streamResourceEventsUrl(req);
 
     const headers: Record<string, string> = {
       "Accept": "text/event-stream",
@@ -135,12 +173,20 @@ export class SSEServiceClient {
     }
   }
 
+  /** Builds the URL of streamFilteredEvents, relative to the client's base URL. */
+  static streamFilteredEventsUrl(query: { eventType?: string; limit?: number } = {}): string {
+    const path = "/api/v1/events/filtered";
+    const search = new URLSearchParams();
+    if (query.eventType != null && query.eventType !== "") search.set("type", String(query.eventType));
+    if (query.limit != null && query.limit !== 0) search.set("limit", String(query.limit));
+    search.sort();
+    const queryString = search.toString();
+    return queryString ? path + "?" + queryString : path;
+  }
+
+  /** SSE with query params */
   async *streamFilteredEvents(req: StreamFilteredEventsRequest, options?: SSEServiceCallOptions): AsyncGenerator<Event> {
-    let path = "/api/v1/events/filtered";
-    const params = new URLSearchParams();
-    if (req.eventType != null && req.eventType !== "") params.set("type", String(req.eventType));
-    if (req.limit != null && req.limit !== 0) params.set("limit", String(req.limit));
-    const url = this.baseURL + path + (params.toString() ? "?" + params.toString() : "");
+    const url = this.baseURL + SSEServiceClient.streamFilteredEventsUrl(req);
 
     const headers: Record<string, string> = {
       "Accept": "text/event-stream",
@@ -183,17 +229,39 @@ export class SSEServiceClient {
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
+    }
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
+export default { SSEServiceClient };
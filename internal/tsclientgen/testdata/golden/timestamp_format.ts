@@ -2,10 +2,15 @@
 // source: timestamp_format.proto
 
 export interface TimestampFormatTest {
+  /** Default (RFC3339) - no annotation */
   defaultTs?: string;
+  /** Explicit RFC3339 */
   rfc3339Ts?: string;
+  /** Unix seconds - serializes as integer */
   unixSecondsTs?: number;
+  /** Unix milliseconds - serializes as integer */
   unixMillisTs?: number;
+  /** Date only - serializes as "2024-01-15" */
   dateTs?: string;
 }
 
@@ -2,32 +2,51 @@
 // source: timestamp_format.proto
 
 import { ApiError, ValidationError } from "./errors.js";
+import { ResponseCache, type ResponseCacheOptions } from "./response_cache.js";
+import { type ResponseMetadata, reportResponseMetadata } from "./response_metadata.js";
 import type { TimestampFormatRequest, TimestampFormatTest } from "./timestamp_format.js";
 
 export interface TimestampFormatServiceClientOptions {
   fetch?: typeof fetch;
   defaultHeaders?: Record<string, string>;
+  cache?: ResponseCacheOptions;
 }
 
 export interface TimestampFormatServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
 }
 
+/** TimestampFormatService tests timestamp format in responses. */
 export class TimestampFormatServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    createTimestampFormat: { method: "POST", path: "/api/v1/timestamp-format" },
+    getTimestampFormat: { method: "GET", path: "/api/v1/timestamp-format/{id}" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
+  private cache?: ResponseCache;
 
   constructor(baseURL: string, options?: TimestampFormatServiceClientOptions) {
     this.baseURL = baseURL.replace(/\/+$/, "");
     this.fetchFn = options?.fetch ?? globalThis.fetch;
     this.defaultHeaders = { ...options?.defaultHeaders };
+    this.cache = options?.cache ? new ResponseCache(options.cache) : undefined;
+  }
+
+  /** Builds the URL of createTimestampFormat, relative to the client's base URL. */
+  static createTimestampFormatUrl(): string {
+    const path = "/api/v1/timestamp-format";
+    return path;
   }
 
   async createTimestampFormat(req: TimestampFormatTest, options?: TimestampFormatServiceCallOptions): Promise<TimestampFormatTest> {
-    let path = "/api/v1/timestamp-format";
-    const url = this.baseURL + path;
+    const url = this.baseURL + TimestampFormatServiceClient.createTimestampFormatUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -46,13 +65,22 @@ export class TimestampFormatServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as TimestampFormatTest;
+    this.cache?.invalidate(this.baseURL + "/api/v1/timestamp-format");
+
+    const result = await resp.json() as TimestampFormatTest;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
-  async getTimestampFormat(req: TimestampFormatRequest, options?: TimestampFormatServiceCallOptions): Promise<TimestampFormatTest> {
+  /** Builds the URL of getTimestampFormat, relative to the client's base URL. */
+  static getTimestampFormatUrl(params: { id: string }): string {
     let path = "/api/v1/timestamp-format/{id}";
-    path = path.replace("{id}", encodeURIComponent(String(req.id)));
-    const url = this.baseURL + path;
+    path = path.replace("{id}", encodeURIComponent(String(params.id)));
+    return path;
+  }
+
+  async getTimestampFormat(req: TimestampFormatRequest, options?: TimestampFormatServiceCallOptions): Promise<TimestampFormatTest> {
+    const url = this.baseURL + TimestampFormatServiceClient.getTimestampFormatUrl(req);
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -60,32 +88,59 @@ export class TimestampFormatServiceClient {
       ...options?.headers,
     };
 
-    const resp = await this.fetchFn(url, {
-      method: "GET",
-      headers,
-      signal: options?.signal,
-    });
+    const load = async (): Promise<TimestampFormatTest> => {
+      const resp = await this.fetchFn(url, {
+        method: "GET",
+        headers,
+        signal: options?.signal,
+      });
 
-    if (!resp.ok) {
-      return this.handleError(resp);
-    }
+      if (!resp.ok) {
+        return this.handleError(resp);
+      }
 
-    return await resp.json() as TimestampFormatTest;
+      const result = await resp.json() as TimestampFormatTest;
+      reportResponseMetadata(resp, options?.onResponse, result);
+      return result;
+    };
+    return this.cache ? this.cache.get(url, headers, load) : load();
   }
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
+    }
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
+export default { TimestampFormatServiceClient };
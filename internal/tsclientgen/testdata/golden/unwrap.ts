@@ -8,11 +8,17 @@ export interface GetOptionBarsRequest {
 }
 
 export interface GetOptionBarsResponse {
+  /**
+   * Map from symbol to option bars list
+   * JSON output: {"bars": {"AAPL": [...], "GOOG": [...]}}
+   * instead of: {"bars": {"AAPL": {"bars": [...]}, "GOOG": {"bars": [...]}}}
+   */
   bars: { [key: string]: OptionBar[] };
   nextPageToken: string;
 }
 
 export interface OptionBarsList {
+  /** The bars field is marked for unwrapping */
   bars: OptionBar[];
 }
 
@@ -2,6 +2,7 @@
 // source: unwrap.proto
 
 import { ApiError, ValidationError } from "./errors.js";
+import { type ResponseMetadata, reportResponseMetadata } from "./response_metadata.js";
 import type { GetOptionBarsRequest, GetOptionBarsResponse, OptionBar } from "./unwrap.js";
 
 export interface OptionDataServiceClientOptions {
@@ -12,9 +13,17 @@ export interface OptionDataServiceClientOptions {
 export interface OptionDataServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
 }
 
+/** OptionDataService provides option market data */
 export class OptionDataServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    getOptionBars: { method: "POST", path: "/api/v1/options/bars" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
@@ -25,9 +34,15 @@ export class OptionDataServiceClient {
     this.defaultHeaders = { ...options?.defaultHeaders };
   }
 
+  /** Builds the URL of getOptionBars, relative to the client's base URL. */
+  static getOptionBarsUrl(): string {
+    const path = "/api/v1/options/bars";
+    return path;
+  }
+
+  /** GetOptionBars retrieves option bar data for multiple symbols */
   async getOptionBars(req: GetOptionBarsRequest, options?: OptionDataServiceCallOptions): Promise<GetOptionBarsResponse> {
-    let path = "/api/v1/options/bars";
-    const url = this.baseURL + path;
+    const url = this.baseURL + OptionDataServiceClient.getOptionBarsUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -46,22 +61,45 @@ export class OptionDataServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as GetOptionBarsResponse;
+    const result = await resp.json() as GetOptionBarsResponse;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
+    }
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
@@ -73,9 +111,20 @@ export interface UnwrapServiceClientOptions {
 export interface UnwrapServiceCallOptions {
   headers?: Record<string, string>;
   signal?: AbortSignal;
+  /** Receives the status, headers and warnings of the response to a unary call. */
+  onResponse?: (metadata: ResponseMetadata) => void;
 }
 
+/** UnwrapService tests all unwrap variants including root-level unwrap */
 export class UnwrapServiceClient {
+  /** The HTTP verb and path template of every method. */
+  static readonly routes = {
+    getOptionBars: { method: "POST", path: "/api/v1/unwrap/options/bars" },
+    getRootMap: { method: "POST", path: "/api/v1/root/map" },
+    getRootRepeated: { method: "POST", path: "/api/v1/root/repeated" },
+    getRootMapWithValueUnwrap: { method: "POST", path: "/api/v1/root/map-value-unwrap" },
+  } as const;
+
   private baseURL: string;
   private fetchFn: typeof fetch;
   private defaultHeaders: Record<string, string>;
@@ -86,9 +135,15 @@ export class UnwrapServiceClient {
     this.defaultHeaders = { ...options?.defaultHeaders };
   }
 
+  /** Builds the URL of getOptionBars, relative to the client's base URL. */
+  static getOptionBarsUrl(): string {
+    const path = "/api/v1/unwrap/options/bars";
+    return path;
+  }
+
+  /** GetOptionBars retrieves option bar data */
   async getOptionBars(req: GetOptionBarsRequest, options?: UnwrapServiceCallOptions): Promise<GetOptionBarsResponse> {
-    let path = "/api/v1/options/bars";
-    const url = this.baseURL + path;
+    const url = this.baseURL + UnwrapServiceClient.getOptionBarsUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -107,12 +162,20 @@ export class UnwrapServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as GetOptionBarsResponse;
+    const result = await resp.json() as GetOptionBarsResponse;
+    reportResponseMetadata(resp, options?.onResponse, result);
+    return result;
   }
 
+  /** Builds the URL of getRootMap, relative to the client's base URL. */
+  static getRootMapUrl(): string {
+    const path = "/api/v1/root/map";
+    return path;
+  }
+
+  /** GetRootMap tests root-level map unwrap response */
   async getRootMap(req: GetOptionBarsRequest, options?: UnwrapServiceCallOptions): Promise<{ [key: string]: OptionBar }> {
-    let path = "/api/v1/root/map";
-    const url = this.baseURL + path;
+    const url = this.baseURL + UnwrapServiceClient.getRootMapUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -131,12 +194,20 @@ export class UnwrapServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as { [key: string]: OptionBar };
+    const result = await resp.json() as { [key: string]: OptionBar };
+    reportResponseMetadata(resp, options?.onResponse);
+    return result;
+  }
+
+  /** Builds the URL of getRootRepeated, relative to the client's base URL. */
+  static getRootRepeatedUrl(): string {
+    const path = "/api/v1/root/repeated";
+    return path;
   }
 
+  /** GetRootRepeated tests root-level repeated unwrap response */
   async getRootRepeated(req: GetOptionBarsRequest, options?: UnwrapServiceCallOptions): Promise<OptionBar[]> {
-    let path = "/api/v1/root/repeated";
-    const url = this.baseURL + path;
+    const url = this.baseURL + UnwrapServiceClient.getRootRepeatedUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -155,12 +226,20 @@ export class UnwrapServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as OptionBar[];
+    const result = await resp.json() as OptionBar[];
+    reportResponseMetadata(resp, options?.onResponse);
+    return result;
   }
 
+  /** Builds the URL of getRootMapWithValueUnwrap, relative to the client's base URL. */
+  static getRootMapWithValueUnwrapUrl(): string {
+    const path = "/api/v1/root/map-value-unwrap";
+    return path;
+  }
+
+  /** GetRootMapWithValueUnwrap tests combined unwrap response */
   async getRootMapWithValueUnwrap(req: GetOptionBarsRequest, options?: UnwrapServiceCallOptions): Promise<{ [key: string]: OptionBar[] }> {
-    let path = "/api/v1/root/map-value-unwrap";
-    const url = this.baseURL + path;
+    const url = this.baseURL + UnwrapServiceClient.getRootMapWithValueUnwrapUrl();
 
     const headers: Record<string, string> = {
       "Content-Type": "application/json",
@@ -179,22 +258,46 @@ export class UnwrapServiceClient {
       return this.handleError(resp);
     }
 
-    return await resp.json() as { [key: string]: OptionBar[] };
+    const result = await resp.json() as { [key: string]: OptionBar[] };
+    reportResponseMetadata(resp, options?.onResponse);
+    return result;
   }
 
   private async handleError(resp: Response): Promise<never> {
     const body = await resp.text();
-    if (resp.status === 400) {
-      try {
-        const parsed = JSON.parse(body);
-        if (parsed.violations) {
-          throw new ValidationError(parsed.violations);
-        }
-      } catch (e) {
-        if (e instanceof ValidationError) throw e;
+    let parsed: Record<string, unknown> | undefined;
+    try {
+      parsed = JSON.parse(body);
+    } catch {
+      parsed = undefined;
+    }
+    // Problem Details (RFC 9457) carry the error as application/problem+json
+    const contentType = resp.headers.get("Content-Type") ?? "";
+    if (contentType.split(";")[0].trim() === "application/problem+json" && parsed) {
+      if (Array.isArray(parsed.errors)) {
+        const errors = parsed.errors as { pointer?: string; detail?: string }[];
+        throw new ValidationError(errors.map((e) => ({
+          field: (e.pointer ?? "")
+            .split("/")
+            .slice(1)
+            .map((s) => s.replace(/~1/g, "/").replace(/~0/g, "~"))
+            .join("."),
+          description: e.detail ?? "",
+        })));
       }
+      const detail = typeof parsed.detail === "string" ? parsed.detail : "";
+      const code = typeof parsed.code === "string" ? parsed.code : "";
+      const details = (parsed.details ?? {}) as Record<string, string>;
+      const message = detail || `Request failed with status ${resp.status}`;
+      throw new ApiError(resp.status, message, body, code, details);
+    }
+    if (resp.status === 400 && Array.isArray(parsed?.violations)) {
+      throw new ValidationError(parsed.violations);
     }
-    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body);
+    const code = typeof parsed?.code === "string" ? parsed.code : "";
+    const details = (parsed?.details ?? {}) as Record<string, string>;
+    throw new ApiError(resp.status, `Request failed with status ${resp.status}`, body, code, details);
   }
 }
 
+export default { OptionDataServiceClient, UnwrapServiceClient };
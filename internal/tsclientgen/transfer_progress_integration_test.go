@@ -0,0 +1,101 @@
+package tsclientgen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestTransferProgressIntegration generates the TypeScript clients for
+// raw_body.proto and multipart_upload.proto and runs transferProgressTSProgram
+// against them with a fetch streaming 1 MB bodies: raw_body methods resolve to
+// the bytes sent and their Content-Type, reporting the download more than once,
+// and multipart uploads report the form they send more than once.
+func TestTransferProgressIntegration(t *testing.T) {
+	plugintest.RunTS(t, plugintest.Module{
+		Protos:   map[string]string{"raw_body.proto": "", "multipart_upload.proto": ""},
+		ProtoDir: filepath.Join("testdata", "proto"),
+		Plugins:  []plugintest.Plugin{{Name: "ts-client"}},
+		Files:    map[string]string{"main.ts": transferProgressTSProgram},
+	})
+}
+
+// transferProgressTSProgram tests the progress callbacks of generated clients.
+// Its fetch streams a 1 MB payload in 64 KB chunks and reads request bodies to
+// the end, as a network would.
+const transferProgressTSProgram = `import assert from "node:assert/strict";
+import { test } from "node:test";
+import { FileServiceClient } from "./raw_body_client.ts";
+import { UploadServiceClient } from "./multipart_upload_client.ts";
+
+const payload = new Uint8Array(1 << 20).map((_, i) => (i * 7 + 0x80) & 0xff);
+
+function streamPayload(): ReadableStream<Uint8Array> {
+  let offset = 0;
+  return new ReadableStream({
+    pull(controller) {
+      if (offset === payload.length) {
+        controller.close();
+        return;
+      }
+      controller.enqueue(payload.slice(offset, offset + 65536));
+      offset += 65536;
+    },
+  });
+}
+
+let uploaded: { bytes: number; contentType: string | undefined } | undefined;
+
+async function fetchFn(url: string, init?: RequestInit): Promise<Response> {
+  if (url.includes("/files/")) {
+    const headers: Record<string, string> = { "Content-Length": String(payload.length) };
+    if (url.endsWith("/content")) {
+      headers["Content-Type"] = "image/png";
+    }
+    return new Response(streamPayload(), { headers });
+  }
+  const contentType = (init?.headers as Record<string, string>)["Content-Type"];
+  const body = await new Response(init?.body, { headers: { "Content-Type": contentType ?? "" } }).arrayBuffer();
+  uploaded = { bytes: body.byteLength, contentType };
+  return new Response(JSON.stringify({ id: "d1", title: "t", filename: "f", size: "0" }));
+}
+
+test("raw_body methods resolve to the body and its Content-Type with download progress", async () => {
+  const client = new FileServiceClient("http://api.test", { fetch: fetchFn as typeof fetch });
+  const progress: [number, number | undefined][] = [];
+  const body = await client.downloadFile({ fileId: "logo" }, {
+    onDownloadProgress: (loaded, total) => progress.push([loaded, total]),
+  });
+  assert.equal(body.contentType, "image/png");
+  assert.deepEqual(new Uint8Array(body.data), payload);
+  assert.ok(progress.length > 1, "progress fires " + progress.length + " times");
+  assert.deepEqual(progress.at(-1), [payload.length, payload.length]);
+  for (let i = 1; i < progress.length; i++) {
+    assert.ok(progress[i][0] > progress[i - 1][0], "progress grows");
+  }
+
+  const archive = await client.exportFiles({ fileIds: ["logo"] });
+  assert.equal(archive.contentType, "application/octet-stream");
+  assert.equal(archive.data.byteLength, payload.length);
+});
+
+test("multipart uploads report upload progress", async () => {
+  const client = new UploadServiceClient("http://api.test", { fetch: fetchFn as typeof fetch });
+  const progress: [number, number][] = [];
+  await client.uploadDocumentMultipart({
+    folderId: "f1",
+    content: new Blob([payload]),
+    filename: "logo.png",
+    title: "Logo",
+    tags: [],
+    revision: 1,
+  }, { onUploadProgress: (loaded, total) => progress.push([loaded, total]) });
+
+  assert.ok(uploaded, "the form was sent");
+  assert.match(uploaded.contentType ?? "", /^multipart\/form-data; boundary=/);
+  assert.ok(uploaded.bytes > payload.length);
+  assert.ok(progress.length > 1, "progress fires " + progress.length + " times");
+  assert.deepEqual(progress.at(-1), [uploaded.bytes, uploaded.bytes]);
+});
+`
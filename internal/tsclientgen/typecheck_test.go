@@ -4,6 +4,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/SebastienMelki/sebuf/internal/tscommon"
 	"github.com/SebastienMelki/sebuf/internal/tscommon/typecheck"
 )
 
@@ -14,3 +15,20 @@ import (
 func TestGoldenTypecheck(t *testing.T) {
 	typecheck.Dir(t, filepath.Join("testdata", "golden"))
 }
+
+// TestFormatGoldenTypecheck compiles each module/target golden tree. Node and
+// isomorphic output is checked without the DOM libs; cjs and both output is
+// additionally compiled with --module commonjs.
+func TestFormatGoldenTypecheck(t *testing.T) {
+	for _, combo := range formatMatrix {
+		name := combo.module + "_" + combo.target
+		t.Run(name, func(t *testing.T) {
+			dir := filepath.Join("testdata", "golden_formats", name)
+			noDOM := combo.target != string(TargetBrowser)
+			typecheck.DirWithOptions(t, dir, typecheck.Options{NoDOM: noDOM})
+			if combo.module != string(tscommon.ModuleFormatESM) {
+				typecheck.DirWithOptions(t, dir, typecheck.Options{CommonJS: true, NoDOM: noDOM})
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package tsclientgen
+
+import (
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestURLBuildersIntegration generates the TypeScript client of
+// urlBuildersProto and runs urlBuildersTSProgram against it: the static URL
+// builders must return the URLs the client fetches, captured by a recording
+// fetch, and the static routes the verb and path template of each method.
+func TestURLBuildersIntegration(t *testing.T) {
+	plugintest.RunTS(t, plugintest.Module{
+		Protos:  map[string]string{"users.proto": urlBuildersProto},
+		Plugins: []plugintest.Plugin{{Name: "ts-client"}},
+		Files:   map[string]string{"main.ts": urlBuildersTSProgram},
+	})
+}
+
+const urlBuildersProto = `syntax = "proto3";
+package test.urlbuilders;
+option go_package = "example.com/urlbuilders;urlbuilders";
+import "sebuf/http/annotations.proto";
+
+enum Region {
+  REGION_UNSPECIFIED = 0;
+  REGION_EU = 1 [(sebuf.http.enum_value) = "eu"];
+}
+
+service UserService {
+  option (sebuf.http.service_config) = { base_path: "/api/v1" };
+  rpc GetUser(GetUserRequest) returns (User) {
+    option (sebuf.http.config) = { path: "/regions/{region}/users/{id}" method: HTTP_METHOD_GET };
+  }
+  rpc ListUsers(ListUsersRequest) returns (ListUsersResponse) {
+    option (sebuf.http.config) = { path: "/users" method: HTTP_METHOD_GET };
+  }
+  rpc CreateUser(User) returns (User) {
+    option (sebuf.http.config) = { path: "/users" method: HTTP_METHOD_POST };
+  }
+}
+
+message GetUserRequest {
+  string id = 1;
+  Region region = 2;
+}
+
+message ListUsersRequest {
+  int64 since_id = 1 [(sebuf.http.query) = { name: "since_id" }];
+  repeated string tags = 2 [(sebuf.http.query) = { name: "tag" }];
+  Region region = 3 [(sebuf.http.query) = { name: "region" }];
+  bool active = 4 [(sebuf.http.query) = { name: "active" }];
+  string q = 5 [(sebuf.http.query) = { name: "q" }];
+}
+
+message User {
+  string id = 1;
+}
+
+message ListUsersResponse {
+  repeated User users = 1;
+}
+`
+
+// urlBuildersTSProgram compares the URL builders of a generated client with
+// the URLs its methods fetch.
+const urlBuildersTSProgram = `import assert from "node:assert/strict";
+import { test } from "node:test";
+import { UserServiceClient } from "./users_client.ts";
+import type { GetUserRequest, ListUsersRequest } from "./users.ts";
+
+let requested = "";
+
+async function fetchFn(url: string): Promise<Response> {
+  requested = url;
+  return new Response("{}");
+}
+
+const client = new UserServiceClient("http://api.test/base/", { fetch: fetchFn as typeof fetch });
+const base = "http://api.test/base";
+
+test("getUserUrl escapes path parameters like getUser", async () => {
+  const req: GetUserRequest = { id: "a/b c", region: "eu" };
+  await client.getUser(req);
+  const built = UserServiceClient.getUserUrl(req);
+  assert.equal(requested, base + built);
+  assert.equal(built, "/api/v1/regions/eu/users/a%2Fb%20c");
+});
+
+test("listUsersUrl serializes query parameters like listUsers", async () => {
+  const empty: ListUsersRequest = { sinceId: "0", tags: [], region: "REGION_UNSPECIFIED", active: false, q: "" };
+  await client.listUsers(empty);
+  assert.equal(requested, base + UserServiceClient.listUsersUrl(empty));
+  assert.equal(UserServiceClient.listUsersUrl(), "/api/v1/users");
+
+  const req: ListUsersRequest = {
+    sinceId: "9007199254740993",
+    tags: ["x", "y&z"],
+    region: "eu",
+    active: true,
+    q: "hello world",
+  };
+  await client.listUsers(req);
+  const built = UserServiceClient.listUsersUrl(req);
+  assert.equal(requested, base + built);
+  assert.equal(built, "/api/v1/users?active=true&q=hello+world&region=eu&since_id=9007199254740993&tag=x&tag=y%26z");
+});
+
+test("createUserUrl takes no parameters", async () => {
+  await client.createUser({ id: "u1" });
+  assert.equal(requested, base + UserServiceClient.createUserUrl());
+});
+
+test("routes hold each method's verb and path template", () => {
+  assert.deepEqual(UserServiceClient.routes, {
+    getUser: { method: "GET", path: "/api/v1/regions/{region}/users/{id}" },
+    listUsers: { method: "GET", path: "/api/v1/users" },
+    createUser: { method: "POST", path: "/api/v1/users" },
+  });
+});
+`
@@ -0,0 +1,371 @@
+package tsclientgen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	validate "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/tscommon"
+)
+
+// validatesRequest reports whether the client checks the method's request
+// before sending it: validate_requests is on and the request carries rules.
+func (g *Generator) validatesRequest(method *protogen.Method) bool {
+	return g.validateRequests && annotations.HasValidationRules(method.Input.Desc)
+}
+
+// validatorFuncName returns the name of a message's request validator.
+func validatorFuncName(msg *protogen.Message) string {
+	return "validate" + tscommon.QualifiedTSName(msg.Desc)
+}
+
+// collectValidatedMessages returns the messages a client module needs a
+// validator for: every validated request, and the messages with rules
+// reachable through their fields, in discovery order.
+func (g *Generator) collectValidatedMessages(file *protogen.File) []*protogen.Message {
+	var messages []*protogen.Message
+	seen := make(map[protoreflect.FullName]bool)
+	var visit func(msg *protogen.Message)
+	visit = func(msg *protogen.Message) {
+		if seen[msg.Desc.FullName()] || !annotations.HasValidationRules(msg.Desc) {
+			return
+		}
+		seen[msg.Desc.FullName()] = true
+		messages = append(messages, msg)
+		for _, field := range msg.Fields {
+			if nested := validatedFieldMessage(field); nested != nil {
+				visit(nested)
+			}
+		}
+	}
+	for _, service := range file.Services {
+		for _, method := range service.Methods {
+			if g.validatesRequest(method) {
+				visit(method.Input)
+			}
+		}
+	}
+	return messages
+}
+
+// validatedFieldMessage returns the message a field's values are validated
+// against: the field's own message, or the value message of a map. Flattened
+// fields are skipped, since their children sit on the parent object.
+func validatedFieldMessage(field *protogen.Field) *protogen.Message {
+	if annotations.IsFlattenField(field) {
+		return nil
+	}
+	if field.Desc.IsMap() {
+		return field.Message.Fields[1].Message
+	}
+	return field.Message
+}
+
+// generateRequestValidator emits validate<Message>, which checks a message
+// against its buf.validate rules and returns the violations under the field
+// paths the server reports: proto field names joined with dots, without list
+// indexes or map keys. Only the rule kinds below are checked locally (required,
+// string lengths and patterns, numeric ranges, repeated item counts); anything
+// else is left to the server.
+func (g *Generator) generateRequestValidator(p printer, msg *protogen.Message, validated map[protoreflect.FullName]bool) {
+	var checks []string
+	cp := printer(tscommon.BufferedPrinter(&checks))
+	for _, field := range msg.Fields {
+		generateFieldValidation(cp, field, validated)
+	}
+
+	p("function %s(msg: %s, prefix = \"\"): FieldViolation[] {", validatorFuncName(msg), g.ctx.RefMessage(msg))
+	p("  const violations: FieldViolation[] = [];")
+	if len(checks) > 0 {
+		p("  const m = msg as unknown as Record<string, any>;")
+		p("  let v: any;")
+		for _, line := range checks {
+			p("%s", line)
+		}
+	}
+	p("  return violations;")
+	p("}")
+	p("")
+}
+
+// generateFieldValidation emits the checks of one field. Fields with presence
+// (messages, optional and oneof members) are only checked when set; implicit
+// fields are checked with their zero value when absent from the object.
+func generateFieldValidation(p printer, field *protogen.Field, validated map[protoreflect.FullName]bool) {
+	rules := annotations.GetFieldValidationRules(field.Desc)
+	if rules.GetIgnore() == validate.Ignore_IGNORE_ALWAYS || annotations.IsFlattenField(field) {
+		return
+	}
+	name := string(field.Desc.Name())
+
+	var checks []string
+	cp := printer(tscommon.BufferedPrinter(&checks))
+	generateRuleChecks(cp, field, rules)
+	if nested := validatedFieldMessage(field); nested != nil && validated[nested.Desc.FullName()] {
+		call := func(value string) string {
+			return fmt.Sprintf("violations.push(...%s(%s, prefix + %s));",
+				validatorFuncName(nested), value, strconv.Quote(name+"."))
+		}
+		switch {
+		case field.Desc.IsMap():
+			cp("for (const item of Object.values(v)) %s", call("item"))
+		case field.Desc.IsList():
+			cp("for (const item of v) %s", call("item"))
+		default:
+			cp("%s", call("v"))
+		}
+	}
+	if len(checks) == 0 && !rules.GetRequired() {
+		return
+	}
+
+	access := tscommon.PropertyAccess("m", annotations.JSONFieldName(field))
+	absent, present := "v === undefined || v === null", "v !== undefined && v !== null"
+	guarded := true
+	if !hasPresence(field) {
+		zero, isZero, isSet := implicitZero(field)
+		access += " ?? " + zero
+		absent, present = isZero, isSet
+		guarded = rules.GetRequired() || rules.GetIgnore() == validate.Ignore_IGNORE_IF_ZERO_VALUE
+	}
+
+	p("  v = %s;", access)
+	switch {
+	case rules.GetRequired():
+		p("  if (%s) {", absent)
+		p("    %s", violation(name, "value is required"))
+		if len(checks) > 0 {
+			p("  } else {")
+			writeIndented(p, "    ", checks)
+		}
+		p("  }")
+	case guarded:
+		p("  if (%s) {", present)
+		writeIndented(p, "    ", checks)
+		p("  }")
+	default:
+		writeIndented(p, "  ", checks)
+	}
+}
+
+// hasPresence reports whether an unset field is left out of validation.
+func hasPresence(field *protogen.Field) bool {
+	return !field.Desc.IsList() && !field.Desc.IsMap() && field.Desc.HasPresence()
+}
+
+// implicitZero returns the zero value of a field without presence, and the
+// expressions testing v for being zero and non-zero.
+func implicitZero(field *protogen.Field) (string, string, string) {
+	switch {
+	case field.Desc.IsMap():
+		return "{}", "Object.keys(v).length === 0", "Object.keys(v).length > 0"
+	case field.Desc.IsList():
+		return "[]", "v.length === 0", "v.length > 0"
+	}
+	//exhaustive:ignore - message kinds have presence and never get here
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return "false", "v === false", "v === true"
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return `""`, `v === ""`, `v !== ""`
+	case protoreflect.EnumKind:
+		unspecified := tscommon.TSEnumUnspecifiedValue(field)
+		return "0", "v === 0 || v === " + unspecified, "v !== 0 && v !== " + unspecified
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "0", "Number(v) === 0", "Number(v) !== 0"
+	default:
+		return "0", "v === 0", "v !== 0"
+	}
+}
+
+// generateRuleChecks emits the checks of the field's own rules on v.
+func generateRuleChecks(p printer, field *protogen.Field, rules *validate.FieldRules) {
+	name := string(field.Desc.Name())
+	if field.Desc.IsList() {
+		repeated := rules.GetRepeated()
+		if repeated.HasMinItems() {
+			p("if (v.length < %d) %s", repeated.GetMinItems(),
+				violation(name, fmt.Sprintf("value must contain at least %d item(s)", repeated.GetMinItems())))
+		}
+		if repeated.HasMaxItems() {
+			p("if (v.length > %d) %s", repeated.GetMaxItems(),
+				violation(name, fmt.Sprintf("value must contain no more than %d item(s)", repeated.GetMaxItems())))
+		}
+		return
+	}
+	if field.Desc.IsMap() {
+		return
+	}
+
+	if str := rules.GetString(); str != nil && field.Desc.Kind() == protoreflect.StringKind {
+		if str.HasLen() {
+			p("if ([...v].length !== %d) %s", str.GetLen(),
+				violation(name, fmt.Sprintf("value length must be %d characters", str.GetLen())))
+		}
+		if str.HasMinLen() {
+			p("if ([...v].length < %d) %s", str.GetMinLen(),
+				violation(name, fmt.Sprintf("value length must be at least %d characters", str.GetMinLen())))
+		}
+		if str.HasMaxLen() {
+			p("if ([...v].length > %d) %s", str.GetMaxLen(),
+				violation(name, fmt.Sprintf("value length must be at most %d characters", str.GetMaxLen())))
+		}
+		if str.HasPattern() {
+			p("if (!new RegExp(%s, \"u\").test(v)) %s", strconv.Quote(str.GetPattern()),
+				violation(name, fmt.Sprintf("value does not match regex pattern `%s`", str.GetPattern())))
+		}
+		return
+	}
+
+	if r, ok := numericRange(field, rules); ok {
+		p("if (%s) %s", r.violated(), violation(name, r.description()))
+	}
+}
+
+// numericBound is one side of a numeric range rule.
+type numericBound struct {
+	inclusive bool    // gte or lte rather than gt or lt
+	literal   string  // TypeScript literal of the bound
+	text      string  // The bound as written in violation messages
+	value     float64 // The bound, for ordering the two sides
+}
+
+// rangeRule is the gt/gte/lt/lte rule of a numeric field, checked as one
+// rule with one message as protovalidate does.
+type rangeRule struct {
+	operand      string // Expression compared with the bounds
+	lower, upper *numericBound
+}
+
+// numericRange reads the range rule of a scalar numeric field. The typed rule
+// messages (Int32Rules, DoubleRules, ...) share the gt/gte/lt/lte field names,
+// so they are read through reflection.
+func numericRange(field *protogen.Field, rules *validate.FieldRules) (rangeRule, bool) {
+	var r rangeRule
+	typeRules := rules.ProtoReflect()
+	set := typeRules.WhichOneof(typeRules.Descriptor().Oneofs().ByName("type"))
+	if set == nil || set.Message() == nil {
+		return r, false
+	}
+	typed := typeRules.Get(set).Message()
+	wide := false
+	//exhaustive:ignore - only numeric kinds have range rules
+	switch field.Desc.Kind() {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		wide = true
+		r.operand = "BigInt(v)"
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.FloatKind, protoreflect.DoubleKind:
+		r.operand = "v"
+	default:
+		return r, false
+	}
+
+	bound := func(fieldName string, inclusive bool) *numericBound {
+		fd := typed.Descriptor().Fields().ByName(protoreflect.Name(fieldName))
+		if fd == nil || !typed.Has(fd) {
+			return nil
+		}
+		b := &numericBound{inclusive: inclusive}
+		value := typed.Get(fd)
+		//exhaustive:ignore - range bounds are integers or floats
+		switch fd.Kind() {
+		case protoreflect.FloatKind, protoreflect.DoubleKind:
+			b.value = value.Float()
+			b.text = strconv.FormatFloat(b.value, 'f', -1, 64)
+		case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+			b.value = float64(value.Uint())
+			b.text = strconv.FormatUint(value.Uint(), 10)
+		default:
+			b.value = float64(value.Int())
+			b.text = strconv.FormatInt(value.Int(), 10)
+		}
+		b.literal = b.text
+		if wide {
+			b.literal += "n"
+		}
+		return b
+	}
+	if r.lower = bound("gt", false); r.lower == nil {
+		r.lower = bound("gte", true)
+	}
+	if r.upper = bound("lt", false); r.upper == nil {
+		r.upper = bound("lte", true)
+	}
+	return r, r.lower != nil || r.upper != nil
+}
+
+// violated returns the condition under which the value breaks the range. When
+// the lower bound exceeds the upper one the range is exclusive: the value must
+// lie outside it.
+func (r rangeRule) violated() string {
+	var below, above string
+	if r.lower != nil {
+		op := "<="
+		if r.lower.inclusive {
+			op = "<"
+		}
+		below = fmt.Sprintf("%s %s %s", r.operand, op, r.lower.literal)
+	}
+	if r.upper != nil {
+		op := ">="
+		if r.upper.inclusive {
+			op = ">"
+		}
+		above = fmt.Sprintf("%s %s %s", r.operand, op, r.upper.literal)
+	}
+	switch {
+	case r.upper == nil:
+		return below
+	case r.lower == nil:
+		return above
+	case r.lower.value >= r.upper.value:
+		return below + " && " + above
+	default:
+		return below + " || " + above
+	}
+}
+
+// description returns the protovalidate message of the range rule.
+func (r rangeRule) description() string {
+	var parts []string
+	if r.lower != nil {
+		if r.lower.inclusive {
+			parts = append(parts, "greater than or equal to "+r.lower.text)
+		} else {
+			parts = append(parts, "greater than "+r.lower.text)
+		}
+	}
+	if r.upper != nil {
+		if r.upper.inclusive {
+			parts = append(parts, "less than or equal to "+r.upper.text)
+		} else {
+			parts = append(parts, "less than "+r.upper.text)
+		}
+	}
+	joiner := " and "
+	if r.lower != nil && r.upper != nil && r.lower.value >= r.upper.value {
+		joiner = " or "
+	}
+	return "value must be " + strings.Join(parts, joiner)
+}
+
+// violation returns the statement recording a violation of the named field.
+func violation(name, description string) string {
+	return fmt.Sprintf("violations.push({ field: prefix + %s, description: %s });",
+		strconv.Quote(name), strconv.Quote(description))
+}
+
+// writeIndented prints lines with indent prepended.
+func writeIndented(p printer, indent string, lines []string) {
+	for _, line := range lines {
+		p("%s%s", indent, line)
+	}
+}
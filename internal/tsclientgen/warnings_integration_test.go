@@ -0,0 +1,80 @@
+package tsclientgen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon/plugintest"
+)
+
+// TestWarningsIntegration generates the TypeScript client for unwrap.proto and
+// runs warningsTSProgram against it with a fetch answering with warnings:
+// onResponse receives them from the Warning headers or, without any, from the
+// _warnings of the body, which the returned message no longer holds, and
+// root-unwrapped maps keep a _warnings key of their own.
+func TestWarningsIntegration(t *testing.T) {
+	plugintest.RunTS(t, plugintest.Module{
+		Protos:   map[string]string{"unwrap.proto": ""},
+		ProtoDir: filepath.Join("testdata", "proto"),
+		Plugins:  []plugintest.Plugin{{Name: "ts-client"}},
+		Files:    map[string]string{"main.ts": warningsTSProgram},
+	})
+}
+
+// warningsTSProgram tests the onResponse option of a generated client. Its
+// fetch answers with the headers and body the test sets.
+const warningsTSProgram = `import assert from "node:assert/strict";
+import { test } from "node:test";
+import { UnwrapServiceClient } from "./unwrap_client.ts";
+import { parseWarnings, type ResponseMetadata } from "./response_metadata.ts";
+
+const partial = { code: "partial_results", message: "3 of 5 backends responded" };
+let headers: Record<string, string> = {};
+let body: unknown = {};
+
+function client(): UnwrapServiceClient {
+  const fetchFn = async () => new Response(JSON.stringify(body), { headers });
+  return new UnwrapServiceClient("http://api.test", { fetch: fetchFn as typeof fetch });
+}
+
+test("warnings are read from the Warning header", async () => {
+  headers = { "Warning": '299 - "partial_results: 3 of 5 backends responded"' };
+  body = { nextPageToken: "p2" };
+  let metadata: ResponseMetadata | undefined;
+  const resp = await client().getOptionBars({}, { onResponse: (m) => (metadata = m) });
+  assert.equal(resp.nextPageToken, "p2");
+  assert.equal(metadata?.status, 200);
+  assert.deepEqual(metadata?.warnings, [partial]);
+});
+
+test("warnings in the body are read and removed from the message", async () => {
+  headers = {};
+  body = { nextPageToken: "p2", _warnings: [partial] };
+  let metadata: ResponseMetadata | undefined;
+  const resp = await client().getOptionBars({}, { onResponse: (m) => (metadata = m) });
+  assert.deepEqual(resp, { nextPageToken: "p2" });
+  assert.deepEqual(metadata?.warnings, [partial]);
+
+  // Removed without an onResponse option too
+  assert.deepEqual(await client().getOptionBars({}), { nextPageToken: "p2" });
+});
+
+test("root-unwrapped bodies are left as they are", async () => {
+  headers = { "Warning": '299 - "partial_results: 3 of 5 backends responded"' };
+  body = { _warnings: [{ symbol: "AAPL", price: 1, volume: "2", timestamp: "t" }] };
+  let metadata: ResponseMetadata | undefined;
+  const resp = await client().getRootMap({}, { onResponse: (m) => (metadata = m) });
+  assert.deepEqual(resp, body);
+  assert.deepEqual(metadata?.warnings, [partial]);
+});
+
+test("parseWarnings reads warnings joined by fetch", () => {
+  const joined = '299 - "a: first, with a comma", 299 - "b: \\"quoted\\"", 299 - "no code"';
+  assert.deepEqual(parseWarnings(joined), [
+    { code: "a", message: "first, with a comma" },
+    { code: "b", message: '"quoted"' },
+    { code: "", message: "no code" },
+  ]);
+  assert.deepEqual(parseWarnings(null), []);
+});
+`
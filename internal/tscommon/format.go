@@ -0,0 +1,189 @@
+package tscommon
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// ModuleFormat selects the module system of the runtime modules (service
+// modules, errors, barrels) the TypeScript generators emit.
+type ModuleFormat string
+
+const (
+	// ModuleFormatESM emits .ts modules that import each other with ".js"
+	// specifiers, as required by Node ESM / nodenext resolution.
+	ModuleFormatESM ModuleFormat = "esm"
+	// ModuleFormatCJS emits .cts modules, which compile to CommonJS regardless
+	// of the consumer's package.json "type", importing each other with ".cjs".
+	ModuleFormatCJS ModuleFormat = "cjs"
+	// ModuleFormatBoth emits every runtime module twice, as .mts and .cts.
+	ModuleFormatBoth ModuleFormat = "both"
+)
+
+// ParseModuleFormat validates a module= plugin parameter. The empty string
+// selects ModuleFormatESM.
+func ParseModuleFormat(s string) (ModuleFormat, error) {
+	switch f := ModuleFormat(s); f {
+	case "":
+		return ModuleFormatESM, nil
+	case ModuleFormatESM, ModuleFormatCJS, ModuleFormatBoth:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unsupported module format %q: expected %q, %q, or %q",
+			s, ModuleFormatESM, ModuleFormatCJS, ModuleFormatBoth)
+	}
+}
+
+// ModuleVariant is one emitted flavour of a runtime module: the extension of
+// the generated source file and the extension its importers reference it by.
+type ModuleVariant struct {
+	SourceExt string // ".ts", ".cts", or ".mts"
+	ImportExt string // ".js", ".cjs", or ".mjs"
+}
+
+// CommonJS reports whether the variant is the CommonJS flavour, whose client
+// modules assign their exports with `export =` instead of exporting a default.
+func (v ModuleVariant) CommonJS() bool {
+	return v.SourceExt == ".cts"
+}
+
+// Variants returns the flavours each runtime module is emitted in.
+func (f ModuleFormat) Variants() []ModuleVariant {
+	esm := ModuleVariant{SourceExt: ".ts", ImportExt: ".js"}
+	cjs := ModuleVariant{SourceExt: ".cts", ImportExt: ".cjs"}
+	mjs := ModuleVariant{SourceExt: ".mts", ImportExt: ".mjs"}
+	switch f {
+	case ModuleFormatCJS:
+		return []ModuleVariant{cjs}
+	case ModuleFormatBoth:
+		return []ModuleVariant{mjs, cjs}
+	case ModuleFormatESM:
+		return []ModuleVariant{esm}
+	default:
+		return []ModuleVariant{esm}
+	}
+}
+
+// TypeModuleExt returns the extension of the per-proto type modules. They hold
+// only interfaces and type aliases, so outside ESM they are emitted once as
+// declaration files shared by every runtime variant; ".js" type-only import
+// specifiers resolve to them in both module systems.
+func (f ModuleFormat) TypeModuleExt() string {
+	if f == ModuleFormatESM || f == "" {
+		return ".ts"
+	}
+	return ".d.ts"
+}
+
+// EmittedModule is a generated module the per-package barrels re-export.
+type EmittedModule struct {
+	// Path is the output-relative, extensionless module path
+	// (e.g. "crosspkg/shop/v1/service_client").
+	Path string
+	// TypeOnly marks per-proto type modules, which barrels outside ESM
+	// re-export with `export type *` so no runtime require is emitted for them.
+	TypeOnly bool
+	// Exports lists the names of a module written by EmitModuleBody, nil for
+	// modules with plain named exports. Barrels re-export the CommonJS flavour
+	// of such a module by name, since `export *` cannot re-export a module
+	// that uses `export =`.
+	Exports *ModuleExports
+}
+
+// ModuleExports lists the names the top-level declarations of a module export.
+type ModuleExports struct {
+	Values []string // classes, functions, enums, and variables
+	Types  []string // interfaces and type aliases
+}
+
+var exportDeclRE = regexp.MustCompile(
+	`^export\s+(?:(?:declare|abstract|async)\s+)*(class|function\*?|const|let|var|enum|interface|type)\s+([A-Za-z_$][\w$]*)`)
+
+// ScanExports collects the names declared by the top-level `export`
+// declarations of body, in declaration order.
+func ScanExports(body []string) ModuleExports {
+	var exports ModuleExports
+	for _, line := range body {
+		m := exportDeclRE.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if m[1] == "interface" || m[1] == "type" {
+			exports.Types = append(exports.Types, m[2])
+		} else {
+			exports.Values = append(exports.Values, m[2])
+		}
+	}
+	return exports
+}
+
+var nonIdentRE = regexp.MustCompile(`[^A-Za-z0-9_$]`)
+
+// ModuleNamespace returns the identifier EmitModuleBody wraps the CommonJS
+// flavour of module in: its base name, e.g. "service_client" for
+// "crosspkg/shop/v1/service_client".
+func ModuleNamespace(module string) string {
+	name := nonIdentRE.ReplaceAllString(path.Base(module), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// EmitModuleBody writes body, the top-level declarations of module, in the
+// flavour of variant, followed by the module's single export. ESM flavours
+// keep the named exports and add a default export holding the runtime
+// values. The CommonJS flavour wraps the declarations in the namespace
+// ModuleNamespace(module) and assigns it with `export =`, so require() returns
+// the same object and TypeScript consumers reach the types through it too.
+// Callers reserve the namespace name in the module's import tracker.
+func EmitModuleBody(gf *protogen.GeneratedFile, variant ModuleVariant, module string, body []string) {
+	exports := ScanExports(body)
+	if !variant.CommonJS() {
+		for _, line := range body {
+			gf.P(line)
+		}
+		gf.P("export default { ", strings.Join(exports.Values, ", "), " };")
+		return
+	}
+
+	for len(body) > 0 && body[len(body)-1] == "" {
+		body = body[:len(body)-1]
+	}
+	namespace := ModuleNamespace(module)
+	gf.P("namespace ", namespace, " {")
+	for _, line := range body {
+		if line == "" {
+			gf.P()
+			continue
+		}
+		gf.P("  ", line)
+	}
+	gf.P("}")
+	gf.P()
+	gf.P("export = ", namespace, ";")
+}
+
+// exportList renders the re-export list of a barrel entry for exports,
+// sorted by name, with the types marked type-only.
+func exportList(exports *ModuleExports) string {
+	names := make([]string, 0, len(exports.Values)+len(exports.Types))
+	isType := map[string]bool{}
+	names = append(names, exports.Values...)
+	for _, name := range exports.Types {
+		names = append(names, name)
+		isType[name] = true
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		if isType[name] {
+			names[i] = "type " + name
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,84 @@
+package tscommon
+
+import (
+	"slices"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestScanExports(t *testing.T) {
+	body := []string{
+		"export type GetResult =",
+		`  | { status: 200; body: Note };`,
+		"export class NotFoundResponse extends ApiError {",
+		"  export const notTopLevel = 1;",
+		"}",
+		"export interface NoteServiceClientOptions {",
+		"export class NoteServiceClient {",
+		"function validateNote(req: Note): void {",
+		"export async function* readItems(resp: Response): AsyncGenerator<unknown> {",
+		`export type * from "./note.js";`,
+	}
+	got := ScanExports(body)
+	if want := []string{"NotFoundResponse", "NoteServiceClient", "readItems"}; !slices.Equal(got.Values, want) {
+		t.Errorf("Values = %v, want %v", got.Values, want)
+	}
+	if want := []string{"GetResult", "NoteServiceClientOptions"}; !slices.Equal(got.Types, want) {
+		t.Errorf("Types = %v, want %v", got.Types, want)
+	}
+}
+
+func TestModuleNamespace(t *testing.T) {
+	tests := map[string]string{
+		"service_client":                  "service_client",
+		"crosspkg/shop/v1/service_client": "service_client",
+		"v1/user-api.v2_client":           "user_api_v2_client",
+		"3d_client":                       "_3d_client",
+	}
+	for module, want := range tests {
+		if got := ModuleNamespace(module); got != want {
+			t.Errorf("ModuleNamespace(%q) = %q, want %q", module, got, want)
+		}
+	}
+}
+
+// TestEmitPackageBarrelsExportAssignment asserts the CommonJS barrel
+// re-exports a module assigning its exports with `export =` by name, since
+// `export *` cannot re-export it, while the ESM barrel keeps `export *`.
+func TestEmitPackageBarrelsExportAssignment(t *testing.T) {
+	plugin, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	EmitPackageBarrels(plugin, ModuleFormatBoth, []EmittedModule{
+		{Path: "shop/v1/shop", TypeOnly: true},
+		{Path: "shop/v1/service_client", Exports: &ModuleExports{
+			Values: []string{"ShopServiceClient"},
+			Types:  []string{"ShopServiceClientOptions", "ShopServiceCallOptions"},
+		}},
+	})
+
+	want := map[string]string{
+		"shop/v1/index.mts": `// Code generated by sebuf. DO NOT EDIT.
+
+export * from "./service_client.mjs";
+export type * from "./shop.js";
+`,
+		"shop/v1/index.cts": `// Code generated by sebuf. DO NOT EDIT.
+
+export { type ShopServiceCallOptions, ShopServiceClient, type ShopServiceClientOptions } from "./service_client.cjs";
+export type * from "./shop.js";
+`,
+	}
+	files := plugin.Response().GetFile()
+	if len(files) != len(want) {
+		t.Fatalf("emitted %d files, want %d", len(files), len(want))
+	}
+	for _, f := range files {
+		if f.GetContent() != want[f.GetName()] {
+			t.Errorf("%s =\n%s\nwant\n%s", f.GetName(), f.GetContent(), want[f.GetName()])
+		}
+	}
+}
@@ -1,9 +1,32 @@
 package tscommon
 
 import (
+	"regexp"
+	"strconv"
 	"strings"
 )
 
+// identifierPattern matches names usable as bare TypeScript property names.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// PropertyKey returns name as a TypeScript property key, quoting it when it is
+// not a valid identifier (e.g., a json_name of "x-trace-id").
+func PropertyKey(name string) string {
+	if identifierPattern.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+// PropertyAccess returns the TypeScript expression reading property name of
+// obj: obj.name, or obj["name"] when name is not a valid identifier.
+func PropertyAccess(obj, name string) string {
+	if identifierPattern.MatchString(name) {
+		return obj + "." + name
+	}
+	return obj + "[" + strconv.Quote(name) + "]"
+}
+
 // SnakeToLowerCamel converts "user_id" to "userId".
 func SnakeToLowerCamel(s string) string {
 	parts := strings.Split(s, "_")
@@ -31,3 +54,40 @@ func HeaderNameToPropertyName(headerName string) string {
 	}
 	return strings.Join(parts, "")
 }
+
+// WriteJSDoc writes a proto comment as a JSDoc block at the given indent so
+// editors surface it on hover. Deprecated declarations get an @deprecated tag.
+// Nothing is written when the comment is empty and the declaration is not
+// deprecated.
+func WriteJSDoc(p Printer, indent, comment string, deprecated bool) {
+	var lines []string
+	if trimmed := strings.TrimSpace(comment); trimmed != "" {
+		for _, line := range strings.Split(trimmed, "\n") {
+			lines = append(lines, strings.ReplaceAll(strings.TrimSpace(line), "*/", "*\\/"))
+		}
+	}
+	if deprecated {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "@deprecated")
+	}
+
+	switch len(lines) {
+	case 0:
+		return
+	case 1:
+		p("%s/** %s */", indent, lines[0])
+		return
+	}
+
+	p("%s/**", indent)
+	for _, line := range lines {
+		if line == "" {
+			p("%s *", indent)
+			continue
+		}
+		p("%s * %s", indent, line)
+	}
+	p("%s */", indent)
+}
@@ -66,3 +66,27 @@ func TestHeaderNameToPropertyName(t *testing.T) {
 		})
 	}
 }
+
+func TestPropertyKeyAndAccess(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantKey    string
+		wantAccess string
+	}{
+		{name: "userId", wantKey: "userId", wantAccess: "req.userId"},
+		{name: "user_id", wantKey: "user_id", wantAccess: "req.user_id"},
+		{name: "$count", wantKey: "$count", wantAccess: "req.$count"},
+		{name: "x-trace-id", wantKey: `"x-trace-id"`, wantAccess: `req["x-trace-id"]`},
+		{name: "2fa", wantKey: `"2fa"`, wantAccess: `req["2fa"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PropertyKey(tt.name); got != tt.wantKey {
+				t.Errorf("PropertyKey(%q) = %s, want %s", tt.name, got, tt.wantKey)
+			}
+			if got := PropertyAccess("req", tt.name); got != tt.wantAccess {
+				t.Errorf("PropertyAccess(%q) = %s, want %s", tt.name, got, tt.wantAccess)
+			}
+		})
+	}
+}
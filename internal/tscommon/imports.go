@@ -15,14 +15,15 @@ import (
 // file emitted at the output root in modules mode.
 const errorsModule = "errors"
 
-// Names of the value exports of the shared errors module (see WriteErrorTypes).
+// Names of the exports of the shared errors module (see WriteErrorTypes).
+// FieldViolation is an interface and is imported type-only.
 const (
 	apiErrorName        = "ApiError"
 	fieldViolationName  = "FieldViolation"
 	validationErrorName = "ValidationError"
 )
 
-// errorHelperNames returns the value exports of the shared errors module,
+// errorHelperNames returns the exports of the shared errors module,
 // sorted. Every ImportTracker pre-reserves them as local names so an imported
 // proto type or enum whose emitted TS name collides with a helper is
 // deterministically aliased (e.g. ApiError_1) instead of producing a duplicate
@@ -104,6 +105,7 @@ type ImportTracker struct {
 	usedAlias   map[string]string           // local alias -> owning "spec\x00symbol"
 	errorSyms   map[string]bool             // error helpers referenced (value import)
 	errorsSpec  string
+	valueSyms   map[string]map[string]bool // other runtime module specifier -> symbols
 }
 
 // NewImportTracker returns an empty tracker with the error-helper and global
@@ -115,6 +117,7 @@ func NewImportTracker() *ImportTracker {
 		aliasOf:     map[string]string{},
 		usedAlias:   map[string]string{},
 		errorSyms:   map[string]bool{},
+		valueSyms:   map[string]map[string]bool{},
 	}
 	for _, name := range errorHelperNames() {
 		t.usedAlias[name] = reservedOwnerKey
@@ -125,6 +128,15 @@ func NewImportTracker() *ImportTracker {
 	return t
 }
 
+// Reserve pre-reserves additional local names, e.g. the exports of a runtime
+// module only some generators emit, so imported types are aliased around them.
+// Call it before any NeedType.
+func (t *ImportTracker) Reserve(names ...string) {
+	for _, name := range names {
+		t.usedAlias[name] = reservedOwnerKey
+	}
+}
+
 // NeedType records that `symbol` from module specifier `spec` is referenced and
 // returns the local name to use (aliased deterministically on collision).
 func (t *ImportTracker) NeedType(spec, symbol string) string {
@@ -157,13 +169,25 @@ func (t *ImportTracker) NeedErrors(spec string, symbols ...string) {
 	}
 }
 
+// NeedValues records that the given exports of a runtime module other than the
+// errors module are referenced. Symbols prefixed with "type " are imported
+// type-only within the same import statement.
+func (t *ImportTracker) NeedValues(spec string, symbols ...string) {
+	if t.valueSyms[spec] == nil {
+		t.valueSyms[spec] = map[string]bool{}
+	}
+	for _, s := range symbols {
+		t.valueSyms[spec][s] = true
+	}
+}
+
 // Empty reports whether no imports were recorded.
 func (t *ImportTracker) Empty() bool {
-	return len(t.errorSyms) == 0 && len(t.typeImports) == 0
+	return len(t.errorSyms) == 0 && len(t.valueSyms) == 0 && len(t.typeImports) == 0
 }
 
-// Render writes the import block (value import for error helpers, then sorted
-// type-only imports). It emits a trailing blank line when anything was written.
+// Render writes the import block (value import for error helpers, then other
+// runtime modules, then sorted type-only imports). It emits a trailing blank line when anything was written.
 func (t *ImportTracker) Render(p Printer) {
 	if t.Empty() {
 		return
@@ -174,8 +198,31 @@ func (t *ImportTracker) Render(p Printer) {
 			syms = append(syms, s)
 		}
 		sort.Strings(syms)
+		for i, s := range syms {
+			// FieldViolation is an interface: mark it type-only so runtimes that
+			// strip types without full compilation (Node, Deno) don't look for a
+			// value export.
+			if s == fieldViolationName {
+				syms[i] = "type " + s
+			}
+		}
 		p(`import { %s } from "%s";`, strings.Join(syms, ", "), t.errorsSpec)
 	}
+	valueSpecs := make([]string, 0, len(t.valueSyms))
+	for s := range t.valueSyms {
+		valueSpecs = append(valueSpecs, s)
+	}
+	sort.Strings(valueSpecs)
+	for _, spec := range valueSpecs {
+		syms := make([]string, 0, len(t.valueSyms[spec]))
+		for s := range t.valueSyms[spec] {
+			syms = append(syms, s)
+		}
+		sort.Slice(syms, func(i, j int) bool {
+			return strings.TrimPrefix(syms[i], "type ") < strings.TrimPrefix(syms[j], "type ")
+		})
+		p(`import { %s } from "%s";`, strings.Join(syms, ", "), spec)
+	}
 	specs := make([]string, 0, len(t.typeImports))
 	for s := range t.typeImports {
 		specs = append(specs, s)
@@ -204,6 +251,10 @@ func (t *ImportTracker) Render(p Printer) {
 type EmitContext struct {
 	SelfModule string // extensionless module path of the file being written
 	Imports    *ImportTracker
+	// ImportExt is the extension runtime modules (errors, fetch) are imported
+	// with: ".js" (the default when empty), ".cjs", or ".mjs". Type modules are
+	// always imported with ".js", which resolves to .ts and .d.ts alike.
+	ImportExt string
 }
 
 func (c *EmitContext) modules() bool {
@@ -244,7 +295,25 @@ func (c *EmitContext) NeedErrors(symbols ...string) {
 	if !c.modules() || len(symbols) == 0 {
 		return
 	}
-	c.Imports.NeedErrors(RelativeImportSpecifier(c.SelfModule, errorsModule), symbols...)
+	c.Imports.NeedErrors(c.runtimeSpecifier(errorsModule), symbols...)
+}
+
+// NeedRuntime records that this file references exports of the runtime module
+// at the given extensionless output path (see ImportTracker.NeedValues).
+func (c *EmitContext) NeedRuntime(module string, symbols ...string) {
+	if !c.modules() || len(symbols) == 0 {
+		return
+	}
+	c.Imports.NeedValues(c.runtimeSpecifier(module), symbols...)
+}
+
+// runtimeSpecifier is RelativeImportSpecifier with the ImportExt extension.
+func (c *EmitContext) runtimeSpecifier(toModule string) string {
+	spec := RelativeImportSpecifier(c.SelfModule, toModule)
+	if c.ImportExt == "" {
+		return spec
+	}
+	return strings.TrimSuffix(spec, ".js") + c.ImportExt
 }
 
 // UsedErrorSymbols returns the error-helper symbols referenced anywhere in the
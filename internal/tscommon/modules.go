@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
 )
 
 // BufferedPrinter returns a Printer that accumulates formatted lines into *lines.
@@ -33,13 +35,12 @@ func DirectPrinter(gf *protogen.GeneratedFile) Printer {
 
 // CollectAllServiceMessages builds the transitive closure of every message/enum
 // referenced by any service across all generated files, plus proto-defined
-// "*Error" messages (matching CollectServiceMessages).
+// "*Error" messages (matching CollectServiceMessages). Files are walked in path
+// order, so the discovery order of the messages does not depend on the order of
+// the files in the request.
 func CollectAllServiceMessages(plugin *protogen.Plugin) *MessageSet {
 	ms := NewMessageSet()
-	for _, file := range plugin.Files {
-		if !file.Generate {
-			continue
-		}
+	for _, file := range annotations.GeneratedFiles(plugin) {
 		for _, service := range file.Services {
 			for _, method := range service.Methods {
 				ms.AddMessage(method.Input)
@@ -85,15 +86,18 @@ func validatePathMode(plugin *protogen.Plugin) error {
 // plus a single shared errors module (errors.ts). Both TS generators call this;
 // the emitted files are byte-identical between them (neutral header). Note the
 // per-package barrels are not (see EmitPackageBarrels), so client and server
-// still require distinct output directories. It returns the output-relative
-// filenames (ending in ".ts") of the type modules it emitted, so callers can
-// fold them into per-package barrels.
+// still require distinct output directories. It returns the type modules it
+// emitted, so callers can fold them into per-package barrels.
+//
+// Outside ModuleFormatESM the type modules are emitted as <proto>.d.ts (see
+// ModuleFormat.TypeModuleExt) and the errors module once per format variant
+// (errors.cts, errors.mts).
 //
 // A proto type whose emitted TS name equals an error helper (ApiError,
 // ValidationError, FieldViolation) is fine: the type module declares it
 // normally, and service modules import it under a deterministic alias because
 // ImportTracker pre-reserves the helper names.
-func EmitSharedModules(plugin *protogen.Plugin) ([]string, error) {
+func EmitSharedModules(plugin *protogen.Plugin, format ModuleFormat) ([]EmittedModule, error) {
 	if err := validatePathMode(plugin); err != nil {
 		return nil, err
 	}
@@ -101,38 +105,42 @@ func EmitSharedModules(plugin *protogen.Plugin) ([]string, error) {
 
 	msgsBySrc := global.MessagesBySourceFile()
 	enumsBySrc := global.EnumsBySourceFile()
-	var emitted []string
+	var emitted []EmittedModule
 	for _, src := range sortedSourceFiles(msgsBySrc, enumsBySrc) {
-		if name := emitTypeModule(plugin, src, msgsBySrc[src], enumsBySrc[src]); name != "" {
-			emitted = append(emitted, name)
+		if name := emitTypeModule(plugin, format, src, msgsBySrc[src], enumsBySrc[src]); name != "" {
+			emitted = append(emitted, EmittedModule{Path: name, TypeOnly: true})
 		}
 	}
-	emitErrorsModule(plugin)
+	for _, variant := range format.Variants() {
+		emitErrorsModule(plugin, variant)
+	}
 	return emitted, nil
 }
 
-// EmitPackageBarrels writes an index.ts barrel into each non-root output
-// directory that contains at least one of the given generated module files,
+// EmitPackageBarrels writes an index barrel into each non-root output
+// directory that contains at least one of the given generated modules,
 // re-exporting every such sibling module so a consumer can import a whole proto
 // package by its directory (e.g. import { Album } from ".../anghamna/album/v1").
-// moduleFiles are output-relative paths ending in ".ts" (e.g.
-// "crosspkg/shop/v1/service_client.ts"). Re-exports are sorted for determinism
-// and carry a ".js" extension to match the relative-import convention. The
-// output root, where the shared errors module lives, never receives a barrel:
-// aggregating the packages at the root is the consumer's concern.
+// One barrel is written per format variant (index.ts, or index.mts/index.cts).
+// Re-exports are sorted for determinism and carry the variant's import
+// extension to match the relative-import convention; type modules outside ESM
+// are re-exported type-only, and modules assigning their CommonJS exports with
+// `export =` are re-exported by name. The output root, where the shared errors
+// module lives, never receives a barrel: aggregating the packages at the root
+// is the consumer's concern.
 //
 // Unlike the type modules and errors.ts, barrels are NOT byte-identical
 // between the client and server generators — each re-exports its own service
 // module — so the two generators must use distinct output directories (see
 // docs/client-generation.md).
-func EmitPackageBarrels(plugin *protogen.Plugin, moduleFiles []string) {
-	byDir := map[string][]string{}
-	for _, f := range moduleFiles {
-		dir := path.Dir(f)
+func EmitPackageBarrels(plugin *protogen.Plugin, format ModuleFormat, modules []EmittedModule) {
+	byDir := map[string][]EmittedModule{}
+	for _, m := range modules {
+		dir := path.Dir(m.Path)
 		if dir == "." || dir == "" {
 			continue
 		}
-		byDir[dir] = append(byDir[dir], path.Base(f))
+		byDir[dir] = append(byDir[dir], m)
 	}
 
 	dirs := make([]string, 0, len(byDir))
@@ -142,14 +150,26 @@ func EmitPackageBarrels(plugin *protogen.Plugin, moduleFiles []string) {
 	sort.Strings(dirs)
 
 	for _, dir := range dirs {
-		bases := byDir[dir]
-		sort.Strings(bases)
-		gf := plugin.NewGeneratedFile(dir+"/index.ts", "")
-		dp := DirectPrinter(gf)
-		dp("// Code generated by sebuf. DO NOT EDIT.")
-		dp("")
-		for _, base := range bases {
-			dp(`export * from "./%s.js";`, strings.TrimSuffix(base, ".ts"))
+		entries := byDir[dir]
+		sort.Slice(entries, func(i, j int) bool { return path.Base(entries[i].Path) < path.Base(entries[j].Path) })
+		for _, variant := range format.Variants() {
+			gf := plugin.NewGeneratedFile(dir+"/index"+variant.SourceExt, "")
+			dp := DirectPrinter(gf)
+			dp("// Code generated by sebuf. DO NOT EDIT.")
+			dp("")
+			for _, m := range entries {
+				base := path.Base(m.Path)
+				switch {
+				case m.TypeOnly && format != ModuleFormatESM:
+					dp(`export type * from "./%s.js";`, base)
+				case m.TypeOnly:
+					dp(`export * from "./%s.js";`, base)
+				case m.Exports != nil && variant.CommonJS():
+					dp(`export { %s } from "./%s%s";`, exportList(m.Exports), base, variant.ImportExt)
+				default:
+					dp(`export * from "./%s%s";`, base, variant.ImportExt)
+				}
+			}
 		}
 	}
 }
@@ -175,6 +195,7 @@ func sortedSourceFiles(msgs map[string][]*protogen.Message, enums map[string][]*
 
 func emitTypeModule(
 	plugin *protogen.Plugin,
+	format ModuleFormat,
 	srcPath string,
 	msgs []*protogen.Message,
 	enums []*protogen.Enum,
@@ -183,7 +204,7 @@ func emitTypeModule(
 		return ""
 	}
 	module := ModuleForFile(srcPath)
-	gf := plugin.NewGeneratedFile(module+".ts", "")
+	gf := plugin.NewGeneratedFile(module+format.TypeModuleExt(), "")
 	tracker := NewImportTracker()
 	ctx := &EmitContext{SelfModule: module, Imports: tracker}
 
@@ -204,11 +225,11 @@ func emitTypeModule(
 	for _, line := range body {
 		gf.P(line)
 	}
-	return module + ".ts"
+	return module
 }
 
-func emitErrorsModule(plugin *protogen.Plugin) {
-	gf := plugin.NewGeneratedFile("errors.ts", "")
+func emitErrorsModule(plugin *protogen.Plugin, variant ModuleVariant) {
+	gf := plugin.NewGeneratedFile(errorsModule+variant.SourceExt, "")
 	dp := DirectPrinter(gf)
 	dp("// Code generated by sebuf. DO NOT EDIT.")
 	dp("")
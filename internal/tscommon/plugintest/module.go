@@ -0,0 +1,349 @@
+package plugintest
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Module is a temporary Go module built around code generated by protoc, for
+// end-to-end tests that compile and run the generated code.
+type Module struct {
+	// Path is the module path. The go_package of every proto lives below it.
+	Path string
+	// Protos maps the proto files to generate to their sources. Files whose
+	// source is empty are read from ProtoDir instead.
+	Protos map[string]string
+	// ProtoDir is an extra import directory, e.g. a package's testdata/proto.
+	ProtoDir string
+	// Mappings are M options, e.g. "Mfoo.proto=foo_test/gen;gen", passed to
+	// every Go plugin.
+	Mappings []string
+	// Plugins run next to protoc-gen-go, which is always run.
+	Plugins []Plugin
+	// Out is the package directory the Go code is generated into, "gen" when
+	// empty.
+	Out string
+	// Files are the other files of the module, keyed by their path in it.
+	Files map[string]string
+	// Require lists requirements beyond sebuf and its protobuf dependencies,
+	// each as "path version".
+	Require []string
+	// Replace lists replacements beyond sebuf's, each as "path => dir".
+	Replace []string
+	// TidyArgs are added to go mod tidy, e.g. -e.
+	TidyArgs []string
+}
+
+// Plugin is a protoc plugin of this repository run by Generate.
+type Plugin struct {
+	// Name is the plugin name without the protoc-gen- prefix, e.g. "go-http".
+	Name string
+	// Opt holds the options that follow paths=source_relative.
+	Opt string
+	// Out is the directory the plugin writes into. Defaults to the Go package
+	// directory of the module.
+	Out string
+}
+
+// ProjectRoot returns the root of the repository.
+func ProjectRoot() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "..")
+}
+
+// Generate runs protoc over m into a temporary module, writes its go.mod and
+// files, runs go mod tidy and returns the module directory. It skips the test
+// when protoc is not installed.
+func Generate(t *testing.T, m Module) string {
+	t.Helper()
+	dir := t.TempDir()
+	GenerateCode(t, dir, m)
+	WriteModule(t, dir, m)
+	return dir
+}
+
+// GenerateCode runs protoc over the protos of m, writing the Go code into the
+// package directory m.Out of the module in dir. Tests that generate several
+// packages call it once per package before WriteModule. It skips the test when
+// protoc is not installed.
+func GenerateCode(t *testing.T, dir string, m Module) {
+	t.Helper()
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping integration test")
+	}
+
+	projectRoot := ProjectRoot()
+	out := m.Out
+	if out == "" {
+		out = "gen"
+	}
+	genDir := filepath.Join(dir, out)
+	if err := os.MkdirAll(genDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	protoDir := t.TempDir()
+	names := make([]string, 0, len(m.Protos))
+	sources := map[string]string{}
+	for name, source := range m.Protos {
+		names = append(names, name)
+		if source != "" {
+			sources[name] = source
+		}
+	}
+	sort.Strings(names)
+	WriteFiles(t, protoDir, sources)
+
+	mapping := ""
+	for _, option := range m.Mappings {
+		mapping += "," + option
+	}
+	args := []string{
+		"--go_out=" + genDir,
+		"--go_opt=paths=source_relative" + mapping,
+		"--proto_path=" + protoDir,
+	}
+	if m.ProtoDir != "" {
+		args = append(args, "--proto_path="+m.ProtoDir)
+	}
+	for _, plugin := range m.Plugins {
+		pluginOut := plugin.Out
+		if pluginOut == "" {
+			pluginOut = genDir
+		}
+		opt := "paths=source_relative"
+		if plugin.Opt != "" {
+			opt += "," + plugin.Opt
+		}
+		if strings.HasPrefix(plugin.Name, "go-") {
+			opt += mapping
+		}
+		args = append(args,
+			"--plugin=protoc-gen-"+plugin.Name+"="+Build(t, projectRoot, "protoc-gen-"+plugin.Name),
+			"--"+plugin.Name+"_out="+pluginOut,
+			"--"+plugin.Name+"_opt="+opt,
+		)
+	}
+	Protoc(t, append(args, names...)...)
+}
+
+// WriteModule writes the go.mod and files of m into dir and runs go mod tidy.
+func WriteModule(t *testing.T, dir string, m Module) {
+	t.Helper()
+	files := map[string]string{"go.mod": GoMod(t, m)}
+	for name, content := range m.Files {
+		files[name] = content
+	}
+	WriteFiles(t, dir, files)
+	GoCommand(t, dir, append([]string{"mod", "tidy"}, m.TidyArgs...)...)
+}
+
+// RunModuleTest generates m, runs go test -v -count=1 over all of its
+// packages, with goTestArgs added to the flags, and returns the output.
+func RunModuleTest(t *testing.T, m Module, goTestArgs ...string) []byte {
+	t.Helper()
+	return GoTest(t, Generate(t, m), goTestArgs...)
+}
+
+// RunTS generates the TypeScript code of m with its plugins, which all write
+// into one temporary directory, and runs the main.ts of m.Files there with
+// RunNode, returning its standard output. The Go fields of m are unused. It
+// skips the test when protoc or a type-stripping node is not installed.
+func RunTS(t *testing.T, m Module) []byte {
+	t.Helper()
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping integration test")
+	}
+	if TypeStrippingNode() == "" {
+		t.Skip("node with --experimental-strip-types not found, skipping integration test")
+	}
+
+	dir := t.TempDir()
+	protoDir := t.TempDir()
+	names := make([]string, 0, len(m.Protos))
+	sources := map[string]string{}
+	for name, source := range m.Protos {
+		names = append(names, name)
+		if source != "" {
+			sources[name] = source
+		}
+	}
+	sort.Strings(names)
+	WriteFiles(t, protoDir, sources)
+
+	args := []string{"--proto_path=" + protoDir}
+	if m.ProtoDir != "" {
+		args = append(args, "--proto_path="+m.ProtoDir)
+	}
+	for _, plugin := range m.Plugins {
+		opt := "paths=source_relative"
+		if plugin.Opt != "" {
+			opt += "," + plugin.Opt
+		}
+		args = append(args,
+			"--plugin=protoc-gen-"+plugin.Name+"="+Build(t, ProjectRoot(), "protoc-gen-"+plugin.Name),
+			"--"+plugin.Name+"_out="+dir,
+			"--"+plugin.Name+"_opt="+opt,
+		)
+	}
+	Protoc(t, append(args, names...)...)
+	return RunNode(t, dir, m.Files)
+}
+
+// RunNode writes files, with a package.json making them ES modules, into dir,
+// where TypeScript code was generated, and runs their main.ts with node
+// --experimental-strip-types. Node runs the TypeScript sources directly, so
+// the .js import specifiers of the generated sources are first pointed at
+// them. It returns the standard output of node, and skips the test when no
+// type-stripping node is installed.
+func RunNode(t *testing.T, dir string, files map[string]string) []byte {
+	t.Helper()
+	node := TypeStrippingNode()
+	if node == "" {
+		t.Skip("node with --experimental-strip-types not found, skipping integration test")
+	}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".ts" {
+			return err
+		}
+		source, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, []byte(strings.ReplaceAll(string(source), `.js";`, `.ts";`)), 0o644)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := map[string]string{"package.json": `{"type": "module"}`}
+	for name, content := range files {
+		all[name] = content
+	}
+	WriteFiles(t, dir, all)
+
+	cmd := exec.CommandContext(context.Background(), node, "--experimental-strip-types", "--no-warnings", "main.ts")
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("node main.ts failed: %v\n%s%s", err, out, stderr.Bytes())
+	}
+	return out
+}
+
+// TypeStrippingNode returns the node binary if it can run .ts files with
+// --experimental-strip-types (Node 22.6+), or "" otherwise.
+func TypeStrippingNode() string {
+	path, err := exec.LookPath("node")
+	if err != nil {
+		return ""
+	}
+	if exec.CommandContext(context.Background(), path, "--experimental-strip-types", "--no-warnings", "-e", "").Run() != nil {
+		return ""
+	}
+	return path
+}
+
+// GoTest runs go test -v -count=1 over all packages of the module in dir, with
+// goTestArgs added to the flags, and returns the output.
+func GoTest(t *testing.T, dir string, goTestArgs ...string) []byte {
+	t.Helper()
+	args := append([]string{"test", "-v", "-count=1"}, goTestArgs...)
+	return GoCommand(t, dir, append(args, "./...")...)
+}
+
+// Protoc runs protoc with args, with the repository's proto directory on the
+// import path.
+func Protoc(t *testing.T, args ...string) {
+	t.Helper()
+	args = append(args, "--proto_path="+filepath.Join(ProjectRoot(), "proto"))
+	cmd := exec.CommandContext(context.Background(), "protoc", args...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("protoc failed: %v\n%s", err, out)
+	}
+}
+
+// GoMod returns the go.mod of m, which requires sebuf from this repository,
+// at the Go, protobuf and protovalidate versions of the repository's own
+// go.mod, and the further requirements and replacements of m.
+func GoMod(t *testing.T, m Module) string {
+	t.Helper()
+	projectRoot := ProjectRoot()
+	content, err := os.ReadFile(filepath.Join(projectRoot, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	goVersion := ""
+	versions := map[string]string{}
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(line), "require "))
+		switch {
+		case len(fields) == 2 && fields[0] == "go":
+			goVersion = fields[1]
+		case len(fields) >= 2:
+			versions[fields[0]] = fields[1]
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("module " + m.Path + "\n\ngo " + goVersion + "\n\nrequire (\n")
+	b.WriteString("\tgithub.com/SebastienMelki/sebuf v0.0.0\n")
+	for _, dep := range []string{
+		"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go",
+		"google.golang.org/protobuf",
+	} {
+		version, ok := versions[dep]
+		if !ok {
+			t.Fatalf("%s not found in go.mod", dep)
+		}
+		b.WriteString("\t" + dep + " " + version + "\n")
+	}
+	for _, dep := range m.Require {
+		b.WriteString("\t" + dep + "\n")
+	}
+	b.WriteString(")\n\nreplace github.com/SebastienMelki/sebuf => " + projectRoot + "\n")
+	for _, replace := range m.Replace {
+		b.WriteString("\nreplace " + replace + "\n")
+	}
+	return b.String()
+}
+
+// GoCommand runs the go command with args in dir, logs its output and returns
+// it. The test fails if the command does.
+func GoCommand(t *testing.T, dir string, args ...string) []byte {
+	t.Helper()
+	cmd := exec.CommandContext(context.Background(), "go", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	t.Logf("go %s output:\n%s", strings.Join(args, " "), out)
+	if err != nil {
+		t.Fatalf("go %s failed: %v", strings.Join(args, " "), err)
+	}
+	return out
+}
+
+// WriteFiles writes files, keyed by their path relative to dir, creating the
+// directories they need.
+func WriteFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
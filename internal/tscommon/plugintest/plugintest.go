@@ -1,5 +1,6 @@
 // Package plugintest builds protoc plugin binaries for subprocess golden
-// tests. Building into a per-test temporary directory — instead of reusing a
+// tests and runs them into temporary Go modules for end-to-end tests.
+// Building into a per-test temporary directory — instead of reusing a
 // prebuilt bin/ binary — keeps the tests hermetic: a stale prebuilt binary
 // silently runs old generator code and reports spurious passes or failures.
 package plugintest
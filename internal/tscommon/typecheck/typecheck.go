@@ -8,6 +8,7 @@ package typecheck
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -19,11 +20,47 @@ import (
 // drift with whatever "latest" is on the machine running the tests.
 const tsVersion = "5.9.3"
 
-// Dir typechecks every .ts file under dir with tsc --noEmit. The test is
-// skipped when no TypeScript toolchain is available (neither tsc nor npx on
-// PATH); any compile error fails the test with the compiler output.
+// Options tweaks the compiler settings Dir uses.
+type Options struct {
+	// CommonJS compiles with module commonjs instead of nodenext, checking
+	// only the .cts runtime modules and .d.ts type modules of a module=cjs or
+	// module=both tree.
+	CommonJS bool
+	// NoDOM drops the DOM libs and declares the few web globals Node provides
+	// (AbortSignal, URLSearchParams, TextDecoder, structuredClone) instead,
+	// proving the output of the node and isomorphic targets does not depend on
+	// lib.dom.d.ts.
+	NoDOM bool
+}
+
+// nodeGlobals declares the web globals a Node runtime provides, standing in
+// for @types/node in NoDOM checks.
+const nodeGlobals = `declare class AbortSignal {
+  readonly aborted: boolean;
+}
+declare class URLSearchParams {
+  constructor();
+  append(name: string, value: string): void;
+  set(name: string, value: string): void;
+  toString(): string;
+}
+declare class TextDecoder {
+  decode(input?: Uint8Array, options?: { stream?: boolean }): string;
+}
+declare function structuredClone<T>(value: T): T;
+`
+
+// Dir typechecks every .ts, .mts, and .cts file under dir with tsc --noEmit.
+// The test is skipped when no TypeScript toolchain is available (neither tsc
+// nor npx on PATH); any compile error fails the test with the compiler output.
 func Dir(t *testing.T, dir string) {
 	t.Helper()
+	DirWithOptions(t, dir, Options{})
+}
+
+// DirWithOptions is Dir with the compiler settings adjusted by opts.
+func DirWithOptions(t *testing.T, dir string, opts Options) {
+	t.Helper()
 
 	tsc := tscCommand(t)
 
@@ -31,25 +68,47 @@ func Dir(t *testing.T, dir string) {
 	if err != nil {
 		t.Fatalf("failed to resolve %s: %v", dir, err)
 	}
+	root := filepath.ToSlash(absDir)
+	configDir := t.TempDir()
+
+	module, resolution := "nodenext", "nodenext"
+	include := []string{root + "/**/*.ts", root + "/**/*.mts", root + "/**/*.cts"}
+	if opts.CommonJS {
+		module, resolution = "commonjs", "node10"
+		include = []string{root + "/**/*.d.ts", root + "/**/*.cts"}
+	}
+	lib := `"es2020", "dom", "dom.iterable", "dom.asynciterable"`
+	if opts.NoDOM {
+		lib = `"es2020"`
+		globalsPath := filepath.Join(configDir, "node-globals.d.ts")
+		if writeErr := os.WriteFile(globalsPath, []byte(nodeGlobals), 0o600); writeErr != nil {
+			t.Fatalf("failed to write node globals: %v", writeErr)
+		}
+		include = append(include, filepath.ToSlash(globalsPath))
+	}
+	includeJSON, err := json.Marshal(include)
+	if err != nil {
+		t.Fatalf("failed to encode include patterns: %v", err)
+	}
 
 	// noUnusedLocals is deliberate: a generated module importing a symbol it
 	// never uses is a generator bug (and breaks consumers with strict configs).
 	config := fmt.Sprintf(`{
   "compilerOptions": {
-    "module": "nodenext",
-    "moduleResolution": "nodenext",
+    "module": %q,
+    "moduleResolution": %q,
     "target": "es2020",
-    "lib": ["es2020", "dom", "dom.iterable", "dom.asynciterable"],
+    "lib": [%s],
     "strict": true,
     "noEmit": true,
     "skipLibCheck": true,
     "noUnusedLocals": true
   },
-  "include": [%q]
+  "include": %s
 }
-`, filepath.ToSlash(absDir)+"/**/*.ts")
+`, module, resolution, lib, includeJSON)
 
-	tsconfigPath := filepath.Join(t.TempDir(), "tsconfig.json")
+	tsconfigPath := filepath.Join(configDir, "tsconfig.json")
 	if writeErr := os.WriteFile(tsconfigPath, []byte(config), 0o600); writeErr != nil {
 		t.Fatalf("failed to write tsconfig: %v", writeErr)
 	}
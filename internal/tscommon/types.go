@@ -169,6 +169,10 @@ func (ms *MessageSet) AddMessage(msg *protogen.Message) {
 	if fullName == "google.protobuf.Timestamp" {
 		return
 	}
+	// Skip Any, Struct, Value and ListValue — typed inline by their JSON shape
+	if annotations.IsDynamicJSONMessage(msg) {
+		return
+	}
 
 	// Skip map entry messages — they're synthetic and handled inline
 	if msg.Desc.IsMapEntry() {
@@ -304,7 +308,11 @@ func TSFieldTypeCtx(ctx *EmitContext, field *protogen.Field) string {
 
 	// Handle repeated fields
 	if field.Desc.IsList() {
-		return TSElementTypeCtx(ctx, field) + "[]"
+		element := TSElementTypeCtx(ctx, field)
+		if strings.Contains(element, " & ") {
+			element = "(" + element + ")"
+		}
+		return element + "[]"
 	}
 
 	// Handle google.protobuf.Timestamp fields (serialized as primitive, not as nested object)
@@ -312,6 +320,11 @@ func TSFieldTypeCtx(ctx *EmitContext, field *protogen.Field) string {
 		return TSTimestampType(field)
 	}
 
+	// Handle Any, Struct, Value and ListValue by their JSON shape
+	if wkt := annotations.DynamicJSONType(field); wkt != "" {
+		return TSDynamicJSONType(wkt)
+	}
+
 	// Handle message fields
 	if field.Desc.Kind() == protoreflect.MessageKind && field.Message != nil {
 		return ctx.RefMessage(field.Message)
@@ -320,7 +333,7 @@ func TSFieldTypeCtx(ctx *EmitContext, field *protogen.Field) string {
 	// Handle enum fields
 	if field.Desc.Kind() == protoreflect.EnumKind && field.Enum != nil {
 		// Check for NUMBER encoding - return number type instead of enum name
-		encoding := annotations.GetEnumEncoding(field)
+		encoding := annotations.ResolveEnumEncoding(field)
 		if encoding == http.EnumEncoding_ENUM_ENCODING_NUMBER {
 			return TSNumber
 		}
@@ -342,12 +355,15 @@ func TSElementTypeCtx(ctx *EmitContext, field *protogen.Field) string {
 	if annotations.IsTimestampField(field) {
 		return TSTimestampType(field)
 	}
+	if wkt := annotations.DynamicJSONType(field); wkt != "" {
+		return TSDynamicJSONType(wkt)
+	}
 	if field.Desc.Kind() == protoreflect.MessageKind && field.Message != nil {
 		return ctx.RefMessage(field.Message)
 	}
 	if field.Desc.Kind() == protoreflect.EnumKind && field.Enum != nil {
 		// Check for NUMBER encoding - return number type instead of enum name
-		encoding := annotations.GetEnumEncoding(field)
+		encoding := annotations.ResolveEnumEncoding(field)
 		if encoding == http.EnumEncoding_ENUM_ENCODING_NUMBER {
 			return TSNumber
 		}
@@ -500,7 +516,7 @@ func synthesizeOneofInfo(oneof *protogen.Oneof) *annotations.OneofDiscriminatorI
 	for _, field := range oneof.Fields {
 		info.Variants = append(info.Variants, annotations.OneofVariant{
 			Field:            field,
-			DiscriminatorVal: field.Desc.JSONName(),
+			DiscriminatorVal: annotations.JSONFieldName(field),
 			IsMessage:        field.Message != nil,
 		})
 	}
@@ -529,7 +545,7 @@ func GenerateOneofDiscriminatedUnionTypeCtx(
 
 	jsonNames := make([]string, len(info.Variants))
 	for i, variant := range info.Variants {
-		jsonNames[i] = variant.Field.Desc.JSONName()
+		jsonNames[i] = PropertyKey(annotations.JSONFieldName(variant.Field))
 	}
 
 	var branches []string
@@ -537,10 +553,10 @@ func GenerateOneofDiscriminatedUnionTypeCtx(
 		var branch string
 		if info.Flatten && variant.IsMessage {
 			// Flattened: { discriminator: "value", ...variant fields }
-			branch = fmt.Sprintf("{ %s: \"%s\"", info.Discriminator, variant.DiscriminatorVal)
+			branch = fmt.Sprintf("{ %s: \"%s\"", PropertyKey(info.Discriminator), variant.DiscriminatorVal)
 			var sb strings.Builder
 			for _, childField := range variant.Field.Message.Fields {
-				jsonName := childField.Desc.JSONName()
+				jsonName := PropertyKey(annotations.JSONFieldName(childField))
 				tsType := TSFieldTypeCtx(ctx, childField)
 				fmt.Fprintf(&sb, "; %s: %s", jsonName, tsType)
 			}
@@ -572,7 +588,7 @@ func GenerateOneofDiscriminatedUnionTypeCtx(
 	// keys; for flattened oneofs they are the promoted child field keys of
 	// every variant.
 	var unset strings.Builder
-	fmt.Fprintf(&unset, "{ %s?: never", info.Discriminator)
+	fmt.Fprintf(&unset, "{ %s?: never", PropertyKey(info.Discriminator))
 	guardedKeys := jsonNames
 	if info.Flatten {
 		guardedKeys = flattenedChildJSONNames(info)
@@ -606,7 +622,7 @@ func nonFlattenedOneofBranch(
 	tsType string,
 ) string {
 	var sb strings.Builder
-	fmt.Fprintf(&sb, "{ %s: \"%s\"; %s: %s", info.Discriminator, discriminatorVal, jsonNames[index], tsType)
+	fmt.Fprintf(&sb, "{ %s: \"%s\"; %s: %s", PropertyKey(info.Discriminator), discriminatorVal, jsonNames[index], tsType)
 	for j := range jsonNames {
 		if j == index {
 			continue
@@ -617,8 +633,8 @@ func nonFlattenedOneofBranch(
 	return sb.String()
 }
 
-// flattenedChildJSONNames returns the unique JSON names of every flattened
-// variant's child fields, in variant order then field order, deduplicated.
+// flattenedChildJSONNames returns the property keys of every flattened variant's
+// child fields, in variant order then field order, deduplicated.
 // These are the keys a flattened oneof promotes onto the parent object; the
 // unset arm guards them so a discriminator-less partial payload (e.g.
 // { body: "x" }) does not spuriously type-check as "nothing set". Only message
@@ -632,12 +648,12 @@ func flattenedChildJSONNames(info *annotations.OneofDiscriminatorInfo) []string
 			continue
 		}
 		for _, childField := range variant.Field.Message.Fields {
-			jsonName := childField.Desc.JSONName()
+			jsonName := annotations.JSONFieldName(childField)
 			if seen[jsonName] {
 				continue
 			}
 			seen[jsonName] = true
-			names = append(names, jsonName)
+			names = append(names, PropertyKey(jsonName))
 		}
 	}
 	return names
@@ -659,7 +675,7 @@ func generatePresenceOneofUnionType(
 	jsonNames := make([]string, len(info.Variants))
 	tsTypes := make([]string, len(info.Variants))
 	for i, variant := range info.Variants {
-		jsonNames[i] = variant.Field.Desc.JSONName()
+		jsonNames[i] = PropertyKey(annotations.JSONFieldName(variant.Field))
 		// Route the payload through the same field-typing path normal fields
 		// use so enum variants emit the enum union (or numeric encoding) and
 		// google.protobuf.Timestamp variants emit string/number per
@@ -827,9 +843,11 @@ func GenerateFieldDeclaration(p Printer, field *protogen.Field) {
 
 // GenerateFieldDeclarationCtx is the import-aware variant.
 func GenerateFieldDeclarationCtx(ctx *EmitContext, p Printer, field *protogen.Field) {
-	jsonName := field.Desc.JSONName()
+	jsonName := PropertyKey(annotations.JSONFieldName(field))
 	tsType := TSFieldTypeCtx(ctx, field)
 
+	WriteJSDoc(p, "  ", string(field.Comments.Leading), annotations.IsFieldDeprecated(field))
+
 	//nolint:gocritic // if-else chain is clearer than switch for distinct boolean checks
 	if annotations.IsNullableField(field) {
 		p("  %s: %s | null;", jsonName, tsType)
@@ -860,9 +878,11 @@ func GenerateFlattenedFields(p Printer, childMsg *protogen.Message, prefix strin
 // GenerateFlattenedFieldsCtx is the import-aware variant.
 func GenerateFlattenedFieldsCtx(ctx *EmitContext, p Printer, childMsg *protogen.Message, prefix string) {
 	for _, childField := range childMsg.Fields {
-		jsonName := prefix + childField.Desc.JSONName()
+		jsonName := PropertyKey(prefix + annotations.JSONFieldName(childField))
 		tsType := TSFieldTypeCtx(ctx, childField)
 
+		WriteJSDoc(p, "  ", string(childField.Comments.Leading), annotations.IsFieldDeprecated(childField))
+
 		//nolint:gocritic // if-else chain is clearer than switch for distinct boolean checks
 		if annotations.IsNullableField(childField) {
 			p("  %s: %s | null;", jsonName, tsType)
@@ -876,8 +896,12 @@ func GenerateFlattenedFieldsCtx(ctx *EmitContext, p Printer, childMsg *protogen.
 
 // IsOptionalField returns true if the field should be optional in TypeScript.
 func IsOptionalField(field *protogen.Field) bool {
-	// Explicit proto3 optional
-	if field.Desc.HasOptionalKeyword() {
+	// proto2 required fields are always sent
+	if annotations.IsRequiredField(field) {
+		return false
+	}
+	// proto3 optional, proto2 optional and editions explicit presence
+	if annotations.IsOptionalScalarField(field) {
 		return true
 	}
 	// Message-typed fields are nullable in proto3
@@ -894,7 +918,7 @@ func IsOptionalField(field *protogen.Field) bool {
 //
 //nolint:exhaustive // Only non-default formats need explicit handling; default covers RFC3339/DATE/UNSPECIFIED
 func TSTimestampType(field *protogen.Field) string {
-	format := annotations.GetTimestampFormat(field)
+	format := annotations.ResolveTimestampFormat(field)
 	switch format {
 	case http.TimestampFormat_TIMESTAMP_FORMAT_UNIX_SECONDS,
 		http.TimestampFormat_TIMESTAMP_FORMAT_UNIX_MILLIS:
@@ -905,6 +929,23 @@ func TSTimestampType(field *protogen.Field) string {
 	}
 }
 
+// TSDynamicJSONType returns the TypeScript type of the JSON protojson writes
+// for the well-known type wkt (see annotations.IsDynamicJSONMessage): Any as
+// its "@type" next to the embedded message's fields, Struct as an object,
+// ListValue as an array and Value as any JSON value.
+func TSDynamicJSONType(wkt protoreflect.FullName) string {
+	switch wkt {
+	case annotations.AnyFullName:
+		return `{ "@type": string } & Record<string, unknown>`
+	case annotations.StructFullName:
+		return "Record<string, unknown>"
+	case annotations.ListValueFullName:
+		return "unknown[]"
+	default:
+		return "unknown"
+	}
+}
+
 // WriteErrorTypes writes the shared error types (FieldViolation, ValidationError, ApiError).
 func WriteErrorTypes(p Printer) {
 	// FieldViolation
@@ -926,16 +967,26 @@ func WriteErrorTypes(p Printer) {
 	p("}")
 	p("")
 
-	// ApiError
+	// ApiError, with the code and details of a sebuf.http.Error body
 	p("export class ApiError extends Error {")
 	p("  statusCode: number;")
 	p("  body: string;")
+	p("  code: string;")
+	p("  details: Record<string, string>;")
 	p("")
-	p("  constructor(statusCode: number, message: string, body: string) {")
+	p("  constructor(")
+	p("    statusCode: number,")
+	p("    message: string,")
+	p("    body: string,")
+	p(`    code = "",`)
+	p("    details: Record<string, string> = {},")
+	p("  ) {")
 	p("    super(message);")
 	p(`    this.name = "ApiError";`)
 	p("    this.statusCode = statusCode;")
 	p("    this.body = body;")
+	p("    this.code = code;")
+	p("    this.details = details;")
 	p("  }")
 	p("}")
 	p("")
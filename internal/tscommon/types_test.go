@@ -183,14 +183,14 @@ func TestTSEnumUnspecifiedValue_ViaGoldenOutput(t *testing.T) {
 			t.Errorf("Expected Region type with custom enum_value annotations:\n  %s", expected)
 		}
 
-		// ...while the zero check lives in the service module.
+		// ...while the zero check lives in the URL builder of the service module.
 		client := readGoldenFile(
 			t, projectRoot,
 			"internal/tsclientgen/testdata/golden/query_params_client.ts",
 		)
 
 		// TSZeroCheckForField for enum query params should use custom unspecified value
-		if !strings.Contains(client, `req.region !== "unspecified"`) {
+		if !strings.Contains(client, `query.region !== "unspecified"`) {
 			t.Error(
 				`Expected zero check to use custom enum_value "unspecified" for Region query param`,
 			)
@@ -0,0 +1,139 @@
+package tsservergen
+
+import (
+	"strings"
+
+	"github.com/SebastienMelki/sebuf/internal/tscommon"
+)
+
+// Runtime selects the server adapter emitted alongside the route descriptors.
+type Runtime string
+
+const (
+	// RuntimeFetch targets fetch-API servers (Hono, Bun, Deno) whose handlers
+	// receive a Request and return a Response.
+	RuntimeFetch Runtime = "fetch"
+	// RuntimeNode targets Node's http module (IncomingMessage/ServerResponse).
+	RuntimeNode Runtime = "node"
+)
+
+func (r Runtime) valid() bool {
+	return r == RuntimeFetch || r == RuntimeNode
+}
+
+// routePath converts a sebuf path template ("/items/{id}") to the ":param"
+// syntax shared by Hono, Express, and the generated route matcher ("/items/:id").
+func routePath(fullPath string) string {
+	segments := strings.Split(fullPath, "/")
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			segments[i] = ":" + seg[1:len(seg)-1]
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// writeAdapters writes the runtime adapters that dispatch incoming requests to
+// the route descriptors. The fetch adapter is always emitted; the Node adapter
+// wraps it when runtime=node.
+func (g *Generator) writeAdapters(p tscommon.Printer) {
+	g.writeMatchRouteFn(p)
+	g.writeFetchHandlerFn(p)
+	if g.runtime == RuntimeNode {
+		g.writeNodeHandlerFn(p)
+	}
+}
+
+func (g *Generator) writeMatchRouteFn(p tscommon.Printer) {
+	p("function matchRoute(routes: RouteDescriptor[], method: string, pathname: string): RouteDescriptor | undefined {")
+	p(`  const pathSegments = pathname.split("/");`)
+	p("  return routes.find((route) => {")
+	p("    if (route.method !== method) return false;")
+	p(`    const routeSegments = route.path.split("/");`)
+	p("    return routeSegments.length === pathSegments.length &&")
+	p(`      routeSegments.every((seg, i) => seg.startsWith(":") ? pathSegments[i] !== "" : seg === pathSegments[i]);`)
+	p("  });")
+	p("}")
+	p("")
+}
+
+func (g *Generator) writeFetchHandlerFn(p tscommon.Printer) {
+	p("export function createFetchHandler(routes: RouteDescriptor[]): (req: Request) => Promise<Response> {")
+	p("  return async (req: Request): Promise<Response> => {")
+	p(`    const url = new URL(req.url, "http://localhost");`)
+	p("    const route = matchRoute(routes, req.method, url.pathname);")
+	p("    if (!route) {")
+	p(`      return new Response(JSON.stringify({ message: "not found" }), {`)
+	p("        status: 404,")
+	p(`        headers: { "Content-Type": "application/json" },`)
+	p("      });")
+	p("    }")
+	p("    return route.handler(req);")
+	p("  };")
+	p("}")
+	p("")
+}
+
+func (g *Generator) writeNodeHandlerFn(p tscommon.Printer) {
+	p("export function createNodeHandler(")
+	p("  routes: RouteDescriptor[],")
+	p("): (req: IncomingMessage, res: ServerResponse) => Promise<void> {")
+	p("  const handle = createFetchHandler(routes);")
+	p("  return async (req: IncomingMessage, res: ServerResponse): Promise<void> => {")
+	p("    const headers = new Headers();")
+	p("    for (const [name, value] of Object.entries(req.headers)) {")
+	p("      if (Array.isArray(value)) value.forEach((v) => headers.append(name, v));")
+	p("      else if (value !== undefined) headers.set(name, value);")
+	p("    }")
+	p("    const chunks: Buffer[] = [];")
+	p("    for await (const chunk of req) chunks.push(chunk as Buffer);")
+	p(`    const hasBody = req.method !== "GET" && req.method !== "HEAD" && chunks.length > 0;`)
+	p("    // Aborted when the client disconnects before the response ends")
+	p("    const abort = new AbortController();")
+	p(`    res.on("close", () => {`)
+	p("      if (!res.writableFinished) abort.abort();")
+	p("    });")
+	p(`    const request = new Request(new URL(req.url ?? "/", "http://" + (req.headers.host ?? "localhost")), {`)
+	p("      method: req.method,")
+	p("      headers,")
+	p("      body: hasBody ? Buffer.concat(chunks) : undefined,")
+	p("      signal: abort.signal,")
+	p("    });")
+	p("")
+	p("    const response = await handle(request);")
+	p("    res.statusCode = response.status;")
+	p("    response.headers.forEach((value, name) => res.setHeader(name, value));")
+	p("    if (response.body) {")
+	p("      const reader = response.body.getReader();")
+	p(`      abort.signal.addEventListener("abort", () => {`)
+	p("        reader.cancel().catch(() => {});")
+	p("      });")
+	p("      try {")
+	p("        while (true) {")
+	p("          const { done, value } = await reader.read();")
+	p("          if (done) break;")
+	p("          if (!res.write(value)) {")
+	p("            // Wait for the client to take the chunk, so a slow client slows the handler")
+	p("            await new Promise<void>((resolve) => {")
+	p("              const resume = (): void => {")
+	p(`                res.off("drain", resume);`)
+	p(`                res.off("close", resume);`)
+	p("                resolve();")
+	p("              };")
+	p(`              res.on("drain", resume);`)
+	p(`              res.on("close", resume);`)
+	p("            });")
+	p("          }")
+	p("        }")
+	p("      } catch {")
+	p("        // A streamed body failed after the status was sent: cut the response short")
+	p("        // once the chunks already written are flushed")
+	p(`        res.write("", () => res.destroy());`)
+	p("        return;")
+	p("      }")
+	p("    }")
+	p("    res.end();")
+	p("  };")
+	p("}")
+	p("")
+}
@@ -0,0 +1,197 @@
+package tsservergen
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	sebufhttp "github.com/SebastienMelki/sebuf/http"
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/tscommon"
+)
+
+// HandlerStyle selects the signature of the generated handler methods.
+type HandlerStyle string
+
+const (
+	// HandlerStyleLegacy generates handler(ctx: ServerContext, req), where the
+	// context exposes the raw request, headers and path parameters as records.
+	HandlerStyleLegacy HandlerStyle = "legacy"
+	// HandlerStyleContext generates handler(req, ctx) with a typed context per
+	// method, whose declared headers, path and query parameters are properties
+	// and whose setStatus and setHeader shape the response.
+	HandlerStyleContext HandlerStyle = "context"
+)
+
+func (s HandlerStyle) valid() bool {
+	return s == HandlerStyleLegacy || s == HandlerStyleContext
+}
+
+// contextTypeName returns the name of a method's typed handler context.
+func contextTypeName(service *protogen.Service, method *protogen.Method) string {
+	return service.GoName + method.GoName + "Context"
+}
+
+// headerPropertyName converts a header name to the camelCase property of the
+// typed context ("X-API-Key" becomes "xApiKey").
+func headerPropertyName(header string) string {
+	words := strings.FieldsFunc(header, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for i, word := range words {
+		word = strings.ToLower(word)
+		if i > 0 {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
+
+// contextHeaders returns the headers declared for a method, service headers
+// first; a method header replaces the service header of the same name.
+func contextHeaders(service *protogen.Service, method *protogen.Method) []*sebufhttp.Header {
+	methodHeaders := annotations.GetMethodHeaders(method)
+	overridden := make(map[string]bool, len(methodHeaders))
+	for _, h := range methodHeaders {
+		overridden[strings.ToLower(h.GetName())] = true
+	}
+	var headers []*sebufhttp.Header
+	for _, h := range annotations.GetServiceHeaders(service) {
+		if !overridden[strings.ToLower(h.GetName())] {
+			headers = append(headers, h)
+		}
+	}
+	return append(headers, methodHeaders...)
+}
+
+// headerAlwaysSet reports whether a declared header has a value once it passed
+// validation: it is required or has a default.
+func headerAlwaysSet(h *sebufhttp.Header) bool {
+	return h.GetRequired() || h.GetDefaultValue() != ""
+}
+
+// writeHandlerContextType writes the HandlerContext interface the typed
+// contexts of every method extend.
+func (g *Generator) writeHandlerContextType(p tscommon.Printer) {
+	p("export interface HandlerContext {")
+	p("  request: Request;")
+	p("  setStatus(code: number): void;")
+	p("  setHeader(name: string, value: string): void;")
+	p("}")
+	p("")
+}
+
+// generateContextTypes writes the typed context of each method of a service.
+func (g *Generator) generateContextTypes(p tscommon.Printer, service *protogen.Service) error {
+	for _, method := range service.Methods {
+		cfg, err := g.buildRPCRouteConfig(service, method)
+		if err != nil {
+			return err
+		}
+		inputType := g.ctx.RefMessage(method.Input)
+
+		p("export interface %s extends HandlerContext {", contextTypeName(service, method))
+		if headers := contextHeaders(service, method); len(headers) == 0 {
+			p("  headers: Record<string, never>;")
+		} else {
+			p("  headers: {")
+			for _, h := range headers {
+				valueType := "string | undefined"
+				switch {
+				case h.GetMultiple():
+					// Empty when the header is omitted
+					valueType = "string[]"
+				case headerAlwaysSet(h):
+					valueType = "string"
+				}
+				p("    %s: %s;", tscommon.PropertyKey(headerPropertyName(h.GetName())), valueType)
+			}
+			p("  };")
+		}
+		if len(cfg.pathParamFields) == 0 {
+			p("  pathParams: Record<string, never>;")
+		} else {
+			p("  pathParams: {")
+			for _, ppf := range cfg.pathParamFields {
+				p("    %s: string;", tscommon.PropertyKey(ppf.jsonName))
+			}
+			p("  };")
+		}
+		if len(cfg.queryParams) == 0 {
+			p("  query: Record<string, never>;")
+		} else {
+			p("  query: {")
+			for _, qp := range cfg.queryParams {
+				p(`    %s: %s["%s"];`, tscommon.PropertyKey(qp.FieldJSONName), inputType, qp.FieldJSONName)
+			}
+			p("  };")
+		}
+		p("}")
+		p("")
+	}
+	return nil
+}
+
+// generateHandlerContext writes the construction of a method's typed context.
+// The status and headers it sets are kept in status and responseHeaders, which
+// the caller declares and applies to the successful response.
+func (g *Generator) generateHandlerContext(
+	p tscommon.Printer,
+	service *protogen.Service,
+	method *protogen.Method,
+	cfg *rpcRouteConfig,
+) {
+	p("          const ctx: %s = {", contextTypeName(service, method))
+	p("            request: req,")
+	if headers := contextHeaders(service, method); len(headers) == 0 {
+		p("            headers: {},")
+	} else {
+		p("            headers: {")
+		for _, h := range headers {
+			fallback := "undefined"
+			switch {
+			case h.GetDefaultValue() != "":
+				fallback = fmt.Sprintf("%q", h.GetDefaultValue())
+			case h.GetRequired() || h.GetMultiple():
+				// Validated above, so never missing, or split into no values
+				fallback = `""`
+			}
+			value := fmt.Sprintf(`req.headers.get("%s") ?? %s`, annotations.CanonicalHeaderName(h.GetName()), fallback)
+			if h.GetMultiple() {
+				value = "splitHeaderValues(" + value + ")"
+			}
+			p(`              %s: %s,`, tscommon.PropertyKey(headerPropertyName(h.GetName())), value)
+		}
+		p("            },")
+	}
+	if len(cfg.pathParamFields) == 0 {
+		p("            pathParams: {},")
+	} else {
+		p("            pathParams: {")
+		for _, ppf := range cfg.pathParamFields {
+			p(`              %s: pathParams["%s"],`, tscommon.PropertyKey(ppf.jsonName), ppf.protoName)
+		}
+		p("            },")
+	}
+	if len(cfg.queryParams) == 0 {
+		p("            query: {},")
+	} else {
+		p("            query: {")
+		for _, qp := range cfg.queryParams {
+			p("              %s: %s,", tscommon.PropertyKey(qp.FieldJSONName), g.queryParamFieldExpr(qp))
+		}
+		p("            },")
+	}
+	p("            setStatus: (code: number): void => {")
+	p("              status = code;")
+	p("            },")
+	p("            setHeader: (name: string, value: string): void => {")
+	p("              responseHeaders.set(name, value);")
+	p("            },")
+	p("          };")
+	p("")
+}
@@ -2,7 +2,9 @@ package tsservergen
 
 import (
 	"fmt"
+	"io"
 	"net/http"
+	"os"
 	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
@@ -15,31 +17,87 @@ import (
 
 // Generator handles TypeScript server code generation for protobuf services.
 type Generator struct {
-	plugin *protogen.Plugin
+	plugin       *protogen.Plugin
+	runtime      Runtime
+	handlerStyle HandlerStyle
+	// strictHeaders fails on method headers overriding the type or format of
+	// a service header, which are otherwise reported to warnings.
+	strictHeaders bool
+	// warnings receives generation-time warnings. Defaults to os.Stderr.
+	warnings io.Writer
 	// ctx carries the emission state (self module + import tracker) for the
 	// service file currently being written.
 	ctx *tscommon.EmitContext
 }
 
+// Options configures the TypeScript server generator.
+type Options struct {
+	// Runtime selects the server adapter emitted next to the route descriptors.
+	// Defaults to RuntimeFetch.
+	Runtime Runtime
+	// HandlerStyle selects the signature of the generated handler methods.
+	// Defaults to HandlerStyleLegacy.
+	HandlerStyle HandlerStyle
+	// StrictHeaders fails generation when a method header overrides a service
+	// header with another type or format, instead of warning about it.
+	StrictHeaders bool
+}
+
 // New creates a new TypeScript server generator.
 func New(plugin *protogen.Plugin) *Generator {
-	return &Generator{plugin: plugin}
+	return NewWithOptions(plugin, Options{})
+}
+
+// NewWithOptions creates a new TypeScript server generator with the given options.
+func NewWithOptions(plugin *protogen.Plugin, opts Options) *Generator {
+	runtime := opts.Runtime
+	if runtime == "" {
+		runtime = RuntimeFetch
+	}
+	handlerStyle := opts.HandlerStyle
+	if handlerStyle == "" {
+		handlerStyle = HandlerStyleLegacy
+	}
+	return &Generator{
+		plugin:        plugin,
+		runtime:       runtime,
+		handlerStyle:  handlerStyle,
+		strictHeaders: opts.StrictHeaders,
+	}
 }
 
 // Generate emits one canonical type module per proto file, a shared errors
 // module, and one slimmed server module per service file.
 func (g *Generator) Generate() error {
+	if !g.runtime.valid() {
+		return fmt.Errorf("unsupported runtime %q: expected %q or %q", g.runtime, RuntimeFetch, RuntimeNode)
+	}
+	if !g.handlerStyle.valid() {
+		return fmt.Errorf(
+			"unsupported handler_style %q: expected %q or %q", g.handlerStyle, HandlerStyleLegacy, HandlerStyleContext,
+		)
+	}
+	if err := annotations.ValidateSyntax(g.plugin); err != nil {
+		return err
+	}
+	if err := annotations.ApplyVisibility(g.plugin, annotations.GeneratorTSServer); err != nil {
+		return err
+	}
 	return g.generateModules()
 }
 
 func (g *Generator) writeServerTypes(p tscommon.Printer) {
-	// ServerContext
-	p("export interface ServerContext {")
-	p("  request: Request;")
-	p("  pathParams: Record<string, string>;")
-	p("  headers: Record<string, string>;")
-	p("}")
-	p("")
+	if g.handlerStyle == HandlerStyleContext {
+		g.writeHandlerContextType(p)
+	} else {
+		// ServerContext
+		p("export interface ServerContext {")
+		p("  request: Request;")
+		p("  pathParams: Record<string, string>;")
+		p("  headers: Record<string, string>;")
+		p("}")
+		p("")
+	}
 
 	// ServerOptions
 	p("export interface ServerOptions {")
@@ -76,6 +134,7 @@ func (g *Generator) fileUsesHeaders(file *protogen.File) bool {
 func (g *Generator) writeHeaderValidationHelpers(p tscommon.Printer) {
 	g.writeHeaderRegexConstants(p)
 	g.writeHeaderConfigType(p)
+	g.writeSplitHeaderValuesFn(p)
 	g.writeValidateHeaderValueFn(p)
 	g.writeValidateHeadersFn(p)
 }
@@ -99,6 +158,16 @@ func (g *Generator) writeHeaderConfigType(p tscommon.Printer) {
 	p("  type: string;")
 	p("  required: boolean;")
 	p("  format?: string;")
+	p("  multiple?: boolean;")
+	p("}")
+	p("")
+}
+
+// writeSplitHeaderValuesFn writes the function splitting the line of a
+// multiple header, whose repeated lines fetch joins with commas, into values.
+func (g *Generator) writeSplitHeaderValuesFn(p tscommon.Printer) {
+	p("function splitHeaderValues(value: string): string[] {")
+	p(`  return value.split(",").map((v) => v.trim()).filter((v) => v !== "");`)
 	p("}")
 	p("")
 }
@@ -149,7 +218,8 @@ func (g *Generator) writeValidateHeadersFn(p tscommon.Printer) {
 	p("  const violations: FieldViolation[] = [];")
 	p("  for (const config of configs) {")
 	p("    const value = req.headers.get(config.name);")
-	p("    if (value == null) {")
+	p("    const values = value == null ? [] : config.multiple ? splitHeaderValues(value) : [value];")
+	p("    if (values.length === 0) {")
 	p("      if (config.required) {")
 	p("        violations.push({")
 	p("          field: config.name,")
@@ -158,7 +228,8 @@ func (g *Generator) writeValidateHeadersFn(p tscommon.Printer) {
 	p("      }")
 	p("      continue;")
 	p("    }")
-	p("    const err = validateHeaderValue(value, config);")
+	p("    // A multiple header is valid when each of its values is")
+	p("    const err = values.map((v) => validateHeaderValue(v, config)).find((e) => e !== undefined);")
 	p("    if (err) {")
 	p("      violations.push({")
 	p("        field: config.name,")
@@ -172,8 +243,40 @@ func (g *Generator) writeValidateHeadersFn(p tscommon.Printer) {
 }
 
 func (g *Generator) generateService(p tscommon.Printer, service *protogen.Service) error {
+	if err := annotations.ValidateServiceHeaders(service); err != nil {
+		return err
+	}
+	warnings := g.warnings
+	if warnings == nil {
+		warnings = os.Stderr
+	}
+	if err := annotations.ReportHeaderOverrides(service, g.strictHeaders, warnings); err != nil {
+		return err
+	}
+	if err := annotations.ValidateBasePathParams(service); err != nil {
+		return err
+	}
+	if err := annotations.ValidateScalarQueryParams(service, "protoc-gen-ts-server"); err != nil {
+		return err
+	}
+	if err := annotations.ValidateQueryAliases(service); err != nil {
+		return err
+	}
+	if err := annotations.ValidateBodylessRequestFields(service); err != nil {
+		return err
+	}
+
+	// Typed handler contexts
+	if g.handlerStyle == HandlerStyleContext {
+		if err := g.generateContextTypes(p, service); err != nil {
+			return err
+		}
+	}
+
 	// Handler interface
-	g.generateHandlerInterface(p, service)
+	if err := g.generateHandlerInterface(p, service); err != nil {
+		return err
+	}
 
 	// Route creation function
 	return g.generateCreateRoutes(p, service)
@@ -186,22 +289,30 @@ func (g *Generator) isSSEMethod(method *protogen.Method) bool {
 }
 
 // generateHandlerInterface generates the XxxServiceHandler interface.
-func (g *Generator) generateHandlerInterface(p tscommon.Printer, service *protogen.Service) {
+func (g *Generator) generateHandlerInterface(p tscommon.Printer, service *protogen.Service) error {
 	serviceName := service.GoName
 
 	p("export interface %sHandler {", serviceName)
 	for _, method := range service.Methods {
 		methodName := annotations.LowerFirst(method.GoName)
 		inputType := g.ctx.RefMessage(method.Input)
-		outputType := g.resolveOutputType(method)
-		if g.isSSEMethod(method) {
-			p("  %s(ctx: ServerContext, req: %s): ReadableStream<%s>;", methodName, inputType, outputType)
+		params := "ctx: ServerContext, req: " + inputType
+		if g.handlerStyle == HandlerStyleContext {
+			params = "req: " + inputType + ", ctx: " + contextTypeName(service, method)
+		}
+		if g.isStreamingMethod(method) {
+			itemType, err := g.streamItemType(method)
+			if err != nil {
+				return fmt.Errorf("service %s, method %s: %w", service.GoName, method.GoName, err)
+			}
+			p("  %s(%s, send: (msg: %s) => Promise<void>): Promise<void>;", methodName, params, itemType)
 		} else {
-			p("  %s(ctx: ServerContext, req: %s): Promise<%s>;", methodName, inputType, outputType)
+			p("  %s(%s): Promise<%s>;", methodName, params, g.resolveOutputType(method))
 		}
 	}
 	p("}")
 	p("")
+	return nil
 }
 
 // resolveOutputType returns the TypeScript return type, handling root unwrap.
@@ -215,9 +326,9 @@ func (g *Generator) resolveOutputType(method *protogen.Method) string {
 
 // pathParamField maps a URL path parameter to its corresponding request message field.
 type pathParamField struct {
-	protoName string // proto field name, e.g. "resource_id"
-	jsonName  string // JSON/TS field name, e.g. "resourceId"
-	field     *protogen.Field
+	protoName string          // proto field name, e.g. "resource_id"
+	jsonName  string          // JSON/TS field name, e.g. "resourceId"
+	field     *protogen.Field // nil for a context_only base path parameter
 }
 
 // rpcRouteConfig holds config for generating a route handler.
@@ -229,6 +340,7 @@ type rpcRouteConfig struct {
 	pathParams      []string
 	pathParamFields []pathParamField
 	queryParams     []annotations.QueryParam
+	headerParams    []annotations.HeaderFieldParam
 	hasBody         bool
 }
 
@@ -253,12 +365,32 @@ func (g *Generator) buildRPCRouteConfig(service *protogen.Service, method *proto
 
 	basePath := annotations.GetServiceBasePath(service)
 	fullPath := annotations.BuildHTTPPath(basePath, httpPath)
+	if wildcard := annotations.PathWildcard(fullPath); wildcard != "" {
+		return nil, fmt.Errorf("service %s, method %s: path %s ends with the wildcard {%s...}, which the "+
+			"TypeScript server does not support", serviceName, methodName, fullPath, wildcard)
+	}
 
-	// Validate and resolve path params against request message fields
-	pathParamFields, err := resolvePathParamFields(pathParams, method)
+	// Validate and resolve path params against request message fields. The
+	// base path parameters are extracted too, and bound unless context_only.
+	boundParams := append(annotations.GetBoundBasePathParams(service), pathParams...)
+	pathParamFields, err := resolvePathParamFields(boundParams, method)
 	if err != nil {
 		return nil, fmt.Errorf("service %s, method %s: %w", serviceName, methodName, err)
 	}
+	if sourceErr := annotations.ValidateFieldSources(method, boundParams); sourceErr != nil {
+		return nil, fmt.Errorf("service %s, method %s: %w", serviceName, methodName, sourceErr)
+	}
+	var basePathParams []string
+	for _, param := range annotations.GetBasePathParams(service) {
+		basePathParams = append(basePathParams, param.Name)
+		if param.ContextOnly {
+			pathParamFields = append(pathParamFields, pathParamField{
+				protoName: param.Name,
+				jsonName:  tscommon.SnakeToLowerCamel(param.Name),
+			})
+		}
+	}
+	pathParams = append(basePathParams, pathParams...)
 
 	return &rpcRouteConfig{
 		serviceName:     serviceName,
@@ -268,6 +400,7 @@ func (g *Generator) buildRPCRouteConfig(service *protogen.Service, method *proto
 		pathParams:      pathParams,
 		pathParamFields: pathParamFields,
 		queryParams:     annotations.GetQueryParams(method.Input),
+		headerParams:    annotations.GetHeaderFieldParams(method.Input),
 		hasBody:         httpMethod == "POST" || httpMethod == "PUT" || httpMethod == "PATCH",
 	}, nil
 }
@@ -295,7 +428,7 @@ func resolvePathParamFields(pathParams []string, method *protogen.Method) ([]pat
 				param, method.Input.Desc.Name(), param,
 			)
 		}
-		fields = append(fields, pathParamField{protoName: param, jsonName: f.Desc.JSONName(), field: f})
+		fields = append(fields, pathParamField{protoName: param, jsonName: annotations.JSONFieldName(f), field: f})
 	}
 	return fields, nil
 }
@@ -317,6 +450,9 @@ func validateFieldCoverage(cfg *rpcRouteConfig, method *protogen.Method) error {
 	for _, q := range cfg.queryParams {
 		covered[q.FieldName] = true
 	}
+	for _, h := range cfg.headerParams {
+		covered[h.FieldName] = true
+	}
 
 	// Check every field on the input message
 	var uncovered []string
@@ -329,7 +465,7 @@ func validateFieldCoverage(cfg *rpcRouteConfig, method *protogen.Method) error {
 
 	if len(uncovered) > 0 {
 		return fmt.Errorf(
-			"fields %v on request message %s are not reachable via path or query parameters for %s %s — "+
+			"fields %v on request message %s are not reachable via path, query, or header parameters for %s %s — "+
 				"annotate them with (sebuf.http.query) or include them as path parameters",
 			uncovered, method.Input.Desc.Name(), cfg.httpMethod, cfg.fullPath,
 		)
@@ -369,8 +505,8 @@ func (g *Generator) generateRouteEntry(p tscommon.Printer, service *protogen.Ser
 		return fmt.Errorf("service %s, method %s: %w", service.GoName, method.GoName, coverageErr)
 	}
 
-	if g.isSSEMethod(method) {
-		return g.generateSSERouteEntry(p, service, method, cfg)
+	if g.isStreamingMethod(method) {
+		return g.generateStreamRouteEntry(p, service, method, cfg)
 	}
 
 	tsMethodName := annotations.LowerFirst(cfg.methodName)
@@ -378,7 +514,7 @@ func (g *Generator) generateRouteEntry(p tscommon.Printer, service *protogen.Ser
 
 	p("    {")
 	p(`      method: "%s",`, cfg.httpMethod)
-	p(`      path: "%s",`, cfg.fullPath)
+	p(`      path: "%s",`, routePath(cfg.fullPath))
 	p("      handler: async (req: Request): Promise<Response> => {")
 
 	// Try-catch wraps the handler body
@@ -394,30 +530,45 @@ func (g *Generator) generateRouteEntry(p tscommon.Printer, service *protogen.Ser
 
 	// Parse request body or query params
 	if cfg.hasBody {
-		g.generateBodyParsing(p, method, tsMethodName)
-		// For body methods, path params must be merged after JSON parse
+		// Body first, then query, then path params: later sources win,
+		// matching the Go server's binding precedence (path > query > body)
+		g.generateBodyParsing(p, method)
+		g.generateQueryParamMerge(p, cfg)
 		g.generatePathParamMerge(p, cfg, method)
+		g.generateHeaderParamMerge(p, cfg)
+		g.generateValidationHook(p, tsMethodName)
 	} else {
 		// For non-body methods, path params are included in the body literal
 		g.generateQueryParamParsing(p, cfg, method, tsMethodName)
 	}
 
-	// Build ServerContext
-	p("          const ctx: ServerContext = {")
-	p("            request: req,")
-	p("            pathParams,")
-	p("            headers: Object.fromEntries(req.headers.entries()),")
-	p("          };")
-	p("")
+	if g.handlerStyle == HandlerStyleContext {
+		// Build the typed context, collecting the status and headers it sets
+		p("          let status = 200;")
+		p(`          const responseHeaders = new Headers({ "Content-Type": "application/json" });`)
+		g.generateHandlerContext(p, service, method, cfg)
 
-	// Call handler
-	p("          const result = await handler.%s(ctx, body);", tsMethodName)
+		// Call handler
+		p("          const result = await handler.%s(body, ctx);", tsMethodName)
 
-	// Return JSON response
-	p("          return new Response(JSON.stringify(result as %s), {", outputType)
-	p("            status: 200,")
-	p(`            headers: { "Content-Type": "application/json" },`)
-	p("          });")
+		// Return JSON response
+		p("          return new Response(JSON.stringify(result as %s), {", outputType)
+		p("            status,")
+		p("            headers: responseHeaders,")
+		p("          });")
+	} else {
+		// Build ServerContext
+		g.generateServerContext(p)
+
+		// Call handler
+		p("          const result = await handler.%s(ctx, body);", tsMethodName)
+
+		// Return JSON response
+		p("          return new Response(JSON.stringify(result as %s), {", outputType)
+		p("            status: 200,")
+		p(`            headers: { "Content-Type": "application/json" },`)
+		p("          });")
+	}
 
 	// Catch block
 	p("        } catch (err: unknown) {")
@@ -442,105 +593,22 @@ func (g *Generator) generateRouteEntry(p tscommon.Printer, service *protogen.Ser
 	return nil
 }
 
-// generateSSERouteEntry generates a route descriptor for an SSE streaming endpoint.
-//
-//nolint:funlen // SSE route entry generation requires many sequential code generation blocks
-func (g *Generator) generateSSERouteEntry(
-	p tscommon.Printer,
-	service *protogen.Service,
-	method *protogen.Method,
-	cfg *rpcRouteConfig,
-) error {
-	tsMethodName := annotations.LowerFirst(cfg.methodName)
-
-	p("    {")
-	p(`      method: "%s",`, cfg.httpMethod)
-	p(`      path: "%s",`, cfg.fullPath)
-	p("      handler: async (req: Request): Promise<Response> => {")
-
-	// Try-catch wraps the handler body
-	p("        try {")
-
-	// Header validation
-	serviceHeaders := annotations.GetServiceHeaders(service)
-	methodHeaders := annotations.GetMethodHeaders(method)
-	g.generateHeaderValidation(p, serviceHeaders, methodHeaders)
-
-	// Extract path params
-	g.generatePathParamExtraction(p, cfg)
-
-	// Parse request body or query params
-	if cfg.hasBody {
-		g.generateBodyParsing(p, method, tsMethodName)
-		g.generatePathParamMerge(p, cfg, method)
-	} else {
-		g.generateQueryParamParsing(p, cfg, method, tsMethodName)
-	}
-
-	// Build ServerContext
+// generateServerContext writes the construction of the legacy ServerContext.
+func (g *Generator) generateServerContext(p tscommon.Printer) {
 	p("          const ctx: ServerContext = {")
 	p("            request: req,")
 	p("            pathParams,")
 	p("            headers: Object.fromEntries(req.headers.entries()),")
 	p("          };")
 	p("")
+}
 
-	// Get the ReadableStream from handler
-	p("          const stream = handler.%s(ctx, body);", tsMethodName)
-	p("")
-
-	// Convert ReadableStream<T> to SSE text stream
-	p("          const sseStream = new ReadableStream({")
-	p("            async start(controller) {")
-	p("              const reader = stream.getReader();")
-	p("              const encoder = new TextEncoder();")
-	p("              try {")
-	p("                while (true) {")
-	p("                  const { done, value } = await reader.read();")
-	p("                  if (done) break;")
-	p("                  controller.enqueue(encoder.encode(`data: ${JSON.stringify(value)}\\n\\n`));")
-	p("                }")
-	p("                controller.close();")
-	p("              } catch (err) {")
-	p("                controller.enqueue(")
-	p("                  encoder.encode(`event: error\\ndata: ${JSON.stringify({ message: String(err) })}\\n\\n`),")
-	p("                );")
-	p("                controller.close();")
-	p("              }")
-	p("            },")
-	p("          });")
-	p("")
-
-	// Return SSE response
-	p("          return new Response(sseStream, {")
-	p("            headers: {")
-	p(`              "Content-Type": "text/event-stream",`)
-	p(`              "Cache-Control": "no-cache",`)
-	p(`              "Connection": "keep-alive",`)
-	p("            },")
-	p("          });")
-
-	// Catch block
-	p("        } catch (err: unknown) {")
-	p("          if (err instanceof ValidationError) {")
-	p("            return new Response(JSON.stringify({ violations: err.violations }), {")
-	p("              status: 400,")
-	p(`              headers: { "Content-Type": "application/json" },`)
-	p("            });")
-	p("          }")
-	p("          if (options?.onError) {")
-	p("            return options.onError(err, req);")
-	p("          }")
-	p("          const message = err instanceof Error ? err.message : String(err);")
-	p("          return new Response(JSON.stringify({ message }), {")
-	p("            status: 500,")
-	p(`            headers: { "Content-Type": "application/json" },`)
-	p("          });")
-	p("        }")
-
-	p("      },")
-	p("    },")
-	return nil
+// writeSSEResponseHeaders writes the headers of an SSE response as object
+// literal entries.
+func (g *Generator) writeSSEResponseHeaders(p tscommon.Printer, indent string) {
+	p(`%s"Content-Type": "text/event-stream",`, indent)
+	p(`%s"Cache-Control": "no-cache",`, indent)
+	p(`%s"Connection": "keep-alive",`, indent)
 }
 
 // emitPathParamAssignment emits a single path param assignment with enum casting if needed.
@@ -550,14 +618,18 @@ func (g *Generator) emitPathParamAssignment(
 	prefix string,
 	suffix string,
 ) {
-	if ppf.field != nil && ppf.field.Desc.Kind() == protoreflect.EnumKind && ppf.field.Enum != nil {
+	if ppf.field == nil {
+		// context_only: read from the context, not bound to the request
+		return
+	}
+	if ppf.field.Desc.Kind() == protoreflect.EnumKind && ppf.field.Enum != nil {
 		enumName := g.ctx.RefEnum(ppf.field.Enum)
 		p(
 			"%s%s: pathParams[\"%s\"] as %s%s",
-			prefix, ppf.jsonName, ppf.protoName, enumName, suffix,
+			prefix, tscommon.PropertyKey(ppf.jsonName), ppf.protoName, enumName, suffix,
 		)
 	} else {
-		p("%s%s: pathParams[\"%s\"]%s", prefix, ppf.jsonName, ppf.protoName, suffix)
+		p("%s%s: pathParams[\"%s\"]%s", prefix, tscommon.PropertyKey(ppf.jsonName), ppf.protoName, suffix)
 	}
 }
 
@@ -568,17 +640,91 @@ func (g *Generator) generatePathParamMerge(p tscommon.Printer, cfg *rpcRouteConf
 		return
 	}
 	for _, ppf := range cfg.pathParamFields {
-		if ppf.field != nil && ppf.field.Desc.Kind() == protoreflect.EnumKind && ppf.field.Enum != nil {
+		if ppf.field == nil {
+			continue
+		}
+		if ppf.field.Desc.Kind() == protoreflect.EnumKind && ppf.field.Enum != nil {
 			enumName := g.ctx.RefEnum(ppf.field.Enum)
 			p(
-				"          body.%s = pathParams[\"%s\"] as %s;",
-				ppf.jsonName, ppf.protoName, enumName,
+				"          %s = pathParams[\"%s\"] as %s;",
+				tscommon.PropertyAccess("body", ppf.jsonName), ppf.protoName, enumName,
 			)
 		} else {
-			p("          body.%s = pathParams[\"%s\"];", ppf.jsonName, ppf.protoName)
+			p("          %s = pathParams[\"%s\"];", tscommon.PropertyAccess("body", ppf.jsonName), ppf.protoName)
 		}
 	}
-	p("")
+}
+
+// generateQueryParamMerge generates code that overlays query parameters onto a
+// parsed JSON body. Only parameters present in the URL override body values.
+func (g *Generator) generateQueryParamMerge(p tscommon.Printer, cfg *rpcRouteConfig) {
+	if len(cfg.queryParams) == 0 {
+		return
+	}
+	if len(cfg.pathParams) == 0 {
+		// url is only declared by path param extraction
+		p("          const url = new URL(req.url, \"http://localhost\");")
+	}
+	p("          const params = url.searchParams;")
+	for _, qp := range cfg.queryParams {
+		if annotations.GetFieldSource(qp.Field) == sebufhttp.FieldSource_FIELD_SOURCE_QUERY {
+			// Declared query-only: the body value never counts
+			p("          %s = %s;", tscommon.PropertyAccess("body", qp.FieldJSONName), g.queryParamFieldExpr(qp))
+			continue
+		}
+		p(`          if (params.has(%s)) %s = %s;`,
+			queryParamNameExpr(qp), tscommon.PropertyAccess("body", qp.FieldJSONName), g.queryParamValueExpr(qp))
+	}
+}
+
+// generateHeaderParamMerge overlays the fields declared with source HEADER onto
+// a parsed JSON body. The body value never counts, even if the header is missing.
+func (g *Generator) generateHeaderParamMerge(p tscommon.Printer, cfg *rpcRouteConfig) {
+	for _, hp := range cfg.headerParams {
+		p("          %s = %s;", tscommon.PropertyAccess("body", hp.FieldJSONName), headerParamValueExpr(hp))
+	}
+}
+
+// headerParamValueExpr returns the TS expression reading a header-sourced field.
+func headerParamValueExpr(hp annotations.HeaderFieldParam) string {
+	if hp.Field.Desc.HasPresence() {
+		return fmt.Sprintf(`req.headers.get("%s") ?? undefined`, hp.HeaderName)
+	}
+	return fmt.Sprintf(`req.headers.get("%s") ?? ""`, hp.HeaderName)
+}
+
+// queryParamValueExpr returns the TS expression converting a present query
+// parameter to the field's type.
+func (g *Generator) queryParamValueExpr(qp annotations.QueryParam) string {
+	name := queryParamNameExpr(qp)
+	if qp.Field == nil {
+		return fmt.Sprintf(`params.get(%s)!`, name)
+	}
+	isEnum := qp.Field.Desc.Kind() == protoreflect.EnumKind && qp.Field.Enum != nil
+	if qp.Field.Desc.IsList() {
+		if isEnum {
+			return fmt.Sprintf(`params.getAll(%s) as %s[]`, name, g.ctx.RefEnum(qp.Field.Enum))
+		}
+		switch tscommon.TSScalarTypeForField(qp.Field) {
+		case tscommon.TSNumber:
+			return fmt.Sprintf(`params.getAll(%s).map(Number)`, name)
+		case tscommon.TSBoolean:
+			return fmt.Sprintf(`params.getAll(%s).map(v => v === "true")`, name)
+		default:
+			return fmt.Sprintf(`params.getAll(%s)`, name)
+		}
+	}
+	if isEnum {
+		return fmt.Sprintf(`params.get(%s) as %s`, name, g.ctx.RefEnum(qp.Field.Enum))
+	}
+	switch tscommon.TSScalarTypeForField(qp.Field) {
+	case tscommon.TSNumber:
+		return fmt.Sprintf(`Number(params.get(%s))`, name)
+	case tscommon.TSBoolean:
+		return fmt.Sprintf(`params.get(%s) === "true"`, name)
+	default:
+		return fmt.Sprintf(`params.get(%s)!`, name)
+	}
 }
 
 // generateHeaderValidation generates header validation code.
@@ -602,8 +748,13 @@ func (g *Generator) generateHeaderValidation(
 		if h.GetFormat() != "" {
 			formatStr = fmt.Sprintf(`, format: "%s"`, h.GetFormat())
 		}
+		if h.GetMultiple() {
+			formatStr += ", multiple: true"
+		}
+		// A header with a default is never missing, so it is not required here
+		name := annotations.CanonicalHeaderName(h.GetName())
 		p(`            { name: "%s", type: "%s", required: %t%s },`,
-			h.GetName(), h.GetType(), h.GetRequired(), formatStr)
+			name, h.GetType(), h.GetRequired() && h.GetDefaultValue() == "", formatStr)
 	}
 	p("          ];")
 	p("          const headerViolations = validateHeaders(req, headerConfigs);")
@@ -616,7 +767,10 @@ func (g *Generator) generateHeaderValidation(
 // generatePathParamExtraction generates code to extract path params from the URL.
 func (g *Generator) generatePathParamExtraction(p tscommon.Printer, cfg *rpcRouteConfig) {
 	if len(cfg.pathParams) == 0 {
-		p("          const pathParams: Record<string, string> = {};")
+		// Only the legacy ServerContext reads an empty pathParams
+		if g.handlerStyle == HandlerStyleLegacy {
+			p("          const pathParams: Record<string, string> = {};")
+		}
 		return
 	}
 
@@ -641,11 +795,14 @@ func (g *Generator) generatePathParamExtraction(p tscommon.Printer, cfg *rpcRout
 }
 
 // generateBodyParsing generates code to parse JSON request body.
-func (g *Generator) generateBodyParsing(p tscommon.Printer, method *protogen.Method, tsMethodName string) {
+func (g *Generator) generateBodyParsing(p tscommon.Printer, method *protogen.Method) {
 	inputType := g.ctx.RefMessage(method.Input)
 	p("          const body = await req.json() as %s;", inputType)
+}
 
-	// Optional validation hook
+// generateValidationHook generates the optional request validation call, run
+// once the request is fully bound.
+func (g *Generator) generateValidationHook(p tscommon.Printer, tsMethodName string) {
 	p("          if (options?.validateRequest) {")
 	p("            const bodyViolations = options.validateRequest(\"%s\", body);", tsMethodName)
 	p("            if (bodyViolations) {")
@@ -665,11 +822,14 @@ func (g *Generator) generateQueryParamParsing(
 	inputType := g.ctx.RefMessage(method.Input)
 
 	if len(cfg.queryParams) == 0 {
-		if len(cfg.pathParamFields) > 0 {
+		if len(cfg.pathParamFields) > 0 || len(cfg.headerParams) > 0 {
 			p("          const body: %s = {", inputType)
 			for _, ppf := range cfg.pathParamFields {
 				g.emitPathParamAssignment(p, ppf, "            ", ",")
 			}
+			for _, hp := range cfg.headerParams {
+				p("            %s: %s,", tscommon.PropertyKey(hp.FieldJSONName), headerParamValueExpr(hp))
+			}
 			p("          };")
 		} else {
 			p("          const body = {} as %s;", inputType)
@@ -691,79 +851,63 @@ func (g *Generator) generateQueryParamParsing(
 		g.emitPathParamAssignment(p, ppf, "            ", ",")
 	}
 	for _, qp := range cfg.queryParams {
-		g.generateQueryParamField(p, qp)
+		p("            %s: %s,", tscommon.PropertyKey(qp.FieldJSONName), g.queryParamFieldExpr(qp))
+	}
+	for _, hp := range cfg.headerParams {
+		p("            %s: %s,", tscommon.PropertyKey(hp.FieldJSONName), headerParamValueExpr(hp))
 	}
 	p("          };")
 
-	// Optional validation hook
-	p("          if (options?.validateRequest) {")
-	p("            const bodyViolations = options.validateRequest(\"%s\", body);", tsMethodName)
-	p("            if (bodyViolations) {")
-	p("              throw new ValidationError(bodyViolations);")
-	p("            }")
-	p("          }")
-	p("")
+	g.generateValidationHook(p, tsMethodName)
 }
 
-// generateQueryParamField generates a single query parameter field extraction.
-func (g *Generator) generateQueryParamField(p tscommon.Printer, qp annotations.QueryParam) {
-	jsonName := qp.FieldJSONName
-	paramName := qp.ParamName
+// queryParamFieldExpr returns the TS expression reading a query parameter into
+// its field, defaulting to the field's zero value when the parameter is absent.
+func (g *Generator) queryParamFieldExpr(qp annotations.QueryParam) string {
+	paramName := queryParamNameExpr(qp)
 
 	// Handle repeated fields: use getAll() for multi-value params, converting
 	// each element to the field's type (getAll always yields strings).
 	if qp.Field != nil && qp.Field.Desc.IsList() {
 		if qp.Field.Desc.Kind() == protoreflect.EnumKind && qp.Field.Enum != nil {
-			p(`            %s: params.getAll("%s") as %s[],`, jsonName, paramName, g.ctx.RefEnum(qp.Field.Enum))
-			return
+			return fmt.Sprintf(`params.getAll(%s) as %s[]`, paramName, g.ctx.RefEnum(qp.Field.Enum))
 		}
 		switch tscommon.TSScalarTypeForField(qp.Field) {
 		case tscommon.TSNumber:
-			p(`            %s: params.getAll("%s").map(Number),`, jsonName, paramName)
+			return fmt.Sprintf(`params.getAll(%s).map(Number)`, paramName)
 		case tscommon.TSBoolean:
-			p(`            %s: params.getAll("%s").map(v => v === "true"),`, jsonName, paramName)
+			return fmt.Sprintf(`params.getAll(%s).map(v => v === "true")`, paramName)
 		default:
-			p(`            %s: params.getAll("%s"),`, jsonName, paramName)
+			return fmt.Sprintf(`params.getAll(%s)`, paramName)
 		}
-		return
 	}
 
 	if qp.Field != nil {
 		// Check if it's an enum field — cast to enum type with UNSPECIFIED default
 		if qp.Field.Desc.Kind() == protoreflect.EnumKind && qp.Field.Enum != nil {
 			unspecified := tscommon.TSEnumUnspecifiedValue(qp.Field)
-			p(
-				`            %s: (params.get("%s") ?? %s) as %s,`,
-				jsonName,
-				paramName,
-				unspecified,
-				g.ctx.RefEnum(qp.Field.Enum),
-			)
-			return
+			return fmt.Sprintf(`(params.get(%s) ?? %s) as %s`, paramName, unspecified, g.ctx.RefEnum(qp.Field.Enum))
 		}
 
-		tsType := tscommon.TSScalarTypeForField(qp.Field)
-		switch tsType {
+		switch tscommon.TSScalarTypeForField(qp.Field) {
 		case tscommon.TSNumber:
-			p(`            %s: Number(params.get("%s") ?? "0"),`, jsonName, paramName)
+			return fmt.Sprintf(`Number(params.get(%s) ?? "0")`, paramName)
 		case tscommon.TSBoolean:
-			p(`            %s: params.get("%s") === "true",`, jsonName, paramName)
+			return fmt.Sprintf(`params.get(%s) === "true"`, paramName)
 		default:
-			p(`            %s: params.get("%s") ?? "",`, jsonName, paramName)
-		}
-	} else {
-		// Fallback based on field kind string
-		switch qp.FieldKind {
-		case "int32", "sint32", "sfixed32", "uint32", "fixed32", "float", "double":
-			p(`            %s: Number(params.get("%s") ?? "0"),`, jsonName, paramName)
-		case "int64", "sint64", "sfixed64", "uint64", "fixed64":
-			p(`            %s: params.get("%s") ?? "0",`, jsonName, paramName)
-		case "bool":
-			p(`            %s: params.get("%s") === "true",`, jsonName, paramName)
-		case "enum":
-			p(`            %s: params.get("%s") ?? "",`, jsonName, paramName)
-		default:
-			p(`            %s: params.get("%s") ?? "",`, jsonName, paramName)
+			return fmt.Sprintf(`params.get(%s) ?? ""`, paramName)
 		}
 	}
+
+	// Fallback based on field kind string
+	switch qp.FieldKind {
+	case "int32", "sint32", "sfixed32", "uint32", "fixed32", "float", "double":
+		return fmt.Sprintf(`Number(params.get(%s) ?? "0")`, paramName)
+	case "int64", "sint64", "sfixed64", "uint64", "fixed64":
+		return fmt.Sprintf(`params.get(%s) ?? "0"`, paramName)
+	case "bool":
+		return fmt.Sprintf(`params.get(%s) === "true"`, paramName)
+	default:
+		return fmt.Sprintf(`params.get(%s) ?? ""`, paramName)
+	}
 }
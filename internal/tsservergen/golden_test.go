@@ -29,6 +29,8 @@ func TestTSServerGenGoldenFiles(t *testing.T) {
 	testCases := []struct {
 		name       string
 		protoFiles []string
+		// opts, when set, is appended to the plugin options.
+		opts string
 		// assertImportFile/assertImport, when set, require the generated file at
 		// assertImportFile (relative to the output dir) to contain assertImport.
 		// Used to lock in cross-package relative imports in the modules layout.
@@ -43,6 +45,7 @@ func TestTSServerGenGoldenFiles(t *testing.T) {
 	}{
 		{name: "comprehensive HTTP verbs", protoFiles: []string{"http_verbs_comprehensive.proto"}},
 		{name: "query parameters", protoFiles: []string{"query_params.proto"}},
+		{name: "field sources", protoFiles: []string{"field_sources.proto"}},
 		{name: "backward compatibility", protoFiles: []string{"backward_compat.proto"}},
 		{name: "complex features", protoFiles: []string{"complex_features.proto"}},
 		{name: "unwrap variants", protoFiles: []string{"unwrap.proto"}},
@@ -52,13 +55,31 @@ func TestTSServerGenGoldenFiles(t *testing.T) {
 		{name: "empty behavior", protoFiles: []string{"empty_behavior.proto"}},
 		{name: "timestamp format", protoFiles: []string{"timestamp_format.proto"}},
 		{name: "bytes encoding", protoFiles: []string{"bytes_encoding.proto"}},
+		{name: "scoped encoding defaults", protoFiles: []string{"scoped_encoding.proto"}},
 		{name: "flatten", protoFiles: []string{"flatten.proto"}},
 		{name: "oneof discriminator", protoFiles: []string{"oneof_discriminator.proto"}},
+		{name: "custom json names", protoFiles: []string{"json_names.proto"}},
+		{name: "json naming policies", protoFiles: []string{"json_naming.proto"}},
+		{name: "free-form well-known types", protoFiles: []string{"dynamic_json.proto"}},
 		{name: "multi-word oneof name", protoFiles: []string{"multi_word_oneof.proto"}},
 		{name: "two un-annotated oneofs in one message", protoFiles: []string{"two_oneofs.proto"}},
 		{name: "SSE streaming", protoFiles: []string{"sse.proto"}},
+		{
+			name:       "streamed list responses",
+			protoFiles: []string{"stream_response.proto"},
+			opts:       "handler_style=context,runtime=node",
+		},
 		{name: "empty request body", protoFiles: []string{"empty_request_body.proto"}},
 		{name: "record map collision", protoFiles: []string{"record_map_collision.proto"}},
+		{name: "query parameter aliases", protoFiles: []string{"query_aliases.proto"}},
+		{name: "edition 2023", protoFiles: []string{"editions.proto"}},
+		{name: "proto2 syntax", protoFiles: []string{"proto2_syntax.proto"}},
+		{name: "typed handler contexts", protoFiles: []string{"handler_context.proto"}, opts: "handler_style=context"},
+		{
+			name:       "base path parameters",
+			protoFiles: []string{"base_path_params.proto"},
+			opts:       "handler_style=context",
+		},
 		{
 			name:             "reserved error-helper names",
 			protoFiles:       []string{"reserved_name.proto"},
@@ -119,10 +140,14 @@ func TestTSServerGenGoldenFiles(t *testing.T) {
 			}
 
 			outDir := t.TempDir()
+			pluginOpts := "paths=source_relative"
+			if tc.opts != "" {
+				pluginOpts += "," + tc.opts
+			}
 			args := []string{
 				"--plugin=protoc-gen-ts-server=" + pluginPath,
 				"--ts-server_out=" + outDir,
-				"--ts-server_opt=paths=source_relative",
+				"--ts-server_opt=" + pluginOpts,
 				"--proto_path=" + protoDir,
 				"--proto_path=" + filepath.Join(projectRoot, "proto"),
 			}
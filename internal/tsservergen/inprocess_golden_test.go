@@ -128,6 +128,83 @@ func TestTSServerGenInProcessReservedName(t *testing.T) {
 	}
 }
 
+// TestTSServerGenInProcessNodeRuntime drives the query-params fixture with
+// runtime=node and asserts the Node adapter and its node:http type import are
+// emitted on top of the default fetch adapter.
+func TestTSServerGenInProcessNodeRuntime(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping in-process runtime test")
+	}
+
+	baseDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	projectRoot := filepath.Join(baseDir, "..", "..")
+	protoDir := filepath.Join(baseDir, "testdata", "proto")
+
+	plugin := buildInProcessPlugin(t, protoDir, projectRoot, []string{"query_params.proto"})
+	if genErr := NewWithOptions(plugin, Options{Runtime: RuntimeNode}).Generate(); genErr != nil {
+		t.Fatalf("Generate() failed: %v", genErr)
+	}
+
+	content := generatedFileContent(t, plugin, "query_params_server.ts")
+	for _, want := range []string{
+		`import type { IncomingMessage, ServerResponse } from "node:http";`,
+		`export function createFetchHandler(routes: RouteDescriptor[])`,
+		`export function createNodeHandler(`,
+		`path: "/api/regions/:region",`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("query_params_server.ts missing %q\n---\n%s", want, content)
+		}
+	}
+
+	unsupported := buildInProcessPlugin(t, protoDir, projectRoot, []string{"query_params.proto"})
+	genErr := NewWithOptions(unsupported, Options{Runtime: "deno"}).Generate()
+	if genErr == nil || !strings.Contains(genErr.Error(), `unsupported runtime "deno"`) {
+		t.Errorf("expected unsupported runtime error, got: %v", genErr)
+	}
+}
+
+// TestTSServerGenInProcessHandlerStyle asserts handler_style defaults to the
+// legacy (ctx, req) signature and rejects unknown styles.
+func TestTSServerGenInProcessHandlerStyle(t *testing.T) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		t.Skip("protoc not found, skipping in-process handler style test")
+	}
+
+	baseDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	projectRoot := filepath.Join(baseDir, "..", "..")
+	protoDir := filepath.Join(baseDir, "testdata", "proto")
+
+	plugin := buildInProcessPlugin(t, protoDir, projectRoot, []string{"handler_context.proto"})
+	if genErr := New(plugin).Generate(); genErr != nil {
+		t.Fatalf("Generate() failed: %v", genErr)
+	}
+	content := generatedFileContent(t, plugin, "handler_context_server.ts")
+	for _, want := range []string{
+		`export interface ServerContext {`,
+		`createTeam(ctx: ServerContext, req: CreateTeamRequest): Promise<Team>;`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("handler_context_server.ts missing %q\n---\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "HandlerContext") {
+		t.Errorf("legacy handler style must not emit typed contexts\n---\n%s", content)
+	}
+
+	unsupported := buildInProcessPlugin(t, protoDir, projectRoot, []string{"handler_context.proto"})
+	genErr := NewWithOptions(unsupported, Options{HandlerStyle: "callback"}).Generate()
+	if genErr == nil || !strings.Contains(genErr.Error(), `unsupported handler_style "callback"`) {
+		t.Errorf("expected unsupported handler_style error, got: %v", genErr)
+	}
+}
+
 // generatedFileContent returns the content of the named file from the plugin
 // response, failing the test if it was not emitted.
 func generatedFileContent(t *testing.T, plugin *protogen.Plugin, name string) string {
@@ -165,6 +242,7 @@ func inProcessFixtures() []inProcessFixture {
 		{name: "bytes encoding", protoFiles: []string{"bytes_encoding.proto"}},
 		{name: "flatten", protoFiles: []string{"flatten.proto"}},
 		{name: "oneof discriminator", protoFiles: []string{"oneof_discriminator.proto"}},
+		{name: "custom json names", protoFiles: []string{"json_names.proto"}},
 		{name: "SSE streaming", protoFiles: []string{"sse.proto"}},
 		{name: "empty request body", protoFiles: []string{"empty_request_body.proto"}},
 		{name: "reserved error-helper names", protoFiles: []string{"reserved_name.proto"}},
@@ -227,6 +305,7 @@ func buildInProcessPluginParams(
 	args := []string{
 		"--descriptor_set_out=" + descPath,
 		"--include_imports",
+		"--include_source_info",
 		"--proto_path=" + protoDir,
 		"--proto_path=" + filepath.Join(projectRoot, "proto"),
 	}
@@ -11,7 +11,7 @@ import (
 // its request/response types and the error helpers, then a per-package barrel
 // (index.ts) re-exporting each package directory's modules.
 func (g *Generator) generateModules() error {
-	moduleFiles, err := tscommon.EmitSharedModules(g.plugin)
+	modules, err := tscommon.EmitSharedModules(g.plugin, tscommon.ModuleFormatESM)
 	if err != nil {
 		return err
 	}
@@ -19,20 +19,20 @@ func (g *Generator) generateModules() error {
 		if !file.Generate || len(file.Services) == 0 {
 			continue
 		}
-		name, emitErr := g.emitServerModule(file)
+		module, emitErr := g.emitServerModule(file)
 		if emitErr != nil {
 			return emitErr
 		}
-		moduleFiles = append(moduleFiles, name)
+		modules = append(modules, tscommon.EmittedModule{Path: module})
 	}
-	tscommon.EmitPackageBarrels(g.plugin, moduleFiles)
+	tscommon.EmitPackageBarrels(g.plugin, tscommon.ModuleFormatESM, modules)
 	return nil
 }
 
 // emitServerModule writes a service file's server framework types + handlers,
 // importing the request/response types from their canonical modules and the
-// shared error helpers. It returns the output-relative filename it emitted
-// (ending in ".ts"), so the caller can fold it into the per-package barrel.
+// shared error helpers. It returns the extensionless output-relative module
+// path it emitted, so the caller can fold it into the per-package barrel.
 func (g *Generator) emitServerModule(file *protogen.File) (string, error) {
 	module := file.GeneratedFilenamePrefix + "_server"
 	gf := g.plugin.NewGeneratedFile(module+".ts", "")
@@ -47,11 +47,18 @@ func (g *Generator) emitServerModule(file *protogen.File) (string, error) {
 	if g.fileUsesHeaders(file) {
 		g.writeHeaderValidationHelpers(bp)
 	}
+	if g.fileUsesQueryAliases(file) {
+		g.writeQueryParamNameFn(bp)
+	}
+	if sse, arrays := g.fileStreaming(file); sse || arrays {
+		g.writeStreamingHelpers(bp, sse, arrays)
+	}
 	for _, service := range file.Services {
 		if err := g.generateService(bp, service); err != nil {
 			return "", err
 		}
 	}
+	g.writeAdapters(bp)
 	// Import only the error helpers actually referenced in the body.
 	g.ctx.NeedErrors(tscommon.UsedErrorSymbols(body)...)
 
@@ -59,9 +66,15 @@ func (g *Generator) emitServerModule(file *protogen.File) (string, error) {
 	dp("// Code generated by protoc-gen-ts-server. DO NOT EDIT.")
 	dp("// source: %s", file.Desc.Path())
 	dp("")
+	if g.runtime == RuntimeNode {
+		dp(`import type { IncomingMessage, ServerResponse } from "node:http";`)
+		if tracker.Empty() {
+			dp("")
+		}
+	}
 	tracker.Render(dp)
 	for _, line := range body {
 		gf.P(line)
 	}
-	return module + ".ts", nil
+	return module, nil
 }
@@ -0,0 +1,51 @@
+package tsservergen
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/tscommon"
+)
+
+// fileUsesQueryAliases returns true if a query parameter of any method in the
+// file accepts aliases.
+func (g *Generator) fileUsesQueryAliases(file *protogen.File) bool {
+	for _, service := range file.Services {
+		for _, method := range service.Methods {
+			for _, qp := range annotations.GetQueryParams(method.Input) {
+				if len(qp.Aliases) > 0 {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// writeQueryParamNameFn writes the function resolving which of a query
+// parameter's name and aliases a request sends it under.
+func (g *Generator) writeQueryParamNameFn(p tscommon.Printer) {
+	p("// queryParamName returns the first of name and its aliases sent with a")
+	p("// non-empty value, so the parameter's name wins over its aliases.")
+	p("function queryParamName(params: URLSearchParams, name: string, aliases: string[]): string {")
+	p(`  return [name, ...aliases].find((n) => params.getAll(n).some((v) => v !== "")) ?? name;`)
+	p("}")
+	p("")
+}
+
+// queryParamNameExpr returns the TS expression naming the query parameter
+// qp is read from: its quoted name, or a queryParamName call when it has
+// aliases.
+func queryParamNameExpr(qp annotations.QueryParam) string {
+	if len(qp.Aliases) == 0 {
+		return strconv.Quote(qp.ParamName)
+	}
+	quoted := make([]string, len(qp.Aliases))
+	for i, alias := range qp.Aliases {
+		quoted[i] = strconv.Quote(alias)
+	}
+	return "queryParamName(params, " + strconv.Quote(qp.ParamName) + ", [" + strings.Join(quoted, ", ") + "])"
+}
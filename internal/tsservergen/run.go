@@ -0,0 +1,29 @@
+package tsservergen
+
+import (
+	"flag"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/SebastienMelki/sebuf/internal/pluginrun"
+)
+
+// Run generates the TypeScript server of req in memory, without reading stdin
+// or writing stdout. opts supplies the defaults for plugin parameters; the
+// runtime, handler_style and strict_headers parameters in req override them.
+// Invalid input is reported in the response's Error field; the error is only
+// set if generation panicked.
+func Run(req *pluginpb.CodeGeneratorRequest, opts Options) (*pluginpb.CodeGeneratorResponse, error) {
+	var flags flag.FlagSet
+	runtime := flags.String("runtime", string(opts.Runtime), "server adapter to generate: fetch or node")
+	handlerStyle := flags.String("handler_style", string(opts.HandlerStyle), "handler signature: legacy or context")
+	flags.BoolVar(&opts.StrictHeaders, "strict_headers", opts.StrictHeaders,
+		"fail on method headers changing the type or format of a service header instead of warning")
+
+	return pluginrun.Run(req, flags.Set, func(plugin *protogen.Plugin) error {
+		opts.Runtime = Runtime(*runtime)
+		opts.HandlerStyle = HandlerStyle(*handlerStyle)
+		return NewWithOptions(plugin, opts).Generate()
+	})
+}
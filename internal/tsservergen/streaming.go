@@ -0,0 +1,288 @@
+package tsservergen
+
+import (
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"github.com/SebastienMelki/sebuf/internal/annotations"
+	"github.com/SebastienMelki/sebuf/internal/tscommon"
+)
+
+// isStreamingMethod reports whether a method's handler sends its response
+// through a send function: an SSE method or a stream_response method.
+func (g *Generator) isStreamingMethod(method *protogen.Method) bool {
+	return g.isSSEMethod(method) || annotations.IsStreamResponse(method)
+}
+
+// fileStreaming reports whether any method of file is an SSE method, and
+// whether any is a stream_response method, so the module emits the helpers
+// they need and no other.
+func (g *Generator) fileStreaming(file *protogen.File) (bool, bool) {
+	var sse, arrays bool
+	for _, service := range file.Services {
+		for _, method := range service.Methods {
+			sse = sse || g.isSSEMethod(method)
+			arrays = arrays || annotations.IsStreamResponse(method)
+		}
+	}
+	return sse, arrays
+}
+
+// streamItemType returns the TypeScript type of the messages the handler of a
+// streaming method sends: the output of an SSE method, or the item of the
+// repeated field of a stream_response method.
+func (g *Generator) streamItemType(method *protogen.Method) (string, error) {
+	if g.isSSEMethod(method) {
+		return g.resolveOutputType(method), nil
+	}
+	field, err := annotations.StreamResponseField(method)
+	if err != nil {
+		return "", err
+	}
+	return g.ctx.RefMessage(field.Message), nil
+}
+
+// streamFraming returns the expression of the StreamFraming of a streaming
+// method. stream_response methods write the document the Go server writes:
+// {"key":[...]} keyed by the JSON name of the field, or a bare array when the
+// response is a root unwrap.
+func (g *Generator) streamFraming(method *protogen.Method) string {
+	if g.isSSEMethod(method) {
+		return "sseFraming"
+	}
+	if annotations.IsRootUnwrap(method.Output) {
+		return "jsonArrayFraming(undefined)"
+	}
+	return "jsonArrayFraming(" + strconv.Quote(annotations.JSONFieldName(method.Output.Fields[0])) + ")"
+}
+
+// writeStreamingHelpers writes the runtime of the streaming routes, with the
+// framing of SSE methods and of stream_response methods when the module has
+// any. It mirrors the Go server: an error thrown before the first message is
+// answered like the error of any other method; after it, SSE streams end with
+// an error event and JSON arrays are cut short.
+//
+//nolint:funlen // The helpers are emitted together as one block of the server module
+func (g *Generator) writeStreamingHelpers(p tscommon.Printer, sse, arrays bool) {
+	p("/** How a streaming route writes the messages its handler sends. */")
+	p("interface StreamFraming {")
+	p("  /** Written before the first message. */")
+	p("  open: string;")
+	p("  /** Writes the message at index. */")
+	p("  item(msg: unknown, index: number): string;")
+	p("  /** Written once the handler returns. */")
+	p("  close: string;")
+	p("  /** Reports a failure after the first message, or undefined to cut the response short. */")
+	p("  error(err: unknown): string | undefined;")
+	p("}")
+	p("")
+	if sse {
+		p("/** Server-Sent Events: one data frame per message and an error event on failure. */")
+		p("const sseFraming: StreamFraming = {")
+		p(`  open: "",`)
+		p("  item: (msg) => `data: ${JSON.stringify(msg)}\\n\\n`,")
+		p(`  close: "",`)
+		p("  error: (err) =>")
+		p("    `event: error\\ndata: ${JSON.stringify(err instanceof Error ? err.message : String(err))}\\n\\n`,")
+		p("};")
+		p("")
+	}
+	if arrays {
+		p("/** A JSON array written one item at a time, bare or as the only key of an object. */")
+		p("function jsonArrayFraming(key: string | undefined): StreamFraming {")
+		p("  return {")
+		p("    open: key === undefined ? \"[\" : `{${JSON.stringify(key)}:[`,")
+		p(`    item: (msg, index) => (index > 0 ? "," : "") + JSON.stringify(msg),`)
+		p(`    close: key === undefined ? "]" : "]}",`)
+		p("    error: () => undefined,")
+		p("  };")
+		p("}")
+		p("")
+	}
+	p("/**")
+	p(" * Runs the handler of a streaming route with a send function writing each")
+	p(" * message to the body of the returned Response, which init describes. The")
+	p(" * Response is returned once the first message is sent or the handler returns,")
+	p(" * so an error thrown before reaches the caller. send waits for the client to")
+	p(" * take each message, and rejects once the client is gone or req is aborted.")
+	p(" */")
+	p("async function streamResponse<T>(")
+	p("  req: Request,")
+	p("  framing: StreamFraming,")
+	p("  run: (send: (msg: T) => Promise<void>) => Promise<void>,")
+	p("  init: () => ResponseInit,")
+	p("): Promise<Response> {")
+	p("  const encoder = new TextEncoder();")
+	p("  let controller!: ReadableStreamDefaultController<Uint8Array>;")
+	p("  let pulled: (() => void) | undefined;")
+	p("  let cancelled = false;")
+	p("  const body = new ReadableStream<Uint8Array>(")
+	p("    {")
+	p("      start(c) {")
+	p("        controller = c;")
+	p("      },")
+	p("      pull() {")
+	p("        pulled?.();")
+	p("      },")
+	p("      cancel() {")
+	p("        cancelled = true;")
+	p("        pulled?.();")
+	p("      },")
+	p("    },")
+	p("    { highWaterMark: 0 },")
+	p("  );")
+	p("  const gone = (): unknown => req.signal.reason ?? new Error(\"client disconnected\");")
+	p("")
+	p("  let sent = 0;")
+	p("  let started!: () => void;")
+	p("  const start = new Promise<void>((resolve) => {")
+	p("    started = resolve;")
+	p("  });")
+	p("  const send = async (msg: T): Promise<void> => {")
+	p("    if (cancelled || req.signal.aborted) throw gone();")
+	p(`    const text = (sent === 0 ? framing.open : "") + framing.item(msg, sent);`)
+	p("    sent++;")
+	p("    started();")
+	p("    const taken = new Promise<void>((resolve) => {")
+	p("      pulled = resolve;")
+	p("    });")
+	p("    controller.enqueue(encoder.encode(text));")
+	p("    await taken;")
+	p("    pulled = undefined;")
+	p("    if (cancelled) throw gone();")
+	p("  };")
+	p("")
+	p("  const done = run(send);")
+	p("  await Promise.race([start, done]);")
+	p("  done")
+	p("    .then(")
+	p("      () => {")
+	p(`        controller.enqueue(encoder.encode((sent === 0 ? framing.open : "") + framing.close));`)
+	p("        controller.close();")
+	p("      },")
+	p("      (err: unknown) => {")
+	p("        const text = framing.error(err);")
+	p("        if (text === undefined) {")
+	p("          controller.error(err);")
+	p("          return;")
+	p("        }")
+	p("        controller.enqueue(encoder.encode(text));")
+	p("        controller.close();")
+	p("      },")
+	p("    )")
+	p("    .catch(() => {")
+	p("      // The client is gone, so nothing more can be written")
+	p("    });")
+	p("  return new Response(body, init());")
+	p("}")
+	p("")
+}
+
+// generateStreamRouteEntry generates a route descriptor for an SSE or
+// stream_response method, whose handler receives a send function instead of
+// returning its response.
+//
+//nolint:funlen // Stream route entry generation requires many sequential code generation blocks
+func (g *Generator) generateStreamRouteEntry(
+	p tscommon.Printer,
+	service *protogen.Service,
+	method *protogen.Method,
+	cfg *rpcRouteConfig,
+) error {
+	tsMethodName := annotations.LowerFirst(cfg.methodName)
+	itemType, err := g.streamItemType(method)
+	if err != nil {
+		return fmt.Errorf("service %s, method %s: %w", service.GoName, method.GoName, err)
+	}
+	sse := g.isSSEMethod(method)
+
+	p("    {")
+	p(`      method: "%s",`, cfg.httpMethod)
+	p(`      path: "%s",`, routePath(cfg.fullPath))
+	p("      handler: async (req: Request): Promise<Response> => {")
+
+	// Try-catch wraps the handler body
+	p("        try {")
+
+	// Header validation
+	serviceHeaders := annotations.GetServiceHeaders(service)
+	methodHeaders := annotations.GetMethodHeaders(method)
+	g.generateHeaderValidation(p, serviceHeaders, methodHeaders)
+
+	// Extract path params
+	g.generatePathParamExtraction(p, cfg)
+
+	// Parse request body or query params
+	if cfg.hasBody {
+		g.generateBodyParsing(p, method)
+		g.generateQueryParamMerge(p, cfg)
+		g.generatePathParamMerge(p, cfg, method)
+		g.generateHeaderParamMerge(p, cfg)
+		g.generateValidationHook(p, tsMethodName)
+	} else {
+		g.generateQueryParamParsing(p, cfg, method, tsMethodName)
+	}
+
+	writeHeaders := func(indent string) {
+		if sse {
+			g.writeSSEResponseHeaders(p, indent)
+		} else {
+			p(`%s"Content-Type": "application/json",`, indent)
+		}
+	}
+
+	var call, init string
+	if g.handlerStyle == HandlerStyleContext {
+		// Build the typed context, collecting the status and headers it sets
+		// until the response starts
+		p("          let status = 200;")
+		p("          const responseHeaders = new Headers({")
+		writeHeaders("            ")
+		p("          });")
+		g.generateHandlerContext(p, service, method, cfg)
+		call = fmt.Sprintf("handler.%s(body, ctx, send)", tsMethodName)
+		init = "() => ({ status, headers: responseHeaders })"
+	} else {
+		g.generateServerContext(p)
+		call = fmt.Sprintf("handler.%s(ctx, body, send)", tsMethodName)
+	}
+
+	p("          return await streamResponse<%s>(", itemType)
+	p("            req,")
+	p("            %s,", g.streamFraming(method))
+	p("            (send) => %s,", call)
+	if init != "" {
+		p("            %s,", init)
+	} else {
+		p("            () => ({")
+		p("              headers: {")
+		writeHeaders("                ")
+		p("              },")
+		p("            }),")
+	}
+	p("          );")
+
+	// Catch block
+	p("        } catch (err: unknown) {")
+	p("          if (err instanceof ValidationError) {")
+	p("            return new Response(JSON.stringify({ violations: err.violations }), {")
+	p("              status: 400,")
+	p(`              headers: { "Content-Type": "application/json" },`)
+	p("            });")
+	p("          }")
+	p("          if (options?.onError) {")
+	p("            return options.onError(err, req);")
+	p("          }")
+	p("          const message = err instanceof Error ? err.message : String(err);")
+	p("          return new Response(JSON.stringify({ message }), {")
+	p("            status: 500,")
+	p(`            headers: { "Content-Type": "application/json" },`)
+	p("          });")
+	p("        }")
+
+	p("      },")
+	p("    },")
+	return nil
+}
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-ts-server. DO NOT EDIT.
 // source: backward_compat.proto
 
-import { FieldViolation, ValidationError } from "./errors.js";
+import { type FieldViolation, ValidationError } from "./errors.js";
 import type { ActionRequest, ActionResponse, AnotherRequest, AnotherResponse, SimpleRequest, SimpleResponse } from "./backward_compat.js";
 
 export interface ServerContext {
@@ -219,3 +219,27 @@ export function createBasePathOnlyServiceRoutes(
   ];
 }
 
+function matchRoute(routes: RouteDescriptor[], method: string, pathname: string): RouteDescriptor | undefined {
+  const pathSegments = pathname.split("/");
+  return routes.find((route) => {
+    if (route.method !== method) return false;
+    const routeSegments = route.path.split("/");
+    return routeSegments.length === pathSegments.length &&
+      routeSegments.every((seg, i) => seg.startsWith(":") ? pathSegments[i] !== "" : seg === pathSegments[i]);
+  });
+}
+
+export function createFetchHandler(routes: RouteDescriptor[]): (req: Request) => Promise<Response> {
+  return async (req: Request): Promise<Response> => {
+    const url = new URL(req.url, "http://localhost");
+    const route = matchRoute(routes, req.method, url.pathname);
+    if (!route) {
+      return new Response(JSON.stringify({ message: "not found" }), {
+        status: 404,
+        headers: { "Content-Type": "application/json" },
+      });
+    }
+    return route.handler(req);
+  };
+}
+
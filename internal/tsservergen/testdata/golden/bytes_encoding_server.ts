@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-ts-server. DO NOT EDIT.
 // source: bytes_encoding.proto
 
-import { FieldViolation, ValidationError } from "./errors.js";
+import { type FieldViolation, ValidationError } from "./errors.js";
 import type { BytesEncodingRequest, BytesEncodingTest } from "./bytes_encoding.js";
 
 export interface ServerContext {
@@ -76,7 +76,7 @@ export function createBytesEncodingServiceRoutes(
     },
     {
       method: "GET",
-      path: "/api/v1/bytes-encoding/{id}",
+      path: "/api/v1/bytes-encoding/:id",
       handler: async (req: Request): Promise<Response> => {
         try {
           const pathParams: Record<string, string> = {};
@@ -120,3 +120,27 @@ export function createBytesEncodingServiceRoutes(
   ];
 }
 
+function matchRoute(routes: RouteDescriptor[], method: string, pathname: string): RouteDescriptor | undefined {
+  const pathSegments = pathname.split("/");
+  return routes.find((route) => {
+    if (route.method !== method) return false;
+    const routeSegments = route.path.split("/");
+    return routeSegments.length === pathSegments.length &&
+      routeSegments.every((seg, i) => seg.startsWith(":") ? pathSegments[i] !== "" : seg === pathSegments[i]);
+  });
+}
+
+export function createFetchHandler(routes: RouteDescriptor[]): (req: Request) => Promise<Response> {
+  return async (req: Request): Promise<Response> => {
+    const url = new URL(req.url, "http://localhost");
+    const route = matchRoute(routes, req.method, url.pathname);
+    if (!route) {
+      return new Response(JSON.stringify({ message: "not found" }), {
+        status: 404,
+        headers: { "Content-Type": "application/json" },
+      });
+    }
+    return route.handler(req);
+  };
+}
+
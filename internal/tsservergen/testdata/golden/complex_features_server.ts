@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-ts-server. DO NOT EDIT.
 // source: complex_features.proto
 
-import { FieldViolation, ValidationError } from "./errors.js";
+import { type FieldViolation, ValidationError } from "./errors.js";
 import type { Bar, BarsBySymbol, CreateNoteRequest, GetBarsBySymbolRequest, GetCombinedUnwrapRequest, GetNoteListRequest, GetNoteMapRequest, GetNoteRequest, ListNotesRequest, ListNotesResponse, Note, UpdateNoteRequest } from "./complex_features.js";
 
 export interface ServerContext {
@@ -36,6 +36,11 @@ interface HeaderConfig {
   type: string;
   required: boolean;
   format?: string;
+  multiple?: boolean;
+}
+
+function splitHeaderValues(value: string): string[] {
+  return value.split(",").map((v) => v.trim()).filter((v) => v !== "");
 }
 
 function validateHeaderValue(value: string, config: HeaderConfig): string | undefined {
@@ -80,7 +85,8 @@ function validateHeaders(
   const violations: FieldViolation[] = [];
   for (const config of configs) {
     const value = req.headers.get(config.name);
-    if (value == null) {
+    const values = value == null ? [] : config.multiple ? splitHeaderValues(value) : [value];
+    if (values.length === 0) {
       if (config.required) {
         violations.push({
           field: config.name,
@@ -89,7 +95,8 @@ function validateHeaders(
       }
       continue;
     }
-    const err = validateHeaderValue(value, config);
+    // A multiple header is valid when each of its values is
+    const err = values.map((v) => validateHeaderValue(v, config)).find((e) => e !== undefined);
     if (err) {
       violations.push({
         field: config.name,
@@ -122,8 +129,9 @@ export function createFeatureServiceRoutes(
       handler: async (req: Request): Promise<Response> => {
         try {
           const headerConfigs: HeaderConfig[] = [
-            { name: "X-API-Key", type: "string", required: true, format: "uuid" },
-            { name: "X-Tenant-ID", type: "integer", required: true },
+            { name: "X-Api-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Tenant-Id", type: "integer", required: true },
+            { name: "X-Note-Tag", type: "string", required: false, multiple: true },
           ];
           const headerViolations = validateHeaders(req, headerConfigs);
           if (headerViolations) {
@@ -176,12 +184,12 @@ export function createFeatureServiceRoutes(
     },
     {
       method: "GET",
-      path: "/api/v1/notes/{note_id}",
+      path: "/api/v1/notes/:note_id",
       handler: async (req: Request): Promise<Response> => {
         try {
           const headerConfigs: HeaderConfig[] = [
-            { name: "X-API-Key", type: "string", required: true, format: "uuid" },
-            { name: "X-Tenant-ID", type: "integer", required: true },
+            { name: "X-Api-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Tenant-Id", type: "integer", required: true },
           ];
           const headerViolations = validateHeaders(req, headerConfigs);
           if (headerViolations) {
@@ -232,9 +240,9 @@ export function createFeatureServiceRoutes(
       handler: async (req: Request): Promise<Response> => {
         try {
           const headerConfigs: HeaderConfig[] = [
-            { name: "X-API-Key", type: "string", required: true, format: "uuid" },
-            { name: "X-Tenant-ID", type: "integer", required: true },
-            { name: "X-Request-ID", type: "string", required: true, format: "uuid" },
+            { name: "X-Api-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Tenant-Id", type: "integer", required: true },
+            { name: "X-Request-Id", type: "string", required: true, format: "uuid" },
           ];
           const headerViolations = validateHeaders(req, headerConfigs);
           if (headerViolations) {
@@ -281,12 +289,12 @@ export function createFeatureServiceRoutes(
     },
     {
       method: "PUT",
-      path: "/api/v1/notes/{note_id}",
+      path: "/api/v1/notes/:note_id",
       handler: async (req: Request): Promise<Response> => {
         try {
           const headerConfigs: HeaderConfig[] = [
-            { name: "X-API-Key", type: "string", required: true, format: "uuid" },
-            { name: "X-Tenant-ID", type: "integer", required: true },
+            { name: "X-Api-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Tenant-Id", type: "integer", required: true },
             { name: "X-Idempotency-Key", type: "string", required: true, format: "uuid" },
           ];
           const headerViolations = validateHeaders(req, headerConfigs);
@@ -300,6 +308,7 @@ export function createFeatureServiceRoutes(
           pathParams["note_id"] = decodeURIComponent(pathSegments[4] ?? "");
 
           const body = await req.json() as UpdateNoteRequest;
+          body.noteId = pathParams["note_id"];
           if (options?.validateRequest) {
             const bodyViolations = options.validateRequest("updateNote", body);
             if (bodyViolations) {
@@ -307,8 +316,6 @@ export function createFeatureServiceRoutes(
             }
           }
 
-          body.noteId = pathParams["note_id"];
-
           const ctx: ServerContext = {
             request: req,
             pathParams,
@@ -344,8 +351,8 @@ export function createFeatureServiceRoutes(
       handler: async (req: Request): Promise<Response> => {
         try {
           const headerConfigs: HeaderConfig[] = [
-            { name: "X-API-Key", type: "string", required: true, format: "uuid" },
-            { name: "X-Tenant-ID", type: "integer", required: true },
+            { name: "X-Api-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Tenant-Id", type: "integer", required: true },
           ];
           const headerViolations = validateHeaders(req, headerConfigs);
           if (headerViolations) {
@@ -396,8 +403,8 @@ export function createFeatureServiceRoutes(
       handler: async (req: Request): Promise<Response> => {
         try {
           const headerConfigs: HeaderConfig[] = [
-            { name: "X-API-Key", type: "string", required: true, format: "uuid" },
-            { name: "X-Tenant-ID", type: "integer", required: true },
+            { name: "X-Api-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Tenant-Id", type: "integer", required: true },
           ];
           const headerViolations = validateHeaders(req, headerConfigs);
           if (headerViolations) {
@@ -448,8 +455,8 @@ export function createFeatureServiceRoutes(
       handler: async (req: Request): Promise<Response> => {
         try {
           const headerConfigs: HeaderConfig[] = [
-            { name: "X-API-Key", type: "string", required: true, format: "uuid" },
-            { name: "X-Tenant-ID", type: "integer", required: true },
+            { name: "X-Api-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Tenant-Id", type: "integer", required: true },
           ];
           const headerViolations = validateHeaders(req, headerConfigs);
           if (headerViolations) {
@@ -500,8 +507,8 @@ export function createFeatureServiceRoutes(
       handler: async (req: Request): Promise<Response> => {
         try {
           const headerConfigs: HeaderConfig[] = [
-            { name: "X-API-Key", type: "string", required: true, format: "uuid" },
-            { name: "X-Tenant-ID", type: "integer", required: true },
+            { name: "X-Api-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Tenant-Id", type: "integer", required: true },
           ];
           const headerViolations = validateHeaders(req, headerConfigs);
           if (headerViolations) {
@@ -549,3 +556,27 @@ export function createFeatureServiceRoutes(
   ];
 }
 
+function matchRoute(routes: RouteDescriptor[], method: string, pathname: string): RouteDescriptor | undefined {
+  const pathSegments = pathname.split("/");
+  return routes.find((route) => {
+    if (route.method !== method) return false;
+    const routeSegments = route.path.split("/");
+    return routeSegments.length === pathSegments.length &&
+      routeSegments.every((seg, i) => seg.startsWith(":") ? pathSegments[i] !== "" : seg === pathSegments[i]);
+  });
+}
+
+export function createFetchHandler(routes: RouteDescriptor[]): (req: Request) => Promise<Response> {
+  return async (req: Request): Promise<Response> => {
+    const url = new URL(req.url, "http://localhost");
+    const route = matchRoute(routes, req.method, url.pathname);
+    if (!route) {
+      return new Response(JSON.stringify({ message: "not found" }), {
+        status: 404,
+        headers: { "Content-Type": "application/json" },
+      });
+    }
+    return route.handler(req);
+  };
+}
+
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-ts-server. DO NOT EDIT.
 // source: empty_behavior.proto
 
-import { FieldViolation, ValidationError } from "./errors.js";
+import { type FieldViolation, ValidationError } from "./errors.js";
 import type { GetResponseRequest, Response as Response_1 } from "./empty_behavior.js";
 
 export interface ServerContext {
@@ -32,7 +32,7 @@ export function createEmptyBehaviorServiceRoutes(
   return [
     {
       method: "GET",
-      path: "/api/v1/responses/{id}",
+      path: "/api/v1/responses/:id",
       handler: async (req: Request): Promise<Response> => {
         try {
           const pathParams: Record<string, string> = {};
@@ -76,3 +76,27 @@ export function createEmptyBehaviorServiceRoutes(
   ];
 }
 
+function matchRoute(routes: RouteDescriptor[], method: string, pathname: string): RouteDescriptor | undefined {
+  const pathSegments = pathname.split("/");
+  return routes.find((route) => {
+    if (route.method !== method) return false;
+    const routeSegments = route.path.split("/");
+    return routeSegments.length === pathSegments.length &&
+      routeSegments.every((seg, i) => seg.startsWith(":") ? pathSegments[i] !== "" : seg === pathSegments[i]);
+  });
+}
+
+export function createFetchHandler(routes: RouteDescriptor[]): (req: Request) => Promise<Response> {
+  return async (req: Request): Promise<Response> => {
+    const url = new URL(req.url, "http://localhost");
+    const route = matchRoute(routes, req.method, url.pathname);
+    if (!route) {
+      return new Response(JSON.stringify({ message: "not found" }), {
+        status: 404,
+        headers: { "Content-Type": "application/json" },
+      });
+    }
+    return route.handler(req);
+  };
+}
+
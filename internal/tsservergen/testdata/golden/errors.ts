@@ -18,12 +18,22 @@ export class ValidationError extends Error {
 export class ApiError extends Error {
   statusCode: number;
   body: string;
+  code: string;
+  details: Record<string, string>;
 
-  constructor(statusCode: number, message: string, body: string) {
+  constructor(
+    statusCode: number,
+    message: string,
+    body: string,
+    code = "",
+    details: Record<string, string> = {},
+  ) {
     super(message);
     this.name = "ApiError";
     this.statusCode = statusCode;
     this.body = body;
+    this.code = code;
+    this.details = details;
   }
 }
 
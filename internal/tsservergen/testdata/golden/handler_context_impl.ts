@@ -0,0 +1,58 @@
+// Hand-written, not generated: a TeamServiceHandler implementation against the
+// handler_style=context golden, so TestGoldenTypecheck proves the typed
+// contexts accept what an implementation does with them.
+
+import type { ListTeamsResponse, PingTeamsResponse, Team } from "./handler_context.js";
+import { createTeamServiceRoutes, type TeamServiceHandler } from "./handler_context_server.js";
+
+const handler: TeamServiceHandler = {
+  async createTeam(req, ctx): Promise<Team> {
+    const apiKey: string = ctx.headers.xApiKey;
+    const requestId: string | undefined = ctx.headers.xRequestId;
+    if (ctx.query.dryRun) {
+      ctx.setStatus(202);
+    } else {
+      ctx.setStatus(201);
+    }
+    ctx.setHeader("Location", `/api/v1/orgs/${ctx.pathParams.orgId}/teams/${req.name}`);
+    ctx.setHeader("X-Request-ID", requestId ?? apiKey.slice(0, 8));
+    return { orgId: ctx.pathParams.orgId, teamId: req.name, name: req.name, visibility: "TEAM_VISIBILITY_PUBLIC" };
+  },
+
+  async listTeams(req, ctx): Promise<ListTeamsResponse> {
+    const region: string = ctx.headers.xRegion;
+    const pageSize: number = ctx.query.pageSize;
+    const tags: string[] = ctx.query.tags;
+    ctx.setHeader("X-Served-Region", region);
+    return {
+      teams: tags.slice(0, pageSize).map((tag) => ({
+        orgId: req.orgId,
+        teamId: tag,
+        name: tag,
+        visibility: ctx.query.visibility,
+      })),
+    };
+  },
+
+  async pingTeams(_req, ctx): Promise<PingTeamsResponse> {
+    ctx.setStatus(204);
+    return { ok: ctx.request.method === "POST" };
+  },
+
+  watchTeam(req, ctx): ReadableStream<Team> {
+    ctx.setHeader("X-Accel-Buffering", "no");
+    return new ReadableStream<Team>({
+      start(controller) {
+        controller.enqueue({
+          orgId: ctx.pathParams.orgId,
+          teamId: ctx.pathParams.teamId,
+          name: req.teamId,
+          visibility: "TEAM_VISIBILITY_PRIVATE",
+        });
+        controller.close();
+      },
+    });
+  },
+};
+
+export const teamServiceRoutes = createTeamServiceRoutes(handler);
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-ts-server. DO NOT EDIT.
 // source: http_verbs_comprehensive.proto
 
-import { FieldViolation, ValidationError } from "./errors.js";
+import { type FieldViolation, ValidationError } from "./errors.js";
 import type { CreateResourceRequest, DefaultPostRequest, DefaultPostResponse, DeleteResourceRequest, DeleteResourceResponse, GetNestedResourceRequest, GetResourceRequest, LegacyRequest, LegacyResponse, ListResourcesRequest, ListResourcesResponse, PatchResourceRequest, Resource, ResourceStatus, SearchResourcesRequest, UpdateResourceRequest } from "./http_verbs_comprehensive.js";
 
 export interface ServerContext {
@@ -36,6 +36,11 @@ interface HeaderConfig {
   type: string;
   required: boolean;
   format?: string;
+  multiple?: boolean;
+}
+
+function splitHeaderValues(value: string): string[] {
+  return value.split(",").map((v) => v.trim()).filter((v) => v !== "");
 }
 
 function validateHeaderValue(value: string, config: HeaderConfig): string | undefined {
@@ -80,7 +85,8 @@ function validateHeaders(
   const violations: FieldViolation[] = [];
   for (const config of configs) {
     const value = req.headers.get(config.name);
-    if (value == null) {
+    const values = value == null ? [] : config.multiple ? splitHeaderValues(value) : [value];
+    if (values.length === 0) {
       if (config.required) {
         violations.push({
           field: config.name,
@@ -89,7 +95,8 @@ function validateHeaders(
       }
       continue;
     }
-    const err = validateHeaderValue(value, config);
+    // A multiple header is valid when each of its values is
+    const err = values.map((v) => validateHeaderValue(v, config)).find((e) => e !== undefined);
     if (err) {
       violations.push({
         field: config.name,
@@ -123,7 +130,10 @@ export function createRESTfulAPIServiceRoutes(
       handler: async (req: Request): Promise<Response> => {
         try {
           const headerConfigs: HeaderConfig[] = [
-            { name: "X-API-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Api-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Client-Version", type: "string", required: false },
+            { name: "Accept-Language", type: "string", required: false },
+            { name: "X-Resource-Tag", type: "string", required: false, multiple: true },
           ];
           const headerViolations = validateHeaders(req, headerConfigs);
           if (headerViolations) {
@@ -181,11 +191,12 @@ export function createRESTfulAPIServiceRoutes(
     },
     {
       method: "GET",
-      path: "/api/v1/resources/{resource_id}",
+      path: "/api/v1/resources/:resource_id",
       handler: async (req: Request): Promise<Response> => {
         try {
           const headerConfigs: HeaderConfig[] = [
-            { name: "X-API-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Api-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Client-Version", type: "string", required: false },
           ];
           const headerViolations = validateHeaders(req, headerConfigs);
           if (headerViolations) {
@@ -232,11 +243,12 @@ export function createRESTfulAPIServiceRoutes(
     },
     {
       method: "GET",
-      path: "/api/v1/orgs/{org_id}/teams/{team_id}/resources/{resource_id}",
+      path: "/api/v1/orgs/:org_id/teams/:team_id/resources/:resource_id",
       handler: async (req: Request): Promise<Response> => {
         try {
           const headerConfigs: HeaderConfig[] = [
-            { name: "X-API-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Api-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Client-Version", type: "string", required: false },
           ];
           const headerViolations = validateHeaders(req, headerConfigs);
           if (headerViolations) {
@@ -291,8 +303,9 @@ export function createRESTfulAPIServiceRoutes(
       handler: async (req: Request): Promise<Response> => {
         try {
           const headerConfigs: HeaderConfig[] = [
-            { name: "X-API-Key", type: "string", required: true, format: "uuid" },
-            { name: "X-Request-ID", type: "string", required: true, format: "uuid" },
+            { name: "X-Api-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Client-Version", type: "string", required: false },
+            { name: "X-Request-Id", type: "string", required: true, format: "uuid" },
           ];
           const headerViolations = validateHeaders(req, headerConfigs);
           if (headerViolations) {
@@ -339,11 +352,12 @@ export function createRESTfulAPIServiceRoutes(
     },
     {
       method: "PUT",
-      path: "/api/v1/resources/{resource_id}",
+      path: "/api/v1/resources/:resource_id",
       handler: async (req: Request): Promise<Response> => {
         try {
           const headerConfigs: HeaderConfig[] = [
-            { name: "X-API-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Api-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Client-Version", type: "string", required: false },
           ];
           const headerViolations = validateHeaders(req, headerConfigs);
           if (headerViolations) {
@@ -356,6 +370,7 @@ export function createRESTfulAPIServiceRoutes(
           pathParams["resource_id"] = decodeURIComponent(pathSegments[4] ?? "");
 
           const body = await req.json() as UpdateResourceRequest;
+          body.resourceId = pathParams["resource_id"];
           if (options?.validateRequest) {
             const bodyViolations = options.validateRequest("updateResource", body);
             if (bodyViolations) {
@@ -363,8 +378,6 @@ export function createRESTfulAPIServiceRoutes(
             }
           }
 
-          body.resourceId = pathParams["resource_id"];
-
           const ctx: ServerContext = {
             request: req,
             pathParams,
@@ -396,11 +409,12 @@ export function createRESTfulAPIServiceRoutes(
     },
     {
       method: "PATCH",
-      path: "/api/v1/resources/{resource_id}",
+      path: "/api/v1/resources/:resource_id",
       handler: async (req: Request): Promise<Response> => {
         try {
           const headerConfigs: HeaderConfig[] = [
-            { name: "X-API-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Api-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Client-Version", type: "string", required: false },
           ];
           const headerViolations = validateHeaders(req, headerConfigs);
           if (headerViolations) {
@@ -413,6 +427,7 @@ export function createRESTfulAPIServiceRoutes(
           pathParams["resource_id"] = decodeURIComponent(pathSegments[4] ?? "");
 
           const body = await req.json() as PatchResourceRequest;
+          body.resourceId = pathParams["resource_id"];
           if (options?.validateRequest) {
             const bodyViolations = options.validateRequest("patchResource", body);
             if (bodyViolations) {
@@ -420,8 +435,6 @@ export function createRESTfulAPIServiceRoutes(
             }
           }
 
-          body.resourceId = pathParams["resource_id"];
-
           const ctx: ServerContext = {
             request: req,
             pathParams,
@@ -453,11 +466,12 @@ export function createRESTfulAPIServiceRoutes(
     },
     {
       method: "DELETE",
-      path: "/api/v1/resources/{resource_id}",
+      path: "/api/v1/resources/:resource_id",
       handler: async (req: Request): Promise<Response> => {
         try {
           const headerConfigs: HeaderConfig[] = [
-            { name: "X-API-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Api-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Client-Version", type: "string", required: false },
           ];
           const headerViolations = validateHeaders(req, headerConfigs);
           if (headerViolations) {
@@ -508,7 +522,8 @@ export function createRESTfulAPIServiceRoutes(
       handler: async (req: Request): Promise<Response> => {
         try {
           const headerConfigs: HeaderConfig[] = [
-            { name: "X-API-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Api-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Client-Version", type: "string", required: false },
           ];
           const headerViolations = validateHeaders(req, headerConfigs);
           if (headerViolations) {
@@ -559,7 +574,8 @@ export function createRESTfulAPIServiceRoutes(
       handler: async (req: Request): Promise<Response> => {
         try {
           const headerConfigs: HeaderConfig[] = [
-            { name: "X-API-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Api-Key", type: "string", required: true, format: "uuid" },
+            { name: "X-Client-Version", type: "string", required: false },
           ];
           const headerViolations = validateHeaders(req, headerConfigs);
           if (headerViolations) {
@@ -667,3 +683,27 @@ export function createBackwardCompatServiceRoutes(
   ];
 }
 
+function matchRoute(routes: RouteDescriptor[], method: string, pathname: string): RouteDescriptor | undefined {
+  const pathSegments = pathname.split("/");
+  return routes.find((route) => {
+    if (route.method !== method) return false;
+    const routeSegments = route.path.split("/");
+    return routeSegments.length === pathSegments.length &&
+      routeSegments.every((seg, i) => seg.startsWith(":") ? pathSegments[i] !== "" : seg === pathSegments[i]);
+  });
+}
+
+export function createFetchHandler(routes: RouteDescriptor[]): (req: Request) => Promise<Response> {
+  return async (req: Request): Promise<Response> => {
+    const url = new URL(req.url, "http://localhost");
+    const route = matchRoute(routes, req.method, url.pathname);
+    if (!route) {
+      return new Response(JSON.stringify({ message: "not found" }), {
+        status: 404,
+        headers: { "Content-Type": "application/json" },
+      });
+    }
+    return route.handler(req);
+  };
+}
+
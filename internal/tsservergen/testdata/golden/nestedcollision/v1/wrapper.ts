@@ -2,7 +2,9 @@
 // source: nestedcollision/v1/wrapper.proto
 
 export interface Wrapper {
+  /** Resolves to the nested Wrapper.Status (innermost scope wins). */
   nestedStatus?: WrapperStatus;
+  /** Resolves to the nested Wrapper.Kind. */
   nestedKind: WrapperKind;
 }
 
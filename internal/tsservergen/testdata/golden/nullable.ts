@@ -6,10 +6,15 @@ export interface GetUserRequest {
 }
 
 export interface User {
+  /** Required field (not nullable) */
   id: string;
+  /** Optional field with nullable=true - serializes as null when unset */
   middleName: string | null;
+  /** Optional field without nullable - omitted when unset */
   nickname?: string;
+  /** Nullable integer field */
   age: number | null;
+  /** Nullable boolean field */
   isVerified: boolean | null;
 }
 
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-ts-server. DO NOT EDIT.
 // source: nullable.proto
 
-import { FieldViolation, ValidationError } from "./errors.js";
+import { type FieldViolation, ValidationError } from "./errors.js";
 import type { GetUserRequest, UpdateUserRequest, User } from "./nullable.js";
 
 export interface ServerContext {
@@ -33,7 +33,7 @@ export function createNullableServiceRoutes(
   return [
     {
       method: "GET",
-      path: "/api/v1/users/{id}",
+      path: "/api/v1/users/:id",
       handler: async (req: Request): Promise<Response> => {
         try {
           const pathParams: Record<string, string> = {};
@@ -76,7 +76,7 @@ export function createNullableServiceRoutes(
     },
     {
       method: "PUT",
-      path: "/api/v1/users/{id}",
+      path: "/api/v1/users/:id",
       handler: async (req: Request): Promise<Response> => {
         try {
           const pathParams: Record<string, string> = {};
@@ -85,6 +85,7 @@ export function createNullableServiceRoutes(
           pathParams["id"] = decodeURIComponent(pathSegments[4] ?? "");
 
           const body = await req.json() as UpdateUserRequest;
+          body.id = pathParams["id"];
           if (options?.validateRequest) {
             const bodyViolations = options.validateRequest("updateUser", body);
             if (bodyViolations) {
@@ -92,8 +93,6 @@ export function createNullableServiceRoutes(
             }
           }
 
-          body.id = pathParams["id"];
-
           const ctx: ServerContext = {
             request: req,
             pathParams,
@@ -126,3 +125,27 @@ export function createNullableServiceRoutes(
   ];
 }
 
+function matchRoute(routes: RouteDescriptor[], method: string, pathname: string): RouteDescriptor | undefined {
+  const pathSegments = pathname.split("/");
+  return routes.find((route) => {
+    if (route.method !== method) return false;
+    const routeSegments = route.path.split("/");
+    return routeSegments.length === pathSegments.length &&
+      routeSegments.every((seg, i) => seg.startsWith(":") ? pathSegments[i] !== "" : seg === pathSegments[i]);
+  });
+}
+
+export function createFetchHandler(routes: RouteDescriptor[]): (req: Request) => Promise<Response> {
+  return async (req: Request): Promise<Response> => {
+    const url = new URL(req.url, "http://localhost");
+    const route = matchRoute(routes, req.method, url.pathname);
+    if (!route) {
+      return new Response(JSON.stringify({ message: "not found" }), {
+        status: 404,
+        headers: { "Content-Type": "application/json" },
+      });
+    }
+    return route.handler(req);
+  };
+}
+
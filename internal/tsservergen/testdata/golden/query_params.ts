@@ -2,6 +2,7 @@
 // source: query_params.proto
 
 export interface SearchWithTypesRequest {
+  /** Different scalar types as query params */
   query: string;
   limit: number;
   offset: string;
@@ -18,12 +19,15 @@ export interface SearchResponse {
 }
 
 export interface SearchRequiredRequest {
+  /** Required query param */
   query: string;
+  /** Optional query params */
   page: number;
   pageSize: number;
 }
 
 export interface SearchCustomNamesRequest {
+  /** Field name differs from query param name */
   searchTerm: string;
   resultsPerPage: number;
   pageNumber: number;
@@ -32,22 +36,32 @@ export interface SearchCustomNamesRequest {
 }
 
 export interface GetWithFiltersRequest {
+  /** Path parameter */
   resourceId: string;
+  /** Query parameters */
   filter: string;
   limit: number;
 }
 
 export interface SearchAdvancedRequest {
+  /** Enum query param (bugs #1 and #2) */
   region: Region;
+  /** Repeated string query param (issue #161) */
   countries: string[];
+  /** Normal string for baseline */
   keyword: string;
+  /** Repeated int32 query param (issue #161 scope audit) */
   years: number[];
+  /** Repeated bool query param (issue #161 scope audit) */
   flags: boolean[];
+  /** Repeated enum query param */
   regions: Region[];
 }
 
 export interface GetByRegionRequest {
+  /** Enum as path parameter */
   region: Region;
+  /** Query parameter alongside enum path param */
   keyword: string;
 }
 
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-ts-server. DO NOT EDIT.
 // source: query_params.proto
 
-import { FieldViolation, ValidationError } from "./errors.js";
+import { type FieldViolation, ValidationError } from "./errors.js";
 import type { EmptyRequest, GetByRegionRequest, GetWithFiltersRequest, Region, SearchAdvancedRequest, SearchCustomNamesRequest, SearchRequiredRequest, SearchResponse, SearchWithTypesRequest } from "./query_params.js";
 
 export interface ServerContext {
@@ -192,7 +192,7 @@ export function createQueryParamServiceRoutes(
     },
     {
       method: "GET",
-      path: "/api/resources/{resource_id}/items",
+      path: "/api/resources/:resource_id/items",
       handler: async (req: Request): Promise<Response> => {
         try {
           const pathParams: Record<string, string> = {};
@@ -296,7 +296,7 @@ export function createQueryParamServiceRoutes(
     },
     {
       method: "GET",
-      path: "/api/regions/{region}",
+      path: "/api/regions/:region",
       handler: async (req: Request): Promise<Response> => {
         try {
           const pathParams: Record<string, string> = {};
@@ -385,3 +385,27 @@ export function createQueryParamServiceRoutes(
   ];
 }
 
+function matchRoute(routes: RouteDescriptor[], method: string, pathname: string): RouteDescriptor | undefined {
+  const pathSegments = pathname.split("/");
+  return routes.find((route) => {
+    if (route.method !== method) return false;
+    const routeSegments = route.path.split("/");
+    return routeSegments.length === pathSegments.length &&
+      routeSegments.every((seg, i) => seg.startsWith(":") ? pathSegments[i] !== "" : seg === pathSegments[i]);
+  });
+}
+
+export function createFetchHandler(routes: RouteDescriptor[]): (req: Request) => Promise<Response> {
+  return async (req: Request): Promise<Response> => {
+    const url = new URL(req.url, "http://localhost");
+    const route = matchRoute(routes, req.method, url.pathname);
+    if (!route) {
+      return new Response(JSON.stringify({ message: "not found" }), {
+        status: 404,
+        headers: { "Content-Type": "application/json" },
+      });
+    }
+    return route.handler(req);
+  };
+}
+
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-ts-server. DO NOT EDIT.
 // source: sse.proto
 
-import { FieldViolation, ValidationError } from "./errors.js";
+import { type FieldViolation, ValidationError } from "./errors.js";
 import type { Event, GetStatusRequest, ResourceEvent, StatusResponse, StreamEventsRequest, StreamFilteredEventsRequest, StreamResourceEventsRequest } from "./sse.js";
 
 export interface ServerContext {
@@ -21,11 +21,109 @@ export interface RouteDescriptor {
   handler: (req: Request) => Promise<Response>;
 }
 
+/** How a streaming route writes the messages its handler sends. */
+interface StreamFraming {
+  /** Written before the first message. */
+  open: string;
+  /** Writes the message at index. */
+  item(msg: unknown, index: number): string;
+  /** Written once the handler returns. */
+  close: string;
+  /** Reports a failure after the first message, or undefined to cut the response short. */
+  error(err: unknown): string | undefined;
+}
+
+/** Server-Sent Events: one data frame per message and an error event on failure. */
+const sseFraming: StreamFraming = {
+  open: "",
+  item: (msg) => `data: ${JSON.stringify(msg)}\n\n`,
+  close: "",
+  error: (err) =>
+    `event: error\ndata: ${JSON.stringify(err instanceof Error ? err.message : String(err))}\n\n`,
+};
+
+/**
+ * Runs the handler of a streaming route with a send function writing each
+ * message to the body of the returned Response, which init describes. The
+ * Response is returned once the first message is sent or the handler returns,
+ * so an error thrown before reaches the caller. send waits for the client to
+ * take each message, and rejects once the client is gone or req is aborted.
+ */
+async function streamResponse<T>(
+  req: Request,
+  framing: StreamFraming,
+  run: (send: (msg: T) => Promise<void>) => Promise<void>,
+  init: () => ResponseInit,
+): Promise<Response> {
+  const encoder = new TextEncoder();
+  let controller!: ReadableStreamDefaultController<Uint8Array>;
+  let pulled: (() => void) | undefined;
+  let cancelled = false;
+  const body = new ReadableStream<Uint8Array>(
+    {
+      start(c) {
+        controller = c;
+      },
+      pull() {
+        pulled?.();
+      },
+      cancel() {
+        cancelled = true;
+        pulled?.();
+      },
+    },
+    { highWaterMark: 0 },
+  );
+  const gone = (): unknown => req.signal.reason ?? new Error("client disconnected");
+
+  let sent = 0;
+  let started!: () => void;
+  const start = new Promise<void>((resolve) => {
+    started = resolve;
+  });
+  const send = async (msg: T): Promise<void> => {
+    if (cancelled || req.signal.aborted) throw gone();
+    const text = (sent === 0 ? framing.open : "") + framing.item(msg, sent);
+    sent++;
+    started();
+    const taken = new Promise<void>((resolve) => {
+      pulled = resolve;
+    });
+    controller.enqueue(encoder.encode(text));
+    await taken;
+    pulled = undefined;
+    if (cancelled) throw gone();
+  };
+
+  const done = run(send);
+  await Promise.race([start, done]);
+  done
+    .then(
+      () => {
+        controller.enqueue(encoder.encode((sent === 0 ? framing.open : "") + framing.close));
+        controller.close();
+      },
+      (err: unknown) => {
+        const text = framing.error(err);
+        if (text === undefined) {
+          controller.error(err);
+          return;
+        }
+        controller.enqueue(encoder.encode(text));
+        controller.close();
+      },
+    )
+    .catch(() => {
+      // The client is gone, so nothing more can be written
+    });
+  return new Response(body, init());
+}
+
 export interface SSEServiceHandler {
   getStatus(ctx: ServerContext, req: GetStatusRequest): Promise<StatusResponse>;
-  streamEvents(ctx: ServerContext, req: StreamEventsRequest): ReadableStream<Event>;
-  streamResourceEvents(ctx: ServerContext, req: StreamResourceEventsRequest): ReadableStream<ResourceEvent>;
-  streamFilteredEvents(ctx: ServerContext, req: StreamFilteredEventsRequest): ReadableStream<Event>;
+  streamEvents(ctx: ServerContext, req: StreamEventsRequest, send: (msg: Event) => Promise<void>): Promise<void>;
+  streamResourceEvents(ctx: ServerContext, req: StreamResourceEventsRequest, send: (msg: ResourceEvent) => Promise<void>): Promise<void>;
+  streamFilteredEvents(ctx: ServerContext, req: StreamFilteredEventsRequest, send: (msg: Event) => Promise<void>): Promise<void>;
 }
 
 export function createSSEServiceRoutes(
@@ -84,35 +182,18 @@ export function createSSEServiceRoutes(
             headers: Object.fromEntries(req.headers.entries()),
           };
 
-          const stream = handler.streamEvents(ctx, body);
-
-          const sseStream = new ReadableStream({
-            async start(controller) {
-              const reader = stream.getReader();
-              const encoder = new TextEncoder();
-              try {
-                while (true) {
-                  const { done, value } = await reader.read();
-                  if (done) break;
-                  controller.enqueue(encoder.encode(`data: ${JSON.stringify(value)}\n\n`));
-                }
-                controller.close();
-              } catch (err) {
-                controller.enqueue(
-                  encoder.encode(`event: error\ndata: ${JSON.stringify({ message: String(err) })}\n\n`),
-                );
-                controller.close();
-              }
-            },
-          });
-
-          return new Response(sseStream, {
-            headers: {
-              "Content-Type": "text/event-stream",
-              "Cache-Control": "no-cache",
-              "Connection": "keep-alive",
-            },
-          });
+          return await streamResponse<Event>(
+            req,
+            sseFraming,
+            (send) => handler.streamEvents(ctx, body, send),
+            () => ({
+              headers: {
+                "Content-Type": "text/event-stream",
+                "Cache-Control": "no-cache",
+                "Connection": "keep-alive",
+              },
+            }),
+          );
         } catch (err: unknown) {
           if (err instanceof ValidationError) {
             return new Response(JSON.stringify({ violations: err.violations }), {
@@ -133,7 +214,7 @@ export function createSSEServiceRoutes(
     },
     {
       method: "GET",
-      path: "/api/v1/resources/{resource_id}/events",
+      path: "/api/v1/resources/:resource_id/events",
       handler: async (req: Request): Promise<Response> => {
         try {
           const pathParams: Record<string, string> = {};
@@ -151,35 +232,18 @@ export function createSSEServiceRoutes(
             headers: Object.fromEntries(req.headers.entries()),
           };
 
-          const stream = handler.streamResourceEvents(ctx, body);
-
-          const sseStream = new ReadableStream({
-            async start(controller) {
-              const reader = stream.getReader();
-              const encoder = new TextEncoder();
-              try {
-                while (true) {
-                  const { done, value } = await reader.read();
-                  if (done) break;
-                  controller.enqueue(encoder.encode(`data: ${JSON.stringify(value)}\n\n`));
-                }
-                controller.close();
-              } catch (err) {
-                controller.enqueue(
-                  encoder.encode(`event: error\ndata: ${JSON.stringify({ message: String(err) })}\n\n`),
-                );
-                controller.close();
-              }
-            },
-          });
-
-          return new Response(sseStream, {
-            headers: {
-              "Content-Type": "text/event-stream",
-              "Cache-Control": "no-cache",
-              "Connection": "keep-alive",
-            },
-          });
+          return await streamResponse<ResourceEvent>(
+            req,
+            sseFraming,
+            (send) => handler.streamResourceEvents(ctx, body, send),
+            () => ({
+              headers: {
+                "Content-Type": "text/event-stream",
+                "Cache-Control": "no-cache",
+                "Connection": "keep-alive",
+              },
+            }),
+          );
         } catch (err: unknown) {
           if (err instanceof ValidationError) {
             return new Response(JSON.stringify({ violations: err.violations }), {
@@ -223,35 +287,18 @@ export function createSSEServiceRoutes(
             headers: Object.fromEntries(req.headers.entries()),
           };
 
-          const stream = handler.streamFilteredEvents(ctx, body);
-
-          const sseStream = new ReadableStream({
-            async start(controller) {
-              const reader = stream.getReader();
-              const encoder = new TextEncoder();
-              try {
-                while (true) {
-                  const { done, value } = await reader.read();
-                  if (done) break;
-                  controller.enqueue(encoder.encode(`data: ${JSON.stringify(value)}\n\n`));
-                }
-                controller.close();
-              } catch (err) {
-                controller.enqueue(
-                  encoder.encode(`event: error\ndata: ${JSON.stringify({ message: String(err) })}\n\n`),
-                );
-                controller.close();
-              }
-            },
-          });
-
-          return new Response(sseStream, {
-            headers: {
-              "Content-Type": "text/event-stream",
-              "Cache-Control": "no-cache",
-              "Connection": "keep-alive",
-            },
-          });
+          return await streamResponse<Event>(
+            req,
+            sseFraming,
+            (send) => handler.streamFilteredEvents(ctx, body, send),
+            () => ({
+              headers: {
+                "Content-Type": "text/event-stream",
+                "Cache-Control": "no-cache",
+                "Connection": "keep-alive",
+              },
+            }),
+          );
         } catch (err: unknown) {
           if (err instanceof ValidationError) {
             return new Response(JSON.stringify({ violations: err.violations }), {
@@ -273,3 +320,27 @@ export function createSSEServiceRoutes(
   ];
 }
 
+function matchRoute(routes: RouteDescriptor[], method: string, pathname: string): RouteDescriptor | undefined {
+  const pathSegments = pathname.split("/");
+  return routes.find((route) => {
+    if (route.method !== method) return false;
+    const routeSegments = route.path.split("/");
+    return routeSegments.length === pathSegments.length &&
+      routeSegments.every((seg, i) => seg.startsWith(":") ? pathSegments[i] !== "" : seg === pathSegments[i]);
+  });
+}
+
+export function createFetchHandler(routes: RouteDescriptor[]): (req: Request) => Promise<Response> {
+  return async (req: Request): Promise<Response> => {
+    const url = new URL(req.url, "http://localhost");
+    const route = matchRoute(routes, req.method, url.pathname);
+    if (!route) {
+      return new Response(JSON.stringify({ message: "not found" }), {
+        status: 404,
+        headers: { "Content-Type": "application/json" },
+      });
+    }
+    return route.handler(req);
+  };
+}
+